@@ -0,0 +1,140 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+// CheckAccessPolicies checks the run's active sharing links and everyone's explicit access against the
+// policies (audit-scoped), failing with ErrRowCapExceeded when the run has too many to analyze. Findings
+// are not persisted and carry no site or run.
+func (s *SiteContentService) CheckAccessPolicies(ctx context.Context, siteID int64, policies []audit.AccessPolicy) ([]*audit.PolicyFinding, error) {
+	links, err := s.contentAggregate.GetSharingLinkFacts(ctx, siteID, s.auditRunID, s.maxRows)
+	if err != nil {
+		return nil, err
+	}
+	access, err := s.GetAccessReviewEntries(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := s.GetItemLabels(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item labels: %w", err)
+	}
+	return DetectPolicyFindings(policies, links, access, labels), nil
+}
+
+// DetectPolicyFindings reports each sharing link and grant violating a policy, in policy order. labels
+// holds the sensitivity label of each labeled item by item GUID. A principal reaching the same role on an
+// object more than one way violates a policy once.
+func DetectPolicyFindings(policies []audit.AccessPolicy, links []*contracts.SharingLinkFact, access []*AccessReviewEntry, labels map[string]string) []*audit.PolicyFinding {
+	findings := []*audit.PolicyFinding{}
+	for _, policy := range policies {
+		switch policy.Target {
+		case audit.PolicyTargetLink:
+			for _, link := range links {
+				subject := audit.PolicySubject{
+					Target:     audit.PolicyTargetLink,
+					Audience:   linkAudience(link.LinkKind, link.Scope),
+					Edit:       link.IsEditLink || link.LinkKind == sharepoint.LinkKindOrganizationEdit || link.LinkKind == sharepoint.LinkKindAnonymousEdit,
+					ObjectType: sharepoint.ObjectTypeItem,
+					Label:      labels[link.ItemGUID],
+				}
+				if !policy.Violated(subject) {
+					continue
+				}
+				findings = append(findings, &audit.PolicyFinding{
+					Policy:      policy.Name,
+					Severity:    policy.Severity,
+					ObjectType:  sharepoint.ObjectTypeItem,
+					ObjectKey:   link.ItemGUID,
+					ObjectURL:   link.ItemURL,
+					ObjectName:  link.ItemName,
+					Subject:     link.LinkID,
+					Detail:      describeLinkFinding(link, subject),
+					Remediation: policy.Remediation,
+				})
+			}
+		case audit.PolicyTargetAccess:
+			seen := map[string]bool{}
+			for _, entry := range access {
+				subject := audit.PolicySubject{
+					Target:     audit.PolicyTargetAccess,
+					External:   entry.Principal.IsGuest(),
+					Role:       entry.Role,
+					ObjectType: entry.ObjectType,
+				}
+				if entry.ObjectType == sharepoint.ObjectTypeItem {
+					subject.Label = labels[entry.ObjectKey]
+				}
+				key := fmt.Sprintf("%s/%s/%d/%s", entry.ObjectType, entry.ObjectKey, entry.Principal.ID, entry.Role)
+				if seen[key] || !policy.Violated(subject) {
+					continue
+				}
+				seen[key] = true
+				findings = append(findings, &audit.PolicyFinding{
+					Policy:      policy.Name,
+					Severity:    policy.Severity,
+					ObjectType:  entry.ObjectType,
+					ObjectKey:   entry.ObjectKey,
+					ObjectURL:   entry.ObjectURL,
+					ObjectName:  entry.ObjectName,
+					Subject:     entry.Principal.LoginName,
+					Detail:      describeAccessFinding(entry, subject),
+					Remediation: policy.Remediation,
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// linkAudience returns who a sharing link of the kind and scope works for. Only flexible links carry
+// their audience in the scope.
+func linkAudience(linkKind, scope int) string {
+	switch {
+	case sharepoint.IsAnyoneLinkKind(linkKind, scope):
+		return audit.LinkAudienceAnyone
+	case linkKind == sharepoint.LinkKindOrganizationView, linkKind == sharepoint.LinkKindOrganizationEdit,
+		linkKind == sharepoint.LinkKindFlexible && scope == sharepoint.ScopeOrganization:
+		return audit.LinkAudienceOrganization
+	default:
+		return audit.LinkAudienceSpecific
+	}
+}
+
+// describeLinkFinding summarizes a sharing link violating a policy.
+func describeLinkFinding(link *contracts.SharingLinkFact, subject audit.PolicySubject) string {
+	permission := "view"
+	if subject.Edit {
+		permission = "edit"
+	}
+	detail := fmt.Sprintf("%s link for %s grants %s", sharepoint.LinkKindName(link.LinkKind), subject.Audience, permission)
+	if subject.Label != "" {
+		detail += fmt.Sprintf(" on an item labeled %s", subject.Label)
+	}
+	return detail
+}
+
+// describeAccessFinding summarizes a principal's access violating a policy.
+func describeAccessFinding(entry *AccessReviewEntry, subject audit.PolicySubject) string {
+	who := entry.Principal.GetDisplayName()
+	if subject.External {
+		who += " (external)"
+	}
+	detail := fmt.Sprintf("%s holds %s", who, entry.Role)
+	switch entry.GrantType {
+	case AccessGrantGroup:
+		detail += " through group " + entry.GrantedVia
+	case AccessGrantSharingLink:
+		detail += " through sharing link " + entry.GrantedVia
+	}
+	if subject.Label != "" {
+		detail += fmt.Sprintf(" on an item labeled %s", subject.Label)
+	}
+	return detail
+}
@@ -0,0 +1,136 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"spaudit/domain/sharepoint"
+)
+
+// Ways an access review entry's principal was granted access.
+const (
+	AccessGrantDirect      = "direct"
+	AccessGrantGroup       = "group"
+	AccessGrantSharingLink = "sharing_link"
+)
+
+// AccessReviewEntry is one principal's access to one object, the unit a reviewer certifies or revokes
+// in an access certification campaign. Access through SharePoint groups and sharing links is expanded to
+// the members, since reviewers decide on the people behind them.
+type AccessReviewEntry struct {
+	Principal  *sharepoint.Principal
+	ObjectType string
+	ObjectKey  string
+	ObjectURL  string
+	ObjectName string
+	Role       string
+	GrantType  string // AccessGrantDirect, AccessGrantGroup or AccessGrantSharingLink
+	GrantedVia string // The group's title or the sharing link's ID; empty for direct grants
+}
+
+// GetAccessReviewEntries lists every principal with explicit access to the site's web, lists and items
+// in the audit run (audit-scoped), ordered by principal then object URL. Limited Access is left out.
+// It fails with contracts.ErrRowCapExceeded when the run has more permissions than can be analyzed.
+func (s *SiteContentService) GetAccessReviewEntries(ctx context.Context, siteID int64) ([]*AccessReviewEntry, error) {
+	facts, err := s.GetRunFacts(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	groupMembers, err := s.sharePointGroupMembers(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	sharingService := sharepoint.NewSharingService()
+	linkMembers := map[string][]*sharepoint.Principal{} // By sharing ID
+	entries := []*AccessReviewEntry{}
+	for _, fact := range facts.Permissions {
+		if fact.Principal == nil || sharepoint.IsLimitedAccessRole(fact.RoleDefID, fact.Role) {
+			continue
+		}
+		entry := AccessReviewEntry{
+			ObjectType: fact.ObjectType,
+			ObjectKey:  fact.ObjectKey,
+			ObjectURL:  fact.ObjectURL,
+			ObjectName: fact.ObjectName,
+			Role:       fact.Role,
+			GrantType:  AccessGrantDirect,
+		}
+
+		members := []*sharepoint.Principal{fact.Principal}
+		switch {
+		case fact.Principal.IsSharingLinkPrincipal():
+			info := sharingService.ParseSharingLink(fact.Principal.LoginName)
+			if !info.IsValid {
+				break
+			}
+			if members, err = s.sharingLinkMembers(ctx, siteID, info.SharingID, linkMembers); err != nil {
+				return nil, err
+			}
+			entry.GrantType, entry.GrantedVia = AccessGrantSharingLink, info.SharingID
+		case fact.Principal.IsSharePointGroup():
+			members = groupMembers[fact.Principal.ID]
+			entry.GrantType, entry.GrantedVia = AccessGrantGroup, fact.Principal.GetDisplayName()
+		}
+
+		for _, member := range members {
+			memberEntry := entry
+			memberEntry.Principal = member
+			entries = append(entries, &memberEntry)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		nameA, nameB := strings.ToLower(a.Principal.GetDisplayName()), strings.ToLower(b.Principal.GetDisplayName())
+		if nameA != nameB {
+			return nameA < nameB
+		}
+		return a.ObjectURL < b.ObjectURL
+	})
+	return entries, nil
+}
+
+// sharePointGroupMembers returns the members of each SharePoint group in the audit run by group ID.
+func (s *SiteContentService) sharePointGroupMembers(ctx context.Context, siteID int64) (map[int64][]*sharepoint.Principal, error) {
+	principals, err := s.GetAuditRunPrincipals(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	members := map[int64][]*sharepoint.Principal{}
+	for _, principal := range principals {
+		if principal.IsSharePointGroup() {
+			continue
+		}
+		groupIDs, err := s.GetGroupIDsForPrincipal(ctx, siteID, principal.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, groupID := range groupIDs {
+			members[groupID] = append(members[groupID], principal)
+		}
+	}
+	return members, nil
+}
+
+// sharingLinkMembers returns the members of a sharing link in the audit run, caching them by sharing ID.
+// A link the run did not capture has no members.
+func (s *SiteContentService) sharingLinkMembers(ctx context.Context, siteID int64, sharingID string, cache map[string][]*sharepoint.Principal) ([]*sharepoint.Principal, error) {
+	if members, ok := cache[sharingID]; ok {
+		return members, nil
+	}
+	link, err := s.contentAggregate.GetSharingLinkForAuditRun(ctx, siteID, s.auditRunID, sharingID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sharing link %s: %w", sharingID, err)
+	}
+	var members []*sharepoint.Principal
+	if link != nil {
+		if members, err = s.contentAggregate.GetSharingLinkMembersForAuditRun(ctx, siteID, s.auditRunID, link.ID); err != nil {
+			return nil, fmt.Errorf("failed to get members of sharing link %s: %w", link.ID, err)
+		}
+	}
+	cache[sharingID] = members
+	return members, nil
+}
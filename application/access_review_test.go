@@ -0,0 +1,54 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	"spaudit/test/helpers"
+)
+
+func TestSiteContentService_GetAccessReviewEntries(t *testing.T) {
+	ctx := context.Background()
+	mocks := helpers.NewMockRepositories()
+
+	alice := &sharepoint.Principal{ID: 10, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Alice"}
+	bob := &sharepoint.Principal{ID: 11, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Bob"}
+	finance := &sharepoint.Principal{ID: 7, PrincipalType: sharepoint.PrincipalTypeSharePointGroup, Title: "Finance"}
+	link := &sharepoint.Principal{ID: 8, PrincipalType: sharepoint.PrincipalTypeSharePointGroup,
+		LoginName: "SharingLinks.0b6e1c4a-58c5-4a8e-9a0e-3f2d1b7c9e11.OrganizationView.5d7e9f1a-2b3c-4d5e-8f90-a1b2c3d4e5f6"}
+
+	mocks.SiteContentAggregate.On("GetRunFacts", ctx, int64(1), int64(7), sharepoint.DefaultMaxAnalysisRows).Return(&contracts.RunFacts{
+		Permissions: []*contracts.PermissionFact{
+			{ObjectType: sharepoint.ObjectTypeWeb, ObjectURL: "/sites/a", Principal: finance, RoleDefID: 1073741827, Role: "Contribute"},
+			{ObjectType: sharepoint.ObjectTypeList, ObjectURL: "/sites/a/docs", Principal: bob, RoleDefID: 1073741829, Role: "Full Control"},
+			{ObjectType: sharepoint.ObjectTypeList, ObjectURL: "/sites/a/docs", Principal: alice, RoleDefID: 1073741825, Role: "Limited Access"},
+			{ObjectType: sharepoint.ObjectTypeItem, ObjectURL: "/sites/a/docs/plan.docx", Principal: link, RoleDefID: 1073741826, Role: "Read"},
+		},
+	}, nil)
+	mocks.SiteContentAggregate.On("GetPrincipalsForAuditRun", ctx, int64(1), int64(7)).Return([]*sharepoint.Principal{alice, bob, finance}, nil)
+	mocks.SiteContentAggregate.On("GetGroupIDsForMember", ctx, int64(1), int64(7), int64(10)).Return([]int64{7}, nil)
+	mocks.SiteContentAggregate.On("GetGroupIDsForMember", ctx, int64(1), int64(7), int64(11)).Return([]int64{}, nil)
+	mocks.SiteContentAggregate.On("GetSharingLinkForAuditRun", ctx, int64(1), int64(7), "5d7e9f1a-2b3c-4d5e-8f90-a1b2c3d4e5f6").Return(&sharepoint.SharingLinkWithItemData{SharingLink: &sharepoint.SharingLink{ID: "5D7E9F1A-2B3C-4D5E-8F90-A1B2C3D4E5F6"}}, nil)
+	mocks.SiteContentAggregate.On("GetSharingLinkMembersForAuditRun", ctx, int64(1), int64(7), "5D7E9F1A-2B3C-4D5E-8F90-A1B2C3D4E5F6").Return([]*sharepoint.Principal{bob}, nil)
+
+	service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+	entries, err := service.GetAccessReviewEntries(ctx, 1)
+	require.NoError(t, err)
+
+	type row struct{ principal, url, role, grant, via string }
+	var rows []row
+	for _, entry := range entries {
+		rows = append(rows, row{entry.Principal.Title, entry.ObjectURL, entry.Role, entry.GrantType, entry.GrantedVia})
+	}
+	// Groups and sharing links are expanded to their members; Limited Access is left out
+	assert.Equal(t, []row{
+		{"Alice", "/sites/a", "Contribute", AccessGrantGroup, "Finance"},
+		{"Bob", "/sites/a/docs", "Full Control", AccessGrantDirect, ""},
+		{"Bob", "/sites/a/docs/plan.docx", "Read", AccessGrantSharingLink, "5d7e9f1a-2b3c-4d5e-8f90-a1b2c3d4e5f6"},
+	}, rows)
+}
@@ -0,0 +1,176 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+// GetAuditRunPrincipals returns the principals captured by the audit run, excluding sharing link groups,
+// ordered by display name (audit-scoped).
+func (s *SiteContentService) GetAuditRunPrincipals(ctx context.Context, siteID int64) ([]*sharepoint.Principal, error) {
+	principals, err := s.contentAggregate.GetPrincipalsForAuditRun(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := principals[:0]
+	for _, principal := range principals {
+		if !principal.IsSharingLinkPrincipal() {
+			result = append(result, principal)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return strings.ToLower(result[i].GetDisplayName()) < strings.ToLower(result[j].GetDisplayName())
+	})
+	return result, nil
+}
+
+// GetGroupIDsForPrincipal returns the SharePoint groups a principal belongs to in the audit run (audit-scoped).
+func (s *SiteContentService) GetGroupIDsForPrincipal(ctx context.Context, siteID, principalID int64) ([]int64, error) {
+	return s.contentAggregate.GetGroupIDsForMember(ctx, siteID, s.auditRunID, principalID)
+}
+
+// GetEntraGroupIDsForUser returns the Entra groups whose users the audit run resolved include the user
+// with the given UPN or mail (audit-scoped).
+func (s *SiteContentService) GetEntraGroupIDsForUser(ctx context.Context, siteID int64, identity string) ([]int64, error) {
+	return s.contentAggregate.GetEntraGroupIDsForUser(ctx, siteID, s.auditRunID, identity)
+}
+
+// GetSharingLinkIDsForMember returns the sharing links a principal is a member of in the audit run (audit-scoped).
+func (s *SiteContentService) GetSharingLinkIDsForMember(ctx context.Context, siteID, principalID int64) ([]string, error) {
+	return s.contentAggregate.GetSharingLinkIDsForMember(ctx, siteID, s.auditRunID, principalID)
+}
+
+// GetSharingLinksCreatedBy returns the active sharing links a principal created in the audit run (audit-scoped).
+func (s *SiteContentService) GetSharingLinksCreatedBy(ctx context.Context, siteID, principalID int64) ([]*contracts.SharingLinkFact, error) {
+	return s.contentAggregate.GetSharingLinkFactsCreatedBy(ctx, siteID, s.auditRunID, principalID)
+}
+
+// GetItemLabels returns the display name of the sensitivity label of each labeled item by item GUID (audit-scoped).
+func (s *SiteContentService) GetItemLabels(ctx context.Context, siteID int64) (map[string]string, error) {
+	return s.contentAggregate.GetItemLabels(ctx, siteID, s.auditRunID)
+}
+
+// GetItemsOwnedBy returns the items whose sensitivity label names the owner with the given email (audit-scoped).
+func (s *SiteContentService) GetItemsOwnedBy(ctx context.Context, siteID int64, email string) ([]*contracts.OwnedItem, error) {
+	return s.contentAggregate.GetItemsOwnedBy(ctx, siteID, s.auditRunID, email)
+}
+
+// getAccessibleListItemsPage serves a page of the list items a principal can access.
+// Access is evaluated per item in memory, so every item matching the rest of the filter is read.
+func (s *SiteContentService) getAccessibleListItemsPage(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter, page, pageSize int) (*ListItemsPageData, error) {
+	items, err := s.listItemsAccessibleBy(ctx, siteID, listID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	total := int64(len(items))
+	page = clampPage(page, pageSize, total)
+	start := min((page-1)*pageSize, len(items))
+	end := min(start+pageSize, len(items))
+
+	return &ListItemsPageData{
+		Items:      items[start:end],
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: total,
+	}, nil
+}
+
+// listItemsAccessibleBy returns the list items matching the filter that filter.AccessibleBy can access, by item ID.
+// A principal can access an item when it, or a SharePoint group it belongs to, holds a role other than
+// Limited Access on the item's permission source (see itemPermissionSource), or is a member of a
+// sharing link on the item or a folder above it. Azure AD group membership is not stored, so access
+// through a security group only counts for the group itself.
+func (s *SiteContentService) listItemsAccessibleBy(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter) ([]*sharepoint.Item, error) {
+	list, err := s.contentAggregate.GetListByID(ctx, siteID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	identities := map[int64]bool{filter.AccessibleBy: true}
+	groupIDs, err := s.contentAggregate.GetGroupIDsForMember(ctx, siteID, s.auditRunID, filter.AccessibleBy)
+	if err != nil {
+		return nil, err
+	}
+	for _, groupID := range groupIDs {
+		identities[groupID] = true
+	}
+
+	itemFilter := filter
+	itemFilter.AccessibleBy = 0
+	items, err := s.allFilteredListItems(ctx, siteID, listID, itemFilter)
+	if err != nil {
+		return nil, err
+	}
+	folders, err := s.allFilteredListItems(ctx, siteID, listID, contracts.ItemFilter{UniqueOnly: true, Kind: contracts.ItemKindFolder})
+	if err != nil {
+		return nil, err
+	}
+
+	// Items and folders with a sharing link the principal is a member of
+	linked := make(map[string]bool)
+	links, err := s.contentAggregate.GetListSharingLinks(ctx, siteID, listID)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		members, err := s.contentAggregate.GetSharingLinkMembers(ctx, siteID, link.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range members {
+			if identities[member.ID] {
+				linked[link.ItemGUID] = true
+				linked[link.FileFolderUniqueID] = true
+				break
+			}
+		}
+	}
+	delete(linked, "")
+
+	// Items share a handful of permission sources, so each source's assignments are read once
+	granted := make(map[string]bool)
+	grants := func(source *PermissionSource) (bool, error) {
+		key := source.ObjectType + ":" + source.ObjectKey
+		if result, seen := granted[key]; seen {
+			return result, nil
+		}
+		assignments, err := s.contentAggregate.GetAssignmentsForObject(ctx, siteID, s.auditRunID, source.ObjectType, source.ObjectKey)
+		if err != nil {
+			return false, err
+		}
+		granted[key] = false
+		for _, assignment := range assignments {
+			if identities[assignment.RoleAssignment.PrincipalID] && (assignment.RoleDefinition == nil || !assignment.RoleDefinition.IsLimitedAccess()) {
+				granted[key] = true
+				break
+			}
+		}
+		return granted[key], nil
+	}
+
+	var accessible []*sharepoint.Item
+	for _, item := range items {
+		ancestors := ancestorFolders(item, folders)
+		hasAccess := linked[item.GUID]
+		for _, folder := range ancestors {
+			hasAccess = hasAccess || linked[folder.GUID]
+		}
+		if !hasAccess {
+			if hasAccess, err = grants(itemPermissionSource(item, ancestors, list)); err != nil {
+				return nil, err
+			}
+		}
+		if hasAccess {
+			accessible = append(accessible, item)
+		}
+	}
+
+	sort.SliceStable(accessible, func(i, j int) bool { return accessible[i].ID < accessible[j].ID })
+	return accessible, nil
+}
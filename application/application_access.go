@@ -0,0 +1,106 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+// ApplicationAccessData is what an audit run found apps can access on the site, apart from user access.
+type ApplicationAccessData struct {
+	CollectedAt    *time.Time                  // Nil when the run did not collect installed apps
+	Apps           []*ApplicationAccess        // Installed apps by title, then app principals granted roles without an installed app
+	GraphScannedAt *time.Time                  // Nil when the run did not read Graph app grants
+	GraphGrants    []*sharepoint.GraphAppGrant // Site grants first, then tenant-wide roles; none shows in role assignments
+}
+
+// ApplicationAccess is an installed app, an app principal granted roles on the site, or both.
+type ApplicationAccess struct {
+	App       *sharepoint.InstalledApp    // Nil for app principals granted roles without being installed, such as Entra apps
+	Principal *sharepoint.Principal       // Nil when the app's principal holds no role on the site
+	Grants    []*contracts.PermissionFact // Roles granted to the app's principal on the web, lists and items
+}
+
+// HasSiteWideGrant returns true if the app holds a role on the web, which applies to everything
+// inheriting from it.
+func (a *ApplicationAccess) HasSiteWideGrant() bool {
+	for _, grant := range a.Grants {
+		if grant.ObjectType == "web" {
+			return true
+		}
+	}
+	return false
+}
+
+// GetApplicationAccess reports the apps installed on the site, the roles app principals hold, and the
+// apps Microsoft Graph grants access to the site (audit-scoped). Roles are reported even for runs
+// that did not collect installed apps, as they are captured with the rest of the site's permissions.
+func (s *SiteContentService) GetApplicationAccess(ctx context.Context, siteID int64) (*ApplicationAccessData, error) {
+	data := &ApplicationAccessData{}
+
+	collectedAt, err := s.contentAggregate.GetAppInventory(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, err
+	}
+	data.CollectedAt = collectedAt
+
+	var apps []*sharepoint.InstalledApp
+	if collectedAt != nil {
+		if apps, err = s.contentAggregate.GetInstalledApps(ctx, siteID, s.auditRunID); err != nil {
+			return nil, err
+		}
+	}
+	grants, err := s.contentAggregate.GetAppPermissionFacts(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, err
+	}
+	data.Apps = MatchApplicationAccess(apps, grants)
+
+	graphScannedAt, err := s.contentAggregate.GetGraphGrantScan(ctx, siteID, s.auditRunID)
+	if err != nil || graphScannedAt == nil {
+		return data, err
+	}
+	data.GraphScannedAt = graphScannedAt
+	if data.GraphGrants, err = s.contentAggregate.GetGraphAppGrants(ctx, siteID, s.auditRunID); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// MatchApplicationAccess pairs installed apps with the roles held by their principals, matched by
+// client ID. Apps come in the order given, followed by the principals holding roles that no
+// installed app names, in the order of their first grant.
+func MatchApplicationAccess(apps []*sharepoint.InstalledApp, grants []*contracts.PermissionFact) []*ApplicationAccess {
+	var principals []*ApplicationAccess
+	byClientID := make(map[string]*ApplicationAccess)
+	for _, grant := range grants {
+		clientID := sharepoint.AppClientID(grant.Principal.LoginName)
+		access, ok := byClientID[clientID]
+		if !ok {
+			access = &ApplicationAccess{Principal: grant.Principal}
+			byClientID[clientID] = access
+			principals = append(principals, access)
+		}
+		access.Grants = append(access.Grants, grant)
+	}
+
+	result := make([]*ApplicationAccess, 0, len(apps)+len(principals))
+	for _, app := range apps {
+		access := &ApplicationAccess{App: app}
+		if app.AppPrincipalID != "" {
+			if granted, ok := byClientID[sharepoint.AppClientID(app.AppPrincipalID)]; ok && granted.App == nil {
+				granted.App = app
+				access = granted
+			}
+		}
+		result = append(result, access)
+	}
+	for _, access := range principals {
+		if access.App == nil {
+			result = append(result, access)
+		}
+	}
+	return result
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	"spaudit/test/mocks"
+)
+
+func TestMatchApplicationAccess(t *testing.T) {
+	workflow := &sharepoint.InstalledApp{AppID: "app-1", Title: "Approvals", AppSource: sharepoint.AppSourceCorporateCatalog,
+		AppPrincipalID: "i:0i.t|ms.sp.ext|5A3C2B1D-0000-4000-8000-000000000001@realm"}
+	webPart := &sharepoint.InstalledApp{AppID: "app-2", Title: "Weather", AppSource: sharepoint.AppSourceMarketplace}
+
+	addIn := &sharepoint.Principal{ID: 11, Title: "Approvals", LoginName: "i:0i.t|ms.sp.ext|5a3c2b1d-0000-4000-8000-000000000001@realm"}
+	entraApp := &sharepoint.Principal{ID: 12, Title: "Backup", LoginName: "i:0i.t|00000003-0000-0ff1-ce00-000000000000|backup-client@realm"}
+	grants := []*contracts.PermissionFact{
+		{ObjectType: "list", ObjectKey: "docs", ObjectName: "Documents", Principal: addIn, RoleDefID: 1073741827, Role: "Contribute"},
+		{ObjectType: "web", ObjectKey: "web-1", ObjectName: "Finance", Principal: entraApp, RoleDefID: 1073741829, Role: "Full Control"},
+	}
+
+	access := MatchApplicationAccess([]*sharepoint.InstalledApp{workflow, webPart}, grants)
+	require.Len(t, access, 3)
+
+	assert.Same(t, workflow, access[0].App)
+	assert.Same(t, addIn, access[0].Principal, "matched by client ID, whatever its case")
+	require.Len(t, access[0].Grants, 1)
+	assert.False(t, access[0].HasSiteWideGrant())
+
+	assert.Same(t, webPart, access[1].App)
+	assert.Nil(t, access[1].Principal)
+	assert.Empty(t, access[1].Grants)
+
+	assert.Nil(t, access[2].App, "granted roles without being installed")
+	assert.Same(t, entraApp, access[2].Principal)
+	assert.True(t, access[2].HasSiteWideGrant())
+}
+
+func TestSiteContentService_GetApplicationAccess_NotCollected(t *testing.T) {
+	aggregate := &mocks.MockSiteContentAggregateRepository{}
+	aggregate.On("GetAppInventory", mock.Anything, int64(1), int64(4)).Return(nil, nil)
+	aggregate.On("GetGraphGrantScan", mock.Anything, int64(1), int64(4)).Return(nil, nil)
+	aggregate.On("GetAppPermissionFacts", mock.Anything, int64(1), int64(4)).Return([]*contracts.PermissionFact{
+		{ObjectType: "web", ObjectKey: "web-1", Principal: &sharepoint.Principal{ID: 12, LoginName: "i:0i.t|ms.sp.ext|backup@realm"}, Role: "Read"},
+	}, nil)
+	service := NewAuditScopedSiteContentService(aggregate, 4)
+
+	data, err := service.GetApplicationAccess(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Nil(t, data.CollectedAt)
+	require.Len(t, data.Apps, 1, "roles are captured whether or not installed apps were")
+	aggregate.AssertNotCalled(t, "GetInstalledApps", mock.Anything, mock.Anything, mock.Anything)
+	assert.Nil(t, data.GraphScannedAt)
+	aggregate.AssertNotCalled(t, "GetGraphAppGrants", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSiteContentService_GetApplicationAccess_GraphGrants(t *testing.T) {
+	scannedAt := time.Date(2026, 5, 4, 8, 0, 0, 0, time.UTC)
+	aggregate := &mocks.MockSiteContentAggregateRepository{}
+	aggregate.On("GetAppInventory", mock.Anything, int64(1), int64(4)).Return(nil, nil)
+	aggregate.On("GetAppPermissionFacts", mock.Anything, int64(1), int64(4)).Return([]*contracts.PermissionFact{}, nil)
+	aggregate.On("GetGraphGrantScan", mock.Anything, int64(1), int64(4)).Return(&scannedAt, nil)
+	aggregate.On("GetGraphAppGrants", mock.Anything, int64(1), int64(4)).Return([]*sharepoint.GraphAppGrant{
+		{GrantID: "perm-1", Kind: sharepoint.GraphGrantSiteSelected, AppID: "sync-client", AppName: "Sync", Roles: []string{"write"}},
+		{GrantID: "assign-1", Kind: sharepoint.GraphGrantTenantWide, AppID: "backup-client", AppName: "Backup", Roles: []string{"Sites.FullControl.All"}, Resource: "Microsoft Graph"},
+	}, nil)
+	service := NewAuditScopedSiteContentService(aggregate, 4)
+
+	data, err := service.GetApplicationAccess(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, &scannedAt, data.GraphScannedAt)
+	require.Len(t, data.GraphGrants, 2)
+	assert.True(t, data.GraphGrants[0].CanWrite())
+	assert.False(t, data.GraphGrants[0].HasFullControl())
+	assert.True(t, data.GraphGrants[1].HasFullControl())
+}
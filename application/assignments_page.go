@@ -0,0 +1,114 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"spaudit/domain/sharepoint"
+)
+
+// ErrInvalidCursor indicates a page cursor that is malformed or was issued for another audit run.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// AssignmentsPage is one chunk of an object's resolved assignments, in display order.
+type AssignmentsPage struct {
+	Assignments []*sharepoint.ResolvedAssignment
+	Offset      int    // Position of the first assignment among all of the object's assignments
+	NextCursor  string // Continues after this chunk; empty on the last one
+
+	// Every assignment of the object without root cause analysis, for totals and help cards.
+	// Set on the first chunk only.
+	All []*sharepoint.Assignment
+}
+
+// GetAssignmentsPage retrieves up to limit resolved assignments of an object (audit-scoped), starting at
+// the cursor of a previous page or at the first assignment when the cursor is empty. Root causes are
+// analyzed, sharing links unwrapped and SharePoint groups expanded for the page only, so long assignment
+// lists load in chunks.
+func (s *SiteContentService) GetAssignmentsPage(ctx context.Context, siteID int64, objectType, objectKey, cursor string, limit int) (*AssignmentsPage, error) {
+	offset := 0
+	page := &AssignmentsPage{}
+	if cursor != "" {
+		var err error
+		if offset, err = s.decodeAssignmentsCursor(cursor); err != nil {
+			return nil, err
+		}
+	} else {
+		all, err := s.contentAggregate.GetAssignmentsForObject(ctx, siteID, s.auditRunID, objectType, objectKey)
+		if err != nil {
+			return nil, err
+		}
+		page.All = all
+	}
+
+	// One more than asked for tells whether another page follows
+	assignments, err := s.contentAggregate.GetResolvedAssignmentsPageForObject(ctx, siteID, s.auditRunID, objectType, objectKey, offset, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(assignments) > limit {
+		assignments = assignments[:limit]
+		page.NextCursor = s.encodeAssignmentsCursor(offset + limit)
+	}
+	if err := s.unwrapSharingLinks(ctx, siteID, assignments); err != nil {
+		return nil, err
+	}
+	if err := s.expandSiteGroups(ctx, siteID, assignments); err != nil {
+		return nil, err
+	}
+	page.Assignments = assignments
+	page.Offset = offset
+	return page, nil
+}
+
+// GetAssignmentAt retrieves the resolved assignment at a position among an object's assignments (audit-scoped).
+// It returns nil when there is no assignment at that position.
+func (s *SiteContentService) GetAssignmentAt(ctx context.Context, siteID int64, objectType, objectKey string, index int) (*sharepoint.ResolvedAssignment, error) {
+	if index < 0 {
+		return nil, nil
+	}
+	assignments, err := s.contentAggregate.GetResolvedAssignmentsPageForObject(ctx, siteID, s.auditRunID, objectType, objectKey, index, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(assignments) == 0 {
+		return nil, nil
+	}
+	if err := s.unwrapSharingLinks(ctx, siteID, assignments); err != nil {
+		return nil, err
+	}
+	if err := s.expandSiteGroups(ctx, siteID, assignments); err != nil {
+		return nil, err
+	}
+	return assignments[0], nil
+}
+
+// encodeAssignmentsCursor encodes the position of the next page, bound to the audit run.
+func (s *SiteContentService) encodeAssignmentsCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", s.auditRunID, offset)))
+}
+
+// decodeAssignmentsCursor returns the position a cursor continues from.
+func (s *SiteContentService) decodeAssignmentsCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	runPart, offsetPart, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+	auditRunID, err := strconv.ParseInt(runPart, 10, 64)
+	if err != nil || auditRunID != s.auditRunID {
+		return 0, fmt.Errorf("%w: cursor is not for audit run %d", ErrInvalidCursor, s.auditRunID)
+	}
+	offset, err := strconv.Atoi(offsetPart)
+	if err != nil || offset < 0 {
+		return 0, fmt.Errorf("%w: %q", ErrInvalidCursor, cursor)
+	}
+	return offset, nil
+}
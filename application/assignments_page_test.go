@@ -0,0 +1,64 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/sharepoint"
+	"spaudit/test/helpers"
+)
+
+func testResolvedAssignments(count int) []*sharepoint.ResolvedAssignment {
+	resolved := make([]*sharepoint.ResolvedAssignment, count)
+	for i := range resolved {
+		resolved[i] = &sharepoint.ResolvedAssignment{Assignment: &sharepoint.Assignment{
+			Principal:      &sharepoint.Principal{ID: int64(i + 1), Title: fmt.Sprintf("User %d", i+1)},
+			RoleDefinition: &sharepoint.RoleDefinition{ID: 1, Name: "Read"},
+		}}
+	}
+	return resolved
+}
+
+func TestSiteContentService_GetAssignmentsPage(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("cursor continues after the first page", func(t *testing.T) {
+		mocks := helpers.NewMockRepositories()
+		all := []*sharepoint.Assignment{{}, {}, {}}
+		resolved := testResolvedAssignments(3)
+		mocks.SiteContentAggregate.On("GetAssignmentsForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeList, "list-1").Return(all, nil)
+		mocks.SiteContentAggregate.On("GetResolvedAssignmentsPageForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeList, "list-1", 0, 3).Return(resolved, nil)
+		mocks.SiteContentAggregate.On("GetResolvedAssignmentsPageForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeList, "list-1", 2, 3).Return(resolved[2:], nil)
+		service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+		first, err := service.GetAssignmentsPage(ctx, 1, sharepoint.ObjectTypeList, "list-1", "", 2)
+		require.NoError(t, err)
+		assert.Equal(t, resolved[:2], first.Assignments)
+		assert.Equal(t, 0, first.Offset)
+		assert.Len(t, first.All, 3)
+		require.NotEmpty(t, first.NextCursor)
+
+		next, err := service.GetAssignmentsPage(ctx, 1, sharepoint.ObjectTypeList, "list-1", first.NextCursor, 2)
+		require.NoError(t, err)
+		assert.Equal(t, resolved[2:], next.Assignments)
+		assert.Equal(t, 2, next.Offset)
+		assert.Nil(t, next.All)
+		assert.Empty(t, next.NextCursor)
+	})
+
+	t.Run("cursors are rejected when malformed or issued for another run", func(t *testing.T) {
+		mocks := helpers.NewMockRepositories()
+		otherRun := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 8).encodeAssignmentsCursor(2)
+		service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+		for _, cursor := range []string{"not a cursor", otherRun} {
+			_, err := service.GetAssignmentsPage(ctx, 1, sharepoint.ObjectTypeList, "list-1", cursor, 2)
+			assert.ErrorIs(t, err, ErrInvalidCursor, cursor)
+		}
+		mocks.SiteContentAggregate.AssertNotCalled(t, "GetResolvedAssignmentsPageForObject")
+	})
+}
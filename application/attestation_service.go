@@ -0,0 +1,323 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// ErrInvalidAttestation is returned for attestation requests or responses that cannot be recorded.
+var ErrInvalidAttestation = errors.New("invalid attestation")
+
+// Kinds of attestation entries.
+const (
+	AttestationEntryExternalAccess = "external_access" // A guest's explicit access to the web, a list or an item
+	AttestationEntrySharingLink    = "sharing_link"    // An active sharing link
+)
+
+// AttestationEntry is one external access grant or sharing link a site owner is asked about.
+type AttestationEntry struct {
+	Key        string // Stable within the audit run; responses are recorded by it
+	Kind       string // AttestationEntryExternalAccess or AttestationEntrySharingLink
+	ObjectType string
+	ObjectName string
+	ObjectURL  string
+
+	// External access
+	Principal  string
+	LoginName  string
+	Role       string
+	GrantType  string
+	GrantedVia string
+
+	// Sharing links
+	LinkKind     int
+	Scope        int
+	MembersCount int64
+	Expiration   *time.Time
+
+	Decision    string // audit.AttestationKeep, audit.AttestationRemove or empty while unanswered
+	RespondedAt *time.Time
+}
+
+// AttestationReview is an attestation with the entries the owner is asked about and their responses.
+type AttestationReview struct {
+	Attestation *audit.Attestation
+	SiteURL     string
+	Entries     []*AttestationEntry // External access first, then sharing links, each ordered by URL
+}
+
+// Answered returns the number of entries the owner has responded to.
+func (r *AttestationReview) Answered() int {
+	answered := 0
+	for _, entry := range r.Entries {
+		if entry.Decision != "" {
+			answered++
+		}
+	}
+	return answered
+}
+
+// AttestationService asks site owners to attest to the external access and sharing links of an
+// audit run through review links, and records their responses against the run.
+type AttestationService struct {
+	db             *database.Database
+	serviceFactory AuditRunScopedServiceFactory
+	now            func() time.Time
+	logger         *logging.Logger
+}
+
+// NewAttestationService creates a new attestation service.
+func NewAttestationService(db *database.Database, serviceFactory AuditRunScopedServiceFactory) *AttestationService {
+	return &AttestationService{
+		db:             db,
+		serviceFactory: serviceFactory,
+		now:            func() time.Time { return time.Now().UTC() },
+		logger:         logging.Default().WithComponent("attestation_service"),
+	}
+}
+
+// CreateAttestation creates a review link for the owner of a site to attest to an audit run.
+func (s *AttestationService) CreateAttestation(ctx context.Context, siteID, auditRunID int64, owner string) (*audit.Attestation, error) {
+	owner = strings.TrimSpace(owner)
+	if owner == "" {
+		return nil, fmt.Errorf("%w: the site owner is required", ErrInvalidAttestation)
+	}
+	auditRun, err := s.db.ReadQueries().GetAuditRun(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("audit run %d not found: %w", auditRunID, err)
+	}
+	if auditRun.SiteID != siteID {
+		return nil, fmt.Errorf("audit run %d: %w", auditRunID, contracts.ErrSiteScopeMismatch)
+	}
+
+	token, err := newLinkToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate attestation token: %w", err)
+	}
+	id, err := s.db.Queries().CreateAttestation(ctx, db.CreateAttestationParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		Token:      token,
+		Owner:      owner,
+		CreatedAt:  s.now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create attestation for audit run %d: %w", auditRunID, err)
+	}
+
+	s.logger.Info("Created attestation", "site_id", siteID, "audit_run_id", auditRunID, "attestation_id", id, "owner", owner)
+	return s.getAttestation(ctx, id)
+}
+
+// GetAttestations returns the attestations of an audit run with their response counts, newest first.
+func (s *AttestationService) GetAttestations(ctx context.Context, siteID, auditRunID int64) ([]*audit.Attestation, error) {
+	rows, err := s.db.ReadQueries().GetAttestationsForAuditRun(ctx, db.GetAttestationsForAuditRunParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attestations for audit run %d: %w", auditRunID, err)
+	}
+
+	attestations := make([]*audit.Attestation, len(rows))
+	for i, row := range rows {
+		attestations[i] = newAttestation(db.Attestation{
+			AttestationID: row.AttestationID, SiteID: row.SiteID, AuditRunID: row.AuditRunID, Token: row.Token,
+			Owner: row.Owner, CreatedAt: row.CreatedAt, SubmittedAt: row.SubmittedAt,
+		})
+		attestations[i].KeepCount = int(row.KeepCount)
+		attestations[i].RemoveCount = int(row.RemoveCount)
+	}
+	return attestations, nil
+}
+
+// GetReview returns the review behind a review link token.
+func (s *AttestationService) GetReview(ctx context.Context, token string) (*AttestationReview, error) {
+	row, err := s.db.ReadQueries().GetAttestationByToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("attestation not found: %w", err)
+	}
+	return s.review(ctx, newAttestation(row))
+}
+
+// GetReviewByID returns an attestation of an audit run with its responses.
+func (s *AttestationService) GetReviewByID(ctx context.Context, siteID, auditRunID, attestationID int64) (*AttestationReview, error) {
+	attestation, err := s.getAttestation(ctx, attestationID)
+	if err != nil {
+		return nil, err
+	}
+	if attestation.SiteID != siteID || attestation.AuditRunID != auditRunID {
+		return nil, fmt.Errorf("attestation %d: %w", attestationID, contracts.ErrSiteScopeMismatch)
+	}
+	return s.review(ctx, attestation)
+}
+
+// RecordResponses records the owner's decisions, by entry key, on the review behind a token.
+// Entries left out keep their previous response; a changed decision is timestamped anew.
+func (s *AttestationService) RecordResponses(ctx context.Context, token string, decisions map[string]string) (*AttestationReview, error) {
+	review, err := s.GetReview(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(review.Entries))
+	for _, entry := range review.Entries {
+		known[entry.Key] = true
+	}
+	for key, decision := range decisions {
+		if !known[key] {
+			return nil, fmt.Errorf("%w: unknown entry %q", ErrInvalidAttestation, key)
+		}
+		if !audit.IsAttestationDecision(decision) {
+			return nil, fmt.Errorf("%w: decision must be %s or %s", ErrInvalidAttestation, audit.AttestationKeep, audit.AttestationRemove)
+		}
+	}
+
+	id := review.Attestation.ID
+	now := s.now()
+	err = s.db.WithTx(func(queries *db.Queries) error {
+		for key, decision := range decisions {
+			if err := queries.UpsertAttestationResponse(ctx, db.UpsertAttestationResponseParams{
+				AttestationID: id,
+				EntryKey:      key,
+				Decision:      decision,
+				RespondedAt:   now,
+			}); err != nil {
+				return err
+			}
+		}
+		return queries.MarkAttestationSubmitted(ctx, db.MarkAttestationSubmittedParams{
+			AttestationID: id,
+			SubmittedAt:   sql.NullTime{Time: now, Valid: true},
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record responses to attestation %d: %w", id, err)
+	}
+
+	s.logger.Info("Recorded attestation responses", "attestation_id", id, "audit_run_id", review.Attestation.AuditRunID, "responses", len(decisions))
+	return s.GetReview(ctx, token)
+}
+
+// getAttestation loads an attestation by ID.
+func (s *AttestationService) getAttestation(ctx context.Context, attestationID int64) (*audit.Attestation, error) {
+	row, err := s.db.ReadQueries().GetAttestation(ctx, attestationID)
+	if err != nil {
+		return nil, fmt.Errorf("attestation %d not found: %w", attestationID, err)
+	}
+	return newAttestation(row), nil
+}
+
+// review lists the external access and sharing links of the attestation's run with the owner's responses.
+func (s *AttestationService) review(ctx context.Context, attestation *audit.Attestation) (*AttestationReview, error) {
+	services, err := s.serviceFactory.CreateForAuditRun(ctx, attestation.SiteID, strconv.FormatInt(attestation.AuditRunID, 10))
+	if err != nil {
+		return nil, err
+	}
+	content := services.SiteContentService
+	site, err := services.SiteBrowsingService.GetSiteWithMetadata(ctx, attestation.SiteID)
+	if err != nil {
+		return nil, err
+	}
+	access, err := content.GetAccessReviewEntries(ctx, attestation.SiteID)
+	if err != nil {
+		return nil, err
+	}
+	facts, err := content.GetRunFacts(ctx, attestation.SiteID)
+	if err != nil {
+		return nil, err
+	}
+
+	review := &AttestationReview{Attestation: attestation, SiteURL: site.Site.URL, Entries: []*AttestationEntry{}}
+	seen := map[string]bool{}
+	for _, grant := range access {
+		if !grant.Principal.IsGuest() {
+			continue
+		}
+		key := fmt.Sprintf("access/%s/%s/%d/%s", grant.ObjectType, grant.ObjectKey, grant.Principal.ID, grant.Role)
+		if seen[key] {
+			continue // Reached through more than one group or link; the owner decides once
+		}
+		seen[key] = true
+		review.Entries = append(review.Entries, &AttestationEntry{
+			Key:        key,
+			Kind:       AttestationEntryExternalAccess,
+			ObjectType: grant.ObjectType,
+			ObjectName: grant.ObjectName,
+			ObjectURL:  grant.ObjectURL,
+			Principal:  grant.Principal.GetDisplayName(),
+			LoginName:  grant.Principal.LoginName,
+			Role:       grant.Role,
+			GrantType:  grant.GrantType,
+			GrantedVia: grant.GrantedVia,
+		})
+	}
+	sort.SliceStable(review.Entries, func(i, j int) bool {
+		return review.Entries[i].ObjectURL < review.Entries[j].ObjectURL
+	})
+	for _, link := range facts.SharingLinks {
+		review.Entries = append(review.Entries, &AttestationEntry{
+			Key:          "link/" + link.LinkID,
+			Kind:         AttestationEntrySharingLink,
+			ObjectType:   sharepoint.ObjectTypeItem,
+			ObjectName:   link.ItemName,
+			ObjectURL:    link.ItemURL,
+			LinkKind:     link.LinkKind,
+			Scope:        link.Scope,
+			MembersCount: link.MembersCount,
+			Expiration:   link.Expiration,
+		})
+	}
+
+	responses, err := s.db.ReadQueries().GetAttestationResponses(ctx, attestation.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get responses to attestation %d: %w", attestation.ID, err)
+	}
+	byKey := make(map[string]db.GetAttestationResponsesRow, len(responses))
+	for _, response := range responses {
+		byKey[response.EntryKey] = response
+	}
+	for _, entry := range review.Entries {
+		if response, ok := byKey[entry.Key]; ok {
+			entry.Decision = response.Decision
+			entry.RespondedAt = &response.RespondedAt
+		}
+	}
+	return review, nil
+}
+
+// newAttestation converts a stored attestation.
+func newAttestation(row db.Attestation) *audit.Attestation {
+	attestation := &audit.Attestation{
+		ID:         row.AttestationID,
+		SiteID:     row.SiteID,
+		AuditRunID: row.AuditRunID,
+		Token:      row.Token,
+		Owner:      row.Owner,
+		CreatedAt:  row.CreatedAt,
+	}
+	if row.SubmittedAt.Valid {
+		attestation.SubmittedAt = &row.SubmittedAt.Time
+	}
+	return attestation
+}
+
+// newLinkToken generates the random token identifying a review or report share link.
+func newLinkToken() (string, error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
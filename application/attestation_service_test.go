@@ -0,0 +1,114 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
+)
+
+// newAttestationTestService stores a run of site 1 where a guest reads Documents, a member edits it and an
+// item has an active sharing link.
+func newAttestationTestService(t *testing.T) *AttestationService {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	for _, stmt := range []string{
+		`INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A'), (2, 'https://contoso.sharepoint.com/sites/b', 'B')`,
+		`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`,
+		`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at, completed_at) VALUES (1, 'job-1', 1, '2025-01-01 09:00:00', '2025-01-01 10:00:00')`,
+		`INSERT INTO webs (site_id, web_id, audit_run_id, title) VALUES (1, 'web-1', 1, 'A')`,
+		`INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title, url, has_unique) VALUES (1, 'docs', 1, 'web-1', 'Documents', '/sites/a/Shared Documents', TRUE)`,
+		`INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, name, url, is_file) VALUES (1, 'item-1', 1, 'docs', 1, 'plan.docx', '/sites/a/Shared Documents/plan.docx', TRUE)`,
+		`INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES
+			(1, 20, 1, 'Pat Partner', 'i:0#.f|membership|pat_northwind.com#ext#@contoso.onmicrosoft.com', 1),
+			(1, 21, 1, 'Mia Member', 'i:0#.f|membership|mia@contoso.com', 1)`,
+		`INSERT INTO role_definitions (site_id, role_def_id, audit_run_id, name) VALUES (1, 1073741826, 1, 'Read'), (1, 1073741830, 1, 'Edit')`,
+		`INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES
+			(1, 'list', 'docs', 20, 1073741826, 1),
+			(1, 'list', 'docs', 21, 1073741830, 1)`,
+		`INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, file_folder_unique_id, link_kind, scope, is_active) VALUES (1, 'link-1', 1, 'item-1', 'item-1', 3, 1, 1)`,
+	} {
+		mustExec(t, testDB, stmt)
+	}
+
+	serviceFactory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		repositories.NewSqlcAuditRepository(testDB),
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunAnyStatus,
+	)
+	return NewAttestationService(testDB, serviceFactory)
+}
+
+func TestAttestationService_RecordResponses(t *testing.T) {
+	service := newAttestationTestService(t)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	_, err := service.CreateAttestation(ctx, 1, 1, " ")
+	assert.ErrorIs(t, err, ErrInvalidAttestation, "the owner is required")
+	_, err = service.CreateAttestation(ctx, 2, 1, "owner@contoso.com")
+	assert.ErrorIs(t, err, contracts.ErrSiteScopeMismatch)
+
+	attestation, err := service.CreateAttestation(ctx, 1, 1, "owner@contoso.com")
+	require.NoError(t, err)
+	assert.Len(t, attestation.Token, 48)
+	assert.False(t, attestation.IsSubmitted())
+
+	// The guest's access and the sharing link are up for review; the member's access is not
+	review, err := service.GetReview(ctx, attestation.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "https://contoso.sharepoint.com/sites/a", review.SiteURL)
+	require.Len(t, review.Entries, 2)
+	access, link := review.Entries[0], review.Entries[1]
+	assert.Equal(t, AttestationEntryExternalAccess, access.Kind)
+	assert.Equal(t, "Pat Partner", access.Principal)
+	assert.Equal(t, "Read", access.Role)
+	assert.Equal(t, AttestationEntrySharingLink, link.Kind)
+	assert.Equal(t, "link/link-1", link.Key)
+	assert.Equal(t, "/sites/a/Shared Documents/plan.docx", link.ObjectURL)
+	assert.Zero(t, review.Answered())
+
+	_, err = service.RecordResponses(ctx, attestation.Token, map[string]string{"link/other": audit.AttestationKeep})
+	assert.ErrorIs(t, err, ErrInvalidAttestation, "entries outside the review are rejected")
+	_, err = service.RecordResponses(ctx, attestation.Token, map[string]string{link.Key: "maybe"})
+	assert.ErrorIs(t, err, ErrInvalidAttestation)
+
+	review, err = service.RecordResponses(ctx, attestation.Token, map[string]string{access.Key: audit.AttestationKeep, link.Key: audit.AttestationRemove})
+	require.NoError(t, err)
+	assert.Equal(t, 2, review.Answered())
+	assert.Equal(t, audit.AttestationRemove, review.Entries[1].Decision)
+	require.True(t, review.Attestation.IsSubmitted())
+
+	// Changing one decision leaves the other as answered
+	now = now.Add(time.Hour)
+	review, err = service.RecordResponses(ctx, attestation.Token, map[string]string{link.Key: audit.AttestationKeep})
+	require.NoError(t, err)
+	assert.Equal(t, now, *review.Entries[1].RespondedAt)
+	assert.Equal(t, now.Add(-time.Hour), *review.Entries[0].RespondedAt)
+
+	attestations, err := service.GetAttestations(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, attestations, 1)
+	assert.Equal(t, 2, attestations[0].KeepCount)
+	assert.Zero(t, attestations[0].RemoveCount)
+
+	byID, err := service.GetReviewByID(ctx, 1, 1, attestation.ID)
+	require.NoError(t, err)
+	assert.Equal(t, attestation.Token, byID.Attestation.Token)
+	_, err = service.GetReviewByID(ctx, 2, 1, attestation.ID)
+	assert.ErrorIs(t, err, contracts.ErrSiteScopeMismatch)
+	_, err = service.GetReview(ctx, "unknown")
+	assert.Error(t, err)
+}
@@ -0,0 +1,191 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+// ChangeCategoryWebAssignment marks role assignments on the site's webs, which only run diffs compare.
+const ChangeCategoryWebAssignment = "web_assignment"
+
+// Permission diff kinds
+const (
+	PermissionAdded   = "added"   // The principal gained access to the object
+	PermissionRemoved = "removed" // The principal lost access to the object
+	PermissionChanged = "changed" // The principal kept access to the object with different roles
+)
+
+// PermissionDiff is the change to one principal's roles on the web, a list or an item between two audit runs.
+type PermissionDiff struct {
+	Kind         string
+	ListID       string // Empty for web assignments
+	ListTitle    string
+	ObjectType   string
+	ObjectKey    string
+	ObjectName   string
+	Principal    *sharepoint.Principal
+	RemovedRoles []string // Roles only the base run granted
+	AddedRoles   []string // Roles only the later run granted
+}
+
+// AuditRunDiff compares a later audit run of a site with a base run.
+type AuditRunDiff struct {
+	SiteID            int64
+	BaseAuditRunID    int64
+	AuditRunID        int64
+	Lists             []*ListDrift      // Lists added, removed or with changed permissions, ordered by title
+	Permissions       []*PermissionDiff // Ordered by list, object and principal; web assignments first
+	NewSharingLinks   []*SnapshotChange // Active links only the later run captured
+	NewUniqueItems    []*SnapshotChange // Items that gained unique permissions
+	RemovedPrincipals []*sharepoint.Principal
+}
+
+// HasChanges returns true if the runs differ.
+func (d *AuditRunDiff) HasChanges() bool {
+	return len(d.Lists) > 0 || len(d.Permissions) > 0 || len(d.RemovedPrincipals) > 0
+}
+
+// AuditDiffService compares two audit runs of the same site.
+type AuditDiffService struct {
+	serviceFactory AuditRunScopedServiceFactory
+}
+
+// NewAuditDiffService creates a new audit diff service.
+func NewAuditDiffService(serviceFactory AuditRunScopedServiceFactory) *AuditDiffService {
+	return &AuditDiffService{serviceFactory: serviceFactory}
+}
+
+// CompareRuns compares an audit run with a base run of the same site. Either run may be an alias
+// such as latest or baseline. The comparison runs in SQL per list, failing with
+// contracts.ErrRowCapExceeded when a list or the site has too many changes.
+func (s *AuditDiffService) CompareRuns(ctx context.Context, siteID int64, baseAuditRunID, auditRunID string) (*AuditRunDiff, error) {
+	baseServices, err := s.serviceFactory.CreateForAuditRun(ctx, siteID, baseAuditRunID)
+	if err != nil {
+		return nil, err
+	}
+	runServices, err := s.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &AuditRunDiff{
+		SiteID:            siteID,
+		BaseAuditRunID:    baseServices.AuditRunID,
+		AuditRunID:        runServices.AuditRunID,
+		Lists:             []*ListDrift{},
+		Permissions:       []*PermissionDiff{},
+		NewSharingLinks:   []*SnapshotChange{},
+		NewUniqueItems:    []*SnapshotChange{},
+		RemovedPrincipals: []*sharepoint.Principal{},
+	}
+	if diff.BaseAuditRunID == diff.AuditRunID {
+		return diff, nil
+	}
+
+	runChanges, err := runServices.SiteContentService.GetRunChanges(ctx, siteID, diff.BaseAuditRunID)
+	if err != nil {
+		return nil, err
+	}
+	diff.Permissions = append(diff.Permissions, groupPermissionChanges("", "", webAssignmentChanges(runChanges))...)
+	diff.RemovedPrincipals = append(diff.RemovedPrincipals, runChanges.RemovedPrincipals...)
+
+	diff.Lists, err = compareRunLists(ctx, siteID, runServices, baseServices)
+	if err != nil {
+		return nil, err
+	}
+	for _, list := range diff.Lists {
+		var assignments []*SnapshotChange
+		for _, change := range list.Changes {
+			switch change.Category {
+			case ChangeCategoryListAssignment, ChangeCategoryItemAssignment:
+				assignments = append(assignments, change)
+			case ChangeCategorySharingLink:
+				if change.Change == ChangeAdded {
+					diff.NewSharingLinks = append(diff.NewSharingLinks, change)
+				}
+			case ChangeCategoryUniqueItem:
+				if change.Change == ChangeAdded {
+					diff.NewUniqueItems = append(diff.NewUniqueItems, change)
+				}
+			}
+		}
+		diff.Permissions = append(diff.Permissions, groupPermissionChanges(list.List.ID, list.List.Title, assignments)...)
+	}
+	return diff, nil
+}
+
+// webAssignmentChanges converts the run's web assignment changes to snapshot changes.
+func webAssignmentChanges(changes *contracts.RunChanges) []*SnapshotChange {
+	converted := make([]*SnapshotChange, len(changes.WebAssignments))
+	for i, change := range changes.WebAssignments {
+		marker := ChangeRemoved
+		if change.Added {
+			marker = ChangeAdded
+		}
+		converted[i] = &SnapshotChange{
+			Change:     marker,
+			Category:   ChangeCategoryWebAssignment,
+			ObjectType: sharepoint.ObjectTypeWeb,
+			ObjectKey:  change.ObjectKey,
+			ObjectName: change.ObjectName,
+			Principal:  change.Principal,
+			RoleDefID:  change.RoleDefID,
+			Role:       change.Role,
+		}
+	}
+	return converted
+}
+
+// groupPermissionChanges merges the role assignments added and removed for the same principal on the
+// same object, so a principal whose roles were swapped is reported once as changed. Changes arrive
+// ordered by object and principal, which the result keeps.
+func groupPermissionChanges(listID, listTitle string, changes []*SnapshotChange) []*PermissionDiff {
+	type key struct {
+		objectType, objectKey string
+		principalID           int64
+	}
+	byKey := make(map[key]*PermissionDiff)
+	var diffs []*PermissionDiff
+
+	for _, change := range changes {
+		k := key{change.ObjectType, change.ObjectKey, principalID(change)}
+		permission, ok := byKey[k]
+		if !ok {
+			permission = &PermissionDiff{
+				ListID:     listID,
+				ListTitle:  listTitle,
+				ObjectType: change.ObjectType,
+				ObjectKey:  change.ObjectKey,
+				ObjectName: change.ObjectName,
+				Principal:  change.Principal,
+			}
+			byKey[k] = permission
+			diffs = append(diffs, permission)
+		}
+
+		role := sharepoint.CanonicalRoleName(change.RoleDefID, change.Role)
+		if role == "" {
+			role = fmt.Sprintf("Role %d", change.RoleDefID)
+		}
+		if change.Change == ChangeAdded {
+			permission.AddedRoles = append(permission.AddedRoles, role)
+		} else {
+			permission.RemovedRoles = append(permission.RemovedRoles, role)
+		}
+	}
+
+	for _, permission := range diffs {
+		switch {
+		case len(permission.RemovedRoles) == 0:
+			permission.Kind = PermissionAdded
+		case len(permission.AddedRoles) == 0:
+			permission.Kind = PermissionRemoved
+		default:
+			permission.Kind = PermissionChanged
+		}
+	}
+	return diffs
+}
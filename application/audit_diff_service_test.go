@@ -0,0 +1,112 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
+)
+
+func newAuditDiffTestService(t *testing.T) *AuditDiffService {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	// Run 2 swaps Finance from Read to Contribute on Documents, grants Visitors Read on the web,
+	// breaks inheritance on Budget.xlsx, shares it with a link and no longer captures the contractor
+	for _, stmt := range []string{
+		`INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A'), (2, 'https://contoso.sharepoint.com/sites/b', 'B')`,
+		`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-3', 2, 'https://contoso.sharepoint.com/sites/b', 'site_audit', 'completed')`,
+		`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at, completed_at) VALUES (1, 'job-1', 1, '2025-01-01 09:00:00', '2025-01-01 10:00:00'), (2, 'job-2', 1, '2025-01-02 09:00:00', '2025-01-02 10:00:00'), (3, 'job-3', 2, '2025-01-02 09:00:00', '2025-01-02 10:00:00')`,
+		`INSERT INTO webs (site_id, web_id, audit_run_id, title) VALUES (1, 'web-1', 1, 'A'), (1, 'web-1', 2, 'A')`,
+		`INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title, url, has_unique) VALUES
+			(1, 'docs', 1, 'web-1', 'Documents', '/sites/a/Shared Documents', TRUE),
+			(1, 'docs', 2, 'web-1', 'Documents', '/sites/a/Shared Documents', TRUE)`,
+		`INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, name, url, has_unique) VALUES
+			(1, 'budget', 1, 'docs', 1, 'Budget.xlsx', '/sites/a/Shared Documents/Budget.xlsx', FALSE),
+			(1, 'budget', 2, 'docs', 1, 'Budget.xlsx', '/sites/a/Shared Documents/Budget.xlsx', TRUE)`,
+		`INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES
+			(1, 4, 1, 'Visitors', 'Visitors', 8), (1, 4, 2, 'Visitors', 'Visitors', 8),
+			(1, 7, 1, 'Finance', 'Finance', 8), (1, 7, 2, 'Finance', 'Finance', 8),
+			(1, 9, 1, 'Contractor', 'i:0#.f|membership|contractor@contoso.com', 1)`,
+		`INSERT INTO role_definitions (site_id, role_def_id, audit_run_id, name) VALUES
+			(1, 1073741826, 1, 'Read'), (1, 1073741826, 2, 'Read'), (1, 1073741827, 1, 'Contribute'), (1, 1073741827, 2, 'Contribute')`,
+		`INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES
+			(1, 'list', 'docs', 7, 1073741826, 1),
+			(1, 'list', 'docs', 7, 1073741827, 2),
+			(1, 'web', 'web-1', 4, 1073741826, 2)`,
+		`INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, link_kind, is_active) VALUES (1, 'link-1', 2, 'budget', 2, 1)`,
+	} {
+		mustExec(t, testDB, stmt)
+	}
+
+	serviceFactory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		repositories.NewSqlcAuditRepository(testDB),
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunAnyStatus,
+	)
+	return NewAuditDiffService(serviceFactory)
+}
+
+func TestAuditDiffService_CompareRuns(t *testing.T) {
+	service := newAuditDiffTestService(t)
+	ctx := context.Background()
+
+	diff, err := service.CompareRuns(ctx, 1, "1", "2")
+	require.NoError(t, err)
+	assert.True(t, diff.HasChanges())
+	assert.Equal(t, int64(1), diff.BaseAuditRunID)
+	assert.Equal(t, int64(2), diff.AuditRunID)
+
+	require.Len(t, diff.Lists, 1)
+	assert.Equal(t, DriftPermissionsChanged, diff.Lists[0].Kind)
+
+	require.Len(t, diff.Permissions, 2)
+	web, finance := diff.Permissions[0], diff.Permissions[1]
+	assert.Equal(t, PermissionAdded, web.Kind)
+	assert.Equal(t, sharepoint.ObjectTypeWeb, web.ObjectType)
+	assert.Equal(t, "A", web.ObjectName)
+	assert.Equal(t, []string{"Read"}, web.AddedRoles)
+
+	assert.Equal(t, PermissionChanged, finance.Kind, "swapped roles are one change")
+	assert.Equal(t, "docs", finance.ListID)
+	assert.Equal(t, "Finance", finance.Principal.Title)
+	assert.Equal(t, []string{"Read"}, finance.RemovedRoles)
+	assert.Equal(t, []string{"Contribute"}, finance.AddedRoles)
+
+	require.Len(t, diff.NewUniqueItems, 1)
+	assert.Equal(t, "Budget.xlsx", diff.NewUniqueItems[0].ObjectName)
+	require.Len(t, diff.NewSharingLinks, 1)
+	assert.Equal(t, "link-1", diff.NewSharingLinks[0].ObjectKey)
+
+	require.Len(t, diff.RemovedPrincipals, 1)
+	assert.Equal(t, int64(9), diff.RemovedPrincipals[0].ID)
+
+	// Comparing the other way round reports the reverse changes
+	reverse, err := service.CompareRuns(ctx, 1, "2", "1")
+	require.NoError(t, err)
+	require.Len(t, reverse.Permissions, 2)
+	assert.Equal(t, PermissionRemoved, reverse.Permissions[0].Kind)
+	assert.Empty(t, reverse.NewSharingLinks)
+	assert.Empty(t, reverse.RemovedPrincipals)
+}
+
+func TestAuditDiffService_CompareRunsOfOneSite(t *testing.T) {
+	service := newAuditDiffTestService(t)
+	ctx := context.Background()
+
+	same, err := service.CompareRuns(ctx, 1, "2", audit.RunAliasLatest)
+	require.NoError(t, err)
+	assert.False(t, same.HasChanges())
+
+	_, err = service.CompareRuns(ctx, 1, "1", "3")
+	assert.ErrorIs(t, err, contracts.ErrSiteScopeMismatch)
+}
@@ -2,10 +2,15 @@ package application
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
+	"time"
 
+	"spaudit/domain/audit"
 	"spaudit/domain/contracts"
+	"spaudit/gen/db"
 	"spaudit/infrastructure/factories"
 	"spaudit/infrastructure/repositories"
 )
@@ -16,6 +21,7 @@ type AuditRunScopedServices struct {
 	PermissionService   *PermissionService
 	SiteBrowsingService *SiteBrowsingService
 	AuditRunID          int64
+	Exceptions          *audit.PermissionExceptionSet // The site's exceptions active now, whichever run is scoped
 }
 
 // AuditRunScopedServiceFactory creates audit-run-scoped services.
@@ -25,18 +31,27 @@ type AuditRunScopedServiceFactory interface {
 
 // AuditRunScopedServiceFactoryImpl implements the factory.
 type AuditRunScopedServiceFactoryImpl struct {
-	repositoryFactory factories.ScopedRepositoryFactory
-	baseAuditRepo     contracts.AuditRepository
+	repositoryFactory      factories.ScopedRepositoryFactory
+	baseAuditRepo          contracts.AuditRepository
+	uniqueDensityThreshold float64
+	maxAnalysisRows        int
+	latestRunPolicy        audit.LatestRunPolicy
 }
 
 // NewAuditRunScopedServiceFactory creates a new service factory.
 func NewAuditRunScopedServiceFactory(
 	repositoryFactory factories.ScopedRepositoryFactory,
 	baseAuditRepo contracts.AuditRepository,
+	uniqueDensityThreshold float64,
+	maxAnalysisRows int,
+	latestRunPolicy audit.LatestRunPolicy,
 ) AuditRunScopedServiceFactory {
 	return &AuditRunScopedServiceFactoryImpl{
-		repositoryFactory: repositoryFactory,
-		baseAuditRepo:     baseAuditRepo,
+		repositoryFactory:      repositoryFactory,
+		baseAuditRepo:          baseAuditRepo,
+		uniqueDensityThreshold: uniqueDensityThreshold,
+		maxAnalysisRows:        maxAnalysisRows,
+		latestRunPolicy:        latestRunPolicy,
 	}
 }
 
@@ -69,41 +84,65 @@ func (f *AuditRunScopedServiceFactoryImpl) CreateForAuditRun(
 		itemRepo,
 		sharingRepo,
 	)
-	permissionAggregate := repositories.NewPermissionAggregateRepository(
-		f.repositoryFactory.GetBaseRepository(),
-		itemRepo,
-		sharingRepo,
-	)
+	permissionAggregate := repositories.NewPermissionAggregateRepository(f.repositoryFactory.GetBaseRepository())
 
 	// Note: All individual repositories (siteRepo, listRepo, etc.) are now audit-run-scoped for reading
 
-	// Step 5: Create audit-run-scoped application services
+	// Step 5: Load the site's accepted exceptions, left out of findings and risk scoring
+	exceptions, err := loadPermissionExceptionSet(ctx, f.repositoryFactory.GetBaseRepository().ReadQueries(), siteID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 6: Create audit-run-scoped application services
 	siteContentService := NewAuditScopedSiteContentService(siteContentAggregate, auditRunID)
 	permissionService := NewAuditScopedPermissionService(permissionAggregate, auditRunID)
-	siteBrowsingService := NewSiteBrowsingService(siteContentAggregate) // Site browsing doesn't need audit scoping
+	siteContentService.SetUniqueDensityThreshold(f.uniqueDensityThreshold)
+	permissionService.SetUniqueDensityThreshold(f.uniqueDensityThreshold)
+	permissionService.SetExceptions(exceptions)
+	siteContentService.SetMaxAnalysisRows(f.maxAnalysisRows)
+	siteBrowsingService := NewSiteBrowsingService(siteContentAggregate, f.latestRunPolicy) // Site browsing doesn't need audit scoping
 
 	return &AuditRunScopedServices{
 		SiteContentService:  siteContentService,
 		PermissionService:   permissionService,
 		SiteBrowsingService: siteBrowsingService,
 		AuditRunID:          auditRunID,
+		Exceptions:          exceptions,
 	}, nil
 }
 
-// resolveAuditRunID converts "latest" or numeric string to actual audit run ID
+// resolveAuditRunID converts a run alias ("latest", "reference", "baseline") or numeric string to actual audit run ID
 func (f *AuditRunScopedServiceFactoryImpl) resolveAuditRunID(
 	ctx context.Context,
 	siteID int64,
 	auditRunIDStr string,
 ) (int64, error) {
 	
-	if auditRunIDStr == "latest" {
-		// Get the latest audit run for this site
-		latestRun, err := f.repositoryFactory.GetBaseRepository().ReadQueries().GetLatestAuditRunForSite(ctx, siteID)
+	if label, ok := audit.ParseLabeledLatestRun(auditRunIDStr); ok {
+		return f.resolveLatestAuditRunID(ctx, siteID, label)
+	}
+
+	switch auditRunIDStr {
+	case audit.RunAliasLatest:
+		return f.resolveLatestAuditRunID(ctx, siteID, "")
+	case audit.RunAliasReference:
+		// Use the pinned reference run, falling back to latest when none is pinned
+		pinnedRun, err := f.repositoryFactory.GetBaseRepository().ReadQueries().GetPinnedAuditRunForSite(ctx, siteID)
+		if err == nil {
+			return pinnedRun.AuditRunID, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("get reference audit run for site %d: %w", siteID, err)
+		}
+		return f.resolveLatestAuditRunID(ctx, siteID, "")
+	case audit.RunAliasBaseline:
+		// Use the approved baseline; a site without one has nothing to resolve to
+		baseline, err := f.repositoryFactory.GetBaseRepository().ReadQueries().GetCurrentSiteBaseline(ctx, siteID)
 		if err != nil {
-			return 0, fmt.Errorf("get latest audit run for site %d: %w", siteID, err)
+			return 0, fmt.Errorf("get baseline audit run for site %d: %w", siteID, err)
 		}
-		return latestRun.AuditRunID, nil
+		return baseline.AuditRunID, nil
 	}
 
 	// Parse numeric audit run ID
@@ -119,10 +158,36 @@ func (f *AuditRunScopedServiceFactoryImpl) resolveAuditRunID(
 	}
 
 	if auditRun.SiteID != siteID {
-		return 0, fmt.Errorf("audit run %d belongs to site %d, not site %d", 
-			auditRunID, auditRun.SiteID, siteID)
+		return 0, fmt.Errorf("audit run %d belongs to site %d, not site %d: %w", 
+			auditRunID, auditRun.SiteID, siteID, contracts.ErrSiteScopeMismatch)
 	}
 
 	return auditRunID, nil
 }
 
+// resolveLatestAuditRunID resolves "latest" according to the configured policy, among the runs tagged
+// with label unless it is empty. With the completed-only policy, a site with no completed run yet falls
+// back to its latest run.
+func (f *AuditRunScopedServiceFactoryImpl) resolveLatestAuditRunID(ctx context.Context, siteID int64, label string) (int64, error) {
+	queries := f.repositoryFactory.GetBaseRepository().ReadQueries()
+
+	if f.latestRunPolicy == audit.LatestRunCompleted {
+		completedRun, err := queries.GetLatestCompletedAuditRunForSite(ctx, db.GetLatestCompletedAuditRunForSiteParams{SiteID: siteID, Label: label})
+		if err == nil {
+			return completedRun.AuditRunID, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, fmt.Errorf("get latest completed audit run for site %d: %w", siteID, err)
+		}
+	}
+
+	latestRun, err := queries.GetLatestAuditRunForSite(ctx, db.GetLatestAuditRunForSiteParams{SiteID: siteID, Label: label})
+	if err != nil {
+		if label != "" {
+			return 0, fmt.Errorf("get latest audit run labeled %q for site %d: %w", label, siteID, err)
+		}
+		return 0, fmt.Errorf("get latest audit run for site %d: %w", siteID, err)
+	}
+	return latestRun.AuditRunID, nil
+}
+
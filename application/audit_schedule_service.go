@@ -0,0 +1,405 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// Errors returned when managing audit schedules.
+var (
+	ErrInvalidAuditSchedule  = errors.New("invalid audit schedule")
+	ErrSiteAlreadyScheduled  = errors.New("site already scheduled")
+	ErrAuditScheduleNotFound = errors.New("audit schedule not found")
+)
+
+// auditScheduleTick is how often schedules are checked for being due.
+const auditScheduleTick = time.Minute
+
+// RunComparer compares two audit runs of a site.
+type RunComparer interface {
+	CompareRuns(ctx context.Context, siteID int64, baseAuditRunID, auditRunID string) (*AuditRunDiff, error)
+}
+
+// DriftAlerter raises an alert for a scheduled run whose drift exceeded its schedule's thresholds.
+type DriftAlerter interface {
+	RaiseDriftAlert(ctx context.Context, siteID, auditRunID, baseAuditRunID int64, breaches []audit.DriftBreach) (*audit.FindingAlert, error)
+}
+
+// AuditScheduleService audits sites on a schedule. Once each scheduled audit completes, its run is
+// compared with the site's previous completed full audit or its approved baseline, and a drift alert
+// is raised only when the drift exceeds one of the schedule's thresholds, so routine change stays quiet.
+type AuditScheduleService struct {
+	db           *database.Database
+	auditService AuditService
+	comparer     RunComparer
+	alerter      DriftAlerter
+	now          func() time.Time
+	logger       *logging.Logger
+}
+
+// NewAuditScheduleService creates a new audit schedule service.
+func NewAuditScheduleService(db *database.Database, auditService AuditService, comparer RunComparer) *AuditScheduleService {
+	return &AuditScheduleService{
+		db:           db,
+		auditService: auditService,
+		comparer:     comparer,
+		now:          func() time.Time { return time.Now().UTC() },
+		logger:       logging.Default().WithComponent("audit_schedule_service"),
+	}
+}
+
+// SetDriftAlerter sets the alerter drift past the thresholds is raised through. Without one drift is
+// only recorded.
+func (s *AuditScheduleService) SetDriftAlerter(alerter DriftAlerter) {
+	s.alerter = alerter
+}
+
+// Schedule starts auditing a site every interval, or daily when it is zero, comparing each run with
+// compareWith, the previous run when empty. The site is audited on the first check.
+func (s *AuditScheduleService) Schedule(ctx context.Context, siteURL string, interval time.Duration, compareWith string, thresholds audit.DriftThresholds) (*audit.AuditSchedule, error) {
+	siteURL = strings.TrimRight(strings.TrimSpace(siteURL), "/")
+	if err := audit.ValidateSiteURL(siteURL); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAuditSchedule, err)
+	}
+	if interval == 0 {
+		interval = audit.DefaultAuditScheduleInterval
+	}
+	if interval < audit.MinAuditScheduleInterval {
+		return nil, fmt.Errorf("%w: interval must be at least %s", ErrInvalidAuditSchedule, audit.MinAuditScheduleInterval)
+	}
+	if compareWith == "" {
+		compareWith = audit.CompareWithPrevious
+	}
+	if compareWith != audit.CompareWithPrevious && compareWith != audit.CompareWithBaseline {
+		return nil, fmt.Errorf("%w: compare_with must be %q or %q", ErrInvalidAuditSchedule, audit.CompareWithPrevious, audit.CompareWithBaseline)
+	}
+	if err := thresholds.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAuditSchedule, err)
+	}
+
+	if _, err := s.db.ReadQueries().GetAuditScheduleForSite(ctx, siteURL); err == nil {
+		return nil, fmt.Errorf("%w: %s", ErrSiteAlreadyScheduled, siteURL)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check audit schedules: %w", err)
+	}
+
+	now := s.now()
+	schedule := &audit.AuditSchedule{
+		SiteURL:     siteURL,
+		Interval:    interval,
+		CompareWith: compareWith,
+		Thresholds:  thresholds,
+		CreatedAt:   now,
+		NextRunAt:   now,
+	}
+	var err error
+	schedule.ID, err = s.db.Queries().CreateAuditSchedule(ctx, db.CreateAuditScheduleParams{
+		SiteUrl:              schedule.SiteURL,
+		IntervalSeconds:      int64(schedule.Interval / time.Second),
+		CompareWith:          schedule.CompareWith,
+		MaxExternalGrants:    nullThreshold(thresholds.ExternalGrants),
+		MaxPermissionChanges: nullThreshold(thresholds.PermissionChanges),
+		MaxSharingLinks:      nullThreshold(thresholds.SharingLinks),
+		MaxUniqueItems:       nullThreshold(thresholds.UniqueItems),
+		MaxRemovedPrincipals: nullThreshold(thresholds.RemovedPrincipals),
+		CreatedAt:            schedule.CreatedAt,
+		NextRunAt:            schedule.NextRunAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record audit schedule: %w", err)
+	}
+
+	s.logger.Info("Scheduled site audits", "schedule_id", schedule.ID, "site_url", siteURL, "interval", interval, "compare_with", compareWith)
+	return schedule, nil
+}
+
+// GetSchedules returns every audit schedule, by site.
+func (s *AuditScheduleService) GetSchedules(ctx context.Context) ([]*audit.AuditSchedule, error) {
+	rows, err := s.db.ReadQueries().GetAuditSchedules(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit schedules: %w", err)
+	}
+	schedules := make([]*audit.AuditSchedule, len(rows))
+	for i, row := range rows {
+		schedules[i] = newAuditSchedule(row)
+	}
+	return schedules, nil
+}
+
+// Unschedule stops auditing a site on a schedule, dropping the drift of its past runs. Audits already
+// queued still run but are no longer checked for drift.
+func (s *AuditScheduleService) Unschedule(ctx context.Context, scheduleID int64) error {
+	deleted, err := s.db.Queries().DeleteAuditSchedule(ctx, scheduleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete audit schedule: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: %d", ErrAuditScheduleNotFound, scheduleID)
+	}
+	s.logger.Info("Stopped scheduled site audits", "schedule_id", scheduleID)
+	return nil
+}
+
+// GetChecks returns the drift of a schedule's most recent runs, newest first.
+func (s *AuditScheduleService) GetChecks(ctx context.Context, scheduleID, limit int64) ([]*audit.AuditScheduleCheck, error) {
+	if _, err := s.getSchedule(ctx, scheduleID); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.ReadQueries().GetAuditScheduleChecks(ctx, db.GetAuditScheduleChecksParams{ScheduleID: scheduleID, LimitCount: limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit schedule checks: %w", err)
+	}
+	checks := make([]*audit.AuditScheduleCheck, len(rows))
+	for i, row := range rows {
+		checks[i] = newAuditScheduleCheck(row)
+	}
+	return checks, nil
+}
+
+// RunDue queues an audit of every site whose schedule is due and returns how many were queued.
+// Schedules whose site is being audited, or that wait for database maintenance, stay due for the
+// next call.
+func (s *AuditScheduleService) RunDue(ctx context.Context) (int, error) {
+	rows, err := s.db.ReadQueries().GetDueAuditSchedules(ctx, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due audit schedules: %w", err)
+	}
+
+	queued := 0
+	var errs []error
+	for _, row := range rows {
+		if ctx.Err() != nil {
+			break
+		}
+		schedule := newAuditSchedule(row)
+		request, err := s.auditService.QueueAudit(ctx, schedule.SiteURL, audit.DefaultParameters())
+		switch {
+		case errors.Is(err, ErrAuditAlreadyQueued) || errors.Is(err, ErrMaintenanceRunning):
+			continue
+		case err != nil:
+			errs = append(errs, fmt.Errorf("schedule %d: %w", schedule.ID, err))
+			continue
+		}
+
+		now := s.now()
+		if err := s.db.Queries().RecordAuditScheduleRun(ctx, db.RecordAuditScheduleRunParams{
+			NextRunAt:  now.Add(schedule.Interval),
+			LastJobID:  sql.NullString{String: request.ID, Valid: true},
+			LastRunAt:  sql.NullTime{Time: now, Valid: true},
+			ScheduleID: schedule.ID,
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("schedule %d: failed to record scheduled audit: %w", schedule.ID, err))
+			continue
+		}
+		queued++
+		s.logger.Info("Queued scheduled site audit", "schedule_id", schedule.ID, "job_id", request.ID, "site_url", schedule.SiteURL)
+	}
+	return queued, errors.Join(errs...)
+}
+
+// Run queues due scheduled audits every auditScheduleTick until ctx is cancelled.
+func (s *AuditScheduleService) Run(ctx context.Context) {
+	ticker := time.NewTicker(auditScheduleTick)
+	defer ticker.Stop()
+	for {
+		if _, err := s.RunDue(ctx); err != nil {
+			s.logger.Error("Failed to run scheduled audits", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckRunDrift compares a completed scheduled run with the run its schedule compares with, records
+// the drift, and raises a drift alert when it exceeds a threshold. It returns nil when the run is not
+// the latest of a schedule or there is nothing to compare it with, as for a site's first run or one
+// without a baseline. Checking a run again returns the drift first recorded.
+func (s *AuditScheduleService) CheckRunDrift(ctx context.Context, auditRunID int64) (*audit.AuditScheduleCheck, error) {
+	auditRun, err := s.db.ReadQueries().GetAuditRun(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("audit run %d not found: %w", auditRunID, err)
+	}
+	row, err := s.db.ReadQueries().GetAuditScheduleForJob(ctx, sql.NullString{String: auditRun.JobID, Valid: true})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit schedule: %w", err)
+	}
+	schedule := newAuditSchedule(row)
+
+	if recorded, err := s.db.ReadQueries().GetAuditScheduleCheck(ctx, db.GetAuditScheduleCheckParams{ScheduleID: schedule.ID, AuditRunID: auditRunID}); err == nil {
+		return newAuditScheduleCheck(recorded), nil
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get audit schedule check: %w", err)
+	}
+
+	baseAuditRunID, err := s.baseRun(ctx, schedule, auditRun.SiteID, auditRunID)
+	if err != nil || baseAuditRunID == 0 {
+		return nil, err
+	}
+	diff, err := s.comparer.CompareRuns(ctx, auditRun.SiteID, strconv.FormatInt(baseAuditRunID, 10), strconv.FormatInt(auditRunID, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	check := &audit.AuditScheduleCheck{
+		ScheduleID:     schedule.ID,
+		AuditRunID:     auditRunID,
+		BaseAuditRunID: baseAuditRunID,
+		Drift:          measureDrift(diff),
+		CheckedAt:      s.now(),
+	}
+	breaches := schedule.Thresholds.Exceeded(check.Drift)
+	if len(breaches) > 0 && s.alerter != nil {
+		alert, err := s.alerter.RaiseDriftAlert(ctx, auditRun.SiteID, auditRunID, baseAuditRunID, breaches)
+		if err != nil {
+			return nil, err
+		}
+		check.AlertID = alert.ID
+	}
+
+	if err := s.db.Queries().CreateAuditScheduleCheck(ctx, db.CreateAuditScheduleCheckParams{
+		ScheduleID:        check.ScheduleID,
+		AuditRunID:        check.AuditRunID,
+		BaseAuditRunID:    check.BaseAuditRunID,
+		ExternalGrants:    int64(check.Drift.ExternalGrants),
+		PermissionChanges: int64(check.Drift.PermissionChanges),
+		SharingLinks:      int64(check.Drift.SharingLinks),
+		UniqueItems:       int64(check.Drift.UniqueItems),
+		RemovedPrincipals: int64(check.Drift.RemovedPrincipals),
+		AlertID:           sql.NullInt64{Int64: check.AlertID, Valid: check.AlertID != 0},
+		CheckedAt:         check.CheckedAt,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to record audit schedule check: %w", err)
+	}
+
+	s.logger.Info("Checked scheduled run for drift", "schedule_id", schedule.ID, "audit_run_id", auditRunID,
+		"base_audit_run_id", baseAuditRunID, "breaches", len(breaches))
+	return check, nil
+}
+
+// baseRun returns the run a scheduled run is compared with, or zero when there is none.
+func (s *AuditScheduleService) baseRun(ctx context.Context, schedule *audit.AuditSchedule, siteID, auditRunID int64) (int64, error) {
+	if schedule.CompareWith == audit.CompareWithBaseline {
+		baseline, err := s.db.ReadQueries().GetCurrentSiteBaseline(ctx, siteID)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && baseline.AuditRunID == auditRunID) {
+			return 0, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to get site baseline: %w", err)
+		}
+		return baseline.AuditRunID, nil
+	}
+
+	previous, err := s.db.ReadQueries().GetPreviousCompletedAuditRunForScope(ctx, auditRunID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get previous audit run: %w", err)
+	}
+	return previous.AuditRunID, nil
+}
+
+// measureDrift counts the drift of a run diff. External grants are the guests that gained access or
+// roles.
+func measureDrift(diff *AuditRunDiff) audit.DriftMeasure {
+	drift := audit.DriftMeasure{
+		PermissionChanges: len(diff.Permissions),
+		SharingLinks:      len(diff.NewSharingLinks),
+		UniqueItems:       len(diff.NewUniqueItems),
+		RemovedPrincipals: len(diff.RemovedPrincipals),
+	}
+	for _, permission := range diff.Permissions {
+		granted := permission.Kind == PermissionAdded || (permission.Kind == PermissionChanged && len(permission.AddedRoles) > 0)
+		if granted && permission.Principal != nil && permission.Principal.IsGuest() {
+			drift.ExternalGrants++
+		}
+	}
+	return drift
+}
+
+// getSchedule returns an audit schedule, or ErrAuditScheduleNotFound.
+func (s *AuditScheduleService) getSchedule(ctx context.Context, scheduleID int64) (*audit.AuditSchedule, error) {
+	row, err := s.db.ReadQueries().GetAuditSchedule(ctx, scheduleID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrAuditScheduleNotFound, scheduleID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit schedule: %w", err)
+	}
+	return newAuditSchedule(row), nil
+}
+
+// newAuditSchedule converts an audit schedule row.
+func newAuditSchedule(row db.AuditSchedule) *audit.AuditSchedule {
+	schedule := &audit.AuditSchedule{
+		ID:          row.ScheduleID,
+		SiteURL:     row.SiteUrl,
+		Interval:    time.Duration(row.IntervalSeconds) * time.Second,
+		CompareWith: row.CompareWith,
+		Thresholds: audit.DriftThresholds{
+			ExternalGrants:    thresholdValue(row.MaxExternalGrants),
+			PermissionChanges: thresholdValue(row.MaxPermissionChanges),
+			SharingLinks:      thresholdValue(row.MaxSharingLinks),
+			UniqueItems:       thresholdValue(row.MaxUniqueItems),
+			RemovedPrincipals: thresholdValue(row.MaxRemovedPrincipals),
+		},
+		CreatedAt: row.CreatedAt,
+		NextRunAt: row.NextRunAt,
+		LastJobID: row.LastJobID.String,
+	}
+	if row.LastRunAt.Valid {
+		schedule.LastRunAt = &row.LastRunAt.Time
+	}
+	return schedule
+}
+
+// newAuditScheduleCheck converts an audit schedule check row.
+func newAuditScheduleCheck(row db.AuditScheduleCheck) *audit.AuditScheduleCheck {
+	return &audit.AuditScheduleCheck{
+		ScheduleID:     row.ScheduleID,
+		AuditRunID:     row.AuditRunID,
+		BaseAuditRunID: row.BaseAuditRunID,
+		Drift: audit.DriftMeasure{
+			ExternalGrants:    int(row.ExternalGrants),
+			PermissionChanges: int(row.PermissionChanges),
+			SharingLinks:      int(row.SharingLinks),
+			UniqueItems:       int(row.UniqueItems),
+			RemovedPrincipals: int(row.RemovedPrincipals),
+		},
+		AlertID:   row.AlertID.Int64,
+		CheckedAt: row.CheckedAt,
+	}
+}
+
+// nullThreshold converts an optional threshold to a nullable column.
+func nullThreshold(threshold *int) sql.NullInt64 {
+	if threshold == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: int64(*threshold), Valid: true}
+}
+
+// thresholdValue converts a nullable threshold column.
+func thresholdValue(column sql.NullInt64) *int {
+	if !column.Valid {
+		return nil
+	}
+	threshold := int(column.Int64)
+	return &threshold
+}
@@ -0,0 +1,114 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+)
+
+func newAuditScheduleTestService(t *testing.T) (*AuditScheduleService, *fakeMicroAudits, *recordingAlertNotifier) {
+	t.Helper()
+	alertService, notifier := newFindingAlertTestService(t, audit.QuietHours{})
+	audits := &fakeMicroAudits{}
+	service := NewAuditScheduleService(alertService.db, audits, NewAuditDiffService(alertService.serviceFactory))
+	service.SetDriftAlerter(alertService)
+	return service, audits, notifier
+}
+
+func TestAuditScheduleService_Schedule(t *testing.T) {
+	const siteURL = "https://contoso.sharepoint.com/sites/a"
+	ctx := context.Background()
+	service, _, _ := newAuditScheduleTestService(t)
+	five := 5
+
+	_, err := service.Schedule(ctx, siteURL, 0, "", audit.DriftThresholds{})
+	assert.ErrorIs(t, err, ErrInvalidAuditSchedule, "thresholds are required")
+	_, err = service.Schedule(ctx, siteURL, 30*time.Minute, "", audit.DriftThresholds{ExternalGrants: &five})
+	assert.ErrorIs(t, err, ErrInvalidAuditSchedule)
+	_, err = service.Schedule(ctx, siteURL, 0, "reference", audit.DriftThresholds{ExternalGrants: &five})
+	assert.ErrorIs(t, err, ErrInvalidAuditSchedule)
+
+	schedule, err := service.Schedule(ctx, siteURL+"/", 0, "", audit.DriftThresholds{ExternalGrants: &five})
+	require.NoError(t, err)
+	assert.Equal(t, siteURL, schedule.SiteURL)
+	assert.Equal(t, audit.DefaultAuditScheduleInterval, schedule.Interval)
+	assert.Equal(t, audit.CompareWithPrevious, schedule.CompareWith)
+
+	_, err = service.Schedule(ctx, siteURL, 0, audit.CompareWithBaseline, audit.DriftThresholds{ExternalGrants: &five})
+	assert.ErrorIs(t, err, ErrSiteAlreadyScheduled)
+
+	schedules, err := service.GetSchedules(ctx)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	assert.Equal(t, &five, schedules[0].Thresholds.ExternalGrants)
+	assert.Nil(t, schedules[0].Thresholds.SharingLinks)
+
+	require.NoError(t, service.Unschedule(ctx, schedule.ID))
+	assert.ErrorIs(t, service.Unschedule(ctx, schedule.ID), ErrAuditScheduleNotFound)
+}
+
+func TestAuditScheduleService_CheckRunDrift(t *testing.T) {
+	ctx := context.Background()
+	service, audits, notifier := newAuditScheduleTestService(t)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	zero, five := 0, 5
+
+	// The fake queues job-1 and then job-2, the jobs of runs 1 and 2; run 2 grants Finance contribute on Documents
+	schedule, err := service.Schedule(ctx, "https://contoso.sharepoint.com/sites/a", 0, "", audit.DriftThresholds{ExternalGrants: &five, PermissionChanges: &zero})
+	require.NoError(t, err)
+
+	t.Run("first runs have nothing to compare with", func(t *testing.T) {
+		queued, err := service.RunDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, queued)
+		queued, err = service.RunDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, queued, "not due again before the interval")
+
+		check, err := service.CheckRunDrift(ctx, 1)
+		require.NoError(t, err)
+		assert.Nil(t, check)
+	})
+
+	t.Run("drift past a threshold raises an alert", func(t *testing.T) {
+		now = now.Add(audit.DefaultAuditScheduleInterval)
+		queued, err := service.RunDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, queued)
+		require.Len(t, audits.queued, 2)
+
+		check, err := service.CheckRunDrift(ctx, 2)
+		require.NoError(t, err)
+		require.NotNil(t, check)
+		assert.Equal(t, int64(1), check.BaseAuditRunID)
+		assert.Equal(t, audit.DriftMeasure{PermissionChanges: 1}, check.Drift)
+		assert.NotZero(t, check.AlertID)
+		require.Len(t, notifier.alerts, 1)
+		assert.Equal(t, ScheduledDriftExceeded, notifier.alerts[0].Category)
+		assert.Contains(t, notifier.alerts[0].Message, "1 permission changes (threshold 0)")
+	})
+
+	t.Run("checking again raises no alert", func(t *testing.T) {
+		check, err := service.CheckRunDrift(ctx, 2)
+		require.NoError(t, err)
+		require.NotNil(t, check)
+		assert.Len(t, notifier.alerts, 1)
+
+		checks, err := service.GetChecks(ctx, schedule.ID, 10)
+		require.NoError(t, err)
+		require.Len(t, checks, 1)
+		assert.Equal(t, check.AlertID, checks[0].AlertID)
+	})
+
+	t.Run("runs of earlier schedule jobs are not checked", func(t *testing.T) {
+		check, err := service.CheckRunDrift(ctx, 1)
+		require.NoError(t, err)
+		assert.Nil(t, check)
+	})
+}
@@ -2,12 +2,16 @@ package application
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"spaudit/database"
 	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
 	"spaudit/domain/jobs"
 	"spaudit/gen/db"
 	"spaudit/logging"
@@ -24,13 +28,41 @@ type AuditService interface {
 	// Methods needed by other services.
 	IsSiteBeingAudited(siteURL string) bool
 	BuildAuditParametersFromFormData(formData map[string][]string) *audit.AuditParameters
-	GetAuditRunsForSite(ctx context.Context, siteID int64, limit int) ([]*audit.AuditRun, error)
+	GetAuditRunsForSite(ctx context.Context, siteID int64, label string, limit int) ([]*audit.AuditRun, error)
+	GetAuditRun(ctx context.Context, siteID, auditRunID int64) (*audit.AuditRun, error)
+	GetAuditRunForJob(ctx context.Context, jobID string) (*audit.AuditRun, error)
+	GetAuditRunSchemaDrift(ctx context.Context, siteID, auditRunID int64) ([]audit.SchemaDrift, error)
+
+	// Reference run pinning.
+	PinAuditRun(ctx context.Context, siteID, auditRunID int64) error
+	UnpinAuditRun(ctx context.Context, siteID int64) error
+	GetPinnedAuditRunID(ctx context.Context, siteID int64) (int64, error)
+
+	// Legal hold.
+	HoldAuditRun(ctx context.Context, siteID, auditRunID int64, reason string) (*audit.AuditRun, error)
+	ReleaseAuditRunHold(ctx context.Context, siteID, auditRunID int64) (*audit.AuditRun, error)
 }
 
+// ErrAuditAlreadyQueued is returned by QueueAudit when the site already has a running or pending audit.
+var ErrAuditAlreadyQueued = errors.New("audit already running or queued")
+
+// ErrInvalidAuditScope is returned by QueueAudit when the folder an audit is scoped to is not inside the site.
+var ErrInvalidAuditScope = errors.New("invalid audit scope")
+
+// ErrInvalidRunLabel is returned by QueueAudit when the label a run is to be tagged with is not a valid label.
+var ErrInvalidRunLabel = errors.New("invalid run label")
+
+// ErrInvalidCollectorBackend is returned by QueueAudit when the audit is to read the site through an unknown backend.
+var ErrInvalidCollectorBackend = errors.New("invalid collector backend")
+
+// ErrMaintenanceRunning is returned by QueueAudit while database maintenance rewrites the database.
+var ErrMaintenanceRunning = errors.New("database maintenance is running")
+
 // AuditServiceImpl implements AuditService.
 type AuditServiceImpl struct {
 	jobService JobService
 	db         *database.Database
+	storage    *StorageMonitor // Optional, gates new audits on the storage soft limit
 	logger     *logging.Logger
 }
 
@@ -38,10 +70,12 @@ type AuditServiceImpl struct {
 func NewAuditService(
 	jobService JobService,
 	db *database.Database,
+	storage *StorageMonitor,
 ) AuditService {
 	return &AuditServiceImpl{
 		jobService: jobService,
 		db:         db,
+		storage:    storage,
 		logger:     logging.Default().WithComponent("audit_service"),
 	}
 }
@@ -109,15 +143,70 @@ func (s *AuditServiceImpl) BuildAuditParametersFromFormData(formData map[string]
 		parameters.IncludeSharing = false
 	}
 
+	if hasFormValue("collect_analytics") {
+		parameters.CollectAnalytics = true
+	} else if _, exists := formData["collect_analytics"]; exists {
+		parameters.CollectAnalytics = false
+	}
+
+	if hasFormValue("scan_recycle_bin") {
+		parameters.ScanRecycleBin = true
+	} else if _, exists := formData["scan_recycle_bin"]; exists {
+		parameters.ScanRecycleBin = false
+	}
+
+	if hasFormValue("audit_graph_grants") {
+		parameters.AuditGraphGrants = true
+	} else if _, exists := formData["audit_graph_grants"]; exists {
+		parameters.AuditGraphGrants = false
+	}
+
+	if hasFormValue("expand_entra_groups") {
+		parameters.ExpandEntraGroups = true
+	} else if _, exists := formData["expand_entra_groups"]; exists {
+		parameters.ExpandEntraGroups = false
+	}
+
+	if hasFormValue("graph_collector") {
+		parameters.CollectorBackend = audit.CollectorBackendGraph
+	} else if _, exists := formData["graph_collector"]; exists {
+		parameters.CollectorBackend = audit.CollectorBackendREST
+	}
+	if values, exists := formData["collector_backend"]; exists && len(values) > 0 {
+		parameters.CollectorBackend = audit.NormalizeCollectorBackend(values[0])
+	}
+
+	if hasFormValue("sample_large_lists") {
+		parameters.SampleLargeLists = true
+	} else if _, exists := formData["sample_large_lists"]; exists {
+		parameters.SampleLargeLists = false
+	}
+
 	// Handle numeric parameters
 	if batchSize := getIntValue("batch_size"); batchSize > 0 {
 		parameters.BatchSize = batchSize
 	}
 
+	if maxRequestsPerSecond := getIntValue("max_requests_per_second"); maxRequestsPerSecond > 0 {
+		parameters.MaxRequestsPerSecond = maxRequestsPerSecond
+	}
+
 	if timeout := getIntValue("timeout"); timeout > 0 {
 		parameters.Timeout = timeout
 	}
 
+	if sampleThreshold := getIntValue("sample_threshold"); sampleThreshold > 0 {
+		parameters.SampleThreshold = sampleThreshold
+	}
+
+	if values, exists := formData["folder_path"]; exists && len(values) > 0 {
+		parameters.FolderPath = strings.TrimSpace(values[0])
+	}
+
+	if values, exists := formData["label"]; exists && len(values) > 0 {
+		parameters.Label = audit.NormalizeRunLabel(values[0])
+	}
+
 	return parameters
 }
 
@@ -128,13 +217,51 @@ func (s *AuditServiceImpl) QueueAudit(ctx context.Context, siteURL string, param
 	// Check if audit is already running or pending for this site
 	if s.IsSiteBeingAudited(siteURL) {
 		s.logger.Info("Rejecting duplicate audit request", "site_url", siteURL)
-		return nil, fmt.Errorf("audit already running or queued for site: %s", siteURL)
+		return nil, fmt.Errorf("%w for site: %s", ErrAuditAlreadyQueued, siteURL)
+	}
+
+	for _, job := range s.jobService.ListJobsByType(jobs.JobTypeDatabaseMaintenance) {
+		if job.IsActive() {
+			s.logger.Info("Rejecting audit request during database maintenance", "site_url", siteURL, "job_id", job.ID)
+			return nil, fmt.Errorf("%w, try again when job %s finishes", ErrMaintenanceRunning, job.ID)
+		}
+	}
+
+	if s.storage != nil {
+		if err := s.storage.CheckNewAudit(); err != nil {
+			s.logger.Info("Rejecting audit request over storage quota", "site_url", siteURL)
+			return nil, err
+		}
+	}
+
+	// Resolve a folder scope to its server-relative path, which is what the collector matches items against
+	description := fmt.Sprintf("Audit: %s", siteURL)
+	if parameters != nil && parameters.FolderPath != "" {
+		folderPath, err := audit.ResolveFolderPath(siteURL, parameters.FolderPath)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidAuditScope, err)
+		}
+		parameters.FolderPath = folderPath
+		description = fmt.Sprintf("Audit: %s (folder %s)", siteURL, folderPath)
+	}
+	if parameters != nil && parameters.Label != "" {
+		parameters.Label = audit.NormalizeRunLabel(parameters.Label)
+		if err := audit.ValidateRunLabel(parameters.Label); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRunLabel, err)
+		}
+		description += fmt.Sprintf(" [%s]", parameters.Label)
+	}
+	if parameters != nil {
+		parameters.CollectorBackend = audit.NormalizeCollectorBackend(parameters.CollectorBackend)
+		if err := audit.ValidateCollectorBackend(parameters.CollectorBackend); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCollectorBackend, err)
+		}
 	}
 
 	// Use the StartJob method which creates AND starts the job
 	params := JobParams{
 		"siteURL":     siteURL,
-		"description": fmt.Sprintf("Audit: %s", siteURL),
+		"description": description,
 		"parameters":  parameters,
 	}
 
@@ -246,11 +373,12 @@ func (s *AuditServiceImpl) CancelAudit(siteURL string) error {
 	return nil
 }
 
-// GetAuditRunsForSite retrieves audit runs for a specific site
-func (s *AuditServiceImpl) GetAuditRunsForSite(ctx context.Context, siteID int64, limit int) ([]*audit.AuditRun, error) {
+// GetAuditRunsForSite retrieves audit runs for a specific site, only those tagged with label unless it is empty
+func (s *AuditServiceImpl) GetAuditRunsForSite(ctx context.Context, siteID int64, label string, limit int) ([]*audit.AuditRun, error) {
 	// Query database for audit runs
 	rows, err := s.db.Queries().GetAuditRunsForSite(ctx, db.GetAuditRunsForSiteParams{
 		SiteID:     siteID,
+		Label:      label,
 		LimitCount: int64(limit),
 	})
 	if err != nil {
@@ -261,25 +389,160 @@ func (s *AuditServiceImpl) GetAuditRunsForSite(ctx context.Context, siteID int64
 	// Convert to domain objects
 	auditRuns := make([]*audit.AuditRun, len(rows))
 	for i, row := range rows {
-		var completedAt *time.Time
-		if row.CompletedAt.Valid {
-			completedAt = &row.CompletedAt.Time
-		}
+		auditRuns[i] = newAuditRun(db.GetAuditRunRow(row))
+	}
+
+	return auditRuns, nil
+}
+
+// GetAuditRun retrieves a single audit run, scoped to the site
+func (s *AuditServiceImpl) GetAuditRun(ctx context.Context, siteID, auditRunID int64) (*audit.AuditRun, error) {
+	row, err := s.db.ReadQueries().GetAuditRun(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("audit run %d not found: %w", auditRunID, err)
+	}
+	if row.SiteID != siteID {
+		return nil, fmt.Errorf("audit run %d: %w", auditRunID, contracts.ErrSiteScopeMismatch)
+	}
+
+	return newAuditRun(row), nil
+}
 
-		trigger := ""
-		if row.AuditTrigger.Valid {
-			trigger = row.AuditTrigger.String
+// GetAuditRunForJob retrieves the audit run created by an audit job
+func (s *AuditServiceImpl) GetAuditRunForJob(ctx context.Context, jobID string) (*audit.AuditRun, error) {
+	row, err := s.db.ReadQueries().GetAuditRunByJobID(ctx, jobID)
+	if err != nil {
+		return nil, fmt.Errorf("audit run for job %s not found: %w", jobID, err)
+	}
+
+	return newAuditRun(db.GetAuditRunRow(row)), nil
+}
+
+// GetAuditRunSchemaDrift retrieves the API schema drift recorded by an audit run, missing fields first
+func (s *AuditServiceImpl) GetAuditRunSchemaDrift(ctx context.Context, siteID, auditRunID int64) ([]audit.SchemaDrift, error) {
+	if _, err := s.GetAuditRun(ctx, siteID, auditRunID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.ReadQueries().GetAuditRunSchemaDrift(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("get schema drift for audit run %d: %w", auditRunID, err)
+	}
+
+	drift := make([]audit.SchemaDrift, len(rows))
+	for i, row := range rows {
+		drift[i] = audit.SchemaDrift{
+			Source:      row.Source,
+			Field:       row.Field,
+			Kind:        audit.SchemaDriftKind(row.Kind),
+			Occurrences: row.Occurrences,
 		}
+	}
+	return drift, nil
+}
+
+// newAuditRun converts audit run columns to the domain model.
+// The audit run queries select the same columns, so their rows convert to GetAuditRunRow.
+func newAuditRun(row db.GetAuditRunRow) *audit.AuditRun {
+	auditRun := &audit.AuditRun{
+		ID:        row.AuditRunID,
+		JobID:     row.JobID,
+		SiteID:    row.SiteID,
+		StartedAt: row.StartedAt,
+		OnHold:    row.OnHold,
+	}
+	if row.CompletedAt.Valid {
+		auditRun.CompletedAt = &row.CompletedAt.Time
+	}
+	if row.AuditTrigger.Valid {
+		auditRun.Trigger = row.AuditTrigger.String
+	}
+	if row.HeldAt.Valid {
+		auditRun.HeldAt = &row.HeldAt.Time
+	}
+	if row.HoldReason.Valid {
+		auditRun.HoldReason = row.HoldReason.String
+	}
+	if row.ScopePath.Valid {
+		auditRun.ScopePath = row.ScopePath.String
+	}
+	if row.Label.Valid {
+		auditRun.Label = row.Label.String
+	}
+	return auditRun
+}
+
+// PinAuditRun marks an audit run as the site's reference run, replacing any existing pin
+func (s *AuditServiceImpl) PinAuditRun(ctx context.Context, siteID, auditRunID int64) error {
+	auditRun, err := s.db.ReadQueries().GetAuditRun(ctx, auditRunID)
+	if err != nil {
+		return fmt.Errorf("audit run %d not found: %w", auditRunID, err)
+	}
+	if auditRun.SiteID != siteID {
+		return fmt.Errorf("audit run %d belongs to site %d, not site %d", auditRunID, auditRun.SiteID, siteID)
+	}
+
+	if err := s.db.WriteQueries().PinAuditRunForSite(ctx, db.PinAuditRunForSiteParams{
+		SiteID:     siteID,
+		AuditRunID: sql.NullInt64{Int64: auditRunID, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to pin audit run: %w", err)
+	}
+
+	s.logger.Info("Pinned reference audit run", "site_id", siteID, "audit_run_id", auditRunID)
+	return nil
+}
 
-		auditRuns[i] = &audit.AuditRun{
-			ID:          row.AuditRunID,
-			JobID:       row.JobID,
-			SiteID:      row.SiteID,
-			StartedAt:   row.StartedAt,
-			CompletedAt: completedAt,
-			Trigger:     trigger,
+// UnpinAuditRun clears the site's reference run
+func (s *AuditServiceImpl) UnpinAuditRun(ctx context.Context, siteID int64) error {
+	if err := s.db.WriteQueries().UnpinAuditRunForSite(ctx, siteID); err != nil {
+		return fmt.Errorf("failed to unpin audit run: %w", err)
+	}
+
+	s.logger.Info("Unpinned reference audit run", "site_id", siteID)
+	return nil
+}
+
+// GetPinnedAuditRunID returns the site's reference run ID, or 0 if no run is pinned
+func (s *AuditServiceImpl) GetPinnedAuditRunID(ctx context.Context, siteID int64) (int64, error) {
+	pinnedRun, err := s.db.ReadQueries().GetPinnedAuditRunForSite(ctx, siteID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil // No reference run pinned
 		}
+		return 0, fmt.Errorf("failed to get reference audit run: %w", err)
 	}
+	return pinnedRun.AuditRunID, nil
+}
 
-	return auditRuns, nil
+// HoldAuditRun places the audit run on legal hold, exempting it from retention pruning and archival.
+// Holding a run that is already on hold replaces its reason.
+func (s *AuditServiceImpl) HoldAuditRun(ctx context.Context, siteID, auditRunID int64, reason string) (*audit.AuditRun, error) {
+	if _, err := s.GetAuditRun(ctx, siteID, auditRunID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WriteQueries().HoldAuditRun(ctx, db.HoldAuditRunParams{
+		AuditRunID: auditRunID,
+		HoldReason: sql.NullString{String: reason, Valid: reason != ""},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to hold audit run: %w", err)
+	}
+
+	s.logger.Info("Placed audit run on hold", "site_id", siteID, "audit_run_id", auditRunID, "reason", reason)
+	return s.GetAuditRun(ctx, siteID, auditRunID)
+}
+
+// ReleaseAuditRunHold takes the audit run off legal hold, returning it to normal retention
+func (s *AuditServiceImpl) ReleaseAuditRunHold(ctx context.Context, siteID, auditRunID int64) (*audit.AuditRun, error) {
+	if _, err := s.GetAuditRun(ctx, siteID, auditRunID); err != nil {
+		return nil, err
+	}
+
+	if err := s.db.WriteQueries().ReleaseAuditRunHold(ctx, auditRunID); err != nil {
+		return nil, fmt.Errorf("failed to release audit run hold: %w", err)
+	}
+
+	s.logger.Info("Released audit run hold", "site_id", siteID, "audit_run_id", auditRunID)
+	return s.GetAuditRun(ctx, siteID, auditRunID)
 }
@@ -1,13 +1,20 @@
 package application
 
 import (
+	"context"
+	"database/sql"
 	"net/url"
 	"os"
 	"testing"
 
 	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/gen/db"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAuditServiceImpl_BuildAuditParametersFromFormData(t *testing.T) {
@@ -48,6 +55,39 @@ func TestAuditServiceImpl_BuildAuditParametersFromFormData(t *testing.T) {
 				// Check that defaults are used for other values
 				assert.True(t, parameters.ScanIndividualItems) // default
 				assert.True(t, parameters.SkipHidden)          // default
+				assert.False(t, parameters.CollectAnalytics)   // default
+				assert.False(t, parameters.SampleLargeLists)   // default
+				assert.Equal(t, audit.DefaultSampleThreshold, parameters.SampleThreshold)
+			},
+		},
+		{
+			name: "sampling of large lists",
+			formData: map[string][]string{
+				"sample_large_lists": {"on"},
+				"sample_threshold":   {"20000"},
+			},
+			expected: func(parameters *audit.AuditParameters) {
+				assert.True(t, parameters.SampleLargeLists)
+				assert.Equal(t, 20000, parameters.SampleThreshold)
+			},
+		},
+		{
+			name: "folder scope",
+			formData: map[string][]string{
+				"folder_path": {"  /sites/Finance/Shared Documents/Payroll "},
+			},
+			expected: func(parameters *audit.AuditParameters) {
+				assert.Equal(t, "/sites/Finance/Shared Documents/Payroll", parameters.FolderPath)
+				assert.True(t, parameters.IsFolderScoped())
+			},
+		},
+		{
+			name: "run label",
+			formData: map[string][]string{
+				"label": {" Prod-Tenant "},
+			},
+			expected: func(parameters *audit.AuditParameters) {
+				assert.Equal(t, "prod-tenant", parameters.Label)
 			},
 		},
 		{
@@ -56,6 +96,7 @@ func TestAuditServiceImpl_BuildAuditParametersFromFormData(t *testing.T) {
 				"scan_individual_items": {"on"},
 				"skip_hidden":           {"on"},
 				"include_sharing":       {"on"},
+				"collect_analytics":     {"on"},
 				"timeout":               {"600"},
 				"batch_size":            {"200"},
 			},
@@ -63,6 +104,7 @@ func TestAuditServiceImpl_BuildAuditParametersFromFormData(t *testing.T) {
 				assert.True(t, parameters.ScanIndividualItems)
 				assert.True(t, parameters.SkipHidden)
 				assert.True(t, parameters.IncludeSharing)
+				assert.True(t, parameters.CollectAnalytics)
 				assert.Equal(t, 600, parameters.Timeout)
 				assert.Equal(t, 200, parameters.BatchSize)
 			},
@@ -137,3 +179,123 @@ func TestAuditServiceImpl_BuildAuditParametersFromFormData_URLValues(t *testing.
 	assert.Equal(t, 75, parameters.BatchSize)
 	assert.True(t, parameters.ScanIndividualItems)
 }
+
+func TestAuditServiceImpl_FolderScopedRuns(t *testing.T) {
+	testDB := newTestDatabase(t)
+
+	for _, stmt := range []string{
+		`INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A')`,
+		`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`,
+		`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at, completed_at) VALUES (1, 'job-1', 1, '2025-01-01 09:00:00', '2025-01-01 10:00:00')`,
+		`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, scope_path) VALUES (2, 'job-2', 1, '2025-01-02 09:00:00', '2025-01-02 09:05:00', 'investigation', '/sites/a/Shared Documents/Payroll')`,
+	} {
+		mustExec(t, testDB, stmt)
+	}
+
+	ctx := context.Background()
+	service := NewAuditService(nil, testDB, nil)
+
+	scoped, err := service.GetAuditRun(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "/sites/a/Shared Documents/Payroll", scoped.ScopePath)
+	assert.Equal(t, "investigation", scoped.Trigger)
+	assert.True(t, scoped.IsFolderScoped())
+
+	// The newer scoped run does not replace the full site run as the latest
+	latest, err := testDB.ReadQueries().GetLatestCompletedAuditRunForSite(ctx, db.GetLatestCompletedAuditRunForSiteParams{SiteID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), latest.AuditRunID)
+}
+
+func TestAuditServiceImpl_HoldAuditRun(t *testing.T) {
+	testDB := newTestDatabase(t)
+
+	for _, stmt := range []string{
+		`INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A'), (2, 'https://contoso.sharepoint.com/sites/b', 'B')`,
+		`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`,
+		`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (1, 'job-1', 1, CURRENT_TIMESTAMP)`,
+	} {
+		mustExec(t, testDB, stmt)
+	}
+
+	ctx := context.Background()
+	service := NewAuditService(nil, testDB, nil)
+
+	held, err := service.HoldAuditRun(ctx, 1, 1, "Case 4711")
+	require.NoError(t, err)
+	assert.True(t, held.OnHold)
+	assert.NotNil(t, held.HeldAt)
+	assert.Equal(t, "Case 4711", held.HoldReason)
+
+	runs, err := service.GetAuditRunsForSite(ctx, 1, "", 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.True(t, runs[0].OnHold, "run history reports the hold")
+
+	// Runs of other sites cannot be held through this site
+	_, err = service.HoldAuditRun(ctx, 2, 1, "")
+	assert.Error(t, err)
+
+	released, err := service.ReleaseAuditRunHold(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.False(t, released.OnHold)
+	assert.Nil(t, released.HeldAt)
+	assert.Empty(t, released.HoldReason)
+}
+
+func TestAuditServiceImpl_RunLabels(t *testing.T) {
+	testDB := newTestDatabase(t)
+
+	// Both sides of a tenant migration are audited; run 3 is the newest but unlabeled
+	for _, stmt := range []string{
+		`INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A'), (2, 'https://fabrikam.sharepoint.com/sites/a', 'A (target)')`,
+		`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-3', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-4', 2, 'https://fabrikam.sharepoint.com/sites/a', 'site_audit', 'completed')`,
+		`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at, completed_at, label) VALUES
+			(1, 'job-1', 1, '2025-01-01 09:00:00', '2025-01-01 10:00:00', 'pre-migration'),
+			(2, 'job-2', 1, '2025-01-02 09:00:00', '2025-01-02 10:00:00', 'prod'),
+			(3, 'job-3', 1, '2025-01-03 09:00:00', '2025-01-03 10:00:00', NULL),
+			(4, 'job-4', 2, '2025-01-04 09:00:00', '2025-01-04 10:00:00', 'test')`,
+	} {
+		mustExec(t, testDB, stmt)
+	}
+
+	ctx := context.Background()
+	service := NewAuditService(nil, testDB, nil)
+
+	runs, err := service.GetAuditRunsForSite(ctx, 1, "prod", 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, int64(2), runs[0].ID)
+	assert.Equal(t, "prod", runs[0].Label)
+
+	runs, err = service.GetAuditRunsForSite(ctx, 1, "", 10)
+	require.NoError(t, err)
+	assert.Len(t, runs, 3, "no label lists every run")
+
+	// A labeled latest alias resolves among the runs with the label only
+	serviceFactory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		repositories.NewSqlcAuditRepository(testDB),
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunCompleted,
+	)
+	for alias, expected := range map[string]int64{
+		audit.RunAliasLatest:                    3,
+		audit.LabeledLatestRun("prod"):          2,
+		audit.LabeledLatestRun("pre-migration"): 1,
+	} {
+		scoped, err := serviceFactory.CreateForAuditRun(ctx, 1, alias)
+		require.NoError(t, err, alias)
+		assert.Equal(t, expected, scoped.AuditRunID, alias)
+	}
+	_, err = serviceFactory.CreateForAuditRun(ctx, 1, audit.LabeledLatestRun("test"))
+	assert.ErrorIs(t, err, sql.ErrNoRows, "the label is only on another site's runs")
+
+	// The dashboard lists only sites audited with the label, summarising their latest such run
+	sites, err := repositories.NewSiteContentAggregateRepository(repositories.NewBaseRepository(testDB), nil, nil, nil, nil, nil).
+		GetAllSitesWithLatestRunMetadata(ctx, true, "test")
+	require.NoError(t, err)
+	require.Len(t, sites, 1)
+	assert.Equal(t, int64(2), sites[0].Site.ID)
+}
@@ -0,0 +1,293 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// ErrNoBaseline is returned when a site has no approved baseline to compare with.
+var ErrNoBaseline = errors.New("site has no approved baseline")
+
+// ErrBaselineRunIncomplete is returned when approving a run that is still running as baseline.
+var ErrBaselineRunIncomplete = errors.New("only completed audit runs can be approved as baseline")
+
+// Drift finding kinds
+const (
+	DriftListAdded          = "list_added"          // List has data in the run but not in the baseline
+	DriftListRemoved        = "list_removed"        // List has data in the baseline but not in the run
+	DriftPermissionsChanged = "permissions_changed" // List exists in both with different permissions
+)
+
+// ListDrift is a drift finding for one list. Changes are relative to the baseline,
+// so every permission of an added list is an addition and every permission of a removed list a removal.
+type ListDrift struct {
+	Kind     string
+	Severity audit.Severity // Rated by the finding severity mapping
+	*ListSnapshotDiffData
+}
+
+// DriftReport is the drift of an audit run from the site's approved baseline.
+type DriftReport struct {
+	Baseline   *audit.Baseline
+	AuditRunID int64
+	Findings   []*ListDrift // Ordered by list title
+}
+
+// HasDrift returns true if the run differs from the baseline.
+func (r *DriftReport) HasDrift() bool {
+	return len(r.Findings) > 0
+}
+
+// HighestSeverity returns the most urgent severity among the findings, or "" when there is no drift.
+func (r *DriftReport) HighestSeverity() audit.Severity {
+	var highest audit.Severity
+	for _, finding := range r.Findings {
+		if highest == "" || finding.Severity.Rank() > highest.Rank() {
+			highest = finding.Severity
+		}
+	}
+	return highest
+}
+
+// BaselineService manages each site's approved permission baseline and the drift of later runs from it.
+type BaselineService struct {
+	db             *database.Database
+	serviceFactory AuditRunScopedServiceFactory
+	severities     *FindingSeverities
+	now            func() time.Time
+	logger         *logging.Logger
+}
+
+// NewBaselineService creates a new baseline service.
+func NewBaselineService(db *database.Database, serviceFactory AuditRunScopedServiceFactory) *BaselineService {
+	return &BaselineService{
+		db:             db,
+		serviceFactory: serviceFactory,
+		now:            func() time.Time { return time.Now().UTC() },
+		logger:         logging.Default().WithComponent("baseline_service"),
+	}
+}
+
+// SetFindingSeverities sets the mapping drift findings are rated with; without one they rate with the defaults.
+func (s *BaselineService) SetFindingSeverities(severities *FindingSeverities) {
+	s.severities = severities
+}
+
+// ApproveBaseline approves a completed audit run as the site's baseline, superseding the current one.
+// Approving a later run resets the baseline, accepting the drift up to that run.
+func (s *BaselineService) ApproveBaseline(ctx context.Context, siteID, auditRunID int64, approvedBy, note string) (*audit.Baseline, error) {
+	auditRun, err := s.db.ReadQueries().GetAuditRun(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("audit run %d not found: %w", auditRunID, err)
+	}
+	if auditRun.SiteID != siteID {
+		return nil, fmt.Errorf("audit run %d: %w", auditRunID, contracts.ErrSiteScopeMismatch)
+	}
+	if !auditRun.CompletedAt.Valid {
+		return nil, fmt.Errorf("audit run %d: %w", auditRunID, ErrBaselineRunIncomplete)
+	}
+
+	now := s.now()
+	err = s.db.WithTx(func(queries *db.Queries) error {
+		if _, err := queries.SupersedeSiteBaseline(ctx, db.SupersedeSiteBaselineParams{
+			SiteID:       siteID,
+			SupersededAt: sql.NullTime{Time: now, Valid: true},
+		}); err != nil {
+			return err
+		}
+		_, err := queries.CreateSiteBaseline(ctx, db.CreateSiteBaselineParams{
+			SiteID:     siteID,
+			AuditRunID: auditRunID,
+			ApprovedBy: sql.NullString{String: approvedBy, Valid: approvedBy != ""},
+			Note:       sql.NullString{String: note, Valid: note != ""},
+			ApprovedAt: now,
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to approve baseline for site %d: %w", siteID, err)
+	}
+
+	s.logger.Info("Approved baseline audit run", "site_id", siteID, "audit_run_id", auditRunID, "approved_by", approvedBy)
+	return s.GetBaseline(ctx, siteID)
+}
+
+// ClearBaseline supersedes the site's baseline without approving another run.
+func (s *BaselineService) ClearBaseline(ctx context.Context, siteID int64) error {
+	cleared, err := s.db.Queries().SupersedeSiteBaseline(ctx, db.SupersedeSiteBaselineParams{
+		SiteID:       siteID,
+		SupersededAt: sql.NullTime{Time: s.now(), Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear baseline for site %d: %w", siteID, err)
+	}
+	if cleared == 0 {
+		return fmt.Errorf("site %d: %w", siteID, ErrNoBaseline)
+	}
+
+	s.logger.Info("Cleared baseline", "site_id", siteID)
+	return nil
+}
+
+// GetBaseline returns the site's current baseline.
+func (s *BaselineService) GetBaseline(ctx context.Context, siteID int64) (*audit.Baseline, error) {
+	row, err := s.db.ReadQueries().GetCurrentSiteBaseline(ctx, siteID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("site %d: %w", siteID, ErrNoBaseline)
+		}
+		return nil, fmt.Errorf("failed to get baseline for site %d: %w", siteID, err)
+	}
+	return newBaseline(row), nil
+}
+
+// GetBaselineHistory returns every baseline the site has had, newest first.
+func (s *BaselineService) GetBaselineHistory(ctx context.Context, siteID int64) ([]*audit.Baseline, error) {
+	rows, err := s.db.ReadQueries().GetSiteBaselines(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get baselines for site %d: %w", siteID, err)
+	}
+
+	baselines := make([]*audit.Baseline, len(rows))
+	for i, row := range rows {
+		baselines[i] = newBaseline(row)
+	}
+	return baselines, nil
+}
+
+// ComputeDrift compares every list of an audit run with the site's baseline.
+// The baseline run itself has no drift, and changes accepted exceptions cover are no drift.
+func (s *BaselineService) ComputeDrift(ctx context.Context, siteID, auditRunID int64) (*DriftReport, error) {
+	baseline, err := s.GetBaseline(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &DriftReport{Baseline: baseline, AuditRunID: auditRunID, Findings: []*ListDrift{}}
+	if baseline.AuditRunID == auditRunID {
+		return report, nil
+	}
+
+	runServices, err := s.serviceFactory.CreateForAuditRun(ctx, siteID, strconv.FormatInt(auditRunID, 10))
+	if err != nil {
+		return nil, err
+	}
+	baseServices, err := s.serviceFactory.CreateForAuditRun(ctx, siteID, strconv.FormatInt(baseline.AuditRunID, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	report.Findings, err = compareRunLists(ctx, siteID, runServices, baseServices)
+	if err != nil {
+		return nil, err
+	}
+	report.Findings = withoutExceptedChanges(report.Findings, runServices.Exceptions)
+	s.severities.RateDrift(report)
+	return report, nil
+}
+
+// withoutExceptedChanges drops the permission changes accepted exceptions cover from drift findings,
+// and the findings of changed permissions left without changes. Lists added or removed are kept.
+func withoutExceptedChanges(findings []*ListDrift, exceptions *audit.PermissionExceptionSet) []*ListDrift {
+	if exceptions.Len() == 0 {
+		return findings
+	}
+	kept := findings[:0]
+	for _, finding := range findings {
+		changes := finding.Changes[:0]
+		for _, change := range finding.Changes {
+			if !exceptionCoversChange(exceptions, finding.List.ID, change) {
+				changes = append(changes, change)
+			}
+		}
+		finding.Changes = changes
+		if finding.Kind == DriftPermissionsChanged && len(changes) == 0 {
+			continue
+		}
+		kept = append(kept, finding)
+	}
+	return kept
+}
+
+// compareRunLists compares every list either run captured with its base run, returning a finding
+// for each list added, removed or with changed permissions, ordered by list title and unrated.
+func compareRunLists(ctx context.Context, siteID int64, runServices, baseServices *AuditRunScopedServices) ([]*ListDrift, error) {
+	baseAuditRunID := baseServices.AuditRunID
+	runLists, err := runServices.SiteContentService.GetListsForSite(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists for audit run %d: %w", runServices.AuditRunID, err)
+	}
+	baseLists, err := baseServices.SiteContentService.GetListsForSite(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists for base audit run %d: %w", baseAuditRunID, err)
+	}
+
+	inBase := make(map[string]*sharepoint.List, len(baseLists))
+	for _, list := range baseLists {
+		inBase[list.ID] = list
+	}
+	inRun := make(map[string]bool, len(runLists))
+	findings := []*ListDrift{}
+
+	// Lists only one run captured compare against no rows in the other
+	for _, list := range runLists {
+		inRun[list.ID] = true
+		diff, err := runServices.SiteContentService.GetListChanges(ctx, siteID, list, baseAuditRunID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare list %s with audit run %d: %w", list.ID, baseAuditRunID, err)
+		}
+
+		if _, ok := inBase[list.ID]; !ok {
+			findings = append(findings, &ListDrift{Kind: DriftListAdded, ListSnapshotDiffData: diff})
+			continue
+		}
+		if len(diff.Changes) > 0 {
+			findings = append(findings, &ListDrift{Kind: DriftPermissionsChanged, ListSnapshotDiffData: diff})
+		}
+	}
+
+	for _, list := range baseLists {
+		if inRun[list.ID] {
+			continue
+		}
+		diff, err := runServices.SiteContentService.GetListChanges(ctx, siteID, list, baseAuditRunID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare list %s with audit run %d: %w", list.ID, baseAuditRunID, err)
+		}
+		findings = append(findings, &ListDrift{Kind: DriftListRemoved, ListSnapshotDiffData: diff})
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return strings.ToLower(findings[i].List.Title) < strings.ToLower(findings[j].List.Title)
+	})
+	return findings, nil
+}
+
+// newBaseline converts a stored baseline row to the domain type.
+func newBaseline(row db.SiteBaseline) *audit.Baseline {
+	baseline := &audit.Baseline{
+		ID:         row.BaselineID,
+		SiteID:     row.SiteID,
+		AuditRunID: row.AuditRunID,
+		ApprovedBy: row.ApprovedBy.String,
+		Note:       row.Note.String,
+		ApprovedAt: row.ApprovedAt,
+	}
+	if row.SupersededAt.Valid {
+		baseline.SupersededAt = &row.SupersededAt.Time
+	}
+	return baseline
+}
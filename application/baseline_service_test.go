@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
+)
+
+func newBaselineTestService(t *testing.T) *BaselineService {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	// Run 2 grants Finance contribute on Documents, drops Archive and adds Projects; run 3 is still running
+	for _, stmt := range []string{
+		`INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A'), (2, 'https://contoso.sharepoint.com/sites/b', 'B')`,
+		`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-3', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'running')`,
+		`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at, completed_at) VALUES (1, 'job-1', 1, '2025-01-01 09:00:00', '2025-01-01 10:00:00'), (2, 'job-2', 1, '2025-01-02 09:00:00', '2025-01-02 10:00:00'), (3, 'job-3', 1, '2025-01-03 09:00:00', NULL)`,
+		`INSERT INTO webs (site_id, web_id, audit_run_id, title) VALUES (1, 'web-1', 1, 'A'), (1, 'web-1', 2, 'A')`,
+		`INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title, url, has_unique) VALUES
+			(1, 'docs', 1, 'web-1', 'Documents', '/sites/a/Shared Documents', TRUE),
+			(1, 'archive', 1, 'web-1', 'Archive', '/sites/a/Archive', FALSE),
+			(1, 'docs', 2, 'web-1', 'Documents', '/sites/a/Shared Documents', TRUE),
+			(1, 'projects', 2, 'web-1', 'Projects', '/sites/a/Projects', FALSE)`,
+		`INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES (1, 7, 1, 'Finance', 'Finance', 8), (1, 7, 2, 'Finance', 'Finance', 8)`,
+		`INSERT INTO role_definitions (site_id, role_def_id, audit_run_id, name) VALUES (1, 1073741827, 1, 'Contribute'), (1, 1073741827, 2, 'Contribute')`,
+		`INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'list', 'docs', 7, 1073741827, 2)`,
+	} {
+		mustExec(t, testDB, stmt)
+	}
+
+	serviceFactory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		repositories.NewSqlcAuditRepository(testDB),
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunAnyStatus,
+	)
+	return NewBaselineService(testDB, serviceFactory)
+}
+
+func TestBaselineService_ApproveAndReset(t *testing.T) {
+	service := newBaselineTestService(t)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	_, err := service.GetBaseline(ctx, 1)
+	assert.ErrorIs(t, err, ErrNoBaseline)
+	_, err = service.serviceFactory.CreateForAuditRun(ctx, 1, audit.RunAliasBaseline)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "the baseline alias has no fallback")
+
+	_, err = service.ApproveBaseline(ctx, 1, 3, "alice", "")
+	assert.ErrorIs(t, err, ErrBaselineRunIncomplete, "running runs cannot be approved")
+	_, err = service.ApproveBaseline(ctx, 2, 1, "alice", "")
+	assert.ErrorIs(t, err, contracts.ErrSiteScopeMismatch)
+
+	first, err := service.ApproveBaseline(ctx, 1, 1, "alice", "Q1 sign-off")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.AuditRunID)
+	assert.Equal(t, "alice", first.ApprovedBy)
+	assert.Equal(t, "Q1 sign-off", first.Note)
+	assert.True(t, first.IsCurrent())
+
+	// The baseline alias follows the approved run
+	scoped, err := service.serviceFactory.CreateForAuditRun(ctx, 1, audit.RunAliasBaseline)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), scoped.AuditRunID)
+
+	// Resetting to a later run supersedes the approved one but keeps it as history
+	now = now.Add(time.Hour)
+	second, err := service.ApproveBaseline(ctx, 1, 2, "bob", "Accepted Finance access")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), second.AuditRunID)
+
+	history, err := service.GetBaselineHistory(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, second.ID, history[0].ID, "newest first")
+	require.NotNil(t, history[1].SupersededAt)
+	assert.True(t, history[1].SupersededAt.Equal(now))
+
+	require.NoError(t, service.ClearBaseline(ctx, 1))
+	_, err = service.GetBaseline(ctx, 1)
+	assert.ErrorIs(t, err, ErrNoBaseline)
+	assert.ErrorIs(t, service.ClearBaseline(ctx, 1), ErrNoBaseline)
+}
+
+func TestBaselineService_ComputeDrift(t *testing.T) {
+	service := newBaselineTestService(t)
+	ctx := context.Background()
+
+	_, err := service.ComputeDrift(ctx, 1, 2)
+	assert.ErrorIs(t, err, ErrNoBaseline)
+
+	_, err = service.ApproveBaseline(ctx, 1, 1, "alice", "")
+	require.NoError(t, err)
+
+	// The baseline run has not drifted from itself
+	report, err := service.ComputeDrift(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.False(t, report.HasDrift())
+
+	report, err = service.ComputeDrift(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.True(t, report.HasDrift())
+	assert.Equal(t, int64(1), report.Baseline.AuditRunID)
+
+	require.Len(t, report.Findings, 3)
+	archive, docs, projects := report.Findings[0], report.Findings[1], report.Findings[2]
+
+	assert.Equal(t, DriftListRemoved, archive.Kind)
+	assert.Equal(t, "archive", archive.List.ID)
+
+	assert.Equal(t, DriftPermissionsChanged, docs.Kind)
+	require.Len(t, docs.Changes, 1)
+	assert.Equal(t, ChangeAdded, docs.Changes[0].Change)
+	assert.Equal(t, ChangeCategoryListAssignment, docs.Changes[0].Category)
+	assert.Equal(t, "Contribute", docs.Changes[0].Role)
+
+	assert.Equal(t, DriftListAdded, projects.Kind)
+	assert.Equal(t, "projects", projects.List.ID)
+	assert.Equal(t, int64(1), projects.BaseAuditRunID)
+	assert.Equal(t, int64(2), projects.AuditRunID)
+
+	// Findings are rated with the default mapping unless an administrator remaps them
+	assert.Equal(t, audit.SeverityMedium, docs.Severity)
+	assert.Equal(t, audit.SeverityLow, projects.Severity)
+	assert.Equal(t, audit.SeverityMedium, report.HighestSeverity())
+
+	severities, err := NewFindingSeverities(map[string]audit.Severity{DriftListAdded: audit.SeverityCritical})
+	require.NoError(t, err)
+	service.SetFindingSeverities(severities)
+	report, err = service.ComputeDrift(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, audit.SeverityCritical, report.Findings[2].Severity)
+	assert.Equal(t, audit.SeverityCritical, report.HighestSeverity())
+}
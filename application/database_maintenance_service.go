@@ -0,0 +1,265 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/jobs"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// ErrMaintenanceBusy is returned by StartMaintenance while another job is running or queued.
+// Maintenance rewrites the database file, so it never runs alongside audits.
+var ErrMaintenanceBusy = errors.New("database maintenance needs all jobs to finish first")
+
+// maintenanceIntegrityErrors caps how many problems an integrity check reports.
+const maintenanceIntegrityErrors = 100
+
+// maintenanceSchedulerTick is how often the scheduler checks whether maintenance is due.
+const maintenanceSchedulerTick = time.Minute
+
+// DatabaseMaintenanceService runs ANALYZE, incremental VACUUM and integrity checks as a background
+// job, on request or on a schedule, and records each run. Pruned runs leave free pages behind that
+// only a vacuum returns to the file system.
+type DatabaseMaintenanceService struct {
+	db         *database.Database
+	jobService JobService
+	schedule   audit.MaintenanceSchedule
+	logger     *logging.Logger
+	now        func() time.Time
+
+	mu      sync.Mutex
+	trigger string // Of the run being started, read by RunMaintenance
+}
+
+// NewDatabaseMaintenanceService creates a database maintenance service.
+func NewDatabaseMaintenanceService(db *database.Database, jobService JobService, schedule audit.MaintenanceSchedule) *DatabaseMaintenanceService {
+	return &DatabaseMaintenanceService{
+		db:         db,
+		jobService: jobService,
+		schedule:   schedule,
+		logger:     logging.Default().WithComponent("database_maintenance"),
+		now:        time.Now,
+	}
+}
+
+// StartMaintenance queues a maintenance job, unless another job is running or queued.
+func (s *DatabaseMaintenanceService) StartMaintenance(trigger string) (*jobs.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.idle() {
+		return nil, ErrMaintenanceBusy
+	}
+	s.trigger = trigger
+	job, err := s.jobService.StartJob(jobs.JobTypeDatabaseMaintenance, JobParams{"description": "Database maintenance"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start job: %w", err)
+	}
+	s.logger.Info("Database maintenance queued", "job_id", job.ID, "trigger", trigger)
+	return job, nil
+}
+
+// idle reports whether no job is running or queued.
+func (s *DatabaseMaintenanceService) idle() bool {
+	return len(s.jobService.ListJobsByStatus(jobs.JobStatusRunning)) == 0 &&
+		len(s.jobService.ListJobsByStatus(jobs.JobStatusPending)) == 0
+}
+
+// RunMaintenance runs maintenance for a job and records the run. A failed step ends the run, and
+// is recorded on it as well as returned.
+func (s *DatabaseMaintenanceService) RunMaintenance(ctx context.Context, jobID string, progress ProgressCallback) (*audit.MaintenanceRun, error) {
+	s.mu.Lock()
+	trigger := s.trigger
+	s.mu.Unlock()
+	if trigger == "" {
+		trigger = audit.MaintenanceManual
+	}
+
+	before, err := s.db.DiskUsage()
+	if err != nil {
+		return nil, err
+	}
+	run := &audit.MaintenanceRun{
+		JobID:           jobID,
+		Trigger:         trigger,
+		StartedAt:       s.now().UTC(),
+		BytesBefore:     before.TotalBytes(),
+		FreeBytesBefore: before.FreeBytes,
+	}
+	run.ID, err = s.db.Queries().CreateDatabaseMaintenanceRun(ctx, db.CreateDatabaseMaintenanceRunParams{
+		JobID:           run.JobID,
+		Trigger:         run.Trigger,
+		StartedAt:       run.StartedAt,
+		BytesBefore:     run.BytesBefore,
+		FreeBytesBefore: run.FreeBytesBefore,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record maintenance run: %w", err)
+	}
+
+	stepErr := s.runSteps(ctx, run, progress)
+	if stepErr != nil {
+		run.Error = stepErr.Error()
+	}
+
+	if after, err := s.db.DiskUsage(); err == nil {
+		run.BytesAfter = after.TotalBytes()
+		run.FreeBytesAfter = after.FreeBytes
+	} else {
+		s.logger.Warn("Failed to measure database after maintenance", "error", err)
+	}
+	completedAt := s.now().UTC()
+	run.CompletedAt = &completedAt
+
+	// Recorded even when the job was cancelled, so the run does not stay open
+	if err := s.completeRun(context.WithoutCancel(ctx), run); err != nil {
+		return run, err
+	}
+	s.logger.Info("Database maintenance finished",
+		"job_id", jobID,
+		"reclaimed_bytes", run.ReclaimedBytes(),
+		"integrity_errors", len(run.IntegrityErrors),
+		"error", run.Error)
+	return run, stepErr
+}
+
+func (s *DatabaseMaintenanceService) runSteps(ctx context.Context, run *audit.MaintenanceRun, progress ProgressCallback) error {
+	progress("analyzing", "Updating query planner statistics", 10, 0, 3)
+	if err := s.db.Analyze(ctx); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	progress("vacuuming", "Returning free pages to the file system", 40, 1, 3)
+	converted, err := s.db.IncrementalVacuum(ctx)
+	run.Converted = converted
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	progress("checking", "Checking tables and indexes", 70, 2, 3)
+	run.IntegrityErrors, err = s.db.IntegrityCheck(ctx, maintenanceIntegrityErrors)
+	if err != nil {
+		return err
+	}
+	progress("completed", "Maintenance finished", 100, 3, 3)
+	return nil
+}
+
+func (s *DatabaseMaintenanceService) completeRun(ctx context.Context, run *audit.MaintenanceRun) error {
+	var integrityErrors sql.NullString
+	if len(run.IntegrityErrors) > 0 {
+		encoded, err := json.Marshal(run.IntegrityErrors)
+		if err != nil {
+			return fmt.Errorf("failed to encode integrity errors: %w", err)
+		}
+		integrityErrors = sql.NullString{String: string(encoded), Valid: true}
+	}
+
+	err := s.db.Queries().CompleteDatabaseMaintenanceRun(ctx, db.CompleteDatabaseMaintenanceRunParams{
+		CompletedAt:      sql.NullTime{Time: *run.CompletedAt, Valid: true},
+		BytesAfter:       sql.NullInt64{Int64: run.BytesAfter, Valid: true},
+		FreeBytesAfter:   sql.NullInt64{Int64: run.FreeBytesAfter, Valid: true},
+		Converted:        run.Converted,
+		IntegrityErrors:  integrityErrors,
+		Error:            sql.NullString{String: run.Error, Valid: run.Error != ""},
+		MaintenanceRunID: run.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record maintenance result: %w", err)
+	}
+	return nil
+}
+
+// ListRuns returns the most recent maintenance runs, newest first.
+func (s *DatabaseMaintenanceService) ListRuns(ctx context.Context, limit int64) ([]*audit.MaintenanceRun, error) {
+	return listMaintenanceRuns(ctx, s.db, limit)
+}
+
+// RunScheduler starts scheduled maintenance whenever it is due and no job is running, until ctx
+// is cancelled. It returns at once when the schedule has no interval.
+func (s *DatabaseMaintenanceService) RunScheduler(ctx context.Context) {
+	if s.schedule.Interval <= 0 {
+		return
+	}
+	s.logger.Info("Database maintenance scheduled", "interval", s.schedule.Interval.String())
+
+	ticker := time.NewTicker(maintenanceSchedulerTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.startIfDue(ctx)
+		}
+	}
+}
+
+// startIfDue starts scheduled maintenance when the schedule says it is due.
+func (s *DatabaseMaintenanceService) startIfDue(ctx context.Context) {
+	runs, err := s.ListRuns(ctx, 1)
+	if err != nil {
+		s.logger.Warn("Failed to read last maintenance run", "error", err)
+		return
+	}
+	var lastRun time.Time
+	if len(runs) > 0 {
+		lastRun = runs[0].StartedAt
+	}
+	if !s.schedule.Due(lastRun, s.now()) {
+		return
+	}
+
+	if _, err := s.StartMaintenance(audit.MaintenanceScheduled); err != nil && !errors.Is(err, ErrMaintenanceBusy) {
+		s.logger.Error("Failed to start scheduled maintenance", "error", err)
+	}
+}
+
+// listMaintenanceRuns reads the most recent maintenance runs, newest first.
+func listMaintenanceRuns(ctx context.Context, database *database.Database, limit int64) ([]*audit.MaintenanceRun, error) {
+	rows, err := database.ReadQueries().GetDatabaseMaintenanceRuns(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance runs: %w", err)
+	}
+
+	runs := make([]*audit.MaintenanceRun, len(rows))
+	for i, row := range rows {
+		run := &audit.MaintenanceRun{
+			ID:              row.MaintenanceRunID,
+			JobID:           row.JobID,
+			Trigger:         row.Trigger,
+			StartedAt:       row.StartedAt,
+			BytesBefore:     row.BytesBefore,
+			BytesAfter:      row.BytesAfter.Int64,
+			FreeBytesBefore: row.FreeBytesBefore,
+			FreeBytesAfter:  row.FreeBytesAfter.Int64,
+			Converted:       row.Converted,
+			Error:           row.Error.String,
+		}
+		if row.CompletedAt.Valid {
+			run.CompletedAt = &row.CompletedAt.Time
+		}
+		if row.IntegrityErrors.Valid {
+			if err := json.Unmarshal([]byte(row.IntegrityErrors.String), &run.IntegrityErrors); err != nil {
+				return nil, fmt.Errorf("failed to decode integrity errors of maintenance run %d: %w", row.MaintenanceRunID, err)
+			}
+		}
+		runs[i] = run
+	}
+	return runs, nil
+}
@@ -0,0 +1,76 @@
+package application
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/logging"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseMaintenanceService_RunMaintenance(t *testing.T) {
+	logger := logging.NewLogger(&logging.Config{Level: "error", Format: "text", Output: "stderr"})
+	testDB, err := database.New(database.Config{
+		Path:              filepath.Join(t.TempDir(), "test.db"),
+		MaxOpenConns:      2,
+		MaxIdleConns:      1,
+		BusyTimeoutMs:     1000,
+		EnableForeignKeys: true,
+		EnableWAL:         true,
+	}, logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { testDB.Close() })
+
+	// Pruned rows leave free pages behind
+	for i := 0; i < 200; i++ {
+		_, err := testDB.WriteDB().Exec(`INSERT INTO jobs (job_id, site_url, job_type, status, result) VALUES (?, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed', printf('%.4000c', 'x'))`, i)
+		require.NoError(t, err)
+	}
+	_, err = testDB.WriteDB().Exec(`DELETE FROM jobs`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 2, 0, 0, 0, time.UTC)
+	service := NewDatabaseMaintenanceService(testDB, nil, audit.MaintenanceSchedule{})
+	service.now = func() time.Time { return now }
+
+	var stages []string
+	progress := func(stage, description string, percentage, itemsDone, itemsTotal int) {
+		stages = append(stages, stage)
+	}
+
+	first, err := service.RunMaintenance(ctx, "maintenance-1", progress)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"analyzing", "vacuuming", "checking", "completed"}, stages)
+	assert.True(t, first.Converted, "an existing database is converted to incremental auto-vacuum once")
+	assert.True(t, first.Healthy())
+	assert.Equal(t, audit.MaintenanceManual, first.Trigger)
+	assert.Positive(t, first.FreeBytesBefore)
+	assert.Zero(t, first.FreeBytesAfter)
+	assert.Positive(t, first.ReclaimedBytes())
+
+	now = now.Add(24 * time.Hour)
+	second, err := service.RunMaintenance(ctx, "maintenance-2", progress)
+	require.NoError(t, err)
+	assert.False(t, second.Converted)
+
+	runs, err := service.ListRuns(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	assert.Equal(t, "maintenance-2", runs[0].JobID, "newest first")
+	assert.Equal(t, first.ID, runs[1].ID)
+	assert.Equal(t, first.BytesAfter, runs[1].BytesAfter)
+	assert.Empty(t, runs[1].IntegrityErrors)
+	require.NotNil(t, runs[1].CompletedAt)
+
+	usage, err := NewStorageMonitor(testDB, audit.StorageQuota{}).Usage(ctx, 10)
+	require.NoError(t, err)
+	require.NotNil(t, usage.LastMaintenance)
+	assert.Equal(t, second.ID, usage.LastMaintenance.ID)
+}
@@ -0,0 +1,34 @@
+package application
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"spaudit/database"
+	"spaudit/logging"
+)
+
+// newTestDatabase opens an empty, migrated database that is closed when the test ends.
+func newTestDatabase(t *testing.T) *database.Database {
+	t.Helper()
+	logger := logging.NewLogger(&logging.Config{Level: "error", Format: "text", Output: "stderr"})
+	testDB, err := database.New(database.Config{
+		Path:              filepath.Join(t.TempDir(), "test.db"),
+		MaxOpenConns:      2,
+		MaxIdleConns:      1,
+		BusyTimeoutMs:     1000,
+		EnableForeignKeys: true,
+	}, logger)
+	require.NoError(t, err)
+	t.Cleanup(func() { testDB.Close() })
+	return testDB
+}
+
+// mustExec runs a statement against the test database, failing the test when it errors.
+func mustExec(t *testing.T, testDB *database.Database, query string, args ...any) {
+	t.Helper()
+	_, err := testDB.WriteDB().Exec(query, args...)
+	require.NoError(t, err, query)
+}
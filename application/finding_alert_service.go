@@ -0,0 +1,538 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// findingAlertDeliveryTick is how often queued alerts are checked against the quiet hours.
+const findingAlertDeliveryTick = time.Minute
+
+// ExceptionReopened is the finding category of what an exception accepted, once the exception is
+// revoked on review.
+const ExceptionReopened = "exception_reopened"
+
+// ScheduledDriftExceeded is the finding category of a scheduled audit run whose drift exceeded the
+// thresholds of its schedule.
+const ScheduledDriftExceeded = "scheduled_drift"
+
+// FindingAlertNotifier announces finding alerts to users.
+type FindingAlertNotifier interface {
+	NotifyFindingAlert(alert *audit.FindingAlert)
+}
+
+// FindingAlertService raises an alert for each finding of a completed audit run, skipping findings
+// already seen in the site's previous completed run of the same scope so repeats do not cause alert
+// fatigue. Alerts raised during quiet hours are queued and announced once the quiet hours end.
+type FindingAlertService struct {
+	db              *database.Database
+	serviceFactory  AuditRunScopedServiceFactory
+	baselineService *BaselineService
+	severities      *FindingSeverities
+	policyPacks     *PolicyPackService
+	quietHours      audit.QuietHours
+	notifiers       []FindingAlertNotifier
+	now             func() time.Time
+	logger          *logging.Logger
+}
+
+// NewFindingAlertService creates a new finding alert service rating findings with the severity mapping.
+func NewFindingAlertService(db *database.Database, serviceFactory AuditRunScopedServiceFactory, baselineService *BaselineService, severities *FindingSeverities, quietHours audit.QuietHours) *FindingAlertService {
+	return &FindingAlertService{
+		db:              db,
+		serviceFactory:  serviceFactory,
+		baselineService: baselineService,
+		severities:      severities,
+		quietHours:      quietHours,
+		now:             func() time.Time { return time.Now().UTC() },
+		logger:          logging.Default().WithComponent("finding_alert_service"),
+	}
+}
+
+// AddNotifier adds a notifier alerts are announced through. Without one alerts are only recorded.
+func (s *FindingAlertService) AddNotifier(notifier FindingAlertNotifier) {
+	s.notifiers = append(s.notifiers, notifier)
+}
+
+// SetPolicyPacks rates findings under the policy pack each site is checked with, and leaves the
+// categories it mutes unraised. Without it every site is rated with the global defaults.
+func (s *FindingAlertService) SetPolicyPacks(policyPacks *PolicyPackService) {
+	s.policyPacks = policyPacks
+}
+
+// runFinding is a finding of an audit run that may be raised as an alert.
+type runFinding struct {
+	category string
+	subject  string
+	severity audit.Severity
+	message  string
+}
+
+// RaiseRunAlerts raises an alert for each finding of an audit run that was not also seen in the site's
+// previous completed run of the same scope, most severe first. The findings of a folder-scoped run are
+// the access added in its folder. Findings the site's policy pack mutes raise no alert. Alerts are
+// announced at once, or queued during quiet hours.
+// Raising the alerts of a run again raises none.
+func (s *FindingAlertService) RaiseRunAlerts(ctx context.Context, auditRunID int64) ([]*audit.FindingAlert, error) {
+	auditRun, err := s.db.ReadQueries().GetAuditRun(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("audit run %d not found: %w", auditRunID, err)
+	}
+	site, err := s.db.ReadQueries().GetSiteByID(ctx, auditRun.SiteID)
+	if err != nil {
+		return nil, fmt.Errorf("site %d not found: %w", auditRun.SiteID, err)
+	}
+
+	var previousRunID int64
+	previous, err := s.db.ReadQueries().GetPreviousCompletedAuditRunForScope(ctx, auditRunID)
+	if err == nil {
+		previousRunID = previous.AuditRunID
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get previous audit run: %w", err)
+	}
+
+	severities := s.runSeverities(ctx, site.SiteID, auditRunID)
+	var findings []runFinding
+	if auditRun.ScopePath.Valid {
+		findings, err = s.folderChangeFindings(ctx, site.SiteID, auditRunID, previousRunID, severities)
+	} else {
+		findings, err = s.runFindings(ctx, site.SiteID, auditRunID, severities)
+	}
+	if err != nil {
+		return nil, err
+	}
+	raised := findings[:0]
+	for _, finding := range findings {
+		if severities.Raises(finding.category) {
+			raised = append(raised, finding)
+		}
+	}
+	muted := len(findings) - len(raised)
+	findings = raised
+	sightings, err := s.db.ReadQueries().GetFindingAlertSightings(ctx, site.SiteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finding sightings: %w", err)
+	}
+	lastSeen := make(map[string]int64, len(sightings))
+	for _, sighting := range sightings {
+		lastSeen[sighting.Fingerprint] = sighting.LastAuditRunID
+	}
+
+	now := s.now()
+	quiet := s.quietHours.Active(now.Local())
+	var alerts []*audit.FindingAlert
+	suppressed := 0
+	err = s.db.WithTx(func(queries *db.Queries) error {
+		for _, finding := range findings {
+			fingerprint := audit.FindingFingerprint(finding.category, finding.subject)
+			seenIn := lastSeen[fingerprint]
+			if seenIn == auditRunID || (previousRunID != 0 && seenIn == previousRunID) {
+				suppressed++
+			} else {
+				alert := &audit.FindingAlert{
+					SiteID:      site.SiteID,
+					AuditRunID:  auditRunID,
+					Fingerprint: fingerprint,
+					Category:    finding.category,
+					Severity:    finding.severity,
+					Message:     fmt.Sprintf("%s: %s", site.SiteUrl, finding.message),
+					CreatedAt:   now,
+				}
+				if !quiet {
+					alert.DeliveredAt = &now
+				}
+				id, err := queries.CreateFindingAlert(ctx, db.CreateFindingAlertParams{
+					SiteID:      alert.SiteID,
+					AuditRunID:  alert.AuditRunID,
+					Fingerprint: alert.Fingerprint,
+					Category:    alert.Category,
+					Severity:    string(alert.Severity),
+					Message:     alert.Message,
+					CreatedAt:   alert.CreatedAt,
+					DeliveredAt: sql.NullTime{Time: now, Valid: !quiet},
+				})
+				if err != nil {
+					return fmt.Errorf("failed to record finding alert: %w", err)
+				}
+				alert.ID = id
+				alerts = append(alerts, alert)
+			}
+
+			if err := queries.UpsertFindingAlertSighting(ctx, db.UpsertFindingAlertSightingParams{
+				SiteID:      site.SiteID,
+				Fingerprint: fingerprint,
+				AuditRunID:  auditRunID,
+			}); err != nil {
+				return fmt.Errorf("failed to record finding sighting: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Raised finding alerts", "site_id", site.SiteID, "audit_run_id", auditRunID,
+		"raised", len(alerts), "suppressed", suppressed, "muted", muted, "queued", quiet && len(alerts) > 0)
+	if !quiet {
+		s.notify(alerts)
+	}
+	return alerts, nil
+}
+
+// runFindings collects the risky defaults, page library exposure, items deleted while shared and
+// baseline drift of an audit run, rated and ordered most severe first. A run whose links or page libraries exceed the row cap, or a
+// site without a baseline, contributes no findings of that kind.
+func (s *FindingAlertService) runFindings(ctx context.Context, siteID, auditRunID int64, severities *FindingSeverities) ([]runFinding, error) {
+	var findings []runFinding
+
+	scopedServices, err := s.serviceFactory.CreateForAuditRun(ctx, siteID, strconv.FormatInt(auditRunID, 10))
+	if err != nil {
+		return nil, err
+	}
+	riskyDefaults, err := scopedServices.SiteContentService.GetRiskyDefaults(ctx, siteID)
+	if err != nil {
+		s.logger.Warn("Skipping risky default alerts", "site_id", siteID, "audit_run_id", auditRunID, "error", err)
+	} else {
+		severities.RateRiskyDefaults(riskyDefaults)
+		for _, finding := range riskyDefaults.Findings {
+			findings = append(findings, runFinding{
+				category: finding.Kind,
+				subject:  finding.WebID,
+				severity: finding.Severity,
+				message:  describeRiskyDefault(finding),
+			})
+		}
+	}
+
+	pageLibraries, err := scopedServices.SiteContentService.GetPageLibraryExposure(ctx, siteID)
+	if err != nil {
+		s.logger.Warn("Skipping page library alerts", "site_id", siteID, "audit_run_id", auditRunID, "error", err)
+	} else {
+		severities.RatePageLibraryExposure(pageLibraries)
+		for _, finding := range pageLibraries.Findings() {
+			findings = append(findings, runFinding{
+				category: finding.Kind,
+				subject:  finding.ListID,
+				severity: finding.Severity,
+				message:  describePageLibraryFinding(finding),
+			})
+		}
+	}
+
+	remnants, err := scopedServices.SiteContentService.GetRecycleBinRemnants(ctx, siteID)
+	if err != nil {
+		s.logger.Warn("Skipping recycle bin alerts", "site_id", siteID, "audit_run_id", auditRunID, "error", err)
+	} else {
+		severities.RateRecycleBinRemnants(remnants)
+		for _, remnant := range remnants.Remnants {
+			findings = append(findings, runFinding{
+				category: RecycleBinSharingRemnant,
+				subject:  remnant.ItemGUID,
+				severity: remnant.Severity,
+				message:  describeRecycleBinRemnant(remnant),
+			})
+		}
+	}
+
+	drift, err := s.baselineService.ComputeDrift(ctx, siteID, auditRunID)
+	if err != nil && !errors.Is(err, ErrNoBaseline) {
+		return nil, fmt.Errorf("failed to compute drift: %w", err)
+	}
+	if err == nil {
+		severities.RateDrift(drift)
+		for _, finding := range drift.Findings {
+			findings = append(findings, runFinding{
+				category: finding.Kind,
+				subject:  finding.List.ID,
+				severity: finding.Severity,
+				message:  describeDrift(finding),
+			})
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].severity.Rank() > findings[j].severity.Rank()
+	})
+	return findings, nil
+}
+
+// describeRiskyDefault summarizes a risky default for an alert.
+func describeRiskyDefault(finding *RiskyDefault) string {
+	var description string
+	switch finding.Kind {
+	case RiskyDefaultEditLink:
+		description = "the share dialog offers edit links by default"
+	case RiskyDefaultAnyoneLink:
+		description = "the share dialog offers anyone links by default"
+	case RiskyDefaultMembersCanShare:
+		description = fmt.Sprintf("members of %s can share what they can reach", finding.WebURL)
+	case RiskyDefaultAnyoneLinksDiscouraged:
+		description = "anyone links are allowed although the tenant restricts them"
+	default:
+		description = finding.Kind
+	}
+	return fmt.Sprintf("%s (%d active links)", description, finding.ObjectCount)
+}
+
+// describePageLibraryFinding summarizes a page library finding for an alert.
+func describePageLibraryFinding(finding *PageLibraryFinding) string {
+	switch finding.Kind {
+	case PageLibraryAnonymousAccess:
+		return fmt.Sprintf("%s has %d anyone links", finding.ListTitle, finding.ObjectCount)
+	case PageLibraryExternalContributor:
+		return fmt.Sprintf("guests can edit %s (%d grants)", finding.ListTitle, finding.ObjectCount)
+	default:
+		return finding.Kind
+	}
+}
+
+// describeRecycleBinRemnant summarizes an item deleted while still shared for an alert.
+func describeRecycleBinRemnant(remnant *RecycleBinRemnant) string {
+	return fmt.Sprintf("%q was deleted with %d active sharing links instead of being unshared", remnant.ItemName, len(remnant.Links))
+}
+
+// describeDrift summarizes a drift finding for an alert.
+func describeDrift(finding *ListDrift) string {
+	switch finding.Kind {
+	case DriftListAdded:
+		return fmt.Sprintf("list %q was added since the baseline", finding.List.Title)
+	case DriftListRemoved:
+		return fmt.Sprintf("list %q was removed since the baseline", finding.List.Title)
+	default:
+		return fmt.Sprintf("permissions of list %q changed since the baseline (%d changes)", finding.List.Title, len(finding.Changes))
+	}
+}
+
+// ReopenExceptionFinding raises an alert for what a revoked exception had accepted, against the site's
+// latest audit run, so the finding the exception suppressed is open again rather than waiting for a
+// change to raise it. The alert is announced at once, or queued during quiet hours.
+func (s *FindingAlertService) ReopenExceptionFinding(ctx context.Context, exception *audit.PermissionException) (*audit.FindingAlert, error) {
+	scopedServices, err := s.serviceFactory.CreateForAuditRun(ctx, exception.SiteID, audit.RunAliasLatest)
+	if err != nil {
+		return nil, err
+	}
+	alert, err := s.raiseAlert(ctx, exception.SiteID, scopedServices.AuditRunID, ExceptionReopened,
+		exceptionSubject(exception), describeReopenedException(exception))
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Reopened finding of revoked exception", "site_id", alert.SiteID, "audit_run_id", alert.AuditRunID,
+		"kind", exception.Kind, "object_key", exception.ObjectKey, "queued", alert.DeliveredAt == nil)
+	return alert, nil
+}
+
+// RaiseDriftAlert raises an alert for a scheduled audit run whose drift against the run it was
+// compared with exceeded the schedule's thresholds. The alert is announced at once, or queued during
+// quiet hours.
+func (s *FindingAlertService) RaiseDriftAlert(ctx context.Context, siteID, auditRunID, baseAuditRunID int64, breaches []audit.DriftBreach) (*audit.FindingAlert, error) {
+	alert, err := s.raiseAlert(ctx, siteID, auditRunID, ScheduledDriftExceeded,
+		fmt.Sprintf("run:%d", auditRunID), describeDriftBreaches(baseAuditRunID, breaches))
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Info("Raised scheduled drift alert", "site_id", siteID, "audit_run_id", auditRunID,
+		"base_audit_run_id", baseAuditRunID, "breaches", len(breaches), "queued", alert.DeliveredAt == nil)
+	return alert, nil
+}
+
+// raiseAlert records an alert outside a run's findings and announces it, or queues it during quiet hours.
+func (s *FindingAlertService) raiseAlert(ctx context.Context, siteID, auditRunID int64, category, subject, message string) (*audit.FindingAlert, error) {
+	site, err := s.db.ReadQueries().GetSiteByID(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("site %d not found: %w", siteID, err)
+	}
+
+	now := s.now()
+	quiet := s.quietHours.Active(now.Local())
+	alert := &audit.FindingAlert{
+		SiteID:      site.SiteID,
+		AuditRunID:  auditRunID,
+		Fingerprint: audit.FindingFingerprint(category, subject),
+		Category:    category,
+		Severity:    s.runSeverities(ctx, siteID, auditRunID).Severity(category),
+		Message:     fmt.Sprintf("%s: %s", site.SiteUrl, message),
+		CreatedAt:   now,
+	}
+	if !quiet {
+		alert.DeliveredAt = &now
+	}
+	alert.ID, err = s.db.Queries().CreateFindingAlert(ctx, db.CreateFindingAlertParams{
+		SiteID:      alert.SiteID,
+		AuditRunID:  alert.AuditRunID,
+		Fingerprint: alert.Fingerprint,
+		Category:    alert.Category,
+		Severity:    string(alert.Severity),
+		Message:     alert.Message,
+		CreatedAt:   alert.CreatedAt,
+		DeliveredAt: sql.NullTime{Time: now, Valid: !quiet},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record finding alert: %w", err)
+	}
+
+	if !quiet {
+		s.notify([]*audit.FindingAlert{alert})
+	}
+	return alert, nil
+}
+
+// runSeverities returns the severity mapping of the policy pack an audit run's site is checked with,
+// falling back to the global defaults when the pack cannot be resolved.
+func (s *FindingAlertService) runSeverities(ctx context.Context, siteID, auditRunID int64) *FindingSeverities {
+	if s.policyPacks == nil {
+		return s.severities
+	}
+	severities, err := s.policyPacks.SeveritiesForRun(ctx, siteID, auditRunID)
+	if err != nil {
+		s.logger.Warn("Rating findings without a policy pack", "site_id", siteID, "audit_run_id", auditRunID, "error", err)
+		return s.severities
+	}
+	return severities
+}
+
+// exceptionSubject identifies what an exception accepted: the list and object, and for assignments
+// the principal and role.
+func exceptionSubject(exception *audit.PermissionException) string {
+	if exception.Kind != audit.ExceptionAssignment {
+		return fmt.Sprintf("%s:%s", exception.ListID, exception.ObjectKey)
+	}
+	return fmt.Sprintf("%s:%s:%d:%d", exception.ListID, exception.ObjectKey, exception.PrincipalID, exception.RoleDefID)
+}
+
+// describeReopenedException summarizes what a revoked exception had accepted for an alert.
+func describeReopenedException(exception *audit.PermissionException) string {
+	var accepted string
+	switch exception.Kind {
+	case audit.ExceptionSharingLink:
+		accepted = fmt.Sprintf("sharing link %s", exception.ObjectKey)
+	case audit.ExceptionUniqueItem:
+		accepted = fmt.Sprintf("the unique permissions of item %s", exception.ObjectKey)
+	default:
+		accepted = fmt.Sprintf("role %d of principal %d on %s", exception.RoleDefID, exception.PrincipalID, exception.ObjectKey)
+	}
+	return fmt.Sprintf("the exception accepting %s in list %s was revoked on review", accepted, exception.ListID)
+}
+
+// describeDriftBreaches summarizes the thresholds a scheduled run exceeded for an alert.
+func describeDriftBreaches(baseAuditRunID int64, breaches []audit.DriftBreach) string {
+	parts := make([]string, len(breaches))
+	for i, breach := range breaches {
+		parts[i] = fmt.Sprintf("%d %s (threshold %d)", breach.Count, strings.ReplaceAll(breach.Metric, "_", " "), breach.Threshold)
+	}
+	return fmt.Sprintf("scheduled audit drifted from run %d: %s", baseAuditRunID, strings.Join(parts, ", "))
+}
+
+// DeliverQueued announces the alerts queued during quiet hours, oldest first, once the quiet hours
+// have ended, and returns how many were announced.
+func (s *FindingAlertService) DeliverQueued(ctx context.Context) (int, error) {
+	now := s.now()
+	if s.quietHours.Active(now.Local()) {
+		return 0, nil
+	}
+	rows, err := s.db.ReadQueries().GetQueuedFindingAlerts(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queued finding alerts: %w", err)
+	}
+
+	alerts := make([]*audit.FindingAlert, 0, len(rows))
+	for _, row := range rows {
+		if err := s.db.Queries().MarkFindingAlertDelivered(ctx, db.MarkFindingAlertDeliveredParams{
+			DeliveredAt: sql.NullTime{Time: now, Valid: true},
+			AlertID:     row.AlertID,
+		}); err != nil {
+			return len(alerts), fmt.Errorf("failed to mark finding alert %d delivered: %w", row.AlertID, err)
+		}
+		alert := newFindingAlert(row)
+		alert.DeliveredAt = &now
+		alerts = append(alerts, alert)
+	}
+	s.notify(alerts)
+	return len(alerts), nil
+}
+
+// RunDeliveryScheduler announces queued alerts whenever quiet hours end, until ctx is cancelled.
+// Without quiet hours it announces any alerts left queued and returns.
+func (s *FindingAlertService) RunDeliveryScheduler(ctx context.Context) {
+	s.deliverQueued(ctx)
+	if s.quietHours == (audit.QuietHours{}) {
+		return
+	}
+
+	ticker := time.NewTicker(findingAlertDeliveryTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.deliverQueued(ctx)
+		}
+	}
+}
+
+// deliverQueued announces queued alerts, logging failures for the next tick to retry.
+func (s *FindingAlertService) deliverQueued(ctx context.Context) {
+	delivered, err := s.DeliverQueued(ctx)
+	if err != nil {
+		s.logger.Error("Failed to deliver queued finding alerts", "error", err)
+	}
+	if delivered > 0 {
+		s.logger.Info("Delivered finding alerts queued during quiet hours", "count", delivered)
+	}
+}
+
+// GetSiteAlerts returns the most recent alerts raised for a site, newest first.
+func (s *FindingAlertService) GetSiteAlerts(ctx context.Context, siteID, limit int64) ([]*audit.FindingAlert, error) {
+	if _, err := s.db.ReadQueries().GetSiteByID(ctx, siteID); err != nil {
+		return nil, fmt.Errorf("site %d not found: %w", siteID, err)
+	}
+	rows, err := s.db.ReadQueries().GetFindingAlertsForSite(ctx, db.GetFindingAlertsForSiteParams{SiteID: siteID, LimitCount: limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finding alerts: %w", err)
+	}
+	alerts := make([]*audit.FindingAlert, len(rows))
+	for i, row := range rows {
+		alerts[i] = newFindingAlert(row)
+	}
+	return alerts, nil
+}
+
+// notify announces alerts through every notifier.
+func (s *FindingAlertService) notify(alerts []*audit.FindingAlert) {
+	for _, notifier := range s.notifiers {
+		for _, alert := range alerts {
+			notifier.NotifyFindingAlert(alert)
+		}
+	}
+}
+
+// newFindingAlert converts a stored finding alert row to the domain type.
+func newFindingAlert(row db.FindingAlert) *audit.FindingAlert {
+	alert := &audit.FindingAlert{
+		ID:          row.AlertID,
+		SiteID:      row.SiteID,
+		AuditRunID:  row.AuditRunID,
+		Fingerprint: row.Fingerprint,
+		Category:    row.Category,
+		Severity:    audit.Severity(row.Severity),
+		Message:     row.Message,
+		CreatedAt:   row.CreatedAt,
+	}
+	if row.DeliveredAt.Valid {
+		deliveredAt := row.DeliveredAt.Time
+		alert.DeliveredAt = &deliveredAt
+	}
+	return alert
+}
@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+)
+
+// recordingAlertNotifier records the alerts announced.
+type recordingAlertNotifier struct {
+	alerts []*audit.FindingAlert
+}
+
+func (n *recordingAlertNotifier) NotifyFindingAlert(alert *audit.FindingAlert) {
+	n.alerts = append(n.alerts, alert)
+}
+
+func newFindingAlertTestService(t *testing.T, quietHours audit.QuietHours) (*FindingAlertService, *recordingAlertNotifier) {
+	t.Helper()
+	baselineService := newBaselineTestService(t)
+	_, err := baselineService.ApproveBaseline(context.Background(), 1, 1, "alice", "")
+	require.NoError(t, err)
+
+	service := NewFindingAlertService(baselineService.db, baselineService.serviceFactory, baselineService, nil, quietHours)
+	notifier := &recordingAlertNotifier{}
+	service.AddNotifier(notifier)
+	return service, notifier
+}
+
+func TestFindingAlertService_RaiseRunAlerts(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("findings of a run are announced once, most severe first", func(t *testing.T) {
+		service, notifier := newFindingAlertTestService(t, audit.QuietHours{})
+
+		alerts, err := service.RaiseRunAlerts(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, alerts, 3)
+		assert.Equal(t, alerts, notifier.alerts)
+		assert.Equal(t, "list_removed:archive", alerts[0].Fingerprint)
+		assert.Equal(t, audit.SeverityMedium, alerts[0].Severity)
+		assert.Equal(t, `https://contoso.sharepoint.com/sites/a: list "Archive" was removed since the baseline`, alerts[0].Message)
+		assert.Equal(t, "list_added:projects", alerts[2].Fingerprint)
+		assert.False(t, alerts[0].IsQueued())
+
+		again, err := service.RaiseRunAlerts(ctx, 2)
+		require.NoError(t, err)
+		assert.Empty(t, again, "a run raises its alerts once")
+
+		stored, err := service.GetSiteAlerts(ctx, 1, 10)
+		require.NoError(t, err)
+		assert.Len(t, stored, 3)
+	})
+
+	t.Run("findings repeated from the previous completed run are suppressed", func(t *testing.T) {
+		service, notifier := newFindingAlertTestService(t, audit.QuietHours{})
+		_, err := service.RaiseRunAlerts(ctx, 2)
+		require.NoError(t, err)
+
+		// Run 3 keeps Projects and the Documents grant, so only the removal of Archive is gone
+		for _, stmt := range []string{
+			`UPDATE audit_runs SET completed_at = '2025-01-03 10:00:00' WHERE audit_run_id = 3`,
+			`INSERT INTO webs (site_id, web_id, audit_run_id, title) VALUES (1, 'web-1', 3, 'A')`,
+			`INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title, url, has_unique) VALUES
+				(1, 'docs', 3, 'web-1', 'Documents', '/sites/a/Shared Documents', TRUE),
+				(1, 'archive', 3, 'web-1', 'Archive', '/sites/a/Archive', FALSE),
+				(1, 'projects', 3, 'web-1', 'Projects', '/sites/a/Projects', FALSE)`,
+			`INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES (1, 7, 3, 'Finance', 'Finance', 8)`,
+			`INSERT INTO role_definitions (site_id, role_def_id, audit_run_id, name) VALUES (1, 1073741827, 3, 'Contribute')`,
+			`INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'list', 'docs', 7, 1073741827, 3)`,
+		} {
+			mustExec(t, service.db, stmt)
+		}
+		notifier.alerts = nil
+
+		alerts, err := service.RaiseRunAlerts(ctx, 3)
+		require.NoError(t, err)
+		assert.Empty(t, alerts)
+		assert.Empty(t, notifier.alerts)
+	})
+
+	t.Run("categories the site's policy pack mutes raise no alerts", func(t *testing.T) {
+		service, _ := newFindingAlertTestService(t, audit.QuietHours{})
+		service.SetPolicyPacks(NewPolicyPackService(service.db, nil))
+		_, err := service.db.WriteDB().Exec(`UPDATE webs SET template = 'SITEPAGEPUBLISHING#0' WHERE audit_run_id = 2`)
+		require.NoError(t, err)
+
+		alerts, err := service.RaiseRunAlerts(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, alerts, 2)
+		for _, alert := range alerts {
+			assert.NotEqual(t, DriftListAdded, alert.Category, "communication sites add lists as they publish")
+		}
+	})
+
+	t.Run("folder-scoped runs alert on access added in their folder", func(t *testing.T) {
+		service, _ := newFindingAlertTestService(t, audit.QuietHours{})
+
+		// Runs 4 and 5 audit only Documents, and run 5 grants Finance contribute on it
+		for _, stmt := range []string{
+			`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-4', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-5', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`,
+			`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, scope_path) VALUES
+				(4, 'job-4', 1, '2025-01-04 09:00:00', '2025-01-04 09:05:00', 'investigation', '/sites/a/Shared Documents'),
+				(5, 'job-5', 1, '2025-01-05 09:00:00', '2025-01-05 09:05:00', 'investigation', '/sites/a/Shared Documents')`,
+			`INSERT INTO webs (site_id, web_id, audit_run_id, title) VALUES (1, 'web-1', 4, 'A'), (1, 'web-1', 5, 'A')`,
+			`INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title, url, has_unique) VALUES
+				(1, 'docs', 4, 'web-1', 'Documents', '/sites/a/Shared Documents', TRUE),
+				(1, 'docs', 5, 'web-1', 'Documents', '/sites/a/Shared Documents', TRUE)`,
+			`INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES (1, 7, 5, 'Finance', 'Finance', 8)`,
+			`INSERT INTO role_definitions (site_id, role_def_id, audit_run_id, name) VALUES (1, 1073741827, 5, 'Contribute')`,
+			`INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'list', 'docs', 7, 1073741827, 5)`,
+		} {
+			mustExec(t, service.db, stmt)
+		}
+
+		first, err := service.RaiseRunAlerts(ctx, 4)
+		require.NoError(t, err)
+		assert.Empty(t, first, "the first run of a folder has nothing to compare with, and site-wide findings are left to full runs")
+
+		alerts, err := service.RaiseRunAlerts(ctx, 5)
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		assert.Equal(t, "folder_access_granted:docs:7:1073741827", alerts[0].Fingerprint)
+		assert.Equal(t, audit.SeverityMedium, alerts[0].Severity)
+		assert.Equal(t, `https://contoso.sharepoint.com/sites/a: Finance was granted Contribute on list "Documents"`, alerts[0].Message)
+	})
+
+	t.Run("alerts raised during quiet hours wait until they end", func(t *testing.T) {
+		now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+		local := now.Local()
+		start := time.Duration(local.Hour()) * time.Hour
+		service, notifier := newFindingAlertTestService(t, audit.QuietHours{Start: start, End: (start + time.Hour) % (24 * time.Hour)})
+		service.now = func() time.Time { return now }
+
+		alerts, err := service.RaiseRunAlerts(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, alerts, 3)
+		assert.True(t, alerts[0].IsQueued())
+		assert.Empty(t, notifier.alerts)
+
+		delivered, err := service.DeliverQueued(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered, "still quiet")
+
+		now = now.Add(2 * time.Hour)
+		delivered, err = service.DeliverQueued(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, delivered)
+		require.Len(t, notifier.alerts, 3)
+		assert.Equal(t, now, *notifier.alerts[0].DeliveredAt)
+
+		delivered, err = service.DeliverQueued(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, delivered, "alerts are delivered once")
+	})
+}
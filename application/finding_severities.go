@@ -0,0 +1,146 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+
+	"spaudit/domain/audit"
+)
+
+// defaultFindingSeverities rates every finding category unless an administrator remaps it.
+var defaultFindingSeverities = map[string]audit.Severity{
+	RiskyDefaultEditLink:               audit.SeverityMedium,
+	RiskyDefaultAnyoneLink:             audit.SeverityHigh,
+	RiskyDefaultMembersCanShare:        audit.SeverityLow,
+	RiskyDefaultAnyoneLinksDiscouraged: audit.SeverityHigh,
+	DriftListAdded:                     audit.SeverityLow,
+	DriftListRemoved:                   audit.SeverityMedium,
+	DriftPermissionsChanged:            audit.SeverityMedium,
+	PageLibraryAnonymousAccess:         audit.SeverityHigh,
+	PageLibraryExternalContributor:     audit.SeverityHigh,
+	RecycleBinSharingRemnant:           audit.SeverityMedium,
+	FolderAccessGranted:                audit.SeverityMedium,
+	FolderInheritanceBroken:            audit.SeverityLow,
+	FolderLinkCreated:                  audit.SeverityMedium,
+	FolderLinkMemberAdded:              audit.SeverityLow,
+	ExceptionReopened:                  audit.SeverityMedium,
+	ScheduledDriftExceeded:             audit.SeverityHigh,
+}
+
+// FindingSeverity is the severity a finding category is rated with.
+type FindingSeverity struct {
+	Category string
+	Severity audit.Severity
+	Default  audit.Severity // The policy pack's severity, or the global default
+}
+
+// IsCustom returns true if an administrator remapped the category.
+func (f FindingSeverity) IsCustom() bool {
+	return f.Severity != f.Default
+}
+
+// FindingSeverities rates findings by category, with administrator overrides taking precedence over
+// the defaults of the policy pack a site is checked with, and those over the global defaults. A nil
+// FindingSeverities rates with the global defaults.
+type FindingSeverities struct {
+	overrides map[string]audit.Severity
+	pack      *PolicyPack
+}
+
+// NewFindingSeverities creates the severity mapping from overrides keyed by finding category,
+// failing with audit.ErrInvalidSeverityMapping for categories no finding has.
+func NewFindingSeverities(overrides map[string]audit.Severity) (*FindingSeverities, error) {
+	for category := range overrides {
+		if _, ok := defaultFindingSeverities[category]; !ok {
+			return nil, fmt.Errorf("%w: unknown finding category %q", audit.ErrInvalidSeverityMapping, category)
+		}
+	}
+	return &FindingSeverities{overrides: overrides}, nil
+}
+
+// ForPack returns the severity mapping for the sites checked with a policy pack, keeping the
+// administrator overrides.
+func (s *FindingSeverities) ForPack(pack *PolicyPack) *FindingSeverities {
+	forPack := &FindingSeverities{pack: pack}
+	if s != nil {
+		forPack.overrides = s.overrides
+	}
+	return forPack
+}
+
+// Pack returns the policy pack the mapping rates with, nil for the global defaults.
+func (s *FindingSeverities) Pack() *PolicyPack {
+	if s == nil {
+		return nil
+	}
+	return s.pack
+}
+
+// Raises returns true if findings of the category raise alerts: the policy pack does not mute it.
+func (s *FindingSeverities) Raises(category string) bool {
+	return s == nil || s.pack == nil || !s.pack.Muted[category]
+}
+
+// Severity returns the severity of a finding category; unknown categories rate as info.
+func (s *FindingSeverities) Severity(category string) audit.Severity {
+	if s != nil {
+		if severity, ok := s.overrides[category]; ok {
+			return severity
+		}
+	}
+	return s.defaultSeverity(category)
+}
+
+// defaultSeverity returns the severity of a finding category before administrator overrides: the
+// policy pack's when it rates the category, the global default otherwise
+func (s *FindingSeverities) defaultSeverity(category string) audit.Severity {
+	if s != nil && s.pack != nil {
+		if severity, ok := s.pack.Severities[category]; ok {
+			return severity
+		}
+	}
+	if severity, ok := defaultFindingSeverities[category]; ok {
+		return severity
+	}
+	return audit.SeverityInfo
+}
+
+// Mapping returns the severity of every finding category, ordered by category.
+func (s *FindingSeverities) Mapping() []FindingSeverity {
+	mapping := make([]FindingSeverity, 0, len(defaultFindingSeverities))
+	for category := range defaultFindingSeverities {
+		mapping = append(mapping, FindingSeverity{Category: category, Severity: s.Severity(category), Default: s.defaultSeverity(category)})
+	}
+	sort.Slice(mapping, func(i, j int) bool { return mapping[i].Category < mapping[j].Category })
+	return mapping
+}
+
+// RateRiskyDefaults sets the severity of each risky default found.
+func (s *FindingSeverities) RateRiskyDefaults(data *RiskyDefaultsData) {
+	for _, finding := range data.Findings {
+		finding.Severity = s.Severity(finding.Kind)
+	}
+}
+
+// RateDrift sets the severity of each drift finding.
+func (s *FindingSeverities) RateDrift(report *DriftReport) {
+	for _, finding := range report.Findings {
+		finding.Severity = s.Severity(finding.Kind)
+	}
+}
+
+// RatePageLibraryExposure sets the severity of each page library finding.
+func (s *FindingSeverities) RatePageLibraryExposure(data *PageLibraryExposureData) {
+	for _, library := range data.Libraries {
+		for _, finding := range library.Findings {
+			finding.Severity = s.Severity(finding.Kind)
+		}
+	}
+}
+
+// RateRecycleBinRemnants sets the severity of each item deleted while still shared.
+func (s *FindingSeverities) RateRecycleBinRemnants(data *RecycleBinRemnantsData) {
+	for _, remnant := range data.Remnants {
+		remnant.Severity = s.Severity(RecycleBinSharingRemnant)
+	}
+}
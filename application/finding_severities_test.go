@@ -0,0 +1,76 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+)
+
+func TestFindingSeverities(t *testing.T) {
+	t.Run("overrides take precedence over the defaults", func(t *testing.T) {
+		severities, err := NewFindingSeverities(map[string]audit.Severity{RiskyDefaultEditLink: audit.SeverityCritical})
+		require.NoError(t, err)
+
+		assert.Equal(t, audit.SeverityCritical, severities.Severity(RiskyDefaultEditLink))
+		assert.Equal(t, audit.SeverityHigh, severities.Severity(RiskyDefaultAnyoneLink))
+		assert.Equal(t, audit.SeverityInfo, severities.Severity("unknown"))
+
+		data := &RiskyDefaultsData{Findings: []*RiskyDefault{{Kind: RiskyDefaultEditLink}, {Kind: RiskyDefaultMembersCanShare}}}
+		severities.RateRiskyDefaults(data)
+		assert.Equal(t, audit.SeverityCritical, data.Findings[0].Severity)
+		assert.Equal(t, audit.SeverityLow, data.Findings[1].Severity)
+	})
+
+	t.Run("a nil mapping rates with the defaults", func(t *testing.T) {
+		var severities *FindingSeverities
+		assert.Equal(t, audit.SeverityMedium, severities.Severity(RiskyDefaultEditLink))
+		for _, finding := range severities.Mapping() {
+			assert.False(t, finding.IsCustom(), finding.Category)
+		}
+	})
+
+	t.Run("mapping lists every category with custom ones flagged", func(t *testing.T) {
+		severities, err := NewFindingSeverities(map[string]audit.Severity{DriftListAdded: audit.SeverityInfo})
+		require.NoError(t, err)
+
+		mapping := severities.Mapping()
+		require.Len(t, mapping, len(defaultFindingSeverities))
+		assert.Equal(t, FindingSeverity{Category: RiskyDefaultAnyoneLinksDiscouraged, Severity: audit.SeverityHigh, Default: audit.SeverityHigh}, mapping[0])
+		for _, finding := range mapping {
+			assert.Equal(t, finding.Category == DriftListAdded, finding.IsCustom(), finding.Category)
+		}
+	})
+
+	t.Run("policy packs rate between the overrides and the defaults", func(t *testing.T) {
+		severities, err := NewFindingSeverities(map[string]audit.Severity{DriftListAdded: audit.SeverityHigh})
+		require.NoError(t, err)
+		communication, ok := GetPolicyPack("communication")
+		require.True(t, ok)
+
+		forPack := severities.ForPack(communication)
+		assert.Equal(t, audit.SeverityHigh, forPack.Severity(DriftListAdded), "overrides win over the pack")
+		assert.Equal(t, audit.SeverityInfo, forPack.Severity(RiskyDefaultMembersCanShare))
+		assert.Equal(t, audit.SeverityMedium, forPack.Severity(RiskyDefaultEditLink))
+		assert.False(t, forPack.Raises(RiskyDefaultMembersCanShare))
+		assert.True(t, forPack.Raises(RiskyDefaultEditLink))
+		assert.True(t, severities.Raises(RiskyDefaultMembersCanShare), "without a pack every category raises alerts")
+
+		for _, finding := range forPack.Mapping() {
+			switch finding.Category {
+			case RiskyDefaultMembersCanShare:
+				assert.Equal(t, audit.SeverityInfo, finding.Default)
+				assert.False(t, finding.IsCustom())
+			case DriftListAdded:
+				assert.True(t, finding.IsCustom())
+			}
+		}
+	})
+
+	t.Run("unknown categories are rejected", func(t *testing.T) {
+		_, err := NewFindingSeverities(map[string]audit.Severity{"org_wide_links": audit.SeverityHigh})
+		assert.ErrorIs(t, err, audit.ErrInvalidSeverityMapping)
+	})
+}
@@ -0,0 +1,241 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	"spaudit/logging"
+)
+
+// GuestGrant is an explicit permission a guest account holds, directly or through a SharePoint group.
+type GuestGrant struct {
+	ObjectType string
+	ObjectURL  string
+	ObjectName string
+	Role       string
+	Via        string // Title of the SharePoint group granting the role, empty for direct grants
+}
+
+// GuestAccount is a guest's account in one tenant, as captured by the latest audit run of one site.
+type GuestAccount struct {
+	Tenant     string
+	SiteID     int64
+	SiteURL    string
+	AuditRunID int64
+	Principal  *sharepoint.Principal
+	Groups     []string      // SharePoint groups the account belongs to, by title
+	Grants     []*GuestGrant // Ordered by object URL and role; Limited Access is left out
+}
+
+// GuestIdentity is one guest correlated across every audited site and tenant by the email
+// address they were invited with.
+type GuestIdentity struct {
+	Email      string
+	Domain     string          // The guest's home domain, i.e. the partner organisation
+	Tenants    []string        // Tenants with an account for the guest, sorted
+	Accounts   []*GuestAccount // Ordered by tenant and site URL
+	GrantCount int
+}
+
+// GuestSkippedSite is a site left out of a guest correlation, with the reason.
+type GuestSkippedSite struct {
+	SiteID  int64
+	SiteURL string
+	Reason  string
+}
+
+// GuestCorrelationFilter narrows a guest correlation.
+type GuestCorrelationFilter struct {
+	MinTenants int    // Only guests with accounts in at least this many tenants
+	Domain     string // Only guests of this home domain, empty for every domain
+}
+
+// GuestCorrelationData is the consolidated access of guests across audited tenants.
+type GuestCorrelationData struct {
+	Identities   []*GuestIdentity // Ordered by tenant count, grant count, then email
+	SitesScanned int
+	Skipped      []*GuestSkippedSite
+}
+
+// GuestCorrelationService correlates B2B guest accounts across the latest runs of every audited site,
+// so a partner's total access can be reported across tenants.
+type GuestCorrelationService struct {
+	siteBrowsing   *SiteBrowsingService
+	serviceFactory AuditRunScopedServiceFactory
+	logger         *logging.Logger
+}
+
+// NewGuestCorrelationService creates a new guest correlation service.
+func NewGuestCorrelationService(siteBrowsing *SiteBrowsingService, serviceFactory AuditRunScopedServiceFactory) *GuestCorrelationService {
+	return &GuestCorrelationService{
+		siteBrowsing:   siteBrowsing,
+		serviceFactory: serviceFactory,
+		logger:         logging.Default().WithComponent("guest_correlation_service"),
+	}
+}
+
+// Correlate groups the guest accounts of every site's latest audit run by home email address.
+// Sites without a run, or whose permissions exceed the analysis row cap, are skipped and reported.
+func (s *GuestCorrelationService) Correlate(ctx context.Context, filter GuestCorrelationFilter) (*GuestCorrelationData, error) {
+	sites, err := s.siteBrowsing.GetAllSitesWithMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &GuestCorrelationData{Identities: []*GuestIdentity{}, Skipped: []*GuestSkippedSite{}}
+	identities := map[string]*GuestIdentity{}
+	for _, site := range sites {
+		if site.Site == nil {
+			continue
+		}
+		accounts, err := s.siteGuestAccounts(ctx, site.Site)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			data.Skipped = append(data.Skipped, &GuestSkippedSite{SiteID: site.Site.ID, SiteURL: site.Site.URL, Reason: err.Error()})
+			continue
+		}
+		data.SitesScanned++
+
+		for email, account := range accounts {
+			identity, ok := identities[email]
+			if !ok {
+				_, domain, _ := strings.Cut(email, "@")
+				identity = &GuestIdentity{Email: email, Domain: domain}
+				identities[email] = identity
+			}
+			identity.Accounts = append(identity.Accounts, account)
+			identity.GrantCount += len(account.Grants)
+		}
+	}
+
+	domain := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(filter.Domain), "@"))
+	for _, identity := range identities {
+		identity.Tenants = guestTenants(identity.Accounts)
+		if len(identity.Tenants) < filter.MinTenants || (domain != "" && identity.Domain != domain) {
+			continue
+		}
+		sort.Slice(identity.Accounts, func(i, j int) bool {
+			a, b := identity.Accounts[i], identity.Accounts[j]
+			if a.Tenant != b.Tenant {
+				return a.Tenant < b.Tenant
+			}
+			return a.SiteURL < b.SiteURL
+		})
+		data.Identities = append(data.Identities, identity)
+	}
+	sort.Slice(data.Identities, func(i, j int) bool {
+		a, b := data.Identities[i], data.Identities[j]
+		if len(a.Tenants) != len(b.Tenants) {
+			return len(a.Tenants) > len(b.Tenants)
+		}
+		if a.GrantCount != b.GrantCount {
+			return a.GrantCount > b.GrantCount
+		}
+		return a.Email < b.Email
+	})
+
+	s.logger.Info("Guests correlated", "sites", data.SitesScanned, "skipped", len(data.Skipped), "identities", len(data.Identities))
+	return data, nil
+}
+
+// siteGuestAccounts loads the guest accounts of a site's latest run by home email, with their grants.
+func (s *GuestCorrelationService) siteGuestAccounts(ctx context.Context, site *sharepoint.Site) (map[string]*GuestAccount, error) {
+	services, err := s.serviceFactory.CreateForAuditRun(ctx, site.ID, audit.RunAliasLatest)
+	if err != nil {
+		return nil, err
+	}
+	content := services.SiteContentService
+	principals, err := content.GetAuditRunPrincipals(ctx, site.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := map[string]*GuestAccount{}
+	grantees := map[int64][]*GuestAccount{} // Accounts each principal's grants apply to
+	groupTitles := map[int64]string{}
+	for _, principal := range principals {
+		groupTitles[principal.ID] = principal.GetDisplayName()
+	}
+	for _, principal := range principals {
+		email, ok := principal.GuestHomeEmail()
+		if !ok {
+			continue
+		}
+		account := &GuestAccount{
+			Tenant:     sharepoint.TenantForSiteURL(site.URL),
+			SiteID:     site.ID,
+			SiteURL:    site.URL,
+			AuditRunID: services.AuditRunID,
+			Principal:  principal,
+			Groups:     []string{},
+			Grants:     []*GuestGrant{},
+		}
+		accounts[email] = account
+		grantees[principal.ID] = append(grantees[principal.ID], account)
+
+		groupIDs, err := content.GetGroupIDsForPrincipal(ctx, site.ID, principal.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, groupID := range groupIDs {
+			account.Groups = append(account.Groups, groupTitles[groupID])
+			grantees[groupID] = append(grantees[groupID], account)
+		}
+		sort.Strings(account.Groups)
+	}
+	if len(accounts) == 0 {
+		return accounts, nil
+	}
+
+	facts, err := content.GetRunFacts(ctx, site.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, fact := range facts.Permissions {
+		if fact.Principal == nil || sharepoint.IsLimitedAccessRole(fact.RoleDefID, fact.Role) {
+			continue
+		}
+		for _, account := range grantees[fact.Principal.ID] {
+			account.Grants = append(account.Grants, guestGrant(fact, account))
+		}
+	}
+	for _, account := range accounts {
+		sort.Slice(account.Grants, func(i, j int) bool {
+			a, b := account.Grants[i], account.Grants[j]
+			if a.ObjectURL != b.ObjectURL {
+				return a.ObjectURL < b.ObjectURL
+			}
+			return a.Role < b.Role
+		})
+	}
+	return accounts, nil
+}
+
+// guestGrant describes a permission fact as a grant to a guest account, naming the group it came through.
+func guestGrant(fact *contracts.PermissionFact, account *GuestAccount) *GuestGrant {
+	grant := &GuestGrant{ObjectType: fact.ObjectType, ObjectURL: fact.ObjectURL, ObjectName: fact.ObjectName, Role: fact.Role}
+	if fact.Principal.ID != account.Principal.ID {
+		grant.Via = fact.Principal.GetDisplayName()
+	}
+	return grant
+}
+
+// guestTenants returns the distinct tenants of a guest's accounts, sorted.
+func guestTenants(accounts []*GuestAccount) []string {
+	seen := map[string]bool{}
+	tenants := []string{}
+	for _, account := range accounts {
+		if !seen[account.Tenant] {
+			seen[account.Tenant] = true
+			tenants = append(tenants, account.Tenant)
+		}
+	}
+	sort.Strings(tenants)
+	return tenants
+}
@@ -0,0 +1,99 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
+	"spaudit/test/dataset"
+)
+
+// newGuestCorrelationTestService stores a synthetic site in the contoso tenant (site 1) and in the fabrikam
+// tenant (site 2), plus a contoso site never audited (site 3). User 0 is invited to both tenants as a guest
+// from northwind.com, and to site 1's Visitors group; user 1 is a guest from litware.com on site 2 only.
+func newGuestCorrelationTestService(t *testing.T) *GuestCorrelationService {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	tenants := map[int64]string{1: "contoso", 2: "fabrikam"}
+	for site, tenant := range tenants {
+		siteURL := fmt.Sprintf("https://%s.sharepoint.com/sites/partners", tenant)
+		d := dataset.Generate(dataset.Spec{SiteID: site, SiteURL: siteURL, Lists: 1, ItemsPerList: 10, AssignmentsPerItem: 2, Users: 4, UniqueRatio: 0.5, Seed: 5})
+		mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (?, ?, 'Partners')`, site, siteURL)
+		mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES (?, ?, ?, 'site_audit', 'completed')`, fmt.Sprintf("job-%d", site), site, siteURL)
+		mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)`, site, fmt.Sprintf("job-%d", site), site)
+		require.NoError(t, d.Save(context.Background(), repositories.NewSqlcAuditRepository(testDB), site))
+
+		mustExec(t, testDB, `UPDATE principals SET login_name = ?, email = 'Partner@Northwind.com' WHERE site_id = ? AND principal_id = 100`,
+			fmt.Sprintf("i:0#.f|membership|partner_northwind.com#EXT#@%s.onmicrosoft.com", tenant), site)
+	}
+	mustExec(t, testDB, `INSERT INTO group_members (site_id, group_id, member_id, audit_run_id) VALUES (1, 5, 100, 1)`)
+	mustExec(t, testDB, `UPDATE principals SET login_name = 'i:0#.f|membership|sam_litware.com#ext#@fabrikam.onmicrosoft.com' WHERE site_id = 2 AND principal_id = 101`)
+	mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (3, 'https://contoso.sharepoint.com/sites/new', 'New')`)
+
+	factory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		repositories.NewSqlcAuditRepository(testDB),
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunAnyStatus,
+	)
+	baseRepo := repositories.NewBaseRepository(testDB)
+	siteContent := repositories.NewSiteContentAggregateRepository(baseRepo, repositories.NewSqlcSiteRepository(testDB), nil, nil, nil, nil)
+	return NewGuestCorrelationService(NewSiteBrowsingService(siteContent, audit.LatestRunAnyStatus), factory)
+}
+
+func TestGuestCorrelationService_Correlate(t *testing.T) {
+	service := newGuestCorrelationTestService(t)
+
+	result, err := service.Correlate(context.Background(), GuestCorrelationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.SitesScanned)
+	require.Len(t, result.Skipped, 1)
+	assert.Equal(t, int64(3), result.Skipped[0].SiteID)
+
+	require.Len(t, result.Identities, 2)
+	partner := result.Identities[0]
+	assert.Equal(t, "partner@northwind.com", partner.Email)
+	assert.Equal(t, "northwind.com", partner.Domain)
+	assert.Equal(t, []string{"contoso", "fabrikam"}, partner.Tenants)
+	require.Len(t, partner.Accounts, 2)
+	assert.Equal(t, int64(1), partner.Accounts[0].SiteID)
+	assert.Equal(t, []string{"Benchmark Visitors"}, partner.Accounts[0].Groups)
+	assert.Empty(t, partner.Accounts[1].Groups)
+
+	var viaGroup int
+	for _, grant := range partner.Accounts[0].Grants {
+		assert.NotEqual(t, "Limited Access", grant.Role)
+		if grant.Via == "Benchmark Visitors" {
+			viaGroup++
+			assert.Equal(t, "Read", grant.Role)
+		}
+	}
+	assert.Positive(t, viaGroup)
+	assert.Equal(t, len(partner.Accounts[0].Grants)+len(partner.Accounts[1].Grants), partner.GrantCount)
+
+	assert.Equal(t, "sam@litware.com", result.Identities[1].Email)
+	assert.Equal(t, []string{"fabrikam"}, result.Identities[1].Tenants)
+}
+
+func TestGuestCorrelationService_Correlate_Filters(t *testing.T) {
+	service := newGuestCorrelationTestService(t)
+
+	result, err := service.Correlate(context.Background(), GuestCorrelationFilter{MinTenants: 2})
+	require.NoError(t, err)
+	require.Len(t, result.Identities, 1)
+	assert.Equal(t, "partner@northwind.com", result.Identities[0].Email)
+
+	result, err = service.Correlate(context.Background(), GuestCorrelationFilter{Domain: "@Litware.com"})
+	require.NoError(t, err)
+	require.Len(t, result.Identities, 1)
+	assert.Equal(t, "sam@litware.com", result.Identities[0].Email)
+}
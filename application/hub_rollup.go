@@ -0,0 +1,238 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/logging"
+)
+
+// HubSite is a site's hub association and risk, as captured by its latest audit run.
+type HubSite struct {
+	Site       *sharepoint.Site
+	AuditRunID int64
+	Hub        *sharepoint.HubAssociation
+	Risk       *SiteRiskSummaryData
+}
+
+// HubRollup is the aggregate risk of the sites associated with one hub.
+type HubRollup struct {
+	HubSiteID       string // Empty for the sites associated with no hub
+	Title           string // The hub's title, or its ID when no run could read the hub
+	URL             string
+	PermissionsSync bool       // The hub's visitors are synced to its associated sites
+	Sites           []*HubSite // The hub site first, then by risk score
+	RiskiestSite    *HubSite
+	RiskLevel       string // Highest site risk level
+
+	HighRiskSites      int
+	MediumRiskSites    int
+	LowRiskSites       int
+	VisitorSyncedSites int // Associated sites the hub's visitors can read without a role assignment there
+
+	ListsAnalyzed          int
+	HighRiskLists          int
+	TotalItems             int64
+	ItemsWithUnique        int64
+	SharingLinks           int
+	AnonymousLinks         int
+	OrganizationLinks      int
+	FullControlAssignments int
+}
+
+// HubSkippedSite is a site left out of a hub rollup, with the reason.
+type HubSkippedSite struct {
+	SiteID  int64
+	SiteURL string
+	Reason  string
+}
+
+// HubRollupFilter narrows a hub rollup.
+type HubRollupFilter struct {
+	HubSiteID string // Only the sites of this hub, empty for every hub
+}
+
+// HubRollupData is the risk of every audited site, rolled up by hub.
+type HubRollupData struct {
+	Hubs         []*HubRollup // Ordered by risk level and title; the sites with no hub come last
+	SitesScanned int
+	Skipped      []*HubSkippedSite
+}
+
+// GetHubAssociation retrieves the hub the site was associated with (audit-scoped), or nil if the run
+// did not collect it.
+func (s *SiteContentService) GetHubAssociation(ctx context.Context, siteID int64) (*sharepoint.HubAssociation, error) {
+	return s.contentAggregate.GetHubAssociation(ctx, siteID, s.auditRunID)
+}
+
+// HubRollupService rolls the risk of every site's latest audit run up to the hub the site is associated
+// with, since governance reviews are organised by hub and department.
+type HubRollupService struct {
+	siteBrowsing   *SiteBrowsingService
+	serviceFactory AuditRunScopedServiceFactory
+	logger         *logging.Logger
+}
+
+// NewHubRollupService creates a new hub rollup service.
+func NewHubRollupService(siteBrowsing *SiteBrowsingService, serviceFactory AuditRunScopedServiceFactory) *HubRollupService {
+	return &HubRollupService{
+		siteBrowsing:   siteBrowsing,
+		serviceFactory: serviceFactory,
+		logger:         logging.Default().WithComponent("hub_rollup_service"),
+	}
+}
+
+// Rollup groups every site by the hub its latest audit run found it associated with, and aggregates
+// the sites' risk per hub. Sites without a run, whose run did not collect the hub, or whose permissions
+// exceed the analysis row cap are skipped and reported.
+func (s *HubRollupService) Rollup(ctx context.Context, filter HubRollupFilter) (*HubRollupData, error) {
+	sites, err := s.siteBrowsing.GetAllSitesWithMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hubID := sharepoint.NormalizeHubSiteID(filter.HubSiteID)
+	data := &HubRollupData{Hubs: []*HubRollup{}, Skipped: []*HubSkippedSite{}}
+	hubs := map[string]*HubRollup{}
+	for _, site := range sites {
+		if site.Site == nil {
+			continue
+		}
+		hubSite, err := s.siteHubRisk(ctx, site.Site, hubID)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			data.Skipped = append(data.Skipped, &HubSkippedSite{SiteID: site.Site.ID, SiteURL: site.Site.URL, Reason: err.Error()})
+			continue
+		}
+		if hubSite == nil {
+			continue
+		}
+		data.SitesScanned++
+
+		rollup, ok := hubs[hubSite.Hub.HubSiteID]
+		if !ok {
+			rollup = &HubRollup{HubSiteID: hubSite.Hub.HubSiteID, RiskLevel: "Low"}
+			hubs[hubSite.Hub.HubSiteID] = rollup
+		}
+		rollup.add(hubSite)
+	}
+
+	for _, rollup := range hubs {
+		rollup.finish()
+		data.Hubs = append(data.Hubs, rollup)
+	}
+	sort.Slice(data.Hubs, func(i, j int) bool {
+		a, b := data.Hubs[i], data.Hubs[j]
+		if (a.HubSiteID == "") != (b.HubSiteID == "") {
+			return b.HubSiteID == ""
+		}
+		if riskLevelRank(a.RiskLevel) != riskLevelRank(b.RiskLevel) {
+			return riskLevelRank(a.RiskLevel) > riskLevelRank(b.RiskLevel)
+		}
+		return strings.ToLower(a.Title) < strings.ToLower(b.Title)
+	})
+
+	s.logger.Info("Hubs rolled up", "sites", data.SitesScanned, "skipped", len(data.Skipped), "hubs", len(data.Hubs))
+	return data, nil
+}
+
+// siteHubRisk loads the hub association and risk of a site's latest run, or nil for a site of another
+// hub than the one asked for.
+func (s *HubRollupService) siteHubRisk(ctx context.Context, site *sharepoint.Site, hubID string) (*HubSite, error) {
+	services, err := s.serviceFactory.CreateForAuditRun(ctx, site.ID, audit.RunAliasLatest)
+	if err != nil {
+		return nil, err
+	}
+	hub, err := services.SiteContentService.GetHubAssociation(ctx, site.ID)
+	if err != nil {
+		return nil, err
+	}
+	if hub == nil {
+		return nil, errHubNotCollected
+	}
+	if hubID != "" && hub.HubSiteID != hubID {
+		return nil, nil
+	}
+
+	lists, err := services.SiteContentService.GetListsForSite(ctx, site.ID)
+	if err != nil {
+		return nil, err
+	}
+	risk, err := services.PermissionService.SummarizeSiteRisk(ctx, site.ID, lists)
+	if err != nil {
+		return nil, err
+	}
+	return &HubSite{Site: site, AuditRunID: services.AuditRunID, Hub: hub, Risk: risk}, nil
+}
+
+// errHubNotCollected skips the sites whose latest run predates hub collection, or failed to collect it
+var errHubNotCollected = errors.New("the latest audit run did not collect the site's hub")
+
+// add counts a site towards the hub.
+func (r *HubRollup) add(site *HubSite) {
+	r.Sites = append(r.Sites, site)
+	if site.Hub.IsHub || r.Title == "" {
+		r.Title = site.Hub.HubTitle
+		r.URL = site.Hub.HubURL
+		r.PermissionsSync = site.Hub.PermissionsSync
+		if r.Title == "" && site.Hub.IsHub {
+			r.Title = site.Site.Title
+			r.URL = site.Site.URL
+		}
+	}
+	if site.Hub.InheritsVisitors() {
+		r.VisitorSyncedSites++
+	}
+
+	risk := site.Risk
+	switch risk.RiskLevel {
+	case "High":
+		r.HighRiskSites++
+	case "Medium":
+		r.MediumRiskSites++
+	default:
+		r.LowRiskSites++
+	}
+	if riskLevelRank(risk.RiskLevel) > riskLevelRank(r.RiskLevel) {
+		r.RiskLevel = risk.RiskLevel
+	}
+	if r.RiskiestSite == nil || risk.HighestRiskScore > r.RiskiestSite.Risk.HighestRiskScore {
+		r.RiskiestSite = site
+	}
+
+	r.ListsAnalyzed += risk.ListsAnalyzed
+	r.HighRiskLists += risk.HighRiskLists
+	r.TotalItems += risk.TotalItems
+	r.ItemsWithUnique += risk.ItemsWithUnique
+	r.SharingLinks += risk.SharingLinks
+	r.AnonymousLinks += risk.AnonymousLinks
+	r.OrganizationLinks += risk.OrganizationLinks
+	r.FullControlAssignments += risk.FullControlAssignments
+}
+
+// finish orders the hub's sites and names a hub no run could read by its ID.
+func (r *HubRollup) finish() {
+	if r.HubSiteID == "" {
+		r.Title = ""
+		r.URL = ""
+		r.PermissionsSync = false
+	} else if r.Title == "" {
+		r.Title = r.HubSiteID
+	}
+	sort.SliceStable(r.Sites, func(i, j int) bool {
+		a, b := r.Sites[i], r.Sites[j]
+		if a.Hub.IsHub != b.Hub.IsHub {
+			return a.Hub.IsHub
+		}
+		if a.Risk.HighestRiskScore != b.Risk.HighestRiskScore {
+			return a.Risk.HighestRiskScore > b.Risk.HighestRiskScore
+		}
+		return a.Site.URL < b.Site.URL
+	})
+}
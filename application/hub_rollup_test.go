@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
+	"spaudit/test/dataset"
+)
+
+// newHubRollupTestService stores three synthetic sites: the Finance hub (site 1), a site associated
+// with it through permission sync (site 2), and a site with no hub (site 3). Site 4 was never audited.
+func newHubRollupTestService(t *testing.T) *HubRollupService {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	ctx := context.Background()
+	auditRepo := repositories.NewSqlcAuditRepository(testDB)
+	hubs := map[int64]*sharepoint.HubAssociation{
+		1: {IsHub: true, HubSiteID: "hub-1", HubTitle: "Finance", HubURL: "https://contoso.sharepoint.com/sites/finance", PermissionsSync: true},
+		2: {HubSiteID: "hub-1", HubTitle: "Finance", HubURL: "https://contoso.sharepoint.com/sites/finance", PermissionsSync: true},
+		3: {},
+	}
+	for site, hub := range hubs {
+		siteURL := fmt.Sprintf("https://contoso.sharepoint.com/sites/site%d", site)
+		d := dataset.Generate(dataset.Spec{SiteID: site, SiteURL: siteURL, Lists: 1, ItemsPerList: 10, AssignmentsPerItem: 2, Users: 4, UniqueRatio: 0.5, Seed: 5})
+		mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (?, ?, ?)`, site, siteURL, fmt.Sprintf("Site %d", site))
+		mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES (?, ?, ?, 'site_audit', 'completed')`, fmt.Sprintf("job-%d", site), site, siteURL)
+		mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)`, site, fmt.Sprintf("job-%d", site), site)
+		require.NoError(t, d.Save(ctx, auditRepo, site))
+		require.NoError(t, auditRepo.SaveHubAssociation(ctx, site, site, time.Now().UTC(), hub))
+	}
+	mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (4, 'https://contoso.sharepoint.com/sites/new', 'New')`)
+
+	factory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		auditRepo,
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunAnyStatus,
+	)
+	baseRepo := repositories.NewBaseRepository(testDB)
+	siteContent := repositories.NewSiteContentAggregateRepository(baseRepo, repositories.NewSqlcSiteRepository(testDB), nil, nil, nil, nil)
+	return NewHubRollupService(NewSiteBrowsingService(siteContent, audit.LatestRunAnyStatus), factory)
+}
+
+func TestHubRollupService_Rollup(t *testing.T) {
+	service := newHubRollupTestService(t)
+
+	result, err := service.Rollup(context.Background(), HubRollupFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.SitesScanned)
+	require.Len(t, result.Skipped, 1)
+	assert.Equal(t, int64(4), result.Skipped[0].SiteID)
+
+	require.Len(t, result.Hubs, 2)
+	finance := result.Hubs[0]
+	assert.Equal(t, "hub-1", finance.HubSiteID)
+	assert.Equal(t, "Finance", finance.Title)
+	assert.True(t, finance.PermissionsSync)
+	require.Len(t, finance.Sites, 2)
+	assert.Equal(t, int64(1), finance.Sites[0].Site.ID, "the hub site comes first")
+	assert.Equal(t, 1, finance.VisitorSyncedSites)
+	assert.Equal(t, 2, finance.HighRiskSites+finance.MediumRiskSites+finance.LowRiskSites)
+	assert.Equal(t, finance.Sites[0].Risk.ListsAnalyzed+finance.Sites[1].Risk.ListsAnalyzed, finance.ListsAnalyzed)
+	assert.Equal(t, finance.Sites[0].Risk.TotalItems+finance.Sites[1].Risk.TotalItems, finance.TotalItems)
+	require.NotNil(t, finance.RiskiestSite)
+
+	none := result.Hubs[1]
+	assert.Empty(t, none.HubSiteID, "the sites with no hub come last")
+	assert.Empty(t, none.Title)
+	require.Len(t, none.Sites, 1)
+	assert.Equal(t, int64(3), none.Sites[0].Site.ID)
+}
+
+func TestHubRollupService_Rollup_FiltersByHub(t *testing.T) {
+	service := newHubRollupTestService(t)
+
+	result, err := service.Rollup(context.Background(), HubRollupFilter{HubSiteID: "{HUB-1}"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.SitesScanned)
+	require.Len(t, result.Hubs, 1)
+	assert.Equal(t, "hub-1", result.Hubs[0].HubSiteID)
+}
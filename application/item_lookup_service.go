@@ -0,0 +1,219 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/logging"
+)
+
+// Item lookup sources.
+const (
+	ItemLookupStored = "stored" // Found in an audit run
+	ItemLookupLive   = "live"   // Looked up in SharePoint
+)
+
+// ErrItemNotFound is returned by LookupItem when neither stored audit data nor SharePoint has the item.
+var ErrItemNotFound = errors.New("item not found")
+
+// ErrLiveLookupFailed is returned by LookupItem when SharePoint could not be asked about the item.
+var ErrLiveLookupFailed = errors.New("live SharePoint lookup failed")
+
+// LiveItemResolver looks items up directly in SharePoint, for items no audit run holds.
+type LiveItemResolver interface {
+	ResolveItem(ctx context.Context, siteURL string, ref sharepoint.ItemReference) (*LiveItemData, error)
+}
+
+// LiveItemData is an item as SharePoint reports it now.
+type LiveItemData struct {
+	Item *sharepoint.Item
+	List *sharepoint.List
+
+	// Effective role assignments, from the item or the object it inherits permissions from
+	Assignments []*sharepoint.Assignment
+}
+
+// ItemLookupResult is an item resolved from a pasted GUID or URL, with its list and permissions.
+type ItemLookupResult struct {
+	Reference sharepoint.ItemReference
+	Source    string // ItemLookupStored or ItemLookupLive
+	SiteID    int64  // Stored results only
+	SiteURL   string
+
+	Stored *ItemAccessExplanationData // Access as of the latest audit run holding the item
+	Live   *LiveItemData
+}
+
+// ItemLookupService resolves items for incident response, from stored audit data first and SharePoint second.
+type ItemLookupService struct {
+	db             *database.Database
+	serviceFactory AuditRunScopedServiceFactory
+	live           LiveItemResolver
+	logger         *logging.Logger
+}
+
+// NewItemLookupService creates a new item lookup service.
+// With a nil live resolver only stored audit data is searched.
+func NewItemLookupService(db *database.Database, serviceFactory AuditRunScopedServiceFactory, live LiveItemResolver) *ItemLookupService {
+	return &ItemLookupService{
+		db:             db,
+		serviceFactory: serviceFactory,
+		live:           live,
+		logger:         logging.Default().WithComponent("item_lookup_service"),
+	}
+}
+
+// storedItemMatch locates an item within an audit run.
+type storedItemMatch struct {
+	SiteID     int64
+	AuditRunID int64
+	ListID     string
+	ItemGUID   string
+}
+
+// LookupItem resolves an item GUID, item URL or sharing link URL to the item, its list and its permissions.
+// Stored audit data is searched first, across all sites. Otherwise the item is looked up live in siteURL,
+// or when siteURL is empty in the audited site the URL belongs to.
+func (s *ItemLookupService) LookupItem(ctx context.Context, input, siteURL string) (*ItemLookupResult, error) {
+	ref, err := sharepoint.ParseItemReference(input)
+	if err != nil {
+		return nil, err
+	}
+
+	match, err := s.findStoredItem(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+	if match != nil {
+		return s.storedResult(ctx, ref, match)
+	}
+
+	if ref.Kind == sharepoint.ItemReferenceSharingLink {
+		return nil, fmt.Errorf("%w: no audit run recorded this sharing link", ErrItemNotFound)
+	}
+	if s.live == nil {
+		return nil, fmt.Errorf("%w: no audit run holds it", ErrItemNotFound)
+	}
+
+	siteURL = strings.TrimSpace(siteURL)
+	if siteURL == "" {
+		if siteURL, err = s.siteForReference(ctx, ref); err != nil {
+			return nil, err
+		}
+		if siteURL == "" {
+			return nil, fmt.Errorf("%w: no audit run holds it; give the site URL to look it up in SharePoint", ErrItemNotFound)
+		}
+	}
+
+	live, err := s.live.ResolveItem(ctx, siteURL, ref)
+	if err != nil {
+		if errors.Is(err, ErrItemNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrLiveLookupFailed, err)
+	}
+
+	s.logger.Info("Resolved item live", "site_url", siteURL, "list_id", live.Item.ListID, "item_guid", live.Item.GUID)
+	return &ItemLookupResult{Reference: ref, Source: ItemLookupLive, SiteURL: siteURL, Live: live}, nil
+}
+
+// findStoredItem returns the most recent audit run's copy of the referenced item, nil when no run holds it.
+// A URL is matched as a recorded sharing link before it is matched as an item URL.
+func (s *ItemLookupService) findStoredItem(ctx context.Context, ref sharepoint.ItemReference) (*storedItemMatch, error) {
+	queries := s.db.ReadQueries()
+
+	if ref.URL != "" {
+		row, err := queries.FindLatestItemBySharingLinkURL(ctx, ref.URL)
+		if err == nil {
+			return &storedItemMatch{SiteID: row.SiteID, AuditRunID: row.AuditRunID, ListID: row.ListID, ItemGUID: row.ItemGuid}, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to look up sharing link: %w", err)
+		}
+	}
+
+	var (
+		match storedItemMatch
+		err   error
+	)
+	switch ref.Kind {
+	case sharepoint.ItemReferenceGUID:
+		row, queryErr := queries.FindLatestItemByGUID(ctx, ref.GUID)
+		match, err = storedItemMatch{SiteID: row.SiteID, AuditRunID: row.AuditRunID, ListID: row.ListID, ItemGUID: row.ItemGuid}, queryErr
+	case sharepoint.ItemReferencePath:
+		// Items store the URL the collector joined from the web URL and the item's server-relative path
+		itemURL := (&url.URL{Scheme: "https", Host: ref.Host, Path: ref.Path}).String()
+		row, queryErr := queries.FindLatestItemByURL(ctx, itemURL)
+		match, err = storedItemMatch{SiteID: row.SiteID, AuditRunID: row.AuditRunID, ListID: row.ListID, ItemGUID: row.ItemGuid}, queryErr
+	default:
+		return nil, nil
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up item: %w", err)
+	}
+	return &match, nil
+}
+
+// storedResult explains access to a stored item as of the audit run it was found in.
+func (s *ItemLookupService) storedResult(ctx context.Context, ref sharepoint.ItemReference, match *storedItemMatch) (*ItemLookupResult, error) {
+	scopedServices, err := s.serviceFactory.CreateForAuditRun(ctx, match.SiteID, strconv.FormatInt(match.AuditRunID, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	explanation, err := scopedServices.SiteContentService.ExplainItemAccess(ctx, match.SiteID, match.ListID, match.ItemGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to explain access to item %s: %w", match.ItemGUID, err)
+	}
+
+	site, err := s.db.ReadQueries().GetSiteByID(ctx, match.SiteID)
+	if err != nil {
+		return nil, fmt.Errorf("site %d not found: %w", match.SiteID, err)
+	}
+
+	return &ItemLookupResult{
+		Reference: ref,
+		Source:    ItemLookupStored,
+		SiteID:    match.SiteID,
+		SiteURL:   site.SiteUrl,
+		Stored:    explanation,
+	}, nil
+}
+
+// siteForReference returns the audited site a URL reference lies in, the deepest match when sites nest.
+// Empty when the reference has no URL or no audited site contains it.
+func (s *ItemLookupService) siteForReference(ctx context.Context, ref sharepoint.ItemReference) (string, error) {
+	if ref.Host == "" || ref.Path == "" {
+		return "", nil
+	}
+
+	sites, err := s.db.ReadQueries().ListSites(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list sites: %w", err)
+	}
+
+	siteURL, matchLength := "", -1
+	for _, site := range sites {
+		parsed, err := url.Parse(site.SiteUrl)
+		if err != nil || !strings.EqualFold(parsed.Host, ref.Host) {
+			continue
+		}
+		sitePath := strings.TrimRight(parsed.Path, "/")
+		if audit.PathInFolder(ref.Path, sitePath) && len(sitePath) > matchLength {
+			siteURL, matchLength = site.SiteUrl, len(sitePath)
+		}
+	}
+	return siteURL, nil
+}
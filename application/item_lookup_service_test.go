@@ -0,0 +1,149 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
+)
+
+// fakeLiveItemResolver records the live lookup it was asked for
+type fakeLiveItemResolver struct {
+	siteURL string
+	ref     sharepoint.ItemReference
+	data    *LiveItemData
+	err     error
+}
+
+func (f *fakeLiveItemResolver) ResolveItem(ctx context.Context, siteURL string, ref sharepoint.ItemReference) (*LiveItemData, error) {
+	f.siteURL, f.ref = siteURL, ref
+	return f.data, f.err
+}
+
+func newItemLookupTestDB(t *testing.T) *database.Database {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	// Two runs of the same site; the item's sharing link was only recorded by the newer one
+	for _, stmt := range []string{
+		`INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A')`,
+		`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`,
+		`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at, completed_at) VALUES (1, 'job-1', 1, '2025-01-01 09:00:00', '2025-01-01 10:00:00'), (2, 'job-2', 1, '2025-01-02 09:00:00', '2025-01-02 10:00:00')`,
+		`INSERT INTO webs (site_id, web_id, audit_run_id, title) VALUES (1, 'web-1', 1, 'A'), (1, 'web-1', 2, 'A')`,
+		`INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title, url) VALUES (1, 'docs', 1, 'web-1', 'Documents', '/sites/a/Shared Documents'), (1, 'docs', 2, 'web-1', 'Documents', '/sites/a/Shared Documents')`,
+		`INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, list_item_guid, name, url, is_file) VALUES
+			(1, '3f2504e0-4f89-11d3-9a0c-0305e82c3301', 1, 'docs', 5, 'aaaaaaaa-0000-0000-0000-000000000001', 'Budget.xlsx', 'https://contoso.sharepoint.com/sites/a/Shared%20Documents/Budget.xlsx', TRUE),
+			(1, '3f2504e0-4f89-11d3-9a0c-0305e82c3301', 2, 'docs', 5, 'aaaaaaaa-0000-0000-0000-000000000001', 'Budget.xlsx', 'https://contoso.sharepoint.com/sites/a/Shared%20Documents/Budget.xlsx', TRUE)`,
+		`INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, file_folder_unique_id, url, link_kind, scope) VALUES
+			(1, 'link-1', 2, '3f2504e0-4f89-11d3-9a0c-0305e82c3301', '3f2504e0-4f89-11d3-9a0c-0305e82c3301', 'https://contoso.sharepoint.com/:x:/s/a/EaBcDeF?e=1', 5, 2)`,
+	} {
+		mustExec(t, testDB, stmt)
+	}
+	return testDB
+}
+
+func newItemLookupTestService(testDB *database.Database, live LiveItemResolver) *ItemLookupService {
+	serviceFactory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		repositories.NewSqlcAuditRepository(testDB),
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunCompleted,
+	)
+	return NewItemLookupService(testDB, serviceFactory, live)
+}
+
+func TestItemLookupService_LookupItem_Stored(t *testing.T) {
+	testDB := newItemLookupTestDB(t)
+	live := &fakeLiveItemResolver{err: errors.New("not expected")}
+	service := newItemLookupTestService(testDB, live)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		input string
+		kind  string
+	}{
+		{"item GUID", "{3F2504E0-4F89-11D3-9A0C-0305E82C3301}", sharepoint.ItemReferenceGUID},
+		{"list item GUID", "aaaaaaaa-0000-0000-0000-000000000001", sharepoint.ItemReferenceGUID},
+		{"document URL", "https://contoso.sharepoint.com/sites/a/Shared%20Documents/Budget.xlsx", sharepoint.ItemReferencePath},
+		{"sharing link", "https://contoso.sharepoint.com/:x:/s/a/EaBcDeF?e=1", sharepoint.ItemReferenceSharingLink},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.LookupItem(ctx, tt.input, "")
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.kind, result.Reference.Kind)
+			assert.Equal(t, ItemLookupStored, result.Source)
+			assert.Equal(t, int64(1), result.SiteID)
+			assert.Equal(t, "https://contoso.sharepoint.com/sites/a", result.SiteURL)
+			require.NotNil(t, result.Stored)
+			assert.Equal(t, int64(2), result.Stored.AuditRunID, "the latest run holding the item wins")
+			assert.Equal(t, "docs", result.Stored.List.ID)
+			assert.Equal(t, "Budget.xlsx", result.Stored.Item.Name)
+			assert.Nil(t, result.Live)
+		})
+	}
+	assert.Empty(t, live.siteURL, "stored items are not looked up live")
+}
+
+func TestItemLookupService_LookupItem_Live(t *testing.T) {
+	testDB := newItemLookupTestDB(t)
+	ctx := context.Background()
+
+	t.Run("falls back to the audited site the URL belongs to", func(t *testing.T) {
+		live := &fakeLiveItemResolver{data: &LiveItemData{
+			Item: &sharepoint.Item{GUID: "new-item", ListID: "docs", Name: "New.docx"},
+			List: &sharepoint.List{ID: "docs", Title: "Documents"},
+		}}
+		service := newItemLookupTestService(testDB, live)
+
+		result, err := service.LookupItem(ctx, "https://contoso.sharepoint.com/sites/a/Shared%20Documents/New.docx", "")
+		require.NoError(t, err)
+		assert.Equal(t, ItemLookupLive, result.Source)
+		assert.Equal(t, "https://contoso.sharepoint.com/sites/a", live.siteURL)
+		assert.Equal(t, "/sites/a/Shared Documents/New.docx", live.ref.Path)
+		assert.Same(t, live.data, result.Live)
+		assert.Nil(t, result.Stored)
+	})
+
+	t.Run("GUID needs a site URL", func(t *testing.T) {
+		service := newItemLookupTestService(testDB, &fakeLiveItemResolver{})
+
+		_, err := service.LookupItem(ctx, "11111111-2222-3333-4444-555555555555", "")
+		assert.ErrorIs(t, err, ErrItemNotFound)
+	})
+
+	t.Run("SharePoint failures are reported as such", func(t *testing.T) {
+		service := newItemLookupTestService(testDB, &fakeLiveItemResolver{err: errors.New("401 Unauthorized")})
+
+		_, err := service.LookupItem(ctx, "11111111-2222-3333-4444-555555555555", "https://contoso.sharepoint.com/sites/b")
+		assert.ErrorIs(t, err, ErrLiveLookupFailed)
+	})
+
+	t.Run("unknown sharing links are not looked up live", func(t *testing.T) {
+		live := &fakeLiveItemResolver{}
+		service := newItemLookupTestService(testDB, live)
+
+		_, err := service.LookupItem(ctx, "https://contoso.sharepoint.com/:w:/s/a/Unknown", "https://contoso.sharepoint.com/sites/a")
+		assert.ErrorIs(t, err, ErrItemNotFound)
+		assert.Empty(t, live.siteURL)
+	})
+
+	t.Run("stored data only without a live resolver", func(t *testing.T) {
+		service := newItemLookupTestService(testDB, nil)
+
+		_, err := service.LookupItem(ctx, "https://contoso.sharepoint.com/sites/a/Shared%20Documents/New.docx", "")
+		assert.ErrorIs(t, err, ErrItemNotFound)
+	})
+}
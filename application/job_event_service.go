@@ -0,0 +1,104 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/events"
+)
+
+// ErrInvalidJobEventFilter indicates a replay filter with an unknown event type or no limit.
+var ErrInvalidJobEventFilter = errors.New("invalid job event filter")
+
+// JobEventPage is a chunk of the job event log in sequence order.
+type JobEventPage struct {
+	Events    []*events.RecordedJobEvent
+	NextAfter int64 // Sequence to continue after; the filter's own position when the page is empty
+	HasMore   bool  // Whether more events matching the filter follow this page
+}
+
+// DeadLetterRedeliverer delivers a dead letter's event to its handler once more.
+type DeadLetterRedeliverer interface {
+	Redeliver(ctx context.Context, deadLetter *events.DeadLetter) error
+}
+
+// JobEventService replays the job event log so reconnecting clients can catch up on missed events
+// and integrations can reprocess the history of jobs. It also manages the events durable handlers
+// gave up on.
+type JobEventService struct {
+	eventLog    contracts.JobEventLog
+	deliveries  contracts.JobEventDeliveryStore
+	redeliverer DeadLetterRedeliverer
+}
+
+// NewJobEventService creates a new job event service reading the event log and delivery store.
+func NewJobEventService(eventLog contracts.JobEventLog, deliveries contracts.JobEventDeliveryStore) *JobEventService {
+	return &JobEventService{eventLog: eventLog, deliveries: deliveries}
+}
+
+// SetRedeliverer sets what retries dead letters. Without one retrying a dead letter fails.
+func (s *JobEventService) SetRedeliverer(redeliverer DeadLetterRedeliverer) {
+	s.redeliverer = redeliverer
+}
+
+// ListEvents returns up to filter.Limit events matching the filter, oldest first.
+func (s *JobEventService) ListEvents(ctx context.Context, filter events.JobEventFilter) (*JobEventPage, error) {
+	if filter.Type != "" && !filter.Type.IsValid() {
+		return nil, fmt.Errorf("%w: unknown job event type %q", ErrInvalidJobEventFilter, filter.Type)
+	}
+	if filter.Limit < 1 {
+		return nil, fmt.Errorf("%w: limit must be positive", ErrInvalidJobEventFilter)
+	}
+
+	// One more than asked for tells whether another page follows
+	limit := filter.Limit
+	filter.Limit++
+	recorded, err := s.eventLog.ListJobEvents(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &JobEventPage{NextAfter: filter.AfterSequence}
+	if len(recorded) > limit {
+		recorded = recorded[:limit]
+		page.HasMore = true
+	}
+	if len(recorded) > 0 {
+		page.NextAfter = recorded[len(recorded)-1].Sequence
+	}
+	page.Events = recorded
+	return page, nil
+}
+
+// ListDeadLetters returns up to limit dead letters, most recently failed first.
+func (s *JobEventService) ListDeadLetters(ctx context.Context, limit int) ([]*events.DeadLetter, error) {
+	return s.deliveries.ListDeadLetters(ctx, limit)
+}
+
+// RetryDeadLetter delivers a dead letter's event to its handler again, removing the dead letter when
+// the handler succeeds. A handler failing again returns events.ErrHandlerFailed.
+func (s *JobEventService) RetryDeadLetter(ctx context.Context, id int64) error {
+	deadLetter, err := s.deliveries.GetDeadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+	if s.redeliverer == nil {
+		return fmt.Errorf("%w: %s", events.ErrUnknownHandler, deadLetter.Handler)
+	}
+	return s.redeliverer.Redeliver(ctx, deadLetter)
+}
+
+// DismissDeadLetter removes a dead letter without delivering its event again.
+func (s *JobEventService) DismissDeadLetter(ctx context.Context, id int64) error {
+	deleted, err := s.deliveries.DeleteDeadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return fmt.Errorf("dead letter %d: %w", id, sql.ErrNoRows)
+	}
+	return nil
+}
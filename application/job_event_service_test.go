@@ -0,0 +1,70 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/events"
+)
+
+// sliceJobEventLog filters a fixed slice of events like the event log table.
+type sliceJobEventLog struct {
+	events []*events.RecordedJobEvent
+}
+
+func (l *sliceJobEventLog) AppendJobEvent(_ context.Context, event *events.RecordedJobEvent) error {
+	event.Sequence = int64(len(l.events) + 1)
+	l.events = append(l.events, event)
+	return nil
+}
+
+func (l *sliceJobEventLog) ListJobEvents(_ context.Context, filter events.JobEventFilter) ([]*events.RecordedJobEvent, error) {
+	var matching []*events.RecordedJobEvent
+	for _, event := range l.events {
+		if event.Sequence > filter.AfterSequence && (filter.Type == "" || event.Type == filter.Type) && len(matching) < filter.Limit {
+			matching = append(matching, event)
+		}
+	}
+	return matching, nil
+}
+
+func (l *sliceJobEventLog) GetJobEvent(_ context.Context, sequence int64) (*events.RecordedJobEvent, error) {
+	return l.events[sequence-1], nil
+}
+
+func (l *sliceJobEventLog) LatestJobEventSequence(_ context.Context) (int64, error) {
+	return int64(len(l.events)), nil
+}
+
+func TestJobEventService_ListEvents(t *testing.T) {
+	ctx := context.Background()
+	eventLog := &sliceJobEventLog{}
+	for _, eventType := range []events.JobEventType{events.JobEventCompleted, events.JobEventSiteAuditCompleted, events.JobEventFailed} {
+		require.NoError(t, eventLog.AppendJobEvent(ctx, &events.RecordedJobEvent{Type: eventType}))
+	}
+	service := NewJobEventService(eventLog, nil)
+
+	first, err := service.ListEvents(ctx, events.JobEventFilter{Limit: 2})
+	require.NoError(t, err)
+	assert.Len(t, first.Events, 2)
+	assert.True(t, first.HasMore)
+	assert.Equal(t, int64(2), first.NextAfter)
+
+	rest, err := service.ListEvents(ctx, events.JobEventFilter{AfterSequence: first.NextAfter, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, rest.Events, 1)
+	assert.Equal(t, events.JobEventFailed, rest.Events[0].Type)
+	assert.False(t, rest.HasMore)
+	assert.Equal(t, int64(3), rest.NextAfter)
+
+	empty, err := service.ListEvents(ctx, events.JobEventFilter{AfterSequence: rest.NextAfter, Limit: 2})
+	require.NoError(t, err)
+	assert.Empty(t, empty.Events)
+	assert.Equal(t, int64(3), empty.NextAfter, "an empty page keeps the position")
+
+	_, err = service.ListEvents(ctx, events.JobEventFilter{Type: "job_exploded", Limit: 2})
+	assert.ErrorIs(t, err, ErrInvalidJobEventFilter)
+}
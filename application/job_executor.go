@@ -4,6 +4,7 @@ import (
 	"context"
 	"spaudit/domain/audit"
 	"spaudit/domain/jobs"
+	"spaudit/logging"
 )
 
 // JobExecutor defines the interface for executing specific job types
@@ -11,12 +12,20 @@ type JobExecutor interface {
 	Execute(ctx context.Context, job *jobs.Job, progressCallback ProgressCallback) error
 }
 
+// ResumableJobExecutor is a JobExecutor that can continue a failed or cancelled job from where it
+// stopped instead of starting it over.
+type ResumableJobExecutor interface {
+	JobExecutor
+	Resume(ctx context.Context, job *jobs.Job, progressCallback ProgressCallback) error
+}
+
 // ProgressCallback is called during job execution to report progress
 type ProgressCallback func(stage, description string, percentage, itemsDone, itemsTotal int)
 
-// WorkflowFactory defines the interface for creating workflows
+// WorkflowFactory defines the interface for creating workflows.
+// Workflow components log through jobLogger so their lines can be attributed to the job.
 type WorkflowFactory interface {
-	CreateAuditWorkflow(siteURL string, auditRunID int64, parameters *audit.AuditParameters) (AuditWorkflow, error)
+	CreateAuditWorkflow(siteURL string, auditRunID int64, parameters *audit.AuditParameters, jobLogger *logging.Logger) (AuditWorkflow, error)
 }
 
 // AuditWorkflow defines the interface for audit workflow operations
@@ -2,6 +2,8 @@ package application
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"sync"
 	"time"
@@ -128,11 +130,7 @@ func (s *JobServiceImpl) executeJobAsync(job *jobs.Job, executor JobExecutor) {
 	s.jobsMutex.Unlock()
 	
 	// Ensure cleanup on completion
-	defer func() {
-		s.jobsMutex.Lock()
-		delete(s.runningJobs, job.ID)
-		s.jobsMutex.Unlock()
-	}()
+	defer s.releaseRunningJob(job.ID)
 
 	// Start job
 	jobLifecycle := &jobs.JobLifecycle{}
@@ -163,8 +161,22 @@ func (s *JobServiceImpl) executeJobAsync(job *jobs.Job, executor JobExecutor) {
 	s.notifyJobUpdate(job.ID, job)
 
 	// Execute using the specific executor
+	s.runJob(ctx, job, executor.Execute)
+}
+
+// resumeJobAsync continues a resumed job asynchronously. The job is already running and registered
+// by ResumeJob, so a cancellation in the meantime reaches it.
+func (s *JobServiceImpl) resumeJobAsync(ctx context.Context, job *jobs.Job, executor ResumableJobExecutor) {
+	defer s.releaseRunningJob(job.ID)
+
+	s.notifyJobUpdate(job.ID, job)
+	s.runJob(ctx, job, executor.Resume)
+}
+
+// runJob runs a started job to its end, then records how it ended
+func (s *JobServiceImpl) runJob(ctx context.Context, job *jobs.Job, run func(ctx context.Context, job *jobs.Job, progressCallback ProgressCallback) error) {
 	progressCallback := s.createProgressCallback(job)
-	err := executor.Execute(ctx, job, progressCallback)
+	err := run(ctx, job, progressCallback)
 
 	// Handle completion
 	if err != nil {
@@ -189,6 +201,13 @@ func (s *JobServiceImpl) executeJobAsync(job *jobs.Job, executor JobExecutor) {
 	s.notifyJobUpdate(job.ID, job)
 }
 
+// releaseRunningJob forgets the cancel function of a job that stopped running
+func (s *JobServiceImpl) releaseRunningJob(jobID string) {
+	s.jobsMutex.Lock()
+	delete(s.runningJobs, jobID)
+	s.jobsMutex.Unlock()
+}
+
 // createProgressCallback creates a progress callback for job execution
 func (s *JobServiceImpl) createProgressCallback(job *jobs.Job) ProgressCallback {
 	return func(stage, description string, percentage, itemsDone, itemsTotal int) {
@@ -300,6 +319,131 @@ func (s *JobServiceImpl) CancelJob(jobID string) (*jobs.Job, error) {
 	return job, nil
 }
 
+// ResumeJob continues a failed or cancelled job from where it stopped. Only jobs whose executor can
+// resume them can be, and only while no other job audits the same site. A site audit continues in
+// its own audit run, with the parameters it was started with, skipping the lists it completed.
+func (s *JobServiceImpl) ResumeJob(jobID string) (*jobs.Job, error) {
+	ctx := context.Background()
+	job, err := s.jobRepo.GetJob(ctx, jobID)
+	if err != nil || job == nil {
+		return nil, fmt.Errorf("job not found: %s", jobID)
+	}
+
+	executor, err := s.registry.GetExecutor(job.Type)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resume job: %w", err)
+	}
+	resumable, ok := executor.(ResumableJobExecutor)
+	if !ok {
+		return nil, fmt.Errorf("%s jobs cannot be resumed", job.GetJobTypeDisplayName())
+	}
+
+	// Refuse while another job audits the same site, since both would write to it
+	activeJobs, err := s.jobRepo.ListActiveJobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active jobs: %w", err)
+	}
+	for _, active := range activeJobs {
+		if active.ID != job.ID && active.Type == job.Type && active.GetSiteURL() == job.GetSiteURL() {
+			return nil, fmt.Errorf("site %s is already being audited by job %s", job.GetSiteURL(), active.ID)
+		}
+	}
+
+	if job.Type == jobs.JobTypeSiteAudit {
+		if err := s.restoreAuditRun(ctx, job); err != nil {
+			return nil, err
+		}
+	}
+
+	// A cancelled job may still be winding down; it is registered until it has
+	s.jobsMutex.Lock()
+	if _, running := s.runningJobs[job.ID]; running {
+		s.jobsMutex.Unlock()
+		return nil, fmt.Errorf("job %s is still stopping, try again shortly", job.ID)
+	}
+
+	jobLifecycle := &jobs.JobLifecycle{}
+	if err := jobLifecycle.ResumeJob(job); err != nil {
+		s.jobsMutex.Unlock()
+		return nil, err
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.runningJobs[job.ID] = cancel
+	s.jobsMutex.Unlock()
+
+	// Persist the running status, clearing how the job ended
+	if err := s.jobRepo.ReopenJob(ctx, job.ID); err != nil {
+		s.releaseRunningJob(job.ID)
+		cancel()
+		return nil, fmt.Errorf("failed to reopen job: %w", err)
+	}
+	if err := s.jobRepo.UpdateJob(ctx, job); err != nil {
+		s.logger.Error("Failed to update resumed job", "job_id", job.ID, "error", err)
+	}
+
+	go s.resumeJobAsync(runCtx, job, resumable)
+
+	s.logger.Info("Job resumed", "job_id", job.ID, "type", job.Type, "audit_run_id", job.GetAuditRunID())
+	return job, nil
+}
+
+// restoreAuditRun sets the audit run a site audit job started, and the parameters it started it with,
+// on a job read back from the repository, which holds neither
+func (s *JobServiceImpl) restoreAuditRun(ctx context.Context, job *jobs.Job) error {
+	baseRepo := s.auditRepo.(*repositories.SqlcAuditRepository)
+	run, err := baseRepo.ReadQueries().GetAuditRunParametersByJobID(ctx, job.ID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("job %s has no audit run to resume, start a new audit instead", job.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get audit run of job %s: %w", job.ID, err)
+	}
+	job.SetAuditRunID(run.AuditRunID)
+
+	// Runs started before their parameters were recorded resume with the defaults
+	if run.ParametersJson.Valid {
+		var parameters audit.AuditParameters
+		if err := json.Unmarshal([]byte(run.ParametersJson.String), &parameters); err != nil {
+			return fmt.Errorf("failed to read parameters of audit run %d: %w", run.AuditRunID, err)
+		}
+		job.UpdateParameters(&parameters, audit.DefaultApiConstraints())
+	}
+	return nil
+}
+
+// FailInterruptedJobs fails the jobs left pending or running by a server that stopped, since nothing
+// runs them any more. Failed site audits can then be resumed. It returns how many jobs it failed.
+func (s *JobServiceImpl) FailInterruptedJobs() (int, error) {
+	ctx := context.Background()
+	activeJobs, err := s.jobRepo.ListActiveJobs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list active jobs: %w", err)
+	}
+
+	failed := 0
+	jobLifecycle := &jobs.JobLifecycle{}
+	for _, job := range activeJobs {
+		s.jobsMutex.RLock()
+		_, running := s.runningJobs[job.ID]
+		s.jobsMutex.RUnlock()
+		if running {
+			continue
+		}
+
+		if err := jobLifecycle.FailJob(job, "Interrupted by a server restart"); err != nil {
+			s.logger.Error("Failed to fail interrupted job", "job_id", job.ID, "error", err)
+			continue
+		}
+		if err := s.jobRepo.UpdateJob(ctx, job); err != nil {
+			s.logger.Error("Failed to update interrupted job", "job_id", job.ID, "error", err)
+			continue
+		}
+		failed++
+		s.logger.Warn("Marked job interrupted by a server restart as failed", "job_id", job.ID, "type", job.Type)
+	}
+	return failed, nil
+}
+
 // ListAllJobs returns all jobs from repository
 func (s *JobServiceImpl) ListAllJobs() []*jobs.Job {
 	ctx := context.Background()
@@ -386,13 +530,31 @@ func (s *JobServiceImpl) createAuditRun(ctx context.Context, job *jobs.Job) (int
 		return 0, fmt.Errorf("failed to get/create site: %w", err)
 	}
 
-	// Create audit run with database autoincrement
-	baseRepo := s.auditRepo.(*repositories.SqlcAuditRepository)
-	auditRunID, err := baseRepo.WriteQueries().CreateAuditRun(ctx, db.CreateAuditRunParams{
+	// Folder-scoped runs are recorded as investigations so they are not mistaken for full audits
+	params := db.CreateAuditRunParams{
 		JobID:     job.ID,
 		SiteID:    siteID,
 		StartedAt: time.Now(),
-	})
+	}
+	if auditParams := job.GetAuditParameters(); auditParams != nil && auditParams.IsFolderScoped() {
+		params.AuditTrigger = sql.NullString{String: "investigation", Valid: true}
+		params.ScopePath = sql.NullString{String: auditParams.FolderPath, Valid: true}
+	}
+	if auditParams := job.GetAuditParameters(); auditParams != nil && auditParams.Label != "" {
+		params.Label = sql.NullString{String: auditParams.Label, Valid: true}
+	}
+	// Recorded so the run can be resumed with them
+	if auditParams := job.GetAuditParameters(); auditParams != nil {
+		encoded, err := json.Marshal(auditParams)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode audit parameters: %w", err)
+		}
+		params.ParametersJson = sql.NullString{String: string(encoded), Valid: true}
+	}
+
+	// Create audit run with database autoincrement
+	baseRepo := s.auditRepo.(*repositories.SqlcAuditRepository)
+	auditRunID, err := baseRepo.WriteQueries().CreateAuditRun(ctx, params)
 	if err != nil {
 		return 0, fmt.Errorf("failed to create audit run: %w", err)
 	}
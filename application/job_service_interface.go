@@ -22,6 +22,8 @@ type JobService interface {
 	CreateJob(jobType jobs.JobType, siteURL, description string) (*jobs.Job, error)
 	GetJob(jobID string) (*jobs.Job, bool)
 	CancelJob(jobID string) (*jobs.Job, error)
+	ResumeJob(jobID string) (*jobs.Job, error)
+	FailInterruptedJobs() (int, error) // Fails the jobs a stopped server left active, so they can be resumed
 
 	// Job listing and filtering
 	ListAllJobs() []*jobs.Job
@@ -0,0 +1,283 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// Errors returned when managing list monitors.
+var (
+	ErrInvalidListMonitor   = errors.New("invalid list monitor")
+	ErrListAlreadyMonitored = errors.New("list already monitored")
+	ErrListMonitorNotFound  = errors.New("list monitor not found")
+)
+
+// listMonitorTick is how often monitors are checked for being due.
+const listMonitorTick = time.Minute
+
+// ListChangeReader reads lists and their change logs from SharePoint.
+type ListChangeReader interface {
+	GetList(ctx context.Context, siteURL, listID string) (*sharepoint.List, error)
+	GetChangeToken(ctx context.Context, siteURL, listID string) (string, error)
+	GetChangedItems(ctx context.Context, siteURL, listID, changeToken string) ([]*sharepoint.ChangedItem, string, error)
+}
+
+// ListMonitorService keeps critical lists under continuous surveillance. Each monitored list's change
+// log is read every interval, and a list that changed is audited again with a folder-scoped audit of
+// its root folder. The access each of those audits finds added since the last is raised as finding
+// alerts when the audit completes. Unlike list subscriptions, monitors need no public webhook URL.
+type ListMonitorService struct {
+	db              *database.Database
+	auditService    AuditService
+	client          ListChangeReader
+	defaultInterval time.Duration
+	now             func() time.Time
+	logger          *logging.Logger
+}
+
+// NewListMonitorService creates a list monitor service checking monitors made without an interval
+// every defaultInterval.
+func NewListMonitorService(db *database.Database, auditService AuditService, client ListChangeReader, defaultInterval time.Duration) *ListMonitorService {
+	if defaultInterval < audit.MinListMonitorInterval {
+		defaultInterval = audit.DefaultListMonitorInterval
+	}
+	return &ListMonitorService{
+		db:              db,
+		auditService:    auditService,
+		client:          client,
+		defaultInterval: defaultInterval,
+		now:             func() time.Time { return time.Now().UTC() },
+		logger:          logging.Default().WithComponent("list_monitor_service"),
+	}
+}
+
+// Monitor starts monitoring a list every interval, or the default interval when it is zero. The
+// list is audited on the first check, giving later audits a run to compare with.
+func (s *ListMonitorService) Monitor(ctx context.Context, siteURL, listID string, interval time.Duration) (*audit.ListMonitor, error) {
+	siteURL = strings.TrimRight(strings.TrimSpace(siteURL), "/")
+	if err := audit.ValidateSiteURL(siteURL); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidListMonitor, err)
+	}
+	listID = strings.Trim(strings.TrimSpace(listID), "{}")
+	if listID == "" {
+		return nil, fmt.Errorf("%w: list_id is required", ErrInvalidListMonitor)
+	}
+	if interval == 0 {
+		interval = s.defaultInterval
+	}
+	if interval < audit.MinListMonitorInterval {
+		return nil, fmt.Errorf("%w: interval must be at least %s", ErrInvalidListMonitor, audit.MinListMonitorInterval)
+	}
+
+	if _, err := s.db.ReadQueries().GetListMonitorForList(ctx, db.GetListMonitorForListParams{SiteUrl: siteURL, ListID: listID}); err == nil {
+		return nil, fmt.Errorf("%w: list %s of %s", ErrListAlreadyMonitored, listID, siteURL)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check list monitors: %w", err)
+	}
+
+	list, err := s.client.GetList(ctx, siteURL, listID)
+	if err != nil {
+		return nil, err
+	}
+	rootPath, err := audit.ResolveFolderPath(siteURL, list.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidListMonitor, err)
+	}
+	changeToken, err := s.client.GetChangeToken(ctx, siteURL, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	monitor := &audit.ListMonitor{
+		SiteURL:      siteURL,
+		ListID:       listID,
+		ListTitle:    list.Title,
+		ListRootPath: rootPath,
+		Interval:     interval,
+		ChangeToken:  changeToken,
+		CreatedAt:    now,
+		NextCheckAt:  now,
+	}
+	monitor.ID, err = s.db.Queries().CreateListMonitor(ctx, db.CreateListMonitorParams{
+		SiteUrl:         monitor.SiteURL,
+		ListID:          monitor.ListID,
+		ListTitle:       monitor.ListTitle,
+		ListRootPath:    monitor.ListRootPath,
+		IntervalSeconds: int64(monitor.Interval / time.Second),
+		ChangeToken:     monitor.ChangeToken,
+		CreatedAt:       monitor.CreatedAt,
+		NextCheckAt:     monitor.NextCheckAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record list monitor: %w", err)
+	}
+
+	s.logger.Info("Monitoring list", "monitor_id", monitor.ID, "site_url", siteURL, "list_title", list.Title, "interval", interval)
+	return monitor, nil
+}
+
+// GetMonitors returns every list monitor, by site and list title.
+func (s *ListMonitorService) GetMonitors(ctx context.Context) ([]*audit.ListMonitor, error) {
+	rows, err := s.db.ReadQueries().GetListMonitors(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list monitors: %w", err)
+	}
+	monitors := make([]*audit.ListMonitor, len(rows))
+	for i, row := range rows {
+		monitors[i] = newListMonitor(row)
+	}
+	return monitors, nil
+}
+
+// Unmonitor stops monitoring a list. Audits of the list already queued still run.
+func (s *ListMonitorService) Unmonitor(ctx context.Context, monitorID int64) error {
+	deleted, err := s.db.Queries().DeleteListMonitor(ctx, monitorID)
+	if err != nil {
+		return fmt.Errorf("failed to delete list monitor: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: %d", ErrListMonitorNotFound, monitorID)
+	}
+	s.logger.Info("Stopped monitoring list", "monitor_id", monitorID)
+	return nil
+}
+
+// Check reads the changes made to a monitored list since its last check and, if there are any or the
+// list was never audited, queues an audit of the list. It returns nil when nothing was queued. While
+// the site is being audited it returns ErrAuditAlreadyQueued and leaves the monitor due.
+func (s *ListMonitorService) Check(ctx context.Context, monitorID int64) (*audit.AuditRequest, error) {
+	monitor, err := s.getMonitor(ctx, monitorID)
+	if err != nil {
+		return nil, err
+	}
+	if s.auditService.IsSiteBeingAudited(monitor.SiteURL) {
+		return nil, fmt.Errorf("%w for site: %s", ErrAuditAlreadyQueued, monitor.SiteURL)
+	}
+
+	changed, changeToken, err := s.client.GetChangedItems(ctx, monitor.SiteURL, monitor.ListID, monitor.ChangeToken)
+	if err != nil {
+		return nil, err
+	}
+	now := s.now()
+	nextCheckAt := now.Add(monitor.Interval)
+	if len(changed) == 0 && monitor.LastAuditJobID != "" {
+		if err := s.db.Queries().RecordListMonitorCheck(ctx, db.RecordListMonitorCheckParams{
+			ChangeToken:   changeToken,
+			LastCheckedAt: sql.NullTime{Time: now, Valid: true},
+			NextCheckAt:   nextCheckAt,
+			MonitorID:     monitor.ID,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to record list check: %w", err)
+		}
+		return nil, nil
+	}
+
+	parameters := audit.DefaultParameters()
+	parameters.FolderPath = monitor.ListRootPath
+	request, err := s.auditService.QueueAudit(ctx, monitor.SiteURL, parameters)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Queries().RecordListMonitorAudit(ctx, db.RecordListMonitorAuditParams{
+		ChangeToken:    changeToken,
+		LastCheckedAt:  sql.NullTime{Time: now, Valid: true},
+		NextCheckAt:    nextCheckAt,
+		LastAuditJobID: sql.NullString{String: request.ID, Valid: true},
+		MonitorID:      monitor.ID,
+	}); err != nil {
+		return request, fmt.Errorf("failed to record list audit: %w", err)
+	}
+
+	s.logger.Info("Queued audit of monitored list", "monitor_id", monitor.ID, "job_id", request.ID,
+		"list_title", monitor.ListTitle, "changed_items", len(changed))
+	return request, nil
+}
+
+// CheckDue checks every monitor that is due and returns how many audits were queued. Monitors whose
+// site is being audited, or that wait for database maintenance, stay due for the next call.
+func (s *ListMonitorService) CheckDue(ctx context.Context) (int, error) {
+	rows, err := s.db.ReadQueries().GetDueListMonitors(ctx, s.now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due list monitors: %w", err)
+	}
+
+	queued := 0
+	var errs []error
+	for _, row := range rows {
+		if ctx.Err() != nil {
+			break
+		}
+		request, err := s.Check(ctx, row.MonitorID)
+		switch {
+		case errors.Is(err, ErrAuditAlreadyQueued) || errors.Is(err, ErrMaintenanceRunning):
+			continue
+		case err != nil:
+			errs = append(errs, fmt.Errorf("monitor %d: %w", row.MonitorID, err))
+		case request != nil:
+			queued++
+		}
+	}
+	return queued, errors.Join(errs...)
+}
+
+// Run checks due monitors every listMonitorTick until ctx is cancelled.
+func (s *ListMonitorService) Run(ctx context.Context) {
+	ticker := time.NewTicker(listMonitorTick)
+	defer ticker.Stop()
+	for {
+		if _, err := s.CheckDue(ctx); err != nil {
+			s.logger.Error("Failed to check monitored lists", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// getMonitor returns a list monitor, or ErrListMonitorNotFound.
+func (s *ListMonitorService) getMonitor(ctx context.Context, monitorID int64) (*audit.ListMonitor, error) {
+	row, err := s.db.ReadQueries().GetListMonitor(ctx, monitorID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrListMonitorNotFound, monitorID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list monitor: %w", err)
+	}
+	return newListMonitor(row), nil
+}
+
+// newListMonitor converts a list monitor row.
+func newListMonitor(row db.ListMonitor) *audit.ListMonitor {
+	monitor := &audit.ListMonitor{
+		ID:             row.MonitorID,
+		SiteURL:        row.SiteUrl,
+		ListID:         row.ListID,
+		ListTitle:      row.ListTitle,
+		ListRootPath:   row.ListRootPath,
+		Interval:       time.Duration(row.IntervalSeconds) * time.Second,
+		ChangeToken:    row.ChangeToken,
+		CreatedAt:      row.CreatedAt,
+		NextCheckAt:    row.NextCheckAt,
+		LastAuditJobID: row.LastAuditJobID.String,
+	}
+	if row.LastCheckedAt.Valid {
+		monitor.LastCheckedAt = &row.LastCheckedAt.Time
+	}
+	if row.LastAuditAt.Valid {
+		monitor.LastAuditAt = &row.LastAuditAt.Time
+	}
+	return monitor
+}
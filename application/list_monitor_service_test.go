@@ -0,0 +1,90 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListMonitorService_Monitor(t *testing.T) {
+	const siteURL = "https://contoso.sharepoint.com/sites/finance"
+	ctx := context.Background()
+	webhooks, client, audits := newListWebhookTestService(t, "")
+	service := NewListMonitorService(webhooks.db, audits, client, 0)
+
+	_, err := service.Monitor(ctx, siteURL, "list-1", 30*time.Second)
+	assert.ErrorIs(t, err, ErrInvalidListMonitor)
+
+	monitor, err := service.Monitor(ctx, siteURL+"/", "{list-1}", 0)
+	require.NoError(t, err)
+	assert.Equal(t, siteURL, monitor.SiteURL)
+	assert.Equal(t, "/sites/finance/Shared Documents", monitor.ListRootPath)
+	assert.Equal(t, audit.DefaultListMonitorInterval, monitor.Interval)
+
+	_, err = service.Monitor(ctx, siteURL, "list-1", 0)
+	assert.ErrorIs(t, err, ErrListAlreadyMonitored)
+
+	require.NoError(t, service.Unmonitor(ctx, monitor.ID))
+	assert.ErrorIs(t, service.Unmonitor(ctx, monitor.ID), ErrListMonitorNotFound)
+}
+
+func TestListMonitorService_CheckDue(t *testing.T) {
+	ctx := context.Background()
+	webhooks, client, audits := newListWebhookTestService(t, "")
+	service := NewListMonitorService(webhooks.db, audits, client, 5*time.Minute)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	monitor, err := service.Monitor(ctx, "https://contoso.sharepoint.com/sites/finance", "list-1", 0)
+	require.NoError(t, err)
+
+	t.Run("new monitors audit the list at once", func(t *testing.T) {
+		queued, err := service.CheckDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, queued)
+		require.Len(t, audits.queued, 1)
+		assert.Equal(t, "/sites/finance/Shared Documents", audits.queued[0].FolderPath)
+
+		queued, err = service.CheckDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, queued, "not due again before the interval")
+	})
+
+	t.Run("unchanged lists are not audited", func(t *testing.T) {
+		now = now.Add(5 * time.Minute)
+		queued, err := service.CheckDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, queued)
+		assert.Len(t, audits.queued, 1)
+	})
+
+	t.Run("changed lists wait while the site is audited", func(t *testing.T) {
+		client.changed = []*sharepoint.ChangedItem{{ID: 1, Path: "/sites/finance/Shared Documents/Payroll/March.xlsx"}}
+		now = now.Add(5 * time.Minute)
+		audits.auditing = true
+		queued, err := service.CheckDue(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, queued)
+
+		audits.auditing = false
+		queued, err = service.CheckDue(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, queued, "still due once the audit finishes")
+		require.Len(t, audits.queued, 2)
+		assert.Equal(t, "/sites/finance/Shared Documents", audits.queued[1].FolderPath, "the whole list is audited")
+
+		monitors, err := service.GetMonitors(ctx)
+		require.NoError(t, err)
+		require.Len(t, monitors, 1)
+		assert.Equal(t, monitor.ID, monitors[0].ID)
+		assert.Equal(t, "token-1", monitors[0].ChangeToken)
+		assert.Equal(t, "job-2", monitors[0].LastAuditJobID)
+		assert.Equal(t, now.Add(5*time.Minute), monitors[0].NextCheckAt.UTC())
+	})
+}
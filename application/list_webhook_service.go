@@ -0,0 +1,399 @@
+package application
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// Errors returned when managing list subscriptions.
+var (
+	ErrInvalidListSubscription  = errors.New("invalid list subscription")
+	ErrListAlreadySubscribed    = errors.New("list already subscribed")
+	ErrListSubscriptionNotFound = errors.New("list subscription not found")
+	ErrWebhooksNotConfigured    = errors.New("webhook receiver not configured, set WEBHOOK_PUBLIC_URL")
+)
+
+// Timing of micro-audits and subscription upkeep
+const (
+	listWebhookDebounce      = 30 * time.Second // Groups a burst of notifications for a list into one micro-audit
+	listWebhookSweepInterval = time.Hour        // Renews subscriptions and catches up on changes whose notification was lost
+	listWebhookQueueSize     = 64               // Notified subscriptions waiting for the debounce; more wait for the sweep
+)
+
+// ListWebhookClient manages SharePoint webhook subscriptions to lists and reads their change logs.
+type ListWebhookClient interface {
+	GetList(ctx context.Context, siteURL, listID string) (*sharepoint.List, error)
+	Subscribe(ctx context.Context, siteURL, listID, notificationURL, clientState string, expiration time.Time) (string, time.Time, error)
+	Renew(ctx context.Context, siteURL, listID, subscriptionID string, expiration time.Time) (time.Time, error)
+	Unsubscribe(ctx context.Context, siteURL, listID, subscriptionID string) error
+	GetChangeToken(ctx context.Context, siteURL, listID string) (string, error)
+	GetChangedItems(ctx context.Context, siteURL, listID, changeToken string) ([]*sharepoint.ChangedItem, string, error)
+}
+
+// ListWebhookService subscribes to the changes of high-priority lists and audits what changed in
+// near-real-time between full audits. SharePoint only says that a list changed, so each burst of
+// notifications is followed by reading the list's change log and queueing a micro-audit of the
+// narrowest folder holding the changes.
+type ListWebhookService struct {
+	db              *database.Database
+	auditService    AuditService
+	client          ListWebhookClient
+	notificationURL string // Where SharePoint posts notifications, empty when not reachable
+	debounce        time.Duration
+	now             func() time.Time
+	logger          *logging.Logger
+
+	notified chan string // Subscription IDs with notifications to act on
+}
+
+// NewListWebhookService creates a list webhook service whose subscriptions notify notificationURL.
+// Without a notification URL no new subscriptions can be made.
+func NewListWebhookService(db *database.Database, auditService AuditService, client ListWebhookClient, notificationURL string) *ListWebhookService {
+	return &ListWebhookService{
+		db:              db,
+		auditService:    auditService,
+		client:          client,
+		notificationURL: notificationURL,
+		debounce:        listWebhookDebounce,
+		now:             func() time.Time { return time.Now().UTC() },
+		logger:          logging.Default().WithComponent("list_webhook_service"),
+		notified:        make(chan string, listWebhookQueueSize),
+	}
+}
+
+// Subscribe subscribes to the changes of a list. Changes from now on are micro-audited.
+func (s *ListWebhookService) Subscribe(ctx context.Context, siteURL, listID string) (*audit.ListSubscription, error) {
+	if s.notificationURL == "" {
+		return nil, ErrWebhooksNotConfigured
+	}
+	siteURL = strings.TrimRight(strings.TrimSpace(siteURL), "/")
+	if err := audit.ValidateSiteURL(siteURL); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidListSubscription, err)
+	}
+	listID = strings.Trim(strings.TrimSpace(listID), "{}")
+	if listID == "" {
+		return nil, fmt.Errorf("%w: list_id is required", ErrInvalidListSubscription)
+	}
+
+	if _, err := s.db.ReadQueries().GetListSubscriptionForList(ctx, db.GetListSubscriptionForListParams{SiteUrl: siteURL, ListID: listID}); err == nil {
+		return nil, fmt.Errorf("%w: list %s of %s", ErrListAlreadySubscribed, listID, siteURL)
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check list subscriptions: %w", err)
+	}
+
+	list, err := s.client.GetList(ctx, siteURL, listID)
+	if err != nil {
+		return nil, err
+	}
+	rootPath, err := audit.ResolveFolderPath(siteURL, list.URL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidListSubscription, err)
+	}
+
+	// Changes are read from the token taken before subscribing, so none fall between the two
+	changeToken, err := s.client.GetChangeToken(ctx, siteURL, listID)
+	if err != nil {
+		return nil, err
+	}
+	clientState, err := newLinkToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate client state: %w", err)
+	}
+	now := s.now()
+	subscriptionID, expiresAt, err := s.client.Subscribe(ctx, siteURL, listID, s.notificationURL, clientState, now.Add(audit.ListSubscriptionLifetime))
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := &audit.ListSubscription{
+		ID:           subscriptionID,
+		SiteURL:      siteURL,
+		ListID:       listID,
+		ListTitle:    list.Title,
+		ListRootPath: rootPath,
+		ClientState:  clientState,
+		ChangeToken:  changeToken,
+		ExpiresAt:    expiresAt,
+		CreatedAt:    now,
+	}
+	if err := s.db.Queries().CreateListSubscription(ctx, db.CreateListSubscriptionParams{
+		SubscriptionID: subscription.ID,
+		SiteUrl:        subscription.SiteURL,
+		ListID:         subscription.ListID,
+		ListTitle:      subscription.ListTitle,
+		ListRootPath:   subscription.ListRootPath,
+		ClientState:    subscription.ClientState,
+		ChangeToken:    subscription.ChangeToken,
+		ExpiresAt:      subscription.ExpiresAt,
+		CreatedAt:      subscription.CreatedAt,
+	}); err != nil {
+		if unsubscribeErr := s.client.Unsubscribe(ctx, siteURL, listID, subscriptionID); unsubscribeErr != nil {
+			s.logger.Warn("Failed to remove unrecorded list subscription", "subscription_id", subscriptionID, "error", unsubscribeErr)
+		}
+		return nil, fmt.Errorf("failed to record list subscription: %w", err)
+	}
+
+	s.logger.Info("Subscribed to list changes", "subscription_id", subscription.ID, "site_url", siteURL, "list_title", list.Title, "expires_at", expiresAt)
+	return subscription, nil
+}
+
+// GetSubscriptions returns every list subscription, by site and list title.
+func (s *ListWebhookService) GetSubscriptions(ctx context.Context) ([]*audit.ListSubscription, error) {
+	rows, err := s.db.ReadQueries().GetListSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list subscriptions: %w", err)
+	}
+	subscriptions := make([]*audit.ListSubscription, len(rows))
+	for i, row := range rows {
+		subscriptions[i] = newListSubscription(row)
+	}
+	return subscriptions, nil
+}
+
+// Unsubscribe ends a list subscription. It is forgotten even when SharePoint cannot be told, as
+// notifications for unknown subscriptions are ignored and SharePoint drops it once it lapses.
+func (s *ListWebhookService) Unsubscribe(ctx context.Context, subscriptionID string) error {
+	subscription, err := s.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return err
+	}
+	if err := s.client.Unsubscribe(ctx, subscription.SiteURL, subscription.ListID, subscription.ID); err != nil {
+		s.logger.Warn("Failed to remove list subscription from SharePoint", "subscription_id", subscription.ID, "error", err)
+	}
+	if _, err := s.db.Queries().DeleteListSubscription(ctx, subscription.ID); err != nil {
+		return fmt.Errorf("failed to delete list subscription: %w", err)
+	}
+	s.logger.Info("Unsubscribed from list changes", "subscription_id", subscription.ID, "site_url", subscription.SiteURL, "list_title", subscription.ListTitle)
+	return nil
+}
+
+// HandleNotifications accepts the notifications SharePoint posted and returns how many were for a
+// known subscription and carried its client state. They are acted on by Run once the burst they
+// belong to settles, as SharePoint expects a response within seconds.
+func (s *ListWebhookService) HandleNotifications(ctx context.Context, notifications []sharepoint.ChangeNotification) int {
+	accepted := 0
+	for _, notification := range notifications {
+		subscription, err := s.getSubscription(ctx, notification.SubscriptionID)
+		if err != nil {
+			s.logger.Warn("Ignoring notification for unknown list subscription", "subscription_id", notification.SubscriptionID, "error", err)
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(notification.ClientState), []byte(subscription.ClientState)) != 1 {
+			s.logger.Warn("Ignoring list notification with the wrong client state", "subscription_id", subscription.ID)
+			continue
+		}
+		accepted++
+
+		if err := s.db.Queries().MarkListSubscriptionNotified(ctx, db.MarkListSubscriptionNotifiedParams{
+			LastNotifiedAt: sql.NullTime{Time: s.now(), Valid: true},
+			SubscriptionID: subscription.ID,
+		}); err != nil {
+			s.logger.Warn("Failed to record list notification", "subscription_id", subscription.ID, "error", err)
+		}
+		select {
+		case s.notified <- subscription.ID:
+		default:
+			s.logger.Warn("List notifications backed up, leaving changes to the next sweep", "subscription_id", subscription.ID)
+		}
+	}
+	return accepted
+}
+
+// AuditChanges queues a micro-audit of the list changes made since the subscription's last one and
+// moves the subscription past them. It returns nil without changes to audit. While the site is
+// being audited it returns ErrAuditAlreadyQueued and leaves the changes for a later call.
+func (s *ListWebhookService) AuditChanges(ctx context.Context, subscriptionID string) (*audit.AuditRequest, error) {
+	subscription, err := s.getSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if s.auditService.IsSiteBeingAudited(subscription.SiteURL) {
+		return nil, fmt.Errorf("%w for site: %s", ErrAuditAlreadyQueued, subscription.SiteURL)
+	}
+
+	changed, changeToken, err := s.client.GetChangedItems(ctx, subscription.SiteURL, subscription.ListID, subscription.ChangeToken)
+	if err != nil {
+		return nil, err
+	}
+	folders := make([]string, len(changed))
+	for i, item := range changed {
+		folders[i] = item.Folder()
+	}
+	folder := audit.MicroAuditFolder(subscription.ListRootPath, folders)
+	if folder == "" {
+		if changeToken != subscription.ChangeToken {
+			if err := s.db.Queries().UpdateListSubscriptionChangeToken(ctx, db.UpdateListSubscriptionChangeTokenParams{
+				ChangeToken:    changeToken,
+				SubscriptionID: subscription.ID,
+			}); err != nil {
+				return nil, fmt.Errorf("failed to record change token: %w", err)
+			}
+		}
+		return nil, nil
+	}
+
+	parameters := audit.DefaultParameters()
+	parameters.FolderPath = folder
+	request, err := s.auditService.QueueAudit(ctx, subscription.SiteURL, parameters)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.Queries().RecordListSubscriptionAudit(ctx, db.RecordListSubscriptionAuditParams{
+		ChangeToken:    changeToken,
+		LastAuditJobID: sql.NullString{String: request.ID, Valid: true},
+		LastAuditAt:    sql.NullTime{Time: s.now(), Valid: true},
+		SubscriptionID: subscription.ID,
+	}); err != nil {
+		return request, fmt.Errorf("failed to record micro-audit: %w", err)
+	}
+
+	s.logger.Info("Queued micro-audit of list changes", "subscription_id", subscription.ID, "job_id", request.ID,
+		"list_title", subscription.ListTitle, "changed_items", len(changed), "folder_path", folder)
+	return request, nil
+}
+
+// RenewSubscriptions renews the subscriptions that lapse within audit.ListSubscriptionRenewBefore
+// and returns how many were renewed. A subscription SharePoint already dropped is replaced by a
+// new one that carries on from the same change token.
+func (s *ListWebhookService) RenewSubscriptions(ctx context.Context) (int, error) {
+	subscriptions, err := s.GetSubscriptions(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	renewed := 0
+	var errs []error
+	for _, subscription := range subscriptions {
+		now := s.now()
+		if !subscription.NeedsRenewal(now) {
+			continue
+		}
+		expiration := now.Add(audit.ListSubscriptionLifetime)
+		subscriptionID := subscription.ID
+		expiresAt, err := s.client.Renew(ctx, subscription.SiteURL, subscription.ListID, subscription.ID, expiration)
+		if err != nil && !now.Before(subscription.ExpiresAt) && s.notificationURL != "" {
+			subscriptionID, expiresAt, err = s.client.Subscribe(ctx, subscription.SiteURL, subscription.ListID, s.notificationURL, subscription.ClientState, expiration)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", subscription.ID, err))
+			continue
+		}
+
+		if err := s.db.Queries().RenewListSubscription(ctx, db.RenewListSubscriptionParams{
+			NewSubscriptionID: subscriptionID,
+			ExpiresAt:         expiresAt,
+			SubscriptionID:    subscription.ID,
+		}); err != nil {
+			errs = append(errs, fmt.Errorf("subscription %s: %w", subscription.ID, err))
+			continue
+		}
+		renewed++
+		s.logger.Info("Renewed list subscription", "subscription_id", subscriptionID, "replaced", subscriptionID != subscription.ID,
+			"list_title", subscription.ListTitle, "expires_at", expiresAt)
+	}
+	return renewed, errors.Join(errs...)
+}
+
+// Run micro-audits the changes of notified lists once their notifications settle, and sweeps every
+// listWebhookSweepInterval to renew subscriptions and audit changes whose notification was lost,
+// until ctx is cancelled. Lists whose site is being audited are retried after the debounce.
+func (s *ListWebhookService) Run(ctx context.Context) {
+	pending := make(map[string]bool)
+	var settled <-chan time.Time
+
+	sweep := func() {
+		if _, err := s.RenewSubscriptions(ctx); err != nil {
+			s.logger.Error("Failed to renew list subscriptions", "error", err)
+		}
+		subscriptions, err := s.GetSubscriptions(ctx)
+		if err != nil {
+			s.logger.Error("Failed to get list subscriptions", "error", err)
+			return
+		}
+		for _, subscription := range subscriptions {
+			pending[subscription.ID] = true
+		}
+	}
+	auditPending := func() {
+		for subscriptionID := range pending {
+			if ctx.Err() != nil {
+				return
+			}
+			_, err := s.AuditChanges(ctx, subscriptionID)
+			switch {
+			case errors.Is(err, ErrAuditAlreadyQueued) || errors.Is(err, ErrMaintenanceRunning):
+				continue
+			case err != nil:
+				s.logger.Error("Failed to audit list changes", "subscription_id", subscriptionID, "error", err)
+			}
+			delete(pending, subscriptionID)
+		}
+	}
+
+	sweep()
+	auditPending()
+	ticker := time.NewTicker(listWebhookSweepInterval)
+	defer ticker.Stop()
+	for {
+		if len(pending) > 0 && settled == nil {
+			settled = time.After(s.debounce)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case subscriptionID := <-s.notified:
+			pending[subscriptionID] = true
+		case <-settled:
+			settled = nil
+			auditPending()
+		case <-ticker.C:
+			sweep()
+		}
+	}
+}
+
+// getSubscription returns a list subscription, or ErrListSubscriptionNotFound.
+func (s *ListWebhookService) getSubscription(ctx context.Context, subscriptionID string) (*audit.ListSubscription, error) {
+	row, err := s.db.ReadQueries().GetListSubscription(ctx, subscriptionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %s", ErrListSubscriptionNotFound, subscriptionID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list subscription: %w", err)
+	}
+	return newListSubscription(row), nil
+}
+
+// newListSubscription converts a list subscription row.
+func newListSubscription(row db.ListSubscription) *audit.ListSubscription {
+	subscription := &audit.ListSubscription{
+		ID:             row.SubscriptionID,
+		SiteURL:        row.SiteUrl,
+		ListID:         row.ListID,
+		ListTitle:      row.ListTitle,
+		ListRootPath:   row.ListRootPath,
+		ClientState:    row.ClientState,
+		ChangeToken:    row.ChangeToken,
+		ExpiresAt:      row.ExpiresAt,
+		CreatedAt:      row.CreatedAt,
+		LastAuditJobID: row.LastAuditJobID.String,
+	}
+	if row.LastNotifiedAt.Valid {
+		subscription.LastNotifiedAt = &row.LastNotifiedAt.Time
+	}
+	if row.LastAuditAt.Valid {
+		subscription.LastAuditAt = &row.LastAuditAt.Time
+	}
+	return subscription
+}
@@ -0,0 +1,195 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeListWebhookClient stands in for SharePoint: one document library whose change log holds changed.
+type fakeListWebhookClient struct {
+	changed       []*sharepoint.ChangedItem
+	subscriptions int
+	renewErr      error
+	unsubscribed  []string
+}
+
+func (c *fakeListWebhookClient) GetList(ctx context.Context, siteURL, listID string) (*sharepoint.List, error) {
+	return &sharepoint.List{ID: listID, Title: "Documents", URL: siteURL + "/Shared Documents"}, nil
+}
+
+func (c *fakeListWebhookClient) Subscribe(ctx context.Context, siteURL, listID, notificationURL, clientState string, expiration time.Time) (string, time.Time, error) {
+	c.subscriptions++
+	return fmt.Sprintf("subscription-%d", c.subscriptions), expiration, nil
+}
+
+func (c *fakeListWebhookClient) Renew(ctx context.Context, siteURL, listID, subscriptionID string, expiration time.Time) (time.Time, error) {
+	return expiration, c.renewErr
+}
+
+func (c *fakeListWebhookClient) Unsubscribe(ctx context.Context, siteURL, listID, subscriptionID string) error {
+	c.unsubscribed = append(c.unsubscribed, subscriptionID)
+	return nil
+}
+
+func (c *fakeListWebhookClient) GetChangeToken(ctx context.Context, siteURL, listID string) (string, error) {
+	return "token-0", nil
+}
+
+func (c *fakeListWebhookClient) GetChangedItems(ctx context.Context, siteURL, listID, changeToken string) ([]*sharepoint.ChangedItem, string, error) {
+	return c.changed, fmt.Sprintf("token-%d", len(c.changed)), nil
+}
+
+// fakeMicroAudits records the audits queued by the list webhook service.
+type fakeMicroAudits struct {
+	AuditService
+	auditing bool
+	queued   []*audit.AuditParameters
+}
+
+func (a *fakeMicroAudits) IsSiteBeingAudited(siteURL string) bool {
+	return a.auditing
+}
+
+func (a *fakeMicroAudits) QueueAudit(ctx context.Context, siteURL string, parameters *audit.AuditParameters) (*audit.AuditRequest, error) {
+	a.queued = append(a.queued, parameters)
+	return &audit.AuditRequest{ID: fmt.Sprintf("job-%d", len(a.queued)), SiteURL: siteURL}, nil
+}
+
+func newListWebhookTestService(t *testing.T, notificationURL string) (*ListWebhookService, *fakeListWebhookClient, *fakeMicroAudits) {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	client := &fakeListWebhookClient{}
+	audits := &fakeMicroAudits{}
+	return NewListWebhookService(testDB, audits, client, notificationURL), client, audits
+}
+
+func TestListWebhookService_Subscribe(t *testing.T) {
+	const siteURL = "https://contoso.sharepoint.com/sites/finance"
+	ctx := context.Background()
+
+	unconfigured, _, _ := newListWebhookTestService(t, "")
+	_, err := unconfigured.Subscribe(ctx, siteURL, "list-1")
+	assert.ErrorIs(t, err, ErrWebhooksNotConfigured)
+
+	service, client, _ := newListWebhookTestService(t, "https://spaudit.contoso.com/webhooks/sharepoint")
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	_, err = service.Subscribe(ctx, "contoso.sharepoint.com/sites/finance", "list-1")
+	assert.ErrorIs(t, err, ErrInvalidListSubscription)
+
+	subscription, err := service.Subscribe(ctx, siteURL+"/", "{list-1}")
+	require.NoError(t, err)
+	assert.Equal(t, "subscription-1", subscription.ID)
+	assert.Equal(t, siteURL, subscription.SiteURL)
+	assert.Equal(t, "list-1", subscription.ListID)
+	assert.Equal(t, "/sites/finance/Shared Documents", subscription.ListRootPath)
+	assert.Equal(t, "token-0", subscription.ChangeToken)
+	assert.Len(t, subscription.ClientState, 48)
+	assert.Equal(t, now.Add(audit.ListSubscriptionLifetime), subscription.ExpiresAt)
+
+	_, err = service.Subscribe(ctx, siteURL, "list-1")
+	assert.ErrorIs(t, err, ErrListAlreadySubscribed)
+
+	require.NoError(t, service.Unsubscribe(ctx, subscription.ID))
+	assert.Equal(t, []string{"subscription-1"}, client.unsubscribed)
+	assert.ErrorIs(t, service.Unsubscribe(ctx, subscription.ID), ErrListSubscriptionNotFound)
+}
+
+func TestListWebhookService_AuditChanges(t *testing.T) {
+	ctx := context.Background()
+	service, client, audits := newListWebhookTestService(t, "https://spaudit.contoso.com/webhooks/sharepoint")
+	subscription, err := service.Subscribe(ctx, "https://contoso.sharepoint.com/sites/finance", "list-1")
+	require.NoError(t, err)
+
+	t.Run("notifications need the client state", func(t *testing.T) {
+		accepted := service.HandleNotifications(ctx, []sharepoint.ChangeNotification{
+			{SubscriptionID: subscription.ID, ClientState: subscription.ClientState},
+			{SubscriptionID: subscription.ID, ClientState: "forged"},
+			{SubscriptionID: "unknown", ClientState: subscription.ClientState},
+		})
+		assert.Equal(t, 1, accepted)
+		assert.Equal(t, subscription.ID, <-service.notified)
+
+		subscriptions, err := service.GetSubscriptions(ctx)
+		require.NoError(t, err)
+		require.Len(t, subscriptions, 1)
+		assert.NotNil(t, subscriptions[0].LastNotifiedAt)
+	})
+
+	t.Run("nothing changed", func(t *testing.T) {
+		request, err := service.AuditChanges(ctx, subscription.ID)
+		require.NoError(t, err)
+		assert.Nil(t, request)
+		assert.Empty(t, audits.queued)
+	})
+
+	client.changed = []*sharepoint.ChangedItem{
+		{ID: 1, Path: "/sites/finance/Shared Documents/Payroll/2025/March.xlsx"},
+		{ID: 2, Path: "/sites/finance/Shared Documents/Payroll/2024", IsFolder: true},
+	}
+
+	t.Run("deferred while the site is audited", func(t *testing.T) {
+		audits.auditing = true
+		defer func() { audits.auditing = false }()
+		_, err := service.AuditChanges(ctx, subscription.ID)
+		assert.ErrorIs(t, err, ErrAuditAlreadyQueued)
+		assert.Empty(t, audits.queued)
+	})
+
+	t.Run("micro-audits the folder holding the changes", func(t *testing.T) {
+		request, err := service.AuditChanges(ctx, subscription.ID)
+		require.NoError(t, err)
+		require.NotNil(t, request)
+		require.Len(t, audits.queued, 1)
+		assert.Equal(t, "/sites/finance/Shared Documents/Payroll", audits.queued[0].FolderPath)
+
+		subscriptions, err := service.GetSubscriptions(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "token-2", subscriptions[0].ChangeToken, "audited changes are not audited again")
+		assert.Equal(t, request.ID, subscriptions[0].LastAuditJobID)
+	})
+}
+
+func TestListWebhookService_RenewSubscriptions(t *testing.T) {
+	ctx := context.Background()
+	service, client, _ := newListWebhookTestService(t, "https://spaudit.contoso.com/webhooks/sharepoint")
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	subscription, err := service.Subscribe(ctx, "https://contoso.sharepoint.com/sites/finance", "list-1")
+	require.NoError(t, err)
+
+	renewed, err := service.RenewSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, renewed, "new subscriptions are not renewed")
+
+	service.now = func() time.Time { return subscription.ExpiresAt.Add(-24 * time.Hour) }
+	renewed, err = service.RenewSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, renewed)
+
+	// SharePoint has dropped the lapsed subscription, so it is replaced
+	client.renewErr = errors.New("404 Not Found")
+	lapsedAt := subscription.ExpiresAt.Add(2 * audit.ListSubscriptionLifetime)
+	service.now = func() time.Time { return lapsedAt }
+	renewed, err = service.RenewSubscriptions(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, renewed)
+
+	subscriptions, err := service.GetSubscriptions(ctx)
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 1)
+	assert.Equal(t, "subscription-2", subscriptions[0].ID)
+	assert.Equal(t, subscription.ChangeToken, subscriptions[0].ChangeToken)
+	assert.Equal(t, lapsedAt.Add(audit.ListSubscriptionLifetime), subscriptions[0].ExpiresAt.UTC())
+}
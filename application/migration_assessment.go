@@ -0,0 +1,317 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	"spaudit/logging"
+)
+
+// ErrInvalidMigrationMapping is returned when a migration assessment's URL or principal mappings are invalid.
+var ErrInvalidMigrationMapping = errors.New("invalid migration mapping")
+
+// Migration object statuses
+const (
+	MigrationObjectMissing = "missing" // No explicit permissions at the mapped URL: the object is missing or inherits
+	MigrationObjectDiffers = "differs" // Explicit permissions at the mapped URL differ
+)
+
+// MigrationRunRef names one side of a migration assessment: a site and an audit run ID or alias.
+type MigrationRunRef struct {
+	SiteID   int64
+	AuditRun string
+}
+
+// MigrationSide is the site and resolved audit run on one side of a migration assessment.
+type MigrationSide struct {
+	SiteID     int64
+	SiteURL    string
+	AuditRunID int64
+}
+
+// MigrationPermission is an explicit permission present on only one side of a migration.
+// Missing permissions are described by the source run, extra permissions by the target run.
+type MigrationPermission struct {
+	ObjectType string
+	SourceURL  string // Empty for extra permissions
+	TargetURL  string
+	ObjectName string
+	Principal  *sharepoint.Principal
+	Identity   string // The principal's identity on the target, after principal mappings
+	Role       string
+}
+
+// MigrationObjectGap is a source object with explicit permissions that did not carry over unchanged.
+type MigrationObjectGap struct {
+	ObjectType string
+	SourceURL  string
+	TargetURL  string
+	ObjectName string
+	Status     string
+	Missing    int // Source permissions absent on the target
+	Extra      int // Target permissions absent on the source
+}
+
+// MigrationLinkGap is a kind of sharing link whose count differs between an item and its migrated copy.
+type MigrationLinkGap struct {
+	SourceURL   string // Empty for items only the target shares
+	TargetURL   string
+	ItemName    string
+	LinkKind    int
+	Scope       int
+	SourceLinks int
+	TargetLinks int
+}
+
+// MigrationAssessmentData compares an audit run of a migration's source site with one of its target site.
+// Objects are matched by mapped URL, principals by identity, roles by name and sharing links by kind and scope.
+type MigrationAssessmentData struct {
+	Source             MigrationSide
+	Target             MigrationSide
+	Objects            []*MigrationObjectGap  // Ordered by source URL
+	MissingPermissions []*MigrationPermission // Ordered by URL, identity and role
+	ExtraPermissions   []*MigrationPermission // Ordered by URL, identity and role
+	LinkGaps           []*MigrationLinkGap    // Ordered by URL, kind and scope
+	MatchedPermissions int
+	MatchedLinks       int
+	Unmapped           int // Source permissions and links no URL mapping covers, left out of the comparison
+}
+
+// HasGaps returns true if the target does not reproduce the source's permissions and sharing links.
+func (d *MigrationAssessmentData) HasGaps() bool {
+	return len(d.MissingPermissions) > 0 || len(d.ExtraPermissions) > 0 || len(d.LinkGaps) > 0
+}
+
+// MigrationAssessmentService compares the permissions of a migration's source site with its target site.
+type MigrationAssessmentService struct {
+	serviceFactory AuditRunScopedServiceFactory
+	logger         *logging.Logger
+}
+
+// NewMigrationAssessmentService creates a new migration assessment service.
+func NewMigrationAssessmentService(serviceFactory AuditRunScopedServiceFactory) *MigrationAssessmentService {
+	return &MigrationAssessmentService{
+		serviceFactory: serviceFactory,
+		logger:         logging.Default().WithComponent("migration_assessment_service"),
+	}
+}
+
+// Assess compares a source run with a target run, mapping source URLs and principals to the target's.
+// Limited Access grants and sharing link groups are left out: SharePoint recreates them with the
+// content and links, which are compared directly.
+func (s *MigrationAssessmentService) Assess(ctx context.Context, source, target MigrationRunRef, mapping audit.MigrationMapping) (*MigrationAssessmentData, error) {
+	sourceSide, sourceFacts, err := s.loadSide(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("source: %w", err)
+	}
+	targetSide, targetFacts, err := s.loadSide(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("target: %w", err)
+	}
+
+	mapper, err := audit.NewMigrationMapper(sourceSide.SiteURL, targetSide.SiteURL, mapping)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMigrationMapping, err)
+	}
+
+	data := &MigrationAssessmentData{
+		Source:             *sourceSide,
+		Target:             *targetSide,
+		Objects:            []*MigrationObjectGap{},
+		MissingPermissions: []*MigrationPermission{},
+		ExtraPermissions:   []*MigrationPermission{},
+		LinkGaps:           []*MigrationLinkGap{},
+	}
+	comparePermissions(data, mapper, sourceFacts.Permissions, targetFacts.Permissions)
+	compareSharingLinks(data, mapper, sourceFacts.SharingLinks, targetFacts.SharingLinks)
+
+	s.logger.Info("Migration assessed",
+		"source_site_id", sourceSide.SiteID, "source_audit_run_id", sourceSide.AuditRunID,
+		"target_site_id", targetSide.SiteID, "target_audit_run_id", targetSide.AuditRunID,
+		"missing", len(data.MissingPermissions), "extra", len(data.ExtraPermissions), "link_gaps", len(data.LinkGaps))
+	return data, nil
+}
+
+// loadSide resolves one side's audit run and loads its permission facts.
+func (s *MigrationAssessmentService) loadSide(ctx context.Context, ref MigrationRunRef) (*MigrationSide, *contracts.RunFacts, error) {
+	services, err := s.serviceFactory.CreateForAuditRun(ctx, ref.SiteID, ref.AuditRun)
+	if err != nil {
+		return nil, nil, err
+	}
+	site, err := services.SiteBrowsingService.GetSiteWithMetadata(ctx, ref.SiteID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("site %d: %w", ref.SiteID, err)
+	}
+	facts, err := services.SiteContentService.GetRunFacts(ctx, ref.SiteID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &MigrationSide{SiteID: ref.SiteID, SiteURL: site.Site.URL, AuditRunID: services.AuditRunID}, facts, nil
+}
+
+// migrationPermissionKey identifies a permission on the target: object URL, principal identity and role.
+type migrationPermissionKey struct {
+	url, identity, role string
+}
+
+// comparePermissions reports the explicit permissions present on only one side, and the objects they affect.
+func comparePermissions(data *MigrationAssessmentData, mapper *audit.MigrationMapper, source, target []*contracts.PermissionFact) {
+	targetKeys := map[migrationPermissionKey]bool{}
+	targetObjects := map[string]int{} // Explicit permissions per target URL
+	for _, fact := range target {
+		if ignoredInMigration(fact) || fact.ObjectURL == "" {
+			continue
+		}
+		url := audit.NormalizeMigrationURL(fact.ObjectURL)
+		targetKeys[migrationPermissionKey{url, audit.PrincipalIdentity(fact.Principal.LoginName, fact.Principal.Title), strings.ToLower(fact.Role)}] = true
+		targetObjects[url]++
+	}
+
+	sourceKeys := map[migrationPermissionKey]bool{}
+	sourceObjects := map[string]*contracts.PermissionFact{} // A source fact per mapped URL
+	objects := map[string]*MigrationObjectGap{}
+	objectGap := func(fact *contracts.PermissionFact, targetURL string) *MigrationObjectGap {
+		gap, ok := objects[targetURL]
+		if !ok {
+			gap = &MigrationObjectGap{ObjectType: fact.ObjectType, SourceURL: fact.ObjectURL, TargetURL: targetURL, ObjectName: fact.ObjectName, Status: MigrationObjectDiffers}
+			if targetObjects[targetURL] == 0 {
+				gap.Status = MigrationObjectMissing
+			}
+			objects[targetURL] = gap
+		}
+		return gap
+	}
+
+	for _, fact := range source {
+		if ignoredInMigration(fact) {
+			continue
+		}
+		targetURL, ok := mapper.MapURL(fact.ObjectURL)
+		if !ok || fact.ObjectURL == "" {
+			data.Unmapped++
+			continue
+		}
+		identity := mapper.MapPrincipal(audit.PrincipalIdentity(fact.Principal.LoginName, fact.Principal.Title))
+		key := migrationPermissionKey{targetURL, identity, strings.ToLower(fact.Role)}
+		sourceKeys[key] = true
+		if _, ok := sourceObjects[targetURL]; !ok {
+			sourceObjects[targetURL] = fact
+		}
+		if targetKeys[key] {
+			data.MatchedPermissions++
+			continue
+		}
+		data.MissingPermissions = append(data.MissingPermissions, &MigrationPermission{
+			ObjectType: fact.ObjectType, SourceURL: fact.ObjectURL, TargetURL: targetURL, ObjectName: fact.ObjectName,
+			Principal: fact.Principal, Identity: identity, Role: fact.Role,
+		})
+		objectGap(fact, targetURL).Missing++
+	}
+
+	for _, fact := range target {
+		if ignoredInMigration(fact) || fact.ObjectURL == "" {
+			continue
+		}
+		url := audit.NormalizeMigrationURL(fact.ObjectURL)
+		identity := audit.PrincipalIdentity(fact.Principal.LoginName, fact.Principal.Title)
+		if sourceKeys[migrationPermissionKey{url, identity, strings.ToLower(fact.Role)}] {
+			continue
+		}
+		data.ExtraPermissions = append(data.ExtraPermissions, &MigrationPermission{
+			ObjectType: fact.ObjectType, TargetURL: fact.ObjectURL, ObjectName: fact.ObjectName,
+			Principal: fact.Principal, Identity: identity, Role: fact.Role,
+		})
+		if sourceFact, ok := sourceObjects[url]; ok {
+			objectGap(sourceFact, url).Extra++
+		}
+	}
+
+	for _, gap := range objects {
+		data.Objects = append(data.Objects, gap)
+	}
+	sort.Slice(data.Objects, func(i, j int) bool { return data.Objects[i].SourceURL < data.Objects[j].SourceURL })
+	sortMigrationPermissions(data.MissingPermissions)
+	sortMigrationPermissions(data.ExtraPermissions)
+}
+
+// migrationLinkKey identifies a kind of sharing link on an item on the target.
+type migrationLinkKey struct {
+	url         string
+	kind, scope int
+}
+
+// compareSharingLinks reports the kinds of sharing links whose count differs between an item and its migrated copy.
+// Link URLs and members are tenant-specific, so links are compared by kind and scope only.
+func compareSharingLinks(data *MigrationAssessmentData, mapper *audit.MigrationMapper, source, target []*contracts.SharingLinkFact) {
+	gaps := map[migrationLinkKey]*MigrationLinkGap{}
+	gap := func(key migrationLinkKey, itemName string) *MigrationLinkGap {
+		g, ok := gaps[key]
+		if !ok {
+			g = &MigrationLinkGap{TargetURL: key.url, ItemName: itemName, LinkKind: key.kind, Scope: key.scope}
+			gaps[key] = g
+		}
+		return g
+	}
+
+	for _, link := range source {
+		targetURL, ok := mapper.MapURL(link.ItemURL)
+		if !ok || link.ItemURL == "" {
+			data.Unmapped++
+			continue
+		}
+		g := gap(migrationLinkKey{targetURL, link.LinkKind, link.Scope}, link.ItemName)
+		g.SourceURL = link.ItemURL
+		g.SourceLinks++
+	}
+	for _, link := range target {
+		if link.ItemURL == "" {
+			continue
+		}
+		gap(migrationLinkKey{audit.NormalizeMigrationURL(link.ItemURL), link.LinkKind, link.Scope}, link.ItemName).TargetLinks++
+	}
+
+	for _, g := range gaps {
+		data.MatchedLinks += min(g.SourceLinks, g.TargetLinks)
+		if g.SourceLinks != g.TargetLinks {
+			data.LinkGaps = append(data.LinkGaps, g)
+		}
+	}
+	sort.Slice(data.LinkGaps, func(i, j int) bool {
+		a, b := data.LinkGaps[i], data.LinkGaps[j]
+		if a.TargetURL != b.TargetURL {
+			return a.TargetURL < b.TargetURL
+		}
+		if a.LinkKind != b.LinkKind {
+			return a.LinkKind < b.LinkKind
+		}
+		return a.Scope < b.Scope
+	})
+}
+
+// ignoredInMigration returns true for permissions SharePoint manages itself: Limited Access grants and the
+// groups behind sharing links and Limited Access.
+func ignoredInMigration(fact *contracts.PermissionFact) bool {
+	role := &sharepoint.RoleDefinition{Name: fact.Role}
+	return role.IsLimitedAccess() || fact.Principal.IsSharingLinkPrincipal() ||
+		strings.Contains(fact.Principal.LoginName, "Limited Access System Group")
+}
+
+// sortMigrationPermissions orders permissions by target URL, principal identity and role.
+func sortMigrationPermissions(permissions []*MigrationPermission) {
+	sort.Slice(permissions, func(i, j int) bool {
+		a, b := permissions[i], permissions[j]
+		if a.TargetURL != b.TargetURL {
+			return a.TargetURL < b.TargetURL
+		}
+		if a.Identity != b.Identity {
+			return a.Identity < b.Identity
+		}
+		return a.Role < b.Role
+	})
+}
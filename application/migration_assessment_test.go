@@ -0,0 +1,149 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
+	"spaudit/test/dataset"
+)
+
+const (
+	migrationSourceURL = "https://contoso.sharepoint.com/sites/finance"
+	migrationTargetURL = "https://fabrikam.sharepoint.com/sites/finance-new"
+)
+
+// newMigrationTestService stores the same synthetic site as the source (site 1, run 1) and the target
+// (site 2, run 2) of a migration to another tenant, then changes the target: users moved to fabrikam.com,
+// Library 1 renamed to Documents, an item assignment and a sharing link lost, and a list assignment added.
+func newMigrationTestService(t *testing.T) (*MigrationAssessmentService, *dataset.Dataset) {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	var source *dataset.Dataset
+	for site, siteURL := range map[int64]string{1: migrationSourceURL, 2: migrationTargetURL} {
+		d := dataset.Generate(dataset.Spec{SiteID: site, SiteURL: siteURL, Lists: 2, ItemsPerList: 20, AssignmentsPerItem: 2, Users: 8, UniqueRatio: 0.5, Seed: 3})
+		mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (?, ?, 'Finance')`, site, siteURL)
+		mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES (?, ?, ?, 'site_audit', 'completed')`, fmt.Sprintf("job-%d", site), site, siteURL)
+		mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)`, site, fmt.Sprintf("job-%d", site), site)
+		require.NoError(t, d.Save(context.Background(), repositories.NewSqlcAuditRepository(testDB), site))
+		if site == 1 {
+			source = d
+		}
+	}
+
+	unique := source.UniqueItems()
+	mustExec(t, testDB, `UPDATE principals SET login_name = REPLACE(login_name, '@contoso.com', '@fabrikam.com') WHERE site_id = 2`)
+	mustExec(t, testDB, `UPDATE lists SET url = REPLACE(url, '/Library1', '/Documents') WHERE site_id = 2`)
+	mustExec(t, testDB, `UPDATE items SET url = REPLACE(url, '/Library1/', '/Documents/') WHERE site_id = 2`)
+	mustExec(t, testDB, `DELETE FROM role_assignments WHERE site_id = 2 AND object_key = ? AND principal_id = (
+		SELECT MIN(principal_id) FROM role_assignments WHERE site_id = 2 AND object_key = ?)`, unique[0].GUID, unique[0].GUID)
+	mustExec(t, testDB, `INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (2, 'list', ?, 100, ?, 2)`, source.Lists[1].ID, dataset.RoleEdit)
+
+	// Limited Access is recreated by SharePoint on the target, so the source's grant is not a gap
+	mustExec(t, testDB, `INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'list', ?, 101, ?, 1)`, source.Lists[0].ID, dataset.RoleLimitedAccess)
+
+	links := []struct {
+		site     int64
+		id, item string
+	}{
+		{1, "kept-source", unique[1].GUID},
+		{2, "kept-target", unique[1].GUID},
+		{1, "lost", unique[2].GUID},
+	}
+	for _, link := range links {
+		mustExec(t, testDB, `INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, file_folder_unique_id, link_kind, scope, is_active) VALUES (?, ?, ?, ?, ?, 3, 1, 1)`,
+			link.site, link.id, link.site, link.item, link.item)
+	}
+
+	factory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		repositories.NewSqlcAuditRepository(testDB),
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunAnyStatus,
+	)
+	return NewMigrationAssessmentService(factory), source
+}
+
+func TestMigrationAssessmentService_Assess(t *testing.T) {
+	service, source := newMigrationTestService(t)
+	unique := source.UniqueItems()
+
+	result, err := service.Assess(context.Background(),
+		MigrationRunRef{SiteID: 1, AuditRun: "latest"},
+		MigrationRunRef{SiteID: 2, AuditRun: "latest"},
+		audit.MigrationMapping{
+			URLs:       []audit.PrefixMapping{{Source: migrationSourceURL + "/Library1", Target: migrationTargetURL + "/Documents"}},
+			Principals: []audit.PrefixMapping{{Source: "@contoso.com", Target: "@fabrikam.com"}},
+		})
+	require.NoError(t, err)
+
+	assert.Equal(t, MigrationSide{SiteID: 1, SiteURL: migrationSourceURL, AuditRunID: 1}, result.Source)
+	assert.Equal(t, MigrationSide{SiteID: 2, SiteURL: migrationTargetURL, AuditRunID: 2}, result.Target)
+	assert.True(t, result.HasGaps())
+	assert.Zero(t, result.Unmapped)
+
+	require.Len(t, result.MissingPermissions, 1)
+	missing := result.MissingPermissions[0]
+	assert.Equal(t, sharepoint.ObjectTypeItem, missing.ObjectType)
+	assert.Equal(t, unique[0].URL, missing.SourceURL)
+	assert.Contains(t, missing.Identity, "@fabrikam.com")
+
+	require.Len(t, result.ExtraPermissions, 1)
+	assert.Equal(t, migrationTargetURL+"/Documents", result.ExtraPermissions[0].TargetURL)
+	assert.Equal(t, "user0@fabrikam.com", result.ExtraPermissions[0].Identity)
+
+	require.Len(t, result.Objects, 2)
+	for _, object := range result.Objects {
+		assert.Equal(t, MigrationObjectDiffers, object.Status, object.SourceURL)
+		assert.Equal(t, 1, object.Missing+object.Extra, object.SourceURL)
+	}
+
+	require.Len(t, result.LinkGaps, 1)
+	assert.Equal(t, MigrationLinkGap{
+		SourceURL: unique[2].URL, TargetURL: audit.NormalizeMigrationURL(migrationTargetURL + unique[2].URL[len(migrationSourceURL):]),
+		ItemName: unique[2].Name, LinkKind: 3, Scope: 1, SourceLinks: 1,
+	}, *result.LinkGaps[0])
+	assert.Equal(t, 1, result.MatchedLinks)
+	assert.Positive(t, result.MatchedPermissions)
+}
+
+func TestMigrationAssessmentService_Assess_WithoutPrincipalMapping(t *testing.T) {
+	service, _ := newMigrationTestService(t)
+
+	result, err := service.Assess(context.Background(), MigrationRunRef{SiteID: 1, AuditRun: "1"}, MigrationRunRef{SiteID: 2, AuditRun: "2"}, audit.MigrationMapping{})
+	require.NoError(t, err)
+
+	// Users kept their contoso.com identities, and Library 1 was not mapped to Documents
+	for _, permission := range result.MissingPermissions {
+		if permission.Principal.IsUser() {
+			assert.Contains(t, permission.Identity, "@contoso.com")
+		}
+	}
+	assert.Greater(t, len(result.MissingPermissions), 1)
+	assert.Contains(t, result.Objects, &MigrationObjectGap{
+		ObjectType: sharepoint.ObjectTypeList, SourceURL: migrationSourceURL + "/Library1", TargetURL: migrationTargetURL + "/library1",
+		ObjectName: "Library 1", Status: MigrationObjectMissing, Missing: 3,
+	})
+}
+
+func TestMigrationAssessmentService_Assess_Errors(t *testing.T) {
+	service, _ := newMigrationTestService(t)
+	ctx := context.Background()
+
+	_, err := service.Assess(ctx, MigrationRunRef{SiteID: 1, AuditRun: "1"}, MigrationRunRef{SiteID: 2, AuditRun: "2"},
+		audit.MigrationMapping{URLs: []audit.PrefixMapping{{Source: "/sites/finance", Target: migrationTargetURL}}})
+	assert.ErrorIs(t, err, ErrInvalidMigrationMapping)
+
+	_, err = service.Assess(ctx, MigrationRunRef{SiteID: 1, AuditRun: "2"}, MigrationRunRef{SiteID: 2, AuditRun: "2"}, audit.MigrationMapping{})
+	assert.ErrorIs(t, err, contracts.ErrSiteScopeMismatch, "run 2 belongs to the target site")
+}
@@ -0,0 +1,508 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/jobs"
+	"spaudit/domain/sharepoint"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// Errors returned when running offboarding checks and tracking their remediation.
+var (
+	ErrOffboardingCheckNotFound       = errors.New("offboarding check not found")
+	ErrOffboardingReportNotReady      = errors.New("offboarding report not ready")
+	ErrOffboardingRemediationNotFound = errors.New("offboarding remediation not found")
+	ErrInvalidRemediationStatus       = errors.New("invalid remediation status")
+)
+
+// OffboardingService checks what a leaver still has across the latest run of every audited site: the
+// access their accounts hold, the sharing links they created and the items their sensitivity labels
+// name them the owner of. Each check runs as a job and keeps its report for download; it can also
+// queue the actions that would take that access away, for an administrator to carry out and record.
+type OffboardingService struct {
+	db             *database.Database
+	jobService     JobService
+	siteBrowsing   *SiteBrowsingService
+	serviceFactory AuditRunScopedServiceFactory
+	artifacts      *RunArtifactService
+	now            func() time.Time
+	logger         *logging.Logger
+
+	mu sync.Mutex // Held while a check is started, so its row exists before the job reads it
+}
+
+// NewOffboardingService creates a new offboarding service attaching each site's findings to the run
+// they were read from through the artifact service.
+func NewOffboardingService(db *database.Database, jobService JobService, siteBrowsing *SiteBrowsingService, serviceFactory AuditRunScopedServiceFactory, artifacts *RunArtifactService) *OffboardingService {
+	return &OffboardingService{
+		db:             db,
+		jobService:     jobService,
+		siteBrowsing:   siteBrowsing,
+		serviceFactory: serviceFactory,
+		artifacts:      artifacts,
+		now:            func() time.Time { return time.Now().UTC() },
+		logger:         logging.Default().WithComponent("offboarding_service"),
+	}
+}
+
+// StartCheck queues an offboarding check of the principal with the given account, claims login name
+// or email address, queuing remediation actions for what it finds when remediate is set.
+func (s *OffboardingService) StartCheck(ctx context.Context, identity string, remediate bool) (*audit.OffboardingCheck, error) {
+	identity = audit.PrincipalIdentity(identity, "")
+	if identity == "" {
+		return nil, ErrInvalidPrincipalIdentity
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, err := s.jobService.StartJob(jobs.JobTypeOffboardingCheck, JobParams{"description": "Offboarding check for " + identity})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start job: %w", err)
+	}
+	check := &audit.OffboardingCheck{JobID: job.ID, Identity: identity, Remediate: remediate, StartedAt: s.now()}
+	check.ID, err = s.db.Queries().CreateOffboardingCheck(ctx, db.CreateOffboardingCheckParams{
+		JobID:     check.JobID,
+		Identity:  check.Identity,
+		Remediate: check.Remediate,
+		StartedAt: check.StartedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record offboarding check: %w", err)
+	}
+
+	s.logger.Info("Offboarding check queued", "check_id", check.ID, "job_id", job.ID, "identity", identity, "remediate", remediate)
+	return check, nil
+}
+
+// RunCheck runs the offboarding check of a job and records its report, and its remediation actions
+// when it was asked to queue them. A check that failed part way is recorded with its error and
+// queues nothing.
+func (s *OffboardingService) RunCheck(ctx context.Context, jobID string, progress ProgressCallback) (*audit.OffboardingCheck, error) {
+	// Read once StartCheck has recorded the check
+	s.mu.Lock()
+	row, err := s.db.ReadQueries().GetOffboardingCheckForJob(ctx, jobID)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offboarding check of job %s: %w", jobID, err)
+	}
+	check := newOffboardingCheck(db.GetOffboardingChecksRow(row))
+
+	report := &audit.OffboardingReport{
+		Identity:    check.Identity,
+		GeneratedAt: s.now(),
+		Findings:    []*audit.OffboardingFinding{},
+		Skipped:     []*audit.OffboardingSkippedSite{},
+	}
+	var remediations []*audit.OffboardingRemediation
+	runErr := s.checkSites(ctx, check, report, &remediations, progress)
+	if runErr != nil {
+		check.Error = runErr.Error()
+		remediations = nil
+	}
+	completedAt := s.now()
+	check.CompletedAt = &completedAt
+	check.Access = report.Count(audit.OffboardingAccess)
+	check.LinksCreated = report.Count(audit.OffboardingLinkCreated)
+	check.ItemsOwned = report.Count(audit.OffboardingItemOwned)
+	check.Remediations = len(remediations)
+
+	// Recorded even when the job was cancelled, so the check does not stay open
+	if err := s.completeCheck(context.WithoutCancel(ctx), check, report, remediations); err != nil {
+		return check, err
+	}
+	if runErr == nil {
+		s.attachSiteReports(context.WithoutCancel(ctx), check, report)
+	}
+	s.logger.Info("Offboarding check finished",
+		"check_id", check.ID,
+		"identity", check.Identity,
+		"access", check.Access,
+		"links_created", check.LinksCreated,
+		"items_owned", check.ItemsOwned,
+		"remediations", check.Remediations,
+		"error", check.Error)
+	return check, runErr
+}
+
+// checkSites adds what the leaver still has on each site to the report, and the actions taking it
+// away to remediations when the check queues them.
+func (s *OffboardingService) checkSites(ctx context.Context, check *audit.OffboardingCheck, report *audit.OffboardingReport, remediations *[]*audit.OffboardingRemediation, progress ProgressCallback) error {
+	sites, err := s.siteBrowsing.GetAllSitesWithMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	progress("checking", fmt.Sprintf("Checking %d sites", len(sites)), 0, 0, len(sites))
+	for i, site := range sites {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if site.Site == nil {
+			continue
+		}
+		findings, actions, err := s.checkSite(ctx, site.Site, check)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			report.Skipped = append(report.Skipped, &audit.OffboardingSkippedSite{SiteID: site.Site.ID, SiteURL: site.Site.URL, Reason: err.Error()})
+		} else {
+			report.SitesScanned++
+			report.Findings = append(report.Findings, findings...)
+			if check.Remediate {
+				*remediations = append(*remediations, actions...)
+			}
+		}
+		progress("checking", fmt.Sprintf("%d of %d sites checked", i+1, len(sites)), (i+1)*100/len(sites), i+1, len(sites))
+	}
+
+	sort.SliceStable(report.Findings, func(i, j int) bool {
+		a, b := report.Findings[i], report.Findings[j]
+		if a.SiteURL != b.SiteURL {
+			return a.SiteURL < b.SiteURL
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.ObjectURL < b.ObjectURL
+	})
+	progress("completed", "Offboarding check finished", 100, len(sites), len(sites))
+	return nil
+}
+
+// checkSite returns what the leaver still has on a site's latest run, and the actions that would take
+// it away.
+func (s *OffboardingService) checkSite(ctx context.Context, site *sharepoint.Site, check *audit.OffboardingCheck) ([]*audit.OffboardingFinding, []*audit.OffboardingRemediation, error) {
+	services, err := s.serviceFactory.CreateForAuditRun(ctx, site.ID, audit.RunAliasLatest)
+	if err != nil {
+		return nil, nil, err
+	}
+	content := services.SiteContentService
+	access, err := siteAccess(ctx, content, site, services.AuditRunID, check.Identity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var findings []*audit.OffboardingFinding
+	var actions []*audit.OffboardingRemediation
+	finding := func(kind, objectType, objectURL, objectName string) *audit.OffboardingFinding {
+		f := &audit.OffboardingFinding{
+			Kind:       kind,
+			SiteID:     site.ID,
+			SiteURL:    site.URL,
+			AuditRunID: services.AuditRunID,
+			ObjectType: objectType,
+			ObjectURL:  objectURL,
+			ObjectName: objectName,
+		}
+		findings = append(findings, f)
+		return f
+	}
+	action := func(name string, principalID int64, detail string) *audit.OffboardingRemediation {
+		a := &audit.OffboardingRemediation{
+			CheckID:     check.ID,
+			SiteID:      site.ID,
+			SiteURL:     site.URL,
+			AuditRunID:  services.AuditRunID,
+			Action:      name,
+			PrincipalID: principalID,
+			Detail:      detail,
+			Status:      audit.RemediationQueued,
+		}
+		actions = append(actions, a)
+		return a
+	}
+
+	// Only what the leaver's own accounts hold can be taken away in SharePoint; access through Entra
+	// groups is removed in Entra
+	accounts := map[int64]bool{}
+	for _, account := range access.Accounts {
+		accounts[account.ID] = true
+	}
+
+	for _, grant := range access.Grants {
+		f := finding(audit.OffboardingAccess, grant.ObjectType, grant.ObjectURL, grant.ObjectName)
+		f.Role, f.Through, f.Via = grant.Role, grant.Through, grant.Via
+		if !accounts[grant.PrincipalID] {
+			continue
+		}
+		switch grant.Through {
+		case PrincipalAccessDirect:
+			a := action(audit.RemediationRemoveAssignment, grant.PrincipalID, grant.Role)
+			a.ObjectType, a.ObjectKey, a.ObjectURL = grant.ObjectType, grant.ObjectKey, grant.ObjectURL
+		case PrincipalAccessSharingLink:
+			a := action(audit.RemediationRemoveLinkMember, grant.PrincipalID, grant.Role)
+			a.ObjectType, a.ObjectKey, a.ObjectURL, a.LinkID = grant.ObjectType, grant.ObjectKey, grant.ObjectURL, grant.Via
+		}
+	}
+	for _, membership := range access.Memberships {
+		if accounts[membership.MemberID] {
+			a := action(audit.RemediationRemoveGroupMember, membership.MemberID, membership.Group)
+			a.GroupID = membership.GroupID
+		}
+	}
+
+	emails := []string{}
+	if strings.Contains(check.Identity, "@") {
+		emails = append(emails, check.Identity)
+	}
+	for _, account := range access.Accounts {
+		links, err := content.GetSharingLinksCreatedBy(ctx, site.ID, account.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, link := range links {
+			f := finding(audit.OffboardingLinkCreated, sharepoint.ObjectTypeItem, link.ItemURL, link.ItemName)
+			f.Role, f.Via = sharepoint.LinkKindName(link.LinkKind), link.LinkID
+			a := action(audit.RemediationRevokeLink, account.ID, f.Role)
+			a.ObjectType, a.ObjectKey, a.ObjectURL, a.LinkID = sharepoint.ObjectTypeItem, link.ItemGUID, link.ItemURL, link.LinkID
+		}
+		if email := strings.ToLower(strings.TrimSpace(account.Email)); email != "" {
+			emails = append(emails, email)
+		}
+	}
+
+	owned := map[string]bool{}
+	for _, email := range emails {
+		items, err := content.GetItemsOwnedBy(ctx, site.ID, email)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, item := range items {
+			if owned[item.ItemGUID] {
+				continue
+			}
+			owned[item.ItemGUID] = true
+			f := finding(audit.OffboardingItemOwned, sharepoint.ObjectTypeItem, item.ItemURL, item.ItemName)
+			f.Via = item.Label
+		}
+	}
+	return findings, actions, nil
+}
+
+// completeCheck records the outcome of a check with its report and remediation actions.
+func (s *OffboardingService) completeCheck(ctx context.Context, check *audit.OffboardingCheck, report *audit.OffboardingReport, remediations []*audit.OffboardingRemediation) error {
+	encoded, err := json.Marshal(report)
+	if err != nil {
+		return fmt.Errorf("failed to encode offboarding report: %w", err)
+	}
+
+	err = s.db.WithTx(func(queries *db.Queries) error {
+		for _, remediation := range remediations {
+			remediation.QueuedAt = *check.CompletedAt
+			err := queries.CreateOffboardingRemediation(ctx, db.CreateOffboardingRemediationParams{
+				CheckID:     remediation.CheckID,
+				SiteID:      remediation.SiteID,
+				SiteUrl:     remediation.SiteURL,
+				AuditRunID:  remediation.AuditRunID,
+				Action:      remediation.Action,
+				PrincipalID: remediation.PrincipalID,
+				ObjectType:  sql.NullString{String: remediation.ObjectType, Valid: remediation.ObjectType != ""},
+				ObjectKey:   sql.NullString{String: remediation.ObjectKey, Valid: remediation.ObjectKey != ""},
+				ObjectUrl:   sql.NullString{String: remediation.ObjectURL, Valid: remediation.ObjectURL != ""},
+				GroupID:     sql.NullInt64{Int64: remediation.GroupID, Valid: remediation.GroupID != 0},
+				LinkID:      sql.NullString{String: remediation.LinkID, Valid: remediation.LinkID != ""},
+				Detail:      sql.NullString{String: remediation.Detail, Valid: remediation.Detail != ""},
+				Status:      remediation.Status,
+				QueuedAt:    remediation.QueuedAt,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return queries.CompleteOffboardingCheck(ctx, db.CompleteOffboardingCheckParams{
+			CompletedAt:  sql.NullTime{Time: *check.CompletedAt, Valid: true},
+			AccessCount:  int64(check.Access),
+			LinksCreated: int64(check.LinksCreated),
+			ItemsOwned:   int64(check.ItemsOwned),
+			Remediations: int64(check.Remediations),
+			Report:       sql.NullString{String: string(encoded), Valid: true},
+			Error:        sql.NullString{String: check.Error, Valid: check.Error != ""},
+			CheckID:      check.ID,
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record offboarding check result: %w", err)
+	}
+	return nil
+}
+
+// attachSiteReports attaches the findings on each site to the audit run they were read from, as a JSON
+// artifact downloadable with the run's other reports. The check already holds the whole report, so a
+// site report failing to attach is only logged.
+func (s *OffboardingService) attachSiteReports(ctx context.Context, check *audit.OffboardingCheck, report *audit.OffboardingReport) {
+	if s.artifacts == nil {
+		return
+	}
+
+	var sites []*audit.OffboardingReport
+	bySite := map[int64]*audit.OffboardingReport{}
+	for _, finding := range report.Findings {
+		siteReport, ok := bySite[finding.SiteID]
+		if !ok {
+			siteReport = &audit.OffboardingReport{
+				Identity:     report.Identity,
+				GeneratedAt:  report.GeneratedAt,
+				SitesScanned: 1,
+				Findings:     []*audit.OffboardingFinding{},
+				Skipped:      []*audit.OffboardingSkippedSite{},
+			}
+			bySite[finding.SiteID] = siteReport
+			sites = append(sites, siteReport)
+		}
+		siteReport.Findings = append(siteReport.Findings, finding)
+	}
+
+	for _, siteReport := range sites {
+		site := siteReport.Findings[0]
+		encoded, err := json.Marshal(siteReport)
+		if err != nil {
+			s.logger.Warn("Failed to encode offboarding site report", "check_id", check.ID, "site_id", site.SiteID, "error", err)
+			continue
+		}
+		filename := fmt.Sprintf("offboarding-check%d-site%d-run%d.json", check.ID, site.SiteID, site.AuditRunID)
+		if _, err := s.artifacts.RegisterArtifact(ctx, site.SiteID, site.AuditRunID, filename, "application/json", encoded); err != nil {
+			s.logger.Warn("Failed to attach offboarding site report", "check_id", check.ID, "site_id", site.SiteID, "audit_run_id", site.AuditRunID, "error", err)
+		}
+	}
+}
+
+// ListChecks returns the most recent offboarding checks, newest first.
+func (s *OffboardingService) ListChecks(ctx context.Context, limit int64) ([]*audit.OffboardingCheck, error) {
+	rows, err := s.db.ReadQueries().GetOffboardingChecks(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offboarding checks: %w", err)
+	}
+	checks := make([]*audit.OffboardingCheck, len(rows))
+	for i, row := range rows {
+		checks[i] = newOffboardingCheck(row)
+	}
+	return checks, nil
+}
+
+// GetCheck returns an offboarding check, or ErrOffboardingCheckNotFound.
+func (s *OffboardingService) GetCheck(ctx context.Context, checkID int64) (*audit.OffboardingCheck, error) {
+	row, err := s.db.ReadQueries().GetOffboardingCheck(ctx, checkID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrOffboardingCheckNotFound, checkID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offboarding check: %w", err)
+	}
+	return newOffboardingCheck(db.GetOffboardingChecksRow(row)), nil
+}
+
+// GetReport returns what an offboarding check found, or ErrOffboardingReportNotReady while it runs.
+func (s *OffboardingService) GetReport(ctx context.Context, checkID int64) (*audit.OffboardingReport, error) {
+	encoded, err := s.db.ReadQueries().GetOffboardingCheckReport(ctx, checkID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrOffboardingCheckNotFound, checkID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offboarding report: %w", err)
+	}
+	if !encoded.Valid {
+		return nil, fmt.Errorf("%w: check %d", ErrOffboardingReportNotReady, checkID)
+	}
+	var report audit.OffboardingReport
+	if err := json.Unmarshal([]byte(encoded.String), &report); err != nil {
+		return nil, fmt.Errorf("failed to decode report of offboarding check %d: %w", checkID, err)
+	}
+	return &report, nil
+}
+
+// GetRemediations returns the remediation actions an offboarding check queued, in the order queued.
+func (s *OffboardingService) GetRemediations(ctx context.Context, checkID int64) ([]*audit.OffboardingRemediation, error) {
+	if _, err := s.GetCheck(ctx, checkID); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.ReadQueries().GetOffboardingRemediations(ctx, checkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get offboarding remediations: %w", err)
+	}
+	remediations := make([]*audit.OffboardingRemediation, len(rows))
+	for i, row := range rows {
+		remediations[i] = newOffboardingRemediation(row)
+	}
+	return remediations, nil
+}
+
+// UpdateRemediationStatus records the outcome of a remediation action an administrator carried out
+// or dismissed.
+func (s *OffboardingService) UpdateRemediationStatus(ctx context.Context, checkID, remediationID int64, status string) error {
+	if err := audit.ValidateRemediationStatus(status); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRemediationStatus, err)
+	}
+	updated, err := s.db.Queries().UpdateOffboardingRemediationStatus(ctx, db.UpdateOffboardingRemediationStatusParams{
+		Status:        status,
+		UpdatedAt:     sql.NullTime{Time: s.now(), Valid: true},
+		CheckID:       checkID,
+		RemediationID: remediationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update offboarding remediation: %w", err)
+	}
+	if updated == 0 {
+		return fmt.Errorf("%w: %d of check %d", ErrOffboardingRemediationNotFound, remediationID, checkID)
+	}
+	s.logger.Info("Offboarding remediation updated", "check_id", checkID, "remediation_id", remediationID, "status", status)
+	return nil
+}
+
+// newOffboardingCheck converts an offboarding check row.
+func newOffboardingCheck(row db.GetOffboardingChecksRow) *audit.OffboardingCheck {
+	check := &audit.OffboardingCheck{
+		ID:           row.CheckID,
+		JobID:        row.JobID,
+		Identity:     row.Identity,
+		Remediate:    row.Remediate,
+		StartedAt:    row.StartedAt,
+		Access:       int(row.AccessCount),
+		LinksCreated: int(row.LinksCreated),
+		ItemsOwned:   int(row.ItemsOwned),
+		Remediations: int(row.Remediations),
+		Error:        row.Error.String,
+	}
+	if row.CompletedAt.Valid {
+		check.CompletedAt = &row.CompletedAt.Time
+	}
+	return check
+}
+
+// newOffboardingRemediation converts an offboarding remediation row.
+func newOffboardingRemediation(row db.OffboardingRemediation) *audit.OffboardingRemediation {
+	remediation := &audit.OffboardingRemediation{
+		ID:          row.RemediationID,
+		CheckID:     row.CheckID,
+		SiteID:      row.SiteID,
+		SiteURL:     row.SiteUrl,
+		AuditRunID:  row.AuditRunID,
+		Action:      row.Action,
+		PrincipalID: row.PrincipalID,
+		ObjectType:  row.ObjectType.String,
+		ObjectKey:   row.ObjectKey.String,
+		ObjectURL:   row.ObjectUrl.String,
+		GroupID:     row.GroupID.Int64,
+		LinkID:      row.LinkID.String,
+		Detail:      row.Detail.String,
+		Status:      row.Status,
+		QueuedAt:    row.QueuedAt,
+	}
+	if row.UpdatedAt.Valid {
+		remediation.UpdatedAt = &row.UpdatedAt.Time
+	}
+	return remediation
+}
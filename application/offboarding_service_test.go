@@ -0,0 +1,148 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/jobs"
+)
+
+// fakeOffboardingJobs stands in for the job service, leaving queued checks for the test to run.
+type fakeOffboardingJobs struct {
+	JobService
+	started []*jobs.Job
+}
+
+func (f *fakeOffboardingJobs) StartJob(jobType jobs.JobType, params JobParams) (*jobs.Job, error) {
+	job := &jobs.Job{ID: fmt.Sprintf("%s-%d", jobType, len(f.started)+1), Type: jobType, Status: jobs.JobStatusPending}
+	f.started = append(f.started, job)
+	return job, nil
+}
+
+// newOffboardingTestService stores the sites of newPrincipalAccessTestService, where on site 1 user 0
+// also created a sharing link on the second item and owns the third through its sensitivity label.
+func newOffboardingTestService(t *testing.T) (*OffboardingService, *fakeOffboardingJobs) {
+	t.Helper()
+	testDB, siteBrowsing, factory, d := newPrincipalAccessTestSites(t)
+	mustExec(t, testDB, `INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, file_folder_unique_id, link_kind, scope, is_active, created_by_principal_id) VALUES (1, 'link-2', 1, ?, ?, 4, 2, 1, 100)`,
+		d.Items[1].GUID, d.Items[1].GUID)
+	mustExec(t, testDB, `INSERT INTO sensitivity_labels (site_id, item_guid, audit_run_id, display_name, owner_email) VALUES (1, ?, 1, 'Confidential', 'User0@Contoso.com')`, d.Items[2].GUID)
+
+	fake := &fakeOffboardingJobs{}
+	return NewOffboardingService(testDB, fake, siteBrowsing, factory, NewRunArtifactService(testDB, 0)), fake
+}
+
+func TestOffboardingService_RunCheck(t *testing.T) {
+	service, fake := newOffboardingTestService(t)
+	ctx := context.Background()
+
+	_, err := service.StartCheck(ctx, "  ", true)
+	require.ErrorIs(t, err, ErrInvalidPrincipalIdentity)
+
+	started, err := service.StartCheck(ctx, "i:0#.f|membership|User0@contoso.com", true)
+	require.NoError(t, err)
+	require.Len(t, fake.started, 1)
+	assert.Equal(t, jobs.JobTypeOffboardingCheck, fake.started[0].Type)
+	assert.Equal(t, "user0@contoso.com", started.Identity)
+
+	var stages []string
+	progress := func(stage, description string, percentage, itemsDone, itemsTotal int) {
+		stages = append(stages, stage)
+	}
+	check, err := service.RunCheck(ctx, started.JobID, progress)
+	require.NoError(t, err)
+	assert.Equal(t, "completed", stages[len(stages)-1])
+	assert.Equal(t, started.ID, check.ID)
+	assert.NotNil(t, check.CompletedAt)
+	assert.Positive(t, check.Access)
+	assert.Equal(t, 1, check.LinksCreated)
+	assert.Equal(t, 1, check.ItemsOwned)
+
+	report, err := service.GetReport(ctx, check.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.SitesScanned)
+	require.Len(t, report.Skipped, 1, "the site never audited")
+	assert.Equal(t, check.Access, report.Count(audit.OffboardingAccess))
+	direct := 0
+	for _, finding := range report.Findings {
+		assert.Equal(t, int64(1), finding.SiteID)
+		switch finding.Kind {
+		case audit.OffboardingAccess:
+			if finding.Through == PrincipalAccessDirect {
+				direct++
+			}
+		case audit.OffboardingLinkCreated:
+			assert.Equal(t, "link-2", finding.Via)
+		case audit.OffboardingItemOwned:
+			assert.Equal(t, "Confidential", finding.Via)
+		}
+	}
+
+	artifacts, err := service.artifacts.ListArtifacts(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, artifacts, 1, "the findings are attached to the run they were read from")
+	assert.Equal(t, fmt.Sprintf("offboarding-check%d-site1-run1.json", check.ID), artifacts[0].Filename)
+	_, content, err := service.artifacts.GetArtifact(ctx, 1, 1, artifacts[0].ID)
+	require.NoError(t, err)
+	var siteReport audit.OffboardingReport
+	require.NoError(t, json.Unmarshal(content, &siteReport))
+	assert.Equal(t, report.Findings, siteReport.Findings)
+
+	remediations, err := service.GetRemediations(ctx, check.ID)
+	require.NoError(t, err)
+	assert.Len(t, remediations, check.Remediations)
+	actions := map[string]int{}
+	for _, remediation := range remediations {
+		assert.Equal(t, int64(100), remediation.PrincipalID, "only the leaver's own account is remediated")
+		assert.Equal(t, audit.RemediationQueued, remediation.Status)
+		actions[remediation.Action]++
+		switch remediation.Action {
+		case audit.RemediationRemoveLinkMember:
+			assert.Equal(t, "link-1", remediation.LinkID)
+		case audit.RemediationRevokeLink:
+			assert.Equal(t, "link-2", remediation.LinkID)
+		case audit.RemediationRemoveGroupMember:
+			assert.Equal(t, int64(5), remediation.GroupID)
+		}
+	}
+	assert.Equal(t, direct, actions[audit.RemediationRemoveAssignment])
+	assert.Equal(t, 1, actions[audit.RemediationRemoveGroupMember], "the Entra group is left to Entra")
+	assert.Equal(t, 1, actions[audit.RemediationRemoveLinkMember])
+	assert.Equal(t, 1, actions[audit.RemediationRevokeLink])
+
+	require.NoError(t, service.UpdateRemediationStatus(ctx, check.ID, remediations[0].ID, audit.RemediationCompleted))
+	require.ErrorIs(t, service.UpdateRemediationStatus(ctx, check.ID, remediations[0].ID, "done"), ErrInvalidRemediationStatus)
+	require.ErrorIs(t, service.UpdateRemediationStatus(ctx, check.ID+1, remediations[0].ID, audit.RemediationDismissed), ErrOffboardingRemediationNotFound)
+	remediations, err = service.GetRemediations(ctx, check.ID)
+	require.NoError(t, err)
+	assert.Equal(t, audit.RemediationCompleted, remediations[0].Status)
+	assert.NotNil(t, remediations[0].UpdatedAt)
+}
+
+func TestOffboardingService_RunCheckWithoutRemediation(t *testing.T) {
+	service, _ := newOffboardingTestService(t)
+	ctx := context.Background()
+
+	started, err := service.StartCheck(ctx, "user0@contoso.com", false)
+	require.NoError(t, err)
+	_, err = service.GetReport(ctx, started.ID)
+	require.ErrorIs(t, err, ErrOffboardingReportNotReady)
+
+	check, err := service.RunCheck(ctx, started.JobID, func(string, string, int, int, int) {})
+	require.NoError(t, err)
+	assert.Positive(t, check.Access)
+	assert.Zero(t, check.Remediations)
+
+	checks, err := service.ListChecks(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, checks, 1)
+	assert.Equal(t, check.Access, checks[0].Access)
+	_, err = service.GetCheck(ctx, check.ID+1)
+	require.ErrorIs(t, err, ErrOffboardingCheckNotFound)
+}
@@ -0,0 +1,412 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// Errors returned when managing organizational unit mappings.
+var (
+	ErrInvalidOrgUnitMapping  = errors.New("invalid org unit mapping")
+	ErrOrgUnitMappingNotFound = errors.New("org unit mapping not found")
+)
+
+// DefaultOrgUnitStaleAfter is how old a site's latest audit run may be before its scorecard counts the
+// site as stale.
+const DefaultOrgUnitStaleAfter = 30 * 24 * time.Hour
+
+// AuditRunReader reads the audit runs of a site.
+type AuditRunReader interface {
+	GetAuditRun(ctx context.Context, siteID, auditRunID int64) (*audit.AuditRun, error)
+}
+
+// OrgUnitSite is a site's organizational unit and the risk, external access and freshness of its
+// latest audit run. Risk is nil for a site never audited.
+type OrgUnitSite struct {
+	Site         *sharepoint.Site
+	Source       string // Match type of the mapping that assigned the unit, audit.OrgUnitSourceHub, or empty
+	AuditRunID   int64
+	AuditedAt    *time.Time // When the latest run completed, or started if it has not
+	Stale        bool
+	Risk         *SiteRiskSummaryData
+	GuestUsers   int
+	HubSiteID    string // Empty when the site is associated with no hub
+	HubTitle     string
+	HubURL       string
+	RunLabel     string
+	NeverAudited bool
+}
+
+// HasExternalAccess returns true if people outside the organization can reach the site's content:
+// guests were granted access or anonymous links exist.
+func (s *OrgUnitSite) HasExternalAccess() bool {
+	return s.GuestUsers > 0 || (s.Risk != nil && s.Risk.AnonymousLinks > 0)
+}
+
+// OrgUnitScorecard is the aggregate risk, external access and audit freshness of the sites of one
+// organizational unit.
+type OrgUnitScorecard struct {
+	OrgUnit      string         // Empty for the sites no mapping assigned
+	Sites        []*OrgUnitSite // By risk score, the never audited last
+	RiskiestSite *OrgUnitSite
+	RiskLevel    string // Highest site risk level
+
+	HighRiskSites   int
+	MediumRiskSites int
+	LowRiskSites    int
+
+	ListsAnalyzed          int
+	HighRiskLists          int
+	SharingLinks           int
+	AnonymousLinks         int
+	OrganizationLinks      int
+	FullControlAssignments int
+
+	GuestUsers              int // Summed per site, so a guest of two sites counts twice
+	SitesWithExternalAccess int
+
+	FreshSites        int
+	StaleSites        int
+	NeverAuditedSites int
+	OldestAudit       *time.Time
+	LatestAudit       *time.Time
+}
+
+// OrgUnitSkippedSite is an audited site left out of the scorecards, with the reason.
+type OrgUnitSkippedSite struct {
+	SiteID  int64
+	SiteURL string
+	Reason  string
+}
+
+// OrgUnitScorecardFilter shapes organizational unit scorecards.
+type OrgUnitScorecardFilter struct {
+	StaleAfter  time.Duration // Zero for DefaultOrgUnitStaleAfter
+	HubFallback bool          // Group sites no mapping assigned by their hub's title
+}
+
+// OrgUnitScorecardData is a scorecard for every organizational unit.
+type OrgUnitScorecardData struct {
+	Scorecards   []*OrgUnitScorecard // By risk level and unit; the unassigned sites come last
+	SitesScanned int
+	StaleAfter   time.Duration
+	GeneratedAt  time.Time
+	Skipped      []*OrgUnitSkippedSite
+}
+
+// OrgUnitService maps sites to organizational units, by site URL, hub or run label, and summarizes
+// each unit's sites in a scorecard for leadership.
+type OrgUnitService struct {
+	db             *database.Database
+	siteBrowsing   *SiteBrowsingService
+	serviceFactory AuditRunScopedServiceFactory
+	auditRuns      AuditRunReader
+	now            func() time.Time
+	logger         *logging.Logger
+}
+
+// NewOrgUnitService creates a new organizational unit service.
+func NewOrgUnitService(db *database.Database, siteBrowsing *SiteBrowsingService, serviceFactory AuditRunScopedServiceFactory, auditRuns AuditRunReader) *OrgUnitService {
+	return &OrgUnitService{
+		db:             db,
+		siteBrowsing:   siteBrowsing,
+		serviceFactory: serviceFactory,
+		auditRuns:      auditRuns,
+		now:            func() time.Time { return time.Now().UTC() },
+		logger:         logging.Default().WithComponent("org_unit_service"),
+	}
+}
+
+// SetMapping maps the sites matching a site URL, hub or run label to an organizational unit, replacing
+// the unit the same match was mapped to.
+func (s *OrgUnitService) SetMapping(ctx context.Context, matchType, matchValue, orgUnit string) (*audit.OrgUnitMapping, error) {
+	mapping, err := audit.NewOrgUnitMapping(matchType, matchValue, orgUnit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOrgUnitMapping, err)
+	}
+	if err := upsertOrgUnitMapping(ctx, s.db.Queries(), mapping, s.now()); err != nil {
+		return nil, err
+	}
+	return mapping, nil
+}
+
+// ImportMappings stores the mappings of a CSV sheet, see audit.ParseOrgUnitMappingsCSV. With replace,
+// the existing mappings are deleted first. Nothing is stored unless every row is valid.
+func (s *OrgUnitService) ImportMappings(ctx context.Context, r io.Reader, replace bool) ([]*audit.OrgUnitMapping, error) {
+	mappings, err := audit.ParseOrgUnitMappingsCSV(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidOrgUnitMapping, err)
+	}
+
+	now := s.now()
+	err = s.db.WithTx(func(queries *db.Queries) error {
+		if replace {
+			if err := queries.DeleteAllOrgUnitMappings(ctx); err != nil {
+				return fmt.Errorf("failed to delete org unit mappings: %w", err)
+			}
+		}
+		for _, mapping := range mappings {
+			if err := upsertOrgUnitMapping(ctx, queries, mapping, now); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Org unit mappings imported", "mappings", len(mappings), "replace", replace)
+	return mappings, nil
+}
+
+// GetMappings retrieves every organizational unit mapping, by unit.
+func (s *OrgUnitService) GetMappings(ctx context.Context) ([]*audit.OrgUnitMapping, error) {
+	rows, err := s.db.ReadQueries().GetOrgUnitMappings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org unit mappings: %w", err)
+	}
+	mappings := make([]*audit.OrgUnitMapping, len(rows))
+	for i, row := range rows {
+		mappings[i] = newOrgUnitMapping(row)
+	}
+	return mappings, nil
+}
+
+// DeleteMapping deletes an organizational unit mapping.
+func (s *OrgUnitService) DeleteMapping(ctx context.Context, mappingID int64) error {
+	deleted, err := s.db.Queries().DeleteOrgUnitMapping(ctx, mappingID)
+	if err != nil {
+		return fmt.Errorf("failed to delete org unit mapping: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: %d", ErrOrgUnitMappingNotFound, mappingID)
+	}
+	return nil
+}
+
+// Scorecards assigns every site to its organizational unit by the mappings and aggregates each unit's
+// risk, external access and audit freshness from the sites' latest audit runs. Sites never audited
+// count towards their unit's freshness; audited sites whose run cannot be analyzed are skipped and
+// reported.
+func (s *OrgUnitService) Scorecards(ctx context.Context, filter OrgUnitScorecardFilter) (*OrgUnitScorecardData, error) {
+	if filter.StaleAfter <= 0 {
+		filter.StaleAfter = DefaultOrgUnitStaleAfter
+	}
+	mappings, err := s.GetMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolver := audit.NewOrgUnitResolver(mappings)
+	sites, err := s.siteBrowsing.GetAllSitesWithMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	data := &OrgUnitScorecardData{
+		Scorecards:  []*OrgUnitScorecard{},
+		StaleAfter:  filter.StaleAfter,
+		GeneratedAt: now,
+		Skipped:     []*OrgUnitSkippedSite{},
+	}
+	scorecards := map[string]*OrgUnitScorecard{}
+	for _, site := range sites {
+		if site.Site == nil {
+			continue
+		}
+		unitSite, err := s.orgUnitSite(ctx, site.Site)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			data.Skipped = append(data.Skipped, &OrgUnitSkippedSite{SiteID: site.Site.ID, SiteURL: site.Site.URL, Reason: err.Error()})
+			continue
+		}
+		data.SitesScanned++
+
+		orgUnit, source := resolver.Resolve(site.Site.URL, unitSite.HubSiteID, unitSite.HubURL, unitSite.RunLabel)
+		if orgUnit == "" && filter.HubFallback && unitSite.HubTitle != "" {
+			orgUnit, source = unitSite.HubTitle, audit.OrgUnitSourceHub
+		}
+		unitSite.Source = source
+		unitSite.Stale = unitSite.AuditedAt != nil && now.Sub(*unitSite.AuditedAt) > filter.StaleAfter
+
+		scorecard, ok := scorecards[orgUnit]
+		if !ok {
+			scorecard = &OrgUnitScorecard{OrgUnit: orgUnit, RiskLevel: "Low"}
+			scorecards[orgUnit] = scorecard
+		}
+		scorecard.add(unitSite)
+	}
+
+	for _, scorecard := range scorecards {
+		scorecard.finish()
+		data.Scorecards = append(data.Scorecards, scorecard)
+	}
+	sort.Slice(data.Scorecards, func(i, j int) bool {
+		a, b := data.Scorecards[i], data.Scorecards[j]
+		if (a.OrgUnit == "") != (b.OrgUnit == "") {
+			return b.OrgUnit == ""
+		}
+		if riskLevelRank(a.RiskLevel) != riskLevelRank(b.RiskLevel) {
+			return riskLevelRank(a.RiskLevel) > riskLevelRank(b.RiskLevel)
+		}
+		return strings.ToLower(a.OrgUnit) < strings.ToLower(b.OrgUnit)
+	})
+
+	s.logger.Info("Org unit scorecards built", "sites", data.SitesScanned, "skipped", len(data.Skipped), "org_units", len(data.Scorecards))
+	return data, nil
+}
+
+// orgUnitSite loads the latest run of a site: its hub, label, risk, guests and when it ran
+func (s *OrgUnitService) orgUnitSite(ctx context.Context, site *sharepoint.Site) (*OrgUnitSite, error) {
+	services, err := s.serviceFactory.CreateForAuditRun(ctx, site.ID, audit.RunAliasLatest)
+	if errors.Is(err, sql.ErrNoRows) {
+		return &OrgUnitSite{Site: site, NeverAudited: true}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	unitSite := &OrgUnitSite{Site: site, AuditRunID: services.AuditRunID}
+
+	run, err := s.auditRuns.GetAuditRun(ctx, site.ID, services.AuditRunID)
+	if err != nil {
+		return nil, err
+	}
+	unitSite.RunLabel = run.Label
+	auditedAt := run.StartedAt
+	if run.CompletedAt != nil {
+		auditedAt = *run.CompletedAt
+	}
+	unitSite.AuditedAt = &auditedAt
+
+	content := services.SiteContentService
+	hub, err := content.GetHubAssociation(ctx, site.ID)
+	if err != nil {
+		return nil, err
+	}
+	if hub != nil && hub.IsAssociated() {
+		unitSite.HubSiteID, unitSite.HubTitle, unitSite.HubURL = hub.HubSiteID, hub.HubTitle, hub.HubURL
+	}
+
+	lists, err := content.GetListsForSite(ctx, site.ID)
+	if err != nil {
+		return nil, err
+	}
+	if unitSite.Risk, err = services.PermissionService.SummarizeSiteRisk(ctx, site.ID, lists); err != nil {
+		return nil, err
+	}
+
+	principals, err := content.GetAuditRunPrincipals(ctx, site.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, principal := range principals {
+		if _, ok := principal.GuestHomeEmail(); ok {
+			unitSite.GuestUsers++
+		}
+	}
+	return unitSite, nil
+}
+
+// add counts a site towards the scorecard.
+func (c *OrgUnitScorecard) add(site *OrgUnitSite) {
+	c.Sites = append(c.Sites, site)
+	if site.NeverAudited {
+		c.NeverAuditedSites++
+		return
+	}
+
+	if site.Stale {
+		c.StaleSites++
+	} else {
+		c.FreshSites++
+	}
+	if c.OldestAudit == nil || site.AuditedAt.Before(*c.OldestAudit) {
+		c.OldestAudit = site.AuditedAt
+	}
+	if c.LatestAudit == nil || site.AuditedAt.After(*c.LatestAudit) {
+		c.LatestAudit = site.AuditedAt
+	}
+
+	c.GuestUsers += site.GuestUsers
+	if site.HasExternalAccess() {
+		c.SitesWithExternalAccess++
+	}
+
+	risk := site.Risk
+	switch risk.RiskLevel {
+	case "High":
+		c.HighRiskSites++
+	case "Medium":
+		c.MediumRiskSites++
+	default:
+		c.LowRiskSites++
+	}
+	if riskLevelRank(risk.RiskLevel) > riskLevelRank(c.RiskLevel) {
+		c.RiskLevel = risk.RiskLevel
+	}
+	if c.RiskiestSite == nil || risk.HighestRiskScore > c.RiskiestSite.Risk.HighestRiskScore {
+		c.RiskiestSite = site
+	}
+
+	c.ListsAnalyzed += risk.ListsAnalyzed
+	c.HighRiskLists += risk.HighRiskLists
+	c.SharingLinks += risk.SharingLinks
+	c.AnonymousLinks += risk.AnonymousLinks
+	c.OrganizationLinks += risk.OrganizationLinks
+	c.FullControlAssignments += risk.FullControlAssignments
+}
+
+// finish orders the unit's sites by risk score, the never audited last.
+func (c *OrgUnitScorecard) finish() {
+	sort.SliceStable(c.Sites, func(i, j int) bool {
+		a, b := c.Sites[i], c.Sites[j]
+		if a.NeverAudited != b.NeverAudited {
+			return b.NeverAudited
+		}
+		if !a.NeverAudited && a.Risk.HighestRiskScore != b.Risk.HighestRiskScore {
+			return a.Risk.HighestRiskScore > b.Risk.HighestRiskScore
+		}
+		return a.Site.URL < b.Site.URL
+	})
+}
+
+// upsertOrgUnitMapping stores a mapping, setting its ID and update time
+func upsertOrgUnitMapping(ctx context.Context, queries *db.Queries, mapping *audit.OrgUnitMapping, now time.Time) error {
+	id, err := queries.UpsertOrgUnitMapping(ctx, db.UpsertOrgUnitMappingParams{
+		MatchType:  mapping.MatchType,
+		MatchValue: mapping.MatchValue,
+		OrgUnit:    mapping.OrgUnit,
+		UpdatedAt:  now,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store org unit mapping: %w", err)
+	}
+	mapping.ID, mapping.UpdatedAt = id, now
+	return nil
+}
+
+// newOrgUnitMapping converts a stored mapping
+func newOrgUnitMapping(row db.OrgUnitMapping) *audit.OrgUnitMapping {
+	return &audit.OrgUnitMapping{
+		ID:         row.MappingID,
+		MatchType:  row.MatchType,
+		MatchValue: row.MatchValue,
+		OrgUnit:    row.OrgUnit,
+		UpdatedAt:  row.UpdatedAt,
+	}
+}
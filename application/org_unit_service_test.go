@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
+	"spaudit/test/dataset"
+)
+
+// newOrgUnitTestService stores three synthetic sites: two of the Finance hub (sites 1 and 2), the
+// second audited 60 days ago, and a site with no hub labeled "emea" (site 3). Site 4 was never audited.
+func newOrgUnitTestService(t *testing.T) *OrgUnitService {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	ctx := context.Background()
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	auditRepo := repositories.NewSqlcAuditRepository(testDB)
+	finance := sharepoint.HubAssociation{HubSiteID: "hub-1", HubTitle: "Finance", HubURL: "https://contoso.sharepoint.com/sites/finance"}
+	sites := map[int64]struct {
+		hub      sharepoint.HubAssociation
+		label    string
+		auditAge time.Duration
+	}{
+		1: {hub: finance, auditAge: 24 * time.Hour},
+		2: {hub: finance, auditAge: 60 * 24 * time.Hour},
+		3: {label: "emea", auditAge: time.Hour},
+	}
+	for site, spec := range sites {
+		siteURL := fmt.Sprintf("https://contoso.sharepoint.com/sites/site%d", site)
+		d := dataset.Generate(dataset.Spec{SiteID: site, SiteURL: siteURL, Lists: 1, ItemsPerList: 10, AssignmentsPerItem: 2, Users: 4, UniqueRatio: 0.5, Seed: 5})
+		auditedAt := now.Add(-spec.auditAge)
+		mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (?, ?, ?)`, site, siteURL, fmt.Sprintf("Site %d", site))
+		mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES (?, ?, ?, 'site_audit', 'completed')`, fmt.Sprintf("job-%d", site), site, siteURL)
+		mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at, completed_at, label) VALUES (?, ?, ?, ?, ?, ?)`,
+			site, fmt.Sprintf("job-%d", site), site, auditedAt.Add(-time.Minute), auditedAt, spec.label)
+		require.NoError(t, d.Save(ctx, auditRepo, site))
+		hub := spec.hub
+		require.NoError(t, auditRepo.SaveHubAssociation(ctx, site, site, auditedAt, &hub))
+	}
+	mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (4, 'https://contoso.sharepoint.com/sites/new', 'New')`)
+
+	factory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		auditRepo,
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunAnyStatus,
+	)
+	baseRepo := repositories.NewBaseRepository(testDB)
+	siteContent := repositories.NewSiteContentAggregateRepository(baseRepo, repositories.NewSqlcSiteRepository(testDB), nil, nil, nil, nil)
+	service := NewOrgUnitService(testDB, NewSiteBrowsingService(siteContent, audit.LatestRunAnyStatus), factory, NewAuditService(nil, testDB, nil))
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestOrgUnitService_Scorecards(t *testing.T) {
+	service := newOrgUnitTestService(t)
+	ctx := context.Background()
+
+	_, err := service.SetMapping(ctx, "hub", "{HUB-1}", "Finance")
+	require.NoError(t, err)
+	_, err = service.SetMapping(ctx, "label", "EMEA", "Sales")
+	require.NoError(t, err)
+	_, err = service.SetMapping(ctx, "site", "https://contoso.sharepoint.com/sites/New/", "Sales")
+	require.NoError(t, err)
+
+	result, err := service.Scorecards(ctx, OrgUnitScorecardFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.SitesScanned)
+	assert.Empty(t, result.Skipped)
+	assert.Equal(t, DefaultOrgUnitStaleAfter, result.StaleAfter)
+
+	units := map[string]*OrgUnitScorecard{}
+	for _, scorecard := range result.Scorecards {
+		units[scorecard.OrgUnit] = scorecard
+	}
+	require.Len(t, units, 2)
+
+	finance := units["Finance"]
+	require.Len(t, finance.Sites, 2)
+	assert.Equal(t, audit.OrgUnitMatchHub, finance.Sites[0].Source)
+	assert.Equal(t, 1, finance.FreshSites)
+	assert.Equal(t, 1, finance.StaleSites)
+	assert.Equal(t, 2, finance.HighRiskSites+finance.MediumRiskSites+finance.LowRiskSites)
+	assert.Equal(t, finance.Sites[0].Risk.ListsAnalyzed+finance.Sites[1].Risk.ListsAnalyzed, finance.ListsAnalyzed)
+	require.NotNil(t, finance.OldestAudit)
+	assert.True(t, finance.OldestAudit.Before(*finance.LatestAudit))
+	require.NotNil(t, finance.RiskiestSite)
+
+	sales := units["Sales"]
+	require.Len(t, sales.Sites, 2)
+	assert.Equal(t, 1, sales.FreshSites)
+	assert.Equal(t, 1, sales.NeverAuditedSites)
+	assert.Equal(t, int64(3), sales.Sites[0].Site.ID)
+	assert.Equal(t, audit.OrgUnitMatchLabel, sales.Sites[0].Source)
+	assert.True(t, sales.Sites[1].NeverAudited, "never audited sites come last")
+	assert.Equal(t, audit.OrgUnitMatchSite, sales.Sites[1].Source)
+}
+
+func TestOrgUnitService_Scorecards_HubFallback(t *testing.T) {
+	service := newOrgUnitTestService(t)
+
+	result, err := service.Scorecards(context.Background(), OrgUnitScorecardFilter{HubFallback: true})
+	require.NoError(t, err)
+	require.Len(t, result.Scorecards, 2)
+	assert.Equal(t, "Finance", result.Scorecards[0].OrgUnit)
+	assert.Equal(t, audit.OrgUnitSourceHub, result.Scorecards[0].Sites[0].Source)
+	assert.Empty(t, result.Scorecards[1].OrgUnit, "the unassigned sites come last")
+	assert.Len(t, result.Scorecards[1].Sites, 2)
+}
+
+func TestOrgUnitService_ImportMappings(t *testing.T) {
+	service := newOrgUnitTestService(t)
+	ctx := context.Background()
+
+	_, err := service.SetMapping(ctx, "label", "emea", "Sales")
+	require.NoError(t, err)
+
+	_, err = service.ImportMappings(ctx, strings.NewReader("match_type,match_value,org_unit\nhub,hub-1,Finance\nsite,bad,HR\n"), true)
+	assert.True(t, errors.Is(err, ErrInvalidOrgUnitMapping))
+	mappings, err := service.GetMappings(ctx)
+	require.NoError(t, err)
+	require.Len(t, mappings, 1, "a failed import stores nothing")
+
+	imported, err := service.ImportMappings(ctx, strings.NewReader("match_type,match_value,org_unit\nhub,hub-1,Finance\nlabel,emea,EMEA Sales\n"), true)
+	require.NoError(t, err)
+	require.Len(t, imported, 2)
+	mappings, err = service.GetMappings(ctx)
+	require.NoError(t, err)
+	require.Len(t, mappings, 2)
+	assert.Equal(t, "EMEA Sales", mappings[0].OrgUnit)
+
+	require.NoError(t, service.DeleteMapping(ctx, mappings[0].ID))
+	assert.True(t, errors.Is(service.DeleteMapping(ctx, mappings[0].ID), ErrOrgUnitMappingNotFound))
+}
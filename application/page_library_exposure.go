@@ -0,0 +1,201 @@
+package application
+
+import (
+	"context"
+	"sort"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+// Page library exposure findings. Images and files embedded in pages leak through the libraries
+// holding them, so exposure of Site Pages and Site Assets is reported on its own.
+const (
+	PageLibraryAnonymousAccess     = "page_library_anonymous_access"     // Anyone links on pages or page assets
+	PageLibraryExternalContributor = "page_library_external_contributor" // Guests who can add or change pages or page assets
+)
+
+// PageLibraryExposureData is the exposure of every Site Pages and Site Assets library in one audit run.
+type PageLibraryExposureData struct {
+	Libraries []*PageLibraryExposure // Ordered by title, including libraries with no exposure
+}
+
+// Findings returns the findings of every library, in library order.
+func (d *PageLibraryExposureData) Findings() []*PageLibraryFinding {
+	findings := []*PageLibraryFinding{}
+	for _, library := range d.Libraries {
+		findings = append(findings, library.Findings...)
+	}
+	return findings
+}
+
+// PageLibraryExposure is what one Site Pages or Site Assets library exposes outside the organization.
+type PageLibraryExposure struct {
+	List                 *sharepoint.List
+	AnonymousLinks       []*PageLibraryLink        // Active anyone links on the library or its items
+	ExternalContributors []*PageLibraryContributor // Guests with edit access; direct grants first, then edit link members
+	Findings             []*PageLibraryFinding     // One per kind of exposure found
+}
+
+// PageLibraryLink is an anyone link on a page library or one of its items.
+type PageLibraryLink struct {
+	Link     *sharepoint.SharingLink
+	ItemName string // Empty when the run did not capture the item
+	ItemURL  string
+}
+
+// PageLibraryContributor is a guest who can add or change content of a page library or one of its items.
+type PageLibraryContributor struct {
+	Principal  *sharepoint.Principal
+	ObjectType string
+	ObjectKey  string
+	ObjectName string
+	Role       string
+	GrantType  string // AccessGrantDirect or AccessGrantSharingLink
+	GrantedVia string // The sharing link's ID; empty for direct grants
+}
+
+// PageLibraryFinding is one kind of exposure found in a page library.
+type PageLibraryFinding struct {
+	Kind        string
+	Severity    audit.Severity // Rated by the finding severity mapping
+	ListID      string
+	ListTitle   string
+	ObjectCount int // Anyone links or external contributor grants
+}
+
+// GetPageLibraryExposure checks the run's Site Pages and Site Assets libraries for anyone links and
+// guests who can edit them (audit-scoped). Each library is snapshotted, failing with ErrRowCapExceeded
+// when one has more rows than can be analyzed.
+func (s *SiteContentService) GetPageLibraryExposure(ctx context.Context, siteID int64) (*PageLibraryExposureData, error) {
+	lists, err := s.contentAggregate.GetListsForSite(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &PageLibraryExposureData{Libraries: []*PageLibraryExposure{}}
+	for _, list := range lists {
+		if !list.IsPageLibrary() {
+			continue
+		}
+		snapshot, err := s.GetListSnapshot(ctx, siteID, list.ID)
+		if err != nil {
+			return nil, err
+		}
+		data.Libraries = append(data.Libraries, DetectPageLibraryExposure(snapshot))
+	}
+	sort.SliceStable(data.Libraries, func(i, j int) bool {
+		return data.Libraries[i].List.Title < data.Libraries[j].List.Title
+	})
+	return data, nil
+}
+
+// DetectPageLibraryExposure finds the anyone links and external contributors of a page library's snapshot.
+// Guests count as contributors through direct grants of an editing role on the library or its items, and
+// through membership of an edit link. Limited Access and custom roles are not counted.
+func DetectPageLibraryExposure(snapshot *ListSnapshotData) *PageLibraryExposure {
+	list := snapshot.List
+	exposure := &PageLibraryExposure{
+		List:                 list,
+		AnonymousLinks:       []*PageLibraryLink{},
+		ExternalContributors: []*PageLibraryContributor{},
+		Findings:             []*PageLibraryFinding{},
+	}
+
+	items := make(map[string]*sharepoint.Item, len(snapshot.Items))
+	for _, item := range snapshot.Items {
+		items[item.GUID] = item
+	}
+
+	for _, resolved := range snapshot.Assignments {
+		if contributor := guestContributor(resolved.Assignment, sharepoint.ObjectTypeList, list.ID, list.Title); contributor != nil {
+			exposure.ExternalContributors = append(exposure.ExternalContributors, contributor)
+		}
+	}
+	for _, item := range snapshot.Items {
+		for _, assignment := range snapshot.ItemAssignments[item.GUID] {
+			if contributor := guestContributor(assignment, sharepoint.ObjectTypeItem, item.GUID, item.Name); contributor != nil {
+				exposure.ExternalContributors = append(exposure.ExternalContributors, contributor)
+			}
+		}
+	}
+
+	for _, link := range snapshot.SharingLinks {
+		if !link.IsActive {
+			continue
+		}
+		item := items[link.ItemGUID]
+		if item == nil {
+			item = items[link.FileFolderUniqueID]
+		}
+
+		if link.IsAnyoneLink() {
+			anyone := &PageLibraryLink{Link: link}
+			if item != nil {
+				anyone.ItemName, anyone.ItemURL = item.Name, item.URL
+			}
+			exposure.AnonymousLinks = append(exposure.AnonymousLinks, anyone)
+			continue
+		}
+		if !link.IsEditLink {
+			continue
+		}
+		for _, member := range link.Members {
+			if !member.IsGuest() {
+				continue
+			}
+			contributor := &PageLibraryContributor{
+				Principal:  member,
+				ObjectType: sharepoint.ObjectTypeItem,
+				ObjectKey:  link.ItemGUID,
+				Role:       sharepoint.RoleNameEdit,
+				GrantType:  AccessGrantSharingLink,
+				GrantedVia: link.ID,
+			}
+			if item != nil {
+				contributor.ObjectKey, contributor.ObjectName = item.GUID, item.Name
+			}
+			exposure.ExternalContributors = append(exposure.ExternalContributors, contributor)
+		}
+	}
+
+	if len(exposure.AnonymousLinks) > 0 {
+		exposure.Findings = append(exposure.Findings, pageLibraryFinding(PageLibraryAnonymousAccess, list, len(exposure.AnonymousLinks)))
+	}
+	if len(exposure.ExternalContributors) > 0 {
+		exposure.Findings = append(exposure.Findings, pageLibraryFinding(PageLibraryExternalContributor, list, len(exposure.ExternalContributors)))
+	}
+	return exposure
+}
+
+// guestContributor returns the contributor an assignment makes of a guest with an editing role,
+// or nil when the assignment grants no guest edit access.
+func guestContributor(assignment *sharepoint.Assignment, objectType, objectKey, objectName string) *PageLibraryContributor {
+	if assignment == nil || assignment.Principal == nil || assignment.RoleDefinition == nil || !assignment.Principal.IsGuest() {
+		return nil
+	}
+	role := assignment.RoleDefinition
+	if !sharepoint.GrantsEditRole(role.ID, role.Name) {
+		return nil
+	}
+	return &PageLibraryContributor{
+		Principal:  assignment.Principal,
+		ObjectType: objectType,
+		ObjectKey:  objectKey,
+		ObjectName: objectName,
+		Role:       sharepoint.CanonicalRoleName(role.ID, role.Name),
+		GrantType:  AccessGrantDirect,
+	}
+}
+
+// pageLibraryFinding builds a finding rated with the default severity until
+// FindingSeverities.RatePageLibraryExposure applies the configured mapping.
+func pageLibraryFinding(kind string, list *sharepoint.List, count int) *PageLibraryFinding {
+	return &PageLibraryFinding{
+		Kind:        kind,
+		Severity:    defaultFindingSeverities[kind],
+		ListID:      list.ID,
+		ListTitle:   list.Title,
+		ObjectCount: count,
+	}
+}
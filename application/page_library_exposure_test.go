@@ -0,0 +1,47 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+func TestDetectPageLibraryExposure(t *testing.T) {
+	guest := &sharepoint.Principal{ID: 7, Title: "Partner", LoginName: "i:0#.f|membership|bob_partner.com#ext#@contoso.onmicrosoft.com"}
+	member := &sharepoint.Principal{ID: 8, Title: "Alice", LoginName: "i:0#.f|membership|alice@contoso.com"}
+	contribute := &sharepoint.RoleDefinition{ID: 1073741827, Name: "Contribute"}
+	read := &sharepoint.RoleDefinition{ID: 1073741826, Name: "Read"}
+	assets := &sharepoint.List{ID: "assets", Title: "Site Assets", URL: "/sites/a/SiteAssets", BaseTemplate: 101}
+	logo := &sharepoint.Item{GUID: "logo", Name: "logo.png", URL: "/sites/a/SiteAssets/logo.png", IsFile: true, HasUnique: true}
+	banner := &sharepoint.Item{GUID: "banner", Name: "banner.png", URL: "/sites/a/SiteAssets/banner.png", IsFile: true}
+
+	anyone := &sharepoint.SharingLink{ID: "anyone", ItemGUID: "logo", LinkKind: sharepoint.LinkKindFlexible, Scope: sharepoint.ScopeAnonymous, IsActive: true}
+	expired := &sharepoint.SharingLink{ID: "expired", ItemGUID: "banner", LinkKind: sharepoint.LinkKindAnonymousView, IsActive: false}
+	edit := &sharepoint.SharingLink{ID: "edit", ItemGUID: "banner", LinkKind: sharepoint.LinkKindFlexible, Scope: sharepoint.ScopeSpecificPeople, IsActive: true, IsEditLink: true, Members: []*sharepoint.Principal{member, guest}}
+
+	exposure := DetectPageLibraryExposure(&ListSnapshotData{
+		List: assets,
+		Assignments: []*sharepoint.ResolvedAssignment{
+			{Assignment: &sharepoint.Assignment{Principal: guest, RoleDefinition: read}},
+			{Assignment: &sharepoint.Assignment{Principal: member, RoleDefinition: contribute}},
+		},
+		Items:           []*sharepoint.Item{logo, banner},
+		ItemAssignments: map[string][]*sharepoint.Assignment{"logo": {{Principal: guest, RoleDefinition: contribute}}},
+		SharingLinks:    []*sharepoint.SharingLink{anyone, expired, edit},
+	})
+
+	require.Len(t, exposure.AnonymousLinks, 1)
+	assert.Equal(t, &PageLibraryLink{Link: anyone, ItemName: "logo.png", ItemURL: "/sites/a/SiteAssets/logo.png"}, exposure.AnonymousLinks[0])
+	assert.Equal(t, []*PageLibraryContributor{
+		{Principal: guest, ObjectType: sharepoint.ObjectTypeItem, ObjectKey: "logo", ObjectName: "logo.png", Role: sharepoint.RoleNameContribute, GrantType: AccessGrantDirect},
+		{Principal: guest, ObjectType: sharepoint.ObjectTypeItem, ObjectKey: "banner", ObjectName: "banner.png", Role: sharepoint.RoleNameEdit, GrantType: AccessGrantSharingLink, GrantedVia: "edit"},
+	}, exposure.ExternalContributors)
+	assert.Equal(t, []*PageLibraryFinding{
+		{Kind: PageLibraryAnonymousAccess, Severity: audit.SeverityHigh, ListID: "assets", ListTitle: "Site Assets", ObjectCount: 1},
+		{Kind: PageLibraryExternalContributor, Severity: audit.SeverityHigh, ListID: "assets", ListTitle: "Site Assets", ObjectCount: 2},
+	}, exposure.Findings)
+}
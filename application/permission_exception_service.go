@@ -0,0 +1,490 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// Errors returned when managing permission exceptions.
+var (
+	ErrInvalidPermissionException  = errors.New("invalid permission exception")
+	ErrPermissionExceptionNotFound = errors.New("permission exception not found")
+)
+
+// exceptionReviewReminderTick is how often exceptions nearing expiry are checked for owners to remind.
+const exceptionReviewReminderTick = time.Hour
+
+// ExceptionFindingReopener raises the finding a revoked exception had suppressed.
+type ExceptionFindingReopener interface {
+	ReopenExceptionFinding(ctx context.Context, exception *audit.PermissionException) (*audit.FindingAlert, error)
+}
+
+// ExceptionReviewNotifier reminds the owner of exceptions that they near expiry, returning an error
+// unless the reminder reached them.
+type ExceptionReviewNotifier interface {
+	NotifyExceptionReview(ctx context.Context, owner string, reviews []*audit.ExceptionReview) error
+}
+
+// PermissionExceptionService records the sharing links, assignments and items with unique
+// permissions that were reviewed and accepted, so they stop raising findings and are left out of risk
+// scoring until their exception expires.
+type PermissionExceptionService struct {
+	db             *database.Database
+	serviceFactory AuditRunScopedServiceFactory
+	reopener       ExceptionFindingReopener
+	notifier       ExceptionReviewNotifier
+	now            func() time.Time
+	logger         *logging.Logger
+}
+
+// NewPermissionExceptionService creates a new permission exception service.
+func NewPermissionExceptionService(db *database.Database, serviceFactory AuditRunScopedServiceFactory) *PermissionExceptionService {
+	return &PermissionExceptionService{
+		db:             db,
+		serviceFactory: serviceFactory,
+		now:            func() time.Time { return time.Now().UTC() },
+		logger:         logging.Default().WithComponent("permission_exception_service"),
+	}
+}
+
+// SetFindingReopener sets what raises the findings of exceptions revoked on review. Without one,
+// revoking on review only revokes.
+func (s *PermissionExceptionService) SetFindingReopener(reopener ExceptionFindingReopener) {
+	s.reopener = reopener
+}
+
+// SetReviewNotifier sets the notifier owners are reminded through. Without one nobody is reminded.
+func (s *PermissionExceptionService) SetReviewNotifier(notifier ExceptionReviewNotifier) {
+	s.notifier = notifier
+}
+
+// AcceptException records an exception for a sharing link, assignment or item with unique permissions
+// of a list, which the site's latest audit run must have captured. Accepting the same object again
+// renews its exception with the new justification and expiry.
+func (s *PermissionExceptionService) AcceptException(ctx context.Context, exception *audit.PermissionException) (*audit.PermissionException, error) {
+	now := s.now()
+	exception.ListID = strings.Trim(strings.TrimSpace(exception.ListID), "{}")
+	exception.ObjectKey = strings.Trim(strings.TrimSpace(exception.ObjectKey), "{}")
+	exception.Justification = strings.TrimSpace(exception.Justification)
+	exception.Owner = strings.TrimSpace(exception.Owner)
+	if err := exception.Validate(now); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPermissionException, err)
+	}
+
+	scopedServices, err := s.serviceFactory.CreateForAuditRun(ctx, exception.SiteID, audit.RunAliasLatest)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: site %d has not been audited", ErrInvalidPermissionException, exception.SiteID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolveExceptionObject(ctx, exception, scopedServices.AuditRunID); err != nil {
+		return nil, err
+	}
+
+	exception.CreatedAt = now
+	exception.ID, err = s.db.Queries().UpsertPermissionException(ctx, db.UpsertPermissionExceptionParams{
+		SiteID:        exception.SiteID,
+		ListID:        exception.ListID,
+		Kind:          exception.Kind,
+		ObjectKey:     exception.ObjectKey,
+		PrincipalID:   exception.PrincipalID,
+		RoleDefID:     exception.RoleDefID,
+		Justification: exception.Justification,
+		Owner:         exception.Owner,
+		ExpiresAt:     exception.ExpiresAt.UTC(),
+		CreatedAt:     now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save permission exception: %w", err)
+	}
+
+	s.logger.Info("Permission exception accepted", "site_id", exception.SiteID, "list_id", exception.ListID,
+		"kind", exception.Kind, "object_key", exception.ObjectKey, "expires_at", exception.ExpiresAt)
+	return exception, nil
+}
+
+// GetExceptions returns a site's exceptions, only those of a list unless listID is empty, by list and
+// expiry. Expired exceptions are left out unless includeExpired is set.
+func (s *PermissionExceptionService) GetExceptions(ctx context.Context, siteID int64, listID string, includeExpired bool) ([]*audit.PermissionException, error) {
+	rows, err := s.db.ReadQueries().GetPermissionExceptionsForSite(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permission exceptions: %w", err)
+	}
+
+	now := s.now()
+	listID = strings.Trim(strings.TrimSpace(listID), "{}")
+	exceptions := []*audit.PermissionException{}
+	for _, row := range rows {
+		exception := toPermissionException(row)
+		if listID != "" && !strings.EqualFold(exception.ListID, listID) {
+			continue
+		}
+		if !includeExpired && !exception.Active(now) {
+			continue
+		}
+		exceptions = append(exceptions, exception)
+	}
+	return exceptions, nil
+}
+
+// RevokeException deletes an exception, so what it accepted raises findings and counts towards risk again.
+func (s *PermissionExceptionService) RevokeException(ctx context.Context, siteID, exceptionID int64) error {
+	deleted, err := s.db.Queries().DeletePermissionException(ctx, db.DeletePermissionExceptionParams{
+		SiteID:      siteID,
+		ExceptionID: exceptionID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete permission exception: %w", err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: %d", ErrPermissionExceptionNotFound, exceptionID)
+	}
+	s.logger.Info("Permission exception revoked", "site_id", siteID, "exception_id", exceptionID)
+	return nil
+}
+
+// GetReviewQueue returns the exceptions of every site expiring within the window from now, expired
+// ones not yet renewed or revoked included, soonest first.
+func (s *PermissionExceptionService) GetReviewQueue(ctx context.Context, within time.Duration) ([]*audit.ExceptionReview, error) {
+	rows, err := s.db.ReadQueries().GetPermissionExceptionsExpiringBefore(ctx, s.now().Add(within))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expiring permission exceptions: %w", err)
+	}
+	reviews := make([]*audit.ExceptionReview, len(rows))
+	for i, row := range rows {
+		reviews[i] = &audit.ExceptionReview{
+			Exception: toPermissionException(db.PermissionException{
+				ExceptionID:      row.ExceptionID,
+				SiteID:           row.SiteID,
+				ListID:           row.ListID,
+				Kind:             row.Kind,
+				ObjectKey:        row.ObjectKey,
+				PrincipalID:      row.PrincipalID,
+				RoleDefID:        row.RoleDefID,
+				Justification:    row.Justification,
+				ExpiresAt:        row.ExpiresAt,
+				CreatedAt:        row.CreatedAt,
+				Owner:            row.Owner,
+				ReviewNotifiedAt: row.ReviewNotifiedAt,
+			}),
+			SiteURL: row.SiteUrl,
+		}
+	}
+	return reviews, nil
+}
+
+// RenewException extends an exception from now by as long as it was last accepted or renewed for,
+// at most audit.MaxExceptionLifetime, keeping its justification and owner. An expired exception
+// can be renewed too.
+func (s *PermissionExceptionService) RenewException(ctx context.Context, siteID, exceptionID int64) (*audit.PermissionException, error) {
+	exception, err := s.getException(ctx, siteID, exceptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	exception.ExpiresAt = exception.RenewedExpiry(now)
+	exception.CreatedAt = now
+	exception.ReviewNotifiedAt = nil
+	renewed, err := s.db.Queries().RenewPermissionException(ctx, db.RenewPermissionExceptionParams{
+		ExpiresAt:   exception.ExpiresAt,
+		RenewedAt:   now,
+		SiteID:      siteID,
+		ExceptionID: exceptionID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to renew permission exception: %w", err)
+	}
+	if renewed == 0 {
+		return nil, fmt.Errorf("%w: %d", ErrPermissionExceptionNotFound, exceptionID)
+	}
+
+	s.logger.Info("Permission exception renewed", "site_id", siteID, "exception_id", exceptionID, "expires_at", exception.ExpiresAt)
+	return exception, nil
+}
+
+// RevokeAndReopen revokes an exception and reopens the finding it suppressed, returning the alert
+// raised for it. The alert is nil when no finding reopener is set.
+func (s *PermissionExceptionService) RevokeAndReopen(ctx context.Context, siteID, exceptionID int64) (*audit.FindingAlert, error) {
+	exception, err := s.getException(ctx, siteID, exceptionID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.RevokeException(ctx, siteID, exceptionID); err != nil {
+		return nil, err
+	}
+	if s.reopener == nil {
+		return nil, nil
+	}
+
+	alert, err := s.reopener.ReopenExceptionFinding(ctx, exception)
+	if err != nil {
+		return nil, fmt.Errorf("exception %d revoked, but its finding could not be reopened: %w", exceptionID, err)
+	}
+	return alert, nil
+}
+
+// NotifyExpiringOwners reminds the owners of exceptions expiring within audit.ExceptionReviewWindow
+// once per exception, each owner of all their exceptions at once, and returns how many exceptions
+// were notified. Exceptions are marked notified only once their owner's reminder is delivered, so a
+// failed reminder is tried again on the next call. Renewing an exception has its owner reminded again
+// before the new expiry.
+func (s *PermissionExceptionService) NotifyExpiringOwners(ctx context.Context) (int, error) {
+	if s.notifier == nil {
+		return 0, nil
+	}
+	reviews, err := s.GetReviewQueue(ctx, audit.ExceptionReviewWindow)
+	if err != nil {
+		return 0, err
+	}
+
+	now := s.now()
+	var owners []string
+	byOwner := map[string][]*audit.ExceptionReview{}
+	for _, review := range reviews {
+		exception := review.Exception
+		if exception.Owner == "" || exception.ReviewNotifiedAt != nil || !exception.Active(now) {
+			continue
+		}
+		if _, ok := byOwner[exception.Owner]; !ok {
+			owners = append(owners, exception.Owner)
+		}
+		byOwner[exception.Owner] = append(byOwner[exception.Owner], review)
+	}
+
+	notified := 0
+	var errs []error
+	for _, owner := range owners {
+		if err := s.notifier.NotifyExceptionReview(ctx, owner, byOwner[owner]); err != nil {
+			errs = append(errs, fmt.Errorf("owner %s: %w", owner, err))
+			continue
+		}
+		for _, review := range byOwner[owner] {
+			if err := s.db.Queries().MarkPermissionExceptionReviewNotified(ctx, db.MarkPermissionExceptionReviewNotifiedParams{
+				NotifiedAt:  sql.NullTime{Time: now, Valid: true},
+				ExceptionID: review.Exception.ID,
+			}); err != nil {
+				return notified, fmt.Errorf("failed to mark permission exception %d notified: %w", review.Exception.ID, err)
+			}
+			notified++
+		}
+	}
+	return notified, errors.Join(errs...)
+}
+
+// RunReviewReminderScheduler reminds owners of exceptions nearing expiry at start and hourly after,
+// until ctx is cancelled.
+func (s *PermissionExceptionService) RunReviewReminderScheduler(ctx context.Context) {
+	ticker := time.NewTicker(exceptionReviewReminderTick)
+	defer ticker.Stop()
+	for {
+		notified, err := s.NotifyExpiringOwners(ctx)
+		if err != nil {
+			s.logger.Error("Failed to remind owners of expiring exceptions", "error", err)
+		}
+		if notified > 0 {
+			s.logger.Info("Reminded owners of expiring exceptions", "count", notified)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// getException loads an exception of a site
+func (s *PermissionExceptionService) getException(ctx context.Context, siteID, exceptionID int64) (*audit.PermissionException, error) {
+	row, err := s.db.ReadQueries().GetPermissionException(ctx, db.GetPermissionExceptionParams{
+		SiteID:      siteID,
+		ExceptionID: exceptionID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrPermissionExceptionNotFound, exceptionID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permission exception: %w", err)
+	}
+	return toPermissionException(row), nil
+}
+
+// resolveExceptionObject checks that the audit run captured what an exception accepts, on the
+// exception's list, and replaces the keys it names with the run's own
+func (s *PermissionExceptionService) resolveExceptionObject(ctx context.Context, exception *audit.PermissionException, auditRunID int64) error {
+	queries := s.db.ReadQueries()
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s", ErrInvalidPermissionException, fmt.Sprintf(format, args...))
+	}
+	getItem := func(itemGUID string) (*db.GetItemByGUIDByAuditRunRow, error) {
+		item, err := queries.GetItemByGUIDByAuditRun(ctx, db.GetItemByGUIDByAuditRunParams{
+			SiteID:     exception.SiteID,
+			ItemGuid:   itemGUID,
+			AuditRunID: auditRunID,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get item %s: %w", itemGUID, err)
+		}
+		return &item, nil
+	}
+
+	list, err := queries.GetListByAuditRun(ctx, db.GetListByAuditRunParams{
+		SiteID:     exception.SiteID,
+		ListID:     exception.ListID,
+		AuditRunID: auditRunID,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return invalid("list %s is not in the site's latest audit run", exception.ListID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get list %s: %w", exception.ListID, err)
+	}
+	exception.ListID = list.ListID
+
+	switch exception.Kind {
+	case audit.ExceptionSharingLink:
+		link, err := queries.GetSharingLinkByAuditRun(ctx, db.GetSharingLinkByAuditRunParams{
+			SiteID:     exception.SiteID,
+			AuditRunID: auditRunID,
+			LinkID:     exception.ObjectKey,
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return invalid("sharing link %s is not in the site's latest audit run", exception.ObjectKey)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get sharing link %s: %w", exception.ObjectKey, err)
+		}
+		// Lists audited without item scanning have no items to place the link on
+		for _, itemGUID := range []sql.NullString{link.ItemGuid, link.FileFolderUniqueID} {
+			if !itemGUID.Valid {
+				continue
+			}
+			item, err := getItem(itemGUID.String)
+			if err != nil {
+				return err
+			}
+			if item == nil {
+				continue
+			}
+			if item.ListID != list.ListID {
+				return invalid("sharing link %s is not on an item of list %s", exception.ObjectKey, exception.ListID)
+			}
+			break
+		}
+		exception.ObjectKey = link.LinkID
+
+	case audit.ExceptionUniqueItem:
+		item, err := getItem(exception.ObjectKey)
+		if err != nil {
+			return err
+		}
+		if item == nil || item.ListID != list.ListID {
+			return invalid("item %s is not in list %s in the site's latest audit run", exception.ObjectKey, exception.ListID)
+		}
+		if !item.HasUnique.Valid || !item.HasUnique.Bool {
+			return invalid("item %s inherits its permissions", exception.ObjectKey)
+		}
+		exception.ObjectKey = item.ItemGuid
+
+	case audit.ExceptionAssignment:
+		objectType := sharepoint.ObjectTypeList
+		if strings.EqualFold(exception.ObjectKey, list.ListID) {
+			exception.ObjectKey = list.ListID
+		} else {
+			item, err := getItem(exception.ObjectKey)
+			if err != nil {
+				return err
+			}
+			if item == nil || item.ListID != list.ListID {
+				return invalid("object_key must be the list ID or the GUID of an item of list %s", exception.ListID)
+			}
+			objectType = sharepoint.ObjectTypeItem
+			exception.ObjectKey = item.ItemGuid
+		}
+
+		assignments, err := queries.GetAssignmentsForObjectByAuditRun(ctx, db.GetAssignmentsForObjectByAuditRunParams{
+			SiteID:     exception.SiteID,
+			ObjectType: objectType,
+			ObjectKey:  exception.ObjectKey,
+			AuditRunID: auditRunID,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get assignments: %w", err)
+		}
+		for _, assignment := range assignments {
+			if assignment.PrincipalID == exception.PrincipalID && assignment.RoleDefID == exception.RoleDefID {
+				return nil
+			}
+		}
+		return invalid("principal %d has no role %d on %s %s in the site's latest audit run",
+			exception.PrincipalID, exception.RoleDefID, objectType, exception.ObjectKey)
+	}
+	return nil
+}
+
+// loadPermissionExceptionSet loads the exceptions of a site active at now.
+func loadPermissionExceptionSet(ctx context.Context, queries *db.Queries, siteID int64, now time.Time) (*audit.PermissionExceptionSet, error) {
+	rows, err := queries.GetActivePermissionExceptionsForSite(ctx, db.GetActivePermissionExceptionsForSiteParams{
+		SiteID: siteID,
+		Now:    now.UTC(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get permission exceptions for site %d: %w", siteID, err)
+	}
+	exceptions := make([]*audit.PermissionException, len(rows))
+	for i, row := range rows {
+		exceptions[i] = toPermissionException(row)
+	}
+	return audit.NewPermissionExceptionSet(exceptions, now), nil
+}
+
+// toPermissionException converts a stored permission exception.
+func toPermissionException(row db.PermissionException) *audit.PermissionException {
+	exception := &audit.PermissionException{
+		ID:            row.ExceptionID,
+		SiteID:        row.SiteID,
+		ListID:        row.ListID,
+		Kind:          row.Kind,
+		ObjectKey:     row.ObjectKey,
+		PrincipalID:   row.PrincipalID,
+		RoleDefID:     row.RoleDefID,
+		Justification: row.Justification,
+		Owner:         row.Owner,
+		ExpiresAt:     row.ExpiresAt,
+		CreatedAt:     row.CreatedAt,
+	}
+	if row.ReviewNotifiedAt.Valid {
+		notifiedAt := row.ReviewNotifiedAt.Time
+		exception.ReviewNotifiedAt = &notifiedAt
+	}
+	return exception
+}
+
+// exceptionCoversChange returns true if an accepted exception covers a permission change of a list:
+// the sharing link or item it is on, or the principal's role it grants.
+func exceptionCoversChange(exceptions *audit.PermissionExceptionSet, listID string, change *SnapshotChange) bool {
+	switch change.Category {
+	case ChangeCategorySharingLink, ChangeCategorySharingLinkMember:
+		return exceptions.CoversSharingLink(listID, change.ObjectKey)
+	case ChangeCategoryUniqueItem:
+		return exceptions.CoversUniqueItem(listID, change.ObjectKey)
+	case ChangeCategoryListAssignment, ChangeCategoryItemAssignment:
+		return change.Principal != nil && exceptions.CoversAssignment(listID, change.ObjectKey, change.Principal.ID, change.RoleDefID)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,263 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
+	"spaudit/test/dataset"
+)
+
+// newExceptionTestService stores a synthetic site audited by run 1 (site 1) and a site never audited
+// (site 2), and returns the service with the site's dataset and the factory scoring it.
+func newExceptionTestService(t *testing.T) (*PermissionExceptionService, *dataset.Dataset, AuditRunScopedServiceFactory) {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	ctx := context.Background()
+	siteURL := "https://contoso.sharepoint.com/sites/finance"
+	d := dataset.Generate(dataset.Spec{SiteID: 1, SiteURL: siteURL, Lists: 1, ItemsPerList: 10, AssignmentsPerItem: 2, Users: 4, UniqueRatio: 0.5, Seed: 9})
+	mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (1, ?, 'Finance')`, siteURL)
+	mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (2, 'https://contoso.sharepoint.com/sites/new', 'New')`)
+	mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, ?, 'site_audit', 'completed')`, siteURL)
+	mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (1, 'job-1', 1, CURRENT_TIMESTAMP)`)
+	auditRepo := repositories.NewSqlcAuditRepository(testDB)
+	require.NoError(t, d.Save(ctx, auditRepo, 1))
+
+	factory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		auditRepo,
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunAnyStatus,
+	)
+	return NewPermissionExceptionService(testDB, factory), d, factory
+}
+
+func TestPermissionExceptionService_AcceptedExceptionsLeaveRiskScoring(t *testing.T) {
+	service, d, factory := newExceptionTestService(t)
+	ctx := context.Background()
+	list := d.Lists[0]
+
+	var listAssignment *sharepoint.RoleAssignment
+	for _, assignment := range d.Assignments {
+		if assignment.ObjectType == sharepoint.ObjectTypeList && assignment.ObjectKey == list.ID {
+			listAssignment = assignment
+			break
+		}
+	}
+	require.NotNil(t, listAssignment)
+	var uniqueItem *sharepoint.Item
+	for _, item := range d.Items {
+		if item.HasUnique {
+			uniqueItem = item
+			break
+		}
+	}
+	require.NotNil(t, uniqueItem)
+
+	analyze := func() *PermissionAnalysisData {
+		scoped, err := factory.CreateForAuditRun(ctx, 1, audit.RunAliasLatest)
+		require.NoError(t, err)
+		data, err := scoped.PermissionService.AnalyzeListPermissions(ctx, 1, list)
+		require.NoError(t, err)
+		return data
+	}
+	before := analyze()
+
+	expiresAt := time.Now().Add(30 * 24 * time.Hour)
+	accepted, err := service.AcceptException(ctx, &audit.PermissionException{
+		SiteID:        1,
+		ListID:        "{" + list.ID + "}",
+		Kind:          audit.ExceptionAssignment,
+		ObjectKey:     list.ID,
+		PrincipalID:   listAssignment.PrincipalID,
+		RoleDefID:     listAssignment.RoleDefID,
+		Justification: "Owners group, reviewed by the site owner",
+		ExpiresAt:     expiresAt,
+	})
+	require.NoError(t, err)
+	assert.NotZero(t, accepted.ID)
+	assert.Equal(t, list.ID, accepted.ListID, "the list ID is stored as the run captured it")
+
+	_, err = service.AcceptException(ctx, &audit.PermissionException{
+		SiteID:        1,
+		ListID:        list.ID,
+		Kind:          audit.ExceptionUniqueItem,
+		ObjectKey:     uniqueItem.GUID,
+		Justification: "Board pack shared with the auditors",
+		ExpiresAt:     expiresAt,
+	})
+	require.NoError(t, err)
+
+	after := analyze()
+	assert.Equal(t, 2, after.ExceptedObjects)
+	assert.Equal(t, before.TotalAssignments-1, after.TotalAssignments)
+	assert.Equal(t, before.ItemsWithUnique-1, after.ItemsWithUnique)
+
+	exceptions, err := service.GetExceptions(ctx, 1, list.ID, false)
+	require.NoError(t, err)
+	assert.Len(t, exceptions, 2)
+
+	// Revoked exceptions count towards risk again
+	for _, exception := range exceptions {
+		require.NoError(t, service.RevokeException(ctx, 1, exception.ID))
+	}
+	assert.Equal(t, 0, analyze().ExceptedObjects)
+	assert.ErrorIs(t, service.RevokeException(ctx, 1, accepted.ID), ErrPermissionExceptionNotFound)
+}
+
+func TestPermissionExceptionService_AcceptException_Rejects(t *testing.T) {
+	service, d, _ := newExceptionTestService(t)
+	ctx := context.Background()
+	list := d.Lists[0]
+
+	var inheritingItem *sharepoint.Item
+	for _, item := range d.Items {
+		if !item.HasUnique {
+			inheritingItem = item
+			break
+		}
+	}
+	require.NotNil(t, inheritingItem)
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	for name, exception := range map[string]*audit.PermissionException{
+		"no justification": {SiteID: 1, ListID: list.ID, Kind: audit.ExceptionUniqueItem, ObjectKey: inheritingItem.GUID, ExpiresAt: expiresAt},
+		"unknown list":     {SiteID: 1, ListID: "missing", Kind: audit.ExceptionUniqueItem, ObjectKey: inheritingItem.GUID, Justification: "x", ExpiresAt: expiresAt},
+		"inheriting item":  {SiteID: 1, ListID: list.ID, Kind: audit.ExceptionUniqueItem, ObjectKey: inheritingItem.GUID, Justification: "x", ExpiresAt: expiresAt},
+		"unknown link":     {SiteID: 1, ListID: list.ID, Kind: audit.ExceptionSharingLink, ObjectKey: "link-1", Justification: "x", ExpiresAt: expiresAt},
+		"no such role":     {SiteID: 1, ListID: list.ID, Kind: audit.ExceptionAssignment, ObjectKey: list.ID, PrincipalID: 999, RoleDefID: 1, Justification: "x", ExpiresAt: expiresAt},
+		"never audited":    {SiteID: 2, ListID: list.ID, Kind: audit.ExceptionUniqueItem, ObjectKey: inheritingItem.GUID, Justification: "x", ExpiresAt: expiresAt},
+	} {
+		_, err := service.AcceptException(ctx, exception)
+		assert.True(t, errors.Is(err, ErrInvalidPermissionException), "%s: %v", name, err)
+	}
+}
+
+// recordingReviewNotifier records the reminders delivered to exception owners, failing those to unreachable owners.
+type recordingReviewNotifier struct {
+	reminded    map[string]int
+	unreachable map[string]bool
+}
+
+func (n *recordingReviewNotifier) NotifyExceptionReview(ctx context.Context, owner string, reviews []*audit.ExceptionReview) error {
+	if n.unreachable[owner] {
+		return errors.New("mailbox unavailable")
+	}
+	n.reminded[owner] += len(reviews)
+	return nil
+}
+
+func TestPermissionExceptionService_ReviewQueue(t *testing.T) {
+	service, d, factory := newExceptionTestService(t)
+	ctx := context.Background()
+	list := d.Lists[0]
+	var uniqueItems []*sharepoint.Item
+	for _, item := range d.Items {
+		if item.HasUnique {
+			uniqueItems = append(uniqueItems, item)
+		}
+	}
+	require.GreaterOrEqual(t, len(uniqueItems), 3)
+
+	notifier := &recordingReviewNotifier{reminded: map[string]int{}, unreachable: map[string]bool{"alice@contoso.com": true}}
+	service.SetReviewNotifier(notifier)
+	alerts := NewFindingAlertService(service.db, factory, nil, nil, audit.QuietHours{})
+	service.SetFindingReopener(alerts)
+
+	accept := func(item *sharepoint.Item, owner string, lifetime time.Duration) *audit.PermissionException {
+		exception, err := service.AcceptException(ctx, &audit.PermissionException{
+			SiteID:        1,
+			ListID:        list.ID,
+			Kind:          audit.ExceptionUniqueItem,
+			ObjectKey:     item.GUID,
+			Justification: "Shared with the auditors",
+			Owner:         owner,
+			ExpiresAt:     time.Now().Add(lifetime),
+		})
+		require.NoError(t, err)
+		return exception
+	}
+	soon := accept(uniqueItems[0], " alice@contoso.com ", 10*24*time.Hour)
+	sooner := accept(uniqueItems[1], "alice@contoso.com", 5*24*time.Hour)
+	accept(uniqueItems[2], "bob@contoso.com", 90*24*time.Hour)
+
+	queue, err := service.GetReviewQueue(ctx, audit.ExceptionReviewWindow)
+	require.NoError(t, err)
+	require.Len(t, queue, 2, "only exceptions expiring within the window are due")
+	assert.Equal(t, sooner.ID, queue[0].Exception.ID, "soonest first")
+	assert.Equal(t, "https://contoso.sharepoint.com/sites/finance", queue[0].SiteURL)
+
+	// Exceptions stay unnotified while their owner's reminder fails, and are tried again
+	notified, err := service.NotifyExpiringOwners(ctx)
+	assert.ErrorContains(t, err, "mailbox unavailable")
+	assert.Zero(t, notified)
+	queue, err = service.GetReviewQueue(ctx, audit.ExceptionReviewWindow)
+	require.NoError(t, err)
+	assert.Nil(t, queue[0].Exception.ReviewNotifiedAt)
+	assert.Empty(t, notifier.reminded)
+
+	// Owners are reminded of all their exceptions at once, and once only
+	notifier.unreachable = nil
+	notified, err = service.NotifyExpiringOwners(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, notified)
+	assert.Equal(t, map[string]int{"alice@contoso.com": 2}, notifier.reminded)
+	notified, err = service.NotifyExpiringOwners(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, notified)
+
+	// Renewing extends by the lifetime last granted and has the owner reminded again
+	renewed, err := service.RenewException(ctx, 1, soon.ID)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(10*24*time.Hour), renewed.ExpiresAt, time.Minute)
+	notified, err = service.NotifyExpiringOwners(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, notified)
+
+	// Revoking on review reopens the finding the exception suppressed
+	alert, err := service.RevokeAndReopen(ctx, 1, sooner.ID)
+	require.NoError(t, err)
+	require.NotNil(t, alert)
+	assert.Equal(t, ExceptionReopened, alert.Category)
+	assert.Equal(t, int64(1), alert.AuditRunID)
+	assert.Equal(t, audit.SeverityMedium, alert.Severity)
+	queue, err = service.GetReviewQueue(ctx, audit.ExceptionReviewWindow)
+	require.NoError(t, err)
+	assert.Len(t, queue, 1)
+
+	_, err = service.RevokeAndReopen(ctx, 1, sooner.ID)
+	assert.ErrorIs(t, err, ErrPermissionExceptionNotFound)
+	_, err = service.RenewException(ctx, 1, sooner.ID)
+	assert.ErrorIs(t, err, ErrPermissionExceptionNotFound)
+}
+
+func TestWithoutExceptedChanges(t *testing.T) {
+	exceptions := audit.NewPermissionExceptionSet([]*audit.PermissionException{
+		{ListID: "list-1", Kind: audit.ExceptionSharingLink, ObjectKey: "link-1", ExpiresAt: time.Now().Add(time.Hour)},
+	}, time.Now())
+	linkCreated := &SnapshotChange{Change: ChangeAdded, Category: ChangeCategorySharingLink, ObjectKey: "LINK-1"}
+	memberAdded := &SnapshotChange{Change: ChangeAdded, Category: ChangeCategorySharingLinkMember, ObjectKey: "link-1", Principal: &sharepoint.Principal{ID: 4}}
+	itemBroken := &SnapshotChange{Change: ChangeAdded, Category: ChangeCategoryUniqueItem, ObjectKey: "item-1"}
+
+	findings := withoutExceptedChanges([]*ListDrift{
+		{Kind: DriftPermissionsChanged, ListSnapshotDiffData: &ListSnapshotDiffData{List: &sharepoint.List{ID: "list-1"}, Changes: []*SnapshotChange{linkCreated, memberAdded}}},
+		{Kind: DriftPermissionsChanged, ListSnapshotDiffData: &ListSnapshotDiffData{List: &sharepoint.List{ID: "list-1"}, Changes: []*SnapshotChange{linkCreated, itemBroken}}},
+		{Kind: DriftListAdded, ListSnapshotDiffData: &ListSnapshotDiffData{List: &sharepoint.List{ID: "list-1"}, Changes: []*SnapshotChange{linkCreated}}},
+	}, exceptions)
+
+	// Drift left without changes is no finding; added lists are
+	require.Len(t, findings, 2)
+	assert.Equal(t, []*SnapshotChange{itemBroken}, findings[0].Changes)
+	assert.Equal(t, DriftListAdded, findings[1].Kind)
+	assert.Empty(t, findings[1].Changes)
+}
@@ -0,0 +1,196 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+// PermissionMatrixData pivots the permissions of a list or web into objects × principals. The objects are
+// the list or web itself and the items or lists below it with unique permissions; those inheriting share
+// their parent's row.
+type PermissionMatrixData struct {
+	Objects    []*PermissionMatrixObject // The list or web first, then by URL
+	Principals []*sharepoint.Principal   // Principals granted a role on any object, ordered by title
+	Roles      []string                  // Roles granted on any object, most privileged first
+}
+
+// PermissionMatrixObject is a row of a permission matrix.
+type PermissionMatrixObject struct {
+	Type   string // sharepoint.ObjectType*
+	Key    string // Web or list ID, or item GUID
+	Title  string
+	URL    string
+	Grants map[int64][]string // Principal ID → role names, most privileged first
+}
+
+// ObjectAssignments is an object with the assignments captured on it, pivoted by PivotPermissions.
+type ObjectAssignments struct {
+	Object      *PermissionMatrixObject
+	Assignments []*sharepoint.Assignment
+}
+
+// GetListPermissionMatrix pivots the permissions of a list and its items with unique permissions (audit-scoped).
+// Fails with ErrRowCapExceeded when the items and assignments loaded exceed the configured cap.
+func (s *SiteContentService) GetListPermissionMatrix(ctx context.Context, siteID int64, listID string) (*PermissionMatrixData, error) {
+	list, err := s.contentAggregate.GetListByID(ctx, siteID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.allFilteredListItems(ctx, siteID, listID, contracts.ItemFilter{UniqueOnly: true})
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]*ObjectAssignments, 0, len(items)+1)
+	listObject := &PermissionMatrixObject{Type: sharepoint.ObjectTypeList, Key: list.ID, Title: list.Title, URL: list.URL}
+	for _, item := range items {
+		objects = append(objects, &ObjectAssignments{
+			Object: &PermissionMatrixObject{Type: sharepoint.ObjectTypeItem, Key: item.GUID, Title: item.Name, URL: item.URL},
+		})
+	}
+	return s.pivotObjectPermissions(ctx, siteID, "permission matrix of list "+listID, listObject, objects)
+}
+
+// GetWebPermissionMatrix pivots the permissions of a web and its lists with unique permissions (audit-scoped).
+// Returns sql.ErrNoRows when the run did not capture the web.
+func (s *SiteContentService) GetWebPermissionMatrix(ctx context.Context, siteID int64, webID string) (*PermissionMatrixData, error) {
+	webs, err := s.contentAggregate.GetWebsForSite(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webs: %w", err)
+	}
+	var web *sharepoint.Web
+	for _, candidate := range webs {
+		if candidate.ID == webID {
+			web = candidate
+			break
+		}
+	}
+	if web == nil {
+		return nil, fmt.Errorf("web %s: %w", webID, sql.ErrNoRows)
+	}
+
+	lists, err := s.contentAggregate.GetListsForSite(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+
+	var objects []*ObjectAssignments
+	webObject := &PermissionMatrixObject{Type: sharepoint.ObjectTypeWeb, Key: web.ID, Title: web.Title, URL: web.URL}
+	for _, list := range lists {
+		if list.WebID != web.ID || !list.HasUnique {
+			continue
+		}
+		objects = append(objects, &ObjectAssignments{
+			Object: &PermissionMatrixObject{Type: sharepoint.ObjectTypeList, Key: list.ID, Title: list.Title, URL: list.URL},
+		})
+	}
+	return s.pivotObjectPermissions(ctx, siteID, "permission matrix of web "+webID, webObject, objects)
+}
+
+// pivotObjectPermissions loads the assignments of the scope object and of the objects below it, counting
+// every row against the cap, and pivots them.
+func (s *SiteContentService) pivotObjectPermissions(ctx context.Context, siteID int64, what string, scope *PermissionMatrixObject, below []*ObjectAssignments) (*PermissionMatrixData, error) {
+	objects := append([]*ObjectAssignments{{Object: scope}}, below...)
+	loaded := len(objects)
+	for _, object := range objects {
+		assignments, err := s.contentAggregate.GetAssignmentsForObject(ctx, siteID, s.auditRunID, object.Object.Type, object.Object.Key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assignments for %s %s: %w", object.Object.Type, object.Object.Key, err)
+		}
+		object.Assignments = assignments
+		loaded += len(assignments)
+		if err := s.checkRowCap(loaded, what); err != nil {
+			return nil, err
+		}
+	}
+	return PivotPermissions(objects), nil
+}
+
+// PivotPermissions pivots objects' assignments into a permission matrix. The first object keeps its place and
+// the rest are ordered by URL. Limited Access, which SharePoint grants only to navigate to content below,
+// is left out, as are principals holding nothing else.
+func PivotPermissions(objects []*ObjectAssignments) *PermissionMatrixData {
+	data := &PermissionMatrixData{Objects: make([]*PermissionMatrixObject, 0, len(objects))}
+	principals := make(map[int64]*sharepoint.Principal)
+	roles := make(map[string]bool)
+
+	for _, object := range objects {
+		row := object.Object
+		row.Grants = make(map[int64][]string)
+		for _, assignment := range object.Assignments {
+			if assignment.Principal == nil || assignment.RoleDefinition == nil {
+				continue
+			}
+			role := sharepoint.CanonicalRoleName(assignment.RoleDefinition.ID, assignment.RoleDefinition.Name)
+			if sharepoint.IsLimitedAccessRole(assignment.RoleDefinition.ID, role) {
+				continue
+			}
+			principal := assignment.Principal
+			if !slices.Contains(row.Grants[principal.ID], role) {
+				row.Grants[principal.ID] = append(row.Grants[principal.ID], role)
+			}
+			principals[principal.ID] = principal
+			roles[role] = true
+		}
+		for _, granted := range row.Grants {
+			sortRoleNames(granted)
+		}
+		data.Objects = append(data.Objects, row)
+	}
+
+	if len(data.Objects) > 1 {
+		below := data.Objects[1:]
+		sort.SliceStable(below, func(i, j int) bool { return below[i].URL < below[j].URL })
+	}
+
+	for _, principal := range principals {
+		data.Principals = append(data.Principals, principal)
+	}
+	sort.Slice(data.Principals, func(i, j int) bool {
+		a, b := strings.ToLower(data.Principals[i].Title), strings.ToLower(data.Principals[j].Title)
+		if a != b {
+			return a < b
+		}
+		return data.Principals[i].ID < data.Principals[j].ID
+	})
+
+	for role := range roles {
+		data.Roles = append(data.Roles, role)
+	}
+	sortRoleNames(data.Roles)
+	return data
+}
+
+// builtInRoleRanks orders the built-in permission levels from most to least privileged.
+var builtInRoleRanks = map[string]int{
+	sharepoint.RoleNameFullControl: 0,
+	sharepoint.RoleNameDesign:      1,
+	sharepoint.RoleNameEdit:        2,
+	sharepoint.RoleNameContribute:  3,
+	sharepoint.RoleNameRead:        4,
+	sharepoint.RoleNameViewOnly:    5,
+}
+
+// sortRoleNames orders role names by privilege, custom levels after the built-in ones by name.
+func sortRoleNames(roles []string) {
+	rank := func(role string) int {
+		if r, ok := builtInRoleRanks[role]; ok {
+			return r
+		}
+		return len(builtInRoleRanks)
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		if rank(roles[i]) != rank(roles[j]) {
+			return rank(roles[i]) < rank(roles[j])
+		}
+		return roles[i] < roles[j]
+	})
+}
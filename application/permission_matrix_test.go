@@ -0,0 +1,65 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/sharepoint"
+)
+
+func TestPivotPermissions(t *testing.T) {
+	owners := &sharepoint.Principal{ID: 3, Title: "Owners"}
+	ada := &sharepoint.Principal{ID: 10, Title: "ada"}
+	visitors := &sharepoint.Principal{ID: 5, Title: "Visitors"}
+	fullControl := &sharepoint.RoleDefinition{ID: 1073741829, Name: "Vollzugriff"}
+	read := &sharepoint.RoleDefinition{ID: 1073741826, Name: "Read"}
+	edit := &sharepoint.RoleDefinition{ID: 1073741830, Name: "Edit"}
+	limited := &sharepoint.RoleDefinition{ID: 1073741825, Name: "Limited Access"}
+	approve := &sharepoint.RoleDefinition{ID: 1073742000, Name: "Approve"}
+
+	objects := []*ObjectAssignments{
+		{
+			Object: &PermissionMatrixObject{Type: sharepoint.ObjectTypeList, Key: "docs", URL: "https://contoso.sharepoint.com/sites/a/docs"},
+			Assignments: []*sharepoint.Assignment{
+				{Principal: owners, RoleDefinition: fullControl},
+				{Principal: visitors, RoleDefinition: read},
+				{Principal: visitors, RoleDefinition: limited},
+				{Principal: owners, RoleDefinition: fullControl},
+			},
+		},
+		{
+			Object: &PermissionMatrixObject{Type: sharepoint.ObjectTypeItem, Key: "b", URL: "https://contoso.sharepoint.com/sites/a/docs/b.docx"},
+			Assignments: []*sharepoint.Assignment{
+				{Principal: ada, RoleDefinition: read},
+				{Principal: ada, RoleDefinition: approve},
+				{Principal: ada, RoleDefinition: edit},
+			},
+		},
+		{
+			Object: &PermissionMatrixObject{Type: sharepoint.ObjectTypeItem, Key: "a", URL: "https://contoso.sharepoint.com/sites/a/docs/a.docx"},
+			Assignments: []*sharepoint.Assignment{
+				{Principal: &sharepoint.Principal{ID: 99, Title: "Navigator"}, RoleDefinition: limited},
+				{Principal: owners},
+			},
+		},
+	}
+
+	data := PivotPermissions(objects)
+	require.Len(t, data.Objects, 3)
+	assert.Equal(t, []string{"docs", "a", "b"}, []string{data.Objects[0].Key, data.Objects[1].Key, data.Objects[2].Key},
+		"the scope stays first, the objects below it follow by URL")
+
+	assert.Equal(t, map[int64][]string{3: {"Full Control"}, 5: {"Read"}}, data.Objects[0].Grants,
+		"built-in roles are canonical, duplicates and Limited Access are dropped")
+	assert.Empty(t, data.Objects[1].Grants)
+	assert.Equal(t, []string{"Edit", "Read", "Approve"}, data.Objects[2].Grants[10])
+
+	var principals []int64
+	for _, principal := range data.Principals {
+		principals = append(principals, principal.ID)
+	}
+	assert.Equal(t, []int64{10, 3, 5}, principals, "principals holding only Limited Access are left out")
+	assert.Equal(t, []string{"Full Control", "Edit", "Read", "Approve"}, data.Roles)
+}
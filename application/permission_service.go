@@ -2,49 +2,80 @@ package application
 
 import (
 	"context"
+	"fmt"
 
+	"spaudit/domain/audit"
 	"spaudit/domain/contracts"
 	"spaudit/domain/sharepoint"
 )
 
 // PermissionAnalysisData represents permission analysis results.
 type PermissionAnalysisData struct {
-	TotalAssignments       int
-	UniqueAssignments      int
-	InheritedAssignments   int
-	ItemLevelAssignments   int
-	UserCount              int
-	GroupCount             int
-	SharingLinkCount       int
-	SharingLinkUsers       int
-	FlexibleLinksCount     int
-	OrganizationViewCount  int
-	OrganizationEditCount  int
-	AnonymousViewCount     int
-	AnonymousEditCount     int
-	DirectLinksCount       int
-	OtherLinksCount        int
+	TotalAssignments        int
+	UniqueAssignments       int
+	InheritedAssignments    int
+	ItemLevelAssignments    int
+	UserCount               int
+	GroupCount              int
+	SharingLinkCount        int
+	SharingLinkUsers        int
+	FlexibleLinksCount      int
+	OrganizationViewCount   int
+	OrganizationEditCount   int
+	AnonymousViewCount      int
+	AnonymousEditCount      int
+	DirectLinksCount        int
+	OtherLinksCount         int
+	TotalItems              int64
+	ItemsWithUnique         int64
+	FilesCount              int64
+	FoldersCount            int64
+	FullControlCount        int
+	ContributeCount         int
+	ReadCount               int
+	LimitedAccessCount      int
+	OtherRolesCount         int
+	PermissionRiskLevel     string
+	PermissionRiskScore     float64
+	RiskFromUniqueItems     float64
+	RiskFromAssignments     float64
+	RiskFromSharingLinks    float64
+	RiskFromElevatedAccess  float64
+	UniqueDensity           float64
+	UniqueDensityThreshold  float64
+	ExceedsDensityThreshold bool
+	RiskLowReduced          bool // Factors were halved because the list only grants limited or read access
+	RiskRaisedForDensity    bool // Level raised to Medium because the unique density exceeds the threshold
+	ExceptedObjects         int  // Accepted assignments, sharing links and unique items left out of the counts and risk
+	EffectiveUsers          int  // Distinct users the list's assignments reach, SharePoint and resolved Entra groups expanded
+	ExpandedEntraGroups     int  // Entra groups whose users EffectiveUsers includes
+	UnexpandedEntraGroups   int  // Entra groups the audit run did not resolve, whose users EffectiveUsers leaves out
+}
+
+// SiteRiskSummaryData rolls up list permission analysis across a site.
+type SiteRiskSummaryData struct {
+	ListsAnalyzed          int
+	HighRiskLists          int
+	MediumRiskLists        int
+	LowRiskLists           int
+	DenseLists             int // Lists whose unique-permission density exceeds the threshold
 	TotalItems             int64
 	ItemsWithUnique        int64
-	FilesCount             int64
-	FoldersCount           int64
-	FullControlCount       int
-	ContributeCount        int
-	ReadCount              int
-	LimitedAccessCount     int
-	OtherRolesCount        int
-	PermissionRiskLevel    string
-	PermissionRiskScore    float64
-	RiskFromUniqueItems    float64
-	RiskFromAssignments    float64
-	RiskFromSharingLinks   float64
-	RiskFromElevatedAccess float64
+	SharingLinks           int
+	AnonymousLinks         int
+	OrganizationLinks      int
+	FullControlAssignments int
+	RiskLevel              string  // Highest list risk level, "Low" for a site without lists
+	HighestRiskScore       float64 // Risk score of RiskiestList
+	RiskiestList           *sharepoint.List
 }
 
 // PermissionService handles permission analysis and risk assessment.
 type PermissionService struct {
-	permissionAggregate contracts.PermissionAggregateRepository
-	auditRunID          int64 // For audit-scoped operations
+	permissionAggregate    contracts.PermissionAggregateRepository
+	auditRunID             int64   // For audit-scoped operations
+	uniqueDensityThreshold float64 // Density above which a list is flagged
+	exceptions             *audit.PermissionExceptionSet
 }
 
 // NewPermissionService creates a new permission service.
@@ -68,12 +99,24 @@ func newPermissionService(
 	auditRunID int64,
 ) *PermissionService {
 	return &PermissionService{
-		permissionAggregate: permissionAggregate,
-		auditRunID:          auditRunID,
+		permissionAggregate:    permissionAggregate,
+		auditRunID:             auditRunID,
+		uniqueDensityThreshold: sharepoint.DefaultUniqueDensityThreshold,
 	}
 }
 
-// AnalyzeListPermissions analyzes permissions for a list.
+// SetUniqueDensityThreshold sets the unique-permission density threshold used to flag lists.
+func (s *PermissionService) SetUniqueDensityThreshold(threshold float64) {
+	s.uniqueDensityThreshold = threshold
+}
+
+// SetExceptions sets the accepted exceptions whose sharing links, assignments and unique items are
+// left out of permission analysis.
+func (s *PermissionService) SetExceptions(exceptions *audit.PermissionExceptionSet) {
+	s.exceptions = exceptions
+}
+
+// AnalyzeListPermissions analyzes permissions for a list, leaving out what accepted exceptions cover.
 func (s *PermissionService) AnalyzeListPermissions(
 	ctx context.Context,
 	siteID int64,
@@ -85,67 +128,136 @@ func (s *PermissionService) AnalyzeListPermissions(
 		return nil, err
 	}
 
+	excepted := 0
+	if len(s.exceptions.ForList(list.ID)) > 0 {
+		exceptedComponents, err := s.permissionAggregate.GetExceptedPermissionComponents(ctx, siteID, s.auditRunID, list, s.exceptions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count excepted permissions: %w", err)
+		}
+		excepted = subtractPermissionComponents(components, exceptedComponents)
+	}
+
+	data := s.AnalyzePermissionComponents(components)
+	data.ExceptedObjects = excepted
+	return data, nil
+}
+
+// subtractPermissionComponents removes the excepted counts from a list's grouped counts, dropping
+// groups left empty, and returns how many assignments, links and unique items it removed.
+func subtractPermissionComponents(components, excepted *contracts.PermissionAnalysisComponents) int {
+	removed := 0
+	for _, exceptedCount := range excepted.AssignmentCounts {
+		for i, count := range components.AssignmentCounts {
+			if count.PrincipalType == exceptedCount.PrincipalType && count.RoleDefID == exceptedCount.RoleDefID && count.SharingLink == exceptedCount.SharingLink {
+				n := min(count.Count, exceptedCount.Count)
+				components.AssignmentCounts[i].Count -= n
+				removed += n
+				break
+			}
+		}
+	}
+	assignments := components.AssignmentCounts[:0]
+	for _, count := range components.AssignmentCounts {
+		if count.Count > 0 {
+			assignments = append(assignments, count)
+		}
+	}
+	components.AssignmentCounts = assignments
+
+	for _, exceptedCount := range excepted.SharingLinkCounts {
+		for i, count := range components.SharingLinkCounts {
+			if count.LinkKind == exceptedCount.LinkKind {
+				n := min(count.LinkCount, exceptedCount.LinkCount)
+				components.SharingLinkCounts[i].LinkCount -= n
+				components.SharingLinkCounts[i].MemberCount = max(count.MemberCount-exceptedCount.MemberCount, 0)
+				removed += n
+				break
+			}
+		}
+	}
+	links := components.SharingLinkCounts[:0]
+	for _, count := range components.SharingLinkCounts {
+		if count.LinkCount > 0 {
+			links = append(links, count)
+		}
+	}
+	components.SharingLinkCounts = links
+
+	if components.ItemCounts != nil && excepted.ItemCounts != nil {
+		n := min(components.ItemCounts.ItemsWithUnique, excepted.ItemCounts.ItemsWithUnique)
+		components.ItemCounts.ItemsWithUnique -= n
+		removed += int(n)
+	}
+	return removed
+}
+
+// AnalyzePermissionComponents computes analytics and the risk assessment from a list's grouped counts.
+func (s *PermissionService) AnalyzePermissionComponents(components *contracts.PermissionAnalysisComponents) *PermissionAnalysisData {
+	list := components.List
 	data := &PermissionAnalysisData{
 		// List will be populated by presenter
 	}
 
 	// Calculate assignment analytics - we only audit unique permissions
-	data.TotalAssignments = len(components.Assignments)
-	data.UniqueAssignments = len(components.Assignments) // All assignments we audit are unique
-	data.InheritedAssignments = 0                        // We don't audit inherited permissions
-	data.UserCount, data.GroupCount, _ = s.calculatePrincipalTypes(components.Assignments)
+	for _, count := range components.AssignmentCounts {
+		data.TotalAssignments += count.Count
+	}
+	data.UniqueAssignments = data.TotalAssignments // All assignments we audit are unique
+	data.InheritedAssignments = 0                  // We don't audit inherited permissions
+	data.UserCount, data.GroupCount, _ = s.calculatePrincipalTypes(components.AssignmentCounts)
 	data.FullControlCount, data.ContributeCount, data.ReadCount,
-		data.LimitedAccessCount, data.OtherRolesCount = s.calculateRoleDistribution(components.Assignments)
-
-	// Handle sharing links
-	if components.SharingLinks != nil {
-		data.SharingLinkCount = len(components.SharingLinks)
-		// Calculate total sharing link users by summing actual members count
-		// and count different link types
-		totalSharingLinkUsers := 0
-		for _, link := range components.SharingLinks {
-			totalSharingLinkUsers += int(link.TotalMembersCount)
-
-			// Count by link kind name (using string comparison for reliability)
-			switch link.GetLinkKindName() {
-			case "Flexible":
-				data.FlexibleLinksCount++
-			case "Organization View":
-				data.OrganizationViewCount++
-			case "Organization Edit":
-				data.OrganizationEditCount++
-			case "Anonymous View":
-				data.AnonymousViewCount++
-			case "Anonymous Edit":
-				data.AnonymousEditCount++
-			case "Direct":
-				data.DirectLinksCount++
-			default:
-				data.OtherLinksCount++
-			}
+		data.LimitedAccessCount, data.OtherRolesCount = s.calculateRoleDistribution(components.AssignmentCounts)
+
+	// Handle sharing links, counted by link kind
+	for _, count := range components.SharingLinkCounts {
+		data.SharingLinkCount += count.LinkCount
+		data.SharingLinkUsers += count.MemberCount
+
+		// Count by link kind name (using string comparison for reliability)
+		link := &sharepoint.SharingLink{LinkKind: count.LinkKind}
+		switch link.GetLinkKindName() {
+		case "Flexible":
+			data.FlexibleLinksCount += count.LinkCount
+		case "Organization View":
+			data.OrganizationViewCount += count.LinkCount
+		case "Organization Edit":
+			data.OrganizationEditCount += count.LinkCount
+		case "Anonymous View":
+			data.AnonymousViewCount += count.LinkCount
+		case "Anonymous Edit":
+			data.AnonymousEditCount += count.LinkCount
+		case "Direct":
+			data.DirectLinksCount += count.LinkCount
+		default:
+			data.OtherLinksCount += count.LinkCount
 		}
-		data.SharingLinkUsers = totalSharingLinkUsers
+	}
+
+	// Effective users, counted before exceptions as an accepted assignment still reaches its users
+	if components.EffectiveUsers != nil {
+		data.EffectiveUsers = components.EffectiveUsers.Users
+		data.ExpandedEntraGroups = components.EffectiveUsers.ResolvedGroups
+		data.UnexpandedEntraGroups = components.EffectiveUsers.UnresolvedGroups
 	}
 
 	// Always use SharePoint's reported item count for total
 	data.TotalItems = int64(list.ItemCount)
 
 	// Handle items analysis
-	if components.Items != nil {
-		// Use content service for comprehensive analysis
-		contentService := sharepoint.NewContentService()
-		contentAnalysis := contentService.AnalyzeItems(components.Items)
-		data.ItemsWithUnique = contentAnalysis.ItemsWithUnique
-		data.FilesCount = contentAnalysis.FilesCount
-		data.FoldersCount = contentAnalysis.FoldersCount
-
-		// Calculate item-level assignments count for items with unique permissions
+	if components.ItemCounts != nil {
+		data.ItemsWithUnique = components.ItemCounts.ItemsWithUnique
+		data.FilesCount = components.ItemCounts.FilesCount
+		data.FoldersCount = components.ItemCounts.FoldersCount
+
+		// Item-level assignments are not counted in the aggregate approach
 		data.ItemLevelAssignments = 0
-		// This would require additional repository calls in the aggregate approach
+	} else {
+		// Fall back to the count persisted at the end of the audit run
+		data.ItemsWithUnique = int64(list.UniqueItemCount)
 	}
 
 	// Risk assessment using extracted business logic
-	permissionsService := sharepoint.NewPermissionsService()
+	permissionsService := sharepoint.NewPermissionsServiceWithDensityThreshold(s.uniqueDensityThreshold)
 	riskData := &sharepoint.SharePointRiskData{
 		TotalItems:         data.TotalItems,
 		ItemsWithUnique:    data.ItemsWithUnique,
@@ -165,44 +277,109 @@ func (s *PermissionService) AnalyzeListPermissions(
 	data.RiskFromAssignments = assessment.RiskFromAssignments
 	data.RiskFromSharingLinks = assessment.RiskFromSharingLinks
 	data.RiskFromElevatedAccess = assessment.RiskFromElevatedAccess
+	data.UniqueDensity = assessment.UniqueDensity
+	data.UniqueDensityThreshold = permissionsService.UniqueDensityThreshold()
+	data.ExceedsDensityThreshold = assessment.ExceedsDensityThreshold
+	data.RiskLowReduced = assessment.LowRiskReduced
+	data.RiskRaisedForDensity = assessment.RaisedForDensity
 
-	return data, nil
+	return data
+}
+
+// SummarizeSiteRisk analyzes each list and rolls the results up into a site-level summary.
+// The site's risk level is that of its riskiest list.
+func (s *PermissionService) SummarizeSiteRisk(
+	ctx context.Context,
+	siteID int64,
+	lists []*sharepoint.List,
+) (*SiteRiskSummaryData, error) {
+	summary := &SiteRiskSummaryData{RiskLevel: "Low"}
+
+	for _, list := range lists {
+		analysis, err := s.AnalyzeListPermissions(ctx, siteID, list)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze list %s: %w", list.ID, err)
+		}
+
+		summary.ListsAnalyzed++
+		switch analysis.PermissionRiskLevel {
+		case "High":
+			summary.HighRiskLists++
+		case "Medium":
+			summary.MediumRiskLists++
+		default:
+			summary.LowRiskLists++
+		}
+		if analysis.ExceedsDensityThreshold {
+			summary.DenseLists++
+		}
+
+		summary.TotalItems += analysis.TotalItems
+		summary.ItemsWithUnique += analysis.ItemsWithUnique
+		summary.SharingLinks += analysis.SharingLinkCount
+		summary.AnonymousLinks += analysis.AnonymousViewCount + analysis.AnonymousEditCount
+		summary.OrganizationLinks += analysis.OrganizationViewCount + analysis.OrganizationEditCount
+		summary.FullControlAssignments += analysis.FullControlCount
+
+		if summary.RiskiestList == nil || analysis.PermissionRiskScore > summary.HighestRiskScore {
+			summary.RiskiestList = list
+			summary.HighestRiskScore = analysis.PermissionRiskScore
+		}
+		if riskLevelRank(analysis.PermissionRiskLevel) > riskLevelRank(summary.RiskLevel) {
+			summary.RiskLevel = analysis.PermissionRiskLevel
+		}
+	}
+
+	return summary, nil
+}
+
+// riskLevelRank orders risk levels from Low to High.
+func riskLevelRank(level string) int {
+	switch level {
+	case "High":
+		return 2
+	case "Medium":
+		return 1
+	default:
+		return 0
+	}
 }
 
-// calculatePrincipalTypes counts different types of principals.
-func (s *PermissionService) calculatePrincipalTypes(assignments []*sharepoint.Assignment) (users, groups, sharingLinks int) {
-	for _, assignment := range assignments {
-		switch assignment.Principal.PrincipalType {
+// calculatePrincipalTypes counts assignments by principal type.
+func (s *PermissionService) calculatePrincipalTypes(counts []contracts.AssignmentCount) (users, groups, sharingLinks int) {
+	for _, count := range counts {
+		switch count.PrincipalType {
 		case sharepoint.PrincipalTypeUser:
-			users++
+			users += count.Count
 		case sharepoint.PrincipalTypeSecurity, sharepoint.PrincipalTypeSharePointGroup:
-			groups++
+			groups += count.Count
 		default:
-			// Check if it's a sharing link based on login name pattern
-			if len(assignment.Principal.LoginName) > 12 && assignment.Principal.LoginName[:12] == "SharingLinks" {
-				sharingLinks++
+			// Sharing link principals are identified by their login name pattern
+			if count.SharingLink {
+				sharingLinks += count.Count
 			} else {
-				groups++ // Default unknown types to groups
+				groups += count.Count // Default unknown types to groups
 			}
 		}
 	}
 	return
 }
 
-// calculateRoleDistribution counts assignments by role type.
-func (s *PermissionService) calculateRoleDistribution(assignments []*sharepoint.Assignment) (fullControl, contribute, read, limitedAccess, other int) {
-	for _, assignment := range assignments {
-		switch assignment.RoleDefinition.Name {
-		case "Full Control":
-			fullControl++
-		case "Contribute":
-			contribute++
-		case "Read":
-			read++
-		case "Limited Access", "Web-Only Limited Access":
-			limitedAccess++
+// calculateRoleDistribution counts assignments by role type. Built-in roles are matched by
+// their canonical names, so localized tenants are counted the same way.
+func (s *PermissionService) calculateRoleDistribution(counts []contracts.AssignmentCount) (fullControl, contribute, read, limitedAccess, other int) {
+	for _, count := range counts {
+		switch sharepoint.CanonicalRoleName(count.RoleDefID, count.RoleName) {
+		case sharepoint.RoleNameFullControl:
+			fullControl += count.Count
+		case sharepoint.RoleNameContribute:
+			contribute += count.Count
+		case sharepoint.RoleNameRead:
+			read += count.Count
+		case sharepoint.RoleNameLimitedAccess, sharepoint.RoleNameWebOnlyLimitedAccess:
+			limitedAccess += count.Count
 		default:
-			other++
+			other += count.Count
 		}
 	}
 	return
@@ -0,0 +1,178 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	"spaudit/test/helpers"
+	"spaudit/test/mocks"
+)
+
+func TestPermissionService_SummarizeSiteRisk(t *testing.T) {
+	// Arrange
+	testData := helpers.NewTestData()
+	calmList := testData.SimpleList("calm", false, 100)
+	riskyList := testData.SimpleList("risky", true, 10)
+
+	repo := &mocks.MockPermissionAggregateRepository{}
+	repo.On("GetPermissionAnalysisComponents", mock.Anything, int64(1), int64(7), calmList).
+		Return(&contracts.PermissionAnalysisComponents{List: calmList}, nil)
+	repo.On("GetPermissionAnalysisComponents", mock.Anything, int64(1), int64(7), riskyList).
+		Return(&contracts.PermissionAnalysisComponents{
+			List: riskyList,
+			AssignmentCounts: []contracts.AssignmentCount{
+				{PrincipalType: sharepoint.PrincipalTypeUser, RoleName: "Full Control", Count: 50},
+			},
+			SharingLinkCounts: []contracts.SharingLinkKindCount{
+				{LinkKind: sharepoint.LinkKindAnonymousView, LinkCount: 5},
+				{LinkKind: sharepoint.LinkKindOrganizationView, LinkCount: 3},
+			},
+			ItemCounts: &contracts.ItemCounts{TotalItems: 10, ItemsWithUnique: 10},
+		}, nil)
+
+	service := NewAuditScopedPermissionService(repo, 7)
+
+	// Act
+	summary, err := service.SummarizeSiteRisk(context.Background(), 1, []*sharepoint.List{calmList, riskyList})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, summary.ListsAnalyzed)
+	assert.Equal(t, 1, summary.HighRiskLists)
+	assert.Equal(t, 0, summary.MediumRiskLists)
+	assert.Equal(t, 1, summary.LowRiskLists)
+	assert.Equal(t, 1, summary.DenseLists)
+	assert.Equal(t, int64(110), summary.TotalItems)
+	assert.Equal(t, int64(10), summary.ItemsWithUnique)
+	assert.Equal(t, 8, summary.SharingLinks)
+	assert.Equal(t, 5, summary.AnonymousLinks)
+	assert.Equal(t, 3, summary.OrganizationLinks)
+	assert.Equal(t, 50, summary.FullControlAssignments)
+
+	// The site takes the risk level of its riskiest list
+	assert.Equal(t, "High", summary.RiskLevel)
+	assert.Same(t, riskyList, summary.RiskiestList)
+	assert.Greater(t, summary.HighestRiskScore, 50.0)
+	repo.AssertExpectations(t)
+}
+
+func TestPermissionService_SummarizeSiteRisk_NoLists(t *testing.T) {
+	service := NewAuditScopedPermissionService(&mocks.MockPermissionAggregateRepository{}, 7)
+
+	summary, err := service.SummarizeSiteRisk(context.Background(), 1, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Low", summary.RiskLevel)
+	assert.Equal(t, 0, summary.ListsAnalyzed)
+	assert.Nil(t, summary.RiskiestList)
+}
+
+func TestPermissionService_SummarizeSiteRisk_RepositoryError(t *testing.T) {
+	list := helpers.NewTestData().SimpleList("broken", true, 10)
+	repo := &mocks.MockPermissionAggregateRepository{}
+	repo.On("GetPermissionAnalysisComponents", mock.Anything, int64(1), int64(7), list).
+		Return(nil, errors.New("database error"))
+
+	service := NewAuditScopedPermissionService(repo, 7)
+
+	summary, err := service.SummarizeSiteRisk(context.Background(), 1, []*sharepoint.List{list})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "broken")
+	assert.Nil(t, summary)
+}
+
+func TestPermissionService_AnalyzePermissionComponents_LocalizedRoleNames(t *testing.T) {
+	service := NewAuditScopedPermissionService(&mocks.MockPermissionAggregateRepository{}, 7)
+
+	// A German tenant names the built-in levels differently; their IDs are the same everywhere
+	data := service.AnalyzePermissionComponents(&contracts.PermissionAnalysisComponents{
+		List: helpers.NewTestData().SimpleList("docs", true, 10),
+		AssignmentCounts: []contracts.AssignmentCount{
+			{PrincipalType: sharepoint.PrincipalTypeUser, RoleDefID: 1073741829, RoleName: "Vollzugriff", Count: 2},
+			{PrincipalType: sharepoint.PrincipalTypeUser, RoleDefID: 1073741827, RoleName: "Mitwirken", Count: 3},
+			{PrincipalType: sharepoint.PrincipalTypeUser, RoleDefID: 1073741826, RoleName: "Lesen", Count: 4},
+			{PrincipalType: sharepoint.PrincipalTypeUser, RoleDefID: 1073741825, RoleName: "Beschränkter Zugriff", Count: 5},
+			{PrincipalType: sharepoint.PrincipalTypeUser, RoleDefID: 1073742000, RoleName: "Projektleitung", Count: 1},
+		},
+	})
+
+	assert.Equal(t, 2, data.FullControlCount)
+	assert.Equal(t, 3, data.ContributeCount)
+	assert.Equal(t, 4, data.ReadCount)
+	assert.Equal(t, 5, data.LimitedAccessCount)
+	assert.Equal(t, 1, data.OtherRolesCount)
+}
+
+func TestPermissionService_AnalyzePermissionComponents_EffectiveUsers(t *testing.T) {
+	service := NewAuditScopedPermissionService(&mocks.MockPermissionAggregateRepository{}, 7)
+
+	data := service.AnalyzePermissionComponents(&contracts.PermissionAnalysisComponents{
+		List: helpers.NewTestData().SimpleList("docs", true, 10),
+		AssignmentCounts: []contracts.AssignmentCount{
+			{PrincipalType: sharepoint.PrincipalTypeUser, RoleDefID: 1073741826, RoleName: "Read", Count: 2},
+			{PrincipalType: sharepoint.PrincipalTypeSecurity, RoleDefID: 1073741826, RoleName: "Read", Count: 2},
+		},
+		EffectiveUsers: &contracts.EffectiveUserCounts{Users: 140, ResolvedGroups: 1, UnresolvedGroups: 1},
+	})
+
+	assert.Equal(t, 2, data.UserCount)
+	assert.Equal(t, 140, data.EffectiveUsers)
+	assert.Equal(t, 1, data.ExpandedEntraGroups)
+	assert.Equal(t, 1, data.UnexpandedEntraGroups)
+}
+
+func TestPermissionService_AnalyzeListPermissions_LeavesOutExceptions(t *testing.T) {
+	list := helpers.NewTestData().SimpleList("shared", true, 10)
+	exceptions := audit.NewPermissionExceptionSet([]*audit.PermissionException{
+		{ListID: "shared", Kind: audit.ExceptionSharingLink, ObjectKey: "link-1", ExpiresAt: time.Now().Add(time.Hour)},
+	}, time.Now())
+
+	repo := &mocks.MockPermissionAggregateRepository{}
+	repo.On("GetPermissionAnalysisComponents", mock.Anything, int64(1), int64(7), list).
+		Return(&contracts.PermissionAnalysisComponents{
+			List: list,
+			AssignmentCounts: []contracts.AssignmentCount{
+				{PrincipalType: sharepoint.PrincipalTypeUser, RoleDefID: 1073741829, RoleName: "Full Control", Count: 2},
+			},
+			SharingLinkCounts: []contracts.SharingLinkKindCount{
+				{LinkKind: sharepoint.LinkKindAnonymousView, LinkCount: 1, MemberCount: 0},
+				{LinkKind: sharepoint.LinkKindOrganizationView, LinkCount: 2, MemberCount: 3},
+			},
+			ItemCounts: &contracts.ItemCounts{TotalItems: 10, ItemsWithUnique: 2},
+		}, nil)
+	repo.On("GetExceptedPermissionComponents", mock.Anything, int64(1), int64(7), list, exceptions).
+		Return(&contracts.PermissionAnalysisComponents{
+			List: list,
+			AssignmentCounts: []contracts.AssignmentCount{
+				{PrincipalType: sharepoint.PrincipalTypeUser, RoleDefID: 1073741829, RoleName: "Full Control", Count: 1},
+			},
+			SharingLinkCounts: []contracts.SharingLinkKindCount{
+				{LinkKind: sharepoint.LinkKindAnonymousView, LinkCount: 1},
+			},
+			ItemCounts: &contracts.ItemCounts{ItemsWithUnique: 1},
+		}, nil)
+
+	service := NewAuditScopedPermissionService(repo, 7)
+	service.SetExceptions(exceptions)
+
+	data, err := service.AnalyzeListPermissions(context.Background(), 1, list)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, data.ExceptedObjects)
+	assert.Equal(t, 1, data.FullControlCount)
+	assert.Equal(t, 2, data.SharingLinkCount)
+	assert.Equal(t, 0, data.AnonymousViewCount)
+	assert.Equal(t, 3, data.SharingLinkUsers)
+	assert.Equal(t, int64(1), data.ItemsWithUnique)
+	repo.AssertExpectations(t)
+}
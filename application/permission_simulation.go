@@ -0,0 +1,269 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+// ErrInvalidProposedChange is returned when a proposed change does not apply to the list as audited.
+var ErrInvalidProposedChange = errors.New("invalid proposed change")
+
+// Proposed change actions for what-if simulations.
+const (
+	ProposedRemoveAssignment = "remove_assignment" // Remove every role of a principal on the list or one of its items
+	ProposedRevokeLink       = "revoke_link"       // Delete a sharing link, its members and the link's group assignments
+)
+
+// ProposedChange is one remediation step to simulate against a list's stored audit data.
+type ProposedChange struct {
+	Action      string
+	PrincipalID int64  // Remove assignment only
+	ItemGUID    string // Remove assignment only: the item to remove the principal from, empty for the list itself
+	LinkID      string // Revoke link only
+}
+
+// PermissionSimulationData previews the impact of proposed changes on a list before they are made in SharePoint.
+type PermissionSimulationData struct {
+	List       *sharepoint.List
+	AuditRunID int64
+	Proposed   []ProposedChange
+	Before     *PermissionAnalysisData
+	After      *PermissionAnalysisData
+
+	// Permissions the changes would remove, in snapshot diff order
+	Changes []*SnapshotChange
+
+	// Principals left without any assignment or link membership on the list or its items
+	AccessLost []*sharepoint.Principal
+
+	// Principals that keep some access but lose at least one grant
+	AccessReduced []*sharepoint.Principal
+}
+
+// SimulateListChanges applies proposed changes to a copy of a list snapshot and recomputes
+// effective access and the risk assessment, leaving the stored audit data untouched.
+func (s *PermissionService) SimulateListChanges(snapshot *ListSnapshotData, proposed []ProposedChange) (*PermissionSimulationData, error) {
+	if len(proposed) == 0 {
+		return nil, fmt.Errorf("no changes proposed: %w", ErrInvalidProposedChange)
+	}
+
+	simulated := copyListSnapshot(snapshot)
+	for i, change := range proposed {
+		if err := applyProposedChange(simulated, change); err != nil {
+			return nil, fmt.Errorf("change %d: %w", i+1, err)
+		}
+	}
+
+	data := &PermissionSimulationData{
+		List:       snapshot.List,
+		AuditRunID: snapshot.AuditRunID,
+		Proposed:   proposed,
+		Before:     s.AnalyzePermissionComponents(snapshotAnalysisComponents(snapshot)),
+		After:      s.AnalyzePermissionComponents(snapshotAnalysisComponents(simulated)),
+		Changes:    DiffListSnapshots(snapshot, simulated).Changes,
+	}
+
+	_, data.AccessLost = diffPrincipals(snapshotPrincipals(snapshot), snapshotPrincipals(simulated))
+	lost := make(map[int64]bool, len(data.AccessLost))
+	for _, principal := range data.AccessLost {
+		lost[principal.ID] = true
+	}
+	reduced := make(map[int64]bool)
+	for _, change := range data.Changes {
+		if change.Principal == nil || lost[change.Principal.ID] || reduced[change.Principal.ID] {
+			continue
+		}
+		reduced[change.Principal.ID] = true
+		data.AccessReduced = append(data.AccessReduced, change.Principal)
+	}
+	sort.SliceStable(data.AccessReduced, func(i, j int) bool { return data.AccessReduced[i].ID < data.AccessReduced[j].ID })
+
+	return data, nil
+}
+
+// applyProposedChange removes what a change would remove from the simulated snapshot.
+func applyProposedChange(snapshot *ListSnapshotData, change ProposedChange) error {
+	switch change.Action {
+	case ProposedRemoveAssignment:
+		if change.PrincipalID == 0 {
+			return fmt.Errorf("principal_id is required to remove an assignment: %w", ErrInvalidProposedChange)
+		}
+		if change.ItemGUID == "" {
+			remaining := snapshot.Assignments[:0:0]
+			for _, resolved := range snapshot.Assignments {
+				if resolved.Assignment.RoleAssignment.PrincipalID != change.PrincipalID {
+					remaining = append(remaining, resolved)
+				}
+			}
+			if len(remaining) == len(snapshot.Assignments) {
+				return fmt.Errorf("principal %d has no assignment on list %s: %w", change.PrincipalID, snapshot.List.ID, ErrInvalidProposedChange)
+			}
+			snapshot.Assignments = remaining
+			return nil
+		}
+
+		assignments := snapshot.ItemAssignments[change.ItemGUID]
+		remaining := removeAssignments(assignments, func(assignment *sharepoint.Assignment) bool {
+			return assignment.RoleAssignment.PrincipalID == change.PrincipalID
+		})
+		if len(remaining) == len(assignments) {
+			return fmt.Errorf("principal %d has no assignment on item %s: %w", change.PrincipalID, change.ItemGUID, ErrInvalidProposedChange)
+		}
+		snapshot.ItemAssignments[change.ItemGUID] = remaining
+		return nil
+
+	case ProposedRevokeLink:
+		if change.LinkID == "" {
+			return fmt.Errorf("link_id is required to revoke a link: %w", ErrInvalidProposedChange)
+		}
+		remaining := snapshot.SharingLinks[:0:0]
+		for _, link := range snapshot.SharingLinks {
+			if !strings.EqualFold(link.ID, change.LinkID) {
+				remaining = append(remaining, link)
+			}
+		}
+		if len(remaining) == len(snapshot.SharingLinks) {
+			return fmt.Errorf("sharing link %s not found on list %s: %w", change.LinkID, snapshot.List.ID, ErrInvalidProposedChange)
+		}
+		snapshot.SharingLinks = remaining
+
+		// SharePoint deletes the link's "SharingLinks.*" group along with the link
+		sharingService := sharepoint.NewSharingService()
+		isLinkGroup := func(principal *sharepoint.Principal) bool {
+			return principal != nil && principal.IsSharingLinkPrincipal() &&
+				strings.EqualFold(sharingService.ParseSharingLink(principal.LoginName).SharingID, change.LinkID)
+		}
+		listAssignments := snapshot.Assignments[:0:0]
+		for _, resolved := range snapshot.Assignments {
+			if !isLinkGroup(resolved.Assignment.Principal) {
+				listAssignments = append(listAssignments, resolved)
+			}
+		}
+		snapshot.Assignments = listAssignments
+		for itemGUID, assignments := range snapshot.ItemAssignments {
+			snapshot.ItemAssignments[itemGUID] = removeAssignments(assignments, func(assignment *sharepoint.Assignment) bool {
+				return isLinkGroup(assignment.Principal)
+			})
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown action %q: %w", change.Action, ErrInvalidProposedChange)
+	}
+}
+
+// removeAssignments returns the assignments that do not match, without modifying the slice passed in.
+func removeAssignments(assignments []*sharepoint.Assignment, matches func(*sharepoint.Assignment) bool) []*sharepoint.Assignment {
+	remaining := assignments[:0:0]
+	for _, assignment := range assignments {
+		if !matches(assignment) {
+			remaining = append(remaining, assignment)
+		}
+	}
+	return remaining
+}
+
+// copyListSnapshot copies the collections a simulation changes so the original snapshot is not modified.
+func copyListSnapshot(snapshot *ListSnapshotData) *ListSnapshotData {
+	simulated := *snapshot
+	simulated.Assignments = append(snapshot.Assignments[:0:0], snapshot.Assignments...)
+	simulated.SharingLinks = append(snapshot.SharingLinks[:0:0], snapshot.SharingLinks...)
+	simulated.ItemAssignments = make(map[string][]*sharepoint.Assignment, len(snapshot.ItemAssignments))
+	for itemGUID, assignments := range snapshot.ItemAssignments {
+		simulated.ItemAssignments[itemGUID] = append(assignments[:0:0], assignments...)
+	}
+	return &simulated
+}
+
+// snapshotAnalysisComponents groups a snapshot into the counts permission analysis uses,
+// matching the grouped count queries run against stored audit data.
+func snapshotAnalysisComponents(snapshot *ListSnapshotData) *contracts.PermissionAnalysisComponents {
+	components := &contracts.PermissionAnalysisComponents{List: snapshot.List}
+
+	assignmentCounts := make(map[contracts.AssignmentCount]int)
+	for _, resolved := range snapshot.Assignments {
+		assignment := resolved.Assignment
+		if assignment.Principal == nil || assignment.RoleDefinition == nil {
+			continue
+		}
+		key := contracts.AssignmentCount{
+			PrincipalType: assignment.Principal.PrincipalType,
+			RoleDefID:     assignment.RoleDefinition.ID,
+			RoleName:      assignment.RoleDefinition.Name,
+			SharingLink:   assignment.Principal.IsSharingLinkPrincipal(),
+		}
+		assignmentCounts[key]++
+	}
+	for key, count := range assignmentCounts {
+		key.Count = count
+		components.AssignmentCounts = append(components.AssignmentCounts, key)
+	}
+
+	linkCounts := make(map[int]*contracts.SharingLinkKindCount)
+	for _, link := range snapshot.SharingLinks {
+		if !link.IsActive {
+			continue
+		}
+		count, exists := linkCounts[link.LinkKind]
+		if !exists {
+			count = &contracts.SharingLinkKindCount{LinkKind: link.LinkKind}
+			linkCounts[link.LinkKind] = count
+		}
+		count.LinkCount++
+		count.MemberCount += len(link.Members)
+	}
+	for _, count := range linkCounts {
+		components.SharingLinkCounts = append(components.SharingLinkCounts, *count)
+	}
+
+	if len(snapshot.Items) > 0 {
+		components.ItemCounts = &contracts.ItemCounts{TotalItems: int64(len(snapshot.Items))}
+		for _, item := range snapshot.Items {
+			if item.HasUnique {
+				components.ItemCounts.ItemsWithUnique++
+			}
+			if item.IsFile {
+				components.ItemCounts.FilesCount++
+			} else if item.IsFolder {
+				components.ItemCounts.FoldersCount++
+			}
+		}
+	}
+
+	return components
+}
+
+// snapshotPrincipals returns every principal with an assignment or link membership on the list or its items, by ID.
+func snapshotPrincipals(snapshot *ListSnapshotData) []*sharepoint.Principal {
+	seen := make(map[int64]bool)
+	var principals []*sharepoint.Principal
+	add := func(principal *sharepoint.Principal) {
+		if principal == nil || seen[principal.ID] {
+			return
+		}
+		seen[principal.ID] = true
+		principals = append(principals, principal)
+	}
+
+	for _, resolved := range snapshot.Assignments {
+		add(resolved.Assignment.Principal)
+	}
+	for _, assignments := range snapshot.ItemAssignments {
+		for _, assignment := range assignments {
+			add(assignment.Principal)
+		}
+	}
+	for _, link := range snapshot.SharingLinks {
+		for _, member := range link.Members {
+			add(member)
+		}
+	}
+
+	sort.SliceStable(principals, func(i, j int) bool { return principals[i].ID < principals[j].ID })
+	return principals
+}
@@ -0,0 +1,136 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/sharepoint"
+)
+
+const simulationLinkID = "9a8b7c6d-0000-4000-8000-000000000002"
+
+func newSimulationTestSnapshot() *ListSnapshotData {
+	finance := &sharepoint.Principal{ID: 7, Title: "Finance", LoginName: "Finance", PrincipalType: sharepoint.PrincipalTypeSharePointGroup}
+	alice := &sharepoint.Principal{ID: 9, Title: "Alice", LoginName: "i:0#.f|membership|alice@contoso.com", PrincipalType: sharepoint.PrincipalTypeUser}
+	bob := &sharepoint.Principal{ID: 11, Title: "Bob", LoginName: "i:0#.f|membership|bob@contoso.com", PrincipalType: sharepoint.PrincipalTypeUser}
+	linkGroup := &sharepoint.Principal{
+		ID:            20,
+		LoginName:     "SharingLinks.0f1e2d3c-0000-4000-8000-000000000001.OrganizationEdit." + simulationLinkID,
+		PrincipalType: sharepoint.PrincipalTypeSharePointGroup,
+	}
+	contribute := &sharepoint.RoleDefinition{ID: 1073741827, Name: "Contribute"}
+	fullControl := &sharepoint.RoleDefinition{ID: 1073741829, Name: "Full Control"}
+	assign := func(objectType, objectKey string, principal *sharepoint.Principal, role *sharepoint.RoleDefinition) *sharepoint.Assignment {
+		return &sharepoint.Assignment{
+			RoleAssignment: &sharepoint.RoleAssignment{ObjectType: objectType, ObjectKey: objectKey, PrincipalID: principal.ID, RoleDefID: role.ID},
+			Principal:      principal,
+			RoleDefinition: role,
+		}
+	}
+
+	return &ListSnapshotData{
+		List:       &sharepoint.List{ID: "docs", Title: "Documents", ItemCount: 2},
+		AuditRunID: 3,
+		Assignments: []*sharepoint.ResolvedAssignment{
+			{Assignment: assign(sharepoint.ObjectTypeList, "docs", finance, fullControl)},
+			{Assignment: assign(sharepoint.ObjectTypeList, "docs", alice, contribute)},
+		},
+		Items: []*sharepoint.Item{
+			{GUID: "budget", Name: "budget.xlsx", IsFile: true, HasUnique: true},
+			{GUID: "notes", Name: "notes.docx", IsFile: true},
+		},
+		ItemAssignments: map[string][]*sharepoint.Assignment{
+			"budget": {
+				assign(sharepoint.ObjectTypeItem, "budget", finance, fullControl),
+				assign(sharepoint.ObjectTypeItem, "budget", linkGroup, contribute),
+			},
+		},
+		SharingLinks: []*sharepoint.SharingLink{
+			{ID: "9A8B7C6D-0000-4000-8000-000000000002", ItemGUID: "budget", LinkKind: 4, IsActive: true, IsEditLink: true, Members: []*sharepoint.Principal{alice, bob}},
+		},
+	}
+}
+
+func TestPermissionService_SimulateListChanges_RemoveAssignment(t *testing.T) {
+	service := NewPermissionService(nil)
+	snapshot := newSimulationTestSnapshot()
+
+	result, err := service.SimulateListChanges(snapshot, []ProposedChange{
+		{Action: ProposedRemoveAssignment, PrincipalID: 7},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, result.Before.TotalAssignments)
+	assert.Equal(t, 1, result.After.TotalAssignments)
+	assert.Equal(t, 1, result.Before.FullControlCount)
+	assert.Equal(t, 0, result.After.FullControlCount)
+	assert.Less(t, result.After.PermissionRiskScore, result.Before.PermissionRiskScore)
+
+	require.Len(t, result.Changes, 1)
+	assert.Equal(t, ChangeRemoved, result.Changes[0].Change)
+	assert.Equal(t, ChangeCategoryListAssignment, result.Changes[0].Category)
+	assert.Equal(t, "Full Control", result.Changes[0].Role)
+
+	// Finance keeps its item assignment on budget.xlsx
+	assert.Empty(t, result.AccessLost)
+	require.Len(t, result.AccessReduced, 1)
+	assert.Equal(t, int64(7), result.AccessReduced[0].ID)
+
+	assert.Len(t, snapshot.Assignments, 2, "the stored snapshot is not modified")
+}
+
+func TestPermissionService_SimulateListChanges_RevokeLink(t *testing.T) {
+	service := NewPermissionService(nil)
+	snapshot := newSimulationTestSnapshot()
+
+	result, err := service.SimulateListChanges(snapshot, []ProposedChange{
+		{Action: ProposedRevokeLink, LinkID: simulationLinkID},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Before.SharingLinkCount)
+	assert.Equal(t, 0, result.After.SharingLinkCount)
+	assert.Less(t, result.After.RiskFromSharingLinks, result.Before.RiskFromSharingLinks)
+
+	// The link, its two members and the link group's assignment on the item go
+	var categories []string
+	for _, change := range result.Changes {
+		assert.Equal(t, ChangeRemoved, change.Change)
+		categories = append(categories, change.Category)
+	}
+	assert.Equal(t, []string{ChangeCategoryItemAssignment, ChangeCategorySharingLink, ChangeCategorySharingLinkMember, ChangeCategorySharingLinkMember}, categories)
+
+	// Bob only had access through the link; Alice keeps her list assignment
+	var lost []int64
+	for _, principal := range result.AccessLost {
+		lost = append(lost, principal.ID)
+	}
+	assert.Equal(t, []int64{11, 20}, lost)
+	require.Len(t, result.AccessReduced, 1)
+	assert.Equal(t, int64(9), result.AccessReduced[0].ID)
+
+	assert.Len(t, snapshot.ItemAssignments["budget"], 2, "the stored snapshot is not modified")
+}
+
+func TestPermissionService_SimulateListChanges_Invalid(t *testing.T) {
+	service := NewPermissionService(nil)
+	snapshot := newSimulationTestSnapshot()
+
+	for name, proposed := range map[string][]ProposedChange{
+		"no changes":           nil,
+		"unknown action":       {{Action: "grant"}},
+		"missing principal":    {{Action: ProposedRemoveAssignment}},
+		"not assigned":         {{Action: ProposedRemoveAssignment, PrincipalID: 11}},
+		"not assigned on item": {{Action: ProposedRemoveAssignment, PrincipalID: 9, ItemGUID: "budget"}},
+		"unknown link":         {{Action: ProposedRevokeLink, LinkID: "missing"}},
+		"revoked twice": {
+			{Action: ProposedRevokeLink, LinkID: simulationLinkID},
+			{Action: ProposedRevokeLink, LinkID: simulationLinkID},
+		},
+	} {
+		_, err := service.SimulateListChanges(snapshot, proposed)
+		assert.ErrorIs(t, err, ErrInvalidProposedChange, name)
+	}
+}
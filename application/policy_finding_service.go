@@ -0,0 +1,204 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// ErrPolicyEvaluationNotFound is returned for an audit run never checked against the access policies.
+var ErrPolicyEvaluationNotFound = errors.New("audit run not checked against the access policies")
+
+// PolicyFindingService checks audit runs against the access policies and keeps what violates them, so
+// findings can be listed without checking the run again. Folder-scoped runs only capture part of a
+// site and are not checked.
+type PolicyFindingService struct {
+	db             *database.Database
+	serviceFactory AuditRunScopedServiceFactory
+	policies       []audit.AccessPolicy
+	now            func() time.Time
+	logger         *logging.Logger
+}
+
+// NewPolicyFindingService creates a new policy finding service checking runs against the policies.
+func NewPolicyFindingService(db *database.Database, serviceFactory AuditRunScopedServiceFactory, policies []audit.AccessPolicy) *PolicyFindingService {
+	return &PolicyFindingService{
+		db:             db,
+		serviceFactory: serviceFactory,
+		policies:       policies,
+		now:            func() time.Time { return time.Now().UTC() },
+		logger:         logging.Default().WithComponent("policy_finding_service"),
+	}
+}
+
+// Policies returns the access policies runs are checked against.
+func (s *PolicyFindingService) Policies() []audit.AccessPolicy {
+	return s.policies
+}
+
+// EvaluateRun checks an audit run against the access policies and records its findings, replacing
+// those of an earlier check, e.g. once the policies changed. A folder-scoped run is skipped and returns nil.
+func (s *PolicyFindingService) EvaluateRun(ctx context.Context, auditRunID int64) (*audit.PolicyEvaluation, error) {
+	auditRun, err := s.db.ReadQueries().GetAuditRun(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("audit run %d not found: %w", auditRunID, err)
+	}
+	if auditRun.ScopePath.Valid {
+		return nil, nil
+	}
+	services, err := s.serviceFactory.CreateForAuditRun(ctx, auditRun.SiteID, strconv.FormatInt(auditRunID, 10))
+	if err != nil {
+		return nil, err
+	}
+	findings, err := services.SiteContentService.CheckAccessPolicies(ctx, auditRun.SiteID, s.policies)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluation := &audit.PolicyEvaluation{
+		SiteID:      auditRun.SiteID,
+		AuditRunID:  auditRunID,
+		EvaluatedAt: s.now(),
+		Policies:    len(s.policies),
+		Findings:    findings,
+	}
+	err = s.db.WithTx(func(queries *db.Queries) error {
+		if err := queries.DeletePolicyFindings(ctx, auditRunID); err != nil {
+			return err
+		}
+		if err := queries.DeletePolicyEvaluation(ctx, auditRunID); err != nil {
+			return err
+		}
+		err := queries.CreatePolicyEvaluation(ctx, db.CreatePolicyEvaluationParams{
+			AuditRunID:  evaluation.AuditRunID,
+			SiteID:      evaluation.SiteID,
+			EvaluatedAt: evaluation.EvaluatedAt,
+			Policies:    int64(evaluation.Policies),
+		})
+		if err != nil {
+			return err
+		}
+		for _, finding := range findings {
+			finding.SiteID, finding.AuditRunID = evaluation.SiteID, evaluation.AuditRunID
+			err := queries.CreatePolicyFinding(ctx, db.CreatePolicyFindingParams{
+				AuditRunID:  finding.AuditRunID,
+				SiteID:      finding.SiteID,
+				Policy:      finding.Policy,
+				Severity:    string(finding.Severity),
+				ObjectType:  finding.ObjectType,
+				ObjectKey:   finding.ObjectKey,
+				ObjectUrl:   finding.ObjectURL,
+				ObjectName:  finding.ObjectName,
+				Subject:     finding.Subject,
+				Detail:      finding.Detail,
+				Remediation: finding.Remediation,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to record policy findings: %w", err)
+	}
+
+	s.logger.Info("Audit run checked against access policies", "site_id", evaluation.SiteID, "audit_run_id", auditRunID,
+		"policies", evaluation.Policies, "findings", len(findings))
+	return s.GetEvaluation(ctx, auditRunID)
+}
+
+// GetEvaluation returns the last check of an audit run against the access policies with its findings.
+func (s *PolicyFindingService) GetEvaluation(ctx context.Context, auditRunID int64) (*audit.PolicyEvaluation, error) {
+	row, err := s.db.ReadQueries().GetPolicyEvaluation(ctx, auditRunID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrPolicyEvaluationNotFound, auditRunID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy evaluation: %w", err)
+	}
+	rows, err := s.db.ReadQueries().GetPolicyFindings(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy findings: %w", err)
+	}
+
+	evaluation := &audit.PolicyEvaluation{
+		SiteID:      row.SiteID,
+		AuditRunID:  row.AuditRunID,
+		EvaluatedAt: row.EvaluatedAt,
+		Policies:    int(row.Policies),
+		Findings:    make([]*audit.PolicyFinding, len(rows)),
+	}
+	for i, finding := range rows {
+		evaluation.Findings[i] = newPolicyFinding(finding)
+	}
+	sortPolicyFindings(evaluation.Findings)
+	return evaluation, nil
+}
+
+// GetLatestFindings returns the findings of the latest checked run of each site at least as severe as
+// minSeverity, of the given policy unless it is empty, most severe first then by site URL.
+func (s *PolicyFindingService) GetLatestFindings(ctx context.Context, minSeverity audit.Severity, policy string) ([]*audit.PolicyFinding, error) {
+	rows, err := s.db.ReadQueries().GetLatestPolicyFindings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policy findings: %w", err)
+	}
+	findings := []*audit.PolicyFinding{}
+	for _, row := range rows {
+		finding := newPolicyFinding(db.PolicyFinding{
+			FindingID:   row.FindingID,
+			AuditRunID:  row.AuditRunID,
+			SiteID:      row.SiteID,
+			Policy:      row.Policy,
+			Severity:    row.Severity,
+			ObjectType:  row.ObjectType,
+			ObjectKey:   row.ObjectKey,
+			ObjectUrl:   row.ObjectUrl,
+			ObjectName:  row.ObjectName,
+			Subject:     row.Subject,
+			Detail:      row.Detail,
+			Remediation: row.Remediation,
+		})
+		finding.SiteURL = row.SiteUrl
+		if finding.Severity.Rank() < minSeverity.Rank() || (policy != "" && finding.Policy != policy) {
+			continue
+		}
+		findings = append(findings, finding)
+	}
+	sortPolicyFindings(findings)
+	return findings, nil
+}
+
+// sortPolicyFindings orders findings most severe first, keeping the order of equally severe ones.
+func sortPolicyFindings(findings []*audit.PolicyFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity.Rank() > findings[j].Severity.Rank()
+	})
+}
+
+// newPolicyFinding converts a stored policy finding.
+func newPolicyFinding(row db.PolicyFinding) *audit.PolicyFinding {
+	return &audit.PolicyFinding{
+		ID:          row.FindingID,
+		SiteID:      row.SiteID,
+		AuditRunID:  row.AuditRunID,
+		Policy:      row.Policy,
+		Severity:    audit.Severity(row.Severity),
+		ObjectType:  row.ObjectType,
+		ObjectKey:   row.ObjectKey,
+		ObjectURL:   row.ObjectUrl,
+		ObjectName:  row.ObjectName,
+		Subject:     row.Subject,
+		Detail:      row.Detail,
+		Remediation: row.Remediation,
+	}
+}
@@ -0,0 +1,79 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/test/dataset"
+)
+
+// newPolicyFindingTestService stores the sites of newPrincipalAccessTestService, where on site 1 a guest
+// can read the second item, labeled Highly Confidential, and the third item has an anyone edit link.
+func newPolicyFindingTestService(t *testing.T) *PolicyFindingService {
+	t.Helper()
+	testDB, _, factory, d := newPrincipalAccessTestSites(t)
+	mustExec(t, testDB, `INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES (1, 300, 1, 'Guest', 'i:0#.f|membership|guest_fabrikam.com#ext#@contoso.onmicrosoft.com', ?)`, sharepoint.PrincipalTypeUser)
+	mustExec(t, testDB, `INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'item', ?, 300, ?, 1)`, d.Items[1].GUID, dataset.RoleRead)
+	mustExec(t, testDB, `INSERT INTO sensitivity_labels (site_id, item_guid, audit_run_id, label_id, display_name) VALUES (1, ?, 1, 'label-1', 'Highly Confidential')`, d.Items[1].GUID)
+	mustExec(t, testDB, `INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, file_folder_unique_id, link_kind, scope, is_active) VALUES (1, 'link-2', 1, ?, ?, 5, 0, 1)`,
+		d.Items[2].GUID, d.Items[2].GUID)
+	mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-3', 1, 'https://contoso.sharepoint.com/sites/site1', 'site_audit', 'completed')`)
+	mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at, scope_path) VALUES (3, 'job-3', 1, CURRENT_TIMESTAMP, '/sites/site1/Shared Documents')`)
+
+	return NewPolicyFindingService(testDB, factory, audit.AccessPoliciesWith(nil))
+}
+
+func TestPolicyFindingService_EvaluateRun(t *testing.T) {
+	service := newPolicyFindingTestService(t)
+	ctx := context.Background()
+
+	_, err := service.GetEvaluation(ctx, 1)
+	require.ErrorIs(t, err, ErrPolicyEvaluationNotFound)
+
+	evaluation, err := service.EvaluateRun(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), evaluation.SiteID)
+	assert.Equal(t, len(audit.DefaultAccessPolicies), evaluation.Policies)
+	require.Len(t, evaluation.Findings, 2)
+	byPolicy := map[string]*audit.PolicyFinding{}
+	for _, finding := range evaluation.Findings {
+		assert.Positive(t, finding.ID)
+		assert.Equal(t, audit.SeverityHigh, finding.Severity)
+		assert.NotEmpty(t, finding.Remediation)
+		byPolicy[finding.Policy] = finding
+	}
+	require.Contains(t, byPolicy, "no-anyone-edit-links")
+	assert.Equal(t, "link-2", byPolicy["no-anyone-edit-links"].Subject)
+	require.Contains(t, byPolicy, "no-external-on-confidential")
+	assert.Equal(t, sharepoint.ObjectTypeItem, byPolicy["no-external-on-confidential"].ObjectType)
+	assert.Contains(t, byPolicy["no-external-on-confidential"].Subject, "#ext#")
+	assert.Contains(t, byPolicy["no-external-on-confidential"].Detail, "Highly Confidential")
+
+	again, err := service.EvaluateRun(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, again.Findings, 2, "checking a run again replaces its findings")
+	stored, err := service.GetEvaluation(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, again.Findings, stored.Findings)
+
+	scoped, err := service.EvaluateRun(ctx, 3)
+	require.NoError(t, err)
+	assert.Nil(t, scoped)
+	_, err = service.GetEvaluation(ctx, 3)
+	require.ErrorIs(t, err, ErrPolicyEvaluationNotFound)
+
+	_, err = service.EvaluateRun(ctx, 2)
+	require.NoError(t, err)
+	latest, err := service.GetLatestFindings(ctx, audit.SeverityHigh, "no-anyone-edit-links")
+	require.NoError(t, err)
+	require.Len(t, latest, 1)
+	assert.Equal(t, "https://contoso.sharepoint.com/sites/site1", latest[0].SiteURL)
+	latest, err = service.GetLatestFindings(ctx, audit.SeverityCritical, "")
+	require.NoError(t, err)
+	assert.Empty(t, latest)
+}
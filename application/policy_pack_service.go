@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// Errors returned when choosing a site's policy pack.
+var (
+	ErrUnknownPolicyPack    = errors.New("unknown policy pack")
+	ErrSitePolicyPackNotSet = errors.New("site policy pack not set")
+)
+
+// SitePolicy is the policy pack a site is checked with, and the pack its template selects.
+type SitePolicy struct {
+	SiteID     int64
+	AuditRunID int64                 // Run whose root web template was read, zero for a site never audited
+	Template   string                // Root web template, empty when unknown
+	Detected   *PolicyPack           // The pack of the kind of site the template makes
+	Pack       *PolicyPack           // The override's pack, or the detected one
+	Override   *audit.SitePolicyPack // nil when the detected pack applies
+	Severities *FindingSeverities    // The mapping findings are rated with under Pack
+}
+
+// PolicyPackService selects the policy pack each site is checked with: the pack of the kind of site
+// its root web's template makes, unless an administrator chose another for it.
+type PolicyPackService struct {
+	db         *database.Database
+	severities *FindingSeverities
+	now        func() time.Time
+	logger     *logging.Logger
+}
+
+// NewPolicyPackService creates a policy pack service rating findings with severities under each pack.
+func NewPolicyPackService(db *database.Database, severities *FindingSeverities) *PolicyPackService {
+	return &PolicyPackService{
+		db:         db,
+		severities: severities,
+		now:        func() time.Time { return time.Now().UTC() },
+		logger:     logging.Default().WithComponent("policy_pack_service"),
+	}
+}
+
+// GetSitePolicy returns the policy pack a site is checked with for an audit run, detecting its kind
+// from the run's root web template. A zero auditRunID reads the site's latest run; a site never
+// audited is checked as a team site.
+func (s *PolicyPackService) GetSitePolicy(ctx context.Context, siteID, auditRunID int64) (*SitePolicy, error) {
+	queries := s.db.ReadQueries()
+	if _, err := queries.GetSiteByID(ctx, siteID); err != nil {
+		return nil, fmt.Errorf("failed to get site %d: %w", siteID, err)
+	}
+
+	if auditRunID == 0 {
+		latestRun, err := queries.GetLatestAuditRunForSite(ctx, db.GetLatestAuditRunForSiteParams{SiteID: siteID})
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to get latest audit run for site %d: %w", siteID, err)
+		}
+		auditRunID = latestRun.AuditRunID
+	}
+
+	policy := &SitePolicy{SiteID: siteID, AuditRunID: auditRunID}
+	if auditRunID != 0 {
+		webs, err := queries.ListWebsForSiteByAuditRun(ctx, db.ListWebsForSiteByAuditRunParams{SiteID: siteID, AuditRunID: auditRunID})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get webs for audit run %d: %w", auditRunID, err)
+		}
+		// Webs are ordered by URL, so the root web comes first
+		if len(webs) > 0 {
+			policy.Template = webs[0].Template.String
+		}
+	}
+	policy.Detected, _ = GetPolicyPack(sharepoint.SiteKindForTemplate(policy.Template))
+	policy.Pack = policy.Detected
+
+	row, err := queries.GetSitePolicyPack(ctx, siteID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return nil, fmt.Errorf("failed to get policy pack of site %d: %w", siteID, err)
+	default:
+		if pack, ok := GetPolicyPack(row.Pack); ok {
+			policy.Pack = pack
+			policy.Override = &audit.SitePolicyPack{SiteID: row.SiteID, Pack: row.Pack, SetAt: row.SetAt}
+		} else {
+			s.logger.Warn("Ignoring unknown policy pack", "site_id", siteID, "pack", row.Pack)
+		}
+	}
+	policy.Severities = s.severities.ForPack(policy.Pack)
+	return policy, nil
+}
+
+// SeveritiesForRun returns the severity mapping findings of an audit run are rated with, under the
+// policy pack its site is checked with.
+func (s *PolicyPackService) SeveritiesForRun(ctx context.Context, siteID, auditRunID int64) (*FindingSeverities, error) {
+	policy, err := s.GetSitePolicy(ctx, siteID, auditRunID)
+	if err != nil {
+		return nil, err
+	}
+	return policy.Severities, nil
+}
+
+// SetSitePolicyPack checks a site with the named policy pack whatever its template, replacing the
+// pack chosen before.
+func (s *PolicyPackService) SetSitePolicyPack(ctx context.Context, siteID int64, name string) (*audit.SitePolicyPack, error) {
+	if _, ok := GetPolicyPack(name); !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicyPack, name)
+	}
+	if _, err := s.db.ReadQueries().GetSiteByID(ctx, siteID); err != nil {
+		return nil, fmt.Errorf("failed to get site %d: %w", siteID, err)
+	}
+
+	override := &audit.SitePolicyPack{SiteID: siteID, Pack: name, SetAt: s.now()}
+	err := s.db.Queries().UpsertSitePolicyPack(ctx, db.UpsertSitePolicyPackParams{
+		SiteID: override.SiteID,
+		Pack:   override.Pack,
+		SetAt:  override.SetAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set policy pack of site %d: %w", siteID, err)
+	}
+
+	s.logger.Info("Site policy pack set", "site_id", siteID, "pack", name)
+	return override, nil
+}
+
+// ClearSitePolicyPack returns a site to the policy pack its template selects.
+func (s *PolicyPackService) ClearSitePolicyPack(ctx context.Context, siteID int64) error {
+	deleted, err := s.db.Queries().DeleteSitePolicyPack(ctx, siteID)
+	if err != nil {
+		return fmt.Errorf("failed to clear policy pack of site %d: %w", siteID, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: site %d", ErrSitePolicyPackNotSet, siteID)
+	}
+
+	s.logger.Info("Site policy pack cleared", "site_id", siteID)
+	return nil
+}
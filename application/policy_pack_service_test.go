@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+)
+
+func TestPolicyPackService(t *testing.T) {
+	ctx := context.Background()
+	baselineService := newBaselineTestService(t)
+	_, err := baselineService.db.WriteDB().Exec(`UPDATE webs SET template = 'SITEPAGEPUBLISHING#0' WHERE audit_run_id = 2`)
+	require.NoError(t, err)
+
+	severities, err := NewFindingSeverities(map[string]audit.Severity{RiskyDefaultMembersCanShare: audit.SeverityHigh})
+	require.NoError(t, err)
+	service := NewPolicyPackService(baselineService.db, severities)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	t.Run("the run's template selects the pack", func(t *testing.T) {
+		policy, err := service.GetSitePolicy(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "SITEPAGEPUBLISHING#0", policy.Template)
+		assert.Equal(t, "communication", policy.Detected.Name)
+		assert.Equal(t, "communication", policy.Pack.Name)
+		assert.Nil(t, policy.Override)
+		assert.Equal(t, audit.SeverityHigh, policy.Severities.Severity(RiskyDefaultMembersCanShare), "overrides still apply")
+		assert.False(t, policy.Severities.Raises(DriftListAdded))
+	})
+
+	t.Run("without a run the latest is read, and an unknown template is a team site", func(t *testing.T) {
+		policy, err := service.GetSitePolicy(ctx, 1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), policy.AuditRunID)
+		assert.Empty(t, policy.Template)
+		assert.Equal(t, "team", policy.Pack.Name)
+
+		policy, err = service.GetSitePolicy(ctx, 2, 0)
+		require.NoError(t, err)
+		assert.Zero(t, policy.AuditRunID, "site 2 was never audited")
+		assert.Equal(t, "team", policy.Pack.Name)
+	})
+
+	t.Run("an override applies whatever the template until cleared", func(t *testing.T) {
+		override, err := service.SetSitePolicyPack(ctx, 1, "channel")
+		require.NoError(t, err)
+		assert.Equal(t, &audit.SitePolicyPack{SiteID: 1, Pack: "channel", SetAt: now}, override)
+
+		policy, err := service.GetSitePolicy(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "communication", policy.Detected.Name)
+		assert.Equal(t, "channel", policy.Pack.Name)
+		require.NotNil(t, policy.Override)
+		assert.True(t, policy.Override.SetAt.Equal(now))
+
+		require.NoError(t, service.ClearSitePolicyPack(ctx, 1))
+		policy, err = service.GetSitePolicy(ctx, 1, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "communication", policy.Pack.Name)
+		assert.ErrorIs(t, service.ClearSitePolicyPack(ctx, 1), ErrSitePolicyPackNotSet)
+	})
+
+	t.Run("unknown packs and sites are rejected", func(t *testing.T) {
+		_, err := service.SetSitePolicyPack(ctx, 1, "intranet")
+		assert.ErrorIs(t, err, ErrUnknownPolicyPack)
+		_, err = service.SetSitePolicyPack(ctx, 99, "team")
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		_, err = service.GetSitePolicy(ctx, 99, 0)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+}
@@ -0,0 +1,69 @@
+package application
+
+import (
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+// PolicyPack is the default set of checks for a kind of site: the severity it rates finding categories
+// with where it differs from the global defaults, and the categories it raises no alerts for. Muted
+// findings still show in the site's views, rated with the pack's severity.
+type PolicyPack struct {
+	Name        string // The kind of site the pack is for, a sharepoint.SiteKind
+	Description string
+	Severities  map[string]audit.Severity
+	Muted       map[string]bool
+}
+
+// policyPacks are the packs sites are checked with, in the order they are listed. The team pack keeps
+// the global defaults, so sites audited before packs existed are checked as they were.
+var policyPacks = []*PolicyPack{
+	{
+		Name:        sharepoint.SiteKindTeam,
+		Description: "Collaboration sites whose members share and restructure content among themselves; every check applies with its default severity.",
+		Severities:  map[string]audit.Severity{},
+		Muted:       map[string]bool{},
+	},
+	{
+		Name:        sharepoint.SiteKindCommunication,
+		Description: "Publishing sites read by a wide audience and edited by a few owners; members sharing, new lists and folders with their own permissions are expected.",
+		Severities: map[string]audit.Severity{
+			RiskyDefaultMembersCanShare: audit.SeverityInfo,
+			DriftListAdded:              audit.SeverityInfo,
+			FolderInheritanceBroken:     audit.SeverityInfo,
+		},
+		Muted: map[string]bool{
+			RiskyDefaultMembersCanShare: true,
+			DriftListAdded:              true,
+			FolderInheritanceBroken:     true,
+		},
+	},
+	{
+		Name:        sharepoint.SiteKindChannel,
+		Description: "Sites of Teams private and shared channels, whose membership follows the channel's; members sharing and joining links are expected.",
+		Severities: map[string]audit.Severity{
+			RiskyDefaultMembersCanShare: audit.SeverityInfo,
+			DriftPermissionsChanged:     audit.SeverityLow,
+			FolderLinkMemberAdded:       audit.SeverityInfo,
+		},
+		Muted: map[string]bool{
+			RiskyDefaultMembersCanShare: true,
+			FolderLinkMemberAdded:       true,
+		},
+	},
+}
+
+// PolicyPacks returns every policy pack.
+func PolicyPacks() []*PolicyPack {
+	return policyPacks
+}
+
+// GetPolicyPack returns the policy pack with the given name.
+func GetPolicyPack(name string) (*PolicyPack, bool) {
+	for _, pack := range policyPacks {
+		if pack.Name == name {
+			return pack, true
+		}
+	}
+	return nil, false
+}
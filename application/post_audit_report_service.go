@@ -0,0 +1,89 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/logging"
+)
+
+// GeneratedReport is a rendered report ready to be attached to an audit run.
+type GeneratedReport struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// RunReportRenderer renders a report of an audit run. A report may render to several files,
+// e.g. one per list, or to none when there is nothing to report.
+type RunReportRenderer interface {
+	RenderRunReport(ctx context.Context, siteID, auditRunID int64, report string) ([]GeneratedReport, error)
+}
+
+// PostAuditReportService generates the reports configured for a site when one of its audit runs completes,
+// so they are attached to the run by the time users are notified.
+type PostAuditReportService struct {
+	db              *database.Database
+	rules           []audit.ReportRule
+	artifactService *RunArtifactService
+	renderer        RunReportRenderer
+	logger          *logging.Logger
+}
+
+// NewPostAuditReportService creates a new post-audit report service generating reports by the rules.
+func NewPostAuditReportService(db *database.Database, artifactService *RunArtifactService, rules []audit.ReportRule) *PostAuditReportService {
+	return &PostAuditReportService{
+		db:              db,
+		rules:           rules,
+		artifactService: artifactService,
+		logger:          logging.Default().WithComponent("post_audit_report_service"),
+	}
+}
+
+// SetRenderer sets the renderer producing report content. Without one no reports are generated.
+func (s *PostAuditReportService) SetRenderer(renderer RunReportRenderer) {
+	s.renderer = renderer
+}
+
+// GenerateRunReports generates and attaches the reports configured for the site of the audit run.
+// A report failing to render is logged and skipped so it does not hold back the others.
+func (s *PostAuditReportService) GenerateRunReports(ctx context.Context, auditRunID int64) ([]*audit.RunArtifact, error) {
+	if s.renderer == nil || len(s.rules) == 0 {
+		return nil, nil
+	}
+
+	auditRun, err := s.db.ReadQueries().GetAuditRun(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("audit run %d not found: %w", auditRunID, err)
+	}
+	site, err := s.db.ReadQueries().GetSiteByID(ctx, auditRun.SiteID)
+	if err != nil {
+		return nil, fmt.Errorf("site %d not found: %w", auditRun.SiteID, err)
+	}
+
+	reports := audit.ReportsForSite(s.rules, site.SiteUrl)
+	if len(reports) == 0 {
+		return nil, nil
+	}
+
+	var artifacts []*audit.RunArtifact
+	for _, report := range reports {
+		generated, err := s.renderer.RenderRunReport(ctx, site.SiteID, auditRunID, report)
+		if err != nil {
+			s.logger.Warn("Failed to render post-audit report", "report", report, "site_id", site.SiteID, "audit_run_id", auditRunID, "error", err)
+			continue
+		}
+		for _, file := range generated {
+			artifact, err := s.artifactService.AttachArtifact(ctx, site.SiteID, auditRunID, file.Filename, file.ContentType, file.Content)
+			if err != nil {
+				return artifacts, fmt.Errorf("failed to attach report %s: %w", file.Filename, err)
+			}
+			artifacts = append(artifacts, artifact)
+		}
+	}
+
+	s.logger.Info("Generated post-audit reports", "site_id", site.SiteID, "audit_run_id", auditRunID, "reports", reports, "artifacts", len(artifacts))
+	return artifacts, nil
+}
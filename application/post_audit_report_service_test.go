@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+)
+
+// fakeReportRenderer renders one file per report, failing the reports it is told to
+type fakeReportRenderer struct {
+	failing  map[string]bool
+	rendered []string
+}
+
+func (r *fakeReportRenderer) RenderRunReport(ctx context.Context, siteID, auditRunID int64, report string) ([]GeneratedReport, error) {
+	r.rendered = append(r.rendered, report)
+	if r.failing[report] {
+		return nil, errors.New("render failed")
+	}
+	return []GeneratedReport{{
+		Filename:    fmt.Sprintf("%s-site%d-run%d.csv", report, siteID, auditRunID),
+		ContentType: "text/csv",
+		Content:     []byte(report),
+	}}, nil
+}
+
+func TestPostAuditReportService_GenerateRunReports(t *testing.T) {
+	testDB := newTestDatabase(t)
+
+	for _, stmt := range []string{
+		`INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/Finance', 'Finance'), (2, 'https://contoso.sharepoint.com/sites/hr', 'HR')`,
+		`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/Finance', 'site_audit', 'completed'), ('job-2', 2, 'https://contoso.sharepoint.com/sites/hr', 'site_audit', 'completed')`,
+		`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (1, 'job-1', 1, CURRENT_TIMESTAMP), (2, 'job-2', 2, CURRENT_TIMESTAMP)`,
+	} {
+		mustExec(t, testDB, stmt)
+	}
+
+	ctx := context.Background()
+	notifier := &recordingArtifactNotifier{}
+	artifactService := NewRunArtifactService(testDB, time.Hour)
+	artifactService.SetNotifier(notifier)
+
+	rules, err := audit.ParseReportRules("https://contoso.sharepoint.com/sites/finance*=lists,changes,assignments")
+	require.NoError(t, err)
+	service := NewPostAuditReportService(testDB, artifactService, rules)
+
+	// Without a renderer nothing is generated
+	artifacts, err := service.GenerateRunReports(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, artifacts)
+
+	renderer := &fakeReportRenderer{failing: map[string]bool{audit.ReportChanges: true}}
+	service.SetRenderer(renderer)
+
+	// A failing report is skipped without holding back the others
+	artifacts, err = service.GenerateRunReports(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []string{audit.ReportLists, audit.ReportChanges, audit.ReportAssignments}, renderer.rendered)
+	require.Len(t, artifacts, 2)
+	assert.Equal(t, "lists-site1-run1.csv", artifacts[0].Filename)
+	assert.Equal(t, "assignments-site1-run1.csv", artifacts[1].Filename)
+	assert.Empty(t, notifier.artifacts, "reports are announced by the job completion, not one by one")
+
+	runArtifacts, err := artifactService.ListRunArtifacts(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, runArtifacts, 2)
+
+	// Sites matching no rule get no reports
+	renderer.rendered = nil
+	artifacts, err = service.GenerateRunReports(ctx, 2)
+	require.NoError(t, err)
+	assert.Empty(t, artifacts)
+	assert.Empty(t, renderer.rendered)
+
+	_, err = service.GenerateRunReports(ctx, 99)
+	assert.Error(t, err)
+}
@@ -0,0 +1,295 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	"spaudit/logging"
+)
+
+// ErrInvalidPrincipalIdentity is returned for an empty principal identity.
+var ErrInvalidPrincipalIdentity = errors.New("invalid principal identity")
+
+// How a principal reaches what it can access.
+const (
+	PrincipalAccessDirect          = "direct"           // A role assigned to one of its accounts
+	PrincipalAccessSharePointGroup = "sharepoint_group" // A role assigned to a SharePoint group it belongs to
+	PrincipalAccessEntraGroup      = "entra_group"      // A role assigned to an Entra group that reaches it
+	PrincipalAccessSharingLink     = "sharing_link"     // Membership of a sharing link on an item
+)
+
+// PrincipalAccessGrant is one thing a principal can access on a site: a role on the web, a list or an
+// item, or a sharing link on an item.
+type PrincipalAccessGrant struct {
+	ObjectType  string
+	ObjectKey   string // Web or list ID, or item GUID
+	ObjectURL   string
+	ObjectName  string
+	PrincipalID int64  // The account or group holding the role, or the link member
+	Role        string // Role name, or the link kind of a sharing link
+	Through     string // PrincipalAccessDirect, PrincipalAccessSharePointGroup, PrincipalAccessEntraGroup or PrincipalAccessSharingLink
+	Via         string // Title of the group holding the role, or the sharing link ID; empty for direct grants
+}
+
+// PrincipalGroupMembership is the membership of one of a principal's accounts, or of an Entra group
+// reaching it, in a SharePoint group.
+type PrincipalGroupMembership struct {
+	GroupID  int64
+	Group    string
+	MemberID int64
+}
+
+// PrincipalSiteAccess is what a principal can access on one site, as the site's latest audit run
+// captured it.
+type PrincipalSiteAccess struct {
+	SiteID     int64
+	SiteURL    string
+	AuditRunID int64
+	Accounts   []*sharepoint.Principal // The site's principals for the identity
+	Groups     []string                // SharePoint and Entra groups reaching the principal, sorted
+	Grants     []*PrincipalAccessGrant // Ordered by object URL and role; Limited Access is left out
+
+	Memberships []*PrincipalGroupMembership // How the SharePoint groups in Groups reach the principal
+}
+
+// reached returns true if the principal has an account on the site or a group reaches it.
+func (a *PrincipalSiteAccess) reached() bool {
+	return len(a.Accounts) > 0 || len(a.Groups) > 0
+}
+
+// PrincipalAccessSkippedSite is a site left out of a principal's access, with the reason.
+type PrincipalAccessSkippedSite struct {
+	SiteID  int64
+	SiteURL string
+	Reason  string
+}
+
+// PrincipalAccessData is everything one principal can access across the latest runs of every audited
+// site, e.g. what a leaver still holds.
+type PrincipalAccessData struct {
+	Identity     string                 // Normalized: the account of a claims login name, lowercased
+	Sites        []*PrincipalSiteAccess // Sites the principal has an account on or access to, by URL
+	GrantCount   int
+	SitesScanned int
+	Skipped      []*PrincipalAccessSkippedSite
+}
+
+// PrincipalAccessService reports what one principal can access across every audited site. Principal
+// IDs differ per site, so the principal is identified by its account (a user principal name or login
+// name) or email address.
+type PrincipalAccessService struct {
+	siteBrowsing   *SiteBrowsingService
+	serviceFactory AuditRunScopedServiceFactory
+	logger         *logging.Logger
+}
+
+// NewPrincipalAccessService creates a new principal access service.
+func NewPrincipalAccessService(siteBrowsing *SiteBrowsingService, serviceFactory AuditRunScopedServiceFactory) *PrincipalAccessService {
+	return &PrincipalAccessService{
+		siteBrowsing:   siteBrowsing,
+		serviceFactory: serviceFactory,
+		logger:         logging.Default().WithComponent("principal_access_service"),
+	}
+}
+
+// GetAccess returns what the principal with the given account, claims login name or email address can
+// access on each site, as the site's latest audit run captured: the roles its accounts hold directly or
+// through SharePoint groups and resolved Entra groups, and the sharing links it is a member of. Sites
+// without a run, or whose permissions exceed the analysis row cap, are skipped and reported.
+func (s *PrincipalAccessService) GetAccess(ctx context.Context, identity string) (*PrincipalAccessData, error) {
+	identity = audit.PrincipalIdentity(identity, "")
+	if identity == "" {
+		return nil, ErrInvalidPrincipalIdentity
+	}
+	sites, err := s.siteBrowsing.GetAllSitesWithMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &PrincipalAccessData{Identity: identity, Sites: []*PrincipalSiteAccess{}, Skipped: []*PrincipalAccessSkippedSite{}}
+	for _, site := range sites {
+		if site.Site == nil {
+			continue
+		}
+		access, err := s.latestSiteAccess(ctx, site.Site, identity)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			data.Skipped = append(data.Skipped, &PrincipalAccessSkippedSite{SiteID: site.Site.ID, SiteURL: site.Site.URL, Reason: err.Error()})
+			continue
+		}
+		data.SitesScanned++
+		if access.reached() {
+			data.Sites = append(data.Sites, access)
+			data.GrantCount += len(access.Grants)
+		}
+	}
+	sort.Slice(data.Sites, func(i, j int) bool { return data.Sites[i].SiteURL < data.Sites[j].SiteURL })
+
+	s.logger.Info("Principal access gathered", "identity", identity, "sites", len(data.Sites), "grants", data.GrantCount, "skipped", len(data.Skipped))
+	return data, nil
+}
+
+// latestSiteAccess gathers what the principal can access on a site's latest run.
+func (s *PrincipalAccessService) latestSiteAccess(ctx context.Context, site *sharepoint.Site, identity string) (*PrincipalSiteAccess, error) {
+	services, err := s.serviceFactory.CreateForAuditRun(ctx, site.ID, audit.RunAliasLatest)
+	if err != nil {
+		return nil, err
+	}
+	return siteAccess(ctx, services.SiteContentService, site, services.AuditRunID, identity)
+}
+
+// siteAccess gathers what the principal can access on a site's audit run. Its facts are only read
+// when the principal has an account there or a group reaches it.
+func siteAccess(ctx context.Context, content *SiteContentService, site *sharepoint.Site, auditRunID int64, identity string) (*PrincipalSiteAccess, error) {
+	principals, err := content.GetAuditRunPrincipals(ctx, site.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	access := &PrincipalSiteAccess{
+		SiteID:      site.ID,
+		SiteURL:     site.URL,
+		AuditRunID:  auditRunID,
+		Groups:      []string{},
+		Grants:      []*PrincipalAccessGrant{},
+		Memberships: []*PrincipalGroupMembership{},
+	}
+	titles := map[int64]string{}
+	reached := map[int64]string{} // How each principal holding the identity's access reaches it
+	for _, principal := range principals {
+		titles[principal.ID] = principal.GetDisplayName()
+		if principalMatchesIdentity(principal, identity) {
+			access.Accounts = append(access.Accounts, principal)
+			reached[principal.ID] = PrincipalAccessDirect
+		}
+	}
+	entraGroupIDs, err := content.GetEntraGroupIDsForUser(ctx, site.ID, identity)
+	if err != nil {
+		return nil, err
+	}
+	for _, groupID := range entraGroupIDs {
+		if _, ok := reached[groupID]; !ok {
+			reached[groupID] = PrincipalAccessEntraGroup
+		}
+	}
+	if len(reached) == 0 {
+		return access, nil
+	}
+
+	// Accounts and Entra groups both count towards the SharePoint groups they belong to
+	members := make([]int64, 0, len(reached))
+	for principalID := range reached {
+		members = append(members, principalID)
+	}
+	sort.Slice(members, func(i, j int) bool { return members[i] < members[j] })
+	for _, memberID := range members {
+		groupIDs, err := content.GetGroupIDsForPrincipal(ctx, site.ID, memberID)
+		if err != nil {
+			return nil, err
+		}
+		for _, groupID := range groupIDs {
+			if _, ok := reached[groupID]; !ok {
+				reached[groupID] = PrincipalAccessSharePointGroup
+			}
+			access.Memberships = append(access.Memberships, &PrincipalGroupMembership{GroupID: groupID, Group: titles[groupID], MemberID: memberID})
+		}
+	}
+	for principalID, through := range reached {
+		if through != PrincipalAccessDirect {
+			access.Groups = append(access.Groups, titles[principalID])
+		}
+	}
+	sort.Strings(access.Groups)
+
+	facts, err := content.GetRunFacts(ctx, site.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, fact := range facts.Permissions {
+		if fact.Principal == nil || sharepoint.IsLimitedAccessRole(fact.RoleDefID, fact.Role) {
+			continue
+		}
+		if through, ok := reached[fact.Principal.ID]; ok {
+			access.Grants = append(access.Grants, principalRoleGrant(fact, through))
+		}
+	}
+
+	holders := make([]int64, 0, len(reached))
+	for principalID := range reached {
+		holders = append(holders, principalID)
+	}
+	sort.Slice(holders, func(i, j int) bool { return holders[i] < holders[j] })
+	// A link reaching the principal through several members is one grant, held by one of its own
+	// accounts when one is a member
+	links := map[string]int64{} // Link ID in lower case -> member holding the principal's access
+	for _, principalID := range holders {
+		linkIDs, err := content.GetSharingLinkIDsForMember(ctx, site.ID, principalID)
+		if err != nil {
+			return nil, err
+		}
+		for _, linkID := range linkIDs {
+			key := strings.ToLower(linkID)
+			if memberID, ok := links[key]; !ok || (reached[memberID] != PrincipalAccessDirect && reached[principalID] == PrincipalAccessDirect) {
+				links[key] = principalID
+			}
+		}
+	}
+	for _, link := range facts.SharingLinks {
+		key := strings.ToLower(link.LinkID)
+		memberID, ok := links[key]
+		if !ok {
+			continue
+		}
+		delete(links, key)
+		access.Grants = append(access.Grants, &PrincipalAccessGrant{
+			ObjectType:  sharepoint.ObjectTypeItem,
+			ObjectKey:   link.ItemGUID,
+			ObjectURL:   link.ItemURL,
+			ObjectName:  link.ItemName,
+			PrincipalID: memberID,
+			Role:        sharepoint.LinkKindName(link.LinkKind),
+			Through:     PrincipalAccessSharingLink,
+			Via:         link.LinkID,
+		})
+	}
+
+	sort.SliceStable(access.Grants, func(i, j int) bool {
+		a, b := access.Grants[i], access.Grants[j]
+		if a.ObjectURL != b.ObjectURL {
+			return a.ObjectURL < b.ObjectURL
+		}
+		return a.Role < b.Role
+	})
+	return access, nil
+}
+
+// principalMatchesIdentity returns true if a principal is an account of the identity, by its login
+// name's account or its email address.
+func principalMatchesIdentity(principal *sharepoint.Principal, identity string) bool {
+	return audit.PrincipalIdentity(principal.LoginName, principal.Title) == identity ||
+		strings.EqualFold(strings.TrimSpace(principal.Email), identity)
+}
+
+// principalRoleGrant describes a permission fact as a grant to the principal, naming the group it came through.
+func principalRoleGrant(fact *contracts.PermissionFact, through string) *PrincipalAccessGrant {
+	grant := &PrincipalAccessGrant{
+		ObjectType:  fact.ObjectType,
+		ObjectKey:   fact.ObjectKey,
+		ObjectURL:   fact.ObjectURL,
+		ObjectName:  fact.ObjectName,
+		PrincipalID: fact.Principal.ID,
+		Role:        fact.Role,
+		Through:     through,
+	}
+	if through != PrincipalAccessDirect {
+		grant.Via = fact.Principal.GetDisplayName()
+	}
+	return grant
+}
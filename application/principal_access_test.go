@@ -0,0 +1,149 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
+	"spaudit/test/dataset"
+)
+
+// newPrincipalAccessTestService stores two synthetic sites, plus a site never audited (site 3). On site 1,
+// user 0 is in the Visitors group, reached by the Finance Entra group holding Edit on the web, and a
+// member of a sharing link on the first item; on site 2 user 0 has no account.
+func newPrincipalAccessTestService(t *testing.T) (*PrincipalAccessService, *dataset.Dataset) {
+	t.Helper()
+	_, siteBrowsing, factory, first := newPrincipalAccessTestSites(t)
+	return NewPrincipalAccessService(siteBrowsing, factory), first
+}
+
+// newPrincipalAccessTestSites stores the sites of newPrincipalAccessTestService, returning the database
+// and the services reading them.
+func newPrincipalAccessTestSites(t *testing.T) (*database.Database, *SiteBrowsingService, AuditRunScopedServiceFactory, *dataset.Dataset) {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	var first *dataset.Dataset
+	for _, site := range []int64{1, 2} {
+		siteURL := fmt.Sprintf("https://contoso.sharepoint.com/sites/site%d", site)
+		d := dataset.Generate(dataset.Spec{SiteID: site, SiteURL: siteURL, Lists: 1, ItemsPerList: 10, AssignmentsPerItem: 2, Users: 4, UniqueRatio: 0.5, Seed: 7})
+		mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (?, ?, 'Site')`, site, siteURL)
+		mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES (?, ?, ?, 'site_audit', 'completed')`, fmt.Sprintf("job-%d", site), site, siteURL)
+		mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (?, ?, ?, CURRENT_TIMESTAMP)`, site, fmt.Sprintf("job-%d", site), site)
+		require.NoError(t, d.Save(context.Background(), repositories.NewSqlcAuditRepository(testDB), site))
+		if first == nil {
+			first = d
+		}
+	}
+	mustExec(t, testDB, `INSERT INTO group_members (site_id, group_id, member_id, audit_run_id) VALUES (1, 5, 100, 1)`)
+	mustExec(t, testDB, `INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES (1, 200, 1, 'Finance', 'c:0t.c|tenant|finance', ?)`, sharepoint.PrincipalTypeSecurity)
+	mustExec(t, testDB, `INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'web', ?, 200, ?, 1)`, first.Web.ID, dataset.RoleEdit)
+	mustExec(t, testDB, `INSERT INTO entra_groups (site_id, principal_id, audit_run_id, object_id, member_count, resolved_at) VALUES (1, 200, 1, 'finance-id', 1, CURRENT_TIMESTAMP)`)
+	mustExec(t, testDB, `INSERT INTO entra_group_members (site_id, principal_id, audit_run_id, member_object_id, user_principal_name) VALUES (1, 200, 1, 'user-0', 'User0@Contoso.com')`)
+	mustExec(t, testDB, `INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, file_folder_unique_id, link_kind, scope, is_active) VALUES (1, 'link-1', 1, ?, ?, 3, 1, 1)`,
+		first.Items[0].GUID, first.Items[0].GUID)
+	mustExec(t, testDB, `INSERT INTO sharing_link_members (site_id, link_id, principal_id, audit_run_id) VALUES (1, 'link-1', 100, 1)`)
+	mustExec(t, testDB, `UPDATE principals SET login_name = 'i:0#.f|membership|someone@contoso.com', email = 'someone@contoso.com' WHERE site_id = 2 AND principal_id = 100`)
+	mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (3, 'https://contoso.sharepoint.com/sites/new', 'New')`)
+
+	factory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		repositories.NewSqlcAuditRepository(testDB),
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunAnyStatus,
+	)
+	baseRepo := repositories.NewBaseRepository(testDB)
+	siteContent := repositories.NewSiteContentAggregateRepository(baseRepo, repositories.NewSqlcSiteRepository(testDB), nil, nil, nil, nil)
+	return testDB, NewSiteBrowsingService(siteContent, audit.LatestRunAnyStatus), factory, first
+}
+
+func TestPrincipalAccessService_GetAccess(t *testing.T) {
+	service, d := newPrincipalAccessTestService(t)
+
+	result, err := service.GetAccess(context.Background(), "i:0#.f|membership|User0@contoso.com")
+	require.NoError(t, err)
+	assert.Equal(t, "user0@contoso.com", result.Identity)
+	assert.Equal(t, 2, result.SitesScanned)
+	require.Len(t, result.Skipped, 1)
+	assert.Equal(t, int64(3), result.Skipped[0].SiteID)
+
+	require.Len(t, result.Sites, 1)
+	site := result.Sites[0]
+	assert.Equal(t, int64(1), site.SiteID)
+	require.Len(t, site.Accounts, 1)
+	assert.Equal(t, int64(100), site.Accounts[0].ID)
+	assert.Equal(t, []string{"Benchmark Visitors", "Finance"}, site.Groups)
+	assert.Equal(t, len(site.Grants), result.GrantCount)
+
+	through := map[string]int{}
+	for _, grant := range site.Grants {
+		assert.NotEqual(t, "Limited Access", grant.Role)
+		through[grant.Through]++
+		switch grant.Through {
+		case PrincipalAccessDirect:
+			assert.Equal(t, sharepoint.ObjectTypeItem, grant.ObjectType)
+			assert.Empty(t, grant.Via)
+		case PrincipalAccessSharePointGroup:
+			assert.Equal(t, "Benchmark Visitors", grant.Via)
+			assert.Equal(t, "Read", grant.Role)
+		case PrincipalAccessEntraGroup:
+			assert.Equal(t, "Finance", grant.Via)
+			assert.Equal(t, "Edit", grant.Role)
+			assert.Equal(t, sharepoint.ObjectTypeWeb, grant.ObjectType)
+		case PrincipalAccessSharingLink:
+			assert.Equal(t, "link-1", grant.Via)
+			assert.Equal(t, "Organization Edit", grant.Role)
+			assert.Equal(t, d.Items[0].URL, grant.ObjectURL)
+		}
+	}
+	assert.Positive(t, through[PrincipalAccessDirect])
+	assert.Equal(t, 2, through[PrincipalAccessSharePointGroup]) // The web and the list
+	assert.Equal(t, 1, through[PrincipalAccessEntraGroup])
+	assert.Equal(t, 1, through[PrincipalAccessSharingLink])
+}
+
+func TestPrincipalAccessService_GetAccess_LinkWithSeveralMembers(t *testing.T) {
+	testDB, siteBrowsing, factory, _ := newPrincipalAccessTestSites(t)
+	// The Visitors group and the Finance Entra group both reach user 0, who is also a member on their own
+	mustExec(t, testDB, `INSERT INTO sharing_link_members (site_id, link_id, principal_id, audit_run_id) VALUES (1, 'link-1', 5, 1), (1, 'link-1', 200, 1)`)
+	service := NewPrincipalAccessService(siteBrowsing, factory)
+
+	result, err := service.GetAccess(context.Background(), "user0@contoso.com")
+	require.NoError(t, err)
+	require.Len(t, result.Sites, 1)
+	var links []*PrincipalAccessGrant
+	for _, grant := range result.Sites[0].Grants {
+		if grant.Through == PrincipalAccessSharingLink {
+			links = append(links, grant)
+		}
+	}
+	require.Len(t, links, 1, "one grant per link")
+	assert.Equal(t, "link-1", links[0].Via)
+	assert.Equal(t, int64(100), links[0].PrincipalID, "held by the account rather than a group")
+}
+
+func TestPrincipalAccessService_GetAccess_ByEmail(t *testing.T) {
+	service, _ := newPrincipalAccessTestService(t)
+
+	result, err := service.GetAccess(context.Background(), " USER0@contoso.com ")
+	require.NoError(t, err)
+	require.Len(t, result.Sites, 1)
+	assert.Equal(t, int64(1), result.Sites[0].SiteID)
+
+	result, err = service.GetAccess(context.Background(), "nobody@contoso.com")
+	require.NoError(t, err)
+	assert.Empty(t, result.Sites)
+	assert.Zero(t, result.GrantCount)
+
+	_, err = service.GetAccess(context.Background(), "  ")
+	assert.ErrorIs(t, err, ErrInvalidPrincipalIdentity)
+}
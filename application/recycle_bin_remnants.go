@@ -0,0 +1,107 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+// RecycleBinSharingRemnant is the finding for an item deleted while it still had active sharing links.
+// Deletion only suspends the links; whoever restores the item from the recycle bin revives them.
+const RecycleBinSharingRemnant = "recycle_bin_sharing_remnant"
+
+// RecycleBinRemnantsData is what an audit run's recycle bin scan found.
+type RecycleBinRemnantsData struct {
+	ScannedAt    *time.Time           // Nil when the run did not scan the recycle bin
+	DeletedItems int                  // Files, folders and list items in the recycle bin
+	Remnants     []*RecycleBinRemnant // Most recently deleted first
+}
+
+// RecycleBinRemnant is a deleted item that had active sharing links when an earlier run last audited it.
+type RecycleBinRemnant struct {
+	Item       *sharepoint.RecycleBinItem
+	AuditRunID int64 // The last run before the deletion, which captured the item and its links
+	ListID     string
+	ItemGUID   string
+	ItemName   string
+	Links      []*contracts.DeletedItemLink
+	Severity   audit.Severity // Rated by the finding severity mapping
+}
+
+// HasAnyoneLink returns true if any of the item's links worked for anyone with the URL.
+func (r *RecycleBinRemnant) HasAnyoneLink() bool {
+	for _, link := range r.Links {
+		if sharepoint.IsAnyoneLinkKind(link.LinkKind, link.Scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetRecycleBinRemnants finds the items in the run's recycle bin scan that were deleted while still
+// shared (audit-scoped). Links are only known for items an earlier run of the site captured before
+// the deletion, so a first run of a site finds none.
+func (s *SiteContentService) GetRecycleBinRemnants(ctx context.Context, siteID int64) (*RecycleBinRemnantsData, error) {
+	data := &RecycleBinRemnantsData{Remnants: []*RecycleBinRemnant{}}
+
+	scannedAt, err := s.contentAggregate.GetRecycleBinScan(ctx, siteID, s.auditRunID)
+	if err != nil || scannedAt == nil {
+		return data, err
+	}
+	data.ScannedAt = scannedAt
+
+	items, err := s.contentAggregate.GetRecycleBinItems(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.contentAggregate.GetDeletedItemLinks(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, err
+	}
+	data.DeletedItems = len(items)
+	data.Remnants = DetectRecycleBinRemnants(items, links)
+	return data, nil
+}
+
+// DetectRecycleBinRemnants groups the links active on deleted items by recycle bin entry, keeping
+// the order of items. Items without links were unshared before deletion, or never shared. Links a run
+// captured after the deletion were on a newer item at the same location, and are not counted.
+func DetectRecycleBinRemnants(items []*sharepoint.RecycleBinItem, links []*contracts.DeletedItemLink) []*RecycleBinRemnant {
+	deletedAt := make(map[string]time.Time, len(items))
+	for _, item := range items {
+		deletedAt[item.ID] = item.DeletedAt
+	}
+	linksByItem := make(map[string][]*contracts.DeletedItemLink)
+	for _, link := range links {
+		if !link.AuditRunAt.Before(deletedAt[link.RecycleBinID]) {
+			continue
+		}
+		linksByItem[link.RecycleBinID] = append(linksByItem[link.RecycleBinID], link)
+	}
+
+	remnants := []*RecycleBinRemnant{}
+	for _, item := range items {
+		itemLinks := linksByItem[item.ID]
+		if len(itemLinks) == 0 {
+			continue
+		}
+		first := itemLinks[0]
+		name := first.ItemName
+		if name == "" {
+			name = item.Title
+		}
+		remnants = append(remnants, &RecycleBinRemnant{
+			Item:       item,
+			AuditRunID: first.AuditRunID,
+			ListID:     first.ListID,
+			ItemGUID:   first.ItemGUID,
+			ItemName:   name,
+			Links:      itemLinks,
+			Severity:   defaultFindingSeverities[RecycleBinSharingRemnant],
+		})
+	}
+	return remnants
+}
@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	"spaudit/test/mocks"
+)
+
+func TestDetectRecycleBinRemnants(t *testing.T) {
+	deletedAt := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+	before := deletedAt.Add(-24 * time.Hour)
+	budget := &sharepoint.RecycleBinItem{ID: "rb-budget", ItemType: sharepoint.RecycleBinItemTypeFile, Title: "budget.xlsx", DeletedAt: deletedAt}
+	notes := &sharepoint.RecycleBinItem{ID: "rb-notes", ItemType: sharepoint.RecycleBinItemTypeFile, Title: "notes.docx", DeletedAt: deletedAt}
+	plan := &sharepoint.RecycleBinItem{ID: "rb-plan", ItemType: sharepoint.RecycleBinItemTypeFolder, Title: "Plans", DeletedAt: before.Add(-time.Hour)}
+
+	links := []*contracts.DeletedItemLink{
+		{RecycleBinID: "rb-budget", AuditRunID: 3, AuditRunAt: before, ItemGUID: "budget", ListID: "docs", LinkID: "anyone", LinkKind: sharepoint.LinkKindFlexible, Scope: sharepoint.ScopeAnonymous},
+		{RecycleBinID: "rb-budget", AuditRunID: 3, AuditRunAt: before, ItemGUID: "budget", ListID: "docs", LinkID: "org", LinkKind: sharepoint.LinkKindOrganizationView},
+		// Captured after the folder was deleted, so it was on a newer folder at the same location
+		{RecycleBinID: "rb-plan", AuditRunID: 3, AuditRunAt: before, ItemGUID: "plan", ListID: "docs", LinkID: "org-plan", LinkKind: sharepoint.LinkKindOrganizationView},
+	}
+
+	remnants := DetectRecycleBinRemnants([]*sharepoint.RecycleBinItem{budget, notes, plan}, links)
+	require.Len(t, remnants, 1)
+	assert.Same(t, budget, remnants[0].Item)
+	assert.Equal(t, int64(3), remnants[0].AuditRunID)
+	assert.Equal(t, "budget.xlsx", remnants[0].ItemName, "falls back to the recycle bin title")
+	assert.Len(t, remnants[0].Links, 2)
+	assert.True(t, remnants[0].HasAnyoneLink())
+	assert.Equal(t, audit.SeverityMedium, remnants[0].Severity)
+
+	severities, err := NewFindingSeverities(map[string]audit.Severity{RecycleBinSharingRemnant: audit.SeverityHigh})
+	require.NoError(t, err)
+	data := &RecycleBinRemnantsData{Remnants: remnants}
+	severities.RateRecycleBinRemnants(data)
+	assert.Equal(t, audit.SeverityHigh, data.Remnants[0].Severity)
+}
+
+func TestSiteContentService_GetRecycleBinRemnants_NotScanned(t *testing.T) {
+	aggregate := &mocks.MockSiteContentAggregateRepository{}
+	aggregate.On("GetRecycleBinScan", mock.Anything, int64(1), int64(4)).Return(nil, nil)
+	service := NewAuditScopedSiteContentService(aggregate, 4)
+
+	data, err := service.GetRecycleBinRemnants(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Nil(t, data.ScannedAt)
+	assert.Empty(t, data.Remnants)
+	aggregate.AssertNotCalled(t, "GetDeletedItemLinks", mock.Anything, mock.Anything, mock.Anything)
+}
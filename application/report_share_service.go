@@ -0,0 +1,161 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// ErrInvalidReportShare is returned for report share requests that cannot be granted.
+var ErrInvalidReportShare = errors.New("invalid report share")
+
+// Bounds on how long a report share stays open.
+const (
+	MinReportShareTTL = time.Hour
+	MaxReportShareTTL = 90 * 24 * time.Hour
+)
+
+// ReportShareService grants expiring, read-only links to the printable summaries of an audit run
+// for stakeholders without access to spaudit.
+type ReportShareService struct {
+	db             *database.Database
+	serviceFactory AuditRunScopedServiceFactory
+	now            func() time.Time
+	logger         *logging.Logger
+}
+
+// NewReportShareService creates a new report share service.
+func NewReportShareService(db *database.Database, serviceFactory AuditRunScopedServiceFactory) *ReportShareService {
+	return &ReportShareService{
+		db:             db,
+		serviceFactory: serviceFactory,
+		now:            func() time.Time { return time.Now().UTC() },
+		logger:         logging.Default().WithComponent("report_share_service"),
+	}
+}
+
+// CreateShare creates a link to the summary of a site in an audit run, or of one of its lists when
+// listID is given, that stays open for ttl.
+func (s *ReportShareService) CreateShare(ctx context.Context, siteID, auditRunID int64, listID string, ttl time.Duration) (*audit.ReportShare, error) {
+	if ttl < MinReportShareTTL || ttl > MaxReportShareTTL {
+		return nil, fmt.Errorf("%w: links must expire between %s and %d days from now",
+			ErrInvalidReportShare, MinReportShareTTL, int(MaxReportShareTTL.Hours()/24))
+	}
+	auditRun, err := s.db.ReadQueries().GetAuditRun(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("audit run %d not found: %w", auditRunID, err)
+	}
+	if auditRun.SiteID != siteID {
+		return nil, fmt.Errorf("audit run %d: %w", auditRunID, contracts.ErrSiteScopeMismatch)
+	}
+	listID = strings.TrimSpace(listID)
+	if listID != "" {
+		scopedServices, err := s.serviceFactory.CreateForAuditRun(ctx, siteID, fmt.Sprint(auditRunID))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID); err != nil {
+			return nil, fmt.Errorf("list %s not found in audit run %d: %w", listID, auditRunID, err)
+		}
+	}
+
+	token, err := newLinkToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate report share token: %w", err)
+	}
+	now := s.now()
+	id, err := s.db.Queries().CreateReportShare(ctx, db.CreateReportShareParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		ListID:     sql.NullString{String: listID, Valid: listID != ""},
+		Token:      token,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(ttl),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create report share for audit run %d: %w", auditRunID, err)
+	}
+
+	s.logger.Info("Created report share", "site_id", siteID, "audit_run_id", auditRunID, "list_id", listID, "share_id", id, "expires_in", ttl)
+	return s.resolve(ctx, token)
+}
+
+// GetShares returns the report shares of an audit run, newest first, including expired and revoked ones.
+func (s *ReportShareService) GetShares(ctx context.Context, siteID, auditRunID int64) ([]*audit.ReportShare, error) {
+	rows, err := s.db.ReadQueries().GetReportSharesForAuditRun(ctx, db.GetReportSharesForAuditRunParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report shares for audit run %d: %w", auditRunID, err)
+	}
+	shares := make([]*audit.ReportShare, len(rows))
+	for i, row := range rows {
+		shares[i] = newReportShare(row)
+	}
+	return shares, nil
+}
+
+// ResolveShare returns the active report share behind a link token. Expired and revoked links are
+// reported as not found, so a link reveals nothing once closed.
+func (s *ReportShareService) ResolveShare(ctx context.Context, token string) (*audit.ReportShare, error) {
+	share, err := s.resolve(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !share.IsActive(s.now()) {
+		return nil, fmt.Errorf("report share %d is closed: %w", share.ID, sql.ErrNoRows)
+	}
+	return share, nil
+}
+
+// RevokeShare closes a report share of an audit run before it expires.
+func (s *ReportShareService) RevokeShare(ctx context.Context, siteID, auditRunID, shareID int64) error {
+	revoked, err := s.db.Queries().RevokeReportShare(ctx, db.RevokeReportShareParams{
+		RevokedAt:  sql.NullTime{Time: s.now(), Valid: true},
+		ShareID:    shareID,
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke report share %d: %w", shareID, err)
+	}
+	if revoked == 0 {
+		return fmt.Errorf("report share %d is not open in audit run %d: %w", shareID, auditRunID, sql.ErrNoRows)
+	}
+
+	s.logger.Info("Revoked report share", "site_id", siteID, "audit_run_id", auditRunID, "share_id", shareID)
+	return nil
+}
+
+// resolve returns the report share behind a link token, open or not.
+func (s *ReportShareService) resolve(ctx context.Context, token string) (*audit.ReportShare, error) {
+	row, err := s.db.ReadQueries().GetReportShareByToken(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("report share not found: %w", err)
+	}
+	return newReportShare(row), nil
+}
+
+// newReportShare converts a stored report share.
+func newReportShare(row db.ReportShare) *audit.ReportShare {
+	share := &audit.ReportShare{
+		ID:         row.ShareID,
+		SiteID:     row.SiteID,
+		AuditRunID: row.AuditRunID,
+		ListID:     row.ListID.String,
+		Token:      row.Token,
+		CreatedAt:  row.CreatedAt,
+		ExpiresAt:  row.ExpiresAt,
+	}
+	if row.RevokedAt.Valid {
+		share.RevokedAt = &row.RevokedAt.Time
+	}
+	return share
+}
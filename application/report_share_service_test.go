@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/contracts"
+)
+
+func TestReportShareService_Lifecycle(t *testing.T) {
+	attestations := newAttestationTestService(t)
+	service := NewReportShareService(attestations.db, attestations.serviceFactory)
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	for _, ttl := range []time.Duration{time.Minute, 91 * 24 * time.Hour} {
+		_, err := service.CreateShare(ctx, 1, 1, "", ttl)
+		assert.ErrorIs(t, err, ErrInvalidReportShare, ttl)
+	}
+	_, err := service.CreateShare(ctx, 2, 1, "", 24*time.Hour)
+	assert.ErrorIs(t, err, contracts.ErrSiteScopeMismatch)
+	_, err = service.CreateShare(ctx, 1, 1, "missing", 24*time.Hour)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "unknown lists cannot be shared")
+
+	siteShare, err := service.CreateShare(ctx, 1, 1, "", 24*time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, siteShare.Token, 48)
+	assert.Empty(t, siteShare.ListID)
+	assert.Equal(t, now.Add(24*time.Hour), siteShare.ExpiresAt.UTC())
+	listShare, err := service.CreateShare(ctx, 1, 1, "docs", 7*24*time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, "docs", listShare.ListID)
+
+	resolved, err := service.ResolveShare(ctx, siteShare.Token)
+	require.NoError(t, err)
+	assert.Equal(t, siteShare.ID, resolved.ID)
+	_, err = service.ResolveShare(ctx, "unknown")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	t.Run("expired links are not found", func(t *testing.T) {
+		service.now = func() time.Time { return now.Add(25 * time.Hour) }
+		defer func() { service.now = func() time.Time { return now } }()
+		_, err := service.ResolveShare(ctx, siteShare.Token)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		_, err = service.ResolveShare(ctx, listShare.Token)
+		assert.NoError(t, err)
+	})
+
+	t.Run("revoked links are not found", func(t *testing.T) {
+		assert.ErrorIs(t, service.RevokeShare(ctx, 2, 1, listShare.ID), sql.ErrNoRows, "shares are revoked within their site")
+		require.NoError(t, service.RevokeShare(ctx, 1, 1, listShare.ID))
+		assert.ErrorIs(t, service.RevokeShare(ctx, 1, 1, listShare.ID), sql.ErrNoRows, "shares are revoked once")
+		_, err := service.ResolveShare(ctx, listShare.Token)
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+	})
+
+	shares, err := service.GetShares(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, shares, 2)
+	assert.True(t, shares[0].IsRevoked())
+	assert.False(t, shares[1].IsRevoked())
+}
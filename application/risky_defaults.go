@@ -0,0 +1,124 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+// Risky defaults a site can rely on.
+const (
+	RiskyDefaultEditLink               = "default_link_edit"        // The share dialog offers an edit link
+	RiskyDefaultAnyoneLink             = "default_link_anyone"      // The share dialog offers an anyone link
+	RiskyDefaultMembersCanShare        = "members_can_share"        // Web members can share what they can reach
+	RiskyDefaultAnyoneLinksDiscouraged = "anyone_links_discouraged" // The site allows anyone links the tenant restricts
+)
+
+// MaxRiskyDefaultObjects caps the objects listed for each finding; ObjectCount keeps the full count.
+const MaxRiskyDefaultObjects = 100
+
+// RiskyDefault is one risky default a site relies on, with the sharing links created under it.
+type RiskyDefault struct {
+	Kind        string
+	Severity    audit.Severity // Rated by the finding severity mapping
+	WebID       string         // Set for members_can_share, the web whose members can share
+	WebURL      string
+	Objects     []*contracts.SharingLinkFact // At most MaxRiskyDefaultObjects
+	ObjectCount int
+}
+
+// RiskyDefaultsData represents the risky defaults found in one audit run.
+type RiskyDefaultsData struct {
+	GovernanceCaptured bool // False when the run captured no sharing policy, so only web settings were checked
+	DefaultSharingLink *sharepoint.DefaultSharingLink
+	Findings           []*RiskyDefault
+}
+
+// GetRiskyDefaults combines the run's sharing policy with its webs and active sharing links to find
+// objects relying on risky defaults (audit-scoped), failing with ErrRowCapExceeded when there are too many links.
+func (s *SiteContentService) GetRiskyDefaults(ctx context.Context, siteID int64) (*RiskyDefaultsData, error) {
+	governance, err := s.contentAggregate.GetSharingGovernanceForAuditRun(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sharing governance: %w", err)
+	}
+	webs, err := s.contentAggregate.GetWebsForSite(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webs: %w", err)
+	}
+	links, err := s.contentAggregate.GetSharingLinkFacts(ctx, siteID, s.auditRunID, s.maxRows)
+	if err != nil {
+		return nil, err
+	}
+	return DetectRiskyDefaults(governance, webs, links), nil
+}
+
+// DetectRiskyDefaults finds the risky defaults in a sharing policy and web settings, listing the active
+// sharing links that relied on each. The policy may be nil. Findings are reported even when no link relied
+// on them yet.
+func DetectRiskyDefaults(governance *sharepoint.SharingGovernance, webs []*sharepoint.Web, links []*contracts.SharingLinkFact) *RiskyDefaultsData {
+	data := &RiskyDefaultsData{GovernanceCaptured: governance != nil, Findings: []*RiskyDefault{}}
+
+	if governance != nil && governance.DefaultSharingLink != nil {
+		defaultLink := governance.DefaultSharingLink
+		data.DefaultSharingLink = defaultLink
+		if defaultLink.GrantsEdit() {
+			data.Findings = append(data.Findings, riskyDefault(RiskyDefaultEditLink, links, func(link *contracts.SharingLinkFact) bool {
+				return link.IsDefault && link.IsEditLink
+			}))
+		}
+		if defaultLink.IsAnyone() {
+			data.Findings = append(data.Findings, riskyDefault(RiskyDefaultAnyoneLink, links, func(link *contracts.SharingLinkFact) bool {
+				return link.IsDefault && isAnyoneLink(link)
+			}))
+		}
+	}
+
+	if governance != nil && anyoneLinksEnabled(governance.SharingAbilities) && governance.AnonymousLinkExpirationRestrictionDays > 0 {
+		data.Findings = append(data.Findings, riskyDefault(RiskyDefaultAnyoneLinksDiscouraged, links, isAnyoneLink))
+	}
+
+	for _, web := range webs {
+		if web.MembersCanShare == nil || !*web.MembersCanShare {
+			continue
+		}
+		finding := riskyDefault(RiskyDefaultMembersCanShare, links, func(link *contracts.SharingLinkFact) bool {
+			return link.WebID == web.ID
+		})
+		finding.WebID = web.ID
+		finding.WebURL = web.URL
+		data.Findings = append(data.Findings, finding)
+	}
+	return data
+}
+
+// riskyDefault builds a finding from the links that match it, rated with the default severity
+// until FindingSeverities.RateRiskyDefaults applies the configured mapping.
+func riskyDefault(kind string, links []*contracts.SharingLinkFact, matches func(*contracts.SharingLinkFact) bool) *RiskyDefault {
+	finding := &RiskyDefault{Kind: kind, Severity: defaultFindingSeverities[kind], Objects: []*contracts.SharingLinkFact{}}
+	for _, link := range links {
+		if !matches(link) {
+			continue
+		}
+		finding.ObjectCount++
+		if len(finding.Objects) < MaxRiskyDefaultObjects {
+			finding.Objects = append(finding.Objects, link)
+		}
+	}
+	return finding
+}
+
+// isAnyoneLink returns true if the link works for anyone who has it, without signing in.
+func isAnyoneLink(link *contracts.SharingLinkFact) bool {
+	return sharepoint.IsAnyoneLinkKind(link.LinkKind, link.Scope)
+}
+
+// anyoneLinksEnabled returns true if the site lets its users create anyone links.
+func anyoneLinksEnabled(abilities *sharepoint.SharingAbilities) bool {
+	if abilities == nil || abilities.AnyoneLinkAbilities == nil {
+		return false
+	}
+	return abilities.AnyoneLinkAbilities.CanGetReadLink.Enabled || abilities.AnyoneLinkAbilities.CanGetEditLink.Enabled
+}
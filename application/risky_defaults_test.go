@@ -0,0 +1,101 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	"spaudit/test/helpers"
+)
+
+func TestDetectRiskyDefaults(t *testing.T) {
+	canShare, cannotShare := true, false
+	webs := []*sharepoint.Web{
+		{ID: "web-1", URL: "https://contoso.sharepoint.com/sites/a", MembersCanShare: &canShare},
+		{ID: "web-2", URL: "https://contoso.sharepoint.com/sites/a/team", MembersCanShare: &cannotShare},
+		{ID: "web-3", URL: "https://contoso.sharepoint.com/sites/a/old"},
+	}
+	defaultEdit := &contracts.SharingLinkFact{LinkID: "default-edit", WebID: "web-1", LinkKind: sharepoint.LinkKindOrganizationEdit, Scope: sharepoint.ScopeOrganization, IsDefault: true, IsEditLink: true}
+	chosenEdit := &contracts.SharingLinkFact{LinkID: "chosen-edit", WebID: "web-2", LinkKind: sharepoint.LinkKindOrganizationEdit, Scope: sharepoint.ScopeOrganization, IsEditLink: true}
+	anyone := &contracts.SharingLinkFact{LinkID: "anyone", WebID: "web-1", LinkKind: sharepoint.LinkKindFlexible, Scope: sharepoint.ScopeAnonymous}
+	direct := &contracts.SharingLinkFact{LinkID: "direct", WebID: "web-2", LinkKind: sharepoint.LinkKindDirect}
+	links := []*contracts.SharingLinkFact{defaultEdit, chosenEdit, anyone, direct}
+
+	t.Run("edit default, anyone links the tenant restricts and members who can share", func(t *testing.T) {
+		governance := &sharepoint.SharingGovernance{
+			AnonymousLinkExpirationRestrictionDays: 30,
+			DefaultSharingLink:                     &sharepoint.DefaultSharingLink{LinkKind: sharepoint.LinkKindOrganizationEdit, Permission: sharepoint.RoleEdit, Scope: sharepoint.ScopeOrganization},
+			SharingAbilities: &sharepoint.SharingAbilities{
+				AnyoneLinkAbilities: &sharepoint.SharingLinkAbilities{CanGetReadLink: sharepoint.SharingAbilityStatus{Enabled: true}},
+			},
+		}
+
+		result := DetectRiskyDefaults(governance, webs, links)
+
+		assert.True(t, result.GovernanceCaptured)
+		require.Len(t, result.Findings, 3)
+		assert.Equal(t, &RiskyDefault{Kind: RiskyDefaultEditLink, Severity: audit.SeverityMedium, Objects: []*contracts.SharingLinkFact{defaultEdit}, ObjectCount: 1}, result.Findings[0])
+		assert.Equal(t, &RiskyDefault{Kind: RiskyDefaultAnyoneLinksDiscouraged, Severity: audit.SeverityHigh, Objects: []*contracts.SharingLinkFact{anyone}, ObjectCount: 1}, result.Findings[1])
+		assert.Equal(t, &RiskyDefault{
+			Kind: RiskyDefaultMembersCanShare, Severity: audit.SeverityLow, WebID: "web-1", WebURL: "https://contoso.sharepoint.com/sites/a",
+			Objects: []*contracts.SharingLinkFact{defaultEdit, anyone}, ObjectCount: 2,
+		}, result.Findings[2])
+	})
+
+	t.Run("anyone links allowed by the tenant are not flagged", func(t *testing.T) {
+		governance := &sharepoint.SharingGovernance{
+			DefaultSharingLink: &sharepoint.DefaultSharingLink{LinkKind: sharepoint.LinkKindAnonymousView, Permission: sharepoint.RoleView, Scope: sharepoint.ScopeAnonymous},
+			SharingAbilities: &sharepoint.SharingAbilities{
+				AnyoneLinkAbilities: &sharepoint.SharingLinkAbilities{CanGetReadLink: sharepoint.SharingAbilityStatus{Enabled: true}},
+			},
+		}
+
+		result := DetectRiskyDefaults(governance, nil, links)
+
+		require.Len(t, result.Findings, 1)
+		assert.Equal(t, RiskyDefaultAnyoneLink, result.Findings[0].Kind)
+		assert.Zero(t, result.Findings[0].ObjectCount, "the anyone link was not created from the default")
+		assert.Empty(t, result.Findings[0].Objects)
+	})
+
+	t.Run("without a sharing policy only webs are checked", func(t *testing.T) {
+		result := DetectRiskyDefaults(nil, webs, links)
+
+		assert.False(t, result.GovernanceCaptured)
+		assert.Nil(t, result.DefaultSharingLink)
+		require.Len(t, result.Findings, 1)
+		assert.Equal(t, RiskyDefaultMembersCanShare, result.Findings[0].Kind)
+	})
+
+	t.Run("caps listed objects", func(t *testing.T) {
+		many := make([]*contracts.SharingLinkFact, MaxRiskyDefaultObjects+5)
+		for i := range many {
+			many[i] = anyone
+		}
+
+		result := DetectRiskyDefaults(nil, webs[:1], many)
+
+		assert.Len(t, result.Findings[0].Objects, MaxRiskyDefaultObjects)
+		assert.Equal(t, MaxRiskyDefaultObjects+5, result.Findings[0].ObjectCount)
+	})
+}
+
+func TestSiteContentService_GetRiskyDefaults(t *testing.T) {
+	mocks := helpers.NewMockRepositories()
+	ctx := context.Background()
+	mocks.SiteContentAggregate.On("GetSharingGovernanceForAuditRun", ctx, int64(1), int64(7)).Return(nil, nil)
+	mocks.SiteContentAggregate.On("GetWebsForSite", ctx, int64(1), int64(7)).Return([]*sharepoint.Web{}, nil)
+	mocks.SiteContentAggregate.On("GetSharingLinkFacts", ctx, int64(1), int64(7), 50).Return(nil, contracts.ErrRowCapExceeded)
+
+	service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+	service.SetMaxAnalysisRows(50)
+
+	_, err := service.GetRiskyDefaults(ctx, 1)
+
+	assert.ErrorIs(t, err, contracts.ErrRowCapExceeded)
+}
@@ -0,0 +1,224 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/gen/db"
+	"spaudit/logging"
+	"spaudit/platform/compress"
+)
+
+// ArtifactNotifier is told about artifacts attached to audit runs, e.g. to notify connected users.
+type ArtifactNotifier interface {
+	NotifyArtifactRegistered(artifact *audit.RunArtifact)
+}
+
+// ErrArtifactNotFound is returned when an artifact does not exist, belongs to another run or has expired
+// while its run is not on hold.
+var ErrArtifactNotFound = errors.New("artifact not found")
+
+// RunArtifactService keeps reports and exports generated from audit runs for later download. Artifacts
+// expire after the retention period unless their run is on hold, which keeps them for as long as the hold lasts.
+type RunArtifactService struct {
+	db          *database.Database
+	retention   time.Duration
+	compression compress.Encoding
+	notifier    ArtifactNotifier
+	now         func() time.Time
+	logger      *logging.Logger
+}
+
+// NewRunArtifactService creates a new run artifact service keeping artifacts for the retention period.
+func NewRunArtifactService(db *database.Database, retention time.Duration) *RunArtifactService {
+	if retention <= 0 {
+		retention = audit.DefaultArtifactRetention
+	}
+	return &RunArtifactService{
+		db:          db,
+		retention:   retention,
+		compression: compress.Zstd,
+		now:         func() time.Time { return time.Now().UTC() },
+		logger:      logging.Default().WithComponent("run_artifact_service"),
+	}
+}
+
+// SetNotifier sets the notifier told about newly registered artifacts.
+func (s *RunArtifactService) SetNotifier(notifier ArtifactNotifier) {
+	s.notifier = notifier
+}
+
+// SetCompression sets how artifact content is compressed in the database. Artifacts already stored
+// keep their encoding and read back the same.
+func (s *RunArtifactService) SetCompression(encoding compress.Encoding) {
+	s.compression = encoding
+}
+
+// RegisterArtifact attaches a generated report to an audit run of the site and notifies about it.
+func (s *RunArtifactService) RegisterArtifact(ctx context.Context, siteID, auditRunID int64, filename, contentType string, content []byte) (*audit.RunArtifact, error) {
+	artifact, err := s.AttachArtifact(ctx, siteID, auditRunID, filename, contentType, content)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.notifier != nil {
+		s.notifier.NotifyArtifactRegistered(artifact)
+	}
+	return artifact, nil
+}
+
+// AttachArtifact attaches a generated report to an audit run of the site without notifying,
+// for reports announced by another notification. Expired artifacts of runs not on hold are pruned on the way.
+func (s *RunArtifactService) AttachArtifact(ctx context.Context, siteID, auditRunID int64, filename, contentType string, content []byte) (*audit.RunArtifact, error) {
+	auditRun, err := s.db.ReadQueries().GetAuditRun(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("audit run %d not found: %w", auditRunID, err)
+	}
+	if auditRun.SiteID != siteID {
+		return nil, fmt.Errorf("audit run %d: %w", auditRunID, contracts.ErrSiteScopeMismatch)
+	}
+
+	now := s.now()
+	if pruned, err := s.db.Queries().DeleteExpiredAuditRunArtifacts(ctx, now); err != nil {
+		s.logger.Warn("Failed to prune expired artifacts", "error", err)
+	} else if pruned > 0 {
+		s.logger.Info("Pruned expired artifacts", "count", pruned)
+	}
+
+	artifact := &audit.RunArtifact{
+		AuditRunID:  auditRunID,
+		SiteID:      siteID,
+		Filename:    filename,
+		ContentType: contentType,
+		SizeBytes:   int64(len(content)),
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.retention),
+	}
+	stored, encoding, err := compress.Encode(content, s.compression)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compress artifact for audit run %d: %w", auditRunID, err)
+	}
+	artifact.ID, err = s.db.Queries().CreateAuditRunArtifact(ctx, db.CreateAuditRunArtifactParams{
+		AuditRunID:      artifact.AuditRunID,
+		SiteID:          artifact.SiteID,
+		Filename:        artifact.Filename,
+		ContentType:     artifact.ContentType,
+		SizeBytes:       artifact.SizeBytes,
+		Content:         stored,
+		ContentEncoding: string(encoding),
+		CreatedAt:       artifact.CreatedAt,
+		ExpiresAt:       artifact.ExpiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store artifact for audit run %d: %w", auditRunID, err)
+	}
+
+	s.logger.Info("Registered run artifact", "site_id", siteID, "audit_run_id", auditRunID, "artifact_id", artifact.ID, "filename", filename, "size_bytes", artifact.SizeBytes, "stored_bytes", len(stored))
+	return artifact, nil
+}
+
+// ListArtifacts returns the unexpired artifacts of an audit run of the site, newest first.
+func (s *RunArtifactService) ListArtifacts(ctx context.Context, siteID, auditRunID int64) ([]*audit.RunArtifact, error) {
+	runArtifacts, err := s.ListRunArtifacts(ctx, auditRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	artifacts := make([]*audit.RunArtifact, 0, len(runArtifacts))
+	for _, artifact := range runArtifacts {
+		if artifact.SiteID == siteID {
+			artifacts = append(artifacts, artifact)
+		}
+	}
+	return artifacts, nil
+}
+
+// ListRunArtifacts returns the unexpired artifacts of an audit run of any site, newest first,
+// e.g. to offer them in the run's completion notification.
+func (s *RunArtifactService) ListRunArtifacts(ctx context.Context, auditRunID int64) ([]*audit.RunArtifact, error) {
+	rows, err := s.db.ReadQueries().GetAuditRunArtifacts(ctx, db.GetAuditRunArtifactsParams{
+		AuditRunID: auditRunID,
+		Now:        s.now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifacts for audit run %d: %w", auditRunID, err)
+	}
+
+	artifacts := make([]*audit.RunArtifact, len(rows))
+	for i, row := range rows {
+		artifacts[i] = newRunArtifact(db.GetSiteAuditRunArtifactsRow(row))
+	}
+	return artifacts, nil
+}
+
+// ListSiteArtifacts returns the unexpired artifacts of all audit runs of the site, newest first.
+func (s *RunArtifactService) ListSiteArtifacts(ctx context.Context, siteID int64) ([]*audit.RunArtifact, error) {
+	rows, err := s.db.ReadQueries().GetSiteAuditRunArtifacts(ctx, db.GetSiteAuditRunArtifactsParams{
+		SiteID: siteID,
+		Now:    s.now(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get artifacts for site %d: %w", siteID, err)
+	}
+
+	artifacts := make([]*audit.RunArtifact, len(rows))
+	for i, row := range rows {
+		artifacts[i] = newRunArtifact(row)
+	}
+	return artifacts, nil
+}
+
+// GetArtifact returns an unexpired artifact of an audit run of the site with its content.
+func (s *RunArtifactService) GetArtifact(ctx context.Context, siteID, auditRunID, artifactID int64) (*audit.RunArtifact, []byte, error) {
+	row, err := s.db.ReadQueries().GetAuditRunArtifact(ctx, db.GetAuditRunArtifactParams{
+		ArtifactID: artifactID,
+		Now:        s.now(),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("artifact %d: %w", artifactID, ErrArtifactNotFound)
+		}
+		return nil, nil, fmt.Errorf("failed to get artifact %d: %w", artifactID, err)
+	}
+	if row.SiteID != siteID || row.AuditRunID != auditRunID {
+		return nil, nil, fmt.Errorf("artifact %d: %w", artifactID, ErrArtifactNotFound)
+	}
+
+	content, err := compress.Decode(row.Content, compress.Encoding(row.ContentEncoding))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decompress artifact %d: %w", artifactID, err)
+	}
+
+	artifact := &audit.RunArtifact{
+		ID:          row.ArtifactID,
+		AuditRunID:  row.AuditRunID,
+		SiteID:      row.SiteID,
+		Filename:    row.Filename,
+		ContentType: row.ContentType,
+		SizeBytes:   row.SizeBytes,
+		CreatedAt:   row.CreatedAt,
+		ExpiresAt:   row.ExpiresAt,
+	}
+	return artifact, content, nil
+}
+
+// newRunArtifact converts artifact metadata columns to the domain model.
+// The artifact listing queries select the same columns, so their rows convert to GetSiteAuditRunArtifactsRow.
+func newRunArtifact(row db.GetSiteAuditRunArtifactsRow) *audit.RunArtifact {
+	return &audit.RunArtifact{
+		ID:          row.ArtifactID,
+		AuditRunID:  row.AuditRunID,
+		SiteID:      row.SiteID,
+		Filename:    row.Filename,
+		ContentType: row.ContentType,
+		SizeBytes:   row.SizeBytes,
+		CreatedAt:   row.CreatedAt,
+		ExpiresAt:   row.ExpiresAt,
+	}
+}
@@ -0,0 +1,140 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+)
+
+// recordingArtifactNotifier records the artifacts it was told about
+type recordingArtifactNotifier struct {
+	artifacts []*audit.RunArtifact
+}
+
+func (n *recordingArtifactNotifier) NotifyArtifactRegistered(artifact *audit.RunArtifact) {
+	n.artifacts = append(n.artifacts, artifact)
+}
+
+func TestRunArtifactService(t *testing.T) {
+	testDB := newTestDatabase(t)
+
+	for _, stmt := range []string{
+		`INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A'), (2, 'https://contoso.sharepoint.com/sites/b', 'B')`,
+		`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`,
+		`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (1, 'job-1', 1, CURRENT_TIMESTAMP), (2, 'job-2', 1, CURRENT_TIMESTAMP)`,
+	} {
+		mustExec(t, testDB, stmt)
+	}
+
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	notifier := &recordingArtifactNotifier{}
+	service := NewRunArtifactService(testDB, 24*time.Hour)
+	service.SetNotifier(notifier)
+	service.now = func() time.Time { return now }
+
+	lists, err := service.RegisterArtifact(ctx, 1, 1, "lists-site1-run1.csv", "text/csv; charset=utf-8", []byte("Title\nDocuments\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(16), lists.SizeBytes)
+	assert.Equal(t, now.Add(24*time.Hour), lists.ExpiresAt)
+	require.Len(t, notifier.artifacts, 1, "registering notifies")
+	assert.Equal(t, lists.ID, notifier.artifacts[0].ID)
+
+	now = now.Add(time.Hour)
+	items, err := service.RegisterArtifact(ctx, 1, 2, "items-docs-run2.csv", "text/csv; charset=utf-8", []byte("Name\n"))
+	require.NoError(t, err)
+
+	// Runs of other sites cannot have artifacts attached through this site
+	_, err = service.RegisterArtifact(ctx, 2, 1, "lists.csv", "text/csv", []byte("x"))
+	assert.Error(t, err)
+
+	runArtifacts, err := service.ListArtifacts(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, runArtifacts, 1)
+	assert.Equal(t, "lists-site1-run1.csv", runArtifacts[0].Filename)
+
+	siteArtifacts, err := service.ListSiteArtifacts(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, siteArtifacts, 2)
+	assert.Equal(t, items.ID, siteArtifacts[0].ID, "newest first")
+
+	artifact, content, err := service.GetArtifact(ctx, 1, 1, lists.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv; charset=utf-8", artifact.ContentType)
+	assert.Equal(t, "Title\nDocuments\n", string(content))
+
+	_, _, err = service.GetArtifact(ctx, 1, 2, lists.ID)
+	assert.ErrorIs(t, err, ErrArtifactNotFound, "artifacts are scoped to their run")
+
+	// The first artifact expires first, and is pruned by the next registration
+	now = lists.ExpiresAt
+	_, _, err = service.GetArtifact(ctx, 1, 1, lists.ID)
+	assert.ErrorIs(t, err, ErrArtifactNotFound)
+	siteArtifacts, err = service.ListSiteArtifacts(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, siteArtifacts, 1)
+	assert.Equal(t, items.ID, siteArtifacts[0].ID)
+
+	_, err = service.RegisterArtifact(ctx, 1, 2, "assignments-docs-run2.csv", "text/csv", []byte("x"))
+	require.NoError(t, err)
+	var stored int
+	require.NoError(t, testDB.ReadDB().QueryRow(`SELECT COUNT(*) FROM audit_run_artifacts`).Scan(&stored))
+	assert.Equal(t, 2, stored)
+
+	// Compressible content is stored compressed and reads back as registered
+	csv := []byte("Name\n" + strings.Repeat("Quarterly report.docx\n", 200))
+	report, err := service.RegisterArtifact(ctx, 1, 2, "report-run2.csv", "text/csv", csv)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(csv)), report.SizeBytes)
+	var encoding string
+	var storedBytes int
+	require.NoError(t, testDB.ReadDB().QueryRow(`SELECT content_encoding, length(content) FROM audit_run_artifacts WHERE artifact_id = ?`, report.ID).Scan(&encoding, &storedBytes))
+	assert.Equal(t, "zstd", encoding)
+	assert.Less(t, storedBytes, len(csv))
+	_, content, err = service.GetArtifact(ctx, 1, 2, report.ID)
+	require.NoError(t, err)
+	assert.Equal(t, csv, content)
+}
+
+func TestRunArtifactService_KeepsArtifactsOfHeldRuns(t *testing.T) {
+	testDB := newTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A')`)
+	mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`)
+	mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (1, 'job-1', 1, CURRENT_TIMESTAMP), (2, 'job-2', 1, CURRENT_TIMESTAMP)`)
+
+	ctx := context.Background()
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := NewRunArtifactService(testDB, 24*time.Hour)
+	service.now = func() time.Time { return now }
+
+	held, err := service.RegisterArtifact(ctx, 1, 1, "lists-site1-run1.csv", "text/csv", []byte("Title\n"))
+	require.NoError(t, err)
+	_, err = service.RegisterArtifact(ctx, 1, 2, "lists-site1-run2.csv", "text/csv", []byte("Title\n"))
+	require.NoError(t, err)
+	mustExec(t, testDB, `UPDATE audit_runs SET on_hold = TRUE, held_at = CURRENT_TIMESTAMP, hold_reason = 'litigation' WHERE audit_run_id = 1`)
+
+	// Both artifacts are past their expiry when the next one is registered
+	now = now.Add(48 * time.Hour)
+	_, err = service.RegisterArtifact(ctx, 1, 2, "items-run2.csv", "text/csv", []byte("Name\n"))
+	require.NoError(t, err)
+
+	var stored int
+	require.NoError(t, testDB.ReadDB().QueryRow(`SELECT COUNT(*) FROM audit_run_artifacts WHERE audit_run_id = 1`).Scan(&stored))
+	assert.Equal(t, 1, stored, "the held run's expired artifact is not pruned")
+	runArtifacts, err := service.ListArtifacts(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, runArtifacts, 1)
+	assert.Equal(t, held.ID, runArtifacts[0].ID)
+	siteArtifacts, err := service.ListSiteArtifacts(ctx, 1)
+	require.NoError(t, err)
+	assert.Len(t, siteArtifacts, 2, "the held run's artifact and the new one")
+	_, content, err := service.GetArtifact(ctx, 1, 1, held.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Title\n", string(content))
+}
@@ -0,0 +1,189 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// ListSnapshotEncoder encodes a list snapshot in its canonical exported form, the bytes a manifest hashes.
+type ListSnapshotEncoder func(siteID int64, snapshot *ListSnapshotData) ([]byte, error)
+
+// ErrRunAlreadySealed is returned when sealing an audit run that already has a manifest.
+// A manifest is never replaced, otherwise it would prove nothing.
+var ErrRunAlreadySealed = errors.New("audit run already has a manifest")
+
+// ErrRunNotSealed is returned when an audit run has no manifest to return or verify against.
+var ErrRunNotSealed = errors.New("audit run has no manifest")
+
+// RunManifestService seals audit runs with content hashes and verifies stored runs against them.
+type RunManifestService struct {
+	db             *database.Database
+	serviceFactory AuditRunScopedServiceFactory
+	encode         ListSnapshotEncoder
+	logger         *logging.Logger
+}
+
+// NewRunManifestService creates a new run manifest service.
+// SetSnapshotEncoder must be called before runs can be sealed or verified.
+func NewRunManifestService(db *database.Database, serviceFactory AuditRunScopedServiceFactory) *RunManifestService {
+	return &RunManifestService{
+		db:             db,
+		serviceFactory: serviceFactory,
+		logger:         logging.Default().WithComponent("run_manifest_service"),
+	}
+}
+
+// SetSnapshotEncoder sets the canonical snapshot encoding that manifests hash.
+// It is the snapshot API's encoding, so each list hash can be reproduced from a downloaded snapshot.
+func (s *RunManifestService) SetSnapshotEncoder(encode ListSnapshotEncoder) {
+	s.encode = encode
+}
+
+// SealAuditRun computes and stores the manifest of an audit run.
+func (s *RunManifestService) SealAuditRun(ctx context.Context, siteID, auditRunID int64) (*audit.RunManifest, error) {
+	if _, err := s.GetManifest(ctx, siteID, auditRunID); err == nil {
+		return nil, fmt.Errorf("audit run %d: %w", auditRunID, ErrRunAlreadySealed)
+	} else if !errors.Is(err, ErrRunNotSealed) {
+		return nil, err
+	}
+
+	entries, err := s.computeEntries(ctx, siteID, auditRunID)
+	if err != nil {
+		return nil, err
+	}
+	contentHash := audit.ComputeManifestHash(entries)
+
+	err = s.db.WithTx(func(queries *db.Queries) error {
+		if err := queries.CreateAuditRunManifest(ctx, db.CreateAuditRunManifestParams{
+			AuditRunID:  auditRunID,
+			SiteID:      siteID,
+			Algorithm:   audit.ManifestAlgorithm,
+			ContentHash: contentHash,
+		}); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := queries.AddAuditRunManifestEntry(ctx, db.AddAuditRunManifestEntryParams{
+				AuditRunID:  auditRunID,
+				ListID:      entry.ListID,
+				ContentHash: entry.ContentHash,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to store manifest for audit run %d: %w", auditRunID, err)
+	}
+
+	s.logger.Info("Sealed audit run", "site_id", siteID, "audit_run_id", auditRunID, "lists", len(entries), "content_hash", contentHash)
+	return s.GetManifest(ctx, siteID, auditRunID)
+}
+
+// SealCompletedAuditRun seals an audit run that has just completed, looking up its site.
+func (s *RunManifestService) SealCompletedAuditRun(ctx context.Context, auditRunID int64) error {
+	auditRun, err := s.db.ReadQueries().GetAuditRun(ctx, auditRunID)
+	if err != nil {
+		return fmt.Errorf("audit run %d not found: %w", auditRunID, err)
+	}
+
+	_, err = s.SealAuditRun(ctx, auditRun.SiteID, auditRunID)
+	return err
+}
+
+// GetManifest returns the stored manifest of an audit run, scoped to the site.
+func (s *RunManifestService) GetManifest(ctx context.Context, siteID, auditRunID int64) (*audit.RunManifest, error) {
+	row, err := s.db.ReadQueries().GetAuditRunManifest(ctx, auditRunID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("audit run %d: %w", auditRunID, ErrRunNotSealed)
+		}
+		return nil, fmt.Errorf("failed to get manifest for audit run %d: %w", auditRunID, err)
+	}
+	if row.SiteID != siteID {
+		return nil, fmt.Errorf("audit run %d: %w", auditRunID, contracts.ErrSiteScopeMismatch)
+	}
+
+	entryRows, err := s.db.ReadQueries().GetAuditRunManifestEntries(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest entries for audit run %d: %w", auditRunID, err)
+	}
+
+	manifest := &audit.RunManifest{
+		AuditRunID:  row.AuditRunID,
+		SiteID:      row.SiteID,
+		Algorithm:   row.Algorithm,
+		ContentHash: row.ContentHash,
+		CreatedAt:   row.CreatedAt,
+		Entries:     make([]audit.ManifestEntry, len(entryRows)),
+	}
+	for i, entry := range entryRows {
+		manifest.Entries[i] = audit.ManifestEntry{ListID: entry.ListID, ContentHash: entry.ContentHash}
+	}
+	return manifest, nil
+}
+
+// VerifyAuditRun recomputes the hashes of an audit run's stored data and compares them with its manifest.
+func (s *RunManifestService) VerifyAuditRun(ctx context.Context, siteID, auditRunID int64) (*audit.ManifestVerification, error) {
+	manifest, err := s.GetManifest(ctx, siteID, auditRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.computeEntries(ctx, siteID, auditRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	verification := audit.VerifyManifest(manifest, entries)
+	if !verification.Verified {
+		s.logger.Warn("Audit run failed manifest verification", "site_id", siteID, "audit_run_id", auditRunID)
+	}
+	return verification, nil
+}
+
+// computeEntries hashes the canonical snapshot of every list in the audit run.
+func (s *RunManifestService) computeEntries(ctx context.Context, siteID, auditRunID int64) ([]audit.ManifestEntry, error) {
+	if s.encode == nil {
+		return nil, errors.New("run manifest service has no snapshot encoder")
+	}
+
+	scopedServices, err := s.serviceFactory.CreateForAuditRun(ctx, siteID, strconv.FormatInt(auditRunID, 10))
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := scopedServices.SiteContentService.GetListsForSite(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists for audit run %d: %w", auditRunID, err)
+	}
+
+	entries := make([]audit.ManifestEntry, 0, len(lists))
+	for _, list := range lists {
+		snapshot, err := scopedServices.SiteContentService.GetListSnapshot(ctx, siteID, list.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get snapshot of list %s: %w", list.ID, err)
+		}
+		encoded, err := s.encode(siteID, snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode snapshot of list %s: %w", list.ID, err)
+		}
+		sum := sha256.Sum256(encoded)
+		entries = append(entries, audit.ManifestEntry{ListID: list.ID, ContentHash: hex.EncodeToString(sum[:])})
+	}
+
+	audit.SortManifestEntries(entries)
+	return entries, nil
+}
@@ -0,0 +1,182 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/events"
+	"spaudit/domain/jobs"
+	"spaudit/logging"
+)
+
+// RunSummaryNotifier announces a summary of an audit run once its job finishes or fails, e.g. by email.
+type RunSummaryNotifier interface {
+	NotifyRunSummary(ctx context.Context, summary *audit.RunSummary) error
+}
+
+// ErrUnknownNotificationChannel is returned when announcing on a channel that was never added.
+var ErrUnknownNotificationChannel = errors.New("unknown notification channel")
+
+// runNotificationChannel is a channel announcing run summaries and the name its deliveries are tracked under.
+type runNotificationChannel struct {
+	name     string
+	notifier RunSummaryNotifier
+}
+
+// RunNotificationService summarizes the audit runs of finished jobs for the notification channels.
+type RunNotificationService struct {
+	db             *database.Database
+	serviceFactory AuditRunScopedServiceFactory
+	channels       []runNotificationChannel
+	now            func() time.Time
+	logger         *logging.Logger
+}
+
+// NewRunNotificationService creates a run notification service; AddNotifier adds its channels.
+func NewRunNotificationService(db *database.Database, serviceFactory AuditRunScopedServiceFactory) *RunNotificationService {
+	return &RunNotificationService{
+		db:             db,
+		serviceFactory: serviceFactory,
+		now:            func() time.Time { return time.Now().UTC() },
+		logger:         logging.Default().WithComponent("run_notification_service"),
+	}
+}
+
+// AddNotifier adds a channel announcing run summaries under a name, e.g. "email", that its
+// deliveries are tracked by.
+func (s *RunNotificationService) AddNotifier(name string, notifier RunSummaryNotifier) {
+	s.channels = append(s.channels, runNotificationChannel{name: name, notifier: notifier})
+}
+
+// Enabled returns true if any channel announces run summaries.
+func (s *RunNotificationService) Enabled() bool {
+	return len(s.channels) > 0
+}
+
+// Channels returns the names of the channels announcing run summaries, in the order added.
+func (s *RunNotificationService) Channels() []string {
+	names := make([]string, len(s.channels))
+	for i, channel := range s.channels {
+		names[i] = channel.name
+	}
+	return names
+}
+
+// NotifyJobEvent summarizes the audit run of a site audit that completed or failed and announces it on
+// the named channel; other events are ignored. Each channel is delivered the event on its own, so one
+// failing is retried without announcing the run again on the others.
+func (s *RunNotificationService) NotifyJobEvent(ctx context.Context, channel string, event *events.RecordedJobEvent) error {
+	var notifier RunSummaryNotifier
+	for _, added := range s.channels {
+		if added.name == channel {
+			notifier = added.notifier
+		}
+	}
+	if notifier == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownNotificationChannel, channel)
+	}
+	if event.JobType != jobs.JobTypeSiteAudit || (event.Type != events.JobEventCompleted && event.Type != events.JobEventFailed) {
+		return nil
+	}
+
+	summary := s.SummarizeJobEvent(ctx, event)
+	if err := notifier.NotifyRunSummary(ctx, summary); err != nil {
+		return fmt.Errorf("failed to announce audit run of job %s by %s: %w", event.JobID, channel, err)
+	}
+	return nil
+}
+
+// SummarizeJobEvent summarizes what the audit run of a finished job captured. Counts that cannot be
+// read are left out rather than failing, so a failed job is still announced.
+func (s *RunNotificationService) SummarizeJobEvent(ctx context.Context, event *events.RecordedJobEvent) *audit.RunSummary {
+	summary := &audit.RunSummary{
+		JobID:      event.JobID,
+		SiteURL:    event.SiteURL,
+		AuditRunID: event.AuditRunID,
+		Failed:     event.Type == events.JobEventFailed || event.JobStatus == jobs.JobStatusFailed,
+		Error:      event.Error,
+		FinishedAt: event.OccurredAt.UTC(),
+	}
+	if summary.Failed && summary.Error == "" {
+		summary.Error = "audit failed"
+	}
+	if event.OccurredAt.IsZero() {
+		summary.FinishedAt = s.now()
+	}
+	if summary.AuditRunID == 0 {
+		return summary
+	}
+
+	queries := s.db.ReadQueries()
+	auditRun, err := queries.GetAuditRun(ctx, summary.AuditRunID)
+	if err != nil {
+		s.logger.Warn("Announcing job without its audit run", "job_id", event.JobID, "audit_run_id", summary.AuditRunID, "error", err)
+		return summary
+	}
+	summary.SiteID = auditRun.SiteID
+	if site, err := queries.GetSiteByID(ctx, auditRun.SiteID); err == nil {
+		summary.SiteURL = site.SiteUrl
+		summary.SiteTitle = site.Title.String
+	}
+
+	if err := s.countRun(ctx, summary); err != nil {
+		s.logger.Warn("Announcing audit run without its counts", "job_id", event.JobID, "audit_run_id", summary.AuditRunID, "error", err)
+	}
+	return summary
+}
+
+// countRun counts the lists, unique items and sharing links of a summary's run and ranks its riskiest lists
+func (s *RunNotificationService) countRun(ctx context.Context, summary *audit.RunSummary) error {
+	services, err := s.serviceFactory.CreateForAuditRun(ctx, summary.SiteID, strconv.FormatInt(summary.AuditRunID, 10))
+	if err != nil {
+		return err
+	}
+	lists, err := services.SiteContentService.GetListsForSite(ctx, summary.SiteID)
+	if err != nil {
+		return err
+	}
+
+	summary.RiskLevel = "Low"
+	risks := make([]*audit.RunRisk, 0, len(lists))
+	for _, list := range lists {
+		summary.Lists++
+		summary.UniqueItems += list.UniqueItemCount
+		summary.SharingLinks += list.SharingLinkCount
+		summary.ExternalPrincipals += list.ExternalPrincipalCount
+
+		analysis, err := services.PermissionService.AnalyzeListPermissions(ctx, summary.SiteID, list)
+		if err != nil {
+			return fmt.Errorf("failed to analyze list %s: %w", list.ID, err)
+		}
+		if riskLevelRank(analysis.PermissionRiskLevel) > riskLevelRank(summary.RiskLevel) {
+			summary.RiskLevel = analysis.PermissionRiskLevel
+		}
+		if analysis.PermissionRiskScore > 0 {
+			risks = append(risks, &audit.RunRisk{
+				ListID:       list.ID,
+				ListTitle:    list.Title,
+				ListURL:      list.URL,
+				RiskLevel:    analysis.PermissionRiskLevel,
+				RiskScore:    analysis.PermissionRiskScore,
+				UniqueItems:  list.UniqueItemCount,
+				SharingLinks: list.SharingLinkCount,
+			})
+		}
+	}
+
+	sort.SliceStable(risks, func(i, j int) bool {
+		return risks[i].RiskScore > risks[j].RiskScore
+	})
+	if len(risks) > audit.MaxRunSummaryRisks {
+		risks = risks[:audit.MaxRunSummaryRisks]
+	}
+	summary.TopRisks = risks
+	summary.Counted = true
+	return nil
+}
@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/events"
+	"spaudit/domain/jobs"
+)
+
+// recordingRunNotifier records the summaries it announces, failing with err.
+type recordingRunNotifier struct {
+	summaries []*audit.RunSummary
+	err       error
+}
+
+func (n *recordingRunNotifier) NotifyRunSummary(ctx context.Context, summary *audit.RunSummary) error {
+	n.summaries = append(n.summaries, summary)
+	return n.err
+}
+
+func TestRunNotificationService(t *testing.T) {
+	ctx := context.Background()
+	baselineService := newBaselineTestService(t)
+	_, err := baselineService.db.WriteDB().Exec(`UPDATE lists SET unique_item_count = 4, sharing_link_count = 3, external_principal_count = 1, item_count = 10 WHERE list_id = 'docs' AND audit_run_id = 2`)
+	require.NoError(t, err)
+
+	service := NewRunNotificationService(baselineService.db, baselineService.serviceFactory)
+	completedAt := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	job := &jobs.Job{ID: "job-2", Type: jobs.JobTypeSiteAudit, Status: jobs.JobStatusCompleted, CompletedAt: &completedAt}
+	job.SetAuditRunID(2)
+	completed := events.NewRecordedJobEvent(events.JobEventCompleted, job, "", "", completedAt)
+
+	assert.False(t, service.Enabled())
+	require.ErrorIs(t, service.NotifyJobEvent(ctx, "email", completed), ErrUnknownNotificationChannel)
+
+	failing := &recordingRunNotifier{err: errors.New("smtp unavailable")}
+	recording := &recordingRunNotifier{}
+	service.AddNotifier("email", failing)
+	service.AddNotifier("chat", recording)
+	assert.True(t, service.Enabled())
+	assert.Equal(t, []string{"email", "chat"}, service.Channels())
+
+	err = service.NotifyJobEvent(ctx, "email", completed)
+	assert.ErrorContains(t, err, "smtp unavailable")
+	assert.Empty(t, recording.summaries, "each channel is announced on its own")
+	require.NoError(t, service.NotifyJobEvent(ctx, "chat", completed))
+	require.Len(t, recording.summaries, 1)
+	assert.Len(t, failing.summaries, 1)
+
+	// The run's other events, and jobs other than site audits, are not announced
+	maintenance := &jobs.Job{ID: "job-3", Type: jobs.JobTypeDatabaseMaintenance, Status: jobs.JobStatusCompleted}
+	for _, event := range []*events.RecordedJobEvent{
+		events.NewRecordedJobEvent(events.JobEventSiteAuditCompleted, job, "", "", completedAt),
+		events.NewRecordedJobEvent(events.JobEventCancelled, job, "", "", completedAt),
+		events.NewRecordedJobEvent(events.JobEventCompleted, maintenance, "", "", completedAt),
+	} {
+		require.NoError(t, service.NotifyJobEvent(ctx, "chat", event))
+	}
+	require.Len(t, recording.summaries, 1)
+
+	summary := recording.summaries[0]
+	assert.Equal(t, int64(1), summary.SiteID)
+	assert.Equal(t, "https://contoso.sharepoint.com/sites/a", summary.SiteURL)
+	assert.Equal(t, "A", summary.SiteTitle)
+	assert.False(t, summary.Failed)
+	assert.Equal(t, completedAt, summary.FinishedAt)
+	require.True(t, summary.Counted)
+	assert.Equal(t, 2, summary.Lists)
+	assert.Equal(t, 4, summary.UniqueItems)
+	assert.Equal(t, 3, summary.SharingLinks)
+	assert.Equal(t, 1, summary.ExternalPrincipals)
+	require.NotEmpty(t, summary.TopRisks)
+	assert.Equal(t, "docs", summary.TopRisks[0].ListID)
+	assert.Equal(t, 4, summary.TopRisks[0].UniqueItems)
+	for i := 1; i < len(summary.TopRisks); i++ {
+		assert.GreaterOrEqual(t, summary.TopRisks[i-1].RiskScore, summary.TopRisks[i].RiskScore)
+	}
+
+	failed := &jobs.Job{ID: "job-9", Type: jobs.JobTypeSiteAudit, Status: jobs.JobStatusFailed, Context: jobs.AuditJobContext{SiteURL: "https://contoso.sharepoint.com/sites/b"}}
+	summary = service.SummarizeJobEvent(ctx, events.NewRecordedJobEvent(events.JobEventFailed, failed, "", "throttled", completedAt))
+	assert.True(t, summary.Failed)
+	assert.Equal(t, "throttled", summary.Error)
+	assert.Equal(t, "https://contoso.sharepoint.com/sites/b", summary.SiteURL)
+	assert.Zero(t, summary.AuditRunID)
+	assert.False(t, summary.Counted, "jobs failing before their run starts have nothing to count")
+	summary = service.SummarizeJobEvent(ctx, events.NewRecordedJobEvent(events.JobEventFailed, failed, "", "", completedAt))
+	assert.Equal(t, "audit failed", summary.Error)
+}
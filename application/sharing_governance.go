@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"spaudit/domain/sharepoint"
+)
+
+// SharingGovernanceChange is one sharing policy setting that differs between two audit runs.
+type SharingGovernanceChange struct {
+	Setting string // Field path, e.g. AnyoneLinkAbilities.CanGetEditLink.Enabled
+	Before  string // Empty when the earlier run did not capture the setting
+	After   string // Empty when the later run did not capture the setting
+}
+
+// SharingGovernanceData represents a site's sharing policy as captured by an audit run, and how it
+// changed since the most recent earlier audit run that captured it.
+type SharingGovernanceData struct {
+	Governance         *sharepoint.SharingGovernance
+	PreviousAuditRunID int64 // 0 when no earlier audit run captured the policy
+	Changes            []*SharingGovernanceChange
+}
+
+// GetSharingGovernance retrieves the site's sharing policy with its changes since the previous
+// audit run that captured it (audit-scoped).
+func (s *SiteContentService) GetSharingGovernance(ctx context.Context, siteID int64) (*SharingGovernanceData, error) {
+	governance, err := s.contentAggregate.GetSharingGovernanceForAuditRun(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, err
+	}
+	if governance == nil {
+		return nil, fmt.Errorf("audit run %d captured no sharing governance: %w", s.auditRunID, sql.ErrNoRows)
+	}
+
+	data := &SharingGovernanceData{Governance: governance, Changes: []*SharingGovernanceChange{}}
+
+	previousRunID, err := s.contentAggregate.GetPreviousSharingGovernanceAuditRun(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find previous audit run for sharing governance: %w", err)
+	}
+	if previousRunID == 0 {
+		return data, nil
+	}
+
+	previous, err := s.contentAggregate.GetSharingGovernanceForAuditRun(ctx, siteID, previousRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sharing governance for audit run %d: %w", previousRunID, err)
+	}
+
+	data.PreviousAuditRunID = previousRunID
+	data.Changes = DiffSharingGovernance(previous, governance)
+	return data, nil
+}
+
+// DiffSharingGovernance compares the sharing policy captured by two audit runs setting by setting.
+// Either side may be nil. Changes are ordered by setting.
+func DiffSharingGovernance(base, current *sharepoint.SharingGovernance) []*SharingGovernanceChange {
+	before := SharingGovernanceSettings(base)
+	after := SharingGovernanceSettings(current)
+
+	changes := []*SharingGovernanceChange{}
+	for setting, value := range before {
+		if after[setting] != value {
+			changes = append(changes, &SharingGovernanceChange{Setting: setting, Before: value, After: after[setting]})
+		}
+	}
+	for setting, value := range after {
+		if _, ok := before[setting]; !ok {
+			changes = append(changes, &SharingGovernanceChange{Setting: setting, After: value})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Setting < changes[j].Setting })
+	return changes
+}
+
+// SharingGovernanceSettings flattens a sharing policy into setting paths and their values. The ability
+// matrices have dozens of flags that SharePoint adds to over time, so they are walked by reflection
+// rather than listed. Run and site identifiers are not settings.
+func SharingGovernanceSettings(governance *sharepoint.SharingGovernance) map[string]string {
+	settings := make(map[string]string)
+	if governance == nil {
+		return settings
+	}
+	flattenGovernanceValue("", reflect.ValueOf(*governance), settings)
+	delete(settings, "SiteID")
+	delete(settings, "AuditRunID")
+	return settings
+}
+
+func flattenGovernanceValue(path string, value reflect.Value, settings map[string]string) {
+	switch value.Kind() {
+	case reflect.Pointer:
+		if !value.IsNil() {
+			flattenGovernanceValue(path, value.Elem(), settings)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			name := value.Type().Field(i).Name
+			if path != "" {
+				name = path + "." + name
+			}
+			flattenGovernanceValue(name, value.Field(i), settings)
+		}
+	case reflect.Slice:
+		elements := make([]string, value.Len())
+		for i := range elements {
+			elements[i] = fmt.Sprint(value.Index(i).Interface())
+		}
+		settings[path] = strings.Join(elements, ", ")
+	case reflect.Bool:
+		settings[path] = strconv.FormatBool(value.Bool())
+	default:
+		settings[path] = fmt.Sprint(value.Interface())
+	}
+}
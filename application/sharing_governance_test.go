@@ -0,0 +1,106 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/sharepoint"
+	"spaudit/test/helpers"
+)
+
+func TestDiffSharingGovernance(t *testing.T) {
+	base := &sharepoint.SharingGovernance{
+		SiteID:                  1,
+		AuditRunID:              4,
+		CanAddExternalPrincipal: true,
+		SiteIBSegmentIDs:        []string{"seg-1"},
+		SharingAbilities: &sharepoint.SharingAbilities{
+			AnyoneLinkAbilities: &sharepoint.SharingLinkAbilities{
+				CanGetEditLink: sharepoint.SharingAbilityStatus{Enabled: true},
+			},
+		},
+	}
+	current := &sharepoint.SharingGovernance{
+		SiteID:                  1,
+		AuditRunID:              7,
+		CanAddExternalPrincipal: false,
+		SiteIBSegmentIDs:        []string{"seg-1", "seg-2"},
+		SharingAbilities: &sharepoint.SharingAbilities{
+			AnyoneLinkAbilities: &sharepoint.SharingLinkAbilities{
+				CanGetEditLink: sharepoint.SharingAbilityStatus{Enabled: false, DisabledReason: 3},
+			},
+		},
+		RecipientLimits: &sharepoint.RecipientLimits{
+			ShareLink: &sharepoint.RecipientLimitsInfo{EmailOnly: 50},
+		},
+	}
+
+	changes := DiffSharingGovernance(base, current)
+
+	assert.Equal(t, []*SharingGovernanceChange{
+		{Setting: "CanAddExternalPrincipal", Before: "true", After: "false"},
+		{Setting: "RecipientLimits.ShareLink.AliasOnly", After: "0"},
+		{Setting: "RecipientLimits.ShareLink.EmailOnly", After: "50"},
+		{Setting: "RecipientLimits.ShareLink.MixedRecipients", After: "0"},
+		{Setting: "RecipientLimits.ShareLink.ObjectIdOnly", After: "0"},
+		{Setting: "SharingAbilities.AnyoneLinkAbilities.CanGetEditLink.DisabledReason", Before: "0", After: "3"},
+		{Setting: "SharingAbilities.AnyoneLinkAbilities.CanGetEditLink.Enabled", Before: "true", After: "false"},
+		{Setting: "SiteIBSegmentIDs", Before: "seg-1", After: "seg-1, seg-2"},
+	}, changes)
+	assert.Empty(t, DiffSharingGovernance(current, current), "run identifiers are not settings")
+}
+
+func TestSiteContentService_GetSharingGovernance(t *testing.T) {
+	t.Run("diffs against the previous run that captured governance", func(t *testing.T) {
+		mocks := helpers.NewMockRepositories()
+		ctx := context.Background()
+		previous := &sharepoint.SharingGovernance{SiteID: 1, AuditRunID: 4, AnyoneLinkTrackUsers: false}
+		current := &sharepoint.SharingGovernance{SiteID: 1, AuditRunID: 7, AnyoneLinkTrackUsers: true}
+		mocks.SiteContentAggregate.On("GetSharingGovernanceForAuditRun", ctx, int64(1), int64(7)).Return(current, nil)
+		mocks.SiteContentAggregate.On("GetPreviousSharingGovernanceAuditRun", ctx, int64(1), int64(7)).Return(int64(4), nil)
+		mocks.SiteContentAggregate.On("GetSharingGovernanceForAuditRun", ctx, int64(1), int64(4)).Return(previous, nil)
+
+		service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+		result, err := service.GetSharingGovernance(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Same(t, current, result.Governance)
+		assert.Equal(t, int64(4), result.PreviousAuditRunID)
+		assert.Equal(t, []*SharingGovernanceChange{{Setting: "AnyoneLinkTrackUsers", Before: "false", After: "true"}}, result.Changes)
+	})
+
+	t.Run("governance not captured by an earlier run", func(t *testing.T) {
+		mocks := helpers.NewMockRepositories()
+		ctx := context.Background()
+		mocks.SiteContentAggregate.On("GetSharingGovernanceForAuditRun", ctx, int64(1), int64(7)).Return(&sharepoint.SharingGovernance{}, nil)
+		mocks.SiteContentAggregate.On("GetPreviousSharingGovernanceAuditRun", ctx, int64(1), int64(7)).Return(int64(0), nil)
+
+		service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+		result, err := service.GetSharingGovernance(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), result.PreviousAuditRunID)
+		assert.Empty(t, result.Changes)
+		mocks.SiteContentAggregate.AssertNumberOfCalls(t, "GetSharingGovernanceForAuditRun", 1)
+	})
+
+	t.Run("run captured no governance", func(t *testing.T) {
+		mocks := helpers.NewMockRepositories()
+		ctx := context.Background()
+		mocks.SiteContentAggregate.On("GetSharingGovernanceForAuditRun", ctx, int64(1), int64(7)).Return(nil, nil)
+
+		service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+		_, err := service.GetSharingGovernance(ctx, 1)
+
+		assert.ErrorIs(t, err, sql.ErrNoRows)
+		mocks.SiteContentAggregate.AssertNotCalled(t, "GetPreviousSharingGovernanceAuditRun", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
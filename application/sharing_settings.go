@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+// SharingSettingsData is the default sharing behavior of every web and list in one audit run.
+type SharingSettingsData struct {
+	Sensitivity *audit.SiteSensitivity // Nil when the site is not tagged sensitive
+	Webs        []*WebSharingSettings  // Ordered by URL
+	Lists       []*ListSharingSettings // Ordered by web URL, then title
+	Flagged     int                    // Lists whose members can share on a site tagged sensitive
+}
+
+// WebSharingSettings is whether a web's members can share and where its access requests go.
+type WebSharingSettings struct {
+	Web             *sharepoint.Web
+	MembersCanShare *bool                         // Nil when the run did not capture it
+	AccessRequests  *sharepoint.WebAccessRequests // Nil when the run did not capture them
+}
+
+// ListSharingSettings is the default sharing behavior of a list, inherited from its web. Flagged is set
+// when the list's members can share and its site is tagged sensitive.
+type ListSharingSettings struct {
+	List            *sharepoint.List
+	WebURL          string // Empty when the run did not capture the list's web
+	MembersCanShare *bool
+	AccessRequests  *sharepoint.WebAccessRequests
+	Flagged         bool
+}
+
+// GetSharingSettings reports whether members can share and where access requests go for each web and
+// list of the run (audit-scoped), flagging lists whose members can share when the site is tagged
+// sensitive. sensitivity is nil for an untagged site.
+func (s *SiteContentService) GetSharingSettings(ctx context.Context, siteID int64, sensitivity *audit.SiteSensitivity) (*SharingSettingsData, error) {
+	webs, err := s.contentAggregate.GetWebsForSite(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webs: %w", err)
+	}
+	lists, err := s.contentAggregate.GetListsForSite(ctx, siteID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lists: %w", err)
+	}
+	return ReportSharingSettings(webs, lists, sensitivity), nil
+}
+
+// ReportSharingSettings reports the sharing settings of webs and of the lists in them. Lists follow their
+// web's settings; a list whose web was not captured has none.
+func ReportSharingSettings(webs []*sharepoint.Web, lists []*sharepoint.List, sensitivity *audit.SiteSensitivity) *SharingSettingsData {
+	data := &SharingSettingsData{
+		Sensitivity: sensitivity,
+		Webs:        make([]*WebSharingSettings, 0, len(webs)),
+		Lists:       make([]*ListSharingSettings, 0, len(lists)),
+	}
+
+	websByID := make(map[string]*sharepoint.Web, len(webs))
+	for _, web := range webs {
+		websByID[web.ID] = web
+		data.Webs = append(data.Webs, &WebSharingSettings{
+			Web:             web,
+			MembersCanShare: web.MembersCanShare,
+			AccessRequests:  web.AccessRequests,
+		})
+	}
+	sort.SliceStable(data.Webs, func(i, j int) bool {
+		return data.Webs[i].Web.URL < data.Webs[j].Web.URL
+	})
+
+	for _, list := range lists {
+		settings := &ListSharingSettings{List: list}
+		if web, ok := websByID[list.WebID]; ok {
+			settings.WebURL = web.URL
+			settings.MembersCanShare = web.MembersCanShare
+			settings.AccessRequests = web.AccessRequests
+		}
+		if sensitivity != nil && settings.MembersCanShare != nil && *settings.MembersCanShare {
+			settings.Flagged = true
+			data.Flagged++
+		}
+		data.Lists = append(data.Lists, settings)
+	}
+	sort.SliceStable(data.Lists, func(i, j int) bool {
+		if data.Lists[i].WebURL != data.Lists[j].WebURL {
+			return data.Lists[i].WebURL < data.Lists[j].WebURL
+		}
+		return data.Lists[i].List.Title < data.Lists[j].List.Title
+	})
+	return data
+}
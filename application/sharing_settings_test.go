@@ -0,0 +1,48 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+func TestReportSharingSettings(t *testing.T) {
+	canShare, cannotShare := true, false
+	toOwners := &sharepoint.WebAccessRequests{ToOwners: true}
+	webs := []*sharepoint.Web{
+		{ID: "team", URL: "https://contoso.sharepoint.com/sites/a/team", MembersCanShare: &cannotShare, AccessRequests: &sharepoint.WebAccessRequests{}},
+		{ID: "root", URL: "https://contoso.sharepoint.com/sites/a", MembersCanShare: &canShare, AccessRequests: toOwners},
+	}
+	lists := []*sharepoint.List{
+		{ID: "plans", WebID: "team", Title: "Plans"},
+		{ID: "docs", WebID: "root", Title: "Documents"},
+		{ID: "orphan", WebID: "gone", Title: "Archive"},
+		{ID: "assets", WebID: "root", Title: "Assets"},
+	}
+
+	data := ReportSharingSettings(webs, lists, nil)
+	require.Len(t, data.Webs, 2)
+	assert.Equal(t, "root", data.Webs[0].Web.ID)
+	assert.True(t, data.Webs[0].AccessRequests.Enabled())
+	assert.False(t, data.Webs[1].AccessRequests.Enabled())
+	assert.Zero(t, data.Flagged, "lists are only flagged on sites tagged sensitive")
+
+	var order []string
+	for _, list := range data.Lists {
+		order = append(order, list.List.ID)
+		assert.False(t, list.Flagged)
+	}
+	assert.Equal(t, []string{"orphan", "assets", "docs", "plans"}, order)
+	assert.Nil(t, data.Lists[0].MembersCanShare, "lists of webs not captured have no settings")
+	assert.Same(t, toOwners, data.Lists[1].AccessRequests)
+
+	data = ReportSharingSettings(webs, lists, &audit.SiteSensitivity{SiteID: 1, Reason: "Board papers"})
+	assert.Equal(t, 2, data.Flagged)
+	assert.True(t, data.Lists[1].Flagged)
+	assert.True(t, data.Lists[2].Flagged)
+	assert.False(t, data.Lists[3].Flagged, "lists whose members cannot share are not flagged")
+}
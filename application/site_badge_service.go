@@ -0,0 +1,94 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"spaudit/domain/audit"
+	"spaudit/logging"
+)
+
+// ErrBadgesDisabled is returned for badges while no signing key is configured.
+var ErrBadgesDisabled = errors.New("site badges are disabled; set BADGE_SIGNING_KEY to enable them")
+
+// SiteBadgeService reports a site's current risk level and last audit date as a small badge other
+// portals embed, such as intranet site directories, so site owners see their score where they
+// already are. Badge URLs carry a signature only this service can issue, so a badge reveals the
+// risk of no site it was not issued for.
+type SiteBadgeService struct {
+	siteBrowsing   *SiteBrowsingService
+	serviceFactory AuditRunScopedServiceFactory
+	auditRuns      AuditRunReader
+	signingKey     []byte
+	logger         *logging.Logger
+}
+
+// NewSiteBadgeService creates a site badge service signing badge URLs with signingKey. An empty key
+// disables badges.
+func NewSiteBadgeService(siteBrowsing *SiteBrowsingService, serviceFactory AuditRunScopedServiceFactory, auditRuns AuditRunReader, signingKey string) *SiteBadgeService {
+	return &SiteBadgeService{
+		siteBrowsing:   siteBrowsing,
+		serviceFactory: serviceFactory,
+		auditRuns:      auditRuns,
+		signingKey:     []byte(signingKey),
+		logger:         logging.Default().WithComponent("site_badge_service"),
+	}
+}
+
+// SignBadge returns the signature of a site's badge URL.
+func (s *SiteBadgeService) SignBadge(ctx context.Context, siteID int64) (string, error) {
+	if len(s.signingKey) == 0 {
+		return "", ErrBadgesDisabled
+	}
+	if _, err := s.siteBrowsing.GetSiteWithMetadata(ctx, siteID); err != nil {
+		return "", err
+	}
+	return audit.SignSiteBadge(s.signingKey, siteID), nil
+}
+
+// GetBadge returns the badge of a site once its signature is verified, with the risk of the site's
+// latest audit run. A site never audited has a badge without a risk level.
+func (s *SiteBadgeService) GetBadge(ctx context.Context, siteID int64, signature string) (*audit.SiteBadge, error) {
+	if len(s.signingKey) == 0 {
+		return nil, ErrBadgesDisabled
+	}
+	if err := audit.VerifySiteBadge(s.signingKey, siteID, signature); err != nil {
+		return nil, err
+	}
+	site, err := s.siteBrowsing.GetSiteWithMetadata(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	badge := &audit.SiteBadge{SiteID: siteID, SiteURL: site.Site.URL, Title: site.Site.Title}
+
+	services, err := s.serviceFactory.CreateForAuditRun(ctx, siteID, audit.RunAliasLatest)
+	if errors.Is(err, sql.ErrNoRows) {
+		return badge, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.auditRuns.GetAuditRun(ctx, siteID, services.AuditRunID)
+	if err != nil {
+		return nil, err
+	}
+	lists, err := services.SiteContentService.GetListsForSite(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+	risk, err := services.PermissionService.SummarizeSiteRisk(ctx, siteID, lists)
+	if err != nil {
+		return nil, err
+	}
+
+	badge.AuditRunID = services.AuditRunID
+	badge.RiskLevel = risk.RiskLevel
+	badge.RiskScore = risk.HighestRiskScore
+	auditedAt := run.StartedAt
+	if run.CompletedAt != nil {
+		auditedAt = *run.CompletedAt
+	}
+	badge.LastAuditAt = &auditedAt
+	return badge, nil
+}
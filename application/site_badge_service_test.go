@@ -0,0 +1,47 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+)
+
+func TestSiteBadgeService_GetBadge(t *testing.T) {
+	ctx := context.Background()
+	orgUnits := newOrgUnitTestService(t)
+	service := NewSiteBadgeService(orgUnits.siteBrowsing, orgUnits.serviceFactory, orgUnits.auditRuns, "badge-key")
+
+	signature, err := service.SignBadge(ctx, 1)
+	require.NoError(t, err)
+	_, err = service.SignBadge(ctx, 99)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	badge, err := service.GetBadge(ctx, 1, signature)
+	require.NoError(t, err)
+	assert.Equal(t, "https://contoso.sharepoint.com/sites/site1", badge.SiteURL)
+	assert.Equal(t, int64(1), badge.AuditRunID)
+	assert.NotEmpty(t, badge.RiskLevel)
+	require.NotNil(t, badge.LastAuditAt)
+	assert.Equal(t, orgUnits.now().AddDate(0, 0, -1), badge.LastAuditAt.UTC())
+
+	_, err = service.GetBadge(ctx, 2, signature)
+	assert.ErrorIs(t, err, audit.ErrInvalidBadgeSignature, "a signature opens only its own site's badge")
+
+	signature, err = service.SignBadge(ctx, 4)
+	require.NoError(t, err)
+	badge, err = service.GetBadge(ctx, 4, signature)
+	require.NoError(t, err)
+	assert.False(t, badge.IsAudited())
+	assert.Empty(t, badge.RiskLevel)
+
+	disabled := NewSiteBadgeService(orgUnits.siteBrowsing, orgUnits.serviceFactory, orgUnits.auditRuns, "")
+	_, err = disabled.SignBadge(ctx, 1)
+	assert.ErrorIs(t, err, ErrBadgesDisabled)
+	_, err = disabled.GetBadge(ctx, 1, signature)
+	assert.ErrorIs(t, err, ErrBadgesDisabled)
+}
@@ -3,18 +3,23 @@ package application
 import (
 	"context"
 
+	"spaudit/domain/audit"
 	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
 )
 
 // SiteBrowsingService handles site browsing and selection.
 type SiteBrowsingService struct {
 	contentAggregate contracts.SiteContentAggregateRepository
+	latestRunPolicy  audit.LatestRunPolicy
 }
 
 // NewSiteBrowsingService creates a new site browsing service.
-func NewSiteBrowsingService(contentAggregate contracts.SiteContentAggregateRepository) *SiteBrowsingService {
+// The latest run policy decides which run the dashboard summarises for each site.
+func NewSiteBrowsingService(contentAggregate contracts.SiteContentAggregateRepository, latestRunPolicy audit.LatestRunPolicy) *SiteBrowsingService {
 	return &SiteBrowsingService{
 		contentAggregate: contentAggregate,
+		latestRunPolicy:  latestRunPolicy,
 	}
 }
 
@@ -23,11 +28,22 @@ func (s *SiteBrowsingService) GetAllSitesWithMetadata(ctx context.Context) ([]*c
 	return s.contentAggregate.GetAllSitesWithMetadata(ctx)
 }
 
+// GetAllSitesWithLatestRunMetadata retrieves all sites with metadata for each site's latest audit run,
+// resolved with the same policy as the "latest" run alias. A label only considers runs tagged with it.
+func (s *SiteBrowsingService) GetAllSitesWithLatestRunMetadata(ctx context.Context, label string) ([]*contracts.SiteWithMetadata, error) {
+	return s.contentAggregate.GetAllSitesWithLatestRunMetadata(ctx, s.latestRunPolicy == audit.LatestRunCompleted, label)
+}
+
 // GetSiteWithMetadata retrieves a site with metadata.
 func (s *SiteBrowsingService) GetSiteWithMetadata(ctx context.Context, siteID int64) (*contracts.SiteWithMetadata, error) {
 	return s.contentAggregate.GetSiteWithMetadata(ctx, siteID)
 }
 
+// GetSiteByURL retrieves an audited site by its exact URL.
+func (s *SiteBrowsingService) GetSiteByURL(ctx context.Context, siteURL string) (*sharepoint.Site, error) {
+	return s.contentAggregate.GetSiteByURL(ctx, siteURL)
+}
+
 // SearchSites filters sites by search query.
 func (s *SiteBrowsingService) SearchSites(ctx context.Context, searchQuery string) ([]*contracts.SiteWithMetadata, error) {
 	return s.contentAggregate.SearchSites(ctx, searchQuery)
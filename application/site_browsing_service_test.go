@@ -0,0 +1,44 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/test/helpers"
+)
+
+func TestSiteBrowsingService_GetAllSitesWithLatestRunMetadata_AppliesPolicy(t *testing.T) {
+	tests := []struct {
+		name            string
+		policy          audit.LatestRunPolicy
+		preferCompleted bool
+	}{
+		{name: "any status", policy: audit.LatestRunAnyStatus, preferCompleted: false},
+		{name: "completed", policy: audit.LatestRunCompleted, preferCompleted: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mocks := helpers.NewMockRepositories()
+			testData := helpers.NewTestData()
+			expected := []*contracts.SiteWithMetadata{{Site: testData.SimpleSite(1, "Test Site"), TotalLists: 2}}
+			mocks.SiteContentAggregate.On("GetAllSitesWithLatestRunMetadata", context.Background(), tt.preferCompleted, "").Return(expected, nil)
+
+			service := NewSiteBrowsingService(mocks.SiteContentAggregate, tt.policy)
+
+			// Act
+			result, err := service.GetAllSitesWithLatestRunMetadata(context.Background(), "")
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, expected, result)
+			mocks.SiteContentAggregate.AssertExpectations(t)
+		})
+	}
+}
@@ -2,6 +2,10 @@ package application
 
 import (
 	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"spaudit/domain/contracts"
@@ -18,12 +22,114 @@ type SiteWithListsData struct {
 	LastAuditDate    *time.Time
 	LastAuditDaysAgo int
 	AuditRunID       int64
+
+	// Lists whose unique-permission density exceeds the configured threshold
+	DenseLists             int
+	UniqueDensityThreshold float64
+}
+
+// WebAssignmentsData represents a web and its role assignments with root cause analysis.
+type WebAssignmentsData struct {
+	Web         *sharepoint.Web
+	Assignments []*sharepoint.ResolvedAssignment
+}
+
+// SharingLinkMemberChangesData represents a sharing link's members and how they changed
+// since the most recent earlier audit run that captured the same link.
+type SharingLinkMemberChangesData struct {
+	Members            []*sharepoint.Principal
+	PreviousAuditRunID int64 // 0 when no earlier audit run captured the link
+	Added              []*sharepoint.Principal
+	Removed            []*sharepoint.Principal
+}
+
+// ListSnapshotData represents the complete audited state of a list within an audit run.
+// Collections are sorted by their natural keys so repeated snapshots of the same run are identical.
+type ListSnapshotData struct {
+	List              *sharepoint.List
+	AuditRunID        int64
+	Assignments       []*sharepoint.ResolvedAssignment
+	Items             []*sharepoint.Item
+	ItemAssignments   map[string][]*sharepoint.Assignment // Keyed by item GUID, items with unique permissions only
+	SharingLinks      []*sharepoint.SharingLink           // Members populated
+	SensitivityLabels []*sharepoint.ItemSensitivityLabel
+}
+
+// ListItemsPageData represents one page of filtered list items.
+type ListItemsPageData struct {
+	Items      []*sharepoint.Item
+	Page       int // 1-based, clamped to the last page
+	PageSize   int
+	TotalCount int64 // Items matching the filter across all pages
+}
+
+// ItemDetailsData represents an item with its full metadata for the detail view.
+type ItemDetailsData struct {
+	Item             *sharepoint.Item
+	List             *sharepoint.List
+	SensitivityLabel *sharepoint.ItemSensitivityLabel // nil when the item has no label
+	Attachments      []*sharepoint.ItemAttachment     // Files attached to a list item, which follow its permissions
 }
 
+// Access path types explaining how a principal reaches an item.
+const (
+	AccessPathDirect      = "direct"      // Assigned to the principal on the item itself
+	AccessPathGroup       = "group"       // Assigned on the item to a group; members gain access through membership
+	AccessPathLink        = "link"        // Granted by a sharing link on the item or an ancestor folder
+	AccessPathInheritance = "inheritance" // Assigned on the folder, list or web the item inherits from
+)
+
+// Permission source types: the securable object whose assignments apply to an item.
+const (
+	PermissionSourceItem   = "item"
+	PermissionSourceFolder = "folder"
+	PermissionSourceList   = "list"
+	PermissionSourceWeb    = "web"
+)
+
+// PermissionSource identifies the object whose role assignments apply to an item.
+type PermissionSource struct {
+	Type       string // One of the PermissionSource constants
+	ObjectType string // Role assignment object type (item, list or web)
+	ObjectKey  string
+	Title      string
+	URL        string
+}
+
+// AccessPath is one way a principal can access an item.
+type AccessPath struct {
+	Type        string                  // One of the AccessPath constants
+	Role        string                  // Role definition name, or the link's access level for link paths
+	Source      *PermissionSource       // Object carrying the assignment or sharing link
+	SharingLink *sharepoint.SharingLink // Link paths only
+	RootCauses  []sharepoint.RootCause  // Assignment paths only
+}
+
+// PrincipalAccess lists every path by which a principal can access an item.
+type PrincipalAccess struct {
+	Principal *sharepoint.Principal
+	Paths     []*AccessPath
+}
+
+// ItemAccessExplanationData explains who can access an item and why (audit-scoped).
+type ItemAccessExplanationData struct {
+	Item             *sharepoint.Item
+	List             *sharepoint.List
+	AuditRunID       int64
+	PermissionSource *PermissionSource
+	Principals       []*PrincipalAccess        // Sorted by principal ID
+	SharingLinks     []*sharepoint.SharingLink // Links on the item or an ancestor folder, members populated
+}
+
+// snapshotItemPageSize is the page size used when walking all items or assignments of a list for a snapshot.
+const snapshotItemPageSize = 500
+
 // SiteContentService handles site content operations.
 type SiteContentService struct {
-	contentAggregate contracts.SiteContentAggregateRepository
-	auditRunID       int64 // For audit-scoped operations
+	contentAggregate       contracts.SiteContentAggregateRepository
+	auditRunID             int64   // For audit-scoped operations
+	uniqueDensityThreshold float64 // Density above which a list is flagged
+	maxRows                int     // Rows a snapshot or comparison may load, 0 for no cap
 }
 
 // NewSiteContentService creates a new site content service.
@@ -47,11 +153,28 @@ func newSiteContentService(
 	auditRunID int64,
 ) *SiteContentService {
 	return &SiteContentService{
-		contentAggregate: contentAggregate,
-		auditRunID:       auditRunID,
+		contentAggregate:       contentAggregate,
+		auditRunID:             auditRunID,
+		uniqueDensityThreshold: sharepoint.DefaultUniqueDensityThreshold,
+		maxRows:                sharepoint.DefaultMaxAnalysisRows,
 	}
 }
 
+// SetUniqueDensityThreshold sets the unique-permission density threshold used to flag lists.
+func (s *SiteContentService) SetUniqueDensityThreshold(threshold float64) {
+	s.uniqueDensityThreshold = threshold
+}
+
+// SetMaxAnalysisRows caps the rows a list snapshot or comparison loads; 0 lifts the cap.
+func (s *SiteContentService) SetMaxAnalysisRows(maxRows int) {
+	s.maxRows = maxRows
+}
+
+// UniqueDensityThreshold returns the unique-permission density threshold used to flag lists.
+func (s *SiteContentService) UniqueDensityThreshold() float64 {
+	return s.uniqueDensityThreshold
+}
+
 // GetSiteWithLists retrieves a site and its lists with statistics.
 func (s *SiteContentService) GetSiteWithLists(ctx context.Context, siteID int64) (*SiteWithListsData, error) {
 	// Get site with metadata from aggregate repository
@@ -76,11 +199,15 @@ func (s *SiteContentService) GetSiteWithLists(ctx context.Context, siteID int64)
 	// Calculate business statistics from list data
 	totalItems := int64(0)
 	listsWithUnique := 0
+	denseLists := 0
 
 	for _, list := range lists {
 		if list.HasUnique {
 			listsWithUnique++
 		}
+		if list.ExceedsUniqueDensity(s.uniqueDensityThreshold) {
+			denseLists++
+		}
 		totalItems += int64(list.ItemCount)
 	}
 
@@ -98,6 +225,9 @@ func (s *SiteContentService) GetSiteWithLists(ctx context.Context, siteID int64)
 		TotalItems:       totalItems,
 		LastAuditDate:    lastAuditDate,
 		LastAuditDaysAgo: lastAuditDaysAgo,
+
+		DenseLists:             denseLists,
+		UniqueDensityThreshold: s.uniqueDensityThreshold,
 	}, nil
 }
 
@@ -112,8 +242,89 @@ func (s *SiteContentService) GetListsForSite(ctx context.Context, siteID int64)
 }
 
 // GetListAssignmentsWithRootCause retrieves resolved assignments with root cause analysis for a list (audit-scoped).
+// Sharing link group assignments are unwrapped to the link and its members, and SharePoint group
+// assignments expanded to the group's members.
 func (s *SiteContentService) GetListAssignmentsWithRootCause(ctx context.Context, siteID int64, listID string) ([]*sharepoint.ResolvedAssignment, error) {
-	return s.contentAggregate.GetListAssignmentsWithRootCause(ctx, siteID, s.auditRunID, listID)
+	assignments, err := s.contentAggregate.GetListAssignmentsWithRootCause(ctx, siteID, s.auditRunID, listID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.unwrapSharingLinks(ctx, siteID, assignments); err != nil {
+		return nil, err
+	}
+	if err := s.expandSiteGroups(ctx, siteID, assignments); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// GetAssignmentsWithRootCause retrieves resolved assignments with root cause analysis for any object (audit-scoped).
+// Sharing link group assignments are unwrapped to the link and its members, and SharePoint group
+// assignments expanded to the group's members.
+func (s *SiteContentService) GetAssignmentsWithRootCause(ctx context.Context, siteID int64, objectType, objectKey string) ([]*sharepoint.ResolvedAssignment, error) {
+	assignments, err := s.contentAggregate.GetResolvedAssignmentsForObject(ctx, siteID, s.auditRunID, objectType, objectKey)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.unwrapSharingLinks(ctx, siteID, assignments); err != nil {
+		return nil, err
+	}
+	if err := s.expandSiteGroups(ctx, siteID, assignments); err != nil {
+		return nil, err
+	}
+	return assignments, nil
+}
+
+// unwrapSharingLinks attaches the sharing link behind each "SharingLinks.*" group assignment,
+// with its members, so views can show who the link grants access to without a trip to the Links tab.
+// Links the audit run did not capture are left unwrapped.
+func (s *SiteContentService) unwrapSharingLinks(ctx context.Context, siteID int64, assignments []*sharepoint.ResolvedAssignment) error {
+	sharingService := sharepoint.NewSharingService()
+	for _, resolved := range assignments {
+		principal := resolved.Assignment.Principal
+		if principal == nil || !principal.IsSharingLinkPrincipal() {
+			continue
+		}
+		info := sharingService.ParseSharingLink(principal.LoginName)
+		if !info.IsValid {
+			continue
+		}
+
+		link, err := s.contentAggregate.GetSharingLinkForAuditRun(ctx, siteID, s.auditRunID, info.SharingID)
+		if err != nil {
+			return fmt.Errorf("failed to get sharing link %s: %w", info.SharingID, err)
+		}
+		if link == nil {
+			continue
+		}
+
+		members, err := s.contentAggregate.GetSharingLinkMembersForAuditRun(ctx, siteID, s.auditRunID, link.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get members of sharing link %s: %w", link.ID, err)
+		}
+		link.Members = members
+		resolved.SharingLink = link
+	}
+	return nil
+}
+
+// GetWebAssignmentsWithRootCause retrieves every web in the site with its resolved assignments (audit-scoped).
+func (s *SiteContentService) GetWebAssignmentsWithRootCause(ctx context.Context, siteID int64) ([]*WebAssignmentsData, error) {
+	webs, err := s.contentAggregate.GetWebsForSite(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*WebAssignmentsData, 0, len(webs))
+	for _, web := range webs {
+		assignments, err := s.GetAssignmentsWithRootCause(ctx, siteID, sharepoint.ObjectTypeWeb, web.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assignments for web %s: %w", web.ID, err)
+		}
+		result = append(result, &WebAssignmentsData{Web: web, Assignments: assignments})
+	}
+
+	return result, nil
 }
 
 // GetListItems retrieves items with unique permissions for a list with pagination.
@@ -121,6 +332,228 @@ func (s *SiteContentService) GetListItems(ctx context.Context, siteID int64, lis
 	return s.contentAggregate.GetListItems(ctx, siteID, listID, offset, limit)
 }
 
+// GetListItemsPage retrieves one page of list items matching the filter (audit-scoped).
+// Pages past the end are clamped to the last page so stale links still show results.
+func (s *SiteContentService) GetListItemsPage(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter, page, pageSize int) (*ListItemsPageData, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("page size must be positive, got %d", pageSize)
+	}
+	if filter.AccessibleBy != 0 {
+		return s.getAccessibleListItemsPage(ctx, siteID, listID, filter, page, pageSize)
+	}
+
+	total, err := s.contentAggregate.CountFilteredListItems(ctx, siteID, listID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page = clampPage(page, pageSize, total)
+	items, err := s.contentAggregate.GetFilteredListItems(ctx, siteID, listID, filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListItemsPageData{
+		Items:      items,
+		Page:       page,
+		PageSize:   pageSize,
+		TotalCount: total,
+	}, nil
+}
+
+// GetItemDetails retrieves an item with its sensitivity label and attachments (audit-scoped).
+func (s *SiteContentService) GetItemDetails(ctx context.Context, siteID int64, itemGUID string) (*ItemDetailsData, error) {
+	item, err := s.contentAggregate.GetItemByGUID(ctx, siteID, itemGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := s.contentAggregate.GetListByID(ctx, siteID, item.ListID)
+	if err != nil {
+		return nil, err
+	}
+
+	label, err := s.contentAggregate.GetItemSensitivityLabel(ctx, siteID, itemGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.contentAggregate.GetItemAttachments(ctx, siteID, s.auditRunID, itemGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ItemDetailsData{
+		Item:             item,
+		List:             list,
+		SensitivityLabel: label,
+		Attachments:      attachments,
+	}, nil
+}
+
+// ExplainItemAccess explains every path by which each principal can access an item (audit-scoped).
+// Assignments come from the item when it has unique permissions, otherwise from the nearest
+// ancestor folder, list or web with unique permissions, and carry the same root cause analysis
+// as list assignments. Sharing links on the item or an ancestor folder add a path for each member.
+func (s *SiteContentService) ExplainItemAccess(ctx context.Context, siteID int64, listID, itemGUID string) (*ItemAccessExplanationData, error) {
+	item, err := s.contentAggregate.GetItemByGUID(ctx, siteID, itemGUID)
+	if err != nil {
+		return nil, err
+	}
+	if item.ListID != listID {
+		return nil, fmt.Errorf("item %s is not in list %s: %w", itemGUID, listID, sql.ErrNoRows)
+	}
+
+	list, err := s.contentAggregate.GetListByID(ctx, siteID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	ancestors, err := s.uniqueAncestorFolders(ctx, siteID, item)
+	if err != nil {
+		return nil, err
+	}
+
+	source := itemPermissionSource(item, ancestors, list)
+	assignments, err := s.contentAggregate.GetResolvedAssignmentsForObject(ctx, siteID, s.auditRunID, source.ObjectType, source.ObjectKey)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(assignments, func(i, j int) bool {
+		return lessAssignment(assignments[i].Assignment, assignments[j].Assignment)
+	})
+
+	explanation := &ItemAccessExplanationData{
+		Item:             item,
+		List:             list,
+		AuditRunID:       s.auditRunID,
+		PermissionSource: source,
+	}
+	principals := make(map[int64]*PrincipalAccess)
+	addPath := func(principal *sharepoint.Principal, path *AccessPath) {
+		access, exists := principals[principal.ID]
+		if !exists {
+			access = &PrincipalAccess{Principal: principal}
+			principals[principal.ID] = access
+			explanation.Principals = append(explanation.Principals, access)
+		}
+		access.Paths = append(access.Paths, path)
+	}
+
+	for _, resolved := range assignments {
+		assignment := resolved.Assignment
+		addPath(assignment.Principal, &AccessPath{
+			Type:       assignmentPathType(assignment.Principal, source),
+			Role:       assignment.RoleDefinition.Name,
+			Source:     source,
+			RootCauses: resolved.RootCauses,
+		})
+	}
+
+	links, err := s.contentAggregate.GetListSharingLinks(ctx, siteID, listID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(links, func(i, j int) bool { return links[i].ID < links[j].ID })
+
+	// A link on a folder grants access to everything beneath it
+	linkSources := map[string]*PermissionSource{item.GUID: objectPermissionSource(item, PermissionSourceItem)}
+	for _, folder := range ancestors {
+		linkSources[folder.GUID] = objectPermissionSource(folder, PermissionSourceFolder)
+	}
+	for _, link := range links {
+		linkSource, ok := linkSources[link.ItemGUID]
+		if !ok {
+			if linkSource, ok = linkSources[link.FileFolderUniqueID]; !ok {
+				continue
+			}
+		}
+
+		members, err := s.contentAggregate.GetSharingLinkMembers(ctx, siteID, link.ID)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+		link.Members = members
+		explanation.SharingLinks = append(explanation.SharingLinks, link)
+
+		for _, member := range members {
+			addPath(member, &AccessPath{
+				Type:        AccessPathLink,
+				Role:        sharingLinkRole(link),
+				Source:      linkSource,
+				SharingLink: link,
+			})
+		}
+	}
+
+	sort.SliceStable(explanation.Principals, func(i, j int) bool {
+		return explanation.Principals[i].Principal.ID < explanation.Principals[j].Principal.ID
+	})
+
+	return explanation, nil
+}
+
+// uniqueAncestorFolders returns the folders above an item that have unique permissions, nearest first.
+// Folder hierarchy is not stored, so ancestry is derived from URLs.
+func (s *SiteContentService) uniqueAncestorFolders(ctx context.Context, siteID int64, item *sharepoint.Item) ([]*sharepoint.Item, error) {
+	folders, err := s.allFilteredListItems(ctx, siteID, item.ListID, contracts.ItemFilter{UniqueOnly: true, Kind: contracts.ItemKindFolder})
+	if err != nil {
+		return nil, err
+	}
+	return ancestorFolders(item, folders), nil
+}
+
+// ancestorFolders returns the folders that contain an item, nearest first.
+func ancestorFolders(item *sharepoint.Item, folders []*sharepoint.Item) []*sharepoint.Item {
+	var ancestors []*sharepoint.Item
+	for _, folder := range folders {
+		if folder.GUID != item.GUID && folder.URL != "" && strings.HasPrefix(item.URL, strings.TrimRight(folder.URL, "/")+"/") {
+			ancestors = append(ancestors, folder)
+		}
+	}
+	sort.SliceStable(ancestors, func(i, j int) bool { return len(ancestors[i].URL) > len(ancestors[j].URL) })
+	return ancestors
+}
+
+// allFilteredListItems walks every page of list items matching the filter.
+func (s *SiteContentService) allFilteredListItems(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter) ([]*sharepoint.Item, error) {
+	var items []*sharepoint.Item
+	for offset := 0; ; offset += snapshotItemPageSize {
+		page, err := s.contentAggregate.GetFilteredListItems(ctx, siteID, listID, filter, offset, snapshotItemPageSize)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if err := s.checkRowCap(len(items), "items of list "+listID); err != nil {
+			return nil, err
+		}
+		if len(page) < snapshotItemPageSize {
+			return items, nil
+		}
+	}
+}
+
+// checkRowCap fails once loaded rows pass the cap, before a large run exhausts memory.
+func (s *SiteContentService) checkRowCap(loaded int, what string) error {
+	if s.maxRows > 0 && loaded > s.maxRows {
+		return fmt.Errorf("%s exceed %d rows: %w", what, s.maxRows, contracts.ErrRowCapExceeded)
+	}
+	return nil
+}
+
+// clampPage keeps a 1-based page within the pages needed for total results.
+func clampPage(page, pageSize int, total int64) int {
+	lastPage := int((total + int64(pageSize) - 1) / int64(pageSize))
+	if page > lastPage {
+		page = lastPage
+	}
+	if page < 1 {
+		page = 1
+	}
+	return page
+}
+
 // GetListSharingLinks retrieves sharing links for a list.
 func (s *SiteContentService) GetListSharingLinks(ctx context.Context, siteID int64, listID string) ([]*sharepoint.SharingLink, error) {
 	return s.contentAggregate.GetListSharingLinks(ctx, siteID, listID)
@@ -131,6 +564,18 @@ func (s *SiteContentService) GetListSharingLinksWithItemData(ctx context.Context
 	return s.contentAggregate.GetListSharingLinksWithItemData(ctx, siteID, listID)
 }
 
+// GetAnonymousSharingLinks retrieves the active anonymous sharing links on items in any of the site's
+// lists, without having to open each list (audit-scoped).
+func (s *SiteContentService) GetAnonymousSharingLinks(ctx context.Context, siteID int64) ([]*sharepoint.AnonymousSharingLink, error) {
+	return s.contentAggregate.GetAnonymousSharingLinksForAuditRun(ctx, siteID, s.auditRunID)
+}
+
+// GetLinkCreators retrieves who created the active sharing links in the site, those who exposed the
+// most links to anyone or to external users first (audit-scoped).
+func (s *SiteContentService) GetLinkCreators(ctx context.Context, siteID int64) ([]*sharepoint.LinkCreator, error) {
+	return s.contentAggregate.GetLinkCreatorsForAuditRun(ctx, siteID, s.auditRunID)
+}
+
 // GetAssignmentsForObject retrieves assignments for any object type (audit-scoped).
 func (s *SiteContentService) GetAssignmentsForObject(ctx context.Context, siteID int64, objectType, objectKey string) ([]*sharepoint.Assignment, error) {
 	return s.contentAggregate.GetAssignmentsForObject(ctx, siteID, s.auditRunID, objectType, objectKey)
@@ -140,3 +585,228 @@ func (s *SiteContentService) GetAssignmentsForObject(ctx context.Context, siteID
 func (s *SiteContentService) GetSharingLinkMembers(ctx context.Context, siteID int64, linkID string) ([]*sharepoint.Principal, error) {
 	return s.contentAggregate.GetSharingLinkMembers(ctx, siteID, linkID)
 }
+
+// GetSharingLinkMemberChanges retrieves a sharing link's members with additions and removals
+// since the previous audit run that captured the link (audit-scoped).
+func (s *SiteContentService) GetSharingLinkMemberChanges(ctx context.Context, siteID int64, linkID string) (*SharingLinkMemberChangesData, error) {
+	members, err := s.contentAggregate.GetSharingLinkMembers(ctx, siteID, linkID)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &SharingLinkMemberChangesData{Members: members}
+
+	previousRunID, err := s.contentAggregate.GetPreviousSharingLinkAuditRun(ctx, siteID, s.auditRunID, linkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find previous audit run for sharing link %s: %w", linkID, err)
+	}
+	if previousRunID == 0 {
+		return data, nil
+	}
+
+	previousMembers, err := s.contentAggregate.GetSharingLinkMembersForAuditRun(ctx, siteID, previousRunID, linkID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sharing link members for audit run %d: %w", previousRunID, err)
+	}
+
+	data.PreviousAuditRunID = previousRunID
+	data.Added, data.Removed = diffPrincipals(previousMembers, members)
+	return data, nil
+}
+
+// GetListSnapshot assembles a canonical snapshot of a list, its items, assignments, sharing links and sensitivity labels (audit-scoped).
+func (s *SiteContentService) GetListSnapshot(ctx context.Context, siteID int64, listID string) (*ListSnapshotData, error) {
+	list, err := s.contentAggregate.GetListByID(ctx, siteID, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Walk every page so the snapshot is not limited by UI pagination, counting every row against the cap
+	what := "snapshot of list " + listID
+	loaded := 0
+	var assignments []*sharepoint.ResolvedAssignment
+	for offset := 0; ; offset += snapshotItemPageSize {
+		page, err := s.contentAggregate.GetResolvedAssignmentsPageForObject(ctx, siteID, s.auditRunID, sharepoint.ObjectTypeList, listID, offset, snapshotItemPageSize)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, page...)
+		loaded += len(page)
+		if err := s.checkRowCap(loaded, what); err != nil {
+			return nil, err
+		}
+		if len(page) < snapshotItemPageSize {
+			break
+		}
+	}
+	sort.SliceStable(assignments, func(i, j int) bool {
+		return lessAssignment(assignments[i].Assignment, assignments[j].Assignment)
+	})
+
+	var items []*sharepoint.Item
+	for offset := 0; ; offset += snapshotItemPageSize {
+		page, err := s.contentAggregate.GetAllListItems(ctx, siteID, listID, offset, snapshotItemPageSize)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		loaded += len(page)
+		if err := s.checkRowCap(loaded, what); err != nil {
+			return nil, err
+		}
+		if len(page) < snapshotItemPageSize {
+			break
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+
+	itemAssignments := make(map[string][]*sharepoint.Assignment)
+	for _, item := range items {
+		if !item.HasUnique {
+			continue
+		}
+		itemAssigns, err := s.contentAggregate.GetAssignmentsForObject(ctx, siteID, s.auditRunID, sharepoint.ObjectTypeItem, item.GUID)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(itemAssigns, func(i, j int) bool { return lessAssignment(itemAssigns[i], itemAssigns[j]) })
+		itemAssignments[item.GUID] = itemAssigns
+		loaded += len(itemAssigns)
+		if err := s.checkRowCap(loaded, what); err != nil {
+			return nil, err
+		}
+	}
+
+	links, err := s.contentAggregate.GetListSharingLinks(ctx, siteID, listID)
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range links {
+		members, err := s.contentAggregate.GetSharingLinkMembers(ctx, siteID, link.ID)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(members, func(i, j int) bool { return members[i].ID < members[j].ID })
+		link.Members = members
+		loaded += 1 + len(members)
+		if err := s.checkRowCap(loaded, what); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(links, func(i, j int) bool { return links[i].ID < links[j].ID })
+
+	labels, err := s.contentAggregate.GetListSensitivityLabels(ctx, siteID, listID)
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(labels, func(i, j int) bool { return labels[i].ItemGUID < labels[j].ItemGUID })
+
+	return &ListSnapshotData{
+		List:              list,
+		AuditRunID:        s.auditRunID,
+		Assignments:       assignments,
+		Items:             items,
+		ItemAssignments:   itemAssignments,
+		SharingLinks:      links,
+		SensitivityLabels: labels,
+	}, nil
+}
+
+// GetListChanges compares a list's permissions in a base audit run with this service's run (audit-scoped).
+// The comparison runs in SQL and loads only the changes, failing with ErrRowCapExceeded when there are too many.
+// The list describes the result, so a list missing from one of the runs can still be compared.
+func (s *SiteContentService) GetListChanges(ctx context.Context, siteID int64, list *sharepoint.List, baseAuditRunID int64) (*ListSnapshotDiffData, error) {
+	changes, err := s.contentAggregate.GetListChanges(ctx, siteID, list.ID, baseAuditRunID, s.auditRunID, s.maxRows)
+	if err != nil {
+		return nil, err
+	}
+	return listChangesDiff(list, baseAuditRunID, s.auditRunID, changes), nil
+}
+
+// GetRunChanges compares the site's web assignments and principals in a base audit run with this
+// service's run (audit-scoped), failing with ErrRowCapExceeded when there are too many changes.
+func (s *SiteContentService) GetRunChanges(ctx context.Context, siteID int64, baseAuditRunID int64) (*contracts.RunChanges, error) {
+	return s.contentAggregate.GetRunChanges(ctx, siteID, baseAuditRunID, s.auditRunID, s.maxRows)
+}
+
+// GetRunFacts retrieves the run's explicit assignments and active sharing links described by URL (audit-scoped),
+// failing with ErrRowCapExceeded when there are too many.
+func (s *SiteContentService) GetRunFacts(ctx context.Context, siteID int64) (*contracts.RunFacts, error) {
+	return s.contentAggregate.GetRunFacts(ctx, siteID, s.auditRunID, s.maxRows)
+}
+
+// lessAssignment orders assignments by principal, then role definition.
+func lessAssignment(a, b *sharepoint.Assignment) bool {
+	if a.RoleAssignment.PrincipalID != b.RoleAssignment.PrincipalID {
+		return a.RoleAssignment.PrincipalID < b.RoleAssignment.PrincipalID
+	}
+	return a.RoleAssignment.RoleDefID < b.RoleAssignment.RoleDefID
+}
+
+// itemPermissionSource returns the object whose assignments apply to an item:
+// the item itself, its nearest uniquely permissioned folder, its list or the list's web.
+func itemPermissionSource(item *sharepoint.Item, ancestors []*sharepoint.Item, list *sharepoint.List) *PermissionSource {
+	switch {
+	case item.HasUnique:
+		return objectPermissionSource(item, PermissionSourceItem)
+	case len(ancestors) > 0:
+		return objectPermissionSource(ancestors[0], PermissionSourceFolder)
+	case list.HasUnique:
+		return &PermissionSource{Type: PermissionSourceList, ObjectType: sharepoint.ObjectTypeList, ObjectKey: list.ID, Title: list.Title, URL: list.URL}
+	default:
+		return &PermissionSource{Type: PermissionSourceWeb, ObjectType: sharepoint.ObjectTypeWeb, ObjectKey: list.WebID}
+	}
+}
+
+// objectPermissionSource describes an item or folder as a permission source.
+func objectPermissionSource(item *sharepoint.Item, sourceType string) *PermissionSource {
+	return &PermissionSource{Type: sourceType, ObjectType: sharepoint.ObjectTypeItem, ObjectKey: item.GUID, Title: item.Name, URL: item.URL}
+}
+
+// assignmentPathType classifies an assignment on the item's permission source.
+func assignmentPathType(principal *sharepoint.Principal, source *PermissionSource) string {
+	switch {
+	case source.Type != PermissionSourceItem:
+		return AccessPathInheritance
+	case principal.IsSharingLinkPrincipal():
+		return AccessPathLink
+	case principal.IsGroup() || principal.IsSharePointGroup():
+		return AccessPathGroup
+	default:
+		return AccessPathDirect
+	}
+}
+
+// sharingLinkRole returns the access level a sharing link grants its members.
+func sharingLinkRole(link *sharepoint.SharingLink) string {
+	switch {
+	case link.IsEditLink:
+		return "Edit"
+	case link.IsReviewLink:
+		return "Review"
+	default:
+		return "View"
+	}
+}
+
+// diffPrincipals returns the principals in current but not previous, and in previous but not current.
+// Principal IDs are stable within a site collection, so they identify the same member across runs.
+func diffPrincipals(previous, current []*sharepoint.Principal) (added, removed []*sharepoint.Principal) {
+	previousIDs := make(map[int64]bool, len(previous))
+	for _, p := range previous {
+		previousIDs[p.ID] = true
+	}
+	currentIDs := make(map[int64]bool, len(current))
+	for _, p := range current {
+		currentIDs[p.ID] = true
+		if !previousIDs[p.ID] {
+			added = append(added, p)
+		}
+	}
+	for _, p := range previous {
+		if !currentIDs[p.ID] {
+			removed = append(removed, p)
+		}
+	}
+	return added, removed
+}
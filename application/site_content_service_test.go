@@ -2,11 +2,13 @@ package application
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"spaudit/domain/contracts"
@@ -238,3 +240,461 @@ func TestSiteContentService_GetSiteWithLists_AuditDateHandling(t *testing.T) {
 		})
 	}
 }
+
+func TestSiteContentService_GetListSnapshot_CanonicalOrdering(t *testing.T) {
+	// Arrange
+	mocks := helpers.NewMockRepositories()
+	ctx := context.Background()
+	list := helpers.NewTestData().SimpleList("docs", true, 3)
+
+	assignment := func(principalID, roleDefID int64) *sharepoint.Assignment {
+		return &sharepoint.Assignment{
+			RoleAssignment: &sharepoint.RoleAssignment{PrincipalID: principalID, RoleDefID: roleDefID},
+			Principal:      &sharepoint.Principal{ID: principalID},
+		}
+	}
+
+	mocks.SiteContentAggregate.On("GetListByID", ctx, int64(1), "docs").Return(list, nil)
+	mocks.SiteContentAggregate.On("GetResolvedAssignmentsPageForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeList, "docs", 0, 500).Return([]*sharepoint.ResolvedAssignment{
+		{Assignment: assignment(5, 2)},
+		{Assignment: assignment(3, 9)},
+		{Assignment: assignment(5, 1)},
+	}, nil)
+	mocks.SiteContentAggregate.On("GetAllListItems", ctx, int64(1), "docs", 0, 500).Return([]*sharepoint.Item{
+		{ID: 3, GUID: "c"},
+		{ID: 1, GUID: "a", HasUnique: true},
+		{ID: 2, GUID: "b"},
+	}, nil)
+	mocks.SiteContentAggregate.On("GetAssignmentsForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeItem, "a").Return([]*sharepoint.Assignment{
+		assignment(9, 1), assignment(4, 1),
+	}, nil)
+	mocks.SiteContentAggregate.On("GetListSharingLinks", ctx, int64(1), "docs").Return([]*sharepoint.SharingLink{
+		{ID: "link-b"}, {ID: "link-a"},
+	}, nil)
+	mocks.SiteContentAggregate.On("GetSharingLinkMembers", ctx, int64(1), "link-a").Return([]*sharepoint.Principal{{ID: 8}, {ID: 2}}, nil)
+	mocks.SiteContentAggregate.On("GetSharingLinkMembers", ctx, int64(1), "link-b").Return([]*sharepoint.Principal{}, nil)
+	mocks.SiteContentAggregate.On("GetListSensitivityLabels", ctx, int64(1), "docs").Return([]*sharepoint.ItemSensitivityLabel{
+		{ItemGUID: "c"}, {ItemGUID: "a"},
+	}, nil)
+
+	service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+	// Act
+	result, err := service.GetListSnapshot(ctx, 1, "docs")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), result.AuditRunID)
+	assert.Equal(t, list, result.List)
+
+	require.Len(t, result.Assignments, 3)
+	assert.Equal(t, int64(3), result.Assignments[0].Assignment.RoleAssignment.PrincipalID)
+	assert.Equal(t, int64(1), result.Assignments[1].Assignment.RoleAssignment.RoleDefID)
+	assert.Equal(t, int64(2), result.Assignments[2].Assignment.RoleAssignment.RoleDefID)
+
+	require.Len(t, result.Items, 3)
+	assert.Equal(t, []int{1, 2, 3}, []int{result.Items[0].ID, result.Items[1].ID, result.Items[2].ID})
+
+	// Only items with unique permissions carry their own assignments
+	require.Len(t, result.ItemAssignments, 1)
+	assert.Equal(t, int64(4), result.ItemAssignments["a"][0].RoleAssignment.PrincipalID)
+
+	require.Len(t, result.SharingLinks, 2)
+	assert.Equal(t, "link-a", result.SharingLinks[0].ID)
+	assert.Equal(t, int64(2), result.SharingLinks[0].Members[0].ID)
+
+	require.Len(t, result.SensitivityLabels, 2)
+	assert.Equal(t, "a", result.SensitivityLabels[0].ItemGUID)
+
+	mocks.AssertAllExpectations(t)
+}
+
+func TestSiteContentService_GetListSnapshot_RowCapStopsBeforeLoadingAllAssignments(t *testing.T) {
+	// Arrange
+	mocks := helpers.NewMockRepositories()
+	ctx := context.Background()
+	list := helpers.NewTestData().SimpleList("docs", true, 3)
+	page := make([]*sharepoint.ResolvedAssignment, 500)
+	for i := range page {
+		page[i] = &sharepoint.ResolvedAssignment{Assignment: &sharepoint.Assignment{
+			RoleAssignment: &sharepoint.RoleAssignment{PrincipalID: int64(i + 1), RoleDefID: 1},
+		}}
+	}
+
+	mocks.SiteContentAggregate.On("GetListByID", ctx, int64(1), "docs").Return(list, nil)
+	mocks.SiteContentAggregate.On("GetResolvedAssignmentsPageForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeList, "docs", 0, 500).Return(page, nil)
+
+	service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+	service.SetMaxAnalysisRows(100)
+
+	// Act
+	_, err := service.GetListSnapshot(ctx, 1, "docs")
+
+	// Assert: the first page passes the cap, so later pages and items are never loaded
+	assert.ErrorIs(t, err, contracts.ErrRowCapExceeded)
+	mocks.AssertAllExpectations(t)
+}
+
+// explainAssignment builds a resolved assignment for access explanation tests.
+func explainAssignment(principal *sharepoint.Principal, roleName string, causes ...sharepoint.RootCause) *sharepoint.ResolvedAssignment {
+	return &sharepoint.ResolvedAssignment{
+		Assignment: &sharepoint.Assignment{
+			RoleAssignment: &sharepoint.RoleAssignment{PrincipalID: principal.ID},
+			Principal:      principal,
+			RoleDefinition: &sharepoint.RoleDefinition{Name: roleName},
+		},
+		RootCauses: causes,
+	}
+}
+
+func TestSiteContentService_ExplainItemAccess_UniqueItem(t *testing.T) {
+	// Arrange
+	mocks := helpers.NewMockRepositories()
+	ctx := context.Background()
+	list := helpers.NewTestData().SimpleList("docs", true, 3)
+	item := &sharepoint.Item{GUID: "file-1", ListID: "docs", Name: "budget.xlsx", URL: "/sites/a/docs/finance/budget.xlsx", IsFile: true, HasUnique: true}
+	folderFilter := contracts.ItemFilter{UniqueOnly: true, Kind: contracts.ItemKindFolder}
+
+	alice := &sharepoint.Principal{ID: 10, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Alice"}
+	owners := &sharepoint.Principal{ID: 3, PrincipalType: sharepoint.PrincipalTypeSharePointGroup, Title: "Owners"}
+	linkGroup := &sharepoint.Principal{ID: 20, PrincipalType: sharepoint.PrincipalTypeSharePointGroup, LoginName: "SharingLinks.file-1.OrganizationEdit.abc"}
+	linkCause := sharepoint.RootCause{Type: sharepoint.RootCauseTypeSharingLink, Detail: "Shared file: budget.xlsx in Docs"}
+
+	mocks.SiteContentAggregate.On("GetItemByGUID", ctx, int64(1), "file-1").Return(item, nil)
+	mocks.SiteContentAggregate.On("GetListByID", ctx, int64(1), "docs").Return(list, nil)
+	mocks.SiteContentAggregate.On("GetFilteredListItems", ctx, int64(1), "docs", folderFilter, 0, 500).Return([]*sharepoint.Item{
+		{GUID: "folder-finance", Name: "finance", URL: "/sites/a/docs/finance", IsFolder: true, HasUnique: true},
+		{GUID: "folder-fin", Name: "fin", URL: "/sites/a/docs/fin", IsFolder: true, HasUnique: true},
+	}, nil)
+	mocks.SiteContentAggregate.On("GetResolvedAssignmentsForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeItem, "file-1").Return([]*sharepoint.ResolvedAssignment{
+		explainAssignment(linkGroup, "Contribute", linkCause),
+		explainAssignment(alice, "Full Control"),
+		explainAssignment(owners, "Full Control"),
+	}, nil)
+	mocks.SiteContentAggregate.On("GetListSharingLinks", ctx, int64(1), "docs").Return([]*sharepoint.SharingLink{
+		{ID: "link-other", ItemGUID: "file-2"},
+		{ID: "link-folder", FileFolderUniqueID: "folder-finance", LinkKind: sharepoint.LinkKindAnonymousView},
+		{ID: "link-file", ItemGUID: "file-1", LinkKind: sharepoint.LinkKindOrganizationEdit, IsEditLink: true},
+	}, nil)
+	mocks.SiteContentAggregate.On("GetSharingLinkMembers", ctx, int64(1), "link-file").Return([]*sharepoint.Principal{alice}, nil)
+	mocks.SiteContentAggregate.On("GetSharingLinkMembers", ctx, int64(1), "link-folder").Return([]*sharepoint.Principal{}, nil)
+
+	service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+	// Act
+	result, err := service.ExplainItemAccess(ctx, 1, "docs", "file-1")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), result.AuditRunID)
+	assert.Equal(t, PermissionSourceItem, result.PermissionSource.Type)
+	assert.Equal(t, "file-1", result.PermissionSource.ObjectKey)
+
+	// Principals are ordered by ID and every path is classified
+	require.Len(t, result.Principals, 3)
+	assert.Equal(t, []int64{3, 10, 20}, []int64{result.Principals[0].Principal.ID, result.Principals[1].Principal.ID, result.Principals[2].Principal.ID})
+
+	require.Len(t, result.Principals[0].Paths, 1)
+	assert.Equal(t, AccessPathGroup, result.Principals[0].Paths[0].Type)
+
+	aliceAccess := result.Principals[1]
+	require.Len(t, aliceAccess.Paths, 2)
+	assert.Equal(t, AccessPathDirect, aliceAccess.Paths[0].Type)
+	assert.Equal(t, "Full Control", aliceAccess.Paths[0].Role)
+	assert.Equal(t, AccessPathLink, aliceAccess.Paths[1].Type)
+	assert.Equal(t, "Edit", aliceAccess.Paths[1].Role)
+	assert.Equal(t, "link-file", aliceAccess.Paths[1].SharingLink.ID)
+
+	linkAccess := result.Principals[2]
+	require.Len(t, linkAccess.Paths, 1)
+	assert.Equal(t, AccessPathLink, linkAccess.Paths[0].Type)
+	assert.Equal(t, []sharepoint.RootCause{linkCause}, linkAccess.Paths[0].RootCauses)
+
+	// Links on an ancestor folder apply; links on unrelated items and look-alike folders do not
+	require.Len(t, result.SharingLinks, 2)
+	assert.Equal(t, "link-file", result.SharingLinks[0].ID)
+	assert.Equal(t, "link-folder", result.SharingLinks[1].ID)
+	mocks.SiteContentAggregate.AssertNotCalled(t, "GetSharingLinkMembers", ctx, int64(1), "link-other")
+}
+
+func TestSiteContentService_ExplainItemAccess_InheritedPermissions(t *testing.T) {
+	folderFilter := contracts.ItemFilter{UniqueOnly: true, Kind: contracts.ItemKindFolder}
+	reader := &sharepoint.Principal{ID: 4, PrincipalType: sharepoint.PrincipalTypeSecurity, Title: "Readers"}
+
+	tests := []struct {
+		name          string
+		listHasUnique bool
+		folders       []*sharepoint.Item
+		sourceType    string
+		objectType    string
+		objectKey     string
+	}{
+		{
+			name:          "nearest unique folder",
+			listHasUnique: true,
+			folders: []*sharepoint.Item{
+				{GUID: "folder-outer", URL: "/sites/a/docs/outer", IsFolder: true, HasUnique: true},
+				{GUID: "folder-inner", URL: "/sites/a/docs/outer/inner", IsFolder: true, HasUnique: true},
+			},
+			sourceType: PermissionSourceFolder,
+			objectType: sharepoint.ObjectTypeItem,
+			objectKey:  "folder-inner",
+		},
+		{
+			name:          "list",
+			listHasUnique: true,
+			sourceType:    PermissionSourceList,
+			objectType:    sharepoint.ObjectTypeList,
+			objectKey:     "docs",
+		},
+		{
+			name:       "web",
+			sourceType: PermissionSourceWeb,
+			objectType: sharepoint.ObjectTypeWeb,
+			objectKey:  "web-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mocks := helpers.NewMockRepositories()
+			ctx := context.Background()
+			list := &sharepoint.List{ID: "docs", WebID: "web-1", Title: "Docs", HasUnique: tt.listHasUnique}
+			item := &sharepoint.Item{GUID: "file-1", ListID: "docs", URL: "/sites/a/docs/outer/inner/report.docx", IsFile: true}
+
+			mocks.SiteContentAggregate.On("GetItemByGUID", ctx, int64(1), "file-1").Return(item, nil)
+			mocks.SiteContentAggregate.On("GetListByID", ctx, int64(1), "docs").Return(list, nil)
+			mocks.SiteContentAggregate.On("GetFilteredListItems", ctx, int64(1), "docs", folderFilter, 0, 500).Return(tt.folders, nil)
+			mocks.SiteContentAggregate.On("GetResolvedAssignmentsForObject", ctx, int64(1), int64(7), tt.objectType, tt.objectKey).Return([]*sharepoint.ResolvedAssignment{
+				explainAssignment(reader, "Read"),
+			}, nil)
+			mocks.SiteContentAggregate.On("GetListSharingLinks", ctx, int64(1), "docs").Return([]*sharepoint.SharingLink{}, nil)
+
+			service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+			result, err := service.ExplainItemAccess(ctx, 1, "docs", "file-1")
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.sourceType, result.PermissionSource.Type)
+			require.Len(t, result.Principals, 1)
+			require.Len(t, result.Principals[0].Paths, 1)
+			assert.Equal(t, AccessPathInheritance, result.Principals[0].Paths[0].Type)
+			assert.Equal(t, result.PermissionSource, result.Principals[0].Paths[0].Source)
+		})
+	}
+}
+
+func TestSiteContentService_ExplainItemAccess_ItemInOtherList(t *testing.T) {
+	mocks := helpers.NewMockRepositories()
+	ctx := context.Background()
+	mocks.SiteContentAggregate.On("GetItemByGUID", ctx, int64(1), "file-1").Return(&sharepoint.Item{GUID: "file-1", ListID: "other"}, nil)
+
+	service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+	_, err := service.ExplainItemAccess(ctx, 1, "docs", "file-1")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSiteContentService_GetWebAssignmentsWithRootCause(t *testing.T) {
+	mocks := helpers.NewMockRepositories()
+	ctx := context.Background()
+	owners := &sharepoint.Principal{ID: 3, PrincipalType: sharepoint.PrincipalTypeSharePointGroup, Title: "Owners"}
+	alice := &sharepoint.Principal{ID: 10, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Alice"}
+	directCause := sharepoint.RootCause{Type: sharepoint.RootCauseTypeDirect, Detail: "Assigned Full Control directly on this web"}
+
+	mocks.SiteContentAggregate.On("GetWebsForSite", ctx, int64(1), int64(7)).Return([]*sharepoint.Web{
+		{ID: "root", Title: "Root"},
+		{ID: "sub", Title: "Sub"},
+	}, nil)
+	mocks.SiteContentAggregate.On("GetResolvedAssignmentsForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeWeb, "root").Return([]*sharepoint.ResolvedAssignment{
+		explainAssignment(owners, "Full Control", directCause),
+	}, nil)
+	mocks.SiteContentAggregate.On("GetResolvedAssignmentsForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeWeb, "sub").Return([]*sharepoint.ResolvedAssignment{}, nil)
+	mocks.SiteContentAggregate.On("GetGroupMembersForAuditRun", ctx, int64(1), int64(7), int64(3)).Return([]*sharepoint.Principal{alice}, nil)
+
+	service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+	result, err := service.GetWebAssignmentsWithRootCause(ctx, 1)
+
+	require.NoError(t, err)
+	require.Len(t, result, 2)
+	assert.Equal(t, "root", result[0].Web.ID)
+	require.Len(t, result[0].Assignments, 1)
+	assert.Equal(t, []sharepoint.RootCause{directCause}, result[0].Assignments[0].RootCauses)
+	assert.Equal(t, []*sharepoint.Principal{alice}, result[0].Assignments[0].GroupMembers, "SharePoint groups are expanded to their members")
+	assert.Equal(t, "sub", result[1].Web.ID)
+	assert.Empty(t, result[1].Assignments)
+}
+
+func TestSiteContentService_GetSiteGroupMembers(t *testing.T) {
+	mocks := helpers.NewMockRepositories()
+	ctx := context.Background()
+	owners := &sharepoint.Principal{ID: 3, PrincipalType: sharepoint.PrincipalTypeSharePointGroup, Title: "Owners"}
+	alice := &sharepoint.Principal{ID: 10, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Alice"}
+	mocks.SiteContentAggregate.On("GetPrincipalsForAuditRun", ctx, int64(1), int64(7)).Return([]*sharepoint.Principal{owners, alice}, nil)
+	mocks.SiteContentAggregate.On("GetGroupMembersForAuditRun", ctx, int64(1), int64(7), int64(3)).Return([]*sharepoint.Principal{alice}, nil)
+
+	service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+	result, err := service.GetSiteGroupMembers(ctx, 1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, owners, result.Group)
+	assert.Equal(t, []*sharepoint.Principal{alice}, result.Members)
+
+	// Users are not groups to expand
+	_, err = service.GetSiteGroupMembers(ctx, 1, 10)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestSiteContentService_GetSharingLinkMemberChanges(t *testing.T) {
+	alice := &sharepoint.Principal{ID: 10, Title: "Alice"}
+	bob := &sharepoint.Principal{ID: 11, Title: "Bob"}
+	carol := &sharepoint.Principal{ID: 12, Title: "Carol"}
+
+	t.Run("diffs against the previous run that captured the link", func(t *testing.T) {
+		mocks := helpers.NewMockRepositories()
+		ctx := context.Background()
+		mocks.SiteContentAggregate.On("GetSharingLinkMembers", ctx, int64(1), "link-1").Return([]*sharepoint.Principal{alice, carol}, nil)
+		mocks.SiteContentAggregate.On("GetPreviousSharingLinkAuditRun", ctx, int64(1), int64(7), "link-1").Return(int64(4), nil)
+		mocks.SiteContentAggregate.On("GetSharingLinkMembersForAuditRun", ctx, int64(1), int64(4), "link-1").Return([]*sharepoint.Principal{alice, bob}, nil)
+
+		service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+		result, err := service.GetSharingLinkMemberChanges(ctx, 1, "link-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, []*sharepoint.Principal{alice, carol}, result.Members)
+		assert.Equal(t, int64(4), result.PreviousAuditRunID)
+		assert.Equal(t, []*sharepoint.Principal{carol}, result.Added)
+		assert.Equal(t, []*sharepoint.Principal{bob}, result.Removed)
+	})
+
+	t.Run("link not captured by an earlier run", func(t *testing.T) {
+		mocks := helpers.NewMockRepositories()
+		ctx := context.Background()
+		mocks.SiteContentAggregate.On("GetSharingLinkMembers", ctx, int64(1), "link-1").Return([]*sharepoint.Principal{alice}, nil)
+		mocks.SiteContentAggregate.On("GetPreviousSharingLinkAuditRun", ctx, int64(1), int64(7), "link-1").Return(int64(0), nil)
+
+		service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+		result, err := service.GetSharingLinkMemberChanges(ctx, 1, "link-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), result.PreviousAuditRunID)
+		assert.Empty(t, result.Added)
+		assert.Empty(t, result.Removed)
+		mocks.SiteContentAggregate.AssertNotCalled(t, "GetSharingLinkMembersForAuditRun", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSiteContentService_GetListAssignmentsWithRootCause_UnwrapsSharingLinks(t *testing.T) {
+	// Arrange
+	mocks := helpers.NewMockRepositories()
+	ctx := context.Background()
+	linkGroup := &sharepoint.Principal{
+		ID:            12,
+		PrincipalType: 8,
+		Title:         "SharingLinks.1f1e2d3c-0000-4000-8000-000000000001.OrganizationView.9a8b7c6d-0000-4000-8000-000000000002",
+		LoginName:     "SharingLinks.1f1e2d3c-0000-4000-8000-000000000001.OrganizationView.9a8b7c6d-0000-4000-8000-000000000002",
+	}
+	owners := &sharepoint.Principal{ID: 3, PrincipalType: 8, Title: "Owners", LoginName: "Owners"}
+	link := &sharepoint.SharingLinkWithItemData{
+		SharingLink: &sharepoint.SharingLink{ID: "9A8B7C6D-0000-4000-8000-000000000002", LinkKind: 4},
+		ItemName:    "budget.xlsx",
+		ItemIsFile:  true,
+	}
+
+	mocks.SiteContentAggregate.On("GetListAssignmentsWithRootCause", ctx, int64(1), int64(7), "docs").Return([]*sharepoint.ResolvedAssignment{
+		explainAssignment(owners, "Full Control"),
+		explainAssignment(linkGroup, "Read"),
+	}, nil)
+	mocks.SiteContentAggregate.On("GetSharingLinkForAuditRun", ctx, int64(1), int64(7), "9a8b7c6d-0000-4000-8000-000000000002").Return(link, nil)
+	mocks.SiteContentAggregate.On("GetSharingLinkMembersForAuditRun", ctx, int64(1), int64(7), link.ID).Return([]*sharepoint.Principal{
+		{ID: 20, Title: "Ada"},
+	}, nil)
+	mocks.SiteContentAggregate.On("GetGroupMembersForAuditRun", ctx, int64(1), int64(7), int64(3)).Return([]*sharepoint.Principal{
+		{ID: 21, Title: "Grace"},
+	}, nil)
+
+	service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+	// Act
+	assignments, err := service.GetListAssignmentsWithRootCause(ctx, 1, "docs")
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, assignments, 2)
+	assert.Nil(t, assignments[0].SharingLink, "ordinary groups are not unwrapped")
+	require.Len(t, assignments[0].GroupMembers, 1, "ordinary groups are expanded to their members")
+	assert.Equal(t, "Grace", assignments[0].GroupMembers[0].Title)
+	assert.Nil(t, assignments[1].GroupMembers, "sharing link groups are not expanded")
+	require.NotNil(t, assignments[1].SharingLink)
+	assert.Equal(t, "budget.xlsx", assignments[1].SharingLink.ItemName)
+	require.Len(t, assignments[1].SharingLink.Members, 1)
+	assert.Equal(t, "Ada", assignments[1].SharingLink.Members[0].Title)
+
+	mocks.AssertAllExpectations(t)
+}
+
+func TestSiteContentService_GetListItemsPage_AccessibleBy(t *testing.T) {
+	// Arrange
+	mocks := helpers.NewMockRepositories()
+	ctx := context.Background()
+	list := helpers.NewTestData().SimpleList("docs", true, 4)
+	folderFilter := contracts.ItemFilter{UniqueOnly: true, Kind: contracts.ItemKindFolder}
+
+	alice := &sharepoint.Principal{ID: 10, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Alice"}
+	bob := &sharepoint.Principal{ID: 11, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Bob"}
+	finance := &sharepoint.Principal{ID: 7, PrincipalType: sharepoint.PrincipalTypeSharePointGroup, Title: "Finance"}
+	assign := func(principal *sharepoint.Principal, role string) *sharepoint.Assignment {
+		return &sharepoint.Assignment{
+			RoleAssignment: &sharepoint.RoleAssignment{PrincipalID: principal.ID},
+			Principal:      principal,
+			RoleDefinition: &sharepoint.RoleDefinition{Name: role},
+		}
+	}
+
+	mocks.SiteContentAggregate.On("GetListByID", ctx, int64(1), "docs").Return(list, nil)
+	mocks.SiteContentAggregate.On("GetGroupIDsForMember", ctx, int64(1), int64(7), int64(10)).Return([]int64{7}, nil)
+	mocks.SiteContentAggregate.On("GetFilteredListItems", ctx, int64(1), "docs", contracts.ItemFilter{}, 0, 500).Return([]*sharepoint.Item{
+		{ID: 1, GUID: "budget", URL: "/sites/a/docs/finance/budget.xlsx", IsFile: true},
+		{ID: 2, GUID: "salaries", URL: "/sites/a/docs/hr/salaries.xlsx", IsFile: true, HasUnique: true},
+		{ID: 3, GUID: "notes", URL: "/sites/a/docs/notes.docx", IsFile: true},
+		{ID: 4, GUID: "shared", URL: "/sites/a/docs/shared.docx", IsFile: true, HasUnique: true},
+	}, nil)
+	mocks.SiteContentAggregate.On("GetFilteredListItems", ctx, int64(1), "docs", folderFilter, 0, 500).Return([]*sharepoint.Item{
+		{GUID: "folder-finance", URL: "/sites/a/docs/finance", IsFolder: true, HasUnique: true},
+	}, nil)
+	mocks.SiteContentAggregate.On("GetListSharingLinks", ctx, int64(1), "docs").Return([]*sharepoint.SharingLink{
+		{ID: "link-shared", ItemGUID: "shared"},
+		{ID: "link-salaries", ItemGUID: "salaries"},
+	}, nil)
+	mocks.SiteContentAggregate.On("GetSharingLinkMembers", ctx, int64(1), "link-shared").Return([]*sharepoint.Principal{alice}, nil)
+	mocks.SiteContentAggregate.On("GetSharingLinkMembers", ctx, int64(1), "link-salaries").Return([]*sharepoint.Principal{bob}, nil)
+
+	// Alice reaches budget.xlsx through her Finance group on the folder; Limited Access on the list does not count
+	mocks.SiteContentAggregate.On("GetAssignmentsForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeItem, "folder-finance").Return([]*sharepoint.Assignment{assign(finance, "Contribute")}, nil)
+	mocks.SiteContentAggregate.On("GetAssignmentsForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeItem, "salaries").Return([]*sharepoint.Assignment{assign(bob, "Full Control")}, nil)
+	mocks.SiteContentAggregate.On("GetAssignmentsForObject", ctx, int64(1), int64(7), sharepoint.ObjectTypeList, "docs").Return([]*sharepoint.Assignment{assign(alice, "Limited Access")}, nil)
+
+	service := NewAuditScopedSiteContentService(mocks.SiteContentAggregate, 7)
+
+	// Act
+	first, err := service.GetListItemsPage(ctx, 1, "docs", contracts.ItemFilter{AccessibleBy: 10}, 1, 1)
+	require.NoError(t, err)
+	second, err := service.GetListItemsPage(ctx, 1, "docs", contracts.ItemFilter{AccessibleBy: 10}, 5, 1)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, int64(2), first.TotalCount)
+	require.Len(t, first.Items, 1)
+	assert.Equal(t, "budget", first.Items[0].GUID)
+
+	// Pages past the end clamp to the last page
+	assert.Equal(t, 2, second.Page)
+	require.Len(t, second.Items, 1)
+	assert.Equal(t, "shared", second.Items[0].GUID)
+
+	mocks.SiteContentAggregate.AssertNotCalled(t, "CountFilteredListItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
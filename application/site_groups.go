@@ -0,0 +1,65 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"spaudit/domain/sharepoint"
+)
+
+// SiteGroupMembersData is a SharePoint group captured by an audit run, with the users and security
+// groups it grants its roles to.
+type SiteGroupMembersData struct {
+	Group   *sharepoint.Principal
+	Members []*sharepoint.Principal // Ordered by title; empty when the run could not read the group
+}
+
+// GetSiteGroupMembers returns a SharePoint group with its members as captured by the audit run (audit-scoped).
+// Fails with sql.ErrNoRows when the run captured no SharePoint group with that ID.
+func (s *SiteContentService) GetSiteGroupMembers(ctx context.Context, siteID, groupID int64) (*SiteGroupMembersData, error) {
+	principals, err := s.contentAggregate.GetPrincipalsForAuditRun(ctx, siteID, s.auditRunID)
+	if err != nil {
+		return nil, err
+	}
+
+	var group *sharepoint.Principal
+	for _, principal := range principals {
+		if principal.ID == groupID && principal.IsSharePointGroup() && !principal.IsSharingLinkPrincipal() {
+			group = principal
+			break
+		}
+	}
+	if group == nil {
+		return nil, fmt.Errorf("SharePoint group %d: %w", groupID, sql.ErrNoRows)
+	}
+
+	members, err := s.contentAggregate.GetGroupMembersForAuditRun(ctx, siteID, s.auditRunID, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get members of group %d: %w", groupID, err)
+	}
+	return &SiteGroupMembersData{Group: group, Members: members}, nil
+}
+
+// expandSiteGroups attaches the members of each SharePoint group assignment, so views can show who the
+// group grants access to. Sharing link groups are left to unwrapSharingLinks.
+func (s *SiteContentService) expandSiteGroups(ctx context.Context, siteID int64, assignments []*sharepoint.ResolvedAssignment) error {
+	members := map[int64][]*sharepoint.Principal{}
+	for _, resolved := range assignments {
+		principal := resolved.Assignment.Principal
+		if principal == nil || !principal.IsSharePointGroup() || principal.IsSharingLinkPrincipal() {
+			continue
+		}
+
+		groupMembers, ok := members[principal.ID]
+		if !ok {
+			var err error
+			if groupMembers, err = s.contentAggregate.GetGroupMembersForAuditRun(ctx, siteID, s.auditRunID, principal.ID); err != nil {
+				return fmt.Errorf("failed to get members of group %d: %w", principal.ID, err)
+			}
+			members[principal.ID] = groupMembers
+		}
+		resolved.GroupMembers = groupMembers
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// ErrSiteNotTaggedSensitive is returned when untagging a site that was never tagged sensitive.
+var ErrSiteNotTaggedSensitive = errors.New("site not tagged sensitive")
+
+// SiteSensitivityService keeps the sites an administrator tagged as holding sensitive content.
+type SiteSensitivityService struct {
+	db     *database.Database
+	now    func() time.Time
+	logger *logging.Logger
+}
+
+// NewSiteSensitivityService creates a site sensitivity service.
+func NewSiteSensitivityService(db *database.Database) *SiteSensitivityService {
+	return &SiteSensitivityService{
+		db:     db,
+		now:    func() time.Time { return time.Now().UTC() },
+		logger: logging.Default().WithComponent("site_sensitivity_service"),
+	}
+}
+
+// GetSiteSensitivity returns a site's sensitivity tag, nil when the site is not tagged.
+func (s *SiteSensitivityService) GetSiteSensitivity(ctx context.Context, siteID int64) (*audit.SiteSensitivity, error) {
+	row, err := s.db.ReadQueries().GetSiteSensitivity(ctx, siteID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sensitivity of site %d: %w", siteID, err)
+	}
+	return &audit.SiteSensitivity{SiteID: row.SiteID, Reason: row.Reason, TaggedAt: row.TaggedAt}, nil
+}
+
+// TagSite tags a site as sensitive, replacing the reason it was tagged with before.
+func (s *SiteSensitivityService) TagSite(ctx context.Context, siteID int64, reason string) (*audit.SiteSensitivity, error) {
+	if _, err := s.db.ReadQueries().GetSiteByID(ctx, siteID); err != nil {
+		return nil, fmt.Errorf("failed to get site %d: %w", siteID, err)
+	}
+
+	tag := &audit.SiteSensitivity{SiteID: siteID, Reason: strings.TrimSpace(reason), TaggedAt: s.now()}
+	err := s.db.Queries().UpsertSiteSensitivity(ctx, db.UpsertSiteSensitivityParams{
+		SiteID:   tag.SiteID,
+		Reason:   tag.Reason,
+		TaggedAt: tag.TaggedAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to tag site %d sensitive: %w", siteID, err)
+	}
+
+	s.logger.Info("Site tagged sensitive", "site_id", siteID)
+	return tag, nil
+}
+
+// UntagSite removes a site's sensitivity tag.
+func (s *SiteSensitivityService) UntagSite(ctx context.Context, siteID int64) error {
+	deleted, err := s.db.Queries().DeleteSiteSensitivity(ctx, siteID)
+	if err != nil {
+		return fmt.Errorf("failed to untag site %d: %w", siteID, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf("%w: site %d", ErrSiteNotTaggedSensitive, siteID)
+	}
+
+	s.logger.Info("Site sensitivity tag removed", "site_id", siteID)
+	return nil
+}
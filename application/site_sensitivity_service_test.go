@@ -0,0 +1,45 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+)
+
+func TestSiteSensitivityService(t *testing.T) {
+	ctx := context.Background()
+	service := NewSiteSensitivityService(newBaselineTestService(t).db)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	tag, err := service.GetSiteSensitivity(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, tag, "sites are not sensitive until tagged")
+
+	tag, err = service.TagSite(ctx, 1, "  Board papers ")
+	require.NoError(t, err)
+	assert.Equal(t, &audit.SiteSensitivity{SiteID: 1, Reason: "Board papers", TaggedAt: now}, tag)
+
+	_, err = service.TagSite(ctx, 1, "Payroll")
+	require.NoError(t, err)
+	tag, err = service.GetSiteSensitivity(ctx, 1)
+	require.NoError(t, err)
+	require.NotNil(t, tag)
+	assert.Equal(t, "Payroll", tag.Reason, "tagging again replaces the reason")
+	assert.True(t, tag.TaggedAt.Equal(now))
+
+	require.NoError(t, service.UntagSite(ctx, 1))
+	tag, err = service.GetSiteSensitivity(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, tag)
+	assert.ErrorIs(t, service.UntagSite(ctx, 1), ErrSiteNotTaggedSensitive)
+
+	_, err = service.TagSite(ctx, 99, "")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,228 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+// Snapshot change markers.
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+)
+
+// Snapshot change categories, in the order they are reported.
+const (
+	ChangeCategoryListAssignment    = "list_assignment"
+	ChangeCategoryUniqueItem        = "unique_item"
+	ChangeCategoryItemAssignment    = "item_assignment"
+	ChangeCategorySharingLink       = "sharing_link"
+	ChangeCategorySharingLinkMember = "sharing_link_member"
+)
+
+var changeCategoryOrder = map[string]int{
+	ChangeCategoryListAssignment:    0,
+	ChangeCategoryUniqueItem:        1,
+	ChangeCategoryItemAssignment:    2,
+	ChangeCategorySharingLink:       3,
+	ChangeCategorySharingLinkMember: 4,
+}
+
+// SnapshotChange is one permission fact present in only one of two list snapshots.
+type SnapshotChange struct {
+	Change     string // ChangeAdded or ChangeRemoved
+	Category   string
+	ObjectType string // List, item or sharing link the change is on
+	ObjectKey  string
+	ObjectName string
+	Principal  *sharepoint.Principal // Nil for unique items and sharing links
+	RoleDefID  int64
+	Role       string
+	Detail     string
+}
+
+// ListSnapshotDiffData represents the permission changes to a list between two audit runs.
+type ListSnapshotDiffData struct {
+	List           *sharepoint.List
+	BaseAuditRunID int64
+	AuditRunID     int64
+	Changes        []*SnapshotChange
+}
+
+// DiffListSnapshots compares two snapshots of the same list.
+// Changes are ordered by category, then object, then principal and role, with removals before additions.
+func DiffListSnapshots(base, current *ListSnapshotData) *ListSnapshotDiffData {
+	baseFacts := snapshotFacts(base)
+	currentFacts := snapshotFacts(current)
+
+	diff := &ListSnapshotDiffData{
+		List:           current.List,
+		BaseAuditRunID: base.AuditRunID,
+		AuditRunID:     current.AuditRunID,
+		Changes:        []*SnapshotChange{},
+	}
+	for key, fact := range baseFacts {
+		if _, ok := currentFacts[key]; !ok {
+			fact.Change = ChangeRemoved
+			diff.Changes = append(diff.Changes, fact)
+		}
+	}
+	for key, fact := range currentFacts {
+		if _, ok := baseFacts[key]; !ok {
+			fact.Change = ChangeAdded
+			diff.Changes = append(diff.Changes, fact)
+		}
+	}
+
+	sort.Slice(diff.Changes, func(i, j int) bool {
+		a, b := diff.Changes[i], diff.Changes[j]
+		if a.Category != b.Category {
+			return changeCategoryOrder[a.Category] < changeCategoryOrder[b.Category]
+		}
+		if a.ObjectKey != b.ObjectKey {
+			return a.ObjectKey < b.ObjectKey
+		}
+		if principalID(a) != principalID(b) {
+			return principalID(a) < principalID(b)
+		}
+		if a.RoleDefID != b.RoleDefID {
+			return a.RoleDefID < b.RoleDefID
+		}
+		return a.Change == ChangeRemoved && b.Change == ChangeAdded
+	})
+	return diff
+}
+
+// listChangesDiff converts changes compared in SQL to the diff DiffListSnapshots produces for the same runs.
+// Each kind of change arrives in diff order, so appending them by category keeps the whole diff ordered.
+func listChangesDiff(list *sharepoint.List, baseAuditRunID, auditRunID int64, changes *contracts.ListChanges) *ListSnapshotDiffData {
+	diff := &ListSnapshotDiffData{
+		List:           list,
+		BaseAuditRunID: baseAuditRunID,
+		AuditRunID:     auditRunID,
+		Changes:        []*SnapshotChange{},
+	}
+	add := func(category, objectType string, change *contracts.ListChange, objectName, detail string) {
+		marker := ChangeRemoved
+		if change.Added {
+			marker = ChangeAdded
+		}
+		diff.Changes = append(diff.Changes, &SnapshotChange{
+			Change:     marker,
+			Category:   category,
+			ObjectType: objectType,
+			ObjectKey:  change.ObjectKey,
+			ObjectName: objectName,
+			Principal:  change.Principal,
+			RoleDefID:  change.RoleDefID,
+			Role:       change.Role,
+			Detail:     detail,
+		})
+	}
+	linkKind := func(change *contracts.ListChange) string {
+		return (&sharepoint.SharingLink{LinkKind: change.LinkKind}).GetLinkKindName()
+	}
+
+	for _, change := range changes.Assignments {
+		add(ChangeCategoryListAssignment, sharepoint.ObjectTypeList, change, list.Title, "")
+	}
+	for _, change := range changes.UniqueItems {
+		add(ChangeCategoryUniqueItem, sharepoint.ObjectTypeItem, change, change.ObjectName, change.URL)
+	}
+	for _, change := range changes.ItemAssignments {
+		add(ChangeCategoryItemAssignment, sharepoint.ObjectTypeItem, change, change.ObjectName, "")
+	}
+	for _, change := range changes.SharingLinks {
+		add(ChangeCategorySharingLink, "sharing_link", change, change.ObjectName, linkKind(change))
+	}
+	for _, change := range changes.LinkMembers {
+		add(ChangeCategorySharingLinkMember, "sharing_link", change, change.ObjectName, linkKind(change))
+	}
+	return diff
+}
+
+// snapshotFacts flattens a snapshot into comparable facts keyed by identity.
+// Principals and role definitions are matched by SharePoint ID, which is stable across runs.
+func snapshotFacts(snapshot *ListSnapshotData) map[string]*SnapshotChange {
+	facts := make(map[string]*SnapshotChange)
+	add := func(fact *SnapshotChange) {
+		key := fmt.Sprintf("%s|%s|%d|%d", fact.Category, fact.ObjectKey, principalID(fact), fact.RoleDefID)
+		facts[key] = fact
+	}
+
+	for _, resolved := range snapshot.Assignments {
+		add(assignmentFact(ChangeCategoryListAssignment, sharepoint.ObjectTypeList, snapshot.List.ID, snapshot.List.Title, resolved.Assignment))
+	}
+
+	itemNames := make(map[string]string, len(snapshot.Items))
+	for _, item := range snapshot.Items {
+		itemNames[item.GUID] = item.Name
+		if item.HasUnique {
+			add(&SnapshotChange{
+				Category:   ChangeCategoryUniqueItem,
+				ObjectType: sharepoint.ObjectTypeItem,
+				ObjectKey:  item.GUID,
+				ObjectName: item.Name,
+				Detail:     item.URL,
+			})
+		}
+	}
+	for itemGUID, assignments := range snapshot.ItemAssignments {
+		for _, assignment := range assignments {
+			add(assignmentFact(ChangeCategoryItemAssignment, sharepoint.ObjectTypeItem, itemGUID, itemNames[itemGUID], assignment))
+		}
+	}
+
+	for _, link := range snapshot.SharingLinks {
+		linkName := itemNames[link.ItemGUID]
+		add(&SnapshotChange{
+			Category:   ChangeCategorySharingLink,
+			ObjectType: "sharing_link",
+			ObjectKey:  link.ID,
+			ObjectName: linkName,
+			Detail:     link.GetLinkKindName(),
+		})
+		for _, member := range link.Members {
+			add(&SnapshotChange{
+				Category:   ChangeCategorySharingLinkMember,
+				ObjectType: "sharing_link",
+				ObjectKey:  link.ID,
+				ObjectName: linkName,
+				Principal:  member,
+				Detail:     link.GetLinkKindName(),
+			})
+		}
+	}
+	return facts
+}
+
+// assignmentFact converts a role assignment on an object to a fact.
+func assignmentFact(category, objectType, objectKey, objectName string, assignment *sharepoint.Assignment) *SnapshotChange {
+	principal := assignment.Principal
+	if principal == nil {
+		principal = &sharepoint.Principal{ID: assignment.RoleAssignment.PrincipalID}
+	}
+	fact := &SnapshotChange{
+		Category:   category,
+		ObjectType: objectType,
+		ObjectKey:  objectKey,
+		ObjectName: objectName,
+		Principal:  principal,
+		RoleDefID:  assignment.RoleAssignment.RoleDefID,
+	}
+	if assignment.RoleDefinition != nil {
+		fact.Role = assignment.RoleDefinition.Name
+	}
+	return fact
+}
+
+// principalID returns the ID of the change's principal, 0 when there is none.
+func principalID(change *SnapshotChange) int64 {
+	if change.Principal == nil {
+		return 0
+	}
+	return change.Principal.ID
+}
@@ -0,0 +1,228 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	infrafactories "spaudit/infrastructure/factories"
+	"spaudit/infrastructure/repositories"
+	"spaudit/test/dataset"
+)
+
+func TestDiffListSnapshots(t *testing.T) {
+	list := &sharepoint.List{ID: "list-1", Title: "Documents"}
+	read := &sharepoint.RoleDefinition{ID: 2, Name: "Read"}
+	edit := &sharepoint.RoleDefinition{ID: 3, Name: "Edit"}
+	alice := &sharepoint.Principal{ID: 10, Title: "Alice", LoginName: "alice"}
+	bob := &sharepoint.Principal{ID: 11, Title: "Bob", LoginName: "bob"}
+
+	assignment := func(principal *sharepoint.Principal, role *sharepoint.RoleDefinition) *sharepoint.Assignment {
+		return &sharepoint.Assignment{
+			RoleAssignment: &sharepoint.RoleAssignment{PrincipalID: principal.ID, RoleDefID: role.ID},
+			Principal:      principal,
+			RoleDefinition: role,
+		}
+	}
+
+	base := &ListSnapshotData{
+		List:       list,
+		AuditRunID: 1,
+		Assignments: []*sharepoint.ResolvedAssignment{
+			{Assignment: assignment(alice, read)},
+			{Assignment: assignment(bob, read)},
+		},
+		Items: []*sharepoint.Item{
+			{GUID: "item-a", Name: "a.docx", HasUnique: true},
+			{GUID: "item-b", Name: "b.docx"},
+		},
+		ItemAssignments: map[string][]*sharepoint.Assignment{
+			"item-a": {assignment(alice, edit)},
+		},
+		SharingLinks: []*sharepoint.SharingLink{
+			{ID: "link-1", ItemGUID: "item-a", LinkKind: 3, Members: []*sharepoint.Principal{alice}},
+		},
+	}
+	current := &ListSnapshotData{
+		List:       list,
+		AuditRunID: 2,
+		Assignments: []*sharepoint.ResolvedAssignment{
+			// Same fact with a different object identity must not be reported
+			{Assignment: assignment(&sharepoint.Principal{ID: 10, Title: "Alice"}, read)},
+			{Assignment: assignment(bob, edit)},
+		},
+		Items: []*sharepoint.Item{
+			{GUID: "item-a", Name: "a.docx", HasUnique: true},
+			{GUID: "item-b", Name: "b.docx", HasUnique: true},
+		},
+		ItemAssignments: map[string][]*sharepoint.Assignment{
+			"item-a": {assignment(alice, edit)},
+			"item-b": {assignment(bob, read)},
+		},
+		SharingLinks: []*sharepoint.SharingLink{
+			{ID: "link-1", ItemGUID: "item-a", LinkKind: 3, Members: []*sharepoint.Principal{alice, bob}},
+		},
+	}
+
+	diff := DiffListSnapshots(base, current)
+
+	assert.Equal(t, int64(1), diff.BaseAuditRunID)
+	assert.Equal(t, int64(2), diff.AuditRunID)
+	require.Len(t, diff.Changes, 5)
+
+	type row struct {
+		change, category, objectKey string
+		principalID                 int64
+		role                        string
+	}
+	var rows []row
+	for _, change := range diff.Changes {
+		rows = append(rows, row{change.Change, change.Category, change.ObjectKey, principalID(change), change.Role})
+	}
+	assert.Equal(t, []row{
+		{ChangeRemoved, ChangeCategoryListAssignment, "list-1", 11, "Read"},
+		{ChangeAdded, ChangeCategoryListAssignment, "list-1", 11, "Edit"},
+		{ChangeAdded, ChangeCategoryUniqueItem, "item-b", 0, ""},
+		{ChangeAdded, ChangeCategoryItemAssignment, "item-b", 11, "Read"},
+		{ChangeAdded, ChangeCategorySharingLinkMember, "link-1", 11, ""},
+	}, rows)
+
+	assert.Equal(t, "b.docx", diff.Changes[3].ObjectName)
+	assert.Equal(t, "a.docx", diff.Changes[4].ObjectName)
+}
+
+func TestDiffListSnapshots_NoChanges(t *testing.T) {
+	snapshot := &ListSnapshotData{
+		List:  &sharepoint.List{ID: "list-1"},
+		Items: []*sharepoint.Item{{GUID: "item-a", HasUnique: true}},
+	}
+
+	diff := DiffListSnapshots(snapshot, snapshot)
+
+	assert.NotNil(t, diff.Changes)
+	assert.Empty(t, diff.Changes)
+}
+
+// newListChangesTestFactory stores a synthetic site as audit runs 1 and 2, then changes run 2:
+// list assignments swapped, an item's permissions inherited again, an item assignment's role changed,
+// and sharing links added, removed, deactivated and with members changed.
+func newListChangesTestFactory(t *testing.T) (AuditRunScopedServiceFactory, *dataset.Dataset) {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	spec := dataset.Spec{SiteID: 1, SiteURL: "https://contoso.sharepoint.com/sites/a", Lists: 2, ItemsPerList: 40, AssignmentsPerItem: 3, Users: 10, UniqueRatio: 0.5, Seed: 7}
+	d := dataset.Generate(spec)
+	mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (1, ?, 'A')`, spec.SiteURL)
+	for run := 1; run <= 2; run++ {
+		mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES (?, 1, ?, 'site_audit', 'completed')`, fmt.Sprintf("job-%d", run), spec.SiteURL)
+		mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (?, ?, 1, CURRENT_TIMESTAMP)`, run, fmt.Sprintf("job-%d", run))
+		require.NoError(t, d.Save(context.Background(), repositories.NewSqlcAuditRepository(testDB), int64(run)))
+	}
+
+	list, unique := d.Lists[0].ID, d.UniqueItems()
+	mustExec(t, testDB, `DELETE FROM role_assignments WHERE audit_run_id = 2 AND object_type = 'list' AND object_key = ? AND principal_id = 5`, list)
+	mustExec(t, testDB, `INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'list', ?, 100, ?, 2)`, list, dataset.RoleEdit)
+	mustExec(t, testDB, `UPDATE items SET has_unique = 0 WHERE audit_run_id = 2 AND item_guid = ?`, unique[0].GUID)
+	mustExec(t, testDB, `UPDATE role_assignments SET role_def_id = ? WHERE audit_run_id = 2 AND object_key = ? AND role_def_id != ?`, dataset.RoleFullControl, unique[1].GUID, dataset.RoleFullControl)
+
+	links := []struct {
+		run                int
+		id, item, uniqueID string
+		active             bool
+		members            []int
+	}{
+		{1, "link-kept", unique[2].GUID, unique[2].GUID, true, []int{100, 101}},
+		{2, "link-kept", unique[2].GUID, unique[2].GUID, true, []int{101, 102}},
+		{1, "link-removed", unique[3].GUID, unique[3].GUID, true, []int{103}},
+		{1, "link-deactivated", d.Items[1].GUID, d.Items[1].GUID, true, nil},
+		{2, "link-deactivated", d.Items[1].GUID, d.Items[1].GUID, false, nil},
+		{2, "link-added", "", d.Items[2].GUID, true, []int{104}}, // Found through the file's UniqueId only
+	}
+	for _, link := range links {
+		var itemGUID any
+		if link.item != "" {
+			itemGUID = link.item
+		}
+		mustExec(t, testDB, `INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, file_folder_unique_id, link_kind, is_active) VALUES (1, ?, ?, ?, ?, 3, ?)`,
+			link.id, link.run, itemGUID, link.uniqueID, link.active)
+		for _, member := range link.members {
+			mustExec(t, testDB, `INSERT INTO sharing_link_members (site_id, link_id, principal_id, audit_run_id) VALUES (1, ?, ?, ?)`, link.id, member, link.run)
+		}
+	}
+
+	factory := NewAuditRunScopedServiceFactory(
+		infrafactories.NewScopedRepositoryFactory(testDB),
+		repositories.NewSqlcAuditRepository(testDB),
+		0.1,
+		sharepoint.DefaultMaxAnalysisRows,
+		audit.LatestRunAnyStatus,
+	)
+	return factory, d
+}
+
+// changeFacts flattens changes to comparable strings; principals are compared by ID.
+func changeFacts(changes []*SnapshotChange) []string {
+	facts := make([]string, len(changes))
+	for i, c := range changes {
+		facts[i] = fmt.Sprintf("%s %s %s %s %q %d %d %q %q", c.Change, c.Category, c.ObjectType, c.ObjectKey, c.ObjectName, principalID(c), c.RoleDefID, c.Role, c.Detail)
+	}
+	return facts
+}
+
+func TestSiteContentService_GetListChanges_MatchesSnapshotDiff(t *testing.T) {
+	factory, d := newListChangesTestFactory(t)
+	ctx := context.Background()
+
+	base, err := factory.CreateForAuditRun(ctx, 1, "1")
+	require.NoError(t, err)
+	current, err := factory.CreateForAuditRun(ctx, 1, "2")
+	require.NoError(t, err)
+
+	for _, list := range d.Lists {
+		baseSnapshot, err := base.SiteContentService.GetListSnapshot(ctx, 1, list.ID)
+		require.NoError(t, err)
+		currentSnapshot, err := current.SiteContentService.GetListSnapshot(ctx, 1, list.ID)
+		require.NoError(t, err)
+		expected := DiffListSnapshots(baseSnapshot, currentSnapshot)
+
+		diff, err := current.SiteContentService.GetListChanges(ctx, 1, currentSnapshot.List, 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), diff.BaseAuditRunID)
+		assert.Equal(t, int64(2), diff.AuditRunID)
+		assert.Equal(t, changeFacts(expected.Changes), changeFacts(diff.Changes), "list %s", list.Title)
+	}
+
+	// Every kind of change is covered by the first list
+	diff, err := current.SiteContentService.GetListChanges(ctx, 1, d.Lists[0], 1)
+	require.NoError(t, err)
+	categories := make(map[string]bool)
+	for _, change := range diff.Changes {
+		categories[change.Category] = true
+	}
+	assert.Len(t, categories, len(changeCategoryOrder))
+}
+
+func TestSiteContentService_RowCap(t *testing.T) {
+	factory, d := newListChangesTestFactory(t)
+	ctx := context.Background()
+
+	current, err := factory.CreateForAuditRun(ctx, 1, "2")
+	require.NoError(t, err)
+	current.SiteContentService.SetMaxAnalysisRows(3)
+
+	_, err = current.SiteContentService.GetListChanges(ctx, 1, d.Lists[0], 1)
+	assert.ErrorIs(t, err, contracts.ErrRowCapExceeded)
+	_, err = current.SiteContentService.GetListSnapshot(ctx, 1, d.Lists[0].ID)
+	assert.ErrorIs(t, err, contracts.ErrRowCapExceeded)
+
+	// 0 lifts the cap
+	current.SiteContentService.SetMaxAnalysisRows(0)
+	_, err = current.SiteContentService.GetListChanges(ctx, 1, d.Lists[0], 1)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,110 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/logging"
+)
+
+// ErrStorageQuotaExceeded is returned by QueueAudit when the database is over its soft limit and
+// new audits are paused.
+var ErrStorageQuotaExceeded = errors.New("storage quota exceeded")
+
+// StorageUsage is the database's disk usage against its soft limit, with the row counts of the
+// most recent runs.
+type StorageUsage struct {
+	Disk      database.DiskUsage
+	Quota     audit.StorageQuota
+	OverLimit bool
+	Runs      []audit.RunRowCounts // Most recent first
+
+	LastMaintenance *audit.MaintenanceRun // Nil when maintenance never ran
+}
+
+// StorageMonitor measures the database file and enforces the storage soft limit.
+type StorageMonitor struct {
+	db     *database.Database
+	quota  audit.StorageQuota
+	logger *logging.Logger
+}
+
+// NewStorageMonitor creates a storage monitor.
+func NewStorageMonitor(db *database.Database, quota audit.StorageQuota) *StorageMonitor {
+	return &StorageMonitor{
+		db:     db,
+		quota:  quota,
+		logger: logging.Default().WithComponent("storage_monitor"),
+	}
+}
+
+// Usage measures the database, counts the rows stored by the runLimit most recent runs and reads
+// the last maintenance run.
+func (m *StorageMonitor) Usage(ctx context.Context, runLimit int64) (*StorageUsage, error) {
+	disk, err := m.db.DiskUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.ReadQueries().GetAuditRunRowCounts(ctx, runLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count audit run rows: %w", err)
+	}
+
+	usage := &StorageUsage{
+		Disk:      disk,
+		Quota:     m.quota,
+		OverLimit: m.quota.Exceeded(disk.TotalBytes()),
+		Runs:      make([]audit.RunRowCounts, len(rows)),
+	}
+	maintenance, err := listMaintenanceRuns(ctx, m.db, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(maintenance) > 0 {
+		usage.LastMaintenance = maintenance[0]
+	}
+
+	for i, row := range rows {
+		usage.Runs[i] = audit.RunRowCounts{
+			AuditRunID:      row.AuditRunID,
+			SiteID:          row.SiteID,
+			SiteURL:         row.SiteUrl,
+			Items:           row.Items,
+			RoleAssignments: row.RoleAssignments,
+			SharingLinks:    row.SharingLinks,
+			Principals:      row.Principals,
+		}
+	}
+	return usage, nil
+}
+
+// CheckNewAudit warns when the database is over its soft limit, and returns ErrStorageQuotaExceeded
+// when new audits are paused. An audit is not refused because the database could not be measured.
+func (m *StorageMonitor) CheckNewAudit() error {
+	if m.quota.SoftLimitBytes <= 0 {
+		return nil
+	}
+
+	disk, err := m.db.DiskUsage()
+	if err != nil {
+		m.logger.Warn("Failed to measure database for storage quota", "error", err)
+		return nil
+	}
+
+	used := disk.TotalBytes()
+	if !m.quota.Exceeded(used) {
+		return nil
+	}
+	m.logger.Warn("Database is over its storage soft limit",
+		"used_bytes", used,
+		"soft_limit_bytes", m.quota.SoftLimitBytes,
+		"pause_audits", m.quota.PauseAudits)
+	if m.quota.BlocksAudits(used) {
+		return fmt.Errorf("%w: database uses %d bytes, soft limit is %d bytes", ErrStorageQuotaExceeded, used, m.quota.SoftLimitBytes)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"spaudit/domain/audit"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageMonitor(t *testing.T) {
+	testDB := newTestDatabase(t)
+
+	for _, stmt := range []string{
+		`INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A')`,
+		`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed'), ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`,
+		`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (1, 'job-1', 1, CURRENT_TIMESTAMP), (2, 'job-2', 1, CURRENT_TIMESTAMP)`,
+		`INSERT INTO principals (site_id, principal_id, audit_run_id, title, principal_type) VALUES (1, 10, 1, 'Alice', 1), (1, 11, 1, 'Bob', 1), (1, 10, 2, 'Alice', 1)`,
+		`INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'web', 'web-1', 10, 1, 2)`,
+	} {
+		mustExec(t, testDB, stmt)
+	}
+	ctx := context.Background()
+
+	t.Run("reports disk usage and recent run row counts", func(t *testing.T) {
+		monitor := NewStorageMonitor(testDB, audit.StorageQuota{SoftLimitBytes: 1})
+
+		usage, err := monitor.Usage(ctx, 10)
+
+		require.NoError(t, err)
+		assert.Positive(t, usage.Disk.DatabaseBytes)
+		assert.True(t, usage.OverLimit)
+		assert.Equal(t, []audit.RunRowCounts{
+			{AuditRunID: 2, SiteID: 1, SiteURL: "https://contoso.sharepoint.com/sites/a", RoleAssignments: 1, Principals: 1},
+			{AuditRunID: 1, SiteID: 1, SiteURL: "https://contoso.sharepoint.com/sites/a", Principals: 2},
+		}, usage.Runs)
+	})
+
+	t.Run("over the limit only warns unless audits pause", func(t *testing.T) {
+		assert.NoError(t, NewStorageMonitor(testDB, audit.StorageQuota{SoftLimitBytes: 1}).CheckNewAudit())
+		assert.NoError(t, NewStorageMonitor(testDB, audit.StorageQuota{SoftLimitBytes: 1 << 40, PauseAudits: true}).CheckNewAudit())
+		assert.ErrorIs(t, NewStorageMonitor(testDB, audit.StorageQuota{SoftLimitBytes: 1, PauseAudits: true}).CheckNewAudit(), ErrStorageQuotaExceeded)
+	})
+}
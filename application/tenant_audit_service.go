@@ -0,0 +1,243 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/jobs"
+	"spaudit/domain/sharepoint"
+	"spaudit/logging"
+)
+
+// tenantAuditPollInterval is how often a tenant audit rechecks a site audit it waits on, in case
+// the site audit finished before it was watched.
+const tenantAuditPollInterval = 10 * time.Second
+
+// TenantSiteDiscoverer lists the site collections of a tenant through its admin center.
+type TenantSiteDiscoverer interface {
+	DiscoverSites(ctx context.Context, adminURL string) ([]*sharepoint.TenantSite, error)
+}
+
+// TenantAuditService audits every site collection of a tenant as a background job. The job queues
+// a site audit per site, a few at a time, and follows them through the job events they publish.
+type TenantAuditService struct {
+	jobService   JobService
+	auditService AuditService
+	discoverer   TenantSiteDiscoverer
+	concurrency  int
+	pollInterval time.Duration
+	logger       *logging.Logger
+
+	mu       sync.Mutex
+	watchers map[string]chan *jobs.Job // Site audit job ID -> tenant audit waiting on it
+}
+
+// NewTenantAuditService creates a tenant audit service running up to concurrency site audits at once.
+func NewTenantAuditService(jobService JobService, auditService AuditService, discoverer TenantSiteDiscoverer, concurrency int) *TenantAuditService {
+	if concurrency < 1 {
+		concurrency = audit.DefaultTenantAuditConcurrency
+	}
+	return &TenantAuditService{
+		jobService:   jobService,
+		auditService: auditService,
+		discoverer:   discoverer,
+		concurrency:  concurrency,
+		pollInterval: tenantAuditPollInterval,
+		logger:       logging.Default().WithComponent("tenant_audit"),
+		watchers:     make(map[string]chan *jobs.Job),
+	}
+}
+
+// StartTenantAudit queues a tenant audit of the tenant any of its URLs belongs to. Each site is
+// audited with the parameters, which cannot be scoped to a folder.
+func (s *TenantAuditService) StartTenantAudit(ctx context.Context, tenantURL string, parameters *audit.AuditParameters) (*jobs.Job, error) {
+	adminURL, err := sharepoint.TenantAdminURL(tenantURL)
+	if err != nil {
+		return nil, err
+	}
+	if parameters == nil {
+		parameters = audit.DefaultParameters()
+	}
+	if parameters.IsFolderScoped() {
+		return nil, fmt.Errorf("%w: a tenant audit covers whole sites", ErrInvalidAuditScope)
+	}
+	if parameters.Label != "" {
+		parameters.Label = audit.NormalizeRunLabel(parameters.Label)
+		if err := audit.ValidateRunLabel(parameters.Label); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRunLabel, err)
+		}
+	}
+	parameters.CollectorBackend = audit.NormalizeCollectorBackend(parameters.CollectorBackend)
+	if err := audit.ValidateCollectorBackend(parameters.CollectorBackend); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCollectorBackend, err)
+	}
+
+	for _, job := range s.jobService.ListJobsByType(jobs.JobTypeTenantAudit) {
+		if job.IsActive() {
+			return nil, fmt.Errorf("%w for tenant: %s", ErrAuditAlreadyQueued, job.GetSiteURL())
+		}
+	}
+	for _, job := range s.jobService.ListJobsByType(jobs.JobTypeDatabaseMaintenance) {
+		if job.IsActive() {
+			return nil, fmt.Errorf("%w, try again when job %s finishes", ErrMaintenanceRunning, job.ID)
+		}
+	}
+
+	job, err := s.jobService.StartJob(jobs.JobTypeTenantAudit, JobParams{
+		"siteURL":     adminURL,
+		"description": fmt.Sprintf("Tenant audit: %s", adminURL),
+		"parameters":  parameters,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start job: %w", err)
+	}
+	s.logger.Info("Tenant audit queued", "job_id", job.ID, "admin_url", adminURL)
+	return job, nil
+}
+
+// JobFinished hands a finished job to the tenant audit waiting on it, if any. Subscribed to the
+// job completed, failed and cancelled events.
+func (s *TenantAuditService) JobFinished(job *jobs.Job) {
+	if job == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if finished, ok := s.watchers[job.ID]; ok {
+		select {
+		case finished <- job:
+		default:
+		}
+	}
+}
+
+// RunTenantAudit lists the tenant's site collections and audits the auditable ones, reporting
+// progress as site audits finish. A site audit failing does not fail the tenant audit unless all
+// of them fail; cancelling the tenant audit cancels the site audits it is waiting on.
+func (s *TenantAuditService) RunTenantAudit(ctx context.Context, job *jobs.Job, progress ProgressCallback) (*audit.TenantAuditResult, error) {
+	result := &audit.TenantAuditResult{AdminURL: job.GetSiteURL(), Sites: []*audit.TenantSiteAudit{}}
+	parameters := job.GetAuditParameters()
+	if parameters == nil {
+		parameters = audit.DefaultParameters()
+	}
+
+	progress("Discovering Sites", "Listing the tenant's site collections", 0, 0, 0)
+	sites, err := s.discoverer.DiscoverSites(ctx, result.AdminURL)
+	if err != nil {
+		return result, fmt.Errorf("discover tenant sites: %w", err)
+	}
+	result.SitesFound = len(sites)
+	for _, site := range sites {
+		if site.IsAuditable() {
+			result.Sites = append(result.Sites, &audit.TenantSiteAudit{SiteURL: site.URL, Title: site.Title, Status: audit.TenantSiteSkipped})
+		}
+	}
+	s.logger.Info("Discovered tenant sites", "job_id", job.ID, "sites_found", result.SitesFound, "auditable", len(result.Sites))
+
+	total := len(result.Sites)
+	var mu sync.Mutex // Serializes progress reports and result updates
+	done := 0
+	report := func() {
+		percentage := 100
+		if total > 0 {
+			percentage = done * 100 / total
+		}
+		progress("Auditing Sites", fmt.Sprintf("%d of %d sites audited, %d failed", done, total, result.Count(audit.TenantSiteFailed)), percentage, done, total)
+	}
+	mu.Lock()
+	report()
+	mu.Unlock()
+
+	slots := make(chan struct{}, s.concurrency)
+	var wg sync.WaitGroup
+queue:
+	for _, siteAudit := range result.Sites {
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			break queue
+		}
+		wg.Add(1)
+		go func(siteAudit *audit.TenantSiteAudit) {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			jobID, status, message := s.auditSite(ctx, siteAudit.SiteURL, parameters)
+			mu.Lock()
+			defer mu.Unlock()
+			siteAudit.JobID, siteAudit.Status, siteAudit.Error = jobID, status, message
+			done++
+			report()
+		}(siteAudit)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+	if failed := result.Count(audit.TenantSiteFailed); total > 0 && failed == total {
+		return result, fmt.Errorf("all %d site audits failed", total)
+	}
+	return result, nil
+}
+
+// auditSite queues a site audit and waits for it to finish, returning its job ID, outcome and error.
+func (s *TenantAuditService) auditSite(ctx context.Context, siteURL string, parameters *audit.AuditParameters) (string, string, string) {
+	siteParameters := *parameters
+	request, err := s.auditService.QueueAudit(ctx, siteURL, &siteParameters)
+	if errors.Is(err, ErrAuditAlreadyQueued) {
+		return "", audit.TenantSiteSkipped, err.Error()
+	}
+	if err != nil {
+		s.logger.Warn("Failed to queue site audit", "site_url", siteURL, "error", err)
+		return "", audit.TenantSiteFailed, err.Error()
+	}
+
+	child, err := s.waitForJob(ctx, request.ID)
+	if err != nil {
+		if _, cancelErr := s.jobService.CancelJob(request.ID); cancelErr != nil {
+			s.logger.Warn("Failed to cancel site audit", "job_id", request.ID, "error", cancelErr)
+		}
+		return request.ID, audit.TenantSiteCancelled, ""
+	}
+	switch child.Status {
+	case jobs.JobStatusCompleted:
+		return child.ID, audit.TenantSiteCompleted, ""
+	case jobs.JobStatusCancelled:
+		return child.ID, audit.TenantSiteCancelled, ""
+	default:
+		return child.ID, audit.TenantSiteFailed, child.Error
+	}
+}
+
+// waitForJob waits until a job finishes, woken by JobFinished and rechecking every pollInterval.
+func (s *TenantAuditService) waitForJob(ctx context.Context, jobID string) (*jobs.Job, error) {
+	finished := make(chan *jobs.Job, 1)
+	s.mu.Lock()
+	s.watchers[jobID] = finished
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.watchers, jobID)
+		s.mu.Unlock()
+	}()
+
+	ticker := time.NewTicker(s.pollInterval)
+	defer ticker.Stop()
+	for {
+		if job, ok := s.jobService.GetJob(jobID); ok && job.IsComplete() {
+			return job, nil
+		}
+		select {
+		case job := <-finished:
+			return job, nil
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
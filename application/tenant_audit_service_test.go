@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/jobs"
+	"spaudit/domain/sharepoint"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTenantSiteDiscoverer struct {
+	sites []*sharepoint.TenantSite
+}
+
+func (d *fakeTenantSiteDiscoverer) DiscoverSites(ctx context.Context, adminURL string) ([]*sharepoint.TenantSite, error) {
+	return d.sites, nil
+}
+
+// fakeTenantJobs stands in for the job and audit services: queued site audits finish on their own,
+// with the outcome set for their site.
+type fakeTenantJobs struct {
+	JobService
+	AuditService
+
+	mu       sync.Mutex
+	outcomes map[string]jobs.JobStatus // Site URL -> how its audit ends; missing sites are already queued
+	jobs     map[string]*jobs.Job
+	started  []*jobs.Job
+	finished func(*jobs.Job)
+}
+
+func (f *fakeTenantJobs) QueueAudit(ctx context.Context, siteURL string, parameters *audit.AuditParameters) (*audit.AuditRequest, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	status, ok := f.outcomes[siteURL]
+	if !ok {
+		return nil, fmt.Errorf("%w for site: %s", ErrAuditAlreadyQueued, siteURL)
+	}
+	job := &jobs.Job{ID: "job-" + siteURL, Type: jobs.JobTypeSiteAudit, Status: jobs.JobStatusRunning}
+	f.jobs[job.ID] = job
+
+	// Published as the job service does, before the job is saved as finished
+	finishedJob := *job
+	finishedJob.Status = status
+	if status == jobs.JobStatusFailed {
+		finishedJob.Error = "access denied"
+	}
+	go func() {
+		time.Sleep(time.Millisecond)
+		f.finished(&finishedJob)
+	}()
+	return &audit.AuditRequest{ID: job.ID, SiteURL: siteURL}, nil
+}
+
+func (f *fakeTenantJobs) GetJob(jobID string) (*jobs.Job, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	job, ok := f.jobs[jobID]
+	if !ok {
+		return nil, false
+	}
+	copied := *job
+	return &copied, true
+}
+
+func (f *fakeTenantJobs) ListJobsByType(jobType jobs.JobType) []*jobs.Job {
+	var matching []*jobs.Job
+	for _, job := range f.started {
+		if job.Type == jobType {
+			matching = append(matching, job)
+		}
+	}
+	return matching
+}
+
+func (f *fakeTenantJobs) StartJob(jobType jobs.JobType, params JobParams) (*jobs.Job, error) {
+	job := &jobs.Job{ID: fmt.Sprintf("%s-%d", jobType, len(f.started)+1), Type: jobType, Status: jobs.JobStatusPending}
+	job.Context = jobs.AuditJobContext{SiteURL: params["siteURL"].(string)}
+	f.started = append(f.started, job)
+	return job, nil
+}
+
+func newTestTenantAuditService(fake *fakeTenantJobs, sites []*sharepoint.TenantSite) *TenantAuditService {
+	service := NewTenantAuditService(fake, fake, &fakeTenantSiteDiscoverer{sites: sites}, 2)
+	service.pollInterval = 10 * time.Millisecond
+	fake.finished = service.JobFinished
+	return service
+}
+
+func TestTenantAuditService_RunTenantAudit(t *testing.T) {
+	fake := &fakeTenantJobs{
+		outcomes: map[string]jobs.JobStatus{
+			"https://contoso.sharepoint.com/sites/finance": jobs.JobStatusCompleted,
+			"https://contoso.sharepoint.com/sites/hr":      jobs.JobStatusFailed,
+		},
+		jobs: make(map[string]*jobs.Job),
+	}
+	service := newTestTenantAuditService(fake, []*sharepoint.TenantSite{
+		{URL: "https://contoso.sharepoint.com/sites/finance", Title: "Finance", Template: "GROUP#0"},
+		{URL: "https://contoso.sharepoint.com/sites/hr", Title: "HR", Template: "STS#3"},
+		{URL: "https://contoso.sharepoint.com/sites/legal", Title: "Legal", Template: "STS#3"},
+		{URL: "https://contoso.sharepoint.com/sites/old-name", Template: "REDIRECTSITE#0"},
+	})
+
+	job := &jobs.Job{ID: "tenant-1", Type: jobs.JobTypeTenantAudit, Context: jobs.AuditJobContext{SiteURL: "https://contoso-admin.sharepoint.com"}}
+	var lastPercentage, lastDone, lastTotal int
+	progress := func(stage, description string, percentage, itemsDone, itemsTotal int) {
+		lastPercentage, lastDone, lastTotal = percentage, itemsDone, itemsTotal
+	}
+
+	result, err := service.RunTenantAudit(context.Background(), job, progress)
+	require.NoError(t, err)
+	assert.Equal(t, 4, result.SitesFound)
+	require.Len(t, result.Sites, 3, "redirect sites are not audited")
+	assert.Equal(t, 100, lastPercentage)
+	assert.Equal(t, 3, lastDone)
+	assert.Equal(t, 3, lastTotal)
+
+	assert.Equal(t, audit.TenantSiteCompleted, result.Sites[0].Status)
+	assert.Equal(t, "job-https://contoso.sharepoint.com/sites/finance", result.Sites[0].JobID)
+	assert.Equal(t, audit.TenantSiteFailed, result.Sites[1].Status)
+	assert.Equal(t, "access denied", result.Sites[1].Error)
+	assert.Equal(t, audit.TenantSiteSkipped, result.Sites[2].Status, "sites already being audited are skipped")
+	assert.Empty(t, result.Sites[2].JobID)
+}
+
+func TestTenantAuditService_RunTenantAudit_AllFailed(t *testing.T) {
+	fake := &fakeTenantJobs{
+		outcomes: map[string]jobs.JobStatus{"https://contoso.sharepoint.com/sites/hr": jobs.JobStatusFailed},
+		jobs:     make(map[string]*jobs.Job),
+	}
+	service := newTestTenantAuditService(fake, []*sharepoint.TenantSite{{URL: "https://contoso.sharepoint.com/sites/hr"}})
+
+	job := &jobs.Job{ID: "tenant-1", Type: jobs.JobTypeTenantAudit, Context: jobs.AuditJobContext{SiteURL: "https://contoso-admin.sharepoint.com"}}
+	result, err := service.RunTenantAudit(context.Background(), job, func(string, string, int, int, int) {})
+	assert.ErrorContains(t, err, "all 1 site audits failed")
+	require.NotNil(t, result)
+	assert.Equal(t, 1, result.Count(audit.TenantSiteFailed))
+}
+
+func TestTenantAuditService_StartTenantAudit(t *testing.T) {
+	fake := &fakeTenantJobs{jobs: make(map[string]*jobs.Job)}
+	service := newTestTenantAuditService(fake, nil)
+	ctx := context.Background()
+
+	_, err := service.StartTenantAudit(ctx, "https://intranet.contoso.com", nil)
+	assert.ErrorIs(t, err, sharepoint.ErrInvalidTenantURL)
+
+	scoped := audit.DefaultParameters()
+	scoped.FolderPath = "/sites/finance/Shared Documents"
+	_, err = service.StartTenantAudit(ctx, "https://contoso.sharepoint.com", scoped)
+	assert.ErrorIs(t, err, ErrInvalidAuditScope)
+
+	job, err := service.StartTenantAudit(ctx, "https://contoso.sharepoint.com/sites/finance", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "https://contoso-admin.sharepoint.com", job.GetSiteURL())
+
+	_, err = service.StartTenantAudit(ctx, "https://contoso-admin.sharepoint.com", nil)
+	assert.ErrorIs(t, err, ErrAuditAlreadyQueued, "one tenant audit at a time")
+}
@@ -0,0 +1,389 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/events"
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// Errors returned when managing webhook deliveries.
+var (
+	ErrInvalidWebhookDeliveryFilter = errors.New("invalid webhook delivery filter")
+	ErrWebhookDeliveryNotFound      = errors.New("webhook delivery not found")
+	ErrWebhookDeliveryNotFailed     = errors.New("only failed webhook deliveries can be retried")
+)
+
+// Timing and limits of webhook deliveries
+const (
+	webhookDeliveryPoll          = 5 * time.Second  // How often deliveries waiting out a backoff are checked
+	webhookDeliveryBatch         = 50               // Deliveries attempted at a time
+	webhookDeliveryTimeout       = 10 * time.Second // Endpoints answering slower count as failed
+	webhookResponseExcerptLength = 256              // Bytes of a failed response kept as its error
+)
+
+// WebhookNotificationService posts job completions and failures and finding alerts to outbound
+// webhook endpoints, such as chat or ticketing integrations. Each event is queued once per endpoint in
+// a delivery log, then posted as signed JSON and retried with backoff until the endpoint accepts it or
+// the attempts run out.
+type WebhookNotificationService struct {
+	db          *database.Database
+	endpoints   []string
+	secret      []byte
+	policy      events.RetryPolicy
+	minSeverity audit.Severity
+	client      *http.Client
+	now         func() time.Time
+	logger      *logging.Logger
+
+	wake chan struct{} // Signals newly queued deliveries
+}
+
+// NewWebhookNotificationService creates a webhook notification service posting to endpoints, signing
+// deliveries with secret unless it is empty. Finding alerts below minSeverity are not posted. Without
+// endpoints nothing is queued.
+func NewWebhookNotificationService(db *database.Database, endpoints []string, secret string, policy events.RetryPolicy, minSeverity audit.Severity) *WebhookNotificationService {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	return &WebhookNotificationService{
+		db:          db,
+		endpoints:   endpoints,
+		secret:      []byte(secret),
+		policy:      policy,
+		minSeverity: minSeverity,
+		client:      &http.Client{Timeout: webhookDeliveryTimeout},
+		now:         func() time.Time { return time.Now().UTC() },
+		logger:      logging.Default().WithComponent("webhook_notification_service"),
+		wake:        make(chan struct{}, 1),
+	}
+}
+
+// Enabled reports whether any webhook endpoint is configured.
+func (s *WebhookNotificationService) Enabled() bool {
+	return len(s.endpoints) > 0
+}
+
+// EnqueueJobEvent queues a job completion or failure for every endpoint; other job events are not
+// posted. Queueing an event again queues nothing.
+func (s *WebhookNotificationService) EnqueueJobEvent(ctx context.Context, event *events.RecordedJobEvent) error {
+	var eventType events.WebhookEventType
+	switch event.Type {
+	case events.JobEventCompleted:
+		eventType = events.WebhookJobCompleted
+	case events.JobEventFailed:
+		eventType = events.WebhookJobFailed
+	default:
+		return nil
+	}
+
+	return s.enqueue(ctx, fmt.Sprintf("job_event:%d", event.Sequence), events.WebhookPayload{
+		Type:       eventType,
+		OccurredAt: event.OccurredAt,
+		Job: &events.WebhookJob{
+			ID:         event.JobID,
+			Type:       string(event.JobType),
+			Status:     string(event.JobStatus),
+			SiteURL:    event.SiteURL,
+			AuditRunID: event.AuditRunID,
+			Error:      event.Error,
+			Sequence:   event.Sequence,
+		},
+	})
+}
+
+// NotifyFindingAlert queues a finding alert of at least the minimum severity for every endpoint.
+// Alerts are announced once delivered, so alerts held back by quiet hours are posted when they end.
+func (s *WebhookNotificationService) NotifyFindingAlert(alert *audit.FindingAlert) {
+	if alert.Severity.Rank() < s.minSeverity.Rank() {
+		return
+	}
+	occurredAt := alert.CreatedAt
+	if alert.DeliveredAt != nil {
+		occurredAt = *alert.DeliveredAt
+	}
+
+	err := s.enqueue(context.Background(), fmt.Sprintf("finding_alert:%d", alert.ID), events.WebhookPayload{
+		Type:       events.WebhookFindingCreated,
+		OccurredAt: occurredAt,
+		Finding: &events.WebhookFinding{
+			AlertID:     alert.ID,
+			SiteID:      alert.SiteID,
+			AuditRunID:  alert.AuditRunID,
+			Fingerprint: alert.Fingerprint,
+			Category:    alert.Category,
+			Severity:    string(alert.Severity),
+			Message:     alert.Message,
+		},
+	})
+	if err != nil {
+		s.logger.Error("Failed to queue finding alert webhook", "alert_id", alert.ID, "error", err)
+	}
+}
+
+// enqueue records a delivery of the payload for every endpoint and wakes the delivery loop.
+func (s *WebhookNotificationService) enqueue(ctx context.Context, eventKey string, payload events.WebhookPayload) error {
+	if !s.Enabled() {
+		return nil
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	now := s.now()
+	err = s.db.WithTx(func(queries *db.Queries) error {
+		for _, endpoint := range s.endpoints {
+			if err := queries.CreateWebhookDelivery(ctx, db.CreateWebhookDeliveryParams{
+				EndpointUrl: endpoint,
+				EventType:   string(payload.Type),
+				EventKey:    eventKey,
+				Payload:     string(body),
+				CreatedAt:   now,
+			}); err != nil {
+				return fmt.Errorf("failed to queue webhook delivery: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	s.signal()
+	return nil
+}
+
+// signal wakes the delivery loop, unless it is already woken.
+func (s *WebhookNotificationService) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// DeliverDue attempts the pending deliveries whose next attempt is due, oldest first, and returns how
+// many the endpoints accepted.
+func (s *WebhookNotificationService) DeliverDue(ctx context.Context) (int, error) {
+	rows, err := s.db.ReadQueries().GetDueWebhookDeliveries(ctx, db.GetDueWebhookDeliveriesParams{
+		Now:        sql.NullTime{Time: s.now(), Valid: true},
+		LimitCount: webhookDeliveryBatch,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due webhook deliveries: %w", err)
+	}
+
+	delivered := 0
+	for _, row := range rows {
+		if ctx.Err() != nil {
+			return delivered, ctx.Err()
+		}
+		delivery, err := s.attempt(ctx, newWebhookDelivery(row))
+		if err != nil {
+			return delivered, err
+		}
+		if delivery.Status == events.WebhookDeliveryDelivered {
+			delivered++
+		}
+	}
+	if len(rows) == webhookDeliveryBatch {
+		s.signal()
+	}
+	return delivered, nil
+}
+
+// attempt posts a delivery once and records the outcome: delivered, pending until its backoff ends,
+// or failed once its attempts run out.
+func (s *WebhookNotificationService) attempt(ctx context.Context, delivery *events.WebhookDelivery) (*events.WebhookDelivery, error) {
+	now := s.now()
+	responseStatus, postErr := s.post(ctx, delivery, now)
+	delivery.Attempts++
+	delivery.ResponseStatus = responseStatus
+	delivery.NextAttemptAt = nil
+
+	switch {
+	case postErr == nil:
+		delivery.Status = events.WebhookDeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+	case delivery.Attempts >= s.policy.MaxAttempts:
+		delivery.Status = events.WebhookDeliveryFailed
+		delivery.LastError = postErr.Error()
+		s.logger.Error("Webhook delivery failed, giving up", "delivery_id", delivery.ID, "endpoint", delivery.EndpointURL,
+			"event_type", delivery.EventType, "attempts", delivery.Attempts, "error", postErr)
+	default:
+		next := now.Add(s.policy.Backoff(delivery.Attempts))
+		delivery.Status = events.WebhookDeliveryPending
+		delivery.LastError = postErr.Error()
+		delivery.NextAttemptAt = &next
+		s.logger.Warn("Webhook delivery failed, retrying", "delivery_id", delivery.ID, "endpoint", delivery.EndpointURL,
+			"event_type", delivery.EventType, "attempt", delivery.Attempts, "retry_at", next, "error", postErr)
+	}
+
+	params := db.RecordWebhookDeliveryAttemptParams{
+		Status:         delivery.Status,
+		Attempts:       int64(delivery.Attempts),
+		ResponseStatus: int64(delivery.ResponseStatus),
+		LastError:      delivery.LastError,
+		DeliveryID:     delivery.ID,
+	}
+	if delivery.NextAttemptAt != nil {
+		params.NextAttemptAt = sql.NullTime{Time: *delivery.NextAttemptAt, Valid: true}
+	}
+	if delivery.DeliveredAt != nil {
+		params.DeliveredAt = sql.NullTime{Time: *delivery.DeliveredAt, Valid: true}
+	}
+	if err := s.db.Queries().RecordWebhookDeliveryAttempt(ctx, params); err != nil {
+		return nil, fmt.Errorf("failed to record webhook delivery %d: %w", delivery.ID, err)
+	}
+	return delivery, nil
+}
+
+// post sends a delivery's payload to its endpoint, returning the response status, 0 when none came.
+// Any status outside 2xx is an error carrying the start of the response body.
+func (s *WebhookNotificationService) post(ctx context.Context, delivery *events.WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.EndpointURL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(events.WebhookEventHeader, string(delivery.EventType))
+	req.Header.Set(events.WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(events.WebhookTimestampHeader, strconv.FormatInt(now.Unix(), 10))
+	if len(s.secret) > 0 {
+		req.Header.Set(events.WebhookSignatureHeader, events.SignWebhookPayload(s.secret, now, delivery.Payload))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, nil
+	}
+	excerpt, _ := io.ReadAll(io.LimitReader(resp.Body, webhookResponseExcerptLength))
+	message := fmt.Sprintf("endpoint answered %s", resp.Status)
+	if text := strings.TrimSpace(string(excerpt)); text != "" {
+		message += ": " + text
+	}
+	return resp.StatusCode, errors.New(message)
+}
+
+// Run posts queued deliveries as they are queued or their backoff ends, until ctx is cancelled.
+// Without endpoints it posts what earlier runs left pending and returns.
+func (s *WebhookNotificationService) Run(ctx context.Context) {
+	s.deliverDue(ctx)
+
+	ticker := time.NewTicker(webhookDeliveryPoll)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-ticker.C:
+		}
+		s.deliverDue(ctx)
+	}
+}
+
+// deliverDue attempts due deliveries, logging failures for the next tick to retry.
+func (s *WebhookNotificationService) deliverDue(ctx context.Context) {
+	delivered, err := s.DeliverDue(ctx)
+	if err != nil && ctx.Err() == nil {
+		s.logger.Error("Failed to deliver webhooks", "error", err)
+	}
+	if delivered > 0 {
+		s.logger.Debug("Delivered webhooks", "count", delivered)
+	}
+}
+
+// GetDeliveries returns the most recent webhook deliveries, newest first, of the given status unless
+// it is empty.
+func (s *WebhookNotificationService) GetDeliveries(ctx context.Context, status string, limit int64) ([]*events.WebhookDelivery, error) {
+	switch status {
+	case "", events.WebhookDeliveryPending, events.WebhookDeliveryDelivered, events.WebhookDeliveryFailed:
+	default:
+		return nil, fmt.Errorf("%w: status must be pending, delivered or failed", ErrInvalidWebhookDeliveryFilter)
+	}
+	rows, err := s.db.ReadQueries().GetWebhookDeliveries(ctx, db.GetWebhookDeliveriesParams{Status: status, LimitCount: limit})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+	deliveries := make([]*events.WebhookDelivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = newWebhookDelivery(row)
+	}
+	return deliveries, nil
+}
+
+// RetryDelivery queues a failed delivery for one more attempt, made at once.
+func (s *WebhookNotificationService) RetryDelivery(ctx context.Context, deliveryID int64) (*events.WebhookDelivery, error) {
+	now := s.now()
+	retried, err := s.db.Queries().RetryWebhookDelivery(ctx, db.RetryWebhookDeliveryParams{
+		NextAttemptAt: sql.NullTime{Time: now, Valid: true},
+		DeliveryID:    deliveryID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to retry webhook delivery: %w", err)
+	}
+	delivery, err := s.getDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	if retried == 0 {
+		return nil, fmt.Errorf("%w: delivery %d is %s", ErrWebhookDeliveryNotFailed, deliveryID, delivery.Status)
+	}
+	s.signal()
+	return delivery, nil
+}
+
+// getDelivery returns a webhook delivery, or ErrWebhookDeliveryNotFound.
+func (s *WebhookNotificationService) getDelivery(ctx context.Context, deliveryID int64) (*events.WebhookDelivery, error) {
+	row, err := s.db.ReadQueries().GetWebhookDelivery(ctx, deliveryID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: %d", ErrWebhookDeliveryNotFound, deliveryID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	return newWebhookDelivery(row), nil
+}
+
+// newWebhookDelivery converts a webhook delivery row.
+func newWebhookDelivery(row db.WebhookDelivery) *events.WebhookDelivery {
+	delivery := &events.WebhookDelivery{
+		ID:             row.DeliveryID,
+		EndpointURL:    row.EndpointUrl,
+		EventType:      events.WebhookEventType(row.EventType),
+		EventKey:       row.EventKey,
+		Payload:        []byte(row.Payload),
+		Status:         row.Status,
+		Attempts:       int(row.Attempts),
+		ResponseStatus: int(row.ResponseStatus),
+		LastError:      row.LastError,
+		CreatedAt:      row.CreatedAt,
+	}
+	if row.NextAttemptAt.Valid {
+		nextAttemptAt := row.NextAttemptAt.Time
+		delivery.NextAttemptAt = &nextAttemptAt
+	}
+	if row.DeliveredAt.Valid {
+		deliveredAt := row.DeliveredAt.Time
+		delivery.DeliveredAt = &deliveredAt
+	}
+	return delivery
+}
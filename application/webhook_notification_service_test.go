@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/events"
+	"spaudit/domain/jobs"
+)
+
+// webhookEndpoint records the deliveries posted to it, answering with status.
+type webhookEndpoint struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func (e *webhookEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.requests = append(e.requests, r)
+	e.bodies = append(e.bodies, body)
+	w.WriteHeader(e.status)
+	if e.status >= 300 {
+		_, _ = w.Write([]byte("endpoint unavailable"))
+	}
+}
+
+func newWebhookTestService(t *testing.T, endpoints ...string) *WebhookNotificationService {
+	t.Helper()
+	testDB := newTestDatabase(t)
+
+	policy := events.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Minute}
+	return NewWebhookNotificationService(testDB, endpoints, "secret", policy, audit.SeverityHigh)
+}
+
+func completedJobEvent(sequence int64) *events.RecordedJobEvent {
+	return &events.RecordedJobEvent{
+		Sequence:   sequence,
+		Type:       events.JobEventCompleted,
+		JobID:      "job-1",
+		JobType:    jobs.JobTypeSiteAudit,
+		JobStatus:  jobs.JobStatusCompleted,
+		SiteURL:    "https://contoso.sharepoint.com/sites/finance",
+		AuditRunID: 7,
+		OccurredAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhookNotificationService_DeliversSignedJobEvents(t *testing.T) {
+	ctx := context.Background()
+	endpoint := &webhookEndpoint{status: http.StatusNoContent}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	service := newWebhookTestService(t, server.URL+"/a", server.URL+"/b")
+	now := time.Date(2025, 3, 1, 12, 0, 5, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	require.NoError(t, service.EnqueueJobEvent(ctx, completedJobEvent(42)))
+	require.NoError(t, service.EnqueueJobEvent(ctx, completedJobEvent(42)), "a redelivered event is queued once")
+	cancelled := completedJobEvent(43)
+	cancelled.Type = events.JobEventCancelled
+	require.NoError(t, service.EnqueueJobEvent(ctx, cancelled))
+
+	delivered, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, delivered)
+	require.Len(t, endpoint.requests, 2)
+
+	request, body := endpoint.requests[0], endpoint.bodies[0]
+	assert.Equal(t, "/a", request.URL.Path)
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	assert.Equal(t, string(events.WebhookJobCompleted), request.Header.Get(events.WebhookEventHeader))
+	assert.Equal(t, strconv.FormatInt(now.Unix(), 10), request.Header.Get(events.WebhookTimestampHeader))
+	assert.Equal(t, events.SignWebhookPayload([]byte("secret"), now, body), request.Header.Get(events.WebhookSignatureHeader))
+
+	var payload events.WebhookPayload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, events.WebhookJobCompleted, payload.Type)
+	require.NotNil(t, payload.Job)
+	assert.Equal(t, "job-1", payload.Job.ID)
+	assert.Equal(t, int64(7), payload.Job.AuditRunID)
+	assert.Equal(t, int64(42), payload.Job.Sequence)
+	assert.Nil(t, payload.Finding)
+
+	deliveries, err := service.GetDeliveries(ctx, events.WebhookDeliveryDelivered, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.Equal(t, "job_event:42", deliveries[0].EventKey)
+	assert.Equal(t, 1, deliveries[0].Attempts)
+	assert.Equal(t, http.StatusNoContent, deliveries[0].ResponseStatus)
+	assert.Nil(t, deliveries[0].NextAttemptAt)
+	require.NotNil(t, deliveries[0].DeliveredAt)
+
+	delivered, err = service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, delivered, "delivered events are posted once")
+
+	_, err = service.GetDeliveries(ctx, "lost", 10)
+	assert.ErrorIs(t, err, ErrInvalidWebhookDeliveryFilter)
+}
+
+func TestWebhookNotificationService_FindingAlerts(t *testing.T) {
+	ctx := context.Background()
+	endpoint := &webhookEndpoint{status: http.StatusOK}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+	service := newWebhookTestService(t, server.URL)
+
+	service.NotifyFindingAlert(&audit.FindingAlert{ID: 1, SiteID: 1, AuditRunID: 7, Category: "list_added", Severity: audit.SeverityLow})
+	service.NotifyFindingAlert(&audit.FindingAlert{ID: 2, SiteID: 1, AuditRunID: 7, Fingerprint: "default_link_anyone:web",
+		Category: "default_link_anyone", Severity: audit.SeverityCritical, Message: "anyone links"})
+
+	delivered, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered, "alerts below the minimum severity are not posted")
+
+	var payload events.WebhookPayload
+	require.NoError(t, json.Unmarshal(endpoint.bodies[0], &payload))
+	assert.Equal(t, events.WebhookFindingCreated, payload.Type)
+	require.NotNil(t, payload.Finding)
+	assert.Equal(t, int64(2), payload.Finding.AlertID)
+	assert.Equal(t, "critical", payload.Finding.Severity)
+
+	unconfigured := newWebhookTestService(t)
+	assert.False(t, unconfigured.Enabled())
+	require.NoError(t, unconfigured.EnqueueJobEvent(ctx, completedJobEvent(1)))
+	deliveries, err := unconfigured.GetDeliveries(ctx, "", 10)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries)
+}
+
+func TestWebhookNotificationService_RetriesFailedDeliveries(t *testing.T) {
+	ctx := context.Background()
+	endpoint := &webhookEndpoint{status: http.StatusServiceUnavailable}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+
+	service := newWebhookTestService(t, server.URL)
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+	require.NoError(t, service.EnqueueJobEvent(ctx, completedJobEvent(1)))
+
+	_, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	deliveries, err := service.GetDeliveries(ctx, events.WebhookDeliveryPending, 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	delivery := deliveries[0]
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+	assert.Contains(t, delivery.LastError, "endpoint unavailable")
+	require.NotNil(t, delivery.NextAttemptAt)
+	assert.True(t, delivery.NextAttemptAt.Equal(now.Add(time.Minute)))
+
+	_, err = service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Len(t, endpoint.requests, 1, "a delivery waits out its backoff")
+
+	_, err = service.RetryDelivery(ctx, delivery.ID)
+	assert.ErrorIs(t, err, ErrWebhookDeliveryNotFailed, "pending deliveries are retried on their own")
+
+	now = now.Add(time.Minute)
+	_, err = service.DeliverDue(ctx)
+	require.NoError(t, err)
+	failed, err := service.GetDeliveries(ctx, events.WebhookDeliveryFailed, 10)
+	require.NoError(t, err)
+	require.Len(t, failed, 1, "a delivery fails once its attempts run out")
+	assert.Equal(t, 2, failed[0].Attempts)
+	assert.Nil(t, failed[0].NextAttemptAt)
+
+	endpoint.status = http.StatusOK
+	retried, err := service.RetryDelivery(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, events.WebhookDeliveryPending, retried.Status)
+	delivered, err := service.DeliverDue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+
+	deliveries, err = service.GetDeliveries(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, events.WebhookDeliveryDelivered, deliveries[0].Status)
+	assert.Equal(t, 3, deliveries[0].Attempts)
+	assert.Empty(t, deliveries[0].LastError)
+
+	_, err = service.RetryDelivery(ctx, 999)
+	assert.ErrorIs(t, err, ErrWebhookDeliveryNotFound)
+}
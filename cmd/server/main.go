@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -17,19 +18,24 @@ import (
 
 	"spaudit/application"
 	"spaudit/database"
+	"spaudit/domain/audit"
 	"spaudit/domain/contracts"
+	domainevents "spaudit/domain/events"
 	jobsdom "spaudit/domain/jobs"
 	"spaudit/gen/db"
 	"spaudit/infrastructure/config"
+	"spaudit/infrastructure/notify"
 	infrafactories "spaudit/infrastructure/factories"
 	"spaudit/infrastructure/repositories"
 	"spaudit/interfaces/web/handlers"
 	"spaudit/interfaces/web/presenters"
 	templates "spaudit/interfaces/web/templates"
 	"spaudit/logging"
+	"spaudit/platform/compress"
 	"spaudit/platform/events"
 	"spaudit/platform/executors"
 	"spaudit/platform/factories"
+	"spaudit/spauth"
 )
 
 func main() {
@@ -42,14 +48,14 @@ func main() {
 	cfg := config.LoadAppConfigFromEnv()
 
 	// Initialize logging
-	logger := initializeLogging(cfg)
+	logger, logTail := initializeLogging(cfg)
 
 	// Initialize database
 	db := initializeDatabase(cfg, logger)
 	defer db.Close()
 
 	// Build dependencies with app context
-	deps := buildDependencies(appCtx, db, logger)
+	deps := buildDependencies(appCtx, cfg, db, logger, logTail)
 
 	// Setup routes and start server
 	router := setupRoutes(deps, cfg)
@@ -64,7 +70,39 @@ type ApplicationServices struct {
 	PermissionService   *application.PermissionService
 	SiteBrowsingService *application.SiteBrowsingService
 	EventBus            *events.JobEventBus
+	EventDispatcher     *events.DurableDispatcher
 	ServiceFactory      application.AuditRunScopedServiceFactory
+	RunManifestService  *application.RunManifestService
+	RunArtifactService  *application.RunArtifactService
+	ItemLookupService   *application.ItemLookupService
+	BaselineService     *application.BaselineService
+	AuditDiffService    *application.AuditDiffService
+	MigrationService    *application.MigrationAssessmentService
+	GuestService        *application.GuestCorrelationService
+	PrincipalAccessService *application.PrincipalAccessService
+	OffboardingService  *application.OffboardingService
+	HubService          *application.HubRollupService
+	OrgUnitService      *application.OrgUnitService
+	BadgeService        *application.SiteBadgeService
+	ExceptionService    *application.PermissionExceptionService
+	AttestationService  *application.AttestationService
+	ReportShareService  *application.ReportShareService
+	FindingSeverities   *application.FindingSeverities
+	PolicyPackService   *application.PolicyPackService
+	SiteSensitivityService *application.SiteSensitivityService
+	FindingAlertService *application.FindingAlertService
+	WebhookNotificationService *application.WebhookNotificationService
+	RunNotificationService *application.RunNotificationService
+	PolicyFindingService *application.PolicyFindingService
+	StorageMonitor      *application.StorageMonitor
+	MaintenanceService  *application.DatabaseMaintenanceService
+	TenantAuditService  *application.TenantAuditService
+	ListWebhookService  *application.ListWebhookService
+	ListMonitorService  *application.ListMonitorService
+	AuditScheduleService *application.AuditScheduleService
+	JobEventService     *application.JobEventService
+
+	PostAuditReportService *application.PostAuditReportService
 }
 
 // PresentationLayer groups all presentation components
@@ -75,12 +113,38 @@ type PresentationLayer struct {
 	ListPresenter       *presenters.ListPresenter
 	PermissionPresenter *presenters.PermissionPresenter
 	SitePresenter       *presenters.SitePresenter
+	ScriptingPresenter  *presenters.ScriptingPresenter
 
 	// Handlers
-	ListHandlers  *handlers.ListHandlers
-	AuditHandlers *handlers.AuditHandlers
-	JobHandlers   *handlers.JobHandlers
-	SSEManager    *handlers.SSEManager
+	ListHandlers      *handlers.ListHandlers
+	AuditHandlers     *handlers.AuditHandlers
+	JobHandlers       *handlers.JobHandlers
+	JobLogHandlers    *handlers.JobLogHandlers
+	ScriptingHandlers *handlers.ScriptingHandlers
+	LookupHandlers    *handlers.LookupHandlers
+	MaintenanceHandlers *handlers.MaintenanceHandlers
+	TenantAuditHandlers *handlers.TenantAuditHandlers
+	ListWebhookHandlers *handlers.ListWebhookHandlers
+	ListMonitorHandlers *handlers.ListMonitorHandlers
+	AuditScheduleHandlers *handlers.AuditScheduleHandlers
+	MigrationHandlers *handlers.MigrationHandlers
+	GuestHandlers     *handlers.GuestHandlers
+	PrincipalAccessHandlers *handlers.PrincipalAccessHandlers
+	OffboardingHandlers *handlers.OffboardingHandlers
+	HubHandlers       *handlers.HubHandlers
+	OrgUnitHandlers   *handlers.OrgUnitHandlers
+	BadgeHandlers     *handlers.SiteBadgeHandlers
+	PolicyPackHandlers *handlers.PolicyPackHandlers
+	SiteSensitivityHandlers *handlers.SiteSensitivityHandlers
+	ExceptionHandlers *handlers.PermissionExceptionHandlers
+	AttestationHandlers *handlers.AttestationHandlers
+	AuditDiffHandlers *handlers.AuditDiffHandlers
+	FindingAlertHandlers *handlers.FindingAlertHandlers
+	WebhookDeliveryHandlers *handlers.WebhookDeliveryHandlers
+	PolicyFindingHandlers *handlers.PolicyFindingHandlers
+	JobEventHandlers  *handlers.JobEventHandlers
+	OnboardingHandlers *handlers.OnboardingHandlers
+	SSEManager        *handlers.SSEManager
 }
 
 // Dependencies holds all application dependencies organized by layer
@@ -108,8 +172,10 @@ func loadEnvironment() {
 	}
 }
 
-func initializeLogging(cfg *config.AppConfig) *logging.Logger {
-	logger := logging.NewLogger(cfg.Logging)
+func initializeLogging(cfg *config.AppConfig) (*logging.Logger, *logging.LogTail) {
+	// Job-scoped log lines are also kept in memory for the live log panel
+	logTail := logging.NewLogTail(cfg.Logging.TailLines)
+	logger := logTail.Attach(logging.NewLogger(cfg.Logging))
 	logging.SetDefault(logger)
 
 	logger.Info("Application starting",
@@ -119,7 +185,7 @@ func initializeLogging(cfg *config.AppConfig) *logging.Logger {
 		"db_path", cfg.Database.Path,
 	)
 
-	return logger
+	return logger, logTail
 }
 
 func initializeDatabase(cfg *config.AppConfig, logger *logging.Logger) *database.Database {
@@ -167,11 +233,7 @@ func buildRepositories(database *database.Database) *RepositoryBundle {
 		itemRepo,
 		sharingRepo,
 	)
-	permissionAggregate := repositories.NewPermissionAggregateRepository(
-		baseRepo,
-		itemRepo,
-		sharingRepo,
-	)
+	permissionAggregate := repositories.NewPermissionAggregateRepository(baseRepo)
 
 	return &RepositoryBundle{
 		JobRepo:     jobRepo,
@@ -187,13 +249,37 @@ func buildRepositories(database *database.Database) *RepositoryBundle {
 	}
 }
 
+// newPayloadSampleStore selects where sampled raw API payloads are kept: a directory when configured, else the database.
+func newPayloadSampleStore(cfg *config.AppConfig, db *database.Database, compression compress.Encoding) contracts.PayloadSampleStore {
+	if cfg.PayloadSamplesDir != "" {
+		return repositories.NewDirectoryPayloadSampleStore(cfg.PayloadSamplesDir, compression)
+	}
+	return repositories.NewSqlcPayloadSampleStore(db, compression)
+}
+
 // buildApplicationServices creates application services with dependency injection.
-func buildApplicationServices(appCtx context.Context, db *database.Database, repos *RepositoryBundle) *ApplicationServices {
-	// Create event bus for job events
+func buildApplicationServices(appCtx context.Context, cfg *config.AppConfig, db *database.Database, repos *RepositoryBundle) *ApplicationServices {
+	// Create event bus for job events, recording each event so it can be replayed
 	eventBus := events.NewJobEventBus()
+	jobEventLog := repositories.NewSqlcJobEventLog(db)
+	eventBus.SetEventLog(jobEventLog)
+
+	// Handlers that must see every event read it from the log, resuming where they left off
+	jobEventDeliveries := repositories.NewSqlcJobEventDeliveryStore(db)
+	eventDispatcher := events.NewDurableDispatcher(jobEventLog, jobEventDeliveries, cfg.EventDelivery)
+	eventBus.OnEventRecorded(eventDispatcher.Notify)
+	jobEventService := application.NewJobEventService(jobEventLog, jobEventDeliveries)
+	jobEventService.SetRedeliverer(eventDispatcher)
+
+	blobCompression, err := compress.ParseEncoding(cfg.BlobCompression)
+	if err != nil {
+		logging.Default().Error("Invalid BLOB_COMPRESSION", "error", err)
+		os.Exit(1)
+	}
 
 	// Create platform factories
 	auditWorkflowFactory := factories.NewAuditWorkflowFactory(db)
+	auditWorkflowFactory.SetPayloadSampling(cfg.PayloadSamples, newPayloadSampleStore(cfg, db, blobCompression))
 
 	// Create platform executors
 	siteAuditExecutor := executors.NewSiteAuditExecutor(auditWorkflowFactory)
@@ -205,7 +291,38 @@ func buildApplicationServices(appCtx context.Context, db *database.Database, rep
 	// Create job service
 	// TODO: Pass appCtx to JobService for graceful job cancellation
 	jobService := application.NewJobService(repos.JobRepo, repos.AuditRepo, registry, nil, eventBus)
-	auditService := application.NewAuditService(jobService, db)
+	storageMonitor := application.NewStorageMonitor(db, cfg.StorageQuota)
+	auditService := application.NewAuditService(jobService, db, storageMonitor)
+
+	// Jobs a stopped server left active never finish; failed, the site audits among them can be resumed
+	if failed, err := jobService.FailInterruptedJobs(); err != nil {
+		logging.Default().Warn("Failed to fail interrupted jobs", "error", err)
+	} else if failed > 0 {
+		logging.Default().Warn("Failed jobs interrupted by the last shutdown", "jobs", failed)
+	}
+
+	// Maintenance runs as a job too, registered once the job service it starts jobs through exists
+	maintenanceSchedule, err := audit.ParseMaintenanceSchedule(cfg.MaintenanceInterval, cfg.MaintenanceWindow)
+	if err != nil {
+		logging.Default().Error("Invalid MAINTENANCE_WINDOW", "error", err)
+		os.Exit(1)
+	}
+	maintenanceService := application.NewDatabaseMaintenanceService(db, jobService, maintenanceSchedule)
+	registry.RegisterExecutor(jobsdom.JobTypeDatabaseMaintenance, executors.NewDatabaseMaintenanceExecutor(maintenanceService))
+
+	// Tenant audits queue site audits through the audit service
+	tenantAuditService := application.NewTenantAuditService(jobService, auditService, factories.NewLiveTenantSiteDiscoverer(), cfg.TenantAuditConcurrency)
+	registry.RegisterExecutor(jobsdom.JobTypeTenantAudit, executors.NewTenantAuditExecutor(tenantAuditService))
+
+	// Subscribed lists are micro-audited as SharePoint notifies the webhook receiver of changes
+	notificationURL := ""
+	if cfg.WebhookPublicURL != "" {
+		notificationURL = strings.TrimRight(cfg.WebhookPublicURL, "/") + handlers.ListWebhookPath
+	}
+	listWebhookService := application.NewListWebhookService(db, auditService, factories.NewLiveListWebhookClient(), notificationURL)
+
+	// Monitored lists are checked for changes on a short interval and audited again when they changed
+	listMonitorService := application.NewListMonitorService(db, auditService, factories.NewLiveListWebhookClient(), cfg.ListMonitorInterval)
 
 	// Services using aggregate repositories
 	siteContentService := application.NewSiteContentService(
@@ -214,11 +331,106 @@ func buildApplicationServices(appCtx context.Context, db *database.Database, rep
 	permissionService := application.NewPermissionService(
 		repos.PermissionAggregate,
 	)
-	siteBrowsingService := application.NewSiteBrowsingService(repos.SiteContentAggregate)
+	siteBrowsingService := application.NewSiteBrowsingService(repos.SiteContentAggregate, cfg.LatestRunPolicy)
 
 	// Create service factory for audit-run-scoped services
 	repositoryFactory := infrafactories.NewScopedRepositoryFactory(db)
-	serviceFactory := application.NewAuditRunScopedServiceFactory(repositoryFactory, repos.AuditRepo)
+	serviceFactory := application.NewAuditRunScopedServiceFactory(repositoryFactory, repos.AuditRepo, cfg.UniqueDensityThreshold, cfg.MaxAnalysisRows, cfg.LatestRunPolicy)
+	runManifestService := application.NewRunManifestService(db, serviceFactory)
+	runArtifactService := application.NewRunArtifactService(db, cfg.ArtifactRetention)
+	runArtifactService.SetCompression(blobCompression)
+	reportRules, err := audit.ParseReportRules(cfg.PostAuditReports)
+	if err != nil {
+		logging.Default().Error("Invalid POST_AUDIT_REPORTS", "error", err)
+		os.Exit(1)
+	}
+	postAuditReportService := application.NewPostAuditReportService(db, runArtifactService, reportRules)
+	itemLookupService := application.NewItemLookupService(db, serviceFactory, factories.NewLiveItemResolver())
+	findingSeverities, err := parseFindingSeverities(cfg.FindingSeverities)
+	if err != nil {
+		logging.Default().Error("Invalid FINDING_SEVERITIES", "error", err)
+		os.Exit(1)
+	}
+	baselineService := application.NewBaselineService(db, serviceFactory)
+	baselineService.SetFindingSeverities(findingSeverities)
+	quietHours, err := audit.ParseQuietHours(cfg.AlertQuietHours)
+	if err != nil {
+		logging.Default().Error("Invalid ALERT_QUIET_HOURS", "error", err)
+		os.Exit(1)
+	}
+	auditDiffService := application.NewAuditDiffService(serviceFactory)
+	policyPackService := application.NewPolicyPackService(db, findingSeverities)
+	siteSensitivityService := application.NewSiteSensitivityService(db)
+	findingAlertService := application.NewFindingAlertService(db, serviceFactory, baselineService, findingSeverities, quietHours)
+	findingAlertService.SetPolicyPacks(policyPackService)
+	// Job completions and failures and severe finding alerts are posted to the notification webhooks
+	webhookEndpoints, err := domainevents.ParseWebhookURLs(cfg.NotificationWebhookURLs)
+	if err != nil {
+		logging.Default().Error("Invalid NOTIFICATION_WEBHOOK_URLS", "error", err)
+		os.Exit(1)
+	}
+	webhookMinSeverity, ok := audit.ParseSeverity(cfg.NotificationWebhookMinSeverity)
+	if !ok {
+		logging.Default().Error("Invalid NOTIFICATION_WEBHOOK_MIN_SEVERITY", "severity", cfg.NotificationWebhookMinSeverity)
+		os.Exit(1)
+	}
+	webhookNotificationService := application.NewWebhookNotificationService(db, webhookEndpoints, cfg.NotificationWebhookSecret, cfg.NotificationWebhookDelivery, webhookMinSeverity)
+	findingAlertService.AddNotifier(webhookNotificationService)
+	// Site audits are summarized for the notification channels once they complete or fail
+	runNotificationService := application.NewRunNotificationService(db, serviceFactory)
+	var emailNotifier *notify.EmailNotifier
+	if cfg.NotificationEmail.Enabled() {
+		recipientRules, err := notify.ParseRecipientRules(cfg.NotificationEmailRecipients)
+		if err != nil {
+			logging.Default().Error("Invalid NOTIFICATION_EMAIL_RECIPIENTS", "error", err)
+			os.Exit(1)
+		}
+		emailNotifier, err = notify.NewEmailNotifier(cfg.NotificationEmail, recipientRules)
+		if err != nil {
+			logging.Default().Error("Invalid notification email settings", "error", err)
+			os.Exit(1)
+		}
+		runNotificationService.AddNotifier("email", emailNotifier)
+	}
+	// Chat channels get the same run summaries and severe finding alerts as cards
+	chatWebhooks, err := notify.ParseChatWebhooks(cfg.NotificationChatWebhooks)
+	if err != nil {
+		logging.Default().Error("Invalid NOTIFICATION_CHAT_WEBHOOKS", "error", err)
+		os.Exit(1)
+	}
+	if len(chatWebhooks) > 0 {
+		chatMinSeverity, ok := audit.ParseSeverity(cfg.NotificationChatMinSeverity)
+		if !ok {
+			logging.Default().Error("Invalid NOTIFICATION_CHAT_MIN_SEVERITY", "severity", cfg.NotificationChatMinSeverity)
+			os.Exit(1)
+		}
+		chatNotifier := notify.NewChatNotifier(chatWebhooks, chatMinSeverity)
+		runNotificationService.AddNotifier("chat", chatNotifier)
+		findingAlertService.AddNotifier(chatNotifier)
+	}
+	accessPolicies, err := audit.ParseAccessPolicies(cfg.AccessPolicies)
+	if err != nil {
+		logging.Default().Error("Invalid ACCESS_POLICIES", "error", err)
+		os.Exit(1)
+	}
+	policyFindingService := application.NewPolicyFindingService(db, serviceFactory, audit.AccessPoliciesWith(accessPolicies))
+	auditScheduleService := application.NewAuditScheduleService(db, auditService, auditDiffService)
+	migrationService := application.NewMigrationAssessmentService(serviceFactory)
+	guestService := application.NewGuestCorrelationService(siteBrowsingService, serviceFactory)
+	principalAccessService := application.NewPrincipalAccessService(siteBrowsingService, serviceFactory)
+	// Offboarding checks run as jobs over the latest run of every site
+	offboardingService := application.NewOffboardingService(db, jobService, siteBrowsingService, serviceFactory, runArtifactService)
+	registry.RegisterExecutor(jobsdom.JobTypeOffboardingCheck, executors.NewOffboardingCheckExecutor(offboardingService))
+	hubService := application.NewHubRollupService(siteBrowsingService, serviceFactory)
+	orgUnitService := application.NewOrgUnitService(db, siteBrowsingService, serviceFactory, auditService)
+	badgeService := application.NewSiteBadgeService(siteBrowsingService, serviceFactory, auditService, cfg.BadgeSigningKey)
+	exceptionService := application.NewPermissionExceptionService(db, serviceFactory)
+	// Owners of exceptions nearing expiry are emailed a reminder when a mail server is configured
+	if emailNotifier != nil {
+		exceptionService.SetReviewNotifier(emailNotifier)
+	}
+	attestationService := application.NewAttestationService(db, serviceFactory)
+	reportShareService := application.NewReportShareService(db, serviceFactory)
 
 	return &ApplicationServices{
 		JobService:          jobService,
@@ -227,18 +439,60 @@ func buildApplicationServices(appCtx context.Context, db *database.Database, rep
 		PermissionService:   permissionService,
 		SiteBrowsingService: siteBrowsingService,
 		EventBus:            eventBus,
+		EventDispatcher:     eventDispatcher,
 		ServiceFactory:      serviceFactory,
+		RunManifestService:  runManifestService,
+		RunArtifactService:  runArtifactService,
+		ItemLookupService:   itemLookupService,
+		BaselineService:     baselineService,
+		AuditDiffService:    auditDiffService,
+		MigrationService:    migrationService,
+		GuestService:        guestService,
+		PrincipalAccessService: principalAccessService,
+		OffboardingService:  offboardingService,
+		HubService:          hubService,
+		OrgUnitService:      orgUnitService,
+		BadgeService:        badgeService,
+		ExceptionService:    exceptionService,
+		AttestationService:  attestationService,
+		ReportShareService:  reportShareService,
+		FindingSeverities:   findingSeverities,
+		PolicyPackService:   policyPackService,
+		SiteSensitivityService: siteSensitivityService,
+		FindingAlertService: findingAlertService,
+		WebhookNotificationService: webhookNotificationService,
+		RunNotificationService: runNotificationService,
+		PolicyFindingService: policyFindingService,
+		StorageMonitor:      storageMonitor,
+		MaintenanceService:  maintenanceService,
+		TenantAuditService:  tenantAuditService,
+		ListWebhookService:  listWebhookService,
+		ListMonitorService:  listMonitorService,
+		AuditScheduleService: auditScheduleService,
+		JobEventService:     jobEventService,
+
+		PostAuditReportService: postAuditReportService,
 	}
 }
 
+// parseFindingSeverities parses the severity overrides of finding categories.
+func parseFindingSeverities(spec string) (*application.FindingSeverities, error) {
+	overrides, err := audit.ParseSeverityMapping(spec)
+	if err != nil {
+		return nil, err
+	}
+	return application.NewFindingSeverities(overrides)
+}
+
 // buildPresentationLayer creates all presenters and handlers
-func buildPresentationLayer(appCtx context.Context, services *ApplicationServices) *PresentationLayer {
+func buildPresentationLayer(appCtx context.Context, services *ApplicationServices, logTail *logging.LogTail) *PresentationLayer {
 	// Build presenters (view logic)
 	auditPresenter := presenters.NewAuditPresenter()
 	jobPresenter := presenters.NewJobPresenter()
 	listPresenter := presenters.NewListPresenter()
 	permissionPresenter := presenters.NewPermissionPresenter()
 	sitePresenter := presenters.NewSitePresenter()
+	scriptingPresenter := presenters.NewScriptingPresenter()
 
 	// Build handlers - orchestrate services & presenters
 	sseManager := handlers.NewSSEManager(appCtx)
@@ -248,6 +502,11 @@ func buildPresentationLayer(appCtx context.Context, services *ApplicationService
 		services.SiteBrowsingService,
 		services.JobService,
 		services.AuditService,
+		services.RunManifestService,
+		services.RunArtifactService,
+		services.BaselineService,
+		services.ReportShareService,
+		services.StorageMonitor,
 		listPresenter,
 		permissionPresenter,
 		sitePresenter,
@@ -255,10 +514,71 @@ func buildPresentationLayer(appCtx context.Context, services *ApplicationService
 	)
 	auditHandlers := handlers.NewAuditHandlers(services.AuditService, auditPresenter, sseManager)
 	jobHandlers := handlers.NewJobHandlers(services.JobService, jobPresenter)
+	jobLogHandlers := handlers.NewJobLogHandlers(appCtx, logTail, jobPresenter)
+	scriptingHandlers := handlers.NewScriptingHandlers(
+		services.AuditService,
+		services.JobService,
+		services.SiteBrowsingService,
+		services.ServiceFactory,
+		scriptingPresenter,
+		sseManager,
+	)
+	lookupHandlers := handlers.NewLookupHandlers(services.ItemLookupService, listPresenter)
+	maintenanceHandlers := handlers.NewMaintenanceHandlers(services.MaintenanceService, sitePresenter, sseManager)
+	tenantAuditHandlers := handlers.NewTenantAuditHandlers(services.TenantAuditService, sseManager)
+	listWebhookHandlers := handlers.NewListWebhookHandlers(services.ListWebhookService, listPresenter)
+	listMonitorHandlers := handlers.NewListMonitorHandlers(services.ListMonitorService, listPresenter)
+	auditScheduleHandlers := handlers.NewAuditScheduleHandlers(services.AuditScheduleService, listPresenter)
+	migrationHandlers := handlers.NewMigrationHandlers(services.MigrationService, listPresenter)
+	guestHandlers := handlers.NewGuestHandlers(services.GuestService, listPresenter)
+	principalAccessHandlers := handlers.NewPrincipalAccessHandlers(services.PrincipalAccessService, listPresenter)
+	offboardingHandlers := handlers.NewOffboardingHandlers(services.OffboardingService, listPresenter, sseManager)
+	hubHandlers := handlers.NewHubHandlers(services.HubService, listPresenter)
+	orgUnitHandlers := handlers.NewOrgUnitHandlers(services.OrgUnitService, listPresenter)
+	badgeHandlers := handlers.NewSiteBadgeHandlers(services.BadgeService, sitePresenter)
+	policyPackHandlers := handlers.NewPolicyPackHandlers(services.PolicyPackService, listPresenter)
+	siteSensitivityHandlers := handlers.NewSiteSensitivityHandlers(services.SiteSensitivityService, listPresenter)
+	exceptionHandlers := handlers.NewPermissionExceptionHandlers(services.ExceptionService, listPresenter)
+	attestationHandlers := handlers.NewAttestationHandlers(services.AttestationService, services.ServiceFactory, listPresenter)
+	auditDiffHandlers := handlers.NewAuditDiffHandlers(services.AuditDiffService, services.SiteBrowsingService, listPresenter)
+	findingAlertHandlers := handlers.NewFindingAlertHandlers(services.FindingAlertService, listPresenter)
+	webhookDeliveryHandlers := handlers.NewWebhookDeliveryHandlers(services.WebhookNotificationService, listPresenter)
+	policyFindingHandlers := handlers.NewPolicyFindingHandlers(services.PolicyFindingService, services.ServiceFactory, listPresenter)
+	jobEventHandlers := handlers.NewJobEventHandlers(services.JobEventService, jobPresenter)
+	onboardingHandlers := handlers.NewOnboardingHandlers(services.AuditService, sitePresenter, sseManager, spauth.CheckCredentials)
 
 	// Wire up update notifications
 	services.JobService.SetUpdateNotifier(sseManager)
 
+	// Manifests hash the same canonical encoding the snapshot API returns
+	services.RunManifestService.SetSnapshotEncoder(listPresenter.EncodeListSnapshot)
+
+	// Tell connected users about reports attached to runs
+	services.RunArtifactService.SetNotifier(sseManager)
+
+	// Finding alerts are announced as toasts
+	services.FindingAlertService.AddNotifier(sseManager)
+
+	// Exceptions revoked on review reopen their finding as an alert
+	services.ExceptionService.SetFindingReopener(services.FindingAlertService)
+
+	// Scheduled runs drifting past their schedule's thresholds raise a finding alert
+	services.AuditScheduleService.SetDriftAlerter(services.FindingAlertService)
+
+	// Findings the API reports are rated with the configured severity mapping, under each site's policy pack
+	listHandlers.SetFindingSeverities(services.FindingSeverities)
+	listHandlers.SetPolicyPacks(services.PolicyPackService)
+
+	// Sharing settings reports flag lists whose members can share on sites tagged sensitive
+	listHandlers.SetSiteSensitivity(services.SiteSensitivityService)
+
+	// Post-audit reports use the export tables, and are offered in the run's completion toast
+	services.PostAuditReportService.SetRenderer(listHandlers)
+	sseManager.SetRunArtifactLister(services.RunArtifactService)
+
+	// Clients reconnecting to the event stream catch up on the job events they missed
+	sseManager.SetJobEventLister(services.JobEventService)
+
 	// Setup event system for job notifications
 	setupEventHandlers(services, sseManager)
 
@@ -268,21 +588,71 @@ func buildPresentationLayer(appCtx context.Context, services *ApplicationService
 		ListPresenter:       listPresenter,
 		PermissionPresenter: permissionPresenter,
 		SitePresenter:       sitePresenter,
+		ScriptingPresenter:  scriptingPresenter,
 		ListHandlers:        listHandlers,
 		AuditHandlers:       auditHandlers,
 		JobHandlers:         jobHandlers,
+		JobLogHandlers:      jobLogHandlers,
+		ScriptingHandlers:   scriptingHandlers,
+		LookupHandlers:      lookupHandlers,
+		MaintenanceHandlers: maintenanceHandlers,
+		TenantAuditHandlers: tenantAuditHandlers,
+		ListWebhookHandlers: listWebhookHandlers,
+		ListMonitorHandlers: listMonitorHandlers,
+		AuditScheduleHandlers: auditScheduleHandlers,
+		MigrationHandlers:   migrationHandlers,
+		GuestHandlers:       guestHandlers,
+		PrincipalAccessHandlers: principalAccessHandlers,
+		OffboardingHandlers: offboardingHandlers,
+		HubHandlers:         hubHandlers,
+		OrgUnitHandlers:     orgUnitHandlers,
+		BadgeHandlers:       badgeHandlers,
+		PolicyPackHandlers:  policyPackHandlers,
+		SiteSensitivityHandlers: siteSensitivityHandlers,
+		ExceptionHandlers:   exceptionHandlers,
+		AttestationHandlers: attestationHandlers,
+		AuditDiffHandlers:   auditDiffHandlers,
+		FindingAlertHandlers: findingAlertHandlers,
+		WebhookDeliveryHandlers: webhookDeliveryHandlers,
+		PolicyFindingHandlers: policyFindingHandlers,
+		JobEventHandlers:    jobEventHandlers,
+		OnboardingHandlers:  onboardingHandlers,
 		SSEManager:          sseManager,
 	}
 }
 
 // buildDependencies creates all application dependencies
-func buildDependencies(appCtx context.Context, db *database.Database, logger *logging.Logger) *Dependencies {
+func buildDependencies(appCtx context.Context, cfg *config.AppConfig, db *database.Database, logger *logging.Logger, logTail *logging.LogTail) *Dependencies {
 	queries := db.Queries()
 
 	// Build each layer
 	repos := buildRepositories(db)
-	services := buildApplicationServices(appCtx, db, repos)
-	presentation := buildPresentationLayer(appCtx, services)
+	services := buildApplicationServices(appCtx, cfg, db, repos)
+	presentation := buildPresentationLayer(appCtx, services, logTail)
+
+	// Scheduled database maintenance stops with the app
+	go services.MaintenanceService.RunScheduler(appCtx)
+
+	// Finding alerts queued during quiet hours are announced once they end
+	go services.FindingAlertService.RunDeliveryScheduler(appCtx)
+
+	// Owners of exceptions nearing expiry are reminded until the app stops
+	go services.ExceptionService.RunReviewReminderScheduler(appCtx)
+
+	// Notified list changes are micro-audited, and list subscriptions renewed, until the app stops
+	go services.ListWebhookService.Run(appCtx)
+
+	// Monitored lists are checked whenever they are due until the app stops
+	go services.ListMonitorService.Run(appCtx)
+
+	// Scheduled site audits are queued whenever they are due until the app stops
+	go services.AuditScheduleService.Run(appCtx)
+
+	// Queued webhook deliveries are posted, and failed attempts retried, until the app stops
+	go services.WebhookNotificationService.Run(appCtx)
+
+	// Durable event handlers catch up on events recorded while the app was stopped
+	go services.EventDispatcher.Run(appCtx)
 
 	return &Dependencies{
 		DB:           db,
@@ -298,9 +668,14 @@ func setupRoutes(deps *Dependencies, cfg *config.AppConfig) *chi.Mux {
 	r := chi.NewRouter()
 
 	// Middleware
+	r.Use(handlers.CorrelationID)
 	setupHTTPLogging(r, deps, cfg)
 	r.Use(middleware.Recoverer)
 
+	// Unmatched routes get the same problem+json errors as handlers
+	r.NotFound(handlers.NotFound)
+	r.MethodNotAllowed(handlers.MethodNotAllowed)
+
 	// Static assets
 	mountStaticAssets(r)
 
@@ -343,11 +718,14 @@ func mountStaticAssets(r chi.Router) {
 	r.Handle("/assets/*", http.StripPrefix("/assets/", http.FileServer(http.FS(sub))))
 }
 
+// healthStorageRuns is the number of recent audit runs whose row counts /health reports.
+const healthStorageRuns = 10
+
 func setupSystemRoutes(r *chi.Mux, deps *Dependencies) {
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		stats, err := deps.DB.Health()
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			handlers.WriteProblem(w, r, http.StatusInternalServerError, handlers.ErrCodeInternal, err.Error())
 			return
 		}
 
@@ -356,42 +734,169 @@ func setupSystemRoutes(r *chi.Mux, deps *Dependencies) {
 			"database": stats,
 		}
 
+		// Storage is reported without failing the check, an unmeasurable file is not an outage
+		if usage, err := deps.Services.StorageMonitor.Usage(r.Context(), healthStorageRuns); err != nil {
+			deps.Logger.Warn("Failed to measure storage for health check", "error", err)
+		} else {
+			response["storage"] = deps.Presentation.SitePresenter.ToStorageUsageView(usage)
+			if usage.OverLimit {
+				response["status"] = "warning"
+			}
+		}
+
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(response)
 	})
 
 	r.Get("/events", deps.Presentation.SSEManager.HandleSSEConnection)
+
+	// API documentation
+	r.Get(handlers.OpenAPISpecPath, handlers.OpenAPISpec)
+	r.Get("/api/docs", handlers.APIDocs)
 }
 
 func setupApplicationRoutes(r *chi.Mux, deps *Dependencies) {
 	// Main pages
 	r.Get("/", deps.Presentation.ListHandlers.Home)
 
+	// First-run setup wizard
+	r.Get("/setup", deps.Presentation.OnboardingHandlers.SetupPage)
+	r.Get("/setup/credentials", deps.Presentation.OnboardingHandlers.CheckCredentials)
+	r.Get("/setup/site", deps.Presentation.OnboardingHandlers.SiteStep)
+	r.Post("/setup/site", deps.Presentation.OnboardingHandlers.SubmitSite)
+	r.Post("/setup/audit", deps.Presentation.OnboardingHandlers.StartAudit)
+
 	// Site management (non-audit scoped)
 	r.Get("/sites", deps.Presentation.ListHandlers.SitesTable)
 	r.Get("/sites/search", deps.Presentation.ListHandlers.SearchSites)
+
+	// Item lookup by GUID or sharing URL
+	r.Get("/lookup", deps.Presentation.LookupHandlers.LookupPage)
+	r.Get("/lookup/results", deps.Presentation.LookupHandlers.LookupResults)
+	r.Get("/api/lookup", deps.Presentation.LookupHandlers.LookupItem)
+
+	// Migration assessment of a source site's run against a target site's run
+	r.Post("/api/migration-assessments", deps.Presentation.MigrationHandlers.AssessMigration)
+
+	// Guests correlated across audited tenants by their home email address
+	r.Get("/api/guest-identities", deps.Presentation.GuestHandlers.GetGuestIdentities)
+
+	// Everything one principal can access across the latest runs of every audited site
+	r.Get("/api/principals/{id}/access", deps.Presentation.PrincipalAccessHandlers.GetPrincipalAccess)
+
+	// Offboarding checks of what a leaver still has, and the remediation actions they queue
+	r.Post("/api/offboarding-checks", deps.Presentation.OffboardingHandlers.StartOffboardingCheck)
+	r.Get("/api/offboarding-checks", deps.Presentation.OffboardingHandlers.ListOffboardingChecks)
+	r.Get("/api/offboarding-checks/{checkID}", deps.Presentation.OffboardingHandlers.GetOffboardingCheck)
+	r.Get("/api/offboarding-checks/{checkID}/report", deps.Presentation.OffboardingHandlers.GetOffboardingReport)
+	r.Put("/api/offboarding-checks/{checkID}/remediations/{remediationID}", deps.Presentation.OffboardingHandlers.UpdateOffboardingRemediation)
+
+	// Site risk rolled up by the hub each site is associated with
+	r.Get("/api/hubs", deps.Presentation.HubHandlers.GetHubRollups)
+
+	// Scorecards of the organizational units sites are mapped to by URL, hub or run label
+	r.Get("/api/org-units/scorecards", deps.Presentation.OrgUnitHandlers.GetOrgUnitScorecards)
+	r.Get("/api/org-units/mappings", deps.Presentation.OrgUnitHandlers.GetOrgUnitMappings)
+	r.Post("/api/org-units/mappings", deps.Presentation.OrgUnitHandlers.SetOrgUnitMapping)
+	r.Post("/api/org-units/mappings/import", deps.Presentation.OrgUnitHandlers.ImportOrgUnitMappings)
+	r.Delete("/api/org-units/mappings/{mappingID}", deps.Presentation.OrgUnitHandlers.DeleteOrgUnitMapping)
+
+	// Signed risk badges other portals embed, such as intranet site directories
+	r.Get("/api/sites/{siteID}/badge", deps.Presentation.BadgeHandlers.GetSiteBadge)
+	r.Get("/api/sites/{siteID}/badge-link", deps.Presentation.BadgeHandlers.GetSiteBadgeLink)
+
+	// Policy packs sites are checked with, detected from their template unless overridden
+	r.Get("/api/policy-packs", deps.Presentation.PolicyPackHandlers.GetPolicyPacks)
+	r.Get("/api/sites/{siteID}/policy-pack", deps.Presentation.PolicyPackHandlers.GetSitePolicyPack)
+	r.Put("/api/sites/{siteID}/policy-pack", deps.Presentation.PolicyPackHandlers.SetSitePolicyPack)
+	r.Delete("/api/sites/{siteID}/policy-pack", deps.Presentation.PolicyPackHandlers.ClearSitePolicyPack)
+	r.Get("/api/sites/{siteID}/sensitivity", deps.Presentation.SiteSensitivityHandlers.GetSiteSensitivity)
+	r.Put("/api/sites/{siteID}/sensitivity", deps.Presentation.SiteSensitivityHandlers.TagSiteSensitive)
+	r.Delete("/api/sites/{siteID}/sensitivity", deps.Presentation.SiteSensitivityHandlers.UntagSiteSensitive)
+
+	// Accepted exceptions of every site due for review
+	r.Get("/exceptions/review", deps.Presentation.ExceptionHandlers.ReviewQueuePage)
+	r.Get("/api/exceptions/review-queue", deps.Presentation.ExceptionHandlers.GetReviewQueue)
+
+	// Severity every finding category is rated with
+	r.Get("/api/finding-severities", deps.Presentation.ListHandlers.GetFindingSeverities)
+
+	// Access policies every audit run is checked against, and the findings of each site's latest checked run
+	r.Get("/api/access-policies", deps.Presentation.PolicyFindingHandlers.GetAccessPolicies)
+	r.Get("/api/policy-findings", deps.Presentation.PolicyFindingHandlers.GetPolicyFindings)
 	
 
-	// API endpoints for audit runs
+	// API endpoints for sites and audit runs
+	r.Get("/api/sites", deps.Presentation.ListHandlers.GetSites)
 	r.Get("/api/sites/{siteID}/audit-runs", deps.Presentation.ListHandlers.GetAuditRunsForSite)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}", deps.Presentation.ListHandlers.GetAuditRun)
+	r.Put("/api/sites/{siteID}/audit-runs/{auditRunID}/hold", deps.Presentation.ListHandlers.HoldAuditRun)
+	r.Delete("/api/sites/{siteID}/audit-runs/{auditRunID}/hold", deps.Presentation.ListHandlers.ReleaseAuditRunHold)
+	r.Get("/api/sites/{siteID}/baseline", deps.Presentation.ListHandlers.GetSiteBaseline)
+	r.Delete("/api/sites/{siteID}/baseline", deps.Presentation.ListHandlers.ClearSiteBaseline)
+	r.Get("/api/sites/{siteID}/finding-alerts", deps.Presentation.FindingAlertHandlers.GetSiteAlerts)
+	r.Get("/api/sites/{siteID}/exceptions", deps.Presentation.ExceptionHandlers.GetSiteExceptions)
+	r.Delete("/api/sites/{siteID}/exceptions/{exceptionID}", deps.Presentation.ExceptionHandlers.RevokeException)
+	r.Post("/api/sites/{siteID}/exceptions/{exceptionID}/renew", deps.Presentation.ExceptionHandlers.RenewException)
+	r.Post("/api/sites/{siteID}/exceptions/{exceptionID}/reopen", deps.Presentation.ExceptionHandlers.RevokeAndReopen)
+	r.Get("/api/sites/{siteID}/lists/{listID}/exceptions", deps.Presentation.ExceptionHandlers.GetListExceptions)
+	r.Post("/api/sites/{siteID}/lists/{listID}/exceptions", deps.Presentation.ExceptionHandlers.AcceptListException)
+	r.Put("/api/sites/{siteID}/audit-runs/{auditRunID}/baseline", deps.Presentation.ListHandlers.ApproveBaseline)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/drift", deps.Presentation.ListHandlers.GetRunDrift)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/policy-findings", deps.Presentation.PolicyFindingHandlers.GetRunPolicyFindings)
+	r.Post("/api/sites/{siteID}/audit-runs/{auditRunID}/policy-findings", deps.Presentation.PolicyFindingHandlers.EvaluateRunPolicies)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/compare/{compareRunID}", deps.Presentation.AuditDiffHandlers.CompareAuditRuns)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/manifest", deps.Presentation.ListHandlers.GetRunManifest)
+	r.Post("/api/sites/{siteID}/audit-runs/{auditRunID}/manifest", deps.Presentation.ListHandlers.SealAuditRun)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/manifest/verify", deps.Presentation.ListHandlers.VerifyAuditRun)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/sharing-governance", deps.Presentation.ListHandlers.GetSharingGovernance)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/risky-defaults", deps.Presentation.ListHandlers.GetRiskyDefaults)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/page-libraries", deps.Presentation.ListHandlers.GetPageLibraryExposure)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/recycle-bin", deps.Presentation.ListHandlers.GetRecycleBinRemnants)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/anonymous-links", deps.Presentation.ListHandlers.GetAnonymousLinks)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/link-creators", deps.Presentation.ListHandlers.GetLinkCreators)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/sharing-settings", deps.Presentation.ListHandlers.GetSharingSettings)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/app-access", deps.Presentation.ListHandlers.GetApplicationAccess)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/groups/{groupID}/members", deps.Presentation.ListHandlers.GetSiteGroupMembers)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/artifacts", deps.Presentation.ListHandlers.GetRunArtifacts)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/artifacts/{artifactID}", deps.Presentation.ListHandlers.DownloadRunArtifact)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/items", deps.Presentation.ListHandlers.GetListItems)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/items/{itemGUID}/explain", deps.Presentation.ListHandlers.GetItemAccessExplanation)
+	r.Get("/api/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/snapshot", deps.Presentation.ListHandlers.GetListSnapshot)
+	r.Post("/api/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/simulate", deps.Presentation.ListHandlers.SimulateListChanges)
 	
 	// Audit-run-scoped routes
 	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/lists", deps.Presentation.ListHandlers.SiteListsPage)
 	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/lists/search", deps.Presentation.ListHandlers.SearchLists)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/lists/export", deps.Presentation.ListHandlers.ExportListsCSV)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/print", deps.Presentation.ListHandlers.SitePrintSummary)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/access-review/export", deps.Presentation.ListHandlers.ExportAccessReview)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/webs/{webID}/permission-matrix/export", deps.Presentation.ListHandlers.ExportWebPermissionMatrix)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/anonymous-links", deps.Presentation.ListHandlers.AnonymousLinksPage)
 
 	// List details
 	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}", deps.Presentation.ListHandlers.ListDetail)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/items/export", deps.Presentation.ListHandlers.ExportItemsCSV)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/assignments/export", deps.Presentation.ListHandlers.ExportAssignmentsCSV)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/changes/export", deps.Presentation.ListHandlers.ExportListChanges)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/permission-matrix/export", deps.Presentation.ListHandlers.ExportListPermissionMatrix)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/print", deps.Presentation.ListHandlers.ListPrintSummary)
+	r.Post("/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/simulate", deps.Presentation.ListHandlers.SimulationResult)
 
 	// List tabs (HTMX partials)
 	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/tabs/{listID}/overview", deps.Presentation.ListHandlers.OverviewTab)
 	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/tabs/{listID}/assignments", deps.Presentation.ListHandlers.AssignmentsTab)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/tabs/{listID}/assignments/rows", deps.Presentation.ListHandlers.AssignmentRows)
 	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/tabs/{listID}/items", deps.Presentation.ListHandlers.ItemsTab)
 	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/tabs/{listID}/links", deps.Presentation.ListHandlers.LinksTab)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/tabs/{listID}/simulate", deps.Presentation.ListHandlers.SimulationTab)
 
 	// Object operations (HTMX partials)
 	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/object/{otype}/{okey}/assignments", deps.Presentation.ListHandlers.GetObjectAssignments)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/webs/assignments", deps.Presentation.ListHandlers.GetWebAssignments)
 	r.Post("/sites/{siteID}/audit-runs/{auditRunID}/assignments/{uniqueID}/toggle", deps.Presentation.ListHandlers.ToggleAssignment)
 	r.Post("/sites/{siteID}/audit-runs/{auditRunID}/items/{itemGUID}/assignments/toggle", deps.Presentation.ListHandlers.ToggleItemAssignments)
+	r.Post("/sites/{siteID}/audit-runs/{auditRunID}/items/{itemGUID}/details/toggle", deps.Presentation.ListHandlers.ToggleItemDetails)
 
 	// Sharing link operations (HTMX partials)
 	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/sharing-links/{linkID}/members", deps.Presentation.ListHandlers.GetSharingLinkMembers)
@@ -400,6 +905,37 @@ func setupApplicationRoutes(r *chi.Mux, deps *Dependencies) {
 	// Audit run switching
 	r.Get("/sites/{siteID}/switch-audit-run", deps.Presentation.ListHandlers.SwitchAuditRun)
 	r.Post("/sites/{siteID}/switch-audit-run", deps.Presentation.ListHandlers.SwitchAuditRun)
+
+	// Reference run pinning
+	r.Post("/sites/{siteID}/audit-runs/{auditRunID}/pin", deps.Presentation.ListHandlers.PinAuditRun)
+	r.Post("/sites/{siteID}/audit-runs/{auditRunID}/unpin", deps.Presentation.ListHandlers.UnpinAuditRun)
+
+	// Approved baseline and drift from it
+	r.Post("/sites/{siteID}/audit-runs/{auditRunID}/baseline", deps.Presentation.ListHandlers.ApproveBaselineFromBanner)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/drift", deps.Presentation.ListHandlers.DriftPanel)
+
+	// Changes between two runs of the site
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/compare/{compareRunID}", deps.Presentation.AuditDiffHandlers.ComparePage)
+
+	// Site owner attestations of the run's external access and sharing links
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/attestations", deps.Presentation.AttestationHandlers.AttestationsPage)
+	r.Post("/sites/{siteID}/audit-runs/{auditRunID}/attestations", deps.Presentation.AttestationHandlers.CreateAttestation)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/attestations/{attestationID}", deps.Presentation.AttestationHandlers.AttestationResults)
+	r.Get("/attest/{token}", deps.Presentation.AttestationHandlers.ReviewPage)
+	r.Post("/attest/{token}", deps.Presentation.AttestationHandlers.SubmitReview)
+
+	// Expiring read-only shares of the run's printable summaries
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/shares", deps.Presentation.ListHandlers.ReportSharesPage)
+	r.Post("/sites/{siteID}/audit-runs/{auditRunID}/shares", deps.Presentation.ListHandlers.CreateReportShare)
+	r.Post("/sites/{siteID}/audit-runs/{auditRunID}/shares/{shareID}/revoke", deps.Presentation.ListHandlers.RevokeReportShare)
+	r.Get("/shared/{token}", deps.Presentation.ListHandlers.SharedReport)
+
+	// Per-browser data table column selections
+	r.Post("/preferences/columns/{table}", deps.Presentation.ListHandlers.SaveTableColumns)
+
+	// Row selection for bulk actions
+	r.Post("/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/selection/{table}", deps.Presentation.ListHandlers.UpdateTableSelection)
+	r.Get("/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/selection/{table}/export", deps.Presentation.ListHandlers.ExportTableSelection)
 }
 
 func setupAuditRoutes(r *chi.Mux, deps *Dependencies) {
@@ -407,12 +943,53 @@ func setupAuditRoutes(r *chi.Mux, deps *Dependencies) {
 	r.Post("/audit", deps.Presentation.AuditHandlers.RunAudit)
 	r.Get("/audit/status", deps.Presentation.AuditHandlers.GetAuditStatus)
 	r.Get("/audit/active", deps.Presentation.AuditHandlers.ListActiveAudits)
+	r.Post("/api/audits", deps.Presentation.AuditHandlers.QueueAudit)
+	r.Post("/api/tenant-audits", deps.Presentation.TenantAuditHandlers.QueueTenantAudit)
+
+	// Micro-audits of subscribed lists, triggered by SharePoint change notifications
+	r.Post(handlers.ListWebhookPath, deps.Presentation.ListWebhookHandlers.ReceiveNotifications)
+	r.Get("/api/list-subscriptions", deps.Presentation.ListWebhookHandlers.GetListSubscriptions)
+	r.Post("/api/list-subscriptions", deps.Presentation.ListWebhookHandlers.SubscribeList)
+	r.Delete("/api/list-subscriptions/{subscriptionID}", deps.Presentation.ListWebhookHandlers.UnsubscribeList)
+	r.Get("/api/list-monitors", deps.Presentation.ListMonitorHandlers.GetListMonitors)
+	r.Post("/api/list-monitors", deps.Presentation.ListMonitorHandlers.MonitorList)
+	r.Delete("/api/list-monitors/{monitorID}", deps.Presentation.ListMonitorHandlers.UnmonitorList)
+	r.Get("/api/audit-schedules", deps.Presentation.AuditScheduleHandlers.GetAuditSchedules)
+	r.Post("/api/audit-schedules", deps.Presentation.AuditScheduleHandlers.ScheduleAudit)
+	r.Delete("/api/audit-schedules/{scheduleID}", deps.Presentation.AuditScheduleHandlers.UnscheduleAudit)
+	r.Get("/api/audit-schedules/{scheduleID}/checks", deps.Presentation.AuditScheduleHandlers.GetAuditScheduleChecks)
+
+	// Single-call endpoints for admin scripts (flat JSON)
+	r.Post("/api/scripting/audit-and-wait", deps.Presentation.ScriptingHandlers.AuditAndWait)
+	r.Get("/api/scripting/risk-summary", deps.Presentation.ScriptingHandlers.GetRiskSummary)
 
 	// Job management
 	r.Get("/jobs", deps.Presentation.JobHandlers.ListJobs)
 
-	// Job cancellation
+	// Job event log replay for integrations
+	r.Get("/api/job-events", deps.Presentation.JobEventHandlers.ListJobEvents)
+
+	// Events durable handlers gave up on
+	r.Get("/dead-letters", deps.Presentation.JobEventHandlers.DeadLettersSection)
+	r.Get("/api/job-events/dead-letters", deps.Presentation.JobEventHandlers.ListDeadLetters)
+	r.Post("/api/job-events/dead-letters/{deadLetterID}/retry", deps.Presentation.JobEventHandlers.RetryDeadLetter)
+	r.Delete("/api/job-events/dead-letters/{deadLetterID}", deps.Presentation.JobEventHandlers.DismissDeadLetter)
+
+	// Notifications posted to outbound webhooks
+	r.Get("/api/webhook-deliveries", deps.Presentation.WebhookDeliveryHandlers.ListDeliveries)
+	r.Post("/api/webhook-deliveries/{deliveryID}/retry", deps.Presentation.WebhookDeliveryHandlers.RetryDelivery)
+
+	// Job cancellation and resumption
 	r.Post("/jobs/{jobID}/cancel", deps.Presentation.JobHandlers.CancelJob)
+	r.Post("/jobs/{jobID}/resume", deps.Presentation.JobHandlers.ResumeJob)
+
+	// Live job log panel
+	r.Get("/jobs/{jobID}/logs", deps.Presentation.JobLogHandlers.LogPanel)
+	r.Get("/jobs/{jobID}/logs/stream", deps.Presentation.JobLogHandlers.StreamLogs)
+
+	// Database maintenance
+	r.Post("/api/maintenance", deps.Presentation.MaintenanceHandlers.StartMaintenance)
+	r.Get("/api/maintenance/runs", deps.Presentation.MaintenanceHandlers.ListMaintenanceRuns)
 }
 
 func startServer(router *chi.Mux, addr string, logger *logging.Logger, deps *Dependencies, appCancel context.CancelFunc) {
@@ -468,7 +1045,34 @@ func startServer(router *chi.Mux, addr string, logger *logging.Logger, deps *Dep
 func setupEventHandlers(services *ApplicationServices, sseManager *handlers.SSEManager) {
 	// Create event handlers using the event bus from services
 	notificationHandlers := events.NewNotificationEventHandlers(sseManager, services.SiteBrowsingService)
+	notificationHandlers.SetPostAuditReporter(services.PostAuditReportService)
+	notificationHandlers.SetPolicyEvaluator(services.PolicyFindingService)
+	notificationHandlers.SetFindingAlerter(services.FindingAlertService)
+	notificationHandlers.SetDriftChecker(services.AuditScheduleService)
+	if services.RunNotificationService.Enabled() {
+		notificationHandlers.SetRunNotifier(services.RunNotificationService)
+	}
 
-	// Register all event handlers with the existing event bus
+	// Register all event handlers with the existing event bus; run summaries are announced by email
+	// and chat from the event log, so they are still sent across a restart
 	notificationHandlers.RegisterHandlers(services.EventBus)
+	notificationHandlers.RegisterDurableHandlers(services.EventDispatcher)
+
+	// Seal each audit run with a tamper-evidence manifest once it completes, even across a restart
+	manifestHandlers := events.NewManifestEventHandlers(services.RunManifestService)
+	manifestHandlers.RegisterHandlers(services.EventDispatcher)
+
+	// Post job completions and failures to the notification webhooks, even across a restart
+	if services.WebhookNotificationService.Enabled() {
+		webhookHandlers := events.NewWebhookEventHandlers(services.WebhookNotificationService)
+		webhookHandlers.RegisterHandlers(services.EventDispatcher)
+	}
+
+	// Stream each recorded job event so clients can resume from the last one they saw
+	services.EventBus.OnEventRecorded(sseManager.NotifyJobEvent)
+
+	// Tenant audits learn the site audits they wait on finished from their job events
+	services.EventBus.OnJobCompleted(func(event domainevents.JobCompletedEvent) { services.TenantAuditService.JobFinished(event.Job) })
+	services.EventBus.OnJobFailed(func(event domainevents.JobFailedEvent) { services.TenantAuditService.JobFinished(event.Job) })
+	services.EventBus.OnJobCancelled(func(event domainevents.JobCancelledEvent) { services.TenantAuditService.JobFinished(event.Job) })
 }
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/infrastructure/config"
+	"spaudit/interfaces/web/openapi"
+)
+
+// newTestRouter builds the router without backing services; handlers are never invoked.
+func newTestRouter() *chi.Mux {
+	return setupRoutes(&Dependencies{Presentation: &PresentationLayer{}}, &config.AppConfig{})
+}
+
+func TestOpenAPISpec_MatchesRoutes(t *testing.T) {
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(openapi.Spec(), &doc))
+
+	documented := make(map[string]bool)
+	for path, ops := range doc.Paths {
+		for method := range ops {
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	routed := make(map[string]bool)
+	require.NoError(t, chi.Walk(newTestRouter(), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		routed[method+" "+route] = true
+		return nil
+	}))
+
+	for op := range documented {
+		assert.True(t, routed[op], "spec documents %s but no route serves it", op)
+	}
+
+	// Everything under /api/ is JSON and must be documented; the Swagger UI page itself is HTML.
+	for op := range routed {
+		if strings.Contains(op, " /api/") && op != "GET /api/docs" {
+			assert.True(t, documented[op], "route %s is missing from openapi.json", op)
+		}
+	}
+}
+
+func TestAPIDocsRoutes(t *testing.T) {
+	router := newTestRouter()
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/openapi.json", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.True(t, json.Valid(w.Body.Bytes()))
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/docs", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, w.Body.String(), "swagger-ui")
+	assert.Contains(t, w.Body.String(), "/api/openapi.json")
+}
@@ -0,0 +1,161 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+const (
+	seedRuns        = 5
+	seedLists       = 4
+	seedItemsPerRun = 400
+)
+
+// newSeededDatabase opens a migrated database holding several runs of one site, so lookups that
+// stop short of audit_run_id scan every run's rows. A positive threshold logs every slower query.
+func newSeededDatabase(tb testing.TB, threshold time.Duration, logger *logging.Logger) *Database {
+	tb.Helper()
+
+	testDB, err := New(Config{
+		Path:               filepath.Join(tb.TempDir(), "test.db"),
+		MaxOpenConns:       2,
+		MaxIdleConns:       1,
+		BusyTimeoutMs:      1000,
+		EnableForeignKeys:  true,
+		SlowQueryThreshold: threshold,
+	}, logger)
+	require.NoError(tb, err)
+	tb.Cleanup(func() { testDB.Close() })
+
+	tx, err := testDB.WriteDB().Begin()
+	require.NoError(tb, err)
+	exec := func(query string, args ...any) {
+		_, err := tx.Exec(query, args...)
+		require.NoError(tb, err)
+	}
+
+	exec(`INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A')`)
+	for run := 1; run <= seedRuns; run++ {
+		exec(`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES (?, 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`, fmt.Sprintf("job-%d", run))
+		exec(`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (?, ?, 1, CURRENT_TIMESTAMP)`, run, fmt.Sprintf("job-%d", run))
+		exec(`INSERT INTO webs (site_id, web_id, audit_run_id, title) VALUES (1, 'web-1', ?, 'Web')`, run)
+		exec(`INSERT INTO role_definitions (site_id, role_def_id, audit_run_id, name) VALUES (1, 1, ?, 'Read')`, run)
+		exec(`INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES (1, 1, ?, 'Members', 'Members', 8)`, run)
+		for list := 1; list <= seedLists; list++ {
+			exec(`INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title) VALUES (1, ?, ?, 'web-1', 'List')`, fmt.Sprintf("list-%d", list), run)
+		}
+		for i := 1; i <= seedItemsPerRun; i++ {
+			item := fmt.Sprintf("item-%d", i)
+			list := fmt.Sprintf("list-%d", i%seedLists+1)
+			exec(`INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, has_unique) VALUES (1, ?, ?, ?, ?, 1)`, item, run, list, i)
+			exec(`INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'item', ?, 1, 1, ?)`, item, run)
+			exec(`INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, link_kind, is_active) VALUES (1, ?, ?, ?, 2, 1)`, "link-"+item, run, item)
+		}
+	}
+	require.NoError(tb, tx.Commit())
+
+	_, err = testDB.WriteDB().Exec(`ANALYZE`)
+	require.NoError(tb, err)
+	return testDB
+}
+
+// runAccessPatterns issues the heaviest per-run lookups once each.
+func runAccessPatterns(ctx context.Context, queries *db.Queries) error {
+	if _, err := queries.ItemsForListByAuditRun(ctx, db.ItemsForListByAuditRunParams{SiteID: 1, ListID: "list-2", AuditRunID: seedRuns, Limit: 50}); err != nil {
+		return err
+	}
+	if _, err := queries.GetAssignmentsForObjectByAuditRun(ctx, db.GetAssignmentsForObjectByAuditRunParams{SiteID: 1, ObjectType: "item", ObjectKey: "item-42", AuditRunID: seedRuns}); err != nil {
+		return err
+	}
+	_, err := queries.GetSharingLinksForListByAuditRun(ctx, db.GetSharingLinksForListByAuditRunParams{SiteID: 1, ListID: "list-2", AuditRunID: seedRuns})
+	return err
+}
+
+func TestSlowQueryLogging_LogsPlansOfHeaviestAccessPatterns(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &logging.Logger{Logger: slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))}
+	// Every query is slower than a nanosecond
+	testDB := newSeededDatabase(t, time.Nanosecond, logger)
+
+	require.NoError(t, runAccessPatterns(context.Background(), testDB.ReadQueries()))
+
+	plans := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record struct {
+			Msg       string   `json:"msg"`
+			QueryName string   `json:"query_name"`
+			Plan      []string `json:"plan"`
+			SQL       string   `json:"sql"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(line), &record))
+		require.Equal(t, "Slow query", record.Msg, line)
+		assert.NotEmpty(t, record.SQL)
+		plans[record.QueryName] = strings.Join(record.Plan, "\n")
+	}
+
+	assert.Contains(t, plans["ItemsForListByAuditRun"], "idx_items_list_run")
+	assert.NotContains(t, plans["ItemsForListByAuditRun"], "TEMP B-TREE", "items are read in item_id order")
+	assert.Contains(t, plans["GetAssignmentsForObjectByAuditRun"], "idx_role_assignments_object_run")
+	assert.Contains(t, plans["GetSharingLinksForListByAuditRun"], "idx_sharing_links_item_run")
+}
+
+func TestSlowQueryLogging_DisabledByDefault(t *testing.T) {
+	var buf bytes.Buffer
+	logger := &logging.Logger{Logger: slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))}
+	testDB := newSeededDatabase(t, 0, logger)
+
+	require.NoError(t, runAccessPatterns(context.Background(), testDB.ReadQueries()))
+	require.NoError(t, testDB.WithTx(func(queries *db.Queries) error {
+		return runAccessPatterns(context.Background(), queries)
+	}))
+	assert.Empty(t, buf.String())
+}
+
+func TestQueryName(t *testing.T) {
+	assert.Equal(t, "ItemsForListByAuditRun", queryName("-- name: ItemsForListByAuditRun :many\nSELECT 1"))
+	assert.Equal(t, "", queryName("SELECT 1"))
+}
+
+// BenchmarkAccessPatterns measures the heaviest per-run lookups over several retained runs.
+// Run with: go test ./database -run '^$' -bench AccessPatterns
+func BenchmarkAccessPatterns(b *testing.B) {
+	logger := logging.NewLogger(&logging.Config{Level: "error", Format: "text", Output: "stderr"})
+	testDB := newSeededDatabase(b, 0, logger)
+	queries := testDB.ReadQueries()
+	ctx := context.Background()
+
+	b.Run("ItemsByListAndRun", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := queries.ItemsForListByAuditRun(ctx, db.ItemsForListByAuditRunParams{SiteID: 1, ListID: "list-2", AuditRunID: seedRuns, Limit: 50}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("AssignmentsByObject", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := queries.GetAssignmentsForObjectByAuditRun(ctx, db.GetAssignmentsForObjectByAuditRunParams{SiteID: 1, ObjectType: "item", ObjectKey: "item-42", AuditRunID: seedRuns}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("LinksByItem", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := queries.GetSharingLinksForListByAuditRun(ctx, db.GetSharingLinksForListByAuditRunParams{SiteID: 1, ListID: "list-2", AuditRunID: seedRuns}); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
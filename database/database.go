@@ -1,8 +1,12 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"io/fs"
+	"os"
 	"time"
 
 	"spaudit/gen/db"
@@ -23,6 +27,10 @@ type Config struct {
 	EnableWAL         bool          `env:"DB_ENABLE_WAL" default:"true"`
 	StrictMode        bool          `env:"DB_STRICT_MODE" default:"true"`
 	SerializeWrites   bool          `env:"DB_SERIALIZE_WRITES" default:"false"`
+
+	// SlowQueryThreshold logs sqlc queries that take at least this long, with their query plan.
+	// Zero disables slow query logging.
+	SlowQueryThreshold time.Duration `env:"DB_SLOW_QUERY_THRESHOLD" default:"0"`
 }
 
 // Database wraps SQL database connections.
@@ -74,13 +82,13 @@ func New(config Config, logger *logging.Logger) (*Database, error) {
 	writeDB.SetConnMaxIdleTime(config.ConnMaxIdleTime)
 
 	database := &Database{
-		readDB:       readDB,
-		writeDB:      writeDB,
-		readQueries:  db.New(readDB),
-		writeQueries: db.New(writeDB),
-		config:       config,
-		logger:       logger,
+		readDB:  readDB,
+		writeDB: writeDB,
+		config:  config,
+		logger:  logger,
 	}
+	database.readQueries = database.newQueries(readDB)
+	database.writeQueries = database.newQueries(writeDB)
 
 	// Test connections and configure database
 	if err := database.initialize(); err != nil {
@@ -282,6 +290,107 @@ func (d *Database) Health() (map[string]interface{}, error) {
 	}, nil
 }
 
+// DiskUsage is the space the database takes on disk.
+type DiskUsage struct {
+	DatabaseBytes int64 // Main database file
+	WALBytes      int64 // Write-ahead log, folded into the database at checkpoints
+	FreeBytes     int64 // Unused pages inside the database file, reclaimable by VACUUM
+}
+
+// TotalBytes returns the size of the database and WAL files together.
+func (u DiskUsage) TotalBytes() int64 {
+	return u.DatabaseBytes + u.WALBytes
+}
+
+// DiskUsage measures the database and WAL files. A missing WAL file counts as empty.
+func (d *Database) DiskUsage() (DiskUsage, error) {
+	var usage DiskUsage
+	info, err := os.Stat(d.config.Path)
+	if err != nil {
+		return usage, fmt.Errorf("failed to stat database file: %w", err)
+	}
+	usage.DatabaseBytes = info.Size()
+
+	if info, err := os.Stat(d.config.Path + "-wal"); err == nil {
+		usage.WALBytes = info.Size()
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return usage, fmt.Errorf("failed to stat WAL file: %w", err)
+	}
+
+	var freePages, pageSize int64
+	if err := d.readDB.QueryRow("PRAGMA freelist_count").Scan(&freePages); err != nil {
+		return usage, fmt.Errorf("failed to read freelist count: %w", err)
+	}
+	if err := d.readDB.QueryRow("PRAGMA page_size").Scan(&pageSize); err != nil {
+		return usage, fmt.Errorf("failed to read page size: %w", err)
+	}
+	usage.FreeBytes = freePages * pageSize
+	return usage, nil
+}
+
+// Analyze refreshes the statistics the query planner chooses indexes by.
+func (d *Database) Analyze(ctx context.Context) error {
+	if _, err := d.writeDB.ExecContext(ctx, "ANALYZE"); err != nil {
+		return fmt.Errorf("analyze failed: %w", err)
+	}
+	if _, err := d.writeDB.ExecContext(ctx, "PRAGMA optimize"); err != nil {
+		return fmt.Errorf("optimize failed: %w", err)
+	}
+	return nil
+}
+
+// IncrementalVacuum returns the database's free pages to the file system and truncates the WAL.
+// Incremental vacuum needs auto_vacuum=INCREMENTAL, which an existing database only takes with a
+// full VACUUM; the first call does that once and reports converted.
+func (d *Database) IncrementalVacuum(ctx context.Context) (converted bool, err error) {
+	var mode int
+	if err := d.writeDB.QueryRowContext(ctx, "PRAGMA auto_vacuum").Scan(&mode); err != nil {
+		return false, fmt.Errorf("failed to read auto_vacuum mode: %w", err)
+	}
+
+	const autoVacuumIncremental = 2
+	if mode != autoVacuumIncremental {
+		if _, err := d.writeDB.ExecContext(ctx, "PRAGMA auto_vacuum = INCREMENTAL"); err != nil {
+			return false, fmt.Errorf("failed to set auto_vacuum mode: %w", err)
+		}
+		if _, err := d.writeDB.ExecContext(ctx, "VACUUM"); err != nil {
+			return false, fmt.Errorf("vacuum failed: %w", err)
+		}
+		converted = true
+	} else if _, err := d.writeDB.ExecContext(ctx, "PRAGMA incremental_vacuum"); err != nil {
+		return false, fmt.Errorf("incremental vacuum failed: %w", err)
+	}
+
+	if d.config.EnableWAL {
+		if _, err := d.writeDB.ExecContext(ctx, "PRAGMA wal_checkpoint(TRUNCATE)"); err != nil {
+			return converted, fmt.Errorf("WAL checkpoint failed: %w", err)
+		}
+	}
+	return converted, nil
+}
+
+// IntegrityCheck verifies the database structure, including that every index matches its table,
+// and returns up to maxErrors problems. No problems means the database is sound.
+func (d *Database) IntegrityCheck(ctx context.Context, maxErrors int) ([]string, error) {
+	rows, err := d.readDB.QueryContext(ctx, fmt.Sprintf("PRAGMA integrity_check(%d)", maxErrors))
+	if err != nil {
+		return nil, fmt.Errorf("integrity check failed: %w", err)
+	}
+	defer rows.Close()
+
+	var problems []string
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			return nil, fmt.Errorf("failed to read integrity check result: %w", err)
+		}
+		if result != "ok" {
+			problems = append(problems, result)
+		}
+	}
+	return problems, rows.Err()
+}
+
 // logPoolStats logs current connection pool statistics for both connections
 func (d *Database) logPoolStats() {
 	readStats := d.readDB.Stats()
@@ -309,7 +418,7 @@ func (d *Database) WithTx(fn func(*db.Queries) error) error {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
-	qtx := d.writeQueries.WithTx(tx)
+	qtx := d.newQueries(tx)
 
 	if err := fn(qtx); err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
@@ -332,7 +441,7 @@ func (d *Database) WithReadTx(fn func(*db.Queries) error) error {
 		return fmt.Errorf("failed to begin read transaction: %w", err)
 	}
 
-	qtx := d.readQueries.WithTx(tx)
+	qtx := d.newQueries(tx)
 
 	if err := fn(qtx); err != nil {
 		if rollbackErr := tx.Rollback(); rollbackErr != nil {
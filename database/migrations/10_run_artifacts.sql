@@ -0,0 +1,26 @@
+-- ======================
+-- Audit run artifacts
+-- ======================
+
+-- Reports and exports generated from a run, kept for download until they expire.
+CREATE TABLE audit_run_artifacts (
+  artifact_id   INTEGER PRIMARY KEY,
+  audit_run_id  INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  site_id       INTEGER NOT NULL REFERENCES sites(site_id),
+  filename      TEXT NOT NULL,
+  content_type  TEXT NOT NULL,
+  size_bytes    INTEGER NOT NULL,
+  content       BLOB NOT NULL,
+  created_at    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  expires_at    DATETIME NOT NULL
+);
+
+CREATE INDEX idx_audit_run_artifacts_run ON audit_run_artifacts(audit_run_id);
+CREATE INDEX idx_audit_run_artifacts_site ON audit_run_artifacts(site_id);
+CREATE INDEX idx_audit_run_artifacts_expiry ON audit_run_artifacts(expires_at);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 10;
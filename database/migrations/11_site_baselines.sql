@@ -0,0 +1,24 @@
+-- ======================
+-- Approved permission baselines
+-- ======================
+
+-- A site can approve one audit run as its permission baseline; later runs report their drift from it.
+-- Approving another run supersedes the current baseline, which is kept as history of who approved what.
+CREATE TABLE site_baselines (
+  baseline_id   INTEGER PRIMARY KEY,
+  site_id       INTEGER NOT NULL REFERENCES sites(site_id),
+  audit_run_id  INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  approved_by   TEXT,
+  note          TEXT,
+  approved_at   DATETIME NOT NULL,
+  superseded_at DATETIME
+);
+
+CREATE INDEX idx_site_baselines_site ON site_baselines(site_id);
+CREATE UNIQUE INDEX idx_site_baselines_current ON site_baselines(site_id) WHERE superseded_at IS NULL;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 11;
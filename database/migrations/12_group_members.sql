@@ -0,0 +1,23 @@
+-- ======================
+-- SharePoint group membership
+-- ======================
+
+-- Members of each SharePoint group as captured by an audit run, so access granted to a group
+-- can be attributed to the people in it. Azure AD security groups are not expanded.
+CREATE TABLE group_members (
+  site_id      INTEGER NOT NULL REFERENCES sites(site_id),
+  group_id     INTEGER NOT NULL,
+  member_id    INTEGER NOT NULL,
+  audit_run_id INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  PRIMARY KEY (site_id, group_id, member_id, audit_run_id),
+  FOREIGN KEY (site_id, group_id, audit_run_id) REFERENCES principals(site_id, principal_id, audit_run_id),
+  FOREIGN KEY (site_id, member_id, audit_run_id) REFERENCES principals(site_id, principal_id, audit_run_id)
+);
+
+CREATE INDEX idx_group_members_member ON group_members(site_id, audit_run_id, member_id);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 12;
@@ -0,0 +1,24 @@
+-- ======================
+-- Document sets and attachments
+-- ======================
+
+-- Content type of each item, so document sets can be told apart from plain folders.
+ALTER TABLE items ADD COLUMN content_type_id TEXT;
+
+-- Files attached to list items. Attachments have no permissions of their own and cannot be
+-- shared separately; whoever can read the item can read its attachments.
+CREATE TABLE item_attachments (
+  site_id       INTEGER NOT NULL REFERENCES sites(site_id),
+  item_guid     TEXT NOT NULL,
+  audit_run_id  INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  file_name     TEXT NOT NULL,
+  url           TEXT,
+  PRIMARY KEY (site_id, item_guid, audit_run_id, file_name),
+  FOREIGN KEY (site_id, item_guid, audit_run_id) REFERENCES items(site_id, item_guid, audit_run_id)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 13;
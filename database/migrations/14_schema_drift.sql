@@ -0,0 +1,21 @@
+-- ======================
+-- API schema drift
+-- ======================
+
+-- Fields of SharePoint API responses that an audit run did not recognise, or expected and did not
+-- receive. Unmapped fields are otherwise dropped silently, so this is how a Microsoft API change
+-- that loses data gets noticed.
+CREATE TABLE audit_run_schema_drift (
+  audit_run_id INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  source       TEXT NOT NULL,    -- Response object: list_item, sharing_information, sharing_link, sharing_principal
+  field        TEXT NOT NULL,
+  kind         TEXT NOT NULL,    -- unknown | missing
+  occurrences  INTEGER NOT NULL, -- Responses the field was unknown in, or missing from
+  PRIMARY KEY (audit_run_id, source, field, kind)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 14;
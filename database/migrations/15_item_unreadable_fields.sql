@@ -0,0 +1,14 @@
+-- ======================
+-- Per-field collection status
+-- ======================
+
+-- Comma-separated fields that could not be collected for the item, e.g. sensitivity_label when
+-- File/Properties was not readable. NULL when every field was collected, so an empty value means
+-- the item has none rather than that it could not be read.
+ALTER TABLE items ADD COLUMN unreadable_fields TEXT;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 15;
@@ -0,0 +1,24 @@
+-- ======================
+-- Raw API payload samples
+-- ======================
+
+-- Sanitized SharePoint API payloads kept from a run for debugging parsing issues, when payload
+-- sampling is enabled and samples are not written to a directory instead.
+CREATE TABLE audit_run_payload_samples (
+  sample_id     INTEGER PRIMARY KEY,
+  audit_run_id  INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  source        TEXT NOT NULL,     -- Response object, as in audit_run_schema_drift
+  sample_key    TEXT NOT NULL,     -- Item or file GUID the payload describes
+  payload       BLOB NOT NULL,
+  truncated     BOOLEAN NOT NULL,  -- Cut at the size limit, and no longer valid JSON
+  schema_drift  BOOLEAN NOT NULL,  -- The payload did not match the expected schema
+  captured_at   DATETIME NOT NULL
+);
+
+CREATE INDEX idx_audit_run_payload_samples_run ON audit_run_payload_samples(audit_run_id);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 16;
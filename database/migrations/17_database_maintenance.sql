@@ -0,0 +1,28 @@
+-- ======================
+-- Database maintenance
+-- ======================
+
+-- One run of ANALYZE, incremental VACUUM and an integrity check, started by hand or on schedule.
+-- Sizes cover the database and WAL files, and are measured before and after the run.
+CREATE TABLE database_maintenance_runs (
+  maintenance_run_id INTEGER PRIMARY KEY,
+  job_id             TEXT NOT NULL,
+  trigger            TEXT NOT NULL,     -- manual or scheduled
+  started_at         DATETIME NOT NULL,
+  completed_at       DATETIME,          -- NULL while running
+  bytes_before       INTEGER NOT NULL,
+  bytes_after        INTEGER,
+  free_bytes_before  INTEGER NOT NULL,
+  free_bytes_after   INTEGER,
+  converted          BOOLEAN NOT NULL DEFAULT FALSE, -- Switched to incremental auto-vacuum by a full VACUUM
+  integrity_errors   TEXT,              -- JSON array, NULL when the check passed or did not run
+  error              TEXT
+);
+
+CREATE INDEX idx_database_maintenance_runs_started ON database_maintenance_runs(started_at);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 17;
@@ -0,0 +1,25 @@
+-- ======================
+-- Access pattern indexes
+-- ======================
+
+-- The heaviest reads look up one object, list or item within one audit run. The original indexes
+-- stop before audit_run_id, so every retained run's rows were scanned and filtered. These replace
+-- them with indexes that end in the run, and sort items by item_id for paging.
+
+-- Role assignments by object (GetAssignmentsForObjectByAuditRun, DeleteRoleAssignmentsForObject)
+DROP INDEX IF EXISTS idx_role_assignments_object;
+CREATE INDEX idx_role_assignments_object_run ON role_assignments(site_id, object_type, object_key, audit_run_id);
+
+-- Items by list and run (ItemsForListByAuditRun and the list item filters)
+DROP INDEX IF EXISTS idx_items_list_id;
+CREATE INDEX idx_items_list_run ON items(site_id, list_id, audit_run_id, item_id);
+
+-- Sharing links by item and run (the item joins of GetSharingLinksForListByAuditRun and FindLatestItemByGUID)
+DROP INDEX IF EXISTS idx_sharing_links_item;
+CREATE INDEX idx_sharing_links_item_run ON sharing_links(site_id, item_guid, audit_run_id) WHERE item_guid IS NOT NULL;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 18;
@@ -0,0 +1,15 @@
+-- ====================
+-- Blob compression
+-- ====================
+
+-- Run artifacts and raw payload samples may be stored compressed. The encoding records how, so
+-- rows written before compression, or with it disabled, keep reading back unchanged. size_bytes
+-- stays the size of the artifact as downloaded.
+ALTER TABLE audit_run_artifacts ADD COLUMN content_encoding TEXT NOT NULL DEFAULT '';
+ALTER TABLE audit_run_payload_samples ADD COLUMN payload_encoding TEXT NOT NULL DEFAULT '';
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 19;
@@ -0,0 +1,16 @@
+-- ======================
+-- Audit run labels
+-- ======================
+
+-- Environment or source a run was tagged with, e.g. prod-tenant and test-tenant when both sides
+-- of a tenant migration are audited; NULL for unlabeled runs. Views filtered by label resolve
+-- the latest run among a site's runs with the label.
+ALTER TABLE audit_runs ADD COLUMN label TEXT;
+
+CREATE INDEX idx_audit_runs_site_label ON audit_runs(site_id, label, started_at);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 20;
@@ -0,0 +1,18 @@
+-- ======================
+-- Sharing defaults
+-- ======================
+
+-- The default link the share dialog offers, as captured with the sharing governance
+-- (SP.SharingLinkKind, SP.Sharing.Role and link scope); NULL for runs before these were captured.
+ALTER TABLE sharing_governance ADD COLUMN default_link_kind INTEGER;
+ALTER TABLE sharing_governance ADD COLUMN default_share_link_permission INTEGER;
+ALTER TABLE sharing_governance ADD COLUMN default_share_link_scope INTEGER;
+
+-- Whether members may share the web and its content; NULL for runs before it was captured.
+ALTER TABLE webs ADD COLUMN members_can_share BOOLEAN;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 21;
@@ -0,0 +1,17 @@
+-- ======================
+-- List access counts
+-- ======================
+
+-- Explicit assignments on the list and its uniquely permissioned items, external principals
+-- granted access through them or through sharing links, and active sharing links on the list's
+-- items. Computed per list once sharing links for an audit run have been collected; for sampled
+-- lists they cover the scanned items only.
+ALTER TABLE lists ADD COLUMN unique_assignment_count  INTEGER DEFAULT 0;
+ALTER TABLE lists ADD COLUMN external_principal_count INTEGER DEFAULT 0;
+ALTER TABLE lists ADD COLUMN sharing_link_count       INTEGER DEFAULT 0;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 22;
@@ -0,0 +1,32 @@
+-- ======================
+-- Site owner attestations
+-- ======================
+
+-- A review link for a site owner to attest to the external access and sharing links an audit run
+-- captured. The token is the link's only credential, so it is random and unique.
+CREATE TABLE attestations (
+  attestation_id INTEGER PRIMARY KEY,
+  site_id        INTEGER NOT NULL REFERENCES sites(site_id),
+  audit_run_id   INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  token          TEXT NOT NULL UNIQUE,
+  owner          TEXT NOT NULL,
+  created_at     DATETIME NOT NULL,
+  submitted_at   DATETIME -- Last time the owner saved their responses
+);
+
+CREATE INDEX idx_attestations_run ON attestations(site_id, audit_run_id);
+
+-- The owner's decision on one entry of the review, keyed by the entry's stable key within the run
+CREATE TABLE attestation_responses (
+  attestation_id INTEGER NOT NULL REFERENCES attestations(attestation_id),
+  entry_key      TEXT NOT NULL,
+  decision       TEXT NOT NULL CHECK (decision IN ('keep', 'remove')),
+  responded_at   DATETIME NOT NULL,
+  PRIMARY KEY (attestation_id, entry_key)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 23;
@@ -0,0 +1,24 @@
+-- ====================
+-- Read-only report shares
+-- ====================
+
+-- An expiring link to the printable summary of a site, or of one of its lists, in an audit run for
+-- stakeholders without access to spaudit. The token is the link's only credential, so it is random and unique.
+CREATE TABLE report_shares (
+  share_id     INTEGER PRIMARY KEY,
+  site_id      INTEGER NOT NULL REFERENCES sites(site_id),
+  audit_run_id INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  list_id      TEXT, -- NULL shares the site summary
+  token        TEXT NOT NULL UNIQUE,
+  created_at   DATETIME NOT NULL,
+  expires_at   DATETIME NOT NULL,
+  revoked_at   DATETIME
+);
+
+CREATE INDEX idx_report_shares_run ON report_shares(site_id, audit_run_id);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 24;
@@ -0,0 +1,35 @@
+-- ====================
+-- Finding alerts
+-- ====================
+
+-- A finding announced when an audit run completes. Alerts raised during quiet hours are queued with
+-- delivered_at NULL and announced once the quiet hours end.
+CREATE TABLE finding_alerts (
+  alert_id     INTEGER PRIMARY KEY,
+  site_id      INTEGER NOT NULL REFERENCES sites(site_id),
+  audit_run_id INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  fingerprint  TEXT NOT NULL, -- Category and subject, equal for the same finding in every run
+  category     TEXT NOT NULL,
+  severity     TEXT NOT NULL,
+  message      TEXT NOT NULL,
+  created_at   DATETIME NOT NULL,
+  delivered_at DATETIME
+);
+
+CREATE INDEX idx_finding_alerts_site ON finding_alerts(site_id, created_at);
+CREATE INDEX idx_finding_alerts_queued ON finding_alerts(created_at) WHERE delivered_at IS NULL;
+
+-- The latest run each finding of a site was seen in. A finding also seen in the site's previous
+-- completed run is a repeat and raises no alert.
+CREATE TABLE finding_alert_sightings (
+  site_id           INTEGER NOT NULL REFERENCES sites(site_id),
+  fingerprint       TEXT NOT NULL,
+  last_audit_run_id INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  PRIMARY KEY (site_id, fingerprint)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 25;
@@ -0,0 +1,25 @@
+-- ====================
+-- Job event log
+-- ====================
+
+-- Every event published on the job event bus, for SSE clients catching up after reconnecting and
+-- integrations reprocessing history. AUTOINCREMENT keeps sequence numbers increasing, never reused.
+CREATE TABLE job_events (
+  sequence     INTEGER PRIMARY KEY AUTOINCREMENT,
+  event_type   TEXT NOT NULL,
+  job_id       TEXT NOT NULL,
+  job_type     TEXT NOT NULL,
+  job_status   TEXT NOT NULL,
+  site_url     TEXT NOT NULL,
+  audit_run_id INTEGER, -- NULL for jobs without an audit run
+  error        TEXT NOT NULL DEFAULT '',
+  occurred_at  DATETIME NOT NULL
+);
+
+CREATE INDEX idx_job_events_job ON job_events(job_id, sequence);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 26;
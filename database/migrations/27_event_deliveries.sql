@@ -0,0 +1,29 @@
+-- ====================
+-- Durable job event delivery
+-- ====================
+
+-- The last job event each durable handler has finished with. Handlers resume after it on startup,
+-- so events recorded but not handled before a crash are delivered again.
+CREATE TABLE event_handler_offsets (
+  handler       TEXT PRIMARY KEY,
+  last_sequence INTEGER NOT NULL,
+  updated_at    DATETIME NOT NULL
+);
+
+-- Events a durable handler still failed on after its retries. Retrying a dead letter delivers the
+-- event to the handler again and removes it on success.
+CREATE TABLE event_dead_letters (
+  dead_letter_id INTEGER PRIMARY KEY,
+  handler        TEXT NOT NULL,
+  sequence       INTEGER NOT NULL REFERENCES job_events(sequence),
+  attempts       INTEGER NOT NULL,
+  last_error     TEXT NOT NULL,
+  failed_at      DATETIME NOT NULL,
+  UNIQUE (handler, sequence)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 27;
@@ -0,0 +1,17 @@
+-- ======================
+-- List item settings
+-- ======================
+
+-- Item-level permission defaults (SP.List.ReadSecurity and WriteSecurity) and the Microsoft Lists
+-- features showing who interacted with items: comments and the rating experience ('' when off,
+-- 'Likes' or 'Ratings'). NULL for runs before these were captured.
+ALTER TABLE lists ADD COLUMN read_security     INTEGER;
+ALTER TABLE lists ADD COLUMN write_security    INTEGER;
+ALTER TABLE lists ADD COLUMN comments_disabled BOOLEAN;
+ALTER TABLE lists ADD COLUMN rating_experience TEXT;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 28;
@@ -0,0 +1,38 @@
+-- ======================
+-- Recycle bin scans
+-- ======================
+
+-- Audit runs that scanned the site's recycle bin, which is opt-in. A scan that found the bin empty
+-- still has a row here, so "not scanned" and "nothing deleted" can be told apart.
+CREATE TABLE recycle_bin_scans (
+  site_id       INTEGER NOT NULL REFERENCES sites(site_id),
+  audit_run_id  INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  scanned_at    DATETIME NOT NULL,
+  PRIMARY KEY (site_id, audit_run_id)
+);
+
+-- Files, folders and list items in the site's recycle bin when the run scanned it. The recycle bin
+-- API does not return sharing links; they are found through the items earlier runs captured at url.
+CREATE TABLE recycle_bin_items (
+  site_id          INTEGER NOT NULL,
+  audit_run_id     INTEGER NOT NULL,
+  recycle_bin_id   TEXT NOT NULL,     -- Recycle bin entry ID, not the item's
+  item_type        INTEGER NOT NULL,  -- SP.RecycleBinItemType
+  item_state       INTEGER NOT NULL,  -- 1 first-stage, 2 second-stage recycle bin
+  title            TEXT,
+  url              TEXT NOT NULL,     -- Where the item was before deletion, in the same form as items.url
+  deleted_at       DATETIME NOT NULL,
+  deleted_by_name  TEXT,
+  deleted_by_email TEXT,
+  PRIMARY KEY (site_id, audit_run_id, recycle_bin_id),
+  FOREIGN KEY (site_id, audit_run_id) REFERENCES recycle_bin_scans(site_id, audit_run_id)
+);
+
+-- Items by URL, for finding what earlier runs captured at a deleted item's location
+CREATE INDEX idx_items_url_run ON items(site_id, url, audit_run_id);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 29;
@@ -0,0 +1,16 @@
+-- ======================
+-- List unique-permission density
+-- ======================
+
+-- Items with unique permissions and the resulting density (unique items / total items),
+-- computed per list once item collection for an audit run has finished.
+ALTER TABLE lists ADD COLUMN unique_item_count INTEGER DEFAULT 0;
+ALTER TABLE lists ADD COLUMN unique_density    REAL DEFAULT 0;
+
+CREATE INDEX idx_lists_unique_density ON lists(site_id, audit_run_id, unique_density);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 2;
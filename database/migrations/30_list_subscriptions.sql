@@ -0,0 +1,27 @@
+-- ====================
+-- List webhook subscriptions
+-- ====================
+
+-- A SharePoint webhook subscription to the changes of a high-priority list. Notifications trigger a
+-- folder-scoped micro-audit of what changed since change_token, which then moves past the changes.
+CREATE TABLE list_subscriptions (
+  subscription_id   TEXT PRIMARY KEY, -- SharePoint's subscription ID
+  site_url          TEXT NOT NULL,
+  list_id           TEXT NOT NULL,
+  list_title        TEXT NOT NULL,
+  list_root_path    TEXT NOT NULL,    -- Server-relative path of the list's root folder
+  client_state      TEXT NOT NULL,    -- Secret SharePoint echoes in each notification
+  change_token      TEXT NOT NULL,
+  expires_at        DATETIME NOT NULL,
+  created_at        DATETIME NOT NULL,
+  last_notified_at  DATETIME,
+  last_audit_job_id TEXT,
+  last_audit_at     DATETIME,
+  UNIQUE (site_url, list_id)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 30;
@@ -0,0 +1,29 @@
+-- ====================
+-- List monitors
+-- ====================
+
+-- A critical list re-checked every interval_seconds. A check reads the list's change log from
+-- change_token and, when anything changed, audits the list's root folder again.
+CREATE TABLE list_monitors (
+  monitor_id        INTEGER PRIMARY KEY,
+  site_url          TEXT NOT NULL,
+  list_id           TEXT NOT NULL,
+  list_title        TEXT NOT NULL,
+  list_root_path    TEXT NOT NULL,    -- Server-relative path of the list's root folder
+  interval_seconds  INTEGER NOT NULL,
+  change_token      TEXT NOT NULL,
+  created_at        DATETIME NOT NULL,
+  next_check_at     DATETIME NOT NULL,
+  last_checked_at   DATETIME,
+  last_audit_job_id TEXT,
+  last_audit_at     DATETIME,
+  UNIQUE (site_url, list_id)
+);
+
+CREATE INDEX idx_list_monitors_due ON list_monitors(next_check_at);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 31;
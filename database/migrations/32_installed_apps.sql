@@ -0,0 +1,33 @@
+-- ======================
+-- Installed apps
+-- ======================
+
+-- Audit runs that collected the site's installed apps. A run that found none still has a row here,
+-- so "not collected" and "nothing installed" can be told apart.
+CREATE TABLE app_inventories (
+  site_id       INTEGER NOT NULL REFERENCES sites(site_id),
+  audit_run_id  INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  collected_at  DATETIME NOT NULL,
+  PRIMARY KEY (site_id, audit_run_id)
+);
+
+-- SharePoint add-ins and SPFx apps installed on the site's root web when the run collected them.
+-- Their permission grants are the role assignments of the principal app_principal_id names.
+CREATE TABLE installed_apps (
+  site_id          INTEGER NOT NULL,
+  audit_run_id     INTEGER NOT NULL,
+  app_id           TEXT NOT NULL,     -- App instance ID
+  product_id       TEXT,              -- The app package's ID, the same on every site it is installed on
+  title            TEXT,
+  app_source       INTEGER NOT NULL,  -- SP.AppSource: store, tenant or site collection app catalog, ...
+  app_principal_id TEXT,              -- Login name of the app's principal, empty for apps without permissions
+  last_modified    DATETIME,
+  PRIMARY KEY (site_id, audit_run_id, app_id),
+  FOREIGN KEY (site_id, audit_run_id) REFERENCES app_inventories(site_id, audit_run_id)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 32;
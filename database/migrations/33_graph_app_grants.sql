@@ -0,0 +1,33 @@
+-- ======================
+-- Graph app grants
+-- ======================
+
+-- Audit runs that read the apps Microsoft Graph grants access to the site, which is opt-in. A run
+-- that found none still has a row here, so "not read" and "no apps" can be told apart.
+CREATE TABLE graph_grant_scans (
+  site_id       INTEGER NOT NULL REFERENCES sites(site_id),
+  audit_run_id  INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  scanned_at    DATETIME NOT NULL,
+  PRIMARY KEY (site_id, audit_run_id)
+);
+
+-- Entra apps with access to the site through Microsoft Graph: site permissions given to apps holding
+-- Sites.Selected, and application roles such as Sites.FullControl.All that reach every site.
+CREATE TABLE graph_app_grants (
+  site_id       INTEGER NOT NULL,
+  audit_run_id  INTEGER NOT NULL,
+  grant_kind    TEXT NOT NULL,     -- site_selected or tenant_wide
+  grant_id      TEXT NOT NULL,     -- Site permission ID, or app role assignment ID
+  app_id        TEXT NOT NULL,     -- Client ID of the app registration
+  app_name      TEXT,
+  roles         TEXT NOT NULL,     -- Comma-separated
+  resource      TEXT,              -- API of a tenant-wide role
+  PRIMARY KEY (site_id, audit_run_id, grant_kind, grant_id),
+  FOREIGN KEY (site_id, audit_run_id) REFERENCES graph_grant_scans(site_id, audit_run_id)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 33;
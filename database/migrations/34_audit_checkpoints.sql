@@ -0,0 +1,24 @@
+-- ======================
+-- Audit checkpoints
+-- ======================
+
+-- How far an audit run got through each list, so a run interrupted by a restart or a cancellation
+-- resumes after the lists it completed instead of starting over. Lists walked in full also record
+-- the last item collected, so the list being collected continues after it.
+CREATE TABLE audit_checkpoints (
+  audit_run_id  INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  list_id       TEXT NOT NULL,
+  last_item_id  INTEGER NOT NULL DEFAULT 0,  -- Items are walked in ID order, so all up to this one are saved
+  completed     BOOLEAN NOT NULL DEFAULT FALSE,
+  updated_at    DATETIME NOT NULL,
+  PRIMARY KEY (audit_run_id, list_id)
+);
+
+-- The parameters a run was started with, which a resumed run continues with. Jobs only hold them in memory.
+ALTER TABLE audit_runs ADD COLUMN parameters_json TEXT;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 34;
@@ -0,0 +1,27 @@
+-- ======================
+-- Hub associations
+-- ======================
+
+-- The hub each audit run found the site associated with. Governance reviews are organised by hub, and
+-- associated sites inherit the hub's navigation and, with permission sync, its visitors. A site with
+-- no hub still has a row here, so "not collected" and "no hub" can be told apart.
+CREATE TABLE site_hubs (
+  site_id             INTEGER NOT NULL REFERENCES sites(site_id),
+  audit_run_id        INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  is_hub              BOOLEAN NOT NULL DEFAULT FALSE,
+  hub_site_id         TEXT,              -- NULL when the site is associated with no hub
+  hub_title           TEXT,
+  hub_url             TEXT,
+  parent_hub_site_id  TEXT,
+  permissions_sync    BOOLEAN NOT NULL DEFAULT FALSE,
+  collected_at        DATETIME NOT NULL,
+  PRIMARY KEY (site_id, audit_run_id)
+);
+
+CREATE INDEX idx_site_hubs_hub ON site_hubs(hub_site_id);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 35;
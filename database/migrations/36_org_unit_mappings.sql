@@ -0,0 +1,21 @@
+-- ========================
+-- Organizational units
+-- ========================
+
+-- Assigns sites to organizational units, e.g. departments, for per-unit scorecards. A mapping matches
+-- a site by its URL, the hub its latest run found it associated with, or its latest run's label;
+-- match_value is normalized so imports of the same spreadsheet update rather than duplicate.
+CREATE TABLE org_unit_mappings (
+  mapping_id   INTEGER PRIMARY KEY,
+  match_type   TEXT NOT NULL,     -- 'site', 'hub' or 'label'
+  match_value  TEXT NOT NULL,
+  org_unit     TEXT NOT NULL,
+  updated_at   DATETIME NOT NULL,
+  UNIQUE (match_type, match_value)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 36;
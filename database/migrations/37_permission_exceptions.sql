@@ -0,0 +1,29 @@
+-- ========================
+-- Permission exceptions
+-- ========================
+
+-- Sharing links, assignments and items with unique permissions of a list that were reviewed and
+-- accepted as intended. Until expires_at, what an exception accepts raises no findings and is left
+-- out of the list's risk score. object_key is the link ID, the item GUID, or for an assignment the
+-- list ID or item GUID the role is on; principal_id and role_def_id are 0 except for assignments.
+CREATE TABLE permission_exceptions (
+  exception_id   INTEGER PRIMARY KEY,
+  site_id        INTEGER NOT NULL REFERENCES sites(site_id),
+  list_id        TEXT NOT NULL,
+  kind           TEXT NOT NULL,     -- 'sharing_link', 'assignment' or 'unique_item'
+  object_key     TEXT NOT NULL,
+  principal_id   INTEGER NOT NULL DEFAULT 0,
+  role_def_id    INTEGER NOT NULL DEFAULT 0,
+  justification  TEXT NOT NULL,
+  expires_at     DATETIME NOT NULL,
+  created_at     DATETIME NOT NULL,
+  UNIQUE (site_id, list_id, kind, object_key, principal_id, role_def_id)
+);
+
+CREATE INDEX idx_permission_exceptions_expiry ON permission_exceptions(site_id, expires_at);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 37;
@@ -0,0 +1,38 @@
+-- ======================
+-- Entra group members
+-- ======================
+
+-- Entra security groups and Microsoft 365 groups holding roles on a site whose users an audit run
+-- resolved through Microsoft Graph, which is opt-in. A group that reaches no users still has a row
+-- here, so "not resolved" and "no users" can be told apart.
+CREATE TABLE entra_groups (
+  site_id       INTEGER NOT NULL,
+  principal_id  INTEGER NOT NULL,  -- The security group principal
+  audit_run_id  INTEGER NOT NULL,
+  object_id     TEXT NOT NULL,     -- Entra object ID of the group
+  owners        BOOLEAN NOT NULL DEFAULT 0, -- The principal stands for the group's owners
+  member_count  INTEGER NOT NULL,
+  resolved_at   DATETIME NOT NULL,
+  PRIMARY KEY (site_id, principal_id, audit_run_id),
+  FOREIGN KEY (site_id, principal_id, audit_run_id) REFERENCES principals(site_id, principal_id, audit_run_id)
+);
+
+-- Users each resolved group reaches, nested groups expanded
+CREATE TABLE entra_group_members (
+  site_id             INTEGER NOT NULL,
+  principal_id        INTEGER NOT NULL,
+  audit_run_id        INTEGER NOT NULL,
+  member_object_id    TEXT NOT NULL,
+  display_name        TEXT,
+  user_principal_name TEXT,
+  mail                TEXT,
+  user_type           TEXT,      -- Member or Guest
+  PRIMARY KEY (site_id, principal_id, audit_run_id, member_object_id),
+  FOREIGN KEY (site_id, principal_id, audit_run_id) REFERENCES entra_groups(site_id, principal_id, audit_run_id)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 38;
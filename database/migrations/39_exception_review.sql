@@ -0,0 +1,18 @@
+-- ======================
+-- Exception review
+-- ======================
+
+-- Who answers for an exception, reminded as it nears expiry; empty when nobody was named.
+ALTER TABLE permission_exceptions ADD COLUMN owner TEXT NOT NULL DEFAULT '';
+
+-- When the owner was reminded the exception nears expiry; NULL until then, and again once renewed.
+ALTER TABLE permission_exceptions ADD COLUMN review_notified_at DATETIME;
+
+-- The review queue spans every site
+CREATE INDEX idx_permission_exceptions_expires_at ON permission_exceptions(expires_at);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 39;
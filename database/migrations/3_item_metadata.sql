@@ -0,0 +1,16 @@
+-- ======================
+-- Item metadata
+-- ======================
+
+-- SharePoint-side metadata shown in the item detail view. created_at/modified_at on
+-- items track the row itself, so the SharePoint timestamps get their own columns.
+ALTER TABLE items ADD COLUMN file_folder_unique_id TEXT;
+ALTER TABLE items ADD COLUMN file_size             INTEGER;
+ALTER TABLE items ADD COLUMN sp_created_at         DATETIME;
+ALTER TABLE items ADD COLUMN sp_modified_at        DATETIME;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 3;
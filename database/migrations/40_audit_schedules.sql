@@ -0,0 +1,46 @@
+-- ====================
+-- Audit schedules
+-- ====================
+
+-- A site audited every interval_seconds. Once each of its audits completes, the run is compared with
+-- the site's previous completed full audit or its approved baseline, and a drift alert is raised only
+-- when the drift exceeds a threshold. A NULL threshold is not checked.
+CREATE TABLE audit_schedules (
+  schedule_id            INTEGER PRIMARY KEY,
+  site_url               TEXT NOT NULL UNIQUE,
+  interval_seconds       INTEGER NOT NULL,
+  compare_with           TEXT NOT NULL,    -- 'previous' or 'baseline'
+  max_external_grants    INTEGER,
+  max_permission_changes INTEGER,
+  max_sharing_links      INTEGER,
+  max_unique_items       INTEGER,
+  max_removed_principals INTEGER,
+  created_at             DATETIME NOT NULL,
+  next_run_at            DATETIME NOT NULL,
+  last_job_id            TEXT,
+  last_run_at            DATETIME
+);
+
+CREATE INDEX idx_audit_schedules_due ON audit_schedules(next_run_at);
+
+-- The drift each scheduled run was found to have against the run it was compared with. alert_id is
+-- the drift alert raised when the drift exceeded a threshold.
+CREATE TABLE audit_schedule_checks (
+  schedule_id        INTEGER NOT NULL REFERENCES audit_schedules(schedule_id) ON DELETE CASCADE,
+  audit_run_id       INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  base_audit_run_id  INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  external_grants    INTEGER NOT NULL,
+  permission_changes INTEGER NOT NULL,
+  sharing_links      INTEGER NOT NULL,
+  unique_items       INTEGER NOT NULL,
+  removed_principals INTEGER NOT NULL,
+  alert_id           INTEGER REFERENCES finding_alerts(alert_id),
+  checked_at         DATETIME NOT NULL,
+  PRIMARY KEY (schedule_id, audit_run_id)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 40;
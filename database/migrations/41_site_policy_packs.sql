@@ -0,0 +1,17 @@
+-- ====================
+-- Site policy packs
+-- ====================
+
+-- The policy pack an administrator chose for a site. Sites without one are checked with the pack
+-- their root web's template selects: communication, channel or team.
+CREATE TABLE site_policy_packs (
+  site_id INTEGER PRIMARY KEY REFERENCES sites(site_id),
+  pack    TEXT NOT NULL,    -- 'team', 'communication' or 'channel'
+  set_at  DATETIME NOT NULL
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 41;
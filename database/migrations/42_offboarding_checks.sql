@@ -0,0 +1,52 @@
+-- ====================
+-- Offboarding checks
+-- ====================
+
+-- A check of what a leaver still has across the latest run of every audited site, run as a job.
+-- report is the JSON report of what was found, kept for download; the counts are set once the
+-- check completes.
+CREATE TABLE offboarding_checks (
+  check_id      INTEGER PRIMARY KEY,
+  job_id        TEXT NOT NULL UNIQUE,
+  identity      TEXT NOT NULL,     -- The leaver's account or email, lowercased
+  remediate     BOOLEAN NOT NULL DEFAULT 0,
+  started_at    DATETIME NOT NULL,
+  completed_at  DATETIME,
+  access_count  INTEGER NOT NULL DEFAULT 0,
+  links_created INTEGER NOT NULL DEFAULT 0,
+  items_owned   INTEGER NOT NULL DEFAULT 0,
+  remediations  INTEGER NOT NULL DEFAULT 0,
+  report        TEXT,
+  error         TEXT
+);
+
+CREATE INDEX idx_offboarding_checks_started ON offboarding_checks(started_at);
+
+-- Actions a check queued to take away what the leaver still has. spaudit changes nothing in
+-- SharePoint; an administrator carries them out and records the outcome in status.
+CREATE TABLE offboarding_remediations (
+  remediation_id INTEGER PRIMARY KEY,
+  check_id       INTEGER NOT NULL REFERENCES offboarding_checks(check_id) ON DELETE CASCADE,
+  site_id        INTEGER NOT NULL,
+  site_url       TEXT NOT NULL,
+  audit_run_id   INTEGER NOT NULL,
+  action         TEXT NOT NULL,    -- 'remove_assignment', 'remove_group_member', 'remove_link_member' or 'revoke_link'
+  principal_id   INTEGER NOT NULL, -- The leaver's account
+  object_type    TEXT,
+  object_key     TEXT,
+  object_url     TEXT,
+  group_id       INTEGER,
+  link_id        TEXT,
+  detail         TEXT,             -- Role name, group title or link kind
+  status         TEXT NOT NULL DEFAULT 'queued',
+  queued_at      DATETIME NOT NULL,
+  updated_at     DATETIME
+);
+
+CREATE INDEX idx_offboarding_remediations_check ON offboarding_remediations(check_id, remediation_id);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 42;
@@ -0,0 +1,39 @@
+-- ====================
+-- Access policy findings
+-- ====================
+
+-- Each full audit run is checked against the access policies once it completes. An evaluation records
+-- when a run was checked, so a run without findings is told apart from one never checked; checking a
+-- run again replaces its evaluation and findings.
+CREATE TABLE policy_evaluations (
+  audit_run_id  INTEGER PRIMARY KEY REFERENCES audit_runs(audit_run_id),
+  site_id       INTEGER NOT NULL REFERENCES sites(site_id),
+  evaluated_at  DATETIME NOT NULL,
+  policies      INTEGER NOT NULL   -- Number of policies checked
+);
+
+CREATE INDEX idx_policy_evaluations_site ON policy_evaluations(site_id, audit_run_id);
+
+-- A sharing link or grant of an evaluated run that violates a policy
+CREATE TABLE policy_findings (
+  finding_id   INTEGER PRIMARY KEY,
+  audit_run_id INTEGER NOT NULL REFERENCES policy_evaluations(audit_run_id),
+  site_id      INTEGER NOT NULL,
+  policy       TEXT NOT NULL,
+  severity     TEXT NOT NULL,
+  object_type  TEXT NOT NULL,
+  object_key   TEXT NOT NULL,
+  object_url   TEXT NOT NULL,
+  object_name  TEXT NOT NULL,
+  subject      TEXT NOT NULL,     -- The sharing link ID, or the login name of the principal holding the access
+  detail       TEXT NOT NULL,
+  remediation  TEXT NOT NULL
+);
+
+CREATE INDEX idx_policy_findings_run ON policy_findings(audit_run_id, finding_id);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 43;
@@ -0,0 +1,30 @@
+-- ====================
+-- Notification webhook deliveries
+-- ====================
+
+-- Job completions, failures and high-severity findings are posted to each configured webhook endpoint.
+-- A delivery is kept per event and endpoint as the delivery log, so an event announced again is not
+-- posted twice and failed deliveries can be retried.
+CREATE TABLE webhook_deliveries (
+  delivery_id      INTEGER PRIMARY KEY,
+  endpoint_url     TEXT NOT NULL,
+  event_type       TEXT NOT NULL,
+  event_key        TEXT NOT NULL,     -- Identifies the event, e.g. job_event:{sequence} or finding_alert:{alert_id}
+  payload          TEXT NOT NULL,     -- JSON body posted to the endpoint
+  status           TEXT NOT NULL,     -- pending, delivered or failed
+  attempts         INTEGER NOT NULL DEFAULT 0,
+  response_status  INTEGER NOT NULL DEFAULT 0,  -- HTTP status of the last attempt, 0 when no response came
+  last_error       TEXT NOT NULL DEFAULT '',
+  created_at       DATETIME NOT NULL,
+  next_attempt_at  DATETIME,         -- Set while pending
+  delivered_at     DATETIME,
+  UNIQUE (endpoint_url, event_key)
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(status, next_attempt_at);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 44;
@@ -0,0 +1,22 @@
+-- ======================
+-- Sharing settings
+-- ======================
+
+-- Where the web sends requests for access: an email address, and whether they go to its owners
+-- group instead; NULL for runs before these were captured.
+ALTER TABLE webs ADD COLUMN request_access_email TEXT;
+ALTER TABLE webs ADD COLUMN use_access_request_default BOOLEAN;
+
+-- Sites an administrator tagged as holding sensitive content, whose lists members should not be
+-- able to share.
+CREATE TABLE site_sensitivity (
+  site_id   INTEGER PRIMARY KEY REFERENCES sites(site_id),
+  reason    TEXT NOT NULL DEFAULT '',
+  tagged_at DATETIME NOT NULL
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 45;
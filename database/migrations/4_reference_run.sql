@@ -0,0 +1,14 @@
+-- ======================
+-- Reference (pinned) audit run per site
+-- ======================
+
+-- A site can pin one audit run, e.g. the signed-off quarterly audit, as its reference run.
+-- Reachable through the "reference" run alias in run-scoped URLs.
+ALTER TABLE sites ADD COLUMN pinned_audit_run_id INTEGER REFERENCES audit_runs(audit_run_id);
+ALTER TABLE sites ADD COLUMN pinned_at           DATETIME;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 4;
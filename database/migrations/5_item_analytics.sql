@@ -0,0 +1,22 @@
+-- ======================
+-- Item usage analytics
+-- ======================
+
+-- All-time access counts for shared items, collected when an audit opts in to
+-- item analytics. Keyed by the file/folder UniqueId that sharing links target.
+-- view_count counts views and downloads; viewer_count counts distinct users.
+CREATE TABLE item_analytics (
+  site_id       INTEGER NOT NULL REFERENCES sites(site_id),
+  item_guid     TEXT NOT NULL,
+  audit_run_id  INTEGER NOT NULL REFERENCES audit_runs(audit_run_id),
+  view_count    INTEGER NOT NULL DEFAULT 0,
+  viewer_count  INTEGER NOT NULL DEFAULT 0,
+  collected_at  DATETIME DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (site_id, item_guid, audit_run_id)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 5;
@@ -0,0 +1,15 @@
+-- ======================
+-- Legal hold on audit runs
+-- ======================
+
+-- A run on hold, e.g. evidence for an ongoing investigation, must be kept as is:
+-- retention pruning and archival skip it until the hold is released.
+ALTER TABLE audit_runs ADD COLUMN on_hold     BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE audit_runs ADD COLUMN held_at     DATETIME;
+ALTER TABLE audit_runs ADD COLUMN hold_reason TEXT;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 6;
@@ -0,0 +1,26 @@
+-- ======================
+-- Tamper-evidence manifests for audit runs
+-- ======================
+
+-- Content hash of each list's canonical snapshot, recorded when a run is sealed.
+-- Recomputing the hashes later proves the stored run data is unchanged.
+CREATE TABLE audit_run_manifests (
+  audit_run_id  INTEGER PRIMARY KEY REFERENCES audit_runs(audit_run_id),
+  site_id       INTEGER NOT NULL REFERENCES sites(site_id),
+  algorithm     TEXT NOT NULL,
+  content_hash  TEXT NOT NULL, -- Hash over all entries
+  created_at    DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE audit_run_manifest_entries (
+  audit_run_id  INTEGER NOT NULL REFERENCES audit_run_manifests(audit_run_id),
+  list_id       TEXT NOT NULL,
+  content_hash  TEXT NOT NULL,
+  PRIMARY KEY (audit_run_id, list_id)
+);
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 7;
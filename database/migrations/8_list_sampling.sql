@@ -0,0 +1,14 @@
+-- ======================
+-- Sampled list scans
+-- ======================
+
+-- Items deep-scanned when an audit sampled the list instead of scanning every item.
+-- NULL when every item was scanned. For sampled lists unique_item_count and
+-- unique_density are estimates extrapolated from the sample.
+ALTER TABLE lists ADD COLUMN sampled_item_count INTEGER;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 8;
@@ -0,0 +1,13 @@
+-- ======================
+-- Folder-scoped audit runs
+-- ======================
+
+-- Server-relative path of the folder a targeted audit was limited to; NULL for
+-- full site audits. Scoped runs hold only that folder and the items below it.
+ALTER TABLE audit_runs ADD COLUMN scope_path TEXT;
+
+-- ====================
+-- Schema version
+-- ====================
+
+PRAGMA user_version = 9;
@@ -0,0 +1,42 @@
+-- name: CreateAttestation :one
+INSERT INTO attestations (site_id, audit_run_id, token, owner, created_at)
+VALUES (sqlc.arg(site_id), sqlc.arg(audit_run_id), sqlc.arg(token), sqlc.arg(owner), sqlc.arg(created_at))
+RETURNING attestation_id;
+
+-- name: GetAttestation :one
+SELECT attestation_id, site_id, audit_run_id, token, owner, created_at, submitted_at
+FROM attestations
+WHERE attestation_id = sqlc.arg(attestation_id);
+
+-- name: GetAttestationByToken :one
+SELECT attestation_id, site_id, audit_run_id, token, owner, created_at, submitted_at
+FROM attestations
+WHERE token = sqlc.arg(token);
+
+-- name: GetAttestationsForAuditRun :many
+SELECT a.attestation_id, a.site_id, a.audit_run_id, a.token, a.owner, a.created_at, a.submitted_at,
+       CAST(COALESCE(SUM(r.decision = 'keep'), 0) AS INTEGER) AS keep_count,
+       CAST(COALESCE(SUM(r.decision = 'remove'), 0) AS INTEGER) AS remove_count
+FROM attestations a
+LEFT JOIN attestation_responses r ON r.attestation_id = a.attestation_id
+WHERE a.site_id = sqlc.arg(site_id) AND a.audit_run_id = sqlc.arg(audit_run_id)
+GROUP BY a.attestation_id
+ORDER BY a.created_at DESC, a.attestation_id DESC;
+
+-- name: UpsertAttestationResponse :exec
+INSERT INTO attestation_responses (attestation_id, entry_key, decision, responded_at)
+VALUES (sqlc.arg(attestation_id), sqlc.arg(entry_key), sqlc.arg(decision), sqlc.arg(responded_at))
+ON CONFLICT (attestation_id, entry_key) DO UPDATE SET
+  decision = excluded.decision,
+  responded_at = excluded.responded_at
+WHERE attestation_responses.decision != excluded.decision;
+
+-- name: GetAttestationResponses :many
+SELECT entry_key, decision, responded_at
+FROM attestation_responses
+WHERE attestation_id = sqlc.arg(attestation_id);
+
+-- name: MarkAttestationSubmitted :exec
+UPDATE attestations
+SET submitted_at = sqlc.arg(submitted_at)
+WHERE attestation_id = sqlc.arg(attestation_id);
@@ -0,0 +1,13 @@
+-- name: UpsertListCheckpoint :exec
+INSERT INTO audit_checkpoints (audit_run_id, list_id, last_item_id, completed, updated_at)
+VALUES (sqlc.arg(audit_run_id), sqlc.arg(list_id), sqlc.arg(last_item_id), sqlc.arg(completed), sqlc.arg(updated_at))
+ON CONFLICT (audit_run_id, list_id) DO UPDATE SET
+  last_item_id = excluded.last_item_id,
+  completed    = excluded.completed,
+  updated_at   = excluded.updated_at;
+
+-- name: GetListCheckpointsByAuditRun :many
+SELECT list_id, last_item_id, completed, updated_at
+FROM audit_checkpoints
+WHERE audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY list_id;
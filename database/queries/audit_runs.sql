@@ -1,27 +1,64 @@
 -- name: CreateAuditRun :one
-INSERT INTO audit_runs (job_id, site_id, started_at, audit_trigger)
-VALUES (sqlc.arg(job_id), sqlc.arg(site_id), sqlc.arg(started_at), sqlc.arg(audit_trigger))
+INSERT INTO audit_runs (job_id, site_id, started_at, audit_trigger, scope_path, label, parameters_json)
+VALUES (sqlc.arg(job_id), sqlc.arg(site_id), sqlc.arg(started_at), sqlc.arg(audit_trigger), sqlc.arg(scope_path), sqlc.arg(label), sqlc.arg(parameters_json))
 RETURNING audit_run_id;
 
 -- name: GetAuditRun :one
-SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger
+SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, on_hold, held_at, hold_reason, scope_path, label
 FROM audit_runs
 WHERE audit_run_id = sqlc.arg(audit_run_id);
 
+-- name: GetAuditRunByJobID :one
+SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, on_hold, held_at, hold_reason, scope_path, label
+FROM audit_runs
+WHERE job_id = sqlc.arg(job_id);
+
+-- name: GetAuditRunParametersByJobID :one
+SELECT audit_run_id, parameters_json
+FROM audit_runs
+WHERE job_id = sqlc.arg(job_id);
+
+-- An empty label lists every run of the site.
 -- name: GetAuditRunsForSite :many
-SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger
+SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, on_hold, held_at, hold_reason, scope_path, label
 FROM audit_runs
-WHERE site_id = sqlc.arg(site_id)
+WHERE site_id = sqlc.arg(site_id) AND (CAST(sqlc.arg(label) AS TEXT) = '' OR label = sqlc.arg(label))
 ORDER BY started_at DESC
 LIMIT sqlc.arg(limit_count);
 
+-- Full site runs are preferred; a folder-scoped run is only latest when the site has no other.
+-- A label restricts the candidates to runs tagged with it.
 -- name: GetLatestAuditRunForSite :one
-SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger
+SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, on_hold, held_at, hold_reason, scope_path, label
 FROM audit_runs
-WHERE site_id = sqlc.arg(site_id)
-ORDER BY started_at DESC
+WHERE site_id = sqlc.arg(site_id) AND (CAST(sqlc.arg(label) AS TEXT) = '' OR label = sqlc.arg(label))
+ORDER BY scope_path IS NULL DESC, started_at DESC
 LIMIT 1;
 
+-- name: GetLatestCompletedAuditRunForSite :one
+SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, on_hold, held_at, hold_reason, scope_path, label
+FROM audit_runs
+WHERE site_id = sqlc.arg(site_id) AND completed_at IS NOT NULL
+  AND (CAST(sqlc.arg(label) AS TEXT) = '' OR label = sqlc.arg(label))
+ORDER BY scope_path IS NULL DESC, started_at DESC
+LIMIT 1;
+
+-- name: GetPinnedAuditRunForSite :one
+SELECT ar.audit_run_id, ar.job_id, ar.site_id, ar.started_at, ar.completed_at, ar.audit_trigger, ar.on_hold, ar.held_at, ar.hold_reason, ar.scope_path, ar.label
+FROM sites s
+JOIN audit_runs ar ON ar.audit_run_id = s.pinned_audit_run_id AND ar.site_id = s.site_id
+WHERE s.site_id = sqlc.arg(site_id);
+
+-- name: HoldAuditRun :exec
+UPDATE audit_runs
+SET on_hold = TRUE, held_at = CURRENT_TIMESTAMP, hold_reason = sqlc.arg(hold_reason)
+WHERE audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: ReleaseAuditRunHold :exec
+UPDATE audit_runs
+SET on_hold = FALSE, held_at = NULL, hold_reason = NULL
+WHERE audit_run_id = sqlc.arg(audit_run_id);
+
 -- name: CompleteAuditRun :exec
 UPDATE audit_runs
 SET completed_at = CURRENT_TIMESTAMP
@@ -45,4 +82,118 @@ AND job_id IN (
     SELECT job_id 
     FROM jobs 
     WHERE completed_at IS NOT NULL
-);
\ No newline at end of file
+);
+-- name: CreateAuditRunManifest :exec
+INSERT INTO audit_run_manifests (audit_run_id, site_id, algorithm, content_hash)
+VALUES (sqlc.arg(audit_run_id), sqlc.arg(site_id), sqlc.arg(algorithm), sqlc.arg(content_hash));
+
+-- name: AddAuditRunManifestEntry :exec
+INSERT INTO audit_run_manifest_entries (audit_run_id, list_id, content_hash)
+VALUES (sqlc.arg(audit_run_id), sqlc.arg(list_id), sqlc.arg(content_hash));
+
+-- name: GetAuditRunManifest :one
+SELECT audit_run_id, site_id, algorithm, content_hash, created_at
+FROM audit_run_manifests
+WHERE audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: GetAuditRunManifestEntries :many
+SELECT list_id, content_hash
+FROM audit_run_manifest_entries
+WHERE audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY list_id;
+
+-- name: CreateAuditRunArtifact :one
+INSERT INTO audit_run_artifacts (audit_run_id, site_id, filename, content_type, size_bytes, content, content_encoding, created_at, expires_at)
+VALUES (sqlc.arg(audit_run_id), sqlc.arg(site_id), sqlc.arg(filename), sqlc.arg(content_type), sqlc.arg(size_bytes), sqlc.arg(content), sqlc.arg(content_encoding), sqlc.arg(created_at), sqlc.arg(expires_at))
+RETURNING artifact_id;
+
+-- name: GetAuditRunArtifact :one
+-- Artifacts of runs on hold are kept past their expiry
+SELECT a.artifact_id, a.audit_run_id, a.site_id, a.filename, a.content_type, a.size_bytes, a.content, a.content_encoding, a.created_at, a.expires_at
+FROM audit_run_artifacts a
+JOIN audit_runs ar ON ar.audit_run_id = a.audit_run_id
+WHERE a.artifact_id = sqlc.arg(artifact_id) AND (a.expires_at > sqlc.arg(now) OR ar.on_hold);
+
+-- name: GetAuditRunArtifacts :many
+SELECT a.artifact_id, a.audit_run_id, a.site_id, a.filename, a.content_type, a.size_bytes, a.created_at, a.expires_at
+FROM audit_run_artifacts a
+JOIN audit_runs ar ON ar.audit_run_id = a.audit_run_id
+WHERE a.audit_run_id = sqlc.arg(audit_run_id) AND (a.expires_at > sqlc.arg(now) OR ar.on_hold)
+ORDER BY a.created_at DESC, a.artifact_id DESC;
+
+-- name: GetSiteAuditRunArtifacts :many
+SELECT a.artifact_id, a.audit_run_id, a.site_id, a.filename, a.content_type, a.size_bytes, a.created_at, a.expires_at
+FROM audit_run_artifacts a
+JOIN audit_runs ar ON ar.audit_run_id = a.audit_run_id
+WHERE a.site_id = sqlc.arg(site_id) AND (a.expires_at > sqlc.arg(now) OR ar.on_hold)
+ORDER BY a.created_at DESC, a.artifact_id DESC;
+
+-- name: DeleteExpiredAuditRunArtifacts :execrows
+-- Runs on hold are exempt from retention, and so are their artifacts
+DELETE FROM audit_run_artifacts
+WHERE expires_at <= sqlc.arg(now)
+  AND audit_run_id NOT IN (SELECT audit_run_id FROM audit_runs WHERE on_hold);
+
+-- name: CreateSiteBaseline :one
+INSERT INTO site_baselines (site_id, audit_run_id, approved_by, note, approved_at)
+VALUES (sqlc.arg(site_id), sqlc.arg(audit_run_id), sqlc.arg(approved_by), sqlc.arg(note), sqlc.arg(approved_at))
+RETURNING baseline_id;
+
+-- name: SupersedeSiteBaseline :execrows
+UPDATE site_baselines
+SET superseded_at = sqlc.arg(superseded_at)
+WHERE site_id = sqlc.arg(site_id) AND superseded_at IS NULL;
+
+-- name: GetCurrentSiteBaseline :one
+SELECT baseline_id, site_id, audit_run_id, approved_by, note, approved_at, superseded_at
+FROM site_baselines
+WHERE site_id = sqlc.arg(site_id) AND superseded_at IS NULL;
+
+-- name: GetSiteBaselines :many
+SELECT baseline_id, site_id, audit_run_id, approved_by, note, approved_at, superseded_at
+FROM site_baselines
+WHERE site_id = sqlc.arg(site_id)
+ORDER BY approved_at DESC, baseline_id DESC;
+
+-- name: InsertAuditRunSchemaDrift :exec
+INSERT INTO audit_run_schema_drift (audit_run_id, source, field, kind, occurrences)
+VALUES (sqlc.arg(audit_run_id), sqlc.arg(source), sqlc.arg(field), sqlc.arg(kind), sqlc.arg(occurrences))
+ON CONFLICT (audit_run_id, source, field, kind) DO UPDATE SET occurrences = occurrences + excluded.occurrences;
+
+-- name: GetAuditRunSchemaDrift :many
+SELECT source, field, kind, occurrences
+FROM audit_run_schema_drift
+WHERE audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY kind, source, field;
+
+-- name: InsertAuditRunPayloadSample :exec
+INSERT INTO audit_run_payload_samples (audit_run_id, source, sample_key, payload, payload_encoding, truncated, schema_drift, captured_at)
+VALUES (sqlc.arg(audit_run_id), sqlc.arg(source), sqlc.arg(sample_key), sqlc.arg(payload), sqlc.arg(payload_encoding), sqlc.arg(truncated), sqlc.arg(schema_drift), sqlc.arg(captured_at));
+
+-- name: GetAuditRunPayloadSamples :many
+SELECT sample_id, source, sample_key, payload, payload_encoding, truncated, schema_drift, captured_at
+FROM audit_run_payload_samples
+WHERE audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY sample_id;
+
+-- Row counts of the most recent runs, for storage monitoring.
+-- name: GetAuditRunRowCounts :many
+SELECT ar.audit_run_id, ar.site_id, s.site_url,
+  CAST((SELECT COUNT(*) FROM items i WHERE i.audit_run_id = ar.audit_run_id) AS INTEGER) AS items,
+  CAST((SELECT COUNT(*) FROM role_assignments ra WHERE ra.audit_run_id = ar.audit_run_id) AS INTEGER) AS role_assignments,
+  CAST((SELECT COUNT(*) FROM sharing_links sl WHERE sl.audit_run_id = ar.audit_run_id) AS INTEGER) AS sharing_links,
+  CAST((SELECT COUNT(*) FROM principals p WHERE p.audit_run_id = ar.audit_run_id) AS INTEGER) AS principals
+FROM audit_runs ar
+JOIN sites s ON s.site_id = ar.site_id
+ORDER BY ar.audit_run_id DESC
+LIMIT sqlc.arg(limit_count);
+
+-- The completed run of the site with the same scope, the same folder or the whole site, started most recently before the given run.
+-- name: GetPreviousCompletedAuditRunForScope :one
+SELECT ar.audit_run_id, ar.job_id, ar.site_id, ar.started_at, ar.completed_at, ar.audit_trigger, ar.on_hold, ar.held_at, ar.hold_reason, ar.scope_path, ar.label
+FROM audit_runs ar
+JOIN audit_runs current_run ON current_run.audit_run_id = sqlc.arg(audit_run_id)
+WHERE ar.site_id = current_run.site_id AND ar.scope_path IS current_run.scope_path AND ar.completed_at IS NOT NULL
+  AND ar.started_at < current_run.started_at
+ORDER BY ar.started_at DESC, ar.audit_run_id DESC
+LIMIT 1;
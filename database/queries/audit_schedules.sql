@@ -0,0 +1,68 @@
+-- name: CreateAuditSchedule :one
+INSERT INTO audit_schedules (site_url, interval_seconds, compare_with, max_external_grants, max_permission_changes,
+                             max_sharing_links, max_unique_items, max_removed_principals, created_at, next_run_at)
+VALUES (sqlc.arg(site_url), sqlc.arg(interval_seconds), sqlc.arg(compare_with), sqlc.narg(max_external_grants),
+        sqlc.narg(max_permission_changes), sqlc.narg(max_sharing_links), sqlc.narg(max_unique_items),
+        sqlc.narg(max_removed_principals), sqlc.arg(created_at), sqlc.arg(next_run_at))
+RETURNING schedule_id;
+
+-- name: GetAuditSchedule :one
+SELECT schedule_id, site_url, interval_seconds, compare_with, max_external_grants, max_permission_changes,
+       max_sharing_links, max_unique_items, max_removed_principals, created_at, next_run_at, last_job_id, last_run_at
+FROM audit_schedules
+WHERE schedule_id = sqlc.arg(schedule_id);
+
+-- name: GetAuditScheduleForSite :one
+SELECT schedule_id, site_url, interval_seconds, compare_with, max_external_grants, max_permission_changes,
+       max_sharing_links, max_unique_items, max_removed_principals, created_at, next_run_at, last_job_id, last_run_at
+FROM audit_schedules
+WHERE site_url = sqlc.arg(site_url);
+
+-- name: GetAuditScheduleForJob :one
+SELECT schedule_id, site_url, interval_seconds, compare_with, max_external_grants, max_permission_changes,
+       max_sharing_links, max_unique_items, max_removed_principals, created_at, next_run_at, last_job_id, last_run_at
+FROM audit_schedules
+WHERE last_job_id = sqlc.arg(job_id);
+
+-- name: GetAuditSchedules :many
+SELECT schedule_id, site_url, interval_seconds, compare_with, max_external_grants, max_permission_changes,
+       max_sharing_links, max_unique_items, max_removed_principals, created_at, next_run_at, last_job_id, last_run_at
+FROM audit_schedules
+ORDER BY site_url, schedule_id;
+
+-- name: GetDueAuditSchedules :many
+SELECT schedule_id, site_url, interval_seconds, compare_with, max_external_grants, max_permission_changes,
+       max_sharing_links, max_unique_items, max_removed_principals, created_at, next_run_at, last_job_id, last_run_at
+FROM audit_schedules
+WHERE next_run_at <= sqlc.arg(now)
+ORDER BY next_run_at, schedule_id;
+
+-- name: RecordAuditScheduleRun :exec
+UPDATE audit_schedules
+SET next_run_at = sqlc.arg(next_run_at), last_job_id = sqlc.arg(last_job_id), last_run_at = sqlc.arg(last_run_at)
+WHERE schedule_id = sqlc.arg(schedule_id);
+
+-- name: DeleteAuditSchedule :execrows
+DELETE FROM audit_schedules
+WHERE schedule_id = sqlc.arg(schedule_id);
+
+-- name: CreateAuditScheduleCheck :exec
+INSERT INTO audit_schedule_checks (schedule_id, audit_run_id, base_audit_run_id, external_grants, permission_changes,
+                                   sharing_links, unique_items, removed_principals, alert_id, checked_at)
+VALUES (sqlc.arg(schedule_id), sqlc.arg(audit_run_id), sqlc.arg(base_audit_run_id), sqlc.arg(external_grants),
+        sqlc.arg(permission_changes), sqlc.arg(sharing_links), sqlc.arg(unique_items), sqlc.arg(removed_principals),
+        sqlc.narg(alert_id), sqlc.arg(checked_at));
+
+-- name: GetAuditScheduleCheck :one
+SELECT schedule_id, audit_run_id, base_audit_run_id, external_grants, permission_changes, sharing_links, unique_items,
+       removed_principals, alert_id, checked_at
+FROM audit_schedule_checks
+WHERE schedule_id = sqlc.arg(schedule_id) AND audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: GetAuditScheduleChecks :many
+SELECT schedule_id, audit_run_id, base_audit_run_id, external_grants, permission_changes, sharing_links, unique_items,
+       removed_principals, alert_id, checked_at
+FROM audit_schedule_checks
+WHERE schedule_id = sqlc.arg(schedule_id)
+ORDER BY checked_at DESC, audit_run_id DESC
+LIMIT sqlc.arg(limit_count);
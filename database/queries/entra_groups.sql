@@ -0,0 +1,68 @@
+-- name: GetSecurityGroupPrincipalsByAuditRun :many
+-- Security group principals an audit run recorded, holding roles directly or through SharePoint groups
+SELECT principal_id, title, login_name
+FROM principals
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id) AND principal_type = 4
+ORDER BY principal_id;
+
+-- name: DeleteEntraGroupMembers :exec
+DELETE FROM entra_group_members
+WHERE site_id = sqlc.arg(site_id) AND principal_id = sqlc.arg(principal_id) AND audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: InsertEntraGroup :exec
+INSERT OR REPLACE INTO entra_groups (site_id, principal_id, audit_run_id, object_id, owners, member_count, resolved_at)
+VALUES (sqlc.arg(site_id), sqlc.arg(principal_id), sqlc.arg(audit_run_id), sqlc.arg(object_id), sqlc.arg(owners),
+        sqlc.arg(member_count), sqlc.arg(resolved_at));
+
+-- name: InsertEntraGroupMember :exec
+INSERT OR IGNORE INTO entra_group_members (site_id, principal_id, audit_run_id, member_object_id, display_name,
+                                           user_principal_name, mail, user_type)
+VALUES (sqlc.arg(site_id), sqlc.arg(principal_id), sqlc.arg(audit_run_id), sqlc.arg(member_object_id), sqlc.arg(display_name),
+        sqlc.arg(user_principal_name), sqlc.arg(mail), sqlc.arg(user_type));
+
+-- name: CountEffectiveUsersForObjectByAuditRun :one
+-- Distinct users an object's role assignments reach, matched by UPN: users assigned directly or through
+-- SharePoint groups, and the users Entra group expansion resolved for Entra groups assigned either way.
+-- Entra groups the run did not resolve are counted apart, as the users they reach are unknown.
+WITH reached AS (
+  SELECT ra.principal_id
+  FROM role_assignments ra
+  WHERE ra.site_id = sqlc.arg(site_id) AND ra.object_type = sqlc.arg(object_type) AND ra.object_key = sqlc.arg(object_key)
+    AND ra.audit_run_id = sqlc.arg(audit_run_id)
+  UNION
+  SELECT gm.member_id
+  FROM role_assignments ra
+  JOIN group_members gm ON gm.site_id = ra.site_id AND gm.group_id = ra.principal_id AND gm.audit_run_id = ra.audit_run_id
+  WHERE ra.site_id = sqlc.arg(site_id) AND ra.object_type = sqlc.arg(object_type) AND ra.object_key = sqlc.arg(object_key)
+    AND ra.audit_run_id = sqlc.arg(audit_run_id)
+),
+reached_users AS (
+  SELECT lower(substr(p.login_name, instr(p.login_name, '|membership|') + 12)) AS upn
+  FROM reached r
+  JOIN principals p ON p.site_id = sqlc.arg(site_id) AND p.principal_id = r.principal_id AND p.audit_run_id = sqlc.arg(audit_run_id)
+  WHERE p.principal_type = 1 AND instr(p.login_name, '|membership|') > 0
+  UNION
+  SELECT lower(COALESCE(NULLIF(m.user_principal_name, ''), m.member_object_id)) AS upn
+  FROM reached r
+  JOIN entra_group_members m ON m.site_id = sqlc.arg(site_id) AND m.principal_id = r.principal_id AND m.audit_run_id = sqlc.arg(audit_run_id)
+),
+reached_entra_groups AS (
+  SELECT CAST(g.principal_id IS NOT NULL AS INTEGER) AS resolved
+  FROM reached r
+  JOIN principals p ON p.site_id = sqlc.arg(site_id) AND p.principal_id = r.principal_id AND p.audit_run_id = sqlc.arg(audit_run_id)
+  LEFT JOIN entra_groups g ON g.site_id = p.site_id AND g.principal_id = p.principal_id AND g.audit_run_id = p.audit_run_id
+  WHERE p.principal_type = 4
+    AND (p.login_name LIKE 'c:0t.c|tenant|%' OR p.login_name LIKE 'c:0o.c|federateddirectoryclaimprovider|%')
+)
+SELECT
+  CAST((SELECT COUNT(*) FROM reached_users) AS INTEGER) AS effective_users,
+  CAST((SELECT COUNT(*) FROM reached_entra_groups WHERE resolved = 1) AS INTEGER) AS resolved_groups,
+  CAST((SELECT COUNT(*) FROM reached_entra_groups WHERE resolved = 0) AS INTEGER) AS unresolved_groups;
+
+-- name: GetEntraGroupIDsForUserByAuditRun :many
+-- Entra group principals whose resolved users include a user, matched by UPN or mail in lower case
+SELECT DISTINCT principal_id
+FROM entra_group_members
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id)
+  AND (lower(user_principal_name) = CAST(sqlc.arg(identity) AS TEXT) OR lower(mail) = CAST(sqlc.arg(identity) AS TEXT))
+ORDER BY principal_id;
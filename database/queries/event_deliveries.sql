@@ -0,0 +1,38 @@
+-- name: InitEventHandlerOffset :exec
+INSERT INTO event_handler_offsets (handler, last_sequence, updated_at)
+VALUES (sqlc.arg(handler), sqlc.arg(last_sequence), sqlc.arg(updated_at))
+ON CONFLICT (handler) DO NOTHING;
+
+-- name: GetEventHandlerOffset :one
+SELECT last_sequence
+FROM event_handler_offsets
+WHERE handler = sqlc.arg(handler);
+
+-- name: SetEventHandlerOffset :exec
+UPDATE event_handler_offsets
+SET last_sequence = sqlc.arg(last_sequence), updated_at = sqlc.arg(updated_at)
+WHERE handler = sqlc.arg(handler);
+
+-- name: UpsertEventDeadLetter :one
+INSERT INTO event_dead_letters (handler, sequence, attempts, last_error, failed_at)
+VALUES (sqlc.arg(handler), sqlc.arg(sequence), sqlc.arg(attempts), sqlc.arg(last_error), sqlc.arg(failed_at))
+ON CONFLICT (handler, sequence) DO UPDATE SET
+  attempts = event_dead_letters.attempts + excluded.attempts,
+  last_error = excluded.last_error,
+  failed_at = excluded.failed_at
+RETURNING dead_letter_id;
+
+-- name: GetEventDeadLetter :one
+SELECT dead_letter_id, handler, sequence, attempts, last_error, failed_at
+FROM event_dead_letters
+WHERE dead_letter_id = sqlc.arg(dead_letter_id);
+
+-- name: ListEventDeadLetters :many
+SELECT dead_letter_id, handler, sequence, attempts, last_error, failed_at
+FROM event_dead_letters
+ORDER BY failed_at DESC, dead_letter_id DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: DeleteEventDeadLetter :execrows
+DELETE FROM event_dead_letters
+WHERE dead_letter_id = sqlc.arg(dead_letter_id);
@@ -0,0 +1,33 @@
+-- name: CreateFindingAlert :one
+INSERT INTO finding_alerts (site_id, audit_run_id, fingerprint, category, severity, message, created_at, delivered_at)
+VALUES (sqlc.arg(site_id), sqlc.arg(audit_run_id), sqlc.arg(fingerprint), sqlc.arg(category), sqlc.arg(severity),
+        sqlc.arg(message), sqlc.arg(created_at), sqlc.narg(delivered_at))
+RETURNING alert_id;
+
+-- name: GetFindingAlertsForSite :many
+SELECT alert_id, site_id, audit_run_id, fingerprint, category, severity, message, created_at, delivered_at
+FROM finding_alerts
+WHERE site_id = sqlc.arg(site_id)
+ORDER BY created_at DESC, alert_id DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetQueuedFindingAlerts :many
+SELECT alert_id, site_id, audit_run_id, fingerprint, category, severity, message, created_at, delivered_at
+FROM finding_alerts
+WHERE delivered_at IS NULL
+ORDER BY created_at, alert_id;
+
+-- name: MarkFindingAlertDelivered :exec
+UPDATE finding_alerts
+SET delivered_at = sqlc.arg(delivered_at)
+WHERE alert_id = sqlc.arg(alert_id) AND delivered_at IS NULL;
+
+-- name: GetFindingAlertSightings :many
+SELECT fingerprint, last_audit_run_id
+FROM finding_alert_sightings
+WHERE site_id = sqlc.arg(site_id);
+
+-- name: UpsertFindingAlertSighting :exec
+INSERT INTO finding_alert_sightings (site_id, fingerprint, last_audit_run_id)
+VALUES (sqlc.arg(site_id), sqlc.arg(fingerprint), sqlc.arg(audit_run_id))
+ON CONFLICT (site_id, fingerprint) DO UPDATE SET last_audit_run_id = excluded.last_audit_run_id;
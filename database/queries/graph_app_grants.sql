@@ -0,0 +1,19 @@
+-- name: InsertGraphGrantScan :exec
+INSERT OR REPLACE INTO graph_grant_scans (site_id, audit_run_id, scanned_at)
+VALUES (sqlc.arg(site_id), sqlc.arg(audit_run_id), sqlc.arg(scanned_at));
+
+-- name: InsertGraphAppGrant :exec
+INSERT OR IGNORE INTO graph_app_grants (site_id, audit_run_id, grant_kind, grant_id, app_id, app_name, roles, resource)
+VALUES (sqlc.arg(site_id), sqlc.arg(audit_run_id), sqlc.arg(grant_kind), sqlc.arg(grant_id), sqlc.arg(app_id), sqlc.arg(app_name),
+        sqlc.arg(roles), sqlc.arg(resource));
+
+-- name: GetGraphGrantScan :one
+SELECT scanned_at
+FROM graph_grant_scans
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: GetGraphAppGrantsByAuditRun :many
+SELECT grant_kind, grant_id, app_id, app_name, roles, resource
+FROM graph_app_grants
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY grant_kind, app_name, app_id, grant_id;
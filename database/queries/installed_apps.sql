@@ -0,0 +1,37 @@
+-- name: InsertAppInventory :exec
+INSERT OR REPLACE INTO app_inventories (site_id, audit_run_id, collected_at)
+VALUES (sqlc.arg(site_id), sqlc.arg(audit_run_id), sqlc.arg(collected_at));
+
+-- name: InsertInstalledApp :exec
+INSERT OR IGNORE INTO installed_apps (site_id, audit_run_id, app_id, product_id, title, app_source, app_principal_id, last_modified)
+VALUES (sqlc.arg(site_id), sqlc.arg(audit_run_id), sqlc.arg(app_id), sqlc.arg(product_id), sqlc.arg(title), sqlc.arg(app_source),
+        sqlc.arg(app_principal_id), sqlc.arg(last_modified));
+
+-- name: GetAppInventory :one
+SELECT collected_at
+FROM app_inventories
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: GetInstalledAppsByAuditRun :many
+SELECT app_id, product_id, title, app_source, app_principal_id, last_modified
+FROM installed_apps
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY title, app_id;
+
+-- name: GetAppPermissionFactsForAuditRun :many
+-- Explicit assignments of app principals on the web, lists and items, described by the object's URL.
+-- The login name prefixes are those sharepoint.ClassifyPrincipal classifies as apps.
+SELECT ra.object_type, ra.object_key,
+       CAST(COALESCE(w.url, l.url, i.url, '') AS TEXT) AS object_url,
+       CAST(COALESCE(w.title, l.title, i.name, '') AS TEXT) AS object_name,
+       ra.principal_id, p.title AS principal_title, p.login_name, p.email, p.principal_type,
+       ra.role_def_id, rd.name AS role_name
+FROM role_assignments ra
+JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+LEFT JOIN webs w ON ra.object_type = 'web' AND w.site_id = ra.site_id AND w.web_id = ra.object_key AND w.audit_run_id = ra.audit_run_id
+LEFT JOIN lists l ON ra.object_type = 'list' AND l.site_id = ra.site_id AND l.list_id = ra.object_key AND l.audit_run_id = ra.audit_run_id
+LEFT JOIN items i ON ra.object_type = 'item' AND i.site_id = ra.site_id AND i.item_guid = ra.object_key AND i.audit_run_id = ra.audit_run_id
+LEFT JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE ra.site_id = sqlc.arg(site_id) AND ra.audit_run_id = sqlc.arg(audit_run_id)
+  AND (LOWER(p.login_name) LIKE 'i:0i.t|ms.sp.ext|%' OR LOWER(p.login_name) LIKE 'i:0i.t|00000003-0000-0ff1-ce00-000000000000|%')
+ORDER BY ra.principal_id, ra.object_type, ra.object_key, ra.role_def_id;
@@ -1,6 +1,24 @@
 -- name: InsertItem :exec
-INSERT INTO items (site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id)
-VALUES (sqlc.arg(site_id), sqlc.arg(item_guid), sqlc.arg(list_item_guid), sqlc.arg(list_id), sqlc.arg(item_id), sqlc.arg(url), sqlc.arg(is_file), sqlc.arg(is_folder), sqlc.arg(has_unique), sqlc.arg(name), sqlc.arg(audit_run_id));
+INSERT INTO items (site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id,
+                   file_folder_unique_id, file_size, sp_created_at, sp_modified_at, content_type_id, unreadable_fields)
+VALUES (sqlc.arg(site_id), sqlc.arg(item_guid), sqlc.arg(list_item_guid), sqlc.arg(list_id), sqlc.arg(item_id), sqlc.arg(url), sqlc.arg(is_file), sqlc.arg(is_folder), sqlc.arg(has_unique), sqlc.arg(name), sqlc.arg(audit_run_id),
+        sqlc.arg(file_folder_unique_id), sqlc.arg(file_size), sqlc.arg(sp_created_at), sqlc.arg(sp_modified_at), sqlc.arg(content_type_id), sqlc.arg(unreadable_fields))
+ON CONFLICT (site_id, item_guid, audit_run_id) DO UPDATE SET
+  list_item_guid = excluded.list_item_guid, list_id = excluded.list_id, item_id = excluded.item_id, url = excluded.url,
+  is_file = excluded.is_file, is_folder = excluded.is_folder, has_unique = excluded.has_unique, name = excluded.name,
+  file_folder_unique_id = excluded.file_folder_unique_id, file_size = excluded.file_size,
+  sp_created_at = excluded.sp_created_at, sp_modified_at = excluded.sp_modified_at,
+  content_type_id = excluded.content_type_id, unreadable_fields = excluded.unreadable_fields;
+
+-- name: InsertItemAttachment :exec
+INSERT OR IGNORE INTO item_attachments (site_id, item_guid, audit_run_id, file_name, url)
+VALUES (sqlc.arg(site_id), sqlc.arg(item_guid), sqlc.arg(audit_run_id), sqlc.arg(file_name), sqlc.arg(url));
+
+-- name: GetItemAttachmentsByAuditRun :many
+SELECT file_name, url
+FROM item_attachments
+WHERE site_id = sqlc.arg(site_id) AND item_guid = sqlc.arg(item_guid) AND audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY file_name;
 
 -- name: ItemsWithUniqueForList :many
 SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id
@@ -17,24 +35,76 @@ ORDER BY item_id
 LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
 
 -- name: ItemsForList :many
-SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id, unreadable_fields
 FROM items
 WHERE site_id = sqlc.arg(site_id) AND list_id = sqlc.arg(list_id)
 ORDER BY item_id
 LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
 
 -- name: ItemsForListByAuditRun :many
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id, unreadable_fields
+FROM items
+WHERE site_id = sqlc.arg(site_id) AND list_id = sqlc.arg(list_id) AND audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY item_id
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: FilteredItemsForList :many
+-- Optional filters: unique_only (0/1) and kind ('', 'file', 'folder' or 'item').
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id
+FROM items
+WHERE site_id = sqlc.arg(site_id) AND list_id = sqlc.arg(list_id)
+  AND (CAST(sqlc.arg(unique_only) AS INTEGER) = 0 OR has_unique = 1)
+  AND (CAST(sqlc.arg(kind) AS TEXT) = ''
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'file' AND is_file = 1)
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'folder' AND is_folder = 1)
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'item' AND COALESCE(is_file, 0) = 0 AND COALESCE(is_folder, 0) = 0))
+ORDER BY item_id
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
+
+-- name: FilteredItemsForListByAuditRun :many
 SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id
 FROM items
 WHERE site_id = sqlc.arg(site_id) AND list_id = sqlc.arg(list_id) AND audit_run_id = sqlc.arg(audit_run_id)
+  AND (CAST(sqlc.arg(unique_only) AS INTEGER) = 0 OR has_unique = 1)
+  AND (CAST(sqlc.arg(kind) AS TEXT) = ''
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'file' AND is_file = 1)
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'folder' AND is_folder = 1)
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'item' AND COALESCE(is_file, 0) = 0 AND COALESCE(is_folder, 0) = 0))
 ORDER BY item_id
 LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
 
+-- name: CountFilteredItemsForList :one
+SELECT COUNT(*)
+FROM items
+WHERE site_id = sqlc.arg(site_id) AND list_id = sqlc.arg(list_id)
+  AND (CAST(sqlc.arg(unique_only) AS INTEGER) = 0 OR has_unique = 1)
+  AND (CAST(sqlc.arg(kind) AS TEXT) = ''
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'file' AND is_file = 1)
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'folder' AND is_folder = 1)
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'item' AND COALESCE(is_file, 0) = 0 AND COALESCE(is_folder, 0) = 0));
+
+-- name: CountFilteredItemsForListByAuditRun :one
+SELECT COUNT(*)
+FROM items
+WHERE site_id = sqlc.arg(site_id) AND list_id = sqlc.arg(list_id) AND audit_run_id = sqlc.arg(audit_run_id)
+  AND (CAST(sqlc.arg(unique_only) AS INTEGER) = 0 OR has_unique = 1)
+  AND (CAST(sqlc.arg(kind) AS TEXT) = ''
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'file' AND is_file = 1)
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'folder' AND is_folder = 1)
+       OR (CAST(sqlc.arg(kind) AS TEXT) = 'item' AND COALESCE(is_file, 0) = 0 AND COALESCE(is_folder, 0) = 0));
+
 -- name: GetItemByGUID :one
-SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id,
+       file_folder_unique_id, file_size, sp_created_at, sp_modified_at, content_type_id, unreadable_fields
 FROM items
 WHERE site_id = sqlc.arg(site_id) AND item_guid = sqlc.arg(item_guid);
 
+-- name: GetItemByGUIDByAuditRun :one
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id,
+       file_folder_unique_id, file_size, sp_created_at, sp_modified_at, content_type_id, unreadable_fields
+FROM items
+WHERE site_id = sqlc.arg(site_id) AND item_guid = sqlc.arg(item_guid) AND audit_run_id = sqlc.arg(audit_run_id);
+
 -- name: GetItemByListAndID :one
 SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id
 FROM items
@@ -49,3 +119,77 @@ WHERE site_id = sqlc.arg(site_id) AND list_id = sqlc.arg(list_id) AND item_guid
 SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id
 FROM items
 WHERE site_id = sqlc.arg(site_id) AND list_item_guid = sqlc.arg(list_item_guid);
+
+-- name: CountItemsByKindForListByAuditRun :one
+-- Item totals for a list, for analytics without loading each item
+SELECT
+  CAST(COUNT(*) AS INTEGER) AS total_items,
+  CAST(COALESCE(SUM(CASE WHEN has_unique THEN 1 ELSE 0 END), 0) AS INTEGER) AS items_with_unique,
+  CAST(COALESCE(SUM(CASE WHEN is_file THEN 1 ELSE 0 END), 0) AS INTEGER) AS files_count,
+  CAST(COALESCE(SUM(CASE WHEN is_folder AND NOT COALESCE(is_file, FALSE) THEN 1 ELSE 0 END), 0) AS INTEGER) AS folders_count
+FROM items
+WHERE site_id = sqlc.arg(site_id) AND list_id = sqlc.arg(list_id) AND audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: UpsertItemAnalytics :exec
+-- All-time access counts for a shared item, keyed by file/folder UniqueId
+INSERT INTO item_analytics (
+  site_id,
+  item_guid,
+  audit_run_id,
+  view_count,
+  viewer_count
+) VALUES (
+  sqlc.arg(site_id),
+  sqlc.arg(item_guid),
+  sqlc.arg(audit_run_id),
+  sqlc.arg(view_count),
+  sqlc.arg(viewer_count)
+)
+ON CONFLICT(site_id, item_guid, audit_run_id) DO UPDATE SET
+  view_count   = excluded.view_count,
+  viewer_count = excluded.viewer_count,
+  collected_at = CURRENT_TIMESTAMP;
+
+-- name: FindLatestItemByGUID :one
+-- Item lookup across all sites: the most recent audit run holding the item.
+-- Matches the item GUID, the list item GUID, or the file/folder UniqueId its sharing links record.
+SELECT i.site_id, i.audit_run_id, i.list_id, i.item_guid
+FROM items i
+JOIN audit_runs ar ON ar.audit_run_id = i.audit_run_id
+WHERE lower(i.item_guid) = lower(sqlc.arg(guid))
+   OR lower(i.list_item_guid) = lower(sqlc.arg(guid))
+   OR EXISTS (
+     SELECT 1 FROM sharing_links sl
+     WHERE sl.site_id = i.site_id AND sl.audit_run_id = i.audit_run_id
+       AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid)
+       AND lower(sl.file_folder_unique_id) = lower(sqlc.arg(guid))
+   )
+ORDER BY ar.started_at DESC, i.audit_run_id DESC
+LIMIT 1;
+
+-- name: FindLatestItemByURL :one
+-- Item lookup across all sites by absolute item URL, case-insensitive
+SELECT i.site_id, i.audit_run_id, i.list_id, i.item_guid
+FROM items i
+JOIN audit_runs ar ON ar.audit_run_id = i.audit_run_id
+WHERE lower(i.url) = lower(sqlc.arg(url))
+ORDER BY ar.started_at DESC, i.audit_run_id DESC
+LIMIT 1;
+
+-- name: FindLatestItemBySharingLinkURL :one
+-- Item lookup across all sites by sharing link URL; the stored link URL is compared without its query
+SELECT i.site_id, i.audit_run_id, i.list_id, i.item_guid
+FROM sharing_links sl
+JOIN items i ON i.site_id = sl.site_id AND i.audit_run_id = sl.audit_run_id
+  AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid)
+JOIN audit_runs ar ON ar.audit_run_id = sl.audit_run_id
+WHERE lower(CASE WHEN instr(sl.url, '?') > 0 THEN substr(sl.url, 1, instr(sl.url, '?') - 1) ELSE sl.url END) = lower(sqlc.arg(url))
+ORDER BY ar.started_at DESC, i.audit_run_id DESC
+LIMIT 1;
+
+-- name: GetItemsForAuditRun :many
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id, unreadable_fields
+FROM items
+WHERE audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY site_id, list_id, item_id
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
@@ -0,0 +1,24 @@
+-- name: InsertJobEvent :one
+INSERT INTO job_events (event_type, job_id, job_type, job_status, site_url, audit_run_id, error, occurred_at)
+VALUES (sqlc.arg(event_type), sqlc.arg(job_id), sqlc.arg(job_type), sqlc.arg(job_status), sqlc.arg(site_url),
+        sqlc.narg(audit_run_id), sqlc.arg(error), sqlc.arg(occurred_at))
+RETURNING sequence;
+
+-- Empty event_type and job_id match every event.
+-- name: ListJobEvents :many
+SELECT sequence, event_type, job_id, job_type, job_status, site_url, audit_run_id, error, occurred_at
+FROM job_events
+WHERE sequence > sqlc.arg(after_sequence)
+  AND (CAST(sqlc.arg(event_type) AS TEXT) = '' OR event_type = sqlc.arg(event_type))
+  AND (CAST(sqlc.arg(job_id) AS TEXT) = '' OR job_id = sqlc.arg(job_id))
+ORDER BY sequence
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetJobEvent :one
+SELECT sequence, event_type, job_id, job_type, job_status, site_url, audit_run_id, error, occurred_at
+FROM job_events
+WHERE sequence = sqlc.arg(sequence);
+
+-- name: GetLatestJobEventSequence :one
+SELECT CAST(COALESCE(MAX(sequence), 0) AS INTEGER) AS sequence
+FROM job_events;
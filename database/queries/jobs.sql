@@ -20,6 +20,12 @@ UPDATE jobs
 SET status = 'failed', error = sqlc.arg(error), completed_at = CURRENT_TIMESTAMP
 WHERE job_id = sqlc.arg(job_id);
 
+-- name: ReopenJob :exec
+-- A resumed job runs again: its error and completion time are cleared.
+UPDATE jobs
+SET status = 'running', error = NULL, completed_at = NULL
+WHERE job_id = sqlc.arg(job_id);
+
 -- name: GetJob :one
 SELECT job_id, job_type, status, site_id, site_url, item_guid, progress, state_json, result, error, started_at, completed_at
 FROM jobs
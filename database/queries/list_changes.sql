@@ -0,0 +1,138 @@
+-- ==================================
+-- List changes between audit runs
+-- ==================================
+-- Each query compares one kind of permission fact on a list between a base run and a run, and
+-- returns only the facts present in one of them, so a diff never loads both runs. Removed facts
+-- are described from the base run, added facts from the run. Rows are ordered by object,
+-- principal and role, removals first.
+
+-- name: GetListAssignmentChanges :many
+SELECT 'removed' AS change, ra.principal_id AS principal_id, ra.role_def_id AS role_def_id,
+       p.title AS principal_title, p.login_name, p.email, p.principal_type, rd.name AS role_name
+FROM role_assignments ra
+LEFT JOIN role_assignments o ON o.site_id = ra.site_id AND o.object_type = ra.object_type AND o.object_key = ra.object_key
+  AND o.principal_id = ra.principal_id AND o.role_def_id = ra.role_def_id AND o.audit_run_id = sqlc.arg(audit_run_id)
+LEFT JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+LEFT JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE ra.site_id = sqlc.arg(site_id) AND ra.object_type = 'list' AND ra.object_key = sqlc.arg(list_id)
+  AND ra.audit_run_id = sqlc.arg(base_audit_run_id) AND o.principal_id IS NULL
+UNION ALL
+SELECT 'added' AS change, ra.principal_id AS principal_id, ra.role_def_id AS role_def_id,
+       p.title AS principal_title, p.login_name, p.email, p.principal_type, rd.name AS role_name
+FROM role_assignments ra
+LEFT JOIN role_assignments o ON o.site_id = ra.site_id AND o.object_type = ra.object_type AND o.object_key = ra.object_key
+  AND o.principal_id = ra.principal_id AND o.role_def_id = ra.role_def_id AND o.audit_run_id = sqlc.arg(base_audit_run_id)
+LEFT JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+LEFT JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE ra.site_id = sqlc.arg(site_id) AND ra.object_type = 'list' AND ra.object_key = sqlc.arg(list_id)
+  AND ra.audit_run_id = sqlc.arg(audit_run_id) AND o.principal_id IS NULL
+ORDER BY principal_id, role_def_id, change DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetUniqueItemChanges :many
+SELECT 'removed' AS change, i.item_guid AS item_guid, i.name, i.url
+FROM items i
+LEFT JOIN items o ON o.site_id = i.site_id AND o.item_guid = i.item_guid AND o.audit_run_id = sqlc.arg(audit_run_id)
+  AND o.list_id = i.list_id AND o.has_unique = 1
+WHERE i.site_id = sqlc.arg(site_id) AND i.list_id = sqlc.arg(list_id) AND i.audit_run_id = sqlc.arg(base_audit_run_id) AND i.has_unique = 1
+  AND o.item_guid IS NULL
+UNION ALL
+SELECT 'added' AS change, i.item_guid AS item_guid, i.name, i.url
+FROM items i
+LEFT JOIN items o ON o.site_id = i.site_id AND o.item_guid = i.item_guid AND o.audit_run_id = sqlc.arg(base_audit_run_id)
+  AND o.list_id = i.list_id AND o.has_unique = 1
+WHERE i.site_id = sqlc.arg(site_id) AND i.list_id = sqlc.arg(list_id) AND i.audit_run_id = sqlc.arg(audit_run_id) AND i.has_unique = 1
+  AND o.item_guid IS NULL
+ORDER BY item_guid, change DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetItemAssignmentChanges :many
+-- Assignments on the list's items with unique permissions
+SELECT 'removed' AS change, ra.object_key AS object_key, i.name AS item_name, ra.principal_id AS principal_id, ra.role_def_id AS role_def_id,
+       p.title AS principal_title, p.login_name, p.email, p.principal_type, rd.name AS role_name
+FROM items i
+JOIN role_assignments ra ON ra.site_id = i.site_id AND ra.object_type = 'item' AND ra.object_key = i.item_guid AND ra.audit_run_id = i.audit_run_id
+LEFT JOIN items oi ON oi.site_id = i.site_id AND oi.item_guid = i.item_guid AND oi.audit_run_id = sqlc.arg(audit_run_id)
+  AND oi.list_id = i.list_id AND oi.has_unique = 1
+LEFT JOIN role_assignments o ON o.site_id = oi.site_id AND o.object_type = 'item' AND o.object_key = oi.item_guid AND o.audit_run_id = oi.audit_run_id
+  AND o.principal_id = ra.principal_id AND o.role_def_id = ra.role_def_id
+LEFT JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+LEFT JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE i.site_id = sqlc.arg(site_id) AND i.list_id = sqlc.arg(list_id) AND i.audit_run_id = sqlc.arg(base_audit_run_id) AND i.has_unique = 1
+  AND o.principal_id IS NULL
+UNION ALL
+SELECT 'added' AS change, ra.object_key AS object_key, i.name AS item_name, ra.principal_id AS principal_id, ra.role_def_id AS role_def_id,
+       p.title AS principal_title, p.login_name, p.email, p.principal_type, rd.name AS role_name
+FROM items i
+JOIN role_assignments ra ON ra.site_id = i.site_id AND ra.object_type = 'item' AND ra.object_key = i.item_guid AND ra.audit_run_id = i.audit_run_id
+LEFT JOIN items oi ON oi.site_id = i.site_id AND oi.item_guid = i.item_guid AND oi.audit_run_id = sqlc.arg(base_audit_run_id)
+  AND oi.list_id = i.list_id AND oi.has_unique = 1
+LEFT JOIN role_assignments o ON o.site_id = oi.site_id AND o.object_type = 'item' AND o.object_key = oi.item_guid AND o.audit_run_id = oi.audit_run_id
+  AND o.principal_id = ra.principal_id AND o.role_def_id = ra.role_def_id
+LEFT JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+LEFT JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE i.site_id = sqlc.arg(site_id) AND i.list_id = sqlc.arg(list_id) AND i.audit_run_id = sqlc.arg(audit_run_id) AND i.has_unique = 1
+  AND o.principal_id IS NULL
+ORDER BY object_key, principal_id, role_def_id, change DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetSharingLinkChanges :many
+-- Active links on the list's items, named after the item they were created on
+SELECT 'removed' AS change, sl.link_id AS link_id, sl.link_kind, n.name AS item_name
+FROM sharing_links sl
+JOIN items i ON i.site_id = sl.site_id AND i.audit_run_id = sl.audit_run_id AND i.list_id = sqlc.arg(list_id)
+  AND (i.item_guid = sl.item_guid OR i.item_guid = sl.file_folder_unique_id)
+LEFT JOIN items n ON n.site_id = sl.site_id AND n.item_guid = sl.item_guid AND n.audit_run_id = sl.audit_run_id AND n.list_id = sqlc.arg(list_id)
+LEFT JOIN sharing_links o ON o.site_id = sl.site_id AND o.link_id = sl.link_id AND o.audit_run_id = sqlc.arg(audit_run_id) AND o.is_active = 1
+LEFT JOIN items oi ON oi.site_id = o.site_id AND oi.audit_run_id = o.audit_run_id AND oi.list_id = sqlc.arg(list_id)
+  AND (oi.item_guid = o.item_guid OR oi.item_guid = o.file_folder_unique_id)
+WHERE sl.site_id = sqlc.arg(site_id) AND sl.audit_run_id = sqlc.arg(base_audit_run_id) AND sl.is_active = 1
+  AND oi.item_guid IS NULL
+UNION ALL
+SELECT 'added' AS change, sl.link_id AS link_id, sl.link_kind, n.name AS item_name
+FROM sharing_links sl
+JOIN items i ON i.site_id = sl.site_id AND i.audit_run_id = sl.audit_run_id AND i.list_id = sqlc.arg(list_id)
+  AND (i.item_guid = sl.item_guid OR i.item_guid = sl.file_folder_unique_id)
+LEFT JOIN items n ON n.site_id = sl.site_id AND n.item_guid = sl.item_guid AND n.audit_run_id = sl.audit_run_id AND n.list_id = sqlc.arg(list_id)
+LEFT JOIN sharing_links o ON o.site_id = sl.site_id AND o.link_id = sl.link_id AND o.audit_run_id = sqlc.arg(base_audit_run_id) AND o.is_active = 1
+LEFT JOIN items oi ON oi.site_id = o.site_id AND oi.audit_run_id = o.audit_run_id AND oi.list_id = sqlc.arg(list_id)
+  AND (oi.item_guid = o.item_guid OR oi.item_guid = o.file_folder_unique_id)
+WHERE sl.site_id = sqlc.arg(site_id) AND sl.audit_run_id = sqlc.arg(audit_run_id) AND sl.is_active = 1
+  AND oi.item_guid IS NULL
+ORDER BY link_id, change DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetSharingLinkMemberChanges :many
+SELECT 'removed' AS change, slm.link_id AS link_id, sl.link_kind, n.name AS item_name, slm.principal_id AS principal_id,
+       p.title AS principal_title, p.login_name, p.email, p.principal_type
+FROM sharing_link_members slm
+JOIN sharing_links sl ON sl.site_id = slm.site_id AND sl.link_id = slm.link_id AND sl.audit_run_id = slm.audit_run_id
+JOIN items i ON i.site_id = sl.site_id AND i.audit_run_id = sl.audit_run_id AND i.list_id = sqlc.arg(list_id)
+  AND (i.item_guid = sl.item_guid OR i.item_guid = sl.file_folder_unique_id)
+LEFT JOIN items n ON n.site_id = sl.site_id AND n.item_guid = sl.item_guid AND n.audit_run_id = sl.audit_run_id AND n.list_id = sqlc.arg(list_id)
+LEFT JOIN sharing_link_members om ON om.site_id = slm.site_id AND om.link_id = slm.link_id AND om.principal_id = slm.principal_id
+  AND om.audit_run_id = sqlc.arg(audit_run_id)
+LEFT JOIN sharing_links o ON o.site_id = om.site_id AND o.link_id = om.link_id AND o.audit_run_id = om.audit_run_id AND o.is_active = 1
+LEFT JOIN items oi ON oi.site_id = o.site_id AND oi.audit_run_id = o.audit_run_id AND oi.list_id = sqlc.arg(list_id)
+  AND (oi.item_guid = o.item_guid OR oi.item_guid = o.file_folder_unique_id)
+LEFT JOIN principals p ON p.site_id = slm.site_id AND p.principal_id = slm.principal_id AND p.audit_run_id = slm.audit_run_id
+WHERE slm.site_id = sqlc.arg(site_id) AND slm.audit_run_id = sqlc.arg(base_audit_run_id) AND sl.is_active = 1
+  AND oi.item_guid IS NULL
+UNION ALL
+SELECT 'added' AS change, slm.link_id AS link_id, sl.link_kind, n.name AS item_name, slm.principal_id AS principal_id,
+       p.title AS principal_title, p.login_name, p.email, p.principal_type
+FROM sharing_link_members slm
+JOIN sharing_links sl ON sl.site_id = slm.site_id AND sl.link_id = slm.link_id AND sl.audit_run_id = slm.audit_run_id
+JOIN items i ON i.site_id = sl.site_id AND i.audit_run_id = sl.audit_run_id AND i.list_id = sqlc.arg(list_id)
+  AND (i.item_guid = sl.item_guid OR i.item_guid = sl.file_folder_unique_id)
+LEFT JOIN items n ON n.site_id = sl.site_id AND n.item_guid = sl.item_guid AND n.audit_run_id = sl.audit_run_id AND n.list_id = sqlc.arg(list_id)
+LEFT JOIN sharing_link_members om ON om.site_id = slm.site_id AND om.link_id = slm.link_id AND om.principal_id = slm.principal_id
+  AND om.audit_run_id = sqlc.arg(base_audit_run_id)
+LEFT JOIN sharing_links o ON o.site_id = om.site_id AND o.link_id = om.link_id AND o.audit_run_id = om.audit_run_id AND o.is_active = 1
+LEFT JOIN items oi ON oi.site_id = o.site_id AND oi.audit_run_id = o.audit_run_id AND oi.list_id = sqlc.arg(list_id)
+  AND (oi.item_guid = o.item_guid OR oi.item_guid = o.file_folder_unique_id)
+LEFT JOIN principals p ON p.site_id = slm.site_id AND p.principal_id = slm.principal_id AND p.audit_run_id = slm.audit_run_id
+WHERE slm.site_id = sqlc.arg(site_id) AND slm.audit_run_id = sqlc.arg(audit_run_id) AND sl.is_active = 1
+  AND oi.item_guid IS NULL
+ORDER BY link_id, principal_id, change DESC
+LIMIT sqlc.arg(limit_count);
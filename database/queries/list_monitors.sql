@@ -0,0 +1,46 @@
+-- name: CreateListMonitor :one
+INSERT INTO list_monitors (site_url, list_id, list_title, list_root_path, interval_seconds, change_token,
+                           created_at, next_check_at)
+VALUES (sqlc.arg(site_url), sqlc.arg(list_id), sqlc.arg(list_title), sqlc.arg(list_root_path), sqlc.arg(interval_seconds),
+        sqlc.arg(change_token), sqlc.arg(created_at), sqlc.arg(next_check_at))
+RETURNING monitor_id;
+
+-- name: GetListMonitor :one
+SELECT monitor_id, site_url, list_id, list_title, list_root_path, interval_seconds, change_token, created_at,
+       next_check_at, last_checked_at, last_audit_job_id, last_audit_at
+FROM list_monitors
+WHERE monitor_id = sqlc.arg(monitor_id);
+
+-- name: GetListMonitorForList :one
+SELECT monitor_id, site_url, list_id, list_title, list_root_path, interval_seconds, change_token, created_at,
+       next_check_at, last_checked_at, last_audit_job_id, last_audit_at
+FROM list_monitors
+WHERE site_url = sqlc.arg(site_url) AND list_id = sqlc.arg(list_id);
+
+-- name: GetListMonitors :many
+SELECT monitor_id, site_url, list_id, list_title, list_root_path, interval_seconds, change_token, created_at,
+       next_check_at, last_checked_at, last_audit_job_id, last_audit_at
+FROM list_monitors
+ORDER BY site_url, list_title, monitor_id;
+
+-- name: GetDueListMonitors :many
+SELECT monitor_id, site_url, list_id, list_title, list_root_path, interval_seconds, change_token, created_at,
+       next_check_at, last_checked_at, last_audit_job_id, last_audit_at
+FROM list_monitors
+WHERE next_check_at <= sqlc.arg(now)
+ORDER BY next_check_at, monitor_id;
+
+-- name: RecordListMonitorCheck :exec
+UPDATE list_monitors
+SET change_token = sqlc.arg(change_token), last_checked_at = sqlc.arg(last_checked_at), next_check_at = sqlc.arg(next_check_at)
+WHERE monitor_id = sqlc.arg(monitor_id);
+
+-- name: RecordListMonitorAudit :exec
+UPDATE list_monitors
+SET change_token = sqlc.arg(change_token), last_checked_at = sqlc.arg(last_checked_at), next_check_at = sqlc.arg(next_check_at),
+    last_audit_job_id = sqlc.arg(last_audit_job_id), last_audit_at = sqlc.arg(last_checked_at)
+WHERE monitor_id = sqlc.arg(monitor_id);
+
+-- name: DeleteListMonitor :execrows
+DELETE FROM list_monitors
+WHERE monitor_id = sqlc.arg(monitor_id);
@@ -0,0 +1,48 @@
+-- name: CreateListSubscription :exec
+INSERT INTO list_subscriptions (subscription_id, site_url, list_id, list_title, list_root_path, client_state,
+                                change_token, expires_at, created_at)
+VALUES (sqlc.arg(subscription_id), sqlc.arg(site_url), sqlc.arg(list_id), sqlc.arg(list_title), sqlc.arg(list_root_path),
+        sqlc.arg(client_state), sqlc.arg(change_token), sqlc.arg(expires_at), sqlc.arg(created_at));
+
+-- name: GetListSubscription :one
+SELECT subscription_id, site_url, list_id, list_title, list_root_path, client_state, change_token, expires_at,
+       created_at, last_notified_at, last_audit_job_id, last_audit_at
+FROM list_subscriptions
+WHERE subscription_id = sqlc.arg(subscription_id);
+
+-- name: GetListSubscriptionForList :one
+SELECT subscription_id, site_url, list_id, list_title, list_root_path, client_state, change_token, expires_at,
+       created_at, last_notified_at, last_audit_job_id, last_audit_at
+FROM list_subscriptions
+WHERE site_url = sqlc.arg(site_url) AND list_id = sqlc.arg(list_id);
+
+-- name: GetListSubscriptions :many
+SELECT subscription_id, site_url, list_id, list_title, list_root_path, client_state, change_token, expires_at,
+       created_at, last_notified_at, last_audit_job_id, last_audit_at
+FROM list_subscriptions
+ORDER BY site_url, list_title, subscription_id;
+
+-- name: MarkListSubscriptionNotified :exec
+UPDATE list_subscriptions
+SET last_notified_at = sqlc.arg(last_notified_at)
+WHERE subscription_id = sqlc.arg(subscription_id);
+
+-- name: UpdateListSubscriptionChangeToken :exec
+UPDATE list_subscriptions
+SET change_token = sqlc.arg(change_token)
+WHERE subscription_id = sqlc.arg(subscription_id);
+
+-- name: RecordListSubscriptionAudit :exec
+UPDATE list_subscriptions
+SET change_token = sqlc.arg(change_token), last_audit_job_id = sqlc.arg(last_audit_job_id), last_audit_at = sqlc.arg(last_audit_at)
+WHERE subscription_id = sqlc.arg(subscription_id);
+
+-- name: RenewListSubscription :exec
+-- A lapsed subscription is replaced by a new one with a new ID, keeping its place in the change log.
+UPDATE list_subscriptions
+SET subscription_id = sqlc.arg(new_subscription_id), expires_at = sqlc.arg(expires_at)
+WHERE subscription_id = sqlc.arg(subscription_id);
+
+-- name: DeleteListSubscription :execrows
+DELETE FROM list_subscriptions
+WHERE subscription_id = sqlc.arg(subscription_id);
@@ -1,6 +1,13 @@
 -- name: InsertList :exec
-INSERT INTO lists (site_id, list_id, web_id, title, url, base_template, item_count, has_unique, audit_run_id)
-VALUES (sqlc.arg(site_id), sqlc.arg(list_id), sqlc.arg(web_id), sqlc.arg(title), sqlc.arg(url), sqlc.arg(base_template), sqlc.arg(item_count), sqlc.arg(has_unique), sqlc.arg(audit_run_id));
+INSERT INTO lists (site_id, list_id, web_id, title, url, base_template, item_count, has_unique, audit_run_id,
+                   read_security, write_security, comments_disabled, rating_experience)
+VALUES (sqlc.arg(site_id), sqlc.arg(list_id), sqlc.arg(web_id), sqlc.arg(title), sqlc.arg(url), sqlc.arg(base_template), sqlc.arg(item_count), sqlc.arg(has_unique), sqlc.arg(audit_run_id),
+        sqlc.arg(read_security), sqlc.arg(write_security), sqlc.arg(comments_disabled), sqlc.arg(rating_experience))
+ON CONFLICT (site_id, list_id, audit_run_id) DO UPDATE SET
+  web_id = excluded.web_id, title = excluded.title, url = excluded.url, base_template = excluded.base_template,
+  item_count = excluded.item_count, has_unique = excluded.has_unique, read_security = excluded.read_security,
+  write_security = excluded.write_security, comments_disabled = excluded.comments_disabled,
+  rating_experience = excluded.rating_experience;
 
 -- name: ListsWithUnique :many
 SELECT l.site_id, l.list_id, l.web_id, l.title, l.url, l.item_count, l.has_unique, w.title AS web_title, s.site_url
@@ -43,20 +50,110 @@ ORDER BY title;
 -- Audit-run-scoped queries for reading historical data
 
 -- name: GetListsByAuditRun :many
-SELECT l.site_id, l.list_id, l.web_id, l.title, l.url, l.base_template, l.item_count, l.has_unique, w.title AS web_title, l.audit_run_id
+SELECT l.site_id, l.list_id, l.web_id, l.title, l.url, l.base_template, l.item_count, l.has_unique, w.title AS web_title, l.audit_run_id,
+       l.unique_item_count, l.unique_density, l.sampled_item_count,
+       l.unique_assignment_count, l.external_principal_count, l.sharing_link_count,
+       l.read_security, l.write_security, l.comments_disabled, l.rating_experience
 FROM lists l
 JOIN webs w ON w.site_id = l.site_id AND w.web_id = l.web_id AND w.audit_run_id = l.audit_run_id
 WHERE l.site_id = sqlc.arg(site_id) AND l.audit_run_id = sqlc.arg(audit_run_id)
 ORDER BY w.title, l.title;
 
 -- name: GetListsWithUniqueByAuditRun :many
-SELECT l.site_id, l.list_id, l.web_id, l.title, l.url, l.base_template, l.item_count, l.has_unique, w.title AS web_title, l.audit_run_id
+SELECT l.site_id, l.list_id, l.web_id, l.title, l.url, l.base_template, l.item_count, l.has_unique, w.title AS web_title, l.audit_run_id,
+       l.unique_item_count, l.unique_density, l.sampled_item_count,
+       l.unique_assignment_count, l.external_principal_count, l.sharing_link_count,
+       l.read_security, l.write_security, l.comments_disabled, l.rating_experience
 FROM lists l
 JOIN webs w ON w.site_id = l.site_id AND w.web_id = l.web_id AND w.audit_run_id = l.audit_run_id
 WHERE l.site_id = sqlc.arg(site_id) AND l.audit_run_id = sqlc.arg(audit_run_id) AND l.has_unique = 1
 ORDER BY w.title, l.title;
 
 -- name: GetListByAuditRun :one
-SELECT site_id, list_id, web_id, title, url, base_template, item_count, has_unique, audit_run_id
+SELECT site_id, list_id, web_id, title, url, base_template, item_count, has_unique, audit_run_id,
+       unique_item_count, unique_density, sampled_item_count,
+       unique_assignment_count, external_principal_count, sharing_link_count,
+       read_security, write_security, comments_disabled, rating_experience
 FROM lists 
 WHERE site_id = sqlc.arg(site_id) AND list_id = sqlc.arg(list_id) AND audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: UpdateListSamplingByAuditRun :exec
+UPDATE lists SET sampled_item_count = sqlc.arg(sampled_item_count)
+WHERE site_id = sqlc.arg(site_id) AND list_id = sqlc.arg(list_id) AND audit_run_id = sqlc.arg(audit_run_id);
+
+-- Sampled lists extrapolate the unique share of the sample to the whole list.
+-- name: UpdateListUniqueDensityByAuditRun :exec
+UPDATE lists
+SET unique_item_count = CASE
+      WHEN COALESCE(lists.sampled_item_count, 0) > 0 THEN CAST(ROUND(CAST((
+        SELECT COUNT(*) FROM items i
+        WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+          AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1
+      ) AS REAL) * COALESCE(lists.item_count, 0) / lists.sampled_item_count) AS INTEGER)
+      ELSE (
+        SELECT COUNT(*) FROM items i
+        WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+          AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1
+      )
+    END,
+    unique_density = CASE
+      WHEN COALESCE(lists.sampled_item_count, 0) > 0 THEN CAST((
+        SELECT COUNT(*) FROM items i
+        WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+          AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1
+      ) AS REAL) / lists.sampled_item_count
+      WHEN COALESCE(lists.item_count, 0) > 0 THEN CAST((
+        SELECT COUNT(*) FROM items i
+        WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+          AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1
+      ) AS REAL) / lists.item_count
+      ELSE 0
+    END
+WHERE lists.site_id = sqlc.arg(site_id) AND lists.audit_run_id = sqlc.arg(audit_run_id);
+
+-- External principals are guest accounts, whose login names carry #ext# or urn:spo:guest.
+-- name: UpdateListAccessCountsByAuditRun :exec
+UPDATE lists
+SET unique_assignment_count = (
+      SELECT COUNT(*) FROM role_assignments ra
+      WHERE ra.site_id = lists.site_id AND ra.audit_run_id = lists.audit_run_id
+        AND ((ra.object_type = 'list' AND ra.object_key = lists.list_id)
+          OR (ra.object_type = 'item' AND ra.object_key IN (
+            SELECT i.item_guid FROM items i
+            WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+              AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1)))
+    ),
+    external_principal_count = (
+      SELECT COUNT(*) FROM principals p
+      WHERE p.site_id = lists.site_id AND p.audit_run_id = lists.audit_run_id
+        AND (p.login_name LIKE '%#ext#%' OR p.login_name LIKE '%urn:spo:guest%')
+        AND (EXISTS (
+            SELECT 1 FROM role_assignments ra
+            WHERE ra.site_id = p.site_id AND ra.principal_id = p.principal_id AND ra.audit_run_id = p.audit_run_id
+              AND ((ra.object_type = 'list' AND ra.object_key = lists.list_id)
+                OR (ra.object_type = 'item' AND ra.object_key IN (
+                  SELECT i.item_guid FROM items i
+                  WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+                    AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1)))
+          ) OR EXISTS (
+            SELECT 1 FROM sharing_link_members m
+            JOIN sharing_links sl ON sl.site_id = m.site_id AND sl.link_id = m.link_id AND sl.audit_run_id = m.audit_run_id
+            JOIN items i ON i.site_id = sl.site_id AND i.item_guid = sl.item_guid AND i.audit_run_id = sl.audit_run_id
+            WHERE m.site_id = p.site_id AND m.principal_id = p.principal_id AND m.audit_run_id = p.audit_run_id
+              AND sl.is_active = 1 AND i.list_id = lists.list_id
+          ))
+    ),
+    sharing_link_count = (
+      SELECT COUNT(*) FROM sharing_links sl
+      JOIN items i ON i.site_id = sl.site_id AND i.item_guid = sl.item_guid AND i.audit_run_id = sl.audit_run_id
+      WHERE sl.site_id = lists.site_id AND sl.audit_run_id = lists.audit_run_id
+        AND sl.is_active = 1 AND i.list_id = lists.list_id
+    )
+WHERE lists.site_id = sqlc.arg(site_id) AND lists.audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: GetListsForAuditRun :many
+SELECT site_id, list_id, web_id, title, base_template, url, item_count, has_unique, audit_run_id
+FROM lists
+WHERE audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY site_id, list_id
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
@@ -0,0 +1,21 @@
+-- name: CreateDatabaseMaintenanceRun :one
+INSERT INTO database_maintenance_runs (job_id, trigger, started_at, bytes_before, free_bytes_before)
+VALUES (sqlc.arg(job_id), sqlc.arg(trigger), sqlc.arg(started_at), sqlc.arg(bytes_before), sqlc.arg(free_bytes_before))
+RETURNING maintenance_run_id;
+
+-- name: CompleteDatabaseMaintenanceRun :exec
+UPDATE database_maintenance_runs
+SET completed_at = sqlc.arg(completed_at),
+    bytes_after = sqlc.arg(bytes_after),
+    free_bytes_after = sqlc.arg(free_bytes_after),
+    converted = sqlc.arg(converted),
+    integrity_errors = sqlc.arg(integrity_errors),
+    error = sqlc.arg(error)
+WHERE maintenance_run_id = sqlc.arg(maintenance_run_id);
+
+-- name: GetDatabaseMaintenanceRuns :many
+SELECT maintenance_run_id, job_id, trigger, started_at, completed_at, bytes_before, bytes_after,
+  free_bytes_before, free_bytes_after, converted, integrity_errors, error
+FROM database_maintenance_runs
+ORDER BY started_at DESC, maintenance_run_id DESC
+LIMIT sqlc.arg(limit_count);
@@ -0,0 +1,58 @@
+-- name: CreateOffboardingCheck :one
+INSERT INTO offboarding_checks (job_id, identity, remediate, started_at)
+VALUES (sqlc.arg(job_id), sqlc.arg(identity), sqlc.arg(remediate), sqlc.arg(started_at))
+RETURNING check_id;
+
+-- name: CompleteOffboardingCheck :exec
+UPDATE offboarding_checks
+SET completed_at = sqlc.arg(completed_at),
+    access_count = sqlc.arg(access_count),
+    links_created = sqlc.arg(links_created),
+    items_owned = sqlc.arg(items_owned),
+    remediations = sqlc.arg(remediations),
+    report = sqlc.narg(report),
+    error = sqlc.narg(error)
+WHERE check_id = sqlc.arg(check_id);
+
+-- name: GetOffboardingCheck :one
+SELECT check_id, job_id, identity, remediate, started_at, completed_at, access_count, links_created, items_owned,
+       remediations, error
+FROM offboarding_checks
+WHERE check_id = sqlc.arg(check_id);
+
+-- name: GetOffboardingCheckForJob :one
+SELECT check_id, job_id, identity, remediate, started_at, completed_at, access_count, links_created, items_owned,
+       remediations, error
+FROM offboarding_checks
+WHERE job_id = sqlc.arg(job_id);
+
+-- name: GetOffboardingChecks :many
+SELECT check_id, job_id, identity, remediate, started_at, completed_at, access_count, links_created, items_owned,
+       remediations, error
+FROM offboarding_checks
+ORDER BY started_at DESC, check_id DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetOffboardingCheckReport :one
+SELECT report
+FROM offboarding_checks
+WHERE check_id = sqlc.arg(check_id);
+
+-- name: CreateOffboardingRemediation :exec
+INSERT INTO offboarding_remediations (check_id, site_id, site_url, audit_run_id, action, principal_id, object_type,
+                                      object_key, object_url, group_id, link_id, detail, status, queued_at)
+VALUES (sqlc.arg(check_id), sqlc.arg(site_id), sqlc.arg(site_url), sqlc.arg(audit_run_id), sqlc.arg(action),
+        sqlc.arg(principal_id), sqlc.narg(object_type), sqlc.narg(object_key), sqlc.narg(object_url),
+        sqlc.narg(group_id), sqlc.narg(link_id), sqlc.narg(detail), sqlc.arg(status), sqlc.arg(queued_at));
+
+-- name: GetOffboardingRemediations :many
+SELECT remediation_id, check_id, site_id, site_url, audit_run_id, action, principal_id, object_type, object_key,
+       object_url, group_id, link_id, detail, status, queued_at, updated_at
+FROM offboarding_remediations
+WHERE check_id = sqlc.arg(check_id)
+ORDER BY remediation_id;
+
+-- name: UpdateOffboardingRemediationStatus :execrows
+UPDATE offboarding_remediations
+SET status = sqlc.arg(status), updated_at = sqlc.arg(updated_at)
+WHERE check_id = sqlc.arg(check_id) AND remediation_id = sqlc.arg(remediation_id);
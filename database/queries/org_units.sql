@@ -0,0 +1,19 @@
+-- name: UpsertOrgUnitMapping :one
+INSERT INTO org_unit_mappings (match_type, match_value, org_unit, updated_at)
+VALUES (sqlc.arg(match_type), sqlc.arg(match_value), sqlc.arg(org_unit), sqlc.arg(updated_at))
+ON CONFLICT (match_type, match_value) DO UPDATE SET
+  org_unit   = excluded.org_unit,
+  updated_at = excluded.updated_at
+RETURNING mapping_id;
+
+-- name: GetOrgUnitMappings :many
+SELECT mapping_id, match_type, match_value, org_unit, updated_at
+FROM org_unit_mappings
+ORDER BY org_unit, match_type, match_value;
+
+-- name: DeleteOrgUnitMapping :execrows
+DELETE FROM org_unit_mappings
+WHERE mapping_id = sqlc.arg(mapping_id);
+
+-- name: DeleteAllOrgUnitMappings :exec
+DELETE FROM org_unit_mappings;
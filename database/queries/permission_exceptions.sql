@@ -0,0 +1,56 @@
+-- name: UpsertPermissionException :one
+-- Accepting the same object again renews its exception
+INSERT INTO permission_exceptions (
+  site_id, list_id, kind, object_key, principal_id, role_def_id, justification, owner, expires_at, created_at
+) VALUES (
+  sqlc.arg(site_id), sqlc.arg(list_id), sqlc.arg(kind), sqlc.arg(object_key), sqlc.arg(principal_id),
+  sqlc.arg(role_def_id), sqlc.arg(justification), sqlc.arg(owner), sqlc.arg(expires_at), sqlc.arg(created_at)
+)
+ON CONFLICT (site_id, list_id, kind, object_key, principal_id, role_def_id) DO UPDATE SET
+  justification      = excluded.justification,
+  owner              = excluded.owner,
+  expires_at         = excluded.expires_at,
+  created_at         = excluded.created_at,
+  review_notified_at = NULL
+RETURNING exception_id;
+
+-- name: GetPermissionException :one
+SELECT exception_id, site_id, list_id, kind, object_key, principal_id, role_def_id, justification, expires_at, created_at, owner, review_notified_at
+FROM permission_exceptions
+WHERE site_id = sqlc.arg(site_id) AND exception_id = sqlc.arg(exception_id);
+
+-- name: GetPermissionExceptionsForSite :many
+SELECT exception_id, site_id, list_id, kind, object_key, principal_id, role_def_id, justification, expires_at, created_at, owner, review_notified_at
+FROM permission_exceptions
+WHERE site_id = sqlc.arg(site_id)
+ORDER BY list_id, expires_at, exception_id;
+
+-- name: GetActivePermissionExceptionsForSite :many
+SELECT exception_id, site_id, list_id, kind, object_key, principal_id, role_def_id, justification, expires_at, created_at, owner, review_notified_at
+FROM permission_exceptions
+WHERE site_id = sqlc.arg(site_id) AND expires_at > sqlc.arg(now)
+ORDER BY list_id, expires_at, exception_id;
+
+-- name: GetPermissionExceptionsExpiringBefore :many
+-- Exceptions of every site that expire by before, expired ones included, soonest first
+SELECT pe.exception_id, pe.site_id, pe.list_id, pe.kind, pe.object_key, pe.principal_id, pe.role_def_id,
+       pe.justification, pe.expires_at, pe.created_at, pe.owner, pe.review_notified_at, s.site_url
+FROM permission_exceptions pe
+JOIN sites s ON s.site_id = pe.site_id
+WHERE pe.expires_at <= sqlc.arg(before)
+ORDER BY pe.expires_at, pe.exception_id;
+
+-- name: RenewPermissionException :execrows
+-- Renewed exceptions are reminded of again as they near their new expiry
+UPDATE permission_exceptions
+SET expires_at = sqlc.arg(expires_at), created_at = sqlc.arg(renewed_at), review_notified_at = NULL
+WHERE site_id = sqlc.arg(site_id) AND exception_id = sqlc.arg(exception_id);
+
+-- name: MarkPermissionExceptionReviewNotified :exec
+UPDATE permission_exceptions
+SET review_notified_at = sqlc.arg(notified_at)
+WHERE exception_id = sqlc.arg(exception_id);
+
+-- name: DeletePermissionException :execrows
+DELETE FROM permission_exceptions
+WHERE site_id = sqlc.arg(site_id) AND exception_id = sqlc.arg(exception_id);
@@ -0,0 +1,39 @@
+-- name: CreatePolicyEvaluation :exec
+INSERT INTO policy_evaluations (audit_run_id, site_id, evaluated_at, policies)
+VALUES (sqlc.arg(audit_run_id), sqlc.arg(site_id), sqlc.arg(evaluated_at), sqlc.arg(policies));
+
+-- name: DeletePolicyEvaluation :exec
+DELETE FROM policy_evaluations
+WHERE audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: GetPolicyEvaluation :one
+SELECT audit_run_id, site_id, evaluated_at, policies
+FROM policy_evaluations
+WHERE audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: CreatePolicyFinding :exec
+INSERT INTO policy_findings (audit_run_id, site_id, policy, severity, object_type, object_key, object_url, object_name,
+                             subject, detail, remediation)
+VALUES (sqlc.arg(audit_run_id), sqlc.arg(site_id), sqlc.arg(policy), sqlc.arg(severity), sqlc.arg(object_type),
+        sqlc.arg(object_key), sqlc.arg(object_url), sqlc.arg(object_name), sqlc.arg(subject), sqlc.arg(detail),
+        sqlc.arg(remediation));
+
+-- name: DeletePolicyFindings :exec
+DELETE FROM policy_findings
+WHERE audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: GetPolicyFindings :many
+SELECT finding_id, audit_run_id, site_id, policy, severity, object_type, object_key, object_url, object_name, subject,
+       detail, remediation
+FROM policy_findings
+WHERE audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY finding_id;
+
+-- name: GetLatestPolicyFindings :many
+-- Findings of the latest evaluated run of each site
+SELECT pf.finding_id, pf.audit_run_id, pf.site_id, s.site_url, pf.policy, pf.severity, pf.object_type, pf.object_key,
+       pf.object_url, pf.object_name, pf.subject, pf.detail, pf.remediation
+FROM policy_findings pf
+JOIN sites s ON s.site_id = pf.site_id
+WHERE pf.audit_run_id IN (SELECT MAX(pe.audit_run_id) FROM policy_evaluations pe GROUP BY pe.site_id)
+ORDER BY s.site_url, pf.finding_id;
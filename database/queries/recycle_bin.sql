@@ -0,0 +1,50 @@
+-- name: InsertRecycleBinScan :exec
+INSERT OR REPLACE INTO recycle_bin_scans (site_id, audit_run_id, scanned_at)
+VALUES (sqlc.arg(site_id), sqlc.arg(audit_run_id), sqlc.arg(scanned_at));
+
+-- name: InsertRecycleBinItem :exec
+INSERT OR IGNORE INTO recycle_bin_items (site_id, audit_run_id, recycle_bin_id, item_type, item_state, title, url,
+                                         deleted_at, deleted_by_name, deleted_by_email)
+VALUES (sqlc.arg(site_id), sqlc.arg(audit_run_id), sqlc.arg(recycle_bin_id), sqlc.arg(item_type), sqlc.arg(item_state), sqlc.arg(title), sqlc.arg(url),
+        sqlc.arg(deleted_at), sqlc.arg(deleted_by_name), sqlc.arg(deleted_by_email));
+
+-- name: GetRecycleBinScan :one
+SELECT scanned_at
+FROM recycle_bin_scans
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: GetRecycleBinItemsByAuditRun :many
+SELECT recycle_bin_id, item_type, item_state, title, url, deleted_at, deleted_by_name, deleted_by_email
+FROM recycle_bin_items
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY deleted_at DESC, recycle_bin_id;
+
+-- name: GetRecycleBinItemSharingLinks :many
+-- Active sharing links on deleted items, as captured by the last earlier run that saw an item at
+-- each one's location. When that run started after the deletion, it saw a new item there.
+SELECT
+  rb.recycle_bin_id,
+  i.audit_run_id,
+  ar.started_at AS audit_run_started_at,
+  i.item_guid,
+  i.list_id,
+  i.name,
+  sl.link_id,
+  sl.link_kind,
+  sl.scope,
+  sl.is_edit_link,
+  sl.total_members_count,
+  sl.expiration
+FROM recycle_bin_items rb
+JOIN items i ON i.site_id = rb.site_id AND i.url = rb.url
+  AND i.audit_run_id = (
+    SELECT MAX(prev.audit_run_id)
+    FROM items prev
+    WHERE prev.site_id = rb.site_id AND prev.url = rb.url AND prev.audit_run_id < rb.audit_run_id
+  )
+JOIN audit_runs ar ON ar.audit_run_id = i.audit_run_id
+JOIN sharing_links sl ON sl.site_id = i.site_id AND sl.audit_run_id = i.audit_run_id
+  AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid)
+WHERE rb.site_id = sqlc.arg(site_id) AND rb.audit_run_id = sqlc.arg(audit_run_id)
+  AND sl.is_active = 1
+ORDER BY rb.deleted_at DESC, rb.recycle_bin_id, sl.link_id;
@@ -0,0 +1,21 @@
+-- name: CreateReportShare :one
+INSERT INTO report_shares (site_id, audit_run_id, list_id, token, created_at, expires_at)
+VALUES (sqlc.arg(site_id), sqlc.arg(audit_run_id), sqlc.narg(list_id), sqlc.arg(token), sqlc.arg(created_at), sqlc.arg(expires_at))
+RETURNING share_id;
+
+-- name: GetReportShareByToken :one
+SELECT share_id, site_id, audit_run_id, list_id, token, created_at, expires_at, revoked_at
+FROM report_shares
+WHERE token = sqlc.arg(token);
+
+-- name: GetReportSharesForAuditRun :many
+SELECT share_id, site_id, audit_run_id, list_id, token, created_at, expires_at, revoked_at
+FROM report_shares
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY created_at DESC, share_id DESC;
+
+-- name: RevokeReportShare :execrows
+UPDATE report_shares
+SET revoked_at = sqlc.arg(revoked_at)
+WHERE share_id = sqlc.arg(share_id) AND site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id)
+  AND revoked_at IS NULL;
@@ -0,0 +1,39 @@
+-- ==================================
+-- Site changes between audit runs
+-- ==================================
+-- Facts outside the site's lists, compared between a base run and a run the way
+-- list_changes.sql compares list facts: only facts present in one of them are returned.
+
+-- name: GetWebAssignmentChanges :many
+SELECT 'removed' AS change, ra.object_key AS object_key, w.title AS web_title, ra.principal_id AS principal_id, ra.role_def_id AS role_def_id,
+       p.title AS principal_title, p.login_name, p.email, p.principal_type, rd.name AS role_name
+FROM role_assignments ra
+LEFT JOIN role_assignments o ON o.site_id = ra.site_id AND o.object_type = ra.object_type AND o.object_key = ra.object_key
+  AND o.principal_id = ra.principal_id AND o.role_def_id = ra.role_def_id AND o.audit_run_id = sqlc.arg(audit_run_id)
+LEFT JOIN webs w ON w.site_id = ra.site_id AND w.web_id = ra.object_key AND w.audit_run_id = ra.audit_run_id
+LEFT JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+LEFT JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE ra.site_id = sqlc.arg(site_id) AND ra.object_type = 'web'
+  AND ra.audit_run_id = sqlc.arg(base_audit_run_id) AND o.principal_id IS NULL
+UNION ALL
+SELECT 'added' AS change, ra.object_key AS object_key, w.title AS web_title, ra.principal_id AS principal_id, ra.role_def_id AS role_def_id,
+       p.title AS principal_title, p.login_name, p.email, p.principal_type, rd.name AS role_name
+FROM role_assignments ra
+LEFT JOIN role_assignments o ON o.site_id = ra.site_id AND o.object_type = ra.object_type AND o.object_key = ra.object_key
+  AND o.principal_id = ra.principal_id AND o.role_def_id = ra.role_def_id AND o.audit_run_id = sqlc.arg(base_audit_run_id)
+LEFT JOIN webs w ON w.site_id = ra.site_id AND w.web_id = ra.object_key AND w.audit_run_id = ra.audit_run_id
+LEFT JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+LEFT JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE ra.site_id = sqlc.arg(site_id) AND ra.object_type = 'web'
+  AND ra.audit_run_id = sqlc.arg(audit_run_id) AND o.principal_id IS NULL
+ORDER BY object_key, principal_id, role_def_id, change DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetRemovedPrincipals :many
+-- Principals the base run captured that the run no longer does, described as the base run captured them
+SELECT p.principal_id, p.title, p.login_name, p.email, p.principal_type
+FROM principals p
+LEFT JOIN principals o ON o.site_id = p.site_id AND o.principal_id = p.principal_id AND o.audit_run_id = sqlc.arg(audit_run_id)
+WHERE p.site_id = sqlc.arg(site_id) AND p.audit_run_id = sqlc.arg(base_audit_run_id) AND o.principal_id IS NULL
+ORDER BY p.principal_id
+LIMIT sqlc.arg(limit_count);
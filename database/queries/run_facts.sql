@@ -0,0 +1,52 @@
+-- ==================================
+-- Run facts
+-- ==================================
+-- A migration assessment compares runs of two different sites, so object keys and principal IDs
+-- cannot be joined across runs. These queries describe each run's permission facts by URL and
+-- principal instead, for the application to match after mapping source URLs to target URLs.
+-- The risky defaults report reads the sharing link facts on their own.
+
+-- name: GetPermissionFactsForAuditRun :many
+-- Explicit assignments on the web, lists and items, described by the object's URL
+SELECT ra.object_type, ra.object_key,
+       CAST(COALESCE(w.url, l.url, i.url, '') AS TEXT) AS object_url,
+       CAST(COALESCE(w.title, l.title, i.name, '') AS TEXT) AS object_name,
+       ra.principal_id, p.title AS principal_title, p.login_name, p.email, p.principal_type,
+       ra.role_def_id, rd.name AS role_name
+FROM role_assignments ra
+LEFT JOIN webs w ON ra.object_type = 'web' AND w.site_id = ra.site_id AND w.web_id = ra.object_key AND w.audit_run_id = ra.audit_run_id
+LEFT JOIN lists l ON ra.object_type = 'list' AND l.site_id = ra.site_id AND l.list_id = ra.object_key AND l.audit_run_id = ra.audit_run_id
+LEFT JOIN items i ON ra.object_type = 'item' AND i.site_id = ra.site_id AND i.item_guid = ra.object_key AND i.audit_run_id = ra.audit_run_id
+LEFT JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+LEFT JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE ra.site_id = sqlc.arg(site_id) AND ra.audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY ra.object_type, ra.object_key, ra.principal_id, ra.role_def_id
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetSharingLinkFactsCreatedByPrincipal :many
+-- Active sharing links a principal created, described by the URL of the item they were created on
+SELECT sl.link_id, sl.item_guid,
+       CAST(COALESCE(i.url, '') AS TEXT) AS item_url,
+       CAST(COALESCE(i.name, '') AS TEXT) AS item_name,
+       CAST(COALESCE(l.web_id, '') AS TEXT) AS web_id,
+       sl.link_kind, sl.scope, sl.total_members_count, sl.is_default, sl.is_edit_link, sl.expiration
+FROM sharing_links sl
+LEFT JOIN items i ON i.site_id = sl.site_id AND i.item_guid = sl.item_guid AND i.audit_run_id = sl.audit_run_id
+LEFT JOIN lists l ON l.site_id = i.site_id AND l.list_id = i.list_id AND l.audit_run_id = i.audit_run_id
+WHERE sl.site_id = sqlc.arg(site_id) AND sl.audit_run_id = sqlc.arg(audit_run_id)
+  AND sl.created_by_principal_id = sqlc.arg(principal_id) AND sl.is_active = 1
+ORDER BY sl.item_guid, sl.link_kind, sl.link_id;
+
+-- name: GetSharingLinkFactsForAuditRun :many
+-- Active sharing links, described by the URL of the item they were created on
+SELECT sl.link_id, sl.item_guid,
+       CAST(COALESCE(i.url, '') AS TEXT) AS item_url,
+       CAST(COALESCE(i.name, '') AS TEXT) AS item_name,
+       CAST(COALESCE(l.web_id, '') AS TEXT) AS web_id,
+       sl.link_kind, sl.scope, sl.total_members_count, sl.is_default, sl.is_edit_link, sl.expiration
+FROM sharing_links sl
+LEFT JOIN items i ON i.site_id = sl.site_id AND i.item_guid = sl.item_guid AND i.audit_run_id = sl.audit_run_id
+LEFT JOIN lists l ON l.site_id = i.site_id AND l.list_id = i.list_id AND l.audit_run_id = i.audit_run_id
+WHERE sl.site_id = sqlc.arg(site_id) AND sl.audit_run_id = sqlc.arg(audit_run_id) AND sl.is_active = 1
+ORDER BY sl.item_guid, sl.link_kind, sl.link_id
+LIMIT sqlc.arg(limit_count);
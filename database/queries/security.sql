@@ -13,11 +13,14 @@ RETURNING principal_id;
 
 -- name: InsertRoleDefinition :exec
 INSERT INTO role_definitions (site_id, role_def_id, name, description, audit_run_id)
-VALUES (sqlc.arg(site_id), sqlc.arg(role_def_id), sqlc.arg(name), sqlc.arg(description), sqlc.arg(audit_run_id));
+VALUES (sqlc.arg(site_id), sqlc.arg(role_def_id), sqlc.arg(name), sqlc.arg(description), sqlc.arg(audit_run_id))
+ON CONFLICT (site_id, role_def_id, audit_run_id) DO UPDATE SET
+  name = excluded.name, description = excluded.description;
 
 -- name: DeleteRoleAssignmentsForObject :exec
 DELETE FROM role_assignments
-WHERE site_id = sqlc.arg(site_id) AND object_type = sqlc.arg(object_type) AND object_key = sqlc.arg(object_key);
+WHERE site_id = sqlc.arg(site_id) AND object_type = sqlc.arg(object_type) AND object_key = sqlc.arg(object_key)
+  AND audit_run_id = sqlc.arg(audit_run_id);
 
 -- name: InsertRoleAssignment :exec
 INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, inherited, audit_run_id)
@@ -32,7 +35,19 @@ JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id
 JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
 WHERE ra.site_id = sqlc.arg(site_id) AND ra.object_type = sqlc.arg(object_type) AND ra.object_key = sqlc.arg(object_key)
   AND ra.audit_run_id = sqlc.arg(audit_run_id)
-ORDER BY principal_title, role_name;
+ORDER BY principal_title, role_name, ra.principal_id, ra.role_def_id;
+
+-- name: GetAssignmentsPageForObjectByAuditRun :many
+-- Same order as GetAssignmentsForObjectByAuditRun, so offsets address the same assignments
+SELECT ra.principal_id, p.title AS principal_title, p.login_name, p.principal_type,
+       ra.role_def_id, rd.name AS role_name, rd.description, ra.inherited
+FROM role_assignments ra
+JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE ra.site_id = sqlc.arg(site_id) AND ra.object_type = sqlc.arg(object_type) AND ra.object_key = sqlc.arg(object_key)
+  AND ra.audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY principal_title, role_name, ra.principal_id, ra.role_def_id
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
 
 -- name: GetWebIdForObject :one
 SELECT 
@@ -76,3 +91,43 @@ LEFT JOIN items i ON sl.site_id = i.site_id AND sl.item_guid = i.item_guid
 LEFT JOIN lists l ON i.site_id = l.site_id AND i.list_id = l.list_id
 WHERE sl.site_id = sqlc.arg(site_id) AND sl.file_folder_unique_id = sqlc.arg(file_folder_guid)
 LIMIT 1;
+
+-- name: CountAssignmentsForObjectByAuditRun :many
+-- Assignment counts grouped by principal type and role, for analytics without loading each assignment
+SELECT
+  p.principal_type,
+  rd.role_def_id,
+  rd.name AS role_name,
+  CAST(COALESCE(length(p.login_name) > 12 AND substr(p.login_name, 1, 12) = 'SharingLinks', 0) AS INTEGER) AS is_sharing_link,
+  CAST(COUNT(*) AS INTEGER) AS assignment_count
+FROM role_assignments ra
+JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE ra.site_id = sqlc.arg(site_id) AND ra.object_type = sqlc.arg(object_type) AND ra.object_key = sqlc.arg(object_key)
+  AND ra.audit_run_id = sqlc.arg(audit_run_id)
+GROUP BY p.principal_type, rd.role_def_id, rd.name, is_sharing_link;
+
+-- name: InsertGroupMember :exec
+INSERT OR IGNORE INTO group_members (site_id, group_id, member_id, audit_run_id)
+VALUES (sqlc.arg(site_id), sqlc.arg(group_id), sqlc.arg(member_id), sqlc.arg(audit_run_id));
+
+-- name: GetGroupIDsForMemberByAuditRun :many
+-- SharePoint groups a principal belongs to, as captured by an audit run
+SELECT group_id
+FROM group_members
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id) AND member_id = sqlc.arg(member_id)
+ORDER BY group_id;
+
+-- name: GetGroupMembersByAuditRun :many
+-- Members of a SharePoint group, as captured by an audit run
+SELECT p.site_id, p.principal_id, p.title, p.login_name, p.email, p.principal_type
+FROM group_members gm
+JOIN principals p ON p.site_id = gm.site_id AND p.principal_id = gm.member_id AND p.audit_run_id = gm.audit_run_id
+WHERE gm.site_id = sqlc.arg(site_id) AND gm.audit_run_id = sqlc.arg(audit_run_id) AND gm.group_id = sqlc.arg(group_id)
+ORDER BY p.title, p.principal_id;
+
+-- name: GetPrincipalsByAuditRun :many
+SELECT site_id, principal_id, title, login_name, email, principal_type
+FROM principals
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY principal_id;
@@ -181,14 +181,79 @@ SELECT
   cb.title as created_by_title,
   cb.login_name as created_by_login,
   mb.title as modified_by_title,
-  mb.login_name as modified_by_login
+  mb.login_name as modified_by_login,
+  ia.view_count as item_view_count,
+  ia.viewer_count as item_viewer_count
 FROM sharing_links sl
 LEFT JOIN items i ON (sl.site_id = i.site_id AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid) AND i.audit_run_id = sl.audit_run_id)
 LEFT JOIN principals cb ON sl.site_id = cb.site_id AND sl.created_by_principal_id = cb.principal_id AND cb.audit_run_id = sl.audit_run_id
 LEFT JOIN principals mb ON sl.site_id = mb.site_id AND sl.last_modified_by_principal_id = mb.principal_id AND mb.audit_run_id = sl.audit_run_id
+LEFT JOIN item_analytics ia ON ia.site_id = sl.site_id AND ia.item_guid = sl.file_folder_unique_id AND ia.audit_run_id = sl.audit_run_id
 WHERE sl.site_id = sqlc.arg(site_id) AND i.list_id = sqlc.arg(list_id)
   AND sl.is_active = 1 AND sl.audit_run_id = sqlc.arg(audit_run_id)
-ORDER BY sl.created_at DESC, sl.link_id;
+-- Most-accessed items first when analytics were collected
+ORDER BY COALESCE(ia.view_count, -1) DESC, sl.created_at DESC, sl.link_id;
+
+-- name: GetAnonymousSharingLinksByAuditRun :many
+-- Active anonymous sharing links on items in every list of a site, filtered by audit run
+SELECT
+  sl.link_id,
+  sl.item_guid,
+  sl.file_folder_unique_id,
+  sl.url,
+  sl.link_kind,
+  sl.scope,
+  sl.is_edit_link,
+  sl.is_review_link,
+  sl.blocks_download,
+  sl.requires_password,
+  sl.expiration,
+  sl.created_at,
+  sl.last_modified_at,
+  i.list_id,
+  l.title as list_title,
+  i.name as item_name,
+  i.url as item_url,
+  i.is_file,
+  i.is_folder,
+  cb.title as created_by_title,
+  cb.login_name as created_by_login,
+  mb.title as modified_by_title,
+  mb.login_name as modified_by_login
+FROM sharing_links sl
+JOIN items i ON (sl.site_id = i.site_id AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid) AND i.audit_run_id = sl.audit_run_id)
+LEFT JOIN lists l ON l.site_id = i.site_id AND l.list_id = i.list_id AND l.audit_run_id = i.audit_run_id
+LEFT JOIN principals cb ON sl.site_id = cb.site_id AND sl.created_by_principal_id = cb.principal_id AND cb.audit_run_id = sl.audit_run_id
+LEFT JOIN principals mb ON sl.site_id = mb.site_id AND sl.last_modified_by_principal_id = mb.principal_id AND mb.audit_run_id = sl.audit_run_id
+WHERE sl.site_id = sqlc.arg(site_id) AND sl.audit_run_id = sqlc.arg(audit_run_id)
+  AND sl.is_active = 1 AND sl.scope = 0
+-- Links without an expiration first, then those expiring soonest
+ORDER BY sl.expiration IS NOT NULL, sl.expiration, l.title, i.name, sl.link_id;
+
+-- name: GetSharingLinkByAuditRun :one
+-- A single sharing link with the item it is on. Link IDs come from sharing link
+-- group login names, whose GUID casing can differ from the sharing API's.
+SELECT
+  sl.link_id,
+  sl.item_guid,
+  sl.file_folder_unique_id,
+  sl.url,
+  sl.link_kind,
+  sl.scope,
+  sl.is_active,
+  sl.is_default,
+  sl.is_edit_link,
+  sl.is_review_link,
+  sl.created_at,
+  (SELECT COUNT(*) FROM sharing_link_members slm WHERE slm.site_id = sl.site_id AND slm.link_id = sl.link_id AND slm.audit_run_id = sl.audit_run_id) as actual_members_count,
+  i.name as item_name,
+  i.is_file,
+  i.is_folder
+FROM sharing_links sl
+LEFT JOIN items i ON (sl.site_id = i.site_id AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid) AND i.audit_run_id = sl.audit_run_id)
+WHERE sl.site_id = sqlc.arg(site_id) AND sl.audit_run_id = sqlc.arg(audit_run_id)
+  AND lower(sl.link_id) = lower(sqlc.arg(link_id))
+LIMIT 1;
 
 -- name: GetSharingLinkMembers :many
 -- Get all members (principals) for a specific sharing link
@@ -218,6 +283,13 @@ JOIN principals p ON slm.site_id = p.site_id AND slm.principal_id = p.principal_
 WHERE slm.site_id = sqlc.arg(site_id) AND slm.link_id = sqlc.arg(link_id) AND slm.audit_run_id = sqlc.arg(audit_run_id)
 ORDER BY p.title;
 
+-- name: GetSharingLinkIDsForMemberByAuditRun :many
+-- Sharing links a principal is a member of, as captured by an audit run
+SELECT link_id
+FROM sharing_link_members
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id) AND principal_id = sqlc.arg(principal_id)
+ORDER BY link_id;
+
 -- ==================================
 -- Governance table queries
 -- ==================================
@@ -237,7 +309,10 @@ INSERT INTO sharing_governance (
   can_request_access_for_grant_access,
   site_ib_mode,
   site_ib_segment_ids,
-  enforce_ib_segment_filtering
+  enforce_ib_segment_filtering,
+  default_link_kind,
+  default_share_link_permission,
+  default_share_link_scope
 ) VALUES (
   sqlc.arg(site_id),
   sqlc.arg(audit_run_id),
@@ -252,7 +327,10 @@ INSERT INTO sharing_governance (
   sqlc.arg(can_request_access_for_grant_access),
   sqlc.arg(site_ib_mode),
   sqlc.arg(site_ib_segment_ids),
-  sqlc.arg(enforce_ib_segment_filtering)
+  sqlc.arg(enforce_ib_segment_filtering),
+  sqlc.arg(default_link_kind),
+  sqlc.arg(default_share_link_permission),
+  sqlc.arg(default_share_link_scope)
 )
 ON CONFLICT(site_id, audit_run_id) DO UPDATE SET
   tenant_id                                  = excluded.tenant_id,
@@ -267,11 +345,15 @@ ON CONFLICT(site_id, audit_run_id) DO UPDATE SET
   site_ib_mode                               = excluded.site_ib_mode,
   site_ib_segment_ids                        = excluded.site_ib_segment_ids,
   enforce_ib_segment_filtering               = excluded.enforce_ib_segment_filtering,
+  default_link_kind                          = excluded.default_link_kind,
+  default_share_link_permission              = excluded.default_share_link_permission,
+  default_share_link_scope                   = excluded.default_share_link_scope,
   updated_at                                 = CURRENT_TIMESTAMP;
 
 -- name: GetSharingGovernance :one
 SELECT 
   site_id,
+  audit_run_id,
   tenant_id,
   tenant_display_name,
   sharepoint_site_id,
@@ -283,9 +365,18 @@ SELECT
   can_request_access_for_grant_access,
   site_ib_mode,
   site_ib_segment_ids,
-  enforce_ib_segment_filtering
+  enforce_ib_segment_filtering,
+  default_link_kind,
+  default_share_link_permission,
+  default_share_link_scope
 FROM sharing_governance
-WHERE site_id = sqlc.arg(site_id);
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id);
+
+-- name: GetPreviousAuditRunForSharingGovernance :one
+-- Most recent audit run before the given one that captured sharing governance, or 0 if none did
+SELECT CAST(COALESCE(MAX(audit_run_id), 0) AS INTEGER) AS audit_run_id
+FROM sharing_governance
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id < sqlc.arg(audit_run_id);
 
 -- name: UpsertSharingAbilities :exec
 INSERT INTO sharing_abilities (
@@ -326,7 +417,7 @@ SELECT
   people_sharing_link_abilities,
   direct_sharing_abilities
 FROM sharing_abilities
-WHERE site_id = sqlc.arg(site_id);
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id);
 
 -- name: UpsertRecipientLimits :exec
 INSERT INTO recipient_limits (
@@ -359,7 +450,7 @@ SELECT
   share_link,
   share_link_with_defer_redeem
 FROM recipient_limits
-WHERE site_id = sqlc.arg(site_id);
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id);
 
 -- name: UpsertItemSensitivityLabel :exec
 INSERT INTO sensitivity_labels (
@@ -424,6 +515,24 @@ SELECT
 FROM sensitivity_labels
 WHERE site_id = sqlc.arg(site_id) AND item_guid = sqlc.arg(item_guid);
 
+-- name: GetItemSensitivityLabelByAuditRun :one
+SELECT 
+  site_id,
+  item_guid,
+  label_id,
+  display_name,
+  owner_email,
+  set_date,
+  assignment_method,
+  has_irm_protection,
+  content_bits,
+  label_flags,
+  discovered_at,
+  promotion_version,
+  label_hash
+FROM sensitivity_labels
+WHERE site_id = sqlc.arg(site_id) AND item_guid = sqlc.arg(item_guid) AND audit_run_id = sqlc.arg(audit_run_id);
+
 -- name: GetSensitivityLabelsForSite :many
 SELECT 
   site_id,
@@ -444,6 +553,67 @@ WHERE site_id = sqlc.arg(site_id)
   AND label_id IS NOT NULL
 ORDER BY discovered_at DESC;
 
+-- name: GetSensitivityLabelsForList :many
+SELECT 
+  sl.site_id,
+  sl.item_guid,
+  sl.label_id,
+  sl.display_name,
+  sl.owner_email,
+  sl.set_date,
+  sl.assignment_method,
+  sl.has_irm_protection,
+  sl.content_bits,
+  sl.label_flags,
+  sl.discovered_at,
+  sl.promotion_version,
+  sl.label_hash
+FROM sensitivity_labels sl
+JOIN items i ON sl.site_id = i.site_id AND sl.item_guid = i.item_guid AND sl.audit_run_id = i.audit_run_id
+WHERE sl.site_id = sqlc.arg(site_id) AND i.list_id = sqlc.arg(list_id)
+  AND sl.label_id IS NOT NULL
+ORDER BY sl.item_guid, sl.audit_run_id;
+
+-- name: GetSensitivityLabelsForListByAuditRun :many
+SELECT 
+  sl.site_id,
+  sl.item_guid,
+  sl.label_id,
+  sl.display_name,
+  sl.owner_email,
+  sl.set_date,
+  sl.assignment_method,
+  sl.has_irm_protection,
+  sl.content_bits,
+  sl.label_flags,
+  sl.discovered_at,
+  sl.promotion_version,
+  sl.label_hash
+FROM sensitivity_labels sl
+JOIN items i ON sl.site_id = i.site_id AND sl.item_guid = i.item_guid AND sl.audit_run_id = i.audit_run_id
+WHERE sl.site_id = sqlc.arg(site_id) AND i.list_id = sqlc.arg(list_id) AND sl.audit_run_id = sqlc.arg(audit_run_id)
+  AND sl.label_id IS NOT NULL
+ORDER BY sl.item_guid;
+
+-- name: GetItemsOwnedByEmailByAuditRun :many
+-- Items whose sensitivity label names an owner, matched by email in lower case
+SELECT sl.item_guid,
+       CAST(COALESCE(i.url, '') AS TEXT) AS item_url,
+       CAST(COALESCE(i.name, '') AS TEXT) AS item_name,
+       CAST(COALESCE(sl.display_name, '') AS TEXT) AS label_name
+FROM sensitivity_labels sl
+JOIN items i ON sl.site_id = i.site_id AND sl.item_guid = i.item_guid AND sl.audit_run_id = i.audit_run_id
+WHERE sl.site_id = sqlc.arg(site_id) AND sl.audit_run_id = sqlc.arg(audit_run_id)
+  AND lower(sl.owner_email) = CAST(sqlc.arg(email) AS TEXT)
+ORDER BY item_url, sl.item_guid;
+
+-- name: GetItemLabelsByAuditRun :many
+-- Display names of the sensitivity labels applied to items
+SELECT item_guid, CAST(display_name AS TEXT) AS label_name
+FROM sensitivity_labels
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id) AND COALESCE(display_name, '') != ''
+ORDER BY item_guid;
+
 -- name: UpsertSensitivityLabel :exec
 INSERT INTO sensitivity_labels (
   site_id,
@@ -473,3 +643,52 @@ ON CONFLICT(site_id, item_guid, audit_run_id) DO UPDATE SET
   tooltip                             = excluded.tooltip,
   has_irm_protection                  = excluded.has_irm_protection,
   sensitivity_label_protection_type   = excluded.sensitivity_label_protection_type;
+
+-- name: CountSharingLinksByCreatorByAuditRun :many
+-- Active sharing links counted per principal that created them, link kind and whether they grant edit
+-- or reach external users: guests invited to or holding the link, whose login names carry #ext# or urn:spo:guest
+SELECT
+  CAST(sl.created_by_principal_id AS INTEGER) AS principal_id,
+  CAST(COALESCE(p.title, '') AS TEXT) AS principal_title,
+  CAST(COALESCE(p.login_name, '') AS TEXT) AS login_name,
+  CAST(COALESCE(p.email, '') AS TEXT) AS email,
+  sl.link_kind,
+  sl.scope,
+  CAST(COALESCE(sl.is_edit_link, 0) AS INTEGER) AS grants_edit,
+  CAST(COALESCE(sl.has_external_guest_invitees, 0) OR EXISTS (
+    SELECT 1 FROM sharing_link_members m
+    JOIN principals mp ON mp.site_id = m.site_id AND mp.principal_id = m.principal_id AND mp.audit_run_id = m.audit_run_id
+    WHERE m.site_id = sl.site_id AND m.link_id = sl.link_id AND m.audit_run_id = sl.audit_run_id
+      AND (mp.login_name LIKE '%#ext#%' OR mp.login_name LIKE '%urn:spo:guest%')
+  ) AS INTEGER) AS reaches_external,
+  CAST(COUNT(*) AS INTEGER) AS link_count
+FROM sharing_links sl
+LEFT JOIN principals p ON p.site_id = sl.site_id AND p.principal_id = sl.created_by_principal_id AND p.audit_run_id = sl.audit_run_id
+WHERE sl.site_id = sqlc.arg(site_id) AND sl.audit_run_id = sqlc.arg(audit_run_id) AND sl.is_active = 1
+  AND sl.created_by_principal_id IS NOT NULL
+GROUP BY sl.created_by_principal_id, sl.link_kind, sl.scope, grants_edit, reaches_external
+ORDER BY sl.created_by_principal_id;
+
+-- name: CountSharingLinksForListByAuditRun :many
+-- Active sharing link and member counts grouped by link kind for items in a list
+SELECT
+  sl.link_kind,
+  CAST(COUNT(*) AS INTEGER) AS link_count,
+  CAST(COALESCE(SUM(
+    (SELECT COUNT(*) FROM sharing_link_members slm WHERE slm.site_id = sl.site_id AND slm.link_id = sl.link_id AND slm.audit_run_id = sl.audit_run_id)
+  ), 0) AS INTEGER) AS member_count
+FROM sharing_links sl
+WHERE sl.site_id = sqlc.arg(site_id) AND sl.audit_run_id = sqlc.arg(audit_run_id) AND sl.is_active = 1
+  AND EXISTS (
+    SELECT 1 FROM items i
+    WHERE i.site_id = sl.site_id AND i.audit_run_id = sl.audit_run_id
+      AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid)
+      AND i.list_id = sqlc.arg(list_id)
+  )
+GROUP BY sl.link_kind;
+
+-- name: GetPreviousAuditRunForSharingLink :one
+-- Most recent audit run before the given one that captured the sharing link, or 0 if none did
+SELECT CAST(COALESCE(MAX(audit_run_id), 0) AS INTEGER) AS audit_run_id
+FROM sharing_links
+WHERE site_id = sqlc.arg(site_id) AND link_id = sqlc.arg(link_id) AND audit_run_id < sqlc.arg(audit_run_id);
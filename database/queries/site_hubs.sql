@@ -0,0 +1,9 @@
+-- name: InsertSiteHub :exec
+INSERT OR REPLACE INTO site_hubs (site_id, audit_run_id, is_hub, hub_site_id, hub_title, hub_url, parent_hub_site_id, permissions_sync, collected_at)
+VALUES (sqlc.arg(site_id), sqlc.arg(audit_run_id), sqlc.arg(is_hub), sqlc.arg(hub_site_id), sqlc.arg(hub_title), sqlc.arg(hub_url),
+        sqlc.arg(parent_hub_site_id), sqlc.arg(permissions_sync), sqlc.arg(collected_at));
+
+-- name: GetSiteHub :one
+SELECT is_hub, hub_site_id, hub_title, hub_url, parent_hub_site_id, permissions_sync, collected_at
+FROM site_hubs
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id);
@@ -0,0 +1,15 @@
+-- name: UpsertSitePolicyPack :exec
+INSERT INTO site_policy_packs (site_id, pack, set_at)
+VALUES (sqlc.arg(site_id), sqlc.arg(pack), sqlc.arg(set_at))
+ON CONFLICT (site_id) DO UPDATE SET
+  pack   = excluded.pack,
+  set_at = excluded.set_at;
+
+-- name: GetSitePolicyPack :one
+SELECT site_id, pack, set_at
+FROM site_policy_packs
+WHERE site_id = sqlc.arg(site_id);
+
+-- name: DeleteSitePolicyPack :execrows
+DELETE FROM site_policy_packs
+WHERE site_id = sqlc.arg(site_id);
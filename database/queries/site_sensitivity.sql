@@ -0,0 +1,15 @@
+-- name: UpsertSiteSensitivity :exec
+INSERT INTO site_sensitivity (site_id, reason, tagged_at)
+VALUES (sqlc.arg(site_id), sqlc.arg(reason), sqlc.arg(tagged_at))
+ON CONFLICT (site_id) DO UPDATE SET
+  reason    = excluded.reason,
+  tagged_at = excluded.tagged_at;
+
+-- name: GetSiteSensitivity :one
+SELECT site_id, reason, tagged_at
+FROM site_sensitivity
+WHERE site_id = sqlc.arg(site_id);
+
+-- name: DeleteSiteSensitivity :execrows
+DELETE FROM site_sensitivity
+WHERE site_id = sqlc.arg(site_id);
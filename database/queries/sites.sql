@@ -20,3 +20,48 @@ WHERE site_id = sqlc.arg(site_id);
 SELECT site_id, site_url, title, created_at, updated_at
 FROM sites
 ORDER BY title;
+
+-- name: PinAuditRunForSite :exec
+UPDATE sites
+SET pinned_audit_run_id = sqlc.arg(audit_run_id), pinned_at = CURRENT_TIMESTAMP
+WHERE site_id = sqlc.arg(site_id);
+
+-- name: UnpinAuditRunForSite :exec
+UPDATE sites
+SET pinned_audit_run_id = NULL, pinned_at = NULL
+WHERE site_id = sqlc.arg(site_id);
+
+-- name: ListSitesWithLatestAuditRunMetadata :many
+-- One row per site with list statistics for its latest audit run. With prefer_completed set,
+-- the latest completed run is used when one exists; sites without runs have no run columns.
+-- Full site runs rank ahead of folder-scoped ones, as for GetLatestAuditRunForSite; with prefer_completed,
+-- completed runs rank ahead of both, as for GetLatestCompletedAuditRunForSite.
+-- A label ranks only the runs tagged with it and leaves out sites with none.
+WITH ranked_runs AS (
+  SELECT
+    audit_run_id, site_id, started_at,
+    ROW_NUMBER() OVER (PARTITION BY site_id ORDER BY scope_path IS NULL DESC, started_at DESC) AS latest_rank,
+    ROW_NUMBER() OVER (PARTITION BY site_id ORDER BY completed_at IS NOT NULL DESC, scope_path IS NULL DESC, started_at DESC) AS completed_rank
+  FROM audit_runs
+  WHERE CAST(sqlc.arg(label) AS TEXT) = '' OR label = sqlc.arg(label)
+)
+SELECT
+  s.site_id, s.site_url, s.title, s.created_at, s.updated_at,
+  ar.audit_run_id, ar.started_at AS audit_started_at,
+  CAST(COUNT(l.list_id) AS INTEGER) AS total_lists,
+  CAST(COALESCE(SUM(CASE WHEN l.has_unique THEN 1 ELSE 0 END), 0) AS INTEGER) AS lists_with_unique
+FROM sites s
+LEFT JOIN ranked_runs ar ON ar.site_id = s.site_id
+  AND (CASE WHEN CAST(sqlc.arg(prefer_completed) AS INTEGER) THEN ar.completed_rank ELSE ar.latest_rank END) = 1
+LEFT JOIN lists l ON l.site_id = s.site_id AND l.audit_run_id = ar.audit_run_id
+WHERE CAST(sqlc.arg(label) AS TEXT) = '' OR ar.audit_run_id IS NOT NULL
+GROUP BY s.site_id
+ORDER BY s.title;
+
+-- name: GetSitesForAuditRun :many
+-- Sites are not versioned per run; this is the site the run audited
+SELECT s.site_id, s.site_url, s.title, s.created_at, s.updated_at
+FROM sites s
+JOIN audit_runs ar ON ar.site_id = s.site_id
+WHERE ar.audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY s.site_id;
@@ -0,0 +1,42 @@
+-- name: CreateWebhookDelivery :exec
+-- Queues an event for an endpoint, unless it was already queued for it
+INSERT INTO webhook_deliveries (endpoint_url, event_type, event_key, payload, status, created_at, next_attempt_at)
+VALUES (sqlc.arg(endpoint_url), sqlc.arg(event_type), sqlc.arg(event_key), sqlc.arg(payload), 'pending',
+        sqlc.arg(created_at), sqlc.arg(created_at))
+ON CONFLICT (endpoint_url, event_key) DO NOTHING;
+
+-- name: GetDueWebhookDeliveries :many
+-- Pending deliveries whose next attempt is due, oldest first
+SELECT delivery_id, endpoint_url, event_type, event_key, payload, status, attempts, response_status, last_error,
+       created_at, next_attempt_at, delivered_at
+FROM webhook_deliveries
+WHERE status = 'pending' AND next_attempt_at <= sqlc.arg(now)
+ORDER BY next_attempt_at, delivery_id
+LIMIT sqlc.arg(limit_count);
+
+-- name: GetWebhookDelivery :one
+SELECT delivery_id, endpoint_url, event_type, event_key, payload, status, attempts, response_status, last_error,
+       created_at, next_attempt_at, delivered_at
+FROM webhook_deliveries
+WHERE delivery_id = sqlc.arg(delivery_id);
+
+-- name: GetWebhookDeliveries :many
+-- Most recent deliveries first, of the given status unless it is empty
+SELECT delivery_id, endpoint_url, event_type, event_key, payload, status, attempts, response_status, last_error,
+       created_at, next_attempt_at, delivered_at
+FROM webhook_deliveries
+WHERE CAST(sqlc.arg(status) AS TEXT) = '' OR status = sqlc.arg(status)
+ORDER BY delivery_id DESC
+LIMIT sqlc.arg(limit_count);
+
+-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET status = sqlc.arg(status), attempts = sqlc.arg(attempts), response_status = sqlc.arg(response_status),
+    last_error = sqlc.arg(last_error), next_attempt_at = sqlc.arg(next_attempt_at), delivered_at = sqlc.arg(delivered_at)
+WHERE delivery_id = sqlc.arg(delivery_id);
+
+-- name: RetryWebhookDelivery :execrows
+-- Queues a failed delivery for one more attempt
+UPDATE webhook_deliveries
+SET status = 'pending', next_attempt_at = sqlc.arg(next_attempt_at)
+WHERE delivery_id = sqlc.arg(delivery_id) AND status = 'failed';
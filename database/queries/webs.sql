@@ -1,6 +1,11 @@
 -- name: InsertWeb :exec
-INSERT INTO webs (site_id, web_id, url, title, template, has_unique, audit_run_id)
-VALUES (sqlc.arg(site_id), sqlc.arg(web_id), sqlc.arg(url), sqlc.arg(title), sqlc.arg(template), sqlc.arg(has_unique), sqlc.arg(audit_run_id));
+INSERT INTO webs (site_id, web_id, url, title, template, has_unique, members_can_share, request_access_email, use_access_request_default, audit_run_id)
+VALUES (sqlc.arg(site_id), sqlc.arg(web_id), sqlc.arg(url), sqlc.arg(title), sqlc.arg(template), sqlc.arg(has_unique), sqlc.arg(members_can_share),
+        sqlc.arg(request_access_email), sqlc.arg(use_access_request_default), sqlc.arg(audit_run_id))
+ON CONFLICT (site_id, web_id, audit_run_id) DO UPDATE SET
+  url = excluded.url, title = excluded.title, template = excluded.template, has_unique = excluded.has_unique,
+  members_can_share = excluded.members_can_share, request_access_email = excluded.request_access_email,
+  use_access_request_default = excluded.use_access_request_default;
 
 -- name: ListWebs :many
 SELECT w.site_id, w.web_id, w.url, w.title, w.template, w.has_unique, w.audit_run_id, s.site_url
@@ -18,3 +23,16 @@ ORDER BY title;
 SELECT site_id, web_id, url, title, template, has_unique, audit_run_id
 FROM webs
 WHERE site_id = sqlc.arg(site_id) AND web_id = sqlc.arg(web_id);
+
+-- name: ListWebsForSiteByAuditRun :many
+SELECT site_id, web_id, url, title, template, has_unique, members_can_share, request_access_email, use_access_request_default, audit_run_id
+FROM webs
+WHERE site_id = sqlc.arg(site_id) AND audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY url, web_id;
+
+-- name: GetWebsForAuditRun :many
+SELECT site_id, web_id, url, title, template, has_unique, members_can_share, request_access_email, use_access_request_default, audit_run_id
+FROM webs
+WHERE audit_run_id = sqlc.arg(audit_run_id)
+ORDER BY site_id, web_id
+LIMIT sqlc.arg(limit) OFFSET sqlc.arg(offset);
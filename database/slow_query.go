@@ -0,0 +1,100 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"spaudit/gen/db"
+	"spaudit/logging"
+)
+
+// slowQueryDBTX times the statements sqlc issues and logs those slower than the threshold with
+// their query plan. Queries are timed until their first row is ready, not until they are drained.
+type slowQueryDBTX struct {
+	db.DBTX
+	threshold time.Duration
+	logger    *logging.Logger
+}
+
+// newQueries creates sqlc queries on conn, timed when slow query logging is enabled.
+func (d *Database) newQueries(conn db.DBTX) *db.Queries {
+	if d.config.SlowQueryThreshold <= 0 {
+		return db.New(conn)
+	}
+	return db.New(&slowQueryDBTX{DBTX: conn, threshold: d.config.SlowQueryThreshold, logger: d.logger})
+}
+
+func (s *slowQueryDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	started := time.Now()
+	result, err := s.DBTX.ExecContext(ctx, query, args...)
+	s.observe(ctx, query, args, time.Since(started))
+	return result, err
+}
+
+func (s *slowQueryDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	started := time.Now()
+	rows, err := s.DBTX.QueryContext(ctx, query, args...)
+	s.observe(ctx, query, args, time.Since(started))
+	return rows, err
+}
+
+func (s *slowQueryDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	started := time.Now()
+	row := s.DBTX.QueryRowContext(ctx, query, args...)
+	s.observe(ctx, query, args, time.Since(started))
+	return row
+}
+
+// observe logs a statement that took longer than the threshold.
+func (s *slowQueryDBTX) observe(ctx context.Context, query string, args []interface{}, elapsed time.Duration) {
+	if elapsed < s.threshold {
+		return
+	}
+
+	plan, err := s.explain(ctx, query, args)
+	if err != nil {
+		s.logger.Warn("Failed to explain slow query", "query_name", queryName(query), "error", err)
+	}
+	s.logger.Warn("Slow query",
+		"subsystem", "database",
+		"query_name", queryName(query),
+		"duration_ms", elapsed.Milliseconds(),
+		"threshold_ms", s.threshold.Milliseconds(),
+		"plan", plan,
+		"sql", query)
+}
+
+// explain returns the detail lines of the statement's query plan. It runs on the same connection
+// or transaction as the statement, so it sees the same schema and never waits on the write lock.
+func (s *slowQueryDBTX) explain(ctx context.Context, query string, args []interface{}) ([]string, error) {
+	rows, err := s.DBTX.QueryContext(ctx, "EXPLAIN QUERY PLAN "+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var plan []string
+	for rows.Next() {
+		var id, parent, notUsed int64
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return nil, err
+		}
+		plan = append(plan, detail)
+	}
+	return plan, rows.Err()
+}
+
+// queryName returns the sqlc name of a query from its "-- name: X :kind" header, or "" for
+// statements that did not come from sqlc.
+func queryName(query string) string {
+	header, _, _ := strings.Cut(query, "\n")
+	name, ok := strings.CutPrefix(header, "-- name: ")
+	if !ok {
+		return ""
+	}
+	name, _, _ = strings.Cut(name, " ")
+	return name
+}
@@ -0,0 +1,219 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// What an access policy checks
+const (
+	PolicyTargetLink   = "link"   // Active sharing links
+	PolicyTargetAccess = "access" // Explicit access, expanded through groups and sharing links to the principals behind them
+)
+
+// Audiences of a sharing link
+const (
+	LinkAudienceAnyone       = "anyone"       // Anyone who has the link, without signing in
+	LinkAudienceOrganization = "organization" // Anyone in the organization who has the link
+	LinkAudienceSpecific     = "specific"     // The people the link was shared with
+)
+
+// PolicyPrincipalExternal matches principals from outside the organization, such as guests
+const PolicyPrincipalExternal = "external"
+
+// ErrInvalidAccessPolicies is returned when access policies cannot be parsed
+var ErrInvalidAccessPolicies = errors.New("invalid access policies")
+
+// AccessPolicy is a declarative rule audit runs are checked against. Each sharing link or grant of its
+// target meeting every condition violates it; an empty condition matches anything.
+type AccessPolicy struct {
+	Name        string // Lowercase, e.g. no-anyone-edit-links
+	Description string
+	Severity    Severity
+	Target      string // PolicyTargetLink or PolicyTargetAccess
+	Remediation string // How to resolve a finding
+
+	Audience   string // Link audience; links only
+	Edit       bool   // Only links that grant edit; links only
+	Principal  string // PolicyPrincipalExternal; access only
+	Role       string // Role name, ignoring case; access only
+	ObjectType string // web, list or item; access only
+	Label      string // Text the item's sensitivity label contains, ignoring case
+}
+
+// PolicySubject is a sharing link or a principal's access checked against access policies
+type PolicySubject struct {
+	Target     string // PolicyTargetLink or PolicyTargetAccess
+	Audience   string // Of a link
+	Edit       bool   // The link grants edit
+	External   bool   // The principal is from outside the organization
+	Role       string
+	ObjectType string
+	Label      string // Sensitivity label of the item; empty when it has none
+}
+
+// Violated returns true if the subject is of the policy's target and meets every condition
+func (p AccessPolicy) Violated(subject PolicySubject) bool {
+	switch {
+	case subject.Target != p.Target:
+		return false
+	case p.Audience != "" && subject.Audience != p.Audience:
+		return false
+	case p.Edit && !subject.Edit:
+		return false
+	case p.Principal == PolicyPrincipalExternal && !subject.External:
+		return false
+	case p.Role != "" && !strings.EqualFold(p.Role, subject.Role):
+		return false
+	case p.ObjectType != "" && p.ObjectType != subject.ObjectType:
+		return false
+	case p.Label != "" && !strings.Contains(strings.ToLower(subject.Label), strings.ToLower(p.Label)):
+		return false
+	}
+	return true
+}
+
+// DefaultAccessPolicies are checked on every audit run unless a configured policy of the same name replaces them
+var DefaultAccessPolicies = []AccessPolicy{
+	{
+		Name:        "no-anyone-edit-links",
+		Description: "Anyone links must not grant edit",
+		Severity:    SeverityHigh,
+		Target:      PolicyTargetLink,
+		Remediation: "Delete the link, or replace it with a view-only or specific people link",
+		Audience:    LinkAudienceAnyone,
+		Edit:        true,
+	},
+	{
+		Name:        "no-external-on-confidential",
+		Description: "External users must not have access to items labeled Confidential",
+		Severity:    SeverityHigh,
+		Target:      PolicyTargetAccess,
+		Remediation: "Remove the external user's access, or relabel the item if it is no longer confidential",
+		Principal:   PolicyPrincipalExternal,
+		Label:       "Confidential",
+	},
+}
+
+// Remediation hints of configured policies
+const (
+	defaultLinkRemediation   = "Delete the link, or narrow who it works for"
+	defaultAccessRemediation = "Remove the principal's access"
+)
+
+// ParseAccessPolicies parses policies of the form "<name>[:<severity>]=<condition>[,<condition>...]"
+// separated by semicolons, e.g. "no-guest-owners:critical=principal:external,role:Full Control".
+// Conditions are "link:<anyone|organization|specific>", "edit", "principal:external", "role:<name>",
+// "object:<web|list|item>" and "label:<text>". Policies with link or edit check sharing links, the others
+// check access. Severity defaults to medium. An empty spec has no policies.
+func ParseAccessPolicies(spec string) ([]AccessPolicy, error) {
+	var policies []AccessPolicy
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		head, conditions, ok := strings.Cut(entry, "=")
+		name, severityName, rated := strings.Cut(head, ":")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !ok || name == "" || strings.ContainsAny(name, " \t") || strings.TrimSpace(conditions) == "" {
+			return nil, fmt.Errorf("%w: %q is not <name>[:<severity>]=<conditions>", ErrInvalidAccessPolicies, entry)
+		}
+
+		policy := AccessPolicy{Name: name, Description: strings.TrimSpace(conditions), Severity: SeverityMedium, Target: PolicyTargetAccess}
+		if rated {
+			severity, ok := ParseSeverity(severityName)
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown severity %q for %s", ErrInvalidAccessPolicies, strings.TrimSpace(severityName), name)
+			}
+			policy.Severity = severity
+		}
+		var linkOnly, accessOnly bool
+		for _, condition := range strings.Split(conditions, ",") {
+			key, value, _ := strings.Cut(condition, ":")
+			key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+			lower := strings.ToLower(value)
+			switch {
+			case key == "link" && (lower == LinkAudienceAnyone || lower == LinkAudienceOrganization || lower == LinkAudienceSpecific):
+				policy.Audience, linkOnly = lower, true
+			case key == "edit" && value == "":
+				policy.Edit, linkOnly = true, true
+			case key == "principal" && lower == PolicyPrincipalExternal:
+				policy.Principal, accessOnly = PolicyPrincipalExternal, true
+			case key == "role" && value != "":
+				policy.Role, accessOnly = value, true
+			case key == "object" && (lower == "web" || lower == "list" || lower == "item"):
+				policy.ObjectType, accessOnly = lower, true
+			case key == "label" && value != "":
+				policy.Label = value
+			default:
+				return nil, fmt.Errorf("%w: unknown condition %q for %s", ErrInvalidAccessPolicies, strings.TrimSpace(condition), name)
+			}
+		}
+		if linkOnly && accessOnly {
+			return nil, fmt.Errorf("%w: %s mixes sharing link and access conditions", ErrInvalidAccessPolicies, name)
+		}
+		policy.Remediation = defaultAccessRemediation
+		if linkOnly {
+			policy.Target, policy.Remediation = PolicyTargetLink, defaultLinkRemediation
+		}
+		policies = append(policies, policy)
+	}
+	return policies, nil
+}
+
+// AccessPoliciesWith returns the default policies with the configured ones of the same name replacing
+// them, followed by the other configured policies in order
+func AccessPoliciesWith(configured []AccessPolicy) []AccessPolicy {
+	byName := make(map[string]AccessPolicy, len(configured))
+	for _, policy := range configured {
+		byName[policy.Name] = policy
+	}
+
+	policies := make([]AccessPolicy, 0, len(DefaultAccessPolicies)+len(configured))
+	seen := make(map[string]bool)
+	for _, policy := range DefaultAccessPolicies {
+		if replacement, ok := byName[policy.Name]; ok {
+			policy = replacement
+		}
+		seen[policy.Name] = true
+		policies = append(policies, policy)
+	}
+	for _, policy := range configured {
+		if !seen[policy.Name] {
+			seen[policy.Name] = true
+			policies = append(policies, byName[policy.Name])
+		}
+	}
+	return policies
+}
+
+// PolicyFinding is a sharing link or grant of an audit run that violates an access policy
+type PolicyFinding struct {
+	ID          int64
+	SiteID      int64
+	SiteURL     string // Set when findings of several sites are listed together
+	AuditRunID  int64
+	Policy      string
+	Severity    Severity
+	ObjectType  string
+	ObjectKey   string
+	ObjectURL   string
+	ObjectName  string
+	Subject     string // The sharing link ID, or the login name of the principal holding the access
+	Detail      string
+	Remediation string
+}
+
+// PolicyEvaluation records an audit run's check against the access policies, so a run without findings
+// is told apart from one never checked
+type PolicyEvaluation struct {
+	SiteID      int64
+	AuditRunID  int64
+	EvaluatedAt time.Time
+	Policies    int // Number of policies checked
+	Findings    []*PolicyFinding
+}
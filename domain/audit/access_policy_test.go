@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccessPolicies(t *testing.T) {
+	policies, err := ParseAccessPolicies(" No-Guest-Owners:Critical=principal:External, role:Full Control ; org-edit=link:organization,edit;; ")
+	require.NoError(t, err)
+	assert.Equal(t, []AccessPolicy{
+		{
+			Name:        "no-guest-owners",
+			Description: "principal:External, role:Full Control",
+			Severity:    SeverityCritical,
+			Target:      PolicyTargetAccess,
+			Remediation: defaultAccessRemediation,
+			Principal:   PolicyPrincipalExternal,
+			Role:        "Full Control",
+		},
+		{
+			Name:        "org-edit",
+			Description: "link:organization,edit",
+			Severity:    SeverityMedium,
+			Target:      PolicyTargetLink,
+			Remediation: defaultLinkRemediation,
+			Audience:    LinkAudienceOrganization,
+			Edit:        true,
+		},
+	}, policies)
+
+	policies, err = ParseAccessPolicies("")
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+
+	for _, spec := range []string{
+		"label:Secret",
+		"=label:Secret",
+		"secret=",
+		"secret:urgent=label:Secret",
+		"secret=label:",
+		"secret=link:everyone",
+		"secret=object:site",
+		"secret=edit:yes",
+		"secret=link:anyone,principal:external",
+	} {
+		_, err := ParseAccessPolicies(spec)
+		assert.ErrorIs(t, err, ErrInvalidAccessPolicies, "spec %q", spec)
+	}
+}
+
+func TestAccessPolicy_Violated(t *testing.T) {
+	anyoneEdit := DefaultAccessPolicies[0]
+	confidential := DefaultAccessPolicies[1]
+
+	tests := []struct {
+		name     string
+		policy   AccessPolicy
+		subject  PolicySubject
+		violated bool
+	}{
+		{"anyone edit link", anyoneEdit, PolicySubject{Target: PolicyTargetLink, Audience: LinkAudienceAnyone, Edit: true}, true},
+		{"anyone view link", anyoneEdit, PolicySubject{Target: PolicyTargetLink, Audience: LinkAudienceAnyone}, false},
+		{"organization edit link", anyoneEdit, PolicySubject{Target: PolicyTargetLink, Audience: LinkAudienceOrganization, Edit: true}, false},
+		{"guest on highly confidential item", confidential, PolicySubject{Target: PolicyTargetAccess, External: true, ObjectType: "item", Label: "Highly Confidential"}, true},
+		{"member on confidential item", confidential, PolicySubject{Target: PolicyTargetAccess, ObjectType: "item", Label: "Confidential"}, false},
+		{"guest on unlabeled item", confidential, PolicySubject{Target: PolicyTargetAccess, External: true, ObjectType: "item"}, false},
+		{"link on confidential item", confidential, PolicySubject{Target: PolicyTargetLink, External: true, Label: "Confidential"}, false},
+		{"role ignoring case", AccessPolicy{Target: PolicyTargetAccess, Role: "Full Control", ObjectType: "web"}, PolicySubject{Target: PolicyTargetAccess, Role: "full control", ObjectType: "web"}, true},
+		{"other object type", AccessPolicy{Target: PolicyTargetAccess, Role: "Full Control", ObjectType: "web"}, PolicySubject{Target: PolicyTargetAccess, Role: "Full Control", ObjectType: "list"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.violated, tt.policy.Violated(tt.subject))
+		})
+	}
+}
+
+func TestAccessPoliciesWith(t *testing.T) {
+	configured, err := ParseAccessPolicies("guest-owners=principal:external,role:Full Control;no-anyone-edit-links:critical=link:anyone,edit")
+	require.NoError(t, err)
+
+	policies := AccessPoliciesWith(configured)
+	require.Len(t, policies, 3)
+	assert.Equal(t, "no-anyone-edit-links", policies[0].Name)
+	assert.Equal(t, SeverityCritical, policies[0].Severity, "replaced by the configured policy")
+	assert.Equal(t, DefaultAccessPolicies[1], policies[1])
+	assert.Equal(t, "guest-owners", policies[2].Name)
+
+	assert.Equal(t, DefaultAccessPolicies, AccessPoliciesWith(nil))
+}
@@ -0,0 +1,33 @@
+package audit
+
+import "time"
+
+// Decisions a site owner can make on an attestation entry.
+const (
+	AttestationKeep   = "keep"   // Still needed
+	AttestationRemove = "remove" // No longer needed and should be removed
+)
+
+// IsAttestationDecision returns true if the decision is one a site owner can make.
+func IsAttestationDecision(decision string) bool {
+	return decision == AttestationKeep || decision == AttestationRemove
+}
+
+// Attestation is a review link asking a site owner whether the external access and sharing links
+// of an audit run are still needed. Responses are recorded against the run.
+type Attestation struct {
+	ID          int64
+	SiteID      int64
+	AuditRunID  int64
+	Token       string // Identifies the review link
+	Owner       string
+	CreatedAt   time.Time
+	SubmittedAt *time.Time // Set once the owner has saved responses
+	KeepCount   int        // Entries marked still needed; set when listing a run's attestations
+	RemoveCount int        // Entries marked for removal; set when listing a run's attestations
+}
+
+// IsSubmitted returns true if the owner has responded.
+func (a *Attestation) IsSubmitted() bool {
+	return a.SubmittedAt != nil
+}
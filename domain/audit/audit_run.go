@@ -13,6 +13,18 @@ type AuditRun struct {
 	CompletedAt *time.Time
 	Status      string
 	Trigger     string
+
+	// A run on hold is exempt from retention pruning and archival, e.g. while it is evidence in an investigation
+	OnHold     bool
+	HeldAt     *time.Time
+	HoldReason string
+
+	// Server-relative path of the folder a targeted audit was limited to; empty for a full site audit
+	ScopePath string
+
+	// Environment or source the run was tagged with, e.g. "prod-tenant" while auditing both sides of a
+	// tenant migration; empty for an unlabeled run
+	Label string
 }
 
 // IsCompleted returns true if the audit run has completed
@@ -20,10 +32,50 @@ func (ar *AuditRun) IsCompleted() bool {
 	return ar.CompletedAt != nil
 }
 
+// IsFolderScoped returns true if the run covers a single folder rather than the whole site
+func (ar *AuditRun) IsFolderScoped() bool {
+	return ar.ScopePath != ""
+}
+
 // GetStatus returns the display status of the audit run
 func (ar *AuditRun) GetStatus() string {
 	if ar.IsCompleted() {
 		return "completed"
 	}
 	return "running"
-}
\ No newline at end of file
+}
+
+// Audit run aliases accepted wherever a run-scoped URL takes an audit run ID
+const (
+	RunAliasLatest    = "latest"    // Resolved according to the LatestRunPolicy
+	RunAliasReference = "reference" // The site's pinned reference run, or latest when none is pinned
+	RunAliasBaseline  = "baseline"  // The site's approved baseline run; there is no fallback
+)
+
+// A labeled "latest" alias, "latest@<label>", resolves like "latest" among the runs tagged with the label
+
+// IsRunAlias returns true if the value is an audit run alias rather than a numeric ID
+func IsRunAlias(value string) bool {
+	if _, ok := ParseLabeledLatestRun(value); ok {
+		return true
+	}
+	return value == RunAliasLatest || value == RunAliasReference || value == RunAliasBaseline
+}
+
+// LatestRunPolicy controls which audit run the "latest" alias resolves to
+type LatestRunPolicy string
+
+const (
+	// LatestRunAnyStatus resolves to the most recently started run, even if still running
+	LatestRunAnyStatus LatestRunPolicy = "any"
+	// LatestRunCompleted resolves to the most recently started completed run
+	LatestRunCompleted LatestRunPolicy = "completed"
+)
+
+// ParseLatestRunPolicy parses a policy name, defaulting to LatestRunAnyStatus
+func ParseLatestRunPolicy(value string) LatestRunPolicy {
+	if LatestRunPolicy(value) == LatestRunCompleted {
+		return LatestRunCompleted
+	}
+	return LatestRunAnyStatus
+}
@@ -0,0 +1,132 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Intervals of audit schedules. Each scheduled run audits the whole site.
+const (
+	DefaultAuditScheduleInterval = 24 * time.Hour
+	MinAuditScheduleInterval     = time.Hour
+)
+
+// What the runs of an audit schedule are compared with.
+const (
+	CompareWithPrevious = "previous" // The site's previous completed full audit
+	CompareWithBaseline = "baseline" // The site's approved baseline run
+)
+
+// Drift metrics, named as in DriftThresholds and alert messages.
+const (
+	DriftMetricExternalGrants    = "external_grants"
+	DriftMetricPermissionChanges = "permission_changes"
+	DriftMetricSharingLinks      = "sharing_links"
+	DriftMetricUniqueItems       = "unique_items"
+	DriftMetricRemovedPrincipals = "removed_principals"
+)
+
+// DriftThresholds is how much drift a scheduled run may show before it raises an alert. A run
+// exceeding any threshold raises one; a nil threshold is not checked.
+type DriftThresholds struct {
+	ExternalGrants    *int // Guests granted access, or given more roles
+	PermissionChanges *int // Principals whose roles on the web, a list or an item changed
+	SharingLinks      *int // Sharing links created
+	UniqueItems       *int // Items that stopped inheriting permissions
+	RemovedPrincipals *int // Principals that no longer hold access
+}
+
+// Validate checks that at least one threshold is set and none is negative.
+func (t DriftThresholds) Validate() error {
+	set := 0
+	for _, threshold := range t.thresholds() {
+		if threshold.max == nil {
+			continue
+		}
+		if *threshold.max < 0 {
+			return fmt.Errorf("%s threshold cannot be negative", threshold.metric)
+		}
+		set++
+	}
+	if set == 0 {
+		return errors.New("at least one drift threshold is required")
+	}
+	return nil
+}
+
+// Exceeded returns the thresholds the drift exceeds, in metric order.
+func (t DriftThresholds) Exceeded(drift DriftMeasure) []DriftBreach {
+	counts := map[string]int{
+		DriftMetricExternalGrants:    drift.ExternalGrants,
+		DriftMetricPermissionChanges: drift.PermissionChanges,
+		DriftMetricSharingLinks:      drift.SharingLinks,
+		DriftMetricUniqueItems:       drift.UniqueItems,
+		DriftMetricRemovedPrincipals: drift.RemovedPrincipals,
+	}
+	var breaches []DriftBreach
+	for _, threshold := range t.thresholds() {
+		if threshold.max != nil && counts[threshold.metric] > *threshold.max {
+			breaches = append(breaches, DriftBreach{Metric: threshold.metric, Count: counts[threshold.metric], Threshold: *threshold.max})
+		}
+	}
+	return breaches
+}
+
+// driftThreshold is a threshold paired with the metric it applies to
+type driftThreshold struct {
+	metric string
+	max    *int
+}
+
+// thresholds pairs each threshold with its metric, in metric order
+func (t DriftThresholds) thresholds() []driftThreshold {
+	return []driftThreshold{
+		{DriftMetricExternalGrants, t.ExternalGrants},
+		{DriftMetricPermissionChanges, t.PermissionChanges},
+		{DriftMetricSharingLinks, t.SharingLinks},
+		{DriftMetricUniqueItems, t.UniqueItems},
+		{DriftMetricRemovedPrincipals, t.RemovedPrincipals},
+	}
+}
+
+// DriftMeasure counts the drift of a run against the run it was compared with.
+type DriftMeasure struct {
+	ExternalGrants    int
+	PermissionChanges int
+	SharingLinks      int
+	UniqueItems       int
+	RemovedPrincipals int
+}
+
+// DriftBreach is a drift threshold a run exceeded.
+type DriftBreach struct {
+	Metric    string
+	Count     int
+	Threshold int
+}
+
+// AuditSchedule audits a site every interval. Once each of its runs completes, the run is compared
+// with the site's previous completed full audit or its baseline, and raises a drift alert only when
+// the drift exceeds a threshold.
+type AuditSchedule struct {
+	ID          int64
+	SiteURL     string
+	Interval    time.Duration
+	CompareWith string // CompareWithPrevious or CompareWithBaseline
+	Thresholds  DriftThresholds
+	CreatedAt   time.Time
+	NextRunAt   time.Time
+	LastJobID   string // Job of the latest scheduled audit, empty before the first
+	LastRunAt   *time.Time
+}
+
+// AuditScheduleCheck is the drift a scheduled run was found to have against the run it was compared with.
+type AuditScheduleCheck struct {
+	ScheduleID     int64
+	AuditRunID     int64
+	BaseAuditRunID int64
+	Drift          DriftMeasure
+	AlertID        int64 // The drift alert raised when the drift exceeded a threshold, zero otherwise
+	CheckedAt      time.Time
+}
@@ -0,0 +1,26 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDriftThresholds_Validate(t *testing.T) {
+	zero, five, negative := 0, 5, -1
+
+	assert.Error(t, DriftThresholds{}.Validate(), "at least one threshold")
+	assert.Error(t, DriftThresholds{ExternalGrants: &five, SharingLinks: &negative}.Validate())
+	assert.NoError(t, DriftThresholds{UniqueItems: &zero}.Validate(), "zero alerts on any drift")
+}
+
+func TestDriftThresholds_Exceeded(t *testing.T) {
+	zero, five := 0, 5
+	thresholds := DriftThresholds{ExternalGrants: &five, RemovedPrincipals: &zero}
+
+	assert.Empty(t, thresholds.Exceeded(DriftMeasure{ExternalGrants: 5, PermissionChanges: 40}), "unset thresholds are not checked")
+	assert.Equal(t, []DriftBreach{
+		{Metric: DriftMetricExternalGrants, Count: 6, Threshold: 5},
+		{Metric: DriftMetricRemovedPrincipals, Count: 1, Threshold: 0},
+	}, thresholds.Exceeded(DriftMeasure{ExternalGrants: 6, RemovedPrincipals: 1}))
+}
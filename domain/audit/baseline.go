@@ -0,0 +1,20 @@
+package audit
+
+import "time"
+
+// Baseline is an audit run approved as the expected permission state of its site.
+// Later runs are compared with the current baseline to find drift.
+type Baseline struct {
+	ID           int64
+	SiteID       int64
+	AuditRunID   int64
+	ApprovedBy   string
+	Note         string
+	ApprovedAt   time.Time
+	SupersededAt *time.Time // Set once another run is approved or the baseline is cleared
+}
+
+// IsCurrent returns true if the baseline has not been superseded
+func (b *Baseline) IsCurrent() bool {
+	return b.SupersededAt == nil
+}
@@ -0,0 +1,40 @@
+package audit
+
+import "time"
+
+// ListCheckpoint is how far an audit run got through a list. An interrupted run resumed later skips
+// the lists it completed, and continues the list it was collecting after LastItemID.
+type ListCheckpoint struct {
+	ListID     string
+	LastItemID int  // Items are walked in ID order, so all up to this one are saved; 0 before any is
+	Completed  bool // The list and all its items were collected
+	UpdatedAt  time.Time
+}
+
+// ListCheckpoints indexes a run's checkpoints by list ID.
+type ListCheckpoints map[string]*ListCheckpoint
+
+// NewListCheckpoints indexes checkpoints by list ID.
+func NewListCheckpoints(checkpoints []*ListCheckpoint) ListCheckpoints {
+	byList := make(ListCheckpoints, len(checkpoints))
+	for _, checkpoint := range checkpoints {
+		byList[checkpoint.ListID] = checkpoint
+	}
+	return byList
+}
+
+// IsCompleted returns true if the run already collected the list.
+func (c ListCheckpoints) IsCompleted(listID string) bool {
+	checkpoint, ok := c[listID]
+	return ok && checkpoint.Completed
+}
+
+// ResumeAfter returns the ID of the last item the run collected from a list it did not complete,
+// or 0 to walk the list from the start.
+func (c ListCheckpoints) ResumeAfter(listID string) int {
+	checkpoint, ok := c[listID]
+	if !ok || checkpoint.Completed {
+		return 0
+	}
+	return checkpoint.LastItemID
+}
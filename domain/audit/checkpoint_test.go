@@ -0,0 +1,23 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCheckpoints(t *testing.T) {
+	checkpoints := NewListCheckpoints([]*ListCheckpoint{
+		{ListID: "done", LastItemID: 40, Completed: true},
+		{ListID: "partial", LastItemID: 250},
+	})
+
+	assert.True(t, checkpoints.IsCompleted("done"))
+	assert.Zero(t, checkpoints.ResumeAfter("done"), "a completed list is not walked again")
+
+	assert.False(t, checkpoints.IsCompleted("partial"))
+	assert.Equal(t, 250, checkpoints.ResumeAfter("partial"))
+
+	assert.False(t, checkpoints.IsCompleted("new"))
+	assert.Zero(t, checkpoints.ResumeAfter("new"), "a list the run never reached is walked from the start")
+}
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Collector backends: the APIs an audit reads a site through.
+const (
+	CollectorBackendREST  = "rest"  // SharePoint REST, for everything
+	CollectorBackendGraph = "graph" // Microsoft Graph where it serves the data, SharePoint REST for the rest
+)
+
+// NormalizeCollectorBackend trims and lowercases a collector backend; empty selects SharePoint REST.
+func NormalizeCollectorBackend(backend string) string {
+	backend = strings.ToLower(strings.TrimSpace(backend))
+	if backend == "" {
+		return CollectorBackendREST
+	}
+	return backend
+}
+
+// ValidateCollectorBackend checks a normalized collector backend.
+func ValidateCollectorBackend(backend string) error {
+	switch backend {
+	case CollectorBackendREST, CollectorBackendGraph:
+		return nil
+	}
+	return fmt.Errorf("collector_backend must be %q or %q, got: %q", CollectorBackendREST, CollectorBackendGraph, backend)
+}
+
+// UsesGraphCollector returns true if the audit reads what Microsoft Graph serves through it
+func (p *AuditParameters) UsesGraphCollector() bool {
+	return NormalizeCollectorBackend(p.CollectorBackend) == CollectorBackendGraph
+}
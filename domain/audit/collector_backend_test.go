@@ -0,0 +1,23 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectorBackend(t *testing.T) {
+	assert.Equal(t, CollectorBackendREST, NormalizeCollectorBackend(""))
+	assert.Equal(t, CollectorBackendGraph, NormalizeCollectorBackend(" Graph "))
+	assert.NoError(t, ValidateCollectorBackend(CollectorBackendGraph))
+	assert.Error(t, ValidateCollectorBackend("csom"))
+
+	parameters := DefaultParameters()
+	assert.False(t, parameters.UsesGraphCollector())
+	parameters.CollectorBackend = "GRAPH"
+	assert.True(t, parameters.UsesGraphCollector())
+	assert.NoError(t, parameters.Validate(DefaultApiConstraints()))
+
+	parameters.CollectorBackend = "csom"
+	assert.Error(t, parameters.Validate(DefaultApiConstraints()))
+}
@@ -2,6 +2,7 @@ package audit
 
 import (
 	"fmt"
+	"strings"
 )
 
 // AuditParameters represents user-configurable audit behavior and preferences.
@@ -11,12 +12,31 @@ type AuditParameters struct {
 	ScanIndividualItems bool // Whether to perform deep scanning of individual documents/folders within lists
 	SkipHidden          bool // Skip hidden lists and items
 	IncludeSharing      bool // Whether to include comprehensive sharing audit
+	CollectAnalytics    bool // Whether to collect view/download counts for shared items
+	SampleLargeLists    bool // Deep-scan only a statistical sample of items in lists larger than SampleThreshold
+	SampleThreshold     int  // Item count above which a list is sampled when SampleLargeLists is set
+	ScanRecycleBin      bool // Whether to record the recycle bin, to find deleted items that were still shared
+	AuditGraphGrants    bool // Whether to read the apps Microsoft Graph grants access to the site, bypassing its role assignments
+	ExpandEntraGroups   bool // Whether to resolve the users Entra groups holding roles on the site reach, through Microsoft Graph
+
+	// Targeted scope
+	FolderPath string // Server-relative path of the folder to audit, only it and the items below it; empty audits the whole site
+
+	// Environment or source label the run is tagged with, e.g. "prod-tenant"; empty leaves it unlabeled
+	Label string
 
 	// Performance parameters
 	BatchSize  int // User-preferred batch size for API calls
-	MaxRetries int // Maximum retry attempts for failed operations
-	RetryDelay int // Delay between retries in milliseconds
+	MaxRetries int // Maximum retry attempts for failed operations, throttled requests included
+	RetryDelay int // Delay between retries in milliseconds, doubled for each further retry of a throttled request
 	Timeout    int // Overall audit timeout in seconds
+
+	// Requests per second every audit of the tenant is paced at together; 0 keeps the tenant's current
+	// pacing, which is none until an audit sets a rate
+	MaxRequestsPerSecond int
+
+	// API the site is read through, CollectorBackendREST or CollectorBackendGraph; empty is REST
+	CollectorBackend string
 }
 
 // DefaultParameters returns sensible default audit parameters.
@@ -24,11 +44,18 @@ func DefaultParameters() *AuditParameters {
 	return &AuditParameters{
 		ScanIndividualItems: true,
 		SkipHidden:          true,
-		IncludeSharing:      true, // Enable comprehensive sharing audit by default
-		BatchSize:           100,  // Standard default batch size
+		IncludeSharing:      true,  // Enable comprehensive sharing audit by default
+		CollectAnalytics:    false, // One extra API call per shared item, so opt-in
+		SampleLargeLists:    false, // Counts become estimates, so opt-in
+		SampleThreshold:     DefaultSampleThreshold,
+		ScanRecycleBin:      false, // Only useful with earlier runs to compare against, so opt-in
+		AuditGraphGrants:    false, // Needs Microsoft Graph application permissions, so opt-in
+		ExpandEntraGroups:   false, // Needs GroupMember.Read.All on Microsoft Graph, so opt-in
+		BatchSize:           100,   // Standard default batch size
 		MaxRetries:          3,
 		RetryDelay:          1000, // 1 second
 		Timeout:             1800, // 30 minutes
+		CollectorBackend:    CollectorBackendREST,
 	}
 }
 
@@ -41,6 +68,8 @@ type SharePointApiConstraints struct {
 	MaxTimeout    int // Maximum reasonable timeout (2 hours)
 	MaxRetries    int // Maximum retry attempts (10)
 	MaxRetryDelay int // Maximum retry delay (60 seconds)
+
+	MaxRequestsPerSecond int // Highest pacing rate (100); above it pacing would not prevent throttling anyway
 }
 
 // DefaultApiConstraints returns SharePoint API technical limits.
@@ -52,6 +81,8 @@ func DefaultApiConstraints() *SharePointApiConstraints {
 		MaxTimeout:    7200, // 2 hours maximum
 		MaxRetries:    10,
 		MaxRetryDelay: 60000, // 60 seconds
+
+		MaxRequestsPerSecond: 100,
 	}
 }
 
@@ -88,6 +119,34 @@ func (p *AuditParameters) Validate(constraints *SharePointApiConstraints) error
 		return fmt.Errorf("retry_delay cannot exceed %d ms, got: %d ms", constraints.MaxRetryDelay, p.RetryDelay)
 	}
 
+	// Validate MaxRequestsPerSecond
+	if p.MaxRequestsPerSecond < 0 {
+		return fmt.Errorf("max_requests_per_second cannot be negative, got: %d", p.MaxRequestsPerSecond)
+	}
+	if p.MaxRequestsPerSecond > constraints.MaxRequestsPerSecond {
+		return fmt.Errorf("max_requests_per_second cannot exceed %d, got: %d", constraints.MaxRequestsPerSecond, p.MaxRequestsPerSecond)
+	}
+
+	// Validate CollectorBackend
+	if err := ValidateCollectorBackend(NormalizeCollectorBackend(p.CollectorBackend)); err != nil {
+		return err
+	}
+
+	// Validate SampleThreshold; below it the sample is most of the list anyway
+	if p.SampleLargeLists && p.SampleThreshold < MinSampleThreshold {
+		return fmt.Errorf("sample_threshold must be at least %d items, got: %d", MinSampleThreshold, p.SampleThreshold)
+	}
+
+	// Validate FolderPath; it is resolved against the site when the audit is queued
+	if p.FolderPath != "" && !strings.HasPrefix(p.FolderPath, "/") {
+		return fmt.Errorf("folder_path must be server-relative, got: %s", p.FolderPath)
+	}
+
+	// Validate Label; it is matched exactly when views are filtered by label
+	if err := ValidateRunLabel(p.Label); err != nil {
+		return err
+	}
+
 	// Validate Timeout
 	if p.Timeout < constraints.MinTimeout {
 		return fmt.Errorf("timeout must be at least %d seconds for SharePoint operations, got: %d seconds", constraints.MinTimeout, p.Timeout)
@@ -121,6 +180,9 @@ func (p *AuditParameters) ValidateAndSetDefaults(constraints *SharePointApiConst
 	if p.Timeout == 0 {
 		p.Timeout = 1800
 	}
+	if p.SampleThreshold == 0 {
+		p.SampleThreshold = DefaultSampleThreshold
+	}
 
 	// Now validate with defaults applied
 	return p.Validate(constraints)
@@ -142,6 +204,17 @@ func (p *AuditParameters) SetBatchSize(batchSize int, constraints *SharePointApi
 	}
 }
 
+// IsFolderScoped returns true if the audit covers a single folder rather than the whole site
+func (p *AuditParameters) IsFolderScoped() bool {
+	return p.FolderPath != ""
+}
+
+// ShouldSample returns true if a list with itemCount items is sampled rather than fully deep-scanned.
+// Folder-scoped audits are never sampled; the folder is only a part of the list's items.
+func (p *AuditParameters) ShouldSample(itemCount int) bool {
+	return p.SampleLargeLists && !p.IsFolderScoped() && itemCount > max(p.SampleThreshold, MinSampleThreshold)
+}
+
 // GetEffectiveBatchSize returns the batch size to use, with fallback to default if not set
 func (p *AuditParameters) GetEffectiveBatchSize() int {
 	if p.BatchSize <= 0 {
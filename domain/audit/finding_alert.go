@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidQuietHours is returned by ParseQuietHours for malformed quiet hours.
+var ErrInvalidQuietHours = errors.New("invalid quiet hours")
+
+// QuietHours is the time of day finding alerts are held back, queued for delivery once it ends.
+// The zero value has no quiet hours.
+type QuietHours struct {
+	Start time.Duration // Time of day, local
+	End   time.Duration // May be before Start for quiet hours across midnight
+}
+
+// ParseQuietHours parses quiet hours of the form "HH:MM-HH:MM", e.g. "22:00-07:00".
+// An empty spec has no quiet hours.
+func ParseQuietHours(spec string) (QuietHours, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return QuietHours{}, nil
+	}
+
+	start, end, ok := strings.Cut(spec, "-")
+	if !ok {
+		return QuietHours{}, fmt.Errorf("%w: %q is not HH:MM-HH:MM", ErrInvalidQuietHours, spec)
+	}
+	var quiet QuietHours
+	var err error
+	if quiet.Start, err = parseTimeOfDay(start); err != nil {
+		return QuietHours{}, fmt.Errorf("%w: %v", ErrInvalidQuietHours, err)
+	}
+	if quiet.End, err = parseTimeOfDay(end); err != nil {
+		return QuietHours{}, fmt.Errorf("%w: %v", ErrInvalidQuietHours, err)
+	}
+	if quiet.Start == quiet.End {
+		return QuietHours{}, fmt.Errorf("%w: %q starts and ends at the same time", ErrInvalidQuietHours, spec)
+	}
+	return quiet, nil
+}
+
+// Active reports whether now falls inside the quiet hours.
+func (q QuietHours) Active(now time.Time) bool {
+	if q.Start == q.End {
+		return false
+	}
+	return inTimeOfDayWindow(q.Start, q.End, now)
+}
+
+// FindingAlert announces a finding of an audit run. Findings also seen in the site's previous
+// completed run raise no alert, so each alert is a finding that is new or has come back.
+type FindingAlert struct {
+	ID          int64
+	SiteID      int64
+	AuditRunID  int64
+	Fingerprint string // Same for the same finding in every run
+	Category    string
+	Severity    Severity
+	Message     string
+	CreatedAt   time.Time
+	DeliveredAt *time.Time // Nil while queued for the end of quiet hours
+}
+
+// IsQueued returns true if the alert is waiting for quiet hours to end.
+func (a *FindingAlert) IsQueued() bool {
+	return a.DeliveredAt == nil
+}
+
+// FindingFingerprint identifies a finding across runs by its category and the subject it is about,
+// e.g. a list or web ID. Findings about the whole site have no subject.
+func FindingFingerprint(category, subject string) string {
+	if subject == "" {
+		return category
+	}
+	return category + ":" + subject
+}
@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuietHours(t *testing.T) {
+	quiet, err := ParseQuietHours(" 22:00 - 07:30 ")
+	require.NoError(t, err)
+	assert.Equal(t, QuietHours{Start: 22 * time.Hour, End: 7*time.Hour + 30*time.Minute}, quiet)
+
+	quiet, err = ParseQuietHours("")
+	require.NoError(t, err)
+	assert.False(t, quiet.Active(time.Date(2025, 3, 1, 3, 0, 0, 0, time.Local)), "no quiet hours")
+
+	for _, spec := range []string{"22:00", "22:00-25:00", "night-07:00", "07:00-07:00"} {
+		_, err := ParseQuietHours(spec)
+		assert.ErrorIs(t, err, ErrInvalidQuietHours, "spec %q", spec)
+	}
+}
+
+func TestQuietHours_Active(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2025, 3, 1, hour, minute, 0, 0, time.Local) }
+
+	overnight := QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour}
+	assert.True(t, overnight.Active(at(23, 0)))
+	assert.True(t, overnight.Active(at(6, 59)))
+	assert.False(t, overnight.Active(at(7, 0)), "quiet hours end at the end time")
+	assert.False(t, overnight.Active(at(12, 0)))
+
+	lunch := QuietHours{Start: 12 * time.Hour, End: 13 * time.Hour}
+	assert.True(t, lunch.Active(at(12, 30)))
+	assert.False(t, lunch.Active(at(13, 30)))
+}
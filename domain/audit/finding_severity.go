@@ -0,0 +1,71 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Severity ranks how urgently a finding needs attention
+type Severity string
+
+// Severities, from least to most urgent
+const (
+	SeverityInfo     Severity = "info"
+	SeverityLow      Severity = "low"
+	SeverityMedium   Severity = "medium"
+	SeverityHigh     Severity = "high"
+	SeverityCritical Severity = "critical"
+)
+
+// Severities lists every severity from least to most urgent
+var Severities = []Severity{SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// ErrInvalidSeverityMapping is returned when finding severity overrides cannot be parsed
+var ErrInvalidSeverityMapping = errors.New("invalid finding severity mapping")
+
+// ParseSeverity returns the severity with the given name, ignoring case
+func ParseSeverity(name string) (Severity, bool) {
+	severity := Severity(strings.ToLower(strings.TrimSpace(name)))
+	for _, known := range Severities {
+		if severity == known {
+			return severity, true
+		}
+	}
+	return "", false
+}
+
+// Rank orders severities, 0 for info up to 4 for critical, and -1 for an unknown severity
+func (s Severity) Rank() int {
+	for i, known := range Severities {
+		if s == known {
+			return i
+		}
+	}
+	return -1
+}
+
+// ParseSeverityMapping parses finding category overrides of the form "<category>=<severity>" separated by
+// semicolons, e.g. "default_link_edit=critical;list_added=info". Categories are lowercased; a category
+// given twice keeps the last severity. An empty spec overrides nothing.
+func ParseSeverityMapping(spec string) (map[string]Severity, error) {
+	mapping := make(map[string]Severity)
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		category, name, ok := strings.Cut(entry, "=")
+		category = strings.ToLower(strings.TrimSpace(category))
+		if !ok || category == "" {
+			return nil, fmt.Errorf("%w: %q is not <category>=<severity>", ErrInvalidSeverityMapping, entry)
+		}
+		severity, ok := ParseSeverity(name)
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown severity %q for %s", ErrInvalidSeverityMapping, strings.TrimSpace(name), category)
+		}
+		mapping[category] = severity
+	}
+	return mapping, nil
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverityMapping(t *testing.T) {
+	mapping, err := ParseSeverityMapping(" Default_Link_Edit = Critical ; list_added=info;; list_added=low ")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]Severity{"default_link_edit": SeverityCritical, "list_added": SeverityLow}, mapping)
+
+	mapping, err = ParseSeverityMapping("")
+	require.NoError(t, err)
+	assert.Empty(t, mapping)
+
+	for _, spec := range []string{
+		"critical",
+		"=high",
+		"default_link_edit=urgent",
+		"default_link_edit=",
+	} {
+		_, err := ParseSeverityMapping(spec)
+		assert.ErrorIs(t, err, ErrInvalidSeverityMapping, "spec %q", spec)
+	}
+}
+
+func TestSeverity_Rank(t *testing.T) {
+	assert.Less(t, SeverityHigh.Rank(), SeverityCritical.Rank())
+	assert.Equal(t, 0, SeverityInfo.Rank())
+	assert.Equal(t, -1, Severity("urgent").Rank())
+}
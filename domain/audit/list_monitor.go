@@ -0,0 +1,28 @@
+package audit
+
+import "time"
+
+// Intervals of list monitors. Checking a list's change log costs one request, so monitors may check
+// often; a check that finds changes audits the whole list.
+const (
+	DefaultListMonitorInterval = 15 * time.Minute
+	MinListMonitorInterval     = time.Minute
+)
+
+// ListMonitor re-checks a critical list on a short interval. Each check reads the list's change log
+// from where the previous one stopped and, when anything changed, audits the list again. The
+// permission changes each of its audits finds since the last are raised as finding alerts.
+type ListMonitor struct {
+	ID             int64
+	SiteURL        string
+	ListID         string
+	ListTitle      string
+	ListRootPath   string // Server-relative path of the list's root folder, the scope of its audits
+	Interval       time.Duration
+	ChangeToken    string // Position in the list's change log up to which changes were audited
+	CreatedAt      time.Time
+	NextCheckAt    time.Time
+	LastCheckedAt  *time.Time
+	LastAuditJobID string // Job of the latest audit of the list, empty before the first
+	LastAuditAt    *time.Time
+}
@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"strings"
+	"time"
+)
+
+// SharePoint drops a list webhook subscription after at most 180 days, so subscriptions are made
+// for a day less, leaving room for clock skew, and renewed a month before they lapse.
+const (
+	ListSubscriptionLifetime    = 179 * 24 * time.Hour
+	ListSubscriptionRenewBefore = 30 * 24 * time.Hour
+)
+
+// ListSubscription is a SharePoint webhook subscription to the changes of a high-priority list.
+// Changes it is notified of are audited between full audits by a micro-audit: a folder-scoped
+// audit of the narrowest folder holding every change.
+type ListSubscription struct {
+	ID             string // SharePoint's subscription ID
+	SiteURL        string
+	ListID         string
+	ListTitle      string
+	ListRootPath   string // Server-relative path of the list's root folder
+	ClientState    string // Secret SharePoint echoes in notifications, proving they are for this subscription
+	ChangeToken    string // Position in the list's change log up to which changes were audited
+	ExpiresAt      time.Time
+	CreatedAt      time.Time
+	LastNotifiedAt *time.Time
+	LastAuditJobID string // Job of the latest micro-audit, empty before the first
+	LastAuditAt    *time.Time
+}
+
+// NeedsRenewal returns true if the subscription lapses within ListSubscriptionRenewBefore of now.
+func (s *ListSubscription) NeedsRenewal(now time.Time) bool {
+	return !now.Before(s.ExpiresAt.Add(-ListSubscriptionRenewBefore))
+}
+
+// MicroAuditFolder returns the narrowest folder of a list that holds every one of the server-relative
+// folders changes were made in, or the list's root folder when they share nothing below it. It
+// returns "" without folders.
+func MicroAuditFolder(listRootPath string, folders []string) string {
+	if len(folders) == 0 {
+		return ""
+	}
+	listRootPath = strings.TrimRight(listRootPath, "/")
+
+	common := strings.Split(strings.TrimRight(folders[0], "/"), "/")
+	for _, folder := range folders[1:] {
+		segments := strings.Split(strings.TrimRight(folder, "/"), "/")
+		n := 0
+		for n < len(common) && n < len(segments) && strings.EqualFold(common[n], segments[n]) {
+			n++
+		}
+		common = common[:n]
+	}
+
+	folder := strings.Join(common, "/")
+	if !PathInFolder(folder, listRootPath) {
+		return listRootPath
+	}
+	return folder
+}
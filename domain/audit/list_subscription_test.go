@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMicroAuditFolder(t *testing.T) {
+	const root = "/sites/Finance/Shared Documents"
+
+	tests := []struct {
+		name     string
+		folders  []string
+		expected string
+	}{
+		{"no changes", nil, ""},
+		{"one folder", []string{root + "/Payroll/2024"}, root + "/Payroll/2024"},
+		{"sibling folders", []string{root + "/Payroll/2024", root + "/Payroll/2025"}, root + "/Payroll"},
+		{"folder and its subfolder", []string{root + "/Payroll", root + "/payroll/2025"}, root + "/Payroll"},
+		{"top level of the list", []string{root, root + "/Payroll"}, root},
+		{"folders sharing a name prefix", []string{root + "/Pay", root + "/Payroll"}, root},
+		{"outside the list", []string{root + "/Payroll", "/sites/Finance/Lists/Tasks"}, root},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, MicroAuditFolder(root+"/", tt.folders))
+		})
+	}
+}
+
+func TestListSubscription_NeedsRenewal(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	subscription := &ListSubscription{ExpiresAt: now.Add(ListSubscriptionLifetime)}
+	assert.False(t, subscription.NeedsRenewal(now))
+
+	subscription.ExpiresAt = now.Add(ListSubscriptionRenewBefore - time.Hour)
+	assert.True(t, subscription.NeedsRenewal(now))
+
+	subscription.ExpiresAt = now.Add(-time.Hour)
+	assert.True(t, subscription.NeedsRenewal(now), "lapsed subscriptions are renewed too")
+}
@@ -0,0 +1,110 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Database maintenance triggers
+const (
+	MaintenanceManual    = "manual"
+	MaintenanceScheduled = "scheduled"
+)
+
+// ErrInvalidMaintenanceWindow is returned by ParseMaintenanceSchedule for a malformed window.
+var ErrInvalidMaintenanceWindow = errors.New("invalid maintenance window")
+
+// MaintenanceSchedule controls when database maintenance starts on its own. Scheduled maintenance
+// also waits until no job is running.
+type MaintenanceSchedule struct {
+	Interval    time.Duration // Between scheduled runs; 0 runs maintenance only on request
+	WindowStart time.Duration // Time of day, local; a window equal at both ends allows any time
+	WindowEnd   time.Duration // May be before WindowStart for a window across midnight
+}
+
+// ParseMaintenanceSchedule builds a schedule from an interval and a window of the form "HH:MM-HH:MM",
+// e.g. "22:30-05:00". An empty window allows any time of day.
+func ParseMaintenanceSchedule(interval time.Duration, window string) (MaintenanceSchedule, error) {
+	schedule := MaintenanceSchedule{Interval: interval}
+	window = strings.TrimSpace(window)
+	if window == "" {
+		return schedule, nil
+	}
+
+	start, end, ok := strings.Cut(window, "-")
+	if !ok {
+		return schedule, fmt.Errorf("%w: %q is not HH:MM-HH:MM", ErrInvalidMaintenanceWindow, window)
+	}
+	var err error
+	if schedule.WindowStart, err = parseTimeOfDay(start); err != nil {
+		return schedule, fmt.Errorf("%w: %v", ErrInvalidMaintenanceWindow, err)
+	}
+	if schedule.WindowEnd, err = parseTimeOfDay(end); err != nil {
+		return schedule, fmt.Errorf("%w: %v", ErrInvalidMaintenanceWindow, err)
+	}
+	return schedule, nil
+}
+
+func parseTimeOfDay(value string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not HH:MM", value)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+// InWindow reports whether now falls inside the maintenance window.
+func (s MaintenanceSchedule) InWindow(now time.Time) bool {
+	if s.WindowStart == s.WindowEnd {
+		return true
+	}
+	return inTimeOfDayWindow(s.WindowStart, s.WindowEnd, now)
+}
+
+// inTimeOfDayWindow reports whether now falls between two times of day, local time. The window
+// wraps past midnight when end is before start.
+func inTimeOfDayWindow(start, end time.Duration, now time.Time) bool {
+	timeOfDay := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute
+	if start < end {
+		return timeOfDay >= start && timeOfDay < end
+	}
+	return timeOfDay >= start || timeOfDay < end
+}
+
+// Due reports whether scheduled maintenance should start at now, given when maintenance last
+// started. A zero lastRun means it never ran.
+func (s MaintenanceSchedule) Due(lastRun, now time.Time) bool {
+	if s.Interval <= 0 || !s.InWindow(now) {
+		return false
+	}
+	return lastRun.IsZero() || now.Sub(lastRun) >= s.Interval
+}
+
+// MaintenanceRun is one run of database maintenance: ANALYZE, incremental VACUUM and an integrity
+// check, which also verifies every index against its table.
+type MaintenanceRun struct {
+	ID              int64
+	JobID           string
+	Trigger         string // MaintenanceManual or MaintenanceScheduled
+	StartedAt       time.Time
+	CompletedAt     *time.Time // Nil while running
+	BytesBefore     int64      // Database and WAL files
+	BytesAfter      int64
+	FreeBytesBefore int64 // Unused pages inside the database file
+	FreeBytesAfter  int64
+	Converted       bool     // The database was switched to incremental auto-vacuum with a full VACUUM
+	IntegrityErrors []string // Empty when the check passed
+	Error           string   // Why the run stopped early
+}
+
+// ReclaimedBytes returns how much smaller the database files became.
+func (r *MaintenanceRun) ReclaimedBytes() int64 {
+	return max(r.BytesBefore-r.BytesAfter, 0)
+}
+
+// Healthy reports whether the run finished with no errors and a passing integrity check.
+func (r *MaintenanceRun) Healthy() bool {
+	return r.CompletedAt != nil && r.Error == "" && len(r.IntegrityErrors) == 0
+}
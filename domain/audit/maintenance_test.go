@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMaintenanceSchedule(t *testing.T) {
+	schedule, err := ParseMaintenanceSchedule(24*time.Hour, " 22:30-05:00 ")
+	require.NoError(t, err)
+	assert.Equal(t, MaintenanceSchedule{Interval: 24 * time.Hour, WindowStart: 22*time.Hour + 30*time.Minute, WindowEnd: 5 * time.Hour}, schedule)
+
+	anyTime, err := ParseMaintenanceSchedule(time.Hour, "")
+	require.NoError(t, err)
+	assert.Equal(t, MaintenanceSchedule{Interval: time.Hour}, anyTime)
+
+	for _, window := range []string{"22:30", "25:00-01:00", "1am-2am"} {
+		_, err := ParseMaintenanceSchedule(time.Hour, window)
+		assert.ErrorIs(t, err, ErrInvalidMaintenanceWindow, window)
+	}
+}
+
+func TestMaintenanceSchedule_Due(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2025, 3, 1, hour, minute, 0, 0, time.Local) }
+	overnight := MaintenanceSchedule{Interval: 24 * time.Hour, WindowStart: 22 * time.Hour, WindowEnd: 5 * time.Hour}
+	daytime := MaintenanceSchedule{Interval: 24 * time.Hour, WindowStart: 9 * time.Hour, WindowEnd: 17 * time.Hour}
+
+	assert.True(t, overnight.Due(time.Time{}, at(23, 0)), "never ran, inside the window")
+	assert.True(t, overnight.Due(time.Time{}, at(4, 59)), "window continues past midnight")
+	assert.False(t, overnight.Due(time.Time{}, at(5, 0)), "window end is exclusive")
+	assert.False(t, overnight.Due(time.Time{}, at(12, 0)))
+	assert.True(t, daytime.Due(time.Time{}, at(9, 0)))
+	assert.False(t, daytime.Due(time.Time{}, at(17, 0)))
+
+	assert.False(t, overnight.Due(at(23, 0).Add(-12*time.Hour), at(23, 0)), "interval not elapsed")
+	assert.True(t, overnight.Due(at(23, 0).Add(-24*time.Hour), at(23, 0)))
+
+	assert.False(t, MaintenanceSchedule{}.Due(time.Time{}, at(12, 0)), "no interval runs only on request")
+	assert.True(t, MaintenanceSchedule{Interval: time.Hour}.Due(time.Time{}, at(12, 0)), "no window allows any time")
+}
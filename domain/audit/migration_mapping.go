@@ -0,0 +1,135 @@
+package audit
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// MaxMigrationMappings bounds the URL and principal mappings of one migration assessment.
+const MaxMigrationMappings = 100
+
+// PrefixMapping rewrites values starting with Source to start with Target instead.
+type PrefixMapping struct {
+	Source string
+	Target string
+}
+
+// MigrationMapping relates a migration's source site to its target site.
+//
+// URL mappings rewrite source URL prefixes to target URL prefixes, e.g. a renamed library.
+// The source site URL always maps to the target site URL, so only objects that moved within
+// the site need a mapping.
+//
+// Principal mappings rewrite principal identities: "@contoso.com" → "@fabrikam.com" moves users
+// to another domain, any other entry maps one user or group exactly, e.g. "finance owners" →
+// "finance-new owners".
+type MigrationMapping struct {
+	URLs       []PrefixMapping
+	Principals []PrefixMapping
+}
+
+// MigrationMapper maps source URLs and principal identities to the target site's.
+type MigrationMapper struct {
+	urls       []PrefixMapping // Normalized, longest source first
+	domains    map[string]string
+	principals map[string]string
+}
+
+// NewMigrationMapper validates a mapping and builds the mapper from sourceSiteURL to targetSiteURL.
+func NewMigrationMapper(sourceSiteURL, targetSiteURL string, mapping MigrationMapping) (*MigrationMapper, error) {
+	if len(mapping.URLs) > MaxMigrationMappings || len(mapping.Principals) > MaxMigrationMappings {
+		return nil, fmt.Errorf("at most %d URL and %d principal mappings are allowed", MaxMigrationMappings, MaxMigrationMappings)
+	}
+
+	m := &MigrationMapper{domains: map[string]string{}, principals: map[string]string{}}
+	for _, prefix := range mapping.URLs {
+		for _, value := range []string{prefix.Source, prefix.Target} {
+			if err := validateMappedURL(value); err != nil {
+				return nil, err
+			}
+		}
+		m.urls = append(m.urls, PrefixMapping{Source: NormalizeMigrationURL(prefix.Source), Target: NormalizeMigrationURL(prefix.Target)})
+	}
+	m.urls = append(m.urls, PrefixMapping{Source: NormalizeMigrationURL(sourceSiteURL), Target: NormalizeMigrationURL(targetSiteURL)})
+	sort.SliceStable(m.urls, func(i, j int) bool { return len(m.urls[i].Source) > len(m.urls[j].Source) })
+
+	for _, principal := range mapping.Principals {
+		source, target := normalizeIdentity(principal.Source), normalizeIdentity(principal.Target)
+		if source == "" || target == "" {
+			return nil, fmt.Errorf("principal mapping %q → %q must name both a source and a target", principal.Source, principal.Target)
+		}
+		sourceDomain, sourceIsDomain := strings.CutPrefix(source, "@")
+		targetDomain, targetIsDomain := strings.CutPrefix(target, "@")
+		switch {
+		case sourceIsDomain && targetIsDomain:
+			m.domains[sourceDomain] = targetDomain
+		case sourceIsDomain || targetIsDomain:
+			return nil, fmt.Errorf("principal mapping %q → %q must map a domain to a domain", principal.Source, principal.Target)
+		default:
+			m.principals[source] = target
+		}
+	}
+	return m, nil
+}
+
+// MapURL maps a source URL to the URL the object should have on the target site. It returns false
+// for URLs no mapping covers, such as content outside the source site.
+func (m *MigrationMapper) MapURL(sourceURL string) (string, bool) {
+	normalized := NormalizeMigrationURL(sourceURL)
+	for _, prefix := range m.urls {
+		if rest, ok := strings.CutPrefix(normalized, prefix.Source); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+			return prefix.Target + rest, true
+		}
+	}
+	return "", false
+}
+
+// MapPrincipal maps a source principal identity, as returned by PrincipalIdentity, to its target identity.
+// An exact mapping wins over a domain mapping; unmapped identities are expected unchanged on the target.
+func (m *MigrationMapper) MapPrincipal(identity string) string {
+	if target, ok := m.principals[identity]; ok {
+		return target
+	}
+	if user, domain, ok := strings.Cut(identity, "@"); ok {
+		if target, ok := m.domains[domain]; ok {
+			return user + "@" + target
+		}
+	}
+	return identity
+}
+
+// NormalizeMigrationURL lowercases a URL and drops its trailing slash, as SharePoint URLs are case-insensitive.
+func NormalizeMigrationURL(value string) string {
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		value = unescaped
+	}
+	return strings.TrimRight(strings.ToLower(strings.TrimSpace(value)), "/")
+}
+
+// PrincipalIdentity identifies a principal across sites, where principal IDs differ: the account of a
+// claims login name such as "i:0#.f|membership|user@contoso.com", else the login name or title.
+func PrincipalIdentity(loginName, title string) string {
+	if loginName == "" {
+		return normalizeIdentity(title)
+	}
+	if i := strings.LastIndex(loginName, "|"); i >= 0 {
+		loginName = loginName[i+1:]
+	}
+	return normalizeIdentity(loginName)
+}
+
+// normalizeIdentity trims and lowercases a principal identity.
+func normalizeIdentity(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// validateMappedURL checks that a URL mapping names an absolute http(s) URL.
+func validateMappedURL(value string) error {
+	parsed, err := url.Parse(strings.TrimSpace(value))
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("URL mapping %q must be an absolute http(s) URL", value)
+	}
+	return nil
+}
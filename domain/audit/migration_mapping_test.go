@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationMapper_MapURL(t *testing.T) {
+	mapper, err := NewMigrationMapper("https://contoso.sharepoint.com/sites/Finance/", "https://fabrikam.sharepoint.com/sites/finance-new", MigrationMapping{
+		URLs: []PrefixMapping{{Source: "https://contoso.sharepoint.com/sites/finance/Shared Documents", Target: "https://fabrikam.sharepoint.com/sites/finance-new/Documents"}},
+	})
+	require.NoError(t, err)
+
+	for source, want := range map[string]string{
+		"https://contoso.sharepoint.com/sites/finance":                                "https://fabrikam.sharepoint.com/sites/finance-new",
+		"https://contoso.sharepoint.com/sites/Finance/Lists/Tasks":                    "https://fabrikam.sharepoint.com/sites/finance-new/lists/tasks",
+		"https://contoso.sharepoint.com/sites/finance/Shared%20Documents/Q1.xlsx":     "https://fabrikam.sharepoint.com/sites/finance-new/documents/q1.xlsx",
+		"https://contoso.sharepoint.com/sites/finance/Shared Documents Archive/a.txt": "https://fabrikam.sharepoint.com/sites/finance-new/shared documents archive/a.txt",
+	} {
+		got, ok := mapper.MapURL(source)
+		assert.True(t, ok, source)
+		assert.Equal(t, want, got, source)
+	}
+
+	for _, source := range []string{"https://contoso.sharepoint.com/sites/finance2/doc.docx", "https://contoso.sharepoint.com/sites/hr", ""} {
+		_, ok := mapper.MapURL(source)
+		assert.False(t, ok, source)
+	}
+}
+
+func TestMigrationMapper_MapPrincipal(t *testing.T) {
+	mapper, err := NewMigrationMapper("https://a/sites/x", "https://b/sites/x", MigrationMapping{
+		Principals: []PrefixMapping{
+			{Source: "@Contoso.com", Target: "@fabrikam.com"},
+			{Source: "ceo@contoso.com", Target: "chief@fabrikam.com"},
+			{Source: "Finance Owners", Target: "Finance-New Owners"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "user@fabrikam.com", mapper.MapPrincipal(PrincipalIdentity("i:0#.f|membership|User@contoso.com", "User")))
+	assert.Equal(t, "chief@fabrikam.com", mapper.MapPrincipal("ceo@contoso.com"))
+	assert.Equal(t, "finance-new owners", mapper.MapPrincipal(PrincipalIdentity("Finance Owners", "Finance Owners")))
+	assert.Equal(t, "user@other.com", mapper.MapPrincipal("user@other.com"))
+	assert.Equal(t, "everyone", PrincipalIdentity("", "Everyone"))
+}
+
+func TestNewMigrationMapper_Invalid(t *testing.T) {
+	for name, mapping := range map[string]MigrationMapping{
+		"relative url":       {URLs: []PrefixMapping{{Source: "/sites/x", Target: "https://b/sites/x"}}},
+		"missing target url": {URLs: []PrefixMapping{{Source: "https://a/sites/x"}}},
+		"empty principal":    {Principals: []PrefixMapping{{Source: "user@contoso.com"}}},
+		"domain to user":     {Principals: []PrefixMapping{{Source: "@contoso.com", Target: "user@fabrikam.com"}}},
+	} {
+		_, err := NewMigrationMapper("https://a/sites/x", "https://b/sites/x", mapping)
+		assert.Error(t, err, name)
+	}
+}
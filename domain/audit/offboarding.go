@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"fmt"
+	"time"
+)
+
+// What an offboarding check finds a leaver still has.
+const (
+	OffboardingAccess      = "access"       // A role, or a sharing link membership, reaching the leaver
+	OffboardingLinkCreated = "link_created" // An active sharing link the leaver created
+	OffboardingItemOwned   = "item_owned"   // An item whose sensitivity label names the leaver its owner
+)
+
+// Remediation actions an offboarding check queues. spaudit changes nothing in SharePoint; an
+// administrator carries each action out and records its outcome.
+const (
+	RemediationRemoveAssignment  = "remove_assignment"   // Remove a role assigned to the leaver's account
+	RemediationRemoveGroupMember = "remove_group_member" // Remove the leaver's account from a SharePoint group
+	RemediationRemoveLinkMember  = "remove_link_member"  // Remove the leaver's account from a sharing link
+	RemediationRevokeLink        = "revoke_link"         // Delete a sharing link the leaver created
+)
+
+// Statuses of a remediation action.
+const (
+	RemediationQueued    = "queued"
+	RemediationCompleted = "completed" // Carried out in SharePoint
+	RemediationDismissed = "dismissed" // Left as it is, e.g. access handed over to a successor
+)
+
+// OffboardingCheck is a check of what a leaver still has across the latest runs of every audited
+// site, run as a job. The counts are set once the check completes.
+type OffboardingCheck struct {
+	ID           int64
+	JobID        string
+	Identity     string // As PrincipalIdentity normalizes it
+	Remediate    bool   // Queue remediation actions for what is found
+	StartedAt    time.Time
+	CompletedAt  *time.Time
+	Access       int
+	LinksCreated int
+	ItemsOwned   int
+	Remediations int
+	Error        string
+}
+
+// OffboardingReport is what an offboarding check found, kept with the check for download.
+type OffboardingReport struct {
+	Identity     string                    `json:"identity"`
+	GeneratedAt  time.Time                 `json:"generated_at"`
+	SitesScanned int                       `json:"sites_scanned"`
+	Findings     []*OffboardingFinding     `json:"findings"` // By site URL, kind and object URL
+	Skipped      []*OffboardingSkippedSite `json:"skipped"`
+}
+
+// OffboardingFinding is one thing a leaver still has on a site.
+type OffboardingFinding struct {
+	Kind       string `json:"kind"`
+	SiteID     int64  `json:"site_id"`
+	SiteURL    string `json:"site_url"`
+	AuditRunID int64  `json:"audit_run_id"`
+	ObjectType string `json:"object_type"`
+	ObjectURL  string `json:"object_url"`
+	ObjectName string `json:"object_name"`
+	Role       string `json:"role,omitempty"`    // Role name, or the link kind of a sharing link
+	Through    string `json:"through,omitempty"` // How access reaches the leaver; access only
+	Via        string `json:"via,omitempty"`     // Group or sharing link ID the access comes through, the ID of a link created, or the sensitivity label of an owned item
+}
+
+// OffboardingSkippedSite is a site an offboarding check could not read, with the reason.
+type OffboardingSkippedSite struct {
+	SiteID  int64  `json:"site_id"`
+	SiteURL string `json:"site_url"`
+	Reason  string `json:"reason"`
+}
+
+// Count returns how many findings are of the kind.
+func (r *OffboardingReport) Count(kind string) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Kind == kind {
+			count++
+		}
+	}
+	return count
+}
+
+// OffboardingRemediation is an action queued by an offboarding check to take away something the
+// leaver still has.
+type OffboardingRemediation struct {
+	ID          int64
+	CheckID     int64
+	SiteID      int64
+	SiteURL     string
+	AuditRunID  int64
+	Action      string // RemediationRemoveAssignment, RemediationRemoveGroupMember, RemediationRemoveLinkMember or RemediationRevokeLink
+	PrincipalID int64  // The leaver's account
+	ObjectType  string // Of the role, or "item" for link actions; empty for group members
+	ObjectKey   string // Web or list ID, or item GUID
+	ObjectURL   string
+	GroupID     int64  // Group members only
+	LinkID      string // Link actions only
+	Detail      string // Role name, group title or link kind
+	Status      string
+	QueuedAt    time.Time
+	UpdatedAt   *time.Time
+}
+
+// ValidateRemediationStatus checks the outcome recorded for a remediation action.
+func ValidateRemediationStatus(status string) error {
+	switch status {
+	case RemediationQueued, RemediationCompleted, RemediationDismissed:
+		return nil
+	}
+	return fmt.Errorf("status must be %q, %q or %q, got: %q", RemediationQueued, RemediationCompleted, RemediationDismissed, status)
+}
@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffboardingReport_Count(t *testing.T) {
+	report := &OffboardingReport{Findings: []*OffboardingFinding{
+		{Kind: OffboardingAccess},
+		{Kind: OffboardingAccess},
+		{Kind: OffboardingLinkCreated},
+	}}
+
+	assert.Equal(t, 2, report.Count(OffboardingAccess))
+	assert.Equal(t, 1, report.Count(OffboardingLinkCreated))
+	assert.Zero(t, report.Count(OffboardingItemOwned))
+}
+
+func TestValidateRemediationStatus(t *testing.T) {
+	for _, status := range []string{RemediationQueued, RemediationCompleted, RemediationDismissed} {
+		assert.NoError(t, ValidateRemediationStatus(status))
+	}
+	assert.Error(t, ValidateRemediationStatus("done"))
+	assert.Error(t, ValidateRemediationStatus(""))
+}
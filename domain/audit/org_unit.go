@@ -0,0 +1,208 @@
+package audit
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// What an organizational unit mapping matches a site on. A site's own mapping wins over its hub's,
+// which wins over its latest run's label.
+const (
+	OrgUnitMatchSite  = "site"  // The site's URL
+	OrgUnitMatchHub   = "hub"   // The hub the site's latest run found it associated with, by hub site ID or URL
+	OrgUnitMatchLabel = "label" // The label the site's latest run was tagged with
+)
+
+// OrgUnitSourceHub is the source of a site grouped under its hub's title because no mapping matched it.
+const OrgUnitSourceHub = "hub_title"
+
+// Bounds of organizational unit mappings, which are imported from spreadsheets.
+const (
+	MaxOrgUnitLength        = 128
+	MaxOrgUnitMappingImport = 10000
+)
+
+// OrgUnitMapping assigns the sites matching a site URL, hub or run label to an organizational unit,
+// e.g. a department, for per-unit scorecards.
+type OrgUnitMapping struct {
+	ID         int64
+	MatchType  string // OrgUnitMatchSite, OrgUnitMatchHub or OrgUnitMatchLabel
+	MatchValue string // Normalized, see NewOrgUnitMapping
+	OrgUnit    string
+	UpdatedAt  time.Time
+}
+
+// NewOrgUnitMapping validates and normalizes a mapping: site URLs and hubs are lowercased without a
+// trailing slash, hub site IDs without braces, and labels as run labels are.
+func NewOrgUnitMapping(matchType, matchValue, orgUnit string) (*OrgUnitMapping, error) {
+	matchType = strings.ToLower(strings.TrimSpace(matchType))
+	orgUnit = strings.TrimSpace(orgUnit)
+	if orgUnit == "" {
+		return nil, errors.New("org_unit is required")
+	}
+	if len(orgUnit) > MaxOrgUnitLength {
+		return nil, fmt.Errorf("org_unit cannot exceed %d characters, got: %d", MaxOrgUnitLength, len(orgUnit))
+	}
+
+	switch matchType {
+	case OrgUnitMatchSite:
+		if err := ValidateSiteURL(matchValue); err != nil {
+			return nil, err
+		}
+		matchValue = normalizeOrgUnitURL(matchValue)
+	case OrgUnitMatchHub:
+		matchValue = normalizeOrgUnitHub(matchValue)
+		if matchValue == "" {
+			return nil, errors.New("hub mappings need a hub site ID or URL")
+		}
+	case OrgUnitMatchLabel:
+		matchValue = NormalizeRunLabel(matchValue)
+		if matchValue == "" {
+			return nil, errors.New("label mappings need a label")
+		}
+		if err := ValidateRunLabel(matchValue); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("match_type must be %q, %q or %q, got: %q", OrgUnitMatchSite, OrgUnitMatchHub, OrgUnitMatchLabel, matchType)
+	}
+	return &OrgUnitMapping{MatchType: matchType, MatchValue: matchValue, OrgUnit: orgUnit}, nil
+}
+
+// ParseOrgUnitMappingsCSV reads organizational unit mappings from CSV with a header row naming its
+// columns: match_type, match_value and org_unit, in any order. A sheet of site_url and org_unit
+// columns maps sites. Blank rows are skipped; any invalid row fails the whole import, naming its line.
+func ParseOrgUnitMappingsCSV(r io.Reader) ([]*OrgUnitMapping, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("CSV is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read CSV header: %w", err)
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i // Excel writes a BOM
+	}
+	typeColumn, hasType := columns["match_type"]
+	valueColumn, hasValue := columns["match_value"]
+	if !hasType {
+		valueColumn, hasValue = columns["site_url"]
+	}
+	unitColumn, hasUnit := columns["org_unit"]
+	if !hasValue || !hasUnit {
+		return nil, errors.New("CSV header must name match_type, match_value and org_unit columns, or site_url and org_unit")
+	}
+
+	var mappings []*OrgUnitMapping
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(mappings) == MaxOrgUnitMappingImport {
+			return nil, fmt.Errorf("at most %d mappings can be imported at once", MaxOrgUnitMappingImport)
+		}
+
+		matchType := OrgUnitMatchSite
+		if hasType {
+			matchType = csvField(record, typeColumn)
+		}
+		mapping, err := NewOrgUnitMapping(matchType, csvField(record, valueColumn), csvField(record, unitColumn))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, nil
+}
+
+// OrgUnitResolver assigns sites to organizational units by the mappings.
+type OrgUnitResolver struct {
+	byType map[string]map[string]string // Match type -> normalized value -> org unit
+}
+
+// NewOrgUnitResolver builds a resolver from normalized mappings.
+func NewOrgUnitResolver(mappings []*OrgUnitMapping) *OrgUnitResolver {
+	r := &OrgUnitResolver{byType: map[string]map[string]string{
+		OrgUnitMatchSite:  {},
+		OrgUnitMatchHub:   {},
+		OrgUnitMatchLabel: {},
+	}}
+	for _, mapping := range mappings {
+		if values, ok := r.byType[mapping.MatchType]; ok {
+			values[mapping.MatchValue] = mapping.OrgUnit
+		}
+	}
+	return r
+}
+
+// Resolve returns the organizational unit of a site and the match type that assigned it: the site's
+// own mapping, else its hub's by site ID or URL, else its latest run label's. It returns empty
+// strings when nothing matches. hubSiteID, hubURL and label may be empty.
+func (r *OrgUnitResolver) Resolve(siteURL, hubSiteID, hubURL, label string) (string, string) {
+	if unit, ok := r.byType[OrgUnitMatchSite][normalizeOrgUnitURL(siteURL)]; ok {
+		return unit, OrgUnitMatchSite
+	}
+	for _, hub := range []string{hubSiteID, hubURL} {
+		if hub = normalizeOrgUnitHub(hub); hub == "" {
+			continue
+		}
+		if unit, ok := r.byType[OrgUnitMatchHub][hub]; ok {
+			return unit, OrgUnitMatchHub
+		}
+	}
+	if label = NormalizeRunLabel(label); label != "" {
+		if unit, ok := r.byType[OrgUnitMatchLabel][label]; ok {
+			return unit, OrgUnitMatchLabel
+		}
+	}
+	return "", ""
+}
+
+// normalizeOrgUnitURL lowercases a URL and drops its trailing slash
+func normalizeOrgUnitURL(value string) string {
+	return strings.ToLower(strings.TrimRight(strings.TrimSpace(value), "/"))
+}
+
+// normalizeOrgUnitHub normalizes a hub site ID or URL
+func normalizeOrgUnitHub(value string) string {
+	value = normalizeOrgUnitURL(value)
+	if !strings.Contains(value, "/") {
+		value = strings.Trim(value, "{}")
+	}
+	return value
+}
+
+// csvField returns a trimmed field of a record, empty when the record is short
+func csvField(record []string, column int) string {
+	if column >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[column])
+}
+
+// isBlankRecord returns true if every field of a record is blank
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOrgUnitMapping_Normalizes(t *testing.T) {
+	site, err := NewOrgUnitMapping(" Site ", "https://Contoso.sharepoint.com/sites/Finance/", " Finance ")
+	require.NoError(t, err)
+	assert.Equal(t, OrgUnitMatchSite, site.MatchType)
+	assert.Equal(t, "https://contoso.sharepoint.com/sites/finance", site.MatchValue)
+	assert.Equal(t, "Finance", site.OrgUnit)
+
+	hub, err := NewOrgUnitMapping("hub", "{ABC-123}", "Finance")
+	require.NoError(t, err)
+	assert.Equal(t, "abc-123", hub.MatchValue)
+
+	label, err := NewOrgUnitMapping("label", " Prod-Tenant ", "IT")
+	require.NoError(t, err)
+	assert.Equal(t, "prod-tenant", label.MatchValue)
+}
+
+func TestNewOrgUnitMapping_Rejects(t *testing.T) {
+	for _, tc := range []struct{ matchType, matchValue, orgUnit string }{
+		{"site", "not a url", "Finance"},
+		{"hub", " ", "Finance"},
+		{"label", "bad label!", "Finance"},
+		{"tag", "finance", "Finance"},
+		{"site", "https://contoso.sharepoint.com/sites/a", " "},
+		{"site", "https://contoso.sharepoint.com/sites/a", strings.Repeat("x", MaxOrgUnitLength+1)},
+	} {
+		_, err := NewOrgUnitMapping(tc.matchType, tc.matchValue, tc.orgUnit)
+		assert.Error(t, err, tc)
+	}
+}
+
+func TestParseOrgUnitMappingsCSV(t *testing.T) {
+	mappings, err := ParseOrgUnitMappingsCSV(strings.NewReader("\ufeffOrg_Unit,Match_Type,Match_Value\n" +
+		"Finance,hub,{HUB-1}\n" +
+		",,\n" +
+		"HR,site,https://contoso.sharepoint.com/sites/hr\n"))
+	require.NoError(t, err)
+	require.Len(t, mappings, 2)
+	assert.Equal(t, OrgUnitMapping{MatchType: OrgUnitMatchHub, MatchValue: "hub-1", OrgUnit: "Finance"}, *mappings[0])
+	assert.Equal(t, "HR", mappings[1].OrgUnit)
+
+	sites, err := ParseOrgUnitMappingsCSV(strings.NewReader("site_url,org_unit\nhttps://contoso.sharepoint.com/sites/legal,Legal\n"))
+	require.NoError(t, err)
+	require.Len(t, sites, 1)
+	assert.Equal(t, OrgUnitMatchSite, sites[0].MatchType)
+}
+
+func TestParseOrgUnitMappingsCSV_Errors(t *testing.T) {
+	_, err := ParseOrgUnitMappingsCSV(strings.NewReader(""))
+	assert.Error(t, err)
+
+	_, err = ParseOrgUnitMappingsCSV(strings.NewReader("match_type,org_unit\nsite,Finance\n"))
+	assert.ErrorContains(t, err, "header")
+
+	_, err = ParseOrgUnitMappingsCSV(strings.NewReader("match_type,match_value,org_unit\nsite,https://contoso.sharepoint.com/sites/a,A\ntag,x,B\n"))
+	assert.ErrorContains(t, err, "line 3")
+}
+
+func TestOrgUnitResolver_Resolve(t *testing.T) {
+	mapping := func(matchType, matchValue, orgUnit string) *OrgUnitMapping {
+		m, err := NewOrgUnitMapping(matchType, matchValue, orgUnit)
+		require.NoError(t, err)
+		return m
+	}
+	resolver := NewOrgUnitResolver([]*OrgUnitMapping{
+		mapping("site", "https://contoso.sharepoint.com/sites/payroll", "HR"),
+		mapping("hub", "hub-1", "Finance"),
+		mapping("hub", "https://contoso.sharepoint.com/sites/legal", "Legal"),
+		mapping("label", "emea", "EMEA"),
+	})
+
+	for _, tc := range []struct {
+		siteURL, hubSiteID, hubURL, label string
+		wantUnit, wantSource              string
+	}{
+		{"https://contoso.sharepoint.com/sites/Payroll/", "hub-1", "", "emea", "HR", OrgUnitMatchSite},
+		{"https://contoso.sharepoint.com/sites/budget", "{HUB-1}", "", "emea", "Finance", OrgUnitMatchHub},
+		{"https://contoso.sharepoint.com/sites/contracts", "hub-2", "https://contoso.sharepoint.com/sites/legal/", "", "Legal", OrgUnitMatchHub},
+		{"https://contoso.sharepoint.com/sites/sales", "", "", "EMEA", "EMEA", OrgUnitMatchLabel},
+		{"https://contoso.sharepoint.com/sites/other", "hub-9", "", "apac", "", ""},
+	} {
+		unit, source := resolver.Resolve(tc.siteURL, tc.hubSiteID, tc.hubURL, tc.label)
+		assert.Equal(t, tc.wantUnit, unit, tc.siteURL)
+		assert.Equal(t, tc.wantSource, source, tc.siteURL)
+	}
+}
@@ -0,0 +1,24 @@
+package audit
+
+// ListViewThreshold is the number of items SharePoint will scan for a query on an unindexed
+// column before refusing it with a throttling error.
+const ListViewThreshold = 5000
+
+// ItemIDRange is a half-open range of list item IDs, From inclusive and To exclusive.
+type ItemIDRange struct {
+	From int
+	To   int
+}
+
+// PartitionItemIDs splits the item IDs after afterID up to and including maxID into ranges of at most
+// size IDs. Filtering on the always-indexed ID column keeps each partition under the list view threshold.
+func PartitionItemIDs(afterID, maxID, size int) []ItemIDRange {
+	if size <= 0 {
+		size = ListViewThreshold
+	}
+	var ranges []ItemIDRange
+	for from := afterID + 1; from <= maxID; from += size {
+		ranges = append(ranges, ItemIDRange{From: from, To: min(from+size, maxID+1)})
+	}
+	return ranges
+}
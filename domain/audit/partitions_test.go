@@ -0,0 +1,19 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPartitionItemIDs(t *testing.T) {
+	assert.Equal(t, []ItemIDRange{{From: 1, To: 5001}, {From: 5001, To: 10001}, {From: 10001, To: 12001}},
+		PartitionItemIDs(0, 12000, 5000))
+
+	// Resuming after the items already walked
+	assert.Equal(t, []ItemIDRange{{From: 4201, To: 9201}, {From: 9201, To: 9501}},
+		PartitionItemIDs(4200, 9500, 5000))
+
+	assert.Empty(t, PartitionItemIDs(9500, 9500, 5000), "nothing left to walk")
+	assert.Equal(t, []ItemIDRange{{From: 1, To: 11}}, PartitionItemIDs(0, 10, 0), "defaults to the threshold")
+}
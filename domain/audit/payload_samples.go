@@ -0,0 +1,68 @@
+package audit
+
+import "time"
+
+// PayloadSampleLimits controls which raw SharePoint API payloads a run keeps for debugging parsing
+// issues. Sampling is off unless Enabled.
+type PayloadSampleLimits struct {
+	Enabled         bool
+	PerSource       int   // Payloads kept per source, e.g. the first 20 list items
+	MaxPayloadBytes int   // Longer payloads are cut to this size
+	MaxRunBytes     int64 // Nothing more is kept once a run's payloads reach this size
+}
+
+// DefaultPayloadSampleLimits returns the limits used when sampling is enabled without overrides.
+func DefaultPayloadSampleLimits() PayloadSampleLimits {
+	return PayloadSampleLimits{
+		PerSource:       20,
+		MaxPayloadBytes: 64 << 10,
+		MaxRunBytes:     4 << 20,
+	}
+}
+
+// PayloadSample is a sanitized raw API payload kept from an audit run
+type PayloadSample struct {
+	Source      string // Response object, as in SchemaDrift
+	Key         string // Item or file GUID the payload describes
+	Payload     []byte // Sanitized JSON; cut short, and no longer valid JSON, when Truncated
+	Truncated   bool
+	SchemaDrift bool // The payload did not match the expected schema
+	CapturedAt  time.Time
+}
+
+// PayloadSampleBudget decides which payloads of a run are kept within its limits.
+// Not safe for concurrent use.
+type PayloadSampleBudget struct {
+	limits    PayloadSampleLimits
+	perSource map[string]int
+	runBytes  int64
+}
+
+// NewPayloadSampleBudget creates a budget for one run.
+func NewPayloadSampleBudget(limits PayloadSampleLimits) *PayloadSampleBudget {
+	return &PayloadSampleBudget{limits: limits, perSource: make(map[string]int)}
+}
+
+// Admit returns how many bytes of a payload of the given size to keep, 0 to skip it. Payloads
+// with schema drift are kept beyond the per-source limit while the run has room, since they are
+// the ones worth debugging.
+func (b *PayloadSampleBudget) Admit(source string, size int, schemaDrift bool) int {
+	if !b.limits.Enabled || size <= 0 {
+		return 0
+	}
+	if b.perSource[source] >= b.limits.PerSource && !schemaDrift {
+		return 0
+	}
+
+	keep := size
+	if b.limits.MaxPayloadBytes > 0 {
+		keep = min(keep, b.limits.MaxPayloadBytes)
+	}
+	if remaining := b.limits.MaxRunBytes - b.runBytes; int64(keep) > remaining {
+		return 0
+	}
+
+	b.perSource[source]++
+	b.runBytes += int64(keep)
+	return keep
+}
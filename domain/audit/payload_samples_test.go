@@ -0,0 +1,24 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadSampleBudget_Admit(t *testing.T) {
+	budget := NewPayloadSampleBudget(PayloadSampleLimits{Enabled: true, PerSource: 2, MaxPayloadBytes: 100, MaxRunBytes: 350})
+
+	assert.Equal(t, 40, budget.Admit("list_item", 40, false))
+	assert.Equal(t, 100, budget.Admit("list_item", 500, false), "long payloads are cut")
+	assert.Zero(t, budget.Admit("list_item", 40, false), "per-source limit reached")
+	assert.Equal(t, 40, budget.Admit("list_item", 40, true), "drifted payloads pass the per-source limit")
+	assert.Equal(t, 100, budget.Admit("sharing_information", 100, false))
+	assert.Zero(t, budget.Admit("sharing_information", 100, false), "run limit reached")
+	assert.Equal(t, 70, budget.Admit("sharing_information", 70, false), "a smaller payload still fits")
+}
+
+func TestPayloadSampleBudget_Disabled(t *testing.T) {
+	budget := NewPayloadSampleBudget(DefaultPayloadSampleLimits())
+	assert.Zero(t, budget.Admit("list_item", 10, true))
+}
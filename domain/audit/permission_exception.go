@@ -0,0 +1,178 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// What a permission exception accepts.
+const (
+	ExceptionSharingLink = "sharing_link" // A sharing link, and the principals added to it
+	ExceptionAssignment  = "assignment"   // A principal's role on the list or on one of its items
+	ExceptionUniqueItem  = "unique_item"  // An item's unique permissions, and the roles granted on it
+)
+
+// Bounds of permission exceptions, which must be reviewed again before they lapse.
+const (
+	MaxExceptionJustificationLength = 1000
+	MaxExceptionOwnerLength         = 320 // The longest email address
+	MaxExceptionLifetime            = 366 * 24 * time.Hour
+)
+
+// ExceptionReviewWindow is how long before expiry exceptions join the review queue and their owners
+// are reminded.
+const ExceptionReviewWindow = 30 * 24 * time.Hour
+
+// PermissionException accepts a sharing link, assignment or item with unique permissions of a list
+// as known and intended. Until it expires, what it accepts raises no findings and is left out of
+// the list's risk score.
+type PermissionException struct {
+	ID            int64
+	SiteID        int64
+	ListID        string
+	Kind          string // ExceptionSharingLink, ExceptionAssignment or ExceptionUniqueItem
+	ObjectKey     string // Link ID, item GUID, or for assignments the list ID or item GUID the role is on
+	PrincipalID   int64  // Assignments only
+	RoleDefID     int64  // Assignments only
+	Justification string
+	Owner         string // Who answers for the exception, e.g. an email address; optional
+	ExpiresAt     time.Time
+	CreatedAt     time.Time // When the exception was accepted or last renewed
+	// When the owner was reminded the exception nears expiry; nil until then, and again once renewed
+	ReviewNotifiedAt *time.Time
+}
+
+// ExceptionReview is an exception in the review queue, with the URL of its site.
+type ExceptionReview struct {
+	Exception *PermissionException
+	SiteURL   string
+}
+
+// Validate checks that an exception names what it accepts, is justified, and expires after now
+// but within MaxExceptionLifetime of it.
+func (e *PermissionException) Validate(now time.Time) error {
+	if strings.TrimSpace(e.ListID) == "" {
+		return errors.New("list_id is required")
+	}
+	if strings.TrimSpace(e.ObjectKey) == "" {
+		return errors.New("object_key is required")
+	}
+	switch e.Kind {
+	case ExceptionAssignment:
+		if e.PrincipalID <= 0 || e.RoleDefID <= 0 {
+			return errors.New("assignment exceptions need a principal_id and role_def_id")
+		}
+	case ExceptionSharingLink, ExceptionUniqueItem:
+		if e.PrincipalID != 0 || e.RoleDefID != 0 {
+			return fmt.Errorf("%s exceptions take no principal_id or role_def_id", e.Kind)
+		}
+	default:
+		return fmt.Errorf("kind must be %q, %q or %q, got: %q", ExceptionSharingLink, ExceptionAssignment, ExceptionUniqueItem, e.Kind)
+	}
+
+	justification := strings.TrimSpace(e.Justification)
+	if justification == "" {
+		return errors.New("justification is required")
+	}
+	if len(justification) > MaxExceptionJustificationLength {
+		return fmt.Errorf("justification cannot exceed %d characters, got: %d", MaxExceptionJustificationLength, len(justification))
+	}
+	if len(strings.TrimSpace(e.Owner)) > MaxExceptionOwnerLength {
+		return fmt.Errorf("owner cannot exceed %d characters", MaxExceptionOwnerLength)
+	}
+	if !e.ExpiresAt.After(now) {
+		return errors.New("expires_at must be in the future")
+	}
+	if e.ExpiresAt.Sub(now) > MaxExceptionLifetime {
+		return fmt.Errorf("expires_at cannot be more than %d days away", int(MaxExceptionLifetime/(24*time.Hour)))
+	}
+	return nil
+}
+
+// Active returns true if the exception has not expired by now.
+func (e *PermissionException) Active(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// RenewedExpiry returns when the exception expires if renewed at now: as long after now as it was
+// last accepted or renewed for, at most MaxExceptionLifetime.
+func (e *PermissionException) RenewedExpiry(now time.Time) time.Time {
+	lifetime := e.ExpiresAt.Sub(e.CreatedAt)
+	if lifetime <= 0 || lifetime > MaxExceptionLifetime {
+		lifetime = MaxExceptionLifetime
+	}
+	return now.Add(lifetime)
+}
+
+// PermissionExceptionSet answers which sharing links, assignments and items the exceptions active
+// at a point in time accept. IDs and GUIDs match regardless of case and braces. A nil set accepts
+// nothing.
+type PermissionExceptionSet struct {
+	byList map[string][]*PermissionException
+	count  int
+}
+
+// NewPermissionExceptionSet builds a set of the exceptions active at now.
+func NewPermissionExceptionSet(exceptions []*PermissionException, now time.Time) *PermissionExceptionSet {
+	s := &PermissionExceptionSet{byList: map[string][]*PermissionException{}}
+	for _, exception := range exceptions {
+		if !exception.Active(now) {
+			continue
+		}
+		listID := normalizeExceptionKey(exception.ListID)
+		s.byList[listID] = append(s.byList[listID], exception)
+		s.count++
+	}
+	return s
+}
+
+// Len returns the number of active exceptions.
+func (s *PermissionExceptionSet) Len() int {
+	if s == nil {
+		return 0
+	}
+	return s.count
+}
+
+// ForList returns the active exceptions of a list.
+func (s *PermissionExceptionSet) ForList(listID string) []*PermissionException {
+	if s == nil {
+		return nil
+	}
+	return s.byList[normalizeExceptionKey(listID)]
+}
+
+// CoversSharingLink returns true if a sharing link of a list is accepted.
+func (s *PermissionExceptionSet) CoversSharingLink(listID, linkID string) bool {
+	return s.covers(listID, ExceptionSharingLink, linkID, 0, 0)
+}
+
+// CoversUniqueItem returns true if the unique permissions of an item of a list are accepted.
+func (s *PermissionExceptionSet) CoversUniqueItem(listID, itemGUID string) bool {
+	return s.covers(listID, ExceptionUniqueItem, itemGUID, 0, 0)
+}
+
+// CoversAssignment returns true if a principal's role on a list, or on an item of it, is accepted,
+// by its own exception or by one accepting the item's unique permissions.
+func (s *PermissionExceptionSet) CoversAssignment(listID, objectKey string, principalID, roleDefID int64) bool {
+	return s.covers(listID, ExceptionAssignment, objectKey, principalID, roleDefID) || s.CoversUniqueItem(listID, objectKey)
+}
+
+// covers returns true if an active exception of a list accepts an object
+func (s *PermissionExceptionSet) covers(listID, kind, objectKey string, principalID, roleDefID int64) bool {
+	objectKey = normalizeExceptionKey(objectKey)
+	for _, exception := range s.ForList(listID) {
+		if exception.Kind == kind && normalizeExceptionKey(exception.ObjectKey) == objectKey &&
+			exception.PrincipalID == principalID && exception.RoleDefID == roleDefID {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeExceptionKey lowercases an ID or GUID and drops its braces
+func normalizeExceptionKey(key string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(key), "{}"))
+}
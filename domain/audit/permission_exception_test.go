@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionException_Validate(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	valid := func() *PermissionException {
+		return &PermissionException{
+			ListID:        "list-1",
+			Kind:          ExceptionAssignment,
+			ObjectKey:     "list-1",
+			PrincipalID:   7,
+			RoleDefID:     1073741829,
+			Justification: "Finance auditors need full control during year end",
+			ExpiresAt:     now.Add(30 * 24 * time.Hour),
+		}
+	}
+	assert.NoError(t, valid().Validate(now))
+
+	for name, mutate := range map[string]func(e *PermissionException){
+		"no list":              func(e *PermissionException) { e.ListID = " " },
+		"no object":            func(e *PermissionException) { e.ObjectKey = "" },
+		"unknown kind":         func(e *PermissionException) { e.Kind = "folder" },
+		"assignment principal": func(e *PermissionException) { e.PrincipalID = 0 },
+		"link with principal":  func(e *PermissionException) { e.Kind = ExceptionSharingLink },
+		"no justification":     func(e *PermissionException) { e.Justification = "  " },
+		"long justification":   func(e *PermissionException) { e.Justification = strings.Repeat("x", MaxExceptionJustificationLength+1) },
+		"long owner":           func(e *PermissionException) { e.Owner = strings.Repeat("x", MaxExceptionOwnerLength+1) },
+		"expired":              func(e *PermissionException) { e.ExpiresAt = now },
+		"too long":             func(e *PermissionException) { e.ExpiresAt = now.Add(MaxExceptionLifetime + time.Hour) },
+	} {
+		exception := valid()
+		mutate(exception)
+		assert.Error(t, exception.Validate(now), name)
+	}
+}
+
+func TestPermissionException_RenewedExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	accepted := now.Add(-80 * 24 * time.Hour)
+
+	// Renewed for as long as it was last accepted for
+	exception := &PermissionException{CreatedAt: accepted, ExpiresAt: accepted.Add(90 * 24 * time.Hour)}
+	assert.Equal(t, now.Add(90*24*time.Hour), exception.RenewedExpiry(now))
+
+	// Never beyond the longest lifetime
+	exception = &PermissionException{CreatedAt: accepted, ExpiresAt: accepted.Add(2 * MaxExceptionLifetime)}
+	assert.Equal(t, now.Add(MaxExceptionLifetime), exception.RenewedExpiry(now))
+}
+
+func TestPermissionExceptionSet_Covers(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	later := now.Add(time.Hour)
+	set := NewPermissionExceptionSet([]*PermissionException{
+		{ListID: "{LIST-1}", Kind: ExceptionSharingLink, ObjectKey: "LINK-A", ExpiresAt: later},
+		{ListID: "list-1", Kind: ExceptionUniqueItem, ObjectKey: "item-1", ExpiresAt: later},
+		{ListID: "list-1", Kind: ExceptionAssignment, ObjectKey: "list-1", PrincipalID: 7, RoleDefID: 3, ExpiresAt: later},
+		{ListID: "list-1", Kind: ExceptionSharingLink, ObjectKey: "link-expired", ExpiresAt: now},
+	}, now)
+
+	assert.Equal(t, 3, set.Len())
+	assert.Len(t, set.ForList("LIST-1"), 3)
+	assert.True(t, set.CoversSharingLink("list-1", "link-a"))
+	assert.False(t, set.CoversSharingLink("list-2", "link-a"), "exceptions are per list")
+	assert.False(t, set.CoversSharingLink("list-1", "link-expired"))
+	assert.True(t, set.CoversUniqueItem("list-1", "{ITEM-1}"))
+	assert.True(t, set.CoversAssignment("list-1", "list-1", 7, 3))
+	assert.False(t, set.CoversAssignment("list-1", "list-1", 7, 5))
+	assert.True(t, set.CoversAssignment("list-1", "item-1", 9, 5), "an accepted item's roles are accepted")
+
+	var none *PermissionExceptionSet
+	assert.Equal(t, 0, none.Len())
+	assert.False(t, none.CoversSharingLink("list-1", "link-a"))
+}
@@ -0,0 +1,11 @@
+package audit
+
+import "time"
+
+// SitePolicyPack is the policy pack an administrator chose for a site, checked with in place of the
+// one the site's template selects.
+type SitePolicyPack struct {
+	SiteID int64
+	Pack   string
+	SetAt  time.Time
+}
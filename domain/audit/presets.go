@@ -0,0 +1,45 @@
+package audit
+
+import "fmt"
+
+// Audit presets offered when starting an audit without picking individual options.
+const (
+	PresetQuick    = "quick"
+	PresetStandard = "standard"
+	PresetThorough = "thorough"
+)
+
+// AuditPreset is a named set of audit parameters.
+type AuditPreset struct {
+	Name        string
+	Title       string
+	Description string
+}
+
+// AuditPresets returns the presets in the order they are offered, lightest first.
+func AuditPresets() []AuditPreset {
+	return []AuditPreset{
+		{Name: PresetQuick, Title: "Quick", Description: "Site, web and list permissions only. Fastest, but misses item-level permissions and sharing links."},
+		{Name: PresetStandard, Title: "Standard", Description: "Scans every item for unique permissions and collects sharing links. Recommended for a first audit."},
+		{Name: PresetThorough, Title: "Thorough", Description: "Standard, plus hidden lists and items and link usage analytics. Slowest."},
+	}
+}
+
+// PresetParameters returns the audit parameters of a preset.
+func PresetParameters(name string) (*AuditParameters, error) {
+	parameters := DefaultParameters()
+	switch name {
+	case PresetQuick:
+		parameters.ScanIndividualItems = false
+		parameters.IncludeSharing = false
+	case PresetStandard:
+	case PresetThorough:
+		parameters.SkipHidden = false
+		parameters.CollectAnalytics = true
+		parameters.ScanRecycleBin = true
+		parameters.AuditGraphGrants = true
+	default:
+		return nil, fmt.Errorf("unknown audit preset: %q", name)
+	}
+	return parameters, nil
+}
@@ -0,0 +1,32 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresetParameters(t *testing.T) {
+	for _, preset := range AuditPresets() {
+		_, err := PresetParameters(preset.Name)
+		assert.NoError(t, err, preset.Name)
+	}
+
+	standard, err := PresetParameters(PresetStandard)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultParameters(), standard)
+
+	quick, err := PresetParameters(PresetQuick)
+	require.NoError(t, err)
+	assert.False(t, quick.ScanIndividualItems)
+	assert.False(t, quick.IncludeSharing)
+
+	thorough, err := PresetParameters(PresetThorough)
+	require.NoError(t, err)
+	assert.False(t, thorough.SkipHidden)
+	assert.True(t, thorough.CollectAnalytics)
+
+	_, err = PresetParameters("everything")
+	assert.Error(t, err)
+}
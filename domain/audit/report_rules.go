@@ -0,0 +1,92 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Reports that can be generated automatically when a run completes
+const (
+	ReportLists       = "lists"       // Lists table as CSV
+	ReportAssignments = "assignments" // Assignments of each list with unique permissions as CSV
+	ReportChanges     = "changes"     // Permission change redline of each changed list since the reference run as XLSX
+)
+
+// ErrInvalidReportRules is returned when post-audit report rules cannot be parsed
+var ErrInvalidReportRules = errors.New("invalid post-audit report rules")
+
+// ReportRule selects the reports generated when a run of a matching site completes
+type ReportRule struct {
+	SitePattern string // Site URL, or a URL prefix ending in "*"; "*" alone matches every site
+	Reports     []string
+}
+
+// Matches returns true if the rule applies to the site
+func (r ReportRule) Matches(siteURL string) bool {
+	return SitePatternMatches(r.SitePattern, siteURL)
+}
+
+// SitePatternMatches returns true if a site pattern, a site URL or a URL prefix ending in "*", matches
+// the site, ignoring case and trailing slashes
+func SitePatternMatches(pattern, siteURL string) bool {
+	siteURL = normalizeSiteURL(siteURL)
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return strings.HasPrefix(siteURL, normalizeSiteURL(prefix))
+	}
+	return siteURL == normalizeSiteURL(pattern)
+}
+
+// ParseReportRules parses rules of the form "<site pattern>=<report>[,<report>...]" separated by semicolons,
+// e.g. "*=lists;https://contoso.sharepoint.com/sites/finance*=assignments,changes". An empty spec has no rules.
+func ParseReportRules(spec string) ([]ReportRule, error) {
+	var rules []ReportRule
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pattern, reportList, ok := strings.Cut(entry, "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("%w: %q is not <site pattern>=<reports>", ErrInvalidReportRules, entry)
+		}
+
+		rule := ReportRule{SitePattern: pattern}
+		for _, report := range strings.Split(reportList, ",") {
+			report = strings.ToLower(strings.TrimSpace(report))
+			switch report {
+			case ReportLists, ReportAssignments, ReportChanges:
+				rule.Reports = append(rule.Reports, report)
+			default:
+				return nil, fmt.Errorf("%w: unknown report %q for %s", ErrInvalidReportRules, report, pattern)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// ReportsForSite returns the reports of every rule matching the site, in rule order without duplicates
+func ReportsForSite(rules []ReportRule, siteURL string) []string {
+	var reports []string
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if !rule.Matches(siteURL) {
+			continue
+		}
+		for _, report := range rule.Reports {
+			if !seen[report] {
+				seen[report] = true
+				reports = append(reports, report)
+			}
+		}
+	}
+	return reports
+}
+
+// normalizeSiteURL lowercases a site URL and drops its trailing slash so patterns match regardless of either
+func normalizeSiteURL(siteURL string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(siteURL)), "/")
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReportRules(t *testing.T) {
+	rules, err := ParseReportRules(" *=lists ; https://contoso.sharepoint.com/sites/Finance*=Assignments, changes;; ")
+	require.NoError(t, err)
+	assert.Equal(t, []ReportRule{
+		{SitePattern: "*", Reports: []string{ReportLists}},
+		{SitePattern: "https://contoso.sharepoint.com/sites/Finance*", Reports: []string{ReportAssignments, ReportChanges}},
+	}, rules)
+
+	rules, err = ParseReportRules("")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	for _, spec := range []string{
+		"lists",
+		"=lists",
+		"*=lists,summary",
+		"*=",
+	} {
+		_, err := ParseReportRules(spec)
+		assert.ErrorIs(t, err, ErrInvalidReportRules, "spec %q", spec)
+	}
+}
+
+func TestReportsForSite(t *testing.T) {
+	rules := []ReportRule{
+		{SitePattern: "*", Reports: []string{ReportLists}},
+		{SitePattern: "https://contoso.sharepoint.com/sites/finance*", Reports: []string{ReportChanges, ReportLists}},
+		{SitePattern: "https://contoso.sharepoint.com/sites/hr/", Reports: []string{ReportAssignments}},
+	}
+
+	tests := []struct {
+		siteURL  string
+		expected []string
+	}{
+		{"https://contoso.sharepoint.com/sites/Marketing", []string{ReportLists}},
+		{"https://contoso.sharepoint.com/sites/Finance-EU", []string{ReportLists, ReportChanges}},
+		{"https://contoso.sharepoint.com/sites/HR", []string{ReportLists, ReportAssignments}},
+		{"https://contoso.sharepoint.com/sites/hr-archive", []string{ReportLists}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.siteURL, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ReportsForSite(rules, tt.siteURL))
+		})
+	}
+
+	assert.Empty(t, ReportsForSite(nil, "https://contoso.sharepoint.com/sites/a"))
+}
@@ -0,0 +1,26 @@
+package audit
+
+import "time"
+
+// ReportShare is an expiring, read-only link to the printable summary of a site, or of one of its
+// lists, in an audit run. Anyone holding the link can open the summary until it expires or is revoked.
+type ReportShare struct {
+	ID         int64
+	SiteID     int64
+	AuditRunID int64
+	ListID     string // Empty for the site summary
+	Token      string // Identifies the link
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// IsRevoked returns true if the link was revoked before it expired.
+func (s *ReportShare) IsRevoked() bool {
+	return s.RevokedAt != nil
+}
+
+// IsActive returns true if the link can still be opened at the given time.
+func (s *ReportShare) IsActive(now time.Time) bool {
+	return !s.IsRevoked() && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,23 @@
+package audit
+
+import "time"
+
+// DefaultArtifactRetention is how long generated reports stay downloadable from their run
+const DefaultArtifactRetention = 7 * 24 * time.Hour
+
+// RunArtifact is a report or export generated from an audit run, kept for download until it expires
+type RunArtifact struct {
+	ID          int64
+	AuditRunID  int64
+	SiteID      int64
+	Filename    string
+	ContentType string
+	SizeBytes   int64
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// IsExpired returns true if the artifact can no longer be downloaded at the given time
+func (a *RunArtifact) IsExpired(now time.Time) bool {
+	return !now.Before(a.ExpiresAt)
+}
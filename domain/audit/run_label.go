@@ -0,0 +1,47 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MaxRunLabelLength bounds a run label, which appears in URLs and run switchers.
+const MaxRunLabelLength = 64
+
+// runLabelSeparator joins the "latest" alias to a run label, as in "latest@prod-tenant".
+const runLabelSeparator = "@"
+
+// NormalizeRunLabel trims and lowercases a run label, so "Prod-Tenant " and "prod-tenant" tag the same runs.
+func NormalizeRunLabel(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
+
+// ValidateRunLabel checks a normalized run label: lowercase letters, digits, '.', '_' and '-',
+// starting with a letter or digit. An empty label leaves the run unlabeled.
+func ValidateRunLabel(label string) error {
+	if len(label) > MaxRunLabelLength {
+		return fmt.Errorf("label cannot exceed %d characters, got: %d", MaxRunLabelLength, len(label))
+	}
+	for i, r := range label {
+		alphanumeric := (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')
+		if !alphanumeric && (i == 0 || (r != '.' && r != '_' && r != '-')) {
+			return fmt.Errorf("label must be lowercase letters, digits, '.', '_' or '-' and start with a letter or digit, got: %q", label)
+		}
+	}
+	return nil
+}
+
+// LabeledLatestRun returns the alias of the latest run tagged with label, e.g. "latest@prod-tenant".
+func LabeledLatestRun(label string) string {
+	return RunAliasLatest + runLabelSeparator + label
+}
+
+// ParseLabeledLatestRun returns the label of a "latest@label" alias. It returns false for
+// anything else, including an alias with an invalid label.
+func ParseLabeledLatestRun(value string) (string, bool) {
+	label, ok := strings.CutPrefix(value, RunAliasLatest+runLabelSeparator)
+	if !ok || label == "" || ValidateRunLabel(label) != nil {
+		return "", false
+	}
+	return label, true
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRunLabel(t *testing.T) {
+	for _, label := range []string{"", "prod", "prod-tenant", "pre_migration.2025", "9"} {
+		assert.NoError(t, ValidateRunLabel(label), label)
+	}
+	for _, label := range []string{"Prod", "-prod", "prod tenant", "prod@tenant", "prod/tenant", strings.Repeat("a", MaxRunLabelLength+1)} {
+		assert.Error(t, ValidateRunLabel(label), label)
+	}
+	assert.Equal(t, "prod-tenant", NormalizeRunLabel(" Prod-Tenant "))
+}
+
+func TestLabeledLatestRun(t *testing.T) {
+	alias := LabeledLatestRun("prod-tenant")
+	assert.Equal(t, "latest@prod-tenant", alias)
+	assert.True(t, IsRunAlias(alias))
+
+	label, ok := ParseLabeledLatestRun(alias)
+	assert.True(t, ok)
+	assert.Equal(t, "prod-tenant", label)
+
+	for _, value := range []string{"latest", "latest@", "latest@Prod", "reference@prod", "7"} {
+		_, ok := ParseLabeledLatestRun(value)
+		assert.False(t, ok, value)
+		assert.Equal(t, value == "latest", IsRunAlias(value), value)
+	}
+}
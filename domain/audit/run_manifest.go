@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"time"
+)
+
+// ManifestAlgorithm is the hash algorithm used for run manifests
+const ManifestAlgorithm = "sha256"
+
+// RunManifest records a content hash of each list's canonical snapshot as it was when the run was sealed,
+// so an archived run can later be proven unchanged
+type RunManifest struct {
+	AuditRunID  int64
+	SiteID      int64
+	Algorithm   string
+	ContentHash string // Hash over all entries, see ComputeManifestHash
+	CreatedAt   time.Time
+	Entries     []ManifestEntry // Ordered by list ID
+}
+
+// ManifestEntry is the content hash of one list's canonical snapshot
+type ManifestEntry struct {
+	ListID      string
+	ContentHash string
+}
+
+// Manifest entry verification statuses
+const (
+	ManifestEntryMatch      = "match"      // Snapshot hash is unchanged
+	ManifestEntryModified   = "modified"   // Snapshot hash differs from the sealed one
+	ManifestEntryMissing    = "missing"    // Sealed list no longer has data in the run
+	ManifestEntryUnexpected = "unexpected" // List has data in the run but was not sealed
+)
+
+// ManifestVerification compares a sealed manifest with hashes recomputed from the stored data
+type ManifestVerification struct {
+	Manifest    *RunManifest
+	ContentHash string // Recomputed hash over all entries
+	Verified    bool   // Every entry matches
+	Entries     []ManifestEntryCheck
+}
+
+// ManifestEntryCheck is the verification result for one list
+type ManifestEntryCheck struct {
+	ListID       string
+	ExpectedHash string // Empty for unexpected lists
+	ActualHash   string // Empty for missing lists
+	Status       string
+}
+
+// SortManifestEntries orders entries by list ID, the order they are hashed and stored in
+func SortManifestEntries(entries []ManifestEntry) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ListID < entries[j].ListID })
+}
+
+// ComputeManifestHash hashes the entries in list ID order as sha256sum-style lines,
+// "<hash>  <list ID>\n", so the run hash can be reproduced with standard tools
+func ComputeManifestHash(entries []ManifestEntry) string {
+	sorted := append([]ManifestEntry(nil), entries...)
+	SortManifestEntries(sorted)
+
+	hash := sha256.New()
+	for _, entry := range sorted {
+		hash.Write([]byte(entry.ContentHash + "  " + entry.ListID + "\n"))
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// VerifyManifest compares a sealed manifest with freshly computed entries
+func VerifyManifest(manifest *RunManifest, current []ManifestEntry) *ManifestVerification {
+	verification := &ManifestVerification{
+		Manifest:    manifest,
+		ContentHash: ComputeManifestHash(current),
+		Verified:    true,
+	}
+
+	actual := make(map[string]string, len(current))
+	for _, entry := range current {
+		actual[entry.ListID] = entry.ContentHash
+	}
+
+	for _, entry := range manifest.Entries {
+		check := ManifestEntryCheck{ListID: entry.ListID, ExpectedHash: entry.ContentHash, Status: ManifestEntryMatch}
+		hash, ok := actual[entry.ListID]
+		switch {
+		case !ok:
+			check.Status = ManifestEntryMissing
+		case hash != entry.ContentHash:
+			check.ActualHash = hash
+			check.Status = ManifestEntryModified
+		default:
+			check.ActualHash = hash
+		}
+		delete(actual, entry.ListID)
+		verification.Entries = append(verification.Entries, check)
+	}
+	for listID, hash := range actual {
+		verification.Entries = append(verification.Entries, ManifestEntryCheck{ListID: listID, ActualHash: hash, Status: ManifestEntryUnexpected})
+	}
+
+	sort.Slice(verification.Entries, func(i, j int) bool { return verification.Entries[i].ListID < verification.Entries[j].ListID })
+	for _, check := range verification.Entries {
+		if check.Status != ManifestEntryMatch {
+			verification.Verified = false
+		}
+	}
+	if verification.ContentHash != manifest.ContentHash {
+		verification.Verified = false
+	}
+	return verification
+}
@@ -0,0 +1,60 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeManifestHash(t *testing.T) {
+	entries := []ManifestEntry{
+		{ListID: "list-b", ContentHash: "bbbb"},
+		{ListID: "list-a", ContentHash: "aaaa"},
+	}
+
+	// Same bytes as `sha256sum` output for the two lists
+	sum := sha256.Sum256([]byte("aaaa  list-a\nbbbb  list-b\n"))
+	assert.Equal(t, hex.EncodeToString(sum[:]), ComputeManifestHash(entries))
+	assert.Equal(t, "list-b", entries[0].ListID, "input order is left untouched")
+
+	reordered := []ManifestEntry{entries[1], entries[0]}
+	assert.Equal(t, ComputeManifestHash(entries), ComputeManifestHash(reordered))
+}
+
+func TestVerifyManifest(t *testing.T) {
+	sealed := []ManifestEntry{
+		{ListID: "list-a", ContentHash: "aaaa"},
+		{ListID: "list-b", ContentHash: "bbbb"},
+		{ListID: "list-c", ContentHash: "cccc"},
+	}
+	manifest := &RunManifest{ContentHash: ComputeManifestHash(sealed), Entries: sealed}
+
+	t.Run("unchanged", func(t *testing.T) {
+		verification := VerifyManifest(manifest, sealed)
+		assert.True(t, verification.Verified)
+		assert.Equal(t, manifest.ContentHash, verification.ContentHash)
+		for _, check := range verification.Entries {
+			assert.Equal(t, ManifestEntryMatch, check.Status)
+		}
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		current := []ManifestEntry{
+			{ListID: "list-a", ContentHash: "aaaa"},
+			{ListID: "list-b", ContentHash: "ffff"},
+			{ListID: "list-d", ContentHash: "dddd"},
+		}
+		verification := VerifyManifest(manifest, current)
+		assert.False(t, verification.Verified)
+		assert.NotEqual(t, manifest.ContentHash, verification.ContentHash)
+
+		require.Len(t, verification.Entries, 4)
+		assert.Equal(t, ManifestEntryCheck{ListID: "list-a", ExpectedHash: "aaaa", ActualHash: "aaaa", Status: ManifestEntryMatch}, verification.Entries[0])
+		assert.Equal(t, ManifestEntryCheck{ListID: "list-b", ExpectedHash: "bbbb", ActualHash: "ffff", Status: ManifestEntryModified}, verification.Entries[1])
+		assert.Equal(t, ManifestEntryCheck{ListID: "list-c", ExpectedHash: "cccc", Status: ManifestEntryMissing}, verification.Entries[2])
+		assert.Equal(t, ManifestEntryCheck{ListID: "list-d", ActualHash: "dddd", Status: ManifestEntryUnexpected}, verification.Entries[3])
+	})
+}
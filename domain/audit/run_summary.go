@@ -0,0 +1,38 @@
+package audit
+
+import "time"
+
+// MaxRunSummaryRisks caps the riskiest lists a run summary names.
+const MaxRunSummaryRisks = 5
+
+// RunSummary is what an audit run found, announced when its job finishes or fails. The counts cover
+// what the run captured, which for a failed run may be only part of the site.
+type RunSummary struct {
+	JobID      string
+	SiteID     int64 // 0 when the job never recorded its site
+	SiteURL    string
+	SiteTitle  string
+	AuditRunID int64 // 0 when the job failed before starting a run
+	Failed     bool
+	Error      string // Why the job failed
+	FinishedAt time.Time
+
+	Counted            bool // False when the run's counts could not be read
+	Lists              int
+	UniqueItems        int // Items with unique permissions, estimated for sampled lists
+	SharingLinks       int // Active sharing links
+	ExternalPrincipals int // Guests granted access, counted per list
+	RiskLevel          string
+	TopRisks           []*RunRisk // Riskiest lists first, at most MaxRunSummaryRisks
+}
+
+// RunRisk is one of the riskiest lists of an audit run.
+type RunRisk struct {
+	ListID       string
+	ListTitle    string
+	ListURL      string
+	RiskLevel    string
+	RiskScore    float64
+	UniqueItems  int
+	SharingLinks int
+}
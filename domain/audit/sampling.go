@@ -0,0 +1,67 @@
+package audit
+
+import (
+	"math"
+)
+
+// Sampling defaults: a list is sampled when it holds more items than the threshold,
+// and enough items are deep-scanned to estimate a proportion within ±5% at 95% confidence.
+const (
+	DefaultSampleThreshold = 5000
+	MinSampleThreshold     = 1000
+	SampleConfidenceZ      = 1.96 // z-score for 95% confidence
+	SampleMarginOfError    = 0.05
+)
+
+// SampleSize returns how many of population items to deep-scan, using Cochran's formula
+// for the worst case proportion (0.5) with the finite population correction.
+func SampleSize(population int) int {
+	if population <= 0 {
+		return 0
+	}
+	n0 := SampleConfidenceZ * SampleConfidenceZ * 0.25 / (SampleMarginOfError * SampleMarginOfError)
+	n := n0 / (1 + (n0-1)/float64(population))
+	return min(int(math.Ceil(n)), population)
+}
+
+// ItemSampler picks a systematic sample from items visited in order:
+// every step-th item from a random start, so the sample is spread across the whole list.
+type ItemSampler struct {
+	population int
+	size       int
+	step       float64
+	next       float64
+	index      int
+}
+
+// NewItemSampler creates a sampler choosing sampleSize of population items.
+// offset in [0, 1) positions the first pick within the first step; pass a random value.
+func NewItemSampler(population, sampleSize int, offset float64) *ItemSampler {
+	step := 1.0
+	if sampleSize > 0 && population > sampleSize {
+		step = float64(population) / float64(sampleSize)
+	}
+	return &ItemSampler{population: population, size: sampleSize, step: step, next: offset * step}
+}
+
+// Population returns the number of items the sample is drawn from.
+func (s *ItemSampler) Population() int {
+	return s.population
+}
+
+// Size returns the number of items the sampler is expected to pick.
+func (s *ItemSampler) Size() int {
+	return s.size
+}
+
+// Next reports whether the next item in the walk is part of the sample.
+// Items beyond the expected population keep being picked at the same rate.
+func (s *ItemSampler) Next() bool {
+	index := s.index
+	s.index++
+	if float64(index) < math.Floor(s.next) {
+		return false
+	}
+	s.next += s.step
+	return true
+}
@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSampleSize(t *testing.T) {
+	assert.Equal(t, 0, SampleSize(0))
+	assert.Equal(t, 10, SampleSize(10), "small populations are scanned in full")
+	assert.Equal(t, 357, SampleSize(5000))
+	assert.Equal(t, 383, SampleSize(100000))
+	assert.Equal(t, 385, SampleSize(1000000))
+}
+
+func TestItemSampler(t *testing.T) {
+	sampler := NewItemSampler(10000, 370, 0.5)
+	assert.Equal(t, 10000, sampler.Population())
+	assert.Equal(t, 370, sampler.Size())
+
+	var picked []int
+	for i := 0; i < 10000; i++ {
+		if sampler.Next() {
+			picked = append(picked, i)
+		}
+	}
+
+	assert.Len(t, picked, 370)
+	// Spread across the whole list rather than bunched at the start
+	assert.Less(t, picked[0], 28)
+	assert.Greater(t, picked[len(picked)-1], 10000-28)
+	for i := 1; i < len(picked); i++ {
+		gap := picked[i] - picked[i-1]
+		assert.True(t, gap == 27 || gap == 28, "gap %d between picks", gap)
+	}
+}
+
+func TestItemSampler_SampleCoversPopulation(t *testing.T) {
+	sampler := NewItemSampler(5, 10, 0.9)
+	for i := 0; i < 5; i++ {
+		assert.True(t, sampler.Next())
+	}
+}
+
+func TestAuditParameters_ShouldSample(t *testing.T) {
+	parameters := DefaultParameters()
+	assert.False(t, parameters.ShouldSample(1000000), "sampling is opt-in")
+
+	parameters.SampleLargeLists = true
+	assert.False(t, parameters.ShouldSample(DefaultSampleThreshold))
+	assert.True(t, parameters.ShouldSample(DefaultSampleThreshold+1))
+
+	parameters.SampleThreshold = 10
+	assert.Error(t, parameters.Validate(nil))
+	assert.False(t, parameters.ShouldSample(500), "lists below the minimum threshold are never sampled")
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaDriftKind classifies a schema drift finding
+type SchemaDriftKind string
+
+const (
+	// SchemaDriftUnknown marks a field the response carried that the collector does not map
+	SchemaDriftUnknown SchemaDriftKind = "unknown"
+	// SchemaDriftMissing marks a field the collector maps that the response did not carry
+	SchemaDriftMissing SchemaDriftKind = "missing"
+)
+
+// SchemaDrift records a SharePoint API response field an audit run did not recognise, or expected and
+// did not receive. Either can mean Microsoft renamed or removed a field the audit relies on, which
+// would otherwise lose data silently.
+type SchemaDrift struct {
+	Source      string // Response object, e.g. list_item or sharing_link
+	Field       string
+	Kind        SchemaDriftKind
+	Occurrences int64 // Responses the field was unknown in, or missing from
+}
+
+// String describes the finding, e.g. "sharing_link.LinkKind missing (3)"
+func (d SchemaDrift) String() string {
+	return fmt.Sprintf("%s.%s %s (%d)", d.Source, d.Field, d.Kind, d.Occurrences)
+}
+
+// SummarizeSchemaDrift describes the drift recorded for a run, missing fields first, or returns
+// an empty string when there is none
+func SummarizeSchemaDrift(drift []SchemaDrift) string {
+	var missing, unknown []string
+	for _, d := range drift {
+		if d.Kind == SchemaDriftMissing {
+			missing = append(missing, d.String())
+		} else {
+			unknown = append(unknown, d.String())
+		}
+	}
+	return strings.Join(append(missing, unknown...), ", ")
+}
+
+// CountMissingFields returns the number of expected fields the run's responses lacked
+func CountMissingFields(drift []SchemaDrift) int {
+	count := 0
+	for _, d := range drift {
+		if d.Kind == SchemaDriftMissing {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,19 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummarizeSchemaDrift(t *testing.T) {
+	drift := []SchemaDrift{
+		{Source: "list_item", Field: "Colour", Kind: SchemaDriftUnknown, Occurrences: 12},
+		{Source: "sharing_link", Field: "LinkKind", Kind: SchemaDriftMissing, Occurrences: 3},
+	}
+
+	assert.Equal(t, "sharing_link.LinkKind missing (3), list_item.Colour unknown (12)", SummarizeSchemaDrift(drift))
+	assert.Equal(t, 1, CountMissingFields(drift))
+	assert.Empty(t, SummarizeSchemaDrift(nil))
+	assert.Zero(t, CountMissingFields(nil))
+}
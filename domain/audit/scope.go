@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ValidateSiteURL checks a site URL is an absolute http(s) URL, e.g. https://contoso.sharepoint.com/sites/Finance.
+func ValidateSiteURL(siteURL string) error {
+	parsed, err := url.Parse(strings.TrimSpace(siteURL))
+	if err != nil {
+		return fmt.Errorf("invalid site URL: %w", err)
+	}
+	if (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("site URL must be an absolute http(s) URL, got: %q", siteURL)
+	}
+	return nil
+}
+
+// ResolveFolderPath normalizes the folder an audit is scoped to into a server-relative path
+// without a trailing slash. The folder may be given as an absolute URL or a server-relative
+// path, and must lie below the site.
+func ResolveFolderPath(siteURL, folder string) (string, error) {
+	site, err := url.Parse(strings.TrimSpace(siteURL))
+	if err != nil {
+		return "", fmt.Errorf("invalid site URL: %w", err)
+	}
+
+	folder = strings.TrimSpace(folder)
+	parsed, err := url.Parse(folder)
+	if err != nil {
+		return "", fmt.Errorf("invalid folder path %q: %w", folder, err)
+	}
+	if parsed.IsAbs() && !strings.EqualFold(parsed.Host, site.Host) {
+		return "", fmt.Errorf("folder %q is not on the site's host %s", folder, site.Host)
+	}
+	if !strings.HasPrefix(parsed.Path, "/") {
+		return "", fmt.Errorf("folder path %q must be a URL or start with /", folder)
+	}
+
+	folderPath := strings.TrimRight(parsed.Path, "/")
+	sitePath := strings.TrimRight(site.Path, "/")
+	if !PathInFolder(folderPath, sitePath) || strings.EqualFold(folderPath, sitePath) {
+		return "", fmt.Errorf("folder %q is not inside site %s", folder, siteURL)
+	}
+	return folderPath, nil
+}
+
+// PathInFolder returns true if the server-relative path is the folder itself or lies below it.
+// SharePoint paths are case-insensitive.
+func PathInFolder(path, folder string) bool {
+	path = strings.TrimRight(path, "/")
+	folder = strings.TrimRight(folder, "/")
+	if folder == "" {
+		return true
+	}
+	if len(path) < len(folder) || !strings.EqualFold(path[:len(folder)], folder) {
+		return false
+	}
+	return len(path) == len(folder) || path[len(folder)] == '/'
+}
@@ -0,0 +1,61 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveFolderPath(t *testing.T) {
+	const siteURL = "https://contoso.sharepoint.com/sites/Finance"
+
+	tests := []struct {
+		name     string
+		folder   string
+		expected string
+	}{
+		{"server-relative path", "/sites/Finance/Shared Documents/Payroll", "/sites/Finance/Shared Documents/Payroll"},
+		{"trailing slash", "/sites/Finance/Shared Documents/Payroll/", "/sites/Finance/Shared Documents/Payroll"},
+		{"absolute URL", "https://contoso.sharepoint.com/sites/Finance/Shared%20Documents/Payroll", "/sites/Finance/Shared Documents/Payroll"},
+		{"host and site case differ", "https://CONTOSO.sharepoint.com/sites/finance/Shared Documents", "/sites/finance/Shared Documents"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			folderPath, err := ResolveFolderPath(siteURL, tt.folder)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, folderPath)
+		})
+	}
+
+	for _, folder := range []string{
+		"Shared Documents/Payroll",                             // Relative
+		"https://fabrikam.sharepoint.com/sites/Finance/Shared", // Other host
+		"/sites/FinanceArchive/Shared Documents",               // Sibling site sharing a prefix
+		"/sites/Finance",                                       // The site itself
+		"/sites/Finance/",
+	} {
+		_, err := ResolveFolderPath(siteURL, folder)
+		assert.Error(t, err, folder)
+	}
+}
+
+func TestPathInFolder(t *testing.T) {
+	const folder = "/sites/Finance/Shared Documents/Payroll"
+
+	assert.True(t, PathInFolder(folder, folder), "the folder itself")
+	assert.True(t, PathInFolder(folder+"/2024/March.xlsx", folder))
+	assert.True(t, PathInFolder("/sites/finance/shared documents/payroll/a.docx", folder), "paths are case-insensitive")
+	assert.False(t, PathInFolder("/sites/Finance/Shared Documents/Payroll Archive/a.docx", folder), "sibling sharing a prefix")
+	assert.False(t, PathInFolder("/sites/Finance/Shared Documents", folder), "parent folder")
+	assert.True(t, PathInFolder("/anything", ""), "no folder covers everything")
+}
+
+func TestValidateSiteURL(t *testing.T) {
+	for _, siteURL := range []string{"https://contoso.sharepoint.com/sites/Finance", " https://contoso.sharepoint.com ", "http://sp.local/sites/a"} {
+		assert.NoError(t, ValidateSiteURL(siteURL), siteURL)
+	}
+	for _, siteURL := range []string{"", "contoso.sharepoint.com/sites/Finance", "/sites/Finance", "ftp://contoso.sharepoint.com", "https://"} {
+		assert.Error(t, ValidateSiteURL(siteURL), siteURL)
+	}
+}
@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidBadgeSignature is returned for badge requests whose signature was not issued for the site.
+var ErrInvalidBadgeSignature = errors.New("invalid badge signature")
+
+// badgeSignaturePrefix keeps badge signatures from being valid for anything else signed with the key.
+const badgeSignaturePrefix = "site-badge:"
+
+// SiteBadge is a site's risk as of its latest audit run, for embedding in portals such as intranet
+// site directories. RiskLevel is empty for a site never audited.
+type SiteBadge struct {
+	SiteID      int64
+	SiteURL     string
+	Title       string
+	AuditRunID  int64
+	RiskLevel   string  // High, Medium or Low; the highest list risk level of the run
+	RiskScore   float64 // Risk score of the site's riskiest list
+	LastAuditAt *time.Time
+}
+
+// IsAudited returns true if the site has an audit run to report.
+func (b *SiteBadge) IsAudited() bool {
+	return b.AuditRunID != 0
+}
+
+// SignSiteBadge returns the signature a site's badge URL carries, the hex HMAC-SHA256 of the site ID.
+// Only the holder of the key can link to a site's badge, so portals cannot be pointed at any site.
+func SignSiteBadge(key []byte, siteID int64) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(badgeSignaturePrefix + strconv.FormatInt(siteID, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySiteBadge checks in constant time that a signature was issued for the site with the key.
+func VerifySiteBadge(key []byte, siteID int64, signature string) error {
+	expected, _ := hex.DecodeString(SignSiteBadge(key, siteID))
+	given, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, given) {
+		return ErrInvalidBadgeSignature
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteBadgeSignature(t *testing.T) {
+	key := []byte("badge-key")
+	signature := SignSiteBadge(key, 7)
+
+	assert.NoError(t, VerifySiteBadge(key, 7, signature))
+	assert.ErrorIs(t, VerifySiteBadge(key, 8, signature), ErrInvalidBadgeSignature, "signatures are per site")
+	assert.ErrorIs(t, VerifySiteBadge([]byte("other-key"), 7, signature), ErrInvalidBadgeSignature)
+	assert.ErrorIs(t, VerifySiteBadge(key, 7, "not-hex"), ErrInvalidBadgeSignature)
+	assert.ErrorIs(t, VerifySiteBadge(key, 7, ""), ErrInvalidBadgeSignature)
+}
@@ -0,0 +1,11 @@
+package audit
+
+import "time"
+
+// SiteSensitivity tags a site as holding sensitive content, so sharing defaults that suit an ordinary
+// site are flagged on it.
+type SiteSensitivity struct {
+	SiteID   int64
+	Reason   string
+	TaggedAt time.Time
+}
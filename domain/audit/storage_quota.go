@@ -0,0 +1,34 @@
+package audit
+
+// StorageQuota is a soft limit on the disk space the database may use. Audits that are already
+// running always finish; the limit only decides whether new audits are warned about or refused.
+type StorageQuota struct {
+	SoftLimitBytes int64 // Database and WAL file size; 0 disables the limit
+	PauseAudits    bool  // Refuse new audits while over the limit, instead of only warning
+}
+
+// Exceeded reports whether usedBytes is over the soft limit.
+func (q StorageQuota) Exceeded(usedBytes int64) bool {
+	return q.SoftLimitBytes > 0 && usedBytes > q.SoftLimitBytes
+}
+
+// BlocksAudits reports whether new audits are refused at usedBytes.
+func (q StorageQuota) BlocksAudits(usedBytes int64) bool {
+	return q.PauseAudits && q.Exceeded(usedBytes)
+}
+
+// RunRowCounts is how many rows an audit run stored in its largest tables.
+type RunRowCounts struct {
+	AuditRunID      int64
+	SiteID          int64
+	SiteURL         string
+	Items           int64
+	RoleAssignments int64
+	SharingLinks    int64
+	Principals      int64
+}
+
+// Total returns the rows counted across all tables.
+func (c RunRowCounts) Total() int64 {
+	return c.Items + c.RoleAssignments + c.SharingLinks + c.Principals
+}
@@ -0,0 +1,28 @@
+package audit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageQuota(t *testing.T) {
+	tests := []struct {
+		name         string
+		quota        StorageQuota
+		used         int64
+		exceeded     bool
+		blocksAudits bool
+	}{
+		{"disabled", StorageQuota{PauseAudits: true}, 1 << 40, false, false},
+		{"under the limit", StorageQuota{SoftLimitBytes: 100, PauseAudits: true}, 100, false, false},
+		{"over the limit warns", StorageQuota{SoftLimitBytes: 100}, 101, true, false},
+		{"over the limit pauses", StorageQuota{SoftLimitBytes: 100, PauseAudits: true}, 101, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exceeded, tt.quota.Exceeded(tt.used))
+			assert.Equal(t, tt.blocksAudits, tt.quota.BlocksAudits(tt.used))
+		})
+	}
+}
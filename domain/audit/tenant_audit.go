@@ -0,0 +1,40 @@
+package audit
+
+// DefaultTenantAuditConcurrency is how many site audits a tenant audit runs at once by default.
+// Every audit draws on the same tenant's throttling budget.
+const DefaultTenantAuditConcurrency = 2
+
+// Outcomes of a site collection in a tenant audit
+const (
+	TenantSiteCompleted = "completed"
+	TenantSiteFailed    = "failed"
+	TenantSiteCancelled = "cancelled"
+	TenantSiteSkipped   = "skipped" // Already being audited, or not started before the tenant audit was cancelled
+)
+
+// TenantAuditResult is the outcome of auditing every site collection of a tenant.
+type TenantAuditResult struct {
+	AdminURL   string             `json:"admin_url"`
+	SitesFound int                `json:"sites_found"` // Including sites that cannot be audited
+	Sites      []*TenantSiteAudit `json:"sites"`       // Auditable sites, in the order the admin center listed them
+}
+
+// TenantSiteAudit is the child site audit of one site collection.
+type TenantSiteAudit struct {
+	SiteURL string `json:"site_url"`
+	Title   string `json:"title,omitempty"`
+	JobID   string `json:"job_id,omitempty"` // Empty when no audit was queued
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Count returns how many sites ended with the status.
+func (r *TenantAuditResult) Count(status string) int {
+	count := 0
+	for _, site := range r.Sites {
+		if site.Status == status {
+			count++
+		}
+	}
+	return count
+}
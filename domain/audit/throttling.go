@@ -0,0 +1,38 @@
+package audit
+
+import (
+	"fmt"
+	"time"
+)
+
+// ThrottleEvent is a request SharePoint throttled with 429 Too Many Requests or 503 Server Too Busy.
+type ThrottleEvent struct {
+	StatusCode int
+	Attempt    int           // Retry the wait precedes, counted from 1
+	Wait       time.Duration // Zero when the request gave up
+	RetryAfter bool          // Wait is what SharePoint asked for in Retry-After, rather than backoff
+	GaveUp     bool          // No retry was left, so the request failed
+}
+
+// Message describes the event for job progress.
+func (e ThrottleEvent) Message() string {
+	if e.GaveUp {
+		return fmt.Sprintf("SharePoint throttled a request (HTTP %d) and no retries were left", e.StatusCode)
+	}
+	return fmt.Sprintf("SharePoint is throttling requests (HTTP %d), retry %d in %s", e.StatusCode, e.Attempt, e.Wait.Round(time.Second))
+}
+
+// ThrottleBackoff returns the wait before retry attempt, counted from 1, of a request SharePoint
+// throttled without a Retry-After: delay doubled for each further attempt and capped at maxDelay.
+// jitter, from 0 to 1, takes up to half of the wait off, so audits throttled together do not retry
+// together.
+func ThrottleBackoff(attempt int, delay, maxDelay time.Duration, jitter float64) time.Duration {
+	backoff := delay
+	for i := 1; i < attempt && backoff < maxDelay; i++ {
+		backoff *= 2
+	}
+	if backoff > maxDelay {
+		backoff = maxDelay
+	}
+	return backoff - time.Duration(float64(backoff)/2*jitter)
+}
@@ -0,0 +1,33 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottleBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, ThrottleBackoff(1, time.Second, time.Minute, 0))
+	assert.Equal(t, 4*time.Second, ThrottleBackoff(3, time.Second, time.Minute, 0))
+	assert.Equal(t, time.Minute, ThrottleBackoff(10, time.Second, time.Minute, 0), "capped")
+	assert.Equal(t, 2*time.Second, ThrottleBackoff(3, time.Second, time.Minute, 1), "jitter takes off up to half")
+}
+
+func TestAuditParameters_ValidateMaxRequestsPerSecond(t *testing.T) {
+	parameters := DefaultParameters()
+	parameters.MaxRequestsPerSecond = 20
+	assert.NoError(t, parameters.Validate(nil))
+
+	parameters.MaxRequestsPerSecond = -1
+	assert.Error(t, parameters.Validate(nil))
+
+	parameters.MaxRequestsPerSecond = DefaultApiConstraints().MaxRequestsPerSecond + 1
+	assert.Error(t, parameters.Validate(nil))
+}
+
+func TestThrottleEvent_Message(t *testing.T) {
+	assert.Equal(t, "SharePoint is throttling requests (HTTP 429), retry 2 in 30s",
+		ThrottleEvent{StatusCode: 429, Attempt: 2, Wait: 30 * time.Second, RetryAfter: true}.Message())
+	assert.Contains(t, ThrottleEvent{StatusCode: 503, GaveUp: true}.Message(), "no retries were left")
+}
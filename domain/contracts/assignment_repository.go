@@ -12,4 +12,7 @@ type AssignmentRepository interface {
 	GetAssignmentsForObject(ctx context.Context, siteID int64, objectType, objectKey string) ([]*sharepoint.Assignment, error)
 	// GetResolvedAssignmentsForObject retrieves role assignments with root cause analysis.
 	GetResolvedAssignmentsForObject(ctx context.Context, siteID int64, objectType, objectKey string) ([]*sharepoint.ResolvedAssignment, error)
+	// GetResolvedAssignmentsPageForObject retrieves up to limit role assignments with root cause analysis,
+	// starting at offset in the order of GetAssignmentsForObject.
+	GetResolvedAssignmentsPageForObject(ctx context.Context, siteID int64, objectType, objectKey string, offset, limit int) ([]*sharepoint.ResolvedAssignment, error)
 }
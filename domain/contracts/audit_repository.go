@@ -2,7 +2,9 @@ package contracts
 
 import (
 	"context"
+	"time"
 
+	"spaudit/domain/audit"
 	"spaudit/domain/sharepoint"
 )
 
@@ -17,6 +19,9 @@ type AuditRepository interface {
 
 	// List operations
 	SaveList(ctx context.Context, auditRunID int64, list *sharepoint.List) error
+	UpdateListUniqueDensity(ctx context.Context, auditRunID, siteID int64) error
+	UpdateListAccessCounts(ctx context.Context, auditRunID, siteID int64) error
+	UpdateListSampling(ctx context.Context, auditRunID, siteID int64, listID string, sampledItemCount int) error
 
 	// Item operations
 	SaveItem(ctx context.Context, auditRunID int64, item *sharepoint.Item) error
@@ -25,7 +30,10 @@ type AuditRepository interface {
 	SaveRoleDefinitions(ctx context.Context, auditRunID int64, siteID int64, roleDefs []*sharepoint.RoleDefinition) error
 	SavePrincipal(ctx context.Context, auditRunID int64, principal *sharepoint.Principal) error
 	SaveRoleAssignments(ctx context.Context, auditRunID int64, siteID int64, assignments []*sharepoint.RoleAssignment) error
-	ClearRoleAssignments(ctx context.Context, siteID int64, objectType, objectKey string) error
+	SaveGroupMembers(ctx context.Context, auditRunID int64, siteID int64, groupID int64, members []*sharepoint.Principal) error
+	GetSecurityGroupPrincipals(ctx context.Context, auditRunID int64, siteID int64) ([]*sharepoint.Principal, error)
+	SaveEntraGroupMembers(ctx context.Context, auditRunID int64, siteID int64, principalID int64, group sharepoint.EntraGroupRef, resolvedAt time.Time, members []*sharepoint.EntraGroupMember) error
+	ReplaceRoleAssignments(ctx context.Context, auditRunID int64, siteID int64, objectType, objectKey string, assignments []*sharepoint.RoleAssignment) error
 
 	// Sharing operations
 	SaveSharingLinks(ctx context.Context, auditRunID int64, siteID int64, links []*sharepoint.SharingLink) error
@@ -44,4 +52,22 @@ type AuditRepository interface {
 	SaveRecipientLimits(ctx context.Context, auditRunID, siteID int64, limits *sharepoint.RecipientLimits) error
 	SaveSensitivityLabel(ctx context.Context, auditRunID, siteID int64, itemGUID string, label *sharepoint.SensitivityLabelInformation) error
 	SaveItemSensitivityLabel(ctx context.Context, label *sharepoint.ItemSensitivityLabel) error
+	SaveItemAnalytics(ctx context.Context, analytics *sharepoint.ItemAnalytics) error
+	SaveRecycleBinScan(ctx context.Context, auditRunID, siteID int64, scannedAt time.Time, items []*sharepoint.RecycleBinItem) error
+	SaveAppInventory(ctx context.Context, auditRunID, siteID int64, collectedAt time.Time, apps []*sharepoint.InstalledApp) error
+	SaveGraphGrantScan(ctx context.Context, auditRunID, siteID int64, scannedAt time.Time, grants []*sharepoint.GraphAppGrant) error
+	SaveHubAssociation(ctx context.Context, auditRunID, siteID int64, collectedAt time.Time, hub *sharepoint.HubAssociation) error
+
+	// Audit run content operations, paged for runs too large to load at once
+	GetSitesByAuditRun(ctx context.Context, auditRunID int64) ([]*sharepoint.Site, error)
+	GetWebsByAuditRun(ctx context.Context, auditRunID int64, offset, limit int64) ([]*sharepoint.Web, error)
+	GetListsByAuditRun(ctx context.Context, auditRunID int64, offset, limit int64) ([]*sharepoint.List, error)
+	GetItemsByAuditRun(ctx context.Context, auditRunID int64, offset, limit int64) ([]*sharepoint.Item, error)
+
+	// Run metadata operations
+	SaveSchemaDrift(ctx context.Context, auditRunID int64, drift []audit.SchemaDrift) error
+
+	// Checkpoint operations, which let an interrupted run resume where it stopped
+	SaveListCheckpoint(ctx context.Context, auditRunID int64, checkpoint *audit.ListCheckpoint) error
+	GetListCheckpoints(ctx context.Context, auditRunID int64) ([]*audit.ListCheckpoint, error)
 }
@@ -6,4 +6,7 @@ import "errors"
 var (
 	// ErrSiteScopeMismatch occurs when a repository scoped to one site ID receives a request for a different site ID
 	ErrSiteScopeMismatch = errors.New("repository scoped to different site ID")
+
+	// ErrRowCapExceeded occurs when an analysis would load more rows than the configured cap allows
+	ErrRowCapExceeded = errors.New("row cap exceeded")
 )
\ No newline at end of file
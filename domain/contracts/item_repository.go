@@ -6,22 +6,44 @@ import (
 	"spaudit/domain/sharepoint"
 )
 
+// Item kinds accepted by ItemFilter.Kind.
+const (
+	ItemKindFile     = "file"
+	ItemKindFolder   = "folder"
+	ItemKindListItem = "item"
+)
+
+// ItemFilter narrows the items returned by filtered item queries.
+// The zero value matches every item in the list.
+type ItemFilter struct {
+	UniqueOnly bool   // Only items with unique permissions
+	Kind       string // Empty for all kinds, otherwise one of the ItemKind constants
+
+	// AccessibleBy keeps only items the principal can access, 0 for no restriction.
+	// Access is resolved by the application layer from assignments, sharing links and
+	// group membership; item repositories ignore it.
+	AccessibleBy int64
+}
+
 // ItemRepository defines operations for Item entities.
-// TODO: Enhance repository interface for pagination and performance:
-// - Add GetItemsCount and GetItemsWithUniqueCount methods for total counts
+// TODO: Enhance repository interface for performance:
 // - Consider adding GetItemsSummaryForList for lighter-weight queries (IDs, names, types only)
 // - Add sorting parameters to methods (by name, date, size, risk level)
-// - Add filtering parameters (by type, permission level, last modified date)
+// - Add last modified date filtering to ItemFilter
 // - Consider cursor-based pagination for very large datasets
 type ItemRepository interface {
+	// GetByGUID retrieves a single item by GUID.
+	GetByGUID(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.Item, error)
+
 	// GetItemsForList retrieves all items for a list.
 	GetItemsForList(ctx context.Context, siteID int64, listID string, offset, limit int64) ([]*sharepoint.Item, error)
 
 	// GetItemsWithUniqueForList retrieves only items with unique permissions for a list.
 	GetItemsWithUniqueForList(ctx context.Context, siteID int64, listID string, offset, limit int64) ([]*sharepoint.Item, error)
 
-	// TODO: Add these methods for proper pagination support:
-	// GetItemsCountForList(ctx context.Context, siteID int64, listID string) (int64, error)
-	// GetItemsWithUniqueCountForList(ctx context.Context, siteID int64, listID string) (int64, error)
-	// GetItemsSummaryForList(...) - lightweight version with minimal fields
+	// GetFilteredItemsForList retrieves a page of items for a list matching the filter.
+	GetFilteredItemsForList(ctx context.Context, siteID int64, listID string, filter ItemFilter, offset, limit int64) ([]*sharepoint.Item, error)
+
+	// CountFilteredItemsForList counts the items for a list matching the filter.
+	CountFilteredItemsForList(ctx context.Context, siteID int64, listID string, filter ItemFilter) (int64, error)
 }
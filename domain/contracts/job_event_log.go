@@ -0,0 +1,47 @@
+package contracts
+
+import (
+	"context"
+
+	"spaudit/domain/events"
+)
+
+// JobEventLog keeps the job events published on the event bus so they can be replayed.
+type JobEventLog interface {
+	// AppendJobEvent stores an event, setting its sequence number.
+	AppendJobEvent(ctx context.Context, event *events.RecordedJobEvent) error
+
+	// ListJobEvents returns the events matching the filter in sequence order.
+	ListJobEvents(ctx context.Context, filter events.JobEventFilter) ([]*events.RecordedJobEvent, error)
+
+	// GetJobEvent returns the event with a sequence number.
+	GetJobEvent(ctx context.Context, sequence int64) (*events.RecordedJobEvent, error)
+
+	// LatestJobEventSequence returns the sequence number of the last event, 0 when there are none.
+	LatestJobEventSequence(ctx context.Context) (int64, error)
+}
+
+// JobEventDeliveryStore tracks how far each durable handler got through the job event log and the
+// events it gave up on.
+type JobEventDeliveryStore interface {
+	// InitHandlerOffset starts a handler at a sequence number unless it already has an offset.
+	InitHandlerOffset(ctx context.Context, handler string, sequence int64) error
+
+	// GetHandlerOffset returns the sequence number of the last event a handler finished with.
+	GetHandlerOffset(ctx context.Context, handler string) (int64, error)
+
+	// SetHandlerOffset records that a handler finished with the events up to a sequence number.
+	SetHandlerOffset(ctx context.Context, handler string, sequence int64) error
+
+	// AddDeadLetter records an event a handler failed on, adding to the attempts of an existing entry.
+	AddDeadLetter(ctx context.Context, deadLetter *events.DeadLetter) error
+
+	// GetDeadLetter returns a dead letter by ID.
+	GetDeadLetter(ctx context.Context, id int64) (*events.DeadLetter, error)
+
+	// ListDeadLetters returns the most recently failed dead letters first.
+	ListDeadLetters(ctx context.Context, limit int) ([]*events.DeadLetter, error)
+
+	// DeleteDeadLetter removes a dead letter, returning false when it does not exist.
+	DeleteDeadLetter(ctx context.Context, id int64) (bool, error)
+}
@@ -24,5 +24,6 @@ type JobRepository interface {
 	CompleteJob(ctx context.Context, jobID string, result string) error
 	FailJob(ctx context.Context, jobID string, errorMsg string) error
 	CancelJob(ctx context.Context, jobID string) error
+	ReopenJob(ctx context.Context, jobID string) error // Clears the error and completion time of a job resumed after it ended
 	DeleteOldJobs(ctx context.Context, olderThan time.Time) error
 }
@@ -0,0 +1,12 @@
+package contracts
+
+import (
+	"context"
+
+	"spaudit/domain/audit"
+)
+
+// PayloadSampleStore keeps the raw API payloads sampled by audit runs, in the database or elsewhere.
+type PayloadSampleStore interface {
+	SavePayloadSamples(ctx context.Context, auditRunID int64, samples []*audit.PayloadSample) error
+}
@@ -2,19 +2,57 @@ package contracts
 
 import (
 	"context"
+	"spaudit/domain/audit"
 	"spaudit/domain/sharepoint"
 )
 
-// PermissionAnalysisComponents represents the raw components needed for permission analysis.
+// PermissionAnalysisComponents represents the grouped counts needed for permission analysis.
 type PermissionAnalysisComponents struct {
-	Assignments  []*sharepoint.Assignment
-	Items        []*sharepoint.Item
-	SharingLinks []*sharepoint.SharingLink
-	List         *sharepoint.List
+	AssignmentCounts  []AssignmentCount
+	SharingLinkCounts []SharingLinkKindCount
+	ItemCounts        *ItemCounts // Nil when no items were audited for the list
+	EffectiveUsers    *EffectiveUserCounts
+	List              *sharepoint.List
+}
+
+// AssignmentCount is the number of assignments sharing a principal type and role.
+type AssignmentCount struct {
+	PrincipalType int64
+	RoleDefID     int64
+	RoleName      string // As the tenant names it, which may be localized
+	SharingLink   bool   // Principal login name identifies a sharing link principal
+	Count         int
+}
+
+// SharingLinkKindCount is the number of active sharing links of a link kind and their members.
+type SharingLinkKindCount struct {
+	LinkKind    int
+	LinkCount   int
+	MemberCount int
+}
+
+// EffectiveUserCounts is the number of distinct users a list's assignments reach once SharePoint groups
+// and the Entra groups the audit run resolved are expanded.
+type EffectiveUserCounts struct {
+	Users            int
+	ResolvedGroups   int // Entra groups whose users the audit run resolved
+	UnresolvedGroups int // Entra groups whose users are unknown, so not in Users
+}
+
+// ItemCounts holds item totals for a list.
+type ItemCounts struct {
+	TotalItems      int64
+	ItemsWithUnique int64
+	FilesCount      int64
+	FoldersCount    int64
 }
 
 // PermissionAggregateRepository handles permission analysis across assignments, items, and sharing.
 type PermissionAggregateRepository interface {
-	// Get raw components for permission analysis (audit-scoped)
+	// Get grouped counts for permission analysis (audit-scoped)
 	GetPermissionAnalysisComponents(ctx context.Context, siteID int64, auditRunID int64, list *sharepoint.List) (*PermissionAnalysisComponents, error)
+
+	// Get grouped counts of the assignments, sharing links and unique items of a list that accepted
+	// exceptions leave out of permission analysis (audit-scoped)
+	GetExceptedPermissionComponents(ctx context.Context, siteID int64, auditRunID int64, list *sharepoint.List, exceptions *audit.PermissionExceptionSet) (*PermissionAnalysisComponents, error)
 }
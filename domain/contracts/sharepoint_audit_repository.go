@@ -2,7 +2,9 @@ package contracts
 
 import (
 	"context"
+	"time"
 
+	"spaudit/domain/audit"
 	"spaudit/domain/sharepoint"
 )
 
@@ -22,6 +24,9 @@ type SharePointAuditRepository interface {
 
 	// List operations
 	SaveList(ctx context.Context, list *sharepoint.List) error
+	UpdateListUniqueDensity(ctx context.Context) error
+	UpdateListAccessCounts(ctx context.Context) error
+	UpdateListSampling(ctx context.Context, listID string, sampledItemCount int) error
 
 	// Item operations
 	SaveItem(ctx context.Context, item *sharepoint.Item) error
@@ -30,7 +35,10 @@ type SharePointAuditRepository interface {
 	SaveRoleDefinitions(ctx context.Context, roleDefs []*sharepoint.RoleDefinition) error
 	SavePrincipal(ctx context.Context, principal *sharepoint.Principal) error
 	SaveRoleAssignments(ctx context.Context, assignments []*sharepoint.RoleAssignment) error
-	ClearRoleAssignments(ctx context.Context, objectType, objectKey string) error
+	SaveGroupMembers(ctx context.Context, groupID int64, members []*sharepoint.Principal) error
+	GetSecurityGroupPrincipals(ctx context.Context) ([]*sharepoint.Principal, error)
+	SaveEntraGroupMembers(ctx context.Context, principalID int64, group sharepoint.EntraGroupRef, resolvedAt time.Time, members []*sharepoint.EntraGroupMember) error
+	ReplaceRoleAssignments(ctx context.Context, objectType, objectKey string, assignments []*sharepoint.RoleAssignment) error
 
 	// Sharing operations (site and audit run scoped by default)
 	SaveSharingLinks(ctx context.Context, links []*sharepoint.SharingLink) error
@@ -49,4 +57,16 @@ type SharePointAuditRepository interface {
 	SaveRecipientLimits(ctx context.Context, limits *sharepoint.RecipientLimits) error
 	SaveSensitivityLabel(ctx context.Context, itemGUID string, label *sharepoint.SensitivityLabelInformation) error
 	SaveItemSensitivityLabel(ctx context.Context, label *sharepoint.ItemSensitivityLabel) error
+	SaveItemAnalytics(ctx context.Context, analytics *sharepoint.ItemAnalytics) error
+	SaveRecycleBinScan(ctx context.Context, scannedAt time.Time, items []*sharepoint.RecycleBinItem) error
+	SaveAppInventory(ctx context.Context, collectedAt time.Time, apps []*sharepoint.InstalledApp) error
+	SaveGraphGrantScan(ctx context.Context, scannedAt time.Time, grants []*sharepoint.GraphAppGrant) error
+	SaveHubAssociation(ctx context.Context, collectedAt time.Time, hub *sharepoint.HubAssociation) error
+
+	// Run metadata operations (audit run scoped by default)
+	SaveSchemaDrift(ctx context.Context, drift []audit.SchemaDrift) error
+
+	// Checkpoint operations (audit run scoped by default)
+	SaveListCheckpoint(ctx context.Context, checkpoint *audit.ListCheckpoint) error
+	GetListCheckpoints(ctx context.Context) ([]*audit.ListCheckpoint, error)
 }
@@ -16,4 +16,10 @@ type SharingRepository interface {
 
 	// GetSharingLinkMembers retrieves members of a sharing link.
 	GetSharingLinkMembers(ctx context.Context, siteID int64, linkID string) ([]*sharepoint.Principal, error)
+
+	// GetItemSensitivityLabel retrieves the sensitivity label for an item, or nil if it has none.
+	GetItemSensitivityLabel(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.ItemSensitivityLabel, error)
+
+	// GetSensitivityLabelsForList retrieves item sensitivity labels for a list.
+	GetSensitivityLabelsForList(ctx context.Context, siteID int64, listID string) ([]*sharepoint.ItemSensitivityLabel, error)
 }
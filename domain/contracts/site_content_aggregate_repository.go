@@ -7,30 +7,181 @@ import (
 	"spaudit/domain/sharepoint"
 )
 
+// ListChanges holds the permission facts on a list present in only one of two audit runs.
+// Each kind is ordered by object, principal and role, with removals first.
+type ListChanges struct {
+	Assignments     []*ListChange // On the list itself
+	UniqueItems     []*ListChange
+	ItemAssignments []*ListChange
+	SharingLinks    []*ListChange
+	LinkMembers     []*ListChange
+}
+
+// ListChange is one permission fact added or removed between two audit runs. Removed facts are
+// described as the base run captured them, added facts as the later run did.
+type ListChange struct {
+	Added      bool
+	ObjectKey  string // List ID, item GUID or sharing link ID
+	ObjectName string
+	Principal  *sharepoint.Principal // Nil for unique items and sharing links
+	RoleDefID  int64
+	Role       string
+	URL        string // Unique items only
+	LinkKind   int    // Sharing links and their members only
+}
+
+// RunChanges holds the facts outside a site's lists present in only one of two audit runs.
+type RunChanges struct {
+	WebAssignments    []*ListChange           // Ordered by web, principal and role, with removals first
+	RemovedPrincipals []*sharepoint.Principal // Captured by the base run only, ordered by ID
+}
+
+// RunFacts holds an audit run's permission facts described by URL and principal rather than by
+// object key, so runs of different sites can be compared.
+type RunFacts struct {
+	Permissions  []*PermissionFact  // Ordered by object and principal
+	SharingLinks []*SharingLinkFact // Ordered by item and link kind
+}
+
+// PermissionFact is one explicit role assignment on the web, a list or an item.
+type PermissionFact struct {
+	ObjectType string
+	ObjectKey  string
+	ObjectURL  string // Empty when the run did not capture the object's URL
+	ObjectName string
+	Principal  *sharepoint.Principal
+	RoleDefID  int64
+	Role       string
+}
+
+// SharingLinkFact is one active sharing link, described by the item it was created on.
+type SharingLinkFact struct {
+	LinkID       string
+	ItemGUID     string
+	ItemURL      string // Empty when the run did not capture the item's URL
+	ItemName     string
+	WebID        string // Empty when the run did not capture the item's list
+	LinkKind     int
+	Scope        int
+	MembersCount int64
+	IsDefault    bool // Created as the link the share dialog offered
+	IsEditLink   bool
+	Expiration   *time.Time
+}
+
+// OwnedItem is an item whose sensitivity label names its owner.
+type OwnedItem struct {
+	ItemGUID string
+	ItemURL  string
+	ItemName string
+	Label    string // Display name of the sensitivity label
+}
+
+// DeletedItemLink is an active sharing link an earlier audit run captured on an item that is now in
+// the recycle bin.
+type DeletedItemLink struct {
+	RecycleBinID string
+	AuditRunID   int64 // The last earlier run to capture an item at the deleted item's location
+	AuditRunAt   time.Time
+	ItemGUID     string
+	ListID       string
+	ItemName     string
+	LinkID       string
+	LinkKind     int
+	Scope        int
+	MembersCount int64
+	IsEditLink   bool
+	Expiration   *time.Time
+}
+
 // SiteContentAggregateRepository handles operations across sites, lists, items, assignments, and sharing.
 type SiteContentAggregateRepository interface {
 	// Site operations with metadata
 	GetSiteWithMetadata(ctx context.Context, siteID int64) (*SiteWithMetadata, error)
 	GetAllSitesWithMetadata(ctx context.Context) ([]*SiteWithMetadata, error)
+	GetAllSitesWithLatestRunMetadata(ctx context.Context, preferCompleted bool, label string) ([]*SiteWithMetadata, error)
 
 	// Site browsing operations
 	SearchSites(ctx context.Context, searchQuery string) ([]*SiteWithMetadata, error)
+	GetSiteByURL(ctx context.Context, siteURL string) (*sharepoint.Site, error)
+
+	// Web operations (audit-scoped)
+	GetWebsForSite(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.Web, error)
 
 	// List operations
 	GetListByID(ctx context.Context, siteID int64, listID string) (*sharepoint.List, error)
 	GetListsForSite(ctx context.Context, siteID int64) ([]*sharepoint.List, error)
 
-	// List assignment operations (audit-scoped)
+	// Assignment operations (audit-scoped)
 	GetListAssignmentsWithRootCause(ctx context.Context, siteID int64, auditRunID int64, listID string) ([]*sharepoint.ResolvedAssignment, error)
+	GetResolvedAssignmentsForObject(ctx context.Context, siteID int64, auditRunID int64, objectType, objectKey string) ([]*sharepoint.ResolvedAssignment, error)
+	GetResolvedAssignmentsPageForObject(ctx context.Context, siteID int64, auditRunID int64, objectType, objectKey string, offset, limit int) ([]*sharepoint.ResolvedAssignment, error)
 	GetAssignmentsForObject(ctx context.Context, siteID int64, auditRunID int64, objectType, objectKey string) ([]*sharepoint.Assignment, error)
 
+	// List change operations between a base audit run and a later one. Fails with ErrRowCapExceeded
+	// once more than maxRows changes are found; maxRows <= 0 lifts the cap.
+	GetListChanges(ctx context.Context, siteID int64, listID string, baseAuditRunID, auditRunID int64, maxRows int) (*ListChanges, error)
+	GetRunChanges(ctx context.Context, siteID int64, baseAuditRunID, auditRunID int64, maxRows int) (*RunChanges, error)
+
+	// Run fact operations (audit-scoped). Fails with ErrRowCapExceeded once more than maxRows
+	// facts are found; maxRows <= 0 lifts the cap.
+	GetRunFacts(ctx context.Context, siteID int64, auditRunID int64, maxRows int) (*RunFacts, error)
+	GetSharingLinkFacts(ctx context.Context, siteID int64, auditRunID int64, maxRows int) ([]*SharingLinkFact, error)
+	GetSharingLinkFactsCreatedBy(ctx context.Context, siteID int64, auditRunID int64, principalID int64) ([]*SharingLinkFact, error)
+	GetItemsOwnedBy(ctx context.Context, siteID int64, auditRunID int64, email string) ([]*OwnedItem, error)
+	GetItemLabels(ctx context.Context, siteID int64, auditRunID int64) (map[string]string, error)
+
+	// Principal operations (audit-scoped)
+	GetPrincipalsForAuditRun(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.Principal, error)
+	GetGroupIDsForMember(ctx context.Context, siteID int64, auditRunID int64, principalID int64) ([]int64, error)
+	GetGroupMembersForAuditRun(ctx context.Context, siteID int64, auditRunID int64, groupID int64) ([]*sharepoint.Principal, error)
+	GetEntraGroupIDsForUser(ctx context.Context, siteID int64, auditRunID int64, identity string) ([]int64, error)
+	GetSharingLinkIDsForMember(ctx context.Context, siteID int64, auditRunID int64, principalID int64) ([]string, error)
+
 	// List item operations
 	GetListItems(ctx context.Context, siteID int64, listID string, offset, limit int) ([]*sharepoint.Item, error)
+	GetAllListItems(ctx context.Context, siteID int64, listID string, offset, limit int) ([]*sharepoint.Item, error)
+	GetFilteredListItems(ctx context.Context, siteID int64, listID string, filter ItemFilter, offset, limit int) ([]*sharepoint.Item, error)
+	CountFilteredListItems(ctx context.Context, siteID int64, listID string, filter ItemFilter) (int64, error)
+	GetItemByGUID(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.Item, error)
+	GetItemAttachments(ctx context.Context, siteID int64, auditRunID int64, itemGUID string) ([]*sharepoint.ItemAttachment, error)
 
 	// List sharing operations
 	GetListSharingLinks(ctx context.Context, siteID int64, listID string) ([]*sharepoint.SharingLink, error)
 	GetListSharingLinksWithItemData(ctx context.Context, siteID int64, listID string) ([]*sharepoint.SharingLinkWithItemData, error)
 	GetSharingLinkMembers(ctx context.Context, siteID int64, linkID string) ([]*sharepoint.Principal, error)
+	GetPreviousSharingLinkAuditRun(ctx context.Context, siteID int64, auditRunID int64, linkID string) (int64, error)
+	GetSharingLinkMembersForAuditRun(ctx context.Context, siteID int64, auditRunID int64, linkID string) ([]*sharepoint.Principal, error)
+	GetSharingLinkForAuditRun(ctx context.Context, siteID int64, auditRunID int64, linkID string) (*sharepoint.SharingLinkWithItemData, error)
+
+	// Anonymous sharing link operations (audit-scoped), across every list of the site
+	GetAnonymousSharingLinksForAuditRun(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.AnonymousSharingLink, error)
+	GetLinkCreatorsForAuditRun(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.LinkCreator, error)
+
+	// Sharing governance operations (audit-scoped)
+	GetSharingGovernanceForAuditRun(ctx context.Context, siteID int64, auditRunID int64) (*sharepoint.SharingGovernance, error)
+	GetPreviousSharingGovernanceAuditRun(ctx context.Context, siteID int64, auditRunID int64) (int64, error)
+
+	// List sensitivity label operations
+	GetListSensitivityLabels(ctx context.Context, siteID int64, listID string) ([]*sharepoint.ItemSensitivityLabel, error)
+	GetItemSensitivityLabel(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.ItemSensitivityLabel, error)
+
+	// Recycle bin operations (audit-scoped). The scan time is nil when the run did not scan the recycle bin.
+	GetRecycleBinScan(ctx context.Context, siteID int64, auditRunID int64) (*time.Time, error)
+	GetRecycleBinItems(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.RecycleBinItem, error)
+	GetDeletedItemLinks(ctx context.Context, siteID int64, auditRunID int64) ([]*DeletedItemLink, error)
+
+	// Installed app operations (audit-scoped). The collection time is nil when the run did not collect installed apps.
+	GetAppInventory(ctx context.Context, siteID int64, auditRunID int64) (*time.Time, error)
+	GetInstalledApps(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.InstalledApp, error)
+	GetAppPermissionFacts(ctx context.Context, siteID int64, auditRunID int64) ([]*PermissionFact, error)
+
+	// Graph app grant operations (audit-scoped). The scan time is nil when the run did not read Graph grants.
+	GetGraphGrantScan(ctx context.Context, siteID int64, auditRunID int64) (*time.Time, error)
+	GetGraphAppGrants(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.GraphAppGrant, error)
+
+	// Hub operations (audit-scoped). The association is nil when the run did not collect it.
+	GetHubAssociation(ctx context.Context, siteID int64, auditRunID int64) (*sharepoint.HubAssociation, error)
 
 	// Job/audit date operations
 	GetLastAuditDate(ctx context.Context, siteID int64) (*time.Time, error)
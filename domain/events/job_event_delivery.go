@@ -0,0 +1,54 @@
+package events
+
+import (
+	"errors"
+	"time"
+)
+
+// Default retry policy of durable event handlers
+const (
+	DefaultDeliveryAttempts = 5
+	DefaultDeliveryBackoff  = 2 * time.Second
+	maxDeliveryBackoff      = 5 * time.Minute
+)
+
+// RetryPolicy controls how often a durable handler is called with an event it fails on before the
+// event is moved to the dead-letter list
+type RetryPolicy struct {
+	MaxAttempts    int           // Calls before giving up, including the first
+	InitialBackoff time.Duration // Wait before the second call, doubling for each further call
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: DefaultDeliveryAttempts, InitialBackoff: DefaultDeliveryBackoff}
+}
+
+// Backoff returns the wait after a failed attempt, counted from 1, capped at five minutes
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && backoff < maxDeliveryBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > maxDeliveryBackoff {
+		return maxDeliveryBackoff
+	}
+	return backoff
+}
+
+// DeadLetter is an event a durable handler still failed on after its retries. The handler has moved
+// on past it; retrying delivers the event to the handler once more.
+type DeadLetter struct {
+	ID        int64
+	Handler   string
+	Sequence  int64 // The event in the event log
+	Attempts  int   // Failed calls so far, including retries from the dead-letter list
+	LastError string
+	FailedAt  time.Time
+}
+
+// ErrUnknownHandler is returned when retrying a dead letter of a handler that is no longer subscribed
+var ErrUnknownHandler = errors.New("unknown event handler")
+
+// ErrHandlerFailed is returned when a handler fails on a dead letter's event again
+var ErrHandlerFailed = errors.New("event handler failed")
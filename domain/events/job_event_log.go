@@ -0,0 +1,73 @@
+package events
+
+import (
+	"time"
+
+	"spaudit/domain/jobs"
+)
+
+// JobEventType names a kind of job event in the event log
+type JobEventType string
+
+// Job event types
+const (
+	JobEventCompleted          JobEventType = "job_completed"
+	JobEventFailed             JobEventType = "job_failed"
+	JobEventCancelled          JobEventType = "job_cancelled"
+	JobEventSiteAuditCompleted JobEventType = "site_audit_completed"
+)
+
+// JobEventTypes lists every job event type
+var JobEventTypes = []JobEventType{JobEventCompleted, JobEventFailed, JobEventCancelled, JobEventSiteAuditCompleted}
+
+// IsValid returns true if the event type is known
+func (t JobEventType) IsValid() bool {
+	for _, known := range JobEventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// RecordedJobEvent is a job event as kept in the event log, numbered in the order it was published.
+// It records the job as it was when the event was published.
+type RecordedJobEvent struct {
+	Sequence   int64 // Set when the event is appended; increases with every event
+	Type       JobEventType
+	JobID      string
+	JobType    jobs.JobType
+	JobStatus  jobs.JobStatus
+	SiteURL    string
+	AuditRunID int64 // 0 when the job has no audit run
+	Error      string
+	OccurredAt time.Time
+}
+
+// NewRecordedJobEvent records an event about a job; the job may be nil
+func NewRecordedJobEvent(eventType JobEventType, job *jobs.Job, siteURL, errorMessage string, occurredAt time.Time) *RecordedJobEvent {
+	event := &RecordedJobEvent{
+		Type:       eventType,
+		SiteURL:    siteURL,
+		Error:      errorMessage,
+		OccurredAt: occurredAt.UTC(),
+	}
+	if job != nil {
+		event.JobID = job.ID
+		event.JobType = job.Type
+		event.JobStatus = job.Status
+		event.AuditRunID = job.GetAuditRunID()
+		if event.SiteURL == "" {
+			event.SiteURL = job.GetSiteURL()
+		}
+	}
+	return event
+}
+
+// JobEventFilter selects events from the event log for replay
+type JobEventFilter struct {
+	AfterSequence int64        // Only events after this sequence number; 0 from the start
+	Type          JobEventType // Only events of this type; empty for every type
+	JobID         string       // Only events about this job; empty for every job
+	Limit         int
+}
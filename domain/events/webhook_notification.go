@@ -0,0 +1,127 @@
+package events
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidWebhookURLs is returned by ParseWebhookURLs for malformed webhook endpoints.
+var ErrInvalidWebhookURLs = errors.New("invalid webhook URLs")
+
+// Default retry policy of webhook deliveries, giving an endpoint about twenty minutes to come back
+const (
+	DefaultWebhookAttempts = 8
+	DefaultWebhookBackoff  = 30 * time.Second
+)
+
+// WebhookEventType names what a notification webhook announces
+type WebhookEventType string
+
+// Webhook event types
+const (
+	WebhookJobCompleted   WebhookEventType = "job_completed"
+	WebhookJobFailed      WebhookEventType = "job_failed"
+	WebhookFindingCreated WebhookEventType = "finding_created"
+)
+
+// Statuses of webhook deliveries
+const (
+	WebhookDeliveryPending   = "pending"   // Waiting for its first or next attempt
+	WebhookDeliveryDelivered = "delivered" // The endpoint answered with a 2xx status
+	WebhookDeliveryFailed    = "failed"    // Every attempt failed; retrying delivers it once more
+)
+
+// Headers sent with every webhook delivery
+const (
+	WebhookEventHeader     = "X-Spaudit-Event"
+	WebhookDeliveryHeader  = "X-Spaudit-Delivery"
+	WebhookTimestampHeader = "X-Spaudit-Timestamp"
+	WebhookSignatureHeader = "X-Spaudit-Signature"
+)
+
+// WebhookDelivery is a notification posted, or to be posted, to one webhook endpoint. Each event is
+// delivered once per endpoint, however often it is announced.
+type WebhookDelivery struct {
+	ID             int64
+	EndpointURL    string
+	EventType      WebhookEventType
+	EventKey       string // Identifies the event, e.g. job_event:{sequence}
+	Payload        []byte
+	Status         string
+	Attempts       int
+	ResponseStatus int // HTTP status of the last attempt, 0 when no response came
+	LastError      string
+	CreatedAt      time.Time
+	NextAttemptAt  *time.Time // Set while pending
+	DeliveredAt    *time.Time
+}
+
+// WebhookPayload is the JSON body posted to webhook endpoints. Job is set for job events and
+// Finding for findings.
+type WebhookPayload struct {
+	Type       WebhookEventType `json:"type"`
+	OccurredAt time.Time        `json:"occurred_at"`
+	Job        *WebhookJob      `json:"job,omitempty"`
+	Finding    *WebhookFinding  `json:"finding,omitempty"`
+}
+
+// WebhookJob is the job a webhook event is about, as it was when the event was published.
+type WebhookJob struct {
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	Status     string `json:"status"`
+	SiteURL    string `json:"site_url,omitempty"`
+	AuditRunID int64  `json:"audit_run_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Sequence   int64  `json:"sequence"` // The event in the job event log
+}
+
+// WebhookFinding is a finding alert raised for an audit run.
+type WebhookFinding struct {
+	AlertID     int64  `json:"alert_id"`
+	SiteID      int64  `json:"site_id"`
+	AuditRunID  int64  `json:"audit_run_id"`
+	Fingerprint string `json:"fingerprint"`
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Message     string `json:"message"`
+}
+
+// ParseWebhookURLs parses a comma-separated list of absolute http or https URLs, dropping duplicates.
+// An empty spec has no endpoints.
+func ParseWebhookURLs(spec string) ([]string, error) {
+	var endpoints []string
+	seen := map[string]bool{}
+	for _, raw := range strings.Split(spec, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		parsed, err := url.Parse(raw)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("%w: %q is not an absolute http or https URL", ErrInvalidWebhookURLs, raw)
+		}
+		if !seen[raw] {
+			seen[raw] = true
+			endpoints = append(endpoints, raw)
+		}
+	}
+	return endpoints, nil
+}
+
+// SignWebhookPayload returns the signature header of a webhook delivery, "sha256=" and the hex
+// HMAC-SHA256 of the Unix timestamp, a dot and the body. Receivers recompute it with the shared secret
+// and reject stale timestamps so a captured delivery cannot be replayed.
+func SignWebhookPayload(secret []byte, timestamp time.Time, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strconv.FormatInt(timestamp.Unix(), 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,36 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWebhookURLs(t *testing.T) {
+	endpoints, err := ParseWebhookURLs("")
+	require.NoError(t, err)
+	assert.Empty(t, endpoints)
+
+	endpoints, err = ParseWebhookURLs(" https://hooks.contoso.com/spaudit , http://10.0.0.5:9000/in,https://hooks.contoso.com/spaudit,")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://hooks.contoso.com/spaudit", "http://10.0.0.5:9000/in"}, endpoints)
+
+	for _, spec := range []string{"hooks.contoso.com/spaudit", "ftp://hooks.contoso.com", "https://", "https://ok.example.com,/relative"} {
+		_, err := ParseWebhookURLs(spec)
+		assert.ErrorIs(t, err, ErrInvalidWebhookURLs, spec)
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	timestamp := time.Unix(1700000000, 0)
+	body := []byte(`{"type":"job_completed"}`)
+
+	signature := SignWebhookPayload([]byte("secret"), timestamp, body)
+	assert.Regexp(t, `^sha256=[0-9a-f]{64}$`, signature)
+	assert.Equal(t, signature, SignWebhookPayload([]byte("secret"), timestamp, body))
+	assert.NotEqual(t, signature, SignWebhookPayload([]byte("other"), timestamp, body))
+	assert.NotEqual(t, signature, SignWebhookPayload([]byte("secret"), timestamp.Add(time.Second), body))
+	assert.NotEqual(t, signature, SignWebhookPayload([]byte("secret"), timestamp, []byte(`{"type":"job_failed"}`)))
+}
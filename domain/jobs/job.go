@@ -22,7 +22,10 @@ const (
 type JobType string
 
 const (
-	JobTypeSiteAudit JobType = "site_audit"
+	JobTypeSiteAudit           JobType = "site_audit"
+	JobTypeDatabaseMaintenance JobType = "database_maintenance"
+	JobTypeTenantAudit         JobType = "tenant_audit"      // Audits every site collection of a tenant as child site audits
+	JobTypeOffboardingCheck    JobType = "offboarding_check" // Checks what a leaver still has across every audited site
 )
 
 // JobProgress represents detailed progress information.
@@ -166,6 +169,12 @@ func (j *Job) GetJobTypeDisplayName() string {
 	switch j.Type {
 	case JobTypeSiteAudit:
 		return "Site Audit"
+	case JobTypeDatabaseMaintenance:
+		return "Database Maintenance"
+	case JobTypeTenantAudit:
+		return "Tenant Audit"
+	case JobTypeOffboardingCheck:
+		return "Offboarding Check"
 	default:
 		return string(j.Type)
 	}
@@ -63,6 +63,32 @@ func (jl *JobLifecycle) CancelJob(job *Job) error {
 	return nil
 }
 
+// ResumeJob transitions a failed or cancelled job back to running. Its state is kept rather than
+// initialized, so the timeline shows where the job stopped and where it resumed.
+func (jl *JobLifecycle) ResumeJob(job *Job) error {
+	if job.Status != JobStatusFailed && job.Status != JobStatusCancelled {
+		return fmt.Errorf("cannot resume job in status: %s", job.Status)
+	}
+
+	job.Status = JobStatusRunning
+	job.Error = ""
+	job.CompletedAt = nil
+
+	now := time.Now()
+	job.State.Stage = "resumed"
+	job.State.CurrentOperation = "Resuming audit from its checkpoints..."
+	job.State.StageStartedAt = now
+	job.State.Timeline = append(job.State.Timeline, JobStageInfo{
+		Stage:   "resumed",
+		Started: now,
+	})
+	job.State.Messages = append(job.State.Messages, fmt.Sprintf("[resumed] %s", job.State.CurrentOperation))
+	if len(job.State.Messages) > 10 {
+		job.State.Messages = job.State.Messages[1:]
+	}
+	return nil
+}
+
 // finalizeJobState handles consistent state finalization for completed jobs
 func (jl *JobLifecycle) finalizeJobState(job *Job, stage, operation string) {
 	// State is always initialized
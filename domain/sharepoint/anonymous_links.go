@@ -0,0 +1,40 @@
+package sharepoint
+
+import "time"
+
+// LinkExpiringWindow is how close to its expiration a sharing link is reported as expiring.
+const LinkExpiringWindow = 30 * 24 * time.Hour
+
+// Expiration statuses of sharing links
+const (
+	LinkExpirationNone     = "none"     // The link never expires
+	LinkExpirationActive   = "active"   // The link expires after LinkExpiringWindow
+	LinkExpirationExpiring = "expiring" // The link expires within LinkExpiringWindow
+	LinkExpirationExpired  = "expired"  // The link has expired since the audit run captured it
+)
+
+// ExpirationStatus returns how the link's expiration stands at now.
+func (s *SharingLink) ExpirationStatus(now time.Time) string {
+	switch {
+	case s.Expiration == nil || s.Expiration.IsZero():
+		return LinkExpirationNone
+	case !s.Expiration.After(now):
+		return LinkExpirationExpired
+	case s.Expiration.Sub(now) <= LinkExpiringWindow:
+		return LinkExpirationExpiring
+	default:
+		return LinkExpirationActive
+	}
+}
+
+// AnonymousSharingLink is an active anonymous sharing link on an item of any of a site's lists,
+// with the list and item it is on.
+type AnonymousSharingLink struct {
+	*SharingLink
+	ListID       string
+	ListTitle    string // Empty when the run did not capture the list
+	ItemName     string
+	ItemURL      string
+	ItemIsFile   bool
+	ItemIsFolder bool
+}
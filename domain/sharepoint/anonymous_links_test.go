@@ -0,0 +1,23 @@
+package sharepoint
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSharingLink_ExpirationStatus(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		expiration := now.Add(d)
+		return &expiration
+	}
+
+	assert.Equal(t, LinkExpirationNone, (&SharingLink{}).ExpirationStatus(now))
+	assert.Equal(t, LinkExpirationNone, (&SharingLink{Expiration: &time.Time{}}).ExpirationStatus(now))
+	assert.Equal(t, LinkExpirationExpired, (&SharingLink{Expiration: at(-time.Hour)}).ExpirationStatus(now))
+	assert.Equal(t, LinkExpirationExpired, (&SharingLink{Expiration: at(0)}).ExpirationStatus(now))
+	assert.Equal(t, LinkExpirationExpiring, (&SharingLink{Expiration: at(LinkExpiringWindow)}).ExpirationStatus(now))
+	assert.Equal(t, LinkExpirationActive, (&SharingLink{Expiration: at(LinkExpiringWindow + time.Hour)}).ExpirationStatus(now))
+}
@@ -0,0 +1,55 @@
+package sharepoint
+
+import "strings"
+
+// Login name prefixes of security group principals that stand for an Entra group
+const (
+	entraSecurityGroupPrefix = "c:0t.c|tenant|"                          // c:0t.c|tenant|<object ID>
+	entraM365GroupPrefix     = "c:0o.c|federateddirectoryclaimprovider|" // c:0o.c|federateddirectoryclaimprovider|<object ID>, "_o" suffixed for owners
+	entraM365OwnersSuffix    = "_o"
+)
+
+// EntraGroupRef identifies the Entra group a security group principal stands for.
+type EntraGroupRef struct {
+	ObjectID string
+	Owners   bool // The principal stands for the owners of a Microsoft 365 group, not its members
+}
+
+// EntraGroupMember is a user an Entra group reaches, directly or through nested groups, as Microsoft
+// Graph resolved it for an audit run.
+type EntraGroupMember struct {
+	SiteID            int64
+	AuditRunID        int64
+	PrincipalID       int64 // The security group principal the member was resolved for
+	ObjectID          string
+	DisplayName       string
+	UserPrincipalName string
+	Mail              string
+	UserType          string // Member or Guest
+}
+
+// EntraGroup returns the Entra group a security group principal stands for. ok is false for other
+// principals, and for claims such as "Everyone except external users" that no group backs.
+func (p *Principal) EntraGroup() (group EntraGroupRef, ok bool) {
+	if p.PrincipalType != PrincipalTypeSecurity {
+		return EntraGroupRef{}, false
+	}
+	login := strings.ToLower(p.LoginName)
+	switch {
+	case strings.HasPrefix(login, entraSecurityGroupPrefix):
+		group.ObjectID = strings.TrimPrefix(login, entraSecurityGroupPrefix)
+	case strings.HasPrefix(login, entraM365GroupPrefix):
+		group.ObjectID, group.Owners = strings.CutSuffix(strings.TrimPrefix(login, entraM365GroupPrefix), entraM365OwnersSuffix)
+	default:
+		return EntraGroupRef{}, false
+	}
+	if group.ObjectID == "" || strings.ContainsAny(group.ObjectID, "|/") {
+		return EntraGroupRef{}, false
+	}
+	return group, true
+}
+
+// IsGuest returns true for members Entra ID records as guests.
+func (m *EntraGroupMember) IsGuest() bool {
+	return strings.EqualFold(m.UserType, "Guest")
+}
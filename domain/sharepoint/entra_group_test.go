@@ -0,0 +1,55 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal_EntraGroup(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal Principal
+		expected  EntraGroupRef
+		ok        bool
+	}{
+		{
+			name:      "security group",
+			principal: Principal{PrincipalType: PrincipalTypeSecurity, LoginName: "c:0t.c|tenant|5F1D4C2A-1111-2222-3333-444455556666"},
+			expected:  EntraGroupRef{ObjectID: "5f1d4c2a-1111-2222-3333-444455556666"},
+			ok:        true,
+		},
+		{
+			name:      "microsoft 365 group members",
+			principal: Principal{PrincipalType: PrincipalTypeSecurity, LoginName: "c:0o.c|federateddirectoryclaimprovider|0c9e8b7a-aaaa-bbbb-cccc-ddddeeeeffff"},
+			expected:  EntraGroupRef{ObjectID: "0c9e8b7a-aaaa-bbbb-cccc-ddddeeeeffff"},
+			ok:        true,
+		},
+		{
+			name:      "microsoft 365 group owners",
+			principal: Principal{PrincipalType: PrincipalTypeSecurity, LoginName: "c:0o.c|federateddirectoryclaimprovider|0c9e8b7a-aaaa-bbbb-cccc-ddddeeeeffff_o"},
+			expected:  EntraGroupRef{ObjectID: "0c9e8b7a-aaaa-bbbb-cccc-ddddeeeeffff", Owners: true},
+			ok:        true,
+		},
+		{
+			name:      "everyone except external users",
+			principal: Principal{PrincipalType: PrincipalTypeSecurity, LoginName: "c:0-.f|rolemanager|spo-grid-all-users/5f1d4c2a-1111-2222-3333-444455556666"},
+		},
+		{
+			name:      "everyone",
+			principal: Principal{PrincipalType: PrincipalTypeSecurity, LoginName: "c:0(.s|true"},
+		},
+		{
+			name:      "user",
+			principal: Principal{PrincipalType: PrincipalTypeUser, LoginName: "i:0#.f|membership|alice@contoso.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			group, ok := tt.principal.EntraGroup()
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, group)
+		})
+	}
+}
@@ -99,17 +99,3 @@ func PrincipalTypeNames(v int) []string {
 	}
 	return out
 }
-
-func PrincipalTypeName(t int) string {
-	// common SharePoint principal types (trimmed)
-	switch t {
-	case 1:
-		return "User"
-	case 4:
-		return "SecurityGroup"
-	case 8:
-		return "SharePointGroup"
-	default:
-		return fmt.Sprintf("%d", t)
-	}
-}
@@ -0,0 +1,64 @@
+package sharepoint
+
+import "strings"
+
+// Kinds of Microsoft Graph grants, which let an app reach a site without any SharePoint role assignment
+const (
+	GraphGrantSiteSelected = "site_selected" // A site permission given to an app holding Sites.Selected
+	GraphGrantTenantWide   = "tenant_wide"   // An application role over every site, such as Sites.FullControl.All
+)
+
+// TenantWideSiteRoles are the application roles of Microsoft Graph and SharePoint that reach every
+// site in the tenant. Sites.Selected is not one: it reaches only the sites granted to the app.
+var TenantWideSiteRoles = []string{
+	"Sites.FullControl.All",
+	"Sites.Manage.All",
+	"Sites.ReadWrite.All",
+	"Sites.Read.All",
+}
+
+// GraphAppGrant is an Entra app's access to a site through Microsoft Graph. It never appears in the
+// site's role assignments, so it is audited separately.
+type GraphAppGrant struct {
+	SiteID     int64
+	AuditRunID int64
+	GrantID    string // Site permission ID, or app role assignment ID of a tenant-wide grant
+	Kind       string
+	AppID      string // Client ID of the app registration
+	AppName    string
+	Roles      []string // read, write, manage, fullcontrol or owner for site grants; the application role for tenant-wide grants
+	Resource   string   // The API a tenant-wide role belongs to, Microsoft Graph or SharePoint; empty for site grants
+}
+
+// IsTenantWideSiteRole returns true if the application role reaches every site.
+func IsTenantWideSiteRole(role string) bool {
+	for _, siteRole := range TenantWideSiteRoles {
+		if strings.EqualFold(role, siteRole) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFullControl returns true if the grant lets the app do anything on the site, permissions included.
+func (g *GraphAppGrant) HasFullControl() bool {
+	for _, role := range g.Roles {
+		switch strings.ToLower(role) {
+		case "fullcontrol", "owner", "sites.fullcontrol.all":
+			return true
+		}
+	}
+	return false
+}
+
+// CanWrite returns true if the grant lets the app change the site's content.
+func (g *GraphAppGrant) CanWrite() bool {
+	for _, role := range g.Roles {
+		switch strings.ToLower(role) {
+		case "read", "sites.read.all":
+		default:
+			return true
+		}
+	}
+	return false
+}
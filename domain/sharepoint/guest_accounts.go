@@ -0,0 +1,67 @@
+package sharepoint
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Markers of B2B guest accounts in claims login names
+const (
+	guestExtMarker = "#ext#"          // i:0#.f|membership|alice_partner.com#ext#@contoso.onmicrosoft.com
+	guestSPOMarker = "urn:spo:guest#" // i:0#.f|membership|urn:spo:guest#alice@partner.com
+)
+
+// IsGuest returns true for B2B guest accounts, whose login names carry #ext# or urn:spo:guest.
+func (p *Principal) IsGuest() bool {
+	login := strings.ToLower(p.LoginName)
+	return strings.Contains(login, guestExtMarker) || strings.Contains(login, guestSPOMarker)
+}
+
+// GuestHomeEmail returns the email address a guest was invited with, which stays the same in every
+// tenant that invited them while login names differ per tenant. It is taken from the login name,
+// else from the email field. ok is false for principals that are not guests or carry no address.
+func (p *Principal) GuestHomeEmail() (email string, ok bool) {
+	if !p.IsGuest() {
+		return "", false
+	}
+	account := strings.ToLower(p.LoginName)
+	if i := strings.LastIndex(account, "|"); i >= 0 {
+		account = account[i+1:]
+	}
+
+	switch {
+	case strings.HasPrefix(account, guestSPOMarker):
+		email = strings.TrimPrefix(account, guestSPOMarker)
+	case strings.Contains(account, guestExtMarker):
+		// The invited address with its @ replaced by the last underscore; domains hold no underscores
+		local := account[:strings.Index(account, guestExtMarker)]
+		if i := strings.LastIndex(local, "_"); i > 0 {
+			email = local[:i] + "@" + local[i+1:]
+		}
+	}
+	if !strings.Contains(email, "@") {
+		email = strings.ToLower(strings.TrimSpace(p.Email))
+	}
+	if !strings.Contains(email, "@") || strings.Contains(email, guestExtMarker) {
+		return "", false
+	}
+	return email, true
+}
+
+// TenantForSiteURL returns the tenant of a SharePoint Online site URL: "contoso" for sites on
+// contoso.sharepoint.com and OneDrives on contoso-my.sharepoint.com. Other hosts, such as
+// SharePoint Server farms, are returned whole as their own tenant.
+func TenantForSiteURL(siteURL string) string {
+	parsed, err := url.Parse(strings.TrimSpace(siteURL))
+	if err != nil || parsed.Host == "" {
+		return strings.ToLower(siteURL)
+	}
+	host := strings.ToLower(parsed.Hostname())
+	tenant, ok := strings.CutSuffix(host, ".sharepoint.com")
+	if !ok {
+		return host
+	}
+	tenant, _ = strings.CutSuffix(tenant, "-my")
+	tenant, _ = strings.CutSuffix(tenant, "-admin")
+	return tenant
+}
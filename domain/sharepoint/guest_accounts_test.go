@@ -0,0 +1,59 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal_GuestHomeEmail(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal Principal
+		expected  string
+		ok        bool
+	}{
+		{
+			name:      "ext login name",
+			principal: Principal{LoginName: "i:0#.f|membership|alice.smith_partner.com#EXT#@contoso.onmicrosoft.com"},
+			expected:  "alice.smith@partner.com",
+			ok:        true,
+		},
+		{
+			name:      "underscore in the local part",
+			principal: Principal{LoginName: "i:0#.f|membership|bob_jones_partner.co.uk#ext#@fabrikam.onmicrosoft.com"},
+			expected:  "bob_jones@partner.co.uk",
+			ok:        true,
+		},
+		{
+			name:      "spo guest login name",
+			principal: Principal{LoginName: "i:0#.f|membership|urn:spo:guest#Alice.Smith@Partner.com"},
+			expected:  "alice.smith@partner.com",
+			ok:        true,
+		},
+		{
+			name:      "email when the login name carries no address",
+			principal: Principal{LoginName: "i:0#.f|membership|#ext#@contoso.onmicrosoft.com", Email: "Alice@Partner.com"},
+			expected:  "alice@partner.com",
+			ok:        true,
+		},
+		{
+			name:      "member account",
+			principal: Principal{LoginName: "i:0#.f|membership|alice@contoso.com", Email: "alice@contoso.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			email, ok := tt.principal.GuestHomeEmail()
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, email)
+		})
+	}
+}
+
+func TestTenantForSiteURL(t *testing.T) {
+	assert.Equal(t, "contoso", TenantForSiteURL("https://Contoso.sharepoint.com/sites/Finance"))
+	assert.Equal(t, "contoso", TenantForSiteURL("https://contoso-my.sharepoint.com/personal/alice"))
+	assert.Equal(t, "intranet.fabrikam.local", TenantForSiteURL("http://intranet.fabrikam.local/sites/hr"))
+}
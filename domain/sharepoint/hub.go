@@ -0,0 +1,47 @@
+package sharepoint
+
+import "strings"
+
+// emptyHubSiteID is the hub ID SharePoint reports for a site associated with no hub
+const emptyHubSiteID = "00000000-0000-0000-0000-000000000000"
+
+// HubAssociation is the hub a site was associated with when an audit run collected it. Hubs group the
+// sites of a department or project: every associated site shows the hub's navigation, and a hub with
+// permission sync grants its visitors read access to every associated site.
+type HubAssociation struct {
+	SiteID          int64
+	AuditRunID      int64
+	IsHub           bool   // The site is itself a hub, and is associated with it
+	HubSiteID       string // Empty when the site is associated with no hub
+	HubTitle        string // Empty when the hub could not be read
+	HubURL          string
+	ParentHubSiteID string // The hub the hub is associated with, empty for top-level hubs
+	PermissionsSync bool   // The hub's visitors are synced to the site's visitors
+}
+
+// NormalizeHubSiteID returns a hub ID in lower case, or empty for the empty GUID SharePoint reports
+// when a site is associated with no hub.
+func NormalizeHubSiteID(id string) string {
+	id = strings.ToLower(strings.Trim(strings.TrimSpace(id), "{}"))
+	if id == emptyHubSiteID {
+		return ""
+	}
+	return id
+}
+
+// IsAssociated returns true if the site is associated with a hub, its own included.
+func (h *HubAssociation) IsAssociated() bool {
+	return h.HubSiteID != ""
+}
+
+// InheritsNavigation returns true if the site shows the navigation of a hub other than its own, which
+// lists the hub's sites to everyone who can open it.
+func (h *HubAssociation) InheritsNavigation() bool {
+	return h.IsAssociated() && !h.IsHub
+}
+
+// InheritsVisitors returns true if the hub's visitors are granted read access to the site without
+// appearing in its own role assignments.
+func (h *HubAssociation) InheritsVisitors() bool {
+	return h.InheritsNavigation() && h.PermissionsSync
+}
@@ -0,0 +1,31 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeHubSiteID(t *testing.T) {
+	assert.Equal(t, "", NormalizeHubSiteID("00000000-0000-0000-0000-000000000000"))
+	assert.Equal(t, "", NormalizeHubSiteID(""))
+	assert.Equal(t, "5a3c2b1d-0000-4000-8000-000000000001", NormalizeHubSiteID("{5A3C2B1D-0000-4000-8000-000000000001}"))
+}
+
+func TestHubAssociation_Inheritance(t *testing.T) {
+	none := &HubAssociation{}
+	assert.False(t, none.IsAssociated())
+	assert.False(t, none.InheritsNavigation())
+
+	hub := &HubAssociation{IsHub: true, HubSiteID: "hub-1", PermissionsSync: true}
+	assert.True(t, hub.IsAssociated())
+	assert.False(t, hub.InheritsNavigation(), "a hub shows its own navigation")
+	assert.False(t, hub.InheritsVisitors())
+
+	member := &HubAssociation{HubSiteID: "hub-1"}
+	assert.True(t, member.InheritsNavigation())
+	assert.False(t, member.InheritsVisitors())
+
+	member.PermissionsSync = true
+	assert.True(t, member.InheritsVisitors())
+}
@@ -0,0 +1,68 @@
+package sharepoint
+
+import (
+	"strings"
+	"time"
+)
+
+// App sources (SP.AppSource) of the apps an audit keeps. Lists and libraries are also shown as app
+// tiles, with none of these sources.
+const (
+	AppSourceMarketplace                    = 1 // The SharePoint Store
+	AppSourceCorporateCatalog               = 2 // The tenant app catalog
+	AppSourceDeveloperSite                  = 3 // Sideloaded on a developer site
+	AppSourceSiteCollectionCorporateCatalog = 6 // A site collection app catalog
+)
+
+// InstalledApp is a SharePoint add-in or SPFx app installed on a site. What an app can do is granted
+// to its principal, whose role assignments are captured like those of users.
+type InstalledApp struct {
+	SiteID         int64
+	AuditRunID     int64
+	AppID          string // App instance ID
+	ProductID      string // The app package's ID, the same on every site it is installed on
+	Title          string
+	AppSource      int    // SP.AppSource
+	AppPrincipalID string // Login name of the app's principal, empty for apps that requested no permissions
+	LastModified   *time.Time
+}
+
+// IsInstalledApp returns true if an app tile with the source is an installed app rather than a list or library.
+func IsInstalledApp(appSource int) bool {
+	switch appSource {
+	case AppSourceMarketplace, AppSourceCorporateCatalog, AppSourceDeveloperSite, AppSourceSiteCollectionCorporateCatalog:
+		return true
+	default:
+		return false
+	}
+}
+
+// SourceName names where the app was installed from, for display.
+func (a *InstalledApp) SourceName() string {
+	switch a.AppSource {
+	case AppSourceMarketplace:
+		return "store"
+	case AppSourceCorporateCatalog:
+		return "tenant_catalog"
+	case AppSourceDeveloperSite:
+		return "developer_site"
+	case AppSourceSiteCollectionCorporateCatalog:
+		return "site_catalog"
+	default:
+		return "other"
+	}
+}
+
+// AppClientID returns the client ID in an app principal's login name, such as
+// i:0i.t|ms.sp.ext|{client ID}@{realm}, lowercased. Login names without a claims prefix or realm are
+// taken as they are. Installed apps and role assignments name the same principal in either form.
+func AppClientID(loginName string) string {
+	clientID := loginName
+	if i := strings.LastIndex(clientID, "|"); i >= 0 {
+		clientID = clientID[i+1:]
+	}
+	if i := strings.Index(clientID, "@"); i >= 0 {
+		clientID = clientID[:i]
+	}
+	return strings.ToLower(strings.TrimSpace(clientID))
+}
@@ -0,0 +1,12 @@
+package sharepoint
+
+// ItemAnalytics holds all-time usage counts for an item, as reported by the
+// Graph item analytics API. Used to rank shared items by how widely they are accessed.
+type ItemAnalytics struct {
+	SiteID     int64
+	ItemGUID   string // File/Folder UniqueId (the GUID sharing links target)
+	AuditRunID int64
+
+	ViewCount   int64 // Views and downloads
+	ViewerCount int64 // Distinct users who viewed or downloaded the item
+}
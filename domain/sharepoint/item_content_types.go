@@ -0,0 +1,38 @@
+package sharepoint
+
+import "strings"
+
+// DocumentSetContentTypeID is the ID of the Document Set content type. Document sets are folders whose
+// content type inherits from it, so a content type ID with this prefix marks a document set.
+const DocumentSetContentTypeID = "0x0120D520"
+
+// ItemAttachment is a file attached to a list item. Attachments are not list items themselves:
+// they have no permissions of their own, always follow the item's, and cannot be shared separately.
+type ItemAttachment struct {
+	FileName string
+	URL      string
+}
+
+// libraryTemplates are the list templates that store files rather than list items; they do not
+// support attachments.
+var libraryTemplates = map[int]bool{
+	101: true, // Document library
+	109: true, // Picture library
+	115: true, // Form library
+	119: true, // Site pages
+	700: true, // My Site documents
+	850: true, // Publishing pages
+	851: true, // Asset library
+}
+
+// SupportsAttachments returns true if items in the list can carry attachments, which only lists
+// (not libraries) allow
+func (l *List) SupportsAttachments() bool {
+	return !libraryTemplates[l.BaseTemplate]
+}
+
+// IsDocumentSet returns true if the item is a document set: a folder whose permissions and sharing
+// links apply to every document in the set
+func (i *Item) IsDocumentSet() bool {
+	return i.IsFolder && strings.HasPrefix(strings.ToUpper(i.ContentTypeID), strings.ToUpper(DocumentSetContentTypeID))
+}
@@ -0,0 +1,33 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItem_IsDocumentSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     Item
+		expected bool
+	}{
+		{"document set", Item{IsFolder: true, ContentTypeID: "0x0120D52000A1B2C3"}, true},
+		{"lower case ID", Item{IsFolder: true, ContentTypeID: "0x0120d52000a1b2c3"}, true},
+		{"plain folder", Item{IsFolder: true, ContentTypeID: "0x012000A1B2C3"}, false},
+		{"no content type", Item{IsFolder: true}, false},
+		{"file", Item{IsFile: true, ContentTypeID: "0x0120D520"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.item.IsDocumentSet())
+		})
+	}
+}
+
+func TestList_SupportsAttachments(t *testing.T) {
+	assert.True(t, (&List{BaseTemplate: 100}).SupportsAttachments(), "custom list")
+	assert.True(t, (&List{BaseTemplate: 107}).SupportsAttachments(), "tasks list")
+	assert.False(t, (&List{BaseTemplate: 101}).SupportsAttachments(), "document library")
+	assert.False(t, (&List{BaseTemplate: 119}).SupportsAttachments(), "site pages")
+}
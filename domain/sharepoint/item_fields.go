@@ -0,0 +1,24 @@
+package sharepoint
+
+import "slices"
+
+// Item fields whose collection can fail without failing the item. An item records the ones that
+// could not be read, so an empty value can be told apart from one that was never collected:
+// "no label" from "label not readable".
+const (
+	ItemFieldSensitivityLabel  = "sensitivity_label"
+	ItemFieldUniquePermissions = "unique_permissions"
+	ItemFieldAttachments       = "attachments"
+)
+
+// MarkUnreadable records that a field of the item could not be collected
+func (i *Item) MarkUnreadable(field string) {
+	if !slices.Contains(i.UnreadableFields, field) {
+		i.UnreadableFields = append(i.UnreadableFields, field)
+	}
+}
+
+// IsReadable returns true unless collecting the field failed for this item
+func (i *Item) IsReadable(field string) bool {
+	return !slices.Contains(i.UnreadableFields, field)
+}
@@ -0,0 +1,19 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestItem_MarkUnreadable(t *testing.T) {
+	item := &Item{}
+	assert.True(t, item.IsReadable(ItemFieldSensitivityLabel))
+
+	item.MarkUnreadable(ItemFieldSensitivityLabel)
+	item.MarkUnreadable(ItemFieldSensitivityLabel)
+
+	assert.False(t, item.IsReadable(ItemFieldSensitivityLabel))
+	assert.True(t, item.IsReadable(ItemFieldAttachments))
+	assert.Equal(t, []string{ItemFieldSensitivityLabel}, item.UnreadableFields)
+}
@@ -0,0 +1,97 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseItemReference(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected ItemReference
+	}{
+		{
+			name:     "bare GUID",
+			input:    "  3F2504E0-4F89-11D3-9A0C-0305E82C3301 ",
+			expected: ItemReference{Kind: ItemReferenceGUID, GUID: "3f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+		},
+		{
+			name:     "braced GUID",
+			input:    "{3f2504e0-4f89-11d3-9a0c-0305e82c3301}",
+			expected: ItemReference{Kind: ItemReferenceGUID, GUID: "3f2504e0-4f89-11d3-9a0c-0305e82c3301"},
+		},
+		{
+			name:  "Office document URL",
+			input: "https://contoso.sharepoint.com/:x:/r/sites/Finance/_layouts/15/Doc.aspx?sourcedoc=%7B3F2504E0-4F89-11D3-9A0C-0305E82C3301%7D&file=Budget.xlsx",
+			expected: ItemReference{
+				Kind: ItemReferenceGUID, GUID: "3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+				URL: "https://contoso.sharepoint.com/:x:/r/sites/Finance/_layouts/15/Doc.aspx", Host: "contoso.sharepoint.com",
+			},
+		},
+		{
+			name:  "guest link with packed GUID",
+			input: "https://contoso.sharepoint.com/sites/Finance/guestaccess.aspx?d=w3f2504e04f8911d39a0c0305e82c3301&authkey=abc",
+			expected: ItemReference{
+				Kind: ItemReferenceGUID, GUID: "3f2504e0-4f89-11d3-9a0c-0305e82c3301",
+				URL: "https://contoso.sharepoint.com/sites/Finance/guestaccess.aspx", Host: "contoso.sharepoint.com",
+			},
+		},
+		{
+			name:  "library view with id",
+			input: "https://contoso.sharepoint.com/sites/Finance/Shared%20Documents/Forms/AllItems.aspx?id=%2Fsites%2FFinance%2FShared%20Documents%2FPayroll",
+			expected: ItemReference{
+				Kind: ItemReferencePath, Path: "/sites/Finance/Shared Documents/Payroll",
+				URL: "https://contoso.sharepoint.com/sites/Finance/Shared%20Documents/Forms/AllItems.aspx", Host: "contoso.sharepoint.com",
+			},
+		},
+		{
+			name:  "redirect sharing link",
+			input: "https://contoso.sharepoint.com/:f:/r/sites/Finance/Shared%20Documents/Payroll?csf=1&web=1",
+			expected: ItemReference{
+				Kind: ItemReferencePath, Path: "/sites/Finance/Shared Documents/Payroll",
+				URL: "https://contoso.sharepoint.com/:f:/r/sites/Finance/Shared%20Documents/Payroll", Host: "contoso.sharepoint.com",
+			},
+		},
+		{
+			name:  "tokenized sharing link",
+			input: "https://contoso.sharepoint.com/:w:/s/Finance/EaBcDeFgHiJ?e=x1Y2z3",
+			expected: ItemReference{
+				Kind: ItemReferenceSharingLink,
+				URL:  "https://contoso.sharepoint.com/:w:/s/Finance/EaBcDeFgHiJ", Host: "contoso.sharepoint.com",
+			},
+		},
+		{
+			name:  "document URL",
+			input: "https://contoso.sharepoint.com/sites/Finance/Shared%20Documents/Budget.xlsx",
+			expected: ItemReference{
+				Kind: ItemReferencePath, Path: "/sites/Finance/Shared Documents/Budget.xlsx",
+				URL: "https://contoso.sharepoint.com/sites/Finance/Shared%20Documents/Budget.xlsx", Host: "contoso.sharepoint.com",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref, err := ParseItemReference(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
+
+func TestParseItemReference_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"not a reference",
+		"3f2504e0-4f89-11d3-9a0c",
+		"ftp://contoso.sharepoint.com/sites/Finance/file.docx",
+		"https://contoso.sharepoint.com/",
+		"https://contoso.sharepoint.com/sites/Finance/_layouts/15/settings.aspx",
+	} {
+		_, err := ParseItemReference(input)
+		assert.ErrorIs(t, err, ErrInvalidItemReference, "input %q", input)
+	}
+}
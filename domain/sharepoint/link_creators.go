@@ -0,0 +1,47 @@
+package sharepoint
+
+import "sort"
+
+// LinkCreator is a principal who created active sharing links in an audit run, with how many of them
+// work for anyone, reach external users or grant edit.
+type LinkCreator struct {
+	Principal      *Principal
+	Links          int64
+	AnonymousLinks int64
+	ExternalLinks  int64 // Links with guests invited to or holding them
+	ExposedLinks   int64 // Links that work for anyone or reach external users, each counted once
+	EditLinks      int64
+}
+
+// AddLinks counts count links of the kind and scope the principal created.
+func (c *LinkCreator) AddLinks(linkKind, scope int, edit, external bool, count int64) {
+	anonymous := IsAnyoneLinkKind(linkKind, scope)
+	c.Links += count
+	if anonymous {
+		c.AnonymousLinks += count
+	}
+	if external {
+		c.ExternalLinks += count
+	}
+	if anonymous || external {
+		c.ExposedLinks += count
+	}
+	if edit || linkKind == LinkKindOrganizationEdit || linkKind == LinkKindAnonymousEdit {
+		c.EditLinks += count
+	}
+}
+
+// SortLinkCreators orders creators by the links they exposed beyond the organization, most first,
+// then by the links they created and by name.
+func SortLinkCreators(creators []*LinkCreator) {
+	sort.SliceStable(creators, func(i, j int) bool {
+		a, b := creators[i], creators[j]
+		if a.ExposedLinks != b.ExposedLinks {
+			return a.ExposedLinks > b.ExposedLinks
+		}
+		if a.Links != b.Links {
+			return a.Links > b.Links
+		}
+		return a.Principal.GetDisplayName() < b.Principal.GetDisplayName()
+	})
+}
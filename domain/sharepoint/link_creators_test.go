@@ -0,0 +1,37 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkCreator_AddLinks(t *testing.T) {
+	creator := &LinkCreator{}
+	creator.AddLinks(LinkKindAnonymousEdit, ScopeAnonymous, false, false, 2)
+	creator.AddLinks(LinkKindFlexible, ScopeAnonymous, false, true, 1)
+	creator.AddLinks(LinkKindDirect, ScopeSpecificPeople, true, true, 3)
+	creator.AddLinks(LinkKindOrganizationView, ScopeOrganization, false, false, 4)
+
+	assert.Equal(t, int64(10), creator.Links)
+	assert.Equal(t, int64(3), creator.AnonymousLinks)
+	assert.Equal(t, int64(4), creator.ExternalLinks)
+	assert.Equal(t, int64(6), creator.ExposedLinks, "an anonymous link reaching guests is exposed once")
+	assert.Equal(t, int64(5), creator.EditLinks)
+}
+
+func TestSortLinkCreators(t *testing.T) {
+	creators := []*LinkCreator{
+		{Principal: &Principal{Title: "Grace"}, Links: 5, ExposedLinks: 1},
+		{Principal: &Principal{Title: "Ada"}, Links: 2, ExposedLinks: 2},
+		{Principal: &Principal{Title: "Edsger"}, Links: 5, ExposedLinks: 1},
+		{Principal: &Principal{Title: "Alan"}, Links: 9},
+	}
+	SortLinkCreators(creators)
+
+	names := make([]string, len(creators))
+	for i, creator := range creators {
+		names[i] = creator.Principal.Title
+	}
+	assert.Equal(t, []string{"Ada", "Edsger", "Grace", "Alan"}, names)
+}
@@ -0,0 +1,62 @@
+package sharepoint
+
+// Item-level read access of a list (SP.List.ReadSecurity)
+const (
+	ListReadAllItems = 1 // Users can read all items
+	ListReadOwnItems = 2 // Users can read only the items they created
+)
+
+// Item-level write access of a list (SP.List.WriteSecurity)
+const (
+	ListWriteAllItems = 1 // Users can create and edit all items
+	ListWriteOwnItems = 2 // Users can create items and edit only the items they created
+	ListWriteNone     = 4 // Users cannot create or edit items
+)
+
+// Rating experiences a list can enable (the Ratings_VotingExperience root folder property)
+const (
+	ListRatingNone  = ""
+	ListRatingLikes = "Likes"
+	ListRatingStars = "Ratings"
+)
+
+// ListItemSettings are a list's item permission defaults and the Microsoft Lists features
+// that expose who interacted with its items. Users with the list's Manage Lists permission
+// bypass the read and write settings.
+type ListItemSettings struct {
+	ReadSecurity     int    // ListReadAllItems or ListReadOwnItems
+	WriteSecurity    int    // ListWriteAllItems, ListWriteOwnItems or ListWriteNone
+	CommentsDisabled bool   // Item comments are turned off for the list
+	RatingExperience string // ListRatingNone, ListRatingLikes or ListRatingStars
+}
+
+// ReadsOwnItemsOnly returns true if users can read only the items they created.
+func (s *ListItemSettings) ReadsOwnItemsOnly() bool {
+	return s.ReadSecurity == ListReadOwnItems
+}
+
+// EditsOwnItemsOnly returns true if users can edit only the items they created.
+func (s *ListItemSettings) EditsOwnItemsOnly() bool {
+	return s.WriteSecurity == ListWriteOwnItems
+}
+
+// ReadOnly returns true if users cannot create or edit items.
+func (s *ListItemSettings) ReadOnly() bool {
+	return s.WriteSecurity == ListWriteNone
+}
+
+// CommentsVisible returns true if item comments, and their authors, are shown to readers of the list.
+func (s *ListItemSettings) CommentsVisible() bool {
+	return !s.CommentsDisabled
+}
+
+// HasRatings returns true if the list lets users like or rate items, showing who did to readers.
+func (s *ListItemSettings) HasRatings() bool {
+	return s.RatingExperience != ListRatingNone
+}
+
+// RestrictsItemAccess returns true if the settings narrow what users can read or edit beyond
+// the list's role assignments.
+func (s *ListItemSettings) RestrictsItemAccess() bool {
+	return s.ReadsOwnItemsOnly() || s.EditsOwnItemsOnly() || s.ReadOnly()
+}
@@ -1,5 +1,7 @@
 package sharepoint
 
+import "strings"
+
 // Principal represents a user, group, or security principal
 type Principal struct {
 	SiteID        int64 // Reference to parent site
@@ -25,6 +27,16 @@ func (p *Principal) IsSharePointGroup() bool {
 	return p.PrincipalType == PrincipalTypeSharePointGroup
 }
 
+// IsSharingLinkPrincipal returns true if the principal is the group SharePoint creates for a sharing link
+func (p *Principal) IsSharingLinkPrincipal() bool {
+	return strings.HasPrefix(p.LoginName, sharingLinkLoginPrefix)
+}
+
+// Kind returns the display classification of the principal
+func (p *Principal) Kind() PrincipalKind {
+	return ClassifyPrincipal(p.PrincipalType, p.LoginName)
+}
+
 // GetDisplayName returns the best display name for the principal
 func (p *Principal) GetDisplayName() string {
 	if p.Title != "" {
@@ -44,6 +56,11 @@ type RoleDefinition struct {
 	Description string
 }
 
+// IsLimitedAccess returns true for the navigation-only levels SharePoint grants automatically
+func (rd *RoleDefinition) IsLimitedAccess() bool {
+	return IsLimitedAccessRole(rd.ID, rd.Name)
+}
+
 // RoleAssignment represents a permission assignment to an object
 type RoleAssignment struct {
 	SiteID      int64  // Reference to parent site
@@ -95,7 +112,7 @@ const (
 
 // RootCause represents a single source of permission access
 type RootCause struct {
-	Type         string // "SHARING_LINK", "SAME_WEB_INHERITANCE", "SYSTEM_GROUP", "UNKNOWN"
+	Type         string // "DIRECT_ASSIGNMENT", "SHARING_LINK", "SAME_WEB_INHERITANCE", "SYSTEM_GROUP", "UNKNOWN"
 	Detail       string // Detailed explanation of this root cause
 	SourceObject string // The object that caused this assignment
 	SourceRole   string // The role that caused this assignment
@@ -103,12 +120,15 @@ type RootCause struct {
 
 // ResolvedAssignment represents an assignment with root cause analysis
 type ResolvedAssignment struct {
-	Assignment *Assignment
-	RootCauses []RootCause // All detected permission sources
+	Assignment   *Assignment
+	RootCauses   []RootCause              // All detected permission sources
+	SharingLink  *SharingLinkWithItemData // Link behind a sharing link group principal, members populated
+	GroupMembers []*Principal             // Members of a SharePoint group principal, as the audit run captured them
 }
 
 // Root cause type constants
 const (
+	RootCauseTypeDirect      = "DIRECT_ASSIGNMENT"
 	RootCauseTypeSharingLink = "SHARING_LINK"
 	RootCauseTypeInheritance = "SAME_WEB_INHERITANCE"
 	RootCauseTypeSystemGroup = "SYSTEM_GROUP"
@@ -38,23 +38,58 @@ type SharePointRiskData struct {
 
 // SharePointRiskAssessment represents detailed risk assessment for SharePoint objects
 type SharePointRiskAssessment struct {
-	RiskScore              float64
-	RiskLevel              string // "Low", "Medium", "High"
-	RiskFromUniqueItems    float64
-	RiskFromAssignments    float64
-	RiskFromSharingLinks   float64
-	RiskFromElevatedAccess float64
+	RiskScore               float64
+	RiskLevel               string // "Low", "Medium", "High"
+	RiskFromUniqueItems     float64
+	RiskFromAssignments     float64
+	RiskFromSharingLinks    float64
+	RiskFromElevatedAccess  float64
+	UniqueDensity           float64 // ItemsWithUnique / TotalItems
+	ExceedsDensityThreshold bool
+	LowRiskReduced          bool // Factors were halved and the score capped at LowRiskScoreCap
+	RaisedForDensity        bool // Level raised from Low to Medium because the unique density exceeds the threshold
 }
 
+// Caps on the points each factor contributes to a risk score, which add up to 100.
+const (
+	MaxRiskFromUniqueItems    = 50.0
+	MaxRiskFromAssignments    = 25.0
+	MaxRiskFromSharingLinks   = 15.0
+	MaxRiskFromElevatedAccess = 10.0
+)
+
+// LowRiskScoreCap caps the score of objects with no unique items, sharing links or elevated access.
+const LowRiskScoreCap = 15.0
+
+// DefaultUniqueDensityThreshold is the unique-permission density above which a list is flagged
+const DefaultUniqueDensityThreshold = 0.2
+
+// DefaultMaxAnalysisRows caps the rows a single list snapshot or comparison loads, so a very
+// large run fails with an error instead of exhausting memory
+const DefaultMaxAnalysisRows = 500000
+
 // PermissionsService provides business logic for analyzing SharePoint permissions
 type PermissionsService struct {
 	// Pure business logic - no external dependencies
-	// Future: could add configuration for risk thresholds, etc.
+	uniqueDensityThreshold float64
 }
 
 // NewPermissionsService creates a new permission service
 func NewPermissionsService() *PermissionsService {
-	return &PermissionsService{}
+	return NewPermissionsServiceWithDensityThreshold(DefaultUniqueDensityThreshold)
+}
+
+// NewPermissionsServiceWithDensityThreshold creates a permission service with a custom density threshold
+func NewPermissionsServiceWithDensityThreshold(threshold float64) *PermissionsService {
+	if threshold <= 0 || threshold > 1 {
+		threshold = DefaultUniqueDensityThreshold
+	}
+	return &PermissionsService{uniqueDensityThreshold: threshold}
+}
+
+// UniqueDensityThreshold returns the density threshold used to flag lists
+func (s *PermissionsService) UniqueDensityThreshold() float64 {
+	return s.uniqueDensityThreshold
 }
 
 // AnalyzeAssignments analyzes a collection of role assignments for business insights
@@ -141,10 +176,11 @@ func (s *PermissionsService) CalculateSharePointRiskAssessment(riskData *SharePo
 	// This is the most important indicator for SharePoint security
 	uniqueItemsRisk := 0.0
 	if riskData.TotalItems > 0 {
-		itemUniquePercentage := float64(riskData.ItemsWithUnique) / float64(riskData.TotalItems)
-		uniqueItemsRisk = itemUniquePercentage * 50.0
+		assessment.UniqueDensity = math.Min(float64(riskData.ItemsWithUnique)/float64(riskData.TotalItems), 1.0)
+		uniqueItemsRisk = assessment.UniqueDensity * MaxRiskFromUniqueItems
 	}
 	assessment.RiskFromUniqueItems = uniqueItemsRisk
+	assessment.ExceedsDensityThreshold = assessment.UniqueDensity > s.uniqueDensityThreshold
 
 	// High-risk assignments: Exclude limited access since it's low risk (0-25 points, logarithmic scale)
 	// Limited Access is automatically granted by SharePoint for navigation - low security risk
@@ -153,18 +189,18 @@ func (s *PermissionsService) CalculateSharePointRiskAssessment(riskData *SharePo
 	if highRiskAssignments > 0 {
 		// Logarithmic scale: 10 assignments = ~8 points, 100 = ~17 points, 1000 = ~25 points
 		complexityScore := math.Log10(float64(highRiskAssignments)) * 8.0
-		assignmentRisk = math.Min(complexityScore, 25.0)
+		assignmentRisk = math.Min(complexityScore, MaxRiskFromAssignments)
 	}
 	assessment.RiskFromAssignments = assignmentRisk
 
 	// Sharing links risk (0-15 points)
 	// External sharing is a significant security concern in SharePoint
-	sharingRisk := math.Min(float64(riskData.SharingLinkCount)*1.5, 15.0)
+	sharingRisk := math.Min(float64(riskData.SharingLinkCount)*1.5, MaxRiskFromSharingLinks)
 	assessment.RiskFromSharingLinks = sharingRisk
 
 	// Elevated permissions risk (0-10 points)
 	// Full Control and Contribute are high-privilege access levels
-	elevatedRisk := math.Min(float64(riskData.FullControlCount+riskData.ContributeCount)*1.5, 10.0)
+	elevatedRisk := math.Min(float64(riskData.FullControlCount+riskData.ContributeCount)*1.5, MaxRiskFromElevatedAccess)
 	assessment.RiskFromElevatedAccess = elevatedRisk
 
 	// Calculate total risk score
@@ -174,7 +210,8 @@ func (s *PermissionsService) CalculateSharePointRiskAssessment(riskData *SharePo
 	// This represents a well-governed SharePoint site with proper inheritance
 	if riskData.ItemsWithUnique == 0 && riskData.SharingLinkCount == 0 &&
 		(riskData.FullControlCount+riskData.ContributeCount) == 0 {
-		riskScore = math.Min(riskScore*0.5, 15.0) // Cap at 15 points for low-risk scenarios
+		riskScore = math.Min(riskScore*0.5, LowRiskScoreCap)
+		assessment.LowRiskReduced = true
 
 		// Update breakdown to reflect the reduction
 		assessment.RiskFromUniqueItems *= 0.5
@@ -192,6 +229,12 @@ func (s *PermissionsService) CalculateSharePointRiskAssessment(riskData *SharePo
 		riskLevel = "Medium"
 	}
 
+	// Dense inheritance breaks are a governance problem even when the overall score is low
+	if assessment.ExceedsDensityThreshold && riskLevel == "Low" {
+		riskLevel = "Medium"
+		assessment.RaisedForDensity = true
+	}
+
 	assessment.RiskScore = math.Min(riskScore, 100.0)
 	assessment.RiskLevel = riskLevel
 
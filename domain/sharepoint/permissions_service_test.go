@@ -0,0 +1,28 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionsService_CalculateSharePointRiskAssessment_Adjustments(t *testing.T) {
+	service := NewPermissionsService()
+
+	readOnly := service.CalculateSharePointRiskAssessment(&SharePointRiskData{TotalItems: 10, TotalAssignments: 100})
+	assert.True(t, readOnly.LowRiskReduced)
+	assert.False(t, readOnly.RaisedForDensity)
+	assert.InDelta(t, 8.0, readOnly.RiskFromAssignments, 0.01, "halved from 16 points")
+	assert.Equal(t, "Low", readOnly.RiskLevel)
+
+	dense := service.CalculateSharePointRiskAssessment(&SharePointRiskData{TotalItems: 10, ItemsWithUnique: 3})
+	assert.False(t, dense.LowRiskReduced)
+	assert.True(t, dense.RaisedForDensity)
+	assert.Equal(t, 15.0, dense.RiskScore)
+	assert.Equal(t, "Medium", dense.RiskLevel)
+
+	capped := service.CalculateSharePointRiskAssessment(&SharePointRiskData{
+		TotalItems: 1, ItemsWithUnique: 1, TotalAssignments: 10000, FullControlCount: 50, SharingLinkCount: 50,
+	})
+	assert.Equal(t, MaxRiskFromUniqueItems+MaxRiskFromAssignments+MaxRiskFromSharingLinks+MaxRiskFromElevatedAccess, capped.RiskScore)
+}
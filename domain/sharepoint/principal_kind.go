@@ -0,0 +1,75 @@
+package sharepoint
+
+import "strings"
+
+// PrincipalKind classifies a principal for display. It folds SharePoint's
+// PrincipalType flags and login name conventions into one taxonomy, so
+// sharing link groups and app principals are not shown as plain groups and users.
+type PrincipalKind string
+
+const (
+	PrincipalKindUser             PrincipalKind = "user"
+	PrincipalKindSharePointGroup  PrincipalKind = "sharepoint_group"
+	PrincipalKindSecurityGroup    PrincipalKind = "security_group"
+	PrincipalKindDistributionList PrincipalKind = "distribution_list"
+	PrincipalKindSharingLink      PrincipalKind = "sharing_link"
+	PrincipalKindApp              PrincipalKind = "app"
+	PrincipalKindUnknown          PrincipalKind = "unknown"
+)
+
+// sharingLinkLoginPrefix starts the login name of the group SharePoint creates for a sharing link
+const sharingLinkLoginPrefix = "SharingLinks."
+
+// appLoginPrefixes start the claims login names of add-in and Entra app principals
+var appLoginPrefixes = []string{
+	"i:0i.t|ms.sp.ext|",
+	"i:0i.t|00000003-0000-0ff1-ce00-000000000000|",
+}
+
+// ClassifyPrincipal returns the kind of a principal from its type and login name.
+// Login name conventions take precedence: sharing link groups are SharePoint groups
+// and app principals are users as far as PrincipalType is concerned.
+func ClassifyPrincipal(principalType int64, loginName string) PrincipalKind {
+	if strings.HasPrefix(loginName, sharingLinkLoginPrefix) {
+		return PrincipalKindSharingLink
+	}
+	lowerLogin := strings.ToLower(loginName)
+	for _, prefix := range appLoginPrefixes {
+		if strings.HasPrefix(lowerLogin, prefix) {
+			return PrincipalKindApp
+		}
+	}
+
+	switch principalType {
+	case PrincipalTypeUser:
+		return PrincipalKindUser
+	case PrincipalTypeDistribution:
+		return PrincipalKindDistributionList
+	case PrincipalTypeSecurity:
+		return PrincipalKindSecurityGroup
+	case PrincipalTypeSharePointGroup:
+		return PrincipalKindSharePointGroup
+	default:
+		return PrincipalKindUnknown
+	}
+}
+
+// Label returns the human-readable name of the kind.
+func (k PrincipalKind) Label() string {
+	switch k {
+	case PrincipalKindUser:
+		return "User"
+	case PrincipalKindSharePointGroup:
+		return "SharePoint Group"
+	case PrincipalKindSecurityGroup:
+		return "Security Group"
+	case PrincipalKindDistributionList:
+		return "Distribution List"
+	case PrincipalKindSharingLink:
+		return "Sharing Link"
+	case PrincipalKindApp:
+		return "App"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,55 @@
+package sharepoint
+
+import "time"
+
+// Recycle bin item types (SP.RecycleBinItemType) that sharing links can be created on
+const (
+	RecycleBinItemTypeFile     = 1
+	RecycleBinItemTypeListItem = 3
+	RecycleBinItemTypeFolder   = 5
+)
+
+// Recycle bin stages (SP.RecycleBinItemState)
+const (
+	RecycleBinStageFirst  = 1 // The site's recycle bin, which members can restore from
+	RecycleBinStageSecond = 2 // The site collection recycle bin, which only admins can restore from
+)
+
+// RecycleBinItem is a deleted file, folder or list item still in the site's recycle bin. Deleting an
+// item does not delete its sharing links: they stop working, and restoring the item revives them.
+type RecycleBinItem struct {
+	SiteID         int64
+	AuditRunID     int64
+	ID             string // Recycle bin entry ID, not the item's
+	ItemType       int    // SP.RecycleBinItemType
+	ItemState      int    // Recycle bin stage
+	Title          string
+	URL            string // Where the item was before deletion, in the same form as Item.URL
+	DeletedAt      time.Time
+	DeletedByName  string
+	DeletedByEmail string
+}
+
+// CanBeShared returns true if the deleted entry is a file, folder or list item, which are what sharing links are created on.
+func (i *RecycleBinItem) CanBeShared() bool {
+	switch i.ItemType {
+	case RecycleBinItemTypeFile, RecycleBinItemTypeListItem, RecycleBinItemTypeFolder:
+		return true
+	default:
+		return false
+	}
+}
+
+// ItemTypeName names the kind of entry for display.
+func (i *RecycleBinItem) ItemTypeName() string {
+	switch i.ItemType {
+	case RecycleBinItemTypeFile:
+		return "file"
+	case RecycleBinItemTypeListItem:
+		return "item"
+	case RecycleBinItemTypeFolder:
+		return "folder"
+	default:
+		return "other"
+	}
+}
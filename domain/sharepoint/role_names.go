@@ -0,0 +1,53 @@
+package sharepoint
+
+import "strings"
+
+// Canonical names of SharePoint's built-in permission levels, as English tenants return them.
+const (
+	RoleNameLimitedAccess        = "Limited Access"
+	RoleNameWebOnlyLimitedAccess = "Web-Only Limited Access"
+	RoleNameRead                 = "Read"
+	RoleNameContribute           = "Contribute"
+	RoleNameDesign               = "Design"
+	RoleNameFullControl          = "Full Control"
+	RoleNameEdit                 = "Edit"
+	RoleNameViewOnly             = "View Only"
+)
+
+// builtInRoleNames maps the role definition IDs SharePoint gives its built-in permission levels on
+// every site to their canonical names. Tenants in other languages return localized names for the
+// same IDs, e.g. "Vollzugriff" for Full Control.
+var builtInRoleNames = map[int64]string{
+	1073741825: RoleNameLimitedAccess,
+	1073741826: RoleNameRead,
+	1073741827: RoleNameContribute,
+	1073741828: RoleNameDesign,
+	1073741829: RoleNameFullControl,
+	1073741830: RoleNameEdit,
+	1073741924: RoleNameViewOnly,
+}
+
+// CanonicalRoleName returns the canonical name of a built-in permission level by its ID, so analytics
+// count roles the same way on every tenant language. Custom levels keep their own name.
+func CanonicalRoleName(roleDefID int64, name string) string {
+	if canonical, ok := builtInRoleNames[roleDefID]; ok {
+		return canonical
+	}
+	return name
+}
+
+// IsLimitedAccessRole returns true for the navigation-only levels SharePoint grants automatically.
+// Web-Only Limited Access has no well-known ID, so it is recognised by its English name.
+func IsLimitedAccessRole(roleDefID int64, name string) bool {
+	return strings.Contains(CanonicalRoleName(roleDefID, name), RoleNameLimitedAccess)
+}
+
+// GrantsEditRole returns true for the built-in permission levels that let a principal add or change
+// content: Contribute, Edit, Design and Full Control. Custom levels are not recognised.
+func GrantsEditRole(roleDefID int64, name string) bool {
+	switch CanonicalRoleName(roleDefID, name) {
+	case RoleNameContribute, RoleNameEdit, RoleNameDesign, RoleNameFullControl:
+		return true
+	}
+	return false
+}
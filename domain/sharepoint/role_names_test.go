@@ -0,0 +1,24 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalRoleName(t *testing.T) {
+	assert.Equal(t, RoleNameFullControl, CanonicalRoleName(1073741829, "Vollzugriff"))
+	assert.Equal(t, RoleNameEdit, CanonicalRoleName(1073741830, "Modifier"))
+	assert.Equal(t, "Projektleitung", CanonicalRoleName(1073742000, "Projektleitung"))
+
+	assert.True(t, (&RoleDefinition{ID: 1073741825, Name: "Beschränkter Zugriff"}).IsLimitedAccess())
+	assert.True(t, (&RoleDefinition{ID: 1073742001, Name: RoleNameWebOnlyLimitedAccess}).IsLimitedAccess())
+	assert.False(t, (&RoleDefinition{ID: 1073741826, Name: "Lesen"}).IsLimitedAccess())
+}
+
+func TestGrantsEditRole(t *testing.T) {
+	assert.True(t, GrantsEditRole(1073741827, "Mitwirken"))
+	assert.True(t, GrantsEditRole(1073741829, RoleNameFullControl))
+	assert.False(t, GrantsEditRole(1073741826, RoleNameRead))
+	assert.False(t, GrantsEditRole(1073742000, "Projektleitung"))
+}
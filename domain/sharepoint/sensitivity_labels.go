@@ -5,11 +5,12 @@ import "time"
 // ItemSensitivityLabel represents sensitivity label information discovered from SharePoint file properties
 // This is separate from sharing-related sensitivity labels and is discovered during list item processing
 type ItemSensitivityLabel struct {
-	SiteID   int64
-	ItemGUID string
+	SiteID     int64
+	ItemGUID   string
+	AuditRunID int64
 
 	// Core sensitivity label information
-	LabelID          string     // vti_x005f_iplabelid
+	LabelID          string     // vti_x005f_iplabelid, or the GUID in MSIP_x005f_Label_x005f_<GUID>_x005f_... names
 	DisplayName      string     // MSIP_x005f_Label_x005f_..._x005f_Name or vti_x005f_iplabeldisplayname
 	OwnerEmail       string     // vti_x005f_iplabelowneremail
 	SetDate          *time.Time // MSIP_x005f_Label_x005f_..._x005f_SetDate
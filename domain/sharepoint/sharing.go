@@ -106,6 +106,11 @@ func (s *SharingLink) IsAnonymousLink() bool {
 	return s.Scope == ScopeAnonymous
 }
 
+// IsAnyoneLink returns true if the link works for anyone who has it, without signing in
+func (s *SharingLink) IsAnyoneLink() bool {
+	return IsAnyoneLinkKind(s.LinkKind, s.Scope)
+}
+
 // IsInternalLink returns true if this is an organization-only link
 func (s *SharingLink) IsInternalLink() bool {
 	return s.Scope == ScopeOrganization
@@ -190,6 +195,18 @@ const (
 	LinkKindFlexible         = 6
 )
 
+// IsAnyoneLinkKind returns true if a link of the kind and scope works for anyone who has it.
+// Only flexible links carry their audience in the scope.
+func IsAnyoneLinkKind(linkKind, scope int) bool {
+	switch linkKind {
+	case LinkKindAnonymousView, LinkKindAnonymousEdit:
+		return true
+	case LinkKindFlexible:
+		return scope == ScopeAnonymous
+	}
+	return false
+}
+
 // Common sharing scopes
 // https://learn.microsoft.com/en-us/graph/api/resources/sharinglink?view=graph-rest-1.0
 // https://learn.microsoft.com/en-us/sharepoint/change-default-sharing-link
@@ -221,9 +238,10 @@ const (
 // SharingLinkWithItemData represents a sharing link enriched with item information for UI display
 type SharingLinkWithItemData struct {
 	*SharingLink
-	ItemName     string
-	ItemIsFile   bool
-	ItemIsFolder bool
+	ItemName      string
+	ItemIsFile    bool
+	ItemIsFolder  bool
+	ItemAnalytics *ItemAnalytics // nil when analytics were not collected for the item
 }
 
 // SensitivityLabelInformation represents sensitivity labeling information for governance
@@ -325,3 +343,47 @@ type RecipientLimitsInfo struct {
 	MixedRecipients int
 	ObjectIdOnly    int
 }
+
+// SharingGovernance represents a site's sharing policy as captured by one audit run.
+// The policy comes with every sharing information response, so each run keeps its own copy
+// and changes between runs can be compared.
+type SharingGovernance struct {
+	SiteID     int64
+	AuditRunID int64
+
+	TenantID          string
+	TenantDisplayName string
+	SharePointSiteID  string
+
+	AnonymousLinkExpirationRestrictionDays int
+	AnyoneLinkTrackUsers                   bool
+	CanAddExternalPrincipal                bool
+	CanAddInternalPrincipal                bool
+	BlockPeoplePickerAndSharing            bool
+	CanRequestAccessForGrantAccess         bool
+
+	SiteIBMode                string
+	SiteIBSegmentIDs          []string
+	EnforceIBSegmentFiltering bool
+
+	DefaultSharingLink *DefaultSharingLink // Nil when the run did not capture it
+	SharingAbilities   *SharingAbilities   // Nil when the run did not capture them
+	RecipientLimits    *RecipientLimits    // Nil when the run did not capture them
+}
+
+// DefaultSharingLink is the link the share dialog offers unless the user picks another.
+type DefaultSharingLink struct {
+	LinkKind   int // SP.SharingLinkKind
+	Permission int // SP.Sharing.Role
+	Scope      int
+}
+
+// GrantsEdit returns true if the default link lets its recipients edit
+func (d *DefaultSharingLink) GrantsEdit() bool {
+	return d.Permission == RoleEdit || d.LinkKind == LinkKindOrganizationEdit || d.LinkKind == LinkKindAnonymousEdit
+}
+
+// IsAnyone returns true if the default link works for anyone who has it, without signing in
+func (d *DefaultSharingLink) IsAnyone() bool {
+	return d.Scope == ScopeAnonymous || d.LinkKind == LinkKindAnonymousView || d.LinkKind == LinkKindAnonymousEdit
+}
@@ -1,6 +1,8 @@
 package sharepoint
 
 import (
+	"math"
+	"strings"
 	"time"
 )
 
@@ -22,19 +24,44 @@ type Web struct {
 	Template   string
 	HasUnique  bool
 	AuditRunID *int64
+
+	MembersCanShare *bool              // Nil when the run did not capture it
+	AccessRequests  *WebAccessRequests // Nil when the run did not capture them
+}
+
+// WebAccessRequests is where a web sends the requests of users asking for access to it or its content.
+// Lists and items follow the settings of their web.
+type WebAccessRequests struct {
+	Email    string // Address requests are emailed to (SP.Web.RequestAccessEmail), empty when none
+	ToOwners bool   // Requests go to the web's owners group (SP.Web.UseAccessRequestDefault)
+}
+
+// Enabled returns true if users without access can ask for it.
+func (a *WebAccessRequests) Enabled() bool {
+	return a.Email != "" || a.ToOwners
 }
 
 // List represents a SharePoint list or document library
 type List struct {
-	SiteID       int64 // Reference to parent site
-	ID           string
-	WebID        string
-	Title        string
-	URL          string
-	BaseTemplate int
-	ItemCount    int
-	HasUnique    bool
-	AuditRunID   *int64
+	SiteID           int64 // Reference to parent site
+	ID               string
+	WebID            string
+	Title            string
+	URL              string
+	BaseTemplate     int
+	ItemCount        int
+	HasUnique        bool
+	UniqueItemCount  int     // Items in the list with unique permissions, estimated when sampled
+	UniqueDensity    float64 // UniqueItemCount / ItemCount, persisted per audit run
+	SampledItemCount int     // Items deep-scanned when the audit sampled the list, 0 when every item was scanned
+	AuditRunID       *int64
+
+	// Access counts persisted per audit run; for sampled lists they cover the scanned items only
+	UniqueAssignmentCount  int // Explicit assignments on the list and its uniquely permissioned items
+	ExternalPrincipalCount int // Guest accounts granted access by those assignments or by sharing links
+	SharingLinkCount       int // Active sharing links on the list's items
+
+	ItemSettings *ListItemSettings // Nil when the run did not capture them
 }
 
 // IsEmpty returns true if the list has no items
@@ -42,6 +69,29 @@ func (l *List) IsEmpty() bool {
 	return l.ItemCount == 0
 }
 
+// ExceedsUniqueDensity returns true if the list's unique-permission density is above the threshold
+func (l *List) ExceedsUniqueDensity(threshold float64) bool {
+	return l.UniqueDensity > threshold
+}
+
+// IsSampled returns true if only a sample of the list's items was deep-scanned,
+// making UniqueItemCount and UniqueDensity estimates
+func (l *List) IsSampled() bool {
+	return l.SampledItemCount > 0
+}
+
+// UniqueDensityMargin returns the 95% confidence margin of error of a sampled list's unique density,
+// 0 when every item was scanned
+func (l *List) UniqueDensityMargin() float64 {
+	if !l.IsSampled() || l.ItemCount <= 1 || l.SampledItemCount >= l.ItemCount {
+		return 0
+	}
+	p := l.UniqueDensity
+	n := float64(l.SampledItemCount)
+	population := float64(l.ItemCount)
+	return 1.96 * math.Sqrt(p*(1-p)/n*(population-n)/(population-1))
+}
+
 // IsDocumentLibrary returns true if this is a document library (BaseTemplate 101)
 func (l *List) IsDocumentLibrary() bool {
 	return l.BaseTemplate == 101
@@ -52,6 +102,23 @@ func (l *List) IsCustomList() bool {
 	return l.BaseTemplate == 100
 }
 
+// IsSitePages returns true if this is the Site Pages library holding the site's pages (BaseTemplate 119)
+func (l *List) IsSitePages() bool {
+	return l.BaseTemplate == 119
+}
+
+// IsSiteAssets returns true if this is the Site Assets library holding images and files embedded in
+// the site's pages. It is a document library recognised by its URL, since its title is localized.
+func (l *List) IsSiteAssets() bool {
+	return l.IsDocumentLibrary() && strings.HasSuffix(strings.ToLower(strings.TrimRight(l.URL, "/")), "/siteassets")
+}
+
+// IsPageLibrary returns true for the Site Pages and Site Assets libraries, whose content is embedded
+// in and linked from the site's pages
+func (l *List) IsPageLibrary() bool {
+	return l.IsSitePages() || l.IsSiteAssets()
+}
+
 // Item represents a SharePoint list item, file, or folder
 type Item struct {
 	SiteID       int64  // Reference to parent site
@@ -65,6 +132,17 @@ type Item struct {
 	IsFolder     bool
 	HasUnique    bool
 	AuditRunID   *int64
+
+	// SharePoint metadata
+	FileFolderUniqueID string     // File.UniqueId or Folder.UniqueId
+	Size               int64      // File length in bytes, 0 for folders and list items
+	CreatedAt          *time.Time // SharePoint Created
+	ModifiedAt         *time.Time // SharePoint Modified
+	ContentTypeID      string     // Identifies document sets among folders, see IsDocumentSet
+
+	Attachments []*ItemAttachment // Files attached to a list item, collected for lists only
+
+	UnreadableFields []string // Fields that could not be collected, see MarkUnreadable
 }
 
 // IsDocument returns true if this is a file
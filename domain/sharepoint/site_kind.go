@@ -0,0 +1,32 @@
+package sharepoint
+
+import "strings"
+
+// Kinds of site, told apart by the template of their root web
+const (
+	SiteKindTeam          = "team"          // Classic and group-connected team sites, and anything unrecognized
+	SiteKindCommunication = "communication" // Sites publishing pages to a wide audience
+	SiteKindChannel       = "channel"       // Sites of a Teams private or shared channel
+)
+
+// Root web template prefixes of the kinds of site other than team sites; the configuration number
+// after the # does not change the kind
+const (
+	siteTemplateCommunication = "SITEPAGEPUBLISHING#"
+	siteTemplateChannel       = "TEAMCHANNEL#"
+)
+
+// SiteKindForTemplate returns the kind of site its root web template makes, e.g. communication for
+// SITEPAGEPUBLISHING#0 and channel for TEAMCHANNEL#0 or TEAMCHANNEL#1. Every other template, GROUP#0
+// and STS#3 included, makes a team site, as does an unknown one.
+func SiteKindForTemplate(template string) string {
+	template = strings.ToUpper(strings.TrimSpace(template))
+	switch {
+	case strings.HasPrefix(template, siteTemplateCommunication):
+		return SiteKindCommunication
+	case strings.HasPrefix(template, siteTemplateChannel):
+		return SiteKindChannel
+	default:
+		return SiteKindTeam
+	}
+}
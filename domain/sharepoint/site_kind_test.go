@@ -0,0 +1,17 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSiteKindForTemplate(t *testing.T) {
+	assert.Equal(t, SiteKindCommunication, SiteKindForTemplate("SITEPAGEPUBLISHING#0"))
+	assert.Equal(t, SiteKindCommunication, SiteKindForTemplate(" sitepagepublishing#0 "))
+	assert.Equal(t, SiteKindChannel, SiteKindForTemplate("TEAMCHANNEL#0"))
+	assert.Equal(t, SiteKindChannel, SiteKindForTemplate("TEAMCHANNEL#1"))
+	assert.Equal(t, SiteKindTeam, SiteKindForTemplate("GROUP#0"))
+	assert.Equal(t, SiteKindTeam, SiteKindForTemplate("STS#3"))
+	assert.Equal(t, SiteKindTeam, SiteKindForTemplate(""), "an unknown template is checked as a team site")
+}
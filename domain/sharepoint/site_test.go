@@ -0,0 +1,14 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestList_IsPageLibrary(t *testing.T) {
+	assert.True(t, (&List{BaseTemplate: 119, URL: "/sites/a/SitePages"}).IsPageLibrary())
+	assert.True(t, (&List{BaseTemplate: 101, URL: "https://contoso.sharepoint.com/sites/a/SiteAssets/"}).IsPageLibrary())
+	assert.False(t, (&List{BaseTemplate: 101, URL: "/sites/a/Shared Documents"}).IsPageLibrary())
+	assert.False(t, (&List{BaseTemplate: 100, URL: "/sites/a/Lists/SiteAssets"}).IsPageLibrary())
+}
@@ -0,0 +1,58 @@
+package sharepoint
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ErrInvalidTenantURL is returned when a URL is not on a SharePoint Online tenant's host
+var ErrInvalidTenantURL = errors.New("not a SharePoint Online tenant URL")
+
+// Site templates of site collections that have nothing to audit
+const (
+	SiteTemplateTenantAdmin = "TENANTADMIN#0"  // The SharePoint admin center
+	SiteTemplateRedirect    = "REDIRECTSITE#0" // Left behind at the old URL when a site is renamed
+)
+
+// SiteLockNoAccess is the lock state of a site collection nobody can open, admins included
+const SiteLockNoAccess = "NoAccess"
+
+// TenantSite is a site collection as the SharePoint admin center lists it.
+type TenantSite struct {
+	URL       string
+	Title     string
+	Template  string // e.g. GROUP#0, SITEPAGEPUBLISHING#0, STS#3
+	LockState string // Unlock, NoAdditions, ReadOnly or NoAccess
+}
+
+// IsAuditable returns true if the site collection can be opened and holds content of its own.
+func (s *TenantSite) IsAuditable() bool {
+	switch strings.ToUpper(s.Template) {
+	case SiteTemplateTenantAdmin, SiteTemplateRedirect:
+		return false
+	}
+	return !strings.EqualFold(s.LockState, SiteLockNoAccess)
+}
+
+// TenantAdminURL returns the SharePoint admin center URL of the tenant a URL belongs to, e.g.
+// https://contoso-admin.sharepoint.com for https://contoso.sharepoint.com/sites/Finance or
+// https://contoso-my.sharepoint.com. Only the host is looked at.
+func TenantAdminURL(tenantURL string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(tenantURL))
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTenantURL, tenantURL)
+	}
+
+	host := strings.ToLower(parsed.Hostname())
+	tenant, domain, found := strings.Cut(host, ".")
+	if !found || !strings.HasPrefix(domain, "sharepoint.") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTenantURL, tenantURL)
+	}
+	tenant = strings.TrimSuffix(strings.TrimSuffix(tenant, "-admin"), "-my")
+	if tenant == "" {
+		return "", fmt.Errorf("%w: %q", ErrInvalidTenantURL, tenantURL)
+	}
+	return fmt.Sprintf("https://%s-admin.%s", tenant, domain), nil
+}
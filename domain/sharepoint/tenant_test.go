@@ -0,0 +1,40 @@
+package sharepoint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTenantAdminURL(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"https://contoso.sharepoint.com/sites/Finance", "https://contoso-admin.sharepoint.com"},
+		{"https://contoso-admin.sharepoint.com/", "https://contoso-admin.sharepoint.com"},
+		{"https://Contoso-My.SharePoint.com/personal/alice_contoso_com", "https://contoso-admin.sharepoint.com"},
+		{"https://fabrikam.sharepoint.us", "https://fabrikam-admin.sharepoint.us"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			adminURL, err := TenantAdminURL(tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, adminURL)
+		})
+	}
+
+	for _, input := range []string{"", "contoso.sharepoint.com", "http://contoso.sharepoint.com", "https://intranet.contoso.com"} {
+		_, err := TenantAdminURL(input)
+		assert.ErrorIs(t, err, ErrInvalidTenantURL, input)
+	}
+}
+
+func TestTenantSite_IsAuditable(t *testing.T) {
+	assert.True(t, (&TenantSite{Template: "GROUP#0", LockState: "Unlock"}).IsAuditable())
+	assert.True(t, (&TenantSite{Template: "STS#3", LockState: "ReadOnly"}).IsAuditable())
+	assert.False(t, (&TenantSite{Template: "STS#3", LockState: "NoAccess"}).IsAuditable())
+	assert.False(t, (&TenantSite{Template: "RedirectSite#0"}).IsAuditable())
+	assert.False(t, (&TenantSite{Template: "TENANTADMIN#0"}).IsAuditable())
+}
@@ -0,0 +1,36 @@
+package sharepoint
+
+import "strings"
+
+// ChangeNotification is one entry of a SharePoint list webhook notification. It only says that a
+// subscribed list changed; what changed is read from the list's change log.
+type ChangeNotification struct {
+	SubscriptionID     string `json:"subscriptionId"`
+	ClientState        string `json:"clientState"`
+	ExpirationDateTime string `json:"expirationDateTime"`
+	Resource           string `json:"resource"` // ID of the list that changed
+	TenantID           string `json:"tenantId"`
+	SiteURL            string `json:"siteUrl"` // Server-relative URL of the site collection
+	WebID              string `json:"webId"`
+}
+
+// ChangedItem is a list item, file or folder that a list's change log reports as added, updated,
+// renamed, moved, restored or re-permissioned, and that still exists.
+type ChangedItem struct {
+	ID       int
+	Path     string // Server-relative
+	IsFolder bool
+}
+
+// Folder returns the server-relative path of the folder the change is in: the folder itself when
+// a folder changed, else the folder holding the file or item.
+func (c *ChangedItem) Folder() string {
+	path := strings.TrimRight(c.Path, "/")
+	if c.IsFolder {
+		return path
+	}
+	if i := strings.LastIndex(path, "/"); i > 0 {
+		return path[:i]
+	}
+	return path
+}
@@ -11,6 +11,22 @@ import (
 	"time"
 )
 
+const addAuditRunManifestEntry = `-- name: AddAuditRunManifestEntry :exec
+INSERT INTO audit_run_manifest_entries (audit_run_id, list_id, content_hash)
+VALUES (?1, ?2, ?3)
+`
+
+type AddAuditRunManifestEntryParams struct {
+	AuditRunID  int64  `json:"audit_run_id"`
+	ListID      string `json:"list_id"`
+	ContentHash string `json:"content_hash"`
+}
+
+func (q *Queries) AddAuditRunManifestEntry(ctx context.Context, arg AddAuditRunManifestEntryParams) error {
+	_, err := q.db.ExecContext(ctx, addAuditRunManifestEntry, arg.AuditRunID, arg.ListID, arg.ContentHash)
+	return err
+}
+
 const completeAuditRun = `-- name: CompleteAuditRun :exec
 UPDATE audit_runs
 SET completed_at = CURRENT_TIMESTAMP
@@ -34,16 +50,19 @@ func (q *Queries) CompleteAuditRunByJobID(ctx context.Context, jobID string) err
 }
 
 const createAuditRun = `-- name: CreateAuditRun :one
-INSERT INTO audit_runs (job_id, site_id, started_at, audit_trigger)
-VALUES (?1, ?2, ?3, ?4)
+INSERT INTO audit_runs (job_id, site_id, started_at, audit_trigger, scope_path, label, parameters_json)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
 RETURNING audit_run_id
 `
 
 type CreateAuditRunParams struct {
-	JobID        string         `json:"job_id"`
-	SiteID       int64          `json:"site_id"`
-	StartedAt    time.Time      `json:"started_at"`
-	AuditTrigger sql.NullString `json:"audit_trigger"`
+	JobID          string         `json:"job_id"`
+	SiteID         int64          `json:"site_id"`
+	StartedAt      time.Time      `json:"started_at"`
+	AuditTrigger   sql.NullString `json:"audit_trigger"`
+	ScopePath      sql.NullString `json:"scope_path"`
+	Label          sql.NullString `json:"label"`
+	ParametersJson sql.NullString `json:"parameters_json"`
 }
 
 func (q *Queries) CreateAuditRun(ctx context.Context, arg CreateAuditRunParams) (int64, error) {
@@ -52,14 +71,115 @@ func (q *Queries) CreateAuditRun(ctx context.Context, arg CreateAuditRunParams)
 		arg.SiteID,
 		arg.StartedAt,
 		arg.AuditTrigger,
+		arg.ScopePath,
+		arg.Label,
+		arg.ParametersJson,
 	)
 	var audit_run_id int64
 	err := row.Scan(&audit_run_id)
 	return audit_run_id, err
 }
 
+const createAuditRunArtifact = `-- name: CreateAuditRunArtifact :one
+INSERT INTO audit_run_artifacts (audit_run_id, site_id, filename, content_type, size_bytes, content, content_encoding, created_at, expires_at)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+RETURNING artifact_id
+`
+
+type CreateAuditRunArtifactParams struct {
+	AuditRunID      int64     `json:"audit_run_id"`
+	SiteID          int64     `json:"site_id"`
+	Filename        string    `json:"filename"`
+	ContentType     string    `json:"content_type"`
+	SizeBytes       int64     `json:"size_bytes"`
+	Content         []byte    `json:"content"`
+	ContentEncoding string    `json:"content_encoding"`
+	CreatedAt       time.Time `json:"created_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreateAuditRunArtifact(ctx context.Context, arg CreateAuditRunArtifactParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createAuditRunArtifact,
+		arg.AuditRunID,
+		arg.SiteID,
+		arg.Filename,
+		arg.ContentType,
+		arg.SizeBytes,
+		arg.Content,
+		arg.ContentEncoding,
+		arg.CreatedAt,
+		arg.ExpiresAt,
+	)
+	var artifact_id int64
+	err := row.Scan(&artifact_id)
+	return artifact_id, err
+}
+
+const createAuditRunManifest = `-- name: CreateAuditRunManifest :exec
+INSERT INTO audit_run_manifests (audit_run_id, site_id, algorithm, content_hash)
+VALUES (?1, ?2, ?3, ?4)
+`
+
+type CreateAuditRunManifestParams struct {
+	AuditRunID  int64  `json:"audit_run_id"`
+	SiteID      int64  `json:"site_id"`
+	Algorithm   string `json:"algorithm"`
+	ContentHash string `json:"content_hash"`
+}
+
+func (q *Queries) CreateAuditRunManifest(ctx context.Context, arg CreateAuditRunManifestParams) error {
+	_, err := q.db.ExecContext(ctx, createAuditRunManifest,
+		arg.AuditRunID,
+		arg.SiteID,
+		arg.Algorithm,
+		arg.ContentHash,
+	)
+	return err
+}
+
+const createSiteBaseline = `-- name: CreateSiteBaseline :one
+INSERT INTO site_baselines (site_id, audit_run_id, approved_by, note, approved_at)
+VALUES (?1, ?2, ?3, ?4, ?5)
+RETURNING baseline_id
+`
+
+type CreateSiteBaselineParams struct {
+	SiteID     int64          `json:"site_id"`
+	AuditRunID int64          `json:"audit_run_id"`
+	ApprovedBy sql.NullString `json:"approved_by"`
+	Note       sql.NullString `json:"note"`
+	ApprovedAt time.Time      `json:"approved_at"`
+}
+
+func (q *Queries) CreateSiteBaseline(ctx context.Context, arg CreateSiteBaselineParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createSiteBaseline,
+		arg.SiteID,
+		arg.AuditRunID,
+		arg.ApprovedBy,
+		arg.Note,
+		arg.ApprovedAt,
+	)
+	var baseline_id int64
+	err := row.Scan(&baseline_id)
+	return baseline_id, err
+}
+
+const deleteExpiredAuditRunArtifacts = `-- name: DeleteExpiredAuditRunArtifacts :execrows
+DELETE FROM audit_run_artifacts
+WHERE expires_at <= ?1
+  AND audit_run_id NOT IN (SELECT audit_run_id FROM audit_runs WHERE on_hold)
+`
+
+func (q *Queries) DeleteExpiredAuditRunArtifacts(ctx context.Context, now time.Time) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredAuditRunArtifacts, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const getAuditRun = `-- name: GetAuditRun :one
-SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger
+SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, on_hold, held_at, hold_reason, scope_path, label
 FROM audit_runs
 WHERE audit_run_id = ?1
 `
@@ -71,6 +191,11 @@ type GetAuditRunRow struct {
 	StartedAt    time.Time      `json:"started_at"`
 	CompletedAt  sql.NullTime   `json:"completed_at"`
 	AuditTrigger sql.NullString `json:"audit_trigger"`
+	OnHold       bool           `json:"on_hold"`
+	HeldAt       sql.NullTime   `json:"held_at"`
+	HoldReason   sql.NullString `json:"hold_reason"`
+	ScopePath    sql.NullString `json:"scope_path"`
+	Label        sql.NullString `json:"label"`
 }
 
 func (q *Queries) GetAuditRun(ctx context.Context, auditRunID int64) (GetAuditRunRow, error) {
@@ -83,21 +208,383 @@ func (q *Queries) GetAuditRun(ctx context.Context, auditRunID int64) (GetAuditRu
 		&i.StartedAt,
 		&i.CompletedAt,
 		&i.AuditTrigger,
+		&i.OnHold,
+		&i.HeldAt,
+		&i.HoldReason,
+		&i.ScopePath,
+		&i.Label,
+	)
+	return i, err
+}
+
+const getAuditRunArtifact = `-- name: GetAuditRunArtifact :one
+SELECT a.artifact_id, a.audit_run_id, a.site_id, a.filename, a.content_type, a.size_bytes, a.content, a.content_encoding, a.created_at, a.expires_at
+FROM audit_run_artifacts a
+JOIN audit_runs ar ON ar.audit_run_id = a.audit_run_id
+WHERE a.artifact_id = ?1 AND (a.expires_at > ?2 OR ar.on_hold)
+`
+
+type GetAuditRunArtifactParams struct {
+	ArtifactID int64     `json:"artifact_id"`
+	Now        time.Time `json:"now"`
+}
+
+type GetAuditRunArtifactRow struct {
+	ArtifactID      int64     `json:"artifact_id"`
+	AuditRunID      int64     `json:"audit_run_id"`
+	SiteID          int64     `json:"site_id"`
+	Filename        string    `json:"filename"`
+	ContentType     string    `json:"content_type"`
+	SizeBytes       int64     `json:"size_bytes"`
+	Content         []byte    `json:"content"`
+	ContentEncoding string    `json:"content_encoding"`
+	CreatedAt       time.Time `json:"created_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+}
+
+func (q *Queries) GetAuditRunArtifact(ctx context.Context, arg GetAuditRunArtifactParams) (GetAuditRunArtifactRow, error) {
+	row := q.db.QueryRowContext(ctx, getAuditRunArtifact, arg.ArtifactID, arg.Now)
+	var i GetAuditRunArtifactRow
+	err := row.Scan(
+		&i.ArtifactID,
+		&i.AuditRunID,
+		&i.SiteID,
+		&i.Filename,
+		&i.ContentType,
+		&i.SizeBytes,
+		&i.Content,
+		&i.ContentEncoding,
+		&i.CreatedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getAuditRunArtifacts = `-- name: GetAuditRunArtifacts :many
+SELECT a.artifact_id, a.audit_run_id, a.site_id, a.filename, a.content_type, a.size_bytes, a.created_at, a.expires_at
+FROM audit_run_artifacts a
+JOIN audit_runs ar ON ar.audit_run_id = a.audit_run_id
+WHERE a.audit_run_id = ?1 AND (a.expires_at > ?2 OR ar.on_hold)
+ORDER BY a.created_at DESC, a.artifact_id DESC
+`
+
+type GetAuditRunArtifactsParams struct {
+	AuditRunID int64     `json:"audit_run_id"`
+	Now        time.Time `json:"now"`
+}
+
+type GetAuditRunArtifactsRow struct {
+	ArtifactID  int64     `json:"artifact_id"`
+	AuditRunID  int64     `json:"audit_run_id"`
+	SiteID      int64     `json:"site_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (q *Queries) GetAuditRunArtifacts(ctx context.Context, arg GetAuditRunArtifactsParams) ([]GetAuditRunArtifactsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAuditRunArtifacts, arg.AuditRunID, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAuditRunArtifactsRow
+	for rows.Next() {
+		var i GetAuditRunArtifactsRow
+		if err := rows.Scan(
+			&i.ArtifactID,
+			&i.AuditRunID,
+			&i.SiteID,
+			&i.Filename,
+			&i.ContentType,
+			&i.SizeBytes,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuditRunByJobID = `-- name: GetAuditRunByJobID :one
+SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, on_hold, held_at, hold_reason, scope_path, label
+FROM audit_runs
+WHERE job_id = ?1
+`
+
+type GetAuditRunByJobIDRow struct {
+	AuditRunID   int64          `json:"audit_run_id"`
+	JobID        string         `json:"job_id"`
+	SiteID       int64          `json:"site_id"`
+	StartedAt    time.Time      `json:"started_at"`
+	CompletedAt  sql.NullTime   `json:"completed_at"`
+	AuditTrigger sql.NullString `json:"audit_trigger"`
+	OnHold       bool           `json:"on_hold"`
+	HeldAt       sql.NullTime   `json:"held_at"`
+	HoldReason   sql.NullString `json:"hold_reason"`
+	ScopePath    sql.NullString `json:"scope_path"`
+	Label        sql.NullString `json:"label"`
+}
+
+func (q *Queries) GetAuditRunByJobID(ctx context.Context, jobID string) (GetAuditRunByJobIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getAuditRunByJobID, jobID)
+	var i GetAuditRunByJobIDRow
+	err := row.Scan(
+		&i.AuditRunID,
+		&i.JobID,
+		&i.SiteID,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.AuditTrigger,
+		&i.OnHold,
+		&i.HeldAt,
+		&i.HoldReason,
+		&i.ScopePath,
+		&i.Label,
 	)
 	return i, err
 }
 
+const getAuditRunManifest = `-- name: GetAuditRunManifest :one
+SELECT audit_run_id, site_id, algorithm, content_hash, created_at
+FROM audit_run_manifests
+WHERE audit_run_id = ?1
+`
+
+func (q *Queries) GetAuditRunManifest(ctx context.Context, auditRunID int64) (AuditRunManifest, error) {
+	row := q.db.QueryRowContext(ctx, getAuditRunManifest, auditRunID)
+	var i AuditRunManifest
+	err := row.Scan(
+		&i.AuditRunID,
+		&i.SiteID,
+		&i.Algorithm,
+		&i.ContentHash,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getAuditRunManifestEntries = `-- name: GetAuditRunManifestEntries :many
+SELECT list_id, content_hash
+FROM audit_run_manifest_entries
+WHERE audit_run_id = ?1
+ORDER BY list_id
+`
+
+type GetAuditRunManifestEntriesRow struct {
+	ListID      string `json:"list_id"`
+	ContentHash string `json:"content_hash"`
+}
+
+func (q *Queries) GetAuditRunManifestEntries(ctx context.Context, auditRunID int64) ([]GetAuditRunManifestEntriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAuditRunManifestEntries, auditRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAuditRunManifestEntriesRow
+	for rows.Next() {
+		var i GetAuditRunManifestEntriesRow
+		if err := rows.Scan(&i.ListID, &i.ContentHash); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuditRunParametersByJobID = `-- name: GetAuditRunParametersByJobID :one
+SELECT audit_run_id, parameters_json
+FROM audit_runs
+WHERE job_id = ?1
+`
+
+type GetAuditRunParametersByJobIDRow struct {
+	AuditRunID     int64          `json:"audit_run_id"`
+	ParametersJson sql.NullString `json:"parameters_json"`
+}
+
+func (q *Queries) GetAuditRunParametersByJobID(ctx context.Context, jobID string) (GetAuditRunParametersByJobIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getAuditRunParametersByJobID, jobID)
+	var i GetAuditRunParametersByJobIDRow
+	err := row.Scan(&i.AuditRunID, &i.ParametersJson)
+	return i, err
+}
+
+const getAuditRunPayloadSamples = `-- name: GetAuditRunPayloadSamples :many
+SELECT sample_id, source, sample_key, payload, payload_encoding, truncated, schema_drift, captured_at
+FROM audit_run_payload_samples
+WHERE audit_run_id = ?1
+ORDER BY sample_id
+`
+
+type GetAuditRunPayloadSamplesRow struct {
+	SampleID        int64     `json:"sample_id"`
+	Source          string    `json:"source"`
+	SampleKey       string    `json:"sample_key"`
+	Payload         []byte    `json:"payload"`
+	PayloadEncoding string    `json:"payload_encoding"`
+	Truncated       bool      `json:"truncated"`
+	SchemaDrift     bool      `json:"schema_drift"`
+	CapturedAt      time.Time `json:"captured_at"`
+}
+
+func (q *Queries) GetAuditRunPayloadSamples(ctx context.Context, auditRunID int64) ([]GetAuditRunPayloadSamplesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAuditRunPayloadSamples, auditRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAuditRunPayloadSamplesRow
+	for rows.Next() {
+		var i GetAuditRunPayloadSamplesRow
+		if err := rows.Scan(
+			&i.SampleID,
+			&i.Source,
+			&i.SampleKey,
+			&i.Payload,
+			&i.PayloadEncoding,
+			&i.Truncated,
+			&i.SchemaDrift,
+			&i.CapturedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuditRunRowCounts = `-- name: GetAuditRunRowCounts :many
+SELECT ar.audit_run_id, ar.site_id, s.site_url,
+  CAST((SELECT COUNT(*) FROM items i WHERE i.audit_run_id = ar.audit_run_id) AS INTEGER) AS items,
+  CAST((SELECT COUNT(*) FROM role_assignments ra WHERE ra.audit_run_id = ar.audit_run_id) AS INTEGER) AS role_assignments,
+  CAST((SELECT COUNT(*) FROM sharing_links sl WHERE sl.audit_run_id = ar.audit_run_id) AS INTEGER) AS sharing_links,
+  CAST((SELECT COUNT(*) FROM principals p WHERE p.audit_run_id = ar.audit_run_id) AS INTEGER) AS principals
+FROM audit_runs ar
+JOIN sites s ON s.site_id = ar.site_id
+ORDER BY ar.audit_run_id DESC
+LIMIT ?1
+`
+
+type GetAuditRunRowCountsRow struct {
+	AuditRunID      int64  `json:"audit_run_id"`
+	SiteID          int64  `json:"site_id"`
+	SiteUrl         string `json:"site_url"`
+	Items           int64  `json:"items"`
+	RoleAssignments int64  `json:"role_assignments"`
+	SharingLinks    int64  `json:"sharing_links"`
+	Principals      int64  `json:"principals"`
+}
+
+// Row counts of the most recent runs, for storage monitoring.
+func (q *Queries) GetAuditRunRowCounts(ctx context.Context, limitCount int64) ([]GetAuditRunRowCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAuditRunRowCounts, limitCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAuditRunRowCountsRow
+	for rows.Next() {
+		var i GetAuditRunRowCountsRow
+		if err := rows.Scan(
+			&i.AuditRunID,
+			&i.SiteID,
+			&i.SiteUrl,
+			&i.Items,
+			&i.RoleAssignments,
+			&i.SharingLinks,
+			&i.Principals,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAuditRunSchemaDrift = `-- name: GetAuditRunSchemaDrift :many
+SELECT source, field, kind, occurrences
+FROM audit_run_schema_drift
+WHERE audit_run_id = ?1
+ORDER BY kind, source, field
+`
+
+type GetAuditRunSchemaDriftRow struct {
+	Source      string `json:"source"`
+	Field       string `json:"field"`
+	Kind        string `json:"kind"`
+	Occurrences int64  `json:"occurrences"`
+}
+
+func (q *Queries) GetAuditRunSchemaDrift(ctx context.Context, auditRunID int64) ([]GetAuditRunSchemaDriftRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAuditRunSchemaDrift, auditRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAuditRunSchemaDriftRow
+	for rows.Next() {
+		var i GetAuditRunSchemaDriftRow
+		if err := rows.Scan(
+			&i.Source,
+			&i.Field,
+			&i.Kind,
+			&i.Occurrences,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAuditRunsForSite = `-- name: GetAuditRunsForSite :many
-SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger
+SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, on_hold, held_at, hold_reason, scope_path, label
 FROM audit_runs
-WHERE site_id = ?1
+WHERE site_id = ?1 AND (CAST(?2 AS TEXT) = '' OR label = ?2)
 ORDER BY started_at DESC
-LIMIT ?2
+LIMIT ?3
 `
 
 type GetAuditRunsForSiteParams struct {
-	SiteID     int64 `json:"site_id"`
-	LimitCount int64 `json:"limit_count"`
+	SiteID     int64  `json:"site_id"`
+	Label      string `json:"label"`
+	LimitCount int64  `json:"limit_count"`
 }
 
 type GetAuditRunsForSiteRow struct {
@@ -107,10 +594,16 @@ type GetAuditRunsForSiteRow struct {
 	StartedAt    time.Time      `json:"started_at"`
 	CompletedAt  sql.NullTime   `json:"completed_at"`
 	AuditTrigger sql.NullString `json:"audit_trigger"`
+	OnHold       bool           `json:"on_hold"`
+	HeldAt       sql.NullTime   `json:"held_at"`
+	HoldReason   sql.NullString `json:"hold_reason"`
+	ScopePath    sql.NullString `json:"scope_path"`
+	Label        sql.NullString `json:"label"`
 }
 
+// An empty label lists every run of the site.
 func (q *Queries) GetAuditRunsForSite(ctx context.Context, arg GetAuditRunsForSiteParams) ([]GetAuditRunsForSiteRow, error) {
-	rows, err := q.db.QueryContext(ctx, getAuditRunsForSite, arg.SiteID, arg.LimitCount)
+	rows, err := q.db.QueryContext(ctx, getAuditRunsForSite, arg.SiteID, arg.Label, arg.LimitCount)
 	if err != nil {
 		return nil, err
 	}
@@ -125,6 +618,11 @@ func (q *Queries) GetAuditRunsForSite(ctx context.Context, arg GetAuditRunsForSi
 			&i.StartedAt,
 			&i.CompletedAt,
 			&i.AuditTrigger,
+			&i.OnHold,
+			&i.HeldAt,
+			&i.HoldReason,
+			&i.ScopePath,
+			&i.Label,
 		); err != nil {
 			return nil, err
 		}
@@ -139,14 +637,40 @@ func (q *Queries) GetAuditRunsForSite(ctx context.Context, arg GetAuditRunsForSi
 	return items, nil
 }
 
+const getCurrentSiteBaseline = `-- name: GetCurrentSiteBaseline :one
+SELECT baseline_id, site_id, audit_run_id, approved_by, note, approved_at, superseded_at
+FROM site_baselines
+WHERE site_id = ?1 AND superseded_at IS NULL
+`
+
+func (q *Queries) GetCurrentSiteBaseline(ctx context.Context, siteID int64) (SiteBaseline, error) {
+	row := q.db.QueryRowContext(ctx, getCurrentSiteBaseline, siteID)
+	var i SiteBaseline
+	err := row.Scan(
+		&i.BaselineID,
+		&i.SiteID,
+		&i.AuditRunID,
+		&i.ApprovedBy,
+		&i.Note,
+		&i.ApprovedAt,
+		&i.SupersededAt,
+	)
+	return i, err
+}
+
 const getLatestAuditRunForSite = `-- name: GetLatestAuditRunForSite :one
-SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger
+SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, on_hold, held_at, hold_reason, scope_path, label
 FROM audit_runs
-WHERE site_id = ?1
-ORDER BY started_at DESC
+WHERE site_id = ?1 AND (CAST(?2 AS TEXT) = '' OR label = ?2)
+ORDER BY scope_path IS NULL DESC, started_at DESC
 LIMIT 1
 `
 
+type GetLatestAuditRunForSiteParams struct {
+	SiteID int64  `json:"site_id"`
+	Label  string `json:"label"`
+}
+
 type GetLatestAuditRunForSiteRow struct {
 	AuditRunID   int64          `json:"audit_run_id"`
 	JobID        string         `json:"job_id"`
@@ -154,10 +678,17 @@ type GetLatestAuditRunForSiteRow struct {
 	StartedAt    time.Time      `json:"started_at"`
 	CompletedAt  sql.NullTime   `json:"completed_at"`
 	AuditTrigger sql.NullString `json:"audit_trigger"`
+	OnHold       bool           `json:"on_hold"`
+	HeldAt       sql.NullTime   `json:"held_at"`
+	HoldReason   sql.NullString `json:"hold_reason"`
+	ScopePath    sql.NullString `json:"scope_path"`
+	Label        sql.NullString `json:"label"`
 }
 
-func (q *Queries) GetLatestAuditRunForSite(ctx context.Context, siteID int64) (GetLatestAuditRunForSiteRow, error) {
-	row := q.db.QueryRowContext(ctx, getLatestAuditRunForSite, siteID)
+// Full site runs are preferred; a folder-scoped run is only latest when the site has no other.
+// A label restricts the candidates to runs tagged with it.
+func (q *Queries) GetLatestAuditRunForSite(ctx context.Context, arg GetLatestAuditRunForSiteParams) (GetLatestAuditRunForSiteRow, error) {
+	row := q.db.QueryRowContext(ctx, getLatestAuditRunForSite, arg.SiteID, arg.Label)
 	var i GetLatestAuditRunForSiteRow
 	err := row.Scan(
 		&i.AuditRunID,
@@ -166,10 +697,311 @@ func (q *Queries) GetLatestAuditRunForSite(ctx context.Context, siteID int64) (G
 		&i.StartedAt,
 		&i.CompletedAt,
 		&i.AuditTrigger,
+		&i.OnHold,
+		&i.HeldAt,
+		&i.HoldReason,
+		&i.ScopePath,
+		&i.Label,
+	)
+	return i, err
+}
+
+const getLatestCompletedAuditRunForSite = `-- name: GetLatestCompletedAuditRunForSite :one
+SELECT audit_run_id, job_id, site_id, started_at, completed_at, audit_trigger, on_hold, held_at, hold_reason, scope_path, label
+FROM audit_runs
+WHERE site_id = ?1 AND completed_at IS NOT NULL
+  AND (CAST(?2 AS TEXT) = '' OR label = ?2)
+ORDER BY scope_path IS NULL DESC, started_at DESC
+LIMIT 1
+`
+
+type GetLatestCompletedAuditRunForSiteParams struct {
+	SiteID int64  `json:"site_id"`
+	Label  string `json:"label"`
+}
+
+type GetLatestCompletedAuditRunForSiteRow struct {
+	AuditRunID   int64          `json:"audit_run_id"`
+	JobID        string         `json:"job_id"`
+	SiteID       int64          `json:"site_id"`
+	StartedAt    time.Time      `json:"started_at"`
+	CompletedAt  sql.NullTime   `json:"completed_at"`
+	AuditTrigger sql.NullString `json:"audit_trigger"`
+	OnHold       bool           `json:"on_hold"`
+	HeldAt       sql.NullTime   `json:"held_at"`
+	HoldReason   sql.NullString `json:"hold_reason"`
+	ScopePath    sql.NullString `json:"scope_path"`
+	Label        sql.NullString `json:"label"`
+}
+
+func (q *Queries) GetLatestCompletedAuditRunForSite(ctx context.Context, arg GetLatestCompletedAuditRunForSiteParams) (GetLatestCompletedAuditRunForSiteRow, error) {
+	row := q.db.QueryRowContext(ctx, getLatestCompletedAuditRunForSite, arg.SiteID, arg.Label)
+	var i GetLatestCompletedAuditRunForSiteRow
+	err := row.Scan(
+		&i.AuditRunID,
+		&i.JobID,
+		&i.SiteID,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.AuditTrigger,
+		&i.OnHold,
+		&i.HeldAt,
+		&i.HoldReason,
+		&i.ScopePath,
+		&i.Label,
+	)
+	return i, err
+}
+
+const getPinnedAuditRunForSite = `-- name: GetPinnedAuditRunForSite :one
+SELECT ar.audit_run_id, ar.job_id, ar.site_id, ar.started_at, ar.completed_at, ar.audit_trigger, ar.on_hold, ar.held_at, ar.hold_reason, ar.scope_path, ar.label
+FROM sites s
+JOIN audit_runs ar ON ar.audit_run_id = s.pinned_audit_run_id AND ar.site_id = s.site_id
+WHERE s.site_id = ?1
+`
+
+type GetPinnedAuditRunForSiteRow struct {
+	AuditRunID   int64          `json:"audit_run_id"`
+	JobID        string         `json:"job_id"`
+	SiteID       int64          `json:"site_id"`
+	StartedAt    time.Time      `json:"started_at"`
+	CompletedAt  sql.NullTime   `json:"completed_at"`
+	AuditTrigger sql.NullString `json:"audit_trigger"`
+	OnHold       bool           `json:"on_hold"`
+	HeldAt       sql.NullTime   `json:"held_at"`
+	HoldReason   sql.NullString `json:"hold_reason"`
+	ScopePath    sql.NullString `json:"scope_path"`
+	Label        sql.NullString `json:"label"`
+}
+
+func (q *Queries) GetPinnedAuditRunForSite(ctx context.Context, siteID int64) (GetPinnedAuditRunForSiteRow, error) {
+	row := q.db.QueryRowContext(ctx, getPinnedAuditRunForSite, siteID)
+	var i GetPinnedAuditRunForSiteRow
+	err := row.Scan(
+		&i.AuditRunID,
+		&i.JobID,
+		&i.SiteID,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.AuditTrigger,
+		&i.OnHold,
+		&i.HeldAt,
+		&i.HoldReason,
+		&i.ScopePath,
+		&i.Label,
+	)
+	return i, err
+}
+
+const getPreviousCompletedAuditRunForScope = `-- name: GetPreviousCompletedAuditRunForScope :one
+SELECT ar.audit_run_id, ar.job_id, ar.site_id, ar.started_at, ar.completed_at, ar.audit_trigger, ar.on_hold, ar.held_at, ar.hold_reason, ar.scope_path, ar.label
+FROM audit_runs ar
+JOIN audit_runs current_run ON current_run.audit_run_id = ?1
+WHERE ar.site_id = current_run.site_id AND ar.scope_path IS current_run.scope_path AND ar.completed_at IS NOT NULL
+  AND ar.started_at < current_run.started_at
+ORDER BY ar.started_at DESC, ar.audit_run_id DESC
+LIMIT 1
+`
+
+type GetPreviousCompletedAuditRunForScopeRow struct {
+	AuditRunID   int64          `json:"audit_run_id"`
+	JobID        string         `json:"job_id"`
+	SiteID       int64          `json:"site_id"`
+	StartedAt    time.Time      `json:"started_at"`
+	CompletedAt  sql.NullTime   `json:"completed_at"`
+	AuditTrigger sql.NullString `json:"audit_trigger"`
+	OnHold       bool           `json:"on_hold"`
+	HeldAt       sql.NullTime   `json:"held_at"`
+	HoldReason   sql.NullString `json:"hold_reason"`
+	ScopePath    sql.NullString `json:"scope_path"`
+	Label        sql.NullString `json:"label"`
+}
+
+// The completed run of the site with the same scope, the same folder or the whole site, started most recently before the given run.
+func (q *Queries) GetPreviousCompletedAuditRunForScope(ctx context.Context, auditRunID int64) (GetPreviousCompletedAuditRunForScopeRow, error) {
+	row := q.db.QueryRowContext(ctx, getPreviousCompletedAuditRunForScope, auditRunID)
+	var i GetPreviousCompletedAuditRunForScopeRow
+	err := row.Scan(
+		&i.AuditRunID,
+		&i.JobID,
+		&i.SiteID,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.AuditTrigger,
+		&i.OnHold,
+		&i.HeldAt,
+		&i.HoldReason,
+		&i.ScopePath,
+		&i.Label,
 	)
 	return i, err
 }
 
+const getSiteAuditRunArtifacts = `-- name: GetSiteAuditRunArtifacts :many
+SELECT a.artifact_id, a.audit_run_id, a.site_id, a.filename, a.content_type, a.size_bytes, a.created_at, a.expires_at
+FROM audit_run_artifacts a
+JOIN audit_runs ar ON ar.audit_run_id = a.audit_run_id
+WHERE a.site_id = ?1 AND (a.expires_at > ?2 OR ar.on_hold)
+ORDER BY a.created_at DESC, a.artifact_id DESC
+`
+
+type GetSiteAuditRunArtifactsParams struct {
+	SiteID int64     `json:"site_id"`
+	Now    time.Time `json:"now"`
+}
+
+type GetSiteAuditRunArtifactsRow struct {
+	ArtifactID  int64     `json:"artifact_id"`
+	AuditRunID  int64     `json:"audit_run_id"`
+	SiteID      int64     `json:"site_id"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+func (q *Queries) GetSiteAuditRunArtifacts(ctx context.Context, arg GetSiteAuditRunArtifactsParams) ([]GetSiteAuditRunArtifactsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSiteAuditRunArtifacts, arg.SiteID, arg.Now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSiteAuditRunArtifactsRow
+	for rows.Next() {
+		var i GetSiteAuditRunArtifactsRow
+		if err := rows.Scan(
+			&i.ArtifactID,
+			&i.AuditRunID,
+			&i.SiteID,
+			&i.Filename,
+			&i.ContentType,
+			&i.SizeBytes,
+			&i.CreatedAt,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSiteBaselines = `-- name: GetSiteBaselines :many
+SELECT baseline_id, site_id, audit_run_id, approved_by, note, approved_at, superseded_at
+FROM site_baselines
+WHERE site_id = ?1
+ORDER BY approved_at DESC, baseline_id DESC
+`
+
+func (q *Queries) GetSiteBaselines(ctx context.Context, siteID int64) ([]SiteBaseline, error) {
+	rows, err := q.db.QueryContext(ctx, getSiteBaselines, siteID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SiteBaseline
+	for rows.Next() {
+		var i SiteBaseline
+		if err := rows.Scan(
+			&i.BaselineID,
+			&i.SiteID,
+			&i.AuditRunID,
+			&i.ApprovedBy,
+			&i.Note,
+			&i.ApprovedAt,
+			&i.SupersededAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const holdAuditRun = `-- name: HoldAuditRun :exec
+UPDATE audit_runs
+SET on_hold = TRUE, held_at = CURRENT_TIMESTAMP, hold_reason = ?1
+WHERE audit_run_id = ?2
+`
+
+type HoldAuditRunParams struct {
+	HoldReason sql.NullString `json:"hold_reason"`
+	AuditRunID int64          `json:"audit_run_id"`
+}
+
+func (q *Queries) HoldAuditRun(ctx context.Context, arg HoldAuditRunParams) error {
+	_, err := q.db.ExecContext(ctx, holdAuditRun, arg.HoldReason, arg.AuditRunID)
+	return err
+}
+
+const insertAuditRunPayloadSample = `-- name: InsertAuditRunPayloadSample :exec
+INSERT INTO audit_run_payload_samples (audit_run_id, source, sample_key, payload, payload_encoding, truncated, schema_drift, captured_at)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8)
+`
+
+type InsertAuditRunPayloadSampleParams struct {
+	AuditRunID      int64     `json:"audit_run_id"`
+	Source          string    `json:"source"`
+	SampleKey       string    `json:"sample_key"`
+	Payload         []byte    `json:"payload"`
+	PayloadEncoding string    `json:"payload_encoding"`
+	Truncated       bool      `json:"truncated"`
+	SchemaDrift     bool      `json:"schema_drift"`
+	CapturedAt      time.Time `json:"captured_at"`
+}
+
+func (q *Queries) InsertAuditRunPayloadSample(ctx context.Context, arg InsertAuditRunPayloadSampleParams) error {
+	_, err := q.db.ExecContext(ctx, insertAuditRunPayloadSample,
+		arg.AuditRunID,
+		arg.Source,
+		arg.SampleKey,
+		arg.Payload,
+		arg.PayloadEncoding,
+		arg.Truncated,
+		arg.SchemaDrift,
+		arg.CapturedAt,
+	)
+	return err
+}
+
+const insertAuditRunSchemaDrift = `-- name: InsertAuditRunSchemaDrift :exec
+INSERT INTO audit_run_schema_drift (audit_run_id, source, field, kind, occurrences)
+VALUES (?1, ?2, ?3, ?4, ?5)
+ON CONFLICT (audit_run_id, source, field, kind) DO UPDATE SET occurrences = occurrences + excluded.occurrences
+`
+
+type InsertAuditRunSchemaDriftParams struct {
+	AuditRunID  int64  `json:"audit_run_id"`
+	Source      string `json:"source"`
+	Field       string `json:"field"`
+	Kind        string `json:"kind"`
+	Occurrences int64  `json:"occurrences"`
+}
+
+func (q *Queries) InsertAuditRunSchemaDrift(ctx context.Context, arg InsertAuditRunSchemaDriftParams) error {
+	_, err := q.db.ExecContext(ctx, insertAuditRunSchemaDrift,
+		arg.AuditRunID,
+		arg.Source,
+		arg.Field,
+		arg.Kind,
+		arg.Occurrences,
+	)
+	return err
+}
+
 const migrateCompletedAuditRuns = `-- name: MigrateCompletedAuditRuns :exec
 UPDATE audit_runs 
 SET completed_at = (
@@ -190,3 +1022,33 @@ func (q *Queries) MigrateCompletedAuditRuns(ctx context.Context) error {
 	_, err := q.db.ExecContext(ctx, migrateCompletedAuditRuns)
 	return err
 }
+
+const releaseAuditRunHold = `-- name: ReleaseAuditRunHold :exec
+UPDATE audit_runs
+SET on_hold = FALSE, held_at = NULL, hold_reason = NULL
+WHERE audit_run_id = ?1
+`
+
+func (q *Queries) ReleaseAuditRunHold(ctx context.Context, auditRunID int64) error {
+	_, err := q.db.ExecContext(ctx, releaseAuditRunHold, auditRunID)
+	return err
+}
+
+const supersedeSiteBaseline = `-- name: SupersedeSiteBaseline :execrows
+UPDATE site_baselines
+SET superseded_at = ?1
+WHERE site_id = ?2 AND superseded_at IS NULL
+`
+
+type SupersedeSiteBaselineParams struct {
+	SupersededAt sql.NullTime `json:"superseded_at"`
+	SiteID       int64        `json:"site_id"`
+}
+
+func (q *Queries) SupersedeSiteBaseline(ctx context.Context, arg SupersedeSiteBaselineParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, supersedeSiteBaseline, arg.SupersededAt, arg.SiteID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
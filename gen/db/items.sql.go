@@ -10,18 +10,207 @@ import (
 	"database/sql"
 )
 
-const getItemByGUID = `-- name: GetItemByGUID :one
+const countFilteredItemsForList = `-- name: CountFilteredItemsForList :one
+SELECT COUNT(*)
+FROM items
+WHERE site_id = ?1 AND list_id = ?2
+  AND (CAST(?3 AS INTEGER) = 0 OR has_unique = 1)
+  AND (CAST(?4 AS TEXT) = ''
+       OR (CAST(?4 AS TEXT) = 'file' AND is_file = 1)
+       OR (CAST(?4 AS TEXT) = 'folder' AND is_folder = 1)
+       OR (CAST(?4 AS TEXT) = 'item' AND COALESCE(is_file, 0) = 0 AND COALESCE(is_folder, 0) = 0))
+`
+
+type CountFilteredItemsForListParams struct {
+	SiteID     int64  `json:"site_id"`
+	ListID     string `json:"list_id"`
+	UniqueOnly int64  `json:"unique_only"`
+	Kind       string `json:"kind"`
+}
+
+func (q *Queries) CountFilteredItemsForList(ctx context.Context, arg CountFilteredItemsForListParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countFilteredItemsForList,
+		arg.SiteID,
+		arg.ListID,
+		arg.UniqueOnly,
+		arg.Kind,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countFilteredItemsForListByAuditRun = `-- name: CountFilteredItemsForListByAuditRun :one
+SELECT COUNT(*)
+FROM items
+WHERE site_id = ?1 AND list_id = ?2 AND audit_run_id = ?3
+  AND (CAST(?4 AS INTEGER) = 0 OR has_unique = 1)
+  AND (CAST(?5 AS TEXT) = ''
+       OR (CAST(?5 AS TEXT) = 'file' AND is_file = 1)
+       OR (CAST(?5 AS TEXT) = 'folder' AND is_folder = 1)
+       OR (CAST(?5 AS TEXT) = 'item' AND COALESCE(is_file, 0) = 0 AND COALESCE(is_folder, 0) = 0))
+`
+
+type CountFilteredItemsForListByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	ListID     string `json:"list_id"`
+	AuditRunID int64  `json:"audit_run_id"`
+	UniqueOnly int64  `json:"unique_only"`
+	Kind       string `json:"kind"`
+}
+
+func (q *Queries) CountFilteredItemsForListByAuditRun(ctx context.Context, arg CountFilteredItemsForListByAuditRunParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countFilteredItemsForListByAuditRun,
+		arg.SiteID,
+		arg.ListID,
+		arg.AuditRunID,
+		arg.UniqueOnly,
+		arg.Kind,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countItemsByKindForListByAuditRun = `-- name: CountItemsByKindForListByAuditRun :one
+SELECT
+  CAST(COUNT(*) AS INTEGER) AS total_items,
+  CAST(COALESCE(SUM(CASE WHEN has_unique THEN 1 ELSE 0 END), 0) AS INTEGER) AS items_with_unique,
+  CAST(COALESCE(SUM(CASE WHEN is_file THEN 1 ELSE 0 END), 0) AS INTEGER) AS files_count,
+  CAST(COALESCE(SUM(CASE WHEN is_folder AND NOT COALESCE(is_file, FALSE) THEN 1 ELSE 0 END), 0) AS INTEGER) AS folders_count
+FROM items
+WHERE site_id = ?1 AND list_id = ?2 AND audit_run_id = ?3
+`
+
+type CountItemsByKindForListByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	ListID     string `json:"list_id"`
+	AuditRunID int64  `json:"audit_run_id"`
+}
+
+type CountItemsByKindForListByAuditRunRow struct {
+	TotalItems      int64 `json:"total_items"`
+	ItemsWithUnique int64 `json:"items_with_unique"`
+	FilesCount      int64 `json:"files_count"`
+	FoldersCount    int64 `json:"folders_count"`
+}
+
+// Item totals for a list, for analytics without loading each item
+func (q *Queries) CountItemsByKindForListByAuditRun(ctx context.Context, arg CountItemsByKindForListByAuditRunParams) (CountItemsByKindForListByAuditRunRow, error) {
+	row := q.db.QueryRowContext(ctx, countItemsByKindForListByAuditRun, arg.SiteID, arg.ListID, arg.AuditRunID)
+	var i CountItemsByKindForListByAuditRunRow
+	err := row.Scan(
+		&i.TotalItems,
+		&i.ItemsWithUnique,
+		&i.FilesCount,
+		&i.FoldersCount,
+	)
+	return i, err
+}
+
+const filteredItemsForList = `-- name: FilteredItemsForList :many
 SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id
 FROM items
-WHERE site_id = ?1 AND item_guid = ?2
+WHERE site_id = ?1 AND list_id = ?2
+  AND (CAST(?3 AS INTEGER) = 0 OR has_unique = 1)
+  AND (CAST(?4 AS TEXT) = ''
+       OR (CAST(?4 AS TEXT) = 'file' AND is_file = 1)
+       OR (CAST(?4 AS TEXT) = 'folder' AND is_folder = 1)
+       OR (CAST(?4 AS TEXT) = 'item' AND COALESCE(is_file, 0) = 0 AND COALESCE(is_folder, 0) = 0))
+ORDER BY item_id
+LIMIT ?6 OFFSET ?5
 `
 
-type GetItemByGUIDParams struct {
-	SiteID   int64  `json:"site_id"`
-	ItemGuid string `json:"item_guid"`
+type FilteredItemsForListParams struct {
+	SiteID     int64  `json:"site_id"`
+	ListID     string `json:"list_id"`
+	UniqueOnly int64  `json:"unique_only"`
+	Kind       string `json:"kind"`
+	Offset     int64  `json:"offset"`
+	Limit      int64  `json:"limit"`
 }
 
-type GetItemByGUIDRow struct {
+type FilteredItemsForListRow struct {
+	SiteID       int64          `json:"site_id"`
+	ItemGuid     string         `json:"item_guid"`
+	ListItemGuid sql.NullString `json:"list_item_guid"`
+	ListID       string         `json:"list_id"`
+	ItemID       int64          `json:"item_id"`
+	Url          sql.NullString `json:"url"`
+	IsFile       sql.NullBool   `json:"is_file"`
+	IsFolder     sql.NullBool   `json:"is_folder"`
+	HasUnique    sql.NullBool   `json:"has_unique"`
+	Name         sql.NullString `json:"name"`
+	AuditRunID   int64          `json:"audit_run_id"`
+}
+
+// Optional filters: unique_only (0/1) and kind (”, 'file', 'folder' or 'item').
+func (q *Queries) FilteredItemsForList(ctx context.Context, arg FilteredItemsForListParams) ([]FilteredItemsForListRow, error) {
+	rows, err := q.db.QueryContext(ctx, filteredItemsForList,
+		arg.SiteID,
+		arg.ListID,
+		arg.UniqueOnly,
+		arg.Kind,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FilteredItemsForListRow
+	for rows.Next() {
+		var i FilteredItemsForListRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.ItemGuid,
+			&i.ListItemGuid,
+			&i.ListID,
+			&i.ItemID,
+			&i.Url,
+			&i.IsFile,
+			&i.IsFolder,
+			&i.HasUnique,
+			&i.Name,
+			&i.AuditRunID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const filteredItemsForListByAuditRun = `-- name: FilteredItemsForListByAuditRun :many
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id
+FROM items
+WHERE site_id = ?1 AND list_id = ?2 AND audit_run_id = ?3
+  AND (CAST(?4 AS INTEGER) = 0 OR has_unique = 1)
+  AND (CAST(?5 AS TEXT) = ''
+       OR (CAST(?5 AS TEXT) = 'file' AND is_file = 1)
+       OR (CAST(?5 AS TEXT) = 'folder' AND is_folder = 1)
+       OR (CAST(?5 AS TEXT) = 'item' AND COALESCE(is_file, 0) = 0 AND COALESCE(is_folder, 0) = 0))
+ORDER BY item_id
+LIMIT ?7 OFFSET ?6
+`
+
+type FilteredItemsForListByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	ListID     string `json:"list_id"`
+	AuditRunID int64  `json:"audit_run_id"`
+	UniqueOnly int64  `json:"unique_only"`
+	Kind       string `json:"kind"`
+	Offset     int64  `json:"offset"`
+	Limit      int64  `json:"limit"`
+}
+
+type FilteredItemsForListByAuditRunRow struct {
 	SiteID       int64          `json:"site_id"`
 	ItemGuid     string         `json:"item_guid"`
 	ListItemGuid sql.NullString `json:"list_item_guid"`
@@ -35,6 +224,219 @@ type GetItemByGUIDRow struct {
 	AuditRunID   int64          `json:"audit_run_id"`
 }
 
+func (q *Queries) FilteredItemsForListByAuditRun(ctx context.Context, arg FilteredItemsForListByAuditRunParams) ([]FilteredItemsForListByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, filteredItemsForListByAuditRun,
+		arg.SiteID,
+		arg.ListID,
+		arg.AuditRunID,
+		arg.UniqueOnly,
+		arg.Kind,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []FilteredItemsForListByAuditRunRow
+	for rows.Next() {
+		var i FilteredItemsForListByAuditRunRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.ItemGuid,
+			&i.ListItemGuid,
+			&i.ListID,
+			&i.ItemID,
+			&i.Url,
+			&i.IsFile,
+			&i.IsFolder,
+			&i.HasUnique,
+			&i.Name,
+			&i.AuditRunID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const findLatestItemByGUID = `-- name: FindLatestItemByGUID :one
+SELECT i.site_id, i.audit_run_id, i.list_id, i.item_guid
+FROM items i
+JOIN audit_runs ar ON ar.audit_run_id = i.audit_run_id
+WHERE lower(i.item_guid) = lower(?1)
+   OR lower(i.list_item_guid) = lower(?1)
+   OR EXISTS (
+     SELECT 1 FROM sharing_links sl
+     WHERE sl.site_id = i.site_id AND sl.audit_run_id = i.audit_run_id
+       AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid)
+       AND lower(sl.file_folder_unique_id) = lower(?1)
+   )
+ORDER BY ar.started_at DESC, i.audit_run_id DESC
+LIMIT 1
+`
+
+type FindLatestItemByGUIDRow struct {
+	SiteID     int64  `json:"site_id"`
+	AuditRunID int64  `json:"audit_run_id"`
+	ListID     string `json:"list_id"`
+	ItemGuid   string `json:"item_guid"`
+}
+
+// Item lookup across all sites: the most recent audit run holding the item.
+// Matches the item GUID, the list item GUID, or the file/folder UniqueId its sharing links record.
+func (q *Queries) FindLatestItemByGUID(ctx context.Context, guid string) (FindLatestItemByGUIDRow, error) {
+	row := q.db.QueryRowContext(ctx, findLatestItemByGUID, guid)
+	var i FindLatestItemByGUIDRow
+	err := row.Scan(
+		&i.SiteID,
+		&i.AuditRunID,
+		&i.ListID,
+		&i.ItemGuid,
+	)
+	return i, err
+}
+
+const findLatestItemBySharingLinkURL = `-- name: FindLatestItemBySharingLinkURL :one
+SELECT i.site_id, i.audit_run_id, i.list_id, i.item_guid
+FROM sharing_links sl
+JOIN items i ON i.site_id = sl.site_id AND i.audit_run_id = sl.audit_run_id
+  AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid)
+JOIN audit_runs ar ON ar.audit_run_id = sl.audit_run_id
+WHERE lower(CASE WHEN instr(sl.url, '?') > 0 THEN substr(sl.url, 1, instr(sl.url, '?') - 1) ELSE sl.url END) = lower(?1)
+ORDER BY ar.started_at DESC, i.audit_run_id DESC
+LIMIT 1
+`
+
+type FindLatestItemBySharingLinkURLRow struct {
+	SiteID     int64  `json:"site_id"`
+	AuditRunID int64  `json:"audit_run_id"`
+	ListID     string `json:"list_id"`
+	ItemGuid   string `json:"item_guid"`
+}
+
+// Item lookup across all sites by sharing link URL; the stored link URL is compared without its query
+func (q *Queries) FindLatestItemBySharingLinkURL(ctx context.Context, url string) (FindLatestItemBySharingLinkURLRow, error) {
+	row := q.db.QueryRowContext(ctx, findLatestItemBySharingLinkURL, url)
+	var i FindLatestItemBySharingLinkURLRow
+	err := row.Scan(
+		&i.SiteID,
+		&i.AuditRunID,
+		&i.ListID,
+		&i.ItemGuid,
+	)
+	return i, err
+}
+
+const findLatestItemByURL = `-- name: FindLatestItemByURL :one
+SELECT i.site_id, i.audit_run_id, i.list_id, i.item_guid
+FROM items i
+JOIN audit_runs ar ON ar.audit_run_id = i.audit_run_id
+WHERE lower(i.url) = lower(?1)
+ORDER BY ar.started_at DESC, i.audit_run_id DESC
+LIMIT 1
+`
+
+type FindLatestItemByURLRow struct {
+	SiteID     int64  `json:"site_id"`
+	AuditRunID int64  `json:"audit_run_id"`
+	ListID     string `json:"list_id"`
+	ItemGuid   string `json:"item_guid"`
+}
+
+// Item lookup across all sites by absolute item URL, case-insensitive
+func (q *Queries) FindLatestItemByURL(ctx context.Context, url string) (FindLatestItemByURLRow, error) {
+	row := q.db.QueryRowContext(ctx, findLatestItemByURL, url)
+	var i FindLatestItemByURLRow
+	err := row.Scan(
+		&i.SiteID,
+		&i.AuditRunID,
+		&i.ListID,
+		&i.ItemGuid,
+	)
+	return i, err
+}
+
+const getItemAttachmentsByAuditRun = `-- name: GetItemAttachmentsByAuditRun :many
+SELECT file_name, url
+FROM item_attachments
+WHERE site_id = ?1 AND item_guid = ?2 AND audit_run_id = ?3
+ORDER BY file_name
+`
+
+type GetItemAttachmentsByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	ItemGuid   string `json:"item_guid"`
+	AuditRunID int64  `json:"audit_run_id"`
+}
+
+type GetItemAttachmentsByAuditRunRow struct {
+	FileName string         `json:"file_name"`
+	Url      sql.NullString `json:"url"`
+}
+
+func (q *Queries) GetItemAttachmentsByAuditRun(ctx context.Context, arg GetItemAttachmentsByAuditRunParams) ([]GetItemAttachmentsByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getItemAttachmentsByAuditRun, arg.SiteID, arg.ItemGuid, arg.AuditRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemAttachmentsByAuditRunRow
+	for rows.Next() {
+		var i GetItemAttachmentsByAuditRunRow
+		if err := rows.Scan(&i.FileName, &i.Url); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getItemByGUID = `-- name: GetItemByGUID :one
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id,
+       file_folder_unique_id, file_size, sp_created_at, sp_modified_at, content_type_id, unreadable_fields
+FROM items
+WHERE site_id = ?1 AND item_guid = ?2
+`
+
+type GetItemByGUIDParams struct {
+	SiteID   int64  `json:"site_id"`
+	ItemGuid string `json:"item_guid"`
+}
+
+type GetItemByGUIDRow struct {
+	SiteID             int64          `json:"site_id"`
+	ItemGuid           string         `json:"item_guid"`
+	ListItemGuid       sql.NullString `json:"list_item_guid"`
+	ListID             string         `json:"list_id"`
+	ItemID             int64          `json:"item_id"`
+	Url                sql.NullString `json:"url"`
+	IsFile             sql.NullBool   `json:"is_file"`
+	IsFolder           sql.NullBool   `json:"is_folder"`
+	HasUnique          sql.NullBool   `json:"has_unique"`
+	Name               sql.NullString `json:"name"`
+	AuditRunID         int64          `json:"audit_run_id"`
+	FileFolderUniqueID sql.NullString `json:"file_folder_unique_id"`
+	FileSize           sql.NullInt64  `json:"file_size"`
+	SpCreatedAt        sql.NullTime   `json:"sp_created_at"`
+	SpModifiedAt       sql.NullTime   `json:"sp_modified_at"`
+	ContentTypeID      sql.NullString `json:"content_type_id"`
+	UnreadableFields   sql.NullString `json:"unreadable_fields"`
+}
+
 func (q *Queries) GetItemByGUID(ctx context.Context, arg GetItemByGUIDParams) (GetItemByGUIDRow, error) {
 	row := q.db.QueryRowContext(ctx, getItemByGUID, arg.SiteID, arg.ItemGuid)
 	var i GetItemByGUIDRow
@@ -50,6 +452,70 @@ func (q *Queries) GetItemByGUID(ctx context.Context, arg GetItemByGUIDParams) (G
 		&i.HasUnique,
 		&i.Name,
 		&i.AuditRunID,
+		&i.FileFolderUniqueID,
+		&i.FileSize,
+		&i.SpCreatedAt,
+		&i.SpModifiedAt,
+		&i.ContentTypeID,
+		&i.UnreadableFields,
+	)
+	return i, err
+}
+
+const getItemByGUIDByAuditRun = `-- name: GetItemByGUIDByAuditRun :one
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id,
+       file_folder_unique_id, file_size, sp_created_at, sp_modified_at, content_type_id, unreadable_fields
+FROM items
+WHERE site_id = ?1 AND item_guid = ?2 AND audit_run_id = ?3
+`
+
+type GetItemByGUIDByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	ItemGuid   string `json:"item_guid"`
+	AuditRunID int64  `json:"audit_run_id"`
+}
+
+type GetItemByGUIDByAuditRunRow struct {
+	SiteID             int64          `json:"site_id"`
+	ItemGuid           string         `json:"item_guid"`
+	ListItemGuid       sql.NullString `json:"list_item_guid"`
+	ListID             string         `json:"list_id"`
+	ItemID             int64          `json:"item_id"`
+	Url                sql.NullString `json:"url"`
+	IsFile             sql.NullBool   `json:"is_file"`
+	IsFolder           sql.NullBool   `json:"is_folder"`
+	HasUnique          sql.NullBool   `json:"has_unique"`
+	Name               sql.NullString `json:"name"`
+	AuditRunID         int64          `json:"audit_run_id"`
+	FileFolderUniqueID sql.NullString `json:"file_folder_unique_id"`
+	FileSize           sql.NullInt64  `json:"file_size"`
+	SpCreatedAt        sql.NullTime   `json:"sp_created_at"`
+	SpModifiedAt       sql.NullTime   `json:"sp_modified_at"`
+	ContentTypeID      sql.NullString `json:"content_type_id"`
+	UnreadableFields   sql.NullString `json:"unreadable_fields"`
+}
+
+func (q *Queries) GetItemByGUIDByAuditRun(ctx context.Context, arg GetItemByGUIDByAuditRunParams) (GetItemByGUIDByAuditRunRow, error) {
+	row := q.db.QueryRowContext(ctx, getItemByGUIDByAuditRun, arg.SiteID, arg.ItemGuid, arg.AuditRunID)
+	var i GetItemByGUIDByAuditRunRow
+	err := row.Scan(
+		&i.SiteID,
+		&i.ItemGuid,
+		&i.ListItemGuid,
+		&i.ListID,
+		&i.ItemID,
+		&i.Url,
+		&i.IsFile,
+		&i.IsFolder,
+		&i.HasUnique,
+		&i.Name,
+		&i.AuditRunID,
+		&i.FileFolderUniqueID,
+		&i.FileSize,
+		&i.SpCreatedAt,
+		&i.SpModifiedAt,
+		&i.ContentTypeID,
+		&i.UnreadableFields,
 	)
 	return i, err
 }
@@ -186,23 +652,102 @@ func (q *Queries) GetItemByListItemGUID(ctx context.Context, arg GetItemByListIt
 	return i, err
 }
 
+const getItemsForAuditRun = `-- name: GetItemsForAuditRun :many
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id, unreadable_fields
+FROM items
+WHERE audit_run_id = ?1
+ORDER BY site_id, list_id, item_id
+LIMIT ?3 OFFSET ?2
+`
+
+type GetItemsForAuditRunParams struct {
+	AuditRunID int64 `json:"audit_run_id"`
+	Offset     int64 `json:"offset"`
+	Limit      int64 `json:"limit"`
+}
+
+type GetItemsForAuditRunRow struct {
+	SiteID           int64          `json:"site_id"`
+	ItemGuid         string         `json:"item_guid"`
+	ListItemGuid     sql.NullString `json:"list_item_guid"`
+	ListID           string         `json:"list_id"`
+	ItemID           int64          `json:"item_id"`
+	Url              sql.NullString `json:"url"`
+	IsFile           sql.NullBool   `json:"is_file"`
+	IsFolder         sql.NullBool   `json:"is_folder"`
+	HasUnique        sql.NullBool   `json:"has_unique"`
+	Name             sql.NullString `json:"name"`
+	AuditRunID       int64          `json:"audit_run_id"`
+	UnreadableFields sql.NullString `json:"unreadable_fields"`
+}
+
+func (q *Queries) GetItemsForAuditRun(ctx context.Context, arg GetItemsForAuditRunParams) ([]GetItemsForAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getItemsForAuditRun, arg.AuditRunID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemsForAuditRunRow
+	for rows.Next() {
+		var i GetItemsForAuditRunRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.ItemGuid,
+			&i.ListItemGuid,
+			&i.ListID,
+			&i.ItemID,
+			&i.Url,
+			&i.IsFile,
+			&i.IsFolder,
+			&i.HasUnique,
+			&i.Name,
+			&i.AuditRunID,
+			&i.UnreadableFields,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertItem = `-- name: InsertItem :exec
-INSERT INTO items (site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id)
-VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11)
+INSERT INTO items (site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id,
+                   file_folder_unique_id, file_size, sp_created_at, sp_modified_at, content_type_id, unreadable_fields)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9, ?10, ?11,
+        ?12, ?13, ?14, ?15, ?16, ?17)
+ON CONFLICT (site_id, item_guid, audit_run_id) DO UPDATE SET
+  list_item_guid = excluded.list_item_guid, list_id = excluded.list_id, item_id = excluded.item_id, url = excluded.url,
+  is_file = excluded.is_file, is_folder = excluded.is_folder, has_unique = excluded.has_unique, name = excluded.name,
+  file_folder_unique_id = excluded.file_folder_unique_id, file_size = excluded.file_size,
+  sp_created_at = excluded.sp_created_at, sp_modified_at = excluded.sp_modified_at,
+  content_type_id = excluded.content_type_id, unreadable_fields = excluded.unreadable_fields
 `
 
 type InsertItemParams struct {
-	SiteID       int64          `json:"site_id"`
-	ItemGuid     string         `json:"item_guid"`
-	ListItemGuid sql.NullString `json:"list_item_guid"`
-	ListID       string         `json:"list_id"`
-	ItemID       int64          `json:"item_id"`
-	Url          sql.NullString `json:"url"`
-	IsFile       sql.NullBool   `json:"is_file"`
-	IsFolder     sql.NullBool   `json:"is_folder"`
-	HasUnique    sql.NullBool   `json:"has_unique"`
-	Name         sql.NullString `json:"name"`
-	AuditRunID   int64          `json:"audit_run_id"`
+	SiteID             int64          `json:"site_id"`
+	ItemGuid           string         `json:"item_guid"`
+	ListItemGuid       sql.NullString `json:"list_item_guid"`
+	ListID             string         `json:"list_id"`
+	ItemID             int64          `json:"item_id"`
+	Url                sql.NullString `json:"url"`
+	IsFile             sql.NullBool   `json:"is_file"`
+	IsFolder           sql.NullBool   `json:"is_folder"`
+	HasUnique          sql.NullBool   `json:"has_unique"`
+	Name               sql.NullString `json:"name"`
+	AuditRunID         int64          `json:"audit_run_id"`
+	FileFolderUniqueID sql.NullString `json:"file_folder_unique_id"`
+	FileSize           sql.NullInt64  `json:"file_size"`
+	SpCreatedAt        sql.NullTime   `json:"sp_created_at"`
+	SpModifiedAt       sql.NullTime   `json:"sp_modified_at"`
+	ContentTypeID      sql.NullString `json:"content_type_id"`
+	UnreadableFields   sql.NullString `json:"unreadable_fields"`
 }
 
 func (q *Queries) InsertItem(ctx context.Context, arg InsertItemParams) error {
@@ -218,12 +763,42 @@ func (q *Queries) InsertItem(ctx context.Context, arg InsertItemParams) error {
 		arg.HasUnique,
 		arg.Name,
 		arg.AuditRunID,
+		arg.FileFolderUniqueID,
+		arg.FileSize,
+		arg.SpCreatedAt,
+		arg.SpModifiedAt,
+		arg.ContentTypeID,
+		arg.UnreadableFields,
+	)
+	return err
+}
+
+const insertItemAttachment = `-- name: InsertItemAttachment :exec
+INSERT OR IGNORE INTO item_attachments (site_id, item_guid, audit_run_id, file_name, url)
+VALUES (?1, ?2, ?3, ?4, ?5)
+`
+
+type InsertItemAttachmentParams struct {
+	SiteID     int64          `json:"site_id"`
+	ItemGuid   string         `json:"item_guid"`
+	AuditRunID int64          `json:"audit_run_id"`
+	FileName   string         `json:"file_name"`
+	Url        sql.NullString `json:"url"`
+}
+
+func (q *Queries) InsertItemAttachment(ctx context.Context, arg InsertItemAttachmentParams) error {
+	_, err := q.db.ExecContext(ctx, insertItemAttachment,
+		arg.SiteID,
+		arg.ItemGuid,
+		arg.AuditRunID,
+		arg.FileName,
+		arg.Url,
 	)
 	return err
 }
 
 const itemsForList = `-- name: ItemsForList :many
-SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id, unreadable_fields
 FROM items
 WHERE site_id = ?1 AND list_id = ?2
 ORDER BY item_id
@@ -238,17 +813,18 @@ type ItemsForListParams struct {
 }
 
 type ItemsForListRow struct {
-	SiteID       int64          `json:"site_id"`
-	ItemGuid     string         `json:"item_guid"`
-	ListItemGuid sql.NullString `json:"list_item_guid"`
-	ListID       string         `json:"list_id"`
-	ItemID       int64          `json:"item_id"`
-	Url          sql.NullString `json:"url"`
-	IsFile       sql.NullBool   `json:"is_file"`
-	IsFolder     sql.NullBool   `json:"is_folder"`
-	HasUnique    sql.NullBool   `json:"has_unique"`
-	Name         sql.NullString `json:"name"`
-	AuditRunID   int64          `json:"audit_run_id"`
+	SiteID           int64          `json:"site_id"`
+	ItemGuid         string         `json:"item_guid"`
+	ListItemGuid     sql.NullString `json:"list_item_guid"`
+	ListID           string         `json:"list_id"`
+	ItemID           int64          `json:"item_id"`
+	Url              sql.NullString `json:"url"`
+	IsFile           sql.NullBool   `json:"is_file"`
+	IsFolder         sql.NullBool   `json:"is_folder"`
+	HasUnique        sql.NullBool   `json:"has_unique"`
+	Name             sql.NullString `json:"name"`
+	AuditRunID       int64          `json:"audit_run_id"`
+	UnreadableFields sql.NullString `json:"unreadable_fields"`
 }
 
 func (q *Queries) ItemsForList(ctx context.Context, arg ItemsForListParams) ([]ItemsForListRow, error) {
@@ -277,6 +853,7 @@ func (q *Queries) ItemsForList(ctx context.Context, arg ItemsForListParams) ([]I
 			&i.HasUnique,
 			&i.Name,
 			&i.AuditRunID,
+			&i.UnreadableFields,
 		); err != nil {
 			return nil, err
 		}
@@ -292,7 +869,7 @@ func (q *Queries) ItemsForList(ctx context.Context, arg ItemsForListParams) ([]I
 }
 
 const itemsForListByAuditRun = `-- name: ItemsForListByAuditRun :many
-SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id
+SELECT site_id, item_guid, list_item_guid, list_id, item_id, url, is_file, is_folder, has_unique, name, audit_run_id, unreadable_fields
 FROM items
 WHERE site_id = ?1 AND list_id = ?2 AND audit_run_id = ?3
 ORDER BY item_id
@@ -308,17 +885,18 @@ type ItemsForListByAuditRunParams struct {
 }
 
 type ItemsForListByAuditRunRow struct {
-	SiteID       int64          `json:"site_id"`
-	ItemGuid     string         `json:"item_guid"`
-	ListItemGuid sql.NullString `json:"list_item_guid"`
-	ListID       string         `json:"list_id"`
-	ItemID       int64          `json:"item_id"`
-	Url          sql.NullString `json:"url"`
-	IsFile       sql.NullBool   `json:"is_file"`
-	IsFolder     sql.NullBool   `json:"is_folder"`
-	HasUnique    sql.NullBool   `json:"has_unique"`
-	Name         sql.NullString `json:"name"`
-	AuditRunID   int64          `json:"audit_run_id"`
+	SiteID           int64          `json:"site_id"`
+	ItemGuid         string         `json:"item_guid"`
+	ListItemGuid     sql.NullString `json:"list_item_guid"`
+	ListID           string         `json:"list_id"`
+	ItemID           int64          `json:"item_id"`
+	Url              sql.NullString `json:"url"`
+	IsFile           sql.NullBool   `json:"is_file"`
+	IsFolder         sql.NullBool   `json:"is_folder"`
+	HasUnique        sql.NullBool   `json:"has_unique"`
+	Name             sql.NullString `json:"name"`
+	AuditRunID       int64          `json:"audit_run_id"`
+	UnreadableFields sql.NullString `json:"unreadable_fields"`
 }
 
 func (q *Queries) ItemsForListByAuditRun(ctx context.Context, arg ItemsForListByAuditRunParams) ([]ItemsForListByAuditRunRow, error) {
@@ -348,6 +926,7 @@ func (q *Queries) ItemsForListByAuditRun(ctx context.Context, arg ItemsForListBy
 			&i.HasUnique,
 			&i.Name,
 			&i.AuditRunID,
+			&i.UnreadableFields,
 		); err != nil {
 			return nil, err
 		}
@@ -501,3 +1080,43 @@ func (q *Queries) ItemsWithUniqueForListByAuditRun(ctx context.Context, arg Item
 	}
 	return items, nil
 }
+
+const upsertItemAnalytics = `-- name: UpsertItemAnalytics :exec
+INSERT INTO item_analytics (
+  site_id,
+  item_guid,
+  audit_run_id,
+  view_count,
+  viewer_count
+) VALUES (
+  ?1,
+  ?2,
+  ?3,
+  ?4,
+  ?5
+)
+ON CONFLICT(site_id, item_guid, audit_run_id) DO UPDATE SET
+  view_count   = excluded.view_count,
+  viewer_count = excluded.viewer_count,
+  collected_at = CURRENT_TIMESTAMP
+`
+
+type UpsertItemAnalyticsParams struct {
+	SiteID      int64  `json:"site_id"`
+	ItemGuid    string `json:"item_guid"`
+	AuditRunID  int64  `json:"audit_run_id"`
+	ViewCount   int64  `json:"view_count"`
+	ViewerCount int64  `json:"viewer_count"`
+}
+
+// All-time access counts for a shared item, keyed by file/folder UniqueId
+func (q *Queries) UpsertItemAnalytics(ctx context.Context, arg UpsertItemAnalyticsParams) error {
+	_, err := q.db.ExecContext(ctx, upsertItemAnalytics,
+		arg.SiteID,
+		arg.ItemGuid,
+		arg.AuditRunID,
+		arg.ViewCount,
+		arg.ViewerCount,
+	)
+	return err
+}
@@ -422,6 +422,18 @@ func (q *Queries) ListAllJobsForSite(ctx context.Context, siteID sql.NullInt64)
 	return items, nil
 }
 
+const reopenJob = `-- name: ReopenJob :exec
+UPDATE jobs
+SET status = 'running', error = NULL, completed_at = NULL
+WHERE job_id = ?1
+`
+
+// A resumed job runs again: its error and completion time are cleared.
+func (q *Queries) ReopenJob(ctx context.Context, jobID string) error {
+	_, err := q.db.ExecContext(ctx, reopenJob, jobID)
+	return err
+}
+
 const updateJobStatus = `-- name: UpdateJobStatus :exec
 UPDATE jobs 
 SET status = ?1, progress = ?2, state_json = ?3
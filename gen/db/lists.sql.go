@@ -50,7 +50,10 @@ func (q *Queries) GetList(ctx context.Context, arg GetListParams) (GetListRow, e
 }
 
 const getListByAuditRun = `-- name: GetListByAuditRun :one
-SELECT site_id, list_id, web_id, title, url, base_template, item_count, has_unique, audit_run_id
+SELECT site_id, list_id, web_id, title, url, base_template, item_count, has_unique, audit_run_id,
+       unique_item_count, unique_density, sampled_item_count,
+       unique_assignment_count, external_principal_count, sharing_link_count,
+       read_security, write_security, comments_disabled, rating_experience
 FROM lists 
 WHERE site_id = ?1 AND list_id = ?2 AND audit_run_id = ?3
 `
@@ -62,15 +65,25 @@ type GetListByAuditRunParams struct {
 }
 
 type GetListByAuditRunRow struct {
-	SiteID       int64          `json:"site_id"`
-	ListID       string         `json:"list_id"`
-	WebID        string         `json:"web_id"`
-	Title        string         `json:"title"`
-	Url          sql.NullString `json:"url"`
-	BaseTemplate sql.NullInt64  `json:"base_template"`
-	ItemCount    sql.NullInt64  `json:"item_count"`
-	HasUnique    sql.NullBool   `json:"has_unique"`
-	AuditRunID   int64          `json:"audit_run_id"`
+	SiteID                 int64           `json:"site_id"`
+	ListID                 string          `json:"list_id"`
+	WebID                  string          `json:"web_id"`
+	Title                  string          `json:"title"`
+	Url                    sql.NullString  `json:"url"`
+	BaseTemplate           sql.NullInt64   `json:"base_template"`
+	ItemCount              sql.NullInt64   `json:"item_count"`
+	HasUnique              sql.NullBool    `json:"has_unique"`
+	AuditRunID             int64           `json:"audit_run_id"`
+	UniqueItemCount        sql.NullInt64   `json:"unique_item_count"`
+	UniqueDensity          sql.NullFloat64 `json:"unique_density"`
+	SampledItemCount       sql.NullInt64   `json:"sampled_item_count"`
+	UniqueAssignmentCount  sql.NullInt64   `json:"unique_assignment_count"`
+	ExternalPrincipalCount sql.NullInt64   `json:"external_principal_count"`
+	SharingLinkCount       sql.NullInt64   `json:"sharing_link_count"`
+	ReadSecurity           sql.NullInt64   `json:"read_security"`
+	WriteSecurity          sql.NullInt64   `json:"write_security"`
+	CommentsDisabled       sql.NullBool    `json:"comments_disabled"`
+	RatingExperience       sql.NullString  `json:"rating_experience"`
 }
 
 func (q *Queries) GetListByAuditRun(ctx context.Context, arg GetListByAuditRunParams) (GetListByAuditRunRow, error) {
@@ -86,13 +99,26 @@ func (q *Queries) GetListByAuditRun(ctx context.Context, arg GetListByAuditRunPa
 		&i.ItemCount,
 		&i.HasUnique,
 		&i.AuditRunID,
+		&i.UniqueItemCount,
+		&i.UniqueDensity,
+		&i.SampledItemCount,
+		&i.UniqueAssignmentCount,
+		&i.ExternalPrincipalCount,
+		&i.SharingLinkCount,
+		&i.ReadSecurity,
+		&i.WriteSecurity,
+		&i.CommentsDisabled,
+		&i.RatingExperience,
 	)
 	return i, err
 }
 
 const getListsByAuditRun = `-- name: GetListsByAuditRun :many
 
-SELECT l.site_id, l.list_id, l.web_id, l.title, l.url, l.base_template, l.item_count, l.has_unique, w.title AS web_title, l.audit_run_id
+SELECT l.site_id, l.list_id, l.web_id, l.title, l.url, l.base_template, l.item_count, l.has_unique, w.title AS web_title, l.audit_run_id,
+       l.unique_item_count, l.unique_density, l.sampled_item_count,
+       l.unique_assignment_count, l.external_principal_count, l.sharing_link_count,
+       l.read_security, l.write_security, l.comments_disabled, l.rating_experience
 FROM lists l
 JOIN webs w ON w.site_id = l.site_id AND w.web_id = l.web_id AND w.audit_run_id = l.audit_run_id
 WHERE l.site_id = ?1 AND l.audit_run_id = ?2
@@ -105,16 +131,26 @@ type GetListsByAuditRunParams struct {
 }
 
 type GetListsByAuditRunRow struct {
-	SiteID       int64          `json:"site_id"`
-	ListID       string         `json:"list_id"`
-	WebID        string         `json:"web_id"`
-	Title        string         `json:"title"`
-	Url          sql.NullString `json:"url"`
-	BaseTemplate sql.NullInt64  `json:"base_template"`
-	ItemCount    sql.NullInt64  `json:"item_count"`
-	HasUnique    sql.NullBool   `json:"has_unique"`
-	WebTitle     sql.NullString `json:"web_title"`
-	AuditRunID   int64          `json:"audit_run_id"`
+	SiteID                 int64           `json:"site_id"`
+	ListID                 string          `json:"list_id"`
+	WebID                  string          `json:"web_id"`
+	Title                  string          `json:"title"`
+	Url                    sql.NullString  `json:"url"`
+	BaseTemplate           sql.NullInt64   `json:"base_template"`
+	ItemCount              sql.NullInt64   `json:"item_count"`
+	HasUnique              sql.NullBool    `json:"has_unique"`
+	WebTitle               sql.NullString  `json:"web_title"`
+	AuditRunID             int64           `json:"audit_run_id"`
+	UniqueItemCount        sql.NullInt64   `json:"unique_item_count"`
+	UniqueDensity          sql.NullFloat64 `json:"unique_density"`
+	SampledItemCount       sql.NullInt64   `json:"sampled_item_count"`
+	UniqueAssignmentCount  sql.NullInt64   `json:"unique_assignment_count"`
+	ExternalPrincipalCount sql.NullInt64   `json:"external_principal_count"`
+	SharingLinkCount       sql.NullInt64   `json:"sharing_link_count"`
+	ReadSecurity           sql.NullInt64   `json:"read_security"`
+	WriteSecurity          sql.NullInt64   `json:"write_security"`
+	CommentsDisabled       sql.NullBool    `json:"comments_disabled"`
+	RatingExperience       sql.NullString  `json:"rating_experience"`
 }
 
 // Audit-run-scoped queries for reading historical data
@@ -138,6 +174,16 @@ func (q *Queries) GetListsByAuditRun(ctx context.Context, arg GetListsByAuditRun
 			&i.HasUnique,
 			&i.WebTitle,
 			&i.AuditRunID,
+			&i.UniqueItemCount,
+			&i.UniqueDensity,
+			&i.SampledItemCount,
+			&i.UniqueAssignmentCount,
+			&i.ExternalPrincipalCount,
+			&i.SharingLinkCount,
+			&i.ReadSecurity,
+			&i.WriteSecurity,
+			&i.CommentsDisabled,
+			&i.RatingExperience,
 		); err != nil {
 			return nil, err
 		}
@@ -208,6 +254,65 @@ func (q *Queries) GetListsByWebID(ctx context.Context, arg GetListsByWebIDParams
 	return items, nil
 }
 
+const getListsForAuditRun = `-- name: GetListsForAuditRun :many
+SELECT site_id, list_id, web_id, title, base_template, url, item_count, has_unique, audit_run_id
+FROM lists
+WHERE audit_run_id = ?1
+ORDER BY site_id, list_id
+LIMIT ?3 OFFSET ?2
+`
+
+type GetListsForAuditRunParams struct {
+	AuditRunID int64 `json:"audit_run_id"`
+	Offset     int64 `json:"offset"`
+	Limit      int64 `json:"limit"`
+}
+
+type GetListsForAuditRunRow struct {
+	SiteID       int64          `json:"site_id"`
+	ListID       string         `json:"list_id"`
+	WebID        string         `json:"web_id"`
+	Title        string         `json:"title"`
+	BaseTemplate sql.NullInt64  `json:"base_template"`
+	Url          sql.NullString `json:"url"`
+	ItemCount    sql.NullInt64  `json:"item_count"`
+	HasUnique    sql.NullBool   `json:"has_unique"`
+	AuditRunID   int64          `json:"audit_run_id"`
+}
+
+func (q *Queries) GetListsForAuditRun(ctx context.Context, arg GetListsForAuditRunParams) ([]GetListsForAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getListsForAuditRun, arg.AuditRunID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetListsForAuditRunRow
+	for rows.Next() {
+		var i GetListsForAuditRunRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.ListID,
+			&i.WebID,
+			&i.Title,
+			&i.BaseTemplate,
+			&i.Url,
+			&i.ItemCount,
+			&i.HasUnique,
+			&i.AuditRunID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getListsForSite = `-- name: GetListsForSite :many
 SELECT l.site_id, l.list_id, l.web_id, l.title, l.url, l.item_count, l.has_unique, w.title AS web_title
 FROM lists l
@@ -260,7 +365,10 @@ func (q *Queries) GetListsForSite(ctx context.Context, siteID int64) ([]GetLists
 }
 
 const getListsWithUniqueByAuditRun = `-- name: GetListsWithUniqueByAuditRun :many
-SELECT l.site_id, l.list_id, l.web_id, l.title, l.url, l.base_template, l.item_count, l.has_unique, w.title AS web_title, l.audit_run_id
+SELECT l.site_id, l.list_id, l.web_id, l.title, l.url, l.base_template, l.item_count, l.has_unique, w.title AS web_title, l.audit_run_id,
+       l.unique_item_count, l.unique_density, l.sampled_item_count,
+       l.unique_assignment_count, l.external_principal_count, l.sharing_link_count,
+       l.read_security, l.write_security, l.comments_disabled, l.rating_experience
 FROM lists l
 JOIN webs w ON w.site_id = l.site_id AND w.web_id = l.web_id AND w.audit_run_id = l.audit_run_id
 WHERE l.site_id = ?1 AND l.audit_run_id = ?2 AND l.has_unique = 1
@@ -273,16 +381,26 @@ type GetListsWithUniqueByAuditRunParams struct {
 }
 
 type GetListsWithUniqueByAuditRunRow struct {
-	SiteID       int64          `json:"site_id"`
-	ListID       string         `json:"list_id"`
-	WebID        string         `json:"web_id"`
-	Title        string         `json:"title"`
-	Url          sql.NullString `json:"url"`
-	BaseTemplate sql.NullInt64  `json:"base_template"`
-	ItemCount    sql.NullInt64  `json:"item_count"`
-	HasUnique    sql.NullBool   `json:"has_unique"`
-	WebTitle     sql.NullString `json:"web_title"`
-	AuditRunID   int64          `json:"audit_run_id"`
+	SiteID                 int64           `json:"site_id"`
+	ListID                 string          `json:"list_id"`
+	WebID                  string          `json:"web_id"`
+	Title                  string          `json:"title"`
+	Url                    sql.NullString  `json:"url"`
+	BaseTemplate           sql.NullInt64   `json:"base_template"`
+	ItemCount              sql.NullInt64   `json:"item_count"`
+	HasUnique              sql.NullBool    `json:"has_unique"`
+	WebTitle               sql.NullString  `json:"web_title"`
+	AuditRunID             int64           `json:"audit_run_id"`
+	UniqueItemCount        sql.NullInt64   `json:"unique_item_count"`
+	UniqueDensity          sql.NullFloat64 `json:"unique_density"`
+	SampledItemCount       sql.NullInt64   `json:"sampled_item_count"`
+	UniqueAssignmentCount  sql.NullInt64   `json:"unique_assignment_count"`
+	ExternalPrincipalCount sql.NullInt64   `json:"external_principal_count"`
+	SharingLinkCount       sql.NullInt64   `json:"sharing_link_count"`
+	ReadSecurity           sql.NullInt64   `json:"read_security"`
+	WriteSecurity          sql.NullInt64   `json:"write_security"`
+	CommentsDisabled       sql.NullBool    `json:"comments_disabled"`
+	RatingExperience       sql.NullString  `json:"rating_experience"`
 }
 
 func (q *Queries) GetListsWithUniqueByAuditRun(ctx context.Context, arg GetListsWithUniqueByAuditRunParams) ([]GetListsWithUniqueByAuditRunRow, error) {
@@ -305,6 +423,16 @@ func (q *Queries) GetListsWithUniqueByAuditRun(ctx context.Context, arg GetLists
 			&i.HasUnique,
 			&i.WebTitle,
 			&i.AuditRunID,
+			&i.UniqueItemCount,
+			&i.UniqueDensity,
+			&i.SampledItemCount,
+			&i.UniqueAssignmentCount,
+			&i.ExternalPrincipalCount,
+			&i.SharingLinkCount,
+			&i.ReadSecurity,
+			&i.WriteSecurity,
+			&i.CommentsDisabled,
+			&i.RatingExperience,
 		); err != nil {
 			return nil, err
 		}
@@ -320,20 +448,31 @@ func (q *Queries) GetListsWithUniqueByAuditRun(ctx context.Context, arg GetLists
 }
 
 const insertList = `-- name: InsertList :exec
-INSERT INTO lists (site_id, list_id, web_id, title, url, base_template, item_count, has_unique, audit_run_id)
-VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+INSERT INTO lists (site_id, list_id, web_id, title, url, base_template, item_count, has_unique, audit_run_id,
+                   read_security, write_security, comments_disabled, rating_experience)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9,
+        ?10, ?11, ?12, ?13)
+ON CONFLICT (site_id, list_id, audit_run_id) DO UPDATE SET
+  web_id = excluded.web_id, title = excluded.title, url = excluded.url, base_template = excluded.base_template,
+  item_count = excluded.item_count, has_unique = excluded.has_unique, read_security = excluded.read_security,
+  write_security = excluded.write_security, comments_disabled = excluded.comments_disabled,
+  rating_experience = excluded.rating_experience
 `
 
 type InsertListParams struct {
-	SiteID       int64          `json:"site_id"`
-	ListID       string         `json:"list_id"`
-	WebID        string         `json:"web_id"`
-	Title        string         `json:"title"`
-	Url          sql.NullString `json:"url"`
-	BaseTemplate sql.NullInt64  `json:"base_template"`
-	ItemCount    sql.NullInt64  `json:"item_count"`
-	HasUnique    sql.NullBool   `json:"has_unique"`
-	AuditRunID   int64          `json:"audit_run_id"`
+	SiteID           int64          `json:"site_id"`
+	ListID           string         `json:"list_id"`
+	WebID            string         `json:"web_id"`
+	Title            string         `json:"title"`
+	Url              sql.NullString `json:"url"`
+	BaseTemplate     sql.NullInt64  `json:"base_template"`
+	ItemCount        sql.NullInt64  `json:"item_count"`
+	HasUnique        sql.NullBool   `json:"has_unique"`
+	AuditRunID       int64          `json:"audit_run_id"`
+	ReadSecurity     sql.NullInt64  `json:"read_security"`
+	WriteSecurity    sql.NullInt64  `json:"write_security"`
+	CommentsDisabled sql.NullBool   `json:"comments_disabled"`
+	RatingExperience sql.NullString `json:"rating_experience"`
 }
 
 func (q *Queries) InsertList(ctx context.Context, arg InsertListParams) error {
@@ -347,6 +486,10 @@ func (q *Queries) InsertList(ctx context.Context, arg InsertListParams) error {
 		arg.ItemCount,
 		arg.HasUnique,
 		arg.AuditRunID,
+		arg.ReadSecurity,
+		arg.WriteSecurity,
+		arg.CommentsDisabled,
+		arg.RatingExperience,
 	)
 	return err
 }
@@ -508,3 +651,117 @@ func (q *Queries) ListsWithUniqueForSite(ctx context.Context, siteID int64) ([]L
 	}
 	return items, nil
 }
+
+const updateListAccessCountsByAuditRun = `-- name: UpdateListAccessCountsByAuditRun :exec
+UPDATE lists
+SET unique_assignment_count = (
+      SELECT COUNT(*) FROM role_assignments ra
+      WHERE ra.site_id = lists.site_id AND ra.audit_run_id = lists.audit_run_id
+        AND ((ra.object_type = 'list' AND ra.object_key = lists.list_id)
+          OR (ra.object_type = 'item' AND ra.object_key IN (
+            SELECT i.item_guid FROM items i
+            WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+              AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1)))
+    ),
+    external_principal_count = (
+      SELECT COUNT(*) FROM principals p
+      WHERE p.site_id = lists.site_id AND p.audit_run_id = lists.audit_run_id
+        AND (p.login_name LIKE '%#ext#%' OR p.login_name LIKE '%urn:spo:guest%')
+        AND (EXISTS (
+            SELECT 1 FROM role_assignments ra
+            WHERE ra.site_id = p.site_id AND ra.principal_id = p.principal_id AND ra.audit_run_id = p.audit_run_id
+              AND ((ra.object_type = 'list' AND ra.object_key = lists.list_id)
+                OR (ra.object_type = 'item' AND ra.object_key IN (
+                  SELECT i.item_guid FROM items i
+                  WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+                    AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1)))
+          ) OR EXISTS (
+            SELECT 1 FROM sharing_link_members m
+            JOIN sharing_links sl ON sl.site_id = m.site_id AND sl.link_id = m.link_id AND sl.audit_run_id = m.audit_run_id
+            JOIN items i ON i.site_id = sl.site_id AND i.item_guid = sl.item_guid AND i.audit_run_id = sl.audit_run_id
+            WHERE m.site_id = p.site_id AND m.principal_id = p.principal_id AND m.audit_run_id = p.audit_run_id
+              AND sl.is_active = 1 AND i.list_id = lists.list_id
+          ))
+    ),
+    sharing_link_count = (
+      SELECT COUNT(*) FROM sharing_links sl
+      JOIN items i ON i.site_id = sl.site_id AND i.item_guid = sl.item_guid AND i.audit_run_id = sl.audit_run_id
+      WHERE sl.site_id = lists.site_id AND sl.audit_run_id = lists.audit_run_id
+        AND sl.is_active = 1 AND i.list_id = lists.list_id
+    )
+WHERE lists.site_id = ?1 AND lists.audit_run_id = ?2
+`
+
+type UpdateListAccessCountsByAuditRunParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
+// External principals are guest accounts, whose login names carry #ext# or urn:spo:guest.
+func (q *Queries) UpdateListAccessCountsByAuditRun(ctx context.Context, arg UpdateListAccessCountsByAuditRunParams) error {
+	_, err := q.db.ExecContext(ctx, updateListAccessCountsByAuditRun, arg.SiteID, arg.AuditRunID)
+	return err
+}
+
+const updateListSamplingByAuditRun = `-- name: UpdateListSamplingByAuditRun :exec
+UPDATE lists SET sampled_item_count = ?1
+WHERE site_id = ?2 AND list_id = ?3 AND audit_run_id = ?4
+`
+
+type UpdateListSamplingByAuditRunParams struct {
+	SampledItemCount sql.NullInt64 `json:"sampled_item_count"`
+	SiteID           int64         `json:"site_id"`
+	ListID           string        `json:"list_id"`
+	AuditRunID       int64         `json:"audit_run_id"`
+}
+
+func (q *Queries) UpdateListSamplingByAuditRun(ctx context.Context, arg UpdateListSamplingByAuditRunParams) error {
+	_, err := q.db.ExecContext(ctx, updateListSamplingByAuditRun,
+		arg.SampledItemCount,
+		arg.SiteID,
+		arg.ListID,
+		arg.AuditRunID,
+	)
+	return err
+}
+
+const updateListUniqueDensityByAuditRun = `-- name: UpdateListUniqueDensityByAuditRun :exec
+UPDATE lists
+SET unique_item_count = CASE
+      WHEN COALESCE(lists.sampled_item_count, 0) > 0 THEN CAST(ROUND(CAST((
+        SELECT COUNT(*) FROM items i
+        WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+          AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1
+      ) AS REAL) * COALESCE(lists.item_count, 0) / lists.sampled_item_count) AS INTEGER)
+      ELSE (
+        SELECT COUNT(*) FROM items i
+        WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+          AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1
+      )
+    END,
+    unique_density = CASE
+      WHEN COALESCE(lists.sampled_item_count, 0) > 0 THEN CAST((
+        SELECT COUNT(*) FROM items i
+        WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+          AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1
+      ) AS REAL) / lists.sampled_item_count
+      WHEN COALESCE(lists.item_count, 0) > 0 THEN CAST((
+        SELECT COUNT(*) FROM items i
+        WHERE i.site_id = lists.site_id AND i.list_id = lists.list_id
+          AND i.audit_run_id = lists.audit_run_id AND i.has_unique = 1
+      ) AS REAL) / lists.item_count
+      ELSE 0
+    END
+WHERE lists.site_id = ?1 AND lists.audit_run_id = ?2
+`
+
+type UpdateListUniqueDensityByAuditRunParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
+// Sampled lists extrapolate the unique share of the sample to the whole list.
+func (q *Queries) UpdateListUniqueDensityByAuditRun(ctx context.Context, arg UpdateListUniqueDensityByAuditRunParams) error {
+	_, err := q.db.ExecContext(ctx, updateListUniqueDensityByAuditRun, arg.SiteID, arg.AuditRunID)
+	return err
+}
@@ -9,6 +9,37 @@ import (
 	"time"
 )
 
+type Attestation struct {
+	AttestationID int64        `json:"attestation_id"`
+	SiteID        int64        `json:"site_id"`
+	AuditRunID    int64        `json:"audit_run_id"`
+	Token         string       `json:"token"`
+	Owner         string       `json:"owner"`
+	CreatedAt     time.Time    `json:"created_at"`
+	SubmittedAt   sql.NullTime `json:"submitted_at"`
+}
+
+type AttestationResponse struct {
+	AttestationID int64     `json:"attestation_id"`
+	EntryKey      string    `json:"entry_key"`
+	Decision      string    `json:"decision"`
+	RespondedAt   time.Time `json:"responded_at"`
+}
+
+type AppInventory struct {
+	SiteID      int64     `json:"site_id"`
+	AuditRunID  int64     `json:"audit_run_id"`
+	CollectedAt time.Time `json:"collected_at"`
+}
+
+type AuditCheckpoint struct {
+	AuditRunID int64     `json:"audit_run_id"`
+	ListID     string    `json:"list_id"`
+	LastItemID int64     `json:"last_item_id"`
+	Completed  bool      `json:"completed"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
 type AuditRun struct {
 	AuditRunID             int64           `json:"audit_run_id"`
 	JobID                  string          `json:"job_id"`
@@ -32,6 +63,25 @@ type AuditRun struct {
 	CoveragePercentage     sql.NullFloat64 `json:"coverage_percentage"`
 	ErrorsEncountered      sql.NullInt64   `json:"errors_encountered"`
 	CreatedAt              sql.NullTime    `json:"created_at"`
+	OnHold                 bool            `json:"on_hold"`
+	HeldAt                 sql.NullTime    `json:"held_at"`
+	HoldReason             sql.NullString  `json:"hold_reason"`
+	ScopePath              sql.NullString  `json:"scope_path"`
+	Label                  sql.NullString  `json:"label"`
+	ParametersJson         sql.NullString  `json:"parameters_json"`
+}
+
+type AuditRunArtifact struct {
+	ArtifactID      int64     `json:"artifact_id"`
+	AuditRunID      int64     `json:"audit_run_id"`
+	SiteID          int64     `json:"site_id"`
+	Filename        string    `json:"filename"`
+	ContentType     string    `json:"content_type"`
+	SizeBytes       int64     `json:"size_bytes"`
+	Content         []byte    `json:"content"`
+	CreatedAt       time.Time `json:"created_at"`
+	ExpiresAt       time.Time `json:"expires_at"`
+	ContentEncoding string    `json:"content_encoding"`
 }
 
 type AuditRunEvent struct {
@@ -43,22 +93,212 @@ type AuditRunEvent struct {
 	CreatedBy  string         `json:"created_by"`
 }
 
+type AuditRunManifest struct {
+	AuditRunID  int64     `json:"audit_run_id"`
+	SiteID      int64     `json:"site_id"`
+	Algorithm   string    `json:"algorithm"`
+	ContentHash string    `json:"content_hash"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type AuditRunManifestEntry struct {
+	AuditRunID  int64  `json:"audit_run_id"`
+	ListID      string `json:"list_id"`
+	ContentHash string `json:"content_hash"`
+}
+
+type AuditRunPayloadSample struct {
+	SampleID        int64     `json:"sample_id"`
+	AuditRunID      int64     `json:"audit_run_id"`
+	Source          string    `json:"source"`
+	SampleKey       string    `json:"sample_key"`
+	Payload         []byte    `json:"payload"`
+	Truncated       bool      `json:"truncated"`
+	SchemaDrift     bool      `json:"schema_drift"`
+	CapturedAt      time.Time `json:"captured_at"`
+	PayloadEncoding string    `json:"payload_encoding"`
+}
+
+type AuditRunSchemaDrift struct {
+	AuditRunID  int64  `json:"audit_run_id"`
+	Source      string `json:"source"`
+	Field       string `json:"field"`
+	Kind        string `json:"kind"`
+	Occurrences int64  `json:"occurrences"`
+}
+
+type AuditSchedule struct {
+	ScheduleID           int64          `json:"schedule_id"`
+	SiteUrl              string         `json:"site_url"`
+	IntervalSeconds      int64          `json:"interval_seconds"`
+	CompareWith          string         `json:"compare_with"`
+	MaxExternalGrants    sql.NullInt64  `json:"max_external_grants"`
+	MaxPermissionChanges sql.NullInt64  `json:"max_permission_changes"`
+	MaxSharingLinks      sql.NullInt64  `json:"max_sharing_links"`
+	MaxUniqueItems       sql.NullInt64  `json:"max_unique_items"`
+	MaxRemovedPrincipals sql.NullInt64  `json:"max_removed_principals"`
+	CreatedAt            time.Time      `json:"created_at"`
+	NextRunAt            time.Time      `json:"next_run_at"`
+	LastJobID            sql.NullString `json:"last_job_id"`
+	LastRunAt            sql.NullTime   `json:"last_run_at"`
+}
+
+type AuditScheduleCheck struct {
+	ScheduleID        int64         `json:"schedule_id"`
+	AuditRunID        int64         `json:"audit_run_id"`
+	BaseAuditRunID    int64         `json:"base_audit_run_id"`
+	ExternalGrants    int64         `json:"external_grants"`
+	PermissionChanges int64         `json:"permission_changes"`
+	SharingLinks      int64         `json:"sharing_links"`
+	UniqueItems       int64         `json:"unique_items"`
+	RemovedPrincipals int64         `json:"removed_principals"`
+	AlertID           sql.NullInt64 `json:"alert_id"`
+	CheckedAt         time.Time     `json:"checked_at"`
+}
+
+type DatabaseMaintenanceRun struct {
+	MaintenanceRunID int64          `json:"maintenance_run_id"`
+	JobID            string         `json:"job_id"`
+	Trigger          string         `json:"trigger"`
+	StartedAt        time.Time      `json:"started_at"`
+	CompletedAt      sql.NullTime   `json:"completed_at"`
+	BytesBefore      int64          `json:"bytes_before"`
+	BytesAfter       sql.NullInt64  `json:"bytes_after"`
+	FreeBytesBefore  int64          `json:"free_bytes_before"`
+	FreeBytesAfter   sql.NullInt64  `json:"free_bytes_after"`
+	Converted        bool           `json:"converted"`
+	IntegrityErrors  sql.NullString `json:"integrity_errors"`
+	Error            sql.NullString `json:"error"`
+}
+
+type EntraGroup struct {
+	SiteID      int64     `json:"site_id"`
+	PrincipalID int64     `json:"principal_id"`
+	AuditRunID  int64     `json:"audit_run_id"`
+	ObjectID    string    `json:"object_id"`
+	Owners      bool      `json:"owners"`
+	MemberCount int64     `json:"member_count"`
+	ResolvedAt  time.Time `json:"resolved_at"`
+}
+
+type EntraGroupMember struct {
+	SiteID            int64          `json:"site_id"`
+	PrincipalID       int64          `json:"principal_id"`
+	AuditRunID        int64          `json:"audit_run_id"`
+	MemberObjectID    string         `json:"member_object_id"`
+	DisplayName       sql.NullString `json:"display_name"`
+	UserPrincipalName sql.NullString `json:"user_principal_name"`
+	Mail              sql.NullString `json:"mail"`
+	UserType          sql.NullString `json:"user_type"`
+}
+
+type EventDeadLetter struct {
+	DeadLetterID int64     `json:"dead_letter_id"`
+	Handler      string    `json:"handler"`
+	Sequence     int64     `json:"sequence"`
+	Attempts     int64     `json:"attempts"`
+	LastError    string    `json:"last_error"`
+	FailedAt     time.Time `json:"failed_at"`
+}
+
+type EventHandlerOffset struct {
+	Handler      string    `json:"handler"`
+	LastSequence int64     `json:"last_sequence"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type FindingAlert struct {
+	AlertID     int64        `json:"alert_id"`
+	SiteID      int64        `json:"site_id"`
+	AuditRunID  int64        `json:"audit_run_id"`
+	Fingerprint string       `json:"fingerprint"`
+	Category    string       `json:"category"`
+	Severity    string       `json:"severity"`
+	Message     string       `json:"message"`
+	CreatedAt   time.Time    `json:"created_at"`
+	DeliveredAt sql.NullTime `json:"delivered_at"`
+}
+
+type FindingAlertSighting struct {
+	SiteID         int64  `json:"site_id"`
+	Fingerprint    string `json:"fingerprint"`
+	LastAuditRunID int64  `json:"last_audit_run_id"`
+}
+
+type GraphAppGrant struct {
+	SiteID     int64          `json:"site_id"`
+	AuditRunID int64          `json:"audit_run_id"`
+	GrantKind  string         `json:"grant_kind"`
+	GrantID    string         `json:"grant_id"`
+	AppID      string         `json:"app_id"`
+	AppName    sql.NullString `json:"app_name"`
+	Roles      string         `json:"roles"`
+	Resource   sql.NullString `json:"resource"`
+}
+
+type GraphGrantScan struct {
+	SiteID     int64     `json:"site_id"`
+	AuditRunID int64     `json:"audit_run_id"`
+	ScannedAt  time.Time `json:"scanned_at"`
+}
+
+type GroupMember struct {
+	SiteID     int64 `json:"site_id"`
+	GroupID    int64 `json:"group_id"`
+	MemberID   int64 `json:"member_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
+type InstalledApp struct {
+	SiteID         int64          `json:"site_id"`
+	AuditRunID     int64          `json:"audit_run_id"`
+	AppID          string         `json:"app_id"`
+	ProductID      sql.NullString `json:"product_id"`
+	Title          sql.NullString `json:"title"`
+	AppSource      int64          `json:"app_source"`
+	AppPrincipalID sql.NullString `json:"app_principal_id"`
+	LastModified   sql.NullTime   `json:"last_modified"`
+}
+
 type Item struct {
-	SiteID       int64          `json:"site_id"`
-	ItemGuid     string         `json:"item_guid"`
-	AuditRunID   int64          `json:"audit_run_id"`
-	ListID       string         `json:"list_id"`
-	ItemID       int64          `json:"item_id"`
-	ListItemGuid sql.NullString `json:"list_item_guid"`
-	Title        sql.NullString `json:"title"`
-	Url          sql.NullString `json:"url"`
-	Name         sql.NullString `json:"name"`
-	FileType     sql.NullString `json:"file_type"`
-	IsFile       sql.NullBool   `json:"is_file"`
-	IsFolder     sql.NullBool   `json:"is_folder"`
-	HasUnique    sql.NullBool   `json:"has_unique"`
-	CreatedAt    sql.NullTime   `json:"created_at"`
-	ModifiedAt   sql.NullTime   `json:"modified_at"`
+	SiteID             int64          `json:"site_id"`
+	ItemGuid           string         `json:"item_guid"`
+	AuditRunID         int64          `json:"audit_run_id"`
+	ListID             string         `json:"list_id"`
+	ItemID             int64          `json:"item_id"`
+	ListItemGuid       sql.NullString `json:"list_item_guid"`
+	Title              sql.NullString `json:"title"`
+	Url                sql.NullString `json:"url"`
+	Name               sql.NullString `json:"name"`
+	FileType           sql.NullString `json:"file_type"`
+	IsFile             sql.NullBool   `json:"is_file"`
+	IsFolder           sql.NullBool   `json:"is_folder"`
+	HasUnique          sql.NullBool   `json:"has_unique"`
+	CreatedAt          sql.NullTime   `json:"created_at"`
+	ModifiedAt         sql.NullTime   `json:"modified_at"`
+	FileFolderUniqueID sql.NullString `json:"file_folder_unique_id"`
+	FileSize           sql.NullInt64  `json:"file_size"`
+	SpCreatedAt        sql.NullTime   `json:"sp_created_at"`
+	SpModifiedAt       sql.NullTime   `json:"sp_modified_at"`
+	ContentTypeID      sql.NullString `json:"content_type_id"`
+	UnreadableFields   sql.NullString `json:"unreadable_fields"`
+}
+
+type ItemAnalytic struct {
+	SiteID      int64        `json:"site_id"`
+	ItemGuid    string       `json:"item_guid"`
+	AuditRunID  int64        `json:"audit_run_id"`
+	ViewCount   int64        `json:"view_count"`
+	ViewerCount int64        `json:"viewer_count"`
+	CollectedAt sql.NullTime `json:"collected_at"`
+}
+
+type ItemAttachment struct {
+	SiteID     int64          `json:"site_id"`
+	ItemGuid   string         `json:"item_guid"`
+	AuditRunID int64          `json:"audit_run_id"`
+	FileName   string         `json:"file_name"`
+	Url        sql.NullString `json:"url"`
 }
 
 type Job struct {
@@ -77,18 +317,149 @@ type Job struct {
 	StateJson   sql.NullString `json:"state_json"`
 }
 
+type JobEvent struct {
+	Sequence   int64         `json:"sequence"`
+	EventType  string        `json:"event_type"`
+	JobID      string        `json:"job_id"`
+	JobType    string        `json:"job_type"`
+	JobStatus  string        `json:"job_status"`
+	SiteUrl    string        `json:"site_url"`
+	AuditRunID sql.NullInt64 `json:"audit_run_id"`
+	Error      string        `json:"error"`
+	OccurredAt time.Time     `json:"occurred_at"`
+}
+
 type List struct {
-	SiteID       int64          `json:"site_id"`
-	ListID       string         `json:"list_id"`
-	AuditRunID   int64          `json:"audit_run_id"`
-	WebID        string         `json:"web_id"`
-	Title        string         `json:"title"`
-	BaseTemplate sql.NullInt64  `json:"base_template"`
-	Url          sql.NullString `json:"url"`
-	ItemCount    sql.NullInt64  `json:"item_count"`
-	HasUnique    sql.NullBool   `json:"has_unique"`
-	Hidden       sql.NullBool   `json:"hidden"`
-	CreatedAt    sql.NullTime   `json:"created_at"`
+	SiteID                 int64           `json:"site_id"`
+	ListID                 string          `json:"list_id"`
+	AuditRunID             int64           `json:"audit_run_id"`
+	WebID                  string          `json:"web_id"`
+	Title                  string          `json:"title"`
+	BaseTemplate           sql.NullInt64   `json:"base_template"`
+	Url                    sql.NullString  `json:"url"`
+	ItemCount              sql.NullInt64   `json:"item_count"`
+	HasUnique              sql.NullBool    `json:"has_unique"`
+	Hidden                 sql.NullBool    `json:"hidden"`
+	CreatedAt              sql.NullTime    `json:"created_at"`
+	UniqueItemCount        sql.NullInt64   `json:"unique_item_count"`
+	UniqueDensity          sql.NullFloat64 `json:"unique_density"`
+	SampledItemCount       sql.NullInt64   `json:"sampled_item_count"`
+	UniqueAssignmentCount  sql.NullInt64   `json:"unique_assignment_count"`
+	ExternalPrincipalCount sql.NullInt64   `json:"external_principal_count"`
+	SharingLinkCount       sql.NullInt64   `json:"sharing_link_count"`
+	ReadSecurity           sql.NullInt64   `json:"read_security"`
+	WriteSecurity          sql.NullInt64   `json:"write_security"`
+	CommentsDisabled       sql.NullBool    `json:"comments_disabled"`
+	RatingExperience       sql.NullString  `json:"rating_experience"`
+}
+
+type ListMonitor struct {
+	MonitorID       int64          `json:"monitor_id"`
+	SiteUrl         string         `json:"site_url"`
+	ListID          string         `json:"list_id"`
+	ListTitle       string         `json:"list_title"`
+	ListRootPath    string         `json:"list_root_path"`
+	IntervalSeconds int64          `json:"interval_seconds"`
+	ChangeToken     string         `json:"change_token"`
+	CreatedAt       time.Time      `json:"created_at"`
+	NextCheckAt     time.Time      `json:"next_check_at"`
+	LastCheckedAt   sql.NullTime   `json:"last_checked_at"`
+	LastAuditJobID  sql.NullString `json:"last_audit_job_id"`
+	LastAuditAt     sql.NullTime   `json:"last_audit_at"`
+}
+
+type ListSubscription struct {
+	SubscriptionID string         `json:"subscription_id"`
+	SiteUrl        string         `json:"site_url"`
+	ListID         string         `json:"list_id"`
+	ListTitle      string         `json:"list_title"`
+	ListRootPath   string         `json:"list_root_path"`
+	ClientState    string         `json:"client_state"`
+	ChangeToken    string         `json:"change_token"`
+	ExpiresAt      time.Time      `json:"expires_at"`
+	CreatedAt      time.Time      `json:"created_at"`
+	LastNotifiedAt sql.NullTime   `json:"last_notified_at"`
+	LastAuditJobID sql.NullString `json:"last_audit_job_id"`
+	LastAuditAt    sql.NullTime   `json:"last_audit_at"`
+}
+
+type OffboardingCheck struct {
+	CheckID      int64          `json:"check_id"`
+	JobID        string         `json:"job_id"`
+	Identity     string         `json:"identity"`
+	Remediate    bool           `json:"remediate"`
+	StartedAt    time.Time      `json:"started_at"`
+	CompletedAt  sql.NullTime   `json:"completed_at"`
+	AccessCount  int64          `json:"access_count"`
+	LinksCreated int64          `json:"links_created"`
+	ItemsOwned   int64          `json:"items_owned"`
+	Remediations int64          `json:"remediations"`
+	Report       sql.NullString `json:"report"`
+	Error        sql.NullString `json:"error"`
+}
+
+type OffboardingRemediation struct {
+	RemediationID int64          `json:"remediation_id"`
+	CheckID       int64          `json:"check_id"`
+	SiteID        int64          `json:"site_id"`
+	SiteUrl       string         `json:"site_url"`
+	AuditRunID    int64          `json:"audit_run_id"`
+	Action        string         `json:"action"`
+	PrincipalID   int64          `json:"principal_id"`
+	ObjectType    sql.NullString `json:"object_type"`
+	ObjectKey     sql.NullString `json:"object_key"`
+	ObjectUrl     sql.NullString `json:"object_url"`
+	GroupID       sql.NullInt64  `json:"group_id"`
+	LinkID        sql.NullString `json:"link_id"`
+	Detail        sql.NullString `json:"detail"`
+	Status        string         `json:"status"`
+	QueuedAt      time.Time      `json:"queued_at"`
+	UpdatedAt     sql.NullTime   `json:"updated_at"`
+}
+
+type OrgUnitMapping struct {
+	MappingID  int64     `json:"mapping_id"`
+	MatchType  string    `json:"match_type"`
+	MatchValue string    `json:"match_value"`
+	OrgUnit    string    `json:"org_unit"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+type PermissionException struct {
+	ExceptionID      int64        `json:"exception_id"`
+	SiteID           int64        `json:"site_id"`
+	ListID           string       `json:"list_id"`
+	Kind             string       `json:"kind"`
+	ObjectKey        string       `json:"object_key"`
+	PrincipalID      int64        `json:"principal_id"`
+	RoleDefID        int64        `json:"role_def_id"`
+	Justification    string       `json:"justification"`
+	ExpiresAt        time.Time    `json:"expires_at"`
+	CreatedAt        time.Time    `json:"created_at"`
+	Owner            string       `json:"owner"`
+	ReviewNotifiedAt sql.NullTime `json:"review_notified_at"`
+}
+
+type PolicyEvaluation struct {
+	AuditRunID  int64     `json:"audit_run_id"`
+	SiteID      int64     `json:"site_id"`
+	EvaluatedAt time.Time `json:"evaluated_at"`
+	Policies    int64     `json:"policies"`
+}
+
+type PolicyFinding struct {
+	FindingID   int64  `json:"finding_id"`
+	AuditRunID  int64  `json:"audit_run_id"`
+	SiteID      int64  `json:"site_id"`
+	Policy      string `json:"policy"`
+	Severity    string `json:"severity"`
+	ObjectType  string `json:"object_type"`
+	ObjectKey   string `json:"object_key"`
+	ObjectUrl   string `json:"object_url"`
+	ObjectName  string `json:"object_name"`
+	Subject     string `json:"subject"`
+	Detail      string `json:"detail"`
+	Remediation string `json:"remediation"`
 }
 
 type Principal struct {
@@ -113,6 +484,36 @@ type RecipientLimit struct {
 	UpdatedAt                sql.NullTime   `json:"updated_at"`
 }
 
+type RecycleBinItem struct {
+	SiteID         int64          `json:"site_id"`
+	AuditRunID     int64          `json:"audit_run_id"`
+	RecycleBinID   string         `json:"recycle_bin_id"`
+	ItemType       int64          `json:"item_type"`
+	ItemState      int64          `json:"item_state"`
+	Title          sql.NullString `json:"title"`
+	Url            string         `json:"url"`
+	DeletedAt      time.Time      `json:"deleted_at"`
+	DeletedByName  sql.NullString `json:"deleted_by_name"`
+	DeletedByEmail sql.NullString `json:"deleted_by_email"`
+}
+
+type RecycleBinScan struct {
+	SiteID     int64     `json:"site_id"`
+	AuditRunID int64     `json:"audit_run_id"`
+	ScannedAt  time.Time `json:"scanned_at"`
+}
+
+type ReportShare struct {
+	ShareID    int64          `json:"share_id"`
+	SiteID     int64          `json:"site_id"`
+	AuditRunID int64          `json:"audit_run_id"`
+	ListID     sql.NullString `json:"list_id"`
+	Token      string         `json:"token"`
+	CreatedAt  time.Time      `json:"created_at"`
+	ExpiresAt  time.Time      `json:"expires_at"`
+	RevokedAt  sql.NullTime   `json:"revoked_at"`
+}
+
 type RoleAssignment struct {
 	SiteID      int64        `json:"site_id"`
 	ObjectType  string       `json:"object_type"`
@@ -186,6 +587,9 @@ type SharingGovernance struct {
 	EnforceIbSegmentFiltering              sql.NullBool   `json:"enforce_ib_segment_filtering"`
 	CreatedAt                              sql.NullTime   `json:"created_at"`
 	UpdatedAt                              sql.NullTime   `json:"updated_at"`
+	DefaultLinkKind                        sql.NullInt64  `json:"default_link_kind"`
+	DefaultShareLinkPermission             sql.NullInt64  `json:"default_share_link_permission"`
+	DefaultShareLinkScope                  sql.NullInt64  `json:"default_share_link_scope"`
 }
 
 type SharingLink struct {
@@ -250,21 +654,75 @@ type SharingLinkMember struct {
 }
 
 type Site struct {
-	SiteID    int64          `json:"site_id"`
-	SiteUrl   string         `json:"site_url"`
-	Title     sql.NullString `json:"title"`
-	CreatedAt sql.NullTime   `json:"created_at"`
-	UpdatedAt sql.NullTime   `json:"updated_at"`
+	SiteID           int64          `json:"site_id"`
+	SiteUrl          string         `json:"site_url"`
+	Title            sql.NullString `json:"title"`
+	CreatedAt        sql.NullTime   `json:"created_at"`
+	UpdatedAt        sql.NullTime   `json:"updated_at"`
+	PinnedAuditRunID sql.NullInt64  `json:"pinned_audit_run_id"`
+	PinnedAt         sql.NullTime   `json:"pinned_at"`
+}
+
+type SiteBaseline struct {
+	BaselineID   int64          `json:"baseline_id"`
+	SiteID       int64          `json:"site_id"`
+	AuditRunID   int64          `json:"audit_run_id"`
+	ApprovedBy   sql.NullString `json:"approved_by"`
+	Note         sql.NullString `json:"note"`
+	ApprovedAt   time.Time      `json:"approved_at"`
+	SupersededAt sql.NullTime   `json:"superseded_at"`
+}
+
+type SiteHub struct {
+	SiteID          int64          `json:"site_id"`
+	AuditRunID      int64          `json:"audit_run_id"`
+	IsHub           bool           `json:"is_hub"`
+	HubSiteID       sql.NullString `json:"hub_site_id"`
+	HubTitle        sql.NullString `json:"hub_title"`
+	HubUrl          sql.NullString `json:"hub_url"`
+	ParentHubSiteID sql.NullString `json:"parent_hub_site_id"`
+	PermissionsSync bool           `json:"permissions_sync"`
+	CollectedAt     time.Time      `json:"collected_at"`
+}
+
+type SitePolicyPack struct {
+	SiteID int64     `json:"site_id"`
+	Pack   string    `json:"pack"`
+	SetAt  time.Time `json:"set_at"`
+}
+
+type SiteSensitivity struct {
+	SiteID   int64     `json:"site_id"`
+	Reason   string    `json:"reason"`
+	TaggedAt time.Time `json:"tagged_at"`
 }
 
 type Web struct {
-	SiteID            int64          `json:"site_id"`
-	WebID             string         `json:"web_id"`
-	AuditRunID        int64          `json:"audit_run_id"`
-	Title             sql.NullString `json:"title"`
-	ServerRelativeUrl sql.NullString `json:"server_relative_url"`
-	Url               sql.NullString `json:"url"`
-	Template          sql.NullString `json:"template"`
-	HasUnique         sql.NullBool   `json:"has_unique"`
-	CreatedAt         sql.NullTime   `json:"created_at"`
+	SiteID                  int64          `json:"site_id"`
+	WebID                   string         `json:"web_id"`
+	AuditRunID              int64          `json:"audit_run_id"`
+	Title                   sql.NullString `json:"title"`
+	ServerRelativeUrl       sql.NullString `json:"server_relative_url"`
+	Url                     sql.NullString `json:"url"`
+	Template                sql.NullString `json:"template"`
+	HasUnique               sql.NullBool   `json:"has_unique"`
+	CreatedAt               sql.NullTime   `json:"created_at"`
+	MembersCanShare         sql.NullBool   `json:"members_can_share"`
+	RequestAccessEmail      sql.NullString `json:"request_access_email"`
+	UseAccessRequestDefault sql.NullBool   `json:"use_access_request_default"`
+}
+
+type WebhookDelivery struct {
+	DeliveryID     int64        `json:"delivery_id"`
+	EndpointUrl    string       `json:"endpoint_url"`
+	EventType      string       `json:"event_type"`
+	EventKey       string       `json:"event_key"`
+	Payload        string       `json:"payload"`
+	Status         string       `json:"status"`
+	Attempts       int64        `json:"attempts"`
+	ResponseStatus int64        `json:"response_status"`
+	LastError      string       `json:"last_error"`
+	CreatedAt      time.Time    `json:"created_at"`
+	NextAttemptAt  sql.NullTime `json:"next_attempt_at"`
+	DeliveredAt    sql.NullTime `json:"delivered_at"`
 }
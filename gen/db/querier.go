@@ -7,49 +7,246 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	AddAuditRunManifestEntry(ctx context.Context, arg AddAuditRunManifestEntryParams) error
 	AddMemberToLink(ctx context.Context, arg AddMemberToLinkParams) error
 	ClearMembersForLink(ctx context.Context, arg ClearMembersForLinkParams) error
 	CompleteAuditRun(ctx context.Context, auditRunID int64) error
 	CompleteAuditRunByJobID(ctx context.Context, jobID string) error
+	CompleteDatabaseMaintenanceRun(ctx context.Context, arg CompleteDatabaseMaintenanceRunParams) error
 	CompleteJob(ctx context.Context, arg CompleteJobParams) error
+	CompleteOffboardingCheck(ctx context.Context, arg CompleteOffboardingCheckParams) error
+	// Assignment counts grouped by principal type and role, for analytics without loading each assignment
+	CountAssignmentsForObjectByAuditRun(ctx context.Context, arg CountAssignmentsForObjectByAuditRunParams) ([]CountAssignmentsForObjectByAuditRunRow, error)
+	// Distinct users an object's role assignments reach, matched by UPN: users assigned directly or through
+	// SharePoint groups, and the users Entra group expansion resolved for Entra groups assigned either way.
+	// Entra groups the run did not resolve are counted apart, as the users they reach are unknown.
+	CountEffectiveUsersForObjectByAuditRun(ctx context.Context, arg CountEffectiveUsersForObjectByAuditRunParams) (CountEffectiveUsersForObjectByAuditRunRow, error)
+	CountFilteredItemsForList(ctx context.Context, arg CountFilteredItemsForListParams) (int64, error)
+	CountFilteredItemsForListByAuditRun(ctx context.Context, arg CountFilteredItemsForListByAuditRunParams) (int64, error)
+	// Item totals for a list, for analytics without loading each item
+	CountItemsByKindForListByAuditRun(ctx context.Context, arg CountItemsByKindForListByAuditRunParams) (CountItemsByKindForListByAuditRunRow, error)
+	// Active sharing links counted per principal that created them, link kind and whether they grant edit
+	// or reach external users: guests invited to or holding the link, whose login names carry #ext# or urn:spo:guest
+	CountSharingLinksByCreatorByAuditRun(ctx context.Context, arg CountSharingLinksByCreatorByAuditRunParams) ([]CountSharingLinksByCreatorByAuditRunRow, error)
+	// Active sharing link and member counts grouped by link kind for items in a list
+	CountSharingLinksForListByAuditRun(ctx context.Context, arg CountSharingLinksForListByAuditRunParams) ([]CountSharingLinksForListByAuditRunRow, error)
+	CreateAttestation(ctx context.Context, arg CreateAttestationParams) (int64, error)
 	CreateAuditRun(ctx context.Context, arg CreateAuditRunParams) (int64, error)
+	CreateAuditRunArtifact(ctx context.Context, arg CreateAuditRunArtifactParams) (int64, error)
+	CreateAuditRunManifest(ctx context.Context, arg CreateAuditRunManifestParams) error
+	CreateAuditSchedule(ctx context.Context, arg CreateAuditScheduleParams) (int64, error)
+	CreateAuditScheduleCheck(ctx context.Context, arg CreateAuditScheduleCheckParams) error
+	CreateDatabaseMaintenanceRun(ctx context.Context, arg CreateDatabaseMaintenanceRunParams) (int64, error)
+	CreateFindingAlert(ctx context.Context, arg CreateFindingAlertParams) (int64, error)
 	CreateJob(ctx context.Context, arg CreateJobParams) error
+	CreateListMonitor(ctx context.Context, arg CreateListMonitorParams) (int64, error)
+	CreateListSubscription(ctx context.Context, arg CreateListSubscriptionParams) error
+	CreateOffboardingCheck(ctx context.Context, arg CreateOffboardingCheckParams) (int64, error)
+	CreateOffboardingRemediation(ctx context.Context, arg CreateOffboardingRemediationParams) error
+	CreatePolicyEvaluation(ctx context.Context, arg CreatePolicyEvaluationParams) error
+	CreatePolicyFinding(ctx context.Context, arg CreatePolicyFindingParams) error
+	CreateReportShare(ctx context.Context, arg CreateReportShareParams) (int64, error)
+	CreateSiteBaseline(ctx context.Context, arg CreateSiteBaselineParams) (int64, error)
+	// Queues an event for an endpoint, unless it was already queued for it
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error
+	DeleteAllOrgUnitMappings(ctx context.Context) error
+	DeleteAuditSchedule(ctx context.Context, scheduleID int64) (int64, error)
+	DeleteEntraGroupMembers(ctx context.Context, arg DeleteEntraGroupMembersParams) error
+	DeleteEventDeadLetter(ctx context.Context, deadLetterID int64) (int64, error)
+	DeleteExpiredAuditRunArtifacts(ctx context.Context, now time.Time) (int64, error)
+	DeleteListMonitor(ctx context.Context, monitorID int64) (int64, error)
+	DeleteListSubscription(ctx context.Context, subscriptionID string) (int64, error)
 	DeleteOldJobs(ctx context.Context) error
 	DeleteOldJobsForSite(ctx context.Context, siteID sql.NullInt64) error
+	DeleteOrgUnitMapping(ctx context.Context, mappingID int64) (int64, error)
+	DeletePermissionException(ctx context.Context, arg DeletePermissionExceptionParams) (int64, error)
+	DeletePolicyEvaluation(ctx context.Context, auditRunID int64) error
+	DeletePolicyFindings(ctx context.Context, auditRunID int64) error
 	DeleteRoleAssignmentsForObject(ctx context.Context, arg DeleteRoleAssignmentsForObjectParams) error
+	DeleteSitePolicyPack(ctx context.Context, siteID int64) (int64, error)
+	DeleteSiteSensitivity(ctx context.Context, siteID int64) (int64, error)
 	FailJob(ctx context.Context, arg FailJobParams) error
+	// Optional filters: unique_only (0/1) and kind ('', 'file', 'folder' or 'item').
+	FilteredItemsForList(ctx context.Context, arg FilteredItemsForListParams) ([]FilteredItemsForListRow, error)
+	FilteredItemsForListByAuditRun(ctx context.Context, arg FilteredItemsForListByAuditRunParams) ([]FilteredItemsForListByAuditRunRow, error)
+	// Item lookup across all sites: the most recent audit run holding the item.
+	// Matches the item GUID, the list item GUID, or the file/folder UniqueId its sharing links record.
+	FindLatestItemByGUID(ctx context.Context, guid string) (FindLatestItemByGUIDRow, error)
+	// Item lookup across all sites by sharing link URL; the stored link URL is compared without its query
+	FindLatestItemBySharingLinkURL(ctx context.Context, url string) (FindLatestItemBySharingLinkURLRow, error)
+	// Item lookup across all sites by absolute item URL, case-insensitive
+	FindLatestItemByURL(ctx context.Context, url string) (FindLatestItemByURLRow, error)
+	GetActivePermissionExceptionsForSite(ctx context.Context, arg GetActivePermissionExceptionsForSiteParams) ([]PermissionException, error)
 	// Find all principals with any SharingLinks patterns in login_name
 	GetAllSharingLinks(ctx context.Context, siteID int64) ([]GetAllSharingLinksRow, error)
+	// Active anonymous sharing links on items in every list of a site, filtered by audit run
+	// Links without an expiration first, then those expiring soonest
+	GetAnonymousSharingLinksByAuditRun(ctx context.Context, arg GetAnonymousSharingLinksByAuditRunParams) ([]GetAnonymousSharingLinksByAuditRunRow, error)
+	GetAppInventory(ctx context.Context, arg GetAppInventoryParams) (time.Time, error)
+	// Explicit assignments of app principals on the web, lists and items, described by the object's URL.
+	// The login name prefixes are those sharepoint.ClassifyPrincipal classifies as apps.
+	GetAppPermissionFactsForAuditRun(ctx context.Context, arg GetAppPermissionFactsForAuditRunParams) ([]GetAppPermissionFactsForAuditRunRow, error)
 	GetAssignmentsForObjectByAuditRun(ctx context.Context, arg GetAssignmentsForObjectByAuditRunParams) ([]GetAssignmentsForObjectByAuditRunRow, error)
+	// Same order as GetAssignmentsForObjectByAuditRun, so offsets address the same assignments
+	GetAssignmentsPageForObjectByAuditRun(ctx context.Context, arg GetAssignmentsPageForObjectByAuditRunParams) ([]GetAssignmentsPageForObjectByAuditRunRow, error)
+	GetAttestation(ctx context.Context, attestationID int64) (Attestation, error)
+	GetAttestationByToken(ctx context.Context, token string) (Attestation, error)
+	GetAttestationResponses(ctx context.Context, attestationID int64) ([]GetAttestationResponsesRow, error)
+	GetAttestationsForAuditRun(ctx context.Context, arg GetAttestationsForAuditRunParams) ([]GetAttestationsForAuditRunRow, error)
 	GetAuditRun(ctx context.Context, auditRunID int64) (GetAuditRunRow, error)
+	GetAuditRunArtifact(ctx context.Context, arg GetAuditRunArtifactParams) (GetAuditRunArtifactRow, error)
+	GetAuditRunArtifacts(ctx context.Context, arg GetAuditRunArtifactsParams) ([]GetAuditRunArtifactsRow, error)
+	GetAuditRunByJobID(ctx context.Context, jobID string) (GetAuditRunByJobIDRow, error)
+	GetAuditRunManifest(ctx context.Context, auditRunID int64) (AuditRunManifest, error)
+	GetAuditRunManifestEntries(ctx context.Context, auditRunID int64) ([]GetAuditRunManifestEntriesRow, error)
+	GetAuditRunParametersByJobID(ctx context.Context, jobID string) (GetAuditRunParametersByJobIDRow, error)
+	GetAuditRunPayloadSamples(ctx context.Context, auditRunID int64) ([]GetAuditRunPayloadSamplesRow, error)
+	// Row counts of the most recent runs, for storage monitoring.
+	GetAuditRunRowCounts(ctx context.Context, limitCount int64) ([]GetAuditRunRowCountsRow, error)
+	GetAuditRunSchemaDrift(ctx context.Context, auditRunID int64) ([]GetAuditRunSchemaDriftRow, error)
+	// An empty label lists every run of the site.
 	GetAuditRunsForSite(ctx context.Context, arg GetAuditRunsForSiteParams) ([]GetAuditRunsForSiteRow, error)
+	GetAuditSchedule(ctx context.Context, scheduleID int64) (AuditSchedule, error)
+	GetAuditScheduleCheck(ctx context.Context, arg GetAuditScheduleCheckParams) (AuditScheduleCheck, error)
+	GetAuditScheduleChecks(ctx context.Context, arg GetAuditScheduleChecksParams) ([]AuditScheduleCheck, error)
+	GetAuditScheduleForJob(ctx context.Context, jobID sql.NullString) (AuditSchedule, error)
+	GetAuditScheduleForSite(ctx context.Context, siteUrl string) (AuditSchedule, error)
+	GetAuditSchedules(ctx context.Context) ([]AuditSchedule, error)
+	GetCurrentSiteBaseline(ctx context.Context, siteID int64) (SiteBaseline, error)
+	GetDatabaseMaintenanceRuns(ctx context.Context, limitCount int64) ([]DatabaseMaintenanceRun, error)
+	GetDueAuditSchedules(ctx context.Context, now time.Time) ([]AuditSchedule, error)
+	GetDueListMonitors(ctx context.Context, now time.Time) ([]ListMonitor, error)
+	// Pending deliveries whose next attempt is due, oldest first
+	GetDueWebhookDeliveries(ctx context.Context, arg GetDueWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	// Entra group principals whose resolved users include a user, matched by UPN or mail in lower case
+	GetEntraGroupIDsForUserByAuditRun(ctx context.Context, arg GetEntraGroupIDsForUserByAuditRunParams) ([]int64, error)
+	GetEventDeadLetter(ctx context.Context, deadLetterID int64) (EventDeadLetter, error)
+	GetEventHandlerOffset(ctx context.Context, handler string) (int64, error)
+	GetFindingAlertSightings(ctx context.Context, siteID int64) ([]GetFindingAlertSightingsRow, error)
+	GetFindingAlertsForSite(ctx context.Context, arg GetFindingAlertsForSiteParams) ([]FindingAlert, error)
 	// Find principals with Flexible sharing link patterns in login_name
 	GetFlexibleSharingLinks(ctx context.Context, siteID int64) ([]GetFlexibleSharingLinksRow, error)
+	// SharePoint groups a principal belongs to, as captured by an audit run
+	GetGraphAppGrantsByAuditRun(ctx context.Context, arg GetGraphAppGrantsByAuditRunParams) ([]GetGraphAppGrantsByAuditRunRow, error)
+	GetGraphGrantScan(ctx context.Context, arg GetGraphGrantScanParams) (time.Time, error)
+	GetGroupIDsForMemberByAuditRun(ctx context.Context, arg GetGroupIDsForMemberByAuditRunParams) ([]int64, error)
+	// Members of a SharePoint group, as captured by an audit run
+	GetGroupMembersByAuditRun(ctx context.Context, arg GetGroupMembersByAuditRunParams) ([]GetGroupMembersByAuditRunRow, error)
+	// Assignments on the list's items with unique permissions
+	GetInstalledAppsByAuditRun(ctx context.Context, arg GetInstalledAppsByAuditRunParams) ([]GetInstalledAppsByAuditRunRow, error)
+	GetItemAssignmentChanges(ctx context.Context, arg GetItemAssignmentChangesParams) ([]GetItemAssignmentChangesRow, error)
+	GetItemAttachmentsByAuditRun(ctx context.Context, arg GetItemAttachmentsByAuditRunParams) ([]GetItemAttachmentsByAuditRunRow, error)
 	GetItemByGUID(ctx context.Context, arg GetItemByGUIDParams) (GetItemByGUIDRow, error)
+	GetItemByGUIDByAuditRun(ctx context.Context, arg GetItemByGUIDByAuditRunParams) (GetItemByGUIDByAuditRunRow, error)
 	GetItemByListAndGUID(ctx context.Context, arg GetItemByListAndGUIDParams) (GetItemByListAndGUIDRow, error)
 	GetItemByListAndID(ctx context.Context, arg GetItemByListAndIDParams) (GetItemByListAndIDRow, error)
 	GetItemByListItemGUID(ctx context.Context, arg GetItemByListItemGUIDParams) (GetItemByListItemGUIDRow, error)
+	// Display names of the sensitivity labels applied to items
+	GetItemLabelsByAuditRun(ctx context.Context, arg GetItemLabelsByAuditRunParams) ([]GetItemLabelsByAuditRunRow, error)
 	GetItemSensitivityLabel(ctx context.Context, arg GetItemSensitivityLabelParams) (GetItemSensitivityLabelRow, error)
+	GetItemSensitivityLabelByAuditRun(ctx context.Context, arg GetItemSensitivityLabelByAuditRunParams) (GetItemSensitivityLabelByAuditRunRow, error)
+	GetItemsForAuditRun(ctx context.Context, arg GetItemsForAuditRunParams) ([]GetItemsForAuditRunRow, error)
+	// Items whose sensitivity label names an owner, matched by email in lower case
+	GetItemsOwnedByEmailByAuditRun(ctx context.Context, arg GetItemsOwnedByEmailByAuditRunParams) ([]GetItemsOwnedByEmailByAuditRunRow, error)
 	GetJob(ctx context.Context, jobID string) (GetJobRow, error)
+	GetJobEvent(ctx context.Context, sequence int64) (JobEvent, error)
 	GetLastCompletedJobForSite(ctx context.Context, arg GetLastCompletedJobForSiteParams) (GetLastCompletedJobForSiteRow, error)
-	GetLatestAuditRunForSite(ctx context.Context, siteID int64) (GetLatestAuditRunForSiteRow, error)
+	// Full site runs are preferred; a folder-scoped run is only latest when the site has no other.
+	// A label restricts the candidates to runs tagged with it.
+	GetLatestAuditRunForSite(ctx context.Context, arg GetLatestAuditRunForSiteParams) (GetLatestAuditRunForSiteRow, error)
+	GetLatestCompletedAuditRunForSite(ctx context.Context, arg GetLatestCompletedAuditRunForSiteParams) (GetLatestCompletedAuditRunForSiteRow, error)
+	GetLatestJobEventSequence(ctx context.Context) (int64, error)
+	// Findings of the latest evaluated run of each site
+	GetLatestPolicyFindings(ctx context.Context) ([]GetLatestPolicyFindingsRow, error)
 	GetLinkIDByUrlKindScope(ctx context.Context, arg GetLinkIDByUrlKindScopeParams) (string, error)
 	GetList(ctx context.Context, arg GetListParams) (GetListRow, error)
+	// ==================================
+	// List changes between audit runs
+	// ==================================
+	// Each query compares one kind of permission fact on a list between a base run and a run, and
+	// returns only the facts present in one of them, so a diff never loads both runs. Removed facts
+	// are described from the base run, added facts from the run. Rows are ordered by object,
+	// principal and role, removals first.
+	GetListAssignmentChanges(ctx context.Context, arg GetListAssignmentChangesParams) ([]GetListAssignmentChangesRow, error)
 	GetListByAuditRun(ctx context.Context, arg GetListByAuditRunParams) (GetListByAuditRunRow, error)
+	GetListCheckpointsByAuditRun(ctx context.Context, auditRunID int64) ([]GetListCheckpointsByAuditRunRow, error)
+	GetListMonitor(ctx context.Context, monitorID int64) (ListMonitor, error)
+	GetListMonitorForList(ctx context.Context, arg GetListMonitorForListParams) (ListMonitor, error)
+	GetListMonitors(ctx context.Context) ([]ListMonitor, error)
+	GetListSubscription(ctx context.Context, subscriptionID string) (ListSubscription, error)
+	GetListSubscriptionForList(ctx context.Context, arg GetListSubscriptionForListParams) (ListSubscription, error)
+	GetListSubscriptions(ctx context.Context) ([]ListSubscription, error)
 	// Audit-run-scoped queries for reading historical data
 	GetListsByAuditRun(ctx context.Context, arg GetListsByAuditRunParams) ([]GetListsByAuditRunRow, error)
 	GetListsByWebID(ctx context.Context, arg GetListsByWebIDParams) ([]GetListsByWebIDRow, error)
+	GetListsForAuditRun(ctx context.Context, arg GetListsForAuditRunParams) ([]GetListsForAuditRunRow, error)
 	GetListsForSite(ctx context.Context, siteID int64) ([]GetListsForSiteRow, error)
 	GetListsWithUniqueByAuditRun(ctx context.Context, arg GetListsWithUniqueByAuditRunParams) ([]GetListsWithUniqueByAuditRunRow, error)
-	GetRecipientLimits(ctx context.Context, siteID int64) (GetRecipientLimitsRow, error)
+	GetOffboardingCheck(ctx context.Context, checkID int64) (GetOffboardingCheckRow, error)
+	GetOffboardingCheckForJob(ctx context.Context, jobID string) (GetOffboardingCheckForJobRow, error)
+	GetOffboardingCheckReport(ctx context.Context, checkID int64) (sql.NullString, error)
+	GetOffboardingChecks(ctx context.Context, limitCount int64) ([]GetOffboardingChecksRow, error)
+	GetOffboardingRemediations(ctx context.Context, checkID int64) ([]OffboardingRemediation, error)
+	GetOrgUnitMappings(ctx context.Context) ([]OrgUnitMapping, error)
+	GetPermissionException(ctx context.Context, arg GetPermissionExceptionParams) (PermissionException, error)
+	// Exceptions of every site that expire by before, expired ones included, soonest first
+	GetPermissionExceptionsExpiringBefore(ctx context.Context, before time.Time) ([]GetPermissionExceptionsExpiringBeforeRow, error)
+	GetPermissionExceptionsForSite(ctx context.Context, siteID int64) ([]PermissionException, error)
+	// ==================================
+	// Run facts
+	// ==================================
+	// A migration assessment compares runs of two different sites, so object keys and principal IDs
+	// cannot be joined across runs. These queries describe each run's permission facts by URL and
+	// principal instead, for the application to match after mapping source URLs to target URLs.
+	// The risky defaults report reads the sharing link facts on their own.
+	// Explicit assignments on the web, lists and items, described by the object's URL
+	GetPermissionFactsForAuditRun(ctx context.Context, arg GetPermissionFactsForAuditRunParams) ([]GetPermissionFactsForAuditRunRow, error)
+	GetPinnedAuditRunForSite(ctx context.Context, siteID int64) (GetPinnedAuditRunForSiteRow, error)
+	GetPolicyEvaluation(ctx context.Context, auditRunID int64) (PolicyEvaluation, error)
+	GetPolicyFindings(ctx context.Context, auditRunID int64) ([]PolicyFinding, error)
+	// Most recent audit run before the given one that captured sharing governance, or 0 if none did
+	GetPreviousAuditRunForSharingGovernance(ctx context.Context, arg GetPreviousAuditRunForSharingGovernanceParams) (int64, error)
+	// Most recent audit run before the given one that captured the sharing link, or 0 if none did
+	GetPreviousAuditRunForSharingLink(ctx context.Context, arg GetPreviousAuditRunForSharingLinkParams) (int64, error)
+	// The completed run of the site with the same scope, the same folder or the whole site, started most recently before the given run.
+	GetPreviousCompletedAuditRunForScope(ctx context.Context, auditRunID int64) (GetPreviousCompletedAuditRunForScopeRow, error)
+	GetPrincipalsByAuditRun(ctx context.Context, arg GetPrincipalsByAuditRunParams) ([]GetPrincipalsByAuditRunRow, error)
+	GetQueuedFindingAlerts(ctx context.Context) ([]FindingAlert, error)
+	GetRecipientLimits(ctx context.Context, arg GetRecipientLimitsParams) (GetRecipientLimitsRow, error)
+	// Active sharing links on deleted items, as captured by the last earlier run that saw an item at
+	// each one's location. When that run started after the deletion, it saw a new item there.
+	GetRecycleBinItemSharingLinks(ctx context.Context, arg GetRecycleBinItemSharingLinksParams) ([]GetRecycleBinItemSharingLinksRow, error)
+	GetRecycleBinItemsByAuditRun(ctx context.Context, arg GetRecycleBinItemsByAuditRunParams) ([]GetRecycleBinItemsByAuditRunRow, error)
+	GetRecycleBinScan(ctx context.Context, arg GetRecycleBinScanParams) (time.Time, error)
+	// Principals the base run captured that the run no longer does, described as the base run captured them
+	GetRemovedPrincipals(ctx context.Context, arg GetRemovedPrincipalsParams) ([]GetRemovedPrincipalsRow, error)
+	GetReportShareByToken(ctx context.Context, token string) (ReportShare, error)
+	GetReportSharesForAuditRun(ctx context.Context, arg GetReportSharesForAuditRunParams) ([]ReportShare, error)
 	GetRootPermissionsForPrincipalInWebByAuditRun(ctx context.Context, arg GetRootPermissionsForPrincipalInWebByAuditRunParams) ([]GetRootPermissionsForPrincipalInWebByAuditRunRow, error)
+	// Security group principals an audit run recorded, holding roles directly or through SharePoint groups
+	GetSecurityGroupPrincipalsByAuditRun(ctx context.Context, arg GetSecurityGroupPrincipalsByAuditRunParams) ([]GetSecurityGroupPrincipalsByAuditRunRow, error)
+	GetSensitivityLabelsForList(ctx context.Context, arg GetSensitivityLabelsForListParams) ([]GetSensitivityLabelsForListRow, error)
+	GetSensitivityLabelsForListByAuditRun(ctx context.Context, arg GetSensitivityLabelsForListByAuditRunParams) ([]GetSensitivityLabelsForListByAuditRunRow, error)
 	GetSensitivityLabelsForSite(ctx context.Context, siteID int64) ([]GetSensitivityLabelsForSiteRow, error)
 	GetSharedItemForSharingLink(ctx context.Context, arg GetSharedItemForSharingLinkParams) (GetSharedItemForSharingLinkRow, error)
-	GetSharingAbilities(ctx context.Context, siteID int64) (GetSharingAbilitiesRow, error)
-	GetSharingGovernance(ctx context.Context, siteID int64) (GetSharingGovernanceRow, error)
+	GetSharingAbilities(ctx context.Context, arg GetSharingAbilitiesParams) (GetSharingAbilitiesRow, error)
+	GetSharingGovernance(ctx context.Context, arg GetSharingGovernanceParams) (GetSharingGovernanceRow, error)
+	// A single sharing link with the item it is on. Link IDs come from sharing link
+	// group login names, whose GUID casing can differ from the sharing API's.
+	GetSharingLinkByAuditRun(ctx context.Context, arg GetSharingLinkByAuditRunParams) (GetSharingLinkByAuditRunRow, error)
+	// Active links on the list's items, named after the item they were created on
+	GetSharingLinkChanges(ctx context.Context, arg GetSharingLinkChangesParams) ([]GetSharingLinkChangesRow, error)
+	// Active sharing links a principal created, described by the URL of the item they were created on
+	GetSharingLinkFactsCreatedByPrincipal(ctx context.Context, arg GetSharingLinkFactsCreatedByPrincipalParams) ([]GetSharingLinkFactsCreatedByPrincipalRow, error)
+	// Active sharing links, described by the URL of the item they were created on
+	GetSharingLinkFactsForAuditRun(ctx context.Context, arg GetSharingLinkFactsForAuditRunParams) ([]GetSharingLinkFactsForAuditRunRow, error)
+	// Sharing links a principal is a member of, as captured by an audit run
+	GetSharingLinkIDsForMemberByAuditRun(ctx context.Context, arg GetSharingLinkIDsForMemberByAuditRunParams) ([]string, error)
+	GetSharingLinkMemberChanges(ctx context.Context, arg GetSharingLinkMemberChangesParams) ([]GetSharingLinkMemberChangesRow, error)
 	// Get all members (principals) for a specific sharing link
 	GetSharingLinkMembers(ctx context.Context, arg GetSharingLinkMembersParams) ([]GetSharingLinkMembersRow, error)
 	// Get all members (principals) for a specific sharing link filtered by audit run
@@ -57,17 +254,47 @@ type Querier interface {
 	// Get all sharing links for items in a specific list with item and principal details
 	GetSharingLinksForList(ctx context.Context, arg GetSharingLinksForListParams) ([]GetSharingLinksForListRow, error)
 	// Get all sharing links for items in a specific list filtered by audit run
+	// Most-accessed items first when analytics were collected
 	GetSharingLinksForListByAuditRun(ctx context.Context, arg GetSharingLinksForListByAuditRunParams) ([]GetSharingLinksForListByAuditRunRow, error)
-	GetSiteByID(ctx context.Context, siteID int64) (Site, error)
-	GetSiteByURL(ctx context.Context, siteUrl string) (Site, error)
+	GetSiteAuditRunArtifacts(ctx context.Context, arg GetSiteAuditRunArtifactsParams) ([]GetSiteAuditRunArtifactsRow, error)
+	GetSiteBaselines(ctx context.Context, siteID int64) ([]SiteBaseline, error)
+	GetSiteByID(ctx context.Context, siteID int64) (GetSiteByIDRow, error)
+	GetSiteByURL(ctx context.Context, siteUrl string) (GetSiteByURLRow, error)
+	GetSiteHub(ctx context.Context, arg GetSiteHubParams) (GetSiteHubRow, error)
+	GetSitePolicyPack(ctx context.Context, siteID int64) (SitePolicyPack, error)
+	GetSiteSensitivity(ctx context.Context, siteID int64) (SiteSensitivity, error)
+	// Sites are not versioned per run; this is the site the run audited
+	GetSitesForAuditRun(ctx context.Context, auditRunID int64) ([]GetSitesForAuditRunRow, error)
+	GetUniqueItemChanges(ctx context.Context, arg GetUniqueItemChangesParams) ([]GetUniqueItemChangesRow, error)
 	GetWeb(ctx context.Context, arg GetWebParams) (GetWebRow, error)
+	GetWebAssignmentChanges(ctx context.Context, arg GetWebAssignmentChangesParams) ([]GetWebAssignmentChangesRow, error)
 	GetWebIdForObject(ctx context.Context, arg GetWebIdForObjectParams) (interface{}, error)
+	// Most recent deliveries first, of the given status unless it is empty
+	GetWebhookDeliveries(ctx context.Context, arg GetWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	GetWebhookDelivery(ctx context.Context, deliveryID int64) (WebhookDelivery, error)
+	GetWebsForAuditRun(ctx context.Context, arg GetWebsForAuditRunParams) ([]GetWebsForAuditRunRow, error)
+	HoldAuditRun(ctx context.Context, arg HoldAuditRunParams) error
+	InitEventHandlerOffset(ctx context.Context, arg InitEventHandlerOffsetParams) error
+	InsertAppInventory(ctx context.Context, arg InsertAppInventoryParams) error
+	InsertAuditRunPayloadSample(ctx context.Context, arg InsertAuditRunPayloadSampleParams) error
+	InsertAuditRunSchemaDrift(ctx context.Context, arg InsertAuditRunSchemaDriftParams) error
+	InsertEntraGroup(ctx context.Context, arg InsertEntraGroupParams) error
+	InsertEntraGroupMember(ctx context.Context, arg InsertEntraGroupMemberParams) error
+	InsertGraphAppGrant(ctx context.Context, arg InsertGraphAppGrantParams) error
+	InsertGraphGrantScan(ctx context.Context, arg InsertGraphGrantScanParams) error
+	InsertGroupMember(ctx context.Context, arg InsertGroupMemberParams) error
+	InsertInstalledApp(ctx context.Context, arg InsertInstalledAppParams) error
 	InsertItem(ctx context.Context, arg InsertItemParams) error
+	InsertItemAttachment(ctx context.Context, arg InsertItemAttachmentParams) error
+	InsertJobEvent(ctx context.Context, arg InsertJobEventParams) (int64, error)
 	InsertList(ctx context.Context, arg InsertListParams) error
 	InsertPrincipal(ctx context.Context, arg InsertPrincipalParams) error
+	InsertRecycleBinItem(ctx context.Context, arg InsertRecycleBinItemParams) error
+	InsertRecycleBinScan(ctx context.Context, arg InsertRecycleBinScanParams) error
 	InsertRoleAssignment(ctx context.Context, arg InsertRoleAssignmentParams) error
 	InsertRoleDefinition(ctx context.Context, arg InsertRoleDefinitionParams) error
 	InsertSharingLink(ctx context.Context, arg InsertSharingLinkParams) (string, error)
+	InsertSiteHub(ctx context.Context, arg InsertSiteHubParams) error
 	InsertWeb(ctx context.Context, arg InsertWebParams) error
 	ItemsForList(ctx context.Context, arg ItemsForListParams) ([]ItemsForListRow, error)
 	ItemsForListByAuditRun(ctx context.Context, arg ItemsForListByAuditRunParams) ([]ItemsForListByAuditRunRow, error)
@@ -77,15 +304,63 @@ type Querier interface {
 	ListActiveJobsForSite(ctx context.Context, siteID sql.NullInt64) ([]ListActiveJobsForSiteRow, error)
 	ListAllJobs(ctx context.Context) ([]ListAllJobsRow, error)
 	ListAllJobsForSite(ctx context.Context, siteID sql.NullInt64) ([]ListAllJobsForSiteRow, error)
-	ListSites(ctx context.Context) ([]Site, error)
+	ListEventDeadLetters(ctx context.Context, limitCount int64) ([]EventDeadLetter, error)
+	// Empty event_type and job_id match every event.
+	ListJobEvents(ctx context.Context, arg ListJobEventsParams) ([]JobEvent, error)
+	ListSites(ctx context.Context) ([]ListSitesRow, error)
+	// One row per site with list statistics for its latest audit run. With prefer_completed set,
+	// the latest completed run is used when one exists; sites without runs have no run columns.
+	// Full site runs rank ahead of folder-scoped ones, as for GetLatestAuditRunForSite.
+	// A label ranks only the runs tagged with it and leaves out sites with none.
+	ListSitesWithLatestAuditRunMetadata(ctx context.Context, arg ListSitesWithLatestAuditRunMetadataParams) ([]ListSitesWithLatestAuditRunMetadataRow, error)
 	ListWebs(ctx context.Context) ([]ListWebsRow, error)
 	ListWebsForSite(ctx context.Context, siteID int64) ([]ListWebsForSiteRow, error)
+	ListWebsForSiteByAuditRun(ctx context.Context, arg ListWebsForSiteByAuditRunParams) ([]ListWebsForSiteByAuditRunRow, error)
 	ListsAll(ctx context.Context) ([]ListsAllRow, error)
 	ListsWithUnique(ctx context.Context) ([]ListsWithUniqueRow, error)
 	ListsWithUniqueForSite(ctx context.Context, siteID int64) ([]ListsWithUniqueForSiteRow, error)
+	MarkAttestationSubmitted(ctx context.Context, arg MarkAttestationSubmittedParams) error
+	MarkFindingAlertDelivered(ctx context.Context, arg MarkFindingAlertDeliveredParams) error
+	MarkListSubscriptionNotified(ctx context.Context, arg MarkListSubscriptionNotifiedParams) error
+	MarkPermissionExceptionReviewNotified(ctx context.Context, arg MarkPermissionExceptionReviewNotifiedParams) error
 	MigrateCompletedAuditRuns(ctx context.Context) error
+	PinAuditRunForSite(ctx context.Context, arg PinAuditRunForSiteParams) error
+	RecordAuditScheduleRun(ctx context.Context, arg RecordAuditScheduleRunParams) error
+	RecordListMonitorAudit(ctx context.Context, arg RecordListMonitorAuditParams) error
+	RecordListMonitorCheck(ctx context.Context, arg RecordListMonitorCheckParams) error
+	RecordListSubscriptionAudit(ctx context.Context, arg RecordListSubscriptionAuditParams) error
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) error
+	ReleaseAuditRunHold(ctx context.Context, auditRunID int64) error
+	// A resumed job runs again: its error and completion time are cleared.
+	ReopenJob(ctx context.Context, jobID string) error
+	// A lapsed subscription is replaced by a new one with a new ID, keeping its place in the change log.
+	RenewListSubscription(ctx context.Context, arg RenewListSubscriptionParams) error
+	// Renewed exceptions are reminded of again as they near their new expiry
+	RenewPermissionException(ctx context.Context, arg RenewPermissionExceptionParams) (int64, error)
+	// Queues a failed delivery for one more attempt
+	RetryWebhookDelivery(ctx context.Context, arg RetryWebhookDeliveryParams) (int64, error)
+	RevokeReportShare(ctx context.Context, arg RevokeReportShareParams) (int64, error)
+	SetEventHandlerOffset(ctx context.Context, arg SetEventHandlerOffsetParams) error
+	SupersedeSiteBaseline(ctx context.Context, arg SupersedeSiteBaselineParams) (int64, error)
+	UnpinAuditRunForSite(ctx context.Context, siteID int64) error
 	UpdateJobStatus(ctx context.Context, arg UpdateJobStatusParams) error
+	// External principals are guest accounts, whose login names carry #ext# or urn:spo:guest.
+	UpdateListAccessCountsByAuditRun(ctx context.Context, arg UpdateListAccessCountsByAuditRunParams) error
+	UpdateListSamplingByAuditRun(ctx context.Context, arg UpdateListSamplingByAuditRunParams) error
+	UpdateListSubscriptionChangeToken(ctx context.Context, arg UpdateListSubscriptionChangeTokenParams) error
+	// Sampled lists extrapolate the unique share of the sample to the whole list.
+	UpdateListUniqueDensityByAuditRun(ctx context.Context, arg UpdateListUniqueDensityByAuditRunParams) error
+	UpdateOffboardingRemediationStatus(ctx context.Context, arg UpdateOffboardingRemediationStatusParams) (int64, error)
+	UpsertAttestationResponse(ctx context.Context, arg UpsertAttestationResponseParams) error
+	UpsertEventDeadLetter(ctx context.Context, arg UpsertEventDeadLetterParams) (int64, error)
+	UpsertFindingAlertSighting(ctx context.Context, arg UpsertFindingAlertSightingParams) error
+	// All-time access counts for a shared item, keyed by file/folder UniqueId
+	UpsertItemAnalytics(ctx context.Context, arg UpsertItemAnalyticsParams) error
 	UpsertItemSensitivityLabel(ctx context.Context, arg UpsertItemSensitivityLabelParams) error
+	UpsertListCheckpoint(ctx context.Context, arg UpsertListCheckpointParams) error
+	UpsertOrgUnitMapping(ctx context.Context, arg UpsertOrgUnitMappingParams) (int64, error)
+	// Accepting the same object again renews its exception
+	UpsertPermissionException(ctx context.Context, arg UpsertPermissionExceptionParams) (int64, error)
 	UpsertPrincipalByLogin(ctx context.Context, arg UpsertPrincipalByLoginParams) (int64, error)
 	UpsertRecipientLimits(ctx context.Context, arg UpsertRecipientLimitsParams) error
 	UpsertSensitivityLabel(ctx context.Context, arg UpsertSensitivityLabelParams) error
@@ -95,6 +370,8 @@ type Querier interface {
 	// ==================================
 	UpsertSharingGovernance(ctx context.Context, arg UpsertSharingGovernanceParams) error
 	UpsertSite(ctx context.Context, arg UpsertSiteParams) (int64, error)
+	UpsertSitePolicyPack(ctx context.Context, arg UpsertSitePolicyPackParams) error
+	UpsertSiteSensitivity(ctx context.Context, arg UpsertSiteSensitivityParams) error
 }
 
 var _ Querier = (*Queries)(nil)
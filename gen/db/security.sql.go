@@ -10,19 +10,91 @@ import (
 	"database/sql"
 )
 
+const countAssignmentsForObjectByAuditRun = `-- name: CountAssignmentsForObjectByAuditRun :many
+SELECT
+  p.principal_type,
+  rd.role_def_id,
+  rd.name AS role_name,
+  CAST(COALESCE(length(p.login_name) > 12 AND substr(p.login_name, 1, 12) = 'SharingLinks', 0) AS INTEGER) AS is_sharing_link,
+  CAST(COUNT(*) AS INTEGER) AS assignment_count
+FROM role_assignments ra
+JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE ra.site_id = ?1 AND ra.object_type = ?2 AND ra.object_key = ?3
+  AND ra.audit_run_id = ?4
+GROUP BY p.principal_type, rd.role_def_id, rd.name, is_sharing_link
+`
+
+type CountAssignmentsForObjectByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	ObjectType string `json:"object_type"`
+	ObjectKey  string `json:"object_key"`
+	AuditRunID int64  `json:"audit_run_id"`
+}
+
+type CountAssignmentsForObjectByAuditRunRow struct {
+	PrincipalType   int64  `json:"principal_type"`
+	RoleDefID       int64  `json:"role_def_id"`
+	RoleName        string `json:"role_name"`
+	IsSharingLink   int64  `json:"is_sharing_link"`
+	AssignmentCount int64  `json:"assignment_count"`
+}
+
+// Assignment counts grouped by principal type and role, for analytics without loading each assignment
+func (q *Queries) CountAssignmentsForObjectByAuditRun(ctx context.Context, arg CountAssignmentsForObjectByAuditRunParams) ([]CountAssignmentsForObjectByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, countAssignmentsForObjectByAuditRun,
+		arg.SiteID,
+		arg.ObjectType,
+		arg.ObjectKey,
+		arg.AuditRunID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountAssignmentsForObjectByAuditRunRow
+	for rows.Next() {
+		var i CountAssignmentsForObjectByAuditRunRow
+		if err := rows.Scan(
+			&i.PrincipalType,
+			&i.RoleDefID,
+			&i.RoleName,
+			&i.IsSharingLink,
+			&i.AssignmentCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const deleteRoleAssignmentsForObject = `-- name: DeleteRoleAssignmentsForObject :exec
 DELETE FROM role_assignments
 WHERE site_id = ?1 AND object_type = ?2 AND object_key = ?3
+  AND audit_run_id = ?4
 `
 
 type DeleteRoleAssignmentsForObjectParams struct {
 	SiteID     int64  `json:"site_id"`
 	ObjectType string `json:"object_type"`
 	ObjectKey  string `json:"object_key"`
+	AuditRunID int64  `json:"audit_run_id"`
 }
 
 func (q *Queries) DeleteRoleAssignmentsForObject(ctx context.Context, arg DeleteRoleAssignmentsForObjectParams) error {
-	_, err := q.db.ExecContext(ctx, deleteRoleAssignmentsForObject, arg.SiteID, arg.ObjectType, arg.ObjectKey)
+	_, err := q.db.ExecContext(ctx, deleteRoleAssignmentsForObject,
+		arg.SiteID,
+		arg.ObjectType,
+		arg.ObjectKey,
+		arg.AuditRunID,
+	)
 	return err
 }
 
@@ -34,7 +106,7 @@ JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id
 JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
 WHERE ra.site_id = ?1 AND ra.object_type = ?2 AND ra.object_key = ?3
   AND ra.audit_run_id = ?4
-ORDER BY principal_title, role_name
+ORDER BY principal_title, role_name, ra.principal_id, ra.role_def_id
 `
 
 type GetAssignmentsForObjectByAuditRunParams struct {
@@ -92,6 +164,220 @@ func (q *Queries) GetAssignmentsForObjectByAuditRun(ctx context.Context, arg Get
 	return items, nil
 }
 
+const getAssignmentsPageForObjectByAuditRun = `-- name: GetAssignmentsPageForObjectByAuditRun :many
+SELECT ra.principal_id, p.title AS principal_title, p.login_name, p.principal_type,
+       ra.role_def_id, rd.name AS role_name, rd.description, ra.inherited
+FROM role_assignments ra
+JOIN principals p ON p.site_id = ra.site_id AND p.principal_id = ra.principal_id AND p.audit_run_id = ra.audit_run_id
+JOIN role_definitions rd ON rd.site_id = ra.site_id AND rd.role_def_id = ra.role_def_id AND rd.audit_run_id = ra.audit_run_id
+WHERE ra.site_id = ?1 AND ra.object_type = ?2 AND ra.object_key = ?3
+  AND ra.audit_run_id = ?4
+ORDER BY principal_title, role_name, ra.principal_id, ra.role_def_id
+LIMIT ?6 OFFSET ?5
+`
+
+type GetAssignmentsPageForObjectByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	ObjectType string `json:"object_type"`
+	ObjectKey  string `json:"object_key"`
+	AuditRunID int64  `json:"audit_run_id"`
+	Offset     int64  `json:"offset"`
+	Limit      int64  `json:"limit"`
+}
+
+type GetAssignmentsPageForObjectByAuditRunRow struct {
+	PrincipalID    int64          `json:"principal_id"`
+	PrincipalTitle sql.NullString `json:"principal_title"`
+	LoginName      sql.NullString `json:"login_name"`
+	PrincipalType  int64          `json:"principal_type"`
+	RoleDefID      int64          `json:"role_def_id"`
+	RoleName       string         `json:"role_name"`
+	Description    sql.NullString `json:"description"`
+	Inherited      sql.NullBool   `json:"inherited"`
+}
+
+// Same order as GetAssignmentsForObjectByAuditRun, so offsets address the same assignments
+func (q *Queries) GetAssignmentsPageForObjectByAuditRun(ctx context.Context, arg GetAssignmentsPageForObjectByAuditRunParams) ([]GetAssignmentsPageForObjectByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAssignmentsPageForObjectByAuditRun,
+		arg.SiteID,
+		arg.ObjectType,
+		arg.ObjectKey,
+		arg.AuditRunID,
+		arg.Offset,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAssignmentsPageForObjectByAuditRunRow
+	for rows.Next() {
+		var i GetAssignmentsPageForObjectByAuditRunRow
+		if err := rows.Scan(
+			&i.PrincipalID,
+			&i.PrincipalTitle,
+			&i.LoginName,
+			&i.PrincipalType,
+			&i.RoleDefID,
+			&i.RoleName,
+			&i.Description,
+			&i.Inherited,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGroupIDsForMemberByAuditRun = `-- name: GetGroupIDsForMemberByAuditRun :many
+SELECT group_id
+FROM group_members
+WHERE site_id = ?1 AND audit_run_id = ?2 AND member_id = ?3
+ORDER BY group_id
+`
+
+type GetGroupIDsForMemberByAuditRunParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+	MemberID   int64 `json:"member_id"`
+}
+
+// SharePoint groups a principal belongs to, as captured by an audit run
+func (q *Queries) GetGroupIDsForMemberByAuditRun(ctx context.Context, arg GetGroupIDsForMemberByAuditRunParams) ([]int64, error) {
+	rows, err := q.db.QueryContext(ctx, getGroupIDsForMemberByAuditRun, arg.SiteID, arg.AuditRunID, arg.MemberID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int64
+	for rows.Next() {
+		var group_id int64
+		if err := rows.Scan(&group_id); err != nil {
+			return nil, err
+		}
+		items = append(items, group_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGroupMembersByAuditRun = `-- name: GetGroupMembersByAuditRun :many
+SELECT p.site_id, p.principal_id, p.title, p.login_name, p.email, p.principal_type
+FROM group_members gm
+JOIN principals p ON p.site_id = gm.site_id AND p.principal_id = gm.member_id AND p.audit_run_id = gm.audit_run_id
+WHERE gm.site_id = ?1 AND gm.audit_run_id = ?2 AND gm.group_id = ?3
+ORDER BY p.title, p.principal_id
+`
+
+type GetGroupMembersByAuditRunParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+	GroupID    int64 `json:"group_id"`
+}
+
+type GetGroupMembersByAuditRunRow struct {
+	SiteID        int64          `json:"site_id"`
+	PrincipalID   int64          `json:"principal_id"`
+	Title         sql.NullString `json:"title"`
+	LoginName     sql.NullString `json:"login_name"`
+	Email         sql.NullString `json:"email"`
+	PrincipalType int64          `json:"principal_type"`
+}
+
+// Members of a SharePoint group, as captured by an audit run
+func (q *Queries) GetGroupMembersByAuditRun(ctx context.Context, arg GetGroupMembersByAuditRunParams) ([]GetGroupMembersByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getGroupMembersByAuditRun, arg.SiteID, arg.AuditRunID, arg.GroupID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetGroupMembersByAuditRunRow
+	for rows.Next() {
+		var i GetGroupMembersByAuditRunRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.PrincipalID,
+			&i.Title,
+			&i.LoginName,
+			&i.Email,
+			&i.PrincipalType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPrincipalsByAuditRun = `-- name: GetPrincipalsByAuditRun :many
+SELECT site_id, principal_id, title, login_name, email, principal_type
+FROM principals
+WHERE site_id = ?1 AND audit_run_id = ?2
+ORDER BY principal_id
+`
+
+type GetPrincipalsByAuditRunParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
+type GetPrincipalsByAuditRunRow struct {
+	SiteID        int64          `json:"site_id"`
+	PrincipalID   int64          `json:"principal_id"`
+	Title         sql.NullString `json:"title"`
+	LoginName     sql.NullString `json:"login_name"`
+	Email         sql.NullString `json:"email"`
+	PrincipalType int64          `json:"principal_type"`
+}
+
+func (q *Queries) GetPrincipalsByAuditRun(ctx context.Context, arg GetPrincipalsByAuditRunParams) ([]GetPrincipalsByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getPrincipalsByAuditRun, arg.SiteID, arg.AuditRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPrincipalsByAuditRunRow
+	for rows.Next() {
+		var i GetPrincipalsByAuditRunRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.PrincipalID,
+			&i.Title,
+			&i.LoginName,
+			&i.Email,
+			&i.PrincipalType,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getRootPermissionsForPrincipalInWebByAuditRun = `-- name: GetRootPermissionsForPrincipalInWebByAuditRun :many
 SELECT ra.object_type, ra.object_key, rd.name as role_name,
        CASE ra.object_type
@@ -214,6 +500,28 @@ func (q *Queries) GetWebIdForObject(ctx context.Context, arg GetWebIdForObjectPa
 	return web_id, err
 }
 
+const insertGroupMember = `-- name: InsertGroupMember :exec
+INSERT OR IGNORE INTO group_members (site_id, group_id, member_id, audit_run_id)
+VALUES (?1, ?2, ?3, ?4)
+`
+
+type InsertGroupMemberParams struct {
+	SiteID     int64 `json:"site_id"`
+	GroupID    int64 `json:"group_id"`
+	MemberID   int64 `json:"member_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
+func (q *Queries) InsertGroupMember(ctx context.Context, arg InsertGroupMemberParams) error {
+	_, err := q.db.ExecContext(ctx, insertGroupMember,
+		arg.SiteID,
+		arg.GroupID,
+		arg.MemberID,
+		arg.AuditRunID,
+	)
+	return err
+}
+
 const insertPrincipal = `-- name: InsertPrincipal :exec
 INSERT INTO principals (site_id, principal_id, principal_type, title, login_name, email, audit_run_id)
 VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
@@ -273,6 +581,8 @@ func (q *Queries) InsertRoleAssignment(ctx context.Context, arg InsertRoleAssign
 const insertRoleDefinition = `-- name: InsertRoleDefinition :exec
 INSERT INTO role_definitions (site_id, role_def_id, name, description, audit_run_id)
 VALUES (?1, ?2, ?3, ?4, ?5)
+ON CONFLICT (site_id, role_def_id, audit_run_id) DO UPDATE SET
+  name = excluded.name, description = excluded.description
 `
 
 type InsertRoleDefinitionParams struct {
@@ -46,6 +46,136 @@ func (q *Queries) ClearMembersForLink(ctx context.Context, arg ClearMembersForLi
 	return err
 }
 
+const countSharingLinksByCreatorByAuditRun = `-- name: CountSharingLinksByCreatorByAuditRun :many
+SELECT
+  CAST(sl.created_by_principal_id AS INTEGER) AS principal_id,
+  CAST(COALESCE(p.title, '') AS TEXT) AS principal_title,
+  CAST(COALESCE(p.login_name, '') AS TEXT) AS login_name,
+  CAST(COALESCE(p.email, '') AS TEXT) AS email,
+  sl.link_kind,
+  sl.scope,
+  CAST(COALESCE(sl.is_edit_link, 0) AS INTEGER) AS grants_edit,
+  CAST(COALESCE(sl.has_external_guest_invitees, 0) OR EXISTS (
+    SELECT 1 FROM sharing_link_members m
+    JOIN principals mp ON mp.site_id = m.site_id AND mp.principal_id = m.principal_id AND mp.audit_run_id = m.audit_run_id
+    WHERE m.site_id = sl.site_id AND m.link_id = sl.link_id AND m.audit_run_id = sl.audit_run_id
+      AND (mp.login_name LIKE '%#ext#%' OR mp.login_name LIKE '%urn:spo:guest%')
+  ) AS INTEGER) AS reaches_external,
+  CAST(COUNT(*) AS INTEGER) AS link_count
+FROM sharing_links sl
+LEFT JOIN principals p ON p.site_id = sl.site_id AND p.principal_id = sl.created_by_principal_id AND p.audit_run_id = sl.audit_run_id
+WHERE sl.site_id = ?1 AND sl.audit_run_id = ?2 AND sl.is_active = 1
+  AND sl.created_by_principal_id IS NOT NULL
+GROUP BY sl.created_by_principal_id, sl.link_kind, sl.scope, grants_edit, reaches_external
+ORDER BY sl.created_by_principal_id
+`
+
+type CountSharingLinksByCreatorByAuditRunParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
+type CountSharingLinksByCreatorByAuditRunRow struct {
+	PrincipalID     int64         `json:"principal_id"`
+	PrincipalTitle  string        `json:"principal_title"`
+	LoginName       string        `json:"login_name"`
+	Email           string        `json:"email"`
+	LinkKind        sql.NullInt64 `json:"link_kind"`
+	Scope           sql.NullInt64 `json:"scope"`
+	GrantsEdit      int64         `json:"grants_edit"`
+	ReachesExternal int64         `json:"reaches_external"`
+	LinkCount       int64         `json:"link_count"`
+}
+
+// Active sharing links counted per principal that created them, link kind and whether they grant edit
+// or reach external users: guests invited to or holding the link, whose login names carry #ext# or urn:spo:guest
+func (q *Queries) CountSharingLinksByCreatorByAuditRun(ctx context.Context, arg CountSharingLinksByCreatorByAuditRunParams) ([]CountSharingLinksByCreatorByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, countSharingLinksByCreatorByAuditRun, arg.SiteID, arg.AuditRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountSharingLinksByCreatorByAuditRunRow
+	for rows.Next() {
+		var i CountSharingLinksByCreatorByAuditRunRow
+		if err := rows.Scan(
+			&i.PrincipalID,
+			&i.PrincipalTitle,
+			&i.LoginName,
+			&i.Email,
+			&i.LinkKind,
+			&i.Scope,
+			&i.GrantsEdit,
+			&i.ReachesExternal,
+			&i.LinkCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countSharingLinksForListByAuditRun = `-- name: CountSharingLinksForListByAuditRun :many
+SELECT
+  sl.link_kind,
+  CAST(COUNT(*) AS INTEGER) AS link_count,
+  CAST(COALESCE(SUM(
+    (SELECT COUNT(*) FROM sharing_link_members slm WHERE slm.site_id = sl.site_id AND slm.link_id = sl.link_id AND slm.audit_run_id = sl.audit_run_id)
+  ), 0) AS INTEGER) AS member_count
+FROM sharing_links sl
+WHERE sl.site_id = ?1 AND sl.audit_run_id = ?2 AND sl.is_active = 1
+  AND EXISTS (
+    SELECT 1 FROM items i
+    WHERE i.site_id = sl.site_id AND i.audit_run_id = sl.audit_run_id
+      AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid)
+      AND i.list_id = ?3
+  )
+GROUP BY sl.link_kind
+`
+
+type CountSharingLinksForListByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	AuditRunID int64  `json:"audit_run_id"`
+	ListID     string `json:"list_id"`
+}
+
+type CountSharingLinksForListByAuditRunRow struct {
+	LinkKind    sql.NullInt64 `json:"link_kind"`
+	LinkCount   int64         `json:"link_count"`
+	MemberCount int64         `json:"member_count"`
+}
+
+// Active sharing link and member counts grouped by link kind for items in a list
+func (q *Queries) CountSharingLinksForListByAuditRun(ctx context.Context, arg CountSharingLinksForListByAuditRunParams) ([]CountSharingLinksForListByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, countSharingLinksForListByAuditRun, arg.SiteID, arg.AuditRunID, arg.ListID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountSharingLinksForListByAuditRunRow
+	for rows.Next() {
+		var i CountSharingLinksForListByAuditRunRow
+		if err := rows.Scan(&i.LinkKind, &i.LinkCount, &i.MemberCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAllSharingLinks = `-- name: GetAllSharingLinks :many
 SELECT site_id, principal_id, login_name, title, email
 FROM principals 
@@ -92,6 +222,121 @@ func (q *Queries) GetAllSharingLinks(ctx context.Context, siteID int64) ([]GetAl
 	return items, nil
 }
 
+const getAnonymousSharingLinksByAuditRun = `-- name: GetAnonymousSharingLinksByAuditRun :many
+SELECT
+  sl.link_id,
+  sl.item_guid,
+  sl.file_folder_unique_id,
+  sl.url,
+  sl.link_kind,
+  sl.scope,
+  sl.is_edit_link,
+  sl.is_review_link,
+  sl.blocks_download,
+  sl.requires_password,
+  sl.expiration,
+  sl.created_at,
+  sl.last_modified_at,
+  i.list_id,
+  l.title as list_title,
+  i.name as item_name,
+  i.url as item_url,
+  i.is_file,
+  i.is_folder,
+  cb.title as created_by_title,
+  cb.login_name as created_by_login,
+  mb.title as modified_by_title,
+  mb.login_name as modified_by_login
+FROM sharing_links sl
+JOIN items i ON (sl.site_id = i.site_id AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid) AND i.audit_run_id = sl.audit_run_id)
+LEFT JOIN lists l ON l.site_id = i.site_id AND l.list_id = i.list_id AND l.audit_run_id = i.audit_run_id
+LEFT JOIN principals cb ON sl.site_id = cb.site_id AND sl.created_by_principal_id = cb.principal_id AND cb.audit_run_id = sl.audit_run_id
+LEFT JOIN principals mb ON sl.site_id = mb.site_id AND sl.last_modified_by_principal_id = mb.principal_id AND mb.audit_run_id = sl.audit_run_id
+WHERE sl.site_id = ?1 AND sl.audit_run_id = ?2
+  AND sl.is_active = 1 AND sl.scope = 0
+ORDER BY sl.expiration IS NOT NULL, sl.expiration, l.title, i.name, sl.link_id
+`
+
+type GetAnonymousSharingLinksByAuditRunParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
+type GetAnonymousSharingLinksByAuditRunRow struct {
+	LinkID             string         `json:"link_id"`
+	ItemGuid           sql.NullString `json:"item_guid"`
+	FileFolderUniqueID sql.NullString `json:"file_folder_unique_id"`
+	Url                sql.NullString `json:"url"`
+	LinkKind           sql.NullInt64  `json:"link_kind"`
+	Scope              sql.NullInt64  `json:"scope"`
+	IsEditLink         sql.NullBool   `json:"is_edit_link"`
+	IsReviewLink       sql.NullBool   `json:"is_review_link"`
+	BlocksDownload     sql.NullBool   `json:"blocks_download"`
+	RequiresPassword   sql.NullBool   `json:"requires_password"`
+	Expiration         sql.NullTime   `json:"expiration"`
+	CreatedAt          sql.NullTime   `json:"created_at"`
+	LastModifiedAt     sql.NullTime   `json:"last_modified_at"`
+	ListID             string         `json:"list_id"`
+	ListTitle          sql.NullString `json:"list_title"`
+	ItemName           sql.NullString `json:"item_name"`
+	ItemUrl            sql.NullString `json:"item_url"`
+	IsFile             sql.NullBool   `json:"is_file"`
+	IsFolder           sql.NullBool   `json:"is_folder"`
+	CreatedByTitle     sql.NullString `json:"created_by_title"`
+	CreatedByLogin     sql.NullString `json:"created_by_login"`
+	ModifiedByTitle    sql.NullString `json:"modified_by_title"`
+	ModifiedByLogin    sql.NullString `json:"modified_by_login"`
+}
+
+// Active anonymous sharing links on items in every list of a site, filtered by audit run
+// Links without an expiration first, then those expiring soonest
+func (q *Queries) GetAnonymousSharingLinksByAuditRun(ctx context.Context, arg GetAnonymousSharingLinksByAuditRunParams) ([]GetAnonymousSharingLinksByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAnonymousSharingLinksByAuditRun, arg.SiteID, arg.AuditRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAnonymousSharingLinksByAuditRunRow
+	for rows.Next() {
+		var i GetAnonymousSharingLinksByAuditRunRow
+		if err := rows.Scan(
+			&i.LinkID,
+			&i.ItemGuid,
+			&i.FileFolderUniqueID,
+			&i.Url,
+			&i.LinkKind,
+			&i.Scope,
+			&i.IsEditLink,
+			&i.IsReviewLink,
+			&i.BlocksDownload,
+			&i.RequiresPassword,
+			&i.Expiration,
+			&i.CreatedAt,
+			&i.LastModifiedAt,
+			&i.ListID,
+			&i.ListTitle,
+			&i.ItemName,
+			&i.ItemUrl,
+			&i.IsFile,
+			&i.IsFolder,
+			&i.CreatedByTitle,
+			&i.CreatedByLogin,
+			&i.ModifiedByTitle,
+			&i.ModifiedByLogin,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getFlexibleSharingLinks = `-- name: GetFlexibleSharingLinks :many
 SELECT site_id, principal_id, login_name, title, email
 FROM principals 
@@ -138,6 +383,47 @@ func (q *Queries) GetFlexibleSharingLinks(ctx context.Context, siteID int64) ([]
 	return items, nil
 }
 
+const getItemLabelsByAuditRun = `-- name: GetItemLabelsByAuditRun :many
+SELECT item_guid, CAST(display_name AS TEXT) AS label_name
+FROM sensitivity_labels
+WHERE site_id = ?1 AND audit_run_id = ?2 AND COALESCE(display_name, '') != ''
+ORDER BY item_guid
+`
+
+type GetItemLabelsByAuditRunParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
+type GetItemLabelsByAuditRunRow struct {
+	ItemGuid  string `json:"item_guid"`
+	LabelName string `json:"label_name"`
+}
+
+// Display names of the sensitivity labels applied to items
+func (q *Queries) GetItemLabelsByAuditRun(ctx context.Context, arg GetItemLabelsByAuditRunParams) ([]GetItemLabelsByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getItemLabelsByAuditRun, arg.SiteID, arg.AuditRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemLabelsByAuditRunRow
+	for rows.Next() {
+		var i GetItemLabelsByAuditRunRow
+		if err := rows.Scan(&i.ItemGuid, &i.LabelName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getItemSensitivityLabel = `-- name: GetItemSensitivityLabel :one
 SELECT 
   site_id,
@@ -199,6 +485,122 @@ func (q *Queries) GetItemSensitivityLabel(ctx context.Context, arg GetItemSensit
 	return i, err
 }
 
+const getItemSensitivityLabelByAuditRun = `-- name: GetItemSensitivityLabelByAuditRun :one
+SELECT 
+  site_id,
+  item_guid,
+  label_id,
+  display_name,
+  owner_email,
+  set_date,
+  assignment_method,
+  has_irm_protection,
+  content_bits,
+  label_flags,
+  discovered_at,
+  promotion_version,
+  label_hash
+FROM sensitivity_labels
+WHERE site_id = ?1 AND item_guid = ?2 AND audit_run_id = ?3
+`
+
+type GetItemSensitivityLabelByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	ItemGuid   string `json:"item_guid"`
+	AuditRunID int64  `json:"audit_run_id"`
+}
+
+type GetItemSensitivityLabelByAuditRunRow struct {
+	SiteID           int64          `json:"site_id"`
+	ItemGuid         string         `json:"item_guid"`
+	LabelID          sql.NullString `json:"label_id"`
+	DisplayName      sql.NullString `json:"display_name"`
+	OwnerEmail       sql.NullString `json:"owner_email"`
+	SetDate          sql.NullTime   `json:"set_date"`
+	AssignmentMethod sql.NullString `json:"assignment_method"`
+	HasIrmProtection sql.NullBool   `json:"has_irm_protection"`
+	ContentBits      sql.NullInt64  `json:"content_bits"`
+	LabelFlags       sql.NullInt64  `json:"label_flags"`
+	DiscoveredAt     sql.NullTime   `json:"discovered_at"`
+	PromotionVersion sql.NullInt64  `json:"promotion_version"`
+	LabelHash        sql.NullString `json:"label_hash"`
+}
+
+func (q *Queries) GetItemSensitivityLabelByAuditRun(ctx context.Context, arg GetItemSensitivityLabelByAuditRunParams) (GetItemSensitivityLabelByAuditRunRow, error) {
+	row := q.db.QueryRowContext(ctx, getItemSensitivityLabelByAuditRun, arg.SiteID, arg.ItemGuid, arg.AuditRunID)
+	var i GetItemSensitivityLabelByAuditRunRow
+	err := row.Scan(
+		&i.SiteID,
+		&i.ItemGuid,
+		&i.LabelID,
+		&i.DisplayName,
+		&i.OwnerEmail,
+		&i.SetDate,
+		&i.AssignmentMethod,
+		&i.HasIrmProtection,
+		&i.ContentBits,
+		&i.LabelFlags,
+		&i.DiscoveredAt,
+		&i.PromotionVersion,
+		&i.LabelHash,
+	)
+	return i, err
+}
+
+const getItemsOwnedByEmailByAuditRun = `-- name: GetItemsOwnedByEmailByAuditRun :many
+SELECT sl.item_guid,
+       CAST(COALESCE(i.url, '') AS TEXT) AS item_url,
+       CAST(COALESCE(i.name, '') AS TEXT) AS item_name,
+       CAST(COALESCE(sl.display_name, '') AS TEXT) AS label_name
+FROM sensitivity_labels sl
+JOIN items i ON sl.site_id = i.site_id AND sl.item_guid = i.item_guid AND sl.audit_run_id = i.audit_run_id
+WHERE sl.site_id = ?1 AND sl.audit_run_id = ?2
+  AND lower(sl.owner_email) = CAST(?3 AS TEXT)
+ORDER BY item_url, sl.item_guid
+`
+
+type GetItemsOwnedByEmailByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	AuditRunID int64  `json:"audit_run_id"`
+	Email      string `json:"email"`
+}
+
+type GetItemsOwnedByEmailByAuditRunRow struct {
+	ItemGuid  string `json:"item_guid"`
+	ItemUrl   string `json:"item_url"`
+	ItemName  string `json:"item_name"`
+	LabelName string `json:"label_name"`
+}
+
+// Items whose sensitivity label names an owner, matched by email in lower case
+func (q *Queries) GetItemsOwnedByEmailByAuditRun(ctx context.Context, arg GetItemsOwnedByEmailByAuditRunParams) ([]GetItemsOwnedByEmailByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getItemsOwnedByEmailByAuditRun, arg.SiteID, arg.AuditRunID, arg.Email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetItemsOwnedByEmailByAuditRunRow
+	for rows.Next() {
+		var i GetItemsOwnedByEmailByAuditRunRow
+		if err := rows.Scan(
+			&i.ItemGuid,
+			&i.ItemUrl,
+			&i.ItemName,
+			&i.LabelName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getLinkIDByUrlKindScope = `-- name: GetLinkIDByUrlKindScope :one
 SELECT link_id
 FROM sharing_links
@@ -231,6 +633,45 @@ func (q *Queries) GetLinkIDByUrlKindScope(ctx context.Context, arg GetLinkIDByUr
 	return link_id, err
 }
 
+const getPreviousAuditRunForSharingGovernance = `-- name: GetPreviousAuditRunForSharingGovernance :one
+SELECT CAST(COALESCE(MAX(audit_run_id), 0) AS INTEGER) AS audit_run_id
+FROM sharing_governance
+WHERE site_id = ?1 AND audit_run_id < ?2
+`
+
+type GetPreviousAuditRunForSharingGovernanceParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
+// Most recent audit run before the given one that captured sharing governance, or 0 if none did
+func (q *Queries) GetPreviousAuditRunForSharingGovernance(ctx context.Context, arg GetPreviousAuditRunForSharingGovernanceParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getPreviousAuditRunForSharingGovernance, arg.SiteID, arg.AuditRunID)
+	var audit_run_id int64
+	err := row.Scan(&audit_run_id)
+	return audit_run_id, err
+}
+
+const getPreviousAuditRunForSharingLink = `-- name: GetPreviousAuditRunForSharingLink :one
+SELECT CAST(COALESCE(MAX(audit_run_id), 0) AS INTEGER) AS audit_run_id
+FROM sharing_links
+WHERE site_id = ?1 AND link_id = ?2 AND audit_run_id < ?3
+`
+
+type GetPreviousAuditRunForSharingLinkParams struct {
+	SiteID     int64  `json:"site_id"`
+	LinkID     string `json:"link_id"`
+	AuditRunID int64  `json:"audit_run_id"`
+}
+
+// Most recent audit run before the given one that captured the sharing link, or 0 if none did
+func (q *Queries) GetPreviousAuditRunForSharingLink(ctx context.Context, arg GetPreviousAuditRunForSharingLinkParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getPreviousAuditRunForSharingLink, arg.SiteID, arg.LinkID, arg.AuditRunID)
+	var audit_run_id int64
+	err := row.Scan(&audit_run_id)
+	return audit_run_id, err
+}
+
 const getRecipientLimits = `-- name: GetRecipientLimits :one
 SELECT 
   site_id,
@@ -239,9 +680,14 @@ SELECT
   share_link,
   share_link_with_defer_redeem
 FROM recipient_limits
-WHERE site_id = ?1
+WHERE site_id = ?1 AND audit_run_id = ?2
 `
 
+type GetRecipientLimitsParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
 type GetRecipientLimitsRow struct {
 	SiteID                   int64          `json:"site_id"`
 	CheckPermissions         sql.NullString `json:"check_permissions"`
@@ -250,8 +696,8 @@ type GetRecipientLimitsRow struct {
 	ShareLinkWithDeferRedeem sql.NullString `json:"share_link_with_defer_redeem"`
 }
 
-func (q *Queries) GetRecipientLimits(ctx context.Context, siteID int64) (GetRecipientLimitsRow, error) {
-	row := q.db.QueryRowContext(ctx, getRecipientLimits, siteID)
+func (q *Queries) GetRecipientLimits(ctx context.Context, arg GetRecipientLimitsParams) (GetRecipientLimitsRow, error) {
+	row := q.db.QueryRowContext(ctx, getRecipientLimits, arg.SiteID, arg.AuditRunID)
 	var i GetRecipientLimitsRow
 	err := row.Scan(
 		&i.SiteID,
@@ -263,6 +709,167 @@ func (q *Queries) GetRecipientLimits(ctx context.Context, siteID int64) (GetReci
 	return i, err
 }
 
+const getSensitivityLabelsForList = `-- name: GetSensitivityLabelsForList :many
+SELECT 
+  sl.site_id,
+  sl.item_guid,
+  sl.label_id,
+  sl.display_name,
+  sl.owner_email,
+  sl.set_date,
+  sl.assignment_method,
+  sl.has_irm_protection,
+  sl.content_bits,
+  sl.label_flags,
+  sl.discovered_at,
+  sl.promotion_version,
+  sl.label_hash
+FROM sensitivity_labels sl
+JOIN items i ON sl.site_id = i.site_id AND sl.item_guid = i.item_guid AND sl.audit_run_id = i.audit_run_id
+WHERE sl.site_id = ?1 AND i.list_id = ?2
+  AND sl.label_id IS NOT NULL
+ORDER BY sl.item_guid, sl.audit_run_id
+`
+
+type GetSensitivityLabelsForListParams struct {
+	SiteID int64  `json:"site_id"`
+	ListID string `json:"list_id"`
+}
+
+type GetSensitivityLabelsForListRow struct {
+	SiteID           int64          `json:"site_id"`
+	ItemGuid         string         `json:"item_guid"`
+	LabelID          sql.NullString `json:"label_id"`
+	DisplayName      sql.NullString `json:"display_name"`
+	OwnerEmail       sql.NullString `json:"owner_email"`
+	SetDate          sql.NullTime   `json:"set_date"`
+	AssignmentMethod sql.NullString `json:"assignment_method"`
+	HasIrmProtection sql.NullBool   `json:"has_irm_protection"`
+	ContentBits      sql.NullInt64  `json:"content_bits"`
+	LabelFlags       sql.NullInt64  `json:"label_flags"`
+	DiscoveredAt     sql.NullTime   `json:"discovered_at"`
+	PromotionVersion sql.NullInt64  `json:"promotion_version"`
+	LabelHash        sql.NullString `json:"label_hash"`
+}
+
+func (q *Queries) GetSensitivityLabelsForList(ctx context.Context, arg GetSensitivityLabelsForListParams) ([]GetSensitivityLabelsForListRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSensitivityLabelsForList, arg.SiteID, arg.ListID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSensitivityLabelsForListRow
+	for rows.Next() {
+		var i GetSensitivityLabelsForListRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.ItemGuid,
+			&i.LabelID,
+			&i.DisplayName,
+			&i.OwnerEmail,
+			&i.SetDate,
+			&i.AssignmentMethod,
+			&i.HasIrmProtection,
+			&i.ContentBits,
+			&i.LabelFlags,
+			&i.DiscoveredAt,
+			&i.PromotionVersion,
+			&i.LabelHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getSensitivityLabelsForListByAuditRun = `-- name: GetSensitivityLabelsForListByAuditRun :many
+SELECT 
+  sl.site_id,
+  sl.item_guid,
+  sl.label_id,
+  sl.display_name,
+  sl.owner_email,
+  sl.set_date,
+  sl.assignment_method,
+  sl.has_irm_protection,
+  sl.content_bits,
+  sl.label_flags,
+  sl.discovered_at,
+  sl.promotion_version,
+  sl.label_hash
+FROM sensitivity_labels sl
+JOIN items i ON sl.site_id = i.site_id AND sl.item_guid = i.item_guid AND sl.audit_run_id = i.audit_run_id
+WHERE sl.site_id = ?1 AND i.list_id = ?2 AND sl.audit_run_id = ?3
+  AND sl.label_id IS NOT NULL
+ORDER BY sl.item_guid
+`
+
+type GetSensitivityLabelsForListByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	ListID     string `json:"list_id"`
+	AuditRunID int64  `json:"audit_run_id"`
+}
+
+type GetSensitivityLabelsForListByAuditRunRow struct {
+	SiteID           int64          `json:"site_id"`
+	ItemGuid         string         `json:"item_guid"`
+	LabelID          sql.NullString `json:"label_id"`
+	DisplayName      sql.NullString `json:"display_name"`
+	OwnerEmail       sql.NullString `json:"owner_email"`
+	SetDate          sql.NullTime   `json:"set_date"`
+	AssignmentMethod sql.NullString `json:"assignment_method"`
+	HasIrmProtection sql.NullBool   `json:"has_irm_protection"`
+	ContentBits      sql.NullInt64  `json:"content_bits"`
+	LabelFlags       sql.NullInt64  `json:"label_flags"`
+	DiscoveredAt     sql.NullTime   `json:"discovered_at"`
+	PromotionVersion sql.NullInt64  `json:"promotion_version"`
+	LabelHash        sql.NullString `json:"label_hash"`
+}
+
+func (q *Queries) GetSensitivityLabelsForListByAuditRun(ctx context.Context, arg GetSensitivityLabelsForListByAuditRunParams) ([]GetSensitivityLabelsForListByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSensitivityLabelsForListByAuditRun, arg.SiteID, arg.ListID, arg.AuditRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSensitivityLabelsForListByAuditRunRow
+	for rows.Next() {
+		var i GetSensitivityLabelsForListByAuditRunRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.ItemGuid,
+			&i.LabelID,
+			&i.DisplayName,
+			&i.OwnerEmail,
+			&i.SetDate,
+			&i.AssignmentMethod,
+			&i.HasIrmProtection,
+			&i.ContentBits,
+			&i.LabelFlags,
+			&i.DiscoveredAt,
+			&i.PromotionVersion,
+			&i.LabelHash,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getSensitivityLabelsForSite = `-- name: GetSensitivityLabelsForSite :many
 SELECT 
   site_id,
@@ -347,9 +954,14 @@ SELECT
   people_sharing_link_abilities,
   direct_sharing_abilities
 FROM sharing_abilities
-WHERE site_id = ?1
+WHERE site_id = ?1 AND audit_run_id = ?2
 `
 
+type GetSharingAbilitiesParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
 type GetSharingAbilitiesRow struct {
 	SiteID                     int64          `json:"site_id"`
 	CanStopSharing             sql.NullBool   `json:"can_stop_sharing"`
@@ -360,8 +972,8 @@ type GetSharingAbilitiesRow struct {
 	DirectSharingAbilities     sql.NullString `json:"direct_sharing_abilities"`
 }
 
-func (q *Queries) GetSharingAbilities(ctx context.Context, siteID int64) (GetSharingAbilitiesRow, error) {
-	row := q.db.QueryRowContext(ctx, getSharingAbilities, siteID)
+func (q *Queries) GetSharingAbilities(ctx context.Context, arg GetSharingAbilitiesParams) (GetSharingAbilitiesRow, error) {
+	row := q.db.QueryRowContext(ctx, getSharingAbilities, arg.SiteID, arg.AuditRunID)
 	var i GetSharingAbilitiesRow
 	err := row.Scan(
 		&i.SiteID,
@@ -378,6 +990,7 @@ func (q *Queries) GetSharingAbilities(ctx context.Context, siteID int64) (GetSha
 const getSharingGovernance = `-- name: GetSharingGovernance :one
 SELECT 
   site_id,
+  audit_run_id,
   tenant_id,
   tenant_display_name,
   sharepoint_site_id,
@@ -389,13 +1002,22 @@ SELECT
   can_request_access_for_grant_access,
   site_ib_mode,
   site_ib_segment_ids,
-  enforce_ib_segment_filtering
+  enforce_ib_segment_filtering,
+  default_link_kind,
+  default_share_link_permission,
+  default_share_link_scope
 FROM sharing_governance
-WHERE site_id = ?1
+WHERE site_id = ?1 AND audit_run_id = ?2
 `
 
+type GetSharingGovernanceParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
 type GetSharingGovernanceRow struct {
 	SiteID                                 int64          `json:"site_id"`
+	AuditRunID                             int64          `json:"audit_run_id"`
 	TenantID                               sql.NullString `json:"tenant_id"`
 	TenantDisplayName                      sql.NullString `json:"tenant_display_name"`
 	SharepointSiteID                       sql.NullString `json:"sharepoint_site_id"`
@@ -408,13 +1030,17 @@ type GetSharingGovernanceRow struct {
 	SiteIbMode                             sql.NullString `json:"site_ib_mode"`
 	SiteIbSegmentIds                       sql.NullString `json:"site_ib_segment_ids"`
 	EnforceIbSegmentFiltering              sql.NullBool   `json:"enforce_ib_segment_filtering"`
+	DefaultLinkKind                        sql.NullInt64  `json:"default_link_kind"`
+	DefaultShareLinkPermission             sql.NullInt64  `json:"default_share_link_permission"`
+	DefaultShareLinkScope                  sql.NullInt64  `json:"default_share_link_scope"`
 }
 
-func (q *Queries) GetSharingGovernance(ctx context.Context, siteID int64) (GetSharingGovernanceRow, error) {
-	row := q.db.QueryRowContext(ctx, getSharingGovernance, siteID)
+func (q *Queries) GetSharingGovernance(ctx context.Context, arg GetSharingGovernanceParams) (GetSharingGovernanceRow, error) {
+	row := q.db.QueryRowContext(ctx, getSharingGovernance, arg.SiteID, arg.AuditRunID)
 	var i GetSharingGovernanceRow
 	err := row.Scan(
 		&i.SiteID,
+		&i.AuditRunID,
 		&i.TenantID,
 		&i.TenantDisplayName,
 		&i.SharepointSiteID,
@@ -427,10 +1053,123 @@ func (q *Queries) GetSharingGovernance(ctx context.Context, siteID int64) (GetSh
 		&i.SiteIbMode,
 		&i.SiteIbSegmentIds,
 		&i.EnforceIbSegmentFiltering,
+		&i.DefaultLinkKind,
+		&i.DefaultShareLinkPermission,
+		&i.DefaultShareLinkScope,
+	)
+	return i, err
+}
+
+const getSharingLinkByAuditRun = `-- name: GetSharingLinkByAuditRun :one
+SELECT
+  sl.link_id,
+  sl.item_guid,
+  sl.file_folder_unique_id,
+  sl.url,
+  sl.link_kind,
+  sl.scope,
+  sl.is_active,
+  sl.is_default,
+  sl.is_edit_link,
+  sl.is_review_link,
+  sl.created_at,
+  (SELECT COUNT(*) FROM sharing_link_members slm WHERE slm.site_id = sl.site_id AND slm.link_id = sl.link_id AND slm.audit_run_id = sl.audit_run_id) as actual_members_count,
+  i.name as item_name,
+  i.is_file,
+  i.is_folder
+FROM sharing_links sl
+LEFT JOIN items i ON (sl.site_id = i.site_id AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid) AND i.audit_run_id = sl.audit_run_id)
+WHERE sl.site_id = ?1 AND sl.audit_run_id = ?2
+  AND lower(sl.link_id) = lower(?3)
+LIMIT 1
+`
+
+type GetSharingLinkByAuditRunParams struct {
+	SiteID     int64  `json:"site_id"`
+	AuditRunID int64  `json:"audit_run_id"`
+	LinkID     string `json:"link_id"`
+}
+
+type GetSharingLinkByAuditRunRow struct {
+	LinkID             string         `json:"link_id"`
+	ItemGuid           sql.NullString `json:"item_guid"`
+	FileFolderUniqueID sql.NullString `json:"file_folder_unique_id"`
+	Url                sql.NullString `json:"url"`
+	LinkKind           sql.NullInt64  `json:"link_kind"`
+	Scope              sql.NullInt64  `json:"scope"`
+	IsActive           sql.NullBool   `json:"is_active"`
+	IsDefault          sql.NullBool   `json:"is_default"`
+	IsEditLink         sql.NullBool   `json:"is_edit_link"`
+	IsReviewLink       sql.NullBool   `json:"is_review_link"`
+	CreatedAt          sql.NullTime   `json:"created_at"`
+	ActualMembersCount int64          `json:"actual_members_count"`
+	ItemName           sql.NullString `json:"item_name"`
+	IsFile             sql.NullBool   `json:"is_file"`
+	IsFolder           sql.NullBool   `json:"is_folder"`
+}
+
+// A single sharing link with the item it is on. Link IDs come from sharing link
+// group login names, whose GUID casing can differ from the sharing API's.
+func (q *Queries) GetSharingLinkByAuditRun(ctx context.Context, arg GetSharingLinkByAuditRunParams) (GetSharingLinkByAuditRunRow, error) {
+	row := q.db.QueryRowContext(ctx, getSharingLinkByAuditRun, arg.SiteID, arg.AuditRunID, arg.LinkID)
+	var i GetSharingLinkByAuditRunRow
+	err := row.Scan(
+		&i.LinkID,
+		&i.ItemGuid,
+		&i.FileFolderUniqueID,
+		&i.Url,
+		&i.LinkKind,
+		&i.Scope,
+		&i.IsActive,
+		&i.IsDefault,
+		&i.IsEditLink,
+		&i.IsReviewLink,
+		&i.CreatedAt,
+		&i.ActualMembersCount,
+		&i.ItemName,
+		&i.IsFile,
+		&i.IsFolder,
 	)
 	return i, err
 }
 
+const getSharingLinkIDsForMemberByAuditRun = `-- name: GetSharingLinkIDsForMemberByAuditRun :many
+SELECT link_id
+FROM sharing_link_members
+WHERE site_id = ?1 AND audit_run_id = ?2 AND principal_id = ?3
+ORDER BY link_id
+`
+
+type GetSharingLinkIDsForMemberByAuditRunParams struct {
+	SiteID      int64 `json:"site_id"`
+	AuditRunID  int64 `json:"audit_run_id"`
+	PrincipalID int64 `json:"principal_id"`
+}
+
+// Sharing links a principal is a member of, as captured by an audit run
+func (q *Queries) GetSharingLinkIDsForMemberByAuditRun(ctx context.Context, arg GetSharingLinkIDsForMemberByAuditRunParams) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, getSharingLinkIDsForMemberByAuditRun, arg.SiteID, arg.AuditRunID, arg.PrincipalID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var link_id string
+		if err := rows.Scan(&link_id); err != nil {
+			return nil, err
+		}
+		items = append(items, link_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getSharingLinkMembers = `-- name: GetSharingLinkMembers :many
 SELECT 
   p.site_id,
@@ -687,14 +1426,17 @@ SELECT
   cb.title as created_by_title,
   cb.login_name as created_by_login,
   mb.title as modified_by_title,
-  mb.login_name as modified_by_login
+  mb.login_name as modified_by_login,
+  ia.view_count as item_view_count,
+  ia.viewer_count as item_viewer_count
 FROM sharing_links sl
 LEFT JOIN items i ON (sl.site_id = i.site_id AND (sl.item_guid = i.item_guid OR sl.file_folder_unique_id = i.item_guid) AND i.audit_run_id = sl.audit_run_id)
 LEFT JOIN principals cb ON sl.site_id = cb.site_id AND sl.created_by_principal_id = cb.principal_id AND cb.audit_run_id = sl.audit_run_id
 LEFT JOIN principals mb ON sl.site_id = mb.site_id AND sl.last_modified_by_principal_id = mb.principal_id AND mb.audit_run_id = sl.audit_run_id
+LEFT JOIN item_analytics ia ON ia.site_id = sl.site_id AND ia.item_guid = sl.file_folder_unique_id AND ia.audit_run_id = sl.audit_run_id
 WHERE sl.site_id = ?1 AND i.list_id = ?2
   AND sl.is_active = 1 AND sl.audit_run_id = ?3
-ORDER BY sl.created_at DESC, sl.link_id
+ORDER BY COALESCE(ia.view_count, -1) DESC, sl.created_at DESC, sl.link_id
 `
 
 type GetSharingLinksForListByAuditRunParams struct {
@@ -727,9 +1469,12 @@ type GetSharingLinksForListByAuditRunRow struct {
 	CreatedByLogin     sql.NullString `json:"created_by_login"`
 	ModifiedByTitle    sql.NullString `json:"modified_by_title"`
 	ModifiedByLogin    sql.NullString `json:"modified_by_login"`
+	ItemViewCount      sql.NullInt64  `json:"item_view_count"`
+	ItemViewerCount    sql.NullInt64  `json:"item_viewer_count"`
 }
 
 // Get all sharing links for items in a specific list filtered by audit run
+// Most-accessed items first when analytics were collected
 func (q *Queries) GetSharingLinksForListByAuditRun(ctx context.Context, arg GetSharingLinksForListByAuditRunParams) ([]GetSharingLinksForListByAuditRunRow, error) {
 	rows, err := q.db.QueryContext(ctx, getSharingLinksForListByAuditRun, arg.SiteID, arg.ListID, arg.AuditRunID)
 	if err != nil {
@@ -763,6 +1508,8 @@ func (q *Queries) GetSharingLinksForListByAuditRun(ctx context.Context, arg GetS
 			&i.CreatedByLogin,
 			&i.ModifiedByTitle,
 			&i.ModifiedByLogin,
+			&i.ItemViewCount,
+			&i.ItemViewerCount,
 		); err != nil {
 			return nil, err
 		}
@@ -1219,7 +1966,10 @@ INSERT INTO sharing_governance (
   can_request_access_for_grant_access,
   site_ib_mode,
   site_ib_segment_ids,
-  enforce_ib_segment_filtering
+  enforce_ib_segment_filtering,
+  default_link_kind,
+  default_share_link_permission,
+  default_share_link_scope
 ) VALUES (
   ?1,
   ?2,
@@ -1234,7 +1984,10 @@ INSERT INTO sharing_governance (
   ?11,
   ?12,
   ?13,
-  ?14
+  ?14,
+  ?15,
+  ?16,
+  ?17
 )
 ON CONFLICT(site_id, audit_run_id) DO UPDATE SET
   tenant_id                                  = excluded.tenant_id,
@@ -1249,6 +2002,9 @@ ON CONFLICT(site_id, audit_run_id) DO UPDATE SET
   site_ib_mode                               = excluded.site_ib_mode,
   site_ib_segment_ids                        = excluded.site_ib_segment_ids,
   enforce_ib_segment_filtering               = excluded.enforce_ib_segment_filtering,
+  default_link_kind                          = excluded.default_link_kind,
+  default_share_link_permission              = excluded.default_share_link_permission,
+  default_share_link_scope                   = excluded.default_share_link_scope,
   updated_at                                 = CURRENT_TIMESTAMP
 `
 
@@ -1267,6 +2023,9 @@ type UpsertSharingGovernanceParams struct {
 	SiteIbMode                             sql.NullString `json:"site_ib_mode"`
 	SiteIbSegmentIds                       sql.NullString `json:"site_ib_segment_ids"`
 	EnforceIbSegmentFiltering              sql.NullBool   `json:"enforce_ib_segment_filtering"`
+	DefaultLinkKind                        sql.NullInt64  `json:"default_link_kind"`
+	DefaultShareLinkPermission             sql.NullInt64  `json:"default_share_link_permission"`
+	DefaultShareLinkScope                  sql.NullInt64  `json:"default_share_link_scope"`
 }
 
 // ==================================
@@ -1288,6 +2047,9 @@ func (q *Queries) UpsertSharingGovernance(ctx context.Context, arg UpsertSharing
 		arg.SiteIbMode,
 		arg.SiteIbSegmentIds,
 		arg.EnforceIbSegmentFiltering,
+		arg.DefaultLinkKind,
+		arg.DefaultShareLinkPermission,
+		arg.DefaultShareLinkScope,
 	)
 	return err
 }
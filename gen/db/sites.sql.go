@@ -16,9 +16,17 @@ FROM sites
 WHERE site_id = ?1
 `
 
-func (q *Queries) GetSiteByID(ctx context.Context, siteID int64) (Site, error) {
+type GetSiteByIDRow struct {
+	SiteID    int64          `json:"site_id"`
+	SiteUrl   string         `json:"site_url"`
+	Title     sql.NullString `json:"title"`
+	CreatedAt sql.NullTime   `json:"created_at"`
+	UpdatedAt sql.NullTime   `json:"updated_at"`
+}
+
+func (q *Queries) GetSiteByID(ctx context.Context, siteID int64) (GetSiteByIDRow, error) {
 	row := q.db.QueryRowContext(ctx, getSiteByID, siteID)
-	var i Site
+	var i GetSiteByIDRow
 	err := row.Scan(
 		&i.SiteID,
 		&i.SiteUrl,
@@ -35,9 +43,17 @@ FROM sites
 WHERE site_url = ?1
 `
 
-func (q *Queries) GetSiteByURL(ctx context.Context, siteUrl string) (Site, error) {
+type GetSiteByURLRow struct {
+	SiteID    int64          `json:"site_id"`
+	SiteUrl   string         `json:"site_url"`
+	Title     sql.NullString `json:"title"`
+	CreatedAt sql.NullTime   `json:"created_at"`
+	UpdatedAt sql.NullTime   `json:"updated_at"`
+}
+
+func (q *Queries) GetSiteByURL(ctx context.Context, siteUrl string) (GetSiteByURLRow, error) {
 	row := q.db.QueryRowContext(ctx, getSiteByURL, siteUrl)
-	var i Site
+	var i GetSiteByURLRow
 	err := row.Scan(
 		&i.SiteID,
 		&i.SiteUrl,
@@ -48,27 +64,158 @@ func (q *Queries) GetSiteByURL(ctx context.Context, siteUrl string) (Site, error
 	return i, err
 }
 
+const getSitesForAuditRun = `-- name: GetSitesForAuditRun :many
+SELECT s.site_id, s.site_url, s.title, s.created_at, s.updated_at
+FROM sites s
+JOIN audit_runs ar ON ar.site_id = s.site_id
+WHERE ar.audit_run_id = ?1
+ORDER BY s.site_id
+`
+
+type GetSitesForAuditRunRow struct {
+	SiteID    int64          `json:"site_id"`
+	SiteUrl   string         `json:"site_url"`
+	Title     sql.NullString `json:"title"`
+	CreatedAt sql.NullTime   `json:"created_at"`
+	UpdatedAt sql.NullTime   `json:"updated_at"`
+}
+
+// Sites are not versioned per run; this is the site the run audited
+func (q *Queries) GetSitesForAuditRun(ctx context.Context, auditRunID int64) ([]GetSitesForAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getSitesForAuditRun, auditRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSitesForAuditRunRow
+	for rows.Next() {
+		var i GetSitesForAuditRunRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.SiteUrl,
+			&i.Title,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listSites = `-- name: ListSites :many
 SELECT site_id, site_url, title, created_at, updated_at
 FROM sites
 ORDER BY title
 `
 
-func (q *Queries) ListSites(ctx context.Context) ([]Site, error) {
+type ListSitesRow struct {
+	SiteID    int64          `json:"site_id"`
+	SiteUrl   string         `json:"site_url"`
+	Title     sql.NullString `json:"title"`
+	CreatedAt sql.NullTime   `json:"created_at"`
+	UpdatedAt sql.NullTime   `json:"updated_at"`
+}
+
+func (q *Queries) ListSites(ctx context.Context) ([]ListSitesRow, error) {
 	rows, err := q.db.QueryContext(ctx, listSites)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Site
+	var items []ListSitesRow
+	for rows.Next() {
+		var i ListSitesRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.SiteUrl,
+			&i.Title,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSitesWithLatestAuditRunMetadata = `-- name: ListSitesWithLatestAuditRunMetadata :many
+WITH ranked_runs AS (
+  SELECT
+    audit_run_id, site_id, started_at,
+    ROW_NUMBER() OVER (PARTITION BY site_id ORDER BY scope_path IS NULL DESC, started_at DESC) AS latest_rank,
+    ROW_NUMBER() OVER (PARTITION BY site_id ORDER BY completed_at IS NOT NULL DESC, scope_path IS NULL DESC, started_at DESC) AS completed_rank
+  FROM audit_runs
+  WHERE CAST(?2 AS TEXT) = '' OR label = ?2
+)
+SELECT
+  s.site_id, s.site_url, s.title, s.created_at, s.updated_at,
+  ar.audit_run_id, ar.started_at AS audit_started_at,
+  CAST(COUNT(l.list_id) AS INTEGER) AS total_lists,
+  CAST(COALESCE(SUM(CASE WHEN l.has_unique THEN 1 ELSE 0 END), 0) AS INTEGER) AS lists_with_unique
+FROM sites s
+LEFT JOIN ranked_runs ar ON ar.site_id = s.site_id
+  AND (CASE WHEN CAST(?1 AS INTEGER) THEN ar.completed_rank ELSE ar.latest_rank END) = 1
+LEFT JOIN lists l ON l.site_id = s.site_id AND l.audit_run_id = ar.audit_run_id
+WHERE CAST(?2 AS TEXT) = '' OR ar.audit_run_id IS NOT NULL
+GROUP BY s.site_id
+ORDER BY s.title
+`
+
+type ListSitesWithLatestAuditRunMetadataParams struct {
+	PreferCompleted int64  `json:"prefer_completed"`
+	Label           string `json:"label"`
+}
+
+type ListSitesWithLatestAuditRunMetadataRow struct {
+	SiteID          int64          `json:"site_id"`
+	SiteUrl         string         `json:"site_url"`
+	Title           sql.NullString `json:"title"`
+	CreatedAt       sql.NullTime   `json:"created_at"`
+	UpdatedAt       sql.NullTime   `json:"updated_at"`
+	AuditRunID      sql.NullInt64  `json:"audit_run_id"`
+	AuditStartedAt  sql.NullTime   `json:"audit_started_at"`
+	TotalLists      int64          `json:"total_lists"`
+	ListsWithUnique int64          `json:"lists_with_unique"`
+}
+
+// One row per site with list statistics for its latest audit run. With prefer_completed set,
+// the latest completed run is used when one exists; sites without runs have no run columns.
+// Full site runs rank ahead of folder-scoped ones, as for GetLatestAuditRunForSite.
+// A label ranks only the runs tagged with it and leaves out sites with none.
+func (q *Queries) ListSitesWithLatestAuditRunMetadata(ctx context.Context, arg ListSitesWithLatestAuditRunMetadataParams) ([]ListSitesWithLatestAuditRunMetadataRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSitesWithLatestAuditRunMetadata, arg.PreferCompleted, arg.Label)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSitesWithLatestAuditRunMetadataRow
 	for rows.Next() {
-		var i Site
+		var i ListSitesWithLatestAuditRunMetadataRow
 		if err := rows.Scan(
 			&i.SiteID,
 			&i.SiteUrl,
 			&i.Title,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.AuditRunID,
+			&i.AuditStartedAt,
+			&i.TotalLists,
+			&i.ListsWithUnique,
 		); err != nil {
 			return nil, err
 		}
@@ -83,6 +230,33 @@ func (q *Queries) ListSites(ctx context.Context) ([]Site, error) {
 	return items, nil
 }
 
+const pinAuditRunForSite = `-- name: PinAuditRunForSite :exec
+UPDATE sites
+SET pinned_audit_run_id = ?1, pinned_at = CURRENT_TIMESTAMP
+WHERE site_id = ?2
+`
+
+type PinAuditRunForSiteParams struct {
+	AuditRunID sql.NullInt64 `json:"audit_run_id"`
+	SiteID     int64         `json:"site_id"`
+}
+
+func (q *Queries) PinAuditRunForSite(ctx context.Context, arg PinAuditRunForSiteParams) error {
+	_, err := q.db.ExecContext(ctx, pinAuditRunForSite, arg.AuditRunID, arg.SiteID)
+	return err
+}
+
+const unpinAuditRunForSite = `-- name: UnpinAuditRunForSite :exec
+UPDATE sites
+SET pinned_audit_run_id = NULL, pinned_at = NULL
+WHERE site_id = ?1
+`
+
+func (q *Queries) UnpinAuditRunForSite(ctx context.Context, siteID int64) error {
+	_, err := q.db.ExecContext(ctx, unpinAuditRunForSite, siteID)
+	return err
+}
+
 const upsertSite = `-- name: UpsertSite :one
 INSERT INTO sites (site_url, title, updated_at)
 VALUES (?1, ?2, CURRENT_TIMESTAMP)
@@ -46,19 +46,88 @@ func (q *Queries) GetWeb(ctx context.Context, arg GetWebParams) (GetWebRow, erro
 	return i, err
 }
 
+const getWebsForAuditRun = `-- name: GetWebsForAuditRun :many
+SELECT site_id, web_id, url, title, template, has_unique, members_can_share, request_access_email, use_access_request_default, audit_run_id
+FROM webs
+WHERE audit_run_id = ?1
+ORDER BY site_id, web_id
+LIMIT ?3 OFFSET ?2
+`
+
+type GetWebsForAuditRunParams struct {
+	AuditRunID int64 `json:"audit_run_id"`
+	Offset     int64 `json:"offset"`
+	Limit      int64 `json:"limit"`
+}
+
+type GetWebsForAuditRunRow struct {
+	SiteID                  int64          `json:"site_id"`
+	WebID                   string         `json:"web_id"`
+	Url                     sql.NullString `json:"url"`
+	Title                   sql.NullString `json:"title"`
+	Template                sql.NullString `json:"template"`
+	HasUnique               sql.NullBool   `json:"has_unique"`
+	MembersCanShare         sql.NullBool   `json:"members_can_share"`
+	RequestAccessEmail      sql.NullString `json:"request_access_email"`
+	UseAccessRequestDefault sql.NullBool   `json:"use_access_request_default"`
+	AuditRunID              int64          `json:"audit_run_id"`
+}
+
+func (q *Queries) GetWebsForAuditRun(ctx context.Context, arg GetWebsForAuditRunParams) ([]GetWebsForAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, getWebsForAuditRun, arg.AuditRunID, arg.Offset, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWebsForAuditRunRow
+	for rows.Next() {
+		var i GetWebsForAuditRunRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.WebID,
+			&i.Url,
+			&i.Title,
+			&i.Template,
+			&i.HasUnique,
+			&i.MembersCanShare,
+			&i.RequestAccessEmail,
+			&i.UseAccessRequestDefault,
+			&i.AuditRunID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const insertWeb = `-- name: InsertWeb :exec
-INSERT INTO webs (site_id, web_id, url, title, template, has_unique, audit_run_id)
-VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+INSERT INTO webs (site_id, web_id, url, title, template, has_unique, members_can_share, request_access_email, use_access_request_default, audit_run_id)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7,
+        ?8, ?9, ?10)
+ON CONFLICT (site_id, web_id, audit_run_id) DO UPDATE SET
+  url = excluded.url, title = excluded.title, template = excluded.template, has_unique = excluded.has_unique,
+  members_can_share = excluded.members_can_share, request_access_email = excluded.request_access_email,
+  use_access_request_default = excluded.use_access_request_default
 `
 
 type InsertWebParams struct {
-	SiteID     int64          `json:"site_id"`
-	WebID      string         `json:"web_id"`
-	Url        sql.NullString `json:"url"`
-	Title      sql.NullString `json:"title"`
-	Template   sql.NullString `json:"template"`
-	HasUnique  sql.NullBool   `json:"has_unique"`
-	AuditRunID int64          `json:"audit_run_id"`
+	SiteID                  int64          `json:"site_id"`
+	WebID                   string         `json:"web_id"`
+	Url                     sql.NullString `json:"url"`
+	Title                   sql.NullString `json:"title"`
+	Template                sql.NullString `json:"template"`
+	HasUnique               sql.NullBool   `json:"has_unique"`
+	MembersCanShare         sql.NullBool   `json:"members_can_share"`
+	RequestAccessEmail      sql.NullString `json:"request_access_email"`
+	UseAccessRequestDefault sql.NullBool   `json:"use_access_request_default"`
+	AuditRunID              int64          `json:"audit_run_id"`
 }
 
 func (q *Queries) InsertWeb(ctx context.Context, arg InsertWebParams) error {
@@ -69,6 +138,9 @@ func (q *Queries) InsertWeb(ctx context.Context, arg InsertWebParams) error {
 		arg.Title,
 		arg.Template,
 		arg.HasUnique,
+		arg.MembersCanShare,
+		arg.RequestAccessEmail,
+		arg.UseAccessRequestDefault,
 		arg.AuditRunID,
 	)
 	return err
@@ -171,3 +243,62 @@ func (q *Queries) ListWebsForSite(ctx context.Context, siteID int64) ([]ListWebs
 	}
 	return items, nil
 }
+
+const listWebsForSiteByAuditRun = `-- name: ListWebsForSiteByAuditRun :many
+SELECT site_id, web_id, url, title, template, has_unique, members_can_share, request_access_email, use_access_request_default, audit_run_id
+FROM webs
+WHERE site_id = ?1 AND audit_run_id = ?2
+ORDER BY url, web_id
+`
+
+type ListWebsForSiteByAuditRunParams struct {
+	SiteID     int64 `json:"site_id"`
+	AuditRunID int64 `json:"audit_run_id"`
+}
+
+type ListWebsForSiteByAuditRunRow struct {
+	SiteID                  int64          `json:"site_id"`
+	WebID                   string         `json:"web_id"`
+	Url                     sql.NullString `json:"url"`
+	Title                   sql.NullString `json:"title"`
+	Template                sql.NullString `json:"template"`
+	HasUnique               sql.NullBool   `json:"has_unique"`
+	MembersCanShare         sql.NullBool   `json:"members_can_share"`
+	RequestAccessEmail      sql.NullString `json:"request_access_email"`
+	UseAccessRequestDefault sql.NullBool   `json:"use_access_request_default"`
+	AuditRunID              int64          `json:"audit_run_id"`
+}
+
+func (q *Queries) ListWebsForSiteByAuditRun(ctx context.Context, arg ListWebsForSiteByAuditRunParams) ([]ListWebsForSiteByAuditRunRow, error) {
+	rows, err := q.db.QueryContext(ctx, listWebsForSiteByAuditRun, arg.SiteID, arg.AuditRunID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListWebsForSiteByAuditRunRow
+	for rows.Next() {
+		var i ListWebsForSiteByAuditRunRow
+		if err := rows.Scan(
+			&i.SiteID,
+			&i.WebID,
+			&i.Url,
+			&i.Title,
+			&i.Template,
+			&i.HasUnique,
+			&i.MembersCanShare,
+			&i.RequestAccessEmail,
+			&i.UseAccessRequestDefault,
+			&i.AuditRunID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
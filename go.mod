@@ -7,6 +7,7 @@ require (
 	github.com/go-chi/chi/v5 v5.2.2
 	github.com/go-chi/httplog/v2 v2.1.1
 	github.com/joho/godotenv v1.5.1
+	github.com/klauspost/compress v1.18.0
 	github.com/koltyakov/gosip v0.0.0-20250809193426-13b579cbf9c5
 	github.com/stretchr/testify v1.10.0
 	modernc.org/sqlite v1.38.2
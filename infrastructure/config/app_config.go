@@ -7,6 +7,10 @@ import (
 	"time"
 
 	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/events"
+	"spaudit/domain/sharepoint"
+	"spaudit/infrastructure/notify"
 	"spaudit/logging"
 )
 
@@ -17,6 +21,99 @@ type AppConfig struct {
 	HTTPLogPath string
 	Database    *database.Config
 	Logging     *logging.Config
+
+	// UniqueDensityThreshold flags lists whose unique items / total items exceeds this ratio.
+	UniqueDensityThreshold float64
+
+	// MaxAnalysisRows caps the rows a list snapshot or comparison loads; 0 lifts the cap.
+	MaxAnalysisRows int
+
+	// LatestRunPolicy controls whether "latest" resolves to the latest completed run or the latest run of any status.
+	LatestRunPolicy audit.LatestRunPolicy
+
+	// ArtifactRetention is how long reports generated from a run stay downloadable.
+	ArtifactRetention time.Duration
+
+	// PostAuditReports selects the reports generated when a run completes, per site URL or URL prefix.
+	// See audit.ParseReportRules for the format.
+	PostAuditReports string
+
+	// FindingSeverities remaps the severity of finding categories, e.g. to treat edit links as critical.
+	// See audit.ParseSeverityMapping for the format.
+	FindingSeverities string
+
+	// AccessPolicies adds policies every audit run is checked against to the default ones, or replaces
+	// them by name. See audit.ParseAccessPolicies for the format.
+	AccessPolicies string
+
+	// AlertQuietHours holds finding alerts back during a time of day, "HH:MM-HH:MM" local time,
+	// delivering them once it ends. See audit.ParseQuietHours for the format.
+	AlertQuietHours string
+
+	// PayloadSamples limits the sanitized raw API payloads kept per run for debugging parsing issues.
+	PayloadSamples audit.PayloadSampleLimits
+
+	// PayloadSamplesDir stores payload samples as files under this directory instead of the database.
+	PayloadSamplesDir string
+
+	// BlobCompression compresses stored run artifacts and payload samples, "zstd" or "none".
+	// See compress.ParseEncoding for the format.
+	BlobCompression string
+
+	// StorageQuota is the soft limit on database disk usage, and whether new audits pause over it.
+	StorageQuota audit.StorageQuota
+
+	// MaintenanceInterval is how often database maintenance runs on its own; 0 runs it only on request.
+	MaintenanceInterval time.Duration
+
+	// MaintenanceWindow limits scheduled maintenance to a time of day, "HH:MM-HH:MM" local time.
+	// See audit.ParseMaintenanceSchedule for the format.
+	MaintenanceWindow string
+
+	// TenantAuditConcurrency is how many site audits a tenant audit runs at once.
+	TenantAuditConcurrency int
+
+	// WebhookPublicURL is the base URL SharePoint reaches the app at from the internet, e.g.
+	// https://spaudit.contoso.com, to post list change notifications; empty allows no list subscriptions.
+	WebhookPublicURL string
+
+	// ListMonitorInterval is how often monitored lists are checked for changes unless a monitor sets
+	// its own interval.
+	ListMonitorInterval time.Duration
+
+	// BadgeSigningKey signs the URLs of the site risk badges other portals embed; empty disables badges.
+	BadgeSigningKey string
+
+	// EventDelivery controls how often durable event handlers are retried before an event is moved
+	// to the dead-letter list.
+	EventDelivery events.RetryPolicy
+
+	// NotificationWebhookURLs lists the endpoints job completions, failures and finding alerts are
+	// posted to, separated by commas; empty posts nothing. See events.ParseWebhookURLs for the format.
+	NotificationWebhookURLs string
+
+	// NotificationWebhookSecret signs webhook deliveries so endpoints can verify them; empty sends them unsigned.
+	NotificationWebhookSecret string
+
+	// NotificationWebhookMinSeverity is the least severe finding alert posted to the webhook endpoints.
+	NotificationWebhookMinSeverity string
+
+	// NotificationWebhookDelivery controls how often a webhook delivery is attempted before it fails.
+	NotificationWebhookDelivery events.RetryPolicy
+
+	// NotificationEmail is the mail server audit run summaries are emailed through; no host sends none.
+	NotificationEmail notify.SMTPConfig
+
+	// NotificationEmailRecipients selects who is emailed about each site's audit runs.
+	// See notify.ParseRecipientRules for the format.
+	NotificationEmailRecipients string
+
+	// NotificationChatWebhooks lists the Slack and Teams incoming webhooks audit run summaries and finding
+	// alerts are posted to as cards; empty posts nothing. See notify.ParseChatWebhooks for the format.
+	NotificationChatWebhooks string
+
+	// NotificationChatMinSeverity is the least severe finding alert posted to the chat webhooks.
+	NotificationChatMinSeverity string
 }
 
 // LoadAppConfigFromEnv loads complete application configuration from environment variables.
@@ -26,6 +123,60 @@ func LoadAppConfigFromEnv() *AppConfig {
 		HTTPLogPath: getEnvWithDefault("HTTP_LOG_PATH", ""),
 		Database:    LoadDatabaseConfigFromEnv(),
 		Logging:     LoadLoggingConfigFromEnv(),
+
+		UniqueDensityThreshold: getEnvFloatWithDefault("UNIQUE_DENSITY_THRESHOLD", sharepoint.DefaultUniqueDensityThreshold),
+		MaxAnalysisRows:        getEnvIntWithDefault("MAX_ANALYSIS_ROWS", sharepoint.DefaultMaxAnalysisRows),
+		LatestRunPolicy:        audit.ParseLatestRunPolicy(getEnvWithDefault("LATEST_RUN_POLICY", string(audit.LatestRunAnyStatus))),
+		ArtifactRetention:      getEnvDurationWithDefault("ARTIFACT_RETENTION", audit.DefaultArtifactRetention),
+		PostAuditReports:       getEnvWithDefault("POST_AUDIT_REPORTS", ""),
+		FindingSeverities:      getEnvWithDefault("FINDING_SEVERITIES", ""),
+		AccessPolicies:         getEnvWithDefault("ACCESS_POLICIES", ""),
+		AlertQuietHours:        getEnvWithDefault("ALERT_QUIET_HOURS", ""),
+		PayloadSamples:         LoadPayloadSampleLimitsFromEnv(),
+		PayloadSamplesDir:      getEnvWithDefault("PAYLOAD_SAMPLES_DIR", ""),
+		BlobCompression:        getEnvWithDefault("BLOB_COMPRESSION", "zstd"),
+		StorageQuota: audit.StorageQuota{
+			SoftLimitBytes: int64(getEnvIntWithDefault("STORAGE_SOFT_LIMIT_BYTES", 0)),
+			PauseAudits:    getEnvBoolWithDefault("STORAGE_PAUSE_AUDITS_OVER_LIMIT", false),
+		},
+		MaintenanceInterval:    getEnvDurationWithDefault("MAINTENANCE_INTERVAL", 0),
+		MaintenanceWindow:      getEnvWithDefault("MAINTENANCE_WINDOW", ""),
+		TenantAuditConcurrency: getEnvIntWithDefault("TENANT_AUDIT_CONCURRENCY", audit.DefaultTenantAuditConcurrency),
+		WebhookPublicURL:       getEnvWithDefault("WEBHOOK_PUBLIC_URL", ""),
+		ListMonitorInterval:    getEnvDurationWithDefault("LIST_MONITOR_INTERVAL", audit.DefaultListMonitorInterval),
+		BadgeSigningKey:        getEnvWithDefault("BADGE_SIGNING_KEY", ""),
+		EventDelivery: events.RetryPolicy{
+			MaxAttempts:    getEnvIntWithDefault("EVENT_HANDLER_MAX_ATTEMPTS", events.DefaultDeliveryAttempts),
+			InitialBackoff: getEnvDurationWithDefault("EVENT_HANDLER_RETRY_BACKOFF", events.DefaultDeliveryBackoff),
+		},
+		NotificationWebhookURLs:        getEnvWithDefault("NOTIFICATION_WEBHOOK_URLS", ""),
+		NotificationWebhookSecret:      getEnvWithDefault("NOTIFICATION_WEBHOOK_SECRET", ""),
+		NotificationWebhookMinSeverity: getEnvWithDefault("NOTIFICATION_WEBHOOK_MIN_SEVERITY", string(audit.SeverityHigh)),
+		NotificationWebhookDelivery: events.RetryPolicy{
+			MaxAttempts:    getEnvIntWithDefault("NOTIFICATION_WEBHOOK_MAX_ATTEMPTS", events.DefaultWebhookAttempts),
+			InitialBackoff: getEnvDurationWithDefault("NOTIFICATION_WEBHOOK_RETRY_BACKOFF", events.DefaultWebhookBackoff),
+		},
+		NotificationEmail: notify.SMTPConfig{
+			Host:     getEnvWithDefault("NOTIFICATION_EMAIL_SMTP_HOST", ""),
+			Port:     getEnvIntWithDefault("NOTIFICATION_EMAIL_SMTP_PORT", notify.DefaultSMTPPort),
+			Username: getEnvWithDefault("NOTIFICATION_EMAIL_SMTP_USERNAME", ""),
+			Password: getEnvWithDefault("NOTIFICATION_EMAIL_SMTP_PASSWORD", ""),
+			From:     getEnvWithDefault("NOTIFICATION_EMAIL_FROM", ""),
+		},
+		NotificationEmailRecipients: getEnvWithDefault("NOTIFICATION_EMAIL_RECIPIENTS", ""),
+		NotificationChatWebhooks:    getEnvWithDefault("NOTIFICATION_CHAT_WEBHOOKS", ""),
+		NotificationChatMinSeverity: getEnvWithDefault("NOTIFICATION_CHAT_MIN_SEVERITY", string(audit.SeverityHigh)),
+	}
+}
+
+// LoadPayloadSampleLimitsFromEnv loads raw API payload sampling limits from environment variables.
+func LoadPayloadSampleLimitsFromEnv() audit.PayloadSampleLimits {
+	defaults := audit.DefaultPayloadSampleLimits()
+	return audit.PayloadSampleLimits{
+		Enabled:         getEnvBoolWithDefault("PAYLOAD_SAMPLES_ENABLED", false),
+		PerSource:       getEnvIntWithDefault("PAYLOAD_SAMPLES_PER_SOURCE", defaults.PerSource),
+		MaxPayloadBytes: getEnvIntWithDefault("PAYLOAD_SAMPLES_MAX_PAYLOAD_BYTES", defaults.MaxPayloadBytes),
+		MaxRunBytes:     int64(getEnvIntWithDefault("PAYLOAD_SAMPLES_MAX_RUN_BYTES", int(defaults.MaxRunBytes))),
 	}
 }
 
@@ -41,15 +192,18 @@ func LoadDatabaseConfigFromEnv() *database.Config {
 		EnableForeignKeys: getEnvBoolWithDefault("DB_ENABLE_FOREIGN_KEYS", true),
 		EnableWAL:         getEnvBoolWithDefault("DB_ENABLE_WAL", true),
 		StrictMode:        getEnvBoolWithDefault("DB_STRICT_MODE", true),
+
+		SlowQueryThreshold: getEnvDurationWithDefault("DB_SLOW_QUERY_THRESHOLD", 0),
 	}
 }
 
 // LoadLoggingConfigFromEnv loads logging configuration from environment variables.
 func LoadLoggingConfigFromEnv() *logging.Config {
 	return &logging.Config{
-		Level:  getEnvWithDefault("LOG_LEVEL", "info"),
-		Format: getEnvWithDefault("LOG_FORMAT", "json"),
-		Output: getEnvWithDefault("LOG_OUTPUT", "stdout"),
+		Level:     getEnvWithDefault("LOG_LEVEL", "info"),
+		Format:    getEnvWithDefault("LOG_FORMAT", "json"),
+		Output:    getEnvWithDefault("LOG_OUTPUT", "stdout"),
+		TailLines: getEnvIntWithDefault("LOG_TAIL_LINES", 1000),
 	}
 }
 
@@ -82,6 +236,15 @@ func getEnvIntWithDefault(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvFloatWithDefault(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
+
 func getEnvBoolWithDefault(key string, defaultValue bool) bool {
 	if value := os.Getenv(key); value != "" {
 		return parseBool(value, defaultValue)
@@ -0,0 +1,60 @@
+package graphclient
+
+// ---------- Microsoft Graph drive item structures ----------
+
+// DrivePermissionsApiResponse is a page of a drive item's permissions: its sharing links and direct grants
+type DrivePermissionsApiResponse struct {
+	Value    []DrivePermissionApiData `json:"value"`
+	NextLink string                   `json:"@odata.nextLink"`
+}
+
+// DrivePermissionApiData represents one permission on a drive item. Sharing links carry Link, and
+// the people a link was shared with in GrantedToIdentitiesV2; direct grants carry GrantedToV2.
+type DrivePermissionApiData struct {
+	ID                    string                `json:"id"`
+	Roles                 []string              `json:"roles"`
+	Link                  *SharingLinkApiData   `json:"link"`
+	HasPassword           bool                  `json:"hasPassword"`
+	ExpirationDateTime    string                `json:"expirationDateTime"`
+	GrantedToV2           *IdentitySetApiData   `json:"grantedToV2"`
+	GrantedToIdentitiesV2 []IdentitySetApiData  `json:"grantedToIdentitiesV2"`
+	InheritedFrom         *ItemReferenceApiData `json:"inheritedFrom"`
+}
+
+// SharingLinkApiData represents the sharing link of a permission
+type SharingLinkApiData struct {
+	Type             string `json:"type"`  // view, edit, review, embed, blocksDownload, createOnly, addressBar, adminDefault
+	Scope            string `json:"scope"` // anonymous, organization, users, existingAccess
+	WebURL           string `json:"webUrl"`
+	PreventsDownload bool   `json:"preventsDownload"`
+}
+
+// IdentitySetApiData represents who a permission is granted to. SiteUser and SiteGroup carry the
+// SharePoint principal IDs role assignments and link members are stored by.
+type IdentitySetApiData struct {
+	User      *IdentityApiData           `json:"user"`
+	Group     *IdentityApiData           `json:"group"`
+	SiteUser  *SharePointIdentityApiData `json:"siteUser"`
+	SiteGroup *SharePointIdentityApiData `json:"siteGroup"`
+}
+
+// IdentityApiData represents an Entra user or group
+type IdentityApiData struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email"`
+}
+
+// SharePointIdentityApiData represents a SharePoint site user or group; ID is the principal's ID in the site
+type SharePointIdentityApiData struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	Email       string `json:"email"`
+	LoginName   string `json:"loginName"`
+}
+
+// ItemReferenceApiData references the item a permission is inherited from
+type ItemReferenceApiData struct {
+	ID   string `json:"id"`
+	Path string `json:"path"`
+}
@@ -0,0 +1,180 @@
+// Package graphclient reads a site through Microsoft Graph where Graph serves data SharePoint REST
+// does not, such as the people a sharing link was shared with, and through SharePoint REST otherwise.
+package graphclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"spaudit/domain/sharepoint"
+	"spaudit/infrastructure/spclient"
+	"spaudit/logging"
+
+	"github.com/koltyakov/gosip/api"
+)
+
+// graphConnector signs in to Microsoft Graph as the SharePoint client's app
+type graphConnector interface {
+	GraphHTTPClient() (*api.HTTPClient, error)
+}
+
+// itemLocation is the list item behind a file or folder UniqueId, which Graph addresses drive items by
+type itemLocation struct {
+	listID string
+	itemID int
+}
+
+// Client implements spclient.SharePointClient over Microsoft Graph's drive item permissions for
+// sharing, and the SharePoint REST client it wraps for everything else.
+type Client struct {
+	spclient.SharePointClient // SharePoint REST, for the operations Graph does not serve
+
+	connector graphConnector
+	siteURL   string
+	logger    *logging.Logger
+
+	mu        sync.Mutex
+	graph     *api.HTTPClient         // Signed in on first use, after the throttle observer is set
+	graphErr  error                   // Why signing in failed, so every item does not try again
+	siteID    string                  // Graph ID of the site
+	locations map[string]itemLocation // File/folder UniqueId -> list item, for the items resolved so far
+}
+
+// NewClient creates a Graph collector client for siteURL over rest, which must be able to sign in to
+// Microsoft Graph. That needs certificate authentication and Sites.Read.All on Microsoft Graph.
+func NewClient(rest spclient.SharePointClient, siteURL string, logger *logging.Logger) (spclient.SharePointClient, error) {
+	connector, ok := rest.(graphConnector)
+	if !ok {
+		return nil, fmt.Errorf("SharePoint client cannot sign in to Microsoft Graph")
+	}
+	return &Client{
+		SharePointClient: rest,
+		connector:        connector,
+		siteURL:          siteURL,
+		logger:           logger.WithComponent("graph_client"),
+		locations:        make(map[string]itemLocation),
+	}, nil
+}
+
+// ResolveFileByGUID resolves a file through SharePoint REST, remembering its list item for Graph.
+func (c *Client) ResolveFileByGUID(ctx context.Context, itemGUID string) (*sharepoint.Item, error) {
+	item, err := c.SharePointClient.ResolveFileByGUID(ctx, itemGUID)
+	if err == nil {
+		c.rememberLocation(itemGUID, item)
+	}
+	return item, err
+}
+
+// ResolveFolderByGUID resolves a folder through SharePoint REST, remembering its list item for Graph.
+func (c *Client) ResolveFolderByGUID(ctx context.Context, itemGUID string) (*sharepoint.Item, error) {
+	item, err := c.SharePointClient.ResolveFolderByGUID(ctx, itemGUID)
+	if err == nil {
+		c.rememberLocation(itemGUID, item)
+	}
+	return item, err
+}
+
+// GetItemSharingInfo retrieves the item's sharing information through SharePoint REST and adds the
+// item's Graph permissions to it: the people each link was shared with, and links REST left out.
+// When Graph cannot be read the REST information is returned alone, as REST does for its own failures.
+func (c *Client) GetItemSharingInfo(ctx context.Context, itemGUID string) (*sharepoint.SharingInfo, error) {
+	info, err := c.SharePointClient.GetItemSharingInfo(ctx, itemGUID)
+	if err != nil {
+		return nil, err
+	}
+	if info.ItemUniqueID == "" {
+		info.ItemUniqueID = itemGUID
+	}
+
+	permissions, err := c.getDrivePermissions(ctx, itemGUID)
+	if err != nil {
+		c.logger.Warn("Failed to get drive item permissions from Microsoft Graph, keeping SharePoint REST sharing info",
+			"item_guid", itemGUID, "error", err.Error())
+		return info, nil
+	}
+	if unresolved := mergeDrivePermissions(info, permissions); unresolved > 0 {
+		c.logger.Debug("Link members without a SharePoint principal left out", "item_guid", itemGUID, "count", unresolved)
+	}
+	return info, nil
+}
+
+// getDrivePermissions retrieves every permission of the drive item behind a file or folder UniqueId
+func (c *Client) getDrivePermissions(ctx context.Context, itemGUID string) ([]DrivePermissionApiData, error) {
+	location, err := c.itemLocation(ctx, itemGUID)
+	if err != nil {
+		return nil, err
+	}
+	graph, siteID, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var permissions []DrivePermissionApiData
+	next := fmt.Sprintf("%s/sites/%s/lists/%s/items/%d/driveItem/permissions", spclient.GraphBaseURL, siteID, location.listID, location.itemID)
+	for next != "" {
+		var page DrivePermissionsApiResponse
+		if err := spclient.GraphGet(ctx, graph, next, &page); err != nil {
+			return nil, fmt.Errorf("get drive item permissions: %w", err)
+		}
+		permissions = append(permissions, page.Value...)
+		next = page.NextLink
+	}
+	return permissions, nil
+}
+
+// connect signs in to Microsoft Graph and looks up the site, once; a failure is not retried
+func (c *Client) connect(ctx context.Context) (*api.HTTPClient, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.graph != nil || c.graphErr != nil {
+		return c.graph, c.siteID, c.graphErr
+	}
+
+	graph, err := c.connector.GraphHTTPClient()
+	if err != nil {
+		c.graphErr = err
+		return nil, "", err
+	}
+	siteID, err := spclient.GraphSiteID(ctx, graph, c.siteURL)
+	if err != nil {
+		if ctx.Err() == nil {
+			c.graphErr = err
+		}
+		return nil, "", err
+	}
+	c.graph, c.siteID = graph, siteID
+	return graph, siteID, nil
+}
+
+// itemLocation returns the list item behind a file or folder UniqueId, resolving it if the audit has not
+func (c *Client) itemLocation(ctx context.Context, itemGUID string) (itemLocation, error) {
+	c.mu.Lock()
+	location, ok := c.locations[itemGUID]
+	c.mu.Unlock()
+	if ok {
+		return location, nil
+	}
+
+	if _, err := c.ResolveFileByGUID(ctx, itemGUID); err != nil {
+		if _, folderErr := c.ResolveFolderByGUID(ctx, itemGUID); folderErr != nil {
+			return itemLocation{}, fmt.Errorf("resolve item %s: file_err=%v, folder_err=%v", itemGUID, err, folderErr)
+		}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if location, ok = c.locations[itemGUID]; !ok {
+		return itemLocation{}, fmt.Errorf("item %s resolved without a list item", itemGUID)
+	}
+	return location, nil
+}
+
+// rememberLocation records the list item a resolved file or folder is
+func (c *Client) rememberLocation(itemGUID string, item *sharepoint.Item) {
+	if item == nil || item.ListID == "" || item.ID == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.locations[itemGUID] = itemLocation{listID: item.ListID, itemID: item.ID}
+}
@@ -0,0 +1,157 @@
+package graphclient
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"spaudit/domain/sharepoint"
+)
+
+// mergeDrivePermissions adds what Microsoft Graph knows about an item's sharing links to the links
+// SharePoint REST returned: the people each link was shared with, and links REST left out. Links are
+// matched by URL. It returns the members whose SharePoint principal Graph did not give, which cannot
+// be stored.
+func mergeDrivePermissions(info *sharepoint.SharingInfo, permissions []DrivePermissionApiData) int {
+	byURL := make(map[string]*sharepoint.SharingLink, len(info.Links))
+	for _, link := range info.Links {
+		if link.URL != "" {
+			byURL[normalizeLinkURL(link.URL)] = link
+		}
+	}
+
+	unresolved := 0
+	for _, permission := range permissions {
+		if permission.Link == nil {
+			continue // Direct grants are collected with the item's role assignments
+		}
+		members, skipped := linkMembers(permission.GrantedToIdentitiesV2)
+		unresolved += skipped
+
+		link, ok := byURL[normalizeLinkURL(permission.Link.WebURL)]
+		if !ok {
+			link = mapSharingLink(info.ItemUniqueID, permission)
+			info.Links = append(info.Links, link)
+			if link.URL != "" {
+				byURL[normalizeLinkURL(link.URL)] = link
+			}
+		}
+		if len(members) > 0 {
+			link.Members = members
+			link.TotalMembersCount = len(members)
+		}
+		link.RequiresPassword = link.RequiresPassword || permission.HasPassword
+		if link.Expiration == nil {
+			link.Expiration = parseGraphTime(permission.ExpirationDateTime)
+		}
+	}
+	return unresolved
+}
+
+// mapSharingLink maps a Graph sharing link permission SharePoint REST did not return
+func mapSharingLink(itemUniqueID string, permission DrivePermissionApiData) *sharepoint.SharingLink {
+	kind, scope := linkKindAndScope(permission.Link.Type, permission.Link.Scope)
+	return &sharepoint.SharingLink{
+		ID:                    permission.ID,
+		FileFolderUniqueID:    itemUniqueID,
+		ShareID:               permission.ID,
+		URL:                   permission.Link.WebURL,
+		LinkKind:              kind,
+		Scope:                 scope,
+		IsActive:              true,
+		IsEditLink:            permission.Link.Type == "edit",
+		IsReviewLink:          permission.Link.Type == "review",
+		BlocksDownload:        permission.Link.PreventsDownload || permission.Link.Type == "blocksDownload",
+		RequiresPassword:      permission.HasPassword,
+		IsInherited:           permission.InheritedFrom != nil,
+		IsCreateOnlyLink:      permission.Link.Type == "createOnly",
+		IsAddressBarLink:      permission.Link.Type == "addressBar",
+		AllowsAnonymousAccess: scope == sharepoint.ScopeAnonymous,
+		Embeddable:            permission.Link.Type == "embed",
+		Expiration:            parseGraphTime(permission.ExpirationDateTime),
+	}
+}
+
+// linkKindAndScope maps a Graph link type and scope to SharePoint's link kind and scope. Graph has
+// no kind of its own: anyone and organization links map to their view or edit kinds, the rest are
+// flexible links carrying their audience in the scope.
+func linkKindAndScope(linkType, linkScope string) (int, int) {
+	edit := linkType == "edit"
+	switch linkScope {
+	case "anonymous":
+		if edit {
+			return sharepoint.LinkKindAnonymousEdit, sharepoint.ScopeAnonymous
+		}
+		return sharepoint.LinkKindAnonymousView, sharepoint.ScopeAnonymous
+	case "organization":
+		if edit {
+			return sharepoint.LinkKindOrganizationEdit, sharepoint.ScopeOrganization
+		}
+		return sharepoint.LinkKindOrganizationView, sharepoint.ScopeOrganization
+	case "existingAccess":
+		return sharepoint.LinkKindFlexible, sharepoint.ScopeExistingAccess
+	default:
+		return sharepoint.LinkKindFlexible, sharepoint.ScopeSpecificPeople
+	}
+}
+
+// linkMembers maps the people a link was shared with to SharePoint principals. It returns how many
+// were skipped for lack of a SharePoint principal ID, such as guests not yet added to the site.
+func linkMembers(identities []IdentitySetApiData) ([]*sharepoint.Principal, int) {
+	var members []*sharepoint.Principal
+	skipped := 0
+	for _, identity := range identities {
+		if member := sharePointPrincipal(identity); member != nil {
+			members = append(members, member)
+		} else {
+			skipped++
+		}
+	}
+	return members, skipped
+}
+
+// sharePointPrincipal returns the SharePoint user or group of an identity set, or nil without one
+func sharePointPrincipal(identity IdentitySetApiData) *sharepoint.Principal {
+	principalType := int64(sharepoint.PrincipalTypeUser)
+	siteIdentity := identity.SiteUser
+	if siteIdentity == nil {
+		principalType = sharepoint.PrincipalTypeSharePointGroup
+		siteIdentity = identity.SiteGroup
+	}
+	if siteIdentity == nil {
+		return nil
+	}
+	id, err := strconv.ParseInt(siteIdentity.ID, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	principal := &sharepoint.Principal{
+		ID:            id,
+		PrincipalType: principalType,
+		Title:         siteIdentity.DisplayName,
+		LoginName:     siteIdentity.LoginName,
+		Email:         siteIdentity.Email,
+	}
+	if principal.Email == "" && identity.User != nil {
+		principal.Email = identity.User.Email
+	}
+	return principal
+}
+
+// normalizeLinkURL lets a link's URL from SharePoint REST and Graph match
+func normalizeLinkURL(linkURL string) string {
+	return strings.ToLower(strings.TrimRight(strings.TrimSpace(linkURL), "/"))
+}
+
+// parseGraphTime parses a Graph timestamp, nil when empty or malformed
+func parseGraphTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,95 @@
+package graphclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/sharepoint"
+)
+
+// drivePermissionsSample is a Graph driveItem permissions response: a people link shared with a site
+// user and a guest not yet in the site, an anyone link SharePoint REST did not return, and a direct grant.
+const drivePermissionsSample = `{
+  "value": [
+    {
+      "id": "aTowIy5mfG1lbWJlcnNoaXB8YWRlbGV2",
+      "roles": ["write"],
+      "link": {"scope": "users", "type": "edit", "webUrl": "https://contoso.sharepoint.com/:w:/s/finance/EabC123"},
+      "hasPassword": true,
+      "grantedToIdentitiesV2": [
+        {"user": {"id": "e1", "displayName": "Adele Vance", "email": "adelev@contoso.com"},
+         "siteUser": {"id": "12", "displayName": "Adele Vance", "loginName": "i:0#.f|membership|adelev@contoso.com"}},
+        {"user": {"displayName": "guest@fabrikam.com", "email": "guest@fabrikam.com"}}
+      ]
+    },
+    {
+      "id": "anyone-1",
+      "roles": ["read"],
+      "link": {"scope": "anonymous", "type": "view", "webUrl": "https://contoso.sharepoint.com/:w:/s/finance/EzZz999", "preventsDownload": true},
+      "expirationDateTime": "2026-12-31T00:00:00Z"
+    },
+    {
+      "id": "direct-1",
+      "roles": ["owner"],
+      "grantedToV2": {"siteGroup": {"id": "3", "displayName": "Finance Owners"}}
+    }
+  ]
+}`
+
+func TestMergeDrivePermissions(t *testing.T) {
+	var page DrivePermissionsApiResponse
+	require.NoError(t, json.Unmarshal([]byte(drivePermissionsSample), &page))
+
+	info := &sharepoint.SharingInfo{
+		ItemUniqueID: "item-guid",
+		Links: []*sharepoint.SharingLink{{
+			ID:                "share-1",
+			URL:               "https://contoso.sharepoint.com/:w:/s/finance/EabC123/",
+			LinkKind:          sharepoint.LinkKindFlexible,
+			Scope:             sharepoint.ScopeSpecificPeople,
+			TotalMembersCount: 2,
+		}},
+	}
+	unresolved := mergeDrivePermissions(info, page.Value)
+	assert.Equal(t, 1, unresolved, "the guest has no SharePoint principal")
+
+	require.Len(t, info.Links, 2, "the direct grant is not a link")
+	people := info.Links[0]
+	assert.Equal(t, "share-1", people.ID, "REST's link is kept")
+	require.Len(t, people.Members, 1)
+	assert.Equal(t, int64(12), people.Members[0].ID)
+	assert.Equal(t, "i:0#.f|membership|adelev@contoso.com", people.Members[0].LoginName)
+	assert.Equal(t, "adelev@contoso.com", people.Members[0].Email)
+	assert.Equal(t, 1, people.TotalMembersCount)
+	assert.True(t, people.RequiresPassword)
+
+	anyone := info.Links[1]
+	assert.Equal(t, "anyone-1", anyone.ID)
+	assert.Equal(t, "item-guid", anyone.FileFolderUniqueID)
+	assert.Equal(t, sharepoint.LinkKindAnonymousView, anyone.LinkKind)
+	assert.True(t, anyone.IsAnyoneLink())
+	assert.True(t, anyone.BlocksDownload)
+	require.NotNil(t, anyone.Expiration)
+	assert.Equal(t, 2026, anyone.Expiration.Year())
+}
+
+func TestLinkKindAndScope(t *testing.T) {
+	tests := []struct {
+		linkType, scope string
+		kind, wantScope int
+	}{
+		{"edit", "anonymous", sharepoint.LinkKindAnonymousEdit, sharepoint.ScopeAnonymous},
+		{"view", "organization", sharepoint.LinkKindOrganizationView, sharepoint.ScopeOrganization},
+		{"edit", "organization", sharepoint.LinkKindOrganizationEdit, sharepoint.ScopeOrganization},
+		{"review", "users", sharepoint.LinkKindFlexible, sharepoint.ScopeSpecificPeople},
+		{"view", "existingAccess", sharepoint.LinkKindFlexible, sharepoint.ScopeExistingAccess},
+	}
+	for _, tt := range tests {
+		kind, scope := linkKindAndScope(tt.linkType, tt.scope)
+		assert.Equal(t, tt.kind, kind, tt.linkType+"/"+tt.scope)
+		assert.Equal(t, tt.wantScope, scope, tt.linkType+"/"+tt.scope)
+	}
+}
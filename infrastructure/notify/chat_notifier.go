@@ -0,0 +1,376 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"spaudit/domain/audit"
+	"spaudit/logging"
+)
+
+// ErrInvalidChatWebhooks is returned by ParseChatWebhooks for malformed chat webhooks
+var ErrInvalidChatWebhooks = errors.New("invalid chat webhooks")
+
+// Chat platforms whose incoming webhooks cards are posted to
+const (
+	ChatPlatformSlack = "slack"
+	ChatPlatformTeams = "teams"
+)
+
+// chatTimeout bounds each post to a chat webhook
+const chatTimeout = 10 * time.Second
+
+// ChatWebhook is the incoming webhook of a Slack or Teams channel
+type ChatWebhook struct {
+	Platform string // ChatPlatformSlack or ChatPlatformTeams
+	URL      string
+}
+
+// ParseChatWebhooks parses webhooks of the form "<platform>=<url>" separated by commas, the platform being
+// slack or teams, e.g. "slack=https://hooks.slack.com/services/T0/B0/x". An empty spec has no webhooks.
+func ParseChatWebhooks(spec string) ([]ChatWebhook, error) {
+	var webhooks []ChatWebhook
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		platform, raw, ok := strings.Cut(entry, "=")
+		platform = strings.ToLower(strings.TrimSpace(platform))
+		raw = strings.TrimSpace(raw)
+		if !ok || (platform != ChatPlatformSlack && platform != ChatPlatformTeams) {
+			return nil, fmt.Errorf("%w: %q is not slack=<url> or teams=<url>", ErrInvalidChatWebhooks, entry)
+		}
+		parsed, err := url.Parse(raw)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return nil, fmt.Errorf("%w: %s webhook %q is not an absolute https URL", ErrInvalidChatWebhooks, platform, raw)
+		}
+		webhooks = append(webhooks, ChatWebhook{Platform: platform, URL: raw})
+	}
+	return webhooks, nil
+}
+
+// chatTone colors a card by how urgent it is
+type chatTone int
+
+const (
+	chatToneNeutral   chatTone = iota
+	chatToneGood               // Completed without high risks
+	chatToneWarning            // High risks or severe findings
+	chatToneAttention          // Failed runs and critical findings
+)
+
+// chatFact is a labelled value shown on a card
+type chatFact struct {
+	Title string
+	Value string
+}
+
+// chatLink is a button opening a URL
+type chatLink struct {
+	Title string
+	URL   string
+}
+
+// chatCard is a platform-neutral card, laid out for each platform when posted
+type chatCard struct {
+	Title       string
+	Text        string
+	Tone        chatTone
+	Facts       []chatFact
+	ListHeading string
+	ListItems   []string
+	Links       []chatLink
+}
+
+// ChatNotifier posts cards announcing finished audit runs and new finding alerts to Slack and Teams
+// channels through their incoming webhooks. A run summary that fails to post is returned, for the
+// durable event dispatcher to retry; a finding alert that fails is logged and not retried.
+type ChatNotifier struct {
+	webhooks    []ChatWebhook
+	minSeverity audit.Severity
+	client      *http.Client
+	logger      *logging.Logger
+}
+
+// NewChatNotifier creates a chat notifier posting to the webhooks. Finding alerts below minSeverity are not posted.
+func NewChatNotifier(webhooks []ChatWebhook, minSeverity audit.Severity) *ChatNotifier {
+	return &ChatNotifier{
+		webhooks:    webhooks,
+		minSeverity: minSeverity,
+		client:      &http.Client{Timeout: chatTimeout},
+		logger:      logging.Default().WithComponent("chat_notifier"),
+	}
+}
+
+// NotifyRunSummary posts a card summarizing a finished audit run to every webhook.
+func (n *ChatNotifier) NotifyRunSummary(ctx context.Context, summary *audit.RunSummary) error {
+	if err := n.post(ctx, runSummaryCard(summary)); err != nil {
+		return fmt.Errorf("failed to post run summary to chat: %w", err)
+	}
+	n.logger.Info("Posted run summary to chat", "job_id", summary.JobID, "audit_run_id", summary.AuditRunID, "webhooks", len(n.webhooks))
+	return nil
+}
+
+// NotifyFindingAlert posts a card for a finding alert of at least the minimum severity to every webhook.
+// Alerts are announced once delivered, so alerts held back by quiet hours are posted when they end.
+func (n *ChatNotifier) NotifyFindingAlert(alert *audit.FindingAlert) {
+	if alert.Severity.Rank() < n.minSeverity.Rank() {
+		return
+	}
+	if err := n.post(context.Background(), findingAlertCard(alert)); err != nil {
+		n.logger.Error("Failed to post finding alert to chat", "alert_id", alert.ID, "error", err)
+	}
+}
+
+// post sends a card to every webhook; a webhook failing does not keep the others from being posted to
+func (n *ChatNotifier) post(ctx context.Context, card chatCard) error {
+	var errs []error
+	for _, webhook := range n.webhooks {
+		var payload any
+		if webhook.Platform == ChatPlatformTeams {
+			payload = teamsPayload(card)
+		} else {
+			payload = slackPayload(card)
+		}
+		if err := n.postJSON(ctx, webhook.URL, payload); err != nil {
+			errs = append(errs, fmt.Errorf("%s webhook: %w", webhook.Platform, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// postJSON posts a JSON body, failing unless the webhook answers with a 2xx status
+func (n *ChatNotifier) postJSON(ctx context.Context, webhookURL string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := n.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(response.Body, 512))
+		return fmt.Errorf("status %d: %s", response.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// runSummaryCard lays out a finished audit run like its summary email
+func runSummaryCard(summary *audit.RunSummary) chatCard {
+	name := summary.SiteTitle
+	if name == "" {
+		name = summary.SiteURL
+	}
+	if name == "" {
+		name = "unknown site"
+	}
+
+	card := chatCard{Title: "Audit completed: " + name, Tone: chatToneGood}
+	outcome := "completed"
+	if summary.Failed {
+		card.Title = "Audit failed: " + name
+		card.Tone = chatToneAttention
+		outcome = "failed"
+	}
+	card.Text = fmt.Sprintf("The audit %s at %s.", outcome, summary.FinishedAt.UTC().Format("2006-01-02 15:04 MST"))
+	if summary.Failed && summary.Error != "" {
+		card.Text += "\nError: " + summary.Error
+	}
+
+	card.Facts = append(card.Facts, chatFact{"Job", summary.JobID})
+	if summary.AuditRunID != 0 {
+		card.Facts = append(card.Facts, chatFact{"Audit run", strconv.FormatInt(summary.AuditRunID, 10)})
+	}
+	switch {
+	case summary.Counted:
+		card.Facts = append(card.Facts,
+			chatFact{"Lists", strconv.Itoa(summary.Lists)},
+			chatFact{"Items with unique permissions", strconv.Itoa(summary.UniqueItems)},
+			chatFact{"Active sharing links", strconv.Itoa(summary.SharingLinks)},
+			chatFact{"External principals", strconv.Itoa(summary.ExternalPrincipals)},
+			chatFact{"Risk level", summary.RiskLevel},
+		)
+		if summary.Failed {
+			card.Text += "\nThe counts cover what the run captured before it failed."
+		} else if summary.RiskLevel == "High" {
+			card.Tone = chatToneWarning
+		}
+	case summary.AuditRunID == 0:
+		card.Text += "\nThe job failed before its audit run started, so nothing was captured."
+	default:
+		card.Text += "\nThe run's counts could not be read."
+	}
+
+	if len(summary.TopRisks) > 0 {
+		card.ListHeading = "Top risks"
+		for i, risk := range summary.TopRisks {
+			card.ListItems = append(card.ListItems, fmt.Sprintf("%d. %s (%s, score %.2f): %d items with unique permissions, %d sharing links",
+				i+1, risk.ListTitle, risk.RiskLevel, risk.RiskScore, risk.UniqueItems, risk.SharingLinks))
+		}
+	}
+	if strings.HasPrefix(summary.SiteURL, "https://") {
+		card.Links = append(card.Links, chatLink{"Open site", summary.SiteURL})
+	}
+	return card
+}
+
+// findingAlertCard lays out a new finding alert, critical findings calling for attention
+func findingAlertCard(alert *audit.FindingAlert) chatCard {
+	card := chatCard{
+		Title: fmt.Sprintf("New %s finding: %s", alert.Severity, alert.Category),
+		Text:  alert.Message,
+		Tone:  chatToneWarning,
+		Facts: []chatFact{
+			{"Severity", string(alert.Severity)},
+			{"Category", alert.Category},
+			{"Site", strconv.FormatInt(alert.SiteID, 10)},
+			{"Audit run", strconv.FormatInt(alert.AuditRunID, 10)},
+		},
+	}
+	if alert.Severity == audit.SeverityCritical {
+		card.Tone = chatToneAttention
+	}
+	return card
+}
+
+// slackColors are the attachment bar colors of each tone
+var slackColors = map[chatTone]string{
+	chatToneNeutral:   "#64748b",
+	chatToneGood:      "#16a34a",
+	chatToneWarning:   "#d97706",
+	chatToneAttention: "#dc2626",
+}
+
+// slackPayload lays out a card as Block Kit blocks in a colored attachment, with the title as the
+// notification text
+func slackPayload(card chatCard) map[string]any {
+	var blocks []map[string]any
+	blocks = append(blocks, map[string]any{
+		"type": "header",
+		"text": map[string]any{"type": "plain_text", "text": truncate(card.Title, 150)},
+	})
+	if card.Text != "" {
+		blocks = append(blocks, slackSection(slackEscape(card.Text)))
+	}
+	// Slack shows at most 10 fields per section
+	for start := 0; start < len(card.Facts); start += 10 {
+		end := min(start+10, len(card.Facts))
+		var fields []map[string]any
+		for _, fact := range card.Facts[start:end] {
+			fields = append(fields, map[string]any{"type": "mrkdwn", "text": "*" + slackEscape(fact.Title) + "*\n" + slackEscape(fact.Value)})
+		}
+		blocks = append(blocks, map[string]any{"type": "section", "fields": fields})
+	}
+	if len(card.ListItems) > 0 {
+		blocks = append(blocks, slackSection("*"+slackEscape(card.ListHeading)+"*\n"+slackEscape(strings.Join(card.ListItems, "\n"))))
+	}
+	if len(card.Links) > 0 {
+		var buttons []map[string]any
+		for _, link := range card.Links {
+			buttons = append(buttons, map[string]any{
+				"type": "button",
+				"text": map[string]any{"type": "plain_text", "text": link.Title},
+				"url":  link.URL,
+			})
+		}
+		blocks = append(blocks, map[string]any{"type": "actions", "elements": buttons})
+	}
+
+	return map[string]any{
+		"text":        card.Title,
+		"attachments": []map[string]any{{"color": slackColors[card.Tone], "blocks": blocks}},
+	}
+}
+
+// slackSection is a section block of mrkdwn text, within Slack's 3000 character limit
+func slackSection(text string) map[string]any {
+	return map[string]any{"type": "section", "text": map[string]any{"type": "mrkdwn", "text": truncate(text, 3000)}}
+}
+
+// slackEscape escapes the characters Slack reads as control sequences in mrkdwn text
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// teamsColors are the title colors of each tone
+var teamsColors = map[chatTone]string{
+	chatToneNeutral:   "Default",
+	chatToneGood:      "Good",
+	chatToneWarning:   "Warning",
+	chatToneAttention: "Attention",
+}
+
+// teamsPayload lays out a card as an Adaptive Card message, which Teams incoming webhooks and
+// workflows both accept
+func teamsPayload(card chatCard) map[string]any {
+	body := []map[string]any{{
+		"type": "TextBlock", "text": card.Title, "size": "Large", "weight": "Bolder",
+		"color": teamsColors[card.Tone], "wrap": true,
+	}}
+	if card.Text != "" {
+		body = append(body, map[string]any{"type": "TextBlock", "text": strings.ReplaceAll(card.Text, "\n", "\n\n"), "wrap": true})
+	}
+	if len(card.Facts) > 0 {
+		var facts []map[string]any
+		for _, fact := range card.Facts {
+			facts = append(facts, map[string]any{"title": fact.Title, "value": fact.Value})
+		}
+		body = append(body, map[string]any{"type": "FactSet", "facts": facts})
+	}
+	if len(card.ListItems) > 0 {
+		body = append(body, map[string]any{"type": "TextBlock", "text": card.ListHeading, "weight": "Bolder", "wrap": true, "spacing": "Medium"})
+		for _, item := range card.ListItems {
+			body = append(body, map[string]any{"type": "TextBlock", "text": item, "wrap": true, "spacing": "Small"})
+		}
+	}
+
+	content := map[string]any{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if len(card.Links) > 0 {
+		var actions []map[string]any
+		for _, link := range card.Links {
+			actions = append(actions, map[string]any{"type": "Action.OpenUrl", "title": link.Title, "url": link.URL})
+		}
+		content["actions"] = actions
+	}
+
+	return map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content":     content,
+		}},
+	}
+}
+
+// truncate shortens text to at most limit characters, marking the cut with an ellipsis
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-1]) + "…"
+}
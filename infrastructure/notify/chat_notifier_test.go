@@ -0,0 +1,122 @@
+package notify
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+)
+
+// chatEndpoint records the JSON bodies posted to it, answering with status.
+type chatEndpoint struct {
+	mu     sync.Mutex
+	status int
+	bodies map[string]map[string]any // Keyed by path
+}
+
+func (e *chatEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var payload map[string]any
+	_ = json.Unmarshal(body, &payload)
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.bodies[r.URL.Path] = payload
+	w.WriteHeader(e.status)
+}
+
+func TestParseChatWebhooks(t *testing.T) {
+	webhooks, err := ParseChatWebhooks("")
+	require.NoError(t, err)
+	assert.Empty(t, webhooks)
+
+	webhooks, err = ParseChatWebhooks(" slack=https://hooks.slack.com/services/T0/B0/x , Teams=https://contoso.webhook.office.com/in?sig=a=b")
+	require.NoError(t, err)
+	assert.Equal(t, []ChatWebhook{
+		{Platform: ChatPlatformSlack, URL: "https://hooks.slack.com/services/T0/B0/x"},
+		{Platform: ChatPlatformTeams, URL: "https://contoso.webhook.office.com/in?sig=a=b"},
+	}, webhooks)
+
+	for _, spec := range []string{"https://hooks.slack.com/services/T0", "discord=https://discord.com/api/webhooks/1", "slack=http://hooks.slack.com/x", "teams="} {
+		_, err := ParseChatWebhooks(spec)
+		assert.ErrorIs(t, err, ErrInvalidChatWebhooks, spec)
+	}
+}
+
+func TestChatNotifier_PostsCards(t *testing.T) {
+	endpoint := &chatEndpoint{status: http.StatusOK, bodies: map[string]map[string]any{}}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+	notifier := NewChatNotifier([]ChatWebhook{
+		{Platform: ChatPlatformSlack, URL: server.URL + "/slack"},
+		{Platform: ChatPlatformTeams, URL: server.URL + "/teams"},
+	}, audit.SeverityHigh)
+
+	summary := testRunSummary()
+	summary.TopRisks[0].ListTitle = "Docs <internal>"
+	require.NoError(t, notifier.NotifyRunSummary(t.Context(), summary))
+
+	slack := endpoint.bodies["/slack"]
+	assert.Equal(t, "Audit completed: Finance", slack["text"])
+	attachment := slack["attachments"].([]any)[0].(map[string]any)
+	assert.Equal(t, "#d97706", attachment["color"], "high risk runs are called out")
+	slackJSON, _ := json.Marshal(slack)
+	assert.Contains(t, string(slackJSON), `*Risk level*\nHigh`)
+	risks := attachment["blocks"].([]any)[3].(map[string]any)["text"].(map[string]any)["text"]
+	assert.Contains(t, risks, "1. Docs &lt;internal&gt; (High, score 0.82)", "mrkdwn control characters are escaped")
+	assert.Contains(t, string(slackJSON), `"url":"https://contoso.sharepoint.com/sites/finance"`)
+
+	teams := endpoint.bodies["/teams"]
+	assert.Equal(t, "message", teams["type"])
+	card := teams["attachments"].([]any)[0].(map[string]any)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", card["contentType"])
+	content := card["content"].(map[string]any)
+	assert.Equal(t, "AdaptiveCard", content["type"])
+	title := content["body"].([]any)[0].(map[string]any)
+	assert.Equal(t, "Audit completed: Finance", title["text"])
+	assert.Equal(t, "Warning", title["color"])
+	teamsJSON, _ := json.Marshal(teams)
+	assert.Contains(t, string(teamsJSON), `{"title":"Items with unique permissions","value":"40"}`)
+	assert.Contains(t, string(teamsJSON), `"type":"Action.OpenUrl"`)
+
+	endpoint.bodies = map[string]map[string]any{}
+	notifier.NotifyFindingAlert(&audit.FindingAlert{ID: 1, SiteID: 1, AuditRunID: 7, Category: "list_added", Severity: audit.SeverityLow})
+	assert.Empty(t, endpoint.bodies, "alerts below the minimum severity are not posted")
+	notifier.NotifyFindingAlert(&audit.FindingAlert{ID: 2, SiteID: 1, AuditRunID: 7, Category: "default_link_anyone",
+		Severity: audit.SeverityCritical, Message: "Anyone links are the default"})
+	assert.Equal(t, "New critical finding: default_link_anyone", endpoint.bodies["/slack"]["text"])
+	assert.Equal(t, "#dc2626", endpoint.bodies["/slack"]["attachments"].([]any)[0].(map[string]any)["color"])
+	teamsJSON, _ = json.Marshal(endpoint.bodies["/teams"])
+	assert.Contains(t, string(teamsJSON), `"color":"Attention"`)
+	assert.Contains(t, string(teamsJSON), "Anyone links are the default")
+}
+
+func TestChatNotifier_ReportsFailedPosts(t *testing.T) {
+	endpoint := &chatEndpoint{status: http.StatusBadRequest, bodies: map[string]map[string]any{}}
+	server := httptest.NewServer(endpoint)
+	defer server.Close()
+	notifier := NewChatNotifier([]ChatWebhook{
+		{Platform: ChatPlatformSlack, URL: server.URL + "/slack"},
+		{Platform: ChatPlatformTeams, URL: server.URL + "/teams"},
+	}, audit.SeverityHigh)
+
+	summary := testRunSummary()
+	summary.Failed, summary.Error = true, "throttled"
+	err := notifier.NotifyRunSummary(t.Context(), summary)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "slack webhook: status 400")
+	assert.Contains(t, err.Error(), "teams webhook: status 400")
+	assert.Len(t, endpoint.bodies, 2, "a failing webhook does not keep the others from being posted to")
+
+	card := runSummaryCard(summary)
+	assert.Equal(t, "Audit failed: Finance", card.Title)
+	assert.Equal(t, chatToneAttention, card.Tone)
+	assert.Contains(t, card.Text, "Error: throttled")
+	assert.Contains(t, card.Text, "before it failed")
+}
@@ -0,0 +1,290 @@
+// Package notify announces audit runs outside the app, emailing a summary of each run over SMTP and
+// posting cards for runs and finding alerts to Slack and Teams channels. Owners of permission exceptions
+// nearing expiry are emailed a reminder to review them.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"spaudit/domain/audit"
+	"spaudit/logging"
+)
+
+// ErrInvalidSMTPConfig is returned by NewEmailNotifier for an SMTP configuration emails cannot be sent with
+var ErrInvalidSMTPConfig = errors.New("invalid SMTP configuration")
+
+// ErrInvalidOwnerAddress is returned by NotifyExceptionReview for an exception owner that is not an email address
+var ErrInvalidOwnerAddress = errors.New("exception owner is not an email address")
+
+// DefaultSMTPPort is the submission port, upgraded to TLS with STARTTLS
+const DefaultSMTPPort = 587
+
+// smtpTimeout bounds a whole SMTP conversation when the caller sets no deadline
+const smtpTimeout = time.Minute
+
+// SMTPConfig is the mail server run summaries are sent through. Port 465 connects over TLS; other ports
+// upgrade with STARTTLS when the server offers it.
+type SMTPConfig struct {
+	Host     string // Empty disables email
+	Port     int
+	Username string // Empty sends without authenticating
+	Password string
+	From     string
+}
+
+// Enabled returns true if a mail server is configured.
+func (c SMTPConfig) Enabled() bool {
+	return c.Host != ""
+}
+
+// EmailNotifier emails a summary of each finished audit run to the recipients of its site.
+type EmailNotifier struct {
+	config SMTPConfig
+	from   string
+	rules  []RecipientRule
+	send   func(ctx context.Context, from string, to []string, message []byte) error
+	now    func() time.Time
+	logger *logging.Logger
+}
+
+// NewEmailNotifier creates an email notifier sending through the mail server to the recipients rules select.
+func NewEmailNotifier(config SMTPConfig, rules []RecipientRule) (*EmailNotifier, error) {
+	if config.Host == "" {
+		return nil, fmt.Errorf("%w: no SMTP host", ErrInvalidSMTPConfig)
+	}
+	if config.Port <= 0 || config.Port > 65535 {
+		return nil, fmt.Errorf("%w: invalid port %d", ErrInvalidSMTPConfig, config.Port)
+	}
+	from, err := mail.ParseAddress(config.From)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid from address %q", ErrInvalidSMTPConfig, config.From)
+	}
+
+	n := &EmailNotifier{
+		config: config,
+		from:   from.Address,
+		rules:  rules,
+		now:    func() time.Time { return time.Now().UTC() },
+		logger: logging.Default().WithComponent("email_notifier"),
+	}
+	n.send = n.sendSMTP
+	return n, nil
+}
+
+// NotifyRunSummary emails a run summary to the recipients of its site; sites without recipients are skipped.
+func (n *EmailNotifier) NotifyRunSummary(ctx context.Context, summary *audit.RunSummary) error {
+	recipients := RecipientsForSite(n.rules, summary.SiteURL)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	subject, body := FormatRunSummaryEmail(summary)
+	if err := n.send(ctx, n.from, recipients, n.buildMessage(recipients, subject, body)); err != nil {
+		return fmt.Errorf("failed to email run summary: %w", err)
+	}
+	n.logger.Info("Emailed run summary", "job_id", summary.JobID, "audit_run_id", summary.AuditRunID, "recipients", len(recipients))
+	return nil
+}
+
+// NotifyExceptionReview emails the owner of permission exceptions nearing expiry a reminder to review them.
+func (n *EmailNotifier) NotifyExceptionReview(ctx context.Context, owner string, reviews []*audit.ExceptionReview) error {
+	if len(reviews) == 0 {
+		return nil
+	}
+	address, err := mail.ParseAddress(owner)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidOwnerAddress, owner)
+	}
+
+	to := []string{address.Address}
+	subject, body := FormatExceptionReviewEmail(reviews)
+	if err := n.send(ctx, n.from, to, n.buildMessage(to, subject, body)); err != nil {
+		return fmt.Errorf("failed to email exception review reminder: %w", err)
+	}
+	n.logger.Info("Emailed exception review reminder", "owner", address.Address, "exceptions", len(reviews))
+	return nil
+}
+
+// buildMessage lays out a plain text email with CRLF line endings
+func (n *EmailNotifier) buildMessage(to []string, subject, body string) []byte {
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", n.config.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerValue(subject)))
+	fmt.Fprintf(&message, "Date: %s\r\n", n.now().Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	message.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	message.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return message.Bytes()
+}
+
+// headerValue drops line breaks, so site titles cannot add headers
+func headerValue(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// sendSMTP delivers a message to the mail server, upgrading to TLS when it can and authenticating
+// when a username is configured
+func (n *EmailNotifier) sendSMTP(ctx context.Context, from string, to []string, message []byte) error {
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, smtpTimeout)
+		defer cancel()
+	}
+	deadline, _ := ctx.Deadline()
+
+	host := n.config.Host
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, strconv.Itoa(n.config.Port)))
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	implicitTLS := n.config.Port == 465
+	if implicitTLS {
+		conn = tls.Client(conn, &tls.Config{ServerName: host})
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok && !implicitTLS {
+		if err := client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if n.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", n.config.Username, n.config.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(from); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// FormatRunSummaryEmail returns the subject and plain text body of a run summary email
+func FormatRunSummaryEmail(summary *audit.RunSummary) (subject, body string) {
+	site := summary.SiteURL
+	if summary.SiteTitle != "" {
+		site = fmt.Sprintf("%s (%s)", summary.SiteTitle, summary.SiteURL)
+	}
+	if site == "" {
+		site = "unknown site"
+	}
+	outcome := "completed"
+	if summary.Failed {
+		outcome = "failed"
+	}
+	name := summary.SiteTitle
+	if name == "" {
+		name = summary.SiteURL
+	}
+	subject = fmt.Sprintf("[spaudit] Audit %s: %s", outcome, name)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "The audit of %s %s at %s.\n\n", site, outcome, summary.FinishedAt.UTC().Format("2006-01-02 15:04 MST"))
+	fmt.Fprintf(&b, "Job: %s\n", summary.JobID)
+	if summary.AuditRunID != 0 {
+		fmt.Fprintf(&b, "Audit run: %d\n", summary.AuditRunID)
+	}
+	if summary.Failed && summary.Error != "" {
+		fmt.Fprintf(&b, "Error: %s\n", summary.Error)
+	}
+	b.WriteString("\n")
+
+	if !summary.Counted {
+		if summary.AuditRunID == 0 {
+			b.WriteString("The job failed before its audit run started, so nothing was captured.\n")
+		} else {
+			b.WriteString("The run's counts could not be read.\n")
+		}
+		return subject, b.String()
+	}
+	if summary.Failed {
+		b.WriteString("The counts cover what the run captured before it failed.\n\n")
+	}
+	fmt.Fprintf(&b, "Lists: %d\n", summary.Lists)
+	fmt.Fprintf(&b, "Items with unique permissions: %d\n", summary.UniqueItems)
+	fmt.Fprintf(&b, "Active sharing links: %d\n", summary.SharingLinks)
+	fmt.Fprintf(&b, "External principals: %d\n", summary.ExternalPrincipals)
+	fmt.Fprintf(&b, "Risk level: %s\n", summary.RiskLevel)
+
+	if len(summary.TopRisks) > 0 {
+		b.WriteString("\nTop risks:\n")
+		for i, risk := range summary.TopRisks {
+			fmt.Fprintf(&b, "%d. %s (%s, score %.2f): %d items with unique permissions, %d sharing links\n",
+				i+1, risk.ListTitle, risk.RiskLevel, risk.RiskScore, risk.UniqueItems, risk.SharingLinks)
+			if risk.ListURL != "" {
+				fmt.Fprintf(&b, "   %s\n", risk.ListURL)
+			}
+		}
+	}
+	return subject, b.String()
+}
+
+// exceptionKindLabels name what each kind of permission exception accepts
+var exceptionKindLabels = map[string]string{
+	audit.ExceptionSharingLink: "Sharing link",
+	audit.ExceptionAssignment:  "Role assignment",
+	audit.ExceptionUniqueItem:  "Item with unique permissions",
+}
+
+// FormatExceptionReviewEmail returns the subject and plain text body of a reminder to review permission
+// exceptions nearing expiry, which are listed soonest first.
+func FormatExceptionReviewEmail(reviews []*audit.ExceptionReview) (subject, body string) {
+	latest := reviews[len(reviews)-1].Exception.ExpiresAt.UTC().Format("2006-01-02")
+	subject = fmt.Sprintf("[spaudit] %d permission exceptions expire by %s", len(reviews), latest)
+	if len(reviews) == 1 {
+		subject = fmt.Sprintf("[spaudit] A permission exception expires on %s", latest)
+	}
+
+	var b strings.Builder
+	b.WriteString("Permission exceptions you own expire soon. Renew the ones still needed and revoke the rest at /exceptions/review.\n\n")
+	for i, review := range reviews {
+		exception := review.Exception
+		kind := exceptionKindLabels[exception.Kind]
+		if kind == "" {
+			kind = exception.Kind
+		}
+		fmt.Fprintf(&b, "%d. %s %s on list %s of %s\n", i+1, kind, exception.ObjectKey, exception.ListID, review.SiteURL)
+		fmt.Fprintf(&b, "   Expires: %s\n", exception.ExpiresAt.UTC().Format("2006-01-02 15:04 MST"))
+		fmt.Fprintf(&b, "   Justification: %s\n", exception.Justification)
+	}
+	return subject, b.String()
+}
@@ -0,0 +1,191 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+)
+
+func testRunSummary() *audit.RunSummary {
+	return &audit.RunSummary{
+		JobID:      "job-2",
+		SiteID:     1,
+		SiteURL:    "https://contoso.sharepoint.com/sites/finance",
+		SiteTitle:  "Finance",
+		AuditRunID: 7,
+		FinishedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+		Counted:    true,
+		Lists:      12, UniqueItems: 40, SharingLinks: 13, ExternalPrincipals: 2, RiskLevel: "High",
+		TopRisks: []*audit.RunRisk{
+			{ListID: "docs", ListTitle: "Documents", ListURL: "/sites/finance/Shared Documents", RiskLevel: "High", RiskScore: 0.82, UniqueItems: 30, SharingLinks: 9},
+		},
+	}
+}
+
+// fakeSMTPServer accepts one unauthenticated SMTP conversation, recording the envelope and message.
+func fakeSMTPServer(t *testing.T) (port int, received chan []string) {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	received = make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+		var lines []string
+		reply("220 localhost ESMTP")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			switch command := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); command {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL", "RCPT":
+				lines = append(lines, line)
+				reply("250 OK")
+			case "DATA":
+				reply("354 Go ahead")
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					lines = append(lines, strings.TrimRight(dataLine, "\r\n"))
+				}
+				reply("250 Queued")
+			case "QUIT":
+				reply("221 Bye")
+				received <- lines
+				return
+			default:
+				reply("502 Unsupported")
+			}
+		}
+	}()
+	return listener.Addr().(*net.TCPAddr).Port, received
+}
+
+func TestEmailNotifier_SendsToSiteRecipients(t *testing.T) {
+	port, received := fakeSMTPServer(t)
+	rules, err := ParseRecipientRules("*=secops@contoso.com;https://contoso.sharepoint.com/sites/finance=cfo@contoso.com")
+	require.NoError(t, err)
+	notifier, err := NewEmailNotifier(SMTPConfig{Host: "127.0.0.1", Port: port, From: "spaudit <spaudit@contoso.com>"}, rules)
+	require.NoError(t, err)
+
+	summary := testRunSummary()
+	summary.SiteTitle = "Finance\r\nBcc: attacker@example.com"
+	require.NoError(t, notifier.NotifyRunSummary(context.Background(), summary))
+
+	var lines []string
+	select {
+	case lines = <-received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+	message := strings.Join(lines, "\n")
+	assert.Contains(t, lines, "MAIL FROM:<spaudit@contoso.com>")
+	assert.Contains(t, lines, "RCPT TO:<secops@contoso.com>")
+	assert.Contains(t, lines, "RCPT TO:<cfo@contoso.com>")
+	assert.Contains(t, lines, "To: secops@contoso.com, cfo@contoso.com")
+	assert.Contains(t, lines, "Subject: [spaudit] Audit completed: Finance Bcc: attacker@example.com", "titles cannot add headers")
+	assert.Contains(t, message, "Items with unique permissions: 40")
+}
+
+func TestEmailNotifier_SkipsSitesWithoutRecipients(t *testing.T) {
+	rules, err := ParseRecipientRules("https://contoso.sharepoint.com/sites/hr=hr@contoso.com")
+	require.NoError(t, err)
+	notifier, err := NewEmailNotifier(SMTPConfig{Host: "smtp.contoso.com", Port: DefaultSMTPPort, From: "spaudit@contoso.com"}, rules)
+	require.NoError(t, err)
+	sent := 0
+	notifier.send = func(ctx context.Context, from string, to []string, message []byte) error {
+		sent++
+		return nil
+	}
+
+	require.NoError(t, notifier.NotifyRunSummary(context.Background(), testRunSummary()))
+	assert.Zero(t, sent)
+
+	for _, config := range []SMTPConfig{
+		{Port: DefaultSMTPPort, From: "spaudit@contoso.com"},
+		{Host: "smtp.contoso.com", Port: 0, From: "spaudit@contoso.com"},
+		{Host: "smtp.contoso.com", Port: DefaultSMTPPort, From: "spaudit"},
+	} {
+		_, err := NewEmailNotifier(config, rules)
+		assert.ErrorIs(t, err, ErrInvalidSMTPConfig, strconv.Itoa(config.Port)+" "+config.From)
+	}
+}
+
+func TestFormatRunSummaryEmail(t *testing.T) {
+	subject, body := FormatRunSummaryEmail(testRunSummary())
+	assert.Equal(t, "[spaudit] Audit completed: Finance", subject)
+	assert.Contains(t, body, "The audit of Finance (https://contoso.sharepoint.com/sites/finance) completed at 2025-03-01 12:00 UTC.")
+	assert.Contains(t, body, "Active sharing links: 13\n")
+	assert.Contains(t, body, "1. Documents (High, score 0.82): 30 items with unique permissions, 9 sharing links\n   /sites/finance/Shared Documents\n")
+
+	failed := testRunSummary()
+	failed.Failed, failed.Error = true, "throttled"
+	subject, body = FormatRunSummaryEmail(failed)
+	assert.Equal(t, "[spaudit] Audit failed: Finance", subject)
+	assert.Contains(t, body, "Error: throttled\n")
+	assert.Contains(t, body, "captured before it failed")
+
+	failed = &audit.RunSummary{JobID: "job-9", SiteURL: "https://contoso.sharepoint.com/sites/hr", Failed: true, Error: "site not found"}
+	subject, body = FormatRunSummaryEmail(failed)
+	assert.Equal(t, "[spaudit] Audit failed: https://contoso.sharepoint.com/sites/hr", subject)
+	assert.Contains(t, body, "before its audit run started")
+	assert.NotContains(t, body, "Lists:")
+}
+
+func TestEmailNotifier_RemindsExceptionOwners(t *testing.T) {
+	notifier, err := NewEmailNotifier(SMTPConfig{Host: "smtp.contoso.com", Port: DefaultSMTPPort, From: "spaudit@contoso.com"}, nil)
+	require.NoError(t, err)
+	var sentTo []string
+	var sent string
+	notifier.send = func(ctx context.Context, from string, to []string, message []byte) error {
+		sentTo, sent = to, string(message)
+		return nil
+	}
+
+	reviews := []*audit.ExceptionReview{
+		{SiteURL: "https://contoso.sharepoint.com/sites/finance", Exception: &audit.PermissionException{
+			Kind: audit.ExceptionSharingLink, ObjectKey: "link-1", ListID: "docs", Justification: "Shared with the auditors",
+			ExpiresAt: time.Date(2025, 3, 5, 9, 0, 0, 0, time.UTC),
+		}},
+		{SiteURL: "https://contoso.sharepoint.com/sites/finance", Exception: &audit.PermissionException{
+			Kind: audit.ExceptionUniqueItem, ObjectKey: "item-1", ListID: "docs", Justification: "Board pack",
+			ExpiresAt: time.Date(2025, 3, 9, 9, 0, 0, 0, time.UTC),
+		}},
+	}
+	require.NoError(t, notifier.NotifyExceptionReview(context.Background(), "Alice <alice@contoso.com>", reviews))
+	assert.Equal(t, []string{"alice@contoso.com"}, sentTo, "only the owner is emailed")
+	assert.Contains(t, sent, "Subject: [spaudit] 2 permission exceptions expire by 2025-03-09")
+	assert.Contains(t, sent, "1. Sharing link link-1 on list docs of https://contoso.sharepoint.com/sites/finance")
+	assert.Contains(t, sent, "Justification: Board pack")
+
+	sentTo = nil
+	err = notifier.NotifyExceptionReview(context.Background(), "Finance team", reviews)
+	assert.ErrorIs(t, err, ErrInvalidOwnerAddress)
+	assert.Nil(t, sentTo)
+}
@@ -0,0 +1,67 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"spaudit/domain/audit"
+)
+
+// ErrInvalidRecipientRules is returned when email recipient rules cannot be parsed
+var ErrInvalidRecipientRules = errors.New("invalid email recipient rules")
+
+// RecipientRule selects who is emailed about the audit runs of matching sites
+type RecipientRule struct {
+	SitePattern string // Site URL, or a URL prefix ending in "*"; "*" alone matches every site
+	Addresses   []string
+}
+
+// ParseRecipientRules parses rules of the form "<site pattern>=<address>[,<address>...]" separated by
+// semicolons, e.g. "*=secops@contoso.com;https://contoso.sharepoint.com/sites/finance*=cfo@contoso.com".
+// An empty spec has no rules.
+func ParseRecipientRules(spec string) ([]RecipientRule, error) {
+	var rules []RecipientRule
+	for _, entry := range strings.Split(spec, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		pattern, addressList, ok := strings.Cut(entry, "=")
+		pattern = strings.TrimSpace(pattern)
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("%w: %q is not <site pattern>=<addresses>", ErrInvalidRecipientRules, entry)
+		}
+
+		rule := RecipientRule{SitePattern: pattern}
+		for _, raw := range strings.Split(addressList, ",") {
+			address, err := mail.ParseAddress(strings.TrimSpace(raw))
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid address %q for %s", ErrInvalidRecipientRules, strings.TrimSpace(raw), pattern)
+			}
+			rule.Addresses = append(rule.Addresses, address.Address)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// RecipientsForSite returns the addresses of every rule matching the site, in rule order without duplicates
+func RecipientsForSite(rules []RecipientRule, siteURL string) []string {
+	var recipients []string
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if !audit.SitePatternMatches(rule.SitePattern, siteURL) {
+			continue
+		}
+		for _, address := range rule.Addresses {
+			if key := strings.ToLower(address); !seen[key] {
+				seen[key] = true
+				recipients = append(recipients, address)
+			}
+		}
+	}
+	return recipients
+}
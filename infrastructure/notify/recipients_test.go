@@ -0,0 +1,29 @@
+package notify
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecipientRules(t *testing.T) {
+	rules, err := ParseRecipientRules("")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	rules, err = ParseRecipientRules(" *=secops@contoso.com ; https://contoso.sharepoint.com/sites/finance*=CFO <cfo@contoso.com>, SecOps@contoso.com;")
+	require.NoError(t, err)
+	assert.Equal(t, []RecipientRule{
+		{SitePattern: "*", Addresses: []string{"secops@contoso.com"}},
+		{SitePattern: "https://contoso.sharepoint.com/sites/finance*", Addresses: []string{"cfo@contoso.com", "SecOps@contoso.com"}},
+	}, rules)
+
+	assert.Equal(t, []string{"secops@contoso.com", "cfo@contoso.com"}, RecipientsForSite(rules, "https://contoso.sharepoint.com/sites/Finance-EU/"))
+	assert.Equal(t, []string{"secops@contoso.com"}, RecipientsForSite(rules, "https://contoso.sharepoint.com/sites/hr"))
+
+	for _, spec := range []string{"secops@contoso.com", "=secops@contoso.com", "*=", "*=secops@contoso.com,not-an-address"} {
+		_, err := ParseRecipientRules(spec)
+		assert.ErrorIs(t, err, ErrInvalidRecipientRules, spec)
+	}
+}
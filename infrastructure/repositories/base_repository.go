@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"database/sql"
+	"strings"
 	"time"
 
 	"spaudit/database"
@@ -67,6 +68,15 @@ func (b *BaseRepository) FromNullInt64(ni sql.NullInt64) int64 {
 	return ni.Int64
 }
 
+// FromNullFloat64 safely converts sql.NullFloat64 to float64.
+// Returns 0 if the SQL value is NULL.
+func (b *BaseRepository) FromNullFloat64(nf sql.NullFloat64) float64 {
+	if !nf.Valid {
+		return 0
+	}
+	return nf.Float64
+}
+
 // FromNullTime safely converts sql.NullTime to *time.Time.
 // Returns nil if the SQL value is NULL.
 func (b *BaseRepository) FromNullTime(nt sql.NullTime) *time.Time {
@@ -118,6 +128,47 @@ func (b *BaseRepository) ToNullTime(t *time.Time) sql.NullTime {
 	return sql.NullTime{Time: *t, Valid: true}
 }
 
+// ToNullList converts a list of values to a comma-separated sql.NullString.
+// Empty list becomes NULL for database storage.
+func (b *BaseRepository) ToNullList(values []string) sql.NullString {
+	return b.ToNullString(strings.Join(values, ","))
+}
+
+// FromNullList converts a comma-separated sql.NullString back to a list of values.
+// Returns nil if the SQL value is NULL or empty.
+func (b *BaseRepository) FromNullList(ns sql.NullString) []string {
+	if !ns.Valid || ns.String == "" {
+		return nil
+	}
+	return strings.Split(ns.String, ",")
+}
+
+// BoolToInt64 converts a bool to the 0/1 integer SQLite uses for flags.
+func (b *BaseRepository) BoolToInt64(value bool) int64 {
+	if value {
+		return 1
+	}
+	return 0
+}
+
+// ToNullBoolPointer converts a *bool to sql.NullBool.
+// Nil pointer becomes NULL for database storage.
+func (b *BaseRepository) ToNullBoolPointer(value *bool) sql.NullBool {
+	if value == nil {
+		return sql.NullBool{Valid: false}
+	}
+	return sql.NullBool{Bool: *value, Valid: true}
+}
+
+// FromNullBoolToPointer safely converts sql.NullBool to *bool.
+// Returns nil if the SQL value is NULL.
+func (b *BaseRepository) FromNullBoolToPointer(nb sql.NullBool) *bool {
+	if !nb.Valid {
+		return nil
+	}
+	return &nb.Bool
+}
+
 // FromNullInt64ToPointer safely converts sql.NullInt64 to *int64.
 // Returns nil if the SQL value is NULL.
 func (b *BaseRepository) FromNullInt64ToPointer(ni sql.NullInt64) *int64 {
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"spaudit/database"
+	"spaudit/domain/sharepoint"
+	"spaudit/logging"
+	"spaudit/test/dataset"
+)
+
+// Benchmarks for the repository hot paths of an audit, over the synthetic site from test/dataset.
+// Run with: mage bench, or go test ./infrastructure/repositories -run '^$' -bench . -benchmem
+
+// newBenchmarkDatabase opens a migrated database holding the generated site in audit run 1.
+func newBenchmarkDatabase(b *testing.B, spec dataset.Spec) (*database.Database, *dataset.Dataset) {
+	b.Helper()
+
+	logger := logging.NewLogger(&logging.Config{Level: "error", Format: "text", Output: "stderr"})
+	testDB, err := database.New(database.Config{
+		Path:              filepath.Join(b.TempDir(), "bench.db"),
+		MaxOpenConns:      2,
+		MaxIdleConns:      1,
+		BusyTimeoutMs:     1000,
+		EnableForeignKeys: true,
+		EnableWAL:         true,
+	}, logger)
+	require.NoError(b, err)
+	b.Cleanup(func() { testDB.Close() })
+
+	_, err = testDB.WriteDB().Exec(`INSERT INTO sites (site_id, site_url, title) VALUES (?, ?, 'Benchmark')`, spec.SiteID, spec.SiteURL)
+	require.NoError(b, err)
+	_, err = testDB.WriteDB().Exec(`INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', ?, ?, 'site_audit', 'completed')`, spec.SiteID, spec.SiteURL)
+	require.NoError(b, err)
+	_, err = testDB.WriteDB().Exec(`INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (1, 'job-1', ?, CURRENT_TIMESTAMP)`, spec.SiteID)
+	require.NoError(b, err)
+
+	d := dataset.Generate(spec)
+	require.NoError(b, d.Save(context.Background(), NewSqlcAuditRepository(testDB), 1))
+	return testDB, d
+}
+
+func BenchmarkSqlcAuditRepository_SaveItem(b *testing.B) {
+	testDB, d := newBenchmarkDatabase(b, dataset.Small)
+	repo := NewSqlcAuditRepository(testDB)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// A copy under a new GUID, so every save is an insert
+		item := *d.Items[i%len(d.Items)]
+		item.GUID = fmt.Sprintf("%s-%d", item.GUID, i)
+		if err := repo.SaveItem(ctx, 1, &item); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSqlcAuditRepository_ReplaceRoleAssignments(b *testing.B) {
+	testDB, d := newBenchmarkDatabase(b, dataset.Small)
+	repo := NewSqlcAuditRepository(testDB)
+	ctx := context.Background()
+
+	byItem := make(map[string][]*sharepoint.RoleAssignment)
+	for _, ra := range d.Assignments {
+		byItem[ra.ObjectKey] = append(byItem[ra.ObjectKey], ra)
+	}
+	unique := d.UniqueItems()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		item := unique[i%len(unique)]
+		if err := repo.ReplaceRoleAssignments(ctx, 1, d.Spec.SiteID, sharepoint.ObjectTypeItem, item.GUID, byItem[item.GUID]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPermissionAggregateRepository_GetPermissionAnalysisComponents(b *testing.B) {
+	testDB, d := newBenchmarkDatabase(b, dataset.Small)
+	repo := NewPermissionAggregateRepository(NewBaseRepository(testDB))
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := repo.GetPermissionAnalysisComponents(ctx, d.Spec.SiteID, 1, d.Lists[i%len(d.Lists)]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
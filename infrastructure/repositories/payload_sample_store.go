@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"spaudit/database"
+	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
+	"spaudit/gen/db"
+	"spaudit/platform/compress"
+)
+
+// SqlcPayloadSampleStore implements contracts.PayloadSampleStore in the audit_run_payload_samples table
+type SqlcPayloadSampleStore struct {
+	*BaseRepository
+	compression compress.Encoding
+}
+
+// NewSqlcPayloadSampleStore creates a payload sample store writing to the database, compressing payloads with the encoding
+func NewSqlcPayloadSampleStore(database *database.Database, compression compress.Encoding) contracts.PayloadSampleStore {
+	return &SqlcPayloadSampleStore{
+		BaseRepository: NewBaseRepository(database),
+		compression:    compression,
+	}
+}
+
+// SavePayloadSamples stores the payloads sampled by an audit run
+func (s *SqlcPayloadSampleStore) SavePayloadSamples(ctx context.Context, auditRunID int64, samples []*audit.PayloadSample) error {
+	for _, sample := range samples {
+		payload, encoding, err := compress.Encode(sample.Payload, s.compression)
+		if err != nil {
+			return fmt.Errorf("compress payload sample %s %s: %w", sample.Source, sample.Key, err)
+		}
+		if err := s.WriteQueries().InsertAuditRunPayloadSample(ctx, db.InsertAuditRunPayloadSampleParams{
+			AuditRunID:      auditRunID,
+			Source:          sample.Source,
+			SampleKey:       sample.Key,
+			Payload:         payload,
+			PayloadEncoding: string(encoding),
+			Truncated:       sample.Truncated,
+			SchemaDrift:     sample.SchemaDrift,
+			CapturedAt:      sample.CapturedAt,
+		}); err != nil {
+			return fmt.Errorf("save payload sample %s %s: %w", sample.Source, sample.Key, err)
+		}
+	}
+	return nil
+}
+
+// GetPayloadSamples returns the payloads sampled by an audit run, decompressed, in the order they were kept
+func (s *SqlcPayloadSampleStore) GetPayloadSamples(ctx context.Context, auditRunID int64) ([]*audit.PayloadSample, error) {
+	rows, err := s.ReadQueries().GetAuditRunPayloadSamples(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("get payload samples for audit run %d: %w", auditRunID, err)
+	}
+
+	samples := make([]*audit.PayloadSample, len(rows))
+	for i, row := range rows {
+		payload, err := compress.Decode(row.Payload, compress.Encoding(row.PayloadEncoding))
+		if err != nil {
+			return nil, fmt.Errorf("decompress payload sample %d: %w", row.SampleID, err)
+		}
+		samples[i] = &audit.PayloadSample{
+			Source:      row.Source,
+			Key:         row.SampleKey,
+			Payload:     payload,
+			Truncated:   row.Truncated,
+			SchemaDrift: row.SchemaDrift,
+			CapturedAt:  row.CapturedAt,
+		}
+	}
+	return samples, nil
+}
+
+// DirectoryPayloadSampleStore implements contracts.PayloadSampleStore as files under a directory,
+// e.g. a mounted volume or a bucket synced to object storage, keeping samples out of the database.
+type DirectoryPayloadSampleStore struct {
+	dir         string
+	compression compress.Encoding
+}
+
+// NewDirectoryPayloadSampleStore creates a payload sample store writing <dir>/run-<id>/<n>-<source>-<key>.json
+// files. Truncated payloads, which are no longer valid JSON, get a .json.partial extension instead.
+// Compressed files carry the encoding's extension as well, e.g. .json.zst.
+func NewDirectoryPayloadSampleStore(dir string, compression compress.Encoding) contracts.PayloadSampleStore {
+	return &DirectoryPayloadSampleStore{dir: dir, compression: compression}
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// SavePayloadSamples writes the payloads sampled by an audit run, one file each
+func (s *DirectoryPayloadSampleStore) SavePayloadSamples(ctx context.Context, auditRunID int64, samples []*audit.PayloadSample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+	runDir := filepath.Join(s.dir, fmt.Sprintf("run-%d", auditRunID))
+	if err := os.MkdirAll(runDir, 0o750); err != nil {
+		return fmt.Errorf("create payload sample directory: %w", err)
+	}
+
+	for i, sample := range samples {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		name := fmt.Sprintf("%04d-%s-%s.json", i+1,
+			unsafeFilenameChars.ReplaceAllString(sample.Source, "_"),
+			unsafeFilenameChars.ReplaceAllString(sample.Key, "_"))
+		if sample.Truncated {
+			name += ".partial"
+		}
+		name += s.compression.Extension()
+		if err := s.writeSample(filepath.Join(runDir, name), sample.Payload); err != nil {
+			return fmt.Errorf("save payload sample %s %s: %w", sample.Source, sample.Key, err)
+		}
+	}
+	return nil
+}
+
+// writeSample streams a payload through the store's compression into a new file.
+func (s *DirectoryPayloadSampleStore) writeSample(path string, payload []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o640)
+	if err != nil {
+		return err
+	}
+	writer, err := compress.NewWriter(file, s.compression)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := io.Copy(writer, bytes.NewReader(payload)); err != nil {
+		writer.Close()
+		file.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
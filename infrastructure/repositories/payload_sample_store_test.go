@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/platform/compress"
+)
+
+func testPayloadSamples() []*audit.PayloadSample {
+	capturedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	return []*audit.PayloadSample{
+		{Source: "list_item", Key: "{item-guid}", Payload: []byte(`{"Id":7}`), CapturedAt: capturedAt},
+		{Source: "sharing_information", Key: "item-guid", Payload: []byte(`{"itemUnique`), Truncated: true, SchemaDrift: true, CapturedAt: capturedAt},
+	}
+}
+
+func TestSqlcPayloadSampleStore_SavePayloadSamples(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	ctx := context.Background()
+
+	require.NoError(t, NewSqlcPayloadSampleStore(testDB, compress.None).SavePayloadSamples(ctx, 1, testPayloadSamples()))
+
+	rows, err := testDB.ReadQueries().GetAuditRunPayloadSamples(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "list_item", rows[0].Source)
+	assert.Equal(t, "{item-guid}", rows[0].SampleKey)
+	assert.JSONEq(t, `{"Id":7}`, string(rows[0].Payload))
+	assert.False(t, rows[0].Truncated)
+	assert.True(t, rows[1].Truncated)
+	assert.True(t, rows[1].SchemaDrift)
+}
+
+func TestDirectoryPayloadSampleStore_SavePayloadSamples(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, NewDirectoryPayloadSampleStore(dir, compress.None).SavePayloadSamples(context.Background(), 3, testPayloadSamples()))
+
+	content, err := os.ReadFile(filepath.Join(dir, "run-3", "0001-list_item-_item-guid_.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Id":7}`, string(content))
+	_, err = os.Stat(filepath.Join(dir, "run-3", "0002-sharing_information-item-guid.json.partial"))
+	assert.NoError(t, err, "truncated payloads are marked partial")
+}
+
+func TestSqlcPayloadSampleStore_CompressesPayloads(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	ctx := context.Background()
+	large := &audit.PayloadSample{Source: "list_item", Key: "large", Payload: []byte(`[` + strings.Repeat(`{"Title":"Quarterly report"},`, 100) + `{}]`)}
+	samples := append(testPayloadSamples(), large)
+
+	store := NewSqlcPayloadSampleStore(testDB, compress.Zstd)
+	require.NoError(t, store.SavePayloadSamples(ctx, 1, samples))
+
+	rows, err := testDB.ReadQueries().GetAuditRunPayloadSamples(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	assert.Equal(t, "", rows[0].PayloadEncoding, "payloads too small to shrink are stored as is")
+	assert.Equal(t, string(compress.Zstd), rows[2].PayloadEncoding)
+	assert.Less(t, len(rows[2].Payload), len(large.Payload))
+
+	read, err := store.(*SqlcPayloadSampleStore).GetPayloadSamples(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, read, 3)
+	for i, sample := range samples {
+		assert.Equal(t, sample.Payload, read[i].Payload)
+		assert.Equal(t, sample.Truncated, read[i].Truncated)
+	}
+}
+
+func TestDirectoryPayloadSampleStore_CompressesFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, NewDirectoryPayloadSampleStore(dir, compress.Zstd).SavePayloadSamples(context.Background(), 3, testPayloadSamples()))
+
+	file, err := os.Open(filepath.Join(dir, "run-3", "0001-list_item-_item-guid_.json.zst"))
+	require.NoError(t, err)
+	defer file.Close()
+	reader, err := compress.NewReader(file, compress.Zstd)
+	require.NoError(t, err)
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"Id":7}`, string(content))
+
+	_, err = os.Stat(filepath.Join(dir, "run-3", "0002-sharing_information-item-guid.json.partial.zst"))
+	assert.NoError(t, err)
+}
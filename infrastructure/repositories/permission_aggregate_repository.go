@@ -2,38 +2,31 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 
+	"spaudit/domain/audit"
 	"spaudit/domain/contracts"
 	"spaudit/domain/sharepoint"
 	"spaudit/gen/db"
 )
 
-// PermissionAggregateRepositoryImpl implements the permission aggregate repository by composing entity repositories.
+// PermissionAggregateRepositoryImpl implements the permission aggregate repository with set-based count queries.
 type PermissionAggregateRepositoryImpl struct {
 	*BaseRepository
-	itemRepo           contracts.ItemRepository
-	sharingRepo        contracts.SharingRepository
-	permissionsService *sharepoint.PermissionsService
-	contentService     *sharepoint.ContentService
 }
 
 // NewPermissionAggregateRepository creates a new permission aggregate repository.
-func NewPermissionAggregateRepository(
-	base *BaseRepository,
-	itemRepo contracts.ItemRepository,
-	sharingRepo contracts.SharingRepository,
-) contracts.PermissionAggregateRepository {
+func NewPermissionAggregateRepository(base *BaseRepository) contracts.PermissionAggregateRepository {
 	return &PermissionAggregateRepositoryImpl{
-		BaseRepository:     base,
-		itemRepo:           itemRepo,
-		sharingRepo:        sharingRepo,
-		permissionsService: sharepoint.NewPermissionsService(),
-		contentService:     sharepoint.NewContentService(),
+		BaseRepository: base,
 	}
 }
 
-// GetPermissionAnalysisComponents retrieves raw components needed for permission analysis (audit-scoped).
+// GetPermissionAnalysisComponents retrieves grouped counts needed for permission analysis (audit-scoped).
+// Issues a fixed number of queries regardless of how many assignments, links or items the list has.
 func (r *PermissionAggregateRepositoryImpl) GetPermissionAnalysisComponents(
 	ctx context.Context,
 	siteID int64,
@@ -44,35 +37,124 @@ func (r *PermissionAggregateRepositoryImpl) GetPermissionAnalysisComponents(
 
 	// Execute within a read transaction for consistency
 	err := r.WithReadTx(func(queries *db.Queries) error {
-		// Create audit-scoped assignment repository
-		scopedAssignmentRepo := NewScopedAssignmentRepository(r.BaseRepository, queries, siteID, auditRunID)
-		
-		// Get assignments for the list
-		assignments, err := scopedAssignmentRepo.GetAssignmentsForObject(ctx, siteID, "list", list.ID)
-		if err != nil {
-			return fmt.Errorf("failed to get assignments: %w", err)
-		}
+		var err error
+		components, err = r.loadPermissionAnalysisComponents(ctx, queries, siteID, auditRunID, list)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
 
-		// Get sharing links (don't fail if not available)
-		sharingLinks, err := r.sharingRepo.GetSharingLinksForList(ctx, siteID, list.ID)
-		if err != nil {
-			sharingLinks = nil // Continue without sharing links
+// loadPermissionAnalysisComponents runs one grouped query each for assignments, effective users, sharing
+// links and items.
+func (r *PermissionAggregateRepositoryImpl) loadPermissionAnalysisComponents(
+	ctx context.Context,
+	queries *db.Queries,
+	siteID int64,
+	auditRunID int64,
+	list *sharepoint.List,
+) (*contracts.PermissionAnalysisComponents, error) {
+	// Assignment counts for the list
+	assignmentRows, err := queries.CountAssignmentsForObjectByAuditRun(ctx, db.CountAssignmentsForObjectByAuditRunParams{
+		SiteID:     siteID,
+		ObjectType: "list",
+		ObjectKey:  list.ID,
+		AuditRunID: auditRunID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count assignments: %w", err)
+	}
+
+	assignmentCounts := make([]contracts.AssignmentCount, len(assignmentRows))
+	for i, row := range assignmentRows {
+		assignmentCounts[i] = contracts.AssignmentCount{
+			PrincipalType: row.PrincipalType,
+			RoleDefID:     row.RoleDefID,
+			RoleName:      row.RoleName,
+			SharingLink:   row.IsSharingLink != 0,
+			Count:         int(row.AssignmentCount),
 		}
+	}
+
+	// Distinct users the list's assignments reach, groups expanded
+	effectiveRow, err := queries.CountEffectiveUsersForObjectByAuditRun(ctx, db.CountEffectiveUsersForObjectByAuditRunParams{
+		SiteID:     siteID,
+		ObjectType: "list",
+		ObjectKey:  list.ID,
+		AuditRunID: auditRunID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count effective users: %w", err)
+	}
+	effectiveUsers := &contracts.EffectiveUserCounts{
+		Users:            int(effectiveRow.EffectiveUsers),
+		ResolvedGroups:   int(effectiveRow.ResolvedGroups),
+		UnresolvedGroups: int(effectiveRow.UnresolvedGroups),
+	}
 
-		// Get items (don't fail if not available)
-		items, err := r.itemRepo.GetItemsForList(ctx, siteID, list.ID, 0, 999999) // Get all items
-		if err != nil {
-			items = nil // Continue without items
+	// Sharing link counts (don't fail if not available)
+	var sharingLinkCounts []contracts.SharingLinkKindCount
+	linkRows, err := queries.CountSharingLinksForListByAuditRun(ctx, db.CountSharingLinksForListByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		ListID:     list.ID,
+	})
+	if err == nil {
+		sharingLinkCounts = make([]contracts.SharingLinkKindCount, len(linkRows))
+		for i, row := range linkRows {
+			sharingLinkCounts[i] = contracts.SharingLinkKindCount{
+				LinkKind:    int(r.FromNullInt64(row.LinkKind)),
+				LinkCount:   int(row.LinkCount),
+				MemberCount: int(row.MemberCount),
+			}
 		}
+	}
 
-		components = &contracts.PermissionAnalysisComponents{
-			Assignments:  assignments,
-			Items:        items,
-			SharingLinks: sharingLinks,
-			List:         list,
+	// Item counts (don't fail if not available; lists audited without item scanning have none)
+	var itemCounts *contracts.ItemCounts
+	itemRow, err := queries.CountItemsByKindForListByAuditRun(ctx, db.CountItemsByKindForListByAuditRunParams{
+		SiteID:     siteID,
+		ListID:     list.ID,
+		AuditRunID: auditRunID,
+	})
+	if err == nil && itemRow.TotalItems > 0 {
+		itemCounts = &contracts.ItemCounts{
+			TotalItems:      itemRow.TotalItems,
+			ItemsWithUnique: itemRow.ItemsWithUnique,
+			FilesCount:      itemRow.FilesCount,
+			FoldersCount:    itemRow.FoldersCount,
 		}
+	}
+
+	return &contracts.PermissionAnalysisComponents{
+		AssignmentCounts:  assignmentCounts,
+		SharingLinkCounts: sharingLinkCounts,
+		ItemCounts:        itemCounts,
+		EffectiveUsers:    effectiveUsers,
+		List:              list,
+	}, nil
+}
 
-		return nil
+// GetExceptedPermissionComponents retrieves grouped counts of what accepted exceptions leave out of a
+// list's permission analysis (audit-scoped): the list's accepted assignments, and its accepted
+// sharing links and unique items the run captured. Issues a query per accepted link and item.
+func (r *PermissionAggregateRepositoryImpl) GetExceptedPermissionComponents(
+	ctx context.Context,
+	siteID int64,
+	auditRunID int64,
+	list *sharepoint.List,
+	exceptions *audit.PermissionExceptionSet,
+) (*contracts.PermissionAnalysisComponents, error) {
+	var components *contracts.PermissionAnalysisComponents
+
+	err := r.WithReadTx(func(queries *db.Queries) error {
+		var err error
+		components, err = r.loadExceptedPermissionComponents(ctx, queries, siteID, auditRunID, list, exceptions)
+		return err
 	})
 
 	if err != nil {
@@ -81,3 +163,103 @@ func (r *PermissionAggregateRepositoryImpl) GetPermissionAnalysisComponents(
 
 	return components, nil
 }
+
+// loadExceptedPermissionComponents counts the list's accepted assignments, active sharing links and
+// items with unique permissions, grouped as loadPermissionAnalysisComponents groups them.
+func (r *PermissionAggregateRepositoryImpl) loadExceptedPermissionComponents(
+	ctx context.Context,
+	queries *db.Queries,
+	siteID int64,
+	auditRunID int64,
+	list *sharepoint.List,
+	exceptions *audit.PermissionExceptionSet,
+) (*contracts.PermissionAnalysisComponents, error) {
+	components := &contracts.PermissionAnalysisComponents{List: list}
+
+	// Accepted assignments on the list itself
+	assignmentRows, err := queries.GetAssignmentsForObjectByAuditRun(ctx, db.GetAssignmentsForObjectByAuditRunParams{
+		SiteID:     siteID,
+		ObjectType: "list",
+		ObjectKey:  list.ID,
+		AuditRunID: auditRunID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments: %w", err)
+	}
+	for _, row := range assignmentRows {
+		if !exceptions.CoversAssignment(list.ID, list.ID, row.PrincipalID, row.RoleDefID) {
+			continue
+		}
+		login := r.FromNullString(row.LoginName)
+		addAssignmentCount(components, contracts.AssignmentCount{
+			PrincipalType: row.PrincipalType,
+			RoleDefID:     row.RoleDefID,
+			RoleName:      row.RoleName,
+			SharingLink:   len(login) > 12 && strings.HasPrefix(login, "SharingLinks"),
+		})
+	}
+
+	for _, exception := range exceptions.ForList(list.ID) {
+		switch exception.Kind {
+		case audit.ExceptionSharingLink:
+			link, err := queries.GetSharingLinkByAuditRun(ctx, db.GetSharingLinkByAuditRunParams{
+				SiteID:     siteID,
+				AuditRunID: auditRunID,
+				LinkID:     exception.ObjectKey,
+			})
+			if errors.Is(err, sql.ErrNoRows) || (err == nil && !r.FromNullBool(link.IsActive)) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get sharing link %s: %w", exception.ObjectKey, err)
+			}
+			addSharingLinkCount(components, int(r.FromNullInt64(link.LinkKind)), int(link.ActualMembersCount))
+		case audit.ExceptionUniqueItem:
+			item, err := queries.GetItemByGUIDByAuditRun(ctx, db.GetItemByGUIDByAuditRunParams{
+				SiteID:     siteID,
+				ItemGuid:   exception.ObjectKey,
+				AuditRunID: auditRunID,
+			})
+			if errors.Is(err, sql.ErrNoRows) || (err == nil && (item.ListID != list.ID || !r.FromNullBool(item.HasUnique))) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to get item %s: %w", exception.ObjectKey, err)
+			}
+			if components.ItemCounts == nil {
+				components.ItemCounts = &contracts.ItemCounts{}
+			}
+			components.ItemCounts.ItemsWithUnique++
+		}
+	}
+
+	return components, nil
+}
+
+// addAssignmentCount counts an assignment in the group of its principal type and role
+func addAssignmentCount(components *contracts.PermissionAnalysisComponents, assignment contracts.AssignmentCount) {
+	for i, count := range components.AssignmentCounts {
+		if count.PrincipalType == assignment.PrincipalType && count.RoleDefID == assignment.RoleDefID && count.SharingLink == assignment.SharingLink {
+			components.AssignmentCounts[i].Count++
+			return
+		}
+	}
+	assignment.Count = 1
+	components.AssignmentCounts = append(components.AssignmentCounts, assignment)
+}
+
+// addSharingLinkCount counts a sharing link and its members in the group of its link kind
+func addSharingLinkCount(components *contracts.PermissionAnalysisComponents, linkKind, members int) {
+	for i, count := range components.SharingLinkCounts {
+		if count.LinkKind == linkKind {
+			components.SharingLinkCounts[i].LinkCount++
+			components.SharingLinkCounts[i].MemberCount += members
+			return
+		}
+	}
+	components.SharingLinkCounts = append(components.SharingLinkCounts, contracts.SharingLinkKindCount{
+		LinkKind:    linkKind,
+		LinkCount:   1,
+		MemberCount: members,
+	})
+}
@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/database"
+	"spaudit/domain/sharepoint"
+	"spaudit/gen/db"
+)
+
+// countingDBTX counts the statements issued through a sqlc Queries instance.
+type countingDBTX struct {
+	db.DBTX
+	queries int
+}
+
+func (c *countingDBTX) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	c.queries++
+	return c.DBTX.ExecContext(ctx, query, args...)
+}
+
+func (c *countingDBTX) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	c.queries++
+	return c.DBTX.QueryContext(ctx, query, args...)
+}
+
+func (c *countingDBTX) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	c.queries++
+	return c.DBTX.QueryRowContext(ctx, query, args...)
+}
+
+// newPermissionTestDatabase opens a migrated database with one site, audit run, web and list.
+func newPermissionTestDatabase(t *testing.T) *database.Database {
+	t.Helper()
+
+	testDB := newTestDatabase(t)
+
+	mustExec(t, testDB, `INSERT INTO sites (site_id, site_url, title) VALUES (1, 'https://contoso.sharepoint.com/sites/a', 'A')`)
+	mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-1', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`)
+	mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (1, 'job-1', 1, CURRENT_TIMESTAMP)`)
+	mustExec(t, testDB, `INSERT INTO webs (site_id, web_id, audit_run_id, title) VALUES (1, 'web-1', 1, 'Web')`)
+	mustExec(t, testDB, `INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title) VALUES (1, 'list-1', 1, 'web-1', 'Documents')`)
+	mustExec(t, testDB, `INSERT INTO role_definitions (site_id, role_def_id, audit_run_id, name) VALUES (1, 1, 1, 'Full Control'), (1, 2, 1, 'Read')`)
+
+	return testDB
+}
+
+// seedListPermissions adds n files with unique permissions, n user assignments and n anonymous view links to the list.
+func seedListPermissions(t *testing.T, testDB *database.Database, offset, n int) {
+	t.Helper()
+
+	for i := offset; i < offset+n; i++ {
+		mustExec(t, testDB, fmt.Sprintf(`INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, has_unique, is_file, is_folder) VALUES (1, 'item-%d', 1, 'list-1', %d, 1, 1, 0)`, i, i))
+		mustExec(t, testDB, fmt.Sprintf(`INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES (1, %d, 1, 'User %d', 'i:0#.f|membership|user%d', 1)`, i, i, i))
+		mustExec(t, testDB, fmt.Sprintf(`INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'list', 'list-1', %d, 2, 1)`, i))
+		mustExec(t, testDB, fmt.Sprintf(`INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, link_kind, is_active) VALUES (1, 'link-%d', 1, 'item-%d', 4, 1)`, i, i))
+		mustExec(t, testDB, fmt.Sprintf(`INSERT INTO sharing_link_members (site_id, link_id, principal_id, audit_run_id) VALUES (1, 'link-%d', %d, 1)`, i, i))
+	}
+}
+
+func TestPermissionAggregateRepository_QueryCountIndependentOfListSize(t *testing.T) {
+	ctx := context.Background()
+	testDB := newPermissionTestDatabase(t)
+	repo := &PermissionAggregateRepositoryImpl{BaseRepository: NewBaseRepository(testDB)}
+	list := &sharepoint.List{SiteID: 1, ID: "list-1"}
+
+	load := func() (int, int) {
+		counter := &countingDBTX{DBTX: testDB.ReadDB()}
+		components, err := repo.loadPermissionAnalysisComponents(ctx, db.New(counter), 1, 1, list)
+		require.NoError(t, err)
+		require.NotNil(t, components.ItemCounts)
+		return counter.queries, int(components.ItemCounts.TotalItems)
+	}
+
+	seedListPermissions(t, testDB, 1, 2)
+	smallQueries, smallItems := load()
+
+	seedListPermissions(t, testDB, 3, 48)
+	largeQueries, largeItems := load()
+
+	assert.Equal(t, 2, smallItems)
+	assert.Equal(t, 50, largeItems)
+	assert.Equal(t, 4, smallQueries, "one grouped query each for assignments, effective users, sharing links and items")
+	assert.Equal(t, smallQueries, largeQueries, "query count must not grow with the number of items, assignments or links")
+}
+
+func TestPermissionAggregateRepository_GroupedCounts(t *testing.T) {
+	ctx := context.Background()
+	testDB := newPermissionTestDatabase(t)
+	repo := &PermissionAggregateRepositoryImpl{BaseRepository: NewBaseRepository(testDB)}
+	list := &sharepoint.List{SiteID: 1, ID: "list-1"}
+
+	seedListPermissions(t, testDB, 1, 3)
+	mustExec(t, testDB, `INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, has_unique, is_file, is_folder) VALUES (1, 'folder-1', 1, 'list-1', 100, 0, 0, 1)`)
+	mustExec(t, testDB, `INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES (1, 100, 1, 'Owners', 'Owners', 8)`)
+	mustExec(t, testDB, `INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'list', 'list-1', 100, 1, 1)`)
+
+	components, err := repo.GetPermissionAnalysisComponents(ctx, 1, 1, list)
+	require.NoError(t, err)
+
+	totalAssignments := 0
+	for _, count := range components.AssignmentCounts {
+		totalAssignments += count.Count
+		switch count.RoleName {
+		case "Read":
+			assert.Equal(t, int64(sharepoint.PrincipalTypeUser), count.PrincipalType)
+			assert.Equal(t, 3, count.Count)
+		case "Full Control":
+			assert.Equal(t, int64(sharepoint.PrincipalTypeSharePointGroup), count.PrincipalType)
+			assert.Equal(t, 1, count.Count)
+		}
+	}
+	assert.Equal(t, 4, totalAssignments)
+
+	require.Len(t, components.SharingLinkCounts, 1)
+	assert.Equal(t, sharepoint.LinkKindAnonymousView, components.SharingLinkCounts[0].LinkKind)
+	assert.Equal(t, 3, components.SharingLinkCounts[0].LinkCount)
+	assert.Equal(t, 3, components.SharingLinkCounts[0].MemberCount)
+
+	require.NotNil(t, components.ItemCounts)
+	assert.Equal(t, int64(4), components.ItemCounts.TotalItems)
+	assert.Equal(t, int64(3), components.ItemCounts.ItemsWithUnique)
+	assert.Equal(t, int64(3), components.ItemCounts.FilesCount)
+	assert.Equal(t, int64(1), components.ItemCounts.FoldersCount)
+}
+
+func TestPermissionAggregateRepository_EffectiveUsers(t *testing.T) {
+	ctx := context.Background()
+	testDB := newPermissionTestDatabase(t)
+	repo := &PermissionAggregateRepositoryImpl{BaseRepository: NewBaseRepository(testDB)}
+	auditRepo := NewSqlcAuditRepository(testDB)
+	list := &sharepoint.List{SiteID: 1, ID: "list-1"}
+
+	// user1 and user2 directly, user2 and user3 again through a SharePoint group holding an Entra group
+	seedListPermissions(t, testDB, 1, 2)
+	mustExec(t, testDB, `INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES
+		(1, 3, 1, 'User 3', 'i:0#.f|membership|user3', 1),
+		(1, 100, 1, 'Members', 'Members', 8),
+		(1, 200, 1, 'Engineering', 'c:0t.c|tenant|group-a', 4),
+		(1, 201, 1, 'Project', 'c:0o.c|federateddirectoryclaimprovider|group-b', 4),
+		(1, 202, 1, 'Everyone except external users', 'c:0-.f|rolemanager|spo-grid-all-users/tenant', 4)`)
+	mustExec(t, testDB, `INSERT INTO group_members (site_id, group_id, member_id, audit_run_id) VALUES (1, 100, 2, 1), (1, 100, 3, 1), (1, 100, 200, 1)`)
+	mustExec(t, testDB, `INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES
+		(1, 'list', 'list-1', 100, 1, 1), (1, 'list', 'list-1', 201, 2, 1), (1, 'list', 'list-1', 202, 2, 1)`)
+
+	group := sharepoint.EntraGroupRef{ObjectID: "group-a"}
+	require.NoError(t, auditRepo.SaveEntraGroupMembers(ctx, 1, 1, 200, group, time.Now(), []*sharepoint.EntraGroupMember{
+		{ObjectID: "obj-9", UserPrincipalName: "stale@contoso.com"},
+	}))
+	// A resumed run resolves the group again, replacing what the earlier attempt saved
+	require.NoError(t, auditRepo.SaveEntraGroupMembers(ctx, 1, 1, 200, group, time.Now(), []*sharepoint.EntraGroupMember{
+		{ObjectID: "obj-1", UserPrincipalName: "USER1"},
+		{ObjectID: "obj-4", UserPrincipalName: "user4", UserType: "Guest"},
+		{ObjectID: "obj-5"},
+	}))
+
+	components, err := repo.GetPermissionAnalysisComponents(ctx, 1, 1, list)
+	require.NoError(t, err)
+
+	require.NotNil(t, components.EffectiveUsers)
+	assert.Equal(t, 5, components.EffectiveUsers.Users, "user1 to user4 and the member without a UPN, each once")
+	assert.Equal(t, 1, components.EffectiveUsers.ResolvedGroups)
+	assert.Equal(t, 1, components.EffectiveUsers.UnresolvedGroups, "the unresolved Microsoft 365 group; Everyone claims are no Entra group")
+}
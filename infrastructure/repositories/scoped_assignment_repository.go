@@ -47,6 +47,38 @@ func (r *ScopedAssignmentRepository) GetAssignmentsForObject(ctx context.Context
 		return nil, err
 	}
 
+	return r.assignmentsFromRows(objectType, objectKey, rows), nil
+}
+
+// GetAssignmentsPageForObject retrieves up to limit role assignments for an object scoped to audit run,
+// starting at offset, in the same order as GetAssignmentsForObject
+func (r *ScopedAssignmentRepository) GetAssignmentsPageForObject(ctx context.Context, siteID int64, objectType, objectKey string, offset, limit int) ([]*sharepoint.Assignment, error) {
+	// Verify the requested siteID matches our scoped siteID
+	if siteID != r.siteID {
+		return nil, contracts.ErrSiteScopeMismatch
+	}
+
+	pageRows, err := r.queries.GetAssignmentsPageForObjectByAuditRun(ctx, db.GetAssignmentsPageForObjectByAuditRunParams{
+		SiteID:     r.siteID,
+		ObjectType: objectType,
+		ObjectKey:  objectKey,
+		AuditRunID: r.auditRunID,
+		Offset:     int64(offset),
+		Limit:      int64(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]db.GetAssignmentsForObjectByAuditRunRow, len(pageRows))
+	for i, row := range pageRows {
+		rows[i] = db.GetAssignmentsForObjectByAuditRunRow(row)
+	}
+	return r.assignmentsFromRows(objectType, objectKey, rows), nil
+}
+
+// assignmentsFromRows converts assignment rows of an object to domain objects
+func (r *ScopedAssignmentRepository) assignmentsFromRows(objectType, objectKey string, rows []db.GetAssignmentsForObjectByAuditRunRow) []*sharepoint.Assignment {
 	// Convert database rows to domain objects
 	var assignments []*sharepoint.Assignment
 	for _, row := range rows {
@@ -88,7 +120,7 @@ func (r *ScopedAssignmentRepository) GetAssignmentsForObject(ctx context.Context
 		assignments = append(assignments, assignment)
 	}
 
-	return assignments, nil
+	return assignments
 }
 
 // GetResolvedAssignmentsForObject retrieves role assignments with root cause analysis scoped to audit run
@@ -103,7 +135,21 @@ func (r *ScopedAssignmentRepository) GetResolvedAssignmentsForObject(ctx context
 	if err != nil {
 		return nil, err
 	}
+	return r.resolveAssignments(ctx, objectType, objectKey, assignments)
+}
+
+// GetResolvedAssignmentsPageForObject retrieves up to limit role assignments for an object with root cause
+// analysis scoped to audit run, starting at offset. Only the page's assignments are analyzed.
+func (r *ScopedAssignmentRepository) GetResolvedAssignmentsPageForObject(ctx context.Context, siteID int64, objectType, objectKey string, offset, limit int) ([]*sharepoint.ResolvedAssignment, error) {
+	assignments, err := r.GetAssignmentsPageForObject(ctx, siteID, objectType, objectKey, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return r.resolveAssignments(ctx, objectType, objectKey, assignments)
+}
 
+// resolveAssignments analyzes the root causes of an object's assignments
+func (r *ScopedAssignmentRepository) resolveAssignments(ctx context.Context, objectType, objectKey string, assignments []*sharepoint.Assignment) ([]*sharepoint.ResolvedAssignment, error) {
 	// Get the parent web ID for inheritance analysis
 	webId, err := r.queries.GetWebIdForObject(ctx, db.GetWebIdForObjectParams{
 		SiteID:     r.siteID,
@@ -140,6 +186,16 @@ func (r *ScopedAssignmentRepository) analyzeRootCause(ctx context.Context, assig
 	loginName := assignment.Principal.LoginName
 	var rootCauses []sharepoint.RootCause
 
+	// Anything other than Limited Access was granted explicitly on this object
+	if !assignment.RoleDefinition.IsLimitedAccess() {
+		rootCauses = append(rootCauses, sharepoint.RootCause{
+			Type:         sharepoint.RootCauseTypeDirect,
+			Detail:       fmt.Sprintf("Assigned %s directly on this %s", assignment.RoleDefinition.Name, assignment.RoleAssignment.ObjectType),
+			SourceObject: assignment.RoleAssignment.ObjectType,
+			SourceRole:   assignment.RoleDefinition.Name,
+		})
+	}
+
 	// Check if it's a sharing link
 	if strings.HasPrefix(loginName, "SharingLinks.") {
 		sharingLinkCause := r.analyzeSharingLinkRootCause(ctx, assignment, loginName)
@@ -167,6 +223,10 @@ func (r *ScopedAssignmentRepository) analyzeRootCause(ctx context.Context, assig
 	if err == nil && len(rootPerms) > 0 {
 		// Process ALL root permissions, not just the first one
 		for _, rootPerm := range rootPerms {
+			// The assignment being analyzed is not its own source
+			if rootPerm.ObjectType == assignment.RoleAssignment.ObjectType && rootPerm.ObjectKey == assignment.RoleAssignment.ObjectKey && rootPerm.RoleName == assignment.RoleDefinition.Name {
+				continue
+			}
 			objectName := "Unknown"
 			if rootPerm.ObjectName != nil {
 				if name, ok := rootPerm.ObjectName.(string); ok {
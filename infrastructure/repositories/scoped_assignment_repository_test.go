@@ -0,0 +1,47 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/sharepoint"
+)
+
+func TestScopedAssignmentRepository_ResolvesWebAssignments(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO role_definitions (site_id, role_def_id, audit_run_id, name) VALUES (1, 3, 1, 'Limited Access')`)
+	mustExec(t, testDB, `INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES (1, 1, 1, 'A Owners', 'A Owners', 8), (1, 2, 1, 'Contractor', 'i:0#.f|membership|contractor', 1)`)
+	// Owners hold Full Control on the web; the contractor only has Read on the list, which leaves Limited Access on the web
+	mustExec(t, testDB, `INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES
+		(1, 'web', 'web-1', 1, 1, 1),
+		(1, 'web', 'web-1', 2, 3, 1),
+		(1, 'list', 'list-1', 2, 2, 1)`)
+
+	base := NewBaseRepository(testDB)
+	repo := NewScopedAssignmentRepository(base, base.ReadQueries(), 1, 1)
+
+	resolved, err := repo.GetResolvedAssignmentsForObject(context.Background(), 1, sharepoint.ObjectTypeWeb, "web-1")
+	require.NoError(t, err)
+	require.Len(t, resolved, 2)
+
+	byPrincipal := make(map[int64][]sharepoint.RootCause)
+	for _, r := range resolved {
+		byPrincipal[r.Assignment.Principal.ID] = r.RootCauses
+	}
+
+	// A direct grant is explained by itself, not by a self-referencing inheritance cause
+	owners := byPrincipal[1]
+	require.Len(t, owners, 1)
+	assert.Equal(t, sharepoint.RootCauseTypeDirect, owners[0].Type)
+	assert.Equal(t, "Assigned Full Control directly on this web", owners[0].Detail)
+
+	// Limited Access on the web is traced back to the list grant
+	contractor := byPrincipal[2]
+	require.Len(t, contractor, 1)
+	assert.Equal(t, sharepoint.RootCauseTypeInheritance, contractor[0].Type)
+	assert.Equal(t, "Documents", contractor[0].SourceObject)
+	assert.Equal(t, "Read", contractor[0].SourceRole)
+}
@@ -2,7 +2,6 @@ package repositories
 
 import (
 	"context"
-	"database/sql"
 
 	"spaudit/domain/contracts"
 	"spaudit/domain/sharepoint"
@@ -35,31 +34,33 @@ func (r *ScopedItemRepository) GetByGUID(ctx context.Context, siteID int64, item
 	}
 
 	// Get item with audit run scoping
-	row, err := r.queries.GetItemByGUID(ctx, db.GetItemByGUIDParams{
-		SiteID:   r.siteID,
-		ItemGuid: itemGUID,
+	row, err := r.queries.GetItemByGUIDByAuditRun(ctx, db.GetItemByGUIDByAuditRunParams{
+		SiteID:     r.siteID,
+		ItemGuid:   itemGUID,
+		AuditRunID: r.auditRunID,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// Verify the item is from the correct audit run
-	if row.AuditRunID != r.auditRunID {
-		return nil, sql.ErrNoRows // Item not found in this audit run
-	}
-
 	return &sharepoint.Item{
-		SiteID:       row.SiteID,
-		GUID:         row.ItemGuid,
-		ListItemGUID: r.FromNullString(row.ListItemGuid),
-		ListID:       row.ListID,
-		ID:           int(row.ItemID),
-		URL:          r.FromNullString(row.Url),
-		IsFile:       r.FromNullBool(row.IsFile),
-		IsFolder:     r.FromNullBool(row.IsFolder),
-		HasUnique:    r.FromNullBool(row.HasUnique),
-		Name:         r.FromNullString(row.Name),
-		AuditRunID:   &r.auditRunID,
+		SiteID:             row.SiteID,
+		GUID:               row.ItemGuid,
+		ListItemGUID:       r.FromNullString(row.ListItemGuid),
+		ListID:             row.ListID,
+		ID:                 int(row.ItemID),
+		URL:                r.FromNullString(row.Url),
+		IsFile:             r.FromNullBool(row.IsFile),
+		IsFolder:           r.FromNullBool(row.IsFolder),
+		HasUnique:          r.FromNullBool(row.HasUnique),
+		Name:               r.FromNullString(row.Name),
+		AuditRunID:         &r.auditRunID,
+		FileFolderUniqueID: r.FromNullString(row.FileFolderUniqueID),
+		Size:               r.FromNullInt64(row.FileSize),
+		CreatedAt:          r.FromNullTime(row.SpCreatedAt),
+		ModifiedAt:         r.FromNullTime(row.SpModifiedAt),
+		ContentTypeID:      r.FromNullString(row.ContentTypeID),
+		UnreadableFields:   r.FromNullList(row.UnreadableFields),
 	}, nil
 }
 
@@ -98,6 +99,8 @@ func (r *ScopedItemRepository) GetItemsForList(ctx context.Context, siteID int64
 			HasUnique:    r.FromNullBool(row.HasUnique),
 			Name:         r.FromNullString(row.Name),
 			AuditRunID:   &r.auditRunID,
+
+			UnreadableFields: r.FromNullList(row.UnreadableFields),
 		}
 		items = append(items, item)
 	}
@@ -147,6 +150,63 @@ func (r *ScopedItemRepository) GetItemsWithUniqueForList(ctx context.Context, si
 	return items, nil
 }
 
+// GetFilteredItemsForList gets a page of items for a list matching the filter scoped to audit run
+func (r *ScopedItemRepository) GetFilteredItemsForList(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter, offset, limit int64) ([]*sharepoint.Item, error) {
+	// Verify the requested siteID matches our scoped siteID
+	if siteID != r.siteID {
+		return nil, contracts.ErrSiteScopeMismatch
+	}
+
+	rows, err := r.queries.FilteredItemsForListByAuditRun(ctx, db.FilteredItemsForListByAuditRunParams{
+		SiteID:     r.siteID,
+		ListID:     listID,
+		AuditRunID: r.auditRunID,
+		UniqueOnly: r.BoolToInt64(filter.UniqueOnly),
+		Kind:       filter.Kind,
+		Offset:     offset,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Transform rows to domain objects
+	items := make([]*sharepoint.Item, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, &sharepoint.Item{
+			SiteID:       row.SiteID,
+			GUID:         row.ItemGuid,
+			ListItemGUID: r.FromNullString(row.ListItemGuid),
+			ListID:       row.ListID,
+			ID:           int(row.ItemID),
+			URL:          r.FromNullString(row.Url),
+			IsFile:       r.FromNullBool(row.IsFile),
+			IsFolder:     r.FromNullBool(row.IsFolder),
+			HasUnique:    r.FromNullBool(row.HasUnique),
+			Name:         r.FromNullString(row.Name),
+			AuditRunID:   &r.auditRunID,
+		})
+	}
+
+	return items, nil
+}
+
+// CountFilteredItemsForList counts the items for a list matching the filter scoped to audit run
+func (r *ScopedItemRepository) CountFilteredItemsForList(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter) (int64, error) {
+	// Verify the requested siteID matches our scoped siteID
+	if siteID != r.siteID {
+		return 0, contracts.ErrSiteScopeMismatch
+	}
+
+	return r.queries.CountFilteredItemsForListByAuditRun(ctx, db.CountFilteredItemsForListByAuditRunParams{
+		SiteID:     r.siteID,
+		ListID:     listID,
+		AuditRunID: r.auditRunID,
+		UniqueOnly: r.BoolToInt64(filter.UniqueOnly),
+		Kind:       filter.Kind,
+	})
+}
+
 // Save is not implemented for scoped repository (use audit repository for saving)
 func (r *ScopedItemRepository) Save(ctx context.Context, item *sharepoint.Item) error {
 	panic("Save not supported on scoped repository - use audit repository for saving")
@@ -90,6 +90,10 @@ func (r *ScopedJobRepository) CancelJob(ctx context.Context, jobID string) error
 	panic("CancelJob not supported on scoped repository - use unscoped repository for job management")
 }
 
+func (r *ScopedJobRepository) ReopenJob(ctx context.Context, jobID string) error {
+	panic("ReopenJob not supported on scoped repository - use unscoped repository for job management")
+}
+
 func (r *ScopedJobRepository) DeleteOldJobs(ctx context.Context, olderThan time.Time) error {
 	panic("DeleteOldJobs not supported on scoped repository - use unscoped repository for job management")
 }
\ No newline at end of file
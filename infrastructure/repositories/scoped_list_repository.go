@@ -58,16 +58,28 @@ func (r *ScopedListRepository) getListsForSiteAndAuditRun(ctx context.Context, s
 		rows = make([]db.GetListsByAuditRunRow, len(uniqueRows))
 		for i, ur := range uniqueRows {
 			rows[i] = db.GetListsByAuditRunRow{
-				SiteID:       ur.SiteID,
-				ListID:       ur.ListID,
-				WebID:        ur.WebID,
-				Title:        ur.Title,
-				Url:          ur.Url,
-				BaseTemplate: ur.BaseTemplate,
-				ItemCount:    ur.ItemCount,
-				HasUnique:    ur.HasUnique,
-				WebTitle:     ur.WebTitle,
-				AuditRunID:   ur.AuditRunID,
+				SiteID:           ur.SiteID,
+				ListID:           ur.ListID,
+				WebID:            ur.WebID,
+				Title:            ur.Title,
+				Url:              ur.Url,
+				BaseTemplate:     ur.BaseTemplate,
+				ItemCount:        ur.ItemCount,
+				HasUnique:        ur.HasUnique,
+				WebTitle:         ur.WebTitle,
+				AuditRunID:       ur.AuditRunID,
+				UniqueItemCount:  ur.UniqueItemCount,
+				UniqueDensity:    ur.UniqueDensity,
+				SampledItemCount: ur.SampledItemCount,
+
+				UniqueAssignmentCount:  ur.UniqueAssignmentCount,
+				ExternalPrincipalCount: ur.ExternalPrincipalCount,
+				SharingLinkCount:       ur.SharingLinkCount,
+
+				ReadSecurity:     ur.ReadSecurity,
+				WriteSecurity:    ur.WriteSecurity,
+				CommentsDisabled: ur.CommentsDisabled,
+				RatingExperience: ur.RatingExperience,
 			}
 		}
 	} else {
@@ -84,15 +96,24 @@ func (r *ScopedListRepository) getListsForSiteAndAuditRun(ctx context.Context, s
 	lists := make([]*sharepoint.List, 0, len(rows))
 	for _, row := range rows {
 		list := &sharepoint.List{
-			ID:           row.ListID,
-			SiteID:       row.SiteID,
-			WebID:        row.WebID,
-			Title:        row.Title,
-			URL:          r.FromNullString(row.Url),
-			BaseTemplate: int(r.FromNullInt64(row.BaseTemplate)),
-			ItemCount:    int(r.FromNullInt64(row.ItemCount)),
-			HasUnique:    r.FromNullBool(row.HasUnique),
-			AuditRunID:   &r.auditRunID,
+			ID:               row.ListID,
+			SiteID:           row.SiteID,
+			WebID:            row.WebID,
+			Title:            row.Title,
+			URL:              r.FromNullString(row.Url),
+			BaseTemplate:     int(r.FromNullInt64(row.BaseTemplate)),
+			ItemCount:        int(r.FromNullInt64(row.ItemCount)),
+			HasUnique:        r.FromNullBool(row.HasUnique),
+			UniqueItemCount:  int(r.FromNullInt64(row.UniqueItemCount)),
+			UniqueDensity:    r.FromNullFloat64(row.UniqueDensity),
+			SampledItemCount: int(r.FromNullInt64(row.SampledItemCount)),
+			AuditRunID:       &r.auditRunID,
+
+			UniqueAssignmentCount:  int(r.FromNullInt64(row.UniqueAssignmentCount)),
+			ExternalPrincipalCount: int(r.FromNullInt64(row.ExternalPrincipalCount)),
+			SharingLinkCount:       int(r.FromNullInt64(row.SharingLinkCount)),
+
+			ItemSettings: toListItemSettings(row.ReadSecurity, row.WriteSecurity, row.CommentsDisabled, row.RatingExperience),
 		}
 		lists = append(lists, list)
 	}
@@ -120,15 +141,24 @@ func (r *ScopedListRepository) GetByID(ctx context.Context, siteID int64, listID
 
 	// Convert to domain object
 	list := &sharepoint.List{
-		ID:           row.ListID,
-		SiteID:       row.SiteID,
-		WebID:        row.WebID,
-		Title:        row.Title,
-		URL:          r.FromNullString(row.Url),
-		BaseTemplate: int(r.FromNullInt64(row.BaseTemplate)),
-		ItemCount:    int(r.FromNullInt64(row.ItemCount)),
-		HasUnique:    r.FromNullBool(row.HasUnique),
-		AuditRunID:   &r.auditRunID,
+		ID:               row.ListID,
+		SiteID:           row.SiteID,
+		WebID:            row.WebID,
+		Title:            row.Title,
+		URL:              r.FromNullString(row.Url),
+		BaseTemplate:     int(r.FromNullInt64(row.BaseTemplate)),
+		ItemCount:        int(r.FromNullInt64(row.ItemCount)),
+		HasUnique:        r.FromNullBool(row.HasUnique),
+		UniqueItemCount:  int(r.FromNullInt64(row.UniqueItemCount)),
+		UniqueDensity:    r.FromNullFloat64(row.UniqueDensity),
+		SampledItemCount: int(r.FromNullInt64(row.SampledItemCount)),
+		AuditRunID:       &r.auditRunID,
+
+		UniqueAssignmentCount:  int(r.FromNullInt64(row.UniqueAssignmentCount)),
+		ExternalPrincipalCount: int(r.FromNullInt64(row.ExternalPrincipalCount)),
+		SharingLinkCount:       int(r.FromNullInt64(row.SharingLinkCount)),
+
+		ItemSettings: toListItemSettings(row.ReadSecurity, row.WriteSecurity, row.CommentsDisabled, row.RatingExperience),
 	}
 
 	return list, nil
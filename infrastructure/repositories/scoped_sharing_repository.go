@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 
 	"spaudit/domain/contracts"
 	"spaudit/domain/sharepoint"
@@ -136,6 +137,17 @@ func (r *ScopedSharingRepository) GetSharingLinksWithItemDataForList(ctx context
 			ItemIsFile:   isFile,
 			ItemIsFolder: isFolder,
 		}
+
+		// Attach usage analytics when they were collected for the item
+		if row.ItemViewCount.Valid {
+			linkWithData.ItemAnalytics = &sharepoint.ItemAnalytics{
+				SiteID:      r.siteID,
+				ItemGUID:    link.FileFolderUniqueID,
+				AuditRunID:  r.auditRunID,
+				ViewCount:   row.ItemViewCount.Int64,
+				ViewerCount: r.FromNullInt64(row.ItemViewerCount),
+			}
+		}
 		
 		links = append(links, linkWithData)
 	}
@@ -178,3 +190,81 @@ func (r *ScopedSharingRepository) GetSharingLinkMembers(ctx context.Context, sit
 	return principals, nil
 }
 
+// GetSensitivityLabelsForList retrieves item sensitivity labels for a list scoped to audit run
+func (r *ScopedSharingRepository) GetSensitivityLabelsForList(ctx context.Context, siteID int64, listID string) ([]*sharepoint.ItemSensitivityLabel, error) {
+	// Verify the requested siteID matches our scoped siteID
+	if siteID != r.siteID {
+		return nil, contracts.ErrSiteScopeMismatch
+	}
+
+	rows, err := r.queries.GetSensitivityLabelsForListByAuditRun(ctx, db.GetSensitivityLabelsForListByAuditRunParams{
+		SiteID:     r.siteID,
+		ListID:     listID,
+		AuditRunID: r.auditRunID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Transform SQLC rows to domain labels
+	var labels []*sharepoint.ItemSensitivityLabel
+	for _, row := range rows {
+		label := &sharepoint.ItemSensitivityLabel{
+			SiteID:           r.siteID,
+			ItemGUID:         row.ItemGuid,
+			AuditRunID:       r.auditRunID,
+			LabelID:          r.FromNullString(row.LabelID),
+			DisplayName:      r.FromNullString(row.DisplayName),
+			OwnerEmail:       r.FromNullString(row.OwnerEmail),
+			SetDate:          r.FromNullTime(row.SetDate),
+			AssignmentMethod: r.FromNullString(row.AssignmentMethod),
+			HasIRMProtection: r.FromNullBool(row.HasIrmProtection),
+			ContentBits:      int(r.FromNullInt64(row.ContentBits)),
+			LabelFlags:       int(r.FromNullInt64(row.LabelFlags)),
+			DiscoveredAt:     row.DiscoveredAt.Time,
+			PromotionVersion: int(r.FromNullInt64(row.PromotionVersion)),
+			LabelHash:        r.FromNullString(row.LabelHash),
+		}
+
+		labels = append(labels, label)
+	}
+
+	return labels, nil
+}
+
+// GetItemSensitivityLabel retrieves the sensitivity label for an item scoped to audit run, or nil if it has none
+func (r *ScopedSharingRepository) GetItemSensitivityLabel(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.ItemSensitivityLabel, error) {
+	// Verify the requested siteID matches our scoped siteID
+	if siteID != r.siteID {
+		return nil, contracts.ErrSiteScopeMismatch
+	}
+
+	row, err := r.queries.GetItemSensitivityLabelByAuditRun(ctx, db.GetItemSensitivityLabelByAuditRunParams{
+		SiteID:     r.siteID,
+		ItemGuid:   itemGUID,
+		AuditRunID: r.auditRunID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Item has no label in this audit run
+		}
+		return nil, err
+	}
+
+	return &sharepoint.ItemSensitivityLabel{
+		SiteID:           r.siteID,
+		ItemGUID:         row.ItemGuid,
+		AuditRunID:       r.auditRunID,
+		LabelID:          r.FromNullString(row.LabelID),
+		DisplayName:      r.FromNullString(row.DisplayName),
+		OwnerEmail:       r.FromNullString(row.OwnerEmail),
+		SetDate:          r.FromNullTime(row.SetDate),
+		AssignmentMethod: r.FromNullString(row.AssignmentMethod),
+		HasIRMProtection: r.FromNullBool(row.HasIrmProtection),
+		ContentBits:      int(r.FromNullInt64(row.ContentBits)),
+		LabelFlags:       int(r.FromNullInt64(row.LabelFlags)),
+		DiscoveredAt:     row.DiscoveredAt.Time,
+		PromotionVersion: int(r.FromNullInt64(row.PromotionVersion)),
+		LabelHash:        r.FromNullString(row.LabelHash),
+	}, nil
+}
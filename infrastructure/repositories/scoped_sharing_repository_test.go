@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/sharepoint"
+)
+
+func TestScopedSharingRepository_RanksLinksByItemViews(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, name, is_file) VALUES
+		(1, 'quiet', 1, 'list-1', 1, 'quiet.docx', 1),
+		(1, 'popular', 1, 'list-1', 2, 'popular.docx', 1),
+		(1, 'unknown', 1, 'list-1', 3, 'unknown.docx', 1)`)
+	mustExec(t, testDB, `INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, file_folder_unique_id, link_kind, is_active) VALUES
+		(1, 'link-quiet', 1, 'quiet', 'quiet', 4, 1),
+		(1, 'link-popular', 1, 'popular', 'popular', 4, 1),
+		(1, 'link-unknown', 1, 'unknown', 'unknown', 4, 1)`)
+
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+	require.NoError(t, auditRepo.SaveItemAnalytics(ctx, &sharepoint.ItemAnalytics{SiteID: 1, AuditRunID: 1, ItemGUID: "quiet", ViewCount: 2, ViewerCount: 1}))
+	require.NoError(t, auditRepo.SaveItemAnalytics(ctx, &sharepoint.ItemAnalytics{SiteID: 1, AuditRunID: 1, ItemGUID: "popular", ViewCount: 40, ViewerCount: 12}))
+
+	base := NewBaseRepository(testDB)
+	repo := NewScopedSharingRepository(base, base.ReadQueries(), 1, 1)
+
+	links, err := repo.GetSharingLinksWithItemDataForList(ctx, 1, "list-1")
+	require.NoError(t, err)
+	require.Len(t, links, 3)
+
+	// Widely-accessed items come first; items without analytics go last
+	assert.Equal(t, "link-popular", links[0].ID)
+	require.NotNil(t, links[0].ItemAnalytics)
+	assert.Equal(t, int64(40), links[0].ItemAnalytics.ViewCount)
+	assert.Equal(t, int64(12), links[0].ItemAnalytics.ViewerCount)
+
+	assert.Equal(t, "link-quiet", links[1].ID)
+	require.NotNil(t, links[1].ItemAnalytics)
+	assert.Equal(t, int64(2), links[1].ItemAnalytics.ViewCount)
+
+	assert.Equal(t, "link-unknown", links[2].ID)
+	assert.Nil(t, links[2].ItemAnalytics)
+}
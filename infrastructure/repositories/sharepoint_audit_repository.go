@@ -2,7 +2,9 @@ package repositories
 
 import (
 	"context"
+	"time"
 
+	"spaudit/domain/audit"
 	"spaudit/domain/contracts"
 	"spaudit/domain/sharepoint"
 )
@@ -66,6 +68,21 @@ func (r *SharePointAuditRepositoryImpl) SaveList(ctx context.Context, list *shar
 	return r.auditRepo.SaveList(ctx, r.auditRunID, list)
 }
 
+// UpdateListUniqueDensity recomputes unique-permission density for every list in the scoped audit run.
+func (r *SharePointAuditRepositoryImpl) UpdateListUniqueDensity(ctx context.Context) error {
+	return r.auditRepo.UpdateListUniqueDensity(ctx, r.auditRunID, r.siteID)
+}
+
+// UpdateListAccessCounts recomputes assignment, external principal and sharing link counts for every list in the scoped audit run.
+func (r *SharePointAuditRepositoryImpl) UpdateListAccessCounts(ctx context.Context) error {
+	return r.auditRepo.UpdateListAccessCounts(ctx, r.auditRunID, r.siteID)
+}
+
+// UpdateListSampling records how many items of a sampled list were deep-scanned in the scoped audit run.
+func (r *SharePointAuditRepositoryImpl) UpdateListSampling(ctx context.Context, listID string, sampledItemCount int) error {
+	return r.auditRepo.UpdateListSampling(ctx, r.auditRunID, r.siteID, listID, sampledItemCount)
+}
+
 // SaveItem persists an item with automatic site ID and audit run ID assignment.
 func (r *SharePointAuditRepositoryImpl) SaveItem(ctx context.Context, item *sharepoint.Item) error {
 	item.SiteID = r.siteID
@@ -97,9 +114,38 @@ func (r *SharePointAuditRepositoryImpl) SaveRoleAssignments(ctx context.Context,
 	return r.auditRepo.SaveRoleAssignments(ctx, r.auditRunID, r.siteID, assignments)
 }
 
-// ClearRoleAssignments clears role assignments for an object using the scoped site ID.
-func (r *SharePointAuditRepositoryImpl) ClearRoleAssignments(ctx context.Context, objectType, objectKey string) error {
-	return r.auditRepo.ClearRoleAssignments(ctx, r.siteID, objectType, objectKey)
+// SaveGroupMembers persists the members of a SharePoint group with automatic site ID assignment.
+func (r *SharePointAuditRepositoryImpl) SaveGroupMembers(ctx context.Context, groupID int64, members []*sharepoint.Principal) error {
+	for _, member := range members {
+		member.SiteID = r.siteID
+	}
+	return r.auditRepo.SaveGroupMembers(ctx, r.auditRunID, r.siteID, groupID, members)
+}
+
+// GetSecurityGroupPrincipals retrieves the security group principals the scoped audit run recorded.
+func (r *SharePointAuditRepositoryImpl) GetSecurityGroupPrincipals(ctx context.Context) ([]*sharepoint.Principal, error) {
+	return r.auditRepo.GetSecurityGroupPrincipals(ctx, r.auditRunID, r.siteID)
+}
+
+// SaveEntraGroupMembers records the users the scoped audit run resolved an Entra group principal to.
+func (r *SharePointAuditRepositoryImpl) SaveEntraGroupMembers(ctx context.Context, principalID int64, group sharepoint.EntraGroupRef, resolvedAt time.Time, members []*sharepoint.EntraGroupMember) error {
+	for _, member := range members {
+		member.SiteID = r.siteID
+		member.AuditRunID = r.auditRunID
+		member.PrincipalID = principalID
+	}
+	return r.auditRepo.SaveEntraGroupMembers(ctx, r.auditRunID, r.siteID, principalID, group, resolvedAt, members)
+}
+
+// ReplaceRoleAssignments replaces the role assignments of an object in the scoped audit run.
+// The assignments are stored against the given object.
+func (r *SharePointAuditRepositoryImpl) ReplaceRoleAssignments(ctx context.Context, objectType, objectKey string, assignments []*sharepoint.RoleAssignment) error {
+	for _, assignment := range assignments {
+		assignment.SiteID = r.siteID
+		assignment.ObjectType = objectType
+		assignment.ObjectKey = objectKey
+	}
+	return r.auditRepo.ReplaceRoleAssignments(ctx, r.auditRunID, r.siteID, objectType, objectKey, assignments)
 }
 
 // SaveSharingLinks persists sharing links with automatic site ID assignment.
@@ -175,8 +221,68 @@ func (r *SharePointAuditRepositoryImpl) SaveSensitivityLabel(ctx context.Context
 // SaveItemSensitivityLabel persists item-level sensitivity label data with automatic site ID assignment.
 func (r *SharePointAuditRepositoryImpl) SaveItemSensitivityLabel(ctx context.Context, label *sharepoint.ItemSensitivityLabel) error {
 	if label != nil {
-		// Ensure site ID matches the scoped repository
+		// Ensure site and audit run IDs match the scoped repository
 		label.SiteID = r.siteID
+		label.AuditRunID = r.auditRunID
 	}
 	return r.auditRepo.SaveItemSensitivityLabel(ctx, label)
 }
+
+// SaveItemAnalytics persists item usage analytics with automatic site ID assignment.
+func (r *SharePointAuditRepositoryImpl) SaveItemAnalytics(ctx context.Context, analytics *sharepoint.ItemAnalytics) error {
+	if analytics != nil {
+		// Ensure site and audit run IDs match the scoped repository
+		analytics.SiteID = r.siteID
+		analytics.AuditRunID = r.auditRunID
+	}
+	return r.auditRepo.SaveItemAnalytics(ctx, analytics)
+}
+
+// SaveRecycleBinScan records that the scoped audit run scanned the recycle bin, with the deleted items it found.
+func (r *SharePointAuditRepositoryImpl) SaveRecycleBinScan(ctx context.Context, scannedAt time.Time, items []*sharepoint.RecycleBinItem) error {
+	for _, item := range items {
+		item.SiteID = r.siteID
+		item.AuditRunID = r.auditRunID
+	}
+	return r.auditRepo.SaveRecycleBinScan(ctx, r.auditRunID, r.siteID, scannedAt, items)
+}
+
+// SaveAppInventory records that the scoped audit run collected the site's installed apps, with the apps it found.
+func (r *SharePointAuditRepositoryImpl) SaveAppInventory(ctx context.Context, collectedAt time.Time, apps []*sharepoint.InstalledApp) error {
+	for _, app := range apps {
+		app.SiteID = r.siteID
+		app.AuditRunID = r.auditRunID
+	}
+	return r.auditRepo.SaveAppInventory(ctx, r.auditRunID, r.siteID, collectedAt, apps)
+}
+
+// SaveGraphGrantScan records that the scoped audit run read the site's Graph app grants, with the grants it found.
+func (r *SharePointAuditRepositoryImpl) SaveGraphGrantScan(ctx context.Context, scannedAt time.Time, grants []*sharepoint.GraphAppGrant) error {
+	for _, grant := range grants {
+		grant.SiteID = r.siteID
+		grant.AuditRunID = r.auditRunID
+	}
+	return r.auditRepo.SaveGraphGrantScan(ctx, r.auditRunID, r.siteID, scannedAt, grants)
+}
+
+// SaveHubAssociation records the hub the scoped audit run found the site associated with.
+func (r *SharePointAuditRepositoryImpl) SaveHubAssociation(ctx context.Context, collectedAt time.Time, hub *sharepoint.HubAssociation) error {
+	hub.SiteID = r.siteID
+	hub.AuditRunID = r.auditRunID
+	return r.auditRepo.SaveHubAssociation(ctx, r.auditRunID, r.siteID, collectedAt, hub)
+}
+
+// SaveSchemaDrift records the API schema drift observed by the scoped audit run.
+func (r *SharePointAuditRepositoryImpl) SaveSchemaDrift(ctx context.Context, drift []audit.SchemaDrift) error {
+	return r.auditRepo.SaveSchemaDrift(ctx, r.auditRunID, drift)
+}
+
+// SaveListCheckpoint records how far the scoped audit run got through a list.
+func (r *SharePointAuditRepositoryImpl) SaveListCheckpoint(ctx context.Context, checkpoint *audit.ListCheckpoint) error {
+	return r.auditRepo.SaveListCheckpoint(ctx, r.auditRunID, checkpoint)
+}
+
+// GetListCheckpoints retrieves how far the scoped audit run got through each list it started.
+func (r *SharePointAuditRepositoryImpl) GetListCheckpoints(ctx context.Context) ([]*audit.ListCheckpoint, error) {
+	return r.auditRepo.GetListCheckpoints(ctx, r.auditRunID)
+}
@@ -2,11 +2,15 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
 	"strings"
 	"time"
 
 	"spaudit/domain/contracts"
 	"spaudit/domain/sharepoint"
+	"spaudit/gen/db"
 )
 
 // SiteContentAggregateRepositoryImpl implements the site content aggregate repository by composing entity repositories.
@@ -48,8 +52,62 @@ func (r *SiteContentAggregateRepositoryImpl) GetAllSitesWithMetadata(ctx context
 	return r.siteRepo.GetAllWithMetadata(ctx)
 }
 
+// GetAllSitesWithLatestRunMetadata retrieves all sites with list statistics for each site's latest audit run
+// in a single query. With preferCompleted, the latest completed run is used when the site has one. A label
+// only considers runs tagged with it and leaves out sites that have none.
+func (r *SiteContentAggregateRepositoryImpl) GetAllSitesWithLatestRunMetadata(ctx context.Context, preferCompleted bool, label string) ([]*contracts.SiteWithMetadata, error) {
+	rows, err := r.ReadQueries().ListSitesWithLatestAuditRunMetadata(ctx, db.ListSitesWithLatestAuditRunMetadataParams{
+		PreferCompleted: r.BoolToInt64(preferCompleted),
+		Label:           label,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sites := make([]*contracts.SiteWithMetadata, len(rows))
+	for i, row := range rows {
+		site := &contracts.SiteWithMetadata{
+			Site: &sharepoint.Site{
+				ID:        row.SiteID,
+				URL:       row.SiteUrl,
+				Title:     r.FromNullString(row.Title),
+				CreatedAt: r.FromNullTime(row.CreatedAt),
+				UpdatedAt: r.FromNullTime(row.UpdatedAt),
+			},
+			TotalLists:      int(row.TotalLists),
+			ListsWithUnique: int(row.ListsWithUnique),
+		}
+
+		// Sites that have never been audited have no run columns
+		if auditDate := r.FromNullTime(row.AuditStartedAt); auditDate != nil {
+			site.LastAuditDate = auditDate
+			site.LastAuditDaysAgo = int(time.Since(*auditDate).Hours() / 24)
+		}
+
+		sites[i] = site
+	}
+
+	return sites, nil
+}
+
 // This method has been removed - service layer will compose the data from individual repository calls
 
+// GetSiteByURL retrieves a site by its exact URL.
+func (r *SiteContentAggregateRepositoryImpl) GetSiteByURL(ctx context.Context, siteURL string) (*sharepoint.Site, error) {
+	row, err := r.ReadQueries().GetSiteByURL(ctx, siteURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sharepoint.Site{
+		ID:        row.SiteID,
+		URL:       row.SiteUrl,
+		Title:     r.FromNullString(row.Title),
+		CreatedAt: r.FromNullTime(row.CreatedAt),
+		UpdatedAt: r.FromNullTime(row.UpdatedAt),
+	}, nil
+}
+
 // SearchSites filters sites based on search query using business rules.
 func (r *SiteContentAggregateRepositoryImpl) SearchSites(ctx context.Context, searchQuery string) ([]*contracts.SiteWithMetadata, error) {
 	allSites, err := r.GetAllSitesWithMetadata(ctx)
@@ -85,6 +143,36 @@ func (r *SiteContentAggregateRepositoryImpl) applySiteSearchFilter(sites []*cont
 	return filteredSites
 }
 
+// GetWebsForSite retrieves the webs captured for a site in an audit run, ordered by URL.
+func (r *SiteContentAggregateRepositoryImpl) GetWebsForSite(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.Web, error) {
+	rows, err := r.ReadQueries().ListWebsForSiteByAuditRun(ctx, db.ListWebsForSiteByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	webs := make([]*sharepoint.Web, len(rows))
+	for i, row := range rows {
+		runID := row.AuditRunID
+		webs[i] = &sharepoint.Web{
+			SiteID:     row.SiteID,
+			ID:         row.WebID,
+			URL:        r.FromNullString(row.Url),
+			Title:      r.FromNullString(row.Title),
+			Template:   r.FromNullString(row.Template),
+			HasUnique:  r.FromNullBool(row.HasUnique),
+			AuditRunID: &runID,
+
+			MembersCanShare: r.FromNullBoolToPointer(row.MembersCanShare),
+			AccessRequests:  toWebAccessRequests(row.RequestAccessEmail, row.UseAccessRequestDefault),
+		}
+	}
+
+	return webs, nil
+}
+
 // GetListByID retrieves a single list by ID.
 func (r *SiteContentAggregateRepositoryImpl) GetListByID(ctx context.Context, siteID int64, listID string) (*sharepoint.List, error) {
 	return r.listRepo.GetByID(ctx, siteID, listID)
@@ -97,8 +185,20 @@ func (r *SiteContentAggregateRepositoryImpl) GetListsForSite(ctx context.Context
 
 // GetListAssignmentsWithRootCause retrieves resolved assignments with root cause analysis for a list (audit-scoped).
 func (r *SiteContentAggregateRepositoryImpl) GetListAssignmentsWithRootCause(ctx context.Context, siteID int64, auditRunID int64, listID string) ([]*sharepoint.ResolvedAssignment, error) {
+	return r.GetResolvedAssignmentsForObject(ctx, siteID, auditRunID, sharepoint.ObjectTypeList, listID)
+}
+
+// GetResolvedAssignmentsForObject retrieves resolved assignments with root cause analysis for any object type (audit-scoped).
+func (r *SiteContentAggregateRepositoryImpl) GetResolvedAssignmentsForObject(ctx context.Context, siteID int64, auditRunID int64, objectType, objectKey string) ([]*sharepoint.ResolvedAssignment, error) {
+	scopedAssignmentRepo := NewScopedAssignmentRepository(r.BaseRepository, r.ReadQueries(), siteID, auditRunID)
+	return scopedAssignmentRepo.GetResolvedAssignmentsForObject(ctx, siteID, objectType, objectKey)
+}
+
+// GetResolvedAssignmentsPageForObject retrieves a page of resolved assignments with root cause analysis
+// for any object type (audit-scoped), analyzing only the page.
+func (r *SiteContentAggregateRepositoryImpl) GetResolvedAssignmentsPageForObject(ctx context.Context, siteID int64, auditRunID int64, objectType, objectKey string, offset, limit int) ([]*sharepoint.ResolvedAssignment, error) {
 	scopedAssignmentRepo := NewScopedAssignmentRepository(r.BaseRepository, r.ReadQueries(), siteID, auditRunID)
-	return scopedAssignmentRepo.GetResolvedAssignmentsForObject(ctx, siteID, "list", listID)
+	return scopedAssignmentRepo.GetResolvedAssignmentsPageForObject(ctx, siteID, objectType, objectKey, offset, limit)
 }
 
 // GetAssignmentsForObject retrieves assignments for any object type (audit-scoped).
@@ -107,11 +207,484 @@ func (r *SiteContentAggregateRepositoryImpl) GetAssignmentsForObject(ctx context
 	return scopedAssignmentRepo.GetAssignmentsForObject(ctx, siteID, objectType, objectKey)
 }
 
+// GetListChanges compares a list's assignments, unique items and sharing links between two audit runs.
+// Each kind of fact is diffed in SQL, so only the changes are loaded, never either run in full.
+func (r *SiteContentAggregateRepositoryImpl) GetListChanges(ctx context.Context, siteID int64, listID string, baseAuditRunID, auditRunID int64, maxRows int) (*contracts.ListChanges, error) {
+	changes := &contracts.ListChanges{}
+	loaded := 0
+
+	// limit asks each query for one row past the cap, so an exceeded cap is detected without loading more
+	limit := func() int64 {
+		if maxRows <= 0 {
+			return -1 // SQLite reads a negative LIMIT as no limit
+		}
+		return int64(maxRows-loaded) + 1
+	}
+	count := func(rows int) error {
+		loaded += rows
+		if maxRows > 0 && loaded > maxRows {
+			return fmt.Errorf("list %s changes between audit runs %d and %d exceed %d rows: %w",
+				listID, baseAuditRunID, auditRunID, maxRows, contracts.ErrRowCapExceeded)
+		}
+		return nil
+	}
+
+	err := r.WithReadTx(func(queries *db.Queries) error {
+		assignmentRows, err := queries.GetListAssignmentChanges(ctx, db.GetListAssignmentChangesParams{
+			SiteID: siteID, ListID: listID, BaseAuditRunID: baseAuditRunID, AuditRunID: auditRunID, LimitCount: limit(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to diff list assignments: %w", err)
+		}
+		if err := count(len(assignmentRows)); err != nil {
+			return err
+		}
+		for _, row := range assignmentRows {
+			changes.Assignments = append(changes.Assignments, &contracts.ListChange{
+				Added:     row.Change == "added",
+				ObjectKey: listID,
+				Principal: r.changePrincipal(siteID, row.PrincipalID, row.PrincipalTitle, row.LoginName, row.Email, row.PrincipalType),
+				RoleDefID: row.RoleDefID,
+				Role:      r.FromNullString(row.RoleName),
+			})
+		}
+
+		itemRows, err := queries.GetUniqueItemChanges(ctx, db.GetUniqueItemChangesParams{
+			SiteID: siteID, ListID: listID, BaseAuditRunID: baseAuditRunID, AuditRunID: auditRunID, LimitCount: limit(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to diff unique items: %w", err)
+		}
+		if err := count(len(itemRows)); err != nil {
+			return err
+		}
+		for _, row := range itemRows {
+			changes.UniqueItems = append(changes.UniqueItems, &contracts.ListChange{
+				Added:      row.Change == "added",
+				ObjectKey:  row.ItemGuid,
+				ObjectName: r.FromNullString(row.Name),
+				URL:        r.FromNullString(row.Url),
+			})
+		}
+
+		itemAssignmentRows, err := queries.GetItemAssignmentChanges(ctx, db.GetItemAssignmentChangesParams{
+			SiteID: siteID, ListID: listID, BaseAuditRunID: baseAuditRunID, AuditRunID: auditRunID, LimitCount: limit(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to diff item assignments: %w", err)
+		}
+		if err := count(len(itemAssignmentRows)); err != nil {
+			return err
+		}
+		for _, row := range itemAssignmentRows {
+			changes.ItemAssignments = append(changes.ItemAssignments, &contracts.ListChange{
+				Added:      row.Change == "added",
+				ObjectKey:  row.ObjectKey,
+				ObjectName: r.FromNullString(row.ItemName),
+				Principal:  r.changePrincipal(siteID, row.PrincipalID, row.PrincipalTitle, row.LoginName, row.Email, row.PrincipalType),
+				RoleDefID:  row.RoleDefID,
+				Role:       r.FromNullString(row.RoleName),
+			})
+		}
+
+		linkRows, err := queries.GetSharingLinkChanges(ctx, db.GetSharingLinkChangesParams{
+			SiteID: siteID, ListID: listID, BaseAuditRunID: baseAuditRunID, AuditRunID: auditRunID, LimitCount: limit(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to diff sharing links: %w", err)
+		}
+		if err := count(len(linkRows)); err != nil {
+			return err
+		}
+		for _, row := range linkRows {
+			changes.SharingLinks = append(changes.SharingLinks, &contracts.ListChange{
+				Added:      row.Change == "added",
+				ObjectKey:  row.LinkID,
+				ObjectName: r.FromNullString(row.ItemName),
+				LinkKind:   int(r.FromNullInt64(row.LinkKind)),
+			})
+		}
+
+		memberRows, err := queries.GetSharingLinkMemberChanges(ctx, db.GetSharingLinkMemberChangesParams{
+			SiteID: siteID, ListID: listID, BaseAuditRunID: baseAuditRunID, AuditRunID: auditRunID, LimitCount: limit(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to diff sharing link members: %w", err)
+		}
+		if err := count(len(memberRows)); err != nil {
+			return err
+		}
+		for _, row := range memberRows {
+			changes.LinkMembers = append(changes.LinkMembers, &contracts.ListChange{
+				Added:      row.Change == "added",
+				ObjectKey:  row.LinkID,
+				ObjectName: r.FromNullString(row.ItemName),
+				Principal:  r.changePrincipal(siteID, row.PrincipalID, row.PrincipalTitle, row.LoginName, row.Email, row.PrincipalType),
+				LinkKind:   int(r.FromNullInt64(row.LinkKind)),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// GetRunFacts retrieves an audit run's explicit assignments and active sharing links, described by URL.
+func (r *SiteContentAggregateRepositoryImpl) GetRunFacts(ctx context.Context, siteID int64, auditRunID int64, maxRows int) (*contracts.RunFacts, error) {
+	facts := &contracts.RunFacts{}
+	loaded := 0
+
+	// limit asks each query for one row past the cap, so an exceeded cap is detected without loading more
+	limit := func() int64 {
+		if maxRows <= 0 {
+			return -1
+		}
+		return int64(maxRows-loaded) + 1
+	}
+	count := func(rows int) error {
+		loaded += rows
+		if maxRows > 0 && loaded > maxRows {
+			return fmt.Errorf("audit run %d permission facts exceed %d rows: %w", auditRunID, maxRows, contracts.ErrRowCapExceeded)
+		}
+		return nil
+	}
+
+	err := r.WithReadTx(func(queries *db.Queries) error {
+		permissionRows, err := queries.GetPermissionFactsForAuditRun(ctx, db.GetPermissionFactsForAuditRunParams{
+			SiteID: siteID, AuditRunID: auditRunID, LimitCount: limit(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get permission facts: %w", err)
+		}
+		if err := count(len(permissionRows)); err != nil {
+			return err
+		}
+		for _, row := range permissionRows {
+			facts.Permissions = append(facts.Permissions, &contracts.PermissionFact{
+				ObjectType: row.ObjectType,
+				ObjectKey:  row.ObjectKey,
+				ObjectURL:  row.ObjectUrl,
+				ObjectName: row.ObjectName,
+				Principal:  r.changePrincipal(siteID, row.PrincipalID, row.PrincipalTitle, row.LoginName, row.Email, row.PrincipalType),
+				RoleDefID:  row.RoleDefID,
+				Role:       r.FromNullString(row.RoleName),
+			})
+		}
+
+		linkRows, err := queries.GetSharingLinkFactsForAuditRun(ctx, db.GetSharingLinkFactsForAuditRunParams{
+			SiteID: siteID, AuditRunID: auditRunID, LimitCount: limit(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get sharing link facts: %w", err)
+		}
+		if err := count(len(linkRows)); err != nil {
+			return err
+		}
+		facts.SharingLinks = r.sharingLinkFacts(linkRows)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return facts, nil
+}
+
+// GetSharingLinkFacts retrieves an audit run's active sharing links, described by the item they were created on.
+func (r *SiteContentAggregateRepositoryImpl) GetSharingLinkFacts(ctx context.Context, siteID int64, auditRunID int64, maxRows int) ([]*contracts.SharingLinkFact, error) {
+	limit := int64(-1)
+	if maxRows > 0 {
+		limit = int64(maxRows) + 1
+	}
+	rows, err := r.ReadQueries().GetSharingLinkFactsForAuditRun(ctx, db.GetSharingLinkFactsForAuditRunParams{
+		SiteID: siteID, AuditRunID: auditRunID, LimitCount: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sharing link facts: %w", err)
+	}
+	if maxRows > 0 && len(rows) > maxRows {
+		return nil, fmt.Errorf("audit run %d sharing links exceed %d rows: %w", auditRunID, maxRows, contracts.ErrRowCapExceeded)
+	}
+	return r.sharingLinkFacts(rows), nil
+}
+
+// GetSharingLinkFactsCreatedBy retrieves the active sharing links a principal created in an audit run.
+func (r *SiteContentAggregateRepositoryImpl) GetSharingLinkFactsCreatedBy(ctx context.Context, siteID int64, auditRunID int64, principalID int64) ([]*contracts.SharingLinkFact, error) {
+	rows, err := r.ReadQueries().GetSharingLinkFactsCreatedByPrincipal(ctx, db.GetSharingLinkFactsCreatedByPrincipalParams{
+		SiteID:      siteID,
+		AuditRunID:  auditRunID,
+		PrincipalID: r.ToNullInt64(principalID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sharing links created by principal %d: %w", principalID, err)
+	}
+	factRows := make([]db.GetSharingLinkFactsForAuditRunRow, len(rows))
+	for i, row := range rows {
+		factRows[i] = db.GetSharingLinkFactsForAuditRunRow(row)
+	}
+	return r.sharingLinkFacts(factRows), nil
+}
+
+// GetItemsOwnedBy retrieves the items of an audit run whose sensitivity label names the owner with the given email.
+func (r *SiteContentAggregateRepositoryImpl) GetItemsOwnedBy(ctx context.Context, siteID int64, auditRunID int64, email string) ([]*contracts.OwnedItem, error) {
+	rows, err := r.ReadQueries().GetItemsOwnedByEmailByAuditRun(ctx, db.GetItemsOwnedByEmailByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		Email:      strings.ToLower(strings.TrimSpace(email)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get items owned by %s: %w", email, err)
+	}
+	items := make([]*contracts.OwnedItem, len(rows))
+	for i, row := range rows {
+		items[i] = &contracts.OwnedItem{ItemGUID: row.ItemGuid, ItemURL: row.ItemUrl, ItemName: row.ItemName, Label: row.LabelName}
+	}
+	return items, nil
+}
+
+// GetItemLabels retrieves the display name of the sensitivity label of each labeled item in an audit run, by item GUID.
+func (r *SiteContentAggregateRepositoryImpl) GetItemLabels(ctx context.Context, siteID int64, auditRunID int64) (map[string]string, error) {
+	rows, err := r.ReadQueries().GetItemLabelsByAuditRun(ctx, db.GetItemLabelsByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item labels: %w", err)
+	}
+	labels := make(map[string]string, len(rows))
+	for _, row := range rows {
+		labels[row.ItemGuid] = row.LabelName
+	}
+	return labels, nil
+}
+
+// sharingLinkFacts converts sharing link fact rows.
+func (r *SiteContentAggregateRepositoryImpl) sharingLinkFacts(rows []db.GetSharingLinkFactsForAuditRunRow) []*contracts.SharingLinkFact {
+	facts := make([]*contracts.SharingLinkFact, len(rows))
+	for i, row := range rows {
+		facts[i] = &contracts.SharingLinkFact{
+			LinkID:       row.LinkID,
+			ItemGUID:     r.FromNullString(row.ItemGuid),
+			ItemURL:      row.ItemUrl,
+			ItemName:     row.ItemName,
+			WebID:        row.WebID,
+			LinkKind:     int(r.FromNullInt64(row.LinkKind)),
+			Scope:        int(r.FromNullInt64(row.Scope)),
+			MembersCount: r.FromNullInt64(row.TotalMembersCount),
+			IsDefault:    r.FromNullBool(row.IsDefault),
+			IsEditLink:   r.FromNullBool(row.IsEditLink),
+			Expiration:   r.FromNullTime(row.Expiration),
+		}
+	}
+	return facts
+}
+
+// GetRunChanges compares the site's web assignments and captured principals between two audit runs.
+// Like GetListChanges it diffs in SQL and loads only the changes.
+func (r *SiteContentAggregateRepositoryImpl) GetRunChanges(ctx context.Context, siteID int64, baseAuditRunID, auditRunID int64, maxRows int) (*contracts.RunChanges, error) {
+	changes := &contracts.RunChanges{}
+	loaded := 0
+
+	limit := func() int64 {
+		if maxRows <= 0 {
+			return -1
+		}
+		return int64(maxRows-loaded) + 1
+	}
+	count := func(rows int) error {
+		loaded += rows
+		if maxRows > 0 && loaded > maxRows {
+			return fmt.Errorf("site %d changes between audit runs %d and %d exceed %d rows: %w",
+				siteID, baseAuditRunID, auditRunID, maxRows, contracts.ErrRowCapExceeded)
+		}
+		return nil
+	}
+
+	err := r.WithReadTx(func(queries *db.Queries) error {
+		webRows, err := queries.GetWebAssignmentChanges(ctx, db.GetWebAssignmentChangesParams{
+			SiteID: siteID, BaseAuditRunID: baseAuditRunID, AuditRunID: auditRunID, LimitCount: limit(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to diff web assignments: %w", err)
+		}
+		if err := count(len(webRows)); err != nil {
+			return err
+		}
+		for _, row := range webRows {
+			changes.WebAssignments = append(changes.WebAssignments, &contracts.ListChange{
+				Added:      row.Change == "added",
+				ObjectKey:  row.ObjectKey,
+				ObjectName: r.FromNullString(row.WebTitle),
+				Principal:  r.changePrincipal(siteID, row.PrincipalID, row.PrincipalTitle, row.LoginName, row.Email, row.PrincipalType),
+				RoleDefID:  row.RoleDefID,
+				Role:       r.FromNullString(row.RoleName),
+			})
+		}
+
+		principalRows, err := queries.GetRemovedPrincipals(ctx, db.GetRemovedPrincipalsParams{
+			SiteID: siteID, BaseAuditRunID: baseAuditRunID, AuditRunID: auditRunID, LimitCount: limit(),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to diff principals: %w", err)
+		}
+		if err := count(len(principalRows)); err != nil {
+			return err
+		}
+		for _, row := range principalRows {
+			changes.RemovedPrincipals = append(changes.RemovedPrincipals, &sharepoint.Principal{
+				SiteID:        siteID,
+				ID:            row.PrincipalID,
+				Title:         r.FromNullString(row.Title),
+				LoginName:     r.FromNullString(row.LoginName),
+				Email:         r.FromNullString(row.Email),
+				PrincipalType: row.PrincipalType,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// changePrincipal builds the principal of a change row. Only the ID is known when the run did not capture the principal.
+func (r *SiteContentAggregateRepositoryImpl) changePrincipal(siteID, principalID int64, title, loginName, email sql.NullString, principalType sql.NullInt64) *sharepoint.Principal {
+	return &sharepoint.Principal{
+		SiteID:        siteID,
+		ID:            principalID,
+		Title:         r.FromNullString(title),
+		LoginName:     r.FromNullString(loginName),
+		Email:         r.FromNullString(email),
+		PrincipalType: r.FromNullInt64(principalType),
+	}
+}
+
+// GetPrincipalsForAuditRun retrieves every principal captured by an audit run, ordered by ID.
+func (r *SiteContentAggregateRepositoryImpl) GetPrincipalsForAuditRun(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.Principal, error) {
+	rows, err := r.ReadQueries().GetPrincipalsByAuditRun(ctx, db.GetPrincipalsByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	principals := make([]*sharepoint.Principal, 0, len(rows))
+	for _, row := range rows {
+		principals = append(principals, &sharepoint.Principal{
+			SiteID:        siteID,
+			ID:            row.PrincipalID,
+			Title:         r.FromNullString(row.Title),
+			LoginName:     r.FromNullString(row.LoginName),
+			Email:         r.FromNullString(row.Email),
+			PrincipalType: row.PrincipalType,
+		})
+	}
+	return principals, nil
+}
+
+// GetGroupIDsForMember retrieves the SharePoint groups a principal belongs to as captured by an audit run.
+func (r *SiteContentAggregateRepositoryImpl) GetGroupIDsForMember(ctx context.Context, siteID int64, auditRunID int64, principalID int64) ([]int64, error) {
+	return r.ReadQueries().GetGroupIDsForMemberByAuditRun(ctx, db.GetGroupIDsForMemberByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		MemberID:   principalID,
+	})
+}
+
+// GetEntraGroupIDsForUser retrieves the Entra group principals whose users, as the audit run resolved
+// them, include the user with the given UPN or mail. Groups the run did not resolve reach no one.
+func (r *SiteContentAggregateRepositoryImpl) GetEntraGroupIDsForUser(ctx context.Context, siteID int64, auditRunID int64, identity string) ([]int64, error) {
+	return r.ReadQueries().GetEntraGroupIDsForUserByAuditRun(ctx, db.GetEntraGroupIDsForUserByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		Identity:   strings.ToLower(strings.TrimSpace(identity)),
+	})
+}
+
+// GetSharingLinkIDsForMember retrieves the sharing links a principal is a member of in an audit run.
+func (r *SiteContentAggregateRepositoryImpl) GetSharingLinkIDsForMember(ctx context.Context, siteID int64, auditRunID int64, principalID int64) ([]string, error) {
+	return r.ReadQueries().GetSharingLinkIDsForMemberByAuditRun(ctx, db.GetSharingLinkIDsForMemberByAuditRunParams{
+		SiteID:      siteID,
+		AuditRunID:  auditRunID,
+		PrincipalID: principalID,
+	})
+}
+
+// GetGroupMembersForAuditRun retrieves the members of a SharePoint group as captured by an audit run, ordered by title.
+// Groups the run did not expand have no members.
+func (r *SiteContentAggregateRepositoryImpl) GetGroupMembersForAuditRun(ctx context.Context, siteID int64, auditRunID int64, groupID int64) ([]*sharepoint.Principal, error) {
+	rows, err := r.ReadQueries().GetGroupMembersByAuditRun(ctx, db.GetGroupMembersByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		GroupID:    groupID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*sharepoint.Principal, 0, len(rows))
+	for _, row := range rows {
+		members = append(members, &sharepoint.Principal{
+			SiteID:        siteID,
+			ID:            row.PrincipalID,
+			Title:         r.FromNullString(row.Title),
+			LoginName:     r.FromNullString(row.LoginName),
+			Email:         r.FromNullString(row.Email),
+			PrincipalType: row.PrincipalType,
+		})
+	}
+	return members, nil
+}
+
 // GetListItems retrieves items with unique permissions for a list with pagination.
 func (r *SiteContentAggregateRepositoryImpl) GetListItems(ctx context.Context, siteID int64, listID string, offset, limit int) ([]*sharepoint.Item, error) {
 	return r.itemRepo.GetItemsWithUniqueForList(ctx, siteID, listID, int64(offset), int64(limit))
 }
 
+// GetAllListItems retrieves all items for a list with pagination, regardless of permission inheritance.
+func (r *SiteContentAggregateRepositoryImpl) GetAllListItems(ctx context.Context, siteID int64, listID string, offset, limit int) ([]*sharepoint.Item, error) {
+	return r.itemRepo.GetItemsForList(ctx, siteID, listID, int64(offset), int64(limit))
+}
+
+// GetFilteredListItems retrieves a page of items for a list matching the filter.
+func (r *SiteContentAggregateRepositoryImpl) GetFilteredListItems(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter, offset, limit int) ([]*sharepoint.Item, error) {
+	return r.itemRepo.GetFilteredItemsForList(ctx, siteID, listID, filter, int64(offset), int64(limit))
+}
+
+// CountFilteredListItems counts the items for a list matching the filter.
+func (r *SiteContentAggregateRepositoryImpl) CountFilteredListItems(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter) (int64, error) {
+	return r.itemRepo.CountFilteredItemsForList(ctx, siteID, listID, filter)
+}
+
+// GetItemByGUID retrieves a single item by GUID.
+func (r *SiteContentAggregateRepositoryImpl) GetItemByGUID(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.Item, error) {
+	return r.itemRepo.GetByGUID(ctx, siteID, itemGUID)
+}
+
+// GetItemAttachments retrieves the files attached to an item as captured by an audit run, ordered by file name.
+func (r *SiteContentAggregateRepositoryImpl) GetItemAttachments(ctx context.Context, siteID int64, auditRunID int64, itemGUID string) ([]*sharepoint.ItemAttachment, error) {
+	rows, err := r.ReadQueries().GetItemAttachmentsByAuditRun(ctx, db.GetItemAttachmentsByAuditRunParams{
+		SiteID:     siteID,
+		ItemGuid:   itemGUID,
+		AuditRunID: auditRunID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*sharepoint.ItemAttachment, 0, len(rows))
+	for _, row := range rows {
+		attachments = append(attachments, &sharepoint.ItemAttachment{
+			FileName: row.FileName,
+			URL:      r.FromNullString(row.Url),
+		})
+	}
+	return attachments, nil
+}
+
 // GetListSharingLinks retrieves sharing links for a list.
 func (r *SiteContentAggregateRepositoryImpl) GetListSharingLinks(ctx context.Context, siteID int64, listID string) ([]*sharepoint.SharingLink, error) {
 	return r.sharingRepo.GetSharingLinksForList(ctx, siteID, listID)
@@ -127,6 +700,437 @@ func (r *SiteContentAggregateRepositoryImpl) GetSharingLinkMembers(ctx context.C
 	return r.sharingRepo.GetSharingLinkMembers(ctx, siteID, linkID)
 }
 
+// GetPreviousSharingLinkAuditRun returns the most recent audit run before auditRunID that captured the link, or 0 if none did.
+func (r *SiteContentAggregateRepositoryImpl) GetPreviousSharingLinkAuditRun(ctx context.Context, siteID int64, auditRunID int64, linkID string) (int64, error) {
+	return r.ReadQueries().GetPreviousAuditRunForSharingLink(ctx, db.GetPreviousAuditRunForSharingLinkParams{
+		SiteID:     siteID,
+		LinkID:     linkID,
+		AuditRunID: auditRunID,
+	})
+}
+
+// GetSharingLinkMembersForAuditRun retrieves members of a sharing link as captured by a specific audit run.
+func (r *SiteContentAggregateRepositoryImpl) GetSharingLinkMembersForAuditRun(ctx context.Context, siteID int64, auditRunID int64, linkID string) ([]*sharepoint.Principal, error) {
+	scopedSharingRepo := NewScopedSharingRepository(r.BaseRepository, r.ReadQueries(), siteID, auditRunID)
+	return scopedSharingRepo.GetSharingLinkMembers(ctx, siteID, linkID)
+}
+
+// GetSharingLinkForAuditRun retrieves a sharing link with its item data as captured by an audit run.
+// Returns nil if the run did not capture the link, e.g. when sharing collection was disabled.
+func (r *SiteContentAggregateRepositoryImpl) GetSharingLinkForAuditRun(ctx context.Context, siteID int64, auditRunID int64, linkID string) (*sharepoint.SharingLinkWithItemData, error) {
+	row, err := r.ReadQueries().GetSharingLinkByAuditRun(ctx, db.GetSharingLinkByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		LinkID:     linkID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &sharepoint.SharingLinkWithItemData{
+		SharingLink: &sharepoint.SharingLink{
+			SiteID:             siteID,
+			ID:                 row.LinkID,
+			ItemGUID:           r.FromNullString(row.ItemGuid),
+			FileFolderUniqueID: r.FromNullString(row.FileFolderUniqueID),
+			URL:                r.FromNullString(row.Url),
+			LinkKind:           int(r.FromNullInt64(row.LinkKind)),
+			Scope:              int(r.FromNullInt64(row.Scope)),
+			IsActive:           r.FromNullBool(row.IsActive),
+			IsDefault:          r.FromNullBool(row.IsDefault),
+			IsEditLink:         r.FromNullBool(row.IsEditLink),
+			IsReviewLink:       r.FromNullBool(row.IsReviewLink),
+			CreatedAt:          r.FromNullTime(row.CreatedAt),
+			TotalMembersCount:  int(row.ActualMembersCount),
+		},
+		ItemName:     r.FromNullString(row.ItemName),
+		ItemIsFile:   r.FromNullBool(row.IsFile),
+		ItemIsFolder: r.FromNullBool(row.IsFolder),
+	}, nil
+}
+
+// GetAnonymousSharingLinksForAuditRun retrieves the active anonymous sharing links on items in any of the
+// site's lists as captured by an audit run, those without an expiration first.
+func (r *SiteContentAggregateRepositoryImpl) GetAnonymousSharingLinksForAuditRun(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.AnonymousSharingLink, error) {
+	rows, err := r.ReadQueries().GetAnonymousSharingLinksByAuditRun(ctx, db.GetAnonymousSharingLinksByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*sharepoint.AnonymousSharingLink, 0, len(rows))
+	for _, row := range rows {
+		links = append(links, &sharepoint.AnonymousSharingLink{
+			SharingLink: &sharepoint.SharingLink{
+				SiteID:             siteID,
+				ID:                 row.LinkID,
+				ItemGUID:           r.FromNullString(row.ItemGuid),
+				FileFolderUniqueID: r.FromNullString(row.FileFolderUniqueID),
+				URL:                r.FromNullString(row.Url),
+				LinkKind:           int(r.FromNullInt64(row.LinkKind)),
+				Scope:              int(r.FromNullInt64(row.Scope)),
+				IsActive:           true,
+				IsEditLink:         r.FromNullBool(row.IsEditLink),
+				IsReviewLink:       r.FromNullBool(row.IsReviewLink),
+				BlocksDownload:     r.FromNullBool(row.BlocksDownload),
+				RequiresPassword:   r.FromNullBool(row.RequiresPassword),
+				Expiration:         r.FromNullTime(row.Expiration),
+				CreatedAt:          r.FromNullTime(row.CreatedAt),
+				CreatedBy:          r.linkPrincipal(siteID, row.CreatedByTitle, row.CreatedByLogin),
+				LastModifiedAt:     r.FromNullTime(row.LastModifiedAt),
+				LastModifiedBy:     r.linkPrincipal(siteID, row.ModifiedByTitle, row.ModifiedByLogin),
+			},
+			ListID:       row.ListID,
+			ListTitle:    r.FromNullString(row.ListTitle),
+			ItemName:     r.FromNullString(row.ItemName),
+			ItemURL:      r.FromNullString(row.ItemUrl),
+			ItemIsFile:   r.FromNullBool(row.IsFile),
+			ItemIsFolder: r.FromNullBool(row.IsFolder),
+		})
+	}
+	return links, nil
+}
+
+// GetLinkCreatorsForAuditRun retrieves the principals who created active sharing links in an audit run,
+// those who exposed the most links beyond the organization first. Links whose creator the run did not
+// capture are left out.
+func (r *SiteContentAggregateRepositoryImpl) GetLinkCreatorsForAuditRun(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.LinkCreator, error) {
+	rows, err := r.ReadQueries().CountSharingLinksByCreatorByAuditRun(ctx, db.CountSharingLinksByCreatorByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count sharing links by creator: %w", err)
+	}
+
+	creators := []*sharepoint.LinkCreator{}
+	byPrincipal := map[int64]*sharepoint.LinkCreator{}
+	for _, row := range rows {
+		creator, ok := byPrincipal[row.PrincipalID]
+		if !ok {
+			creator = &sharepoint.LinkCreator{Principal: &sharepoint.Principal{
+				SiteID:    siteID,
+				ID:        row.PrincipalID,
+				Title:     row.PrincipalTitle,
+				LoginName: row.LoginName,
+				Email:     row.Email,
+			}}
+			byPrincipal[row.PrincipalID] = creator
+			creators = append(creators, creator)
+		}
+		creator.AddLinks(int(r.FromNullInt64(row.LinkKind)), int(r.FromNullInt64(row.Scope)), row.GrantsEdit != 0, row.ReachesExternal != 0, row.LinkCount)
+	}
+	sharepoint.SortLinkCreators(creators)
+	return creators, nil
+}
+
+// linkPrincipal returns the principal who created or last modified a sharing link, or nil when the
+// run did not capture one.
+func (r *SiteContentAggregateRepositoryImpl) linkPrincipal(siteID int64, title, login sql.NullString) *sharepoint.Principal {
+	if !title.Valid && !login.Valid {
+		return nil
+	}
+	return &sharepoint.Principal{
+		SiteID:    siteID,
+		Title:     r.FromNullString(title),
+		LoginName: r.FromNullString(login),
+	}
+}
+
+// GetSharingGovernanceForAuditRun retrieves the sharing policy of a site as captured by an audit run.
+// Returns nil if the run captured none, e.g. when it found no sharing links.
+func (r *SiteContentAggregateRepositoryImpl) GetSharingGovernanceForAuditRun(ctx context.Context, siteID int64, auditRunID int64) (*sharepoint.SharingGovernance, error) {
+	row, err := r.ReadQueries().GetSharingGovernance(ctx, db.GetSharingGovernanceParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	governance := &sharepoint.SharingGovernance{
+		SiteID:                                 siteID,
+		AuditRunID:                             auditRunID,
+		TenantID:                               r.FromNullString(row.TenantID),
+		TenantDisplayName:                      r.FromNullString(row.TenantDisplayName),
+		SharePointSiteID:                       r.FromNullString(row.SharepointSiteID),
+		AnonymousLinkExpirationRestrictionDays: int(r.FromNullInt64(row.AnonymousLinkExpirationRestrictionDays)),
+		AnyoneLinkTrackUsers:                   r.FromNullBool(row.AnyoneLinkTrackUsers),
+		CanAddExternalPrincipal:                r.FromNullBool(row.CanAddExternalPrincipal),
+		CanAddInternalPrincipal:                r.FromNullBool(row.CanAddInternalPrincipal),
+		BlockPeoplePickerAndSharing:            r.FromNullBool(row.BlockPeoplePickerAndSharing),
+		CanRequestAccessForGrantAccess:         r.FromNullBool(row.CanRequestAccessForGrantAccess),
+		SiteIBMode:                             r.FromNullString(row.SiteIbMode),
+		EnforceIBSegmentFiltering:              r.FromNullBool(row.EnforceIbSegmentFiltering),
+	}
+	if err := decodeGovernanceJSON(row.SiteIbSegmentIds, &governance.SiteIBSegmentIDs); err != nil {
+		return nil, fmt.Errorf("decode information barrier segments: %w", err)
+	}
+	if row.DefaultLinkKind.Valid {
+		governance.DefaultSharingLink = &sharepoint.DefaultSharingLink{
+			LinkKind:   int(row.DefaultLinkKind.Int64),
+			Permission: int(r.FromNullInt64(row.DefaultShareLinkPermission)),
+			Scope:      int(r.FromNullInt64(row.DefaultShareLinkScope)),
+		}
+	}
+
+	abilities, err := r.ReadQueries().GetSharingAbilities(ctx, db.GetSharingAbilitiesParams{SiteID: siteID, AuditRunID: auditRunID})
+	switch {
+	case err == nil:
+		governance.SharingAbilities = &sharepoint.SharingAbilities{CanStopSharing: r.FromNullBool(abilities.CanStopSharing)}
+		for _, column := range []governanceColumn{
+			{abilities.AnonymousLinkAbilities, &governance.SharingAbilities.AnonymousLinkAbilities},
+			{abilities.AnyoneLinkAbilities, &governance.SharingAbilities.AnyoneLinkAbilities},
+			{abilities.OrganizationLinkAbilities, &governance.SharingAbilities.OrganizationLinkAbilities},
+			{abilities.PeopleSharingLinkAbilities, &governance.SharingAbilities.PeopleSharingLinkAbilities},
+			{abilities.DirectSharingAbilities, &governance.SharingAbilities.DirectSharingAbilities},
+		} {
+			if err := decodeGovernanceJSON(column.value, column.target); err != nil {
+				return nil, fmt.Errorf("decode sharing abilities: %w", err)
+			}
+		}
+	case err != sql.ErrNoRows:
+		return nil, err
+	}
+
+	limits, err := r.ReadQueries().GetRecipientLimits(ctx, db.GetRecipientLimitsParams{SiteID: siteID, AuditRunID: auditRunID})
+	switch {
+	case err == nil:
+		governance.RecipientLimits = &sharepoint.RecipientLimits{}
+		for _, column := range []governanceColumn{
+			{limits.CheckPermissions, &governance.RecipientLimits.CheckPermissions},
+			{limits.GrantDirectAccess, &governance.RecipientLimits.GrantDirectAccess},
+			{limits.ShareLink, &governance.RecipientLimits.ShareLink},
+			{limits.ShareLinkWithDeferRedeem, &governance.RecipientLimits.ShareLinkWithDeferRedeem},
+		} {
+			if err := decodeGovernanceJSON(column.value, column.target); err != nil {
+				return nil, fmt.Errorf("decode recipient limits: %w", err)
+			}
+		}
+	case err != sql.ErrNoRows:
+		return nil, err
+	}
+
+	return governance, nil
+}
+
+// GetPreviousSharingGovernanceAuditRun finds the most recent audit run before the given one that
+// captured the site's sharing policy, or 0 if none did.
+func (r *SiteContentAggregateRepositoryImpl) GetPreviousSharingGovernanceAuditRun(ctx context.Context, siteID int64, auditRunID int64) (int64, error) {
+	return r.ReadQueries().GetPreviousAuditRunForSharingGovernance(ctx, db.GetPreviousAuditRunForSharingGovernanceParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+	})
+}
+
+// governanceColumn pairs a governance column stored as JSON with the field it decodes into.
+type governanceColumn struct {
+	value  sql.NullString
+	target any
+}
+
+// decodeGovernanceJSON decodes a governance column stored as JSON, leaving target unset when the column is empty.
+func decodeGovernanceJSON(column sql.NullString, target any) error {
+	if !column.Valid || column.String == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(column.String), target)
+}
+
+// GetListSensitivityLabels retrieves item sensitivity labels for a list.
+func (r *SiteContentAggregateRepositoryImpl) GetListSensitivityLabels(ctx context.Context, siteID int64, listID string) ([]*sharepoint.ItemSensitivityLabel, error) {
+	return r.sharingRepo.GetSensitivityLabelsForList(ctx, siteID, listID)
+}
+
+// GetItemSensitivityLabel retrieves the sensitivity label for an item, or nil if it has none.
+func (r *SiteContentAggregateRepositoryImpl) GetItemSensitivityLabel(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.ItemSensitivityLabel, error) {
+	return r.sharingRepo.GetItemSensitivityLabel(ctx, siteID, itemGUID)
+}
+
+// GetRecycleBinScan returns when an audit run scanned the site's recycle bin, or nil if it did not.
+func (r *SiteContentAggregateRepositoryImpl) GetRecycleBinScan(ctx context.Context, siteID int64, auditRunID int64) (*time.Time, error) {
+	scannedAt, err := r.ReadQueries().GetRecycleBinScan(ctx, db.GetRecycleBinScanParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scannedAt, nil
+}
+
+// GetRecycleBinItems retrieves the deleted items an audit run found in the recycle bin, most recently deleted first.
+func (r *SiteContentAggregateRepositoryImpl) GetRecycleBinItems(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.RecycleBinItem, error) {
+	rows, err := r.ReadQueries().GetRecycleBinItemsByAuditRun(ctx, db.GetRecycleBinItemsByAuditRunParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*sharepoint.RecycleBinItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, &sharepoint.RecycleBinItem{
+			SiteID:         siteID,
+			AuditRunID:     auditRunID,
+			ID:             row.RecycleBinID,
+			ItemType:       int(row.ItemType),
+			ItemState:      int(row.ItemState),
+			Title:          r.FromNullString(row.Title),
+			URL:            row.Url,
+			DeletedAt:      row.DeletedAt,
+			DeletedByName:  r.FromNullString(row.DeletedByName),
+			DeletedByEmail: r.FromNullString(row.DeletedByEmail),
+		})
+	}
+	return items, nil
+}
+
+// GetDeletedItemLinks retrieves the sharing links active on the items at the locations of an audit run's
+// recycle bin entries, as the last earlier run to capture each location saw them, in recycle bin order.
+func (r *SiteContentAggregateRepositoryImpl) GetDeletedItemLinks(ctx context.Context, siteID int64, auditRunID int64) ([]*contracts.DeletedItemLink, error) {
+	rows, err := r.ReadQueries().GetRecycleBinItemSharingLinks(ctx, db.GetRecycleBinItemSharingLinksParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err != nil {
+		return nil, err
+	}
+
+	links := make([]*contracts.DeletedItemLink, 0, len(rows))
+	for _, row := range rows {
+		links = append(links, &contracts.DeletedItemLink{
+			RecycleBinID: row.RecycleBinID,
+			AuditRunID:   row.AuditRunID,
+			AuditRunAt:   row.AuditRunStartedAt,
+			ItemGUID:     row.ItemGuid,
+			ListID:       row.ListID,
+			ItemName:     r.FromNullString(row.Name),
+			LinkID:       row.LinkID,
+			LinkKind:     int(r.FromNullInt64(row.LinkKind)),
+			Scope:        int(r.FromNullInt64(row.Scope)),
+			MembersCount: r.FromNullInt64(row.TotalMembersCount),
+			IsEditLink:   r.FromNullBool(row.IsEditLink),
+			Expiration:   r.FromNullTime(row.Expiration),
+		})
+	}
+	return links, nil
+}
+
+// GetAppInventory returns when an audit run collected the site's installed apps, or nil if it did not.
+func (r *SiteContentAggregateRepositoryImpl) GetAppInventory(ctx context.Context, siteID int64, auditRunID int64) (*time.Time, error) {
+	collectedAt, err := r.ReadQueries().GetAppInventory(ctx, db.GetAppInventoryParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &collectedAt, nil
+}
+
+// GetInstalledApps retrieves the apps an audit run found installed on the site, by title.
+func (r *SiteContentAggregateRepositoryImpl) GetInstalledApps(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.InstalledApp, error) {
+	rows, err := r.ReadQueries().GetInstalledAppsByAuditRun(ctx, db.GetInstalledAppsByAuditRunParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make([]*sharepoint.InstalledApp, 0, len(rows))
+	for _, row := range rows {
+		apps = append(apps, &sharepoint.InstalledApp{
+			SiteID:         siteID,
+			AuditRunID:     auditRunID,
+			AppID:          row.AppID,
+			ProductID:      r.FromNullString(row.ProductID),
+			Title:          r.FromNullString(row.Title),
+			AppSource:      int(row.AppSource),
+			AppPrincipalID: r.FromNullString(row.AppPrincipalID),
+			LastModified:   r.FromNullTime(row.LastModified),
+		})
+	}
+	return apps, nil
+}
+
+// GetAppPermissionFacts retrieves the explicit assignments of app principals in an audit run, by principal.
+func (r *SiteContentAggregateRepositoryImpl) GetAppPermissionFacts(ctx context.Context, siteID int64, auditRunID int64) ([]*contracts.PermissionFact, error) {
+	rows, err := r.ReadQueries().GetAppPermissionFactsForAuditRun(ctx, db.GetAppPermissionFactsForAuditRunParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app permission facts: %w", err)
+	}
+
+	facts := make([]*contracts.PermissionFact, len(rows))
+	for i, row := range rows {
+		facts[i] = &contracts.PermissionFact{
+			ObjectType: row.ObjectType,
+			ObjectKey:  row.ObjectKey,
+			ObjectURL:  row.ObjectUrl,
+			ObjectName: row.ObjectName,
+			Principal:  r.changePrincipal(siteID, row.PrincipalID, row.PrincipalTitle, row.LoginName, row.Email, sql.NullInt64{Int64: row.PrincipalType, Valid: true}),
+			RoleDefID:  row.RoleDefID,
+			Role:       r.FromNullString(row.RoleName),
+		}
+	}
+	return facts, nil
+}
+
+// GetGraphGrantScan returns when an audit run read the site's Graph app grants, or nil if it did not.
+func (r *SiteContentAggregateRepositoryImpl) GetGraphGrantScan(ctx context.Context, siteID int64, auditRunID int64) (*time.Time, error) {
+	scannedAt, err := r.ReadQueries().GetGraphGrantScan(ctx, db.GetGraphGrantScanParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &scannedAt, nil
+}
+
+// GetGraphAppGrants retrieves the Graph app grants an audit run found, site grants first, by app.
+func (r *SiteContentAggregateRepositoryImpl) GetGraphAppGrants(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.GraphAppGrant, error) {
+	rows, err := r.ReadQueries().GetGraphAppGrantsByAuditRun(ctx, db.GetGraphAppGrantsByAuditRunParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err != nil {
+		return nil, err
+	}
+
+	grants := make([]*sharepoint.GraphAppGrant, 0, len(rows))
+	for _, row := range rows {
+		grants = append(grants, &sharepoint.GraphAppGrant{
+			SiteID:     siteID,
+			AuditRunID: auditRunID,
+			GrantID:    row.GrantID,
+			Kind:       row.GrantKind,
+			AppID:      row.AppID,
+			AppName:    r.FromNullString(row.AppName),
+			Roles:      strings.Split(row.Roles, ","),
+			Resource:   r.FromNullString(row.Resource),
+		})
+	}
+	return grants, nil
+}
+
+// GetHubAssociation retrieves the hub an audit run found the site associated with, or nil if the run
+// did not collect it.
+func (r *SiteContentAggregateRepositoryImpl) GetHubAssociation(ctx context.Context, siteID int64, auditRunID int64) (*sharepoint.HubAssociation, error) {
+	row, err := r.ReadQueries().GetSiteHub(ctx, db.GetSiteHubParams{SiteID: siteID, AuditRunID: auditRunID})
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sharepoint.HubAssociation{
+		SiteID:          siteID,
+		AuditRunID:      auditRunID,
+		IsHub:           row.IsHub,
+		HubSiteID:       r.FromNullString(row.HubSiteID),
+		HubTitle:        r.FromNullString(row.HubTitle),
+		HubURL:          r.FromNullString(row.HubUrl),
+		ParentHubSiteID: r.FromNullString(row.ParentHubSiteID),
+		PermissionsSync: row.PermissionsSync,
+	}, nil
+}
+
 // GetLastAuditDate retrieves the last audit date for a site.
 func (r *SiteContentAggregateRepositoryImpl) GetLastAuditDate(ctx context.Context, siteID int64) (*time.Time, error) {
 	return r.jobRepo.GetLastAuditDate(ctx, siteID)
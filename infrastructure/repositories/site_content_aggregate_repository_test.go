@@ -0,0 +1,382 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/sharepoint"
+)
+
+func TestSiteContentAggregateRepository_GetSharingLinkForAuditRun(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, name, is_file) VALUES
+		(1, 'budget', 1, 'list-1', 1, 'budget.xlsx', 1)`)
+	mustExec(t, testDB, `INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, file_folder_unique_id, link_kind, is_active) VALUES
+		(1, '9A8B7C6D-0000-4000-8000-000000000002', 1, 'budget', 'budget', 4, 1)`)
+
+	ctx := context.Background()
+	repo := NewSiteContentAggregateRepository(NewBaseRepository(testDB), nil, nil, nil, nil, nil)
+
+	// Login names carry the share id in lower case while the API returns it upper case
+	link, err := repo.GetSharingLinkForAuditRun(ctx, 1, 1, "9a8b7c6d-0000-4000-8000-000000000002")
+	require.NoError(t, err)
+	require.NotNil(t, link)
+	assert.Equal(t, "9A8B7C6D-0000-4000-8000-000000000002", link.ID)
+	assert.Equal(t, "budget.xlsx", link.ItemName)
+	assert.True(t, link.ItemIsFile)
+
+	missing, err := repo.GetSharingLinkForAuditRun(ctx, 1, 1, "00000000-0000-0000-0000-000000000000")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestSiteContentAggregateRepository_GetAnonymousSharingLinksForAuditRun(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title) VALUES (1, 'list-2', 1, 'web-1', 'Tasks')`)
+	mustExec(t, testDB, `INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, name, is_file, is_folder) VALUES
+		(1, 'budget', 1, 'list-1', 1, 'budget.xlsx', 1, 0),
+		(1, 'plans', 1, 'list-1', 2, 'Plans', 0, 1),
+		(1, 'task', 1, 'list-2', 1, '1_.000', 0, 0)`)
+	mustExec(t, testDB, `INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES
+		(1, 10, 1, 'Ada', 'i:0#.f|membership|ada@contoso.com', 1),
+		(1, 11, 1, 'Grace', 'i:0#.f|membership|grace@contoso.com', 1)`)
+	mustExec(t, testDB, `INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, file_folder_unique_id, link_kind, scope, is_active, is_edit_link,
+			expiration, created_by_principal_id, last_modified_by_principal_id) VALUES
+		(1, 'anyone-plans', 1, 'plans', 'plans', 6, 0, 1, 0, '2026-06-01 00:00:00', 10, NULL),
+		(1, 'anyone-task', 1, 'task', 'task', 5, 0, 1, 1, NULL, 10, 11),
+		(1, 'org-budget', 1, 'budget', 'budget', 3, 1, 1, 1, NULL, 10, NULL),
+		(1, 'revoked-budget', 1, 'budget', 'budget', 4, 0, 0, 0, NULL, NULL, NULL)`)
+
+	ctx := context.Background()
+	repo := NewSiteContentAggregateRepository(NewBaseRepository(testDB), nil, nil, nil, nil, nil)
+
+	// Organization and inactive links are left out; links that never expire come first
+	links, err := repo.GetAnonymousSharingLinksForAuditRun(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+
+	assert.Equal(t, "anyone-task", links[0].ID)
+	assert.Equal(t, "list-2", links[0].ListID)
+	assert.Equal(t, "Tasks", links[0].ListTitle)
+	assert.Equal(t, "1_.000", links[0].ItemName)
+	assert.True(t, links[0].IsEditLink)
+	assert.Nil(t, links[0].Expiration)
+	require.NotNil(t, links[0].CreatedBy)
+	assert.Equal(t, "Ada", links[0].CreatedBy.Title)
+	require.NotNil(t, links[0].LastModifiedBy)
+	assert.Equal(t, "Grace", links[0].LastModifiedBy.Title)
+
+	assert.Equal(t, "anyone-plans", links[1].ID)
+	assert.Equal(t, "Documents", links[1].ListTitle)
+	assert.True(t, links[1].ItemIsFolder)
+	require.NotNil(t, links[1].Expiration)
+	assert.Equal(t, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC), links[1].Expiration.UTC())
+	assert.Nil(t, links[1].LastModifiedBy)
+}
+
+func TestSiteContentAggregateRepository_GetLinkCreatorsForAuditRun(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES
+		(1, 10, 1, 'Ada', 'i:0#.f|membership|ada@contoso.com', 1),
+		(1, 11, 1, 'Grace', 'i:0#.f|membership|grace@contoso.com', 1),
+		(1, 20, 1, 'Guest', 'i:0#.f|membership|guest_fabrikam.com#ext#@contoso.onmicrosoft.com', 1)`)
+	mustExec(t, testDB, `INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, link_kind, scope, is_active, is_edit_link,
+			has_external_guest_invitees, created_by_principal_id) VALUES
+		(1, 'ada-anyone-1', 1, 'a', 4, 0, 1, 0, 0, 10),
+		(1, 'ada-anyone-2', 1, 'b', 4, 0, 1, 0, 0, 10),
+		(1, 'ada-direct', 1, 'c', 1, 2, 1, 1, 0, 10),
+		(1, 'grace-invited', 1, 'a', 6, 2, 1, 0, 1, 11),
+		(1, 'grace-member', 1, 'b', 1, 2, 1, 0, 0, 11),
+		(1, 'grace-org', 1, 'c', 3, 1, 1, 1, 0, 11),
+		(1, 'grace-org-2', 1, 'd', 2, 1, 1, 0, 0, 11),
+		(1, 'grace-revoked', 1, 'd', 4, 0, 0, 0, 0, 11),
+		(1, 'unknown-creator', 1, 'd', 4, 0, 1, 0, 0, NULL)`)
+	mustExec(t, testDB, `INSERT INTO sharing_link_members (site_id, link_id, principal_id, audit_run_id) VALUES (1, 'grace-member', 20, 1)`)
+
+	ctx := context.Background()
+	repo := NewSiteContentAggregateRepository(NewBaseRepository(testDB), nil, nil, nil, nil, nil)
+
+	// Inactive links and links without a captured creator are left out
+	creators, err := repo.GetLinkCreatorsForAuditRun(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, creators, 2)
+
+	// Creators exposing as many links come by the links they created; links reach external users
+	// through guest invitees or guest members
+	assert.Equal(t, "Grace", creators[0].Principal.Title)
+	assert.Equal(t, int64(4), creators[0].Links)
+	assert.Equal(t, int64(0), creators[0].AnonymousLinks)
+	assert.Equal(t, int64(2), creators[0].ExternalLinks)
+	assert.Equal(t, int64(2), creators[0].ExposedLinks)
+	assert.Equal(t, int64(1), creators[0].EditLinks)
+
+	assert.Equal(t, "Ada", creators[1].Principal.Title)
+	assert.Equal(t, int64(10), creators[1].Principal.ID)
+	assert.Equal(t, int64(3), creators[1].Links)
+	assert.Equal(t, int64(2), creators[1].AnonymousLinks)
+	assert.Equal(t, int64(0), creators[1].ExternalLinks)
+	assert.Equal(t, int64(1), creators[1].EditLinks)
+}
+
+func TestSiteContentAggregateRepository_GetSharingGovernanceForAuditRun(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`)
+	mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (2, 'job-2', 1, CURRENT_TIMESTAMP)`)
+
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+	repo := NewSiteContentAggregateRepository(NewBaseRepository(testDB), nil, nil, nil, nil, nil)
+
+	// Each run keeps its own policy instead of overwriting the site's
+	require.NoError(t, auditRepo.SaveSharingGovernance(ctx, 1, 1, &sharepoint.SharingInfo{
+		CanAddExternalPrincipal: true, DefaultLinkKind: sharepoint.LinkKindOrganizationEdit, DefaultShareLinkPermission: sharepoint.RoleEdit, DefaultShareLinkScope: sharepoint.ScopeOrganization,
+	}))
+	require.NoError(t, auditRepo.SaveSharingGovernance(ctx, 2, 1, &sharepoint.SharingInfo{SiteIBSegmentIDs: []string{"seg-1", "seg-2"}}))
+	abilities := &sharepoint.SharingAbilities{
+		CanStopSharing:      true,
+		AnyoneLinkAbilities: &sharepoint.SharingLinkAbilities{CanGetEditLink: sharepoint.SharingAbilityStatus{DisabledReason: 3}},
+	}
+	require.NoError(t, auditRepo.SaveSharingAbilities(ctx, 2, 1, abilities))
+	limits := &sharepoint.RecipientLimits{ShareLink: &sharepoint.RecipientLimitsInfo{EmailOnly: 50}}
+	require.NoError(t, auditRepo.SaveRecipientLimits(ctx, 2, 1, limits))
+
+	first, err := repo.GetSharingGovernanceForAuditRun(ctx, 1, 1)
+	require.NoError(t, err)
+	require.NotNil(t, first)
+	assert.True(t, first.CanAddExternalPrincipal)
+	assert.Equal(t, &sharepoint.DefaultSharingLink{LinkKind: 3, Permission: 2, Scope: 1}, first.DefaultSharingLink)
+	assert.Nil(t, first.SharingAbilities)
+	assert.Nil(t, first.RecipientLimits)
+
+	second, err := repo.GetSharingGovernanceForAuditRun(ctx, 1, 2)
+	require.NoError(t, err)
+	require.NotNil(t, second)
+	assert.Equal(t, int64(2), second.AuditRunID)
+	assert.False(t, second.CanAddExternalPrincipal)
+	assert.Equal(t, []string{"seg-1", "seg-2"}, second.SiteIBSegmentIDs)
+	assert.Equal(t, abilities, second.SharingAbilities)
+	assert.Equal(t, limits, second.RecipientLimits)
+
+	previous, err := repo.GetPreviousSharingGovernanceAuditRun(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), previous)
+
+	none, err := repo.GetPreviousSharingGovernanceAuditRun(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Zero(t, none)
+}
+
+func TestSiteContentAggregateRepository_GetDeletedItemLinks(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`)
+	mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (2, 'job-2', 1, CURRENT_TIMESTAMP)`)
+	mustExec(t, testDB, `INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, name, url, is_file) VALUES
+		(1, 'budget', 1, 'list-1', 1, 'budget.xlsx', 'https://contoso.sharepoint.com/sites/a/Shared%20Documents/budget.xlsx', 1),
+		(1, 'notes', 1, 'list-1', 2, 'notes.docx', 'https://contoso.sharepoint.com/sites/a/Shared%20Documents/notes.docx', 1),
+		(1, 'plan', 1, 'list-1', 3, 'plan.docx', 'https://contoso.sharepoint.com/sites/a/Shared%20Documents/plan.docx', 1)`)
+	mustExec(t, testDB, `INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, file_folder_unique_id, link_kind, scope, is_active) VALUES
+		(1, 'anyone-budget', 1, 'budget', 'budget', 5, 0, 1),
+		(1, 'revoked-notes', 1, 'notes', 'notes', 3, 1, 0),
+		(1, 'org-plan', 1, 'plan', 'plan', 3, 1, 1)`)
+
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+	repo := NewSiteContentAggregateRepository(NewBaseRepository(testDB), nil, nil, nil, nil, nil)
+
+	scanned, err := repo.GetRecycleBinScan(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Nil(t, scanned, "run 2 has not scanned the recycle bin yet")
+
+	now := time.Now().UTC()
+	require.NoError(t, auditRepo.SaveRecycleBinScan(ctx, 2, 1, now, []*sharepoint.RecycleBinItem{
+		{ID: "rb-budget", ItemType: sharepoint.RecycleBinItemTypeFile, ItemState: sharepoint.RecycleBinStageFirst, Title: "budget.xlsx",
+			URL: "https://contoso.sharepoint.com/sites/a/Shared%20Documents/budget.xlsx", DeletedAt: now.Add(time.Hour), DeletedByName: "Alice"},
+		{ID: "rb-notes", ItemType: sharepoint.RecycleBinItemTypeFile, ItemState: sharepoint.RecycleBinStageFirst, Title: "notes.docx",
+			URL: "https://contoso.sharepoint.com/sites/a/Shared%20Documents/notes.docx", DeletedAt: now.Add(time.Hour)},
+		// Deleted before run 1 started, so run 1 captured a newer plan.docx
+		{ID: "rb-plan", ItemType: sharepoint.RecycleBinItemTypeFile, ItemState: sharepoint.RecycleBinStageSecond, Title: "plan.docx",
+			URL: "https://contoso.sharepoint.com/sites/a/Shared%20Documents/plan.docx", DeletedAt: now.Add(-48 * time.Hour)},
+	}))
+
+	scanned, err = repo.GetRecycleBinScan(ctx, 1, 2)
+	require.NoError(t, err)
+	require.NotNil(t, scanned)
+
+	items, err := repo.GetRecycleBinItems(ctx, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, "rb-plan", items[2].ID, "most recently deleted first")
+	assert.Equal(t, "Alice", items[0].DeletedByName)
+
+	// Inactive links are left out; telling apart items replaced at the same location is left to the caller
+	links, err := repo.GetDeletedItemLinks(ctx, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, links, 2)
+	assert.Equal(t, "rb-budget", links[0].RecycleBinID)
+	assert.Equal(t, int64(1), links[0].AuditRunID)
+	assert.False(t, links[0].AuditRunAt.IsZero())
+	assert.Equal(t, "budget.xlsx", links[0].ItemName)
+	assert.Equal(t, "anyone-budget", links[0].LinkID)
+	assert.Equal(t, 5, links[0].LinkKind)
+	assert.Equal(t, "rb-plan", links[1].RecycleBinID)
+}
+
+func TestSiteContentAggregateRepository_GetAppPermissionFacts(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	seedListPermissions(t, testDB, 1, 1)
+	mustExec(t, testDB, `INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES
+		(1, 20, 1, 'Approvals', 'i:0i.t|ms.sp.ext|approvals-client@realm', 1),
+		(1, 21, 1, 'Backup', 'I:0i.t|00000003-0000-0ff1-ce00-000000000000|backup-client@realm', 1)`)
+	mustExec(t, testDB, `INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES
+		(1, 'list', 'list-1', 20, 2, 1),
+		(1, 'web', 'web-1', 21, 1, 1)`)
+
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+	repo := NewSiteContentAggregateRepository(NewBaseRepository(testDB), nil, nil, nil, nil, nil)
+
+	collected, err := repo.GetAppInventory(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Nil(t, collected, "run 1 has not collected installed apps yet")
+
+	require.NoError(t, auditRepo.SaveAppInventory(ctx, 1, 1, time.Now().UTC(), []*sharepoint.InstalledApp{
+		{AppID: "app-1", Title: "Approvals", AppSource: sharepoint.AppSourceCorporateCatalog, AppPrincipalID: "i:0i.t|ms.sp.ext|approvals-client@realm"},
+	}))
+	collected, err = repo.GetAppInventory(ctx, 1, 1)
+	require.NoError(t, err)
+	require.NotNil(t, collected)
+
+	apps, err := repo.GetInstalledApps(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, apps, 1)
+	assert.Equal(t, "tenant_catalog", apps[0].SourceName())
+
+	// The user assignment on the list is left out
+	facts, err := repo.GetAppPermissionFacts(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, facts, 2)
+	assert.Equal(t, int64(20), facts[0].Principal.ID)
+	assert.Equal(t, "Documents", facts[0].ObjectName)
+	assert.Equal(t, "Read", facts[0].Role)
+	assert.Equal(t, sharepoint.PrincipalKindApp, facts[1].Principal.Kind())
+	assert.Equal(t, "web", facts[1].ObjectType)
+}
+
+func TestSiteContentAggregateRepository_GetGraphAppGrants(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	seedListPermissions(t, testDB, 1, 1)
+
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+	repo := NewSiteContentAggregateRepository(NewBaseRepository(testDB), nil, nil, nil, nil, nil)
+
+	scanned, err := repo.GetGraphGrantScan(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Nil(t, scanned, "run 1 has not audited Graph grants yet")
+
+	require.NoError(t, auditRepo.SaveGraphGrantScan(ctx, 1, 1, time.Now().UTC(), []*sharepoint.GraphAppGrant{
+		{GrantID: "assign-1", Kind: sharepoint.GraphGrantTenantWide, AppID: "backup-client", AppName: "Backup", Roles: []string{"Sites.Read.All"}, Resource: "Microsoft Graph"},
+		{GrantID: "perm-1", Kind: sharepoint.GraphGrantSiteSelected, AppID: "sync-client", AppName: "Sync", Roles: []string{"read", "write"}},
+	}))
+	scanned, err = repo.GetGraphGrantScan(ctx, 1, 1)
+	require.NoError(t, err)
+	require.NotNil(t, scanned)
+
+	grants, err := repo.GetGraphAppGrants(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, grants, 2)
+	assert.Equal(t, sharepoint.GraphGrantSiteSelected, grants[0].Kind, "site grants come first")
+	assert.Equal(t, []string{"read", "write"}, grants[0].Roles)
+	assert.True(t, grants[0].CanWrite())
+	assert.Equal(t, "Microsoft Graph", grants[1].Resource)
+	assert.False(t, grants[1].CanWrite())
+}
+
+func TestSiteContentAggregateRepository_GetHubAssociation(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	seedListPermissions(t, testDB, 1, 1)
+
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+	repo := NewSiteContentAggregateRepository(NewBaseRepository(testDB), nil, nil, nil, nil, nil)
+
+	hub, err := repo.GetHubAssociation(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Nil(t, hub, "run 1 has not collected its hub yet")
+
+	require.NoError(t, auditRepo.SaveHubAssociation(ctx, 1, 1, time.Now().UTC(), &sharepoint.HubAssociation{}))
+	hub, err = repo.GetHubAssociation(ctx, 1, 1)
+	require.NoError(t, err)
+	require.NotNil(t, hub)
+	assert.False(t, hub.IsAssociated(), "no hub is recorded as such")
+
+	require.NoError(t, auditRepo.SaveHubAssociation(ctx, 1, 1, time.Now().UTC(), &sharepoint.HubAssociation{
+		HubSiteID: "hub-1", HubTitle: "Finance", HubURL: "https://contoso.sharepoint.com/sites/finance", PermissionsSync: true,
+	}))
+	hub, err = repo.GetHubAssociation(ctx, 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Finance", hub.HubTitle)
+	assert.True(t, hub.InheritsVisitors())
+}
+
+func TestSiteContentAggregateRepository_GetGroupMembersForAuditRun(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+	repo := NewSiteContentAggregateRepository(NewBaseRepository(testDB), nil, nil, nil, nil, nil)
+
+	owners := &sharepoint.Principal{SiteID: 1, ID: 100, Title: "Finance Owners", LoginName: "Finance Owners", PrincipalType: sharepoint.PrincipalTypeSharePointGroup}
+	require.NoError(t, auditRepo.SavePrincipal(ctx, 1, owners))
+	require.NoError(t, auditRepo.SaveGroupMembers(ctx, 1, 1, owners.ID, []*sharepoint.Principal{
+		{ID: 102, Title: "Zoe", LoginName: "i:0#.f|membership|zoe@contoso.com", Email: "zoe@contoso.com", PrincipalType: sharepoint.PrincipalTypeUser},
+		{ID: 101, Title: "Adele", LoginName: "i:0#.f|membership|adele@contoso.com", PrincipalType: sharepoint.PrincipalTypeUser},
+	}))
+
+	members, err := repo.GetGroupMembersForAuditRun(ctx, 1, 1, owners.ID)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.Equal(t, "Adele", members[0].Title, "members are ordered by title")
+	assert.Equal(t, "Zoe", members[1].Title)
+	assert.Equal(t, "zoe@contoso.com", members[1].Email)
+	assert.Equal(t, int64(1), members[1].SiteID)
+
+	// The reverse lookup sees the same edges
+	groupIDs, err := repo.GetGroupIDsForMember(ctx, 1, 1, 101)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{owners.ID}, groupIDs)
+
+	none, err := repo.GetGroupMembersForAuditRun(ctx, 1, 1, 999)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestSiteContentAggregateRepository_GetAllSitesWithLatestRunMetadata_PrefersCompletedScopedRun(t *testing.T) {
+	// Run 1 is a full site run still in progress; run 2 is an older, completed folder-scoped run
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'completed')`)
+	mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at, completed_at, scope_path) VALUES
+		(2, 'job-2', 1, datetime('now', '-1 day'), datetime('now', '-1 day'), '/sites/a/Shared Documents/Finance')`)
+	mustExec(t, testDB, `INSERT INTO webs (site_id, web_id, audit_run_id, title) VALUES (1, 'web-1', 2, 'Web')`)
+	mustExec(t, testDB, `INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title, has_unique) VALUES
+		(1, 'list-1', 2, 'web-1', 'Documents', 1), (1, 'list-2', 2, 'web-1', 'Finance', 0)`)
+
+	ctx := context.Background()
+	repo := NewSiteContentAggregateRepository(NewBaseRepository(testDB), nil, nil, nil, nil, nil)
+
+	sites, err := repo.GetAllSitesWithLatestRunMetadata(ctx, true, "")
+	require.NoError(t, err)
+	require.Len(t, sites, 1)
+	assert.Equal(t, 2, sites[0].TotalLists, "a completed scoped run ranks ahead of a running full run")
+	assert.Equal(t, 1, sites[0].ListsWithUnique)
+	assert.Equal(t, 1, sites[0].LastAuditDaysAgo)
+
+	sites, err = repo.GetAllSitesWithLatestRunMetadata(ctx, false, "")
+	require.NoError(t, err)
+	require.Len(t, sites, 1)
+	assert.Equal(t, 1, sites[0].TotalLists, "without prefer_completed the full run ranks first")
+	assert.Equal(t, 0, sites[0].LastAuditDaysAgo)
+}
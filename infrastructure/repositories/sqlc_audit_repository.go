@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"spaudit/database"
+	"spaudit/domain/audit"
 	"spaudit/domain/contracts"
 	"spaudit/domain/sharepoint"
 	"spaudit/gen/db"
@@ -57,7 +59,7 @@ func (r *SqlcAuditRepository) GetSiteByURL(ctx context.Context, siteURL string)
 
 // SaveWeb persists a web to the database
 func (r *SqlcAuditRepository) SaveWeb(ctx context.Context, auditRunID int64, web *sharepoint.Web) error {
-	return r.WriteQueries().InsertWeb(ctx, db.InsertWebParams{
+	return r.WriteQueries().InsertWeb(ctx, withWebAccessRequests(db.InsertWebParams{
 		SiteID:     web.SiteID,
 		WebID:      web.ID,
 		Url:        r.ToNullString(web.URL),
@@ -65,13 +67,33 @@ func (r *SqlcAuditRepository) SaveWeb(ctx context.Context, auditRunID int64, web
 		Template:   r.ToNullString(web.Template),
 		HasUnique:  r.ToNullBool(web.HasUnique),
 		AuditRunID: auditRunID,
-	})
+
+		MembersCanShare: r.ToNullBoolPointer(web.MembersCanShare),
+	}, web.AccessRequests))
+}
+
+// withWebAccessRequests sets the access request columns of a web insert, leaving them NULL when not captured.
+func withWebAccessRequests(params db.InsertWebParams, requests *sharepoint.WebAccessRequests) db.InsertWebParams {
+	if requests == nil {
+		return params
+	}
+	params.RequestAccessEmail = sql.NullString{String: requests.Email, Valid: true}
+	params.UseAccessRequestDefault = sql.NullBool{Bool: requests.ToOwners, Valid: true}
+	return params
+}
+
+// toWebAccessRequests maps the access request columns of a web row, nil for runs before they were captured.
+func toWebAccessRequests(email sql.NullString, toOwners sql.NullBool) *sharepoint.WebAccessRequests {
+	if !toOwners.Valid {
+		return nil
+	}
+	return &sharepoint.WebAccessRequests{Email: email.String, ToOwners: toOwners.Bool}
 }
 
 // SaveList persists a list to the database
 func (r *SqlcAuditRepository) SaveList(ctx context.Context, auditRunID int64, list *sharepoint.List) error {
 	// Transform domain List to SQLC params
-	return r.WriteQueries().InsertList(ctx, db.InsertListParams{
+	return r.WriteQueries().InsertList(ctx, withListItemSettings(db.InsertListParams{
 		SiteID:       list.SiteID,
 		ListID:       list.ID,
 		WebID:        list.WebID,
@@ -81,12 +103,38 @@ func (r *SqlcAuditRepository) SaveList(ctx context.Context, auditRunID int64, li
 		ItemCount:    r.ToNullInt64(int64(list.ItemCount)),
 		HasUnique:    r.ToNullBool(list.HasUnique),
 		AuditRunID:   auditRunID,
+	}, list.ItemSettings))
+}
+
+// UpdateListUniqueDensity computes unique item counts and density for all lists in an audit run
+func (r *SqlcAuditRepository) UpdateListUniqueDensity(ctx context.Context, auditRunID, siteID int64) error {
+	return r.WriteQueries().UpdateListUniqueDensityByAuditRun(ctx, db.UpdateListUniqueDensityByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+	})
+}
+
+// UpdateListAccessCounts computes assignment, external principal and sharing link counts for all lists in an audit run
+func (r *SqlcAuditRepository) UpdateListAccessCounts(ctx context.Context, auditRunID, siteID int64) error {
+	return r.WriteQueries().UpdateListAccessCountsByAuditRun(ctx, db.UpdateListAccessCountsByAuditRunParams{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+	})
+}
+
+// UpdateListSampling records how many items of a sampled list were deep-scanned
+func (r *SqlcAuditRepository) UpdateListSampling(ctx context.Context, auditRunID, siteID int64, listID string, sampledItemCount int) error {
+	return r.WriteQueries().UpdateListSamplingByAuditRun(ctx, db.UpdateListSamplingByAuditRunParams{
+		SampledItemCount: r.ToNullInt64(int64(sampledItemCount)),
+		SiteID:           siteID,
+		ListID:           listID,
+		AuditRunID:       auditRunID,
 	})
 }
 
-// SaveItem persists an item to the database
+// SaveItem persists an item and its attachments to the database
 func (r *SqlcAuditRepository) SaveItem(ctx context.Context, auditRunID int64, item *sharepoint.Item) error {
-	return r.WriteQueries().InsertItem(ctx, db.InsertItemParams{
+	if err := r.WriteQueries().InsertItem(ctx, db.InsertItemParams{
 		SiteID:       item.SiteID,
 		ItemGuid:     item.GUID,
 		ListItemGuid: r.ToNullString(item.ListItemGUID),
@@ -98,7 +146,29 @@ func (r *SqlcAuditRepository) SaveItem(ctx context.Context, auditRunID int64, it
 		HasUnique:    r.ToNullBool(item.HasUnique),
 		Name:         r.ToNullString(item.Name),
 		AuditRunID:   auditRunID,
-	})
+
+		FileFolderUniqueID: r.ToNullString(item.FileFolderUniqueID),
+		FileSize:           r.ToNullInt64(item.Size),
+		SpCreatedAt:        r.ToNullTime(item.CreatedAt),
+		SpModifiedAt:       r.ToNullTime(item.ModifiedAt),
+		ContentTypeID:      r.ToNullString(item.ContentTypeID),
+		UnreadableFields:   r.ToNullList(item.UnreadableFields),
+	}); err != nil {
+		return err
+	}
+
+	for _, attachment := range item.Attachments {
+		if err := r.WriteQueries().InsertItemAttachment(ctx, db.InsertItemAttachmentParams{
+			SiteID:     item.SiteID,
+			ItemGuid:   item.GUID,
+			AuditRunID: auditRunID,
+			FileName:   attachment.FileName,
+			Url:        r.ToNullString(attachment.URL),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // SaveRoleDefinitions persists role definitions to the database
@@ -137,10 +207,33 @@ func (r *SqlcAuditRepository) SavePrincipal(ctx context.Context, auditRunID int6
 	return err
 }
 
-// SaveRoleAssignments persists role assignments to the database
+// SaveRoleAssignments persists role assignments to the database in a single transaction
 func (r *SqlcAuditRepository) SaveRoleAssignments(ctx context.Context, auditRunID int64, siteID int64, assignments []*sharepoint.RoleAssignment) error {
+	return r.WithTx(func(queries *db.Queries) error {
+		return r.insertRoleAssignments(ctx, queries, auditRunID, siteID, assignments)
+	})
+}
+
+// ReplaceRoleAssignments replaces the role assignments of an object within an audit run in a single
+// transaction, so an object collected again, e.g. by a resumed run, never ends up with a mix of old
+// and new assignments, and a failed save leaves the previous ones in place.
+func (r *SqlcAuditRepository) ReplaceRoleAssignments(ctx context.Context, auditRunID int64, siteID int64, objectType, objectKey string, assignments []*sharepoint.RoleAssignment) error {
+	return r.WithTx(func(queries *db.Queries) error {
+		if err := queries.DeleteRoleAssignmentsForObject(ctx, db.DeleteRoleAssignmentsForObjectParams{
+			SiteID:     siteID,
+			ObjectType: objectType,
+			ObjectKey:  objectKey,
+			AuditRunID: auditRunID,
+		}); err != nil {
+			return fmt.Errorf("clear role assignments of %s %s: %w", objectType, objectKey, err)
+		}
+		return r.insertRoleAssignments(ctx, queries, auditRunID, siteID, assignments)
+	})
+}
+
+func (r *SqlcAuditRepository) insertRoleAssignments(ctx context.Context, queries *db.Queries, auditRunID int64, siteID int64, assignments []*sharepoint.RoleAssignment) error {
 	for _, assignment := range assignments {
-		if err := r.WriteQueries().InsertRoleAssignment(ctx, db.InsertRoleAssignmentParams{
+		if err := queries.InsertRoleAssignment(ctx, db.InsertRoleAssignmentParams{
 			SiteID:      siteID,
 			ObjectType:  assignment.ObjectType,
 			ObjectKey:   assignment.ObjectKey,
@@ -155,12 +248,85 @@ func (r *SqlcAuditRepository) SaveRoleAssignments(ctx context.Context, auditRunI
 	return nil
 }
 
-// ClearRoleAssignments removes existing role assignments for an object
-func (r *SqlcAuditRepository) ClearRoleAssignments(ctx context.Context, siteID int64, objectType, objectKey string) error {
-	return r.WriteQueries().DeleteRoleAssignmentsForObject(ctx, db.DeleteRoleAssignmentsForObjectParams{
+// SaveGroupMembers persists the members of a SharePoint group, saving each member as a principal first
+func (r *SqlcAuditRepository) SaveGroupMembers(ctx context.Context, auditRunID int64, siteID int64, groupID int64, members []*sharepoint.Principal) error {
+	for _, member := range members {
+		member.SiteID = siteID
+		if err := r.SavePrincipal(ctx, auditRunID, member); err != nil {
+			return fmt.Errorf("save group member principal %d: %w", member.ID, err)
+		}
+		if err := r.WriteQueries().InsertGroupMember(ctx, db.InsertGroupMemberParams{
+			SiteID:     siteID,
+			GroupID:    groupID,
+			MemberID:   member.ID,
+			AuditRunID: auditRunID,
+		}); err != nil {
+			return fmt.Errorf("save member %d of group %d: %w", member.ID, groupID, err)
+		}
+	}
+	return nil
+}
+
+// GetSecurityGroupPrincipals retrieves the security group principals an audit run recorded
+func (r *SqlcAuditRepository) GetSecurityGroupPrincipals(ctx context.Context, auditRunID int64, siteID int64) ([]*sharepoint.Principal, error) {
+	rows, err := r.ReadQueries().GetSecurityGroupPrincipalsByAuditRun(ctx, db.GetSecurityGroupPrincipalsByAuditRunParams{
 		SiteID:     siteID,
-		ObjectType: objectType,
-		ObjectKey:  objectKey,
+		AuditRunID: auditRunID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("query security group principals: %w", err)
+	}
+
+	principals := make([]*sharepoint.Principal, len(rows))
+	for i, row := range rows {
+		principals[i] = &sharepoint.Principal{
+			SiteID:        siteID,
+			ID:            row.PrincipalID,
+			PrincipalType: sharepoint.PrincipalTypeSecurity,
+			Title:         r.FromNullString(row.Title),
+			LoginName:     r.FromNullString(row.LoginName),
+		}
+	}
+	return principals, nil
+}
+
+// SaveEntraGroupMembers records the users an audit run resolved an Entra group principal to, replacing
+// those an earlier attempt of the run resolved, in one transaction
+func (r *SqlcAuditRepository) SaveEntraGroupMembers(ctx context.Context, auditRunID int64, siteID int64, principalID int64, group sharepoint.EntraGroupRef, resolvedAt time.Time, members []*sharepoint.EntraGroupMember) error {
+	return r.WithTx(func(queries *db.Queries) error {
+		if err := queries.DeleteEntraGroupMembers(ctx, db.DeleteEntraGroupMembersParams{
+			SiteID:      siteID,
+			PrincipalID: principalID,
+			AuditRunID:  auditRunID,
+		}); err != nil {
+			return fmt.Errorf("clear entra group members: %w", err)
+		}
+		if err := queries.InsertEntraGroup(ctx, db.InsertEntraGroupParams{
+			SiteID:      siteID,
+			PrincipalID: principalID,
+			AuditRunID:  auditRunID,
+			ObjectID:    group.ObjectID,
+			Owners:      group.Owners,
+			MemberCount: int64(len(members)),
+			ResolvedAt:  resolvedAt,
+		}); err != nil {
+			return fmt.Errorf("save entra group %s: %w", group.ObjectID, err)
+		}
+		for _, member := range members {
+			if err := queries.InsertEntraGroupMember(ctx, db.InsertEntraGroupMemberParams{
+				SiteID:            siteID,
+				PrincipalID:       principalID,
+				AuditRunID:        auditRunID,
+				MemberObjectID:    member.ObjectID,
+				DisplayName:       r.ToNullString(member.DisplayName),
+				UserPrincipalName: r.ToNullString(member.UserPrincipalName),
+				Mail:              r.ToNullString(member.Mail),
+				UserType:          r.ToNullString(member.UserType),
+			}); err != nil {
+				return fmt.Errorf("save member %s of entra group %s: %w", member.ObjectID, group.ObjectID, err)
+			}
+		}
+		return nil
 	})
 }
 
@@ -412,7 +578,7 @@ func (r *SqlcAuditRepository) intPtrToNullInt64(value *int) sql.NullInt64 {
 	return sql.NullInt64{Int64: int64(*value), Valid: true}
 }
 
-// SaveSharingGovernance persists site-level sharing governance data
+// SaveSharingGovernance persists the site's sharing governance as captured by the audit run
 func (r *SqlcAuditRepository) SaveSharingGovernance(ctx context.Context, auditRunID, siteID int64, sharingInfo *sharepoint.SharingInfo) error {
 	if sharingInfo == nil {
 		return nil // No governance data to save
@@ -421,8 +587,8 @@ func (r *SqlcAuditRepository) SaveSharingGovernance(ctx context.Context, auditRu
 	// Convert slice to JSON string for database storage
 	var segmentIDs string
 	if len(sharingInfo.SiteIBSegmentIDs) > 0 {
-		// Simple JSON array representation
-		segmentIDs = `["` + strings.Join(sharingInfo.SiteIBSegmentIDs, `","`) + `"]`
+		encoded, _ := json.Marshal(sharingInfo.SiteIBSegmentIDs)
+		segmentIDs = string(encoded)
 	}
 
 	return r.WriteQueries().UpsertSharingGovernance(ctx, db.UpsertSharingGovernanceParams{
@@ -440,10 +606,15 @@ func (r *SqlcAuditRepository) SaveSharingGovernance(ctx context.Context, auditRu
 		SiteIbMode:                             r.ToNullString(sharingInfo.SiteIBMode),
 		SiteIbSegmentIds:                       r.ToNullString(segmentIDs),
 		EnforceIbSegmentFiltering:              r.ToNullBool(sharingInfo.EnforceIBSegmentFiltering),
+
+		// Zero is a meaningful kind, role and scope, so the defaults are always stored
+		DefaultLinkKind:            sql.NullInt64{Int64: int64(sharingInfo.DefaultLinkKind), Valid: true},
+		DefaultShareLinkPermission: sql.NullInt64{Int64: int64(sharingInfo.DefaultShareLinkPermission), Valid: true},
+		DefaultShareLinkScope:      sql.NullInt64{Int64: int64(sharingInfo.DefaultShareLinkScope), Valid: true},
 	})
 }
 
-// SaveSharingAbilities persists site-level sharing abilities data as JSON
+// SaveSharingAbilities persists the site's sharing abilities as captured by the audit run, as JSON
 func (r *SqlcAuditRepository) SaveSharingAbilities(ctx context.Context, auditRunID, siteID int64, abilities *sharepoint.SharingAbilities) error {
 	if abilities == nil {
 		return nil // No abilities data to save
@@ -468,7 +639,7 @@ func (r *SqlcAuditRepository) SaveSharingAbilities(ctx context.Context, auditRun
 	})
 }
 
-// SaveRecipientLimits persists recipient limits data as JSON
+// SaveRecipientLimits persists the site's recipient limits as captured by the audit run, as JSON
 func (r *SqlcAuditRepository) SaveRecipientLimits(ctx context.Context, auditRunID, siteID int64, limits *sharepoint.RecipientLimits) error {
 	if limits == nil {
 		return nil // No limits data to save
@@ -518,6 +689,7 @@ func (r *SqlcAuditRepository) SaveItemSensitivityLabel(ctx context.Context, labe
 	return r.WriteQueries().UpsertItemSensitivityLabel(ctx, db.UpsertItemSensitivityLabelParams{
 		SiteID:           label.SiteID,
 		ItemGuid:         label.ItemGUID,
+		AuditRunID:       label.AuditRunID,
 		LabelID:          r.ToNullString(label.LabelID),
 		DisplayName:      r.ToNullString(label.DisplayName),
 		OwnerEmail:       r.ToNullString(label.OwnerEmail),
@@ -532,107 +704,278 @@ func (r *SqlcAuditRepository) SaveItemSensitivityLabel(ctx context.Context, labe
 	})
 }
 
-// GetSitesByAuditRun retrieves all sites from a specific audit run
+// SaveItemAnalytics persists usage analytics for a shared item
+func (r *SqlcAuditRepository) SaveItemAnalytics(ctx context.Context, analytics *sharepoint.ItemAnalytics) error {
+	if analytics == nil {
+		return nil // No analytics data to save
+	}
+
+	return r.WriteQueries().UpsertItemAnalytics(ctx, db.UpsertItemAnalyticsParams{
+		SiteID:      analytics.SiteID,
+		ItemGuid:    analytics.ItemGUID,
+		AuditRunID:  analytics.AuditRunID,
+		ViewCount:   analytics.ViewCount,
+		ViewerCount: analytics.ViewerCount,
+	})
+}
+
+// SaveRecycleBinScan records a recycle bin scan and the deleted items it found in one transaction,
+// so a scan is never recorded with only some of its items
+func (r *SqlcAuditRepository) SaveRecycleBinScan(ctx context.Context, auditRunID, siteID int64, scannedAt time.Time, items []*sharepoint.RecycleBinItem) error {
+	return r.WithTx(func(queries *db.Queries) error {
+		if err := queries.InsertRecycleBinScan(ctx, db.InsertRecycleBinScanParams{
+			SiteID:     siteID,
+			AuditRunID: auditRunID,
+			ScannedAt:  scannedAt,
+		}); err != nil {
+			return fmt.Errorf("save recycle bin scan: %w", err)
+		}
+		for _, item := range items {
+			if err := queries.InsertRecycleBinItem(ctx, db.InsertRecycleBinItemParams{
+				SiteID:         siteID,
+				AuditRunID:     auditRunID,
+				RecycleBinID:   item.ID,
+				ItemType:       int64(item.ItemType),
+				ItemState:      int64(item.ItemState),
+				Title:          r.ToNullString(item.Title),
+				Url:            item.URL,
+				DeletedAt:      item.DeletedAt,
+				DeletedByName:  r.ToNullString(item.DeletedByName),
+				DeletedByEmail: r.ToNullString(item.DeletedByEmail),
+			}); err != nil {
+				return fmt.Errorf("save recycle bin item %s: %w", item.ID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// SaveAppInventory records that an audit run collected the site's installed apps, and the apps it
+// found, in one transaction
+func (r *SqlcAuditRepository) SaveAppInventory(ctx context.Context, auditRunID, siteID int64, collectedAt time.Time, apps []*sharepoint.InstalledApp) error {
+	return r.WithTx(func(queries *db.Queries) error {
+		if err := queries.InsertAppInventory(ctx, db.InsertAppInventoryParams{
+			SiteID:      siteID,
+			AuditRunID:  auditRunID,
+			CollectedAt: collectedAt,
+		}); err != nil {
+			return fmt.Errorf("save app inventory: %w", err)
+		}
+		for _, app := range apps {
+			if err := queries.InsertInstalledApp(ctx, db.InsertInstalledAppParams{
+				SiteID:         siteID,
+				AuditRunID:     auditRunID,
+				AppID:          app.AppID,
+				ProductID:      r.ToNullString(app.ProductID),
+				Title:          r.ToNullString(app.Title),
+				AppSource:      int64(app.AppSource),
+				AppPrincipalID: r.ToNullString(app.AppPrincipalID),
+				LastModified:   r.ToNullTime(app.LastModified),
+			}); err != nil {
+				return fmt.Errorf("save installed app %s: %w", app.AppID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// SaveGraphGrantScan records that an audit run read the site's Graph app grants, and the grants it
+// found, in one transaction
+func (r *SqlcAuditRepository) SaveGraphGrantScan(ctx context.Context, auditRunID, siteID int64, scannedAt time.Time, grants []*sharepoint.GraphAppGrant) error {
+	return r.WithTx(func(queries *db.Queries) error {
+		if err := queries.InsertGraphGrantScan(ctx, db.InsertGraphGrantScanParams{
+			SiteID:     siteID,
+			AuditRunID: auditRunID,
+			ScannedAt:  scannedAt,
+		}); err != nil {
+			return fmt.Errorf("save graph grant scan: %w", err)
+		}
+		for _, grant := range grants {
+			if err := queries.InsertGraphAppGrant(ctx, db.InsertGraphAppGrantParams{
+				SiteID:     siteID,
+				AuditRunID: auditRunID,
+				GrantKind:  grant.Kind,
+				GrantID:    grant.GrantID,
+				AppID:      grant.AppID,
+				AppName:    r.ToNullString(grant.AppName),
+				Roles:      strings.Join(grant.Roles, ","),
+				Resource:   r.ToNullString(grant.Resource),
+			}); err != nil {
+				return fmt.Errorf("save graph app grant %s: %w", grant.GrantID, err)
+			}
+		}
+		return nil
+	})
+}
+
+// SaveHubAssociation records the hub an audit run found the site associated with, none included
+func (r *SqlcAuditRepository) SaveHubAssociation(ctx context.Context, auditRunID, siteID int64, collectedAt time.Time, hub *sharepoint.HubAssociation) error {
+	if err := r.WriteQueries().InsertSiteHub(ctx, db.InsertSiteHubParams{
+		SiteID:          siteID,
+		AuditRunID:      auditRunID,
+		IsHub:           hub.IsHub,
+		HubSiteID:       r.ToNullString(hub.HubSiteID),
+		HubTitle:        r.ToNullString(hub.HubTitle),
+		HubUrl:          r.ToNullString(hub.HubURL),
+		ParentHubSiteID: r.ToNullString(hub.ParentHubSiteID),
+		PermissionsSync: hub.PermissionsSync,
+		CollectedAt:     collectedAt,
+	}); err != nil {
+		return fmt.Errorf("save hub association: %w", err)
+	}
+	return nil
+}
+
+// SaveSchemaDrift records the API schema drift observed by an audit run
+func (r *SqlcAuditRepository) SaveSchemaDrift(ctx context.Context, auditRunID int64, drift []audit.SchemaDrift) error {
+	for _, d := range drift {
+		if err := r.WriteQueries().InsertAuditRunSchemaDrift(ctx, db.InsertAuditRunSchemaDriftParams{
+			AuditRunID:  auditRunID,
+			Source:      d.Source,
+			Field:       d.Field,
+			Kind:        string(d.Kind),
+			Occurrences: d.Occurrences,
+		}); err != nil {
+			return fmt.Errorf("save schema drift %s.%s: %w", d.Source, d.Field, err)
+		}
+	}
+	return nil
+}
+
+// SaveListCheckpoint records how far an audit run got through a list, replacing its previous checkpoint
+func (r *SqlcAuditRepository) SaveListCheckpoint(ctx context.Context, auditRunID int64, checkpoint *audit.ListCheckpoint) error {
+	return r.WriteQueries().UpsertListCheckpoint(ctx, db.UpsertListCheckpointParams{
+		AuditRunID: auditRunID,
+		ListID:     checkpoint.ListID,
+		LastItemID: int64(checkpoint.LastItemID),
+		Completed:  checkpoint.Completed,
+		UpdatedAt:  checkpoint.UpdatedAt,
+	})
+}
+
+// GetListCheckpoints retrieves how far an audit run got through each list it started
+func (r *SqlcAuditRepository) GetListCheckpoints(ctx context.Context, auditRunID int64) ([]*audit.ListCheckpoint, error) {
+	rows, err := r.ReadQueries().GetListCheckpointsByAuditRun(ctx, auditRunID)
+	if err != nil {
+		return nil, fmt.Errorf("query list checkpoints: %w", err)
+	}
+
+	checkpoints := make([]*audit.ListCheckpoint, len(rows))
+	for i, row := range rows {
+		checkpoints[i] = &audit.ListCheckpoint{
+			ListID:     row.ListID,
+			LastItemID: int(row.LastItemID),
+			Completed:  row.Completed,
+			UpdatedAt:  row.UpdatedAt,
+		}
+	}
+	return checkpoints, nil
+}
+
+// GetSitesByAuditRun retrieves the site audited by a specific audit run
 func (r *SqlcAuditRepository) GetSitesByAuditRun(ctx context.Context, auditRunID int64) ([]*sharepoint.Site, error) {
-	rows, err := r.BaseRepository.db.ReadDB().QueryContext(ctx,
-		"SELECT site_id, site_url, title, created_at, updated_at FROM sites WHERE audit_run_id = ?",
-		auditRunID)
+	rows, err := r.ReadQueries().GetSitesForAuditRun(ctx, auditRunID)
 	if err != nil {
 		return nil, fmt.Errorf("query sites by audit run: %w", err)
 	}
-	defer rows.Close()
 
-	var sites []*sharepoint.Site
-	for rows.Next() {
-		site := &sharepoint.Site{}
-		err := rows.Scan(&site.ID, &site.URL, &site.Title, &site.CreatedAt, &site.UpdatedAt)
-		if err != nil {
-			return nil, fmt.Errorf("scan site: %w", err)
-		}
-		sites = append(sites, site)
+	sites := make([]*sharepoint.Site, 0, len(rows))
+	for _, row := range rows {
+		sites = append(sites, &sharepoint.Site{
+			ID:        row.SiteID,
+			URL:       row.SiteUrl,
+			Title:     r.FromNullString(row.Title),
+			CreatedAt: r.FromNullTime(row.CreatedAt),
+			UpdatedAt: r.FromNullTime(row.UpdatedAt),
+		})
 	}
 	return sites, nil
 }
 
-// GetWebsByAuditRun retrieves all webs from a specific audit run
-func (r *SqlcAuditRepository) GetWebsByAuditRun(ctx context.Context, auditRunID int64) ([]*sharepoint.Web, error) {
-	rows, err := r.BaseRepository.db.ReadDB().QueryContext(ctx,
-		"SELECT site_id, web_id, title, url, template, has_unique FROM webs WHERE audit_run_id = ?",
-		auditRunID)
+// GetWebsByAuditRun retrieves a page of the webs from a specific audit run, ordered by site and web ID
+func (r *SqlcAuditRepository) GetWebsByAuditRun(ctx context.Context, auditRunID int64, offset, limit int64) ([]*sharepoint.Web, error) {
+	rows, err := r.ReadQueries().GetWebsForAuditRun(ctx, db.GetWebsForAuditRunParams{
+		AuditRunID: auditRunID,
+		Offset:     offset,
+		Limit:      limit,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("query webs by audit run: %w", err)
 	}
-	defer rows.Close()
 
-	var webs []*sharepoint.Web
-	for rows.Next() {
-		web := &sharepoint.Web{}
-		var hasUnique sql.NullBool
-		err := rows.Scan(&web.SiteID, &web.ID, &web.Title, &web.URL, &web.Template, &hasUnique)
-		if err != nil {
-			return nil, fmt.Errorf("scan web: %w", err)
-		}
-		web.HasUnique = r.FromNullBool(hasUnique)
-		webs = append(webs, web)
+	webs := make([]*sharepoint.Web, 0, len(rows))
+	for _, row := range rows {
+		webs = append(webs, &sharepoint.Web{
+			SiteID:     row.SiteID,
+			ID:         row.WebID,
+			URL:        r.FromNullString(row.Url),
+			Title:      r.FromNullString(row.Title),
+			Template:   r.FromNullString(row.Template),
+			HasUnique:  r.FromNullBool(row.HasUnique),
+			AuditRunID: &auditRunID,
+
+			MembersCanShare: r.FromNullBoolToPointer(row.MembersCanShare),
+			AccessRequests:  toWebAccessRequests(row.RequestAccessEmail, row.UseAccessRequestDefault),
+		})
 	}
 	return webs, nil
 }
 
-// GetListsByAuditRun retrieves all lists from a specific audit run
-func (r *SqlcAuditRepository) GetListsByAuditRun(ctx context.Context, auditRunID int64) ([]*sharepoint.List, error) {
-	rows, err := r.BaseRepository.db.ReadDB().QueryContext(ctx,
-		"SELECT site_id, list_id, web_id, title, base_template, url, item_count, has_unique FROM lists WHERE audit_run_id = ?",
-		auditRunID)
+// GetListsByAuditRun retrieves a page of the lists from a specific audit run, ordered by site and list ID
+func (r *SqlcAuditRepository) GetListsByAuditRun(ctx context.Context, auditRunID int64, offset, limit int64) ([]*sharepoint.List, error) {
+	rows, err := r.ReadQueries().GetListsForAuditRun(ctx, db.GetListsForAuditRunParams{
+		AuditRunID: auditRunID,
+		Offset:     offset,
+		Limit:      limit,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("query lists by audit run: %w", err)
 	}
-	defer rows.Close()
 
-	var lists []*sharepoint.List
-	for rows.Next() {
-		list := &sharepoint.List{}
-		var baseTemplate, itemCount sql.NullInt64
-		var hasUnique sql.NullBool
-		var url sql.NullString
-		err := rows.Scan(&list.SiteID, &list.ID, &list.WebID, &list.Title, &baseTemplate, &url, &itemCount, &hasUnique)
-		if err != nil {
-			return nil, fmt.Errorf("scan list: %w", err)
-		}
-		list.BaseTemplate = int(r.FromNullInt64(baseTemplate))
-		list.URL = r.FromNullString(url)
-		list.ItemCount = int(r.FromNullInt64(itemCount))
-		list.HasUnique = r.FromNullBool(hasUnique)
-		lists = append(lists, list)
+	lists := make([]*sharepoint.List, 0, len(rows))
+	for _, row := range rows {
+		lists = append(lists, &sharepoint.List{
+			SiteID:       row.SiteID,
+			ID:           row.ListID,
+			WebID:        row.WebID,
+			Title:        row.Title,
+			URL:          r.FromNullString(row.Url),
+			BaseTemplate: int(r.FromNullInt64(row.BaseTemplate)),
+			ItemCount:    int(r.FromNullInt64(row.ItemCount)),
+			HasUnique:    r.FromNullBool(row.HasUnique),
+			AuditRunID:   &auditRunID,
+		})
 	}
 	return lists, nil
 }
 
-// GetItemsByAuditRun retrieves all items from a specific audit run
-func (r *SqlcAuditRepository) GetItemsByAuditRun(ctx context.Context, auditRunID int64) ([]*sharepoint.Item, error) {
-	rows, err := r.BaseRepository.db.ReadDB().QueryContext(ctx,
-		"SELECT site_id, item_guid, list_id, item_id, list_item_guid, title, url, name, is_file, is_folder, has_unique FROM items WHERE audit_run_id = ?",
-		auditRunID)
+// GetItemsByAuditRun retrieves a page of the items from a specific audit run, ordered by site, list and item ID
+func (r *SqlcAuditRepository) GetItemsByAuditRun(ctx context.Context, auditRunID int64, offset, limit int64) ([]*sharepoint.Item, error) {
+	rows, err := r.ReadQueries().GetItemsForAuditRun(ctx, db.GetItemsForAuditRunParams{
+		AuditRunID: auditRunID,
+		Offset:     offset,
+		Limit:      limit,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("query items by audit run: %w", err)
 	}
-	defer rows.Close()
 
-	var items []*sharepoint.Item
-	for rows.Next() {
-		item := &sharepoint.Item{}
-		var listItemGUID, title, url, name sql.NullString
-		var isFile, isFolder, hasUnique sql.NullBool
-		err := rows.Scan(&item.SiteID, &item.GUID, &item.ListID, &item.ID, &listItemGUID, &title, &url, &name, &isFile, &isFolder, &hasUnique)
-		if err != nil {
-			return nil, fmt.Errorf("scan item: %w", err)
-		}
-		item.ListItemGUID = r.FromNullString(listItemGUID)
-		item.URL = r.FromNullString(url)
-		item.Name = r.FromNullString(name)
-		item.IsFile = r.FromNullBool(isFile)
-		item.IsFolder = r.FromNullBool(isFolder)
-		item.HasUnique = r.FromNullBool(hasUnique)
-		items = append(items, item)
+	items := make([]*sharepoint.Item, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, &sharepoint.Item{
+			SiteID:           row.SiteID,
+			GUID:             row.ItemGuid,
+			ListItemGUID:     r.FromNullString(row.ListItemGuid),
+			ListID:           row.ListID,
+			ID:               int(row.ItemID),
+			URL:              r.FromNullString(row.Url),
+			Name:             r.FromNullString(row.Name),
+			IsFile:           r.FromNullBool(row.IsFile),
+			IsFolder:         r.FromNullBool(row.IsFolder),
+			HasUnique:        r.FromNullBool(row.HasUnique),
+			AuditRunID:       &auditRunID,
+			UnreadableFields: r.FromNullList(row.UnreadableFields),
+		})
 	}
 	return items, nil
 }
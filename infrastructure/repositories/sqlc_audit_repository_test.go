@@ -0,0 +1,289 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+func TestSqlcAuditRepository_ExtrapolatesSampledListDensity(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `UPDATE lists SET item_count = 10000 WHERE list_id = 'list-1'`)
+	mustExec(t, testDB, `INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title, item_count) VALUES (1, 'list-2', 1, 'web-1', 'Small', 4)`)
+
+	// 40 sampled items of list-1, 10 of them unique; list-2 fully scanned with 1 unique item
+	for i := 0; i < 40; i++ {
+		mustExec(t, testDB, fmt.Sprintf(`INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, has_unique) VALUES (1, 'sampled-%d', 1, 'list-1', %d, %t)`, i, i, i < 10))
+	}
+	for i := 0; i < 4; i++ {
+		mustExec(t, testDB, fmt.Sprintf(`INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, has_unique) VALUES (1, 'small-%d', 1, 'list-2', %d, %t)`, i, i, i == 0))
+	}
+
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+	require.NoError(t, auditRepo.UpdateListSampling(ctx, 1, 1, "list-1", 40))
+	require.NoError(t, auditRepo.UpdateListUniqueDensity(ctx, 1, 1))
+
+	base := NewBaseRepository(testDB)
+	lists, err := NewScopedListRepository(base, base.ReadQueries(), 1, 1).GetAllForSite(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, lists, 2)
+
+	sampled, full := lists[0], lists[1]
+	require.Equal(t, "list-1", sampled.ID)
+	assert.True(t, sampled.IsSampled())
+	assert.Equal(t, 40, sampled.SampledItemCount)
+	assert.InDelta(t, 0.25, sampled.UniqueDensity, 1e-9)
+	assert.Equal(t, 2500, sampled.UniqueItemCount, "unique items are extrapolated to the whole list")
+	assert.Greater(t, sampled.UniqueDensityMargin(), 0.0)
+
+	require.Equal(t, "list-2", full.ID)
+	assert.False(t, full.IsSampled())
+	assert.InDelta(t, 0.25, full.UniqueDensity, 1e-9)
+	assert.Equal(t, 1, full.UniqueItemCount)
+	assert.Zero(t, full.UniqueDensityMargin())
+}
+
+func TestSqlcAuditRepository_UpdateListAccessCounts(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title) VALUES (1, 'list-2', 1, 'web-1', 'Other')`)
+	seedListPermissions(t, testDB, 0, 2)
+
+	// user1 and a sharing link member are guests; a guest granted access on another list is not counted
+	mustExec(t, testDB, `UPDATE principals SET login_name = 'i:0#.f|membership|guest_fabrikam.com#ext#@contoso.onmicrosoft.com' WHERE principal_id = 1`)
+	mustExec(t, testDB, `INSERT INTO principals (site_id, principal_id, audit_run_id, title, login_name, principal_type) VALUES
+		(1, 50, 1, 'Guest', 'urn:spo:guest#partner@fabrikam.com', 1),
+		(1, 51, 1, 'Other guest', 'i:0#.f|membership|other_fabrikam.com#ext#@contoso.onmicrosoft.com', 1)`)
+	mustExec(t, testDB, `INSERT INTO sharing_link_members (site_id, link_id, principal_id, audit_run_id) VALUES (1, 'link-0', 50, 1)`)
+	mustExec(t, testDB, `INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES
+		(1, 'item', 'item-0', 0, 1, 1), (1, 'list', 'list-2', 51, 2, 1)`)
+	mustExec(t, testDB, `INSERT INTO sharing_links (site_id, link_id, audit_run_id, item_guid, link_kind, is_active) VALUES (1, 'expired', 1, 'item-1', 4, 0)`)
+
+	ctx := context.Background()
+	require.NoError(t, NewSqlcAuditRepository(testDB).UpdateListAccessCounts(ctx, 1, 1))
+
+	base := NewBaseRepository(testDB)
+	list, err := NewScopedListRepository(base, base.ReadQueries(), 1, 1).GetByID(ctx, 1, "list-1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, list.UniqueAssignmentCount, "two list assignments and one item assignment")
+	assert.Equal(t, 2, list.ExternalPrincipalCount)
+	assert.Equal(t, 2, list.SharingLinkCount, "inactive links are not counted")
+
+	other, err := NewScopedListRepository(base, base.ReadQueries(), 1, 1).GetByID(ctx, 1, "list-2")
+	require.NoError(t, err)
+	assert.Equal(t, 1, other.UniqueAssignmentCount)
+	assert.Equal(t, 1, other.ExternalPrincipalCount)
+	assert.Zero(t, other.SharingLinkCount)
+}
+
+func TestSqlcAuditRepository_SaveListKeepsItemSettings(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+
+	require.NoError(t, auditRepo.SaveList(ctx, 1, &sharepoint.List{
+		SiteID: 1, ID: "tracker", WebID: "web-1", Title: "Tracker", BaseTemplate: 100,
+		ItemSettings: &sharepoint.ListItemSettings{
+			ReadSecurity:     sharepoint.ListReadOwnItems,
+			WriteSecurity:    sharepoint.ListWriteOwnItems,
+			RatingExperience: sharepoint.ListRatingStars,
+		},
+	}))
+
+	base := NewBaseRepository(testDB)
+	lists := NewScopedListRepository(base, base.ReadQueries(), 1, 1)
+	tracker, err := lists.GetByID(ctx, 1, "tracker")
+	require.NoError(t, err)
+	require.NotNil(t, tracker.ItemSettings)
+	assert.True(t, tracker.ItemSettings.ReadsOwnItemsOnly())
+	assert.True(t, tracker.ItemSettings.EditsOwnItemsOnly())
+	assert.True(t, tracker.ItemSettings.CommentsVisible())
+	assert.Equal(t, sharepoint.ListRatingStars, tracker.ItemSettings.RatingExperience)
+
+	legacy, err := lists.GetByID(ctx, 1, "list-1")
+	require.NoError(t, err)
+	assert.Nil(t, legacy.ItemSettings, "runs before item settings were captured leave them unset")
+}
+
+func TestSqlcAuditRepository_SaveItemKeepsUnreadableFields(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	ctx := context.Background()
+	auditRunID := int64(1)
+
+	item := &sharepoint.Item{SiteID: 1, GUID: "item-guid", ListItemGUID: "item-guid", ListID: "list-1", ID: 7, IsFile: true, Name: "report.docx"}
+	item.MarkUnreadable(sharepoint.ItemFieldSensitivityLabel)
+	require.NoError(t, NewSqlcAuditRepository(testDB).SaveItem(ctx, auditRunID, item))
+	require.NoError(t, NewSqlcAuditRepository(testDB).SaveItem(ctx, auditRunID, &sharepoint.Item{SiteID: 1, GUID: "read-guid", ListID: "list-1", ID: 8, IsFile: true}))
+
+	base := NewBaseRepository(testDB)
+	items := NewScopedItemRepository(base, base.ReadQueries(), 1, auditRunID)
+	saved, err := items.GetByGUID(ctx, 1, "item-guid")
+	require.NoError(t, err)
+	assert.False(t, saved.IsReadable(sharepoint.ItemFieldSensitivityLabel))
+	assert.True(t, saved.IsReadable(sharepoint.ItemFieldUniquePermissions))
+
+	listed, err := items.GetItemsForList(ctx, 1, "list-1", 0, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 2)
+	assert.Equal(t, []string{sharepoint.ItemFieldSensitivityLabel}, listed[0].UnreadableFields)
+	assert.Nil(t, listed[1].UnreadableFields, "an item with every field read stores NULL")
+}
+
+func TestSqlcAuditRepository_ReplaceRoleAssignments(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO jobs (job_id, site_id, site_url, job_type, status) VALUES ('job-2', 1, 'https://contoso.sharepoint.com/sites/a', 'site_audit', 'running')`)
+	mustExec(t, testDB, `INSERT INTO audit_runs (audit_run_id, job_id, site_id, started_at) VALUES (2, 'job-2', 1, CURRENT_TIMESTAMP)`)
+	mustExec(t, testDB, `INSERT INTO role_assignments (site_id, object_type, object_key, principal_id, role_def_id, audit_run_id) VALUES (1, 'list', 'list-1', 10, 1, 1)`)
+
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+	assignment := func(principalID, roleDefID int64) *sharepoint.RoleAssignment {
+		return &sharepoint.RoleAssignment{ObjectType: "list", ObjectKey: "list-1", PrincipalID: principalID, RoleDefID: roleDefID}
+	}
+	assignmentsOf := func(auditRunID int64) []string {
+		rows, err := testDB.ReadDB().Query(`SELECT principal_id, role_def_id FROM role_assignments
+			WHERE object_key = 'list-1' AND audit_run_id = ? ORDER BY principal_id, role_def_id`, auditRunID)
+		require.NoError(t, err)
+		defer rows.Close()
+		var assignments []string
+		for rows.Next() {
+			var principalID, roleDefID int64
+			require.NoError(t, rows.Scan(&principalID, &roleDefID))
+			assignments = append(assignments, fmt.Sprintf("%d:%d", principalID, roleDefID))
+		}
+		return assignments
+	}
+
+	require.NoError(t, auditRepo.ReplaceRoleAssignments(ctx, 2, 1, "list", "list-1", []*sharepoint.RoleAssignment{assignment(10, 1), assignment(11, 2)}))
+	require.NoError(t, auditRepo.ReplaceRoleAssignments(ctx, 2, 1, "list", "list-1", []*sharepoint.RoleAssignment{assignment(11, 2), assignment(12, 2)}))
+	assert.Equal(t, []string{"11:2", "12:2"}, assignmentsOf(2), "collecting an object again replaces its assignments")
+	assert.Equal(t, []string{"10:1"}, assignmentsOf(1), "earlier runs keep theirs")
+
+	// The duplicate fails the insert half way; nothing of the replacement is kept
+	err := auditRepo.ReplaceRoleAssignments(ctx, 2, 1, "list", "list-1", []*sharepoint.RoleAssignment{assignment(13, 2), assignment(13, 2)})
+	require.Error(t, err)
+	assert.Equal(t, []string{"11:2", "12:2"}, assignmentsOf(2))
+}
+
+func TestSqlcAuditRepository_ReadsAuditRunContentInPages(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	mustExec(t, testDB, `INSERT INTO lists (site_id, list_id, audit_run_id, web_id, title, base_template, item_count, has_unique) VALUES (1, 'list-2', 1, 'web-1', 'Tasks', 171, 3, 1)`)
+	for i := 1; i <= 5; i++ {
+		mustExec(t, testDB, fmt.Sprintf(`INSERT INTO items (site_id, item_guid, audit_run_id, list_id, item_id, is_file) VALUES (1, 'item-%d', 1, 'list-2', %d, 1)`, i, i))
+	}
+
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+
+	sites, err := auditRepo.GetSitesByAuditRun(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, sites, 1)
+	assert.Equal(t, "https://contoso.sharepoint.com/sites/a", sites[0].URL)
+	assert.Equal(t, "A", sites[0].Title)
+
+	webs, err := auditRepo.GetWebsByAuditRun(ctx, 1, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, webs, 1)
+	assert.Empty(t, webs[0].URL, "null columns read as empty")
+
+	lists, err := auditRepo.GetListsByAuditRun(ctx, 1, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, lists, 1)
+	assert.Equal(t, "list-2", lists[0].ID)
+	assert.Equal(t, 171, lists[0].BaseTemplate)
+	assert.True(t, lists[0].HasUnique)
+
+	var pages [][]int
+	for offset := int64(0); ; offset += 2 {
+		items, err := auditRepo.GetItemsByAuditRun(ctx, 1, offset, 2)
+		require.NoError(t, err)
+		if len(items) == 0 {
+			break
+		}
+		var ids []int
+		for _, item := range items {
+			ids = append(ids, item.ID)
+		}
+		pages = append(pages, ids)
+	}
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, pages)
+
+	none, err := auditRepo.GetItemsByAuditRun(ctx, 2, 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestSqlcAuditRepository_ListCheckpoints(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+	updatedAt := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	require.NoError(t, auditRepo.SaveListCheckpoint(ctx, 1, &audit.ListCheckpoint{ListID: "list-2", LastItemID: 50, UpdatedAt: updatedAt}))
+	require.NoError(t, auditRepo.SaveListCheckpoint(ctx, 1, &audit.ListCheckpoint{ListID: "list-2", LastItemID: 100, UpdatedAt: updatedAt}))
+	require.NoError(t, auditRepo.SaveListCheckpoint(ctx, 1, &audit.ListCheckpoint{ListID: "list-1", Completed: true, UpdatedAt: updatedAt}))
+
+	checkpoints, err := auditRepo.GetListCheckpoints(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 2)
+	assert.Equal(t, "list-1", checkpoints[0].ListID)
+	assert.True(t, checkpoints[0].Completed)
+	assert.Equal(t, 100, checkpoints[1].LastItemID, "a list's checkpoint is replaced as it progresses")
+	assert.True(t, checkpoints[1].UpdatedAt.Equal(updatedAt))
+
+	other, err := auditRepo.GetListCheckpoints(ctx, 2)
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+func TestSqlcAuditRepository_ResumedRunSavesAgain(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+
+	// A resumed run collects the list it was interrupted in, and the items after its checkpoint, again
+	list := &sharepoint.List{SiteID: 1, ID: "list-1", WebID: "web-1", Title: "Renamed", ItemCount: 12}
+	require.NoError(t, auditRepo.SaveList(ctx, 1, list))
+	item := &sharepoint.Item{SiteID: 1, GUID: "item-guid", ListID: "list-1", ID: 7, Name: "before.docx"}
+	require.NoError(t, auditRepo.SaveItem(ctx, 1, item))
+	item.Name = "after.docx"
+	require.NoError(t, auditRepo.SaveItem(ctx, 1, item))
+
+	base := NewBaseRepository(testDB)
+	saved, err := NewScopedListRepository(base, base.ReadQueries(), 1, 1).GetByID(ctx, 1, "list-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", saved.Title)
+	savedItem, err := NewScopedItemRepository(base, base.ReadQueries(), 1, 1).GetByGUID(ctx, 1, "item-guid")
+	require.NoError(t, err)
+	assert.Equal(t, "after.docx", savedItem.Name)
+}
+
+func TestSqlcAuditRepository_SaveWebAccessRequests(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	ctx := context.Background()
+	auditRepo := NewSqlcAuditRepository(testDB)
+
+	canShare := true
+	requests := &sharepoint.WebAccessRequests{Email: "owners@contoso.com"}
+	require.NoError(t, auditRepo.SaveWeb(ctx, 1, &sharepoint.Web{SiteID: 1, ID: "web-2", URL: "https://contoso.sharepoint.com/sites/a/b", MembersCanShare: &canShare, AccessRequests: requests}))
+	require.NoError(t, auditRepo.SaveWeb(ctx, 1, &sharepoint.Web{SiteID: 1, ID: "web-3", URL: "https://contoso.sharepoint.com/sites/a/c"}))
+
+	webs, err := NewSiteContentAggregateRepository(NewBaseRepository(testDB), nil, nil, nil, nil, nil).GetWebsForSite(ctx, 1, 1)
+	require.NoError(t, err)
+	saved := map[string]*sharepoint.Web{}
+	for _, web := range webs {
+		saved[web.ID] = web
+	}
+	require.Contains(t, saved, "web-2")
+	assert.Equal(t, requests, saved["web-2"].AccessRequests)
+	assert.True(t, saved["web-2"].AccessRequests.Enabled())
+	require.Contains(t, saved, "web-3")
+	assert.Nil(t, saved["web-3"].AccessRequests, "settings not captured stay unknown")
+}
@@ -21,6 +21,38 @@ func NewSqlcItemRepository(database *database.Database) contracts.ItemRepository
 	}
 }
 
+// GetByGUID retrieves a single item by GUID
+func (r *SqlcItemRepository) GetByGUID(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.Item, error) {
+	item, err := r.ReadQueries().GetItemByGUID(ctx, db.GetItemByGUIDParams{
+		SiteID:   siteID,
+		ItemGuid: itemGUID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &sharepoint.Item{
+		SiteID:       item.SiteID,
+		GUID:         item.ItemGuid,
+		ListItemGUID: r.FromNullString(item.ListItemGuid),
+		ListID:       item.ListID,
+		ID:           int(item.ItemID),
+		URL:          r.FromNullString(item.Url),
+		Name:         r.FromNullString(item.Name),
+		IsFile:       r.FromNullBool(item.IsFile),
+		IsFolder:     r.FromNullBool(item.IsFolder),
+		HasUnique:    r.FromNullBool(item.HasUnique),
+		AuditRunID:   &item.AuditRunID,
+
+		FileFolderUniqueID: r.FromNullString(item.FileFolderUniqueID),
+		Size:               r.FromNullInt64(item.FileSize),
+		CreatedAt:          r.FromNullTime(item.SpCreatedAt),
+		ModifiedAt:         r.FromNullTime(item.SpModifiedAt),
+		ContentTypeID:      r.FromNullString(item.ContentTypeID),
+		UnreadableFields:   r.FromNullList(item.UnreadableFields),
+	}, nil
+}
+
 // GetItemsForList retrieves all items for a list
 func (r *SqlcItemRepository) GetItemsForList(ctx context.Context, siteID int64, listID string, offset, limit int64) ([]*sharepoint.Item, error) {
 	items, err := r.ReadQueries().ItemsForList(ctx, db.ItemsForListParams{
@@ -48,6 +80,8 @@ func (r *SqlcItemRepository) GetItemsForList(ctx context.Context, siteID int64,
 			IsFolder:     r.FromNullBool(item.IsFolder),
 			HasUnique:    r.FromNullBool(item.HasUnique),
 			AuditRunID:   &item.AuditRunID,
+
+			UnreadableFields: r.FromNullList(item.UnreadableFields),
 		}
 	}
 	return domainItems, nil
@@ -84,3 +118,47 @@ func (r *SqlcItemRepository) GetItemsWithUniqueForList(ctx context.Context, site
 	}
 	return domainItems, nil
 }
+
+// GetFilteredItemsForList retrieves a page of items for a list matching the filter
+func (r *SqlcItemRepository) GetFilteredItemsForList(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter, offset, limit int64) ([]*sharepoint.Item, error) {
+	items, err := r.ReadQueries().FilteredItemsForList(ctx, db.FilteredItemsForListParams{
+		SiteID:     siteID,
+		ListID:     listID,
+		UniqueOnly: r.BoolToInt64(filter.UniqueOnly),
+		Kind:       filter.Kind,
+		Offset:     offset,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Transform SQLC rows to domain Items
+	domainItems := make([]*sharepoint.Item, len(items))
+	for i, item := range items {
+		domainItems[i] = &sharepoint.Item{
+			SiteID:       item.SiteID,
+			GUID:         item.ItemGuid,
+			ListItemGUID: r.FromNullString(item.ListItemGuid),
+			ListID:       item.ListID,
+			ID:           int(item.ItemID),
+			URL:          r.FromNullString(item.Url),
+			Name:         r.FromNullString(item.Name),
+			IsFile:       r.FromNullBool(item.IsFile),
+			IsFolder:     r.FromNullBool(item.IsFolder),
+			HasUnique:    r.FromNullBool(item.HasUnique),
+			AuditRunID:   &item.AuditRunID,
+		}
+	}
+	return domainItems, nil
+}
+
+// CountFilteredItemsForList counts the items for a list matching the filter
+func (r *SqlcItemRepository) CountFilteredItemsForList(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter) (int64, error) {
+	return r.ReadQueries().CountFilteredItemsForList(ctx, db.CountFilteredItemsForListParams{
+		SiteID:     siteID,
+		ListID:     listID,
+		UniqueOnly: r.BoolToInt64(filter.UniqueOnly),
+		Kind:       filter.Kind,
+	})
+}
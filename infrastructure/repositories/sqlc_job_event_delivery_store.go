@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"spaudit/database"
+	"spaudit/domain/contracts"
+	"spaudit/domain/events"
+	"spaudit/gen/db"
+)
+
+// SqlcJobEventDeliveryStore implements contracts.JobEventDeliveryStore in the event_handler_offsets
+// and event_dead_letters tables
+type SqlcJobEventDeliveryStore struct {
+	*BaseRepository
+}
+
+// NewSqlcJobEventDeliveryStore creates a delivery store kept in the database
+func NewSqlcJobEventDeliveryStore(database *database.Database) contracts.JobEventDeliveryStore {
+	return &SqlcJobEventDeliveryStore{BaseRepository: NewBaseRepository(database)}
+}
+
+// InitHandlerOffset starts a handler at a sequence number unless it already has an offset
+func (s *SqlcJobEventDeliveryStore) InitHandlerOffset(ctx context.Context, handler string, sequence int64) error {
+	err := s.WriteQueries().InitEventHandlerOffset(ctx, db.InitEventHandlerOffsetParams{
+		Handler:      handler,
+		LastSequence: sequence,
+		UpdatedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("init offset of event handler %s: %w", handler, err)
+	}
+	return nil
+}
+
+// GetHandlerOffset returns the sequence number of the last event a handler finished with
+func (s *SqlcJobEventDeliveryStore) GetHandlerOffset(ctx context.Context, handler string) (int64, error) {
+	sequence, err := s.ReadQueries().GetEventHandlerOffset(ctx, handler)
+	if err != nil {
+		return 0, fmt.Errorf("get offset of event handler %s: %w", handler, err)
+	}
+	return sequence, nil
+}
+
+// SetHandlerOffset records that a handler finished with the events up to a sequence number
+func (s *SqlcJobEventDeliveryStore) SetHandlerOffset(ctx context.Context, handler string, sequence int64) error {
+	err := s.WriteQueries().SetEventHandlerOffset(ctx, db.SetEventHandlerOffsetParams{
+		LastSequence: sequence,
+		UpdatedAt:    time.Now().UTC(),
+		Handler:      handler,
+	})
+	if err != nil {
+		return fmt.Errorf("set offset of event handler %s: %w", handler, err)
+	}
+	return nil
+}
+
+// AddDeadLetter records an event a handler failed on, adding to the attempts of an existing entry
+func (s *SqlcJobEventDeliveryStore) AddDeadLetter(ctx context.Context, deadLetter *events.DeadLetter) error {
+	id, err := s.WriteQueries().UpsertEventDeadLetter(ctx, db.UpsertEventDeadLetterParams{
+		Handler:   deadLetter.Handler,
+		Sequence:  deadLetter.Sequence,
+		Attempts:  int64(deadLetter.Attempts),
+		LastError: deadLetter.LastError,
+		FailedAt:  deadLetter.FailedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("add dead letter for event %d of handler %s: %w", deadLetter.Sequence, deadLetter.Handler, err)
+	}
+	deadLetter.ID = id
+	return nil
+}
+
+// GetDeadLetter returns a dead letter by ID
+func (s *SqlcJobEventDeliveryStore) GetDeadLetter(ctx context.Context, id int64) (*events.DeadLetter, error) {
+	row, err := s.ReadQueries().GetEventDeadLetter(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get dead letter %d: %w", id, err)
+	}
+	return toDeadLetter(row), nil
+}
+
+// ListDeadLetters returns the most recently failed dead letters first
+func (s *SqlcJobEventDeliveryStore) ListDeadLetters(ctx context.Context, limit int) ([]*events.DeadLetter, error) {
+	rows, err := s.ReadQueries().ListEventDeadLetters(ctx, int64(limit))
+	if err != nil {
+		return nil, fmt.Errorf("list dead letters: %w", err)
+	}
+	deadLetters := make([]*events.DeadLetter, len(rows))
+	for i, row := range rows {
+		deadLetters[i] = toDeadLetter(row)
+	}
+	return deadLetters, nil
+}
+
+// DeleteDeadLetter removes a dead letter, returning false when it does not exist
+func (s *SqlcJobEventDeliveryStore) DeleteDeadLetter(ctx context.Context, id int64) (bool, error) {
+	deleted, err := s.WriteQueries().DeleteEventDeadLetter(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("delete dead letter %d: %w", id, err)
+	}
+	return deleted > 0, nil
+}
+
+func toDeadLetter(row db.EventDeadLetter) *events.DeadLetter {
+	return &events.DeadLetter{
+		ID:        row.DeadLetterID,
+		Handler:   row.Handler,
+		Sequence:  row.Sequence,
+		Attempts:  int(row.Attempts),
+		LastError: row.LastError,
+		FailedAt:  row.FailedAt,
+	}
+}
@@ -0,0 +1,55 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/events"
+)
+
+func TestSqlcJobEventDeliveryStore(t *testing.T) {
+	testDB := newPermissionTestDatabase(t)
+	eventLog := NewSqlcJobEventLog(testDB)
+	store := NewSqlcJobEventDeliveryStore(testDB)
+	ctx := context.Background()
+
+	event := &events.RecordedJobEvent{Type: events.JobEventSiteAuditCompleted, JobID: "job-1", OccurredAt: time.Now().UTC()}
+	require.NoError(t, eventLog.AppendJobEvent(ctx, event))
+	latest, err := eventLog.LatestJobEventSequence(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, event.Sequence, latest)
+
+	// A handler's first offset is kept when it is initialised again
+	require.NoError(t, store.InitHandlerOffset(ctx, "run_manifest", 0))
+	require.NoError(t, store.SetHandlerOffset(ctx, "run_manifest", event.Sequence))
+	require.NoError(t, store.InitHandlerOffset(ctx, "run_manifest", 0))
+	offset, err := store.GetHandlerOffset(ctx, "run_manifest")
+	require.NoError(t, err)
+	assert.Equal(t, event.Sequence, offset)
+
+	failedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	deadLetter := &events.DeadLetter{Handler: "run_manifest", Sequence: event.Sequence, Attempts: 5, LastError: "database is locked", FailedAt: failedAt}
+	require.NoError(t, store.AddDeadLetter(ctx, deadLetter))
+	retried := &events.DeadLetter{Handler: "run_manifest", Sequence: event.Sequence, Attempts: 1, LastError: "still locked", FailedAt: failedAt.Add(time.Hour)}
+	require.NoError(t, store.AddDeadLetter(ctx, retried))
+	assert.Equal(t, deadLetter.ID, retried.ID, "a failed retry updates the existing dead letter")
+
+	listed, err := store.ListDeadLetters(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	assert.Equal(t, 6, listed[0].Attempts)
+	assert.Equal(t, "still locked", listed[0].LastError)
+
+	deleted, err := store.DeleteDeadLetter(ctx, deadLetter.ID)
+	require.NoError(t, err)
+	assert.True(t, deleted)
+	_, err = store.GetDeadLetter(ctx, deadLetter.ID)
+	assert.Error(t, err)
+	deleted, err = store.DeleteDeadLetter(ctx, deadLetter.ID)
+	require.NoError(t, err)
+	assert.False(t, deleted)
+}
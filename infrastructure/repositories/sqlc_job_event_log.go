@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"spaudit/database"
+	"spaudit/domain/contracts"
+	"spaudit/domain/events"
+	"spaudit/domain/jobs"
+	"spaudit/gen/db"
+)
+
+// SqlcJobEventLog implements contracts.JobEventLog in the job_events table
+type SqlcJobEventLog struct {
+	*BaseRepository
+}
+
+// NewSqlcJobEventLog creates a job event log stored in the database
+func NewSqlcJobEventLog(database *database.Database) contracts.JobEventLog {
+	return &SqlcJobEventLog{BaseRepository: NewBaseRepository(database)}
+}
+
+// AppendJobEvent stores an event, setting its sequence number
+func (l *SqlcJobEventLog) AppendJobEvent(ctx context.Context, event *events.RecordedJobEvent) error {
+	sequence, err := l.WriteQueries().InsertJobEvent(ctx, db.InsertJobEventParams{
+		EventType:  string(event.Type),
+		JobID:      event.JobID,
+		JobType:    string(event.JobType),
+		JobStatus:  string(event.JobStatus),
+		SiteUrl:    event.SiteURL,
+		AuditRunID: l.ToNullInt64(event.AuditRunID),
+		Error:      event.Error,
+		OccurredAt: event.OccurredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("append job event %s for job %s: %w", event.Type, event.JobID, err)
+	}
+	event.Sequence = sequence
+	return nil
+}
+
+// ListJobEvents returns the events matching the filter in sequence order
+func (l *SqlcJobEventLog) ListJobEvents(ctx context.Context, filter events.JobEventFilter) ([]*events.RecordedJobEvent, error) {
+	rows, err := l.ReadQueries().ListJobEvents(ctx, db.ListJobEventsParams{
+		AfterSequence: filter.AfterSequence,
+		EventType:     string(filter.Type),
+		JobID:         filter.JobID,
+		LimitCount:    int64(filter.Limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list job events: %w", err)
+	}
+
+	recorded := make([]*events.RecordedJobEvent, len(rows))
+	for i, row := range rows {
+		recorded[i] = l.toRecordedJobEvent(row)
+	}
+	return recorded, nil
+}
+
+// GetJobEvent returns the event with a sequence number
+func (l *SqlcJobEventLog) GetJobEvent(ctx context.Context, sequence int64) (*events.RecordedJobEvent, error) {
+	row, err := l.ReadQueries().GetJobEvent(ctx, sequence)
+	if err != nil {
+		return nil, fmt.Errorf("get job event %d: %w", sequence, err)
+	}
+	return l.toRecordedJobEvent(row), nil
+}
+
+// LatestJobEventSequence returns the sequence number of the last event, 0 when there are none
+func (l *SqlcJobEventLog) LatestJobEventSequence(ctx context.Context) (int64, error) {
+	sequence, err := l.ReadQueries().GetLatestJobEventSequence(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get latest job event sequence: %w", err)
+	}
+	return sequence, nil
+}
+
+func (l *SqlcJobEventLog) toRecordedJobEvent(row db.JobEvent) *events.RecordedJobEvent {
+	return &events.RecordedJobEvent{
+		Sequence:   row.Sequence,
+		Type:       events.JobEventType(row.EventType),
+		JobID:      row.JobID,
+		JobType:    jobs.JobType(row.JobType),
+		JobStatus:  jobs.JobStatus(row.JobStatus),
+		SiteURL:    row.SiteUrl,
+		AuditRunID: l.FromNullInt64(row.AuditRunID),
+		Error:      row.Error,
+		OccurredAt: row.OccurredAt,
+	}
+}
@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/events"
+	"spaudit/domain/jobs"
+)
+
+func TestSqlcJobEventLog(t *testing.T) {
+	log := NewSqlcJobEventLog(newPermissionTestDatabase(t))
+	ctx := context.Background()
+	occurredAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	completed := &events.RecordedJobEvent{Type: events.JobEventCompleted, JobID: "job-1", JobType: jobs.JobTypeSiteAudit, JobStatus: jobs.JobStatusCompleted, SiteURL: "https://contoso.sharepoint.com/sites/a", AuditRunID: 7, OccurredAt: occurredAt}
+	siteAudit := &events.RecordedJobEvent{Type: events.JobEventSiteAuditCompleted, JobID: "job-1", JobType: jobs.JobTypeSiteAudit, JobStatus: jobs.JobStatusCompleted, SiteURL: "https://contoso.sharepoint.com/sites/a", AuditRunID: 7, OccurredAt: occurredAt}
+	failed := &events.RecordedJobEvent{Type: events.JobEventFailed, JobID: "job-2", JobType: jobs.JobTypeSiteAudit, JobStatus: jobs.JobStatusFailed, Error: "throttled", OccurredAt: occurredAt}
+	for _, event := range []*events.RecordedJobEvent{completed, siteAudit, failed} {
+		require.NoError(t, log.AppendJobEvent(ctx, event))
+	}
+	assert.Less(t, completed.Sequence, siteAudit.Sequence)
+	assert.Less(t, siteAudit.Sequence, failed.Sequence)
+
+	all, err := log.ListJobEvents(ctx, events.JobEventFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []*events.RecordedJobEvent{completed, siteAudit, failed}, all)
+
+	after, err := log.ListJobEvents(ctx, events.JobEventFilter{AfterSequence: completed.Sequence, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []*events.RecordedJobEvent{siteAudit}, after)
+
+	byJob, err := log.ListJobEvents(ctx, events.JobEventFilter{JobID: "job-2", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []*events.RecordedJobEvent{failed}, byJob)
+
+	byType, err := log.ListJobEvents(ctx, events.JobEventFilter{Type: events.JobEventSiteAuditCompleted, Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, []*events.RecordedJobEvent{siteAudit}, byType)
+}
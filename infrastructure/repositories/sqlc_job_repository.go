@@ -254,6 +254,11 @@ func (r *SqlcJobRepository) CancelJob(ctx context.Context, jobID string) error {
 	})
 }
 
+// ReopenJob marks a failed or cancelled job running again, clearing its error and completion time
+func (r *SqlcJobRepository) ReopenJob(ctx context.Context, jobID string) error {
+	return r.WriteQueries().ReopenJob(ctx, jobID)
+}
+
 // DeleteOldJobs deletes jobs older than the specified time
 func (r *SqlcJobRepository) DeleteOldJobs(ctx context.Context, olderThan time.Time) error {
 	// Note: The SQL query uses a hardcoded '-1 day' filter,
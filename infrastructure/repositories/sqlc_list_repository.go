@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 
 	"spaudit/database"
 	"spaudit/domain/contracts"
@@ -34,7 +35,7 @@ func (r *SqlcListRepository) Save(ctx context.Context, list *sharepoint.List) er
 		ItemCount:    r.ToNullInt64(int64(list.ItemCount)),
 		HasUnique:    r.ToNullBool(list.HasUnique),
 	}
-	return r.WriteQueries().InsertList(ctx, params)
+	return r.WriteQueries().InsertList(ctx, withListItemSettings(params, list.ItemSettings))
 }
 
 // GetByID retrieves a list by its ID.
@@ -113,3 +114,28 @@ func (r *SqlcListRepository) GetAllForSite(ctx context.Context, siteID int64) ([
 	}
 	return domainLists, nil
 }
+
+// withListItemSettings sets the item settings columns of a list insert, leaving them NULL when not captured.
+func withListItemSettings(params db.InsertListParams, settings *sharepoint.ListItemSettings) db.InsertListParams {
+	if settings == nil {
+		return params
+	}
+	params.ReadSecurity = sql.NullInt64{Int64: int64(settings.ReadSecurity), Valid: true}
+	params.WriteSecurity = sql.NullInt64{Int64: int64(settings.WriteSecurity), Valid: true}
+	params.CommentsDisabled = sql.NullBool{Bool: settings.CommentsDisabled, Valid: true}
+	params.RatingExperience = sql.NullString{String: settings.RatingExperience, Valid: true}
+	return params
+}
+
+// toListItemSettings maps the item settings columns of a list row, nil for runs before they were captured.
+func toListItemSettings(readSecurity, writeSecurity sql.NullInt64, commentsDisabled sql.NullBool, ratingExperience sql.NullString) *sharepoint.ListItemSettings {
+	if !readSecurity.Valid {
+		return nil
+	}
+	return &sharepoint.ListItemSettings{
+		ReadSecurity:     int(readSecurity.Int64),
+		WriteSecurity:    int(writeSecurity.Int64),
+		CommentsDisabled: commentsDisabled.Bool,
+		RatingExperience: ratingExperience.String,
+	}
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"database/sql"
 
 	"spaudit/database"
 	"spaudit/domain/contracts"
@@ -143,3 +144,65 @@ func (r *SqlcSharingRepository) GetSharingLinkMembers(ctx context.Context, siteI
 	}
 	return principals, nil
 }
+
+// GetSensitivityLabelsForList retrieves item sensitivity labels for a list
+func (r *SqlcSharingRepository) GetSensitivityLabelsForList(ctx context.Context, siteID int64, listID string) ([]*sharepoint.ItemSensitivityLabel, error) {
+	rows, err := r.ReadQueries().GetSensitivityLabelsForList(ctx, db.GetSensitivityLabelsForListParams{
+		SiteID: siteID,
+		ListID: listID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Transform SQLC rows to domain labels
+	labels := make([]*sharepoint.ItemSensitivityLabel, len(rows))
+	for i, row := range rows {
+		labels[i] = &sharepoint.ItemSensitivityLabel{
+			SiteID:           row.SiteID,
+			ItemGUID:         row.ItemGuid,
+			LabelID:          r.FromNullString(row.LabelID),
+			DisplayName:      r.FromNullString(row.DisplayName),
+			OwnerEmail:       r.FromNullString(row.OwnerEmail),
+			SetDate:          r.FromNullTime(row.SetDate),
+			AssignmentMethod: r.FromNullString(row.AssignmentMethod),
+			HasIRMProtection: r.FromNullBool(row.HasIrmProtection),
+			ContentBits:      int(r.FromNullInt64(row.ContentBits)),
+			LabelFlags:       int(r.FromNullInt64(row.LabelFlags)),
+			DiscoveredAt:     row.DiscoveredAt.Time,
+			PromotionVersion: int(r.FromNullInt64(row.PromotionVersion)),
+			LabelHash:        r.FromNullString(row.LabelHash),
+		}
+	}
+	return labels, nil
+}
+
+// GetItemSensitivityLabel retrieves the sensitivity label for an item, or nil if it has none
+func (r *SqlcSharingRepository) GetItemSensitivityLabel(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.ItemSensitivityLabel, error) {
+	row, err := r.ReadQueries().GetItemSensitivityLabel(ctx, db.GetItemSensitivityLabelParams{
+		SiteID:   siteID,
+		ItemGuid: itemGUID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil // Item has no label
+		}
+		return nil, err
+	}
+
+	return &sharepoint.ItemSensitivityLabel{
+		SiteID:           row.SiteID,
+		ItemGUID:         row.ItemGuid,
+		LabelID:          r.FromNullString(row.LabelID),
+		DisplayName:      r.FromNullString(row.DisplayName),
+		OwnerEmail:       r.FromNullString(row.OwnerEmail),
+		SetDate:          r.FromNullTime(row.SetDate),
+		AssignmentMethod: r.FromNullString(row.AssignmentMethod),
+		HasIRMProtection: r.FromNullBool(row.HasIrmProtection),
+		ContentBits:      int(r.FromNullInt64(row.ContentBits)),
+		LabelFlags:       int(r.FromNullInt64(row.LabelFlags)),
+		DiscoveredAt:     row.DiscoveredAt.Time,
+		PromotionVersion: int(r.FromNullInt64(row.PromotionVersion)),
+		LabelHash:        r.FromNullString(row.LabelHash),
+	}, nil
+}
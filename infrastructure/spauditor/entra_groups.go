@@ -0,0 +1,76 @@
+package spauditor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"spaudit/domain/contracts"
+	"spaudit/infrastructure/spclient"
+	"spaudit/logging"
+)
+
+// EntraGroupCollector handles resolving the users Entra groups holding roles on a site reach, through
+// Microsoft Graph, so access granted to a group counts towards the users it reaches
+type EntraGroupCollector struct {
+	spClient spclient.SharePointClient
+	repo     contracts.SharePointAuditRepository
+	logger   *logging.Logger
+}
+
+// NewEntraGroupCollector creates a new Entra group collector
+func NewEntraGroupCollector(spClient spclient.SharePointClient, repo contracts.SharePointAuditRepository, logger *logging.Logger) *EntraGroupCollector {
+	return &EntraGroupCollector{
+		spClient: spClient,
+		repo:     repo,
+		logger:   logger.WithComponent("entra_group_collector"),
+	}
+}
+
+// CollectEntraGroupMembers resolves and persists the users of every Entra group principal the audit run
+// recorded, and returns how many groups it resolved. The groups are read back from the run rather than
+// gathered as assignments are collected, so a resumed run resolves those its earlier attempt recorded.
+// A group that cannot be read is logged and left unresolved; only when none could be is an error returned,
+// as then the app registration most likely lacks GroupMember.Read.All.
+func (ec *EntraGroupCollector) CollectEntraGroupMembers(ctx context.Context) (int, error) {
+	principals, err := ec.repo.GetSecurityGroupPrincipals(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get security group principals: %w", err)
+	}
+
+	resolved, failed := 0, 0
+	var lastErr error
+	for _, principal := range principals {
+		group, ok := principal.EntraGroup()
+		if !ok {
+			continue
+		}
+
+		resolvedAt := time.Now()
+		members, err := ec.spClient.GetEntraGroupMembers(ctx, group)
+		if err == nil {
+			err = ec.repo.SaveEntraGroupMembers(ctx, principal.ID, group, resolvedAt, members)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return resolved, fmt.Errorf("context canceled while resolving entra group %s: %w", group.ObjectID, ctx.Err())
+			}
+			ec.logger.Error("Failed to resolve Entra group members",
+				"principal_id", principal.ID,
+				"title", principal.Title,
+				"object_id", group.ObjectID,
+				"error", err.Error())
+			failed++
+			lastErr = err
+			continue
+		}
+
+		resolved++
+		ec.logger.Debug("Resolved Entra group members", "principal_id", principal.ID, "object_id", group.ObjectID, "members", len(members))
+	}
+
+	if resolved == 0 && failed > 0 {
+		return 0, fmt.Errorf("resolve %d entra groups: %w", failed, lastErr)
+	}
+	return resolved, nil
+}
@@ -0,0 +1,60 @@
+package spauditor
+
+import (
+	"context"
+	"fmt"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+	"spaudit/infrastructure/spclient"
+	"spaudit/logging"
+)
+
+// GroupMembershipCollector handles collection and persistence of SharePoint group members, so access
+// granted to a group can be expanded to the users in it
+type GroupMembershipCollector struct {
+	spClient spclient.SharePointClient
+	repo     contracts.SharePointAuditRepository
+	logger   *logging.Logger
+
+	// SharePoint groups whose members have been collected; a collector serves a single audit run
+	expandedGroups map[int64]bool
+}
+
+// NewGroupMembershipCollector creates a new group membership collector
+func NewGroupMembershipCollector(spClient spclient.SharePointClient, repo contracts.SharePointAuditRepository, logger *logging.Logger) *GroupMembershipCollector {
+	return &GroupMembershipCollector{
+		spClient:       spClient,
+		repo:           repo,
+		logger:         logger.WithComponent("group_membership_collector"),
+		expandedGroups: make(map[int64]bool),
+	}
+}
+
+// CollectGroupMembers retrieves and persists the members of a SharePoint group the first time it is seen.
+// Other principals are skipped, as are sharing link groups since their members are collected with the link.
+// Failures are logged rather than returned so one unreadable group does not fail the audit.
+func (gc *GroupMembershipCollector) CollectGroupMembers(ctx context.Context, principal *sharepoint.Principal) error {
+	if !principal.IsSharePointGroup() || principal.IsSharingLinkPrincipal() || gc.expandedGroups[principal.ID] {
+		return nil
+	}
+	gc.expandedGroups[principal.ID] = true
+
+	members, err := gc.spClient.GetGroupMembers(ctx, principal.ID)
+	if err == nil {
+		err = gc.repo.SaveGroupMembers(ctx, principal.ID, members)
+	}
+	if err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("context canceled while collecting members of group %d: %w", principal.ID, ctx.Err())
+		}
+		gc.logger.Error("Failed to collect group members",
+			"group_id", principal.ID,
+			"title", principal.Title,
+			"error", err.Error())
+		return nil
+	}
+
+	gc.logger.Debug("Collected group members", "group_id", principal.ID, "members", len(members))
+	return nil
+}
@@ -1,8 +1,10 @@
 package spauditor
 
 import (
+	"sync"
 	"time"
 
+	"spaudit/domain/audit"
 	"spaudit/logging"
 )
 
@@ -33,6 +35,12 @@ type PerformanceMetrics struct {
 	ErrorsEncountered   int
 	WarningsEncountered int
 
+	// Throttling metrics, recorded as requests are throttled, whichever collector made them
+	throttleMu        sync.Mutex
+	ThrottledRequests int           // Throttled responses, each followed by a retry unless it gave up
+	ThrottleFailures  int           // Throttled requests that gave up with no retries left
+	ThrottleWait      time.Duration // Total wait before retries
+
 	// Resource usage
 	PeakMemoryUsageMB     int64
 	AverageProcessingRate float64 // items per second
@@ -106,6 +114,17 @@ func (m *PerformanceMetrics) RecordWarning() {
 	m.WarningsEncountered++
 }
 
+// RecordThrottle records a request SharePoint throttled
+func (m *PerformanceMetrics) RecordThrottle(event audit.ThrottleEvent) {
+	m.throttleMu.Lock()
+	defer m.throttleMu.Unlock()
+	m.ThrottledRequests++
+	m.ThrottleWait += event.Wait
+	if event.GaveUp {
+		m.ThrottleFailures++
+	}
+}
+
 // CalculateTotalDuration calculates and stores the total duration
 func (m *PerformanceMetrics) CalculateTotalDuration(start time.Time) {
 	m.TotalDuration = time.Since(start)
@@ -150,6 +169,14 @@ func (m *PerformanceMetrics) LogPerformanceMetrics(logger *logging.Logger, siteU
 		"errors", m.ErrorsEncountered,
 		"warnings", m.WarningsEncountered)
 
+	// Throttling
+	m.throttleMu.Lock()
+	logger.Info("Throttling",
+		"throttled_requests", m.ThrottledRequests,
+		"throttle_failures", m.ThrottleFailures,
+		"throttle_wait_ms", m.ThrottleWait.Milliseconds())
+	m.throttleMu.Unlock()
+
 	// Performance insights
 	if m.TotalDuration > 0 {
 		listPercent := float64(m.ListProcessingDuration.Milliseconds()) / float64(m.TotalDuration.Milliseconds()) * 100
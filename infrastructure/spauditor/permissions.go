@@ -15,14 +15,16 @@ type PermissionCollector struct {
 	spClient spclient.SharePointClient
 	repo     contracts.SharePointAuditRepository
 	logger   *logging.Logger
+	groups   *GroupMembershipCollector
 }
 
 // NewPermissionCollector creates a new permission collector
-func NewPermissionCollector(spClient spclient.SharePointClient, repo contracts.SharePointAuditRepository) *PermissionCollector {
+func NewPermissionCollector(spClient spclient.SharePointClient, repo contracts.SharePointAuditRepository, logger *logging.Logger) *PermissionCollector {
 	return &PermissionCollector{
 		spClient: spClient,
 		repo:     repo,
-		logger:   logging.Default().WithComponent("permission_collector"),
+		logger:   logger.WithComponent("permission_collector"),
+		groups:   NewGroupMembershipCollector(spClient, repo, logger),
 	}
 }
 
@@ -83,9 +85,6 @@ func (pc *PermissionCollector) collectRoleAssignments(ctx context.Context, audit
 
 // collectRoleAssignmentsWithKey allows specifying a different object key (used for items)
 func (pc *PermissionCollector) collectRoleAssignmentsWithKey(ctx context.Context, auditRunID int64, siteID int64, target spclient.PermissionTarget, objectKey string) error {
-	// Note: We don't clear existing assignments since each audit run should preserve historical data
-	// Each audit run gets a unique audit_run_id, so new assignments won't conflict with old ones
-
 	// Get new assignments and principals
 	assignments, principals, err := pc.spClient.GetObjectRoleAssignments(ctx, target)
 	if err != nil {
@@ -110,17 +109,16 @@ func (pc *PermissionCollector) collectRoleAssignmentsWithKey(ctx context.Context
 		}
 	}
 
-	// Update assignments with site ID and object keys for items (assignments come back with listID, but we want itemGUID)
-	for _, assignment := range assignments {
-		assignment.SiteID = siteID
-		if target.ObjectType == sharepoint.ObjectTypeItem {
-			assignment.ObjectKey = objectKey
-		}
+	// Replace the object's assignments in this run as a whole, keyed by item GUID for items since
+	// assignments come back keyed by list ID. Earlier runs keep theirs.
+	if err := pc.repo.ReplaceRoleAssignments(ctx, target.ObjectType, objectKey, assignments); err != nil {
+		return fmt.Errorf("save role assignments: %w", err)
 	}
 
-	// Save assignments
-	if err := pc.repo.SaveRoleAssignments(ctx, assignments); err != nil {
-		return fmt.Errorf("save role assignments: %w", err)
+	for _, principal := range principals {
+		if err := pc.groups.CollectGroupMembers(ctx, principal); err != nil {
+			return err
+		}
 	}
 
 	return nil
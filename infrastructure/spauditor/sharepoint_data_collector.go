@@ -2,7 +2,11 @@ package spauditor
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math/rand/v2"
+	"net/url"
+	"time"
 
 	"spaudit/database"
 	"spaudit/domain/audit"
@@ -21,8 +25,9 @@ type SharePointDataCollector struct {
 	repo                 contracts.SharePointAuditRepository
 	permissionCollector  *PermissionCollector
 	sharingDataCollector *SharingDataCollector
+	entraGroupCollector  *EntraGroupCollector
 	logger               *logging.Logger
-	progressReporter     audit.ProgressReporter
+	progressReporter     *stageTrackingReporter
 	metrics              *PerformanceMetrics
 }
 
@@ -32,8 +37,9 @@ func NewSharePointDataCollector(
 	spClient spclient.SharePointClient,
 	repo contracts.SharePointAuditRepository,
 	db *database.Database,
+	logger *logging.Logger,
 ) *SharePointDataCollector {
-	return NewSharePointDataCollectorWithProgress(parameters, spClient, repo, db, audit.NewNoOpProgressReporter())
+	return NewSharePointDataCollectorWithProgress(parameters, spClient, repo, db, audit.NewNoOpProgressReporter(), logger)
 }
 
 // NewSharePointDataCollectorWithProgress creates a new data collector with progress reporting
@@ -43,23 +49,31 @@ func NewSharePointDataCollectorWithProgress(
 	repo contracts.SharePointAuditRepository,
 	db *database.Database,
 	progressReporter audit.ProgressReporter,
+	logger *logging.Logger,
 ) *SharePointDataCollector {
-	permissionCollector := NewPermissionCollector(spClient, repo)
-	sharingDataCollector := NewSharingDataCollector(spClient, repo)
+	permissionCollector := NewPermissionCollector(spClient, repo, logger)
+	sharingDataCollector := NewSharingDataCollector(spClient, repo, logger)
+	tracker := newStageTrackingReporter(progressReporter)
 	
 	// Set up progress reporting for sharing data collector
-	sharingDataCollector.SetProgressReporter(progressReporter)
+	sharingDataCollector.SetProgressReporter(tracker)
+	sharingDataCollector.SetCollectAnalytics(parameters != nil && parameters.CollectAnalytics)
 
-	return &SharePointDataCollector{
+	collector := &SharePointDataCollector{
 		parameters:           parameters,
 		spClient:             spClient,
 		repo:                 repo,
 		permissionCollector:  permissionCollector,
 		sharingDataCollector: sharingDataCollector,
-		logger:               logging.Default().WithComponent("audit_service"),
-		progressReporter:     progressReporter,
+		entraGroupCollector:  NewEntraGroupCollector(spClient, repo, logger),
+		logger:               logger.WithComponent("audit_service"),
+		progressReporter:     tracker,
 		metrics:              NewPerformanceMetrics(),
 	}
+	if spClient != nil {
+		spClient.SetThrottleObserver(collector.onThrottle)
+	}
+	return collector
 }
 
 // CollectSiteData performs complete data collection from a SharePoint site
@@ -93,6 +107,8 @@ func (s *SharePointDataCollector) CollectSiteData(ctx context.Context, auditRunI
 		s.metrics.CalculateTotalDuration(overallStart)
 		s.metrics.LogPerformanceMetrics(s.logger, siteURL)
 	}()
+	// Saved however collection ends, since drift can be what made it fail
+	defer s.saveSchemaDrift(ctx, siteURL)
 
 	// Validate configuration before starting
 	if err := s.parameters.Validate(audit.DefaultApiConstraints()); err != nil {
@@ -104,10 +120,18 @@ func (s *SharePointDataCollector) CollectSiteData(ctx context.Context, auditRunI
 	s.logger.Debug("Using configuration",
 		"batch_size", s.parameters.BatchSize,
 		"max_retries", s.parameters.MaxRetries,
+		"max_requests_per_second", s.parameters.MaxRequestsPerSecond,
+		"collector_backend", s.parameters.CollectorBackend,
 		"timeout", s.parameters.Timeout,
 		"scan_individual_items", s.parameters.ScanIndividualItems,
 		"include_sharing", s.parameters.IncludeSharing,
-		"skip_hidden", s.parameters.SkipHidden)
+		"skip_hidden", s.parameters.SkipHidden,
+		"sample_large_lists", s.parameters.SampleLargeLists,
+		"sample_threshold", s.parameters.SampleThreshold,
+		"folder_path", s.parameters.FolderPath,
+		"scan_recycle_bin", s.parameters.ScanRecycleBin,
+		"audit_graph_grants", s.parameters.AuditGraphGrants,
+		"expand_entra_groups", s.parameters.ExpandEntraGroups)
 	s.progressReporter.ReportProgress(audit.StandardStages.WebDiscovery, "Starting site data collection", 10)
 
 	// Step 1: Save site entry and get site ID
@@ -178,6 +202,33 @@ func (s *SharePointDataCollector) CollectSiteData(ctx context.Context, auditRunI
 		s.metrics.RecordSharingAnalysis(sharingStart, 0) // TODO: Get actual sharing links count
 	}
 
+	// Step 7: Installed apps, whose grants the web, list and item permissions already hold
+	s.progressReporter.ReportProgress(audit.StandardStages.Permissions, "Collecting installed apps", 91)
+	s.runOptionalStep(ctx, siteURL, "Installed app collection", s.collectInstalledApps)
+
+	// Step 8: Hub association, which governance reports group sites by; hub rollups list the site as
+	// skipped until a run records it
+	s.progressReporter.ReportProgress(audit.StandardStages.Permissions, "Reading hub association", 92)
+	s.runOptionalStep(ctx, siteURL, "Hub association collection", s.collectHubAssociation)
+
+	// Step 9: Graph app grants (if enabled), which no role assignment shows
+	if s.parameters.AuditGraphGrants {
+		s.progressReporter.ReportProgress(audit.StandardStages.Permissions, "Reading Graph app grants", 93)
+		s.runOptionalStep(ctx, siteURL, "Graph app grant scan", s.scanGraphGrants)
+	}
+
+	// Step 10: Entra group expansion (if enabled), after every assignment and SharePoint group member is recorded
+	if s.parameters.ExpandEntraGroups {
+		s.progressReporter.ReportProgress(audit.StandardStages.Permissions, "Expanding Entra groups", 94)
+		s.runOptionalStep(ctx, siteURL, "Entra group expansion", s.expandEntraGroups)
+	}
+
+	// Step 11: Recycle bin scan (if enabled)
+	if s.parameters.ScanRecycleBin {
+		s.progressReporter.ReportProgress(audit.StandardStages.Sharing, "Scanning recycle bin", 95)
+		s.runOptionalStep(ctx, siteURL, "Recycle bin scan", s.scanRecycleBin)
+	}
+
 	s.progressReporter.ReportProgress(audit.StandardStages.Finalization, "Data collection completed successfully", 100)
 	s.logger.Audit("Completed site data collection", siteURL)
 	return nil
@@ -207,6 +258,113 @@ func (s *SharePointDataCollector) CollectSiteSharingData(ctx context.Context, au
 
 // Private helper methods
 
+// runOptionalStep runs a collection step the rest of the audit does not depend on. Like the sharing audit,
+// a failed step is logged and counted as an error without failing the audit; its data is missing from the run.
+func (s *SharePointDataCollector) runOptionalStep(ctx context.Context, siteURL, name string, step func(context.Context) error) {
+	if err := step(ctx); err != nil {
+		s.logger.AuditError(name+" failed", err, siteURL)
+		s.metrics.RecordError()
+	}
+}
+
+// saveSchemaDrift records the API response fields the client did not map, or expected and did not receive,
+// against the audit run
+func (s *SharePointDataCollector) saveSchemaDrift(ctx context.Context, siteURL string) {
+	drift := s.spClient.SchemaDrift()
+	if len(drift) == 0 {
+		return
+	}
+
+	s.logger.Warn("SharePoint API responses did not match the expected schema",
+		"site_url", siteURL,
+		"fields", len(drift),
+		"missing_fields", audit.CountMissingFields(drift),
+		"drift", audit.SummarizeSchemaDrift(drift))
+	if err := s.repo.SaveSchemaDrift(ctx, drift); err != nil {
+		s.logger.Warn("Failed to save schema drift", "site_url", siteURL, "error", err.Error())
+	}
+}
+
+// scanRecycleBin records the deleted files, folders and list items in the site's recycle bin
+func (s *SharePointDataCollector) scanRecycleBin(ctx context.Context) error {
+	scannedAt := time.Now()
+	items, err := s.spClient.GetRecycleBinItems(ctx)
+	if err != nil {
+		return fmt.Errorf("get recycle bin items: %w", err)
+	}
+	s.metrics.RecordAPICall()
+
+	if err := s.repo.SaveRecycleBinScan(ctx, scannedAt, items); err != nil {
+		return fmt.Errorf("save recycle bin scan: %w", err)
+	}
+	s.metrics.RecordDatabaseOperation()
+	s.logger.Info("Scanned recycle bin", "deleted_items", len(items))
+	return nil
+}
+
+// collectInstalledApps records the add-ins and SPFx apps installed on the site
+func (s *SharePointDataCollector) collectInstalledApps(ctx context.Context) error {
+	collectedAt := time.Now()
+	apps, err := s.spClient.GetInstalledApps(ctx)
+	if err != nil {
+		return fmt.Errorf("get installed apps: %w", err)
+	}
+	s.metrics.RecordAPICall()
+
+	if err := s.repo.SaveAppInventory(ctx, collectedAt, apps); err != nil {
+		return fmt.Errorf("save app inventory: %w", err)
+	}
+	s.metrics.RecordDatabaseOperation()
+	s.logger.Info("Collected installed apps", "apps", len(apps))
+	return nil
+}
+
+// collectHubAssociation records the hub the site is associated with, if any
+func (s *SharePointDataCollector) collectHubAssociation(ctx context.Context) error {
+	collectedAt := time.Now()
+	hub, err := s.spClient.GetHubAssociation(ctx)
+	if err != nil {
+		return fmt.Errorf("get hub association: %w", err)
+	}
+	s.metrics.RecordAPICall()
+
+	if err := s.repo.SaveHubAssociation(ctx, collectedAt, hub); err != nil {
+		return fmt.Errorf("save hub association: %w", err)
+	}
+	s.metrics.RecordDatabaseOperation()
+	s.logger.Info("Collected hub association", "hub_site_id", hub.HubSiteID, "is_hub", hub.IsHub)
+	return nil
+}
+
+// scanGraphGrants records the Entra apps Microsoft Graph grants access to the site
+func (s *SharePointDataCollector) scanGraphGrants(ctx context.Context) error {
+	scannedAt := time.Now()
+	grants, err := s.spClient.GetGraphAppGrants(ctx)
+	if err != nil {
+		return fmt.Errorf("get graph app grants: %w", err)
+	}
+	s.metrics.RecordAPICall()
+
+	if err := s.repo.SaveGraphGrantScan(ctx, scannedAt, grants); err != nil {
+		return fmt.Errorf("save graph grant scan: %w", err)
+	}
+	s.metrics.RecordDatabaseOperation()
+	s.logger.Info("Read Graph app grants", "grants", len(grants))
+	return nil
+}
+
+// expandEntraGroups records the users each Entra group holding a role on the site reaches
+func (s *SharePointDataCollector) expandEntraGroups(ctx context.Context) error {
+	resolved, err := s.entraGroupCollector.CollectEntraGroupMembers(ctx)
+	if err != nil {
+		return err
+	}
+	s.metrics.RecordAPICall()
+	s.metrics.RecordDatabaseOperation()
+	s.logger.Info("Expanded Entra groups", "groups", resolved)
+	return nil
+}
+
 // saveSiteEntry creates the initial site entry and returns it with populated ID
 func (s *SharePointDataCollector) saveSiteEntry(ctx context.Context, auditRunID int64, siteURL string) (*sharepoint.Site, error) {
 	site := &sharepoint.Site{
@@ -262,12 +420,26 @@ func (s *SharePointDataCollector) auditLists(ctx context.Context, auditRunID int
 	s.logger.Info("Retrieved lists for processing", "count", len(lists), "web_id", webID)
 	s.metrics.RecordAPICall() // GetLists API call
 
+	// A folder-scoped audit only covers the list holding the folder
+	if s.parameters.IsFolderScoped() {
+		list := listContainingFolder(lists, s.parameters.FolderPath)
+		if list == nil {
+			return fmt.Errorf("no list in web %s contains folder %s", webID, s.parameters.FolderPath)
+		}
+		s.logger.Info("Scoped audit to folder", "folder_path", s.parameters.FolderPath, "list_title", list.Title, "list_id", list.ID)
+		lists = []*sharepoint.List{list}
+	}
+
+	// A resumed run skips the lists it completed and continues the one it was collecting
+	checkpoints := s.loadListCheckpoints(ctx)
+
 	// Start timing for list processing
 	listsStart := s.metrics.StartTiming()
 	
 	// Track skipped lists for better progress reporting
 	var skippedCount int
 	var processedCount int  // Track actually processed lists
+	var resumedCount int    // Track lists completed before the run was interrupted
 
 	// Calculate total lists that will be processed (excluding hidden lists)
 	totalListsToProcess := 0
@@ -322,9 +494,17 @@ func (s *SharePointDataCollector) auditLists(ctx context.Context, auditRunID int
 		// Calculate overall progress for this list (30-80% range)
 		percentage := 30 + int(float64(i+1)/float64(len(lists))*50)
 
+		if checkpoints.IsCompleted(list.ID) {
+			resumedCount++
+			s.progressReporter.ReportItemProgress(audit.StandardStages.ListProcessing,
+				fmt.Sprintf("List %d/%d already collected: %s", processedCount, totalListsToProcess, list.Title),
+				percentage, processedCount, totalListsToProcess)
+			continue
+		}
+
 		// Set site ID for the list
 		list.SiteID = siteID
-		if err := s.auditList(ctx, auditRunID, siteID, list, percentage, processedCount, totalListsToProcess); err != nil {
+		if err := s.auditList(ctx, auditRunID, siteID, list, percentage, processedCount, totalListsToProcess, checkpoints.ResumeAfter(list.ID)); err != nil {
 			s.logger.Warn("Failed to audit list",
 				"list_title", list.Title,
 				"list_id", list.ID,
@@ -344,12 +524,46 @@ func (s *SharePointDataCollector) auditLists(ctx context.Context, auditRunID int
 		"total_discovered", len(lists),
 		"processed", processedCount,
 		"skipped", skippedCount, 
+		"already_collected", resumedCount,
 		"web_id", webID)
 	return nil
 }
 
-// auditList audits a single list
-func (s *SharePointDataCollector) auditList(ctx context.Context, auditRunID int64, siteID int64, list *sharepoint.List, overallPercentage int, currentListNumber int, totalLists int) error {
+// loadListCheckpoints retrieves how far the run got through each list before it was interrupted.
+// Without them every list is collected again, which saves the same rows, so failures are only logged.
+func (s *SharePointDataCollector) loadListCheckpoints(ctx context.Context) audit.ListCheckpoints {
+	checkpoints, err := s.repo.GetListCheckpoints(ctx)
+	if err != nil {
+		s.logger.Warn("Failed to load list checkpoints, collecting every list", "error", err.Error())
+		s.metrics.RecordWarning()
+		return audit.NewListCheckpoints(nil)
+	}
+	if len(checkpoints) > 0 {
+		s.logger.Info("Resuming audit run from list checkpoints", "lists_started", len(checkpoints))
+	}
+	return audit.NewListCheckpoints(checkpoints)
+}
+
+// saveListCheckpoint records how far the run got through a list. Like loading, failures are only
+// logged: a resumed run would collect the list again from an earlier point.
+func (s *SharePointDataCollector) saveListCheckpoint(ctx context.Context, listID string, lastItemID int, completed bool) {
+	checkpoint := &audit.ListCheckpoint{
+		ListID:     listID,
+		LastItemID: lastItemID,
+		Completed:  completed,
+		UpdatedAt:  time.Now(),
+	}
+	if err := s.repo.SaveListCheckpoint(ctx, checkpoint); err != nil {
+		s.logger.Warn("Failed to save list checkpoint", "list_id", listID, "last_item_id", lastItemID, "error", err.Error())
+		s.metrics.RecordWarning()
+		return
+	}
+	s.metrics.RecordDatabaseOperation()
+}
+
+// auditList audits a single list. Its items are collected after resumeAfterID, which is 0 unless an
+// interrupted run got part way through them; the list is checkpointed as completed once they all are.
+func (s *SharePointDataCollector) auditList(ctx context.Context, auditRunID int64, siteID int64, list *sharepoint.List, overallPercentage int, currentListNumber int, totalLists int, resumeAfterID int) error {
 	// Substate 1: Save list metadata
 	s.progressReporter.ReportProgress(audit.StandardStages.ListProcessing,
 		fmt.Sprintf("List %d/%d - Saving metadata: %s", currentListNumber, totalLists, list.Title), overallPercentage)
@@ -366,9 +580,20 @@ func (s *SharePointDataCollector) auditList(ctx context.Context, auditRunID int6
 		s.logger.Warn("Failed to collect list role assignments", "list_title", list.Title, "error", err.Error())
 	}
 
-	// Substate 3: Audit individual items (documents/folders) if individual item scanning is enabled
-	if s.parameters.ScanIndividualItems {
-		if list.ItemCount > 0 {
+	// Substate 3: Audit individual items (documents/folders) if individual item scanning is enabled.
+	// A folder-scoped audit always scans items, they are what it is scoped to.
+	if s.parameters.ScanIndividualItems || s.parameters.IsFolderScoped() {
+		// Lists over the sampling threshold get a systematic sample spread across the whole list
+		var sampler *audit.ItemSampler
+		if s.parameters.ShouldSample(list.ItemCount) {
+			sampleSize := audit.SampleSize(list.ItemCount)
+			sampler = audit.NewItemSampler(list.ItemCount, sampleSize, rand.Float64())
+			s.progressReporter.ReportProgress(audit.StandardStages.ListProcessing,
+				fmt.Sprintf("List %d/%d - Preparing to sample items: %s (~%d of %d items)", currentListNumber, totalLists, list.Title, sampleSize, list.ItemCount), overallPercentage)
+		} else if s.parameters.IsFolderScoped() {
+			s.progressReporter.ReportProgress(audit.StandardStages.ListProcessing,
+				fmt.Sprintf("List %d/%d - Preparing to scan folder: %s (%s)", currentListNumber, totalLists, list.Title, s.parameters.FolderPath), overallPercentage)
+		} else if list.ItemCount > 0 {
 			s.progressReporter.ReportProgress(audit.StandardStages.ListProcessing,
 				fmt.Sprintf("List %d/%d - Preparing to scan items: %s (~%d items)", currentListNumber, totalLists, list.Title, list.ItemCount), overallPercentage)
 		} else {
@@ -376,19 +601,33 @@ func (s *SharePointDataCollector) auditList(ctx context.Context, auditRunID int6
 				fmt.Sprintf("List %d/%d - Preparing to scan items: %s (empty list)", currentListNumber, totalLists, list.Title), overallPercentage)
 		}
 			
-		if err := s.auditListItems(ctx, auditRunID, siteID, list.ID, list.Title, overallPercentage, currentListNumber, totalLists, list.ItemCount); err != nil {
+		expectedItemCount := list.ItemCount
+		if sampler != nil {
+			expectedItemCount = sampler.Size()
+		} else if s.parameters.IsFolderScoped() {
+			expectedItemCount = 0 // Unknown until the folder has been walked
+		}
+		if err := s.auditListItems(ctx, auditRunID, siteID, list, overallPercentage, currentListNumber, totalLists, expectedItemCount, sampler, resumeAfterID); err != nil {
 			s.logger.Warn("Failed to audit individual items in list", "list_title", list.Title, "error", err.Error())
-			// Continue processing other lists - don't return error
+			// Continue processing other lists - don't return error. The list is left incomplete for a resumed run.
+			return nil
 		}
 	}
 
+	s.saveListCheckpoint(ctx, list.ID, 0, true)
 	return nil
 }
 
 // auditListItems performs deep scanning of individual items (documents, folders, files)
 // within a SharePoint list. This includes collecting permissions and metadata for each item.
 // Uses Gosip's native pagination to efficiently handle lists with thousands of items.
-func (s *SharePointDataCollector) auditListItems(ctx context.Context, auditRunID int64, siteID int64, listID string, listTitle string, overallPercentage int, currentListNumber int, totalLists int, expectedItemCount int) error {
+// With a sampler only the sampled items are deep-scanned, and the sample size is recorded on the list.
+// Attachments are collected for list items in lists, which costs an API call per item.
+// Progress is checkpointed as items are walked, so an interrupted walk resumes after resumeAfterID.
+// A sample cannot be continued part way, so sampled lists are neither checkpointed nor resumed.
+func (s *SharePointDataCollector) auditListItems(ctx context.Context, auditRunID int64, siteID int64, list *sharepoint.List, overallPercentage int, currentListNumber int, totalLists int, expectedItemCount int, sampler *audit.ItemSampler, resumeAfterID int) error {
+	listID, listTitle := list.ID, list.Title
+
 	// Check for context cancellation at the start
 	if ctx.Err() != nil {
 		return fmt.Errorf("context canceled before auditing items for list %s: %w", listID, ctx.Err())
@@ -401,13 +640,40 @@ func (s *SharePointDataCollector) auditListItems(ctx context.Context, auditRunID
 	totalProcessed := 0
 	itemsWithUniquePerms := 0
 
-	// Create the items query (*api.Items)
-	itemsQuery := s.spClient.CreateListItemsQuery(ctx, listID, batchSize)
-	s.metrics.RecordAPICall() // GetItemsQuery preparation
+	// Read every item's unique permission flag in bulk; without it each item costs a round trip
+	uniqueItems, err := s.spClient.GetItemUniquePermissions(ctx, listID)
+	s.metrics.RecordAPICall()
+	if err != nil {
+		s.logger.Warn("Failed to prefetch item unique permissions, checking items individually", "list_id", listID, "error", err.Error())
+		s.metrics.RecordWarning()
+		uniqueItems = nil
+	}
+
+	// Expand File/Properties for sensitivity labels until it is denied
+	withProperties := true
+
+	checkpointed := sampler == nil
+	if !checkpointed {
+		resumeAfterID = 0
+	}
+
+	// Items are paged in ID order, so a walk cut short by the list view threshold resumes after the last ID seen
+	lastWalkedID := resumeAfterID
+	walkItem := func(itemResp api.ItemResp) error {
+		lastWalkedID = max(lastWalkedID, itemResponseID(itemResp))
+
+		// Skip items outside the audited folder before converting them, which costs an API call per item
+		if s.parameters.IsFolderScoped() && !audit.PathInFolder(itemServerPath(itemResp), s.parameters.FolderPath) {
+			return nil
+		}
+
+		// Skip items outside the sample before parsing them
+		if sampler != nil && !sampler.Next() {
+			return nil
+		}
 
-	err := s.walkListItems(ctx, itemsQuery, func(itemResp api.ItemResp) error {
 		// Process each individual SharePoint item (document, folder, etc.) and extract sensitivity label in single parse
-		domainItem, sensitivityLabel, err := s.spClient.ConvertItemWithSensitivityLabel(ctx, itemResp, listID, siteID)
+		domainItem, sensitivityLabel, err := s.spClient.ConvertItemWithSensitivityLabel(ctx, itemResp, listID, siteID, uniqueItems)
 		if err != nil {
 			s.logger.Warn("Failed to process individual item response", "error", err.Error())
 			s.metrics.RecordError()
@@ -425,6 +691,19 @@ func (s *SharePointDataCollector) auditListItems(ctx context.Context, auditRunID
 			}
 		}
 
+		// Attachments have no permissions of their own; they are recorded with the item they follow
+		if list.SupportsAttachments() && domainItem.IsListItem() {
+			attachments, err := s.spClient.GetItemAttachments(ctx, listID, domainItem.ID)
+			s.metrics.RecordAPICall()
+			if err != nil {
+				s.logger.Warn("Failed to collect item attachments", "item_guid", domainItem.GUID, "error", err.Error())
+				s.metrics.RecordWarning()
+				domainItem.MarkUnreadable(sharepoint.ItemFieldAttachments)
+			} else {
+				domainItem.Attachments = attachments
+			}
+		}
+
 		// Set site ID and audit this individual item's permissions and metadata
 		domainItem.SiteID = siteID
 		if err := s.auditIndividualItem(ctx, auditRunID, siteID, domainItem); err != nil {
@@ -458,10 +737,43 @@ func (s *SharePointDataCollector) auditListItems(ctx context.Context, auditRunID
 					fmt.Sprintf("List %d/%d - Scanning items: %s (%d items processed)", currentListNumber, totalLists, listTitle, totalProcessed), overallPercentage)
 			}
 			s.logger.Debug("Deep item scanning progress", "items_processed", totalProcessed, "expected_count", expectedItemCount, "list_id", listID)
+
+			// Every item up to the last one walked has been saved
+			if checkpointed {
+				s.saveListCheckpoint(ctx, listID, lastWalkedID, false)
+			}
 		}
 
 		return nil
-	})
+	}
+
+	if resumeAfterID > 0 {
+		// Items up to the checkpoint were saved before the run was interrupted
+		s.logger.Info("Resuming item scan from checkpoint", "list_id", listID, "list_title", listTitle, "resume_after_id", resumeAfterID)
+		s.progressReporter.ReportProgress(audit.StandardStages.ListProcessing,
+			fmt.Sprintf("List %d/%d - Resuming items scan after item %d: %s", currentListNumber, totalLists, resumeAfterID, listTitle), overallPercentage)
+		err = s.walkListItemsByIDRange(ctx, listID, batchSize, resumeAfterID, withProperties, walkItem)
+	} else {
+		// Create the items query (*api.Items)
+		itemsQuery := s.spClient.CreateListItemsQuery(ctx, listID, batchSize, withProperties)
+		s.metrics.RecordAPICall() // GetItemsQuery preparation
+		err = s.walkListItems(ctx, itemsQuery, walkItem)
+	}
+	if spclient.IsAccessDeniedError(err) {
+		// Keep collecting the items; files are recorded with their sensitivity label marked unreadable
+		s.logger.Warn("File properties not readable, collecting items without sensitivity labels", "list_id", listID, "list_title", listTitle, "resume_after_id", lastWalkedID, "error", err.Error())
+		s.metrics.RecordWarning()
+		s.progressReporter.ReportProgress(audit.StandardStages.ListProcessing,
+			fmt.Sprintf("List %d/%d - File properties not readable, sensitivity labels will be marked unreadable: %s", currentListNumber, totalLists, listTitle), overallPercentage)
+		withProperties = false
+		err = s.walkListItemsByIDRange(ctx, listID, batchSize, lastWalkedID, withProperties, walkItem)
+	}
+	if spclient.IsListViewThresholdError(err) {
+		s.logger.Warn("List view threshold exceeded, falling back to ID range queries", "list_id", listID, "list_title", listTitle, "resume_after_id", lastWalkedID)
+		s.progressReporter.ReportProgress(audit.StandardStages.ListProcessing,
+			fmt.Sprintf("List %d/%d - List view threshold exceeded, scanning in ID ranges: %s", currentListNumber, totalLists, listTitle), overallPercentage)
+		err = s.walkListItemsByIDRange(ctx, listID, batchSize, lastWalkedID, withProperties, walkItem)
+	}
 
 	if err != nil {
 		s.metrics.RecordError()
@@ -494,11 +806,21 @@ func (s *SharePointDataCollector) auditListItems(ctx context.Context, auditRunID
 		}
 	}
 
+	if sampler != nil && totalProcessed > 0 {
+		if err := s.repo.UpdateListSampling(ctx, listID, totalProcessed); err != nil {
+			s.metrics.RecordError()
+			return fmt.Errorf("record sampling for list %s (site_id=%d): %w", listID, siteID, err)
+		}
+		s.metrics.RecordDatabaseOperation()
+		s.progressReporter.ReportProgress(audit.StandardStages.ListProcessing,
+			fmt.Sprintf("List %d/%d - Sampled %d of %d items: %s (unique permission counts are estimates)", currentListNumber, totalLists, totalProcessed, sampler.Population(), listTitle), overallPercentage)
+	}
+
 	// Record item processing metrics
 	s.metrics.RecordItemProcessing(itemsStart, totalProcessed)
 	s.metrics.ItemsWithUniquePerms += itemsWithUniquePerms
 
-	s.logger.Info("Completed deep item scanning", "total_items", totalProcessed, "unique_perms", itemsWithUniquePerms, "list_id", listID)
+	s.logger.Info("Completed deep item scanning", "total_items", totalProcessed, "unique_perms", itemsWithUniquePerms, "sampled", sampler != nil, "list_id", listID)
 	return nil
 }
 
@@ -572,6 +894,65 @@ func (s *SharePointDataCollector) walkListItems(ctx context.Context, items *api.
 	return nil
 }
 
+// listContainingFolder returns the list whose root folder contains the folder path, nil when none does.
+// The deepest root wins, as a list's root folder can lie below another list's URL.
+func listContainingFolder(lists []*sharepoint.List, folderPath string) *sharepoint.List {
+	var match *sharepoint.List
+	matchLength := -1
+	for _, list := range lists {
+		listURL, err := url.Parse(list.URL)
+		if err != nil || listURL.Path == "" {
+			continue
+		}
+		if audit.PathInFolder(folderPath, listURL.Path) && len(listURL.Path) > matchLength {
+			match = list
+			matchLength = len(listURL.Path)
+		}
+	}
+	return match
+}
+
+// walkListItemsByIDRange walks the items after afterID in ID ranges no wider than the list view threshold.
+// Filtering on the indexed ID column lets lists too large for a single query, and without indexes, still be scanned.
+// It also resumes a walk that has to continue without File/Properties.
+func (s *SharePointDataCollector) walkListItemsByIDRange(ctx context.Context, listID string, batchSize int, afterID int, withProperties bool, onItem func(api.ItemResp) error) error {
+	maxID, err := s.spClient.GetListMaxItemID(ctx, listID)
+	s.metrics.RecordAPICall()
+	if err != nil {
+		return err
+	}
+
+	for _, idRange := range audit.PartitionItemIDs(afterID, maxID, audit.ListViewThreshold) {
+		query := s.spClient.CreateListItemsRangeQuery(ctx, listID, batchSize, idRange, withProperties)
+		if err := s.walkListItems(ctx, query, onItem); err != nil {
+			return fmt.Errorf("walk item IDs %d-%d: %w", idRange.From, idRange.To-1, err)
+		}
+	}
+	return nil
+}
+
+// itemResponseID returns the ID of a list item, 0 if it cannot be read.
+func itemResponseID(itemResp api.ItemResp) int {
+	var fields struct {
+		ID int `json:"Id"`
+	}
+	if err := json.Unmarshal(itemResp.Normalized(), &fields); err != nil {
+		return 0
+	}
+	return fields.ID
+}
+
+// itemServerPath returns the server-relative path of a list item, empty if it cannot be read.
+func itemServerPath(itemResp api.ItemResp) string {
+	var fields struct {
+		FileRef string `json:"FileRef"`
+	}
+	if err := json.Unmarshal(itemResp.Normalized(), &fields); err != nil {
+		return ""
+	}
+	return fields.FileRef
+}
+
 // auditIndividualItem audits a single SharePoint item (document, folder, or file).
 // This includes saving the item metadata and collecting its unique permissions if it has any.
 func (s *SharePointDataCollector) auditIndividualItem(ctx context.Context, auditRunID int64, siteID int64, item *sharepoint.Item) error {
@@ -18,18 +18,22 @@ type SharingDataCollector struct {
 	sharingService   *sharepoint.SharingService
 	logger           *logging.Logger
 	progressReporter audit.ProgressReporter
+
+	collectAnalytics   bool            // Fetch view/download counts for each shared item
+	analyticsCollected map[string]bool // Items already looked up in the current run
 }
 
 // NewSharingDataCollector creates a new sharing data collector
 func NewSharingDataCollector(
 	spClient spclient.SharePointClient,
 	repo contracts.SharePointAuditRepository,
+	logger *logging.Logger,
 ) *SharingDataCollector {
 	return &SharingDataCollector{
 		spClient:         spClient,
 		repo:             repo,
 		sharingService:   sharepoint.NewSharingService(),
-		logger:           logging.Default().WithComponent("sharing_audit"),
+		logger:           logger.WithComponent("sharing_audit"),
 		progressReporter: &audit.NoOpProgressReporter{}, // Default to no-op
 	}
 }
@@ -41,6 +45,11 @@ func (s *SharingDataCollector) SetProgressReporter(reporter audit.ProgressReport
 	}
 }
 
+// SetCollectAnalytics enables collection of view/download counts for shared items
+func (s *SharingDataCollector) SetCollectAnalytics(enabled bool) {
+	s.collectAnalytics = enabled
+}
+
 // AuditSiteSharing audits site sharing links.
 func (s *SharingDataCollector) AuditSiteSharing(ctx context.Context, auditRunID int64, siteID int64, siteURL string) error {
 	// Defensive checks
@@ -67,6 +76,7 @@ func (s *SharingDataCollector) AuditSiteSharing(ctx context.Context, auditRunID
 	}
 
 	s.logger.Audit("Starting sharing audit", siteURL)
+	s.analyticsCollected = make(map[string]bool)
 
 	// Step 1: Find all sharing links in the principals table (not just flexible)
 	s.progressReporter.ReportProgress(audit.StandardStages.Sharing, "Discovering sharing links...", 0)
@@ -215,6 +225,11 @@ func (s *SharingDataCollector) auditFlexibleSharingLink(ctx context.Context, aud
 			link.ItemGUID, siteID, len(sharingInfo.Links), err)
 	}
 
+	// Attach usage analytics so reports can rank widely-accessed items first
+	if s.collectAnalytics {
+		s.collectItemAnalytics(ctx, item, sharingInfo)
+	}
+
 	// Save governance data (site-level data that comes with each sharing info response)
 	if err := s.saveGovernanceData(ctx, siteID, link.ItemGUID, sharingInfo); err != nil {
 		s.logger.Warn("Failed to save governance data", "error", err, "item_guid", link.ItemGUID)
@@ -225,13 +240,38 @@ func (s *SharingDataCollector) auditFlexibleSharingLink(ctx context.Context, aud
 	return nil
 }
 
+// collectItemAnalytics fetches and saves view/download counts for a shared item.
+// Analytics are best effort: failures are logged and never fail the sharing audit.
+func (s *SharingDataCollector) collectItemAnalytics(ctx context.Context, item *sharepoint.Item, sharingInfo *sharepoint.SharingInfo) {
+	// Key by the UniqueId sharing links are stored against so the two join up
+	itemGUID := sharingInfo.ItemUniqueID
+	if itemGUID == "" {
+		itemGUID = item.GUID
+	}
+	if s.analyticsCollected[itemGUID] {
+		return // Several links can target the same item
+	}
+	s.analyticsCollected[itemGUID] = true
+
+	analytics, err := s.spClient.GetItemAnalytics(ctx, item.ListID, item.ID)
+	if err != nil {
+		s.logger.Warn("Failed to get item analytics", "item_guid", itemGUID, "error", err.Error())
+		return
+	}
+	analytics.ItemGUID = itemGUID
+
+	if err := s.repo.SaveItemAnalytics(ctx, analytics); err != nil {
+		s.logger.Warn("Failed to save item analytics", "item_guid", itemGUID, "error", err.Error())
+	}
+}
+
 // saveGovernanceData persists site-level governance data from sharing information
 func (s *SharingDataCollector) saveGovernanceData(ctx context.Context, siteID int64, itemGUID string, sharingInfo *sharepoint.SharingInfo) error {
 	if sharingInfo == nil {
 		return nil
 	}
 
-	// Save site-level governance data (one record per site)
+	// Save the site's governance data (one record per audit run)
 	if err := s.repo.SaveSharingGovernance(ctx, sharingInfo); err != nil {
 		return fmt.Errorf("save sharing governance: %w", err)
 	}
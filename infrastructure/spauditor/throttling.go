@@ -0,0 +1,55 @@
+package spauditor
+
+import (
+	"sync"
+
+	"spaudit/domain/audit"
+)
+
+// stageTrackingReporter passes progress on and remembers the latest stage and percentage, so events
+// between stages, such as throttling, can be reported without moving progress.
+type stageTrackingReporter struct {
+	audit.ProgressReporter
+
+	mu         sync.Mutex
+	stage      string
+	percentage int
+}
+
+// newStageTrackingReporter wraps reporter, starting at the web discovery stage
+func newStageTrackingReporter(reporter audit.ProgressReporter) *stageTrackingReporter {
+	return &stageTrackingReporter{ProgressReporter: reporter, stage: audit.StandardStages.WebDiscovery}
+}
+
+// ReportProgress implements the ProgressReporter interface
+func (r *stageTrackingReporter) ReportProgress(stage, description string, percentage int) {
+	r.track(stage, percentage)
+	r.ProgressReporter.ReportProgress(stage, description, percentage)
+}
+
+// ReportItemProgress implements the ProgressReporter interface
+func (r *stageTrackingReporter) ReportItemProgress(stage, description string, percentage, itemsDone, itemsTotal int) {
+	r.track(stage, percentage)
+	r.ProgressReporter.ReportItemProgress(stage, description, percentage, itemsDone, itemsTotal)
+}
+
+// ReportAtCurrentStage reports a description at the latest stage and percentage
+func (r *stageTrackingReporter) ReportAtCurrentStage(description string) {
+	r.mu.Lock()
+	stage, percentage := r.stage, r.percentage
+	r.mu.Unlock()
+	r.ProgressReporter.ReportProgress(stage, description, percentage)
+}
+
+func (r *stageTrackingReporter) track(stage string, percentage int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stage = stage
+	r.percentage = percentage
+}
+
+// onThrottle counts a request SharePoint throttled and tells the job why it is waiting
+func (s *SharePointDataCollector) onThrottle(event audit.ThrottleEvent) {
+	s.metrics.RecordThrottle(event)
+	s.progressReporter.ReportAtCurrentStage(event.Message())
+}
@@ -1,8 +1,11 @@
 package spclient
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -66,6 +69,21 @@ type ODataResults[T any] struct {
 	Results []T `json:"results"`
 }
 
+// UnmarshalJSON accepts collections in the verbose {"results": [...]} form and the JSON Light array form.
+func (r *ODataResults[T]) UnmarshalJSON(data []byte) error {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return json.Unmarshal(trimmed, &r.Results)
+	}
+	var verbose struct {
+		Results []T `json:"results"`
+	}
+	if err := json.Unmarshal(data, &verbose); err != nil {
+		return err
+	}
+	r.Results = verbose.Results
+	return nil
+}
+
 // One entry per sharing link present on the item.
 type LinkApiData struct {
 	IsInherited           bool                           `json:"isInherited"`
@@ -325,6 +343,43 @@ type RecipientLimitsInfoApiData struct {
 	ObjectIdOnly    int `json:"ObjectIdOnly"`
 }
 
+// ---------- RenderListDataAsStream structures ----------
+
+// RenderListDataStreamApiResponse is a page of rows from RenderListDataAsStream.
+// Row values are rendered for display, so numbers and booleans arrive as strings.
+type RenderListDataStreamApiResponse struct {
+	Row      []map[string]any `json:"Row"`
+	NextHref string           `json:"NextHref"` // Query string of the next page, empty on the last page
+}
+
+// renderedInt reads a numeric row value rendered as a number or string, 0 if unreadable.
+func renderedInt(value any) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, _ := strconv.Atoi(strings.TrimSpace(v))
+		return n
+	}
+	return 0
+}
+
+// renderedBool reads a boolean row value rendered as a bool, "1"/"0", "True"/"False" or "Yes"/"No".
+func renderedBool(value any) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "1", "true", "yes":
+			return true
+		}
+	}
+	return false
+}
+
 // ---------- File and Properties structures for list item queries ----------
 
 // ListItemApiResponse represents a SharePoint list item from the Items API
@@ -335,19 +390,129 @@ type ListItemApiResponse struct {
 	FileRef              string         `json:"FileRef"`
 	FileSystemObjectType int            `json:"FileSystemObjectType"`
 	FileLeafRef          string         `json:"FileLeafRef"`
+	Created              string         `json:"Created"`
+	Modified             string         `json:"Modified"`
+	ContentTypeID        ContentTypeID  `json:"ContentTypeId"`
 	File                 *FileApiData   `json:"File"`
 	Folder               *FolderApiData `json:"Folder"`
 }
 
+// ContentTypeID is a content type ID, returned either as a plain string or as an
+// SP.ContentTypeId object with a StringValue depending on the endpoint.
+type ContentTypeID string
+
+// UnmarshalJSON accepts both content type ID representations.
+func (id *ContentTypeID) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err == nil {
+		*id = ContentTypeID(value)
+		return nil
+	}
+	var object struct {
+		StringValue string `json:"StringValue"`
+	}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	*id = ContentTypeID(object.StringValue)
+	return nil
+}
+
+// UniqueID returns the File or Folder UniqueId, or empty for plain list items.
+func (it *ListItemApiResponse) UniqueID() string {
+	if it.File != nil && it.File.UniqueId != "" {
+		return it.File.UniqueId
+	}
+	if it.Folder != nil {
+		return it.Folder.UniqueId
+	}
+	return ""
+}
+
+// Size returns the file length in bytes, or 0 when the item is not a file.
+func (it *ListItemApiResponse) Size() int64 {
+	if it.File == nil {
+		return 0
+	}
+	size, err := it.File.Length.Int64()
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// CreatedTime returns the parsed Created timestamp, or nil if absent.
+func (it *ListItemApiResponse) CreatedTime() *time.Time {
+	return parseItemTime(it.Created)
+}
+
+// ModifiedTime returns the parsed Modified timestamp, or nil if absent.
+func (it *ListItemApiResponse) ModifiedTime() *time.Time {
+	return parseItemTime(it.Modified)
+}
+
+// parseItemTime parses a SharePoint list item timestamp.
+func parseItemTime(value string) *time.Time {
+	if value == "" {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
 // FileApiData represents the File object from SharePoint list items
 type FileApiData struct {
 	ServerRelativeUrl string                 `json:"ServerRelativeUrl"`
+	UniqueId          string                 `json:"UniqueId"`
+	Length            json.Number            `json:"Length"` // Returned as a string by some endpoints
 	Properties        *FilePropertiesApiData `json:"Properties"`
 }
 
+// PropertiesRead reports whether the response expanded File/Properties, where sensitivity labels are read from.
+func (f *FileApiData) PropertiesRead() bool {
+	return f.Properties != nil && !f.Properties.Deferred
+}
+
 // FolderApiData represents the Folder object from SharePoint list items
 type FolderApiData struct {
 	ServerRelativeUrl string `json:"ServerRelativeUrl"`
+	UniqueId          string `json:"UniqueId"`
+}
+
+// ListApiData represents a list selected with ListFields
+type ListApiData struct {
+	Id                string `json:"Id"`
+	Title             string `json:"Title"`
+	Hidden            bool   `json:"Hidden"`
+	ItemCount         int    `json:"ItemCount"`
+	BaseTemplate      int    `json:"BaseTemplate"`
+	ReadSecurity      int    `json:"ReadSecurity"`
+	WriteSecurity     int    `json:"WriteSecurity"`
+	DisableCommenting bool   `json:"DisableCommenting"`
+	RootFolder        struct {
+		ServerRelativeUrl string                 `json:"ServerRelativeUrl"`
+		Properties        map[string]interface{} `json:"Properties"` // Property bag, where the rating experience is kept
+	} `json:"RootFolder"`
+}
+
+// ItemSettings maps the list's item permission defaults and Microsoft Lists features.
+func (l *ListApiData) ItemSettings() *sharepoint.ListItemSettings {
+	rating := ""
+	for _, key := range []string{"Ratings_x005f_VotingExperience", "Ratings_VotingExperience"} {
+		if value, ok := l.RootFolder.Properties[key].(string); ok {
+			rating = value
+			break
+		}
+	}
+	return &sharepoint.ListItemSettings{
+		ReadSecurity:     l.ReadSecurity,
+		WriteSecurity:    l.WriteSecurity,
+		CommentsDisabled: l.DisableCommenting,
+		RatingExperience: rating,
+	}
 }
 
 // FilePropertiesApiData represents File.Properties containing sensitivity label and other metadata
@@ -359,11 +524,35 @@ type FilePropertiesApiData struct {
 	IPLabelChangedDate      string `json:"vti_x005f_iplabelchangeddate"`
 	IPLabelSharingProps     string `json:"vti_x005f_iplabelsharingprops"`
 
-	// Microsoft Information Protection (MSIP) Label - GUID-based property names
-	MSIPLabelSetDate string `json:"MSIP_x005f_Label_x005f_8c3d088b_x002d_6243_x002d_4963_x002d_a2e2_x002d_8b321ab7f8fc_x005f_SetDate"`
+	// Microsoft Information Protection (MSIP) Label - properties named after the label GUID,
+	// which differs per tenant and label
+	MSIPLabelID         string                 `json:"-"`
+	MSIPLabelSetDate    string                 `json:"-"`
+	MSIPLabelProperties map[string]interface{} `json:"-"` // By property name, e.g. Name, Method, Enabled
 
 	// Add other common properties as map for flexibility
 	OtherProperties map[string]interface{} `json:"-"`
+
+	// Deferred is set when the verbose response links to the properties instead of expanding them
+	Deferred bool `json:"-"`
+}
+
+// msipLabelPropertyPattern matches the MSIP_Label_<label GUID>_<property> properties Office writes
+// for labels applied to a file, either as File/Properties returns them, with "_" encoded as _x005f_
+// and "-" as _x002d_, or unencoded. The label GUID differs per tenant and label.
+var msipLabelPropertyPattern = regexp.MustCompile(
+	`^MSIP(?:_x005f_|_)Label(?:_x005f_|_)` +
+		`([0-9A-Fa-f]{8}(?:(?:_x002d_|-)[0-9A-Fa-f]{4}){3}(?:_x002d_|-)[0-9A-Fa-f]{12})` +
+		`(?:_x005f_|_)([A-Za-z]+)$`)
+
+// parseMSIPLabelProperty splits an MSIP label property name into the lower-cased label GUID and the
+// property, e.g. SetDate, Name or Method.
+func parseMSIPLabelProperty(key string) (labelID, property string, ok bool) {
+	match := msipLabelPropertyPattern.FindStringSubmatch(key)
+	if match == nil {
+		return "", "", false
+	}
+	return strings.ToLower(strings.ReplaceAll(match[1], "_x002d_", "-")), match[2], true
 }
 
 // UnmarshalJSON custom unmarshaler to capture all properties including unknown ones
@@ -373,6 +562,7 @@ func (f *FilePropertiesApiData) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &allProps); err != nil {
 		return err
 	}
+	_, f.Deferred = allProps["__deferred"]
 
 	// Extract known properties
 	if v, ok := allProps["vti_x005f_iplabelowneremail"].(string); ok {
@@ -390,7 +580,19 @@ func (f *FilePropertiesApiData) UnmarshalJSON(data []byte) error {
 	if v, ok := allProps["vti_x005f_iplabelsharingprops"].(string); ok {
 		f.IPLabelSharingProps = v
 	}
-	if v, ok := allProps["MSIP_x005f_Label_x005f_8c3d088b_x002d_6243_x002d_4963_x002d_a2e2_x002d_8b321ab7f8fc_x005f_SetDate"].(string); ok {
+
+	// Group MSIP properties by label; a file relabelled over time can carry several
+	msipLabels := make(map[string]map[string]interface{})
+	for key, value := range allProps {
+		if labelID, property, ok := parseMSIPLabelProperty(key); ok {
+			if msipLabels[labelID] == nil {
+				msipLabels[labelID] = make(map[string]interface{})
+			}
+			msipLabels[labelID][property] = value
+		}
+	}
+	f.MSIPLabelID, f.MSIPLabelProperties = currentMSIPLabel(msipLabels)
+	if v, ok := f.MSIPLabelProperties["SetDate"].(string); ok {
 		f.MSIPLabelSetDate = v
 	}
 
@@ -402,11 +604,11 @@ func (f *FilePropertiesApiData) UnmarshalJSON(data []byte) error {
 		"vti_x005f_iplabeldisplayname":      true,
 		"vti_x005f_iplabelchangeddate":      true,
 		"vti_x005f_iplabelsharingprops":     true,
-		"MSIP_x005f_Label_x005f_8c3d088b_x002d_6243_x002d_4963_x002d_a2e2_x002d_8b321ab7f8fc_x005f_SetDate": true,
-		"__metadata": true,
+		"__metadata":                        true,
+		"__deferred":                        true,
 	}
 	for key, value := range allProps {
-		if !knownKeys[key] {
+		if _, _, isMSIP := parseMSIPLabelProperty(key); !knownKeys[key] && !isMSIP {
 			f.OtherProperties[key] = value
 		}
 	}
@@ -414,6 +616,44 @@ func (f *FilePropertiesApiData) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// currentMSIPLabel picks the label in effect among a file's MSIP labels: an enabled one over a
+// disabled one, then the most recently set, then the lowest GUID so the choice is stable.
+func currentMSIPLabel(labels map[string]map[string]interface{}) (string, map[string]interface{}) {
+	var (
+		currentID    string
+		currentProps map[string]interface{}
+	)
+	for labelID, props := range labels {
+		if currentProps == nil || msipLabelPrecedes(labelID, props, currentID, currentProps) {
+			currentID, currentProps = labelID, props
+		}
+	}
+	return currentID, currentProps
+}
+
+func msipLabelPrecedes(aID string, a map[string]interface{}, bID string, b map[string]interface{}) bool {
+	if aEnabled, bEnabled := msipBool(a["Enabled"]), msipBool(b["Enabled"]); aEnabled != bEnabled {
+		return aEnabled
+	}
+	aSet, _ := a["SetDate"].(string)
+	bSet, _ := b["SetDate"].(string)
+	if aSet != bSet {
+		return aSet > bSet // RFC 3339 timestamps in UTC order as strings
+	}
+	return aID < bID
+}
+
+// msipBool reads an MSIP flag, written as a JSON boolean or as the string "true"/"false".
+func msipBool(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
 // MapToSensitivityLabel converts file properties to domain sensitivity label model
 func (f *FilePropertiesApiData) MapToSensitivityLabel(siteID int64, itemGUID string) *sharepoint.ItemSensitivityLabel {
 	if f == nil {
@@ -421,7 +661,7 @@ func (f *FilePropertiesApiData) MapToSensitivityLabel(siteID int64, itemGUID str
 	}
 
 	// Only create if we have a label ID
-	labelID := f.IPLabelDisplayName != "" || f.MSIPLabelSetDate != ""
+	labelID := f.IPLabelDisplayName != "" || f.MSIPLabelID != ""
 	if vti_iplabelid, exists := f.OtherProperties["vti_x005f_iplabelid"].(string); exists && vti_iplabelid != "" {
 		labelID = true
 	}
@@ -439,10 +679,13 @@ func (f *FilePropertiesApiData) MapToSensitivityLabel(siteID int64, itemGUID str
 		DiscoveredAt:     time.Now(),
 	}
 
-	// Extract label ID from other properties
+	// Extract label ID from other properties, else from the MSIP property names
 	if vti_iplabelid, ok := f.OtherProperties["vti_x005f_iplabelid"].(string); ok {
 		label.LabelID = vti_iplabelid
 	}
+	if label.LabelID == "" {
+		label.LabelID = f.MSIPLabelID
+	}
 
 	// Parse set date
 	if f.MSIPLabelSetDate != "" {
@@ -453,26 +696,27 @@ func (f *FilePropertiesApiData) MapToSensitivityLabel(siteID int64, itemGUID str
 
 	// Extract MSIP method if not already set
 	if label.AssignmentMethod == "" {
-		if method, ok := f.OtherProperties["MSIP_x005f_Label_x005f_8c3d088b_x002d_6243_x002d_4963_x002d_a2e2_x002d_8b321ab7f8fc_x005f_Method"].(string); ok {
+		if method, ok := f.MSIPLabelProperties["Method"].(string); ok {
 			label.AssignmentMethod = method
 		}
 	}
 
 	// Extract display name from MSIP if not already set
 	if label.DisplayName == "" {
-		if name, ok := f.OtherProperties["MSIP_x005f_Label_x005f_8c3d088b_x002d_6243_x002d_4963_x002d_a2e2_x002d_8b321ab7f8fc_x005f_Name"].(string); ok {
+		if name, ok := f.MSIPLabelProperties["Name"].(string); ok {
 			label.DisplayName = name
 		}
 	}
 
 	// Extract protection status
-	if enabled, ok := f.OtherProperties["MSIP_x005f_Label_x005f_8c3d088b_x002d_6243_x002d_4963_x002d_a2e2_x002d_8b321ab7f8fc_x005f_Enabled"].(bool); ok {
-		label.HasIRMProtection = enabled
-	}
+	label.HasIRMProtection = msipBool(f.MSIPLabelProperties["Enabled"])
 
 	// Extract content bits
-	if contentBits, ok := f.OtherProperties["MSIP_x005f_Label_x005f_8c3d088b_x002d_6243_x002d_4963_x002d_a2e2_x002d_8b321ab7f8fc_x005f_ContentBits"].(float64); ok {
+	switch contentBits := f.MSIPLabelProperties["ContentBits"].(type) {
+	case float64:
 		label.ContentBits = int(contentBits)
+	case string:
+		label.ContentBits, _ = strconv.Atoi(contentBits)
 	}
 
 	// Extract label flags
@@ -492,3 +736,143 @@ func (f *FilePropertiesApiData) MapToSensitivityLabel(siteID int64, itemGUID str
 
 	return label
 }
+
+// ItemActivityStatApiData represents a Graph itemActivityStat, as returned by
+// the item analytics allTime endpoint. Access covers both views and downloads.
+type ItemActivityStatApiData struct {
+	Access *ItemActionStatApiData `json:"access"`
+}
+
+// ItemActionStatApiData represents a Graph itemActionStat
+type ItemActionStatApiData struct {
+	ActionCount int64 `json:"actionCount"`
+	ActorCount  int64 `json:"actorCount"`
+}
+
+// ---------- App tile structures ----------
+
+// AppTilesApiResponse is the web's app tiles, as returned by _api/web/AppTiles without metadata
+type AppTilesApiResponse struct {
+	Value []AppTileApiData `json:"value"`
+}
+
+// AppTileApiData represents one SP.AppTile: an installed app, list or library shown in site contents
+type AppTileApiData struct {
+	AppId            string    `json:"AppId"`
+	ProductId        string    `json:"ProductId"`
+	Title            string    `json:"Title"`
+	AppSource        int       `json:"AppSource"`
+	AppPrincipalId   string    `json:"AppPrincipalId"`
+	LastModifiedDate time.Time `json:"LastModifiedDate"`
+}
+
+// ---------- Hub site structures ----------
+
+// SiteHubApiData is the site's hub association, as returned by _api/site without metadata
+type SiteHubApiData struct {
+	IsHubSite bool   `json:"IsHubSite"`
+	HubSiteId string `json:"HubSiteId"`
+}
+
+// HubSiteApiData represents one SP.HubSite, as returned by _api/HubSites/GetById without metadata
+type HubSiteApiData struct {
+	ID                    string `json:"ID"`
+	Title                 string `json:"Title"`
+	SiteUrl               string `json:"SiteUrl"`
+	ParentHubSiteId       string `json:"ParentHubSiteId"`
+	EnablePermissionsSync bool   `json:"EnablePermissionsSync"`
+}
+
+// ---------- Microsoft Graph structures ----------
+
+// GraphSiteApiData is a Graph site, looked up by its hostname and path
+type GraphSiteApiData struct {
+	ID string `json:"id"`
+}
+
+// GraphSitePermissionsApiResponse is a page of a site's permissions, the grants given to apps holding Sites.Selected
+type GraphSitePermissionsApiResponse struct {
+	Value    []GraphSitePermissionApiData `json:"value"`
+	NextLink string                       `json:"@odata.nextLink"`
+}
+
+// GraphSitePermissionApiData represents one Graph site permission
+type GraphSitePermissionApiData struct {
+	ID                    string                    `json:"id"`
+	Roles                 []string                  `json:"roles"`
+	GrantedToIdentitiesV2 []GraphIdentitySetApiData `json:"grantedToIdentitiesV2"`
+	GrantedToIdentities   []GraphIdentitySetApiData `json:"grantedToIdentities"` // Superseded by V2, still returned for older grants
+}
+
+// GraphIdentitySetApiData represents the identities a permission is granted to
+type GraphIdentitySetApiData struct {
+	Application *GraphIdentityApiData `json:"application"`
+}
+
+// GraphIdentityApiData represents an app or user identity
+type GraphIdentityApiData struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+}
+
+// GraphServicePrincipalApiData represents a service principal with the application roles it defines
+type GraphServicePrincipalApiData struct {
+	ID          string                `json:"id"`
+	AppID       string                `json:"appId"`
+	DisplayName string                `json:"displayName"`
+	AppRoles    []GraphAppRoleApiData `json:"appRoles"`
+}
+
+// GraphAppRoleApiData represents an application role, such as Sites.FullControl.All
+type GraphAppRoleApiData struct {
+	ID    string `json:"id"`
+	Value string `json:"value"`
+}
+
+// GraphAppRoleAssignmentsApiResponse is a page of the application roles granted on a resource
+type GraphAppRoleAssignmentsApiResponse struct {
+	Value    []GraphAppRoleAssignmentApiData `json:"value"`
+	NextLink string                          `json:"@odata.nextLink"`
+}
+
+// GraphAppRoleAssignmentApiData represents an application role granted to a principal
+type GraphAppRoleAssignmentApiData struct {
+	ID                   string `json:"id"`
+	AppRoleID            string `json:"appRoleId"`
+	PrincipalID          string `json:"principalId"`
+	PrincipalDisplayName string `json:"principalDisplayName"`
+	PrincipalType        string `json:"principalType"`
+}
+
+// GraphGroupUsersApiResponse is a page of the users a group's members or owners endpoint returns
+type GraphGroupUsersApiResponse struct {
+	Value    []GraphUserApiData `json:"value"`
+	NextLink string             `json:"@odata.nextLink"`
+}
+
+// GraphUserApiData represents a directory user
+type GraphUserApiData struct {
+	ID                string `json:"id"`
+	DisplayName       string `json:"displayName"`
+	UserPrincipalName string `json:"userPrincipalName"`
+	Mail              string `json:"mail"`
+	UserType          string `json:"userType"`
+}
+
+// ---------- Tenant admin structures ----------
+
+// TenantSitesApiResponse is a page of site collections from the admin center's
+// SPO.Tenant/GetSitePropertiesFromSharePointByFilters endpoint. The next page starts at
+// NextStartIndexFromSharePoint, which is empty on the last page.
+type TenantSitesApiResponse struct {
+	Value                        []TenantSitePropertiesApiData `json:"value"`
+	NextStartIndexFromSharePoint string                        `json:"NextStartIndexFromSharePoint"`
+}
+
+// TenantSitePropertiesApiData represents the SiteProperties of one site collection
+type TenantSitePropertiesApiData struct {
+	Url       string `json:"Url"`
+	Title     string `json:"Title"`
+	Template  string `json:"Template"`
+	LockState string `json:"LockState"`
+}
@@ -0,0 +1,95 @@
+package spclient
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/sharepoint"
+)
+
+func TestParseMSIPLabelProperty(t *testing.T) {
+	tests := []struct {
+		key      string
+		labelID  string
+		property string
+		ok       bool
+	}{
+		{"MSIP_x005f_Label_x005f_d9f23ae3_x002d_a239_x002d_45ea_x002d_bf23_x002d_f515f824c57b_x005f_SetDate", "d9f23ae3-a239-45ea-bf23-f515f824c57b", "SetDate", true},
+		{"MSIP_Label_D9F23AE3-A239-45EA-BF23-F515F824C57B_Name", "d9f23ae3-a239-45ea-bf23-f515f824c57b", "Name", true},
+		{"MSIP_x005f_Label_x005f_8c3d088b_x002d_6243_x002d_4963_x002d_a2e2_x002d_8b321ab7f8fc_x005f_Method", "8c3d088b-6243-4963-a2e2-8b321ab7f8fc", "Method", true},
+		{"MSIP_x005f_Label_x005f_not-a-guid_x005f_Name", "", "", false},
+		{"vti_x005f_iplabelid", "", "", false},
+	}
+	for _, tt := range tests {
+		labelID, property, ok := parseMSIPLabelProperty(tt.key)
+		assert.Equal(t, tt.ok, ok, tt.key)
+		assert.Equal(t, tt.labelID, labelID, tt.key)
+		assert.Equal(t, tt.property, property, tt.key)
+	}
+}
+
+func TestFilePropertiesApiData_UnencodedMSIPLabel(t *testing.T) {
+	var props FilePropertiesApiData
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"MSIP_Label_3e1a7c55-0f2b-4d8e-9b6c-a4f0e2d1c3b5_Enabled": "True",
+		"MSIP_Label_3e1a7c55-0f2b-4d8e-9b6c-a4f0e2d1c3b5_SetDate": "2025-04-02T07:15:30Z",
+		"MSIP_Label_3e1a7c55-0f2b-4d8e-9b6c-a4f0e2d1c3b5_Name": "Internal",
+		"vti_x005f_filesize": 10
+	}`), &props))
+
+	label := props.MapToSensitivityLabel(1, "item-guid")
+	require.NotNil(t, label)
+	assert.Equal(t, "3e1a7c55-0f2b-4d8e-9b6c-a4f0e2d1c3b5", label.LabelID)
+	assert.Equal(t, "Internal", label.DisplayName)
+	assert.True(t, label.HasIRMProtection)
+	require.NotNil(t, label.SetDate)
+	assert.Equal(t, "2025-04-02T07:15:30Z", label.SetDate.Format("2006-01-02T15:04:05Z07:00"))
+	assert.Equal(t, map[string]interface{}{"vti_x005f_filesize": float64(10)}, props.OtherProperties, "MSIP properties are not left in OtherProperties")
+}
+
+func TestListApiData_ItemSettings(t *testing.T) {
+	var list ListApiData
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"Id": "list-1",
+		"Title": "Issue tracker",
+		"BaseTemplate": 100,
+		"ReadSecurity": 2,
+		"WriteSecurity": 4,
+		"DisableCommenting": true,
+		"RootFolder": {
+			"ServerRelativeUrl": "/sites/a/Lists/Issue tracker",
+			"Properties": {"Ratings_x005f_VotingExperience": "Likes", "vti_x005f_listtitle": "Issue tracker"}
+		}
+	}`), &list))
+
+	settings := list.ItemSettings()
+	assert.Equal(t, sharepoint.ListReadOwnItems, settings.ReadSecurity)
+	assert.Equal(t, sharepoint.ListWriteNone, settings.WriteSecurity)
+	assert.True(t, settings.CommentsDisabled)
+	assert.Equal(t, sharepoint.ListRatingLikes, settings.RatingExperience)
+
+	var plain ListApiData
+	require.NoError(t, json.Unmarshal([]byte(`{"Id": "list-2", "ReadSecurity": 1, "WriteSecurity": 1, "RootFolder": {"ServerRelativeUrl": "/sites/a/Shared Documents"}}`), &plain))
+	assert.Equal(t, sharepoint.ListRatingNone, plain.ItemSettings().RatingExperience)
+	assert.False(t, plain.ItemSettings().RestrictsItemAccess())
+}
+
+func TestRenderedInt(t *testing.T) {
+	assert.Equal(t, 42, renderedInt(float64(42)))
+	assert.Equal(t, 7, renderedInt(" 7 "))
+	assert.Equal(t, 0, renderedInt("seven"))
+	assert.Equal(t, 0, renderedInt(nil))
+	assert.Equal(t, 0, renderedInt(true))
+}
+
+func TestRenderedBool(t *testing.T) {
+	for _, value := range []any{true, float64(1), "1", "True", "yes", " TRUE "} {
+		assert.True(t, renderedBool(value), "%v", value)
+	}
+	for _, value := range []any{false, float64(0), "0", "False", "No", "", nil} {
+		assert.False(t, renderedBool(value), "%v", value)
+	}
+}
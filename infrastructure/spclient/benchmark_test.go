@@ -0,0 +1,37 @@
+package spclient
+
+import (
+	"testing"
+
+	"spaudit/domain/sharepoint"
+	"spaudit/test/dataset"
+)
+
+// BenchmarkParseRoleAssignments decodes the role assignments of the synthetic site's unique items.
+// Run with: mage bench, or go test ./infrastructure/spclient -run '^$' -bench . -benchmem
+func BenchmarkParseRoleAssignments(b *testing.B) {
+	d := dataset.Generate(dataset.Small)
+	unique := d.UniqueItems()
+	payloads := make([][]byte, len(unique))
+	for i, item := range unique {
+		payload, err := d.RoleAssignmentPayload(item.GUID)
+		if err != nil {
+			b.Fatal(err)
+		}
+		payloads[i] = payload
+	}
+	client := &SharePointClientImpl{}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n := i % len(unique)
+		assignments, _, err := client.parseRoleAssignments(sharepoint.ObjectTypeItem, unique[n].GUID, payloads[n])
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(assignments) != d.Spec.AssignmentsPerItem {
+			b.Fatalf("parsed %d assignments, want %d", len(assignments), d.Spec.AssignmentsPerItem)
+		}
+	}
+}
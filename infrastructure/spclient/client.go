@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 
 	"github.com/koltyakov/gosip"
 	"github.com/koltyakov/gosip/api"
+	"github.com/koltyakov/gosip/auth/azurecert"
 )
 
 // SharePointPaginatedResult represents a paginated response from SharePoint API calls.
@@ -31,26 +33,67 @@ type SharePointClient interface {
 	// Site Structure Operations
 	GetSiteWeb(ctx context.Context) (*sharepoint.Web, error)
 	GetWebLists(ctx context.Context, webID string) ([]*sharepoint.List, error)
+	GetListByID(ctx context.Context, listID string) (*sharepoint.List, error)
 
 	// Permission Operations
 	GetSiteRoleDefinitions(ctx context.Context) ([]*sharepoint.RoleDefinition, error)
 	GetObjectRoleAssignments(ctx context.Context, target PermissionTarget) ([]*sharepoint.RoleAssignment, []*sharepoint.Principal, error)
 	CheckUniquePermissions(ctx context.Context, target PermissionTarget) (bool, error)
+	GetItemUniquePermissions(ctx context.Context, listID string) (map[int]bool, error)
+	GetGroupMembers(ctx context.Context, groupID int64) ([]*sharepoint.Principal, error)
 
 	// Sharing Operations
 	GetItemSharingInfo(ctx context.Context, itemGUID string) (*sharepoint.SharingInfo, error)
+	GetItemAnalytics(ctx context.Context, listID string, itemID int) (*sharepoint.ItemAnalytics, error)
+	GetItemAttachments(ctx context.Context, listID string, itemID int) ([]*sharepoint.ItemAttachment, error)
 
 	// Item Resolution Operations
 	ResolveFileByGUID(ctx context.Context, itemGUID string) (*sharepoint.Item, error)
 	ResolveFolderByGUID(ctx context.Context, itemGUID string) (*sharepoint.Item, error)
+	ResolveItemByPath(ctx context.Context, serverRelativePath string) (*sharepoint.Item, error)
 
 	// List Item Batch Operations
-	CreateListItemsQuery(ctx context.Context, listID string, batchSize int) *api.Items
+	CreateListItemsQuery(ctx context.Context, listID string, batchSize int, withProperties bool) *api.Items
+	CreateListItemsRangeQuery(ctx context.Context, listID string, batchSize int, idRange audit.ItemIDRange, withProperties bool) *api.Items
+	GetListMaxItemID(ctx context.Context, listID string) (int, error)
 	ConvertItemResponse(ctx context.Context, itemResp interface{}, listID string) (*sharepoint.Item, error)
-	ConvertItemWithSensitivityLabel(ctx context.Context, itemResp interface{}, listID string, siteID int64) (*sharepoint.Item, *sharepoint.ItemSensitivityLabel, error)
+	ConvertItemWithSensitivityLabel(ctx context.Context, itemResp interface{}, listID string, siteID int64, uniqueItems map[int]bool) (*sharepoint.Item, *sharepoint.ItemSensitivityLabel, error)
+
+	// Recycle Bin Operations
+	GetRecycleBinItems(ctx context.Context) ([]*sharepoint.RecycleBinItem, error)
+
+	// Installed App Operations
+	GetInstalledApps(ctx context.Context) ([]*sharepoint.InstalledApp, error)
+
+	// Throttling, reported as SharePoint throttles requests; they are retried by the client
+	SetThrottleObserver(observer func(audit.ThrottleEvent))
+
+	// Hub Site Operations
+	GetHubAssociation(ctx context.Context) (*sharepoint.HubAssociation, error)
+
+	// Microsoft Graph Operations, with the same app registration's Graph permissions
+	GetGraphAppGrants(ctx context.Context) ([]*sharepoint.GraphAppGrant, error)
+
+	// Directory Operations, through Microsoft Graph as the directory app registration when one is set
+	SetDirectoryCredentials(tenantID, clientID, certPath, certPass string)
+	GetEntraGroupMembers(ctx context.Context, group sharepoint.EntraGroupRef) ([]*sharepoint.EntraGroupMember, error)
+
+	// Tenant Operations, for a client of the tenant's admin center site
+	GetTenantSites(ctx context.Context) ([]*sharepoint.TenantSite, error)
+
+	// List Webhook Operations
+	AddListSubscription(ctx context.Context, listID, notificationURL, clientState string, expiration time.Time) (string, time.Time, error)
+	RenewListSubscription(ctx context.Context, listID, subscriptionID string, expiration time.Time) (time.Time, error)
+	DeleteListSubscription(ctx context.Context, listID, subscriptionID string) error
+	GetListChangeToken(ctx context.Context, listID string) (string, error)
+	GetChangedListItems(ctx context.Context, listID, changeToken string) ([]*sharepoint.ChangedItem, string, error)
 
 	// List Metadata Operations
 	CheckListVisibility(listID string) bool // Returns true if list is hidden from normal interfaces
+
+	// Diagnostics
+	SchemaDrift() []audit.SchemaDrift       // Response fields seen so far that the client does not map, or expected and did not receive
+	PayloadSamples() []*audit.PayloadSample // Sanitized raw payloads kept since the last call, when sampling is enabled
 }
 
 // JSON response structures and helpers.
@@ -123,26 +166,59 @@ type SharePointClientImpl struct {
 	listVisibilityCache map[string]bool        // Cache of listID -> isHidden to avoid repeated queries
 	logger              *logging.Logger        // Component logger for debugging and monitoring
 	parameters          *audit.AuditParameters // Audit parameters for batch sizes, timeouts, etc.
+	schemaDrift         *schemaDriftRecorder   // Unmapped and missing fields of item and sharing responses
+	payloadSamples      *payloadSampler        // Raw item and sharing payloads kept for debugging parsing issues
+	throttle            *throttlingTransport   // Paces the auth client's requests and retries those SharePoint throttles
+	directoryAuth       *azurecert.AuthCnfg    // App registration directory reads sign in to Microsoft Graph as; nil uses the auth client's
 }
 
 // NewSharePointClient creates a new SharePoint client implementation with authentication and parameters.
 // The Gosip API client handles most operations, while the auth client is used for
 // direct HTTP calls to APIs not covered by Gosip (like sharing APIs).
-func NewSharePointClient(gosipAPI *api.SP, authClient *gosip.SPClient, parameters *audit.AuditParameters) SharePointClient {
+func NewSharePointClient(gosipAPI *api.SP, authClient *gosip.SPClient, parameters *audit.AuditParameters, logger *logging.Logger) SharePointClient {
+	return NewSharePointClientWithPayloadSampling(gosipAPI, authClient, parameters, audit.PayloadSampleLimits{}, logger)
+}
+
+// NewSharePointClientWithPayloadSampling creates a SharePoint client that keeps sanitized raw item and
+// sharing payloads within the limits, for PayloadSamples to hand over.
+func NewSharePointClientWithPayloadSampling(gosipAPI *api.SP, authClient *gosip.SPClient, parameters *audit.AuditParameters, sampleLimits audit.PayloadSampleLimits, logger *logging.Logger) SharePointClient {
 	if parameters == nil {
 		parameters = audit.DefaultParameters()
 	}
 
-	return &SharePointClientImpl{
+	client := &SharePointClientImpl{
 		gosipAPI:      gosipAPI,
 		authClient:    authClient,
 		defaultConfig: &api.RequestConfig{
 			// Default configuration that can be extended with timeouts, headers, etc.
 		},
 		listVisibilityCache: make(map[string]bool),
-		logger:              logging.Default().WithComponent("sharepoint_client"),
+		logger:              logger.WithComponent("sharepoint_client"),
 		parameters:          parameters,
+		schemaDrift:         newSchemaDriftRecorder(),
+		payloadSamples:      newPayloadSampler(sampleLimits),
+	}
+	if authClient != nil {
+		client.throttle = installThrottling(authClient, siteHost(authClient.AuthCnfg.GetSiteURL()), parameters, client.logger)
 	}
+	return client
+}
+
+// SetThrottleObserver has every request SharePoint throttles reported to observer, Microsoft Graph
+// requests included.
+func (c *SharePointClientImpl) SetThrottleObserver(observer func(audit.ThrottleEvent)) {
+	if c.throttle != nil {
+		c.throttle.setObserver(observer)
+	}
+}
+
+// siteHost returns the host of a site URL, which its tenant's requests are paced by
+func siteHost(siteURL string) string {
+	parsed, err := url.Parse(siteURL)
+	if err != nil {
+		return siteURL
+	}
+	return parsed.Host
 }
 
 // createRequestConfig creates a RequestConfig with the provided context, inheriting default configuration.
@@ -163,10 +239,14 @@ func (c *SharePointClientImpl) GetSiteWeb(ctx context.Context) (*sharepoint.Web,
 	}
 
 	var webData struct {
-		Id          string
-		Title       string
-		Url         string
-		WebTemplate string
+		Id              string
+		Title           string
+		Url             string
+		WebTemplate     string
+		MembersCanShare bool
+
+		RequestAccessEmail      string
+		UseAccessRequestDefault bool
 	}
 	if err := json.Unmarshal(res.Normalized(), &webData); err != nil {
 		return nil, fmt.Errorf("decode web: %w", err)
@@ -188,6 +268,12 @@ func (c *SharePointClientImpl) GetSiteWeb(ctx context.Context) (*sharepoint.Web,
 		Title:     webData.Title,
 		Template:  webData.WebTemplate,
 		HasUnique: hasUnique,
+
+		MembersCanShare: &webData.MembersCanShare,
+		AccessRequests: &sharepoint.WebAccessRequests{
+			Email:    webData.RequestAccessEmail,
+			ToOwners: webData.UseAccessRequestDefault,
+		},
 	}, nil
 }
 
@@ -195,19 +281,12 @@ func (c *SharePointClientImpl) GetSiteWeb(ctx context.Context) (*sharepoint.Web,
 // Discovers all available lists for list-level auditing.
 func (c *SharePointClientImpl) GetWebLists(ctx context.Context, webID string) ([]*sharepoint.List, error) {
 	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
-	res, err := sp.Web().Lists().Select(ListFields).Expand(`RootFolder`).Get()
+	res, err := sp.Web().Lists().Select(ListFields).Expand(ListExpand).Get()
 	if err != nil {
 		return nil, fmt.Errorf("get lists: %w", err)
 	}
 
-	var listsData []struct {
-		Id           string
-		Title        string
-		Hidden       bool
-		ItemCount    int
-		BaseTemplate int
-		RootFolder   struct{ ServerRelativeUrl string }
-	}
+	var listsData []ListApiData
 	if err := json.Unmarshal(res.Normalized(), &listsData); err != nil {
 		return nil, fmt.Errorf("decode lists: %w", err)
 	}
@@ -230,6 +309,7 @@ func (c *SharePointClientImpl) GetWebLists(ctx context.Context, webID string) ([
 			BaseTemplate: l.BaseTemplate,
 			ItemCount:    l.ItemCount,
 			HasUnique:    hasUnique,
+			ItemSettings: l.ItemSettings(),
 		}
 
 		// Cache visibility status to avoid repeated queries
@@ -241,6 +321,34 @@ func (c *SharePointClientImpl) GetWebLists(ctx context.Context, webID string) ([
 	return lists, nil
 }
 
+// GetListByID retrieves a single list's metadata without checking its permission inheritance.
+// Used for live item lookups, where enumerating every list of the web would be wasteful.
+func (c *SharePointClientImpl) GetListByID(ctx context.Context, listID string) (*sharepoint.List, error) {
+	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
+	res, err := sp.Web().Lists().GetByID(listID).Select(ListFields).Expand(ListExpand).Get()
+	if err != nil {
+		return nil, fmt.Errorf("get list %s: %w", listID, err)
+	}
+
+	var listData ListApiData
+	if err := json.Unmarshal(res.Normalized(), &listData); err != nil {
+		return nil, fmt.Errorf("decode list %s: %w", listID, err)
+	}
+
+	siteURL := c.cachedWebURL
+	if siteURL == "" && c.authClient != nil {
+		siteURL = c.authClient.AuthCnfg.GetSiteURL()
+	}
+	return &sharepoint.List{
+		ID:           listData.Id,
+		Title:        listData.Title,
+		URL:          joinURL(siteURL, listData.RootFolder.ServerRelativeUrl),
+		BaseTemplate: listData.BaseTemplate,
+		ItemCount:    listData.ItemCount,
+		ItemSettings: listData.ItemSettings(),
+	}, nil
+}
+
 // CreateListItemsQuery creates a Gosip query object for paginated list items.
 // Returns an *api.Items query that can be used with GetPaged() for continuous iteration.
 // The query selects essential metadata and supports both files and folders.
@@ -248,12 +356,70 @@ func (c *SharePointClientImpl) GetWebLists(ctx context.Context, webID string) ([
 // Parameters:
 //   - listID: SharePoint list GUID
 //   - batchSize: Number of items per page (1-5000, values outside range are clamped)
+//   - withProperties: Expand File/Properties, which carries sensitivity labels; without it files
+//     are converted with the label marked unreadable (see IsAccessDeniedError)
 //
 // Usage:
 //
-//	query := client.CreateListItemsQuery(ctx, listID, 1000)
+//	query := client.CreateListItemsQuery(ctx, listID, 1000, true)
 //	// Pass to walkListItems() or use directly with GetPaged()
-func (c *SharePointClientImpl) CreateListItemsQuery(ctx context.Context, listID string, batchSize int) *api.Items {
+func (c *SharePointClientImpl) CreateListItemsQuery(ctx context.Context, listID string, batchSize int, withProperties bool) *api.Items {
+	return c.listItemsQuery(ctx, listID, batchSize, withProperties)
+}
+
+// CreateListItemsRangeQuery creates a paged list items query limited to a range of item IDs.
+// ID is always indexed, so a range no wider than the list view threshold can be queried on any list;
+// use it when CreateListItemsQuery fails with a list view threshold error (see IsListViewThresholdError).
+func (c *SharePointClientImpl) CreateListItemsRangeQuery(ctx context.Context, listID string, batchSize int, idRange audit.ItemIDRange, withProperties bool) *api.Items {
+	return c.listItemsQuery(ctx, listID, batchSize, withProperties).
+		Filter(fmt.Sprintf("Id ge %d and Id lt %d", idRange.From, idRange.To))
+}
+
+// GetListMaxItemID returns the highest item ID in a list, or 0 for an empty list.
+func (c *SharePointClientImpl) GetListMaxItemID(ctx context.Context, listID string) (int, error) {
+	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
+	itemsResp, err := sp.Web().Lists().GetByID(listID).Items().
+		Select("Id").
+		OrderBy("Id", false).
+		Top(1).
+		Get()
+	if err != nil {
+		return 0, fmt.Errorf("get max item ID of list %s: %w", listID, err)
+	}
+
+	items := itemsResp.Data()
+	if len(items) == 0 {
+		return 0, nil
+	}
+	return items[0].Data().ID, nil
+}
+
+// IsListViewThresholdError reports whether SharePoint refused a query because it would scan more
+// items than the list view threshold allows.
+func IsListViewThresholdError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	return strings.Contains(message, "SPQueryThrottledException") ||
+		strings.Contains(message, "-2147024860") ||
+		strings.Contains(strings.ToLower(message), "list view threshold")
+}
+
+// IsAccessDeniedError reports whether SharePoint refused a request, or an expansion within it,
+// because the account may not read the data.
+func IsAccessDeniedError(err error) bool {
+	if err == nil {
+		return false
+	}
+	message := err.Error()
+	return strings.Contains(message, "UnauthorizedAccessException") ||
+		strings.Contains(message, "-2147024891") ||
+		strings.Contains(strings.ToLower(message), "access denied")
+}
+
+// listItemsQuery builds the paged list items query selecting ItemFields.
+func (c *SharePointClientImpl) listItemsQuery(ctx context.Context, listID string, batchSize int, withProperties bool) *api.Items {
 	// Use parameters-based batch size clamping
 	if batchSize <= 0 {
 		batchSize = c.parameters.GetEffectiveBatchSize()
@@ -267,10 +433,15 @@ func (c *SharePointClientImpl) CreateListItemsQuery(ctx context.Context, listID
 		batchSize = constraints.MaxBatchSize
 	}
 
+	expand := "File,Folder"
+	if withProperties {
+		expand += ",File/Properties"
+	}
+
 	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
 	return sp.Web().Lists().GetByID(listID).Items().
 		Select(ItemFields).
-		Expand("File,Folder,File/Properties").
+		Expand(expand).
 		Top(batchSize)
 }
 
@@ -282,12 +453,14 @@ const (
 
 // SharePoint OData field selectors for consistent API queries
 const (
-	WebFields  = `Id,Title,Url,WebTemplate`
+	WebFields  = `Id,Title,Url,WebTemplate,MembersCanShare,RequestAccessEmail,UseAccessRequestDefault`
 	ListFields = `
 		Id,Title,Hidden,ItemCount,BaseTemplate,
-		RootFolder/ServerRelativeUrl
+		ReadSecurity,WriteSecurity,DisableCommenting,
+		RootFolder/ServerRelativeUrl,RootFolder/Properties
 	`
-	ItemFields           = `Id,GUID,FileSystemObjectType,File/ServerRelativeUrl,Folder/ServerRelativeUrl,FileLeafRef,Title,FileRef`
+	ListExpand           = `RootFolder,RootFolder/Properties`
+	ItemFields           = `Id,GUID,FileSystemObjectType,File/ServerRelativeUrl,File/UniqueId,File/Length,Folder/ServerRelativeUrl,Folder/UniqueId,FileLeafRef,Title,FileRef,Created,Modified,ContentTypeId`
 	RoleAssignmentFields = `
 		RoleAssignments/Member/Id,
 		RoleAssignments/Member/Title,
@@ -336,6 +509,8 @@ func (c *SharePointClientImpl) ConvertItemResponse(ctx context.Context, itemResp
 		if err := json.Unmarshal(normalizedData, &it); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal item: %w", err)
 		}
+		drifted := c.schemaDrift.checkListItem(normalizedData)
+		c.payloadSamples.offer(driftSourceListItem, it.GUID, normalizedData, drifted)
 
 		var (
 			isFile   bool
@@ -363,12 +538,13 @@ func (c *SharePointClientImpl) ConvertItemResponse(ctx context.Context, itemResp
 
 		// Check for unique permissions
 		hasUnique, err := c.CheckUniquePermissions(ctx, PermissionTarget{ObjectType: sharepoint.ObjectTypeItem, ObjectID: listID, ListItemID: it.ID})
+		uniqueReadable := err == nil
 		if err != nil {
 			c.logger.Debug("Failed to check item unique assignments", "item_id", it.ID, "error", err.Error())
 			hasUnique = false
 		}
 
-		return &sharepoint.Item{
+		item := &sharepoint.Item{
 			GUID:         it.GUID,
 			ListItemGUID: it.GUID,
 			ListID:       listID,
@@ -378,7 +554,17 @@ func (c *SharePointClientImpl) ConvertItemResponse(ctx context.Context, itemResp
 			IsFile:       isFile,
 			IsFolder:     isFolder,
 			HasUnique:    hasUnique,
-		}, nil
+
+			FileFolderUniqueID: it.UniqueID(),
+			Size:               it.Size(),
+			CreatedAt:          it.CreatedTime(),
+			ModifiedAt:         it.ModifiedTime(),
+			ContentTypeID:      string(it.ContentTypeID),
+		}
+		if !uniqueReadable {
+			item.MarkUnreadable(sharepoint.ItemFieldUniquePermissions)
+		}
+		return item, nil
 	}
 
 	return nil, fmt.Errorf("itemResp is not api.ItemResp type, got: %T", itemResp)
@@ -386,7 +572,9 @@ func (c *SharePointClientImpl) ConvertItemResponse(ctx context.Context, itemResp
 
 // ConvertItemWithSensitivityLabel converts a SharePoint item response to both domain Item and ItemSensitivityLabel in a single parse.
 // This is more efficient than calling ConvertItemResponse and ExtractItemSensitivityLabel separately.
-func (c *SharePointClientImpl) ConvertItemWithSensitivityLabel(ctx context.Context, itemResp interface{}, listID string, siteID int64) (*sharepoint.Item, *sharepoint.ItemSensitivityLabel, error) {
+// uniqueItems, from GetItemUniquePermissions, answers whether the item has unique permissions; items
+// missing from it, or every item when it is nil, are checked with a round trip each.
+func (c *SharePointClientImpl) ConvertItemWithSensitivityLabel(ctx context.Context, itemResp interface{}, listID string, siteID int64, uniqueItems map[int]bool) (*sharepoint.Item, *sharepoint.ItemSensitivityLabel, error) {
 	// itemResp should be api.ItemResp (which is []byte with generated Normalized() method)
 	if ir, ok := itemResp.(api.ItemResp); ok {
 		// Use the generated Normalized() method directly
@@ -397,6 +585,8 @@ func (c *SharePointClientImpl) ConvertItemWithSensitivityLabel(ctx context.Conte
 		if err := json.Unmarshal(normalizedData, &it); err != nil {
 			return nil, nil, fmt.Errorf("failed to unmarshal item: %w", err)
 		}
+		drifted := c.schemaDrift.checkListItem(normalizedData)
+		c.payloadSamples.offer(driftSourceListItem, it.GUID, normalizedData, drifted)
 
 		// Validate site ID consistency
 		if siteID <= 0 {
@@ -412,13 +602,14 @@ func (c *SharePointClientImpl) ConvertItemWithSensitivityLabel(ctx context.Conte
 		var sensitivityLabel *sharepoint.ItemSensitivityLabel
 		if it.File != nil && it.File.Properties != nil {
 			props := it.File.Properties
-			if props.IPLabelDisplayName != "" || props.IPLabelOwnerEmail != "" || props.MSIPLabelSetDate != "" {
+			if props.IPLabelDisplayName != "" || props.IPLabelOwnerEmail != "" || props.MSIPLabelID != "" {
 				c.logger.Debug("Sensitivity label detected on file",
 					"item_guid", it.GUID,
 					"item_title", it.Title,
 					"label_display_name", props.IPLabelDisplayName,
 					"label_owner_email", props.IPLabelOwnerEmail,
 					"assignment_method", props.IPLabelAssignmentMethod,
+					"msip_label_id", props.MSIPLabelID,
 					"msip_set_date", props.MSIPLabelSetDate,
 					"additional_properties_count", len(props.OtherProperties))
 
@@ -451,11 +642,17 @@ func (c *SharePointClientImpl) ConvertItemWithSensitivityLabel(ctx context.Conte
 			name = it.Title // Fallback to Title if FileLeafRef is empty
 		}
 
-		// Check for unique permissions
-		hasUnique, err := c.CheckUniquePermissions(ctx, PermissionTarget{ObjectType: sharepoint.ObjectTypeItem, ObjectID: listID, ListItemID: it.ID})
-		if err != nil {
-			c.logger.Debug("Failed to check item unique assignments", "item_id", it.ID, "error", err.Error())
-			hasUnique = false
+		// Check for unique permissions, unless the list's were prefetched
+		hasUnique, known := uniqueItems[it.ID]
+		uniqueReadable := true
+		if !known {
+			var err error
+			hasUnique, err = c.CheckUniquePermissions(ctx, PermissionTarget{ObjectType: sharepoint.ObjectTypeItem, ObjectID: listID, ListItemID: it.ID})
+			if err != nil {
+				c.logger.Debug("Failed to check item unique assignments", "item_id", it.ID, "error", err.Error())
+				hasUnique = false
+				uniqueReadable = false
+			}
 		}
 
 		item := &sharepoint.Item{
@@ -468,6 +665,20 @@ func (c *SharePointClientImpl) ConvertItemWithSensitivityLabel(ctx context.Conte
 			IsFile:       isFile,
 			IsFolder:     isFolder,
 			HasUnique:    hasUnique,
+
+			FileFolderUniqueID: it.UniqueID(),
+			Size:               it.Size(),
+			CreatedAt:          it.CreatedTime(),
+			ModifiedAt:         it.ModifiedTime(),
+			ContentTypeID:      string(it.ContentTypeID),
+		}
+		if !uniqueReadable {
+			item.MarkUnreadable(sharepoint.ItemFieldUniquePermissions)
+		}
+		// Without File/Properties, e.g. when the query omitted it after being denied, a missing label proves nothing.
+		// List items without a file have no File at all.
+		if isFile && it.File != nil && !it.File.PropertiesRead() {
+			item.MarkUnreadable(sharepoint.ItemFieldSensitivityLabel)
 		}
 
 		return item, sensitivityLabel, nil
@@ -558,6 +769,33 @@ func (c *SharePointClientImpl) GetObjectRoleAssignments(ctx context.Context, tar
 	return c.parseRoleAssignments(target.ObjectType, target.ObjectID, normalizedData)
 }
 
+// GetGroupMembers retrieves the users and security groups in a SharePoint site group.
+// Security groups are returned as members but not expanded; their membership lives in Azure AD.
+func (c *SharePointClientImpl) GetGroupMembers(ctx context.Context, groupID int64) ([]*sharepoint.Principal, error) {
+	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
+	usersResp, err := sp.Web().SiteGroups().GetByID(int(groupID)).Users().
+		Select("Id,Title,LoginName,Email,PrincipalType").
+		Get()
+	if err != nil {
+		return nil, fmt.Errorf("get members of group %d: %w", groupID, err)
+	}
+
+	users := usersResp.Data()
+	members := make([]*sharepoint.Principal, 0, len(users))
+	for _, userResp := range users {
+		user := userResp.Data()
+		members = append(members, &sharepoint.Principal{
+			ID:            int64(user.ID),
+			PrincipalType: int64(user.PrincipalType),
+			Title:         strings.TrimSpace(user.Title),
+			LoginName:     user.LoginName,
+			Email:         user.Email,
+		})
+	}
+
+	return members, nil
+}
+
 // CheckUniquePermissions checks if a SharePoint object has unique role assignments.
 // This determines whether the object inherits permissions from its parent or has custom permissions.
 // Returns true if the object has unique (non-inherited) permissions, false if inherited.
@@ -638,10 +876,486 @@ func (c *SharePointClientImpl) GetItemSharingInfo(ctx context.Context, itemGUID
 		}, nil
 	}
 
+	drifted := c.schemaDrift.checkSharingInfo(data)
+	c.payloadSamples.offer(driftSourceSharingInfo, itemGUID, data, drifted)
+
 	// Convert SharingApiResponse to sharepoint.SharingInfo
 	return c.mapSharingApiResponseToSharingInfo(sharingApiResponse), nil
 }
 
+// SchemaDrift returns the item and sharing response fields recorded so far that the client models
+// do not map, or map and did not receive.
+func (c *SharePointClientImpl) SchemaDrift() []audit.SchemaDrift {
+	return c.schemaDrift.drift()
+}
+
+// PayloadSamples returns the sanitized raw payloads kept since the last call; none unless the client
+// was created with NewSharePointClientWithPayloadSampling.
+func (c *SharePointClientImpl) PayloadSamples() []*audit.PayloadSample {
+	return c.payloadSamples.take()
+}
+
+// GetItemUniquePermissions returns, for every item in a list by ID, whether it has unique permissions.
+// RenderListDataAsStream exposes HasUniqueRoleAssignments as a view field, so a page of up to
+// the list view threshold costs one request instead of a Roles round trip per item.
+func (c *SharePointClientImpl) GetItemUniquePermissions(ctx context.Context, listID string) (map[int]bool, error) {
+	if c.authClient == nil {
+		return nil, fmt.Errorf("no auth client available for RenderListDataAsStream on list %s", listID)
+	}
+
+	spClient := api.NewHTTPClient(c.authClient)
+	siteURL := c.authClient.AuthCnfg.GetSiteURL()
+	endpoint := fmt.Sprintf("%s/_api/web/lists(guid'%s')/RenderListDataAsStream", strings.TrimRight(siteURL, "/"), listID)
+
+	viewXML := fmt.Sprintf(`<View Scope="RecursiveAll"><Query><OrderBy><FieldRef Name="ID"/></OrderBy></Query>`+
+		`<ViewFields><FieldRef Name="ID"/><FieldRef Name="HasUniqueRoleAssignments"/></ViewFields>`+
+		`<RowLimit Paged="TRUE">%d</RowLimit></View>`, audit.ListViewThreshold)
+	body, err := json.Marshal(map[string]any{
+		"parameters": map[string]any{
+			"RenderOptions": 2, // ListData: rows only
+			"ViewXml":       viewXML,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encode RenderListDataAsStream parameters: %w", err)
+	}
+	config := &api.RequestConfig{
+		Context: ctx,
+		Headers: map[string]string{
+			"Accept":       "application/json;odata=nometadata",
+			"Content-Type": "application/json;odata=nometadata",
+		},
+	}
+
+	uniqueItems, err := collectItemUniquePermissions(ctx, endpoint, func(pageURL string) ([]byte, error) {
+		return spClient.Post(pageURL, bytes.NewReader(body), config)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", listID, err)
+	}
+	return uniqueItems, nil
+}
+
+// collectItemUniquePermissions walks the RenderListDataAsStream pages from endpoint, following each
+// page's NextHref, and reads the unique permission flag of every row. Rows without the flag are left
+// out so their items are checked individually; when no row carries it, the view did not render the
+// field and the result would claim every item inherits, so it fails instead.
+func collectItemUniquePermissions(ctx context.Context, endpoint string, post func(pageURL string) ([]byte, error)) (map[int]bool, error) {
+	uniqueItems := make(map[int]bool)
+	rows := 0
+	for pageURL := endpoint; pageURL != ""; {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		data, err := post(pageURL)
+		if err != nil {
+			return nil, fmt.Errorf("render list data: %w", err)
+		}
+
+		var page RenderListDataStreamApiResponse
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("decode list data: %w", err)
+		}
+		for _, row := range page.Row {
+			id := renderedInt(row["ID"])
+			if id <= 0 {
+				continue
+			}
+			rows++
+			if value, ok := row["HasUniqueRoleAssignments"]; ok {
+				uniqueItems[id] = renderedBool(value)
+			}
+		}
+
+		pageURL = ""
+		if page.NextHref != "" {
+			pageURL = endpoint + "?" + strings.TrimPrefix(page.NextHref, "?")
+		}
+	}
+	if rows > 0 && len(uniqueItems) == 0 {
+		return nil, fmt.Errorf("none of %d rows carried HasUniqueRoleAssignments", rows)
+	}
+	return uniqueItems, nil
+}
+
+// GetItemAttachments retrieves the files attached to a list item. Only lists support attachments;
+// libraries store files as items instead.
+func (c *SharePointClientImpl) GetItemAttachments(ctx context.Context, listID string, itemID int) ([]*sharepoint.ItemAttachment, error) {
+	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
+	attachmentsResp, err := sp.Web().Lists().GetByID(listID).Items().GetByID(itemID).Attachments().Get()
+	if err != nil {
+		return nil, fmt.Errorf("get attachments of item %s/%d: %w", listID, itemID, err)
+	}
+
+	files := attachmentsResp.Data()
+	attachments := make([]*sharepoint.ItemAttachment, 0, len(files))
+	for _, fileResp := range files {
+		file := fileResp.Data()
+		attachments = append(attachments, &sharepoint.ItemAttachment{
+			FileName: file.FileName,
+			URL:      joinURL(c.cachedWebURL, file.ServerRelativeURL),
+		})
+	}
+	return attachments, nil
+}
+
+// recycleBinScanLimit caps the recycle bin entries read by one audit; the most recently deleted are kept.
+const recycleBinScanLimit = 5000
+
+// GetRecycleBinItems retrieves the files, folders and list items in the site's recycle bin, most
+// recently deleted first. Both stages are returned to site collection admins; other accounts only
+// see the first stage. Entries that cannot carry sharing links, such as lists and versions, are skipped.
+func (c *SharePointClientImpl) GetRecycleBinItems(ctx context.Context) ([]*sharepoint.RecycleBinItem, error) {
+	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
+	resp, err := sp.Site().RecycleBin().
+		Select("Id,ItemType,ItemState,Title,LeafName,DirName,DeletedDate,DeletedByName,DeletedByEmail").
+		OrderBy("DeletedDate", false).
+		Top(recycleBinScanLimit).
+		Get()
+	if err != nil {
+		return nil, fmt.Errorf("get recycle bin: %w", err)
+	}
+
+	var items []*sharepoint.RecycleBinItem
+	for _, entryResp := range resp.Data() {
+		entry := entryResp.Data()
+		item := &sharepoint.RecycleBinItem{
+			ID:             entry.ID,
+			ItemType:       entry.ItemType,
+			ItemState:      entry.ItemState,
+			Title:          entry.Title,
+			URL:            joinURL(c.cachedWebURL, "/"+strings.Trim(entry.DirName, "/")+"/"+entry.LeafName),
+			DeletedAt:      entry.DeletedDate,
+			DeletedByName:  entry.DeletedByName,
+			DeletedByEmail: entry.DeletedByEmail,
+		}
+		if item.CanBeShared() {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// GetInstalledApps retrieves the add-ins and SPFx apps installed on the site's web, from its app tiles.
+// gosip has no app tile API, so the tiles are read from REST directly. Lists and libraries, which
+// are tiles too, are skipped.
+func (c *SharePointClientImpl) GetInstalledApps(ctx context.Context) ([]*sharepoint.InstalledApp, error) {
+	if c.authClient == nil {
+		return nil, fmt.Errorf("no auth client available for app tiles")
+	}
+
+	spClient := api.NewHTTPClient(c.authClient)
+	siteURL := c.authClient.AuthCnfg.GetSiteURL()
+	endpoint := fmt.Sprintf(
+		"%s/_api/web/AppTiles?$select=AppId,ProductId,Title,AppSource,AppPrincipalId,LastModifiedDate",
+		strings.TrimRight(siteURL, "/"),
+	)
+	config := &api.RequestConfig{
+		Context: ctx,
+		Headers: map[string]string{"Accept": "application/json;odata=nometadata"},
+	}
+
+	data, err := spClient.Get(endpoint, config)
+	if err != nil {
+		return nil, fmt.Errorf("get app tiles: %w", err)
+	}
+
+	var tiles AppTilesApiResponse
+	if err := json.Unmarshal(data, &tiles); err != nil {
+		return nil, fmt.Errorf("decode app tiles: %w", err)
+	}
+
+	var apps []*sharepoint.InstalledApp
+	for _, tile := range tiles.Value {
+		if !sharepoint.IsInstalledApp(tile.AppSource) {
+			continue
+		}
+		app := &sharepoint.InstalledApp{
+			AppID:          tile.AppId,
+			ProductID:      tile.ProductId,
+			Title:          tile.Title,
+			AppSource:      tile.AppSource,
+			AppPrincipalID: tile.AppPrincipalId,
+		}
+		if !tile.LastModifiedDate.IsZero() {
+			lastModified := tile.LastModifiedDate
+			app.LastModified = &lastModified
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
+
+// GetHubAssociation retrieves the hub the site is associated with, and how the hub is set up. The hub
+// is read through the site, so a hub the app cannot read still groups the site by its ID.
+func (c *SharePointClientImpl) GetHubAssociation(ctx context.Context) (*sharepoint.HubAssociation, error) {
+	if c.authClient == nil {
+		return nil, fmt.Errorf("no auth client available for hub sites")
+	}
+
+	spClient := api.NewHTTPClient(c.authClient)
+	siteURL := strings.TrimRight(c.authClient.AuthCnfg.GetSiteURL(), "/")
+	config := &api.RequestConfig{
+		Context: ctx,
+		Headers: map[string]string{"Accept": "application/json;odata=nometadata"},
+	}
+
+	data, err := spClient.Get(siteURL+"/_api/site?$select=IsHubSite,HubSiteId", config)
+	if err != nil {
+		return nil, fmt.Errorf("get site hub: %w", err)
+	}
+	var site SiteHubApiData
+	if err := json.Unmarshal(data, &site); err != nil {
+		return nil, fmt.Errorf("decode site hub: %w", err)
+	}
+
+	association := &sharepoint.HubAssociation{
+		IsHub:     site.IsHubSite,
+		HubSiteID: sharepoint.NormalizeHubSiteID(site.HubSiteId),
+	}
+	if !association.IsAssociated() {
+		return association, nil
+	}
+
+	endpoint := fmt.Sprintf("%s/_api/HubSites/GetById?hubSiteId='%s'", siteURL, association.HubSiteID)
+	data, err = spClient.Get(endpoint, config)
+	if err != nil {
+		c.logger.Warn("Failed to read hub site, keeping its ID only", "hub_site_id", association.HubSiteID, "error", err.Error())
+		return association, nil
+	}
+	var hub HubSiteApiData
+	if err := json.Unmarshal(data, &hub); err != nil {
+		return nil, fmt.Errorf("decode hub site: %w", err)
+	}
+	association.HubTitle = hub.Title
+	association.HubURL = hub.SiteUrl
+	association.ParentHubSiteID = sharepoint.NormalizeHubSiteID(hub.ParentHubSiteId)
+	association.PermissionsSync = hub.EnablePermissionsSync
+	return association, nil
+}
+
+// GetTenantSites lists the tenant's site collections through the SharePoint admin API, which only
+// answers on the admin center site and needs the app to hold Sites.FullControl.All. OneDrive sites
+// are left out.
+func (c *SharePointClientImpl) GetTenantSites(ctx context.Context) ([]*sharepoint.TenantSite, error) {
+	if c.authClient == nil {
+		return nil, fmt.Errorf("no auth client available for the tenant admin API")
+	}
+
+	spClient := api.NewHTTPClient(c.authClient)
+	adminURL := c.authClient.AuthCnfg.GetSiteURL()
+	endpoint := fmt.Sprintf("%s/_api/SPO.Tenant/GetSitePropertiesFromSharePointByFilters", strings.TrimRight(adminURL, "/"))
+	config := &api.RequestConfig{
+		Context: ctx,
+		Headers: map[string]string{
+			"Accept":       "application/json;odata=nometadata",
+			"Content-Type": "application/json;odata=nometadata",
+		},
+	}
+
+	var sites []*sharepoint.TenantSite
+	for startIndex := "0"; startIndex != ""; {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		body, err := json.Marshal(map[string]any{
+			"speFilter": map[string]any{
+				"StartIndex":          startIndex,
+				"IncludeDetail":       false,
+				"IncludePersonalSite": 2, // Exclude
+			},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("encode tenant site filter: %w", err)
+		}
+		data, err := spClient.Post(endpoint, bytes.NewReader(body), config)
+		if err != nil {
+			return nil, fmt.Errorf("list tenant sites: %w", err)
+		}
+
+		var page TenantSitesApiResponse
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("decode tenant sites: %w", err)
+		}
+		for _, props := range page.Value {
+			sites = append(sites, &sharepoint.TenantSite{
+				URL:       strings.TrimRight(props.Url, "/"),
+				Title:     props.Title,
+				Template:  props.Template,
+				LockState: props.LockState,
+			})
+		}
+
+		if len(page.Value) == 0 || page.NextStartIndexFromSharePoint == startIndex {
+			break
+		}
+		startIndex = page.NextStartIndexFromSharePoint
+	}
+	return sites, nil
+}
+
+// Paging of list change log reads
+const (
+	listChangesPageSize = 1000 // Changes read per request
+	changedItemsPerRead = 50   // Changed items resolved per request, by ID
+)
+
+// AddListSubscription subscribes notificationURL to the list's changes until expiration, returning
+// the subscription's ID and the expiration SharePoint accepted. SharePoint first validates the URL
+// by sending it a validation token it must echo.
+func (c *SharePointClientImpl) AddListSubscription(ctx context.Context, listID, notificationURL, clientState string, expiration time.Time) (string, time.Time, error) {
+	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
+	subscription, err := sp.Web().Lists().GetByID(listID).Subscriptions().Add(notificationURL, expiration, clientState)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("subscribe to list %s: %w", listID, err)
+	}
+	return subscription.ID, subscription.ExpirationDateTime, nil
+}
+
+// RenewListSubscription moves the expiration of a subscription to the list's changes, returning the
+// expiration SharePoint accepted. It fails once the subscription has lapsed.
+func (c *SharePointClientImpl) RenewListSubscription(ctx context.Context, listID, subscriptionID string, expiration time.Time) (time.Time, error) {
+	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
+	subscription, err := sp.Web().Lists().GetByID(listID).Subscriptions().GetByID(subscriptionID).SetExpiration(expiration)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("renew subscription %s to list %s: %w", subscriptionID, listID, err)
+	}
+	return subscription.ExpirationDateTime, nil
+}
+
+// DeleteListSubscription ends a subscription to the list's changes.
+func (c *SharePointClientImpl) DeleteListSubscription(ctx context.Context, listID, subscriptionID string) error {
+	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
+	if err := sp.Web().Lists().GetByID(listID).Subscriptions().GetByID(subscriptionID).Delete(); err != nil {
+		return fmt.Errorf("delete subscription %s to list %s: %w", subscriptionID, listID, err)
+	}
+	return nil
+}
+
+// GetListChangeToken returns the list's current position in its change log.
+func (c *SharePointClientImpl) GetListChangeToken(ctx context.Context, listID string) (string, error) {
+	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
+	token, err := sp.Web().Lists().GetByID(listID).Changes().GetCurrentToken()
+	if err != nil {
+		return "", fmt.Errorf("get change token of list %s: %w", listID, err)
+	}
+	return token, nil
+}
+
+// GetChangedListItems reads the list's change log from changeToken and returns the items, files and
+// folders that were added, updated, renamed, moved, restored or re-permissioned, with the change
+// token to read on from. Items deleted since they changed are left out.
+func (c *SharePointClientImpl) GetChangedListItems(ctx context.Context, listID, changeToken string) ([]*sharepoint.ChangedItem, string, error) {
+	sp := c.gosipAPI.Conf(c.createRequestConfig(ctx))
+	list := sp.Web().Lists().GetByID(listID)
+
+	query := &api.ChangeQuery{
+		ChangeTokenStart:     changeToken,
+		Item:                 true,
+		Add:                  true,
+		Update:               true,
+		SystemUpdate:         true,
+		Rename:               true,
+		Move:                 true,
+		Restore:              true,
+		RoleAssignmentAdd:    true,
+		RoleAssignmentDelete: true,
+		SecurityPolicy:       true,
+	}
+	resp, err := list.Changes().Top(listChangesPageSize).GetChanges(query)
+	if err != nil {
+		return nil, "", fmt.Errorf("get changes of list %s: %w", listID, err)
+	}
+
+	latestToken := changeToken
+	var itemIDs []int
+	seen := make(map[int]bool)
+	for {
+		changes := resp.Data()
+		for _, change := range changes {
+			if change.ChangeToken != nil && change.ChangeToken.StringValue != "" {
+				latestToken = change.ChangeToken.StringValue
+			}
+			if change.ItemID > 0 && !seen[change.ItemID] {
+				seen[change.ItemID] = true
+				itemIDs = append(itemIDs, change.ItemID)
+			}
+		}
+		if len(changes) < listChangesPageSize {
+			break
+		}
+		if resp, err = resp.GetNextPage(); err != nil {
+			return nil, "", fmt.Errorf("get changes of list %s: %w", listID, err)
+		}
+	}
+
+	var changed []*sharepoint.ChangedItem
+	for start := 0; start < len(itemIDs); start += changedItemsPerRead {
+		ids := itemIDs[start:min(start+changedItemsPerRead, len(itemIDs))]
+		filters := make([]string, len(ids))
+		for i, id := range ids {
+			filters[i] = fmt.Sprintf("Id eq %d", id)
+		}
+		itemsResp, err := list.Items().
+			Select("Id,FileRef,FileSystemObjectType").
+			Filter(strings.Join(filters, " or ")).
+			Top(len(ids)).
+			Get()
+		if err != nil {
+			return nil, "", fmt.Errorf("get changed items of list %s: %w", listID, err)
+		}
+
+		var items []itemJSON
+		if err := json.Unmarshal(itemsResp.Normalized(), &items); err != nil {
+			return nil, "", fmt.Errorf("decode changed items of list %s: %w", listID, err)
+		}
+		for _, item := range items {
+			changed = append(changed, &sharepoint.ChangedItem{
+				ID:       max(item.Id, item.IDAlt),
+				Path:     ptrOrEmpty(item.FileRef),
+				IsFolder: item.FileSystemObjectType == SharePointFolder,
+			})
+		}
+	}
+	return changed, latestToken, nil
+}
+
+// GetItemAnalytics retrieves all-time view/download counts for a list item.
+// Uses SharePoint's Graph-compatible v2.1 endpoint so the existing SharePoint
+// authentication applies; no separate Graph token is needed.
+func (c *SharePointClientImpl) GetItemAnalytics(ctx context.Context, listID string, itemID int) (*sharepoint.ItemAnalytics, error) {
+	if c.authClient == nil {
+		return nil, fmt.Errorf("no auth client available for item analytics %s/%d", listID, itemID)
+	}
+
+	spClient := api.NewHTTPClient(c.authClient)
+	siteURL := c.authClient.AuthCnfg.GetSiteURL()
+
+	endpoint := fmt.Sprintf(
+		"%s/_api/v2.1/sites/root/lists/%s/items/%d/analytics/allTime",
+		strings.TrimRight(siteURL, "/"), listID, itemID,
+	)
+
+	data, err := spClient.Get(endpoint, &api.RequestConfig{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("get item analytics %s/%d: %w", listID, itemID, err)
+	}
+
+	var stats ItemActivityStatApiData
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("decode item analytics: %w", err)
+	}
+
+	analytics := &sharepoint.ItemAnalytics{}
+	if stats.Access != nil {
+		analytics.ViewCount = stats.Access.ActionCount
+		analytics.ViewerCount = stats.Access.ActorCount
+	}
+	return analytics, nil
+}
+
 // ResolveFileByGUID retrieves file details by GUID using SharePoint's File API.
 // This resolves a file's UniqueId to its full metadata including list context and URLs.
 // Used primarily for resolving sharing link targets to their source items.
@@ -762,6 +1476,63 @@ func (c *SharePointClientImpl) ResolveFolderByGUID(ctx context.Context, itemGUID
 	}, nil
 }
 
+// ResolveItemByPath retrieves a file or folder by its server-relative path.
+// The path is tried as a file first, then as a folder; the item GUID is the list item GUID.
+func (c *SharePointClientImpl) ResolveItemByPath(ctx context.Context, serverRelativePath string) (*sharepoint.Item, error) {
+	if c.authClient == nil {
+		return nil, fmt.Errorf("no auth client available for ResolveItemByPath %s", serverRelativePath)
+	}
+	spClient := api.NewHTTPClient(c.authClient)
+	siteURL := c.authClient.AuthCnfg.GetSiteURL()
+
+	// The path travels as a parameter alias so quotes and special characters survive
+	pathParam := url.QueryEscape("'" + strings.ReplaceAll(serverRelativePath, "'", "''") + "'")
+
+	var lastErr error
+	for _, kind := range []struct {
+		method   string
+		isFolder bool
+	}{
+		{"GetFileByServerRelativePath", false},
+		{"GetFolderByServerRelativePath", true},
+	} {
+		endpoint := fmt.Sprintf(
+			"%s/_api/web/%s(decodedurl=@p)/ListItemAllFields"+
+				"?$select=Id,GUID,FileSystemObjectType,FileLeafRef,FileRef,"+
+				"ParentList/Id,ParentList/Title,ParentList/RootFolder/ServerRelativeUrl"+
+				"&$expand=ParentList,ParentList/RootFolder&@p=%s",
+			siteURL, kind.method, pathParam,
+		)
+
+		data, err := spClient.Get(endpoint, &api.RequestConfig{Context: ctx})
+		if err != nil {
+			lastErr = fmt.Errorf("%s %s: %w", kind.method, serverRelativePath, err)
+			continue
+		}
+
+		itm, err := decodeItemJSON(data)
+		if err != nil {
+			return nil, fmt.Errorf("decode item JSON: %w", err)
+		}
+		if itm.ParentList.Id == "" || itm.Id == 0 {
+			lastErr = fmt.Errorf("%s %s: not a list item", kind.method, serverRelativePath)
+			continue
+		}
+
+		return &sharepoint.Item{
+			GUID:         itm.GUID,
+			ListItemGUID: itm.GUID,
+			ListID:       itm.ParentList.Id,
+			ID:           itm.Id,
+			Name:         ptrOrEmpty(itm.FileLeafRef),
+			IsFile:       !kind.isFolder,
+			IsFolder:     kind.isFolder,
+			URL:          joinURL(siteURL, ptrOrEmpty(itm.FileRef)),
+		}, nil
+	}
+	return nil, lastErr
+}
+
 // CheckListVisibility checks if a list is marked as hidden in SharePoint.
 // Uses cached visibility information from list enumeration to avoid additional API calls.
 // Returns true if the list is hidden from normal user interfaces.
@@ -0,0 +1,64 @@
+package spclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const renderEndpoint = "https://contoso.sharepoint.com/sites/a/_api/web/lists(guid'docs')/RenderListDataAsStream"
+
+// renderPages answers RenderListDataAsStream requests by URL, recording the URLs requested.
+func renderPages(pages map[string]string, requested *[]string) func(string) ([]byte, error) {
+	return func(pageURL string) ([]byte, error) {
+		*requested = append(*requested, pageURL)
+		page, ok := pages[pageURL]
+		if !ok {
+			return nil, errors.New("unexpected page " + pageURL)
+		}
+		return []byte(page), nil
+	}
+}
+
+func TestCollectItemUniquePermissions_FollowsNextHref(t *testing.T) {
+	var requested []string
+	pages := map[string]string{
+		renderEndpoint: `{"Row":[{"ID":"1","HasUniqueRoleAssignments":"1"},{"ID":"2","HasUniqueRoleAssignments":"0"}],
+			"NextHref":"?Paged=TRUE&p_ID=2&PageFirstRow=3&View=00000000-0000-0000-0000-000000000000"}`,
+		renderEndpoint + "?Paged=TRUE&p_ID=2&PageFirstRow=3&View=00000000-0000-0000-0000-000000000000": `{"Row":[
+			{"ID":"3","HasUniqueRoleAssignments":"True"},{"ID":"4"},{"ID":"","HasUniqueRoleAssignments":"1"}]}`,
+	}
+
+	uniqueItems, err := collectItemUniquePermissions(context.Background(), renderEndpoint, renderPages(pages, &requested))
+	require.NoError(t, err)
+	assert.Len(t, requested, 2)
+	assert.Equal(t, map[int]bool{1: true, 2: false, 3: true}, uniqueItems, "rows without the flag are left to be checked individually")
+
+	uniqueItems, err = collectItemUniquePermissions(context.Background(), renderEndpoint, renderPages(map[string]string{renderEndpoint: `{"Row":[]}`}, &requested))
+	require.NoError(t, err)
+	assert.Empty(t, uniqueItems, "an empty list has nothing to check")
+}
+
+func TestCollectItemUniquePermissions_Failures(t *testing.T) {
+	var requested []string
+	_, err := collectItemUniquePermissions(context.Background(), renderEndpoint,
+		renderPages(map[string]string{renderEndpoint: `{"Row":[{"ID":"1"},{"ID":"2"}]}`}, &requested))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "none of 2 rows carried HasUniqueRoleAssignments")
+
+	_, err = collectItemUniquePermissions(context.Background(), renderEndpoint,
+		renderPages(map[string]string{renderEndpoint: `{"Row":[{"ID":"1","HasUniqueRoleAssignments":"1"}],"NextHref":"?p_ID=1"}`}, &requested))
+	assert.ErrorContains(t, err, "unexpected page", "a page that cannot be read fails the walk")
+
+	_, err = collectItemUniquePermissions(context.Background(), renderEndpoint,
+		renderPages(map[string]string{renderEndpoint: `<html>`}, &requested))
+	assert.ErrorContains(t, err, "decode list data")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = collectItemUniquePermissions(ctx, renderEndpoint, renderPages(nil, &requested))
+	assert.ErrorIs(t, err, context.Canceled)
+}
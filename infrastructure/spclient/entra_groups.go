@@ -0,0 +1,70 @@
+package spclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"spaudit/domain/sharepoint"
+
+	"github.com/koltyakov/gosip/api"
+	"github.com/koltyakov/gosip/auth/azurecert"
+)
+
+// entraUserSelect is the user properties read for Entra group members
+const entraUserSelect = "id,displayName,userPrincipalName,mail,userType"
+
+// SetDirectoryCredentials has directory reads sign in to Microsoft Graph as another app registration
+// than the SharePoint client, one holding GroupMember.Read.All. Unset, they sign in as the same app.
+func (c *SharePointClientImpl) SetDirectoryCredentials(tenantID, clientID, certPath, certPass string) {
+	c.directoryAuth = &azurecert.AuthCnfg{
+		TenantID: tenantID,
+		ClientID: clientID,
+		CertPath: certPath,
+		CertPass: certPass,
+	}
+}
+
+// GetEntraGroupMembers retrieves the users an Entra group reaches: its transitive members, nested
+// groups expanded, or for the owners of a Microsoft 365 group its owners. Devices, service principals
+// and contacts reach no site content as users do, so they are left out. The app registration needs
+// GroupMember.Read.All on Microsoft Graph.
+func (c *SharePointClientImpl) GetEntraGroupMembers(ctx context.Context, group sharepoint.EntraGroupRef) ([]*sharepoint.EntraGroupMember, error) {
+	graph, err := c.directoryHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	relation := "transitiveMembers"
+	if group.Owners {
+		relation = "owners"
+	}
+	var members []*sharepoint.EntraGroupMember
+	for next := fmt.Sprintf("%s/groups/%s/%s/microsoft.graph.user?$select=%s&$top=999", GraphBaseURL, url.PathEscape(group.ObjectID), relation, entraUserSelect); next != ""; {
+		var page GraphGroupUsersApiResponse
+		if err := GraphGet(ctx, graph, next, &page); err != nil {
+			return nil, fmt.Errorf("get group %s %s: %w", group.ObjectID, relation, err)
+		}
+		for _, user := range page.Value {
+			members = append(members, &sharepoint.EntraGroupMember{
+				ObjectID:          user.ID,
+				DisplayName:       user.DisplayName,
+				UserPrincipalName: user.UserPrincipalName,
+				Mail:              user.Mail,
+				UserType:          user.UserType,
+			})
+		}
+		next = page.NextLink
+	}
+	return members, nil
+}
+
+// directoryHTTPClient creates an HTTP client signing in to Microsoft Graph as the directory app
+// registration, or as the SharePoint client's app when none is set
+func (c *SharePointClientImpl) directoryHTTPClient() (*api.HTTPClient, error) {
+	if c.directoryAuth == nil {
+		return c.GraphHTTPClient()
+	}
+	auth := c.directoryAuth
+	return c.graphHTTPClientFor(auth.TenantID, auth.ClientID, auth.CertPath, auth.CertPass), nil
+}
@@ -0,0 +1,159 @@
+package spclient
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/koltyakov/gosip"
+	"github.com/koltyakov/gosip/api"
+	"github.com/koltyakov/gosip/auth/anon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/logging"
+)
+
+// Golden fixture harness for response parsing. Each testdata/<kind>/<name>.json holds a raw API
+// payload as SharePoint returns it; <name>.golden.json holds what the client makes of it, schema
+// drift included. Add a payload seen in production as a new fixture, run
+//
+//	go test ./infrastructure/spclient -run Fixtures -update
+//
+// and review the generated golden file before committing it.
+var updateGolden = flag.Bool("update", false, "rewrite golden files from the current parsing results")
+
+const fixtureWebURL = "https://contoso.sharepoint.com/sites/finance"
+
+// fixtureUniqueItems are the item IDs the fake SharePoint reports unique permissions for.
+var fixtureUniqueItems = map[int]bool{12: true, 27: true}
+
+var itemIDPattern = regexp.MustCompile(`(?i)/items\((\d+)\)/`)
+
+// newFixtureClient creates a client against a fake SharePoint answering the per-item unique
+// permission checks ConvertItemResponse makes.
+func newFixtureClient(t *testing.T) *SharePointClientImpl {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.EqualFold(r.URL.Path, "/_api/ContextInfo"):
+			_, _ = w.Write([]byte(`{"d":{"GetContextWebInformation":{"FormDigestTimeoutSeconds":1800,"FormDigestValue":"fixture-digest"}}}`))
+		case strings.HasSuffix(r.URL.Path, "/HasUniqueRoleAssignments"):
+			match := itemIDPattern.FindStringSubmatch(r.URL.Path)
+			if match == nil {
+				http.NotFound(w, r)
+				return
+			}
+			id, _ := strconv.Atoi(match[1])
+			_, _ = w.Write([]byte(`{"d":{"HasUniqueRoleAssignments":` + strconv.FormatBool(fixtureUniqueItems[id]) + `}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	authClient := &gosip.SPClient{AuthCnfg: &anon.AuthCnfg{SiteURL: server.URL}}
+	client := NewSharePointClient(api.NewSP(authClient), authClient, nil, logging.Default()).(*SharePointClientImpl)
+	client.cachedWebURL = fixtureWebURL
+	return client
+}
+
+// fixtureNames returns the fixture names of a testdata directory, without extension.
+func fixtureNames(t *testing.T, kind string) []string {
+	t.Helper()
+	paths, err := filepath.Glob(filepath.Join("testdata", kind, "*.json"))
+	require.NoError(t, err)
+
+	var names []string
+	for _, path := range paths {
+		if !strings.HasSuffix(path, ".golden.json") {
+			names = append(names, strings.TrimSuffix(filepath.Base(path), ".json"))
+		}
+	}
+	require.NotEmpty(t, names, "no %s fixtures", kind)
+	return names
+}
+
+// assertGolden compares result with the golden file of a fixture, or rewrites it with -update.
+func assertGolden(t *testing.T, kind, name string, result any) {
+	t.Helper()
+	actual, err := json.MarshalIndent(result, "", "  ")
+	require.NoError(t, err)
+	actual = append(actual, '\n')
+
+	path := filepath.Join("testdata", kind, name+".golden.json")
+	if *updateGolden {
+		require.NoError(t, os.WriteFile(path, actual, 0o644))
+		return
+	}
+	expected, err := os.ReadFile(path)
+	require.NoError(t, err, "missing golden file, run with -update to create it")
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+type itemFixtureResult struct {
+	Item             *sharepoint.Item                 `json:"item"`
+	SensitivityLabel *sharepoint.ItemSensitivityLabel `json:"sensitivity_label"`
+	SchemaDrift      []audit.SchemaDrift              `json:"schema_drift"`
+}
+
+func TestItemFixtures(t *testing.T) {
+	for _, name := range fixtureNames(t, "items") {
+		t.Run(name, func(t *testing.T) {
+			payload, err := os.ReadFile(filepath.Join("testdata", "items", name+".json"))
+			require.NoError(t, err)
+			ctx := context.Background()
+
+			client := newFixtureClient(t)
+			item, label, err := client.ConvertItemWithSensitivityLabel(ctx, api.ItemResp(payload), "list-1", 1, fixtureUniqueItems)
+			require.NoError(t, err)
+			if label != nil {
+				label.DiscoveredAt = time.Time{}
+			}
+			assertGolden(t, "items", name, itemFixtureResult{Item: item, SensitivityLabel: label, SchemaDrift: client.SchemaDrift()})
+
+			// Without prefetched unique permissions, and without label extraction, the item is the same
+			plain, err := newFixtureClient(t).ConvertItemResponse(ctx, api.ItemResp(payload), "list-1")
+			require.NoError(t, err)
+			labelled := *item
+			labelled.UnreadableFields = nil
+			assert.Equal(t, &labelled, plain)
+		})
+	}
+}
+
+type sharingFixtureResult struct {
+	SharingInfo *sharepoint.SharingInfo `json:"sharing_info"`
+	SchemaDrift []audit.SchemaDrift     `json:"schema_drift"`
+}
+
+func TestSharingFixtures(t *testing.T) {
+	for _, name := range fixtureNames(t, "sharing") {
+		t.Run(name, func(t *testing.T) {
+			payload, err := os.ReadFile(filepath.Join("testdata", "sharing", name+".json"))
+			require.NoError(t, err)
+
+			client := newFixtureClient(t)
+			response, err := DecodeSharingApiResponse(payload)
+			require.NoError(t, err)
+			client.schemaDrift.checkSharingInfo(payload)
+
+			assertGolden(t, "sharing", name, sharingFixtureResult{
+				SharingInfo: client.mapSharingApiResponseToSharingInfo(response),
+				SchemaDrift: client.SchemaDrift(),
+			})
+		})
+	}
+}
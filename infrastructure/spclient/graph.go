@@ -0,0 +1,203 @@
+package spclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"spaudit/domain/sharepoint"
+
+	"github.com/koltyakov/gosip"
+	"github.com/koltyakov/gosip/api"
+	"github.com/koltyakov/gosip/auth/azurecert"
+)
+
+// GraphBaseURL is the Microsoft Graph v1.0 endpoint
+const GraphBaseURL = "https://graph.microsoft.com/v1.0"
+
+// siteRoleResources are the APIs whose application roles can reach every site: Microsoft Graph and
+// SharePoint, by their well-known app IDs
+var siteRoleResources = []struct {
+	appID string
+	name  string
+}{
+	{appID: "00000003-0000-0000-c000-000000000000", name: "Microsoft Graph"},
+	{appID: "00000003-0000-0ff1-ce00-000000000000", name: "SharePoint"},
+}
+
+// GetGraphAppGrants retrieves the Entra apps with access to the site through Microsoft Graph: the site
+// permissions given to apps holding Sites.Selected, and the application roles of Microsoft Graph and
+// SharePoint that reach every site. The app registration needs Sites.FullControl.All and
+// Application.Read.All on Microsoft Graph. Either part failing fails the whole, as a partial list
+// would pass for a complete one.
+func (c *SharePointClientImpl) GetGraphAppGrants(ctx context.Context) ([]*sharepoint.GraphAppGrant, error) {
+	graph, err := c.GraphHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	siteGrants, err := c.getSitePermissionGrants(ctx, graph)
+	if err != nil {
+		return nil, err
+	}
+	tenantGrants, err := c.getTenantWideGrants(ctx, graph)
+	if err != nil {
+		return nil, err
+	}
+	return append(siteGrants, tenantGrants...), nil
+}
+
+// GraphHTTPClient creates an HTTP client signing in to Microsoft Graph as the app the SharePoint client
+// signs in as. The token's audience follows the configured site's host, so only the host changes.
+func (c *SharePointClientImpl) GraphHTTPClient() (*api.HTTPClient, error) {
+	if c.authClient == nil {
+		return nil, fmt.Errorf("no auth client available for Microsoft Graph")
+	}
+	cnfg, ok := c.authClient.AuthCnfg.(*azurecert.AuthCnfg)
+	if !ok {
+		return nil, fmt.Errorf("Microsoft Graph needs certificate authentication, not %s", c.authClient.AuthCnfg.GetStrategy())
+	}
+	return c.graphHTTPClientFor(cnfg.TenantID, cnfg.ClientID, cnfg.CertPath, cnfg.CertPass), nil
+}
+
+// graphHTTPClientFor creates an HTTP client signing in to Microsoft Graph as an app registration, its
+// requests paced and retried like the SharePoint client's
+func (c *SharePointClientImpl) graphHTTPClientFor(tenantID, clientID, certPath, certPass string) *api.HTTPClient {
+	graphAuth := &azurecert.AuthCnfg{
+		SiteURL:  "https://graph.microsoft.com",
+		TenantID: tenantID,
+		ClientID: clientID,
+		CertPath: certPath,
+		CertPass: certPass,
+	}
+	graph := &gosip.SPClient{AuthCnfg: graphAuth}
+	throttle := installThrottling(graph, siteHost(graphAuth.SiteURL), c.parameters, c.logger)
+	if c.throttle != nil {
+		throttle.setObserver(c.throttle.currentObserver())
+	}
+	return api.NewHTTPClient(graph)
+}
+
+// getSitePermissionGrants retrieves the site permissions given to apps holding Sites.Selected
+func (c *SharePointClientImpl) getSitePermissionGrants(ctx context.Context, graph *api.HTTPClient) ([]*sharepoint.GraphAppGrant, error) {
+	siteID, err := GraphSiteID(ctx, graph, c.authClient.AuthCnfg.GetSiteURL())
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []*sharepoint.GraphAppGrant
+	for next := fmt.Sprintf("%s/sites/%s/permissions", GraphBaseURL, siteID); next != ""; {
+		var page GraphSitePermissionsApiResponse
+		if err := GraphGet(ctx, graph, next, &page); err != nil {
+			return nil, fmt.Errorf("get site permissions: %w", err)
+		}
+		for _, permission := range page.Value {
+			identities := permission.GrantedToIdentitiesV2
+			if len(identities) == 0 {
+				identities = permission.GrantedToIdentities
+			}
+			for _, identity := range identities {
+				if identity.Application == nil {
+					continue
+				}
+				grants = append(grants, &sharepoint.GraphAppGrant{
+					GrantID: permission.ID,
+					Kind:    sharepoint.GraphGrantSiteSelected,
+					AppID:   identity.Application.ID,
+					AppName: identity.Application.DisplayName,
+					Roles:   permission.Roles,
+				})
+			}
+		}
+		next = page.NextLink
+	}
+	return grants, nil
+}
+
+// getTenantWideGrants retrieves the application roles reaching every site that are granted to apps
+func (c *SharePointClientImpl) getTenantWideGrants(ctx context.Context, graph *api.HTTPClient) ([]*sharepoint.GraphAppGrant, error) {
+	var grants []*sharepoint.GraphAppGrant
+	appIDs := make(map[string]string) // Service principal object ID -> client ID
+
+	for _, resource := range siteRoleResources {
+		var principal GraphServicePrincipalApiData
+		endpoint := fmt.Sprintf("%s/servicePrincipals(appId='%s')?$select=id,appRoles", GraphBaseURL, resource.appID)
+		if err := GraphGet(ctx, graph, endpoint, &principal); err != nil {
+			return nil, fmt.Errorf("get %s service principal: %w", resource.name, err)
+		}
+		siteRoles := make(map[string]string)
+		for _, role := range principal.AppRoles {
+			if sharepoint.IsTenantWideSiteRole(role.Value) {
+				siteRoles[role.ID] = role.Value
+			}
+		}
+
+		for next := fmt.Sprintf("%s/servicePrincipals/%s/appRoleAssignedTo?$top=999", GraphBaseURL, principal.ID); next != ""; {
+			var page GraphAppRoleAssignmentsApiResponse
+			if err := GraphGet(ctx, graph, next, &page); err != nil {
+				return nil, fmt.Errorf("get %s role assignments: %w", resource.name, err)
+			}
+			for _, assignment := range page.Value {
+				role, ok := siteRoles[assignment.AppRoleID]
+				if !ok || assignment.PrincipalType != "ServicePrincipal" {
+					continue
+				}
+				appID, ok := appIDs[assignment.PrincipalID]
+				if !ok {
+					var app GraphServicePrincipalApiData
+					endpoint := fmt.Sprintf("%s/servicePrincipals/%s?$select=appId", GraphBaseURL, assignment.PrincipalID)
+					if err := GraphGet(ctx, graph, endpoint, &app); err != nil {
+						return nil, fmt.Errorf("get service principal %s: %w", assignment.PrincipalID, err)
+					}
+					appID = app.AppID
+					appIDs[assignment.PrincipalID] = appID
+				}
+				grants = append(grants, &sharepoint.GraphAppGrant{
+					GrantID:  assignment.ID,
+					Kind:     sharepoint.GraphGrantTenantWide,
+					AppID:    appID,
+					AppName:  assignment.PrincipalDisplayName,
+					Roles:    []string{role},
+					Resource: resource.name,
+				})
+			}
+			next = page.NextLink
+		}
+	}
+	return grants, nil
+}
+
+// GraphSiteID looks up the Microsoft Graph ID of a site by its URL
+func GraphSiteID(ctx context.Context, graph *api.HTTPClient, siteURL string) (string, error) {
+	parsed, err := url.Parse(siteURL)
+	if err != nil {
+		return "", fmt.Errorf("parse site URL: %w", err)
+	}
+	endpoint := fmt.Sprintf("%s/sites/%s", GraphBaseURL, parsed.Host)
+	if path := strings.TrimRight(parsed.Path, "/"); path != "" {
+		endpoint += ":" + path + ":"
+	}
+
+	var site GraphSiteApiData
+	if err := GraphGet(ctx, graph, endpoint+"?$select=id", &site); err != nil {
+		return "", fmt.Errorf("get graph site: %w", err)
+	}
+	return site.ID, nil
+}
+
+// GraphGet reads a Microsoft Graph resource into out
+func GraphGet(ctx context.Context, graph *api.HTTPClient, endpoint string, out any) error {
+	data, err := graph.Get(endpoint, &api.RequestConfig{
+		Context: ctx,
+		Headers: map[string]string{"Accept": "application/json"},
+	})
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("decode %s: %w", endpoint, err)
+	}
+	return nil
+}
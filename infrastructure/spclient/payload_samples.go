@@ -0,0 +1,101 @@
+package spclient
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"spaudit/domain/audit"
+)
+
+// redactedPayloadFields are replaced in sampled payloads, matched case-insensitively at any depth:
+// sharing link URLs and tokens grant access, and the rest identify people. The structure, which is
+// what parsing issues are about, is kept.
+var redactedPayloadFields = map[string]bool{
+	"sharetokenstring":  true,
+	"url":               true,
+	"password":          true,
+	"email":             true,
+	"inviteeemail":      true,
+	"userprincipalname": true,
+	"loginname":         true,
+	"name":              true,
+}
+
+const redactedValue = "[redacted]"
+
+// payloadSampler keeps sanitized raw payloads of a run within its limits. Safe for concurrent use.
+type payloadSampler struct {
+	enabled bool
+	mu      sync.Mutex
+	budget  *audit.PayloadSampleBudget
+	samples []*audit.PayloadSample
+}
+
+func newPayloadSampler(limits audit.PayloadSampleLimits) *payloadSampler {
+	return &payloadSampler{enabled: limits.Enabled, budget: audit.NewPayloadSampleBudget(limits)}
+}
+
+// offer keeps the payload if the run's limits allow it.
+func (s *payloadSampler) offer(source, key string, data []byte, schemaDrift bool) {
+	if !s.enabled {
+		return
+	}
+	payload := sanitizePayload(data)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	keep := s.budget.Admit(source, len(payload), schemaDrift)
+	if keep == 0 {
+		return
+	}
+	s.samples = append(s.samples, &audit.PayloadSample{
+		Source:      source,
+		Key:         key,
+		Payload:     payload[:keep],
+		Truncated:   keep < len(payload),
+		SchemaDrift: schemaDrift,
+		CapturedAt:  time.Now().UTC(),
+	})
+}
+
+// take returns the samples kept so far and forgets them.
+func (s *payloadSampler) take() []*audit.PayloadSample {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	samples := s.samples
+	s.samples = nil
+	return samples
+}
+
+// sanitizePayload returns the payload with redactedPayloadFields replaced, or nothing when it is not JSON.
+func sanitizePayload(data []byte) []byte {
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil
+	}
+	sanitized, err := json.Marshal(redactPayloadValue(value))
+	if err != nil {
+		return nil
+	}
+	return sanitized
+}
+
+func redactPayloadValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if _, isObject := field.(map[string]any); redactedPayloadFields[strings.ToLower(key)] && field != nil && !isObject {
+				v[key] = redactedValue
+			} else {
+				v[key] = redactPayloadValue(field)
+			}
+		}
+	case []any:
+		for i, element := range v {
+			v[i] = redactPayloadValue(element)
+		}
+	}
+	return value
+}
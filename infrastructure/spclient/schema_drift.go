@@ -0,0 +1,214 @@
+package spclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"spaudit/domain/audit"
+)
+
+// Schema drift sources, one per response object that is checked
+const (
+	driftSourceListItem    = "list_item"
+	driftSourceSharingInfo = "sharing_information"
+	driftSourceSharingLink = "sharing_link"
+	driftSourcePrincipal   = "sharing_principal"
+)
+
+// responseShape describes the fields the client expects in one kind of response object.
+type responseShape struct {
+	source        string
+	prefix        string          // Prepended to field names of nested objects, e.g. "File."
+	known         map[string]bool // Lower-cased, json.Unmarshal matches field names case-insensitively
+	required      []string
+	reportUnknown bool // False for models trimmed on purpose, whose unparsed fields are not drift
+}
+
+// newResponseShape builds a shape from the json tags of model, plus fields the client acknowledges
+// without parsing.
+func newResponseShape(source, prefix string, model any, acknowledged, required []string, reportUnknown bool) *responseShape {
+	known := make(map[string]bool)
+	modelType := reflect.TypeOf(model)
+	for i := 0; i < modelType.NumField(); i++ {
+		name, _, _ := strings.Cut(modelType.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			known[strings.ToLower(name)] = true
+		}
+	}
+	for _, name := range acknowledged {
+		known[strings.ToLower(name)] = true
+	}
+	return &responseShape{source: source, prefix: prefix, known: known, required: required, reportUnknown: reportUnknown}
+}
+
+var (
+	listItemShape = newResponseShape(driftSourceListItem, "", ListItemApiResponse{},
+		[]string{"ID"}, []string{"Id", "GUID", "FileSystemObjectType"}, true)
+	listItemFileShape   = newResponseShape(driftSourceListItem, "File.", FileApiData{}, nil, nil, true)
+	listItemFolderShape = newResponseShape(driftSourceListItem, "Folder.", FolderApiData{}, nil, nil, true)
+
+	sharingInfoShape = newResponseShape(driftSourceSharingInfo, "", SharingApiResponse{},
+		nil, []string{"itemUniqueId", "permissionsInformation"}, false)
+	sharingLinkShape = newResponseShape(driftSourceSharingLink, "", LinkApiData{},
+		nil, []string{"linkDetails"}, true)
+	sharingLinkDetailsShape = newResponseShape(driftSourceSharingLink, "linkDetails.", LinkDetailsApiData{},
+		[]string{"ApplicationId", "Description", "Invitations", "RedeemedUsers"}, []string{"LinkKind", "Scope"}, true)
+	principalShape = newResponseShape(driftSourcePrincipal, "", PrincipalApiData{},
+		[]string{"expiration", "isActive", "jobTitle", "userId"}, []string{"id", "loginName"}, true)
+)
+
+type schemaDriftKey struct {
+	source string
+	field  string
+	kind   audit.SchemaDriftKind
+}
+
+// schemaDriftRecorder counts response fields the client models do not map, and mapped fields
+// responses lack. json.Unmarshal ignores both, so without it a field Microsoft renames would only
+// show up as empty columns. Safe for concurrent use.
+type schemaDriftRecorder struct {
+	mu     sync.Mutex
+	counts map[schemaDriftKey]int64
+}
+
+func newSchemaDriftRecorder() *schemaDriftRecorder {
+	return &schemaDriftRecorder{counts: make(map[schemaDriftKey]int64)}
+}
+
+// drift returns the recorded findings ordered by source and field.
+func (r *schemaDriftRecorder) drift() []audit.SchemaDrift {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	drift := make([]audit.SchemaDrift, 0, len(r.counts))
+	for key, occurrences := range r.counts {
+		drift = append(drift, audit.SchemaDrift{Source: key.source, Field: key.field, Kind: key.kind, Occurrences: occurrences})
+	}
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Source != drift[j].Source {
+			return drift[i].Source < drift[j].Source
+		}
+		if drift[i].Field != drift[j].Field {
+			return drift[i].Field < drift[j].Field
+		}
+		return drift[i].Kind < drift[j].Kind
+	})
+	return drift
+}
+
+// check compares one response object against its shape and returns its fields for nested checks,
+// and whether it drifted. Anything that is not a JSON object is ignored.
+func (r *schemaDriftRecorder) check(shape *responseShape, raw json.RawMessage) (map[string]json.RawMessage, bool) {
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &object); err != nil || object == nil {
+		return nil, false
+	}
+
+	present := make(map[string]bool, len(object))
+	drifted := false
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for field := range object {
+		present[strings.ToLower(field)] = true
+		if shape.reportUnknown && !shape.known[strings.ToLower(field)] && !isMetadataField(field) {
+			r.counts[schemaDriftKey{shape.source, shape.prefix + field, audit.SchemaDriftUnknown}]++
+			drifted = true
+		}
+	}
+	for _, field := range shape.required {
+		if !present[strings.ToLower(field)] {
+			r.counts[schemaDriftKey{shape.source, shape.prefix + field, audit.SchemaDriftMissing}]++
+			drifted = true
+		}
+	}
+	return object, drifted
+}
+
+// checkListItem checks a list item response and its File and Folder objects, and reports whether
+// any of them drifted. File.Properties holds arbitrary property bag entries and is not checked.
+func (r *schemaDriftRecorder) checkListItem(data []byte) bool {
+	item, drifted := r.check(listItemShape, data)
+	for field, shape := range map[string]*responseShape{"File": listItemFileShape, "Folder": listItemFolderShape} {
+		if nested, ok := item[field]; ok {
+			_, nestedDrifted := r.check(shape, nested)
+			drifted = drifted || nestedDrifted
+		}
+	}
+	return drifted
+}
+
+// checkSharingInfo checks a GetSharingInformation response, its links and their principals, and
+// reports whether any of them drifted.
+func (r *schemaDriftRecorder) checkSharingInfo(data []byte) bool {
+	var envelope struct {
+		D json.RawMessage `json:"d"`
+	}
+	if err := json.Unmarshal(data, &envelope); err == nil && len(envelope.D) > 0 {
+		data = envelope.D
+	}
+
+	info, drifted := r.check(sharingInfoShape, data)
+	track := func(_ map[string]json.RawMessage, objectDrifted bool) {
+		drifted = drifted || objectDrifted
+	}
+	var permissions struct {
+		Links      json.RawMessage `json:"links"`
+		Principals json.RawMessage `json:"principals"`
+	}
+	if err := json.Unmarshal(info["permissionsInformation"], &permissions); err != nil {
+		return drifted
+	}
+
+	for _, rawLink := range odataArray(permissions.Links) {
+		link, linkDrifted := r.check(sharingLinkShape, rawLink)
+		drifted = drifted || linkDrifted
+		if details, ok := link["linkDetails"]; ok {
+			track(r.check(sharingLinkDetailsShape, details))
+		}
+		for _, member := range odataArray(link["linkMembers"]) {
+			track(r.check(principalShape, member))
+		}
+	}
+	for _, rawEntry := range odataArray(permissions.Principals) {
+		var entry struct {
+			Principal json.RawMessage `json:"principal"`
+		}
+		if err := json.Unmarshal(rawEntry, &entry); err == nil && len(entry.Principal) > 0 {
+			track(r.check(principalShape, entry.Principal))
+		}
+	}
+	return drifted
+}
+
+// odataArray returns the elements of a collection in either the verbose {"results": [...]} form
+// or the plain array form.
+func odataArray(raw json.RawMessage) []json.RawMessage {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil
+	}
+	var elements []json.RawMessage
+	if raw[0] == '[' {
+		if err := json.Unmarshal(raw, &elements); err != nil {
+			return nil
+		}
+		return elements
+	}
+	var results struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal(raw, &results); err != nil {
+		return nil
+	}
+	return results.Results
+}
+
+// isMetadataField reports whether a field is OData metadata rather than data,
+// e.g. __metadata, odata.type or File@odata.navigationLinkUrl.
+func isMetadataField(field string) bool {
+	return strings.HasPrefix(field, "__") || strings.Contains(field, "odata.")
+}
@@ -0,0 +1,237 @@
+package spclient
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"spaudit/domain/audit"
+	"spaudit/logging"
+
+	"github.com/koltyakov/gosip"
+)
+
+// tenantLimiters pace the requests of every client of a host together, since SharePoint throttles
+// a tenant's requests as a whole rather than per audit
+var tenantLimiters = struct {
+	sync.Mutex
+	byHost map[string]*requestLimiter
+}{byHost: make(map[string]*requestLimiter)}
+
+// requestLimiter spaces requests to a host evenly, and holds them all while the host asks for a pause.
+type requestLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // Zero leaves requests unpaced, pauses still apply
+	next     time.Time     // When the next request may be sent
+}
+
+// limiterForHost returns the host's limiter. A requestsPerSecond above 0 sets the rate for every client of
+// the host, so an audit queued with another rate takes effect for the audits already running; 0 leaves
+// the rate as it is, so an audit or Graph client without a setting does not turn pacing off for the others.
+func limiterForHost(host string, requestsPerSecond int) *requestLimiter {
+	tenantLimiters.Lock()
+	defer tenantLimiters.Unlock()
+
+	host = strings.ToLower(host)
+	limiter, ok := tenantLimiters.byHost[host]
+	if !ok {
+		limiter = &requestLimiter{}
+		tenantLimiters.byHost[host] = limiter
+	}
+	if requestsPerSecond > 0 {
+		limiter.setRate(requestsPerSecond)
+	}
+	return limiter
+}
+
+// setRate paces requests at requestsPerSecond
+func (l *requestLimiter) setRate(requestsPerSecond int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.interval = time.Second / time.Duration(requestsPerSecond)
+}
+
+// wait blocks until a request may be sent, or the request is cancelled
+func (l *requestLimiter) wait(req *http.Request) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// pause holds every request to the host for d
+func (l *requestLimiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if until := time.Now().Add(d); until.After(l.next) {
+		l.next = until
+	}
+}
+
+// throttlingTransport paces requests through the host's limiter, and retries the requests SharePoint
+// throttles with 429 or 503: after the Retry-After SharePoint asks for, or with exponential backoff and
+// jitter when it does not say. A Retry-After pauses every client of the host, not only the one throttled.
+type throttlingTransport struct {
+	base       http.RoundTripper
+	limiter    *requestLimiter
+	maxRetries int
+	delay      time.Duration // Backoff before the first retry
+	maxDelay   time.Duration // Backoff cap; a longer Retry-After is still honored
+	logger     *logging.Logger
+
+	mu       sync.RWMutex
+	observer func(audit.ThrottleEvent)
+}
+
+// installThrottling routes the client's requests through a throttling transport with the parameters'
+// retries and pacing, in place of gosip's own retries of throttled requests.
+func installThrottling(client *gosip.SPClient, host string, parameters *audit.AuditParameters, logger *logging.Logger) *throttlingTransport {
+	base := client.Transport
+	if existing, ok := base.(*throttlingTransport); ok {
+		base = existing.base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	delay := time.Duration(parameters.RetryDelay) * time.Millisecond
+	if delay <= 0 {
+		delay = time.Second
+	}
+	transport := &throttlingTransport{
+		base:       base,
+		limiter:    limiterForHost(host, parameters.MaxRequestsPerSecond),
+		maxRetries: parameters.MaxRetries,
+		delay:      delay,
+		maxDelay:   time.Duration(audit.DefaultApiConstraints().MaxRetryDelay) * time.Millisecond,
+		logger:     logger,
+	}
+	client.Transport = transport
+	client.RetryPolicies = map[int]int{http.StatusTooManyRequests: 0, http.StatusServiceUnavailable: 0}
+	return transport
+}
+
+// setObserver has every throttle event reported to observer
+func (t *throttlingTransport) setObserver(observer func(audit.ThrottleEvent)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.observer = observer
+}
+
+// currentObserver returns the observer throttle events are reported to, if any
+func (t *throttlingTransport) currentObserver() func(audit.ThrottleEvent) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.observer
+}
+
+// RoundTrip sends the request, retrying it while SharePoint throttles it and retries are left.
+// Requests whose body cannot be replayed are not retried.
+func (t *throttlingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	attemptReq := req
+	for attempt := 1; ; attempt++ {
+		if err := t.limiter.wait(attemptReq); err != nil {
+			return nil, err
+		}
+		resp, err := t.base.RoundTrip(attemptReq)
+		if err != nil || !isThrottledStatus(resp.StatusCode) {
+			return resp, err
+		}
+
+		replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+		if attempt > t.maxRetries || !replayable {
+			t.notify(audit.ThrottleEvent{StatusCode: resp.StatusCode, Attempt: attempt, GaveUp: true})
+			return resp, nil
+		}
+
+		wait, retryAfter := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		if !retryAfter {
+			wait = audit.ThrottleBackoff(attempt, t.delay, t.maxDelay, rand.Float64())
+		}
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if retryAfter {
+			t.limiter.pause(wait)
+		}
+		t.notify(audit.ThrottleEvent{StatusCode: resp.StatusCode, Attempt: attempt, Wait: wait, RetryAfter: retryAfter})
+		if !retryAfter {
+			timer := time.NewTimer(wait)
+			select {
+			case <-req.Context().Done():
+				timer.Stop()
+				return nil, req.Context().Err()
+			case <-timer.C:
+			}
+		}
+
+		attemptReq = req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq.Body = body
+		}
+	}
+}
+
+// notify logs a throttle event and reports it to the observer
+func (t *throttlingTransport) notify(event audit.ThrottleEvent) {
+	t.logger.Warn("SharePoint throttled a request",
+		"status", event.StatusCode,
+		"attempt", event.Attempt,
+		"wait", event.Wait.String(),
+		"retry_after", event.RetryAfter,
+		"gave_up", event.GaveUp)
+
+	if observer := t.currentObserver(); observer != nil {
+		observer(event)
+	}
+}
+
+// isThrottledStatus reports whether SharePoint throttled a request: 429 Too Many Requests, or
+// 503 Server Too Busy, which SharePoint also answers when a tenant is throttled
+func isThrottledStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode == http.StatusServiceUnavailable
+}
+
+// parseRetryAfter reads a Retry-After header, in seconds or as an HTTP date. It returns false when
+// the header is missing or malformed.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,127 @@
+package spclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/koltyakov/gosip"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/audit"
+	"spaudit/logging"
+)
+
+// newTestThrottlingTransport returns a transport to server's host that records its throttle events
+func newTestThrottlingTransport(maxRetries int) (*throttlingTransport, *[]audit.ThrottleEvent) {
+	events := &[]audit.ThrottleEvent{}
+	transport := &throttlingTransport{
+		base:       http.DefaultTransport,
+		limiter:    &requestLimiter{},
+		maxRetries: maxRetries,
+		delay:      time.Millisecond,
+		maxDelay:   10 * time.Millisecond,
+		logger:     logging.NewLogger(&logging.Config{Level: "error", Format: "text", Output: "stderr"}),
+	}
+	transport.setObserver(func(event audit.ThrottleEvent) { *events = append(*events, event) })
+	return transport, events
+}
+
+func TestThrottlingTransport_RetriesThrottledRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	transport, events := newTestThrottlingTransport(3)
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+	require.Len(t, *events, 2)
+	assert.Equal(t, audit.ThrottleEvent{StatusCode: http.StatusTooManyRequests, Attempt: 1, RetryAfter: true}, (*events)[0])
+	assert.Equal(t, http.StatusServiceUnavailable, (*events)[1].StatusCode)
+	assert.False(t, (*events)[1].RetryAfter, "no Retry-After falls back to backoff")
+	assert.Positive(t, (*events)[1].Wait)
+}
+
+func TestThrottlingTransport_GivesUpWithoutRetriesLeft(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	transport, events := newTestThrottlingTransport(1)
+	resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+	require.Len(t, *events, 2)
+	assert.True(t, (*events)[1].GaveUp)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	wait, ok := parseRetryAfter("120", now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, wait)
+
+	wait, ok = parseRetryAfter(now.Add(30*time.Second).Format(http.TimeFormat), now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, wait)
+
+	for _, value := range []string{"", "-1", "soon"} {
+		_, ok = parseRetryAfter(value, now)
+		assert.False(t, ok, value)
+	}
+}
+
+func TestRequestLimiter_PacesRequests(t *testing.T) {
+	limiter := limiterForHost("pacing.sharepoint.test", 50)
+	req := httptest.NewRequest(http.MethodGet, "https://pacing.sharepoint.test/", nil)
+
+	start := time.Now()
+	for range 3 {
+		require.NoError(t, limiter.wait(req))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 40*time.Millisecond, "the third request waits two intervals")
+	assert.Same(t, limiter, limiterForHost("PACING.sharepoint.test", 50), "clients of a host share its limiter")
+}
+
+func TestInstallThrottling_AuditsOfATenantShareOneLimiter(t *testing.T) {
+	const host = "shared.sharepoint.test"
+	logger := logging.NewLogger(&logging.Config{Level: "error", Format: "text", Output: "stderr"})
+	install := func(requestsPerSecond int) *throttlingTransport {
+		parameters := audit.DefaultParameters()
+		parameters.MaxRequestsPerSecond = requestsPerSecond
+		return installThrottling(&gosip.SPClient{}, host, parameters, logger)
+	}
+
+	first := install(10)
+	second := install(50)
+	assert.Same(t, first.limiter, second.limiter, "audits of a tenant share its limiter")
+	assert.Equal(t, 20*time.Millisecond, first.limiter.interval, "the latest audit's rate applies to both")
+
+	unset := install(0)
+	assert.Same(t, first.limiter, unset.limiter)
+	assert.Equal(t, 20*time.Millisecond, first.limiter.interval, "an audit without a rate keeps the tenant's pacing")
+}
@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/pages"
+)
+
+// AnonymousLinksPage renders the active anonymous sharing links an audit run found across every list
+// of the site, so they can be reviewed without opening each list's Links tab
+// GET /sites/{siteID}/audit-runs/{auditRunID}/anonymous-links
+func (h *ListHandlers) AnonymousLinksPage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	view, ok := h.anonymousLinks(w, r)
+	if !ok {
+		return
+	}
+	RenderResponse(ctx, w, r, pages.AnonymousLinksPage(h.runContext(ctx, view.SiteID, view.AuditRunID), view))
+}
+
+// GetAnonymousLinks returns the active anonymous sharing links an audit run found across every list
+// of the site, with their expiration status, creator and last modification
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/anonymous-links
+func (h *ListHandlers) GetAnonymousLinks(w http.ResponseWriter, r *http.Request) {
+	view, ok := h.anonymousLinks(w, r)
+	if !ok {
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, view); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// anonymousLinks loads the anonymous sharing links of the requested run, writing the problem and
+// returning false when it cannot
+func (h *ListHandlers) anonymousLinks(w http.ResponseWriter, r *http.Request) (presenters.AnonymousLinksView, bool) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return presenters.AnonymousLinksView{}, false
+	}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return presenters.AnonymousLinksView{}, false
+	}
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return presenters.AnonymousLinksView{}, false
+	}
+
+	links, err := scopedServices.SiteContentService.GetAnonymousSharingLinks(ctx, siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return presenters.AnonymousLinksView{}, false
+	}
+	return h.listPresenter.ToAnonymousLinksView(siteID, scopedServices.AuditRunID, links, time.Now()), true
+}
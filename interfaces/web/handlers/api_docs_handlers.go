@@ -0,0 +1,24 @@
+package handlers
+
+import (
+	"net/http"
+
+	"spaudit/interfaces/web/openapi"
+	"spaudit/interfaces/web/templates/pages"
+)
+
+// OpenAPISpecPath is the route serving the OpenAPI document.
+const OpenAPISpecPath = "/api/openapi.json"
+
+// OpenAPISpec serves the OpenAPI 3 specification of the JSON API.
+// GET /api/openapi.json
+func OpenAPISpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(openapi.Spec())
+}
+
+// APIDocs serves Swagger UI for browsing the JSON API.
+// GET /api/docs
+func APIDocs(w http.ResponseWriter, r *http.Request) {
+	RenderResponse(r.Context(), w, r, pages.APIDocs(OpenAPISpecPath))
+}
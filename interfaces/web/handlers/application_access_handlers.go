@@ -0,0 +1,40 @@
+package handlers
+
+import (
+	"net/http"
+)
+
+// GetApplicationAccess returns the apps installed on an audit run's site and the roles app
+// principals hold there, apart from the access of users and groups
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/app-access
+func (h *ListHandlers) GetApplicationAccess(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	access, err := scopedServices.SiteContentService.GetApplicationAccess(ctx, siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToApplicationAccessView(siteID, scopedServices.AuditRunID, access)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
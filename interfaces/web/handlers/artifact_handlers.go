@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+)
+
+// GetRunArtifacts lists the unexpired reports generated from an audit run, newest first
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/artifacts
+func (h *ListHandlers) GetRunArtifacts(w http.ResponseWriter, r *http.Request) {
+	siteID, auditRunID, ok := h.resolveRunRequest(w, r)
+	if !ok {
+		return
+	}
+
+	artifacts, err := h.runArtifactService.ListArtifacts(r.Context(), siteID, auditRunID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToRunArtifactViews(artifacts)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// DownloadRunArtifact downloads a report generated from an audit run until it expires
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/artifacts/{artifactID}
+func (h *ListHandlers) DownloadRunArtifact(w http.ResponseWriter, r *http.Request) {
+	artifactID, err := strconv.ParseInt(chi.URLParam(r, "artifactID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "Invalid artifact ID")
+		return
+	}
+
+	siteID, auditRunID, ok := h.resolveRunRequest(w, r)
+	if !ok {
+		return
+	}
+
+	artifact, content, err := h.runArtifactService.GetArtifact(r.Context(), siteID, auditRunID, artifactID)
+	if err != nil {
+		if errors.Is(err, application.ErrArtifactNotFound) {
+			WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+			return
+		}
+		writeServiceError(w, r, err)
+		return
+	}
+
+	writeDownload(w, artifact.Filename, artifact.ContentType, content)
+}
@@ -0,0 +1,176 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/pages"
+	"spaudit/logging"
+)
+
+// AttestationHandlers requests attestations from site owners and records their responses.
+type AttestationHandlers struct {
+	attestationService *application.AttestationService
+	serviceFactory     application.AuditRunScopedServiceFactory
+	listPresenter      *presenters.ListPresenter
+	logger             *logging.Logger
+}
+
+// NewAttestationHandlers creates a new attestation handlers instance.
+func NewAttestationHandlers(attestationService *application.AttestationService, serviceFactory application.AuditRunScopedServiceFactory, listPresenter *presenters.ListPresenter) *AttestationHandlers {
+	return &AttestationHandlers{
+		attestationService: attestationService,
+		serviceFactory:     serviceFactory,
+		listPresenter:      listPresenter,
+		logger:             logging.Default().WithComponent("attestation_handler"),
+	}
+}
+
+// AttestationsPage lists the attestations requested for an audit run, highlighting the one in created.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/attestations?created=
+func (h *AttestationHandlers) AttestationsPage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, auditRunID, ok := h.resolveRun(w, r)
+	if !ok {
+		return
+	}
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, strconv.FormatInt(auditRunID, 10))
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+	site, err := scopedServices.SiteBrowsingService.GetSiteWithMetadata(ctx, siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	attestations, err := h.attestationService.GetAttestations(ctx, siteID, auditRunID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	createdID, _ := strconv.ParseInt(r.URL.Query().Get("created"), 10, 64)
+	vm := h.listPresenter.ToAttestationsPageVM(siteID, auditRunID, site.Site.URL, attestations, createdID)
+	RenderResponse(ctx, w, r, pages.AttestationsPage(vm))
+}
+
+// CreateAttestation creates a review link for the site owner named in the form, then shows the run's attestations.
+// POST /sites/{siteID}/audit-runs/{auditRunID}/attestations
+func (h *AttestationHandlers) CreateAttestation(w http.ResponseWriter, r *http.Request) {
+	siteID, auditRunID, ok := h.resolveRun(w, r)
+	if !ok {
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid form data")
+		return
+	}
+
+	attestation, err := h.attestationService.CreateAttestation(r.Context(), siteID, auditRunID, r.PostFormValue("owner"))
+	if err != nil {
+		h.writeAttestationError(w, r, err)
+		return
+	}
+
+	location := fmt.Sprintf("/sites/%d/audit-runs/%d/attestations?created=%d", siteID, auditRunID, attestation.ID)
+	http.Redirect(w, r, location, http.StatusSeeOther)
+}
+
+// AttestationResults shows an owner's responses to an attestation.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/attestations/{attestationID}
+func (h *AttestationHandlers) AttestationResults(w http.ResponseWriter, r *http.Request) {
+	siteID, auditRunID, ok := h.resolveRun(w, r)
+	if !ok {
+		return
+	}
+	attestationID, err := strconv.ParseInt(chi.URLParam(r, "attestationID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid attestationID parameter")
+		return
+	}
+
+	review, err := h.attestationService.GetReviewByID(r.Context(), siteID, auditRunID, attestationID)
+	if err != nil {
+		h.writeAttestationError(w, r, err)
+		return
+	}
+	RenderResponse(r.Context(), w, r, pages.AttestationReviewPage(h.listPresenter.ToAttestationReviewVM(review, "", false)))
+}
+
+// ReviewPage renders the review behind a review link for the site owner to respond to.
+// GET /attest/{token}?saved=1
+func (h *AttestationHandlers) ReviewPage(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	review, err := h.attestationService.GetReview(r.Context(), token)
+	if err != nil {
+		h.writeAttestationError(w, r, err)
+		return
+	}
+	vm := h.listPresenter.ToAttestationReviewVM(review, presenters.AttestationReviewPath(token), r.URL.Query().Get("saved") != "")
+	RenderResponse(r.Context(), w, r, pages.AttestationReviewPage(vm))
+}
+
+// SubmitReview records the site owner's decisions, one form field per answered entry, then shows the review again.
+// POST /attest/{token}
+func (h *AttestationHandlers) SubmitReview(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	if err := r.ParseForm(); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid form data")
+		return
+	}
+
+	decisions := map[string]string{}
+	for field, values := range r.PostForm {
+		if key, ok := strings.CutPrefix(field, presenters.AttestationFieldPrefix); ok && len(values) > 0 {
+			decisions[key] = values[0]
+		}
+	}
+	if _, err := h.attestationService.RecordResponses(r.Context(), token, decisions); err != nil {
+		h.writeAttestationError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, presenters.AttestationReviewPath(token)+"?saved=1", http.StatusSeeOther)
+}
+
+// resolveRun resolves the site and audit run of the request, writing the problem when it cannot.
+func (h *AttestationHandlers) resolveRun(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return 0, 0, false
+	}
+	auditRunIDStr := chi.URLParam(r, "auditRunID")
+	if _, err := strconv.ParseInt(auditRunIDStr, 10, 64); err != nil && !audit.IsRunAlias(auditRunIDStr) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "auditRunID must be an audit run ID or run alias")
+		return 0, 0, false
+	}
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(r.Context(), siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return 0, 0, false
+	}
+	return siteID, scopedServices.AuditRunID, true
+}
+
+// writeAttestationError writes an attestation failure as a problem response.
+func (h *AttestationHandlers) writeAttestationError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, application.ErrInvalidAttestation) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	if !isNotFoundError(err) {
+		h.logger.Error("Attestation request failed", "path", r.URL.Path, "error", err)
+	}
+	writeServiceError(w, r, err)
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/pages"
+	"spaudit/logging"
+)
+
+// AuditDiffHandlers compares two audit runs of a site.
+type AuditDiffHandlers struct {
+	auditDiffService    *application.AuditDiffService
+	siteBrowsingService *application.SiteBrowsingService
+	listPresenter       *presenters.ListPresenter
+	logger              *logging.Logger
+}
+
+// NewAuditDiffHandlers creates a new audit diff handlers instance.
+func NewAuditDiffHandlers(auditDiffService *application.AuditDiffService, siteBrowsingService *application.SiteBrowsingService, listPresenter *presenters.ListPresenter) *AuditDiffHandlers {
+	return &AuditDiffHandlers{
+		auditDiffService:    auditDiffService,
+		siteBrowsingService: siteBrowsingService,
+		listPresenter:       listPresenter,
+		logger:              logging.Default().WithComponent("audit_diff_handler"),
+	}
+}
+
+// CompareAuditRuns reports the permissions, sharing links, unique items and principals that changed
+// between a base audit run and a later run of the site
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/compare/{compareRunID}
+func (h *AuditDiffHandlers) CompareAuditRuns(w http.ResponseWriter, r *http.Request) {
+	diff, ok := h.compare(w, r)
+	if !ok {
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToAuditRunDiffView(diff)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// ComparePage renders the comparison of a base audit run with a later run of the site
+// GET /sites/{siteID}/audit-runs/{auditRunID}/compare/{compareRunID}
+func (h *AuditDiffHandlers) ComparePage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	diff, ok := h.compare(w, r)
+	if !ok {
+		return
+	}
+	site, err := h.siteBrowsingService.GetSiteWithMetadata(ctx, diff.SiteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	RenderResponse(ctx, w, r, pages.AuditRunComparePage(h.listPresenter.ToAuditRunComparePageVM(diff, site.Site.URL)))
+}
+
+// compare parses the site and both runs from the path and compares them, writing a problem on failure.
+func (h *AuditDiffHandlers) compare(w http.ResponseWriter, r *http.Request) (*application.AuditRunDiff, bool) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return nil, false
+	}
+	baseAuditRunID, ok := auditRunParam(w, r, "auditRunID")
+	if !ok {
+		return nil, false
+	}
+	auditRunID, ok := auditRunParam(w, r, "compareRunID")
+	if !ok {
+		return nil, false
+	}
+
+	diff, err := h.auditDiffService.CompareRuns(r.Context(), siteID, baseAuditRunID, auditRunID)
+	if err != nil {
+		if !isNotFoundError(err) {
+			h.logger.Error("Audit run comparison failed", "site_id", siteID, "base_audit_run", baseAuditRunID, "audit_run", auditRunID, "error", err)
+		}
+		writeServiceError(w, r, err)
+		return nil, false
+	}
+	return diff, true
+}
+
+// auditRunParam returns the audit run ID or alias in the named path parameter, writing a problem when invalid.
+func auditRunParam(w http.ResponseWriter, r *http.Request, name string) (string, bool) {
+	value := chi.URLParam(r, name)
+	if _, err := strconv.ParseInt(value, 10, 64); err != nil && !audit.IsRunAlias(value) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, name+" must be an audit run ID or run alias")
+		return "", false
+	}
+	return value, true
+}
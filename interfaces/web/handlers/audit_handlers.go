@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"spaudit/application"
+	"spaudit/domain/audit"
 	"spaudit/interfaces/web/presenters"
 	"spaudit/logging"
 )
@@ -38,7 +40,7 @@ func NewAuditHandlers(
 func (h *AuditHandlers) GetAuditStatus(w http.ResponseWriter, r *http.Request) {
 	siteURL := r.URL.Query().Get("site_url")
 	if siteURL == "" {
-		http.Error(w, "missing site_url parameter", http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "missing site_url parameter")
 		return
 	}
 
@@ -56,7 +58,7 @@ func (h *AuditHandlers) GetAuditStatus(w http.ResponseWriter, r *http.Request) {
 
 	if err := json.NewEncoder(w).Encode(auditView); err != nil {
 		h.logger.Error("Failed to encode audit status response", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
 	}
 }
 
@@ -72,7 +74,7 @@ func (h *AuditHandlers) ListActiveAudits(w http.ResponseWriter, r *http.Request)
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(activeAuditsView); err != nil {
 		h.logger.Error("Failed to encode active audits response", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
 	}
 }
 
@@ -108,7 +110,7 @@ func (h *AuditHandlers) RunAudit(w http.ResponseWriter, r *http.Request) {
 
 		// Return formatted HTML error message for HTMX (using 200 OK so HTMX always swaps)
 		var errorResponse string
-		if strings.Contains(err.Error(), "already running") || strings.Contains(err.Error(), "already queued") {
+		if errors.Is(err, application.ErrAuditAlreadyQueued) {
 			errorResponse = h.auditPresenter.FormatAuditConflictResponse(err)
 		} else {
 			errorResponse = h.auditPresenter.FormatAuditErrorResponse(err)
@@ -132,3 +134,122 @@ func (h *AuditHandlers) RunAudit(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	w.Write([]byte(response))
 }
+
+// QueueAuditRequest is the JSON body accepted by QueueAudit.
+// Omitted options keep the default audit parameters.
+type QueueAuditRequest struct {
+	SiteURL              string `json:"site_url"`
+	ScanIndividualItems  *bool  `json:"scan_individual_items,omitempty"`
+	SkipHidden           *bool  `json:"skip_hidden,omitempty"`
+	IncludeSharing       *bool  `json:"include_sharing,omitempty"`
+	CollectAnalytics     *bool  `json:"collect_analytics,omitempty"`
+	SampleLargeLists     *bool  `json:"sample_large_lists,omitempty"`
+	SampleThreshold      int    `json:"sample_threshold,omitempty"`
+	ScanRecycleBin       *bool  `json:"scan_recycle_bin,omitempty"`
+	AuditGraphGrants     *bool  `json:"audit_graph_grants,omitempty"`
+	ExpandEntraGroups    *bool  `json:"expand_entra_groups,omitempty"`
+	FolderPath           string `json:"folder_path,omitempty"`
+	Label                string `json:"label,omitempty"`
+	BatchSize            int    `json:"batch_size,omitempty"`
+	MaxRequestsPerSecond int    `json:"max_requests_per_second,omitempty"`
+	CollectorBackend     string `json:"collector_backend,omitempty"`
+	Timeout              int    `json:"timeout,omitempty"`
+}
+
+// parameters applies the requested options over the default audit parameters.
+func (req *QueueAuditRequest) parameters() *audit.AuditParameters {
+	parameters := audit.DefaultParameters()
+	if req.ScanIndividualItems != nil {
+		parameters.ScanIndividualItems = *req.ScanIndividualItems
+	}
+	if req.SkipHidden != nil {
+		parameters.SkipHidden = *req.SkipHidden
+	}
+	if req.IncludeSharing != nil {
+		parameters.IncludeSharing = *req.IncludeSharing
+	}
+	if req.CollectAnalytics != nil {
+		parameters.CollectAnalytics = *req.CollectAnalytics
+	}
+	if req.SampleLargeLists != nil {
+		parameters.SampleLargeLists = *req.SampleLargeLists
+	}
+	if req.SampleThreshold > 0 {
+		parameters.SampleThreshold = req.SampleThreshold
+	}
+	if req.ScanRecycleBin != nil {
+		parameters.ScanRecycleBin = *req.ScanRecycleBin
+	}
+	if req.AuditGraphGrants != nil {
+		parameters.AuditGraphGrants = *req.AuditGraphGrants
+	}
+	if req.ExpandEntraGroups != nil {
+		parameters.ExpandEntraGroups = *req.ExpandEntraGroups
+	}
+	parameters.FolderPath = strings.TrimSpace(req.FolderPath)
+	parameters.Label = audit.NormalizeRunLabel(req.Label)
+	if req.BatchSize > 0 {
+		parameters.BatchSize = req.BatchSize
+	}
+	if req.MaxRequestsPerSecond > 0 {
+		parameters.MaxRequestsPerSecond = req.MaxRequestsPerSecond
+	}
+	if req.CollectorBackend != "" {
+		parameters.CollectorBackend = audit.NormalizeCollectorBackend(req.CollectorBackend)
+	}
+	if req.Timeout > 0 {
+		parameters.Timeout = req.Timeout
+	}
+	return parameters
+}
+
+// QueueAudit queues a new audit from a JSON request.
+// JSON counterpart of RunAudit for API clients; responds 202 with the job to follow.
+// POST /api/audits
+func (h *AuditHandlers) QueueAudit(w http.ResponseWriter, r *http.Request) {
+	var req QueueAuditRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	siteURL := strings.TrimSpace(req.SiteURL)
+	if siteURL == "" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "site_url is required")
+		return
+	}
+	if req.SampleThreshold != 0 && req.SampleThreshold < audit.MinSampleThreshold {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("sample_threshold must be at least %d", audit.MinSampleThreshold))
+		return
+	}
+
+	request, err := h.auditService.QueueAudit(r.Context(), siteURL, req.parameters())
+	if err != nil {
+		h.logger.Error("Failed to queue audit", "site_url", siteURL, "error", err)
+		if errors.Is(err, application.ErrAuditAlreadyQueued) || errors.Is(err, application.ErrMaintenanceRunning) {
+			WriteProblem(w, r, http.StatusConflict, ErrCodeAuditConflict, err.Error())
+			return
+		}
+		if errors.Is(err, application.ErrInvalidAuditScope) || errors.Is(err, application.ErrInvalidRunLabel) || errors.Is(err, application.ErrInvalidCollectorBackend) {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+			return
+		}
+		if errors.Is(err, application.ErrStorageQuotaExceeded) {
+			WriteProblem(w, r, http.StatusInsufficientStorage, ErrCodeStorageQuotaExceeded, err.Error())
+			return
+		}
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+		return
+	}
+
+	h.logger.Info("Audit queued successfully",
+		"request_id", request.ID,
+		"site_url", siteURL)
+
+	// Broadcast job list update to all SSE clients
+	h.sseManager.BroadcastJobListUpdate()
+
+	if err := WriteJSON(w, http.StatusAccepted, h.auditPresenter.FormatAuditQueued(request)); err != nil {
+		h.logger.Error("Failed to encode queued audit response", "error", err)
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// auditScheduleChecksDefaultLimit is the number of checks listed when no limit is given.
+const auditScheduleChecksDefaultLimit = 50
+
+// AuditScheduleHandlers manages the sites audited on a schedule and checked for drift.
+type AuditScheduleHandlers struct {
+	scheduleService *application.AuditScheduleService
+	listPresenter   *presenters.ListPresenter
+	logger          *logging.Logger
+}
+
+// NewAuditScheduleHandlers creates a new audit schedule handlers instance.
+func NewAuditScheduleHandlers(scheduleService *application.AuditScheduleService, listPresenter *presenters.ListPresenter) *AuditScheduleHandlers {
+	return &AuditScheduleHandlers{
+		scheduleService: scheduleService,
+		listPresenter:   listPresenter,
+		logger:          logging.Default().WithComponent("audit_schedule_handler"),
+	}
+}
+
+// ScheduleAuditRequest is the JSON body accepted by ScheduleAudit.
+type ScheduleAuditRequest struct {
+	SiteURL         string                 `json:"site_url"`
+	IntervalSeconds int                    `json:"interval_seconds,omitempty"` // Zero audits daily
+	CompareWith     string                 `json:"compare_with,omitempty"`     // previous (default) or baseline
+	Thresholds      DriftThresholdsRequest `json:"thresholds"`
+}
+
+// DriftThresholdsRequest is the drift a scheduled run may show before raising an alert. Omitted
+// thresholds are not checked.
+type DriftThresholdsRequest struct {
+	ExternalGrants    *int `json:"external_grants,omitempty"`
+	PermissionChanges *int `json:"permission_changes,omitempty"`
+	SharingLinks      *int `json:"sharing_links,omitempty"`
+	UniqueItems       *int `json:"unique_items,omitempty"`
+	RemovedPrincipals *int `json:"removed_principals,omitempty"`
+}
+
+// GetAuditSchedules lists the audit schedules
+// GET /api/audit-schedules
+func (h *AuditScheduleHandlers) GetAuditSchedules(w http.ResponseWriter, r *http.Request) {
+	schedules, err := h.scheduleService.GetSchedules(r.Context())
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToAuditScheduleViews(schedules)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// ScheduleAudit starts auditing a site on a schedule and responds 201 with the schedule
+// POST /api/audit-schedules
+func (h *AuditScheduleHandlers) ScheduleAudit(w http.ResponseWriter, r *http.Request) {
+	var req ScheduleAuditRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.IntervalSeconds < 0 {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "interval_seconds must not be negative")
+		return
+	}
+
+	thresholds := audit.DriftThresholds{
+		ExternalGrants:    req.Thresholds.ExternalGrants,
+		PermissionChanges: req.Thresholds.PermissionChanges,
+		SharingLinks:      req.Thresholds.SharingLinks,
+		UniqueItems:       req.Thresholds.UniqueItems,
+		RemovedPrincipals: req.Thresholds.RemovedPrincipals,
+	}
+	schedule, err := h.scheduleService.Schedule(r.Context(), req.SiteURL, time.Duration(req.IntervalSeconds)*time.Second, req.CompareWith, thresholds)
+	if err != nil {
+		h.logger.Error("Failed to schedule site audits", "site_url", req.SiteURL, "error", err)
+		writeAuditScheduleError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusCreated, h.listPresenter.ToAuditScheduleView(schedule)); err != nil {
+		h.logger.Error("Failed to encode audit schedule response", "error", err)
+	}
+}
+
+// UnscheduleAudit stops auditing a site on a schedule
+// DELETE /api/audit-schedules/{scheduleID}
+func (h *AuditScheduleHandlers) UnscheduleAudit(w http.ResponseWriter, r *http.Request) {
+	scheduleID, err := strconv.ParseInt(chi.URLParam(r, "scheduleID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid scheduleID parameter")
+		return
+	}
+	if err := h.scheduleService.Unschedule(r.Context(), scheduleID); err != nil {
+		writeAuditScheduleError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetAuditScheduleChecks lists the drift of a schedule's most recent runs, newest first
+// GET /api/audit-schedules/{scheduleID}/checks?limit={n}
+func (h *AuditScheduleHandlers) GetAuditScheduleChecks(w http.ResponseWriter, r *http.Request) {
+	scheduleID, err := strconv.ParseInt(chi.URLParam(r, "scheduleID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid scheduleID parameter")
+		return
+	}
+	limit := int64(auditScheduleChecksDefaultLimit)
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 1 || parsed > apiMaxPageSize {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "limit must be between 1 and "+strconv.Itoa(apiMaxPageSize))
+			return
+		}
+		limit = parsed
+	}
+
+	checks, err := h.scheduleService.GetChecks(r.Context(), scheduleID, limit)
+	if err != nil {
+		writeAuditScheduleError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToAuditScheduleCheckViews(checks)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// writeAuditScheduleError writes a failure to manage an audit schedule as a problem response.
+func writeAuditScheduleError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, application.ErrInvalidAuditSchedule):
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+	case errors.Is(err, application.ErrAuditScheduleNotFound):
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	case errors.Is(err, application.ErrSiteAlreadyScheduled):
+		WriteProblem(w, r, http.StatusConflict, ErrCodeScheduleConflict, err.Error())
+	default:
+		writeServiceError(w, r, err)
+	}
+}
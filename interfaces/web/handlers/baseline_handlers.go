@@ -0,0 +1,186 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/templates/components/site"
+)
+
+// Length caps of the approver and note recorded with a baseline approval.
+const (
+	maxBaselineApproverLength = 200
+	maxBaselineNoteLength     = 500
+)
+
+// ApproveBaselineRequest is the optional JSON body of a baseline approval.
+type ApproveBaselineRequest struct {
+	ApprovedBy string `json:"approved_by"`
+	Note       string `json:"note"`
+}
+
+// GetSiteBaseline returns the site's approved baseline with every baseline it has had
+// GET /api/sites/{siteID}/baseline
+func (h *ListHandlers) GetSiteBaseline(w http.ResponseWriter, r *http.Request) {
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	history, err := h.baselineService.GetBaselineHistory(r.Context(), siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToSiteBaselineView(siteID, history)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// ClearSiteBaseline retires the site's baseline without approving another run
+// DELETE /api/sites/{siteID}/baseline
+func (h *ListHandlers) ClearSiteBaseline(w http.ResponseWriter, r *http.Request) {
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	if err := h.baselineService.ClearBaseline(r.Context(), siteID); err != nil {
+		writeBaselineError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ApproveBaseline approves a completed audit run as the site's baseline, resetting the current one
+// PUT /api/sites/{siteID}/audit-runs/{auditRunID}/baseline
+func (h *ListHandlers) ApproveBaseline(w http.ResponseWriter, r *http.Request) {
+	var req ApproveBaselineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	approvedBy, note, ok := validateBaselineApproval(w, r, req.ApprovedBy, req.Note)
+	if !ok {
+		return
+	}
+
+	siteID, auditRunID, ok := h.resolveRunRequest(w, r)
+	if !ok {
+		return
+	}
+
+	baseline, err := h.baselineService.ApproveBaseline(r.Context(), siteID, auditRunID, approvedBy, note)
+	if err != nil {
+		writeBaselineError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToBaselineView(baseline)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// GetRunDrift compares every list of an audit run with the site's approved baseline
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/drift
+func (h *ListHandlers) GetRunDrift(w http.ResponseWriter, r *http.Request) {
+	siteID, auditRunID, ok := h.resolveRunRequest(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.baselineService.ComputeDrift(r.Context(), siteID, auditRunID)
+	if err != nil {
+		writeBaselineError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToDriftReportView(siteID, report)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// ApproveBaselineFromBanner approves the run as baseline from the run banner and refreshes the page.
+// The approver answers the button's prompt.
+// POST /sites/{siteID}/audit-runs/{auditRunID}/baseline
+func (h *ListHandlers) ApproveBaselineFromBanner(w http.ResponseWriter, r *http.Request) {
+	approvedBy, _, ok := validateBaselineApproval(w, r, r.Header.Get("HX-Prompt"), "")
+	if !ok {
+		return
+	}
+
+	siteID, auditRunID, ok := h.resolveRunRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if _, err := h.baselineService.ApproveBaseline(r.Context(), siteID, auditRunID, approvedBy, ""); err != nil {
+		writeBaselineError(w, r, err)
+		return
+	}
+
+	w.Header().Set("HX-Refresh", "true")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DriftPanel renders the run's drift from the approved baseline below the run banner
+// GET /sites/{siteID}/audit-runs/{auditRunID}/drift
+func (h *ListHandlers) DriftPanel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, auditRunID, ok := h.resolveRunRequest(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.baselineService.ComputeDrift(ctx, siteID, auditRunID)
+	if err != nil {
+		writeBaselineError(w, r, err)
+		return
+	}
+
+	// Only completed runs can become the baseline
+	canReset := false
+	if auditRun, err := h.auditService.GetAuditRun(ctx, siteID, auditRunID); err == nil {
+		canReset = auditRun.IsCompleted()
+	}
+
+	RenderResponse(ctx, w, r, site.BaselineDriftPanel(h.listPresenter.ToDriftReportView(siteID, report), canReset))
+}
+
+// validateBaselineApproval trims and length-checks the approver and note, writing a problem when too long.
+func validateBaselineApproval(w http.ResponseWriter, r *http.Request, approvedBy, note string) (string, string, bool) {
+	approvedBy = strings.TrimSpace(approvedBy)
+	note = strings.TrimSpace(note)
+	if len(approvedBy) > maxBaselineApproverLength {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("approved_by must be at most %d characters", maxBaselineApproverLength))
+		return "", "", false
+	}
+	if len(note) > maxBaselineNoteLength {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("note must be at most %d characters", maxBaselineNoteLength))
+		return "", "", false
+	}
+	return approvedBy, note, true
+}
+
+// writeBaselineError writes a baseline failure as a problem response.
+func writeBaselineError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, application.ErrNoBaseline):
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	case errors.Is(err, application.ErrBaselineRunIncomplete):
+		WriteProblem(w, r, http.StatusConflict, ErrCodeBaselineConflict, err.Error())
+	default:
+		writeServiceError(w, r, err)
+	}
+}
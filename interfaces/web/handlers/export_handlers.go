@@ -0,0 +1,319 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+)
+
+// exportItemPageSize is the number of items loaded per query when exporting.
+const exportItemPageSize = 500
+
+// ExportListsCSV exports the lists table with the current search filter and sort as CSV.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/lists/export?search=&sort=
+func (h *ListHandlers) ExportListsCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	// Same filter and sort as the on-screen table
+	lists, err := h.filteredListSummaries(ctx, r, siteID, scopedServices)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	filename := fmt.Sprintf("lists-site%d-run%d.csv", siteID, scopedServices.AuditRunID)
+	h.writeCSVExport(w, r, siteID, scopedServices.AuditRunID, filename, h.listPresenter.ListsToCSV(lists))
+}
+
+// ExportItemsCSV exports every item matching the items tab filters as CSV.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/items/export?scope=&kind=
+func (h *ListHandlers) ExportItemsCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	// Same filters as the on-screen table, across all pages
+	query := h.permissionPresenter.ParseItemsTabQuery(r.URL.Query())
+	var items []presenters.ItemSummary
+	for page := 1; ; page++ {
+		pageData, err := scopedServices.SiteContentService.GetListItemsPage(ctx, siteID, listID, query.Filter(), page, exportItemPageSize)
+		if err != nil {
+			writeServiceError(w, r, err)
+			return
+		}
+		for _, item := range pageData.Items {
+			items = append(items, h.permissionPresenter.MapItemToViewModel(item))
+		}
+		if len(pageData.Items) == 0 || int64(len(items)) >= pageData.TotalCount {
+			break
+		}
+	}
+
+	filename := fmt.Sprintf("items-%s-run%d.csv", listID, scopedServices.AuditRunID)
+	h.writeCSVExport(w, r, siteID, scopedServices.AuditRunID, filename, h.permissionPresenter.ItemsToCSV(items))
+}
+
+// ExportAssignmentsCSV exports the assignments tab table as CSV.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/assignments/export
+func (h *ListHandlers) ExportAssignmentsCSV(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	listData, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	assignmentsData, err := scopedServices.SiteContentService.GetListAssignmentsWithRootCause(ctx, siteID, listID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	collection := h.permissionPresenter.ToExpandableAssignmentCollection(assignmentsData, listID)
+	collection.InheritsFromWeb = !listData.HasUnique
+
+	filename := fmt.Sprintf("assignments-%s-run%d.csv", listID, scopedServices.AuditRunID)
+	h.writeCSVExport(w, r, siteID, scopedServices.AuditRunID, filename, h.permissionPresenter.AssignmentsToCSV(collection))
+}
+
+// ExportListChanges exports the permission changes to a list since a base audit run as a
+// redline, marking each row as added or removed. The base defaults to the site's reference run.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/changes/export?base=&format=csv|xlsx
+func (h *ListHandlers) ExportListChanges(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	baseRunIDStr := r.URL.Query().Get("base")
+	if baseRunIDStr == "" {
+		baseRunIDStr = audit.RunAliasReference
+	}
+	if _, err := strconv.ParseInt(baseRunIDStr, 10, 64); err != nil && !audit.IsRunAlias(baseRunIDStr) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "base must be an audit run ID or run alias")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "xlsx" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "format must be csv or xlsx")
+		return
+	}
+
+	// Create audit-run-scoped services for both ends of the comparison
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+	baseServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, baseRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+	if baseServices.AuditRunID == scopedServices.AuditRunID {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter,
+			fmt.Sprintf("base resolves to audit run %d, the run being exported", scopedServices.AuditRunID))
+		return
+	}
+
+	// Both runs must have captured the list; the comparison itself only loads what changed
+	list, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if _, err := baseServices.SiteContentService.GetListByID(ctx, siteID, listID); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	diff, err := scopedServices.SiteContentService.GetListChanges(ctx, siteID, list, baseServices.AuditRunID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	table := h.listPresenter.ListDiffToRedline(diff)
+	filename := fmt.Sprintf("changes-%s-run%d-to-run%d.%s", listID, baseServices.AuditRunID, scopedServices.AuditRunID, format)
+	if format == "csv" {
+		h.writeCSVExport(w, r, siteID, scopedServices.AuditRunID, filename, table)
+		return
+	}
+
+	h.writeXLSXExport(w, r, siteID, scopedServices.AuditRunID, filename, "Changes", table, redlineRowStyles(table))
+}
+
+// ExportAccessReview exports every principal's explicit access to the site in the audit run as CSV, to seed
+// an access certification campaign. Access through SharePoint groups and sharing links is expanded to members.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/access-review/export?format=entra|attestation
+func (h *ListHandlers) ExportAccessReview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	format, ok := presenters.ParseAccessReviewFormat(r.URL.Query().Get("format"))
+	if !ok {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "format must be entra or attestation")
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	siteData, err := h.siteBrowsingService.GetSiteWithMetadata(ctx, siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	entries, err := scopedServices.SiteContentService.GetAccessReviewEntries(ctx, siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	table := h.listPresenter.AccessReviewToCSV(siteData.Site.URL, scopedServices.AuditRunID, entries, format)
+	filename := fmt.Sprintf("access-review-%s-site%d-run%d.csv", format, siteID, scopedServices.AuditRunID)
+	h.writeCSVExport(w, r, siteID, scopedServices.AuditRunID, filename, table)
+}
+
+// redlineRowStyles formats each row of a redline table as added or removed.
+func redlineRowStyles(table presenters.CSVTable) []xlsxStyle {
+	rowStyles := make([]xlsxStyle, len(table.Rows))
+	for i, row := range table.Rows {
+		rowStyles[i] = xlsxStyleAdded
+		if row[0] == presenters.RedlineRemoved {
+			rowStyles[i] = xlsxStyleRemoved
+		}
+	}
+	return rowStyles
+}
+
+// csvContentType is the content type of CSV exports.
+const csvContentType = "text/csv; charset=utf-8"
+
+// writeCSVExport sends a CSV table exported from an audit run as a file download.
+func (h *ListHandlers) writeCSVExport(w http.ResponseWriter, r *http.Request, siteID, auditRunID int64, filename string, table presenters.CSVTable) {
+	content, err := encodeCSV(table)
+	if err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to build CSV")
+		return
+	}
+	h.writeRunExport(w, r, siteID, auditRunID, filename, csvContentType, content)
+}
+
+// writeRunExport sends an export of an audit run as a file download and attaches it to the run as an artifact.
+// The download is not held back when the artifact cannot be stored.
+func (h *ListHandlers) writeRunExport(w http.ResponseWriter, r *http.Request, siteID, auditRunID int64, filename, contentType string, content []byte) {
+	if _, err := h.runArtifactService.RegisterArtifact(r.Context(), siteID, auditRunID, filename, contentType, content); err != nil {
+		h.logger.Warn("Failed to attach export to audit run", "site_id", siteID, "audit_run_id", auditRunID, "filename", filename, "error", err)
+	}
+	writeDownload(w, filename, contentType, content)
+}
+
+// writeDownload writes content as a file download.
+func writeDownload(w http.ResponseWriter, filename, contentType string, content []byte) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	_, _ = w.Write(content)
+}
+
+// encodeCSV encodes a CSV table, header first.
+func encodeCSV(table presenters.CSVTable) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(table.Header); err != nil {
+		return nil, err
+	}
+	if err := writer.WriteAll(table.Rows); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// findingAlertsDefaultLimit is the number of alerts listed when no limit is given.
+const findingAlertsDefaultLimit = 50
+
+// FindingAlertHandlers reports the alerts raised for the findings of audit runs.
+type FindingAlertHandlers struct {
+	alertService  *application.FindingAlertService
+	listPresenter *presenters.ListPresenter
+	logger        *logging.Logger
+}
+
+// NewFindingAlertHandlers creates a new finding alert handlers instance.
+func NewFindingAlertHandlers(alertService *application.FindingAlertService, listPresenter *presenters.ListPresenter) *FindingAlertHandlers {
+	return &FindingAlertHandlers{
+		alertService:  alertService,
+		listPresenter: listPresenter,
+		logger:        logging.Default().WithComponent("finding_alert_handler"),
+	}
+}
+
+// GetSiteAlerts lists the most recent alerts raised for a site, newest first, including those queued
+// until quiet hours end
+// GET /api/sites/{siteID}/finding-alerts?limit={n}
+func (h *FindingAlertHandlers) GetSiteAlerts(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	limit := int64(findingAlertsDefaultLimit)
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 1 || parsed > apiMaxPageSize {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "limit must be between 1 and "+strconv.Itoa(apiMaxPageSize))
+			return
+		}
+		limit = parsed
+	}
+
+	alerts, err := h.alertService.GetSiteAlerts(r.Context(), siteID, limit)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToFindingAlertViews(alerts)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
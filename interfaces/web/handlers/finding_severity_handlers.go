@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"spaudit/application"
+)
+
+// SetFindingSeverities sets the mapping findings are rated with; without one they rate with the defaults.
+func (h *ListHandlers) SetFindingSeverities(severities *application.FindingSeverities) {
+	h.findingSeverities = severities
+}
+
+// SetPolicyPacks rates each site's findings under the policy pack it is checked with.
+func (h *ListHandlers) SetPolicyPacks(policyPacks *application.PolicyPackService) {
+	h.policyPacks = policyPacks
+}
+
+// runSeverities returns the mapping an audit run's findings are rated with: that of its site's policy
+// pack, or the global one when the pack cannot be resolved
+func (h *ListHandlers) runSeverities(ctx context.Context, siteID, auditRunID int64) *application.FindingSeverities {
+	if h.policyPacks == nil {
+		return h.findingSeverities
+	}
+	severities, err := h.policyPacks.SeveritiesForRun(ctx, siteID, auditRunID)
+	if err != nil {
+		h.logger.Warn("Rating findings without a policy pack", "site_id", siteID, "audit_run_id", auditRunID, "error", err)
+		return h.findingSeverities
+	}
+	return severities
+}
+
+// GetFindingSeverities returns the severity every finding category is rated with, so integrations
+// can apply the same mapping
+// GET /api/finding-severities
+func (h *ListHandlers) GetFindingSeverities(w http.ResponseWriter, r *http.Request) {
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToFindingSeveritiesView(h.findingSeverities.Mapping())); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
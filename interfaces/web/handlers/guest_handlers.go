@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// GuestHandlers reports guests correlated across audited tenants.
+type GuestHandlers struct {
+	guestService  *application.GuestCorrelationService
+	listPresenter *presenters.ListPresenter
+	logger        *logging.Logger
+}
+
+// NewGuestHandlers creates a new guest handlers instance.
+func NewGuestHandlers(guestService *application.GuestCorrelationService, listPresenter *presenters.ListPresenter) *GuestHandlers {
+	return &GuestHandlers{
+		guestService:  guestService,
+		listPresenter: listPresenter,
+		logger:        logging.Default().WithComponent("guest_handler"),
+	}
+}
+
+// GetGuestIdentities correlates guest accounts across the latest runs of every audited site by the
+// email address the guest was invited with, reporting each partner's total access across tenants
+// GET /api/guest-identities?min_tenants=2&domain=northwind.com
+func (h *GuestHandlers) GetGuestIdentities(w http.ResponseWriter, r *http.Request) {
+	filter := application.GuestCorrelationFilter{Domain: r.URL.Query().Get("domain")}
+	if value := r.URL.Query().Get("min_tenants"); value != "" {
+		minTenants, err := strconv.Atoi(value)
+		if err != nil || minTenants < 1 {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "min_tenants must be a positive integer")
+			return
+		}
+		filter.MinTenants = minTenants
+	}
+
+	result, err := h.guestService.Correlate(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("Guest correlation failed", "error", err)
+		writeServiceError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToGuestIdentitiesView(result)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
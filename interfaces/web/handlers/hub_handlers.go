@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// HubHandlers reports the risk of audited sites rolled up by hub.
+type HubHandlers struct {
+	hubService    *application.HubRollupService
+	listPresenter *presenters.ListPresenter
+	logger        *logging.Logger
+}
+
+// NewHubHandlers creates a new hub handlers instance.
+func NewHubHandlers(hubService *application.HubRollupService, listPresenter *presenters.ListPresenter) *HubHandlers {
+	return &HubHandlers{
+		hubService:    hubService,
+		listPresenter: listPresenter,
+		logger:        logging.Default().WithComponent("hub_handler"),
+	}
+}
+
+// GetHubRollups groups the latest run of every audited site by the hub it is associated with, and
+// aggregates the sites' risk per hub for governance reviews organised by hub
+// GET /api/hubs?hub_site_id=5a3c2b1d-...
+func (h *HubHandlers) GetHubRollups(w http.ResponseWriter, r *http.Request) {
+	filter := application.HubRollupFilter{HubSiteID: r.URL.Query().Get("hub_site_id")}
+
+	result, err := h.hubService.Rollup(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("Hub rollup failed", "error", err)
+		writeServiceError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToHubRollupsView(result)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
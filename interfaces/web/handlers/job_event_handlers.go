@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/domain/events"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/components/dashboard"
+	"spaudit/logging"
+)
+
+// jobEventsDefaultLimit is the number of events replayed when no limit is given.
+const jobEventsDefaultLimit = 100
+
+// deadLettersDefaultLimit is the number of dead letters listed when no limit is given.
+const deadLettersDefaultLimit = 50
+
+// JobEventHandlers replays the job event log for integrations that reprocess job history, and lists
+// the events durable handlers gave up on.
+type JobEventHandlers struct {
+	jobEventService *application.JobEventService
+	jobPresenter    *presenters.JobPresenter
+	logger          *logging.Logger
+}
+
+// NewJobEventHandlers creates a new job event handlers instance.
+func NewJobEventHandlers(jobEventService *application.JobEventService, jobPresenter *presenters.JobPresenter) *JobEventHandlers {
+	return &JobEventHandlers{
+		jobEventService: jobEventService,
+		jobPresenter:    jobPresenter,
+		logger:          logging.Default().WithComponent("job_event_handler"),
+	}
+}
+
+// ListJobEvents replays recorded job events oldest first, continuing after the sequence number given
+// in after; next_after of a response continues the replay.
+// GET /api/job-events?after={sequence}&type={type}&job_id={id}&limit={n}
+func (h *JobEventHandlers) ListJobEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	filter := events.JobEventFilter{
+		Type:  events.JobEventType(query.Get("type")),
+		JobID: query.Get("job_id"),
+		Limit: jobEventsDefaultLimit,
+	}
+	if raw := query.Get("after"); raw != "" {
+		after, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || after < 0 {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "after must be a sequence number")
+			return
+		}
+		filter.AfterSequence = after
+	}
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > apiMaxPageSize {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "limit must be between 1 and "+strconv.Itoa(apiMaxPageSize))
+			return
+		}
+		filter.Limit = limit
+	}
+
+	page, err := h.jobEventService.ListEvents(r.Context(), filter)
+	if err != nil {
+		if errors.Is(err, application.ErrInvalidJobEventFilter) {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+			return
+		}
+		writeServiceError(w, r, err)
+		return
+	}
+	view := h.jobPresenter.FormatJobEventPage(page.Events, page.NextAfter, page.HasMore)
+	if err := WriteJSON(w, http.StatusOK, view); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// ListDeadLetters lists the events durable handlers gave up on, most recently failed first.
+// GET /api/job-events/dead-letters?limit={n}
+func (h *JobEventHandlers) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	limit := deadLettersDefaultLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > apiMaxPageSize {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "limit must be between 1 and "+strconv.Itoa(apiMaxPageSize))
+			return
+		}
+		limit = parsed
+	}
+
+	deadLetters, err := h.jobEventService.ListDeadLetters(r.Context(), limit)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.jobPresenter.ToDeadLetterViews(deadLetters)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// DeadLettersSection renders the dashboard's dead-letter list, empty when there are none.
+// GET /dead-letters
+func (h *JobEventHandlers) DeadLettersSection(w http.ResponseWriter, r *http.Request) {
+	h.renderDeadLetters(w, r, "")
+}
+
+// RetryDeadLetter delivers a dead letter's event to its handler again, removing it on success. The
+// dashboard gets the updated list, API clients 204 or a problem when the handler fails again.
+// POST /api/job-events/dead-letters/{deadLetterID}/retry
+func (h *JobEventHandlers) RetryDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "deadLetterID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid deadLetterID parameter")
+		return
+	}
+
+	err = h.jobEventService.RetryDeadLetter(r.Context(), id)
+	if IsHTMXRequest(r) && !isNotFoundError(err) {
+		status := "Event delivered"
+		if err != nil {
+			status = err.Error()
+		}
+		h.renderDeadLetters(w, r, status)
+		return
+	}
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, events.ErrHandlerFailed), errors.Is(err, events.ErrUnknownHandler):
+		WriteProblem(w, r, http.StatusConflict, ErrCodeRedeliveryFailed, err.Error())
+	default:
+		writeServiceError(w, r, err)
+	}
+}
+
+// DismissDeadLetter removes a dead letter without delivering its event again.
+// DELETE /api/job-events/dead-letters/{deadLetterID}
+func (h *JobEventHandlers) DismissDeadLetter(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "deadLetterID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid deadLetterID parameter")
+		return
+	}
+
+	if err := h.jobEventService.DismissDeadLetter(r.Context(), id); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if IsHTMXRequest(r) {
+		h.renderDeadLetters(w, r, "")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// renderDeadLetters renders the dashboard's dead-letter list with an optional status line
+func (h *JobEventHandlers) renderDeadLetters(w http.ResponseWriter, r *http.Request, status string) {
+	deadLetters, err := h.jobEventService.ListDeadLetters(r.Context(), deadLettersDefaultLimit)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	RenderResponse(r.Context(), w, r, dashboard.DeadLettersSection(h.jobPresenter.ToDeadLetterViews(deadLetters), status))
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 
@@ -37,7 +38,7 @@ func NewJobHandlers(
 func (h *JobHandlers) CancelJob(w http.ResponseWriter, r *http.Request) {
 	jobID := chi.URLParam(r, "jobID")
 	if jobID == "" {
-		http.Error(w, "missing job ID", http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "missing job ID")
 		return
 	}
 
@@ -67,6 +68,33 @@ func (h *JobHandlers) CancelJob(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte(successMessage))
 }
 
+// ResumeJob continues a failed or cancelled job from where it stopped - delegates to service
+func (h *JobHandlers) ResumeJob(w http.ResponseWriter, r *http.Request) {
+	jobID := chi.URLParam(r, "jobID")
+	if jobID == "" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "missing job ID")
+		return
+	}
+
+	if _, err := h.jobService.ResumeJob(jobID); err != nil {
+		h.logger.Error("Failed to resume job", "job_id", jobID, "error", err)
+
+		w.Header().Set("Content-Type", "text/html")
+		if strings.HasPrefix(err.Error(), "job not found") {
+			w.WriteHeader(http.StatusNotFound)
+		} else {
+			w.WriteHeader(http.StatusConflict)
+		}
+		w.Write([]byte(h.jobPresenter.FormatResumeErrorMessage(err)))
+		return
+	}
+
+	h.logger.Info("Job resumed", "job_id", jobID)
+
+	w.Header().Set("Content-Type", "text/html")
+	w.Write([]byte(h.jobPresenter.FormatResumeSuccessMessage()))
+}
+
 // ListJobs returns all jobs as HTML or JSON - delegates to service
 func (h *JobHandlers) ListJobs(w http.ResponseWriter, r *http.Request) {
 	// Get all jobs using service
@@ -102,6 +130,6 @@ func (h *JobHandlers) handleJobListJSON(w http.ResponseWriter, r *http.Request,
 	jobListView := h.jobPresenter.FormatJobList(jobs)
 	if err := json.NewEncoder(w).Encode(jobListView); err != nil {
 		h.logger.Error("Failed to encode job list response", "error", err)
-		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
 	}
 }
@@ -51,6 +51,19 @@ func (m *MockJobService) CancelJob(jobID string) (*jobs.Job, error) {
 	return args.Get(0).(*jobs.Job), args.Error(1)
 }
 
+func (m *MockJobService) ResumeJob(jobID string) (*jobs.Job, error) {
+	args := m.Called(jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*jobs.Job), args.Error(1)
+}
+
+func (m *MockJobService) FailInterruptedJobs() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockJobService) ListAllJobs() []*jobs.Job {
 	args := m.Called()
 	return args.Get(0).([]*jobs.Job)
@@ -158,6 +171,46 @@ func TestJobHandlers_CancelJob(t *testing.T) {
 	mockJobService.AssertExpectations(t)
 }
 
+func TestJobHandlers_ResumeJob(t *testing.T) {
+	jobPresenter := presenters.NewJobPresenter()
+	resume := func(handlers *JobHandlers, jobID string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/jobs/"+jobID+"/resume", nil)
+		rctx := chi.NewRouteContext()
+		rctx.URLParams.Add("jobID", jobID)
+		req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+		w := httptest.NewRecorder()
+		handlers.ResumeJob(w, req)
+		return w
+	}
+
+	t.Run("resumed", func(t *testing.T) {
+		mockJobService := new(MockJobService)
+		resumedJob := &jobs.Job{ID: "failed-job", Type: jobs.JobTypeSiteAudit, Status: jobs.JobStatusRunning}
+		mockJobService.On("ResumeJob", "failed-job").Return(resumedJob, nil)
+
+		w := resume(NewJobHandlers(mockJobService, jobPresenter), "failed-job")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Job resumed")
+	})
+
+	t.Run("job not found", func(t *testing.T) {
+		mockJobService := new(MockJobService)
+		mockJobService.On("ResumeJob", "nonexistent").Return(nil, fmt.Errorf("job not found: nonexistent"))
+
+		w := resume(NewJobHandlers(mockJobService, jobPresenter), "nonexistent")
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("not resumable", func(t *testing.T) {
+		mockJobService := new(MockJobService)
+		mockJobService.On("ResumeJob", "done-job").Return(nil, fmt.Errorf("cannot resume job in status: completed"))
+
+		w := resume(NewJobHandlers(mockJobService, jobPresenter), "done-job")
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "Failed to resume job: cannot resume job in status: completed")
+	})
+}
+
 func TestJobHandlers_ListJobs(t *testing.T) {
 	// Setup
 	mockJobService := new(MockJobService)
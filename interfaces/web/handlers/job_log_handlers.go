@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/components/jobs"
+	"spaudit/logging"
+)
+
+// jobLogKeepAliveInterval is how often an idle log stream sends a keep-alive comment
+const jobLogKeepAliveInterval = 30 * time.Second
+
+// JobLogHandlers serves the live audit log panel and its log stream.
+type JobLogHandlers struct {
+	appCtx       context.Context
+	logTail      *logging.LogTail
+	jobPresenter *presenters.JobPresenter
+	logger       *logging.Logger
+}
+
+// NewJobLogHandlers creates a new job log handlers instance.
+func NewJobLogHandlers(appCtx context.Context, logTail *logging.LogTail, jobPresenter *presenters.JobPresenter) *JobLogHandlers {
+	return &JobLogHandlers{
+		appCtx:       appCtx,
+		logTail:      logTail,
+		jobPresenter: jobPresenter,
+		logger:       logging.Default().WithComponent("job_log_handler"),
+	}
+}
+
+// LogPanel renders the collapsible live log panel for a job.
+// GET /jobs/{jobID}/logs
+func (h *JobLogHandlers) LogPanel(w http.ResponseWriter, r *http.Request) {
+	jobID := chi.URLParam(r, "jobID")
+	RenderResponse(r.Context(), w, r, jobs.JobLogPanel(jobID))
+}
+
+// StreamLogs streams a job's recent log lines, then its new ones, as "log" server-sent events.
+// GET /jobs/{jobID}/logs/stream
+func (h *JobLogHandlers) StreamLogs(w http.ResponseWriter, r *http.Request) {
+	jobID := chi.URLParam(r, "jobID")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeStreamUnavailable, "Failed to establish log stream")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering
+
+	recent, lines, unsubscribe := h.logTail.Subscribe(jobID)
+	defer unsubscribe()
+
+	if _, err := fmt.Fprintf(w, ": log stream for job %s\n\n", jobID); err != nil {
+		return
+	}
+	for _, line := range recent {
+		if err := h.writeLogLine(r.Context(), w, line); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(jobLogKeepAliveInterval)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-h.appCtx.Done():
+			return
+		case line, ok := <-lines:
+			if !ok {
+				return
+			}
+			if err := h.writeLogLine(r.Context(), w, line); err != nil {
+				h.logger.Debug("Log stream write failed", "job_id", jobID, "error", err)
+				return
+			}
+			flusher.Flush()
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeLogLine writes one rendered log line as a "log" event
+func (h *JobLogHandlers) writeLogLine(ctx context.Context, w http.ResponseWriter, line logging.LogLine) error {
+	var html bytes.Buffer
+	if err := jobs.JobLogLine(h.jobPresenter.FormatJobLogLine(line)).Render(ctx, &html); err != nil {
+		return fmt.Errorf("render log line: %w", err)
+	}
+
+	// Each line of a multi-line payload needs its own data field
+	var event strings.Builder
+	event.WriteString("event: log\n")
+	for _, dataLine := range strings.Split(html.String(), "\n") {
+		event.WriteString("data: ")
+		event.WriteString(dataLine)
+		event.WriteString("\n")
+	}
+	event.WriteString("\n")
+
+	_, err := w.Write([]byte(event.String()))
+	return err
+}
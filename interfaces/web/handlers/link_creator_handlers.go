@@ -0,0 +1,67 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// linkCreatorsDefaultTop is how many creators exposing links are highlighted when no top is given.
+const linkCreatorsDefaultTop = 5
+
+// GetLinkCreators returns who created the active sharing links an audit run found in the site, those
+// who exposed the most links to anyone or to external users first and highlighted, as JSON or as a
+// CSV download attached to the run
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/link-creators?top={n}&format=csv
+func (h *ListHandlers) GetLinkCreators(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	top := linkCreatorsDefaultTop
+	if raw := r.URL.Query().Get("top"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 0 || parsed > apiMaxPageSize {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "top must be between 0 and "+strconv.Itoa(apiMaxPageSize))
+			return
+		}
+		top = parsed
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "format must be json or csv")
+		return
+	}
+
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+	creators, err := scopedServices.SiteContentService.GetLinkCreators(ctx, siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	view := h.listPresenter.ToLinkCreatorsView(siteID, scopedServices.AuditRunID, creators, top)
+
+	if format == "csv" {
+		filename := fmt.Sprintf("link-creators-site%d-run%d.csv", siteID, scopedServices.AuditRunID)
+		h.writeCSVExport(w, r, siteID, scopedServices.AuditRunID, filename, h.listPresenter.LinkCreatorsToCSV(view))
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, view); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
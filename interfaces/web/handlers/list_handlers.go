@@ -4,17 +4,36 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"spaudit/domain/audit"
 	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
 
 	"spaudit/application"
 	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/components/assignments"
 	"spaudit/interfaces/web/templates/pages"
+	"spaudit/logging"
+)
+
+// runSwitcherLimit is the number of recent audit runs offered by the run switcher.
+const runSwitcherLimit = 50
+
+// storagePanelRuns is the number of recent audit runs whose row counts the dashboard shows.
+const storagePanelRuns = 10
+
+// Page sizes for paged JSON API endpoints.
+const (
+	apiDefaultPageSize = 100
+	apiMaxPageSize     = 500
 )
 
 // ListHandlers handles HTTP requests for SharePoint list operations.
@@ -25,6 +44,14 @@ type ListHandlers struct {
 	siteBrowsingService *application.SiteBrowsingService
 	jobService          application.JobService
 	auditService        application.AuditService
+	runManifestService  *application.RunManifestService
+	runArtifactService  *application.RunArtifactService
+	baselineService     *application.BaselineService
+	reportShareService  *application.ReportShareService
+	storageMonitor      *application.StorageMonitor
+	findingSeverities   *application.FindingSeverities      // Nil rates findings with the default severities
+	policyPacks         *application.PolicyPackService      // Nil rates every site with findingSeverities
+	siteSensitivity     *application.SiteSensitivityService // Nil treats every site as untagged
 
 	// Presenters (view logic)
 	listPresenter       *presenters.ListPresenter
@@ -33,6 +60,11 @@ type ListHandlers struct {
 	
 	// Service factory for creating audit-run-scoped services
 	serviceFactory      application.AuditRunScopedServiceFactory
+
+	// Rows selected for bulk actions, per browser session
+	selections *SelectionStore
+
+	logger *logging.Logger
 }
 
 // NewListHandlers creates a new list handlers instance.
@@ -42,6 +74,11 @@ func NewListHandlers(
 	siteBrowsingService *application.SiteBrowsingService,
 	jobService application.JobService,
 	auditService application.AuditService,
+	runManifestService *application.RunManifestService,
+	runArtifactService *application.RunArtifactService,
+	baselineService *application.BaselineService,
+	reportShareService *application.ReportShareService,
+	storageMonitor *application.StorageMonitor,
 	listPresenter *presenters.ListPresenter,
 	permissionPresenter *presenters.PermissionPresenter,
 	sitePresenter *presenters.SitePresenter,
@@ -53,10 +90,17 @@ func NewListHandlers(
 		siteBrowsingService: siteBrowsingService,
 		jobService:          jobService,
 		auditService:        auditService,
+		runManifestService:  runManifestService,
+		runArtifactService:  runArtifactService,
+		baselineService:     baselineService,
+		reportShareService:  reportShareService,
+		storageMonitor:      storageMonitor,
 		listPresenter:       listPresenter,
 		permissionPresenter: permissionPresenter,
 		sitePresenter:       sitePresenter,
 		serviceFactory:      serviceFactory,
+		selections:          NewSelectionStore(),
+		logger:              logging.Default().WithComponent("list_handler"),
 	}
 }
 
@@ -68,15 +112,32 @@ func (h *ListHandlers) Home(w http.ResponseWriter, r *http.Request) {
 	// Get business data from services
 	allJobs := h.jobService.ListAllJobs()
 	
+	label, err := h.extractRunLabel(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
 	// Get sites with their latest audit run metadata instead of aggregated data
-	sitesData, err := h.getSitesWithLatestAuditRunMetadata(ctx)
+	sitesData, err := h.getSitesWithLatestAuditRunMetadata(ctx, label)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
+		return
+	}
+
+	// Nothing audited or queued yet: walk the user through setup instead of an empty table
+	if len(sitesData) == 0 && len(allJobs) == 0 && label == "" && r.URL.Query().Get("setup") != "skip" {
+		http.Redirect(w, r, "/setup", http.StatusSeeOther)
 		return
 	}
 
 	// Transform to view model using presenter
-	siteSelectionVM := h.sitePresenter.ToSiteSelectionViewModel(sitesData, len(allJobs) > 0)
+	siteSelectionVM := h.sitePresenter.ToSiteSelectionViewModel(sitesData, len(allJobs) > 0, label)
+	if usage, err := h.storageMonitor.Usage(ctx, storagePanelRuns); err != nil {
+		h.logger.Warn("Failed to measure storage for dashboard", "error", err)
+	} else {
+		siteSelectionVM.Storage = h.sitePresenter.ToStorageUsageView(usage)
+	}
 
 	// Render response
 	RenderResponse(ctx, w, r, pages.SiteSelectionPage(*siteSelectionVM))
@@ -90,27 +151,27 @@ func (h *ListHandlers) SiteListsPage(w http.ResponseWriter, r *http.Request) {
 	// Extract and validate parameters
 	siteID, err := h.extractSiteID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 	
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
 	// Create audit-run-scoped services
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
 		return
 	}
 
 	// Get business data from audit-run-scoped service
 	data, err := scopedServices.SiteContentService.GetSiteWithLists(ctx, siteID)
 	if err != nil {
-		http.Error(w, "Site not found", http.StatusNotFound)
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Site not found")
 		return
 	}
 
@@ -118,25 +179,15 @@ func (h *ListHandlers) SiteListsPage(w http.ResponseWriter, r *http.Request) {
 	data.AuditRunID = scopedServices.AuditRunID
 
 	// Convert to view model using presenter
+	// Search and sort come from the query string so a filtered view can be shared as a link
 	viewModel := h.listPresenter.ToSiteListsViewModel(data)
+	viewModel.Search = h.extractSearchQuery(r)
+	viewModel.SortBy = h.listPresenter.NormalizeListSort(r.URL.Query().Get("sort"))
+	viewModel.Lists = h.listPresenter.FilterListsForSearch(viewModel.Lists, viewModel.Search)
+	viewModel.Lists = h.listPresenter.SortLists(viewModel.Lists, viewModel.SortBy)
 
-	// Fetch audit runs for selector using audit service
-	auditRunsData, err := h.auditService.GetAuditRunsForSite(ctx, siteID, 50)
-	if err != nil {
-		// Log error but don't fail the request - selector just won't be populated
-		// TODO: Add proper logging
-	} else {
-		// Convert to view model format
-		auditRuns := make([]presenters.AuditRunOption, len(auditRunsData))
-		for i, auditRun := range auditRunsData {
-			auditRuns[i] = presenters.AuditRunOption{
-				ID:        auditRun.ID,
-				StartedAt: auditRun.StartedAt,
-				Status:    auditRun.GetStatus(),
-			}
-		}
-		viewModel.AuditRuns = auditRuns
-	}
+	// Breadcrumb and run switcher context
+	viewModel.RunContext = h.runContext(ctx, siteID, scopedServices.AuditRunID)
 
 	// Render response
 	RenderResponse(ctx, w, r, pages.SiteListsPage(*viewModel))
@@ -149,34 +200,34 @@ func (h *ListHandlers) ListDetail(w http.ResponseWriter, r *http.Request) {
 
 	siteID, listID, err := h.extractSiteAndListID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 	
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
 	// Create audit-run-scoped services
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
 		return
 	}
 
 	// Get list data from audit-run-scoped service
 	listData, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
 	// Analyze permissions using audit-run-scoped service
 	analyticsData, err := scopedServices.PermissionService.AnalyzeListPermissions(ctx, siteID, listData)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -185,7 +236,7 @@ func (h *ListHandlers) ListDetail(w http.ResponseWriter, r *http.Request) {
 	analytics := h.permissionPresenter.ToListAnalyticsViewModel(analyticsData, vmList)
 
 	// Render response (default tab: overview)
-	RenderResponse(ctx, w, r, pages.ListShell(vmList, "overview", pages.ListOverviewTab(analytics)))
+	RenderResponse(ctx, w, r, pages.ListShell(h.runContext(ctx, siteID, scopedServices.AuditRunID), vmList, "overview", pages.ListOverviewTab(analytics)))
 }
 
 // OverviewTab renders the overview tab content for a list (HTMX partial).
@@ -194,34 +245,34 @@ func (h *ListHandlers) OverviewTab(w http.ResponseWriter, r *http.Request) {
 
 	siteID, listID, err := h.extractSiteAndListID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 	
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
 	// Create audit-run-scoped services
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
 		return
 	}
 
 	// Get list data from audit-run-scoped service
 	listData, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
 	// Analyze permissions using audit-run-scoped service
 	analyticsData, err := scopedServices.PermissionService.AnalyzeListPermissions(ctx, siteID, listData)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -234,7 +285,7 @@ func (h *ListHandlers) OverviewTab(w http.ResponseWriter, r *http.Request) {
 		RenderResponse(ctx, w, r, pages.TabsAndContent(siteID, scopedServices.AuditRunID, listID, "overview", pages.ListOverviewTab(analytics)))
 	} else {
 		// Direct navigation - render full page
-		RenderResponse(ctx, w, r, pages.ListShell(vmList, "overview", pages.ListOverviewTab(analytics)))
+		RenderResponse(ctx, w, r, pages.ListShell(h.runContext(ctx, siteID, scopedServices.AuditRunID), vmList, "overview", pages.ListOverviewTab(analytics)))
 	}
 }
 
@@ -244,32 +295,33 @@ func (h *ListHandlers) AssignmentsTab(w http.ResponseWriter, r *http.Request) {
 
 	siteID, listID, err := h.extractSiteAndListID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 	
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
 	// Create audit-run-scoped services
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
 		return
 	}
 
-	// Get business data from audit-run-scoped service (assignments with root cause analysis)
-	assignmentsData, err := scopedServices.SiteContentService.GetListAssignmentsWithRootCause(ctx, siteID, listID)
+	// Only the first chunk is analyzed up front; the table fetches the rest as it scrolls
+	assignmentsPage, err := scopedServices.SiteContentService.GetAssignmentsPage(ctx, siteID, sharepoint.ObjectTypeList, listID, "", presenters.AssignmentsChunkSize)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
 	// Transform to view model using presenter
-	assignmentCollection := h.permissionPresenter.ToExpandableAssignmentCollection(assignmentsData, listID)
+	assignmentCollection := h.permissionPresenter.ToAssignmentsChunkCollection(assignmentsPage, siteID, scopedServices.AuditRunID, listID)
+	assignmentCollection.Columns = h.columnLayout(r, presenters.DataTableAssignments)
 
 	if IsHTMXPartialRequest(r) {
 		RenderResponse(ctx, w, r, pages.TabsAndContent(siteID, scopedServices.AuditRunID, listID, "assignments", pages.ListAssignmentsTab(siteID, scopedServices.AuditRunID, assignmentCollection)))
@@ -277,13 +329,56 @@ func (h *ListHandlers) AssignmentsTab(w http.ResponseWriter, r *http.Request) {
 		// Direct navigation - need list data for full page
 		listData, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeServiceError(w, r, err)
 			return
 		}
 
 		vmList := h.permissionPresenter.MapListToViewModel(listData)
-		RenderResponse(ctx, w, r, pages.ListShell(vmList, "assignments", pages.ListAssignmentsTab(siteID, scopedServices.AuditRunID, assignmentCollection)))
+		RenderResponse(ctx, w, r, pages.ListShell(h.runContext(ctx, siteID, scopedServices.AuditRunID), vmList, "assignments", pages.ListAssignmentsTab(siteID, scopedServices.AuditRunID, assignmentCollection)))
+	}
+}
+
+// AssignmentRows renders the next chunk of a list's assignment rows, continuing from the cursor
+// of the previous chunk.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/tabs/{listID}/assignments/rows?cursor=...
+func (h *ListHandlers) AssignmentRows(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	cursor := r.URL.Query().Get("cursor")
+	if cursor == "" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "cursor is required")
+		return
+	}
+
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	assignmentsPage, err := scopedServices.SiteContentService.GetAssignmentsPage(ctx, siteID, sharepoint.ObjectTypeList, listID, cursor, presenters.AssignmentsChunkSize)
+	if errors.Is(err, application.ErrInvalidCursor) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
 	}
+
+	assignmentCollection := h.permissionPresenter.ToAssignmentsChunkCollection(assignmentsPage, siteID, scopedServices.AuditRunID, listID)
+	assignmentCollection.Columns = h.columnLayout(r, presenters.DataTableAssignments)
+	RenderResponse(ctx, w, r, assignments.AssignmentRows(siteID, scopedServices.AuditRunID, assignmentCollection))
 }
 
 // ItemsTab shows the items tab for a list
@@ -292,63 +387,54 @@ func (h *ListHandlers) ItemsTab(w http.ResponseWriter, r *http.Request) {
 
 	siteID, listID, err := h.extractSiteAndListID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 	
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
 	// Create audit-run-scoped services
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
 		return
 	}
 
-	// TODO: Implement proper pagination for items tab
-	// - Extract page/limit from query parameters (e.g., ?page=1&limit=50)
-	// - Add pagination metadata to response (total count, current page, has next/prev)
-	// - Update UI to show pagination controls and handle HTMX partial updates
-	// - Consider default limits: 50 for UI responsiveness, max 500 for performance
-	// - Add loading states for large datasets
+	// Tab state (scope, kind, principal, page) comes from the query string so the view can be shared as a link
+	query := h.permissionPresenter.ParseItemsTabQuery(r.URL.Query())
+	pageData, err := scopedServices.SiteContentService.GetListItemsPage(ctx, siteID, listID, query.Filter(), query.Page, presenters.ItemsTabPageSize)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	page := h.permissionPresenter.ToItemsTabPage(pageData, query)
+	page.Columns = h.columnLayout(r, presenters.DataTableItems)
+	page.Selection = h.tableSelection(r, presenters.DataTableItems, siteID, scopedServices.AuditRunID, listID, page.ItemKeys())
 
-	// TEMPORARY: Using high static limit - replace with pagination
-	itemsData, err := scopedServices.SiteContentService.GetListItems(ctx, siteID, listID, 0, 1000)
+	principals, err := scopedServices.SiteContentService.GetAuditRunPrincipals(ctx, siteID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
+	page = page.WithPrincipalOptions(principals)
 
-	// Transform to view models using presenter
-	items := make([]presenters.ItemSummary, len(itemsData))
-	for i, item := range itemsData {
-		items[i] = h.permissionPresenter.MapItemToViewModel(item)
+	// Get list data for the tab component
+	listData, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
 	}
+	vmList := h.permissionPresenter.MapListToViewModel(listData)
 
 	if IsHTMXPartialRequest(r) {
-		// Get list data for the tab component
-		listData, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		vmList := h.permissionPresenter.MapListToViewModel(listData)
-		RenderResponse(ctx, w, r, pages.TabsAndContent(siteID, scopedServices.AuditRunID, listID, "items", pages.ListItemsTab(vmList, scopedServices.AuditRunID, items)))
+		RenderResponse(ctx, w, r, pages.TabsAndContent(siteID, scopedServices.AuditRunID, listID, "items", pages.ListItemsTab(vmList, scopedServices.AuditRunID, page)))
 	} else {
-		// Direct navigation - need list data for full page
-		listData, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-
-		vmList := h.permissionPresenter.MapListToViewModel(listData)
-		RenderResponse(ctx, w, r, pages.ListShell(vmList, "items", pages.ListItemsTab(vmList, scopedServices.AuditRunID, items)))
+		// Direct navigation - render full page
+		RenderResponse(ctx, w, r, pages.ListShell(h.runContext(ctx, siteID, scopedServices.AuditRunID), vmList, "items", pages.ListItemsTab(vmList, scopedServices.AuditRunID, page)))
 	}
 }
 
@@ -358,27 +444,27 @@ func (h *ListHandlers) LinksTab(w http.ResponseWriter, r *http.Request) {
 
 	siteID, listID, err := h.extractSiteAndListID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 	
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
 	// Create audit-run-scoped services
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
 		return
 	}
 
 	// Get data with item details from audit-run-scoped service
 	linkData, err := scopedServices.SiteContentService.GetListSharingLinksWithItemData(ctx, siteID, listID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
@@ -387,19 +473,21 @@ func (h *ListHandlers) LinksTab(w http.ResponseWriter, r *http.Request) {
 	for i, linkWithItem := range linkData {
 		linkVMs[i] = h.permissionPresenter.MapSharingLinkWithItemDataToViewModel(linkWithItem)
 	}
+	columns := h.columnLayout(r, presenters.DataTableLinks)
+	selection := h.tableSelection(r, presenters.DataTableLinks, siteID, scopedServices.AuditRunID, listID, presenters.SharingLinkKeys(linkVMs))
 
 	if IsHTMXPartialRequest(r) {
-		RenderResponse(ctx, w, r, pages.TabsAndContent(siteID, scopedServices.AuditRunID, listID, "links", pages.ListLinksTab(linkVMs, scopedServices.AuditRunID)))
+		RenderResponse(ctx, w, r, pages.TabsAndContent(siteID, scopedServices.AuditRunID, listID, "links", pages.ListLinksTab(siteID, scopedServices.AuditRunID, listID, linkVMs, columns, selection)))
 	} else {
 		// Direct navigation - need list data for full page
 		listData, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeServiceError(w, r, err)
 			return
 		}
 
 		vmList := h.permissionPresenter.MapListToViewModel(listData)
-		RenderResponse(ctx, w, r, pages.ListShell(vmList, "links", pages.ListLinksTab(linkVMs, scopedServices.AuditRunID)))
+		RenderResponse(ctx, w, r, pages.ListShell(h.runContext(ctx, siteID, scopedServices.AuditRunID), vmList, "links", pages.ListLinksTab(siteID, scopedServices.AuditRunID, listID, linkVMs, columns, selection)))
 	}
 }
 
@@ -410,27 +498,27 @@ func (h *ListHandlers) ToggleAssignment(w http.ResponseWriter, r *http.Request)
 	uniqueID := chi.URLParam(r, "uniqueID")
 	siteID, err := h.extractSiteID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 	
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
 	// Create audit-run-scoped services
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
 		return
 	}
 
-	// Parse unique ID to get list ID and index
-	listID, index, err := h.parseAssignmentUniqueID(uniqueID)
+	// Parse unique ID to get the assigned object and index
+	objectType, objectKey, index, err := h.parseAssignmentUniqueID(uniqueID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
@@ -439,15 +527,15 @@ func (h *ListHandlers) ToggleAssignment(w http.ResponseWriter, r *http.Request)
 	isCurrentlyHidden := currentState == "hidden" || currentState == ""
 
 	if isCurrentlyHidden {
-		// Expand - get business data and generate expanded HTML
-		assignmentsData, err := scopedServices.SiteContentService.GetListAssignmentsWithRootCause(ctx, siteID, listID)
-		if err != nil || index >= len(assignmentsData) {
-			http.Error(w, "Assignment not found", http.StatusNotFound)
+		// Expand - analyze just the toggled assignment, which may sit in a chunk loaded on scroll
+		assignment, err := scopedServices.SiteContentService.GetAssignmentAt(ctx, siteID, objectType, objectKey, index)
+		if err != nil || assignment == nil {
+			WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Assignment not found")
 			return
 		}
 
 		// Use presenter to generate HTML
-		htmlContent := h.permissionPresenter.ToAssignmentToggleHTML(assignmentsData[index], uniqueID, true)
+		htmlContent := h.permissionPresenter.ToAssignmentToggleHTML(assignment, uniqueID, true)
 		w.Header().Set("Content-Type", "text/html")
 		w.Write([]byte(htmlContent))
 	} else {
@@ -464,38 +552,50 @@ func (h *ListHandlers) SearchLists(w http.ResponseWriter, r *http.Request) {
 
 	siteID, err := h.extractSiteID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 	
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
 	// Create audit-run-scoped services
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	filteredLists, err := h.filteredListSummaries(ctx, r, siteID, scopedServices)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
+	// Keep the address bar in step with the table so the current view can be shared
+	w.Header().Set("HX-Replace-Url", h.listPresenter.ListsPageURL(siteID, scopedServices.AuditRunID, h.extractSearchQuery(r), r.FormValue("sort")))
+
+	// Return just the table body rows
+	RenderResponse(ctx, w, r, pages.ListTableRows(filteredLists, siteID, scopedServices.AuditRunID))
+}
+
+// filteredListSummaries loads lists for the audit run and applies the request's search filter and sort.
+func (h *ListHandlers) filteredListSummaries(ctx context.Context, r *http.Request, siteID int64, scopedServices *application.AuditRunScopedServices) ([]presenters.ListSummary, error) {
 	searchQuery := h.extractSearchQuery(r)
 
 	// Get business data from audit-run-scoped service
 	listsData, err := scopedServices.SiteContentService.GetListsForSite(ctx, siteID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+		return nil, err
 	}
 
-	// Convert to view models and apply search filter using presenter
-	listVMs := h.listPresenter.ToListSummaries(listsData)
+	// Convert to view models, apply search filter and sort using presenter
+	listVMs := h.listPresenter.ToListSummariesWithThreshold(listsData, scopedServices.SiteContentService.UniqueDensityThreshold())
 	filteredLists := h.listPresenter.FilterListsForSearch(listVMs, searchQuery)
-
-	// Return just the table body rows
-	RenderResponse(ctx, w, r, pages.ListTableRows(filteredLists, siteID, scopedServices.AuditRunID))
+	return h.listPresenter.SortLists(filteredLists, r.FormValue("sort")), nil
 }
 
 // SearchSites handles HTMX search requests for filtering sites
@@ -503,16 +603,21 @@ func (h *ListHandlers) SearchSites(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	searchQuery := h.extractSearchQuery(r)
+	label, err := h.extractRunLabel(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
 
-	// Get business data from service
-	sitesData, err := h.siteBrowsingService.SearchSites(ctx, searchQuery)
+	// Same latest-run metadata as the dashboard table
+	sitesData, err := h.searchSitesWithLatestAuditRunMetadata(ctx, searchQuery, label)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
 	// Transform to view models using presenter
-	siteVMs := h.sitePresenter.ToSitesWithMetadata(sitesData)
+	siteVMs := h.sitePresenter.ToSitesWithMetadata(sitesData, label)
 
 	// Return just the table body rows
 	RenderResponse(ctx, w, r, pages.SiteTableRows(siteVMs))
@@ -523,38 +628,21 @@ func (h *ListHandlers) SitesTable(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	searchQuery := h.extractSearchQuery(r)
+	label, err := h.extractRunLabel(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
 
 	// Get sites with their latest audit run metadata instead of aggregated data
-	var sitesData []*contracts.SiteWithMetadata
-	var err error
-	
-	if searchQuery == "" {
-		// No search query - get all sites with latest audit run metadata
-		sitesData, err = h.getSitesWithLatestAuditRunMetadata(ctx)
-	} else {
-		// Search query provided - get all sites first, then filter
-		allSitesData, err := h.getSitesWithLatestAuditRunMetadata(ctx)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		
-		// Filter sites based on search query (simple contains search)
-		for _, siteData := range allSitesData {
-			if strings.Contains(strings.ToLower(siteData.Site.Title), strings.ToLower(searchQuery)) ||
-				strings.Contains(strings.ToLower(siteData.Site.URL), strings.ToLower(searchQuery)) {
-				sitesData = append(sitesData, siteData)
-			}
-		}
-	}
-	
+	sitesData, err := h.searchSitesWithLatestAuditRunMetadata(ctx, searchQuery, label)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
 	// Transform to view models using presenter
-	siteSelectionVM := h.sitePresenter.ToSiteSelectionViewModel(sitesData, false)
+	siteSelectionVM := h.sitePresenter.ToSiteSelectionViewModel(sitesData, false, label)
 	RenderResponse(ctx, w, r, pages.SitesTableInner(*siteSelectionVM))
 }
 
@@ -582,335 +670,731 @@ func (h *ListHandlers) GetAuditRunsForSite(w http.ResponseWriter, r *http.Reques
 	siteIDStr := chi.URLParam(r, "siteID")
 	siteID, err := strconv.ParseInt(siteIDStr, 10, 64)
 	if err != nil {
-		http.Error(w, "Invalid site ID", http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "Invalid site ID")
 		return
 	}
 
-	// Get audit runs for this site using audit service
-	auditRunsData, err := h.auditService.GetAuditRunsForSite(ctx, siteID, 50)
+	label, err := h.extractRunLabel(r)
 	if err != nil {
-		http.Error(w, "Failed to get audit runs", http.StatusInternalServerError)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
-	// Convert to JSON response format
-	type AuditRunResponse struct {
-		ID        int64  `json:"id"`
-		StartedAt string `json:"started_at"`
-		Status    string `json:"status"`
+	// Get audit runs for this site using audit service
+	auditRunsData, err := h.auditService.GetAuditRunsForSite(ctx, siteID, label, 50)
+	if err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "Failed to get audit runs")
+		return
 	}
 
-	auditRuns := make([]AuditRunResponse, len(auditRunsData))
-	for i, auditRun := range auditRunsData {
-		auditRuns[i] = AuditRunResponse{
-			ID:        auditRun.ID,
-			StartedAt: auditRun.StartedAt.Format("2006-01-02 15:04:05"),
-			Status:    auditRun.GetStatus(),
-		}
+	auditRuns := h.listPresenter.ToAuditRunViews(auditRunsData)
+
+	// List each run's generated reports with it
+	artifacts, err := h.runArtifactService.ListSiteArtifacts(ctx, siteID)
+	if err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "Failed to get audit run artifacts")
+		return
 	}
+	h.listPresenter.AttachRunArtifacts(auditRuns, artifacts)
 
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(auditRuns); err != nil {
-		http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
 	}
 }
 
-// extractAuditRunID extracts audit run ID from URL parameters
-// Returns "latest" as special case or parses the numeric ID
-func (h *ListHandlers) extractAuditRunID(r *http.Request) (string, error) {
-	auditRunIDParam := chi.URLParam(r, "auditRunID")
-	if auditRunIDParam == "" {
-		// Default to "latest" if not specified
-		return "latest", nil
-	}
-	
-	// Allow "latest" as special case
-	if auditRunIDParam == "latest" {
-		return "latest", nil
-	}
-	
-	// Validate that it's a valid number if not "latest"
-	if _, err := strconv.ParseInt(auditRunIDParam, 10, 64); err != nil {
-		return "", fmt.Errorf("invalid auditRunID parameter: %w", err)
-	}
-	
-	return auditRunIDParam, nil
-}
+// GetListSnapshot returns a canonical JSON snapshot of a list within an audit run
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/snapshot
+func (h *ListHandlers) GetListSnapshot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-func (h *ListHandlers) extractSiteAndListID(r *http.Request) (int64, string, error) {
-	siteID, err := h.extractSiteID(r)
+	siteID, listID, err := h.extractSiteAndListID(r)
 	if err != nil {
-		return 0, "", err
-	}
-
-	listID := chi.URLParam(r, "listID")
-	if listID == "" {
-		return 0, "", fmt.Errorf("listID parameter is required")
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
 	}
 
-	return siteID, listID, nil
-}
-
-func (h *ListHandlers) extractSearchQuery(r *http.Request) string {
-	// Try both query parameter and form value for flexibility
-	searchQuery := strings.TrimSpace(r.FormValue("search"))
-	if searchQuery == "" {
-		searchQuery = strings.TrimSpace(r.URL.Query().Get("search"))
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
 	}
-	return searchQuery
-}
 
-func (h *ListHandlers) parseAssignmentUniqueID(uniqueID string) (string, int, error) {
-	// Parse the unique ID format: assignment-{listID}-{index}
-	if !strings.HasPrefix(uniqueID, "assignment-") {
-		return "", 0, fmt.Errorf("invalid unique ID format")
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
 	}
 
-	parts := strings.Split(uniqueID[len("assignment-"):], "-")
-	if len(parts) < 2 {
-		return "", 0, fmt.Errorf("invalid unique ID format")
+	snapshotData, err := scopedServices.SiteContentService.GetListSnapshot(ctx, siteID, listID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
 	}
 
-	// The index is the last part, everything else is listID
-	indexStr := parts[len(parts)-1]
-	listID := strings.Join(parts[:len(parts)-1], "-")
-
-	index, err := strconv.Atoi(indexStr)
+	// Indented output keeps golden file diffs readable; run manifests hash the same bytes
+	snapshot, err := h.listPresenter.EncodeListSnapshot(siteID, snapshotData)
 	if err != nil {
-		return "", 0, fmt.Errorf("invalid unique ID format: %w", err)
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+		return
 	}
 
-	return listID, index, nil
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(snapshot)
 }
 
-// Helper methods for combining business logic calls
-
-
-// GetObjectAssignments handles GET requests for object assignments (HTMX partial)
-func (h *ListHandlers) GetObjectAssignments(w http.ResponseWriter, r *http.Request) {
+// GetSharingGovernance returns the site's sharing policy as captured by an audit run, with its
+// changes since the previous run that captured it
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/sharing-governance
+func (h *ListHandlers) GetSharingGovernance(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	siteID, err := h.extractSiteID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
-	
+
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
-	// Create audit-run-scoped services
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
 		return
 	}
 
-	objectType := chi.URLParam(r, "otype")
-	objectKey := chi.URLParam(r, "okey")
-
-	// Get business data from audit-run-scoped service
-	assignments, err := scopedServices.SiteContentService.GetAssignmentsForObject(ctx, siteID, objectType, objectKey)
+	governance, err := scopedServices.SiteContentService.GetSharingGovernance(ctx, siteID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
 
-	// Transform to view models using presenter
-	vm := make([]presenters.Assignment, len(assignments))
-	for i, assignment := range assignments {
-		vm[i] = h.permissionPresenter.MapAssignmentToViewModel(assignment)
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToSharingGovernanceView(governance)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
 	}
-
-	assignmentCollection := h.permissionPresenter.NewAssignmentCollection(vm)
-
-	// Render response
-	RenderResponse(ctx, w, r, pages.AssignmentsList(assignmentCollection))
 }
 
-// GetSharingLinkMembers handles GET requests for sharing link members (HTMX partial)
-func (h *ListHandlers) GetSharingLinkMembers(w http.ResponseWriter, r *http.Request) {
+// GetRiskyDefaults returns the risky sharing defaults an audit run's site relies on, with the active
+// sharing links created under each
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/risky-defaults
+func (h *ListHandlers) GetRiskyDefaults(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	siteID, err := h.extractSiteID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
-	
+
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
-	// Create audit-run-scoped services
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
 		return
 	}
 
-	linkID := chi.URLParam(r, "linkID")
-	if linkID == "" {
-		http.Error(w, "invalid link ID", http.StatusBadRequest)
+	riskyDefaults, err := scopedServices.SiteContentService.GetRiskyDefaults(ctx, siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
+	h.runSeverities(ctx, siteID, scopedServices.AuditRunID).RateRiskyDefaults(riskyDefaults)
 
-	// Get business data from audit-run-scoped service
-	principals, err := scopedServices.SiteContentService.GetSharingLinkMembers(ctx, siteID, linkID)
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToRiskyDefaultsView(siteID, scopedServices.AuditRunID, riskyDefaults)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// GetSites returns all audited sites with latest-run metadata, only from runs tagged with ?label= when set
+// GET /api/sites
+func (h *ListHandlers) GetSites(w http.ResponseWriter, r *http.Request) {
+	label, err := h.extractRunLabel(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
-	// Transform to view models using presenter
-	vm := make([]presenters.SharingLinkMember, len(principals))
-	for i, principal := range principals {
-		vm[i] = h.permissionPresenter.MapPrincipalToSharingLinkMemberViewModel(principal)
+	sitesData, err := h.getSitesWithLatestAuditRunMetadata(r.Context(), label)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
 	}
 
-	// Render response
-	RenderResponse(ctx, w, r, pages.SharingLinkMembersList(vm))
+	if err := WriteJSON(w, http.StatusOK, h.sitePresenter.ToSiteViews(sitesData)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
 }
 
-// ToggleSharingLinkMembers handles POST requests for sharing link member visibility toggle
-func (h *ListHandlers) ToggleSharingLinkMembers(w http.ResponseWriter, r *http.Request) {
+// GetAuditRun returns a single audit run, resolving the latest and reference aliases
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}
+func (h *ListHandlers) GetAuditRun(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	siteID, err := h.extractSiteID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
-	
+
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
-	// Create audit-run-scoped services
+	// The factory resolves aliases and rejects runs belonging to other sites
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
 		return
 	}
 
-	linkID := chi.URLParam(r, "linkID")
-	if linkID == "" {
-		http.Error(w, "invalid link ID", http.StatusBadRequest)
+	auditRun, err := h.auditService.GetAuditRun(ctx, siteID, scopedServices.AuditRunID)
+	if err != nil {
+		writeServiceError(w, r, err)
 		return
 	}
 
-	// Get current state and determine action
-	currentState := r.FormValue("state")
-	isCurrentlyHidden := currentState == "hidden" || currentState == ""
-
-	// Get business data from audit-run-scoped service (always needed for member count)
-	principals, err := scopedServices.SiteContentService.GetSharingLinkMembers(ctx, siteID, linkID)
+	view := h.listPresenter.ToAuditRunView(auditRun)
+	drift, err := h.auditService.GetAuditRunSchemaDrift(ctx, siteID, auditRun.ID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeServiceError(w, r, err)
 		return
 	}
+	view.SchemaDrift = h.listPresenter.ToSchemaDriftViews(drift)
 
-	w.Header().Set("Content-Type", "text/html")
-
-	if isCurrentlyHidden {
-		// Show members - return expandable row with proper template rendering
-		vm := make([]presenters.SharingLinkMember, len(principals))
-		for i, principal := range principals {
-			vm[i] = h.permissionPresenter.MapPrincipalToSharingLinkMemberViewModel(principal)
-		}
+	if err := WriteJSON(w, http.StatusOK, view); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
 
-		// Return visible expandable row with content
-		w.Write([]byte(`<tr id="members-row-` + linkID + `" data-state="visible" class="bg-slate-50" style="display: table-row;">
-			<td colspan="8" class="px-3 py-2 border-t">
-				<input type="hidden" name="state" value="visible">`))
+// maxHoldReasonLength caps the reason recorded with a legal hold.
+const maxHoldReasonLength = 500
 
-		RenderResponse(ctx, w, r, pages.SharingLinkMembersList(vm))
-		w.Write([]byte(`</td></tr>`))
+// HoldAuditRunRequest is the optional JSON body of a hold request.
+type HoldAuditRunRequest struct {
+	Reason string `json:"reason"`
+}
 
-		// Update button text with OOB swap
-		memberCount := len(principals)
-		hideText := fmt.Sprintf("Hide %d members", memberCount)
-		siteIDStr := strconv.FormatInt(siteID, 10)
-		auditRunIDStrFormatted := strconv.FormatInt(scopedServices.AuditRunID, 10)
-		endpoint := fmt.Sprintf("/sites/%s/audit-runs/%s/sharing-links/%s/members/toggle", siteIDStr, auditRunIDStrFormatted, linkID)
-		w.Write([]byte(`<button id="btn-members-row-` + linkID + `" hx-swap-oob="true" class="text-blue-600 hover:text-blue-700 text-xs font-medium hover:underline" hx-post="` + endpoint + `" hx-target="#members-row-` + linkID + `" hx-swap="outerHTML" hx-include="#members-row-` + linkID + `">` + hideText + `</button>`))
-	} else {
-		// Hide members - return hidden empty row
-		w.Write([]byte(`<tr id="members-row-` + linkID + `" data-state="hidden" style="display: none;" class="bg-slate-50">
-			<td colspan="8" class="px-3 py-2 border-t">
-				<input type="hidden" name="state" value="hidden">
-			</td>
-		</tr>`))
+// HoldAuditRun places an audit run on legal hold, exempting it from retention pruning and archival
+// PUT /api/sites/{siteID}/audit-runs/{auditRunID}/hold
+func (h *ListHandlers) HoldAuditRun(w http.ResponseWriter, r *http.Request) {
+	var req HoldAuditRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
 
-		// Update button text with OOB swap
-		memberCount := len(principals)
-		viewText := fmt.Sprintf("%d members", memberCount)
-		siteIDStr := strconv.FormatInt(siteID, 10)
-		auditRunIDStrFormatted := strconv.FormatInt(scopedServices.AuditRunID, 10)
-		endpoint := fmt.Sprintf("/sites/%s/audit-runs/%s/sharing-links/%s/members/toggle", siteIDStr, auditRunIDStrFormatted, linkID)
-		w.Write([]byte(`<button id="btn-members-row-` + linkID + `" hx-swap-oob="true" class="text-blue-600 hover:text-blue-700 text-xs font-medium hover:underline" hx-post="` + endpoint + `" hx-target="#members-row-` + linkID + `" hx-swap="outerHTML" hx-include="#members-row-` + linkID + `">` + viewText + `</button>`))
+	reason := strings.TrimSpace(req.Reason)
+	if len(reason) > maxHoldReasonLength {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("reason must be at most %d characters", maxHoldReasonLength))
+		return
 	}
+
+	h.setAuditRunHold(w, r, func(ctx context.Context, siteID, auditRunID int64) (*audit.AuditRun, error) {
+		return h.auditService.HoldAuditRun(ctx, siteID, auditRunID, reason)
+	})
 }
 
-// ToggleItemAssignments handles POST requests for item assignment visibility toggle
-func (h *ListHandlers) ToggleItemAssignments(w http.ResponseWriter, r *http.Request) {
+// ReleaseAuditRunHold takes an audit run off legal hold
+// DELETE /api/sites/{siteID}/audit-runs/{auditRunID}/hold
+func (h *ListHandlers) ReleaseAuditRunHold(w http.ResponseWriter, r *http.Request) {
+	h.setAuditRunHold(w, r, h.auditService.ReleaseAuditRunHold)
+}
+
+// setAuditRunHold resolves the requested run, applies the hold change and responds with the updated run.
+func (h *ListHandlers) setAuditRunHold(w http.ResponseWriter, r *http.Request, apply func(ctx context.Context, siteID, auditRunID int64) (*audit.AuditRun, error)) {
 	ctx := r.Context()
 
 	siteID, err := h.extractSiteID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
-	
+
 	auditRunIDStr, err := h.extractAuditRunID(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
 		return
 	}
 
-	// Create audit-run-scoped services
+	// Resolve aliases and validate the run belongs to the site
 	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
 	if err != nil {
-		http.Error(w, fmt.Sprintf("Failed to create audit-run-scoped services: %v", err), http.StatusInternalServerError)
+		writeAuditRunError(w, r, err)
 		return
 	}
 
-	itemGUID := chi.URLParam(r, "itemGUID")
+	auditRun, err := apply(ctx, siteID, scopedServices.AuditRunID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
 
-	// Get current state and determine action
-	currentState := r.FormValue("state")
-	isCurrentlyHidden := currentState == "hidden" || currentState == ""
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToAuditRunView(auditRun)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
 
-	w.Header().Set("Content-Type", "text/html")
+// GetListItems returns one page of a list's items within an audit run
+// Accepts the items tab filters (scope, kind, page) plus page_size.
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/items
+func (h *ListHandlers) GetListItems(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	pageSize, err := h.extractPageSize(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	// Unknown lists have no items rather than an error, so check the list exists first
+	if _, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID); err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	query := h.permissionPresenter.ParseItemsTabQuery(r.URL.Query())
+	pageData, err := scopedServices.SiteContentService.GetListItemsPage(ctx, siteID, listID, query.Filter(), query.Page, pageSize)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToItemsPageView(pageData)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// GetItemAccessExplanation explains every path by which each principal can access an item
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/items/{itemGUID}/explain
+func (h *ListHandlers) GetItemAccessExplanation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	itemGUID := chi.URLParam(r, "itemGUID")
+	if itemGUID == "" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "missing item GUID")
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	explanation, err := scopedServices.SiteContentService.ExplainItemAccess(ctx, siteID, listID, itemGUID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToItemAccessExplanation(siteID, explanation)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// extractPageSize reads the page_size query parameter for paged API endpoints
+func (h *ListHandlers) extractPageSize(r *http.Request) (int, error) {
+	pageSizeParam := r.URL.Query().Get("page_size")
+	if pageSizeParam == "" {
+		return apiDefaultPageSize, nil
+	}
+
+	pageSize, err := strconv.Atoi(pageSizeParam)
+	if err != nil || pageSize < 1 || pageSize > apiMaxPageSize {
+		return 0, fmt.Errorf("page_size must be between 1 and %d", apiMaxPageSize)
+	}
+
+	return pageSize, nil
+}
+
+// extractAuditRunID extracts audit run ID from URL parameters
+// Returns a run alias ("latest", "reference") as is or parses the numeric ID
+func (h *ListHandlers) extractAuditRunID(r *http.Request) (string, error) {
+	auditRunIDParam := chi.URLParam(r, "auditRunID")
+	if auditRunIDParam == "" {
+		// Default to "latest" if not specified
+		return audit.RunAliasLatest, nil
+	}
+	
+	// Allow run aliases as special cases
+	if audit.IsRunAlias(auditRunIDParam) {
+		return auditRunIDParam, nil
+	}
+	
+	// Validate that it's a valid number if not an alias
+	if _, err := strconv.ParseInt(auditRunIDParam, 10, 64); err != nil {
+		return "", fmt.Errorf("invalid auditRunID parameter: %w", err)
+	}
+	
+	return auditRunIDParam, nil
+}
+
+func (h *ListHandlers) extractSiteAndListID(r *http.Request) (int64, string, error) {
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		return 0, "", err
+	}
+
+	listID := chi.URLParam(r, "listID")
+	if listID == "" {
+		return 0, "", fmt.Errorf("listID parameter is required")
+	}
+
+	return siteID, listID, nil
+}
+
+func (h *ListHandlers) extractSearchQuery(r *http.Request) string {
+	// Try both query parameter and form value for flexibility
+	searchQuery := strings.TrimSpace(r.FormValue("search"))
+	if searchQuery == "" {
+		searchQuery = strings.TrimSpace(r.URL.Query().Get("search"))
+	}
+	return searchQuery
+}
+
+// extractRunLabel returns the normalized ?label= run label views are filtered by, empty for every run.
+func (h *ListHandlers) extractRunLabel(r *http.Request) (string, error) {
+	label := audit.NormalizeRunLabel(r.URL.Query().Get("label"))
+	if err := audit.ValidateRunLabel(label); err != nil {
+		return "", err
+	}
+	return label, nil
+}
+
+func (h *ListHandlers) parseAssignmentUniqueID(uniqueID string) (string, string, int, error) {
+	// Parse the unique ID format: assignment-{objectType}-{objectKey}-{index}
+	if !strings.HasPrefix(uniqueID, "assignment-") {
+		return "", "", 0, fmt.Errorf("invalid unique ID format")
+	}
+
+	parts := strings.Split(uniqueID[len("assignment-"):], "-")
+	if len(parts) < 3 {
+		return "", "", 0, fmt.Errorf("invalid unique ID format")
+	}
+
+	// The object type is the first part, the index is the last part, everything else is the object key
+	objectType := parts[0]
+	switch objectType {
+	case sharepoint.ObjectTypeWeb, sharepoint.ObjectTypeList, sharepoint.ObjectTypeItem:
+	default:
+		return "", "", 0, fmt.Errorf("invalid unique ID format: unknown object type %q", objectType)
+	}
+	indexStr := parts[len(parts)-1]
+	objectKey := strings.Join(parts[1:len(parts)-1], "-")
+
+	index, err := strconv.Atoi(indexStr)
+	if err != nil {
+		return "", "", 0, fmt.Errorf("invalid unique ID format: %w", err)
+	}
+
+	return objectType, objectKey, index, nil
+}
+
+// Helper methods for combining business logic calls
+
+
+// GetObjectAssignments handles GET requests for object assignments (HTMX partial)
+func (h *ListHandlers) GetObjectAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	objectType := chi.URLParam(r, "otype")
+	objectKey := chi.URLParam(r, "okey")
+	switch objectType {
+	case sharepoint.ObjectTypeWeb, sharepoint.ObjectTypeList, sharepoint.ObjectTypeItem:
+	default:
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("unknown object type %q", objectType))
+		return
+	}
+
+	// Get business data from audit-run-scoped service
+	resolved, err := scopedServices.SiteContentService.GetAssignmentsWithRootCause(ctx, siteID, objectType, objectKey)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	// Transform to view models using presenter
+	assignmentCollection := h.permissionPresenter.ToExpandableObjectAssignmentCollection(resolved, objectType, objectKey)
+	assignmentCollection.Columns = h.columnLayout(r, presenters.DataTableAssignments)
+
+	// Render response
+	RenderResponse(ctx, w, r, assignments.ObjectAssignments(siteID, scopedServices.AuditRunID, assignmentCollection, "No explicit role assignments found for this "+objectType+"."))
+}
+
+// GetWebAssignments handles GET requests for the role assignments of every web in the site (HTMX partial)
+func (h *ListHandlers) GetWebAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	webAssignments, err := scopedServices.SiteContentService.GetWebAssignmentsWithRootCause(ctx, siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	vm := h.permissionPresenter.ToWebAssignmentsViewModels(webAssignments)
+	columns := h.columnLayout(r, presenters.DataTableAssignments)
+	for i := range vm {
+		vm[i].Assignments.Columns = columns
+	}
+	RenderResponse(ctx, w, r, pages.WebAssignmentsList(siteID, scopedServices.AuditRunID, vm))
+}
+
+// GetSharingLinkMembers handles GET requests for sharing link members (HTMX partial)
+func (h *ListHandlers) GetSharingLinkMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	linkID := chi.URLParam(r, "linkID")
+	if linkID == "" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid link ID")
+		return
+	}
+
+	// Get business data from audit-run-scoped service
+	changes, err := scopedServices.SiteContentService.GetSharingLinkMemberChanges(ctx, siteID, linkID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	// Render response
+	RenderResponse(ctx, w, r, pages.SharingLinkMemberChangesList(h.permissionPresenter.ToSharingLinkMemberChanges(changes)))
+}
+
+// ToggleSharingLinkMembers handles POST requests for sharing link member visibility toggle
+func (h *ListHandlers) ToggleSharingLinkMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	linkID := chi.URLParam(r, "linkID")
+	if linkID == "" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid link ID")
+		return
+	}
+
+	// Get current state and determine action
+	currentState := r.FormValue("state")
+	isCurrentlyHidden := currentState == "hidden" || currentState == ""
 
 	if isCurrentlyHidden {
-		// Show assignments - load and return expandable row with proper template rendering
-		assignments, err := scopedServices.SiteContentService.GetAssignmentsForObject(ctx, siteID, "item", itemGUID)
+		// Show members with changes since the previous run - return expandable row with proper template rendering
+		changes, err := scopedServices.SiteContentService.GetSharingLinkMemberChanges(ctx, siteID, linkID)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			writeServiceError(w, r, err)
 			return
 		}
+		vm := h.permissionPresenter.ToSharingLinkMemberChanges(changes)
+
+		w.Header().Set("Content-Type", "text/html")
+
+		// Return visible expandable row with content
+		w.Write([]byte(`<tr id="members-row-` + linkID + `" data-state="visible" class="bg-slate-50" style="display: table-row;">
+			<td colspan="8" class="px-3 py-2 border-t">
+				<input type="hidden" name="state" value="visible">`))
+
+		RenderResponse(ctx, w, r, pages.SharingLinkMemberChangesList(vm))
+		w.Write([]byte(`</td></tr>`))
+
+		// Update button text with OOB swap
+		memberCount := len(changes.Members)
+		hideText := fmt.Sprintf("Hide %d members", memberCount)
+		siteIDStr := strconv.FormatInt(siteID, 10)
+		auditRunIDStrFormatted := strconv.FormatInt(scopedServices.AuditRunID, 10)
+		endpoint := fmt.Sprintf("/sites/%s/audit-runs/%s/sharing-links/%s/members/toggle", siteIDStr, auditRunIDStrFormatted, linkID)
+		w.Write([]byte(`<button id="btn-members-row-` + linkID + `" hx-swap-oob="true" class="text-blue-600 hover:text-blue-700 text-xs font-medium hover:underline" hx-post="` + endpoint + `" hx-target="#members-row-` + linkID + `" hx-swap="outerHTML" hx-include="#members-row-` + linkID + `">` + hideText + `</button>`))
+	} else {
+		// Get business data from audit-run-scoped service (needed for member count)
+		principals, err := scopedServices.SiteContentService.GetSharingLinkMembers(ctx, siteID, linkID)
+		if err != nil {
+			writeServiceError(w, r, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html")
+
+		// Hide members - return hidden empty row
+		w.Write([]byte(`<tr id="members-row-` + linkID + `" data-state="hidden" style="display: none;" class="bg-slate-50">
+			<td colspan="8" class="px-3 py-2 border-t">
+				<input type="hidden" name="state" value="hidden">
+			</td>
+		</tr>`))
+
+		// Update button text with OOB swap
+		memberCount := len(principals)
+		viewText := fmt.Sprintf("%d members", memberCount)
+		siteIDStr := strconv.FormatInt(siteID, 10)
+		auditRunIDStrFormatted := strconv.FormatInt(scopedServices.AuditRunID, 10)
+		endpoint := fmt.Sprintf("/sites/%s/audit-runs/%s/sharing-links/%s/members/toggle", siteIDStr, auditRunIDStrFormatted, linkID)
+		w.Write([]byte(`<button id="btn-members-row-` + linkID + `" hx-swap-oob="true" class="text-blue-600 hover:text-blue-700 text-xs font-medium hover:underline" hx-post="` + endpoint + `" hx-target="#members-row-` + linkID + `" hx-swap="outerHTML" hx-include="#members-row-` + linkID + `">` + viewText + `</button>`))
+	}
+}
+
+// ToggleItemAssignments handles POST requests for item assignment visibility toggle
+func (h *ListHandlers) ToggleItemAssignments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	itemGUID := chi.URLParam(r, "itemGUID")
+
+	// Get current state and determine action
+	currentState := r.FormValue("state")
+	isCurrentlyHidden := currentState == "hidden" || currentState == ""
+
+	w.Header().Set("Content-Type", "text/html")
 
-		vm := make([]presenters.Assignment, len(assignments))
-		for i, assignment := range assignments {
-			vm[i] = h.permissionPresenter.MapAssignmentToViewModel(assignment)
+	if isCurrentlyHidden {
+		// Show assignments - load and return expandable row with proper template rendering
+		resolved, err := scopedServices.SiteContentService.GetAssignmentsWithRootCause(ctx, siteID, sharepoint.ObjectTypeItem, itemGUID)
+		if err != nil {
+			writeServiceError(w, r, err)
+			return
 		}
 
-		assignmentCollection := h.permissionPresenter.NewAssignmentCollection(vm)
+		assignmentCollection := h.permissionPresenter.ToExpandableObjectAssignmentCollection(resolved, sharepoint.ObjectTypeItem, itemGUID)
+		assignmentCollection.Columns = h.columnLayout(r, presenters.DataTableAssignments)
 
 		// Return visible expandable row with content
 		w.Write([]byte(`<tr id="assign-row-` + itemGUID + `" data-state="visible" class="bg-slate-50" style="display: table-row;">
 			<td colspan="5" class="px-3 py-2 border-t">
 				<input type="hidden" name="state" value="visible">`))
 
-		RenderResponse(ctx, w, r, pages.AssignmentsList(assignmentCollection))
+		RenderResponse(ctx, w, r, assignments.ObjectAssignments(siteID, scopedServices.AuditRunID, assignmentCollection, "No explicit role assignments found for this item."))
 		w.Write([]byte(`</td></tr>`))
 
 		// Update button text with OOB swap
@@ -933,44 +1417,107 @@ func (h *ListHandlers) ToggleItemAssignments(w http.ResponseWriter, r *http.Requ
 }
 
 
-// getSitesWithLatestAuditRunMetadata gets all sites with their latest audit run metadata
-// instead of aggregated metadata across all audit runs
-func (h *ListHandlers) getSitesWithLatestAuditRunMetadata(ctx context.Context) ([]*contracts.SiteWithMetadata, error) {
-	// First, get all sites (without metadata aggregation)
-	allSitesData, err := h.siteBrowsingService.GetAllSitesWithMetadata(ctx)
+// ToggleItemDetails handles POST requests for the item metadata detail row toggle
+func (h *ListHandlers) ToggleItemDetails(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
 	if err != nil {
-		return nil, err
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
 	}
 
-	// For each site, get the latest audit run metadata using fully scoped services
-	var latestSitesData []*contracts.SiteWithMetadata
-	for _, siteData := range allSitesData {
-		// Use the scoped service factory to get latest audit run data
-		scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteData.Site.ID, "latest")
-		if err != nil {
-			// If no audit runs exist for this site, skip it or use the original data
-			latestSitesData = append(latestSitesData, siteData)
-			continue
-		}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	itemGUID := chi.URLParam(r, "itemGUID")
+	rowID := "detail-row-" + itemGUID
+	endpoint := fmt.Sprintf("/sites/%d/audit-runs/%s/items/%s/details/toggle", siteID, auditRunIDStr, itemGUID)
 
-		// Get site metadata for the latest audit run using fully scoped services
-		latestSiteData, err := scopedServices.SiteBrowsingService.GetSiteWithMetadata(ctx, siteData.Site.ID)
+	// Get current state and determine action
+	currentState := r.FormValue("state")
+	isCurrentlyHidden := currentState == "hidden" || currentState == ""
+
+	// The row spans every column of the items table it sits in
+	colspan := h.columnLayout(r, presenters.DataTableItems).Colspan()
+
+	if isCurrentlyHidden {
+		// Show details - load item metadata before writing anything so a missing item is a clean 404
+		detailsData, err := scopedServices.SiteContentService.GetItemDetails(ctx, siteID, itemGUID)
 		if err != nil {
-			// If error getting latest data, fall back to original
-			latestSitesData = append(latestSitesData, siteData)
-			continue
+			WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Item not found")
+			return
 		}
 
-		latestSitesData = append(latestSitesData, latestSiteData)
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<tr id="` + rowID + `" data-state="visible" class="bg-slate-50" style="display: table-row;">
+			<td colspan="` + colspan + `" class="px-3 py-2 border-t">
+				<input type="hidden" name="state" value="visible">`))
+
+		RenderResponse(ctx, w, r, pages.ItemDetailsPanel(h.permissionPresenter.ToItemDetails(detailsData)))
+		w.Write([]byte(`</td></tr>`))
+
+		// Update button text with OOB swap
+		w.Write([]byte(`<button id="btn-` + rowID + `" hx-swap-oob="true" class="text-blue-600 hover:text-blue-700 text-xs font-medium hover:underline" hx-post="` + endpoint + `" hx-target="#` + rowID + `" hx-swap="outerHTML" hx-include="#` + rowID + `">Hide details</button>`))
+	} else {
+		// Hide details - return hidden empty row
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<tr id="` + rowID + `" data-state="hidden" style="display: none;" class="bg-slate-50">
+			<td colspan="` + colspan + `" class="px-3 py-2 border-t">
+				<input type="hidden" name="state" value="hidden">
+			</td>
+		</tr>`))
+
+		// Update button text with OOB swap
+		w.Write([]byte(`<button id="btn-` + rowID + `" hx-swap-oob="true" class="text-blue-600 hover:text-blue-700 text-xs font-medium hover:underline" hx-post="` + endpoint + `" hx-target="#` + rowID + `" hx-swap="outerHTML" hx-include="#` + rowID + `">Details</button>`))
 	}
+}
 
-	return latestSitesData, nil
+// getSitesWithLatestAuditRunMetadata gets all sites with their latest audit run metadata
+// instead of aggregated metadata across all audit runs, only from runs tagged with label unless it is empty
+func (h *ListHandlers) getSitesWithLatestAuditRunMetadata(ctx context.Context, label string) ([]*contracts.SiteWithMetadata, error) {
+	return h.siteBrowsingService.GetAllSitesWithLatestRunMetadata(ctx, label)
+}
+
+// searchSitesWithLatestAuditRunMetadata filters the latest audit run metadata by site title or URL
+func (h *ListHandlers) searchSitesWithLatestAuditRunMetadata(ctx context.Context, searchQuery, label string) ([]*contracts.SiteWithMetadata, error) {
+	allSitesData, err := h.getSitesWithLatestAuditRunMetadata(ctx, label)
+	if err != nil || searchQuery == "" {
+		return allSitesData, err
+	}
+
+	// Simple case-insensitive contains search
+	searchLower := strings.ToLower(searchQuery)
+	var sitesData []*contracts.SiteWithMetadata
+	for _, siteData := range allSitesData {
+		if strings.Contains(strings.ToLower(siteData.Site.Title), searchLower) ||
+			strings.Contains(strings.ToLower(siteData.Site.URL), searchLower) {
+			sitesData = append(sitesData, siteData)
+		}
+	}
+	return sitesData, nil
 }
 
 // SwitchAuditRun handles audit run switching from the selector
 func (h *ListHandlers) SwitchAuditRun(w http.ResponseWriter, r *http.Request) {
-	siteID := chi.URLParam(r, "siteID")
-	
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
 	// Get selected run ID from form value (POST) or query parameter (GET)
 	selectedRunID := r.FormValue("audit_run_id")
 	if selectedRunID == "" {
@@ -979,11 +1526,222 @@ func (h *ListHandlers) SwitchAuditRun(w http.ResponseWriter, r *http.Request) {
 	if selectedRunID == "" {
 		selectedRunID = "latest"
 	}
-	
-	// Redirect to the same page but with the new audit run ID
-	// For now, redirect to lists page - could be made more sophisticated
-	redirectURL := fmt.Sprintf("/sites/%s/audit-runs/%s/lists", siteID, selectedRunID)
-	
+	if !audit.IsRunAlias(selectedRunID) {
+		if _, err := strconv.ParseInt(selectedRunID, 10, 64); err != nil {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid audit_run_id: %v", err))
+			return
+		}
+	}
+
+	// Open the same location (list, tab, filters) in the selected run, falling back to the lists page
+	redirectURL := fmt.Sprintf("/sites/%d/audit-runs/%s/lists", siteID, selectedRunID)
+	if location, listID, ok := runRelativeLocation(siteID, r.FormValue("return_path")); ok {
+		if listID == "" || h.listExistsInRun(ctx, siteID, selectedRunID, listID) {
+			redirectURL = fmt.Sprintf("/sites/%d/audit-runs/%s/%s", siteID, selectedRunID, location)
+		}
+	}
+
 	w.Header().Set("HX-Redirect", redirectURL)
 	w.WriteHeader(http.StatusOK)
 }
+
+// runRelativeLocation extracts the part of a run-scoped path after the audit run ID,
+// e.g. "tabs/{listID}/items?scope=all" from "/sites/1/audit-runs/7/tabs/{listID}/items?scope=all".
+// The list ID is returned when the location is within a list. Only list pages and tabs are accepted.
+func runRelativeLocation(siteID int64, returnPath string) (location string, listID string, ok bool) {
+	parsed, err := url.Parse(returnPath)
+	if err != nil || parsed.IsAbs() || parsed.Host != "" {
+		return "", "", false
+	}
+
+	prefix := fmt.Sprintf("/sites/%d/audit-runs/", siteID)
+	if !strings.HasPrefix(parsed.Path, prefix) {
+		return "", "", false
+	}
+
+	// Drop the audit run segment
+	segments := strings.Split(strings.TrimPrefix(parsed.Path, prefix), "/")
+	if len(segments) < 2 {
+		return "", "", false
+	}
+	rest := segments[1:]
+
+	switch {
+	case len(rest) == 1 && rest[0] == "lists":
+		// Site lists page
+	case len(rest) == 2 && rest[0] == "lists" && rest[1] != "":
+		listID = rest[1]
+	case len(rest) == 3 && rest[0] == "tabs" && rest[1] != "" && rest[2] != "":
+		listID = rest[1]
+	default:
+		return "", "", false
+	}
+
+	location = strings.Join(rest, "/")
+	if parsed.RawQuery != "" {
+		location += "?" + parsed.RawQuery
+	}
+	return location, listID, true
+}
+
+// listExistsInRun reports whether a list was collected in the given audit run.
+func (h *ListHandlers) listExistsInRun(ctx context.Context, siteID int64, auditRunIDStr string, listID string) bool {
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		return false
+	}
+	list, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID)
+	return err == nil && list != nil
+}
+
+// runContext builds the breadcrumb and run switcher context for a run-scoped page.
+// Lookup failures degrade to fallback labels rather than failing the page.
+func (h *ListHandlers) runContext(ctx context.Context, siteID, auditRunID int64) presenters.RunContext {
+	var site *sharepoint.Site
+	if siteData, err := h.siteBrowsingService.GetSiteWithMetadata(ctx, siteID); err == nil && siteData != nil {
+		site = siteData.Site
+	}
+
+	auditRuns, err := h.auditService.GetAuditRunsForSite(ctx, siteID, "", runSwitcherLimit)
+	if err != nil {
+		auditRuns = nil // Switcher just won't be populated
+	}
+
+	referenceRunID, err := h.auditService.GetPinnedAuditRunID(ctx, siteID)
+	if err != nil {
+		referenceRunID = 0 // Banner just won't mark the reference run
+	}
+
+	artifacts, err := h.runArtifactService.ListArtifacts(ctx, siteID, auditRunID)
+	if err != nil {
+		artifacts = nil // Banner just won't list the run's reports
+	}
+
+	rc := h.listPresenter.ToRunContext(site, siteID, auditRunID, referenceRunID, auditRuns)
+	rc.Artifacts = h.listPresenter.ToRunArtifactViews(artifacts)
+	if baseline, err := h.baselineService.GetBaseline(ctx, siteID); err == nil {
+		rc.BaselineRunID = baseline.AuditRunID // Otherwise the banner just won't offer drift
+	}
+	if drift, err := h.auditService.GetAuditRunSchemaDrift(ctx, siteID, auditRunID); err == nil {
+		rc.SchemaDrift = audit.SummarizeSchemaDrift(drift) // Otherwise the banner just won't warn
+	}
+	return rc
+}
+
+// PinAuditRun pins the audit run as the site's reference run.
+// POST /sites/{siteID}/audit-runs/{auditRunID}/pin
+func (h *ListHandlers) PinAuditRun(w http.ResponseWriter, r *http.Request) {
+	h.setReferenceRun(w, r, true)
+}
+
+// UnpinAuditRun clears the site's reference run.
+// POST /sites/{siteID}/audit-runs/{auditRunID}/unpin
+func (h *ListHandlers) UnpinAuditRun(w http.ResponseWriter, r *http.Request) {
+	h.setReferenceRun(w, r, false)
+}
+
+// setReferenceRun pins or unpins the requested run and refreshes the page so the banner reflects it.
+func (h *ListHandlers) setReferenceRun(w http.ResponseWriter, r *http.Request, pin bool) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Resolve aliases and validate the run belongs to the site
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	if pin {
+		err = h.auditService.PinAuditRun(ctx, siteID, scopedServices.AuditRunID)
+	} else {
+		err = h.auditService.UnpinAuditRun(ctx, siteID)
+	}
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	w.Header().Set("HX-Refresh", "true")
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// columnCookiePrefix prefixes the cookies holding each data table's column selection.
+const columnCookiePrefix = "spaudit_columns_"
+
+// columnCookieMaxAge keeps column selections for a year.
+const columnCookieMaxAge = 365 * 24 * 60 * 60
+
+// columnLayout returns the column selection saved in the browser for a data table.
+func (h *ListHandlers) columnLayout(r *http.Request, table presenters.DataTable) presenters.ColumnLayout {
+	cookie, err := r.Cookie(columnCookiePrefix + string(table))
+	if err != nil {
+		return presenters.DefaultColumnLayout(table)
+	}
+	return h.permissionPresenter.ParseColumnLayout(table, cookie.Value)
+}
+
+// SaveTableColumns saves the column picker selection for a data table and reloads the table.
+// The selection is kept in a cookie, so each browser keeps its own layout.
+// POST /preferences/columns/{table}
+func (h *ListHandlers) SaveTableColumns(w http.ResponseWriter, r *http.Request) {
+	table, ok := presenters.ParseDataTable(chi.URLParam(r, "table"))
+	if !ok {
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, fmt.Sprintf("unknown table %q", chi.URLParam(r, "table")))
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid form data")
+		return
+	}
+
+	returnTo := r.PostFormValue("return_to")
+	if !isLocalPath(returnTo) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "return_to must be a path on this site")
+		return
+	}
+
+	cookie := &http.Cookie{
+		Name:     columnCookiePrefix + string(table),
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if r.PostFormValue("reset") != "" {
+		cookie.MaxAge = -1
+	} else {
+		layout := h.permissionPresenter.ApplyColumnSelection(table, r.PostForm["order"], r.PostForm["columns"], r.PostFormValue("move"))
+		cookie.Value = layout.Encode()
+		cookie.MaxAge = columnCookieMaxAge
+	}
+	http.SetCookie(w, cookie)
+
+	if !IsHTMXRequest(r) {
+		http.Redirect(w, r, returnTo, http.StatusSeeOther)
+		return
+	}
+	location, _ := json.Marshal(map[string]string{"path": returnTo, "target": "#tab-body"})
+	w.Header().Set("HX-Location", string(location))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// isLocalPath reports whether returnPath is a path on this site, so following it cannot leave the site.
+func isLocalPath(returnPath string) bool {
+	parsed, err := url.Parse(returnPath)
+	if err != nil || parsed.IsAbs() || parsed.Host != "" {
+		return false
+	}
+	// Browsers treat backslashes as slashes, so "/\host" would be protocol-relative
+	return strings.HasPrefix(parsed.Path, "/") && !strings.Contains(returnPath, "\\")
+}
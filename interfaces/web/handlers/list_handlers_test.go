@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/interfaces/web/presenters"
+)
+
+func TestRunRelativeLocation(t *testing.T) {
+	tests := []struct {
+		name         string
+		returnPath   string
+		wantLocation string
+		wantListID   string
+		wantOK       bool
+	}{
+		{"lists page keeps search and sort", "/sites/3/audit-runs/7/lists?search=docs&sort=density", "lists?search=docs&sort=density", "", true},
+		{"list detail", "/sites/3/audit-runs/latest/lists/abc", "lists/abc", "abc", true},
+		{"list tab keeps filters", "/sites/3/audit-runs/7/tabs/abc/items?scope=all&kind=folder&page=3", "tabs/abc/items?scope=all&kind=folder&page=3", "abc", true},
+		{"other site", "/sites/4/audit-runs/7/lists", "", "", false},
+		{"absolute URL", "https://example.com/sites/3/audit-runs/7/lists", "", "", false},
+		{"protocol-relative URL", "//example.com/sites/3/audit-runs/7/lists", "", "", false},
+		{"export is not a location", "/sites/3/audit-runs/7/lists/export/extra", "", "", false},
+		{"empty", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, listID, ok := runRelativeLocation(3, tt.returnPath)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantLocation, location)
+			assert.Equal(t, tt.wantListID, listID)
+		})
+	}
+}
+
+func TestParseAssignmentUniqueID(t *testing.T) {
+	tests := []struct {
+		name           string
+		uniqueID       string
+		wantObjectType string
+		wantObjectKey  string
+		wantIndex      int
+		wantErr        bool
+	}{
+		{"list with dashed GUID", "assignment-list-1b2c-3d4e-12", "list", "1b2c-3d4e", 12, false},
+		{"web", "assignment-web-root-0", "web", "root", 0, false},
+		{"item", "assignment-item-abc-3", "item", "abc", 3, false},
+		{"unknown object type", "assignment-folder-abc-3", "", "", 0, true},
+		{"missing object key", "assignment-list-3", "", "", 0, true},
+		{"non-numeric index", "assignment-list-abc-x", "", "", 0, true},
+		{"wrong prefix", "row-list-abc-3", "", "", 0, true},
+	}
+
+	h := &ListHandlers{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objectType, objectKey, index, err := h.parseAssignmentUniqueID(tt.uniqueID)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantObjectType, objectType)
+			assert.Equal(t, tt.wantObjectKey, objectKey)
+			assert.Equal(t, tt.wantIndex, index)
+		})
+	}
+}
+
+func TestIsLocalPath(t *testing.T) {
+	assert.True(t, isLocalPath("/sites/3/audit-runs/7/tabs/abc/items?scope=all"))
+	assert.False(t, isLocalPath("https://example.com/sites/3"))
+	assert.False(t, isLocalPath("//example.com/sites/3"))
+	assert.False(t, isLocalPath("/\\example.com"))
+	assert.False(t, isLocalPath("sites/3"))
+	assert.False(t, isLocalPath(""))
+}
+
+func TestSaveTableColumns(t *testing.T) {
+	h := &ListHandlers{permissionPresenter: presenters.NewPermissionPresenter()}
+	router := chi.NewRouter()
+	router.Post("/preferences/columns/{table}", h.SaveTableColumns)
+
+	post := func(table string, form url.Values, htmx bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/preferences/columns/"+table, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if htmx {
+			req.Header.Set("HX-Request", "true")
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	form := url.Values{
+		"return_to": {"/sites/1/audit-runs/7/tabs/abc/items?scope=all"},
+		"order":     {"item", "permissions", "actions", "size", "modified"},
+		"columns":   {"item", "actions", "modified"},
+		"move":      {"actions:down"},
+	}
+
+	w := post("items", form, true)
+	require.Equal(t, http.StatusNoContent, w.Code)
+	assert.JSONEq(t, `{"path":"/sites/1/audit-runs/7/tabs/abc/items?scope=all","target":"#tab-body"}`, w.Header().Get("HX-Location"))
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "spaudit_columns_items", cookies[0].Name)
+	assert.Equal(t, "item,modified,actions", cookies[0].Value)
+
+	// The saved selection is what the items table renders on the next request
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookies[0])
+	assert.Equal(t, "item,modified,actions", h.columnLayout(req, presenters.DataTableItems).Encode())
+
+	// Without HTMX the browser is redirected back
+	w = post("items", form, false)
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "/sites/1/audit-runs/7/tabs/abc/items?scope=all", w.Header().Get("Location"))
+
+	// Resetting expires the cookie
+	w = post("items", url.Values{"return_to": form["return_to"], "reset": {"true"}}, true)
+	require.Len(t, w.Result().Cookies(), 1)
+	assert.Negative(t, w.Result().Cookies()[0].MaxAge)
+
+	assert.Equal(t, http.StatusNotFound, post("bogus", form, true).Code)
+	assert.Equal(t, http.StatusBadRequest, post("items", url.Values{"return_to": {"https://example.com/"}}, true).Code)
+}
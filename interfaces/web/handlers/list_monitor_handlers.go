@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// ListMonitorHandlers manages the lists kept under continuous monitoring.
+type ListMonitorHandlers struct {
+	monitorService *application.ListMonitorService
+	listPresenter  *presenters.ListPresenter
+	logger         *logging.Logger
+}
+
+// NewListMonitorHandlers creates a new list monitor handlers instance.
+func NewListMonitorHandlers(monitorService *application.ListMonitorService, listPresenter *presenters.ListPresenter) *ListMonitorHandlers {
+	return &ListMonitorHandlers{
+		monitorService: monitorService,
+		listPresenter:  listPresenter,
+		logger:         logging.Default().WithComponent("list_monitor_handler"),
+	}
+}
+
+// MonitorListRequest is the JSON body accepted by MonitorList.
+type MonitorListRequest struct {
+	SiteURL         string `json:"site_url"`
+	ListID          string `json:"list_id"`
+	IntervalSeconds int    `json:"interval_seconds,omitempty"` // Zero checks every LIST_MONITOR_INTERVAL
+}
+
+// GetListMonitors lists the monitored lists
+// GET /api/list-monitors
+func (h *ListMonitorHandlers) GetListMonitors(w http.ResponseWriter, r *http.Request) {
+	monitors, err := h.monitorService.GetMonitors(r.Context())
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToListMonitorViews(monitors)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// MonitorList starts monitoring a list and responds 201 with the monitor
+// POST /api/list-monitors
+func (h *ListMonitorHandlers) MonitorList(w http.ResponseWriter, r *http.Request) {
+	var req MonitorListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.IntervalSeconds < 0 {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "interval_seconds must not be negative")
+		return
+	}
+
+	monitor, err := h.monitorService.Monitor(r.Context(), req.SiteURL, req.ListID, time.Duration(req.IntervalSeconds)*time.Second)
+	if err != nil {
+		h.logger.Error("Failed to monitor list", "site_url", req.SiteURL, "list_id", req.ListID, "error", err)
+		writeListMonitorError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusCreated, h.listPresenter.ToListMonitorView(monitor)); err != nil {
+		h.logger.Error("Failed to encode list monitor response", "error", err)
+	}
+}
+
+// UnmonitorList stops monitoring a list
+// DELETE /api/list-monitors/{monitorID}
+func (h *ListMonitorHandlers) UnmonitorList(w http.ResponseWriter, r *http.Request) {
+	monitorID, err := strconv.ParseInt(chi.URLParam(r, "monitorID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid monitorID parameter")
+		return
+	}
+	if err := h.monitorService.Unmonitor(r.Context(), monitorID); err != nil {
+		writeListMonitorError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeListMonitorError writes a failure to manage a list monitor as a problem response. Anything but
+// a bad request or a known conflict is SharePoint failing to return the list or its change log.
+func writeListMonitorError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, application.ErrInvalidListMonitor):
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+	case errors.Is(err, application.ErrListMonitorNotFound):
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	case errors.Is(err, application.ErrListAlreadyMonitored):
+		WriteProblem(w, r, http.StatusConflict, ErrCodeMonitorConflict, err.Error())
+	default:
+		WriteProblem(w, r, http.StatusBadGateway, ErrCodeLiveLookupFailed, err.Error())
+	}
+}
@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// ListWebhookPath is where SharePoint posts list change notifications. WEBHOOK_PUBLIC_URL must
+// reach the app, as SharePoint calls it from the internet.
+const ListWebhookPath = "/webhooks/sharepoint"
+
+// maxNotificationBytes bounds the notification bodies read from the open webhook endpoint.
+const maxNotificationBytes = 1 << 20
+
+// ListWebhookHandlers receives SharePoint list change notifications and manages the subscriptions
+// that send them.
+type ListWebhookHandlers struct {
+	webhookService *application.ListWebhookService
+	listPresenter  *presenters.ListPresenter
+	logger         *logging.Logger
+}
+
+// NewListWebhookHandlers creates a new list webhook handlers instance.
+func NewListWebhookHandlers(webhookService *application.ListWebhookService, listPresenter *presenters.ListPresenter) *ListWebhookHandlers {
+	return &ListWebhookHandlers{
+		webhookService: webhookService,
+		listPresenter:  listPresenter,
+		logger:         logging.Default().WithComponent("list_webhook_handler"),
+	}
+}
+
+// SubscribeListRequest is the JSON body accepted by SubscribeList.
+type SubscribeListRequest struct {
+	SiteURL string `json:"site_url"`
+	ListID  string `json:"list_id"`
+}
+
+// ReceiveNotifications answers SharePoint's validation of a new subscription by echoing its token,
+// and accepts change notifications for micro-audits, responding before they are acted on.
+// POST /webhooks/sharepoint
+func (h *ListWebhookHandlers) ReceiveNotifications(w http.ResponseWriter, r *http.Request) {
+	if token := r.URL.Query().Get("validationtoken"); token != "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(token))
+		return
+	}
+
+	var body struct {
+		Value []sharepoint.ChangeNotification `json:"value"`
+	}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxNotificationBytes)).Decode(&body); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid notification body: %v", err))
+		return
+	}
+	accepted := h.webhookService.HandleNotifications(r.Context(), body.Value)
+	h.logger.Debug("Received list change notifications", "notifications", len(body.Value), "accepted", accepted)
+	w.WriteHeader(http.StatusOK)
+}
+
+// GetListSubscriptions lists the lists whose changes are micro-audited
+// GET /api/list-subscriptions
+func (h *ListWebhookHandlers) GetListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.webhookService.GetSubscriptions(r.Context())
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToListSubscriptionViews(subscriptions)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// SubscribeList subscribes to a list's changes and responds 201 with the subscription
+// POST /api/list-subscriptions
+func (h *ListWebhookHandlers) SubscribeList(w http.ResponseWriter, r *http.Request) {
+	var req SubscribeListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	subscription, err := h.webhookService.Subscribe(r.Context(), req.SiteURL, req.ListID)
+	if err != nil {
+		h.logger.Error("Failed to subscribe to list changes", "site_url", req.SiteURL, "list_id", req.ListID, "error", err)
+		writeListSubscriptionError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusCreated, h.listPresenter.ToListSubscriptionView(subscription)); err != nil {
+		h.logger.Error("Failed to encode list subscription response", "error", err)
+	}
+}
+
+// UnsubscribeList stops micro-audits of a list's changes
+// DELETE /api/list-subscriptions/{subscriptionID}
+func (h *ListWebhookHandlers) UnsubscribeList(w http.ResponseWriter, r *http.Request) {
+	if err := h.webhookService.Unsubscribe(r.Context(), chi.URLParam(r, "subscriptionID")); err != nil {
+		writeListSubscriptionError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeListSubscriptionError writes a failure to manage a list subscription as a problem response.
+// Anything but a bad request or a known conflict is SharePoint failing or refusing the request.
+func writeListSubscriptionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, application.ErrInvalidListSubscription):
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+	case errors.Is(err, application.ErrListSubscriptionNotFound):
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	case errors.Is(err, application.ErrListAlreadySubscribed) || errors.Is(err, application.ErrWebhooksNotConfigured):
+		WriteProblem(w, r, http.StatusConflict, ErrCodeSubscriptionConflict, err.Error())
+	default:
+		WriteProblem(w, r, http.StatusBadGateway, ErrCodeSubscriptionFailed, err.Error())
+	}
+}
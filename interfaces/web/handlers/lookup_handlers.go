@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/pages"
+	"spaudit/logging"
+)
+
+// LookupHandlers resolves pasted sharing URLs and item GUIDs to items.
+type LookupHandlers struct {
+	lookupService *application.ItemLookupService
+	listPresenter *presenters.ListPresenter
+	logger        *logging.Logger
+}
+
+// NewLookupHandlers creates a new lookup handlers instance.
+func NewLookupHandlers(lookupService *application.ItemLookupService, listPresenter *presenters.ListPresenter) *LookupHandlers {
+	return &LookupHandlers{
+		lookupService: lookupService,
+		listPresenter: listPresenter,
+		logger:        logging.Default().WithComponent("lookup_handler"),
+	}
+}
+
+// LookupItem resolves an item GUID, item URL or sharing link URL to the item, its list and its permissions.
+// GET /api/lookup?q={guid or url}&site_url={optional site for live lookups}
+func (h *LookupHandlers) LookupItem(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "q is required")
+		return
+	}
+
+	result, err := h.lookupService.LookupItem(r.Context(), query, r.URL.Query().Get("site_url"))
+	if err != nil {
+		status, code := lookupErrorStatus(err)
+		if status == http.StatusInternalServerError {
+			h.logger.Error("Item lookup failed", "query", query, "error", err)
+		}
+		WriteProblem(w, r, status, code, err.Error())
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToItemLookup(query, result)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// LookupPage renders the item lookup box, with the result when q is given.
+// GET /lookup
+func (h *LookupHandlers) LookupPage(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	siteURL := strings.TrimSpace(r.URL.Query().Get("site_url"))
+
+	var view *presenters.ItemLookup
+	if query != "" {
+		result, err := h.lookupService.LookupItem(r.Context(), query, siteURL)
+		if err == nil {
+			view = h.listPresenter.ToItemLookup(query, result)
+		} else if status, _ := lookupErrorStatus(err); status == http.StatusInternalServerError {
+			writeServiceError(w, r, err)
+			return
+		}
+	}
+
+	RenderResponse(r.Context(), w, r, pages.ItemLookupPage(query, siteURL, view))
+}
+
+// LookupResults renders a lookup result into the lookup page.
+// Lookups that find nothing render a message rather than an error status so HTMX swaps it in.
+// GET /lookup/results
+func (h *LookupHandlers) LookupResults(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	if query == "" {
+		RenderResponse(r.Context(), w, r, pages.ItemLookupError("Paste a sharing URL or item GUID."))
+		return
+	}
+
+	result, err := h.lookupService.LookupItem(r.Context(), query, r.URL.Query().Get("site_url"))
+	if err != nil {
+		if status, _ := lookupErrorStatus(err); status == http.StatusInternalServerError {
+			h.logger.Error("Item lookup failed", "query", query, "error", err)
+			writeServiceError(w, r, err)
+			return
+		}
+		RenderResponse(r.Context(), w, r, pages.ItemLookupError(err.Error()))
+		return
+	}
+
+	RenderResponse(r.Context(), w, r, pages.ItemLookupResults(h.listPresenter.ToItemLookup(query, result)))
+}
+
+// lookupErrorStatus maps item lookup errors to a response status and problem code.
+func lookupErrorStatus(err error) (int, string) {
+	switch {
+	case errors.Is(err, sharepoint.ErrInvalidItemReference):
+		return http.StatusBadRequest, ErrCodeInvalidParameter
+	case errors.Is(err, application.ErrItemNotFound):
+		return http.StatusNotFound, ErrCodeNotFound
+	case errors.Is(err, application.ErrLiveLookupFailed):
+		return http.StatusBadGateway, ErrCodeLiveLookupFailed
+	default:
+		return http.StatusInternalServerError, ErrCodeInternal
+	}
+}
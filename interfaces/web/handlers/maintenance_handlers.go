@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/components/dashboard"
+	"spaudit/logging"
+)
+
+// maintenanceRunsDefaultLimit is the number of maintenance runs listed when no limit is given.
+const maintenanceRunsDefaultLimit = 20
+
+// MaintenanceHandlers starts database maintenance and reports its runs.
+type MaintenanceHandlers struct {
+	maintenanceService *application.DatabaseMaintenanceService
+	sitePresenter      *presenters.SitePresenter
+	sseManager         *SSEManager
+	logger             *logging.Logger
+}
+
+// NewMaintenanceHandlers creates a new maintenance handlers instance.
+func NewMaintenanceHandlers(maintenanceService *application.DatabaseMaintenanceService, sitePresenter *presenters.SitePresenter, sseManager *SSEManager) *MaintenanceHandlers {
+	return &MaintenanceHandlers{
+		maintenanceService: maintenanceService,
+		sitePresenter:      sitePresenter,
+		sseManager:         sseManager,
+		logger:             logging.Default().WithComponent("maintenance_handler"),
+	}
+}
+
+// MaintenanceStarted acknowledges a queued maintenance job.
+type MaintenanceStarted struct {
+	JobID  string `json:"job_id"`
+	Status string `json:"status"`
+}
+
+// StartMaintenance queues database maintenance. The dashboard gets a status line, API clients 202
+// with the job to follow.
+// POST /api/maintenance
+func (h *MaintenanceHandlers) StartMaintenance(w http.ResponseWriter, r *http.Request) {
+	job, err := h.maintenanceService.StartMaintenance(audit.MaintenanceManual)
+	if err != nil {
+		busy := errors.Is(err, application.ErrMaintenanceBusy)
+		if !busy {
+			h.logger.Error("Failed to start database maintenance", "error", err)
+		}
+		switch {
+		case IsHTMXRequest(r):
+			RenderResponse(r.Context(), w, r, dashboard.MaintenanceStatus(err.Error(), false))
+		case busy:
+			WriteProblem(w, r, http.StatusConflict, ErrCodeMaintenanceConflict, err.Error())
+		default:
+			WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+		}
+		return
+	}
+
+	h.sseManager.BroadcastJobListUpdate()
+
+	if IsHTMXRequest(r) {
+		RenderResponse(r.Context(), w, r, dashboard.MaintenanceStatus("Maintenance started", true))
+		return
+	}
+	if err := WriteJSON(w, http.StatusAccepted, MaintenanceStarted{JobID: job.ID, Status: "queued"}); err != nil {
+		h.logger.Error("Failed to encode maintenance response", "error", err)
+	}
+}
+
+// ListMaintenanceRuns lists the most recent database maintenance runs, newest first.
+// GET /api/maintenance/runs?limit={n}
+func (h *MaintenanceHandlers) ListMaintenanceRuns(w http.ResponseWriter, r *http.Request) {
+	limit := int64(maintenanceRunsDefaultLimit)
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 1 || parsed > apiMaxPageSize {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "limit must be between 1 and "+strconv.Itoa(apiMaxPageSize))
+			return
+		}
+		limit = parsed
+	}
+
+	runs, err := h.maintenanceService.ListRuns(r.Context(), limit)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.sitePresenter.ToMaintenanceRunViews(runs)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
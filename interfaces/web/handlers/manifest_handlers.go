@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"spaudit/application"
+)
+
+// GetRunManifest returns the tamper-evidence manifest sealed for an audit run
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/manifest
+func (h *ListHandlers) GetRunManifest(w http.ResponseWriter, r *http.Request) {
+	siteID, auditRunID, ok := h.resolveRunRequest(w, r)
+	if !ok {
+		return
+	}
+
+	manifest, err := h.runManifestService.GetManifest(r.Context(), siteID, auditRunID)
+	if err != nil {
+		writeManifestError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToRunManifestView(manifest)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// SealAuditRun seals an audit run that has no manifest yet, e.g. one completed before manifests existed.
+// Runs are sealed automatically when their audit completes, and a manifest is never replaced.
+// POST /api/sites/{siteID}/audit-runs/{auditRunID}/manifest
+func (h *ListHandlers) SealAuditRun(w http.ResponseWriter, r *http.Request) {
+	siteID, auditRunID, ok := h.resolveRunRequest(w, r)
+	if !ok {
+		return
+	}
+
+	manifest, err := h.runManifestService.SealAuditRun(r.Context(), siteID, auditRunID)
+	if err != nil {
+		writeManifestError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusCreated, h.listPresenter.ToRunManifestView(manifest)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// VerifyAuditRun recomputes an audit run's content hashes and compares them with its manifest.
+// A run that fails verification is still a 200 response with verified set to false.
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/manifest/verify
+func (h *ListHandlers) VerifyAuditRun(w http.ResponseWriter, r *http.Request) {
+	siteID, auditRunID, ok := h.resolveRunRequest(w, r)
+	if !ok {
+		return
+	}
+
+	verification, err := h.runManifestService.VerifyAuditRun(r.Context(), siteID, auditRunID)
+	if err != nil {
+		writeManifestError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToManifestVerificationView(verification)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// resolveRunRequest resolves the site and audit run of a run-level API request, writing a problem on failure.
+func (h *ListHandlers) resolveRunRequest(w http.ResponseWriter, r *http.Request) (int64, int64, bool) {
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return 0, 0, false
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return 0, 0, false
+	}
+
+	// Resolve aliases and validate the run belongs to the site
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(r.Context(), siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return 0, 0, false
+	}
+
+	return siteID, scopedServices.AuditRunID, true
+}
+
+// writeManifestError writes a run manifest failure as a problem response.
+func writeManifestError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, application.ErrRunNotSealed):
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	case errors.Is(err, application.ErrRunAlreadySealed):
+		WriteProblem(w, r, http.StatusConflict, ErrCodeManifestConflict, err.Error())
+	default:
+		writeServiceError(w, r, err)
+	}
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// MigrationRunRequest names one side of a migration assessment.
+type MigrationRunRequest struct {
+	SiteID   int64  `json:"site_id"`
+	AuditRun string `json:"audit_run"` // Audit run ID or alias, latest when empty
+}
+
+// PrefixMappingRequest rewrites a source prefix to a target prefix.
+type PrefixMappingRequest struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// MigrationAssessmentRequest is the JSON body of a migration assessment.
+type MigrationAssessmentRequest struct {
+	Source            MigrationRunRequest    `json:"source"`
+	Target            MigrationRunRequest    `json:"target"`
+	URLMappings       []PrefixMappingRequest `json:"url_mappings"`
+	PrincipalMappings []PrefixMappingRequest `json:"principal_mappings"`
+}
+
+// MigrationHandlers compares a migration's source site with its target site.
+type MigrationHandlers struct {
+	migrationService *application.MigrationAssessmentService
+	listPresenter    *presenters.ListPresenter
+	logger           *logging.Logger
+}
+
+// NewMigrationHandlers creates a new migration handlers instance.
+func NewMigrationHandlers(migrationService *application.MigrationAssessmentService, listPresenter *presenters.ListPresenter) *MigrationHandlers {
+	return &MigrationHandlers{
+		migrationService: migrationService,
+		listPresenter:    listPresenter,
+		logger:           logging.Default().WithComponent("migration_handler"),
+	}
+}
+
+// AssessMigration compares a run of a migration's source site with a run of its target site, reporting
+// permissions and sharing links that did not carry over
+// POST /api/migration-assessments
+func (h *MigrationHandlers) AssessMigration(w http.ResponseWriter, r *http.Request) {
+	var req MigrationAssessmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if req.Source.SiteID <= 0 || req.Target.SiteID <= 0 {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "source.site_id and target.site_id are required")
+		return
+	}
+
+	result, err := h.migrationService.Assess(r.Context(),
+		migrationRunRef(req.Source), migrationRunRef(req.Target),
+		audit.MigrationMapping{URLs: prefixMappings(req.URLMappings), Principals: prefixMappings(req.PrincipalMappings)})
+	if err != nil {
+		if errors.Is(err, application.ErrInvalidMigrationMapping) {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+			return
+		}
+		if !isNotFoundError(err) {
+			h.logger.Error("Migration assessment failed", "source_site_id", req.Source.SiteID, "target_site_id", req.Target.SiteID, "error", err)
+		}
+		writeServiceError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToMigrationAssessmentView(result)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// migrationRunRef converts one side of a request, defaulting to the site's latest run.
+func migrationRunRef(req MigrationRunRequest) application.MigrationRunRef {
+	run := req.AuditRun
+	if run == "" {
+		run = audit.RunAliasLatest
+	}
+	return application.MigrationRunRef{SiteID: req.SiteID, AuditRun: run}
+}
+
+// prefixMappings converts requested mappings to domain mappings.
+func prefixMappings(reqs []PrefixMappingRequest) []audit.PrefixMapping {
+	mappings := make([]audit.PrefixMapping, len(reqs))
+	for i, req := range reqs {
+		mappings[i] = audit.PrefixMapping{Source: req.Source, Target: req.Target}
+	}
+	return mappings
+}
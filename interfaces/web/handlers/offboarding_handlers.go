@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// offboardingChecksDefaultLimit is the number of offboarding checks listed when no limit is given.
+const offboardingChecksDefaultLimit = 20
+
+// OffboardingHandlers runs offboarding checks and tracks the remediation actions they queue.
+type OffboardingHandlers struct {
+	offboardingService *application.OffboardingService
+	listPresenter      *presenters.ListPresenter
+	sseManager         *SSEManager
+	logger             *logging.Logger
+}
+
+// NewOffboardingHandlers creates a new offboarding handlers instance.
+func NewOffboardingHandlers(offboardingService *application.OffboardingService, listPresenter *presenters.ListPresenter, sseManager *SSEManager) *OffboardingHandlers {
+	return &OffboardingHandlers{
+		offboardingService: offboardingService,
+		listPresenter:      listPresenter,
+		sseManager:         sseManager,
+		logger:             logging.Default().WithComponent("offboarding_handler"),
+	}
+}
+
+// StartOffboardingCheckRequest is the JSON body accepted by StartOffboardingCheck.
+type StartOffboardingCheckRequest struct {
+	Identity  string `json:"identity"`  // The leaver's UPN, claims login name or email address
+	Remediate bool   `json:"remediate"` // Queue remediation actions for what is found
+}
+
+// UpdateOffboardingRemediationRequest is the JSON body accepted by UpdateOffboardingRemediation.
+type UpdateOffboardingRemediationRequest struct {
+	Status string `json:"status"` // queued, completed or dismissed
+}
+
+// StartOffboardingCheck queues a check of what a leaver still has across the latest runs of every
+// audited site, and responds 202 with the check and the job to follow
+// POST /api/offboarding-checks
+func (h *OffboardingHandlers) StartOffboardingCheck(w http.ResponseWriter, r *http.Request) {
+	var req StartOffboardingCheckRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	check, err := h.offboardingService.StartCheck(r.Context(), req.Identity, req.Remediate)
+	if err != nil {
+		writeOffboardingError(w, r, err)
+		return
+	}
+
+	h.sseManager.BroadcastJobListUpdate()
+	if err := WriteJSON(w, http.StatusAccepted, h.listPresenter.ToOffboardingCheckView(check)); err != nil {
+		h.logger.Error("Failed to encode offboarding check response", "check_id", check.ID, "error", err)
+	}
+}
+
+// ListOffboardingChecks lists the most recent offboarding checks, newest first
+// GET /api/offboarding-checks?limit={n}
+func (h *OffboardingHandlers) ListOffboardingChecks(w http.ResponseWriter, r *http.Request) {
+	limit := int64(offboardingChecksDefaultLimit)
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 1 || parsed > apiMaxPageSize {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "limit must be between 1 and "+strconv.Itoa(apiMaxPageSize))
+			return
+		}
+		limit = parsed
+	}
+
+	checks, err := h.offboardingService.ListChecks(r.Context(), limit)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToOffboardingCheckViews(checks)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// GetOffboardingCheck returns an offboarding check with the remediation actions it queued
+// GET /api/offboarding-checks/{checkID}
+func (h *OffboardingHandlers) GetOffboardingCheck(w http.ResponseWriter, r *http.Request) {
+	checkID, err := strconv.ParseInt(chi.URLParam(r, "checkID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid checkID parameter")
+		return
+	}
+
+	check, err := h.offboardingService.GetCheck(r.Context(), checkID)
+	if err != nil {
+		writeOffboardingError(w, r, err)
+		return
+	}
+	remediations, err := h.offboardingService.GetRemediations(r.Context(), checkID)
+	if err != nil {
+		writeOffboardingError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToOffboardingCheckDetailView(check, remediations)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// GetOffboardingReport returns what a completed offboarding check found, as JSON or as a CSV download
+// GET /api/offboarding-checks/{checkID}/report?format=csv
+func (h *OffboardingHandlers) GetOffboardingReport(w http.ResponseWriter, r *http.Request) {
+	checkID, err := strconv.ParseInt(chi.URLParam(r, "checkID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid checkID parameter")
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "format must be json or csv")
+		return
+	}
+
+	report, err := h.offboardingService.GetReport(r.Context(), checkID)
+	if err != nil {
+		writeOffboardingError(w, r, err)
+		return
+	}
+
+	if format == "csv" {
+		content, err := encodeCSV(h.listPresenter.OffboardingReportToCSV(report))
+		if err != nil {
+			WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to build CSV")
+			return
+		}
+		writeDownload(w, fmt.Sprintf("offboarding-check%d.csv", checkID), csvContentType, content)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, report); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// UpdateOffboardingRemediation records the outcome of a remediation action once an administrator
+// carried it out or dismissed it
+// PUT /api/offboarding-checks/{checkID}/remediations/{remediationID}
+func (h *OffboardingHandlers) UpdateOffboardingRemediation(w http.ResponseWriter, r *http.Request) {
+	checkID, err := strconv.ParseInt(chi.URLParam(r, "checkID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid checkID parameter")
+		return
+	}
+	remediationID, err := strconv.ParseInt(chi.URLParam(r, "remediationID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid remediationID parameter")
+		return
+	}
+	var req UpdateOffboardingRemediationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	if err := h.offboardingService.UpdateRemediationStatus(r.Context(), checkID, remediationID, req.Status); err != nil {
+		writeOffboardingError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeOffboardingError writes a failure to run or read an offboarding check as a problem response.
+func writeOffboardingError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, application.ErrInvalidPrincipalIdentity):
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "identity is required")
+	case errors.Is(err, application.ErrInvalidRemediationStatus):
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+	case errors.Is(err, application.ErrOffboardingCheckNotFound), errors.Is(err, application.ErrOffboardingRemediationNotFound):
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	case errors.Is(err, application.ErrOffboardingReportNotReady):
+		WriteProblem(w, r, http.StatusConflict, ErrCodeReportNotReady, err.Error())
+	default:
+		writeServiceError(w, r, err)
+	}
+}
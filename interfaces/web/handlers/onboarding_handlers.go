@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/pages"
+	"spaudit/logging"
+)
+
+// OnboardingHandlers drive the first-run setup wizard: credentials check, add a site, choose a preset
+// and start the first audit.
+type OnboardingHandlers struct {
+	auditService     application.AuditService
+	sitePresenter    *presenters.SitePresenter
+	sseManager       *SSEManager
+	checkCredentials func() error
+	logger           *logging.Logger
+}
+
+// NewOnboardingHandlers creates a new onboarding handlers instance.
+func NewOnboardingHandlers(
+	auditService application.AuditService,
+	sitePresenter *presenters.SitePresenter,
+	sseManager *SSEManager,
+	checkCredentials func() error,
+) *OnboardingHandlers {
+	return &OnboardingHandlers{
+		auditService:     auditService,
+		sitePresenter:    sitePresenter,
+		sseManager:       sseManager,
+		checkCredentials: checkCredentials,
+		logger:           logging.Default().WithComponent("onboarding_handler"),
+	}
+}
+
+// SetupPage renders the setup wizard at its credentials step.
+// GET /setup
+func (h *OnboardingHandlers) SetupPage(w http.ResponseWriter, r *http.Request) {
+	RenderResponse(r.Context(), w, r, pages.OnboardingPage(h.credentialsStep()))
+}
+
+// CheckCredentials re-runs the credentials check.
+// GET /setup/credentials
+func (h *OnboardingHandlers) CheckCredentials(w http.ResponseWriter, r *http.Request) {
+	RenderResponse(r.Context(), w, r, pages.OnboardingStep(h.credentialsStep()))
+}
+
+// SiteStep renders the site form, prefilled with the site and label chosen so far.
+// GET /setup/site
+func (h *OnboardingHandlers) SiteStep(w http.ResponseWriter, r *http.Request) {
+	vm := h.sitePresenter.ToOnboardingVM(presenters.OnboardingStepSite, r.FormValue("site_url"), r.FormValue("label"), "")
+	RenderResponse(r.Context(), w, r, pages.OnboardingStep(vm))
+}
+
+// SubmitSite validates the site and label, moving on to the preset step.
+// Rejected forms are rendered with 200 OK so HTMX swaps them in.
+// POST /setup/site
+func (h *OnboardingHandlers) SubmitSite(w http.ResponseWriter, r *http.Request) {
+	siteURL := strings.TrimSpace(r.FormValue("site_url"))
+	label := audit.NormalizeRunLabel(r.FormValue("label"))
+
+	vm := h.sitePresenter.ToOnboardingVM(presenters.OnboardingStepPreset, siteURL, label, "")
+	if err := validateOnboardingSite(siteURL, label); err != nil {
+		vm.Step = presenters.OnboardingStepSite
+		vm.Error = err.Error()
+	}
+	RenderResponse(r.Context(), w, r, pages.OnboardingStep(vm))
+}
+
+// StartAudit queues the site's first audit with the chosen preset.
+// Rejected forms are rendered with 200 OK so HTMX swaps them in.
+// POST /setup/audit
+func (h *OnboardingHandlers) StartAudit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	siteURL := strings.TrimSpace(r.FormValue("site_url"))
+	label := audit.NormalizeRunLabel(r.FormValue("label"))
+	preset := r.FormValue("preset")
+
+	vm := h.sitePresenter.ToOnboardingVM(presenters.OnboardingStepPreset, siteURL, label, preset)
+	if err := validateOnboardingSite(siteURL, label); err != nil {
+		vm.Step = presenters.OnboardingStepSite
+		vm.Error = err.Error()
+		RenderResponse(ctx, w, r, pages.OnboardingStep(vm))
+		return
+	}
+
+	parameters, err := audit.PresetParameters(vm.Preset)
+	if err != nil {
+		vm.Error = err.Error()
+		RenderResponse(ctx, w, r, pages.OnboardingStep(vm))
+		return
+	}
+	parameters.Label = label
+
+	request, err := h.auditService.QueueAudit(ctx, siteURL, parameters)
+	if err != nil {
+		h.logger.Error("Failed to queue first audit", "site_url", siteURL, "preset", vm.Preset, "error", err)
+		vm.Error = err.Error()
+		RenderResponse(ctx, w, r, pages.OnboardingStep(vm))
+		return
+	}
+
+	h.logger.Info("First audit queued", "request_id", request.ID, "site_url", siteURL, "preset", vm.Preset)
+	h.sseManager.BroadcastJobListUpdate()
+
+	vm.Step = presenters.OnboardingStepStarted
+	vm.JobID = request.ID
+	RenderResponse(ctx, w, r, pages.OnboardingStep(vm))
+}
+
+// credentialsStep builds the credentials step from a fresh credentials check.
+func (h *OnboardingHandlers) credentialsStep() presenters.OnboardingVM {
+	vm := h.sitePresenter.ToOnboardingVM(presenters.OnboardingStepCredentials, "", "", "")
+	if err := h.checkCredentials(); err != nil {
+		vm.CredentialsError = err.Error()
+	} else {
+		vm.CredentialsOK = true
+	}
+	return vm
+}
+
+// validateOnboardingSite validates the site URL and normalized run label submitted to the wizard.
+func validateOnboardingSite(siteURL, label string) error {
+	if err := audit.ValidateSiteURL(siteURL); err != nil {
+		return err
+	}
+	return audit.ValidateRunLabel(label)
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/test/mocks"
+)
+
+func newTestOnboardingHandlers(t *testing.T, auditService *mocks.MockAuditService, credentialsErr error) *OnboardingHandlers {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return NewOnboardingHandlers(auditService, presenters.NewSitePresenter(), NewSSEManager(ctx), func() error {
+		return credentialsErr
+	})
+}
+
+func postForm(handler http.HandlerFunc, target string, form url.Values) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("HX-Request", "true")
+	w := httptest.NewRecorder()
+	handler(w, req)
+	return w
+}
+
+func TestOnboardingHandlers_SetupPage_ReportsCredentials(t *testing.T) {
+	h := newTestOnboardingHandlers(t, &mocks.MockAuditService{}, errors.New("missing SP_TENANT_ID"))
+
+	w := httptest.NewRecorder()
+	h.SetupPage(w, httptest.NewRequest(http.MethodGet, "/setup", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "missing SP_TENANT_ID")
+	assert.Contains(t, w.Body.String(), "Check again")
+}
+
+func TestOnboardingHandlers_SubmitSite(t *testing.T) {
+	h := newTestOnboardingHandlers(t, &mocks.MockAuditService{}, nil)
+
+	t.Run("valid site moves to the preset step", func(t *testing.T) {
+		w := postForm(h.SubmitSite, "/setup/site", url.Values{"site_url": {"https://contoso.sharepoint.com/sites/Finance"}, "label": {" Prod "}})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "Start first audit")
+		assert.Contains(t, w.Body.String(), `value="prod"`)
+	})
+
+	t.Run("invalid site stays on the site step", func(t *testing.T) {
+		w := postForm(h.SubmitSite, "/setup/site", url.Values{"site_url": {"contoso"}})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "absolute http(s) URL")
+		assert.NotContains(t, w.Body.String(), "Start first audit")
+	})
+}
+
+func TestOnboardingHandlers_StartAudit(t *testing.T) {
+	siteURL := "https://contoso.sharepoint.com/sites/Finance"
+
+	t.Run("queues the audit with the preset's parameters", func(t *testing.T) {
+		auditService := &mocks.MockAuditService{}
+		auditService.On("QueueAudit", mock.Anything, siteURL, mock.MatchedBy(func(p *audit.AuditParameters) bool {
+			return !p.ScanIndividualItems && !p.IncludeSharing && p.Label == "prod"
+		})).Return(&audit.AuditRequest{ID: "job-1", SiteURL: siteURL}, nil)
+		h := newTestOnboardingHandlers(t, auditService, nil)
+
+		w := postForm(h.StartAudit, "/setup/audit", url.Values{"site_url": {siteURL}, "label": {"prod"}, "preset": {audit.PresetQuick}})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "job-1")
+		auditService.AssertExpectations(t)
+	})
+
+	t.Run("unknown preset is rejected", func(t *testing.T) {
+		auditService := &mocks.MockAuditService{}
+		h := newTestOnboardingHandlers(t, auditService, nil)
+
+		w := postForm(h.StartAudit, "/setup/audit", url.Values{"site_url": {siteURL}, "preset": {"everything"}})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "everything")
+		auditService.AssertNotCalled(t, "QueueAudit", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("queue failure is shown on the preset step", func(t *testing.T) {
+		auditService := &mocks.MockAuditService{}
+		auditService.On("QueueAudit", mock.Anything, siteURL, mock.Anything).Return(nil, errors.New("audit already queued"))
+		h := newTestOnboardingHandlers(t, auditService, nil)
+
+		w := postForm(h.StartAudit, "/setup/audit", url.Values{"site_url": {siteURL}, "preset": {audit.PresetStandard}})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "audit already queued")
+		assert.Contains(t, w.Body.String(), "Start first audit")
+	})
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// maxOrgUnitImportBytes bounds a CSV of organizational unit mappings.
+const maxOrgUnitImportBytes = 5 << 20
+
+// OrgUnitHandlers maps sites to organizational units and reports a scorecard per unit.
+type OrgUnitHandlers struct {
+	orgUnitService *application.OrgUnitService
+	listPresenter  *presenters.ListPresenter
+	logger         *logging.Logger
+}
+
+// NewOrgUnitHandlers creates a new org unit handlers instance.
+func NewOrgUnitHandlers(orgUnitService *application.OrgUnitService, listPresenter *presenters.ListPresenter) *OrgUnitHandlers {
+	return &OrgUnitHandlers{
+		orgUnitService: orgUnitService,
+		listPresenter:  listPresenter,
+		logger:         logging.Default().WithComponent("org_unit_handler"),
+	}
+}
+
+// SetOrgUnitMappingRequest is the JSON body accepted by SetOrgUnitMapping.
+type SetOrgUnitMappingRequest struct {
+	MatchType  string `json:"match_type"`  // site, hub or label
+	MatchValue string `json:"match_value"` // Site URL, hub site ID or URL, or run label
+	OrgUnit    string `json:"org_unit"`
+}
+
+// GetOrgUnitScorecards aggregates the risk, external access and audit freshness of every
+// organizational unit's sites, as JSON or as a CSV or XLSX export for leadership
+// GET /api/org-units/scorecards?format=xlsx&stale_days=30&hub_fallback=true
+func (h *OrgUnitHandlers) GetOrgUnitScorecards(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" && format != "xlsx" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "format must be json, csv or xlsx")
+		return
+	}
+
+	var filter application.OrgUnitScorecardFilter
+	if value := query.Get("stale_days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 1 {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "stale_days must be a positive number of days")
+			return
+		}
+		filter.StaleAfter = time.Duration(days) * 24 * time.Hour
+	}
+	if value := query.Get("hub_fallback"); value != "" {
+		fallback, err := strconv.ParseBool(value)
+		if err != nil {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "hub_fallback must be true or false")
+			return
+		}
+		filter.HubFallback = fallback
+	}
+
+	result, err := h.orgUnitService.Scorecards(r.Context(), filter)
+	if err != nil {
+		h.logger.Error("Org unit scorecards failed", "error", err)
+		writeServiceError(w, r, err)
+		return
+	}
+
+	filename := fmt.Sprintf("org-unit-scorecards-%s.%s", result.GeneratedAt.Format("2006-01-02"), format)
+	switch format {
+	case "csv":
+		content, err := encodeCSV(h.listPresenter.OrgUnitScorecardsToCSV(result))
+		if err != nil {
+			WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to build CSV")
+			return
+		}
+		writeDownload(w, filename, csvContentType, content)
+	case "xlsx":
+		var buf bytes.Buffer
+		if err := encodeXLSX(&buf, "Org Units", h.listPresenter.OrgUnitScorecardsToCSV(result), nil); err != nil {
+			WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to build spreadsheet")
+			return
+		}
+		writeDownload(w, filename, xlsxContentType, buf.Bytes())
+	default:
+		if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToOrgUnitScorecardsView(result)); err != nil {
+			WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+		}
+	}
+}
+
+// GetOrgUnitMappings lists the organizational unit mappings
+// GET /api/org-units/mappings
+func (h *OrgUnitHandlers) GetOrgUnitMappings(w http.ResponseWriter, r *http.Request) {
+	mappings, err := h.orgUnitService.GetMappings(r.Context())
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToOrgUnitMappingViews(mappings)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// SetOrgUnitMapping maps the sites matching a site URL, hub or run label to an organizational unit
+// and responds with the mapping
+// POST /api/org-units/mappings
+func (h *OrgUnitHandlers) SetOrgUnitMapping(w http.ResponseWriter, r *http.Request) {
+	var req SetOrgUnitMappingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	mapping, err := h.orgUnitService.SetMapping(r.Context(), req.MatchType, req.MatchValue, req.OrgUnit)
+	if err != nil {
+		writeOrgUnitError(w, r, err)
+		return
+	}
+	view := h.listPresenter.ToOrgUnitMappingViews([]*audit.OrgUnitMapping{mapping})[0]
+	if err := WriteJSON(w, http.StatusOK, view); err != nil {
+		h.logger.Error("Failed to encode org unit mapping response", "error", err)
+	}
+}
+
+// ImportOrgUnitMappings stores the mappings of a CSV sheet with match_type, match_value and org_unit
+// columns, or site_url and org_unit, replacing every existing mapping with replace=true
+// POST /api/org-units/mappings/import?replace=true
+func (h *OrgUnitHandlers) ImportOrgUnitMappings(w http.ResponseWriter, r *http.Request) {
+	replace := false
+	if value := r.URL.Query().Get("replace"); value != "" {
+		var err error
+		if replace, err = strconv.ParseBool(value); err != nil {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "replace must be true or false")
+			return
+		}
+	}
+
+	mappings, err := h.orgUnitService.ImportMappings(r.Context(), http.MaxBytesReader(w, r.Body, maxOrgUnitImportBytes), replace)
+	if err != nil {
+		h.logger.Error("Failed to import org unit mappings", "error", err)
+		writeOrgUnitError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToOrgUnitMappingViews(mappings)); err != nil {
+		h.logger.Error("Failed to encode org unit mappings response", "error", err)
+	}
+}
+
+// DeleteOrgUnitMapping deletes an organizational unit mapping
+// DELETE /api/org-units/mappings/{mappingID}
+func (h *OrgUnitHandlers) DeleteOrgUnitMapping(w http.ResponseWriter, r *http.Request) {
+	mappingID, err := strconv.ParseInt(chi.URLParam(r, "mappingID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid mappingID parameter")
+		return
+	}
+	if err := h.orgUnitService.DeleteMapping(r.Context(), mappingID); err != nil {
+		writeOrgUnitError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeOrgUnitError writes a failure to manage organizational unit mappings as a problem response.
+func writeOrgUnitError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, application.ErrInvalidOrgUnitMapping):
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+	case errors.Is(err, application.ErrOrgUnitMappingNotFound):
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	default:
+		writeServiceError(w, r, err)
+	}
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+)
+
+// GetPageLibraryExposure returns the anyone links and external contributors of an audit run's Site Pages
+// and Site Assets libraries, whose content is embedded in and linked from the site's pages
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/page-libraries
+func (h *ListHandlers) GetPageLibraryExposure(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	exposure, err := scopedServices.SiteContentService.GetPageLibraryExposure(ctx, siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	h.runSeverities(ctx, siteID, scopedServices.AuditRunID).RatePageLibraryExposure(exposure)
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToPageLibraryExposureView(siteID, scopedServices.AuditRunID, exposure)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/pages"
+	"spaudit/logging"
+)
+
+// PermissionExceptionHandlers manages the accepted exceptions of a site's lists.
+type PermissionExceptionHandlers struct {
+	exceptionService *application.PermissionExceptionService
+	listPresenter    *presenters.ListPresenter
+	logger           *logging.Logger
+}
+
+// NewPermissionExceptionHandlers creates a new permission exception handlers instance.
+func NewPermissionExceptionHandlers(exceptionService *application.PermissionExceptionService, listPresenter *presenters.ListPresenter) *PermissionExceptionHandlers {
+	return &PermissionExceptionHandlers{
+		exceptionService: exceptionService,
+		listPresenter:    listPresenter,
+		logger:           logging.Default().WithComponent("permission_exception_handler"),
+	}
+}
+
+// AcceptPermissionExceptionRequest is the JSON body accepted by AcceptListException.
+type AcceptPermissionExceptionRequest struct {
+	Kind          string    `json:"kind"`       // sharing_link, assignment or unique_item
+	ObjectKey     string    `json:"object_key"` // Link ID or item GUID; for assignments the list ID or item GUID
+	PrincipalID   int64     `json:"principal_id,omitempty"`
+	RoleDefID     int64     `json:"role_def_id,omitempty"`
+	Justification string    `json:"justification"`
+	Owner         string    `json:"owner,omitempty"` // Reminded as the exception nears expiry
+	ExpiresAt     time.Time `json:"expires_at"`
+}
+
+// GetSiteExceptions lists the accepted exceptions of every list of a site, by list and expiry
+// GET /api/sites/{siteID}/exceptions?include_expired=true
+func (h *PermissionExceptionHandlers) GetSiteExceptions(w http.ResponseWriter, r *http.Request) {
+	h.writeExceptions(w, r, "")
+}
+
+// GetListExceptions lists the accepted exceptions of a list, by expiry
+// GET /api/sites/{siteID}/lists/{listID}/exceptions?include_expired=true
+func (h *PermissionExceptionHandlers) GetListExceptions(w http.ResponseWriter, r *http.Request) {
+	h.writeExceptions(w, r, chi.URLParam(r, "listID"))
+}
+
+// AcceptListException accepts a sharing link, assignment or item with unique permissions of a list
+// until the exception expires, and responds with the exception
+// POST /api/sites/{siteID}/lists/{listID}/exceptions
+func (h *PermissionExceptionHandlers) AcceptListException(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	var req AcceptPermissionExceptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	exception, err := h.exceptionService.AcceptException(r.Context(), &audit.PermissionException{
+		SiteID:        siteID,
+		ListID:        chi.URLParam(r, "listID"),
+		Kind:          req.Kind,
+		ObjectKey:     req.ObjectKey,
+		PrincipalID:   req.PrincipalID,
+		RoleDefID:     req.RoleDefID,
+		Justification: req.Justification,
+		Owner:         req.Owner,
+		ExpiresAt:     req.ExpiresAt,
+	})
+	if err != nil {
+		writePermissionExceptionError(w, r, err)
+		return
+	}
+	view := h.listPresenter.ToPermissionExceptionViews([]*audit.PermissionException{exception}, time.Now())[0]
+	if err := WriteJSON(w, http.StatusOK, view); err != nil {
+		h.logger.Error("Failed to encode permission exception response", "error", err)
+	}
+}
+
+// RevokeException deletes an accepted exception
+// DELETE /api/sites/{siteID}/exceptions/{exceptionID}
+func (h *PermissionExceptionHandlers) RevokeException(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	exceptionID, err := strconv.ParseInt(chi.URLParam(r, "exceptionID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid exceptionID parameter")
+		return
+	}
+	if err := h.exceptionService.RevokeException(r.Context(), siteID, exceptionID); err != nil {
+		writePermissionExceptionError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetReviewQueue lists the exceptions of every site expiring within the window, expired ones included,
+// soonest first
+// GET /api/exceptions/review-queue?within_days=30
+func (h *PermissionExceptionHandlers) GetReviewQueue(w http.ResponseWriter, r *http.Request) {
+	withinDays, ok := parseReviewWindow(w, r)
+	if !ok {
+		return
+	}
+	reviews, err := h.exceptionService.GetReviewQueue(r.Context(), reviewWindow(withinDays))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToExceptionReviewViews(reviews, time.Now())); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// ReviewQueuePage renders the review queue with renew and revoke actions
+// GET /exceptions/review?within_days=30
+func (h *PermissionExceptionHandlers) ReviewQueuePage(w http.ResponseWriter, r *http.Request) {
+	withinDays, ok := parseReviewWindow(w, r)
+	if !ok {
+		return
+	}
+	reviews, err := h.exceptionService.GetReviewQueue(r.Context(), reviewWindow(withinDays))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	RenderResponse(r.Context(), w, r, pages.ExceptionReviewPage(h.listPresenter.ToExceptionReviewViews(reviews, time.Now()), withinDays))
+}
+
+// RenewException extends an exception by as long as it was last accepted for, and responds with the
+// exception, or from the review page with the refreshed queue
+// POST /api/sites/{siteID}/exceptions/{exceptionID}/renew
+func (h *PermissionExceptionHandlers) RenewException(w http.ResponseWriter, r *http.Request) {
+	siteID, exceptionID, ok := parseExceptionPath(w, r)
+	if !ok {
+		return
+	}
+	exception, err := h.exceptionService.RenewException(r.Context(), siteID, exceptionID)
+	if IsHTMXRequest(r) && err == nil {
+		h.renderReviewQueue(w, r, fmt.Sprintf("Renewed until %s", exception.ExpiresAt.UTC().Format("2006-01-02")))
+		return
+	}
+	if err != nil {
+		writePermissionExceptionError(w, r, err)
+		return
+	}
+	view := h.listPresenter.ToPermissionExceptionViews([]*audit.PermissionException{exception}, time.Now())[0]
+	if err := WriteJSON(w, http.StatusOK, view); err != nil {
+		h.logger.Error("Failed to encode permission exception response", "error", err)
+	}
+}
+
+// RevokeAndReopen revokes an exception and reopens the finding it suppressed, and responds with the
+// alert raised for it, or from the review page with the refreshed queue
+// POST /api/sites/{siteID}/exceptions/{exceptionID}/reopen
+func (h *PermissionExceptionHandlers) RevokeAndReopen(w http.ResponseWriter, r *http.Request) {
+	siteID, exceptionID, ok := parseExceptionPath(w, r)
+	if !ok {
+		return
+	}
+	alert, err := h.exceptionService.RevokeAndReopen(r.Context(), siteID, exceptionID)
+	if IsHTMXRequest(r) && err == nil {
+		h.renderReviewQueue(w, r, "Exception revoked, finding reopened")
+		return
+	}
+	if err != nil {
+		writePermissionExceptionError(w, r, err)
+		return
+	}
+	if alert == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToFindingAlertViews([]*audit.FindingAlert{alert})[0]); err != nil {
+		h.logger.Error("Failed to encode finding alert response", "error", err)
+	}
+}
+
+// renderReviewQueue renders the review queue for the window the review page requested, with the
+// outcome of an action
+func (h *PermissionExceptionHandlers) renderReviewQueue(w http.ResponseWriter, r *http.Request, status string) {
+	withinDays, ok := parseReviewWindow(w, r)
+	if !ok {
+		return
+	}
+	reviews, err := h.exceptionService.GetReviewQueue(r.Context(), reviewWindow(withinDays))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	RenderResponse(r.Context(), w, r, pages.ExceptionReviewQueue(h.listPresenter.ToExceptionReviewViews(reviews, time.Now()), withinDays, status))
+}
+
+// writeExceptions responds with a site's exceptions, only those of a list unless listID is empty
+func (h *PermissionExceptionHandlers) writeExceptions(w http.ResponseWriter, r *http.Request, listID string) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	includeExpired := false
+	if value := r.URL.Query().Get("include_expired"); value != "" {
+		if includeExpired, err = strconv.ParseBool(value); err != nil {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "include_expired must be true or false")
+			return
+		}
+	}
+
+	exceptions, err := h.exceptionService.GetExceptions(r.Context(), siteID, listID, includeExpired)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToPermissionExceptionViews(exceptions, time.Now())); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// parseExceptionPath parses the site and exception IDs of an exception's path, writing a problem
+// response if either is invalid
+func parseExceptionPath(w http.ResponseWriter, r *http.Request) (siteID, exceptionID int64, ok bool) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return 0, 0, false
+	}
+	exceptionID, err = strconv.ParseInt(chi.URLParam(r, "exceptionID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid exceptionID parameter")
+		return 0, 0, false
+	}
+	return siteID, exceptionID, true
+}
+
+// parseReviewWindow parses the within_days query parameter of the review queue, defaulting to
+// audit.ExceptionReviewWindow, writing a problem response if it is invalid
+func parseReviewWindow(w http.ResponseWriter, r *http.Request) (int, bool) {
+	value := r.URL.Query().Get("within_days")
+	if value == "" {
+		return int(audit.ExceptionReviewWindow / (24 * time.Hour)), true
+	}
+	maxDays := int(audit.MaxExceptionLifetime / (24 * time.Hour))
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 1 || days > maxDays {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("within_days must be between 1 and %d", maxDays))
+		return 0, false
+	}
+	return days, true
+}
+
+// reviewWindow converts a review window in days to a duration
+func reviewWindow(days int) time.Duration {
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// writePermissionExceptionError writes a failure to manage permission exceptions as a problem response.
+func writePermissionExceptionError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, application.ErrInvalidPermissionException):
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+	case errors.Is(err, application.ErrPermissionExceptionNotFound):
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	default:
+		writeServiceError(w, r, err)
+	}
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/presenters"
+)
+
+// ExportListPermissionMatrix exports a list and its items with unique permissions as a matrix of objects ×
+// principals, or objects × roles, highlighting cells by the access they grant when exported as XLSX.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/permission-matrix/export?columns=principal|role&format=xlsx|csv
+func (h *ListHandlers) ExportListPermissionMatrix(w http.ResponseWriter, r *http.Request) {
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	h.exportPermissionMatrix(w, r, siteID, "list-"+listID, func(services *application.AuditRunScopedServices) (*application.PermissionMatrixData, error) {
+		return services.SiteContentService.GetListPermissionMatrix(r.Context(), siteID, listID)
+	})
+}
+
+// ExportWebPermissionMatrix exports a web and its lists with unique permissions as a matrix of objects ×
+// principals, or objects × roles, highlighting cells by the access they grant when exported as XLSX.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/webs/{webID}/permission-matrix/export?columns=principal|role&format=xlsx|csv
+func (h *ListHandlers) ExportWebPermissionMatrix(w http.ResponseWriter, r *http.Request) {
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	webID := chi.URLParam(r, "webID")
+	if webID == "" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "webID parameter is required")
+		return
+	}
+
+	h.exportPermissionMatrix(w, r, siteID, "web-"+webID, func(services *application.AuditRunScopedServices) (*application.PermissionMatrixData, error) {
+		return services.SiteContentService.GetWebPermissionMatrix(r.Context(), siteID, webID)
+	})
+}
+
+// exportPermissionMatrix validates the export parameters, loads the matrix in the requested audit run and
+// sends it as CSV or XLSX. name identifies the list or web in the filename.
+func (h *ListHandlers) exportPermissionMatrix(w http.ResponseWriter, r *http.Request, siteID int64, name string,
+	load func(*application.AuditRunScopedServices) (*application.PermissionMatrixData, error)) {
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	layout, ok := presenters.ParsePermissionMatrixLayout(r.URL.Query().Get("columns"))
+	if !ok {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "columns must be principal or role")
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "xlsx"
+	}
+	if format != "csv" && format != "xlsx" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "format must be csv or xlsx")
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(r.Context(), siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	matrix, err := load(scopedServices)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	table := h.permissionPresenter.PermissionMatrixToCSV(matrix, layout)
+	filename := fmt.Sprintf("permission-matrix-%s-run%d.%s", name, scopedServices.AuditRunID, format)
+	if format == "csv" {
+		h.writeCSVExport(w, r, siteID, scopedServices.AuditRunID, filename, table)
+		return
+	}
+
+	h.writeXLSXSheetExport(w, r, siteID, scopedServices.AuditRunID, filename, xlsxSheet{
+		Name:               "Permission Matrix",
+		Table:              table,
+		FrozenColumns:      presenters.PermissionMatrixObjectColumns,
+		ConditionalFormats: permissionMatrixFormats(table, matrix.Roles, layout),
+	})
+}
+
+// roleTierHighlights is the highlight of each role tier: red for Full Control, amber for editing and green
+// for reading. Custom levels are not highlighted.
+var roleTierHighlights = map[presenters.RoleTier]xlsxHighlight{
+	presenters.RoleTierFullControl: xlsxHighlightRed,
+	presenters.RoleTierEdit:        xlsxHighlightAmber,
+	presenters.RoleTierRead:        xlsxHighlightGreen,
+}
+
+// permissionMatrixFormats highlights the matrix cells by the most privileged role they grant. With a column
+// per principal, cells are matched by the role names they contain, most privileged first; with a column per
+// role, each column's filled cells take the highlight of its role.
+func permissionMatrixFormats(table presenters.CSVTable, roles []string, layout string) []xlsxConditionalFormat {
+	first, last := presenters.PermissionMatrixObjectColumns, len(table.Header)-1
+	if len(table.Rows) == 0 || last < first {
+		return nil
+	}
+
+	var formats []xlsxConditionalFormat
+	for _, tier := range []presenters.RoleTier{presenters.RoleTierFullControl, presenters.RoleTierEdit, presenters.RoleTierRead} {
+		if layout == presenters.PermissionMatrixByRole {
+			for column := first; column <= last; column++ {
+				if presenters.PermissionRoleTier(table.Header[column]) == tier {
+					formats = append(formats, xlsxConditionalFormat{
+						FirstColumn: column, LastColumn: column, FirstRow: 2, LastRow: len(table.Rows) + 1,
+						Highlight: roleTierHighlights[tier],
+					})
+				}
+			}
+			continue
+		}
+		for _, role := range roles {
+			if presenters.PermissionRoleTier(role) == tier {
+				formats = append(formats, xlsxConditionalFormat{
+					FirstColumn: first, LastColumn: last, FirstRow: 2, LastRow: len(table.Rows) + 1,
+					Text: role, Highlight: roleTierHighlights[tier],
+				})
+			}
+		}
+	}
+	return formats
+}
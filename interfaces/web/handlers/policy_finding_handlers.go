@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// policyFindingsDefaultLimit is the number of findings listed across sites when no limit is given.
+const policyFindingsDefaultLimit = 100
+
+// PolicyFindingHandlers reports what violates the access policies audit runs are checked against.
+type PolicyFindingHandlers struct {
+	findingService *application.PolicyFindingService
+	serviceFactory application.AuditRunScopedServiceFactory
+	listPresenter  *presenters.ListPresenter
+	logger         *logging.Logger
+}
+
+// NewPolicyFindingHandlers creates a new policy finding handlers instance.
+func NewPolicyFindingHandlers(findingService *application.PolicyFindingService, serviceFactory application.AuditRunScopedServiceFactory, listPresenter *presenters.ListPresenter) *PolicyFindingHandlers {
+	return &PolicyFindingHandlers{
+		findingService: findingService,
+		serviceFactory: serviceFactory,
+		listPresenter:  listPresenter,
+		logger:         logging.Default().WithComponent("policy_finding_handler"),
+	}
+}
+
+// GetAccessPolicies lists the policies audit runs are checked against
+// GET /api/access-policies
+func (h *PolicyFindingHandlers) GetAccessPolicies(w http.ResponseWriter, r *http.Request) {
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToAccessPolicyViews(h.findingService.Policies())); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// GetPolicyFindings lists the findings of the latest checked run of every site, most severe first
+// GET /api/policy-findings?severity={min}&policy={name}&limit={n}
+func (h *PolicyFindingHandlers) GetPolicyFindings(w http.ResponseWriter, r *http.Request) {
+	var minSeverity audit.Severity
+	if raw := r.URL.Query().Get("severity"); raw != "" {
+		severity, ok := audit.ParseSeverity(raw)
+		if !ok {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "unknown severity "+strconv.Quote(raw))
+			return
+		}
+		minSeverity = severity
+	}
+	limit := policyFindingsDefaultLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > apiMaxPageSize {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "limit must be between 1 and "+strconv.Itoa(apiMaxPageSize))
+			return
+		}
+		limit = parsed
+	}
+
+	findings, err := h.findingService.GetLatestFindings(r.Context(), minSeverity, strings.ToLower(strings.TrimSpace(r.URL.Query().Get("policy"))))
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	if len(findings) > limit {
+		findings = findings[:limit]
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToPolicyFindingViews(findings)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// GetRunPolicyFindings returns the findings of an audit run as last checked against the access policies
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/policy-findings
+func (h *PolicyFindingHandlers) GetRunPolicyFindings(w http.ResponseWriter, r *http.Request) {
+	auditRunID, ok := h.resolveRun(w, r)
+	if !ok {
+		return
+	}
+
+	evaluation, err := h.findingService.GetEvaluation(r.Context(), auditRunID)
+	if err != nil {
+		writePolicyFindingError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToPolicyEvaluationView(evaluation)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// EvaluateRunPolicies checks an audit run against the access policies again, replacing its findings,
+// e.g. once the policies changed or for a run completed before they were checked
+// POST /api/sites/{siteID}/audit-runs/{auditRunID}/policy-findings
+func (h *PolicyFindingHandlers) EvaluateRunPolicies(w http.ResponseWriter, r *http.Request) {
+	auditRunID, ok := h.resolveRun(w, r)
+	if !ok {
+		return
+	}
+
+	evaluation, err := h.findingService.EvaluateRun(r.Context(), auditRunID)
+	if err != nil {
+		h.logger.Error("Failed to check audit run against access policies", "audit_run_id", auditRunID, "error", err)
+		writePolicyFindingError(w, r, err)
+		return
+	}
+	if evaluation == nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "folder-scoped audit runs are not checked against the access policies")
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToPolicyEvaluationView(evaluation)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// resolveRun resolves the audit run of the request, writing the problem when it cannot.
+func (h *PolicyFindingHandlers) resolveRun(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return 0, false
+	}
+	auditRunIDStr := chi.URLParam(r, "auditRunID")
+	if _, err := strconv.ParseInt(auditRunIDStr, 10, 64); err != nil && !audit.IsRunAlias(auditRunIDStr) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "auditRunID must be an audit run ID or run alias")
+		return 0, false
+	}
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(r.Context(), siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return 0, false
+	}
+	return scopedServices.AuditRunID, true
+}
+
+// writePolicyFindingError writes a failure to check or read policy findings as a problem response.
+func writePolicyFindingError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, application.ErrPolicyEvaluationNotFound) {
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+	writeServiceError(w, r, err)
+}
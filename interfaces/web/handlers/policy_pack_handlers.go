@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// PolicyPackHandlers lists the policy packs and chooses the one each site is checked with.
+type PolicyPackHandlers struct {
+	policyPackService *application.PolicyPackService
+	listPresenter     *presenters.ListPresenter
+	logger            *logging.Logger
+}
+
+// NewPolicyPackHandlers creates a new policy pack handlers instance.
+func NewPolicyPackHandlers(policyPackService *application.PolicyPackService, listPresenter *presenters.ListPresenter) *PolicyPackHandlers {
+	return &PolicyPackHandlers{
+		policyPackService: policyPackService,
+		listPresenter:     listPresenter,
+		logger:            logging.Default().WithComponent("policy_pack_handler"),
+	}
+}
+
+// SetSitePolicyPackRequest is the JSON body accepted by SetSitePolicyPack.
+type SetSitePolicyPackRequest struct {
+	Pack string `json:"pack"` // team, communication or channel
+}
+
+// GetPolicyPacks lists the policy packs and how each differs from the global defaults
+// GET /api/policy-packs
+func (h *PolicyPackHandlers) GetPolicyPacks(w http.ResponseWriter, r *http.Request) {
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToPolicyPackViews(application.PolicyPacks())); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// GetSitePolicyPack returns the policy pack a site is checked with, as its latest audit run's
+// template selects or an administrator chose, with the severity of every finding category under it
+// GET /api/sites/{siteID}/policy-pack
+func (h *PolicyPackHandlers) GetSitePolicyPack(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	h.writeSitePolicy(w, r, siteID)
+}
+
+// SetSitePolicyPack checks a site with the given policy pack whatever its template, and responds
+// with the site's policy
+// PUT /api/sites/{siteID}/policy-pack
+func (h *PolicyPackHandlers) SetSitePolicyPack(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	var req SetSitePolicyPackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	if _, err := h.policyPackService.SetSitePolicyPack(r.Context(), siteID, req.Pack); err != nil {
+		writePolicyPackError(w, r, err)
+		return
+	}
+	h.writeSitePolicy(w, r, siteID)
+}
+
+// ClearSitePolicyPack returns a site to the policy pack its template selects
+// DELETE /api/sites/{siteID}/policy-pack
+func (h *PolicyPackHandlers) ClearSitePolicyPack(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	if err := h.policyPackService.ClearSitePolicyPack(r.Context(), siteID); err != nil {
+		writePolicyPackError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeSitePolicy writes the policy a site is checked with under its latest audit run
+func (h *PolicyPackHandlers) writeSitePolicy(w http.ResponseWriter, r *http.Request, siteID int64) {
+	policy, err := h.policyPackService.GetSitePolicy(r.Context(), siteID, 0)
+	if err != nil {
+		writePolicyPackError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToSitePolicyView(policy)); err != nil {
+		h.logger.Error("Failed to encode site policy response", "site_id", siteID, "error", err)
+	}
+}
+
+// writePolicyPackError writes a failure to choose a site's policy pack as a problem response.
+func writePolicyPackError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, application.ErrUnknownPolicyPack):
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+	case errors.Is(err, application.ErrSitePolicyPackNotSet):
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	default:
+		writeServiceError(w, r, err)
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// PrincipalAccessHandlers reports what one principal can access across audited sites.
+type PrincipalAccessHandlers struct {
+	principalAccessService *application.PrincipalAccessService
+	listPresenter          *presenters.ListPresenter
+	logger                 *logging.Logger
+}
+
+// NewPrincipalAccessHandlers creates a new principal access handlers instance.
+func NewPrincipalAccessHandlers(principalAccessService *application.PrincipalAccessService, listPresenter *presenters.ListPresenter) *PrincipalAccessHandlers {
+	return &PrincipalAccessHandlers{
+		principalAccessService: principalAccessService,
+		listPresenter:          listPresenter,
+		logger:                 logging.Default().WithComponent("principal_access_handler"),
+	}
+}
+
+// GetPrincipalAccess returns every site, list and item a principal can access across the latest runs
+// of every audited site, e.g. what a leaver still has. The principal is its account, claims login name
+// or email address, since principal IDs differ per site
+// GET /api/principals/{id}/access
+func (h *PrincipalAccessHandlers) GetPrincipalAccess(w http.ResponseWriter, r *http.Request) {
+	identity, err := url.PathUnescape(chi.URLParam(r, "id"))
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid id parameter")
+		return
+	}
+
+	result, err := h.principalAccessService.GetAccess(r.Context(), identity)
+	if err != nil {
+		if errors.Is(err, application.ErrInvalidPrincipalIdentity) {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid id parameter")
+			return
+		}
+		h.logger.Error("Principal access failed", "identity", identity, "error", err)
+		writeServiceError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToPrincipalAccessReportView(result)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
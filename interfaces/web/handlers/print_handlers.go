@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/pages"
+)
+
+// SitePrintSummary renders a static summary of the site's lists in the audit run for printing.
+// The sort query parameter orders the lists as on the lists page.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/print
+func (h *ListHandlers) SitePrintSummary(w http.ResponseWriter, r *http.Request) {
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	h.renderSitePrintSummary(w, r, siteID, auditRunIDStr, "")
+}
+
+// ListPrintSummary renders a static summary of the list's role assignments and sharing links
+// in the audit run for printing.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/print
+func (h *ListHandlers) ListPrintSummary(w http.ResponseWriter, r *http.Request) {
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	h.renderListPrintSummary(w, r, siteID, auditRunIDStr, listID, "")
+}
+
+// renderSitePrintSummary renders the printable summary of a site's lists in an audit run.
+// A shared notice marks the summary as viewed through a read-only report share.
+func (h *ListHandlers) renderSitePrintSummary(w http.ResponseWriter, r *http.Request, siteID int64, auditRunIDStr, sharedNotice string) {
+	ctx := r.Context()
+
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	data, err := scopedServices.SiteContentService.GetSiteWithLists(ctx, siteID)
+	if err != nil {
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "Site not found")
+		return
+	}
+	data.AuditRunID = scopedServices.AuditRunID
+
+	viewModel := h.listPresenter.ToSiteListsViewModel(data)
+	viewModel.SortBy = h.listPresenter.NormalizeListSort(r.URL.Query().Get("sort"))
+	viewModel.RunContext = h.runContext(ctx, siteID, scopedServices.AuditRunID)
+
+	vm := h.listPresenter.ToSitePrintViewModel(*viewModel, time.Now())
+	vm.SharedNotice = sharedNotice
+	if exposure, err := scopedServices.SiteContentService.GetPageLibraryExposure(ctx, siteID); err != nil {
+		h.logger.Warn("Omitting page library exposure from summary", "site_id", siteID, "audit_run_id", scopedServices.AuditRunID, "error", err)
+	} else {
+		h.runSeverities(ctx, siteID, scopedServices.AuditRunID).RatePageLibraryExposure(exposure)
+		pageLibraries := h.listPresenter.ToPageLibraryExposureView(siteID, scopedServices.AuditRunID, exposure)
+		vm.PageLibraries = &pageLibraries
+	}
+	RenderResponse(ctx, w, r, pages.SitePrintSummary(vm))
+}
+
+// renderListPrintSummary renders the printable summary of who can access a list in an audit run.
+// A shared notice marks the summary as viewed through a read-only report share.
+func (h *ListHandlers) renderListPrintSummary(w http.ResponseWriter, r *http.Request, siteID int64, auditRunIDStr, listID, sharedNotice string) {
+	ctx := r.Context()
+
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	listData, err := scopedServices.SiteContentService.GetListByID(ctx, siteID, listID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	assignmentsData, err := scopedServices.SiteContentService.GetListAssignmentsWithRootCause(ctx, siteID, listID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	linkData, err := scopedServices.SiteContentService.GetListSharingLinksWithItemData(ctx, siteID, listID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	vmList := h.permissionPresenter.MapListToViewModel(listData)
+	linkVMs := make([]presenters.SharingLink, len(linkData))
+	for i, linkWithItem := range linkData {
+		linkVMs[i] = h.permissionPresenter.MapSharingLinkWithItemDataToViewModel(linkWithItem)
+	}
+	rc := h.runContext(ctx, siteID, scopedServices.AuditRunID).WithList(vmList, "")
+	collection := h.permissionPresenter.ToExpandableAssignmentCollection(assignmentsData, listID)
+
+	vm := h.permissionPresenter.ToListPrintViewModel(rc, vmList, collection, linkVMs, time.Now())
+	vm.SharedNotice = sharedNotice
+	RenderResponse(ctx, w, r, pages.ListPrintSummary(vm))
+}
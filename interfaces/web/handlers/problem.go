@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"spaudit/domain/contracts"
+)
+
+// ProblemContentType is the media type of RFC 7807 error responses.
+const ProblemContentType = "application/problem+json"
+
+// CorrelationIDHeader carries the request correlation ID on every response.
+const CorrelationIDHeader = "X-Request-ID"
+
+// Error codes returned in the code member of problem responses.
+// Clients branch on these rather than on detail text.
+const (
+	ErrCodeInvalidParameter     = "invalid_parameter"
+	ErrCodeNotFound             = "not_found"
+	ErrCodeMethodNotAllowed     = "method_not_allowed"
+	ErrCodeAuditRunUnavailable  = "audit_run_unavailable"
+	ErrCodeAuditConflict        = "audit_conflict"
+	ErrCodeManifestConflict     = "manifest_conflict"
+	ErrCodeBaselineConflict     = "baseline_conflict"
+	ErrCodeMaintenanceConflict  = "maintenance_conflict"
+	ErrCodeRenderFailed         = "render_failed"
+	ErrCodeStreamUnavailable    = "stream_unavailable"
+	ErrCodeLiveLookupFailed     = "live_lookup_failed"
+	ErrCodeStorageQuotaExceeded = "storage_quota_exceeded"
+	ErrCodeRowCapExceeded       = "row_cap_exceeded"
+	ErrCodeRedeliveryFailed     = "redelivery_failed"
+	ErrCodeSubscriptionConflict = "subscription_conflict"
+	ErrCodeSubscriptionFailed   = "subscription_failed"
+	ErrCodeMonitorConflict      = "monitor_conflict"
+	ErrCodeScheduleConflict     = "schedule_conflict"
+	ErrCodeReportNotReady       = "report_not_ready"
+	ErrCodeInvalidSignature     = "invalid_signature"
+	ErrCodeDeliveryConflict     = "delivery_conflict"
+	ErrCodeInternal             = "internal_error"
+)
+
+// problemTypeBase prefixes error codes to form the problem type URI.
+const problemTypeBase = "urn:spaudit:problem:"
+
+// Problem is an RFC 7807 problem details object with code and correlation ID extensions.
+type Problem struct {
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Status        int    `json:"status"`
+	Detail        string `json:"detail,omitempty"`
+	Instance      string `json:"instance,omitempty"`
+	Code          string `json:"code"`
+	CorrelationID string `json:"correlation_id,omitempty"`
+}
+
+// NewProblem builds a problem for the request, titled by the status text.
+func NewProblem(r *http.Request, status int, code, detail string) Problem {
+	return Problem{
+		Type:          problemTypeBase + code,
+		Title:         http.StatusText(status),
+		Status:        status,
+		Detail:        detail,
+		Instance:      r.URL.Path,
+		Code:          code,
+		CorrelationID: middleware.GetReqID(r.Context()),
+	}
+}
+
+// WriteProblem writes an RFC 7807 problem+json error response.
+func WriteProblem(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	problem := NewProblem(r, status, code, detail)
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+// writeServiceError writes a service failure as a problem response.
+// Missing records and records outside the requested site are reported as not found, and analyses
+// that would load more rows than the cap allows as unprocessable.
+func writeServiceError(w http.ResponseWriter, r *http.Request, err error) {
+	if isNotFoundError(err) {
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, contracts.ErrRowCapExceeded) {
+		WriteProblem(w, r, http.StatusUnprocessableEntity, ErrCodeRowCapExceeded, err.Error())
+		return
+	}
+	WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+}
+
+// writeAuditRunError writes a failure to resolve or scope services to an audit run as a problem response.
+func writeAuditRunError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	if isNotFoundError(err) {
+		status = http.StatusNotFound
+	}
+	WriteProblem(w, r, status, ErrCodeAuditRunUnavailable, fmt.Sprintf("Failed to resolve audit run: %v", err))
+}
+
+// isNotFoundError reports whether err means the requested record does not exist for the site.
+func isNotFoundError(err error) bool {
+	return errors.Is(err, sql.ErrNoRows) || errors.Is(err, contracts.ErrSiteScopeMismatch)
+}
+
+// CorrelationID assigns each request a correlation ID, reusing an incoming X-Request-ID,
+// and echoes it on the response so clients can quote it when reporting errors.
+func CorrelationID(next http.Handler) http.Handler {
+	return middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(CorrelationIDHeader, middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r)
+	}))
+}
+
+// NotFound responds to unmatched routes with a problem response.
+func NotFound(w http.ResponseWriter, r *http.Request) {
+	WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, "no route matches "+r.URL.Path)
+}
+
+// MethodNotAllowed responds to unsupported methods with a problem response.
+func MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	WriteProblem(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed, r.Method+" is not supported for "+r.URL.Path)
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/contracts"
+)
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) Problem {
+	t.Helper()
+	assert.Equal(t, ProblemContentType, w.Header().Get("Content-Type"))
+
+	var problem Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	return problem
+}
+
+func TestWriteProblem_UsesIncomingCorrelationID(t *testing.T) {
+	handler := CorrelationID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/sites/abc/audit-runs", nil)
+	req.Header.Set(CorrelationIDHeader, "req-123")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, "req-123", w.Header().Get(CorrelationIDHeader))
+
+	problem := decodeProblem(t, w)
+	assert.Equal(t, "urn:spaudit:problem:invalid_parameter", problem.Type)
+	assert.Equal(t, "Bad Request", problem.Title)
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, "invalid siteID parameter", problem.Detail)
+	assert.Equal(t, "/api/sites/abc/audit-runs", problem.Instance)
+	assert.Equal(t, ErrCodeInvalidParameter, problem.Code)
+	assert.Equal(t, "req-123", problem.CorrelationID)
+}
+
+func TestWriteProblem_GeneratesCorrelationID(t *testing.T) {
+	handler := CorrelationID(http.HandlerFunc(NotFound))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/nope", nil))
+
+	problem := decodeProblem(t, w)
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, ErrCodeNotFound, problem.Code)
+	assert.NotEmpty(t, problem.CorrelationID)
+	assert.Equal(t, problem.CorrelationID, w.Header().Get(CorrelationIDHeader))
+}
+
+func TestWriteServiceError_MapsNotFound(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{"missing row", fmt.Errorf("get list: %w", sql.ErrNoRows), http.StatusNotFound, ErrCodeNotFound},
+		{"other site", contracts.ErrSiteScopeMismatch, http.StatusNotFound, ErrCodeNotFound},
+		{"over row cap", fmt.Errorf("snapshot of list x exceed 10 rows: %w", contracts.ErrRowCapExceeded), http.StatusUnprocessableEntity, ErrCodeRowCapExceeded},
+		{"database failure", errors.New("database is locked"), http.StatusInternalServerError, ErrCodeInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			writeServiceError(w, httptest.NewRequest(http.MethodGet, "/sites/1/audit-runs/1/lists/x", nil), tt.err)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+			problem := decodeProblem(t, w)
+			assert.Equal(t, tt.wantCode, problem.Code)
+			assert.Equal(t, tt.err.Error(), problem.Detail)
+		})
+	}
+}
+
+func TestWriteAuditRunError_UsesAuditRunCode(t *testing.T) {
+	w := httptest.NewRecorder()
+	err := fmt.Errorf("resolve audit run ID: %w", sql.ErrNoRows)
+	writeAuditRunError(w, httptest.NewRequest(http.MethodGet, "/sites/1/audit-runs/99/lists", nil), err)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, ErrCodeAuditRunUnavailable, decodeProblem(t, w).Code)
+}
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+)
+
+// GetRecycleBinRemnants returns the items in an audit run's recycle bin scan that were deleted while
+// they still had active sharing links, which restoring the items would revive
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/recycle-bin
+func (h *ListHandlers) GetRecycleBinRemnants(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	remnants, err := scopedServices.SiteContentService.GetRecycleBinRemnants(ctx, siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	h.runSeverities(ctx, siteID, scopedServices.AuditRunID).RateRecycleBinRemnants(remnants)
+
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToRecycleBinRemnantsView(siteID, scopedServices.AuditRunID, remnants)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"strings"
 
@@ -13,10 +14,17 @@ import (
 func RenderResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, component templ.Component) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 	if err := component.Render(ctx, w); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeRenderFailed, err.Error())
 	}
 }
 
+// WriteJSON writes v as a JSON response with the given status.
+func WriteJSON(w http.ResponseWriter, status int, v interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(v)
+}
+
 // IsHTMXRequest checks if the request came from HTMX.
 func IsHTMXRequest(r *http.Request) bool {
 	return r.Header.Get("HX-Request") == "true"
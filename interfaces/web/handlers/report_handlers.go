@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+)
+
+// RenderRunReport implements application.RunReportRenderer with the same tables as the export downloads,
+// so post-audit reports match what users export by hand.
+func (h *ListHandlers) RenderRunReport(ctx context.Context, siteID, auditRunID int64, report string) ([]application.GeneratedReport, error) {
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, strconv.FormatInt(auditRunID, 10))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve audit run %d: %w", auditRunID, err)
+	}
+
+	switch report {
+	case audit.ReportLists:
+		return h.renderListsReport(ctx, siteID, scopedServices)
+	case audit.ReportAssignments:
+		return h.renderAssignmentsReport(ctx, siteID, scopedServices)
+	case audit.ReportChanges:
+		return h.renderChangesReport(ctx, siteID, scopedServices)
+	default:
+		return nil, fmt.Errorf("unknown report %q", report)
+	}
+}
+
+// renderListsReport renders the unfiltered lists table in its default sort as CSV.
+func (h *ListHandlers) renderListsReport(ctx context.Context, siteID int64, scopedServices *application.AuditRunScopedServices) ([]application.GeneratedReport, error) {
+	listsData, err := scopedServices.SiteContentService.GetListsForSite(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	listVMs := h.listPresenter.ToListSummariesWithThreshold(listsData, scopedServices.SiteContentService.UniqueDensityThreshold())
+	content, err := encodeCSV(h.listPresenter.ListsToCSV(h.listPresenter.SortLists(listVMs, "")))
+	if err != nil {
+		return nil, err
+	}
+
+	return []application.GeneratedReport{{
+		Filename:    fmt.Sprintf("lists-site%d-run%d.csv", siteID, scopedServices.AuditRunID),
+		ContentType: csvContentType,
+		Content:     content,
+	}}, nil
+}
+
+// renderAssignmentsReport renders the assignments table of each list with unique permissions as CSV.
+func (h *ListHandlers) renderAssignmentsReport(ctx context.Context, siteID int64, scopedServices *application.AuditRunScopedServices) ([]application.GeneratedReport, error) {
+	listsData, err := scopedServices.SiteContentService.GetListsForSite(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []application.GeneratedReport
+	for _, list := range listsData {
+		if !list.HasUnique {
+			continue
+		}
+
+		assignmentsData, err := scopedServices.SiteContentService.GetListAssignmentsWithRootCause(ctx, siteID, list.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assignments of list %s: %w", list.ID, err)
+		}
+		collection := h.permissionPresenter.ToExpandableAssignmentCollection(assignmentsData, list.ID)
+		content, err := encodeCSV(h.permissionPresenter.AssignmentsToCSV(collection))
+		if err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, application.GeneratedReport{
+			Filename:    fmt.Sprintf("assignments-%s-run%d.csv", list.ID, scopedServices.AuditRunID),
+			ContentType: csvContentType,
+			Content:     content,
+		})
+	}
+	return reports, nil
+}
+
+// renderChangesReport renders the redline of each list whose permissions changed since the site's
+// reference run as XLSX. Nothing is rendered when the run is itself the reference run.
+func (h *ListHandlers) renderChangesReport(ctx context.Context, siteID int64, scopedServices *application.AuditRunScopedServices) ([]application.GeneratedReport, error) {
+	baseServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, audit.RunAliasReference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve reference run: %w", err)
+	}
+	if baseServices.AuditRunID == scopedServices.AuditRunID {
+		return nil, nil
+	}
+
+	listsData, err := scopedServices.SiteContentService.GetListsForSite(ctx, siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []application.GeneratedReport
+	for _, list := range listsData {
+		_, err := baseServices.SiteContentService.GetListByID(ctx, siteID, list.ID)
+		if isNotFoundError(err) {
+			continue // New since the reference run, nothing to compare with
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get list %s in reference run: %w", list.ID, err)
+		}
+
+		diff, err := scopedServices.SiteContentService.GetListChanges(ctx, siteID, list, baseServices.AuditRunID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare list %s with reference run: %w", list.ID, err)
+		}
+		if len(diff.Changes) == 0 {
+			continue
+		}
+
+		table := h.listPresenter.ListDiffToRedline(diff)
+		var buf bytes.Buffer
+		if err := encodeXLSX(&buf, "Changes", table, redlineRowStyles(table)); err != nil {
+			return nil, err
+		}
+
+		reports = append(reports, application.GeneratedReport{
+			Filename:    fmt.Sprintf("changes-%s-run%d-to-run%d.xlsx", list.ID, baseServices.AuditRunID, scopedServices.AuditRunID),
+			ContentType: xlsxContentType,
+			Content:     buf.Bytes(),
+		})
+	}
+	return reports, nil
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"spaudit/application"
+	"spaudit/domain/jobs"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// Bounds on how long AuditAndWait holds the request open.
+const (
+	defaultAuditWaitSeconds = 600
+	maxAuditWaitSeconds     = 3600
+	auditWaitPollInterval   = time.Second
+)
+
+// ScriptingHandlers serves single-call endpoints for admin scripts.
+// Parameters are read with FormValue so PowerShell's Invoke-RestMethod -Body
+// hashtables work without building JSON, and responses are flat JSON objects.
+type ScriptingHandlers struct {
+	auditService        application.AuditService
+	jobService          application.JobService
+	siteBrowsingService *application.SiteBrowsingService
+	serviceFactory      application.AuditRunScopedServiceFactory
+	scriptingPresenter  *presenters.ScriptingPresenter
+	sseManager          *SSEManager
+	logger              *logging.Logger
+	pollInterval        time.Duration
+}
+
+// NewScriptingHandlers creates a new scripting handlers instance.
+func NewScriptingHandlers(
+	auditService application.AuditService,
+	jobService application.JobService,
+	siteBrowsingService *application.SiteBrowsingService,
+	serviceFactory application.AuditRunScopedServiceFactory,
+	scriptingPresenter *presenters.ScriptingPresenter,
+	sseManager *SSEManager,
+) *ScriptingHandlers {
+	return &ScriptingHandlers{
+		auditService:        auditService,
+		jobService:          jobService,
+		siteBrowsingService: siteBrowsingService,
+		serviceFactory:      serviceFactory,
+		scriptingPresenter:  scriptingPresenter,
+		sseManager:          sseManager,
+		logger:              logging.Default().WithComponent("scripting_handler"),
+		pollInterval:        auditWaitPollInterval,
+	}
+}
+
+// AuditAndWait queues an audit and holds the request open until it finishes.
+// Responds 200 once the job is complete, or 202 with timed_out=true if
+// wait_seconds elapses first; the audit keeps running either way.
+// POST /api/scripting/audit-and-wait
+func (h *ScriptingHandlers) AuditAndWait(w http.ResponseWriter, r *http.Request) {
+	siteURL := strings.TrimSpace(r.FormValue("site_url"))
+	if siteURL == "" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "site_url is required")
+		return
+	}
+
+	wait, err := h.extractWait(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	parameters := h.auditService.BuildAuditParametersFromFormData(r.Form)
+	request, err := h.auditService.QueueAudit(r.Context(), siteURL, parameters)
+	if err != nil {
+		h.logger.Error("Failed to queue audit", "site_url", siteURL, "error", err)
+		if errors.Is(err, application.ErrAuditAlreadyQueued) || errors.Is(err, application.ErrMaintenanceRunning) {
+			WriteProblem(w, r, http.StatusConflict, ErrCodeAuditConflict, err.Error())
+			return
+		}
+		if errors.Is(err, application.ErrInvalidAuditScope) || errors.Is(err, application.ErrInvalidRunLabel) || errors.Is(err, application.ErrInvalidCollectorBackend) {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+			return
+		}
+		if errors.Is(err, application.ErrStorageQuotaExceeded) {
+			WriteProblem(w, r, http.StatusInsufficientStorage, ErrCodeStorageQuotaExceeded, err.Error())
+			return
+		}
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+		return
+	}
+
+	h.logger.Info("Audit queued, waiting for completion",
+		"job_id", request.ID,
+		"site_url", siteURL,
+		"wait", wait)
+
+	// Broadcast job list update to all SSE clients
+	h.sseManager.BroadcastJobListUpdate()
+
+	job, timedOut, err := h.waitForJob(r.Context(), request.ID, wait)
+	if err != nil {
+		if r.Context().Err() != nil {
+			// The client went away; the audit carries on without it
+			h.logger.Info("Client stopped waiting for audit", "job_id", request.ID)
+			return
+		}
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+		return
+	}
+
+	// The audit run is created once the job starts, so it may not exist yet on timeout
+	auditRun, err := h.auditService.GetAuditRunForJob(r.Context(), job.ID)
+	if err != nil {
+		auditRun = nil
+	}
+
+	status := http.StatusOK
+	if timedOut {
+		status = http.StatusAccepted
+	}
+	if err := WriteJSON(w, status, h.scriptingPresenter.ToAuditRunResultView(job, auditRun, timedOut)); err != nil {
+		h.logger.Error("Failed to encode audit result response", "error", err)
+	}
+}
+
+// waitForJob polls the job until it completes or wait elapses.
+// timedOut reports that wait elapsed first; err is set when ctx ends or the job disappears.
+func (h *ScriptingHandlers) waitForJob(ctx context.Context, jobID string, wait time.Duration) (job *jobs.Job, timedOut bool, err error) {
+	deadline := time.NewTimer(wait)
+	defer deadline.Stop()
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, exists := h.jobService.GetJob(jobID)
+		if !exists {
+			return nil, false, fmt.Errorf("job %s not found", jobID)
+		}
+		if job.IsComplete() {
+			return job, false, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-deadline.C:
+			return job, true, nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// extractWait parses the optional wait_seconds parameter.
+func (h *ScriptingHandlers) extractWait(r *http.Request) (time.Duration, error) {
+	waitStr := r.FormValue("wait_seconds")
+	if waitStr == "" {
+		return defaultAuditWaitSeconds * time.Second, nil
+	}
+	seconds, err := strconv.Atoi(waitStr)
+	if err != nil || seconds < 1 || seconds > maxAuditWaitSeconds {
+		return 0, fmt.Errorf("invalid wait_seconds %q: must be between 1 and %d", waitStr, maxAuditWaitSeconds)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// GetRiskSummary returns the permission risk summary of a site's latest audit run.
+// GET /api/scripting/risk-summary?site_url={siteURL}
+func (h *ScriptingHandlers) GetRiskSummary(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteURL := strings.TrimRight(strings.TrimSpace(r.FormValue("site_url")), "/")
+	if siteURL == "" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "site_url is required")
+		return
+	}
+
+	site, err := h.siteBrowsingService.GetSiteByURL(ctx, siteURL)
+	if err != nil {
+		if isNotFoundError(err) {
+			WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, fmt.Sprintf("site %s has not been audited", siteURL))
+			return
+		}
+		writeServiceError(w, r, err)
+		return
+	}
+
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, site.ID, "latest")
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	auditRun, err := h.auditService.GetAuditRun(ctx, site.ID, scopedServices.AuditRunID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	lists, err := scopedServices.SiteContentService.GetListsForSite(ctx, site.ID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	summary, err := scopedServices.PermissionService.SummarizeSiteRisk(ctx, site.ID, lists)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	if err := WriteJSON(w, http.StatusOK, h.scriptingPresenter.ToSiteRiskSummaryView(site, auditRun, summary)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
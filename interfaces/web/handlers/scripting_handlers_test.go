@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/domain/jobs"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/test/mocks"
+)
+
+const scriptingTestSiteURL = "https://contoso.sharepoint.com/sites/finance"
+
+func newTestScriptingHandlers(t *testing.T, auditService application.AuditService, jobService application.JobService) *ScriptingHandlers {
+	t.Helper()
+	h := NewScriptingHandlers(auditService, jobService, nil, nil, presenters.NewScriptingPresenter(), NewSSEManager(context.Background()))
+	h.pollInterval = 5 * time.Millisecond
+	return h
+}
+
+func newAuditAndWaitRequest(form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/api/scripting/audit-and-wait", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req
+}
+
+func newScriptingTestJob(status jobs.JobStatus) *jobs.Job {
+	job := &jobs.Job{
+		ID:        "job-1",
+		Type:      jobs.JobTypeSiteAudit,
+		Status:    status,
+		StartedAt: time.Date(2025, 2, 1, 8, 0, 0, 0, time.UTC),
+		Context:   jobs.AuditJobContext{SiteURL: scriptingTestSiteURL},
+	}
+	job.State.Stats.ListsProcessed = 12
+	if job.IsComplete() {
+		completedAt := job.StartedAt.Add(90 * time.Second)
+		job.CompletedAt = &completedAt
+	}
+	return job
+}
+
+func expectQueuedAudit(auditService *mocks.MockAuditService) {
+	auditService.On("BuildAuditParametersFromFormData", mock.Anything).Return(audit.DefaultParameters())
+	auditService.On("QueueAudit", mock.Anything, scriptingTestSiteURL, mock.Anything).
+		Return(&audit.AuditRequest{ID: "job-1", SiteURL: scriptingTestSiteURL}, nil)
+}
+
+func TestScriptingHandlers_AuditAndWait_Completes(t *testing.T) {
+	auditService := &mocks.MockAuditService{}
+	expectQueuedAudit(auditService)
+	auditService.On("GetAuditRunForJob", mock.Anything, "job-1").Return(&audit.AuditRun{ID: 42, SiteID: 3, JobID: "job-1"}, nil)
+
+	jobService := new(MockJobService)
+	jobService.On("GetJob", "job-1").Return(newScriptingTestJob(jobs.JobStatusRunning), true).Once()
+	jobService.On("GetJob", "job-1").Return(newScriptingTestJob(jobs.JobStatusCompleted), true)
+
+	w := httptest.NewRecorder()
+	newTestScriptingHandlers(t, auditService, jobService).AuditAndWait(w, newAuditAndWaitRequest(url.Values{"site_url": {scriptingTestSiteURL}}))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var result presenters.AuditRunResultView
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+	assert.Equal(t, "completed", result.Status)
+	assert.True(t, result.Completed)
+	assert.False(t, result.TimedOut)
+	assert.Equal(t, int64(42), result.AuditRunID)
+	assert.Equal(t, int64(3), result.SiteID)
+	assert.Equal(t, scriptingTestSiteURL, result.SiteURL)
+	assert.Equal(t, float64(90), result.DurationSeconds)
+	assert.Equal(t, 12, result.ListsProcessed)
+	jobService.AssertExpectations(t)
+}
+
+func TestScriptingHandlers_AuditAndWait_TimesOut(t *testing.T) {
+	auditService := &mocks.MockAuditService{}
+	expectQueuedAudit(auditService)
+	auditService.On("GetAuditRunForJob", mock.Anything, "job-1").Return(nil, fmt.Errorf("audit run for job job-1 not found: %w", sql.ErrNoRows))
+
+	jobService := new(MockJobService)
+	jobService.On("GetJob", "job-1").Return(newScriptingTestJob(jobs.JobStatusPending), true)
+
+	w := httptest.NewRecorder()
+	newTestScriptingHandlers(t, auditService, jobService).AuditAndWait(w, newAuditAndWaitRequest(url.Values{
+		"site_url":     {scriptingTestSiteURL},
+		"wait_seconds": {"1"},
+	}))
+
+	require.Equal(t, http.StatusAccepted, w.Code)
+	var result presenters.AuditRunResultView
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+	assert.True(t, result.TimedOut)
+	assert.False(t, result.Completed)
+	assert.Equal(t, "pending", result.Status)
+	assert.Zero(t, result.AuditRunID)
+	assert.Equal(t, "", result.CompletedAt)
+}
+
+func TestScriptingHandlers_AuditAndWait_Conflict(t *testing.T) {
+	auditService := &mocks.MockAuditService{}
+	auditService.On("BuildAuditParametersFromFormData", mock.Anything).Return(audit.DefaultParameters())
+	auditService.On("QueueAudit", mock.Anything, scriptingTestSiteURL, mock.Anything).
+		Return(nil, fmt.Errorf("%w for site: %s", application.ErrAuditAlreadyQueued, scriptingTestSiteURL))
+
+	w := httptest.NewRecorder()
+	newTestScriptingHandlers(t, auditService, new(MockJobService)).AuditAndWait(w, newAuditAndWaitRequest(url.Values{"site_url": {scriptingTestSiteURL}}))
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), ErrCodeAuditConflict)
+}
+
+func TestScriptingHandlers_AuditAndWait_InvalidParameters(t *testing.T) {
+	tests := []struct {
+		name string
+		form url.Values
+	}{
+		{"missing site_url", url.Values{}},
+		{"non-numeric wait", url.Values{"site_url": {scriptingTestSiteURL}, "wait_seconds": {"soon"}}},
+		{"wait too long", url.Values{"site_url": {scriptingTestSiteURL}, "wait_seconds": {"7200"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditService := &mocks.MockAuditService{}
+
+			w := httptest.NewRecorder()
+			newTestScriptingHandlers(t, auditService, new(MockJobService)).AuditAndWait(w, newAuditAndWaitRequest(tt.form))
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			auditService.AssertNotCalled(t, "QueueAudit", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// selectionCookieName is the cookie identifying a browser session's row selections.
+const selectionCookieName = "spaudit_session"
+
+// selectionSessionTTL drops the selections of sessions idle for longer.
+const selectionSessionTTL = 12 * time.Hour
+
+// maxSelectionSessions bounds the sessions kept in memory; the least recently used is dropped first.
+const maxSelectionSessions = 1000
+
+// MaxSelectedRows bounds the rows selected in one table.
+const MaxSelectedRows = 5000
+
+// SelectionStore keeps the rows each browser session has selected in each table, in memory.
+// Selections do not survive a restart.
+type SelectionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*selectionSession
+	now      func() time.Time
+}
+
+// selectionSession holds one session's selected row keys by table scope.
+type selectionSession struct {
+	scopes   map[string]map[string]struct{}
+	lastSeen time.Time
+}
+
+// NewSelectionStore creates an empty selection store.
+func NewSelectionStore() *SelectionStore {
+	return &SelectionStore{sessions: make(map[string]*selectionSession), now: time.Now}
+}
+
+// Select adds keys to a table's selection, or removes them when selected is false, and returns
+// the number of rows selected. Keys beyond MaxSelectedRows are not added.
+func (s *SelectionStore) Select(sessionID, scope string, keys []string, selected bool) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session := s.session(sessionID)
+	rows := session.scopes[scope]
+	if rows == nil {
+		rows = make(map[string]struct{})
+		session.scopes[scope] = rows
+	}
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if !selected {
+			delete(rows, key)
+		} else if len(rows) < MaxSelectedRows {
+			rows[key] = struct{}{}
+		}
+	}
+	if len(rows) == 0 {
+		delete(session.scopes, scope)
+	}
+	return len(rows)
+}
+
+// Clear empties a table's selection.
+func (s *SelectionStore) Clear(sessionID, scope string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if session, ok := s.sessions[sessionID]; ok {
+		delete(session.scopes, scope)
+	}
+}
+
+// Selected returns the keys selected in a table, sorted.
+func (s *SelectionStore) Selected(sessionID, scope string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[sessionID]
+	if !ok || s.expired(session) {
+		return []string{}
+	}
+	keys := make([]string, 0, len(session.scopes[scope]))
+	for key := range session.scopes[scope] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// session returns the session's selections, creating them and dropping expired sessions as needed.
+// The caller holds the lock.
+func (s *SelectionStore) session(sessionID string) *selectionSession {
+	session, ok := s.sessions[sessionID]
+	if ok && s.expired(session) {
+		delete(s.sessions, sessionID)
+		ok = false
+	}
+	if !ok {
+		s.evict()
+		session = &selectionSession{scopes: make(map[string]map[string]struct{})}
+		s.sessions[sessionID] = session
+	}
+	session.lastSeen = s.now()
+	return session
+}
+
+// evict drops expired sessions, then the least recently used while the store is full.
+// The caller holds the lock.
+func (s *SelectionStore) evict() {
+	for id, session := range s.sessions {
+		if s.expired(session) {
+			delete(s.sessions, id)
+		}
+	}
+	for len(s.sessions) >= maxSelectionSessions {
+		var oldestID string
+		var oldest time.Time
+		for id, session := range s.sessions {
+			if oldestID == "" || session.lastSeen.Before(oldest) {
+				oldestID, oldest = id, session.lastSeen
+			}
+		}
+		delete(s.sessions, oldestID)
+	}
+}
+
+// expired returns true if the session has been idle for longer than selectionSessionTTL.
+func (s *SelectionStore) expired(session *selectionSession) bool {
+	return s.now().Sub(session.lastSeen) > selectionSessionTTL
+}
+
+// selectionSessionID returns the request's selection session, or "" when it has none yet.
+func selectionSessionID(r *http.Request) string {
+	cookie, err := r.Cookie(selectionCookieName)
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// ensureSelectionSession returns the request's selection session, starting one with a new cookie when it has none.
+func ensureSelectionSession(w http.ResponseWriter, r *http.Request) (string, error) {
+	if id := selectionSessionID(r); id != "" {
+		return id, nil
+	}
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(buf)
+	http.SetCookie(w, &http.Cookie{
+		Name:     selectionCookieName,
+		Value:    id,
+		Path:     "/",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return id, nil
+}
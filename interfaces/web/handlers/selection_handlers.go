@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/components/list"
+)
+
+// selectionScope identifies a list table's selection within a session.
+func selectionScope(table presenters.DataTable, siteID, auditRunID int64, listID string) string {
+	return fmt.Sprintf("%s/%d/%d/%s", table, siteID, auditRunID, listID)
+}
+
+// tableSelection builds the bulk selection of a list table for the rows shown.
+func (h *ListHandlers) tableSelection(r *http.Request, table presenters.DataTable, siteID, auditRunID int64, listID string, pageKeys []string) presenters.BulkSelection {
+	selected := h.selections.Selected(selectionSessionID(r), selectionScope(table, siteID, auditRunID, listID))
+	return h.permissionPresenter.ToBulkSelection(table, siteID, auditRunID, listID, selected, pageKeys, r.URL.RequestURI())
+}
+
+// UpdateTableSelection adds rows to or removes rows from a list table's selection, or clears it.
+// Changes to a single row return the bulk action bar for the table view given in view; when return_to
+// is given the table view is reloaded instead, so every checkbox reflects the selection.
+// POST /sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/selection/{table}
+func (h *ListHandlers) UpdateTableSelection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	table, ok := presenters.ParseSelectableTable(chi.URLParam(r, "table"))
+	if !ok {
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, fmt.Sprintf("table %q does not support selection", chi.URLParam(r, "table")))
+		return
+	}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid form data")
+		return
+	}
+	returnTo, view := r.PostFormValue("return_to"), r.PostFormValue("view")
+	if (returnTo != "" && !isLocalPath(returnTo)) || (view != "" && !isLocalPath(view)) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "return_to and view must be paths on this site")
+		return
+	}
+
+	// Resolve aliases so the selection follows the run rather than the alias
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	sessionID, err := ensureSelectionSession(w, r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to start selection session")
+		return
+	}
+	scope := selectionScope(table, siteID, scopedServices.AuditRunID, listID)
+	if r.PostFormValue("clear") != "" {
+		h.selections.Clear(sessionID, scope)
+	} else {
+		h.selections.Select(sessionID, scope, r.PostForm["keys"], r.PostFormValue("selected") == "true")
+	}
+
+	if returnTo != "" {
+		if !IsHTMXRequest(r) {
+			http.Redirect(w, r, returnTo, http.StatusSeeOther)
+			return
+		}
+		location, _ := json.Marshal(map[string]string{"path": returnTo, "target": "#tab-body"})
+		w.Header().Set("HX-Location", string(location))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	selected := h.selections.Selected(sessionID, scope)
+	selection := h.permissionPresenter.ToBulkSelection(table, siteID, scopedServices.AuditRunID, listID, selected, nil, view)
+	RenderResponse(ctx, w, r, list.BulkActionBar(selection))
+}
+
+// ExportTableSelection exports the selected rows of a list table as CSV.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/selection/{table}/export
+func (h *ListHandlers) ExportTableSelection(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	table, ok := presenters.ParseSelectableTable(chi.URLParam(r, "table"))
+	if !ok {
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, fmt.Sprintf("table %q does not support selection", chi.URLParam(r, "table")))
+		return
+	}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	selected := h.selections.Selected(selectionSessionID(r), selectionScope(table, siteID, scopedServices.AuditRunID, listID))
+	if len(selected) == 0 {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "no rows are selected")
+		return
+	}
+	isSelected := make(map[string]bool, len(selected))
+	for _, key := range selected {
+		isSelected[key] = true
+	}
+
+	var csvTable presenters.CSVTable
+	switch table {
+	case presenters.DataTableItems:
+		// Selections span filters, so walk every item of the list
+		query := h.permissionPresenter.ParseItemsTabQuery(url.Values{"scope": {presenters.ItemScopeAll}})
+		var items []presenters.ItemSummary
+		seen := int64(0)
+		for page := 1; len(items) < len(selected); page++ {
+			pageData, err := scopedServices.SiteContentService.GetListItemsPage(ctx, siteID, listID, query.Filter(), page, exportItemPageSize)
+			if err != nil {
+				writeServiceError(w, r, err)
+				return
+			}
+			for _, item := range pageData.Items {
+				if isSelected[item.GUID] {
+					items = append(items, h.permissionPresenter.MapItemToViewModel(item))
+				}
+			}
+			seen += int64(len(pageData.Items))
+			if len(pageData.Items) == 0 || seen >= pageData.TotalCount {
+				break
+			}
+		}
+		csvTable = h.permissionPresenter.ItemsToCSV(items)
+	case presenters.DataTableLinks:
+		linkData, err := scopedServices.SiteContentService.GetListSharingLinksWithItemData(ctx, siteID, listID)
+		if err != nil {
+			writeServiceError(w, r, err)
+			return
+		}
+		var links []presenters.SharingLink
+		for _, linkWithItem := range linkData {
+			if isSelected[linkWithItem.SharingLink.ID] {
+				links = append(links, h.permissionPresenter.MapSharingLinkWithItemDataToViewModel(linkWithItem))
+			}
+		}
+		csvTable = h.permissionPresenter.SharingLinksToCSV(links)
+	}
+
+	filename := fmt.Sprintf("selected-%s-%s-run%d.csv", table, listID, scopedServices.AuditRunID)
+	h.writeCSVExport(w, r, siteID, scopedServices.AuditRunID, filename, csvTable)
+}
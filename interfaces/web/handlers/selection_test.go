@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectionStore(t *testing.T) {
+	store := NewSelectionStore()
+
+	assert.Equal(t, 2, store.Select("s1", "items/1/7/abc", []string{"b", "a", ""}, true))
+	assert.Equal(t, 3, store.Select("s1", "items/1/7/abc", []string{"c", "a"}, true))
+	assert.Equal(t, 2, store.Select("s1", "items/1/7/abc", []string{"b"}, false))
+	assert.Equal(t, []string{"a", "c"}, store.Selected("s1", "items/1/7/abc"))
+
+	// Sessions and tables are kept apart
+	assert.Empty(t, store.Selected("s2", "items/1/7/abc"))
+	assert.Empty(t, store.Selected("s1", "links/1/7/abc"))
+
+	store.Clear("s1", "items/1/7/abc")
+	assert.Empty(t, store.Selected("s1", "items/1/7/abc"))
+}
+
+func TestSelectionStore_Limits(t *testing.T) {
+	store := NewSelectionStore()
+	now := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	keys := make([]string, MaxSelectedRows+10)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key-%d", i)
+	}
+	assert.Equal(t, MaxSelectedRows, store.Select("s1", "items/1/7/abc", keys, true))
+
+	// Idle sessions expire
+	now = now.Add(selectionSessionTTL + time.Minute)
+	assert.Empty(t, store.Selected("s1", "items/1/7/abc"))
+
+	// A full store drops its least recently used session
+	for i := 0; i < maxSelectionSessions; i++ {
+		now = now.Add(time.Second)
+		store.Select(fmt.Sprintf("session-%d", i), "items/1/7/abc", []string{"a"}, true)
+	}
+	store.Select("newest", "items/1/7/abc", []string{"a"}, true)
+	assert.Empty(t, store.Selected("session-0", "items/1/7/abc"))
+	assert.Equal(t, []string{"a"}, store.Selected("session-1", "items/1/7/abc"))
+	assert.Equal(t, []string{"a"}, store.Selected("newest", "items/1/7/abc"))
+}
+
+func TestEnsureSelectionSession(t *testing.T) {
+	w := httptest.NewRecorder()
+	id, err := ensureSelectionSession(w, httptest.NewRequest(http.MethodPost, "/", nil))
+	require.NoError(t, err)
+	require.Len(t, w.Result().Cookies(), 1)
+	cookie := w.Result().Cookies()[0]
+	assert.Equal(t, selectionCookieName, cookie.Name)
+	assert.Equal(t, id, cookie.Value)
+	assert.True(t, cookie.HttpOnly)
+
+	// An existing session is reused without a new cookie
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.AddCookie(cookie)
+	w = httptest.NewRecorder()
+	again, err := ensureSelectionSession(w, req)
+	require.NoError(t, err)
+	assert.Equal(t, id, again)
+	assert.Empty(t, w.Result().Cookies())
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/pages"
+)
+
+// ReportSharesPage lists the read-only shares of an audit run, offering the summary of the list in list
+// and highlighting the share in created.
+// GET /sites/{siteID}/audit-runs/{auditRunID}/shares?list=&created=
+func (h *ListHandlers) ReportSharesPage(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	data, err := scopedServices.SiteContentService.GetSiteWithLists(ctx, siteID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	shares, err := h.reportShareService.GetShares(ctx, siteID, scopedServices.AuditRunID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+
+	createdID, _ := strconv.ParseInt(r.URL.Query().Get("created"), 10, 64)
+	vm := h.listPresenter.ToReportSharesPageVM(siteID, scopedServices.AuditRunID, data.Site.URL, data.Lists, shares,
+		r.URL.Query().Get("list"), createdID, time.Now())
+	RenderResponse(ctx, w, r, pages.ReportSharesPage(vm))
+}
+
+// CreateReportShare creates a read-only link to the site summary, or to the summary of the list in
+// list_id, expiring after the duration in expires_in, then shows the run's shares.
+// POST /sites/{siteID}/audit-runs/{auditRunID}/shares
+func (h *ListHandlers) CreateReportShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid form data")
+		return
+	}
+	ttl, err := time.ParseDuration(r.PostFormValue("expires_in"))
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "expires_in must be a duration such as 168h")
+		return
+	}
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	share, err := h.reportShareService.CreateShare(ctx, siteID, scopedServices.AuditRunID, r.PostFormValue("list_id"), ttl)
+	if err != nil {
+		h.writeReportShareError(w, r, err)
+		return
+	}
+
+	location := fmt.Sprintf("/sites/%d/audit-runs/%d/shares?created=%d", siteID, scopedServices.AuditRunID, share.ID)
+	http.Redirect(w, r, location, http.StatusSeeOther)
+}
+
+// RevokeReportShare closes a read-only share of the run before it expires, then shows the run's shares.
+// POST /sites/{siteID}/audit-runs/{auditRunID}/shares/{shareID}/revoke
+func (h *ListHandlers) RevokeReportShare(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	shareID, err := strconv.ParseInt(chi.URLParam(r, "shareID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid shareID parameter")
+		return
+	}
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	if err := h.reportShareService.RevokeShare(ctx, siteID, scopedServices.AuditRunID, shareID); err != nil {
+		h.writeReportShareError(w, r, err)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/sites/%d/audit-runs/%d/shares", siteID, scopedServices.AuditRunID), http.StatusSeeOther)
+}
+
+// SharedReport renders the printable summary behind a read-only share link, without links back into
+// spaudit. Expired and revoked links are not found.
+// GET /shared/{token}
+func (h *ListHandlers) SharedReport(w http.ResponseWriter, r *http.Request) {
+	share, err := h.reportShareService.ResolveShare(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		h.writeReportShareError(w, r, err)
+		return
+	}
+
+	// Keep the token out of referrers and search indexes
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+
+	notice := presenters.ReportShareNotice(share)
+	auditRunIDStr := strconv.FormatInt(share.AuditRunID, 10)
+	if share.ListID != "" {
+		h.renderListPrintSummary(w, r, share.SiteID, auditRunIDStr, share.ListID, notice)
+		return
+	}
+	h.renderSitePrintSummary(w, r, share.SiteID, auditRunIDStr, notice)
+}
+
+// writeReportShareError writes a report share failure as a problem response.
+func (h *ListHandlers) writeReportShareError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, application.ErrInvalidReportShare) {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	if !isNotFoundError(err) {
+		h.logger.Error("Report share request failed", "path", r.URL.Path, "error", err)
+	}
+	writeServiceError(w, r, err)
+}
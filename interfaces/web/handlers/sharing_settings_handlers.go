@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// SetSiteSensitivity flags lists whose members can share on sites tagged sensitive; without it no
+// list is flagged.
+func (h *ListHandlers) SetSiteSensitivity(siteSensitivity *application.SiteSensitivityService) {
+	h.siteSensitivity = siteSensitivity
+}
+
+// GetSharingSettings returns whether members can share and where access requests go for each web and
+// list of an audit run, flagging lists whose members can share when the site is tagged sensitive
+// GET /api/sites/{siteID}/audit-runs/{auditRunID}/sharing-settings
+func (h *ListHandlers) GetSharingSettings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, err := h.extractSiteID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = "json"
+	}
+	if format != "json" && format != "csv" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "format must be json or csv")
+		return
+	}
+
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+	var sensitivity *audit.SiteSensitivity
+	if h.siteSensitivity != nil {
+		if sensitivity, err = h.siteSensitivity.GetSiteSensitivity(ctx, siteID); err != nil {
+			writeServiceError(w, r, err)
+			return
+		}
+	}
+	settings, err := scopedServices.SiteContentService.GetSharingSettings(ctx, siteID, sensitivity)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	view := h.listPresenter.ToSharingSettingsView(siteID, scopedServices.AuditRunID, settings)
+
+	if format == "csv" {
+		filename := fmt.Sprintf("sharing-settings-site%d-run%d.csv", siteID, scopedServices.AuditRunID)
+		h.writeCSVExport(w, r, siteID, scopedServices.AuditRunID, filename, h.listPresenter.SharingSettingsToCSV(view))
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, view); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// SiteSensitivityHandlers tags sites as holding sensitive content.
+type SiteSensitivityHandlers struct {
+	siteSensitivityService *application.SiteSensitivityService
+	listPresenter          *presenters.ListPresenter
+	logger                 *logging.Logger
+}
+
+// NewSiteSensitivityHandlers creates a new site sensitivity handlers instance.
+func NewSiteSensitivityHandlers(siteSensitivityService *application.SiteSensitivityService, listPresenter *presenters.ListPresenter) *SiteSensitivityHandlers {
+	return &SiteSensitivityHandlers{
+		siteSensitivityService: siteSensitivityService,
+		listPresenter:          listPresenter,
+		logger:                 logging.Default().WithComponent("site_sensitivity_handler"),
+	}
+}
+
+// TagSiteSensitiveRequest is the JSON body accepted by TagSiteSensitive.
+type TagSiteSensitiveRequest struct {
+	Reason string `json:"reason"` // Optional note on what makes the site sensitive
+}
+
+// GetSiteSensitivity returns a site's sensitivity tag, 404 when the site is not tagged
+// GET /api/sites/{siteID}/sensitivity
+func (h *SiteSensitivityHandlers) GetSiteSensitivity(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	tag, err := h.siteSensitivityService.GetSiteSensitivity(r.Context(), siteID)
+	if err != nil {
+		writeSiteSensitivityError(w, r, err)
+		return
+	}
+	if tag == nil {
+		writeSiteSensitivityError(w, r, fmt.Errorf("%w: site %d", application.ErrSiteNotTaggedSensitive, siteID))
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToSiteSensitivityView(tag)); err != nil {
+		h.logger.Error("Failed to encode site sensitivity response", "site_id", siteID, "error", err)
+	}
+}
+
+// TagSiteSensitive tags a site as holding sensitive content, replacing the reason given before
+// PUT /api/sites/{siteID}/sensitivity
+func (h *SiteSensitivityHandlers) TagSiteSensitive(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	var req TagSiteSensitiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	tag, err := h.siteSensitivityService.TagSite(r.Context(), siteID, req.Reason)
+	if err != nil {
+		writeSiteSensitivityError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToSiteSensitivityView(tag)); err != nil {
+		h.logger.Error("Failed to encode site sensitivity response", "site_id", siteID, "error", err)
+	}
+}
+
+// UntagSiteSensitive removes a site's sensitivity tag
+// DELETE /api/sites/{siteID}/sensitivity
+func (h *SiteSensitivityHandlers) UntagSiteSensitive(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	if err := h.siteSensitivityService.UntagSite(r.Context(), siteID); err != nil {
+		writeSiteSensitivityError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeSiteSensitivityError writes a failure to read or change a site's sensitivity tag as a problem response.
+func writeSiteSensitivityError(w http.ResponseWriter, r *http.Request, err error) {
+	if errors.Is(err, application.ErrSiteNotTaggedSensitive) {
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+		return
+	}
+	writeServiceError(w, r, err)
+}
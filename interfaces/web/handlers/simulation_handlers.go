@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/interfaces/web/templates/components/list"
+	"spaudit/interfaces/web/templates/pages"
+)
+
+// maxProposedChanges caps the changes one simulation request may propose.
+const maxProposedChanges = 200
+
+// SimulateListChangesRequest is the JSON body of a what-if simulation.
+type SimulateListChangesRequest struct {
+	Changes []presenters.ProposedChangeView `json:"changes"`
+}
+
+// SimulateListChanges previews how removing assignments and revoking sharing links would change
+// a list's effective access and risk score, computed from stored audit data
+// POST /api/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/simulate
+func (h *ListHandlers) SimulateListChanges(w http.ResponseWriter, r *http.Request) {
+	var req SimulateListChangesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if len(req.Changes) > maxProposedChanges {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("at most %d changes can be simulated at once", maxProposedChanges))
+		return
+	}
+
+	proposed := make([]application.ProposedChange, len(req.Changes))
+	for i, change := range req.Changes {
+		proposed[i] = application.ProposedChange{
+			Action:      change.Action,
+			PrincipalID: change.PrincipalID,
+			ItemGUID:    change.ItemGUID,
+			LinkID:      change.LinkID,
+		}
+	}
+
+	siteID, result, ok := h.simulateListChanges(w, r, proposed)
+	if !ok {
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToPermissionSimulationView(siteID, result)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// SimulationTab renders the what-if tab, offering the list's assignments and sharing links for removal
+// GET /sites/{siteID}/audit-runs/{auditRunID}/tabs/{listID}/simulate
+func (h *ListHandlers) SimulationTab(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return
+	}
+
+	snapshot, err := scopedServices.SiteContentService.GetListSnapshot(ctx, siteID, listID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return
+	}
+	form := h.listPresenter.ToSimulationForm(siteID, snapshot)
+
+	if IsHTMXPartialRequest(r) {
+		RenderResponse(ctx, w, r, pages.TabsAndContent(siteID, scopedServices.AuditRunID, listID, presenters.ListTabSimulate, pages.ListSimulationTab(form)))
+	} else {
+		vmList := h.permissionPresenter.MapListToViewModel(snapshot.List)
+		RenderResponse(ctx, w, r, pages.ListShell(h.runContext(ctx, siteID, scopedServices.AuditRunID), vmList, presenters.ListTabSimulate, pages.ListSimulationTab(form)))
+	}
+}
+
+// SimulationResult renders the what-if tab's preview for the checked assignments and links.
+// Changes that do not apply render a message rather than an error status so HTMX swaps it in.
+// POST /sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/simulate
+func (h *ListHandlers) SimulationResult(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid form: %v", err))
+		return
+	}
+
+	var proposed []application.ProposedChange
+	for _, value := range r.PostForm["remove"] {
+		principalID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid principal ID %q", value))
+			return
+		}
+		proposed = append(proposed, application.ProposedChange{Action: application.ProposedRemoveAssignment, PrincipalID: principalID})
+	}
+	for _, linkID := range r.PostForm["revoke"] {
+		proposed = append(proposed, application.ProposedChange{Action: application.ProposedRevokeLink, LinkID: linkID})
+	}
+	if len(proposed) == 0 {
+		RenderResponse(r.Context(), w, r, list.SimulationError("Select at least one assignment or sharing link to simulate removing."))
+		return
+	}
+
+	siteID, result, ok := h.simulateListChanges(w, r, proposed)
+	if !ok {
+		return
+	}
+	RenderResponse(r.Context(), w, r, list.SimulationResult(h.listPresenter.ToPermissionSimulationView(siteID, result)))
+}
+
+// simulateListChanges runs a simulation against the requested list's snapshot, writing a problem on failure.
+// Proposed changes that do not apply are reported as bad requests, or as a message for HTMX requests.
+func (h *ListHandlers) simulateListChanges(w http.ResponseWriter, r *http.Request, proposed []application.ProposedChange) (int64, *application.PermissionSimulationData, bool) {
+	ctx := r.Context()
+
+	siteID, listID, err := h.extractSiteAndListID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return 0, nil, false
+	}
+
+	auditRunIDStr, err := h.extractAuditRunID(r)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		return 0, nil, false
+	}
+
+	// Create audit-run-scoped services
+	scopedServices, err := h.serviceFactory.CreateForAuditRun(ctx, siteID, auditRunIDStr)
+	if err != nil {
+		writeAuditRunError(w, r, err)
+		return 0, nil, false
+	}
+
+	snapshot, err := scopedServices.SiteContentService.GetListSnapshot(ctx, siteID, listID)
+	if err != nil {
+		writeServiceError(w, r, err)
+		return 0, nil, false
+	}
+
+	result, err := scopedServices.PermissionService.SimulateListChanges(snapshot, proposed)
+	if err != nil {
+		switch {
+		case !errors.Is(err, application.ErrInvalidProposedChange):
+			writeServiceError(w, r, err)
+		case IsHTMXRequest(r):
+			RenderResponse(ctx, w, r, list.SimulationError(err.Error()))
+		default:
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		}
+		return 0, nil, false
+	}
+	return siteID, result, true
+}
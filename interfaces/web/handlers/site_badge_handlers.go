@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// siteBadgeMaxAge is how long portals and browsers may cache a badge, in seconds.
+const siteBadgeMaxAge = 300
+
+// SiteBadgeHandlers serves the risk badges other portals embed.
+type SiteBadgeHandlers struct {
+	badgeService  *application.SiteBadgeService
+	sitePresenter *presenters.SitePresenter
+	logger        *logging.Logger
+}
+
+// NewSiteBadgeHandlers creates a new site badge handlers instance.
+func NewSiteBadgeHandlers(badgeService *application.SiteBadgeService, sitePresenter *presenters.SitePresenter) *SiteBadgeHandlers {
+	return &SiteBadgeHandlers{
+		badgeService:  badgeService,
+		sitePresenter: sitePresenter,
+		logger:        logging.Default().WithComponent("site_badge_handler"),
+	}
+}
+
+// GetSiteBadge returns a site's current risk level and last audit date, as JSON or with format=svg
+// as an image. Any origin may read it, so intranet pages can fetch the JSON from the browser.
+// GET /api/sites/{siteID}/badge?sig={signature}&format={json|svg}
+func (h *SiteBadgeHandlers) GetSiteBadge(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "svg" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "format must be json or svg")
+		return
+	}
+
+	badge, err := h.badgeService.GetBadge(r.Context(), siteID, r.URL.Query().Get("sig"))
+	if err != nil {
+		writeSiteBadgeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(siteBadgeMaxAge))
+	if format == "svg" {
+		w.Header().Set("Content-Type", "image/svg+xml")
+		if _, err := w.Write(h.sitePresenter.RenderSiteBadgeSVG(badge)); err != nil {
+			h.logger.Error("Failed to write site badge", "site_id", siteID, "error", err)
+		}
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.sitePresenter.ToSiteBadgeView(badge)); err != nil {
+		h.logger.Error("Failed to encode site badge", "site_id", siteID, "error", err)
+	}
+}
+
+// GetSiteBadgeLink returns the signed URLs of a site's badge, to paste into a portal
+// GET /api/sites/{siteID}/badge-link
+func (h *SiteBadgeHandlers) GetSiteBadgeLink(w http.ResponseWriter, r *http.Request) {
+	siteID, err := strconv.ParseInt(chi.URLParam(r, "siteID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid siteID parameter")
+		return
+	}
+	signature, err := h.badgeService.SignBadge(r.Context(), siteID)
+	if err != nil {
+		writeSiteBadgeError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.sitePresenter.ToSiteBadgeLinkView(siteID, signature)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// writeSiteBadgeError writes a failure to issue or serve a badge as a problem response.
+func writeSiteBadgeError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, application.ErrBadgesDisabled):
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	case errors.Is(err, audit.ErrInvalidBadgeSignature):
+		WriteProblem(w, r, http.StatusForbidden, ErrCodeInvalidSignature, err.Error())
+	default:
+		writeServiceError(w, r, err)
+	}
+}
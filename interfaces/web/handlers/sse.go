@@ -2,11 +2,16 @@ package handlers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
 
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/domain/events"
 	"spaudit/domain/jobs"
 	"spaudit/interfaces/web/presenters"
 	"spaudit/logging"
@@ -27,10 +32,27 @@ type SSEManager struct {
 	mu             sync.RWMutex
 	logger         *logging.Logger
 	toastPresenter *presenters.ToastPresenter
+	jobPresenter   *presenters.JobPresenter
+	runArtifacts   RunArtifactLister
+	jobEvents      JobEventLister
 	ctx            context.Context
 	cancel         context.CancelFunc
 }
 
+// RunArtifactLister lists the reports attached to an audit run, offered in its job's completion toast.
+type RunArtifactLister interface {
+	ListRunArtifacts(ctx context.Context, auditRunID int64) ([]*audit.RunArtifact, error)
+}
+
+// JobEventLister replays the job event log to clients reconnecting after missing events.
+type JobEventLister interface {
+	ListEvents(ctx context.Context, filter events.JobEventFilter) (*application.JobEventPage, error)
+}
+
+// jobEventReplayLimit caps the events replayed to a reconnecting client; clients missing more
+// catch up through the job list refresh sent after the replay.
+const jobEventReplayLimit = 200
+
 // NewSSEManager creates a new SSE connection manager.
 func NewSSEManager(appCtx context.Context) *SSEManager {
 	ctx, cancel := context.WithCancel(appCtx)
@@ -39,6 +61,7 @@ func NewSSEManager(appCtx context.Context) *SSEManager {
 		clients:        make(map[string]*SSEClient),
 		logger:         logging.Default().WithComponent("sse_manager"),
 		toastPresenter: presenters.NewToastPresenter(),
+		jobPresenter:   presenters.NewJobPresenter(),
 		ctx:            ctx,
 		cancel:         cancel,
 	}
@@ -49,6 +72,16 @@ func NewSSEManager(appCtx context.Context) *SSEManager {
 	return manager
 }
 
+// SetRunArtifactLister sets the lister of reports offered in job completion toasts.
+func (s *SSEManager) SetRunArtifactLister(lister RunArtifactLister) {
+	s.runArtifacts = lister
+}
+
+// SetJobEventLister sets the event log replayed to clients reconnecting with a Last-Event-ID.
+func (s *SSEManager) SetJobEventLister(lister JobEventLister) {
+	s.jobEvents = lister
+}
+
 // AddClient adds a new SSE client connection.
 func (s *SSEManager) AddClient(clientID string, w http.ResponseWriter) *SSEClient {
 	// Set SSE headers
@@ -309,6 +342,24 @@ func (s *SSEManager) BroadcastToast(message, toastType string) {
 		"failed", len(failedClients))
 }
 
+// NotifyFindingAlert broadcasts a finding alert as a toast, colored by its severity
+func (s *SSEManager) NotifyFindingAlert(alert *audit.FindingAlert) {
+	message := fmt.Sprintf("%s finding: %s", presenters.SeverityLabel(string(alert.Severity)), alert.Message)
+	s.BroadcastToast(message, findingAlertToastType(alert.Severity))
+}
+
+// findingAlertToastType returns the toast type of a finding alert's severity
+func findingAlertToastType(severity audit.Severity) string {
+	switch severity {
+	case audit.SeverityCritical, audit.SeverityHigh:
+		return "failed"
+	case audit.SeverityMedium:
+		return "cancelled"
+	default:
+		return "info"
+	}
+}
+
 // BroadcastRichJobToast broadcasts a rich toast notification with job details
 func (s *SSEManager) BroadcastRichJobToast(job *jobs.Job) {
 	// Copy clients list to avoid holding lock during I/O
@@ -329,7 +380,17 @@ func (s *SSEManager) BroadcastRichJobToast(job *jobs.Job) {
 	failedClients := []string{}
 
 	// Use presenter to format rich toast HTML (proper clean architecture)
-	toastHTML, err := s.toastPresenter.FormatRichJobToastNotification(job)
+	// Offer the reports generated when the run completed
+	var artifacts []*audit.RunArtifact
+	if s.runArtifacts != nil && job.Status == jobs.JobStatusCompleted && job.HasAuditRun() {
+		var err error
+		artifacts, err = s.runArtifacts.ListRunArtifacts(s.ctx, job.GetAuditRunID())
+		if err != nil {
+			s.logger.Warn("Failed to list run artifacts for job toast", "job_id", job.ID, "error", err)
+		}
+	}
+
+	toastHTML, err := s.toastPresenter.FormatRichJobToastNotification(job, artifacts)
 	if err != nil {
 		s.logger.Error("Failed to format rich job toast notification", "error", err, "job_id", job.ID)
 		return
@@ -361,6 +422,115 @@ func (s *SSEManager) BroadcastRichJobToast(job *jobs.Job) {
 		"failed", len(failedClients))
 }
 
+// NotifyArtifactRegistered implements ArtifactNotifier by offering the new report for download in a toast
+func (s *SSEManager) NotifyArtifactRegistered(artifact *audit.RunArtifact) {
+	// Copy clients list to avoid holding lock during I/O
+	s.mu.RLock()
+	if len(s.clients) == 0 {
+		s.mu.RUnlock()
+		s.logger.Debug("No SSE clients connected, skipping artifact toast broadcast")
+		return
+	}
+
+	clientList := make(map[string]*SSEClient, len(s.clients))
+	for id, client := range s.clients {
+		clientList[id] = client
+	}
+	s.mu.RUnlock()
+
+	toastHTML, err := s.toastPresenter.FormatArtifactToastNotification(artifact)
+	if err != nil {
+		s.logger.Error("Failed to format artifact toast notification", "error", err, "artifact_id", artifact.ID)
+		return
+	}
+
+	failedClients := []string{}
+	for clientID, client := range clientList {
+		if err := s.sendToClient(client, "toast", toastHTML); err != nil {
+			s.logger.Warn("Failed to send artifact toast to client",
+				"client_id", clientID,
+				"artifact_id", artifact.ID,
+				"error", err)
+			failedClients = append(failedClients, clientID)
+		}
+	}
+
+	// Remove failed clients after broadcasting
+	for _, clientID := range failedClients {
+		s.RemoveClient(clientID)
+	}
+}
+
+// NotifyJobEvent broadcasts a job event once it is recorded in the event log
+func (s *SSEManager) NotifyJobEvent(event *events.RecordedJobEvent) {
+	// Copy clients list to avoid holding lock during I/O
+	s.mu.RLock()
+	clientList := make(map[string]*SSEClient, len(s.clients))
+	for id, client := range s.clients {
+		clientList[id] = client
+	}
+	s.mu.RUnlock()
+
+	failedClients := []string{}
+	for clientID, client := range clientList {
+		if err := s.sendJobEventToClient(client, event); err != nil {
+			s.logger.Warn("Failed to send job event to client",
+				"client_id", clientID,
+				"sequence", event.Sequence,
+				"error", err)
+			failedClients = append(failedClients, clientID)
+		}
+	}
+
+	// Remove failed clients after broadcasting
+	for _, clientID := range failedClients {
+		s.RemoveClient(clientID)
+	}
+}
+
+// replayJobEvents sends a reconnecting client the job events recorded after the last one it saw,
+// then has it refresh the job list to pick up any changes beyond the replay
+func (s *SSEManager) replayJobEvents(ctx context.Context, client *SSEClient, lastEventID int64) error {
+	if s.jobEvents == nil {
+		return nil
+	}
+	page, err := s.jobEvents.ListEvents(ctx, events.JobEventFilter{AfterSequence: lastEventID, Limit: jobEventReplayLimit})
+	if err != nil {
+		s.logger.Warn("Failed to replay job events", "client_id", client.id, "last_event_id", lastEventID, "error", err)
+		return nil
+	}
+	if len(page.Events) == 0 {
+		return nil
+	}
+
+	for _, event := range page.Events {
+		if err := s.sendJobEventToClient(client, event); err != nil {
+			return err
+		}
+	}
+	s.logger.Info("Replayed missed job events",
+		"client_id", client.id,
+		"last_event_id", lastEventID,
+		"replayed", len(page.Events),
+		"truncated", page.HasMore)
+	message := `{"action": "refresh", "timestamp": "` + time.Now().Format(time.RFC3339) + `"}`
+	return s.sendToClient(client, "jobs-updated", message)
+}
+
+// lastEventID returns the sequence of the last job event a reconnecting client saw, from the
+// Last-Event-ID header browsers send on reconnect or the last_event_id query parameter; 0 when absent
+func lastEventID(r *http.Request) int64 {
+	raw := r.Header.Get("Last-Event-ID")
+	if raw == "" {
+		raw = r.URL.Query().Get("last_event_id")
+	}
+	sequence, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || sequence < 0 {
+		return 0
+	}
+	return sequence
+}
+
 // sendToClient sends an SSE message to a specific client
 func (s *SSEManager) sendToClient(client *SSEClient, event, data string) error {
 	select {
@@ -379,6 +549,27 @@ func (s *SSEManager) sendToClient(client *SSEClient, event, data string) error {
 		message = fmt.Sprintf("event: %s\ndata: %s\n\n", event, data)
 	}
 
+	return s.writeToClient(client, message)
+}
+
+// sendJobEventToClient sends a recorded job event with its sequence number as the event id, so a
+// reconnecting browser reports the last event it saw in the Last-Event-ID header
+func (s *SSEManager) sendJobEventToClient(client *SSEClient, event *events.RecordedJobEvent) error {
+	select {
+	case <-client.done:
+		return fmt.Errorf("client connection closed")
+	default:
+	}
+
+	data, err := json.Marshal(s.jobPresenter.FormatJobEvent(event))
+	if err != nil {
+		return fmt.Errorf("encode job event %d: %w", event.Sequence, err)
+	}
+	return s.writeToClient(client, fmt.Sprintf("id: %d\nevent: job-event\ndata: %s\n\n", event.Sequence, data))
+}
+
+// writeToClient writes a formatted SSE message and flushes it
+func (s *SSEManager) writeToClient(client *SSEClient, message string) error {
 	_, err := client.writer.Write([]byte(message))
 	if err != nil {
 		return fmt.Errorf("write error: %w", err)
@@ -463,7 +654,7 @@ func (s *SSEManager) HandleSSEConnection(w http.ResponseWriter, r *http.Request)
 	client := s.AddClient(clientID, w)
 	if client == nil {
 		s.logger.Error("Failed to establish SSE connection", "client_id", clientID)
-		http.Error(w, "Failed to establish SSE connection", http.StatusInternalServerError)
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeStreamUnavailable, "Failed to establish SSE connection")
 		return
 	}
 
@@ -474,6 +665,15 @@ func (s *SSEManager) HandleSSEConnection(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Catch a reconnecting client up on the job events it missed
+	if lastSeen := lastEventID(r); lastSeen > 0 {
+		if err := s.replayJobEvents(r.Context(), client, lastSeen); err != nil {
+			s.logger.Error("Failed to send replayed job events", "client_id", clientID, "error", err)
+			s.RemoveClient(clientID)
+			return
+		}
+	}
+
 	// Keep connection alive until client disconnects
 	ctx := r.Context()
 
@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/application"
+	"spaudit/domain/events"
+)
+
+// stubJobEventLister returns a fixed page of events and records the filter asked for.
+type stubJobEventLister struct {
+	page   *application.JobEventPage
+	filter events.JobEventFilter
+}
+
+func (l *stubJobEventLister) ListEvents(_ context.Context, filter events.JobEventFilter) (*application.JobEventPage, error) {
+	l.filter = filter
+	return l.page, nil
+}
+
+func TestSSEManager_ReplaysMissedJobEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager := NewSSEManager(ctx)
+
+	occurredAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	lister := &stubJobEventLister{page: &application.JobEventPage{Events: []*events.RecordedJobEvent{
+		{Sequence: 8, Type: events.JobEventCompleted, JobID: "job-1", OccurredAt: occurredAt},
+	}}}
+	manager.SetJobEventLister(lister)
+
+	request := httptest.NewRequest("GET", "/events?client_id=c1", nil)
+	request.Header.Set("Last-Event-ID", "7")
+	recorder := httptest.NewRecorder()
+	client := manager.AddClient("c1", recorder)
+	require.NotNil(t, client)
+
+	require.NoError(t, manager.replayJobEvents(ctx, client, lastEventID(request)))
+	assert.Equal(t, int64(7), lister.filter.AfterSequence)
+	body := recorder.Body.String()
+	assert.Contains(t, body, "id: 8\nevent: job-event\ndata: {\"sequence\":8,\"type\":\"job_completed\",\"job_id\":\"job-1\"")
+	assert.Contains(t, body, "event: jobs-updated\n")
+}
+
+func TestLastEventID(t *testing.T) {
+	fromQuery := httptest.NewRequest("GET", "/events?last_event_id=12", nil)
+	assert.Equal(t, int64(12), lastEventID(fromQuery))
+
+	malformed := httptest.NewRequest("GET", "/events", nil)
+	malformed.Header.Set("Last-Event-ID", "not-a-number")
+	assert.Zero(t, lastEventID(malformed))
+}
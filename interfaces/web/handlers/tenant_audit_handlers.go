@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/logging"
+)
+
+// TenantAuditHandlers starts audits of every site collection in a tenant.
+type TenantAuditHandlers struct {
+	tenantAuditService *application.TenantAuditService
+	sseManager         *SSEManager
+	logger             *logging.Logger
+}
+
+// NewTenantAuditHandlers creates a new tenant audit handlers instance.
+func NewTenantAuditHandlers(tenantAuditService *application.TenantAuditService, sseManager *SSEManager) *TenantAuditHandlers {
+	return &TenantAuditHandlers{
+		tenantAuditService: tenantAuditService,
+		sseManager:         sseManager,
+		logger:             logging.Default().WithComponent("tenant_audit_handler"),
+	}
+}
+
+// QueueTenantAuditRequest is the JSON body accepted by QueueTenantAudit. The audit options apply
+// to every site and are those of QueueAuditRequest; site_url is ignored and folder_path refused.
+type QueueTenantAuditRequest struct {
+	TenantURL string `json:"tenant_url"`
+	QueueAuditRequest
+}
+
+// TenantAuditQueued acknowledges a queued tenant audit.
+type TenantAuditQueued struct {
+	JobID    string `json:"job_id"`
+	AdminURL string `json:"admin_url"`
+	Status   string `json:"status"`
+}
+
+// QueueTenantAudit queues an audit of every site collection in a tenant and responds 202 with the
+// job to follow. The job's result lists the site audit jobs it queued.
+// POST /api/tenant-audits
+func (h *TenantAuditHandlers) QueueTenantAudit(w http.ResponseWriter, r *http.Request) {
+	var req QueueTenantAuditRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+
+	tenantURL := strings.TrimSpace(req.TenantURL)
+	if tenantURL == "" {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "tenant_url is required")
+		return
+	}
+	if req.SampleThreshold != 0 && req.SampleThreshold < audit.MinSampleThreshold {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, fmt.Sprintf("sample_threshold must be at least %d", audit.MinSampleThreshold))
+		return
+	}
+
+	job, err := h.tenantAuditService.StartTenantAudit(r.Context(), tenantURL, req.parameters())
+	if err != nil {
+		h.logger.Error("Failed to queue tenant audit", "tenant_url", tenantURL, "error", err)
+		switch {
+		case errors.Is(err, application.ErrAuditAlreadyQueued) || errors.Is(err, application.ErrMaintenanceRunning):
+			WriteProblem(w, r, http.StatusConflict, ErrCodeAuditConflict, err.Error())
+		case errors.Is(err, sharepoint.ErrInvalidTenantURL) || errors.Is(err, application.ErrInvalidAuditScope) || errors.Is(err, application.ErrInvalidRunLabel) ||
+			errors.Is(err, application.ErrInvalidCollectorBackend):
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+		default:
+			WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, err.Error())
+		}
+		return
+	}
+
+	h.sseManager.BroadcastJobListUpdate()
+
+	if err := WriteJSON(w, http.StatusAccepted, TenantAuditQueued{JobID: job.ID, AdminURL: job.GetSiteURL(), Status: "queued"}); err != nil {
+		h.logger.Error("Failed to encode queued tenant audit response", "error", err)
+	}
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"spaudit/application"
+	"spaudit/interfaces/web/presenters"
+	"spaudit/logging"
+)
+
+// webhookDeliveriesDefaultLimit is the number of deliveries listed when no limit is given.
+const webhookDeliveriesDefaultLimit = 50
+
+// WebhookDeliveryHandlers reports and retries the notifications posted to outbound webhook endpoints.
+type WebhookDeliveryHandlers struct {
+	webhookService *application.WebhookNotificationService
+	listPresenter  *presenters.ListPresenter
+	logger         *logging.Logger
+}
+
+// NewWebhookDeliveryHandlers creates a new webhook delivery handlers instance.
+func NewWebhookDeliveryHandlers(webhookService *application.WebhookNotificationService, listPresenter *presenters.ListPresenter) *WebhookDeliveryHandlers {
+	return &WebhookDeliveryHandlers{
+		webhookService: webhookService,
+		listPresenter:  listPresenter,
+		logger:         logging.Default().WithComponent("webhook_delivery_handler"),
+	}
+}
+
+// ListDeliveries lists the most recent webhook deliveries, newest first, optionally of one status
+// GET /api/webhook-deliveries?status={pending|delivered|failed}&limit={n}
+func (h *WebhookDeliveryHandlers) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	limit := int64(webhookDeliveriesDefaultLimit)
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 1 || parsed > apiMaxPageSize {
+			WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "limit must be between 1 and "+strconv.Itoa(apiMaxPageSize))
+			return
+		}
+		limit = parsed
+	}
+
+	deliveries, err := h.webhookService.GetDeliveries(r.Context(), r.URL.Query().Get("status"), limit)
+	if err != nil {
+		writeWebhookDeliveryError(w, r, err)
+		return
+	}
+	if err := WriteJSON(w, http.StatusOK, h.listPresenter.ToWebhookDeliveryViews(deliveries)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// RetryDelivery queues a failed webhook delivery for one more attempt, made at once
+// POST /api/webhook-deliveries/{deliveryID}/retry
+func (h *WebhookDeliveryHandlers) RetryDelivery(w http.ResponseWriter, r *http.Request) {
+	deliveryID, err := strconv.ParseInt(chi.URLParam(r, "deliveryID"), 10, 64)
+	if err != nil {
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, "invalid deliveryID parameter")
+		return
+	}
+
+	delivery, err := h.webhookService.RetryDelivery(r.Context(), deliveryID)
+	if err != nil {
+		writeWebhookDeliveryError(w, r, err)
+		return
+	}
+	h.logger.Info("Webhook delivery queued for retry", "delivery_id", deliveryID, "attempts", delivery.Attempts)
+	if err := WriteJSON(w, http.StatusAccepted, h.listPresenter.ToWebhookDeliveryView(delivery)); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to encode response")
+	}
+}
+
+// writeWebhookDeliveryError writes a webhook delivery service error as a problem response.
+func writeWebhookDeliveryError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, application.ErrInvalidWebhookDeliveryFilter):
+		WriteProblem(w, r, http.StatusBadRequest, ErrCodeInvalidParameter, err.Error())
+	case errors.Is(err, application.ErrWebhookDeliveryNotFound):
+		WriteProblem(w, r, http.StatusNotFound, ErrCodeNotFound, err.Error())
+	case errors.Is(err, application.ErrWebhookDeliveryNotFailed):
+		WriteProblem(w, r, http.StatusConflict, ErrCodeDeliveryConflict, err.Error())
+	default:
+		writeServiceError(w, r, err)
+	}
+}
@@ -0,0 +1,251 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"spaudit/interfaces/web/presenters"
+)
+
+// xlsxStyle is a cell format index into the workbook's styles part.
+type xlsxStyle int
+
+// Cell formats, in the order of cellXfs in xlsxStyles.
+const (
+	xlsxStyleDefault xlsxStyle = iota
+	xlsxStyleHeader            // Bold, grey fill
+	xlsxStyleAdded             // Green fill
+	xlsxStyleRemoved           // Red fill, struck through
+)
+
+// xlsxHighlight is the format a conditional format applies, an index into dxfs in xlsxStyles.
+type xlsxHighlight int
+
+// Conditional highlights, in the order of dxfs in xlsxStyles.
+const (
+	xlsxHighlightRed   xlsxHighlight = iota // Red fill, dark red text
+	xlsxHighlightAmber                      // Amber fill, dark amber text
+	xlsxHighlightGreen                      // Green fill, dark green text
+)
+
+// xlsxConditionalFormat highlights the cells of a range containing Text, or every non-blank cell when
+// Text is empty. Formats listed first take precedence where ranges overlap.
+type xlsxConditionalFormat struct {
+	FirstColumn, LastColumn int // Zero-based
+	FirstRow, LastRow       int // 1-based, the header is row 1
+	Text                    string
+	Highlight               xlsxHighlight
+}
+
+// xlsxSheet is the content and layout of a workbook's single worksheet.
+type xlsxSheet struct {
+	Name               string
+	Table              presenters.CSVTable
+	RowStyles          []xlsxStyle // Format of each data row; the header row is always bold
+	FrozenColumns      int         // Leading columns kept in view when scrolling right
+	ConditionalFormats []xlsxConditionalFormat
+}
+
+const xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+<Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>
+</Types>`
+
+const xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>
+</Relationships>`
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<fonts count="3">
+<font><sz val="11"/><name val="Calibri"/></font>
+<font><b/><sz val="11"/><name val="Calibri"/></font>
+<font><strike/><sz val="11"/><color rgb="FF9C0006"/><name val="Calibri"/></font>
+</fonts>
+<fills count="5">
+<fill><patternFill patternType="none"/></fill>
+<fill><patternFill patternType="gray125"/></fill>
+<fill><patternFill patternType="solid"><fgColor rgb="FFE2E8F0"/></patternFill></fill>
+<fill><patternFill patternType="solid"><fgColor rgb="FFC6EFCE"/></patternFill></fill>
+<fill><patternFill patternType="solid"><fgColor rgb="FFFFC7CE"/></patternFill></fill>
+</fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="4">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/>
+<xf numFmtId="0" fontId="0" fillId="3" borderId="0" xfId="0" applyFill="1"/>
+<xf numFmtId="0" fontId="2" fillId="4" borderId="0" xfId="0" applyFont="1" applyFill="1"/>
+</cellXfs>
+<dxfs count="3">
+<dxf><font><color rgb="FF9C0006"/></font><fill><patternFill><bgColor rgb="FFFFC7CE"/></patternFill></fill></dxf>
+<dxf><font><color rgb="FF9C5700"/></font><fill><patternFill><bgColor rgb="FFFFEB9C"/></patternFill></fill></dxf>
+<dxf><font><color rgb="FF006100"/></font><fill><patternFill><bgColor rgb="FFC6EFCE"/></patternFill></fill></dxf>
+</dxfs>
+</styleSheet>`
+
+// xlsxContentType is the content type of XLSX exports.
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// writeXLSXExport sends a table exported from an audit run as a single-sheet XLSX file download.
+// rowStyles holds the format of each data row; the header row is always bold.
+func (h *ListHandlers) writeXLSXExport(w http.ResponseWriter, r *http.Request, siteID, auditRunID int64, filename, sheetName string, table presenters.CSVTable, rowStyles []xlsxStyle) {
+	h.writeXLSXSheetExport(w, r, siteID, auditRunID, filename, xlsxSheet{Name: sheetName, Table: table, RowStyles: rowStyles})
+}
+
+// writeXLSXSheetExport sends a worksheet exported from an audit run as a single-sheet XLSX file download.
+func (h *ListHandlers) writeXLSXSheetExport(w http.ResponseWriter, r *http.Request, siteID, auditRunID int64, filename string, sheet xlsxSheet) {
+	// Build the archive in memory so a failure can still be reported as a problem
+	var buf bytes.Buffer
+	if err := encodeXLSXSheet(&buf, sheet); err != nil {
+		WriteProblem(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to build spreadsheet")
+		return
+	}
+
+	h.writeRunExport(w, r, siteID, auditRunID, filename, xlsxContentType, buf.Bytes())
+}
+
+// encodeXLSX writes a minimal workbook with one worksheet of inline string cells.
+func encodeXLSX(out io.Writer, sheetName string, table presenters.CSVTable, rowStyles []xlsxStyle) error {
+	return encodeXLSXSheet(out, xlsxSheet{Name: sheetName, Table: table, RowStyles: rowStyles})
+}
+
+// encodeXLSXSheet writes a minimal workbook with the worksheet.
+func encodeXLSXSheet(out io.Writer, sheet xlsxSheet) error {
+	archive := zip.NewWriter(out)
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", xlsxWorkbook(sheet.Name)},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, part := range parts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("create %s: %w", part.name, err)
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return fmt.Errorf("write %s: %w", part.name, err)
+		}
+	}
+
+	part, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return fmt.Errorf("create worksheet: %w", err)
+	}
+	if err := writeXLSXSheet(part, sheet); err != nil {
+		return fmt.Errorf("write worksheet: %w", err)
+	}
+
+	return archive.Close()
+}
+
+// xlsxWorkbook returns the workbook part naming its single sheet.
+func xlsxWorkbook(sheetName string) string {
+	var name bytes.Buffer
+	_ = xml.EscapeText(&name, []byte(sheetName))
+	return `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="` + name.String() + `" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+}
+
+// writeXLSXSheet writes the worksheet part: the header row and any leading columns, frozen, then the data
+// rows and their conditional formats.
+func writeXLSXSheet(out io.Writer, sheet xlsxSheet) error {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	buf.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	if sheet.FrozenColumns > 0 {
+		columns := strconv.Itoa(sheet.FrozenColumns)
+		buf.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane xSplit="` + columns + `" ySplit="1" topLeftCell="` +
+			xlsxColumnName(sheet.FrozenColumns) + `2" activePane="bottomRight" state="frozen"/></sheetView></sheetViews>`)
+	} else {
+		buf.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	}
+	buf.WriteString(`<sheetData>`)
+
+	writeXLSXRow(&buf, 1, sheet.Table.Header, xlsxStyleHeader)
+	for i, row := range sheet.Table.Rows {
+		style := xlsxStyleDefault
+		if i < len(sheet.RowStyles) {
+			style = sheet.RowStyles[i]
+		}
+		writeXLSXRow(&buf, i+2, row, style)
+	}
+
+	buf.WriteString(`</sheetData>`)
+	for i, format := range sheet.ConditionalFormats {
+		writeXLSXConditionalFormat(&buf, format, i+1)
+	}
+	buf.WriteString(`</worksheet>`)
+	_, err := out.Write(buf.Bytes())
+	return err
+}
+
+// writeXLSXRow writes one row of inline string cells with the given format.
+func writeXLSXRow(buf *bytes.Buffer, rowNumber int, cells []string, style xlsxStyle) {
+	row := strconv.Itoa(rowNumber)
+	buf.WriteString(`<row r="` + row + `">`)
+	for i, value := range cells {
+		buf.WriteString(`<c r="` + xlsxColumnName(i) + row + `" t="inlineStr"`)
+		if style != xlsxStyleDefault {
+			buf.WriteString(` s="` + strconv.Itoa(int(style)) + `"`)
+		}
+		buf.WriteString(`><is><t xml:space="preserve">`)
+		_ = xml.EscapeText(buf, []byte(value))
+		buf.WriteString(`</t></is></c>`)
+	}
+	buf.WriteString(`</row>`)
+}
+
+// writeXLSXConditionalFormat writes a conditional format with the given priority, 1 being applied first.
+func writeXLSXConditionalFormat(buf *bytes.Buffer, format xlsxConditionalFormat, priority int) {
+	topLeft := xlsxColumnName(format.FirstColumn) + strconv.Itoa(format.FirstRow)
+	ref := topLeft + ":" + xlsxColumnName(format.LastColumn) + strconv.Itoa(format.LastRow)
+	attrs := ` dxfId="` + strconv.Itoa(int(format.Highlight)) + `" priority="` + strconv.Itoa(priority) + `"`
+
+	buf.WriteString(`<conditionalFormatting sqref="` + ref + `">`)
+	if format.Text == "" {
+		buf.WriteString(`<cfRule type="notContainsBlanks"` + attrs + `><formula>LEN(TRIM(` + topLeft + `))&gt;0</formula></cfRule>`)
+	} else {
+		var text bytes.Buffer
+		_ = xml.EscapeText(&text, []byte(format.Text))
+		quoted := strings.ReplaceAll(text.String(), "&#34;", "&#34;&#34;")
+		buf.WriteString(`<cfRule type="containsText" operator="containsText" text="` + text.String() + `"` + attrs + `>`)
+		buf.WriteString(`<formula>NOT(ISERROR(SEARCH(&#34;` + quoted + `&#34;,` + topLeft + `)))</formula></cfRule>`)
+	}
+	buf.WriteString(`</conditionalFormatting>`)
+}
+
+// xlsxColumnName converts a zero-based column index to its letters (0 → A, 26 → AA).
+func xlsxColumnName(index int) string {
+	name := ""
+	for index >= 0 {
+		name = string(rune('A'+index%26)) + name
+		index = index/26 - 1
+	}
+	return name
+}
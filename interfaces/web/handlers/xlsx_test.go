@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/interfaces/web/presenters"
+)
+
+func TestEncodeXLSX(t *testing.T) {
+	table := presenters.CSVTable{
+		Header: []string{"Change", "Principal"},
+		Rows: [][]string{
+			{"Added", "Alice & Bob <admins>"},
+			{"Removed", "Carol"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, encodeXLSX(&buf, "Changes", table, []xlsxStyle{xlsxStyleAdded, xlsxStyleRemoved}))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	parts := make(map[string][]byte)
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		reader.Close()
+		parts[file.Name] = content
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		require.Contains(t, parts, name)
+	}
+
+	var sheet struct {
+		Rows []struct {
+			Ref   string `xml:"r,attr"`
+			Cells []struct {
+				Ref   string `xml:"r,attr"`
+				Style string `xml:"s,attr"`
+				Text  string `xml:"is>t"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+	require.NoError(t, xml.Unmarshal(parts["xl/worksheets/sheet1.xml"], &sheet))
+	require.Len(t, sheet.Rows, 3)
+
+	assert.Equal(t, "A1", sheet.Rows[0].Cells[0].Ref)
+	assert.Equal(t, "1", sheet.Rows[0].Cells[0].Style)
+	assert.Equal(t, "Alice & Bob <admins>", sheet.Rows[1].Cells[1].Text)
+	assert.Equal(t, "B2", sheet.Rows[1].Cells[1].Ref)
+	assert.Equal(t, "2", sheet.Rows[1].Cells[1].Style)
+	assert.Equal(t, "3", sheet.Rows[2].Cells[0].Style)
+}
+
+func TestXLSXColumnName(t *testing.T) {
+	assert.Equal(t, "A", xlsxColumnName(0))
+	assert.Equal(t, "Z", xlsxColumnName(25))
+	assert.Equal(t, "AA", xlsxColumnName(26))
+	assert.Equal(t, "AZ", xlsxColumnName(51))
+	assert.Equal(t, "BA", xlsxColumnName(52))
+}
+
+func TestWriteXLSXSheet_ConditionalFormats(t *testing.T) {
+	table := presenters.CSVTable{
+		Header: []string{"Type", "Object", "URL", "Ada", "Owners"},
+		Rows: [][]string{
+			{"List", "Documents", "https://contoso.sharepoint.com/sites/a/docs", "Read", "Full Control"},
+			{"Item", "a.docx", "https://contoso.sharepoint.com/sites/a/docs/a.docx", "Edit; Read", ""},
+		},
+	}
+	formats := permissionMatrixFormats(table, []string{"Full Control", "Edit", "Read", `Say "hi"`}, presenters.PermissionMatrixByPrincipal)
+	require.Len(t, formats, 3, "custom roles are not highlighted")
+	assert.Equal(t, xlsxConditionalFormat{FirstColumn: 3, LastColumn: 4, FirstRow: 2, LastRow: 3, Text: "Full Control", Highlight: xlsxHighlightRed}, formats[0])
+	assert.Equal(t, xlsxHighlightAmber, formats[1].Highlight)
+	assert.Equal(t, xlsxHighlightGreen, formats[2].Highlight)
+
+	formats = append(formats, xlsxConditionalFormat{FirstColumn: 3, LastColumn: 3, FirstRow: 2, LastRow: 3, Text: `Say "hi"`})
+	var buf bytes.Buffer
+	require.NoError(t, writeXLSXSheet(&buf, xlsxSheet{Table: table, FrozenColumns: 3, ConditionalFormats: formats}))
+
+	var sheet struct {
+		Pane struct {
+			XSplit      string `xml:"xSplit,attr"`
+			TopLeftCell string `xml:"topLeftCell,attr"`
+		} `xml:"sheetViews>sheetView>pane"`
+		Formats []struct {
+			Ref  string `xml:"sqref,attr"`
+			Rule struct {
+				Type     string `xml:"type,attr"`
+				Text     string `xml:"text,attr"`
+				DxfID    string `xml:"dxfId,attr"`
+				Priority string `xml:"priority,attr"`
+				Formula  string `xml:"formula"`
+			} `xml:"cfRule"`
+		} `xml:"conditionalFormatting"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &sheet))
+	assert.Equal(t, "3", sheet.Pane.XSplit)
+	assert.Equal(t, "D2", sheet.Pane.TopLeftCell)
+	require.Len(t, sheet.Formats, 4)
+	assert.Equal(t, "D2:E3", sheet.Formats[0].Ref)
+	assert.Equal(t, "containsText", sheet.Formats[0].Rule.Type)
+	assert.Equal(t, "0", sheet.Formats[0].Rule.DxfID)
+	assert.Equal(t, "1", sheet.Formats[0].Rule.Priority)
+	assert.Equal(t, `NOT(ISERROR(SEARCH("Full Control",D2)))`, sheet.Formats[0].Rule.Formula)
+	assert.Equal(t, `Say "hi"`, sheet.Formats[3].Rule.Text)
+	assert.Equal(t, `NOT(ISERROR(SEARCH("Say ""hi""",D2)))`, sheet.Formats[3].Rule.Formula, "quotes are doubled in formulas")
+
+	byRole := presenters.CSVTable{Header: []string{"Type", "Object", "URL", "Full Control", "Approve", "Read"}, Rows: table.Rows}
+	formats = permissionMatrixFormats(byRole, nil, presenters.PermissionMatrixByRole)
+	require.Len(t, formats, 2)
+	assert.Equal(t, xlsxConditionalFormat{FirstColumn: 5, LastColumn: 5, FirstRow: 2, LastRow: 3, Highlight: xlsxHighlightGreen}, formats[1])
+	buf.Reset()
+	require.NoError(t, writeXLSXSheet(&buf, xlsxSheet{Table: byRole, ConditionalFormats: formats}))
+	sheet.Formats = nil
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &sheet))
+	assert.Equal(t, "F2:F3", sheet.Formats[1].Ref)
+	assert.Equal(t, "notContainsBlanks", sheet.Formats[1].Rule.Type)
+	assert.Equal(t, "LEN(TRIM(F2))>0", sheet.Formats[1].Rule.Formula)
+}
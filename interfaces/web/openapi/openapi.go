@@ -0,0 +1,13 @@
+// Package openapi embeds the hand-maintained OpenAPI 3 specification of the JSON API.
+// The spec is validated against the router in tests; update openapi.json whenever a JSON endpoint changes.
+package openapi
+
+import _ "embed"
+
+//go:embed openapi.json
+var spec []byte
+
+// Spec returns the OpenAPI document as JSON.
+func Spec() []byte {
+	return spec
+}
@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseSpec(t *testing.T) map[string]interface{} {
+	t.Helper()
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(Spec(), &doc), "openapi.json must be valid JSON")
+	return doc
+}
+
+// collectRefs walks the document and returns every $ref value.
+func collectRefs(node interface{}, refs *[]string) {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				*refs = append(*refs, ref)
+				continue
+			}
+			collectRefs(child, refs)
+		}
+	case []interface{}:
+		for _, child := range v {
+			collectRefs(child, refs)
+		}
+	}
+}
+
+// resolvePointer resolves a local JSON pointer such as #/components/schemas/Problem.
+func resolvePointer(doc map[string]interface{}, ref string) bool {
+	if !strings.HasPrefix(ref, "#/") {
+		return false
+	}
+	var node interface{} = doc
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		obj, ok := node.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if node, ok = obj[part]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSpec_IsOpenAPI3(t *testing.T) {
+	doc := parseSpec(t)
+
+	version, _ := doc["openapi"].(string)
+	assert.True(t, strings.HasPrefix(version, "3."), "openapi version %q", version)
+
+	info, ok := doc["info"].(map[string]interface{})
+	require.True(t, ok, "info is required")
+	assert.NotEmpty(t, info["title"])
+	assert.NotEmpty(t, info["version"])
+
+	paths, ok := doc["paths"].(map[string]interface{})
+	require.True(t, ok, "paths is required")
+	assert.NotEmpty(t, paths)
+}
+
+func TestSpec_RefsResolve(t *testing.T) {
+	doc := parseSpec(t)
+
+	var refs []string
+	collectRefs(doc, &refs)
+	require.NotEmpty(t, refs)
+
+	for _, ref := range refs {
+		assert.True(t, resolvePointer(doc, ref), "unresolved $ref %q", ref)
+	}
+}
+
+func TestSpec_OperationsAreComplete(t *testing.T) {
+	doc := parseSpec(t)
+	paths := doc["paths"].(map[string]interface{})
+	methods := map[string]bool{"get": true, "put": true, "post": true, "delete": true, "patch": true, "head": true, "options": true}
+
+	operationIDs := make(map[string]string)
+	for path, item := range paths {
+		assert.True(t, strings.HasPrefix(path, "/"), "path %q must start with /", path)
+
+		for method, raw := range item.(map[string]interface{}) {
+			require.True(t, methods[method], "unexpected key %q under %s", method, path)
+			op := raw.(map[string]interface{})
+			where := strings.ToUpper(method) + " " + path
+
+			id, _ := op["operationId"].(string)
+			require.NotEmpty(t, id, "%s has no operationId", where)
+			if prev, dup := operationIDs[id]; dup {
+				t.Errorf("operationId %q used by both %s and %s", id, prev, where)
+			}
+			operationIDs[id] = where
+
+			responses, _ := op["responses"].(map[string]interface{})
+			hasSuccess := false
+			for status := range responses {
+				hasSuccess = hasSuccess || strings.HasPrefix(status, "2")
+			}
+			assert.True(t, hasSuccess, "%s must document its success response", where)
+
+			// Every templated path segment must be declared as a path parameter
+			declared := declaredPathParams(t, doc, op)
+			for _, segment := range strings.Split(path, "/") {
+				if strings.HasPrefix(segment, "{") {
+					name := strings.Trim(segment, "{}")
+					assert.True(t, declared[name], "%s does not declare path parameter %q", where, name)
+				}
+			}
+		}
+	}
+
+	assert.Contains(t, operationIDs, "getOpenAPISpec")
+	assert.Equal(t, http.MethodGet+" /api/openapi.json", operationIDs["getOpenAPISpec"])
+}
+
+func declaredPathParams(t *testing.T, doc map[string]interface{}, op map[string]interface{}) map[string]bool {
+	t.Helper()
+	declared := make(map[string]bool)
+	params, _ := op["parameters"].([]interface{})
+	for _, raw := range params {
+		param := raw.(map[string]interface{})
+		if ref, ok := param["$ref"].(string); ok {
+			name := strings.TrimPrefix(ref, "#/components/parameters/")
+			param = doc["components"].(map[string]interface{})["parameters"].(map[string]interface{})[name].(map[string]interface{})
+		}
+		if param["in"] == "path" {
+			assert.Equal(t, true, param["required"], "path parameter %v must be required", param["name"])
+			declared[param["name"].(string)] = true
+		}
+	}
+	return declared
+}
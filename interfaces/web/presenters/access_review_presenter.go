@@ -0,0 +1,113 @@
+package presenters
+
+import (
+	"strconv"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+// Access review export formats.
+const (
+	// AccessReviewFormatEntra lays out rows like the decisions of an Entra ID access review, so a
+	// campaign's reviewers and tooling see the columns they know.
+	AccessReviewFormatEntra = "entra"
+	// AccessReviewFormatAttestation is a generic attestation sheet with columns for the reviewer's decision.
+	AccessReviewFormatAttestation = "attestation"
+)
+
+// ParseAccessReviewFormat returns the access review format with the given name, the Entra layout when empty.
+func ParseAccessReviewFormat(name string) (string, bool) {
+	switch name {
+	case "", AccessReviewFormatEntra:
+		return AccessReviewFormatEntra, true
+	case AccessReviewFormatAttestation:
+		return AccessReviewFormatAttestation, true
+	}
+	return "", false
+}
+
+// AccessReviewToCSV converts a site's access review entries to CSV in the given format.
+func (p *ListPresenter) AccessReviewToCSV(siteURL string, auditRunID int64, entries []*application.AccessReviewEntry, format string) CSVTable {
+	if format == AccessReviewFormatAttestation {
+		return p.accessReviewAttestationCSV(siteURL, auditRunID, entries)
+	}
+
+	table := CSVTable{
+		Header: []string{
+			"ResourceId", "ResourceDisplayName", "ResourceType",
+			"PrincipalId", "PrincipalDisplayName", "UserPrincipalName", "PrincipalType",
+			"AccessLevel", "AssignmentType", "AssignedThrough",
+			"Decision", "Justification", "ReviewedBy", "ReviewedDate",
+		},
+		Rows: make([][]string, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		table.Rows = append(table.Rows, []string{
+			entry.ObjectURL,
+			entry.ObjectName,
+			entry.ObjectType,
+			entry.Principal.LoginName,
+			entry.Principal.GetDisplayName(),
+			accessReviewUPN(entry.Principal),
+			entry.Principal.Kind().Label(),
+			entry.Role,
+			accessReviewAssignmentType(entry.GrantType),
+			entry.GrantedVia,
+			"", "", "", "",
+		})
+	}
+	return table
+}
+
+// accessReviewAttestationCSV lays out access review entries as a generic attestation sheet.
+func (p *ListPresenter) accessReviewAttestationCSV(siteURL string, auditRunID int64, entries []*application.AccessReviewEntry) CSVTable {
+	table := CSVTable{
+		Header: []string{
+			"Site URL", "Audit Run", "Principal", "Login Name", "Email", "Principal Type",
+			"Object Type", "Object Name", "Object URL", "Permission Level", "Access Through", "Granted Via",
+			"Decision (Keep/Remove)", "Reviewer", "Review Date", "Comments",
+		},
+		Rows: make([][]string, 0, len(entries)),
+	}
+	run := strconv.FormatInt(auditRunID, 10)
+	for _, entry := range entries {
+		table.Rows = append(table.Rows, []string{
+			siteURL,
+			run,
+			entry.Principal.GetDisplayName(),
+			entry.Principal.LoginName,
+			entry.Principal.Email,
+			entry.Principal.Kind().Label(),
+			entry.ObjectType,
+			entry.ObjectName,
+			entry.ObjectURL,
+			entry.Role,
+			accessReviewAssignmentType(entry.GrantType),
+			entry.GrantedVia,
+			"", "", "", "",
+		})
+	}
+	return table
+}
+
+// accessReviewUPN returns the user principal name of a user's claims login, empty for other principals.
+func accessReviewUPN(principal *sharepoint.Principal) string {
+	if principal.Kind() != sharepoint.PrincipalKindUser {
+		return ""
+	}
+	return audit.PrincipalIdentity(principal.LoginName, "")
+}
+
+// accessReviewAssignmentType names how access was granted.
+func accessReviewAssignmentType(grantType string) string {
+	switch grantType {
+	case application.AccessGrantGroup:
+		return "SharePoint Group"
+	case application.AccessGrantSharingLink:
+		return "Sharing Link"
+	default:
+		return "Direct"
+	}
+}
@@ -0,0 +1,126 @@
+package presenters
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"spaudit/domain/sharepoint"
+)
+
+// AnonymousLinksView is the active anonymous sharing links an audit run found across a site's lists.
+type AnonymousLinksView struct {
+	SiteID       int64               `json:"site_id"`
+	AuditRunID   int64               `json:"audit_run_id"`
+	Total        int                 `json:"total"`
+	NoExpiration int                 `json:"no_expiration"`
+	Expiring     int                 `json:"expiring"` // Expiring within the warning window
+	Expired      int                 `json:"expired"`
+	Links        []AnonymousLinkView `json:"links"`
+}
+
+// AnonymousLinkView is an anonymous sharing link with the list and item it is on.
+type AnonymousLinkView struct {
+	LinkID           string     `json:"link_id"`
+	URL              string     `json:"url"`
+	LinkType         string     `json:"link_type"`
+	IsEditLink       bool       `json:"is_edit_link"`
+	BlocksDownload   bool       `json:"blocks_download"`
+	RequiresPassword bool       `json:"requires_password"`
+	ListID           string     `json:"list_id"`
+	ListTitle        string     `json:"list_title"`
+	ListURL          string     `json:"-"` // The list's Links tab in the run
+	ItemName         string     `json:"item_name"`
+	ItemURL          string     `json:"item_url"`
+	ItemType         string     `json:"item_type"` // file, folder or item
+	Expiration       *time.Time `json:"expiration,omitempty"`
+	ExpirationStatus string     `json:"expiration_status"` // none, active, expiring or expired
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	CreatedBy        string     `json:"created_by,omitempty"`
+	LastModifiedAt   *time.Time `json:"last_modified_at,omitempty"`
+	LastModifiedBy   string     `json:"last_modified_by,omitempty"`
+}
+
+// ExpirationLabel describes the link's expiration for display.
+func (v AnonymousLinkView) ExpirationLabel() string {
+	switch v.ExpirationStatus {
+	case sharepoint.LinkExpirationNone:
+		return "Never expires"
+	case sharepoint.LinkExpirationExpired:
+		return "Expired"
+	case sharepoint.LinkExpirationExpiring:
+		return "Expiring soon"
+	default:
+		return "Expires"
+	}
+}
+
+// Access describes what the link lets anyone holding it do.
+func (v AnonymousLinkView) Access() string {
+	if v.IsEditLink {
+		return "Edit"
+	}
+	return "View"
+}
+
+// ToAnonymousLinksView converts the anonymous sharing links of an audit run, rating their expiration at now.
+func (p *ListPresenter) ToAnonymousLinksView(siteID, auditRunID int64, links []*sharepoint.AnonymousSharingLink, now time.Time) AnonymousLinksView {
+	view := AnonymousLinksView{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		Total:      len(links),
+		Links:      make([]AnonymousLinkView, len(links)),
+	}
+	for i, link := range links {
+		linkView := AnonymousLinkView{
+			LinkID:           link.ID,
+			URL:              link.URL,
+			LinkType:         link.GetLinkKindName(),
+			IsEditLink:       link.IsEditLink,
+			BlocksDownload:   link.BlocksDownload,
+			RequiresPassword: link.RequiresPassword,
+			ListID:           link.ListID,
+			ListTitle:        link.ListTitle,
+			ListURL:          fmt.Sprintf("/sites/%d/audit-runs/%d/tabs/%s/links", siteID, auditRunID, url.PathEscape(link.ListID)),
+			ItemName:         link.ItemName,
+			ItemURL:          link.ItemURL,
+			ItemType:         anonymousLinkItemType(link),
+			Expiration:       link.Expiration,
+			ExpirationStatus: link.ExpirationStatus(now),
+			CreatedAt:        link.CreatedAt,
+			LastModifiedAt:   link.LastModifiedAt,
+		}
+		if linkView.ListTitle == "" {
+			linkView.ListTitle = link.ListID
+		}
+		if link.CreatedBy != nil {
+			linkView.CreatedBy = link.CreatedBy.Title
+		}
+		if link.LastModifiedBy != nil {
+			linkView.LastModifiedBy = link.LastModifiedBy.Title
+		}
+
+		switch linkView.ExpirationStatus {
+		case sharepoint.LinkExpirationNone:
+			view.NoExpiration++
+		case sharepoint.LinkExpirationExpiring:
+			view.Expiring++
+		case sharepoint.LinkExpirationExpired:
+			view.Expired++
+		}
+		view.Links[i] = linkView
+	}
+	return view
+}
+
+// anonymousLinkItemType names the kind of item a link is on.
+func anonymousLinkItemType(link *sharepoint.AnonymousSharingLink) string {
+	switch {
+	case link.ItemIsFolder:
+		return "folder"
+	case link.ItemIsFile:
+		return "file"
+	default:
+		return "item"
+	}
+}
@@ -0,0 +1,57 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/sharepoint"
+)
+
+func TestListPresenter_ToAnonymousLinksView(t *testing.T) {
+	presenter := NewListPresenter()
+	now := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+	expired := now.Add(-24 * time.Hour)
+	expiring := now.Add(7 * 24 * time.Hour)
+	links := []*sharepoint.AnonymousSharingLink{
+		{
+			SharingLink: &sharepoint.SharingLink{ID: "link-1", LinkKind: sharepoint.LinkKindAnonymousEdit, IsEditLink: true,
+				CreatedBy: &sharepoint.Principal{Title: "Ada"}, LastModifiedBy: &sharepoint.Principal{Title: "Grace"}},
+			ListID: "docs", ListTitle: "Documents", ItemName: "budget.xlsx", ItemIsFile: true,
+		},
+		{
+			SharingLink: &sharepoint.SharingLink{ID: "link-2", LinkKind: sharepoint.LinkKindFlexible, Expiration: &expiring},
+			ListID:      "a b", ItemName: "Plans", ItemIsFolder: true,
+		},
+		{
+			SharingLink: &sharepoint.SharingLink{ID: "link-3", LinkKind: sharepoint.LinkKindAnonymousView, Expiration: &expired},
+			ListID:      "tasks", ListTitle: "Tasks", ItemName: "1_.000",
+		},
+	}
+
+	view := presenter.ToAnonymousLinksView(1, 7, links, now)
+
+	assert.Equal(t, 3, view.Total)
+	assert.Equal(t, 1, view.NoExpiration)
+	assert.Equal(t, 1, view.Expiring)
+	assert.Equal(t, 1, view.Expired)
+	require.Len(t, view.Links, 3)
+
+	assert.Equal(t, "Edit", view.Links[0].Access())
+	assert.Equal(t, "file", view.Links[0].ItemType)
+	assert.Equal(t, "Ada", view.Links[0].CreatedBy)
+	assert.Equal(t, "Grace", view.Links[0].LastModifiedBy)
+	assert.Equal(t, "Never expires", view.Links[0].ExpirationLabel())
+	assert.Equal(t, "/sites/1/audit-runs/7/tabs/docs/links", view.Links[0].ListURL)
+
+	assert.Equal(t, "a b", view.Links[1].ListTitle, "lists the run did not capture fall back to their ID")
+	assert.Equal(t, "/sites/1/audit-runs/7/tabs/a%20b/links", view.Links[1].ListURL)
+	assert.Equal(t, "folder", view.Links[1].ItemType)
+	assert.Equal(t, sharepoint.LinkExpirationExpiring, view.Links[1].ExpirationStatus)
+
+	assert.Equal(t, "View", view.Links[2].Access())
+	assert.Equal(t, "item", view.Links[2].ItemType)
+	assert.Equal(t, "Expired", view.Links[2].ExpirationLabel())
+}
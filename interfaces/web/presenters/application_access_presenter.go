@@ -0,0 +1,118 @@
+package presenters
+
+import (
+	"time"
+
+	"spaudit/application"
+)
+
+// ApplicationAccessView is what an audit run found apps can access on the site.
+type ApplicationAccessView struct {
+	SiteID      int64                  `json:"site_id"`
+	AuditRunID  int64                  `json:"audit_run_id"`
+	Collected   bool                   `json:"collected"`
+	CollectedAt *time.Time             `json:"collected_at,omitempty"`
+	Apps        []ApplicationGrantView `json:"apps"`
+
+	GraphScanned   bool                `json:"graph_scanned"`
+	GraphScannedAt *time.Time          `json:"graph_scanned_at,omitempty"`
+	GraphGrants    []GraphAppGrantView `json:"graph_grants"`
+}
+
+// ApplicationGrantView is an installed app, an app principal holding roles on the site, or both.
+type ApplicationGrantView struct {
+	Title          string             `json:"title"`
+	Installed      bool               `json:"installed"`
+	AppID          string             `json:"app_id,omitempty"`
+	ProductID      string             `json:"product_id,omitempty"`
+	Source         string             `json:"source,omitempty"` // store, tenant_catalog, site_catalog, developer_site or other
+	LastModified   *time.Time         `json:"last_modified,omitempty"`
+	PrincipalID    int64              `json:"principal_id,omitempty"`
+	LoginName      string             `json:"login_name,omitempty"`
+	SiteWideAccess bool               `json:"site_wide_access"`
+	Grants         []AppRoleGrantView `json:"grants"`
+}
+
+// AppRoleGrantView is a role an app's principal holds on the web, a list or an item.
+type AppRoleGrantView struct {
+	ObjectType string `json:"object_type"`
+	ObjectKey  string `json:"object_key"`
+	ObjectName string `json:"object_name,omitempty"`
+	ObjectURL  string `json:"object_url,omitempty"`
+	RoleDefID  int64  `json:"role_def_id"`
+	Role       string `json:"role"`
+}
+
+// GraphAppGrantView is an Entra app's access to the site through Microsoft Graph.
+type GraphAppGrantView struct {
+	Kind        string   `json:"kind"` // site_selected or tenant_wide
+	GrantID     string   `json:"grant_id"`
+	AppID       string   `json:"app_id"`
+	AppName     string   `json:"app_name"`
+	Roles       []string `json:"roles"`
+	Resource    string   `json:"resource,omitempty"`
+	FullControl bool     `json:"full_control"`
+	CanWrite    bool     `json:"can_write"`
+}
+
+// ToApplicationAccessView converts an audit run's application access for the API.
+func (p *ListPresenter) ToApplicationAccessView(siteID, auditRunID int64, data *application.ApplicationAccessData) ApplicationAccessView {
+	view := ApplicationAccessView{
+		SiteID:      siteID,
+		AuditRunID:  auditRunID,
+		Collected:   data.CollectedAt != nil,
+		CollectedAt: data.CollectedAt,
+		Apps:        make([]ApplicationGrantView, len(data.Apps)),
+
+		GraphScanned:   data.GraphScannedAt != nil,
+		GraphScannedAt: data.GraphScannedAt,
+		GraphGrants:    make([]GraphAppGrantView, len(data.GraphGrants)),
+	}
+
+	for i, access := range data.Apps {
+		appView := ApplicationGrantView{
+			Installed:      access.App != nil,
+			SiteWideAccess: access.HasSiteWideGrant(),
+			Grants:         make([]AppRoleGrantView, len(access.Grants)),
+		}
+		if access.App != nil {
+			appView.Title = access.App.Title
+			appView.AppID = access.App.AppID
+			appView.ProductID = access.App.ProductID
+			appView.Source = access.App.SourceName()
+			appView.LastModified = access.App.LastModified
+			appView.LoginName = access.App.AppPrincipalID
+		}
+		if access.Principal != nil {
+			if appView.Title == "" {
+				appView.Title = access.Principal.Title
+			}
+			appView.PrincipalID = access.Principal.ID
+			appView.LoginName = access.Principal.LoginName
+		}
+		for j, grant := range access.Grants {
+			appView.Grants[j] = AppRoleGrantView{
+				ObjectType: grant.ObjectType,
+				ObjectKey:  grant.ObjectKey,
+				ObjectName: grant.ObjectName,
+				ObjectURL:  grant.ObjectURL,
+				RoleDefID:  grant.RoleDefID,
+				Role:       grant.Role,
+			}
+		}
+		view.Apps[i] = appView
+	}
+	for i, grant := range data.GraphGrants {
+		view.GraphGrants[i] = GraphAppGrantView{
+			Kind:        grant.Kind,
+			GrantID:     grant.GrantID,
+			AppID:       grant.AppID,
+			AppName:     grant.AppName,
+			Roles:       grant.Roles,
+			Resource:    grant.Resource,
+			FullControl: grant.HasFullControl(),
+			CanWrite:    grant.CanWrite(),
+		}
+	}
+	return view
+}
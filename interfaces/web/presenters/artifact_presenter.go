@@ -0,0 +1,63 @@
+package presenters
+
+import (
+	"fmt"
+
+	"spaudit/domain/audit"
+)
+
+// RunArtifactView represents a report generated from an audit run for API responses and the run banner.
+type RunArtifactView struct {
+	ID          int64  `json:"id"`
+	AuditRunID  int64  `json:"audit_run_id"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+	Size        string `json:"size"` // Human readable size
+	CreatedAt   string `json:"created_at"`
+	ExpiresAt   string `json:"expires_at"`
+	DownloadURL string `json:"download_url"`
+}
+
+// RunArtifactDownloadURL returns the download URL of an audit run artifact.
+func RunArtifactDownloadURL(artifact *audit.RunArtifact) string {
+	return fmt.Sprintf("/api/sites/%d/audit-runs/%d/artifacts/%d", artifact.SiteID, artifact.AuditRunID, artifact.ID)
+}
+
+// ToRunArtifactView converts a run artifact to its view.
+func (p *ListPresenter) ToRunArtifactView(artifact *audit.RunArtifact) RunArtifactView {
+	return RunArtifactView{
+		ID:          artifact.ID,
+		AuditRunID:  artifact.AuditRunID,
+		Filename:    artifact.Filename,
+		ContentType: artifact.ContentType,
+		SizeBytes:   artifact.SizeBytes,
+		Size:        formatByteSize(artifact.SizeBytes),
+		CreatedAt:   artifact.CreatedAt.UTC().Format(AuditRunTimeFormat),
+		ExpiresAt:   artifact.ExpiresAt.UTC().Format(AuditRunTimeFormat),
+		DownloadURL: RunArtifactDownloadURL(artifact),
+	}
+}
+
+// ToRunArtifactViews converts run artifacts to views, preserving order.
+// The result is never nil so an empty list serializes as [] rather than null.
+func (p *ListPresenter) ToRunArtifactViews(artifacts []*audit.RunArtifact) []RunArtifactView {
+	views := make([]RunArtifactView, len(artifacts))
+	for i, artifact := range artifacts {
+		views[i] = p.ToRunArtifactView(artifact)
+	}
+	return views
+}
+
+// AttachRunArtifacts lists each artifact on the run history entry of the run it was generated from.
+func (p *ListPresenter) AttachRunArtifacts(runs []AuditRunView, artifacts []*audit.RunArtifact) {
+	runIndex := make(map[int64]int, len(runs))
+	for i, run := range runs {
+		runIndex[run.ID] = i
+	}
+	for _, artifact := range artifacts {
+		if i, ok := runIndex[artifact.AuditRunID]; ok {
+			runs[i].Artifacts = append(runs[i].Artifacts, p.ToRunArtifactView(artifact))
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package presenters
+
+import (
+	"fmt"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+// AttestationFieldPrefix starts the form field carrying the decision on an attestation entry; the entry key follows.
+const AttestationFieldPrefix = "decision:"
+
+// AttestationsPageVM lists the attestations of an audit run with a form requesting another.
+type AttestationsPageVM struct {
+	SiteID       int64
+	SiteURL      string
+	AuditRunID   int64
+	RunURL       string
+	CreateURL    string
+	Attestations []AttestationRow
+	CreatedID    int64 // The attestation just requested, whose review link is highlighted
+}
+
+// AttestationRow is one attestation of an audit run.
+type AttestationRow struct {
+	ID          int64
+	Owner       string
+	CreatedAt   string
+	SubmittedAt string // Empty while awaiting the owner's response
+	KeepCount   int
+	RemoveCount int
+	ReviewURL   string // The link sent to the owner
+	ResultsURL  string
+}
+
+// AttestationReviewVM is the review a site owner responds to, or its results for auditors.
+type AttestationReviewVM struct {
+	Owner          string
+	SiteURL        string
+	AuditRunID     int64
+	SubmitURL      string // Empty for the read-only results
+	BackURL        string // The run's attestations, on the results page
+	SubmittedAt    string
+	Saved          bool // Responses were just saved
+	Answered       int
+	Total          int
+	ExternalAccess []AttestationEntryVM
+	SharingLinks   []AttestationEntryVM
+}
+
+// AttestationEntryVM is one external access grant or sharing link with the owner's decision.
+type AttestationEntryVM struct {
+	FieldName   string
+	ObjectType  string
+	ObjectName  string
+	ObjectURL   string
+	Principal   string
+	LoginName   string
+	Role        string
+	Access      string // How the guest was granted access
+	LinkType    string
+	Scope       string
+	Members     int64
+	Expires     string
+	Decision    string
+	RespondedAt string
+}
+
+// IsKeep returns true if the owner marked the entry still needed.
+func (e AttestationEntryVM) IsKeep() bool {
+	return e.Decision == audit.AttestationKeep
+}
+
+// IsRemove returns true if the owner marked the entry for removal.
+func (e AttestationEntryVM) IsRemove() bool {
+	return e.Decision == audit.AttestationRemove
+}
+
+// DecisionLabel describes the owner's decision.
+func (e AttestationEntryVM) DecisionLabel() string {
+	switch e.Decision {
+	case audit.AttestationKeep:
+		return "Still needed"
+	case audit.AttestationRemove:
+		return "Remove"
+	default:
+		return "Not answered"
+	}
+}
+
+// AttestationReviewPath returns the path of the review link sent to a site owner.
+func AttestationReviewPath(token string) string {
+	return "/attest/" + token
+}
+
+// ToAttestationsPageVM builds the attestations page of an audit run.
+func (p *ListPresenter) ToAttestationsPageVM(siteID, auditRunID int64, siteURL string, attestations []*audit.Attestation, createdID int64) AttestationsPageVM {
+	base := fmt.Sprintf("/sites/%d/audit-runs/%d/attestations", siteID, auditRunID)
+	vm := AttestationsPageVM{
+		SiteID:       siteID,
+		SiteURL:      siteURL,
+		AuditRunID:   auditRunID,
+		RunURL:       fmt.Sprintf("/sites/%d/audit-runs/%d/lists", siteID, auditRunID),
+		CreateURL:    base,
+		Attestations: make([]AttestationRow, len(attestations)),
+		CreatedID:    createdID,
+	}
+	for i, attestation := range attestations {
+		row := AttestationRow{
+			ID:          attestation.ID,
+			Owner:       attestation.Owner,
+			CreatedAt:   attestation.CreatedAt.UTC().Format(AuditRunTimeFormat),
+			KeepCount:   attestation.KeepCount,
+			RemoveCount: attestation.RemoveCount,
+			ReviewURL:   AttestationReviewPath(attestation.Token),
+			ResultsURL:  fmt.Sprintf("%s/%d", base, attestation.ID),
+		}
+		if attestation.IsSubmitted() {
+			row.SubmittedAt = attestation.SubmittedAt.UTC().Format(AuditRunTimeFormat)
+		}
+		vm.Attestations[i] = row
+	}
+	return vm
+}
+
+// ToAttestationReviewVM builds the review of an attestation. Without a submit URL it is read-only.
+func (p *ListPresenter) ToAttestationReviewVM(review *application.AttestationReview, submitURL string, saved bool) AttestationReviewVM {
+	attestation := review.Attestation
+	vm := AttestationReviewVM{
+		Owner:          attestation.Owner,
+		SiteURL:        review.SiteURL,
+		AuditRunID:     attestation.AuditRunID,
+		SubmitURL:      submitURL,
+		BackURL:        fmt.Sprintf("/sites/%d/audit-runs/%d/attestations", attestation.SiteID, attestation.AuditRunID),
+		Saved:          saved,
+		Answered:       review.Answered(),
+		Total:          len(review.Entries),
+		ExternalAccess: []AttestationEntryVM{},
+		SharingLinks:   []AttestationEntryVM{},
+	}
+	if attestation.IsSubmitted() {
+		vm.SubmittedAt = attestation.SubmittedAt.UTC().Format(AuditRunTimeFormat)
+	}
+
+	for _, entry := range review.Entries {
+		entryVM := AttestationEntryVM{
+			FieldName:  AttestationFieldPrefix + entry.Key,
+			ObjectType: entry.ObjectType,
+			ObjectName: entry.ObjectName,
+			ObjectURL:  entry.ObjectURL,
+			Decision:   entry.Decision,
+		}
+		if entry.RespondedAt != nil {
+			entryVM.RespondedAt = entry.RespondedAt.UTC().Format(AuditRunTimeFormat)
+		}
+		if entry.Kind == application.AttestationEntrySharingLink {
+			entryVM.LinkType = sharepoint.LinkKindName(entry.LinkKind)
+			entryVM.Scope = sharepoint.ScopeName(entry.Scope)
+			entryVM.Members = entry.MembersCount
+			if entry.Expiration != nil {
+				entryVM.Expires = entry.Expiration.UTC().Format(AuditRunTimeFormat)
+			}
+			vm.SharingLinks = append(vm.SharingLinks, entryVM)
+			continue
+		}
+		entryVM.Principal = entry.Principal
+		entryVM.LoginName = entry.LoginName
+		entryVM.Role = entry.Role
+		switch entry.GrantType {
+		case application.AccessGrantGroup:
+			entryVM.Access = "Through group " + entry.GrantedVia
+		case application.AccessGrantSharingLink:
+			entryVM.Access = "Through a sharing link"
+		default:
+			entryVM.Access = "Direct"
+		}
+		vm.ExternalAccess = append(vm.ExternalAccess, entryVM)
+	}
+	return vm
+}
@@ -0,0 +1,219 @@
+package presenters
+
+import (
+	"fmt"
+	"net/url"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+// AuditRunDiffView compares a later audit run of a site with a base run for API responses.
+type AuditRunDiffView struct {
+	SiteID            int64                  `json:"site_id"`
+	BaseAuditRunID    int64                  `json:"base_audit_run_id"`
+	AuditRunID        int64                  `json:"audit_run_id"`
+	HasChanges        bool                   `json:"has_changes"`
+	Summary           AuditRunDiffSummary    `json:"summary"`
+	Lists             []ListDiffView         `json:"lists"`
+	Permissions       []PermissionDiffView   `json:"permissions"`
+	NewSharingLinks   []PermissionChangeView `json:"new_sharing_links"`
+	NewUniqueItems    []PermissionChangeView `json:"new_unique_items"`
+	RemovedPrincipals []RemovedPrincipalView `json:"removed_principals"`
+}
+
+// AuditRunDiffSummary counts the changes between two audit runs.
+type AuditRunDiffSummary struct {
+	PermissionsAdded   int `json:"permissions_added"`
+	PermissionsRemoved int `json:"permissions_removed"`
+	PermissionsChanged int `json:"permissions_changed"`
+	ListsAdded         int `json:"lists_added"`
+	ListsRemoved       int `json:"lists_removed"`
+	NewSharingLinks    int `json:"new_sharing_links"`
+	NewUniqueItems     int `json:"new_unique_items"`
+	RemovedPrincipals  int `json:"removed_principals"`
+}
+
+// ListDiffView is a list added, removed or with changed permissions between two audit runs.
+type ListDiffView struct {
+	ListID    string `json:"list_id"`
+	ListTitle string `json:"list_title"`
+	Kind      string `json:"kind"`
+	Added     int    `json:"added"`   // Permissions and links only the later run captured
+	Removed   int    `json:"removed"` // Permissions and links only the base run captured
+}
+
+// PermissionDiffView is the change to one principal's roles on an object between two audit runs.
+type PermissionDiffView struct {
+	Kind         string   `json:"kind"`
+	ListID       string   `json:"list_id,omitempty"` // Empty for the site's webs
+	ListTitle    string   `json:"list_title,omitempty"`
+	ObjectType   string   `json:"object_type"`
+	ObjectKey    string   `json:"object_key"`
+	ObjectName   string   `json:"object_name"`
+	Principal    string   `json:"principal,omitempty"`
+	LoginName    string   `json:"login_name,omitempty"`
+	RemovedRoles []string `json:"removed_roles"`
+	AddedRoles   []string `json:"added_roles"`
+}
+
+// RemovedPrincipalView is a principal the base run captured that the later run no longer has.
+type RemovedPrincipalView struct {
+	ID        int64  `json:"id"`
+	Title     string `json:"title"`
+	LoginName string `json:"login_name"`
+	Email     string `json:"email,omitempty"`
+	Kind      string `json:"kind"`
+}
+
+// AuditRunComparePageVM is the comparison page of two audit runs of a site.
+type AuditRunComparePageVM struct {
+	Diff       AuditRunDiffView
+	SiteURL    string
+	RunURL     string
+	BaseRunURL string
+	JSONURL    string
+}
+
+// ToAuditRunDiffView converts a run comparison to its view. Collections are never nil so empty
+// ones serialize as [] rather than null.
+func (p *ListPresenter) ToAuditRunDiffView(diff *application.AuditRunDiff) AuditRunDiffView {
+	view := AuditRunDiffView{
+		SiteID:            diff.SiteID,
+		BaseAuditRunID:    diff.BaseAuditRunID,
+		AuditRunID:        diff.AuditRunID,
+		HasChanges:        diff.HasChanges(),
+		Lists:             make([]ListDiffView, len(diff.Lists)),
+		Permissions:       make([]PermissionDiffView, len(diff.Permissions)),
+		NewSharingLinks:   make([]PermissionChangeView, len(diff.NewSharingLinks)),
+		NewUniqueItems:    make([]PermissionChangeView, len(diff.NewUniqueItems)),
+		RemovedPrincipals: make([]RemovedPrincipalView, len(diff.RemovedPrincipals)),
+	}
+
+	for i, list := range diff.Lists {
+		listView := ListDiffView{ListID: list.List.ID, ListTitle: list.List.Title, Kind: list.Kind}
+		for _, change := range list.Changes {
+			if change.Change == application.ChangeAdded {
+				listView.Added++
+			} else {
+				listView.Removed++
+			}
+		}
+		switch list.Kind {
+		case application.DriftListAdded:
+			view.Summary.ListsAdded++
+		case application.DriftListRemoved:
+			view.Summary.ListsRemoved++
+		}
+		view.Lists[i] = listView
+	}
+
+	for i, permission := range diff.Permissions {
+		permissionView := PermissionDiffView{
+			Kind:         permission.Kind,
+			ListID:       permission.ListID,
+			ListTitle:    permission.ListTitle,
+			ObjectType:   permission.ObjectType,
+			ObjectKey:    permission.ObjectKey,
+			ObjectName:   permission.ObjectName,
+			RemovedRoles: append([]string{}, permission.RemovedRoles...),
+			AddedRoles:   append([]string{}, permission.AddedRoles...),
+		}
+		if permission.Principal != nil {
+			permissionView.Principal = permission.Principal.GetDisplayName()
+			permissionView.LoginName = permission.Principal.LoginName
+		}
+		switch permission.Kind {
+		case application.PermissionAdded:
+			view.Summary.PermissionsAdded++
+		case application.PermissionRemoved:
+			view.Summary.PermissionsRemoved++
+		case application.PermissionChanged:
+			view.Summary.PermissionsChanged++
+		}
+		view.Permissions[i] = permissionView
+	}
+
+	for i, change := range diff.NewSharingLinks {
+		view.NewSharingLinks[i] = toPermissionChangeView(change)
+	}
+	for i, change := range diff.NewUniqueItems {
+		view.NewUniqueItems[i] = toPermissionChangeView(change)
+	}
+	for i, principal := range diff.RemovedPrincipals {
+		view.RemovedPrincipals[i] = RemovedPrincipalView{
+			ID:        principal.ID,
+			Title:     principal.GetDisplayName(),
+			LoginName: principal.LoginName,
+			Email:     principal.Email,
+			Kind:      string(principal.Kind()),
+		}
+	}
+
+	view.Summary.NewSharingLinks = len(view.NewSharingLinks)
+	view.Summary.NewUniqueItems = len(view.NewUniqueItems)
+	view.Summary.RemovedPrincipals = len(view.RemovedPrincipals)
+	return view
+}
+
+// ToAuditRunComparePageVM builds the comparison page of two audit runs of a site.
+func (p *ListPresenter) ToAuditRunComparePageVM(diff *application.AuditRunDiff, siteURL string) AuditRunComparePageVM {
+	return AuditRunComparePageVM{
+		Diff:       p.ToAuditRunDiffView(diff),
+		SiteURL:    siteURL,
+		RunURL:     fmt.Sprintf("/sites/%d/audit-runs/%d/lists", diff.SiteID, diff.AuditRunID),
+		BaseRunURL: fmt.Sprintf("/sites/%d/audit-runs/%d/lists", diff.SiteID, diff.BaseAuditRunID),
+		JSONURL:    AuditRunCompareURL(diff.SiteID, diff.BaseAuditRunID, diff.AuditRunID, true),
+	}
+}
+
+// AuditRunCompareURL returns the comparison of an audit run with a base run, as a page or JSON.
+func AuditRunCompareURL(siteID, baseAuditRunID, auditRunID int64, api bool) string {
+	path := fmt.Sprintf("/sites/%d/audit-runs/%d/compare/%d", siteID, baseAuditRunID, auditRunID)
+	if api {
+		return "/api" + path
+	}
+	return path
+}
+
+// PermissionDiffKindLabel names a permission diff kind for display.
+func PermissionDiffKindLabel(kind string) string {
+	switch kind {
+	case application.PermissionAdded:
+		return "Granted"
+	case application.PermissionRemoved:
+		return "Revoked"
+	case application.PermissionChanged:
+		return "Roles changed"
+	default:
+		return kind
+	}
+}
+
+// PermissionDiffKindBadgeVariant returns the badge variant of a permission diff kind.
+func PermissionDiffKindBadgeVariant(kind string) string {
+	switch kind {
+	case application.PermissionAdded:
+		return "danger"
+	case application.PermissionRemoved:
+		return "info"
+	default:
+		return "warning"
+	}
+}
+
+// ListPageURL links a list of the run, or is empty for a list the run no longer has.
+func (v ListDiffView) ListPageURL(siteID, auditRunID int64) string {
+	if v.Kind == application.DriftListRemoved {
+		return ""
+	}
+	return fmt.Sprintf("/sites/%d/audit-runs/%d/lists/%s", siteID, auditRunID, url.PathEscape(v.ListID))
+}
+
+// ObjectTypeLabel names the object of a permission diff, which is the site for web assignments.
+func (v PermissionDiffView) ObjectTypeLabel() string {
+	if v.ObjectType == sharepoint.ObjectTypeWeb {
+		return "Site"
+	}
+	return v.ObjectType
+}
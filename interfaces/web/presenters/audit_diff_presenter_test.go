@@ -0,0 +1,54 @@
+package presenters
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+func TestListPresenter_ToAuditRunDiffView(t *testing.T) {
+	presenter := NewListPresenter()
+	finance := &sharepoint.Principal{ID: 7, Title: "Finance", LoginName: "Finance", PrincipalType: sharepoint.PrincipalTypeSharePointGroup}
+	diff := &application.AuditRunDiff{
+		SiteID:         1,
+		BaseAuditRunID: 3,
+		AuditRunID:     5,
+		Lists: []*application.ListDrift{
+			{Kind: application.DriftListAdded, ListSnapshotDiffData: &application.ListSnapshotDiffData{
+				List: &sharepoint.List{ID: "new", Title: "New"},
+				Changes: []*application.SnapshotChange{
+					{Change: application.ChangeAdded, Category: application.ChangeCategorySharingLink, ObjectType: "item", ObjectKey: "guid-1", Detail: "Anyone link"},
+				},
+			}},
+		},
+		Permissions: []*application.PermissionDiff{
+			{Kind: application.PermissionChanged, ObjectType: sharepoint.ObjectTypeWeb, ObjectKey: "web-1", ObjectName: "Home", Principal: finance, RemovedRoles: []string{"Read"}, AddedRoles: []string{"Contribute"}},
+			{Kind: application.PermissionRemoved, ListID: "docs", ListTitle: "Documents", ObjectType: "list", ObjectKey: "docs", ObjectName: "Documents", Principal: finance, RemovedRoles: []string{"Read"}},
+		},
+		NewSharingLinks:   []*application.SnapshotChange{{Change: application.ChangeAdded, Category: application.ChangeCategorySharingLink, ObjectType: "item", ObjectKey: "guid-1", Detail: "Anyone link"}},
+		NewUniqueItems:    []*application.SnapshotChange{},
+		RemovedPrincipals: []*sharepoint.Principal{{ID: 9, Title: "Alice", LoginName: "i:0#.f|membership|alice@contoso.com", PrincipalType: sharepoint.PrincipalTypeUser}},
+	}
+
+	view := presenter.ToAuditRunDiffView(diff)
+	assert.True(t, view.HasChanges)
+	assert.Equal(t, AuditRunDiffSummary{PermissionsRemoved: 1, PermissionsChanged: 1, ListsAdded: 1, NewSharingLinks: 1, RemovedPrincipals: 1}, view.Summary)
+	require.Len(t, view.Lists, 1)
+	assert.Equal(t, 1, view.Lists[0].Added)
+	assert.Equal(t, "/sites/1/audit-runs/5/lists/new", view.Lists[0].ListPageURL(1, 5))
+	require.Len(t, view.Permissions, 2)
+	assert.Equal(t, "Site", view.Permissions[0].ObjectTypeLabel())
+	assert.Equal(t, "Finance", view.Permissions[0].Principal)
+	assert.Equal(t, []string{}, view.Permissions[1].AddedRoles)
+	assert.Equal(t, "user", view.RemovedPrincipals[0].Kind)
+
+	encoded, err := json.Marshal(presenter.ToAuditRunDiffView(&application.AuditRunDiff{SiteID: 1, BaseAuditRunID: 5, AuditRunID: 5}))
+	require.NoError(t, err)
+	assert.Contains(t, string(encoded), `"permissions":[]`)
+	assert.Contains(t, string(encoded), `"removed_principals":[]`)
+}
@@ -26,6 +26,15 @@ type ActiveAuditsView struct {
 	ActiveAudits []*AuditStatusView `json:"active_audits"`
 }
 
+// AuditQueuedView acknowledges a queued audit for API responses
+type AuditQueuedView struct {
+	RequestID string    `json:"request_id"`
+	JobID     string    `json:"job_id"`
+	SiteURL   string    `json:"site_url"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 // AuditPresenter transforms audit domain data into UI-ready view models and HTML responses.
 type AuditPresenter struct{}
 
@@ -212,3 +221,15 @@ func (p *AuditPresenter) FormatAuditConflictResponse(err error) string {
 		</div>
 	</div>`, err.Error())
 }
+
+// FormatAuditQueued creates the API acknowledgement for a queued audit.
+// The request ID is the job ID, so clients can follow progress through /jobs.
+func (p *AuditPresenter) FormatAuditQueued(request *audit.AuditRequest) *AuditQueuedView {
+	return &AuditQueuedView{
+		RequestID: request.ID,
+		JobID:     request.ID,
+		SiteURL:   request.SiteURL,
+		Status:    "queued",
+		CreatedAt: request.CreatedAt,
+	}
+}
@@ -0,0 +1,95 @@
+package presenters
+
+import (
+	"time"
+
+	"spaudit/domain/audit"
+)
+
+// AuditScheduleView is a site audited on a schedule, each run checked for drift against thresholds.
+type AuditScheduleView struct {
+	ID              int64               `json:"id"`
+	SiteURL         string              `json:"site_url"`
+	IntervalSeconds int64               `json:"interval_seconds"`
+	CompareWith     string              `json:"compare_with"`
+	Thresholds      DriftThresholdsView `json:"thresholds"`
+	CreatedAt       string              `json:"created_at"`
+	NextRunAt       string              `json:"next_run_at"`
+	LastJobID       string              `json:"last_job_id,omitempty"` // Latest scheduled audit of the site
+	LastRunAt       string              `json:"last_run_at,omitempty"`
+}
+
+// DriftThresholdsView is the drift a scheduled run may show before raising an alert. Thresholds that
+// are not checked are omitted.
+type DriftThresholdsView struct {
+	ExternalGrants    *int `json:"external_grants,omitempty"`
+	PermissionChanges *int `json:"permission_changes,omitempty"`
+	SharingLinks      *int `json:"sharing_links,omitempty"`
+	UniqueItems       *int `json:"unique_items,omitempty"`
+	RemovedPrincipals *int `json:"removed_principals,omitempty"`
+}
+
+// AuditScheduleCheckView is the drift a scheduled run showed against the run it was compared with.
+type AuditScheduleCheckView struct {
+	AuditRunID        int64  `json:"audit_run_id"`
+	BaseAuditRunID    int64  `json:"base_audit_run_id"`
+	ExternalGrants    int    `json:"external_grants"`
+	PermissionChanges int    `json:"permission_changes"`
+	SharingLinks      int    `json:"sharing_links"`
+	UniqueItems       int    `json:"unique_items"`
+	RemovedPrincipals int    `json:"removed_principals"`
+	AlertID           int64  `json:"alert_id,omitempty"` // Set when the drift exceeded a threshold
+	CheckedAt         string `json:"checked_at"`
+}
+
+// ToAuditScheduleView converts an audit schedule for the API.
+func (p *ListPresenter) ToAuditScheduleView(schedule *audit.AuditSchedule) AuditScheduleView {
+	view := AuditScheduleView{
+		ID:              schedule.ID,
+		SiteURL:         schedule.SiteURL,
+		IntervalSeconds: int64(schedule.Interval / time.Second),
+		CompareWith:     schedule.CompareWith,
+		Thresholds: DriftThresholdsView{
+			ExternalGrants:    schedule.Thresholds.ExternalGrants,
+			PermissionChanges: schedule.Thresholds.PermissionChanges,
+			SharingLinks:      schedule.Thresholds.SharingLinks,
+			UniqueItems:       schedule.Thresholds.UniqueItems,
+			RemovedPrincipals: schedule.Thresholds.RemovedPrincipals,
+		},
+		CreatedAt: schedule.CreatedAt.UTC().Format(time.RFC3339),
+		NextRunAt: schedule.NextRunAt.UTC().Format(time.RFC3339),
+		LastJobID: schedule.LastJobID,
+	}
+	if schedule.LastRunAt != nil {
+		view.LastRunAt = schedule.LastRunAt.UTC().Format(time.RFC3339)
+	}
+	return view
+}
+
+// ToAuditScheduleViews converts audit schedules for the API, preserving their order.
+func (p *ListPresenter) ToAuditScheduleViews(schedules []*audit.AuditSchedule) []AuditScheduleView {
+	views := make([]AuditScheduleView, len(schedules))
+	for i, schedule := range schedules {
+		views[i] = p.ToAuditScheduleView(schedule)
+	}
+	return views
+}
+
+// ToAuditScheduleCheckViews converts the drift checks of scheduled runs for the API, preserving their order.
+func (p *ListPresenter) ToAuditScheduleCheckViews(checks []*audit.AuditScheduleCheck) []AuditScheduleCheckView {
+	views := make([]AuditScheduleCheckView, len(checks))
+	for i, check := range checks {
+		views[i] = AuditScheduleCheckView{
+			AuditRunID:        check.AuditRunID,
+			BaseAuditRunID:    check.BaseAuditRunID,
+			ExternalGrants:    check.Drift.ExternalGrants,
+			PermissionChanges: check.Drift.PermissionChanges,
+			SharingLinks:      check.Drift.SharingLinks,
+			UniqueItems:       check.Drift.UniqueItems,
+			RemovedPrincipals: check.Drift.RemovedPrincipals,
+			AlertID:           check.AlertID,
+			CheckedAt:         check.CheckedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return views
+}
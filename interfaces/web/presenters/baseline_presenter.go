@@ -0,0 +1,195 @@
+package presenters
+
+import (
+	"fmt"
+	"net/url"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+)
+
+// BaselineView represents an approved baseline of a site for API responses and the run banner.
+type BaselineView struct {
+	ID           int64  `json:"id"`
+	AuditRunID   int64  `json:"audit_run_id"`
+	ApprovedBy   string `json:"approved_by,omitempty"`
+	Note         string `json:"note,omitempty"`
+	ApprovedAt   string `json:"approved_at"`
+	SupersededAt string `json:"superseded_at,omitempty"`
+	Current      bool   `json:"current"`
+}
+
+// SiteBaselineView is a site's current baseline with every baseline it has had.
+type SiteBaselineView struct {
+	SiteID  int64          `json:"site_id"`
+	Current *BaselineView  `json:"current"` // Nil when no baseline is approved
+	History []BaselineView `json:"history"` // Newest first, including the current baseline
+}
+
+// DriftReportView reports how an audit run has drifted from the site's approved baseline.
+type DriftReportView struct {
+	SiteID       int64              `json:"site_id"`
+	AuditRunID   int64              `json:"audit_run_id"`
+	Baseline     BaselineView       `json:"baseline"`
+	HasDrift     bool               `json:"has_drift"`
+	ListsAdded   int                `json:"lists_added"`
+	ListsRemoved int                `json:"lists_removed"`
+	ListsChanged int                `json:"lists_changed"`
+	Severity     string             `json:"severity,omitempty"` // Most urgent severity among the findings
+	Findings     []DriftFindingView `json:"findings"`
+}
+
+// DriftFindingView is the drift of one list from the baseline.
+type DriftFindingView struct {
+	ListID     string                 `json:"list_id"`
+	ListTitle  string                 `json:"list_title"`
+	Kind       string                 `json:"kind"`
+	Severity   string                 `json:"severity"`
+	Added      int                    `json:"added"`   // Permissions granted since the baseline
+	Removed    int                    `json:"removed"` // Permissions revoked since the baseline
+	RedlineURL string                 `json:"redline_url,omitempty"`
+	Changes    []PermissionChangeView `json:"changes"`
+}
+
+// PermissionChangeView is one permission added or removed, since the baseline or by a simulated change.
+type PermissionChangeView struct {
+	Change     string `json:"change"`
+	Category   string `json:"category"`
+	ObjectType string `json:"object_type"`
+	ObjectKey  string `json:"object_key"`
+	ObjectName string `json:"object_name"`
+	Principal  string `json:"principal,omitempty"`
+	LoginName  string `json:"login_name,omitempty"`
+	Role       string `json:"role,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+// ToBaselineView converts a baseline to its view.
+func (p *ListPresenter) ToBaselineView(baseline *audit.Baseline) BaselineView {
+	view := BaselineView{
+		ID:         baseline.ID,
+		AuditRunID: baseline.AuditRunID,
+		ApprovedBy: baseline.ApprovedBy,
+		Note:       baseline.Note,
+		ApprovedAt: baseline.ApprovedAt.UTC().Format(AuditRunTimeFormat),
+		Current:    baseline.IsCurrent(),
+	}
+	if baseline.SupersededAt != nil {
+		view.SupersededAt = baseline.SupersededAt.UTC().Format(AuditRunTimeFormat)
+	}
+	return view
+}
+
+// ToSiteBaselineView converts a site's baseline history, newest first, to its view.
+// History is never nil so an empty list serializes as [] rather than null.
+func (p *ListPresenter) ToSiteBaselineView(siteID int64, history []*audit.Baseline) SiteBaselineView {
+	view := SiteBaselineView{SiteID: siteID, History: make([]BaselineView, len(history))}
+	for i, baseline := range history {
+		view.History[i] = p.ToBaselineView(baseline)
+		if baseline.IsCurrent() {
+			current := view.History[i]
+			view.Current = &current
+		}
+	}
+	return view
+}
+
+// ToDriftReportView converts a drift report to its view, preserving the order of findings and changes.
+func (p *ListPresenter) ToDriftReportView(siteID int64, report *application.DriftReport) DriftReportView {
+	view := DriftReportView{
+		SiteID:     siteID,
+		AuditRunID: report.AuditRunID,
+		Baseline:   p.ToBaselineView(report.Baseline),
+		HasDrift:   report.HasDrift(),
+		Severity:   string(report.HighestSeverity()),
+		Findings:   make([]DriftFindingView, len(report.Findings)),
+	}
+
+	for i, finding := range report.Findings {
+		switch finding.Kind {
+		case application.DriftListAdded:
+			view.ListsAdded++
+		case application.DriftListRemoved:
+			view.ListsRemoved++
+		case application.DriftPermissionsChanged:
+			view.ListsChanged++
+		}
+
+		findingView := DriftFindingView{
+			ListID:    finding.List.ID,
+			ListTitle: finding.List.Title,
+			Kind:      finding.Kind,
+			Severity:  string(finding.Severity),
+			Changes:   make([]PermissionChangeView, len(finding.Changes)),
+		}
+		if finding.Kind == application.DriftPermissionsChanged {
+			findingView.RedlineURL = DriftRedlineURL(siteID, report.AuditRunID, finding.List.ID, report.Baseline.AuditRunID)
+		}
+		for j, change := range finding.Changes {
+			if change.Change == application.ChangeAdded {
+				findingView.Added++
+			} else {
+				findingView.Removed++
+			}
+
+			findingView.Changes[j] = toPermissionChangeView(change)
+		}
+		view.Findings[i] = findingView
+	}
+	return view
+}
+
+// toPermissionChangeView converts a snapshot change to its view.
+func toPermissionChangeView(change *application.SnapshotChange) PermissionChangeView {
+	view := PermissionChangeView{
+		Change:     change.Change,
+		Category:   change.Category,
+		ObjectType: change.ObjectType,
+		ObjectKey:  change.ObjectKey,
+		ObjectName: change.ObjectName,
+		Role:       change.Role,
+		Detail:     change.Detail,
+	}
+	if change.Principal != nil {
+		view.Principal = change.Principal.GetDisplayName()
+		view.LoginName = change.Principal.LoginName
+	}
+	return view
+}
+
+// DriftRedlineURL returns the XLSX redline of a list's changes since the baseline run.
+func DriftRedlineURL(siteID, auditRunID int64, listID string, baselineRunID int64) string {
+	return fmt.Sprintf("/sites/%d/audit-runs/%d/lists/%s/changes/export?base=%d&format=xlsx",
+		siteID, auditRunID, url.PathEscape(listID), baselineRunID)
+}
+
+// ListRemoved reports whether the list no longer has data in the run, so there is no list page to link to.
+func (f DriftFindingView) ListRemoved() bool {
+	return f.Kind == application.DriftListRemoved
+}
+
+// DriftKindBadgeVariant returns the badge variant of a drift finding kind.
+func DriftKindBadgeVariant(kind string) string {
+	switch kind {
+	case application.DriftListAdded:
+		return "warning"
+	case application.DriftListRemoved:
+		return "info"
+	default:
+		return "danger"
+	}
+}
+
+// DriftKindLabel names a drift finding kind for display.
+func DriftKindLabel(kind string) string {
+	switch kind {
+	case application.DriftListAdded:
+		return "New list"
+	case application.DriftListRemoved:
+		return "List removed"
+	case application.DriftPermissionsChanged:
+		return "Permissions changed"
+	default:
+		return kind
+	}
+}
@@ -0,0 +1,105 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+func TestListPresenter_ToSiteBaselineView(t *testing.T) {
+	presenter := NewListPresenter()
+	approvedAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	supersededAt := approvedAt.Add(time.Hour)
+
+	view := presenter.ToSiteBaselineView(1, []*audit.Baseline{
+		{ID: 2, SiteID: 1, AuditRunID: 5, ApprovedBy: "bob", ApprovedAt: supersededAt},
+		{ID: 1, SiteID: 1, AuditRunID: 3, ApprovedBy: "alice", Note: "Q1", ApprovedAt: approvedAt, SupersededAt: &supersededAt},
+	})
+	require.NotNil(t, view.Current)
+	assert.Equal(t, int64(5), view.Current.AuditRunID)
+	require.Len(t, view.History, 2)
+	assert.False(t, view.History[1].Current)
+	assert.Equal(t, "2025-03-01 13:00:00", view.History[1].SupersededAt)
+
+	empty := presenter.ToSiteBaselineView(1, nil)
+	assert.Nil(t, empty.Current)
+	assert.NotNil(t, empty.History)
+}
+
+func TestListPresenter_ToDriftReportView(t *testing.T) {
+	presenter := NewListPresenter()
+	finance := &sharepoint.Principal{ID: 7, Title: "Finance", LoginName: "Finance"}
+	report := &application.DriftReport{
+		Baseline:   &audit.Baseline{ID: 1, SiteID: 1, AuditRunID: 3, ApprovedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)},
+		AuditRunID: 5,
+		Findings: []*application.ListDrift{
+			{Kind: application.DriftListRemoved, Severity: audit.SeverityMedium, ListSnapshotDiffData: &application.ListSnapshotDiffData{
+				List: &sharepoint.List{ID: "archive", Title: "Archive"}, Changes: []*application.SnapshotChange{},
+			}},
+			{Kind: application.DriftPermissionsChanged, Severity: audit.SeverityCritical, ListSnapshotDiffData: &application.ListSnapshotDiffData{
+				List: &sharepoint.List{ID: "docs", Title: "Documents"},
+				Changes: []*application.SnapshotChange{
+					{Change: application.ChangeRemoved, Category: application.ChangeCategoryListAssignment, ObjectType: "list", ObjectKey: "docs", Principal: finance, Role: "Read"},
+					{Change: application.ChangeAdded, Category: application.ChangeCategoryListAssignment, ObjectType: "list", ObjectKey: "docs", Principal: finance, Role: "Contribute"},
+					{Change: application.ChangeAdded, Category: application.ChangeCategoryUniqueItem, ObjectType: "item", ObjectKey: "guid-1"},
+				},
+			}},
+		},
+	}
+
+	view := presenter.ToDriftReportView(1, report)
+	assert.True(t, view.HasDrift)
+	assert.Equal(t, int64(3), view.Baseline.AuditRunID)
+	assert.Equal(t, 0, view.ListsAdded)
+	assert.Equal(t, 1, view.ListsRemoved)
+	assert.Equal(t, 1, view.ListsChanged)
+	assert.Equal(t, "critical", view.Severity)
+
+	require.Len(t, view.Findings, 2)
+	assert.Empty(t, view.Findings[0].RedlineURL, "removed lists have no redline")
+	docs := view.Findings[1]
+	assert.Equal(t, 2, docs.Added)
+	assert.Equal(t, 1, docs.Removed)
+	assert.Equal(t, "critical", docs.Severity)
+	assert.Equal(t, "/sites/1/audit-runs/5/lists/docs/changes/export?base=3&format=xlsx", docs.RedlineURL)
+	assert.Equal(t, "Finance", docs.Changes[0].Principal)
+	assert.Empty(t, docs.Changes[2].Principal)
+}
+
+func TestListPresenter_ToFindingSeveritiesView(t *testing.T) {
+	presenter := NewListPresenter()
+	severities, err := application.NewFindingSeverities(map[string]audit.Severity{application.RiskyDefaultEditLink: audit.SeverityCritical})
+	require.NoError(t, err)
+
+	view := presenter.ToFindingSeveritiesView(severities.Mapping())
+	assert.Equal(t, []string{"info", "low", "medium", "high", "critical"}, view.Severities)
+	for _, category := range view.Categories {
+		if category.Category == application.RiskyDefaultEditLink {
+			assert.Equal(t, FindingSeverityView{Category: "default_link_edit", Severity: "critical", DefaultSeverity: "medium", Custom: true}, category)
+		} else {
+			assert.False(t, category.Custom, category.Category)
+		}
+	}
+	assert.Equal(t, "Critical", SeverityLabel(view.Categories[2].Severity))
+}
+
+func TestRunContext_Baseline(t *testing.T) {
+	approved := RunContext{AuditRunID: 3, RunStatus: "completed", BaselineRunID: 3}
+	assert.True(t, approved.IsBaselineRun())
+	assert.False(t, approved.CanCheckDrift())
+	assert.False(t, approved.CanApproveBaseline())
+
+	later := RunContext{AuditRunID: 5, RunStatus: "completed", BaselineRunID: 3}
+	assert.True(t, later.CanCheckDrift())
+	assert.True(t, later.CanApproveBaseline(), "a later run can reset the baseline")
+
+	running := RunContext{AuditRunID: 6, RunStatus: "running"}
+	assert.False(t, running.CanCheckDrift())
+	assert.False(t, running.CanApproveBaseline())
+}
@@ -0,0 +1,117 @@
+package presenters
+
+import "fmt"
+
+// Chart colours, matching the Tailwind palette used across the UI.
+const (
+	chartRed     = "#ef4444"
+	chartOrange  = "#f97316"
+	chartAmber   = "#f59e0b"
+	chartBlue    = "#3b82f6"
+	chartEmerald = "#10b981"
+	chartPurple  = "#a855f7"
+	chartSlate   = "#94a3b8"
+)
+
+// Chart is a breakdown of a total into categories, drawn as a donut or a stacked bar.
+type Chart struct {
+	Total  int64
+	Slices []ChartSlice // Non-empty categories in legend order
+}
+
+// ChartSlice is one category of a chart, sized by its share of the chart's total.
+type ChartSlice struct {
+	Label   string
+	Count   int64
+	Color   string
+	Percent float64 // Share of the total, 0-100
+	Offset  float64 // Share of the total taken by the slices before it, 0-100
+}
+
+// newChart builds a chart from its categories, dropping empty ones.
+func newChart(categories ...ChartSlice) Chart {
+	chart := Chart{}
+	for _, category := range categories {
+		chart.Total += category.Count
+	}
+	if chart.Total == 0 {
+		return chart
+	}
+	offset := 0.0
+	for _, category := range categories {
+		if category.Count <= 0 {
+			continue
+		}
+		category.Percent = float64(category.Count) / float64(chart.Total) * 100
+		category.Offset = offset
+		offset += category.Percent
+		chart.Slices = append(chart.Slices, category)
+	}
+	return chart
+}
+
+// IsEmpty returns true if the chart has nothing to draw.
+func (c Chart) IsEmpty() bool {
+	return c.Total == 0
+}
+
+// Summary describes the chart for screen readers.
+func (c Chart) Summary() string {
+	summary := fmt.Sprintf("%d in total", c.Total)
+	for _, slice := range c.Slices {
+		summary += fmt.Sprintf(", %s %d", slice.Label, slice.Count)
+	}
+	return summary
+}
+
+// DashArray returns the stroke-dasharray drawing the slice on a donut ring with a circumference of 100.
+func (s ChartSlice) DashArray() string {
+	return fmt.Sprintf("%.2f %.2f", s.Percent, 100-s.Percent)
+}
+
+// DashOffset returns the stroke-dashoffset starting the slice where the previous one ended,
+// going clockwise from 12 o'clock.
+func (s ChartSlice) DashOffset() string {
+	return fmt.Sprintf("%.2f", 25-s.Offset)
+}
+
+// PercentText formats the slice's share of the total.
+func (s ChartSlice) PercentText() string {
+	return fmt.Sprintf("%.0f%%", s.Percent)
+}
+
+// RoleChart breaks the list's role assignments down by role.
+func (a ListAnalytics) RoleChart() Chart {
+	return newChart(
+		ChartSlice{Label: "Full Control", Count: int64(a.FullControlCount), Color: chartRed},
+		ChartSlice{Label: "Contribute", Count: int64(a.ContributeCount), Color: chartOrange},
+		ChartSlice{Label: "Read", Count: int64(a.ReadCount), Color: chartBlue},
+		ChartSlice{Label: "Limited Access", Count: int64(a.LimitedAccessCount), Color: chartSlate},
+		ChartSlice{Label: "Other Roles", Count: int64(a.OtherRolesCount), Color: chartPurple},
+	)
+}
+
+// LinkTypeChart breaks the list's sharing links down by link type.
+func (a ListAnalytics) LinkTypeChart() Chart {
+	return newChart(
+		ChartSlice{Label: "Flexible", Count: int64(a.FlexibleLinksCount), Color: chartBlue},
+		ChartSlice{Label: "Organization View", Count: int64(a.OrganizationViewCount), Color: chartEmerald},
+		ChartSlice{Label: "Organization Edit", Count: int64(a.OrganizationEditCount), Color: chartAmber},
+		ChartSlice{Label: "Anonymous View", Count: int64(a.AnonymousViewCount), Color: chartOrange},
+		ChartSlice{Label: "Anonymous Edit", Count: int64(a.AnonymousEditCount), Color: chartRed},
+		ChartSlice{Label: "Direct", Count: int64(a.DirectLinksCount), Color: chartPurple},
+		ChartSlice{Label: "Other", Count: int64(a.OtherLinksCount), Color: chartSlate},
+	)
+}
+
+// InheritanceChart splits the list's items into those with unique permissions and those inheriting them.
+func (a ListAnalytics) InheritanceChart() Chart {
+	inherited := a.TotalItems - a.ItemsWithUnique
+	if inherited < 0 {
+		inherited = 0
+	}
+	return newChart(
+		ChartSlice{Label: "Unique", Count: a.ItemsWithUnique, Color: chartAmber},
+		ChartSlice{Label: "Inherited", Count: inherited, Color: chartEmerald},
+	)
+}
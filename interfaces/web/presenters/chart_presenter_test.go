@@ -0,0 +1,30 @@
+package presenters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAnalytics_Charts(t *testing.T) {
+	analytics := ListAnalytics{FullControlCount: 1, ReadCount: 3, TotalItems: 10, ItemsWithUnique: 4}
+
+	roles := analytics.RoleChart()
+	assert.Equal(t, int64(4), roles.Total)
+	require.Len(t, roles.Slices, 2, "empty roles are left out")
+	assert.Equal(t, "Full Control", roles.Slices[0].Label)
+	assert.Equal(t, "25.00 75.00", roles.Slices[0].DashArray())
+	assert.Equal(t, "25.00", roles.Slices[0].DashOffset())
+	assert.Equal(t, "Read", roles.Slices[1].Label)
+	assert.Equal(t, "75%", roles.Slices[1].PercentText())
+	assert.Equal(t, "0.00", roles.Slices[1].DashOffset(), "slices start where the previous one ended")
+	assert.Equal(t, "4 in total, Full Control 1, Read 3", roles.Summary())
+
+	assert.True(t, analytics.LinkTypeChart().IsEmpty())
+
+	inheritance := analytics.InheritanceChart()
+	require.Len(t, inheritance.Slices, 2)
+	assert.Equal(t, ChartSlice{Label: "Unique", Count: 4, Color: chartAmber, Percent: 40}, inheritance.Slices[0])
+	assert.Equal(t, ChartSlice{Label: "Inherited", Count: 6, Color: chartEmerald, Percent: 60, Offset: 40}, inheritance.Slices[1])
+}
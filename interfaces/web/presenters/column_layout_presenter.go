@@ -0,0 +1,227 @@
+package presenters
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DataTable identifies a data table whose columns can be customized.
+type DataTable string
+
+// Customizable data tables.
+const (
+	DataTableItems       DataTable = "items"
+	DataTableAssignments DataTable = "assignments"
+	DataTableLinks       DataTable = "links"
+)
+
+// Items table column keys.
+const (
+	ItemColumnItem        = "item"
+	ItemColumnPermissions = "permissions"
+	ItemColumnSize        = "size"
+	ItemColumnModified    = "modified"
+	ItemColumnActions     = "actions"
+)
+
+// Assignments table column keys.
+const (
+	AssignmentColumnPrincipal  = "principal"
+	AssignmentColumnType       = "type"
+	AssignmentColumnRole       = "role"
+	AssignmentColumnSource     = "source"
+	AssignmentColumnRootCauses = "root_causes"
+	AssignmentColumnActions    = "actions"
+)
+
+// Sharing links table column keys.
+const (
+	LinkColumnItem     = "item"
+	LinkColumnLinkType = "link_type"
+	LinkColumnAccess   = "access"
+	LinkColumnStatus   = "status"
+	LinkColumnMembers  = "members"
+	LinkColumnViews    = "views"
+	LinkColumnCreated  = "created"
+	LinkColumnModified = "modified"
+)
+
+// TableColumn describes one column a data table can render.
+type TableColumn struct {
+	Key     string
+	Label   string
+	Width   string // Tailwind width class for the header cell
+	Default bool   // Shown when no selection has been saved
+}
+
+// tableColumns lists every column each table supports, in default order.
+// Presenters populate the data for all of them; templates render the selected ones.
+var tableColumns = map[DataTable][]TableColumn{
+	DataTableItems: {
+		{Key: ItemColumnItem, Label: "Item", Width: "w-5/8", Default: true},
+		{Key: ItemColumnPermissions, Label: "Permissions", Width: "w-1/6", Default: true},
+		{Key: ItemColumnSize, Label: "Size", Width: "w-1/12"},
+		{Key: ItemColumnModified, Label: "Modified", Width: "w-1/6"},
+		{Key: ItemColumnActions, Label: "Actions", Width: "w-1/6", Default: true},
+	},
+	DataTableAssignments: {
+		{Key: AssignmentColumnPrincipal, Label: "Principal", Width: "w-2/5", Default: true},
+		{Key: AssignmentColumnType, Label: "Type", Width: "w-1/6", Default: true},
+		{Key: AssignmentColumnRole, Label: "Role", Width: "w-1/6", Default: true},
+		{Key: AssignmentColumnSource, Label: "Source", Width: "w-1/6", Default: true},
+		{Key: AssignmentColumnRootCauses, Label: "Root Causes", Width: "w-1/8"},
+		{Key: AssignmentColumnActions, Label: "Actions", Width: "w-20", Default: true},
+	},
+	DataTableLinks: {
+		{Key: LinkColumnItem, Label: "Item", Width: "w-2/5", Default: true},
+		{Key: LinkColumnLinkType, Label: "Link Type", Width: "w-1/6", Default: true},
+		{Key: LinkColumnAccess, Label: "Access", Width: "w-1/8", Default: true},
+		{Key: LinkColumnStatus, Label: "Status", Width: "w-1/8", Default: true},
+		{Key: LinkColumnMembers, Label: "Members", Width: "w-1/8", Default: true},
+		{Key: LinkColumnViews, Label: "Views", Width: "w-1/8"},
+		{Key: LinkColumnCreated, Label: "Created", Width: "w-1/6", Default: true},
+		{Key: LinkColumnModified, Label: "Last Modified", Width: "w-1/6"},
+	},
+}
+
+// ParseDataTable returns the customizable table with the given name.
+func ParseDataTable(name string) (DataTable, bool) {
+	table := DataTable(name)
+	_, ok := tableColumns[table]
+	return table, ok
+}
+
+// ColumnLayout is the ordered selection of columns a data table renders.
+type ColumnLayout struct {
+	Table   DataTable
+	Columns []TableColumn // Selected columns in display order
+	Custom  bool          // Selection differs from the table's defaults
+}
+
+// ColumnChoice is one entry of the column picker.
+type ColumnChoice struct {
+	Column  TableColumn
+	Visible bool
+}
+
+// DefaultColumnLayout returns the default columns of a table.
+func DefaultColumnLayout(table DataTable) ColumnLayout {
+	layout := ColumnLayout{Table: table}
+	for _, column := range tableColumns[table] {
+		if column.Default {
+			layout.Columns = append(layout.Columns, column)
+		}
+	}
+	return layout
+}
+
+// ParseColumnLayout reads a saved selection of comma-separated column keys.
+// Unknown and repeated keys are ignored; an empty selection falls back to the defaults.
+func (p *PermissionPresenter) ParseColumnLayout(table DataTable, value string) ColumnLayout {
+	return newColumnLayout(table, strings.Split(value, ","))
+}
+
+// ApplyColumnSelection builds a layout from the column picker form.
+// order lists every column key as shown in the picker, selected the checked keys, and
+// move is an optional "key:up" or "key:down" instruction swapping a column with its selected neighbour.
+func (p *PermissionPresenter) ApplyColumnSelection(table DataTable, order, selected []string, move string) ColumnLayout {
+	checked := make(map[string]bool, len(selected))
+	for _, key := range selected {
+		checked[key] = true
+	}
+
+	keys := make([]string, 0, len(order))
+	for _, key := range order {
+		if checked[key] {
+			keys = append(keys, key)
+		}
+	}
+	if key, direction, ok := strings.Cut(move, ":"); ok {
+		keys = moveColumnKey(keys, key, direction)
+	}
+	return newColumnLayout(table, keys)
+}
+
+// newColumnLayout resolves column keys against the table's columns.
+func newColumnLayout(table DataTable, keys []string) ColumnLayout {
+	available := make(map[string]TableColumn, len(tableColumns[table]))
+	for _, column := range tableColumns[table] {
+		available[column.Key] = column
+	}
+
+	layout := ColumnLayout{Table: table}
+	seen := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		column, ok := available[strings.TrimSpace(key)]
+		if !ok || seen[column.Key] {
+			continue
+		}
+		seen[column.Key] = true
+		layout.Columns = append(layout.Columns, column)
+	}
+	if len(layout.Columns) == 0 {
+		return DefaultColumnLayout(table)
+	}
+
+	layout.Custom = layout.Encode() != DefaultColumnLayout(table).Encode()
+	return layout
+}
+
+// moveColumnKey swaps key with its neighbour in the given direction.
+func moveColumnKey(order []string, key, direction string) []string {
+	moved := append([]string(nil), order...)
+	for i, candidate := range moved {
+		if candidate != key {
+			continue
+		}
+		switch {
+		case direction == "up" && i > 0:
+			moved[i-1], moved[i] = moved[i], moved[i-1]
+		case direction == "down" && i < len(moved)-1:
+			moved[i], moved[i+1] = moved[i+1], moved[i]
+		}
+		break
+	}
+	return moved
+}
+
+// Encode returns the selection as comma-separated column keys for storage.
+func (l ColumnLayout) Encode() string {
+	keys := make([]string, len(l.Columns))
+	for i, column := range l.Columns {
+		keys[i] = column.Key
+	}
+	return strings.Join(keys, ",")
+}
+
+// Shows reports whether the column with the given key is selected.
+func (l ColumnLayout) Shows(key string) bool {
+	for _, column := range l.Columns {
+		if column.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// Colspan returns the number of rendered columns, for full-width expandable rows.
+func (l ColumnLayout) Colspan() string {
+	return strconv.Itoa(len(l.Columns))
+}
+
+// Choices lists every column of the table for the picker: selected columns in
+// display order, followed by the hidden ones in default order.
+func (l ColumnLayout) Choices() []ColumnChoice {
+	choices := make([]ColumnChoice, 0, len(tableColumns[l.Table]))
+	visible := make(map[string]bool, len(l.Columns))
+	for _, column := range l.Columns {
+		visible[column.Key] = true
+		choices = append(choices, ColumnChoice{Column: column, Visible: true})
+	}
+	for _, column := range tableColumns[l.Table] {
+		if !visible[column.Key] {
+			choices = append(choices, ColumnChoice{Column: column})
+		}
+	}
+	return choices
+}
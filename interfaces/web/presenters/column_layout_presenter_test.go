@@ -0,0 +1,63 @@
+package presenters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionPresenter_ParseColumnLayout(t *testing.T) {
+	presenter := NewPermissionPresenter()
+
+	tests := []struct {
+		name       string
+		saved      string
+		wantKeys   string
+		wantCustom bool
+	}{
+		{"nothing saved", "", "item,permissions,actions", false},
+		{"custom order and extra column", "modified,item,actions", "modified,item,actions", true},
+		{"unknown and repeated keys dropped", "item,bogus,item,size", "item,size", true},
+		{"only unknown keys", "bogus", "item,permissions,actions", false},
+		{"defaults saved explicitly", "item,permissions,actions", "item,permissions,actions", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout := presenter.ParseColumnLayout(DataTableItems, tt.saved)
+			assert.Equal(t, tt.wantKeys, layout.Encode())
+			assert.Equal(t, tt.wantCustom, layout.Custom)
+		})
+	}
+}
+
+func TestPermissionPresenter_ApplyColumnSelection(t *testing.T) {
+	presenter := NewPermissionPresenter()
+	order := []string{"principal", "type", "role", "source", "actions", "root_causes"}
+	selected := []string{"principal", "role", "source", "actions"}
+
+	layout := presenter.ApplyColumnSelection(DataTableAssignments, order, selected, "")
+	assert.Equal(t, "principal,role,source,actions", layout.Encode())
+	assert.Equal(t, "4", layout.Colspan())
+	assert.False(t, layout.Shows(AssignmentColumnType))
+
+	// Moving swaps with the neighbour; moves past either end are ignored
+	assert.Equal(t, "principal,source,role,actions", presenter.ApplyColumnSelection(DataTableAssignments, order, selected, "source:up").Encode())
+	assert.Equal(t, "principal,role,source,actions", presenter.ApplyColumnSelection(DataTableAssignments, order, selected, "principal:up").Encode())
+
+	// Clearing every column falls back to the defaults
+	assert.Equal(t, DefaultColumnLayout(DataTableAssignments).Encode(), presenter.ApplyColumnSelection(DataTableAssignments, order, nil, "").Encode())
+}
+
+func TestColumnLayout_ChoicesListHiddenColumnsLast(t *testing.T) {
+	layout := NewPermissionPresenter().ParseColumnLayout(DataTableLinks, "created,item")
+
+	choices := layout.Choices()
+	keys := make([]string, len(choices))
+	for i, choice := range choices {
+		keys[i] = choice.Column.Key
+	}
+	assert.Equal(t, []string{"created", "item", "link_type", "access", "status", "members", "views", "modified"}, keys)
+	assert.True(t, choices[1].Visible)
+	assert.False(t, choices[2].Visible)
+}
@@ -0,0 +1,147 @@
+package presenters
+
+import (
+	"fmt"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+// Item access explanation JSON structures. Principals, items and sharing links
+// reuse the snapshot shapes so clients can share decoding code.
+
+// ItemAccessExplanation explains who can access an item within an audit run and why.
+type ItemAccessExplanation struct {
+	SiteID           int64                 `json:"site_id"`
+	AuditRunID       int64                 `json:"audit_run_id"`
+	ListID           string                `json:"list_id"`
+	ListTitle        string                `json:"list_title"`
+	Item             SnapshotItem          `json:"item"`
+	PermissionSource ExplanationSource     `json:"permission_source"`
+	Principals       []PrincipalAccessView `json:"principals"`
+	SharingLinks     []SnapshotSharingLink `json:"sharing_links"`
+}
+
+// ExplanationSource identifies the object an assignment or sharing link is on.
+type ExplanationSource struct {
+	Type       string `json:"type"`
+	ObjectType string `json:"object_type"`
+	ObjectKey  string `json:"object_key"`
+	Title      string `json:"title,omitempty"`
+	URL        string `json:"url,omitempty"`
+}
+
+// PrincipalAccessView lists every path by which a principal can access the item.
+type PrincipalAccessView struct {
+	Principal SnapshotPrincipal `json:"principal"`
+	Paths     []AccessPathView  `json:"paths"`
+}
+
+// AccessPathView is one way a principal can access the item.
+type AccessPathView struct {
+	Type            string              `json:"type"`
+	Role            string              `json:"role"`
+	Source          ExplanationSource   `json:"source"`
+	Explanation     string              `json:"explanation"`
+	SharingLinkID   string              `json:"sharing_link_id,omitempty"`
+	SharingLinkKind string              `json:"sharing_link_kind,omitempty"`
+	RootCauses      []SnapshotRootCause `json:"root_causes,omitempty"`
+}
+
+// ToItemAccessExplanation converts an item access explanation to its JSON representation.
+// Principals, their paths and sharing links are made up front, so an item nobody reaches lists them as [].
+func (p *ListPresenter) ToItemAccessExplanation(siteID int64, data *application.ItemAccessExplanationData) *ItemAccessExplanation {
+	view := &ItemAccessExplanation{
+		SiteID:           siteID,
+		AuditRunID:       data.AuditRunID,
+		ListID:           data.List.ID,
+		ListTitle:        data.List.Title,
+		Item:             p.toSnapshotItem(data.Item),
+		PermissionSource: toExplanationSource(data.PermissionSource),
+		Principals:       make([]PrincipalAccessView, 0, len(data.Principals)),
+		SharingLinks:     make([]SnapshotSharingLink, 0, len(data.SharingLinks)),
+	}
+
+	for _, access := range data.Principals {
+		principalView := PrincipalAccessView{
+			Principal: p.toSnapshotPrincipal(access.Principal),
+			Paths:     make([]AccessPathView, 0, len(access.Paths)),
+		}
+		for _, path := range access.Paths {
+			principalView.Paths = append(principalView.Paths, p.toAccessPathView(access.Principal, path))
+		}
+		view.Principals = append(view.Principals, principalView)
+	}
+
+	for _, link := range data.SharingLinks {
+		view.SharingLinks = append(view.SharingLinks, p.toSnapshotSharingLink(link))
+	}
+
+	return view
+}
+
+// toAccessPathView converts an access path, adding a one-line explanation.
+func (p *ListPresenter) toAccessPathView(principal *sharepoint.Principal, path *application.AccessPath) AccessPathView {
+	view := AccessPathView{
+		Type:        path.Type,
+		Role:        path.Role,
+		Source:      toExplanationSource(path.Source),
+		Explanation: explainAccessPath(principal, path),
+	}
+	if path.SharingLink != nil {
+		view.SharingLinkID = path.SharingLink.ID
+		view.SharingLinkKind = path.SharingLink.GetLinkKindName()
+	}
+	for _, cause := range path.RootCauses {
+		view.RootCauses = append(view.RootCauses, SnapshotRootCause{
+			Type:         cause.Type,
+			Detail:       cause.Detail,
+			SourceObject: cause.SourceObject,
+			SourceRole:   cause.SourceRole,
+		})
+	}
+	return view
+}
+
+// explainAccessPath describes an access path in one sentence.
+func explainAccessPath(principal *sharepoint.Principal, path *application.AccessPath) string {
+	source := describeSource(path.Source)
+	switch path.Type {
+	case application.AccessPathDirect:
+		return fmt.Sprintf("Assigned %s directly on %s", path.Role, source)
+	case application.AccessPathGroup:
+		return fmt.Sprintf("Group %s is assigned %s on %s; its members have this access", principal.GetDisplayName(), path.Role, source)
+	case application.AccessPathLink:
+		if path.SharingLink != nil {
+			return fmt.Sprintf("Member of a %s sharing link on %s granting %s access", path.SharingLink.GetLinkKindName(), source, path.Role)
+		}
+		return fmt.Sprintf("Sharing link group assigned %s on %s", path.Role, source)
+	case application.AccessPathInheritance:
+		return fmt.Sprintf("Assigned %s on %s, which the item inherits permissions from", path.Role, source)
+	default:
+		return fmt.Sprintf("Assigned %s on %s", path.Role, source)
+	}
+}
+
+// describeSource names a permission source for explanations.
+func describeSource(source *application.PermissionSource) string {
+	switch {
+	case source.Type == application.PermissionSourceItem:
+		return "the item"
+	case source.Title != "":
+		return fmt.Sprintf("%s %q", source.Type, source.Title)
+	default:
+		return "the " + source.Type
+	}
+}
+
+// toExplanationSource converts a permission source to its JSON form.
+func toExplanationSource(source *application.PermissionSource) ExplanationSource {
+	return ExplanationSource{
+		Type:       source.Type,
+		ObjectType: source.ObjectType,
+		ObjectKey:  source.ObjectKey,
+		Title:      source.Title,
+		URL:        source.URL,
+	}
+}
@@ -0,0 +1,253 @@
+package presenters
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+// CSVTable holds a header row and data rows ready to be written as CSV.
+type CSVTable struct {
+	Header []string
+	Rows   [][]string
+}
+
+// ListsToCSV converts the lists table view to CSV, preserving the given order.
+func (p *ListPresenter) ListsToCSV(lists []ListSummary) CSVTable {
+	table := CSVTable{
+		Header: []string{"List ID", "Title", "Web", "URL", "Items", "Unique Permissions", "Unique Items", "Unique Density", "Exceeds Density Threshold", "Sampled Items", "Last Updated"},
+		Rows:   make([][]string, 0, len(lists)),
+	}
+
+	for _, list := range lists {
+		// Empty when every item was scanned; otherwise the unique counts are estimates
+		sampledItems := ""
+		if list.Sampled {
+			sampledItems = strconv.FormatInt(list.SampledItemCount, 10)
+		}
+		table.Rows = append(table.Rows, []string{
+			list.ListID,
+			list.Title,
+			list.WebTitle,
+			list.URL,
+			strconv.FormatInt(list.ItemCount, 10),
+			strconv.FormatBool(list.HasUnique),
+			strconv.FormatInt(list.UniqueItemCount, 10),
+			fmt.Sprintf("%.4f", list.UniqueDensity),
+			strconv.FormatBool(list.ExceedsDensityThreshold),
+			sampledItems,
+			list.LastModified,
+		})
+	}
+
+	return table
+}
+
+// ItemsToCSV converts the items table view to CSV, preserving the given order.
+func (p *PermissionPresenter) ItemsToCSV(items []ItemSummary) CSVTable {
+	table := CSVTable{
+		Header: []string{"Item ID", "Item GUID", "Name", "Type", "URL", "Permissions"},
+		Rows:   make([][]string, 0, len(items)),
+	}
+
+	for _, item := range items {
+		itemType := "Item"
+		if item.IsFolder {
+			itemType = "Folder"
+		} else if item.IsFile {
+			itemType = "File"
+		}
+
+		permissions := "Inherited"
+		if item.HasUnique {
+			permissions = "Unique"
+		}
+
+		table.Rows = append(table.Rows, []string{
+			strconv.FormatInt(item.ItemID, 10),
+			item.ItemGUID,
+			item.Name,
+			itemType,
+			item.URL,
+			permissions,
+		})
+	}
+
+	return table
+}
+
+// Access sources written to the Access Source column of assignment exports.
+// Sharing link and navigation sources name the link or the objects they come from.
+const (
+	AccessSourceDirect         = "Direct"
+	AccessSourceGroup          = "Group membership"
+	AccessSourceInherited      = "Inherited from web"
+	AccessSourceInheritedGroup = "Inherited from web, group membership"
+	AccessSourceSystemGroup    = "System group"
+	AccessSourceSharingLink    = "Sharing link"
+	AccessSourceNavigation     = "Limited Access for navigation to"
+)
+
+// SharingLinksToCSV converts sharing links table rows to CSV.
+func (p *PermissionPresenter) SharingLinksToCSV(links []SharingLink) CSVTable {
+	table := CSVTable{
+		Header: []string{"Link ID", "Item GUID", "Item", "Link Type", "Scope", "Access", "Default", "Active", "Members", "URL", "Created", "Created By"},
+		Rows:   make([][]string, 0, len(links)),
+	}
+
+	for _, link := range links {
+		access := "View"
+		if link.IsEditLink {
+			access = "Edit"
+		}
+		table.Rows = append(table.Rows, []string{
+			link.LinkID,
+			link.ItemGUID,
+			link.ItemName,
+			link.LinkKindName,
+			link.ScopeName,
+			access,
+			strconv.FormatBool(link.IsDefault),
+			strconv.FormatBool(link.IsActive),
+			strconv.FormatInt(link.ActualMembersCount, 10),
+			link.URL,
+			link.CreatedAt,
+			link.CreatedByTitle,
+		})
+	}
+
+	return table
+}
+
+// AssignmentsToCSV converts the assignments table view to CSV, preserving the given order.
+// The Access Source column explains why each principal holds the role, from its root causes.
+func (p *PermissionPresenter) AssignmentsToCSV(collection ExpandableAssignmentCollection) CSVTable {
+	table := CSVTable{
+		Header: []string{"Principal", "Login Name", "Type", "Role", "Access Source", "Root Causes"},
+		Rows:   make([][]string, 0, len(collection.Assignments)),
+	}
+
+	for _, assignment := range collection.Assignments {
+		source := assignmentAccessSource(assignment, collection.InheritsFromWeb)
+
+		causes := make([]string, 0, len(assignment.RootCauses))
+		for _, cause := range assignment.RootCauses {
+			causes = append(causes, cause.Type+": "+cause.Detail)
+		}
+
+		table.Rows = append(table.Rows, []string{
+			assignment.PrincipalTitle,
+			assignment.LoginName,
+			assignment.PrincipalKind.Label(),
+			assignment.RoleName,
+			source,
+			strings.Join(causes, "; "),
+		})
+	}
+
+	return table
+}
+
+// assignmentAccessSource classifies how a principal came to hold an assignment: through a sharing link,
+// a system group, Limited Access granted for navigation to content below, or a direct or group grant,
+// which is inherited when the object takes its permissions from the web.
+func assignmentAccessSource(assignment ExpandableAssignment, inheritsFromWeb bool) string {
+	if assignment.PrincipalKind == sharepoint.PrincipalKindSharingLink {
+		linkID := sharepoint.NewSharingService().ParseSharingLink(assignment.LoginName).SharingID
+		if assignment.SharingLink != nil {
+			linkID = assignment.SharingLink.LinkID
+		}
+		return strings.TrimSpace(AccessSourceSharingLink + " " + linkID)
+	}
+
+	direct := false
+	var navigatedTo []string
+	for _, cause := range assignment.RootCauses {
+		switch cause.Type {
+		case sharepoint.RootCauseTypeSystemGroup:
+			return AccessSourceSystemGroup
+		case sharepoint.RootCauseTypeDirect:
+			direct = true
+		case sharepoint.RootCauseTypeInheritance:
+			navigatedTo = append(navigatedTo, cause.SourceObject)
+		}
+	}
+	if !direct && len(navigatedTo) > 0 {
+		return AccessSourceNavigation + " " + strings.Join(navigatedTo, ", ")
+	}
+
+	group := assignment.PrincipalKind == sharepoint.PrincipalKindSharePointGroup ||
+		assignment.PrincipalKind == sharepoint.PrincipalKindSecurityGroup ||
+		assignment.PrincipalKind == sharepoint.PrincipalKindDistributionList
+	switch {
+	case inheritsFromWeb && group:
+		return AccessSourceInheritedGroup
+	case inheritsFromWeb || assignment.Inherited:
+		return AccessSourceInherited
+	case group:
+		return AccessSourceGroup
+	default:
+		return AccessSourceDirect
+	}
+}
+
+// Redline markers written in the first column of each change row.
+const (
+	RedlineAdded   = "Added"
+	RedlineRemoved = "Removed"
+)
+
+// ListDiffToRedline converts the changes between two list snapshots to a table whose first
+// column marks each row as added or removed, preserving the diff's order.
+func (p *ListPresenter) ListDiffToRedline(diff *application.ListSnapshotDiffData) CSVTable {
+	table := CSVTable{
+		Header: []string{"Change", "Category", "Object Type", "Object", "Object Key", "Principal", "Login Name", "Principal Type", "Role", "Detail"},
+		Rows:   make([][]string, 0, len(diff.Changes)),
+	}
+
+	for _, change := range diff.Changes {
+		marker := RedlineAdded
+		if change.Change == application.ChangeRemoved {
+			marker = RedlineRemoved
+		}
+
+		var principal, loginName, principalType string
+		if change.Principal != nil {
+			principal = change.Principal.GetDisplayName()
+			loginName = change.Principal.LoginName
+			principalType = change.Principal.Kind().Label()
+		}
+
+		table.Rows = append(table.Rows, []string{
+			marker,
+			redlineCategoryLabels[change.Category],
+			change.ObjectType,
+			change.ObjectName,
+			change.ObjectKey,
+			principal,
+			loginName,
+			principalType,
+			change.Role,
+			change.Detail,
+		})
+	}
+
+	return table
+}
+
+// redlineCategoryLabels names each snapshot change category for exports.
+var redlineCategoryLabels = map[string]string{
+	application.ChangeCategoryListAssignment:    "List Assignment",
+	application.ChangeCategoryUniqueItem:        "Unique Permissions",
+	application.ChangeCategoryItemAssignment:    "Item Assignment",
+	application.ChangeCategorySharingLink:       "Sharing Link",
+	application.ChangeCategorySharingLinkMember: "Sharing Link Member",
+}
+
+// ChangeCategoryLabel names a snapshot change category for display.
+func ChangeCategoryLabel(category string) string {
+	return redlineCategoryLabels[category]
+}
@@ -0,0 +1,148 @@
+package presenters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+func TestPermissionPresenter_AssignmentsToCSV_PrincipalKindLabels(t *testing.T) {
+	presenter := NewPermissionPresenter()
+	read := &sharepoint.RoleDefinition{ID: 2, Name: "Read"}
+
+	principals := []*sharepoint.Principal{
+		{ID: 1, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Alice", LoginName: "i:0#.f|membership|alice@contoso.com"},
+		{ID: 2, PrincipalType: sharepoint.PrincipalTypeSharePointGroup, Title: "Finance Members", LoginName: "Finance Members"},
+		{ID: 3, PrincipalType: sharepoint.PrincipalTypeSecurity, Title: "Everyone", LoginName: "c:0(.s|true"},
+		{ID: 4, PrincipalType: sharepoint.PrincipalTypeDistribution, Title: "All Staff", LoginName: "c:0t.c|tenant|staff"},
+		{ID: 5, PrincipalType: sharepoint.PrincipalTypeSharePointGroup, Title: "SharingLinks.abc.OrganizationView.def", LoginName: "SharingLinks.abc.OrganizationView.def"},
+		{ID: 6, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Backup App", LoginName: "i:0i.t|ms.sp.ext|11111111-2222-3333-4444-555555555555@contoso"},
+		{ID: 7, PrincipalType: 0, Title: "Mystery", LoginName: "mystery"},
+	}
+
+	resolved := make([]*sharepoint.ResolvedAssignment, len(principals))
+	for i, principal := range principals {
+		resolved[i] = &sharepoint.ResolvedAssignment{
+			Assignment: &sharepoint.Assignment{
+				RoleAssignment: &sharepoint.RoleAssignment{PrincipalID: principal.ID, RoleDefID: read.ID},
+				Principal:      principal,
+				RoleDefinition: read,
+			},
+		}
+	}
+
+	table := presenter.AssignmentsToCSV(presenter.ToExpandableAssignmentCollection(resolved, "list-1"))
+
+	require.Len(t, table.Rows, len(principals))
+	var labels []string
+	for _, row := range table.Rows {
+		labels = append(labels, row[2])
+	}
+	assert.Equal(t, []string{"User", "SharePoint Group", "Security Group", "Distribution List", "Sharing Link", "App", "Unknown"}, labels)
+}
+
+func TestListPresenter_ListDiffToRedline(t *testing.T) {
+	presenter := NewListPresenter()
+	alice := &sharepoint.Principal{ID: 10, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Alice", LoginName: "i:0#.f|membership|alice@contoso.com"}
+
+	diff := &application.ListSnapshotDiffData{
+		BaseAuditRunID: 1,
+		AuditRunID:     2,
+		Changes: []*application.SnapshotChange{
+			{Change: application.ChangeRemoved, Category: application.ChangeCategoryListAssignment, ObjectType: sharepoint.ObjectTypeList, ObjectKey: "list-1", ObjectName: "Documents", Principal: alice, RoleDefID: 2, Role: "Read"},
+			{Change: application.ChangeAdded, Category: application.ChangeCategorySharingLink, ObjectType: "sharing_link", ObjectKey: "link-1", ObjectName: "a.docx", Detail: "Anonymous View"},
+		},
+	}
+
+	table := presenter.ListDiffToRedline(diff)
+
+	assert.Equal(t, "Change", table.Header[0])
+	require.Len(t, table.Rows, 2)
+	assert.Equal(t, []string{RedlineRemoved, "List Assignment", "list", "Documents", "list-1", "Alice", "i:0#.f|membership|alice@contoso.com", "User", "Read", ""}, table.Rows[0])
+	assert.Equal(t, []string{RedlineAdded, "Sharing Link", "sharing_link", "a.docx", "link-1", "", "", "", "", "Anonymous View"}, table.Rows[1])
+}
+
+func TestPermissionPresenter_AssignmentsToCSV_AccessSource(t *testing.T) {
+	presenter := NewPermissionPresenter()
+	read := &sharepoint.RoleDefinition{ID: 2, Name: "Read"}
+	limited := &sharepoint.RoleDefinition{ID: 1, Name: "Limited Access"}
+	direct := sharepoint.RootCause{Type: sharepoint.RootCauseTypeDirect}
+	resolve := func(principal *sharepoint.Principal, role *sharepoint.RoleDefinition, causes ...sharepoint.RootCause) *sharepoint.ResolvedAssignment {
+		return &sharepoint.ResolvedAssignment{
+			Assignment: &sharepoint.Assignment{
+				RoleAssignment: &sharepoint.RoleAssignment{PrincipalID: principal.ID, RoleDefID: role.ID},
+				Principal:      principal,
+				RoleDefinition: role,
+			},
+			RootCauses: causes,
+		}
+	}
+
+	alice := &sharepoint.Principal{ID: 1, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Alice", LoginName: "i:0#.f|membership|alice@contoso.com"}
+	members := &sharepoint.Principal{ID: 2, PrincipalType: sharepoint.PrincipalTypeSharePointGroup, Title: "Finance Members", LoginName: "Finance Members"}
+	link := &sharepoint.Principal{ID: 3, PrincipalType: sharepoint.PrincipalTypeSharePointGroup, LoginName: "SharingLinks.0f1e2d3c-0000-4000-8000-000000000001.OrganizationView.9a8b7c6d-0000-4000-8000-000000000002"}
+	system := &sharepoint.Principal{ID: 4, PrincipalType: sharepoint.PrincipalTypeSharePointGroup, Title: "Limited Access System Group", LoginName: "Limited Access System Group"}
+	bob := &sharepoint.Principal{ID: 5, PrincipalType: sharepoint.PrincipalTypeUser, Title: "Bob", LoginName: "i:0#.f|membership|bob@contoso.com"}
+
+	resolved := []*sharepoint.ResolvedAssignment{
+		resolve(alice, read, direct),
+		resolve(members, read, direct),
+		resolve(link, read, direct),
+		resolve(system, limited, sharepoint.RootCause{Type: sharepoint.RootCauseTypeSystemGroup}),
+		resolve(bob, limited,
+			sharepoint.RootCause{Type: sharepoint.RootCauseTypeInheritance, SourceObject: "Documents"},
+			sharepoint.RootCause{Type: sharepoint.RootCauseTypeInheritance, SourceObject: "Budgets"}),
+	}
+	sources := func(collection ExpandableAssignmentCollection) []string {
+		var values []string
+		for _, row := range presenter.AssignmentsToCSV(collection).Rows {
+			values = append(values, row[4])
+		}
+		return values
+	}
+
+	collection := presenter.ToExpandableAssignmentCollection(resolved, "list-1")
+	assert.Equal(t, "Access Source", presenter.AssignmentsToCSV(collection).Header[4])
+	assert.Equal(t, []string{
+		AccessSourceDirect,
+		AccessSourceGroup,
+		"Sharing link 9a8b7c6d-0000-4000-8000-000000000002",
+		AccessSourceSystemGroup,
+		"Limited Access for navigation to Documents, Budgets",
+	}, sources(collection))
+
+	// A list without unique permissions shows the web's assignments
+	collection.InheritsFromWeb = true
+	assert.Equal(t, []string{AccessSourceInherited, AccessSourceInheritedGroup}, sources(collection)[:2])
+}
+
+func TestListPresenter_AccessReviewToCSV(t *testing.T) {
+	presenter := NewListPresenter()
+	entries := []*application.AccessReviewEntry{
+		{
+			Principal:  &sharepoint.Principal{PrincipalType: sharepoint.PrincipalTypeUser, Title: "Alice", LoginName: "i:0#.f|membership|Alice@contoso.com", Email: "alice@contoso.com"},
+			ObjectType: sharepoint.ObjectTypeList, ObjectName: "Documents", ObjectURL: "/sites/a/docs",
+			Role: "Contribute", GrantType: application.AccessGrantGroup, GrantedVia: "Finance",
+		},
+		{
+			Principal:  &sharepoint.Principal{PrincipalType: sharepoint.PrincipalTypeSecurity, Title: "Everyone", LoginName: "c:0(.s|true"},
+			ObjectType: sharepoint.ObjectTypeWeb, ObjectName: "Site", ObjectURL: "/sites/a",
+			Role: "Read", GrantType: application.AccessGrantDirect,
+		},
+	}
+
+	entra := presenter.AccessReviewToCSV("https://contoso.sharepoint.com/sites/a", 4, entries, AccessReviewFormatEntra)
+	require.Len(t, entra.Rows, 2)
+	assert.Equal(t, []string{"/sites/a/docs", "Documents", "list", "i:0#.f|membership|Alice@contoso.com", "Alice", "alice@contoso.com", "User",
+		"Contribute", "SharePoint Group", "Finance", "", "", "", ""}, entra.Rows[0])
+	assert.Empty(t, entra.Rows[1][5], "only users have a user principal name")
+	assert.Equal(t, "Direct", entra.Rows[1][8])
+
+	attestation := presenter.AccessReviewToCSV("https://contoso.sharepoint.com/sites/a", 4, entries, AccessReviewFormatAttestation)
+	assert.Equal(t, len(attestation.Header), len(attestation.Rows[0]))
+	assert.Equal(t, []string{"https://contoso.sharepoint.com/sites/a", "4", "Alice"}, attestation.Rows[0][:3])
+}
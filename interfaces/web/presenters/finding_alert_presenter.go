@@ -0,0 +1,43 @@
+package presenters
+
+import (
+	"time"
+
+	"spaudit/domain/audit"
+)
+
+// FindingAlertView is an alert raised for a finding of an audit run.
+type FindingAlertView struct {
+	ID          int64  `json:"id"`
+	SiteID      int64  `json:"site_id"`
+	AuditRunID  int64  `json:"audit_run_id"`
+	Fingerprint string `json:"fingerprint"`
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Message     string `json:"message"`
+	CreatedAt   string `json:"created_at"`
+	Queued      bool   `json:"queued"` // Waiting for quiet hours to end
+	DeliveredAt string `json:"delivered_at,omitempty"`
+}
+
+// ToFindingAlertViews converts finding alerts for the API, preserving their order.
+func (p *ListPresenter) ToFindingAlertViews(alerts []*audit.FindingAlert) []FindingAlertView {
+	views := make([]FindingAlertView, len(alerts))
+	for i, alert := range alerts {
+		views[i] = FindingAlertView{
+			ID:          alert.ID,
+			SiteID:      alert.SiteID,
+			AuditRunID:  alert.AuditRunID,
+			Fingerprint: alert.Fingerprint,
+			Category:    alert.Category,
+			Severity:    string(alert.Severity),
+			Message:     alert.Message,
+			CreatedAt:   alert.CreatedAt.UTC().Format(time.RFC3339),
+			Queued:      alert.IsQueued(),
+		}
+		if alert.DeliveredAt != nil {
+			views[i].DeliveredAt = alert.DeliveredAt.UTC().Format(time.RFC3339)
+		}
+	}
+	return views
+}
@@ -0,0 +1,66 @@
+package presenters
+
+import (
+	"strings"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+)
+
+// FindingSeveritiesView is the severity every finding category is rated with.
+type FindingSeveritiesView struct {
+	Severities []string              `json:"severities"` // From least to most urgent
+	Categories []FindingSeverityView `json:"categories"`
+}
+
+// FindingSeverityView is the severity of one finding category.
+type FindingSeverityView struct {
+	Category        string `json:"category"`
+	Severity        string `json:"severity"`
+	DefaultSeverity string `json:"default_severity"`
+	Custom          bool   `json:"custom"` // Remapped by an administrator
+}
+
+// ToFindingSeveritiesView converts the severity mapping for the API.
+func (p *ListPresenter) ToFindingSeveritiesView(mapping []application.FindingSeverity) FindingSeveritiesView {
+	view := FindingSeveritiesView{
+		Severities: make([]string, len(audit.Severities)),
+		Categories: make([]FindingSeverityView, len(mapping)),
+	}
+	for i, severity := range audit.Severities {
+		view.Severities[i] = string(severity)
+	}
+	for i, finding := range mapping {
+		view.Categories[i] = FindingSeverityView{
+			Category:        finding.Category,
+			Severity:        string(finding.Severity),
+			DefaultSeverity: string(finding.Default),
+			Custom:          finding.IsCustom(),
+		}
+	}
+	return view
+}
+
+// SeverityLabel names a severity for display.
+func SeverityLabel(severity string) string {
+	if severity == "" {
+		return ""
+	}
+	return strings.ToUpper(severity[:1]) + severity[1:]
+}
+
+// SeverityBadgeVariant returns the badge variant of a severity.
+func SeverityBadgeVariant(severity string) string {
+	switch audit.Severity(severity) {
+	case audit.SeverityCritical:
+		return "purple"
+	case audit.SeverityHigh:
+		return "danger"
+	case audit.SeverityMedium:
+		return "warning"
+	case audit.SeverityLow:
+		return "info"
+	default:
+		return "default"
+	}
+}
@@ -0,0 +1,84 @@
+package presenters
+
+import "spaudit/application"
+
+// GuestIdentitiesView is the consolidated access of guests correlated across audited tenants.
+type GuestIdentitiesView struct {
+	Identities   []GuestIdentityView    `json:"identities"`
+	SitesScanned int                    `json:"sites_scanned"`
+	Skipped      []GuestSkippedSiteView `json:"skipped"`
+}
+
+// GuestIdentityView is one guest and their accounts in every audited tenant.
+type GuestIdentityView struct {
+	Email      string             `json:"email"`
+	Domain     string             `json:"domain"`
+	Tenants    []string           `json:"tenants"`
+	GrantCount int                `json:"grant_count"`
+	Accounts   []GuestAccountView `json:"accounts"`
+}
+
+// GuestAccountView is a guest's account on one site, with the permissions it holds there.
+type GuestAccountView struct {
+	Tenant     string            `json:"tenant"`
+	SiteID     int64             `json:"site_id"`
+	SiteURL    string            `json:"site_url"`
+	AuditRunID int64             `json:"audit_run_id"`
+	Principal  SnapshotPrincipal `json:"principal"`
+	Groups     []string          `json:"groups"`
+	Grants     []GuestGrantView  `json:"grants"`
+}
+
+// GuestGrantView is an explicit permission of a guest account.
+type GuestGrantView struct {
+	ObjectType string `json:"object_type"`
+	ObjectURL  string `json:"object_url"`
+	ObjectName string `json:"object_name"`
+	Role       string `json:"role"`
+	Via        string `json:"via,omitempty"`
+}
+
+// GuestSkippedSiteView is a site left out of the correlation.
+type GuestSkippedSiteView struct {
+	SiteID  int64  `json:"site_id"`
+	SiteURL string `json:"site_url"`
+	Reason  string `json:"reason"`
+}
+
+// ToGuestIdentitiesView converts a guest correlation to its API view.
+func (p *ListPresenter) ToGuestIdentitiesView(data *application.GuestCorrelationData) GuestIdentitiesView {
+	view := GuestIdentitiesView{
+		Identities:   make([]GuestIdentityView, len(data.Identities)),
+		SitesScanned: data.SitesScanned,
+		Skipped:      make([]GuestSkippedSiteView, len(data.Skipped)),
+	}
+	for i, identity := range data.Identities {
+		accounts := make([]GuestAccountView, len(identity.Accounts))
+		for j, account := range identity.Accounts {
+			grants := make([]GuestGrantView, len(account.Grants))
+			for k, grant := range account.Grants {
+				grants[k] = GuestGrantView(*grant)
+			}
+			accounts[j] = GuestAccountView{
+				Tenant:     account.Tenant,
+				SiteID:     account.SiteID,
+				SiteURL:    account.SiteURL,
+				AuditRunID: account.AuditRunID,
+				Principal:  p.toSnapshotPrincipal(account.Principal),
+				Groups:     account.Groups,
+				Grants:     grants,
+			}
+		}
+		view.Identities[i] = GuestIdentityView{
+			Email:      identity.Email,
+			Domain:     identity.Domain,
+			Tenants:    identity.Tenants,
+			GrantCount: identity.GrantCount,
+			Accounts:   accounts,
+		}
+	}
+	for i, site := range data.Skipped {
+		view.Skipped[i] = GuestSkippedSiteView(*site)
+	}
+	return view
+}
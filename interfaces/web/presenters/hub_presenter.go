@@ -0,0 +1,111 @@
+package presenters
+
+import "spaudit/application"
+
+// HubRollupsView is the risk of every audited site rolled up by hub.
+type HubRollupsView struct {
+	Hubs         []HubRollupView      `json:"hubs"`
+	SitesScanned int                  `json:"sites_scanned"`
+	Skipped      []HubSkippedSiteView `json:"skipped"`
+}
+
+// HubRollupView is the aggregate risk of the sites associated with one hub.
+type HubRollupView struct {
+	HubSiteID          string `json:"hub_site_id,omitempty"`
+	Title              string `json:"title,omitempty"`
+	URL                string `json:"url,omitempty"`
+	PermissionsSync    bool   `json:"permissions_sync"`
+	RiskLevel          string `json:"risk_level"`
+	SiteCount          int    `json:"site_count"`
+	HighRiskSites      int    `json:"high_risk_sites"`
+	MediumRiskSites    int    `json:"medium_risk_sites"`
+	LowRiskSites       int    `json:"low_risk_sites"`
+	VisitorSyncedSites int    `json:"visitor_synced_sites"`
+
+	ListsAnalyzed          int   `json:"lists_analyzed"`
+	HighRiskLists          int   `json:"high_risk_lists"`
+	TotalItems             int64 `json:"total_items"`
+	ItemsWithUnique        int64 `json:"items_with_unique"`
+	SharingLinks           int   `json:"sharing_links"`
+	AnonymousLinks         int   `json:"anonymous_links"`
+	OrganizationLinks      int   `json:"organization_links"`
+	FullControlAssignments int   `json:"full_control_assignments"`
+
+	RiskiestSiteID int64             `json:"riskiest_site_id,omitempty"`
+	Sites          []HubSiteRiskView `json:"sites"`
+}
+
+// HubSiteRiskView is one site of a hub and the risk its latest audit run found.
+type HubSiteRiskView struct {
+	SiteID           int64   `json:"site_id"`
+	SiteURL          string  `json:"site_url"`
+	Title            string  `json:"title"`
+	AuditRunID       int64   `json:"audit_run_id"`
+	IsHub            bool    `json:"is_hub"`
+	InheritsVisitors bool    `json:"inherits_visitors"`
+	RiskLevel        string  `json:"risk_level"`
+	HighestRiskScore float64 `json:"highest_risk_score"`
+	HighRiskLists    int     `json:"high_risk_lists"`
+	AnonymousLinks   int     `json:"anonymous_links"`
+}
+
+// HubSkippedSiteView is a site left out of the rollup.
+type HubSkippedSiteView struct {
+	SiteID  int64  `json:"site_id"`
+	SiteURL string `json:"site_url"`
+	Reason  string `json:"reason"`
+}
+
+// ToHubRollupsView converts a hub rollup to its API view.
+func (p *ListPresenter) ToHubRollupsView(data *application.HubRollupData) HubRollupsView {
+	view := HubRollupsView{
+		Hubs:         make([]HubRollupView, len(data.Hubs)),
+		SitesScanned: data.SitesScanned,
+		Skipped:      make([]HubSkippedSiteView, len(data.Skipped)),
+	}
+	for i, hub := range data.Hubs {
+		hubView := HubRollupView{
+			HubSiteID:              hub.HubSiteID,
+			Title:                  hub.Title,
+			URL:                    hub.URL,
+			PermissionsSync:        hub.PermissionsSync,
+			RiskLevel:              hub.RiskLevel,
+			SiteCount:              len(hub.Sites),
+			HighRiskSites:          hub.HighRiskSites,
+			MediumRiskSites:        hub.MediumRiskSites,
+			LowRiskSites:           hub.LowRiskSites,
+			VisitorSyncedSites:     hub.VisitorSyncedSites,
+			ListsAnalyzed:          hub.ListsAnalyzed,
+			HighRiskLists:          hub.HighRiskLists,
+			TotalItems:             hub.TotalItems,
+			ItemsWithUnique:        hub.ItemsWithUnique,
+			SharingLinks:           hub.SharingLinks,
+			AnonymousLinks:         hub.AnonymousLinks,
+			OrganizationLinks:      hub.OrganizationLinks,
+			FullControlAssignments: hub.FullControlAssignments,
+			Sites:                  make([]HubSiteRiskView, len(hub.Sites)),
+		}
+		if hub.RiskiestSite != nil {
+			hubView.RiskiestSiteID = hub.RiskiestSite.Site.ID
+		}
+		for j, site := range hub.Sites {
+			hubView.Sites[j] = HubSiteRiskView{
+				SiteID:           site.Site.ID,
+				SiteURL:          site.Site.URL,
+				Title:            site.Site.Title,
+				AuditRunID:       site.AuditRunID,
+				IsHub:            site.Hub.IsHub,
+				InheritsVisitors: site.Hub.InheritsVisitors(),
+				RiskLevel:        site.Risk.RiskLevel,
+				HighestRiskScore: site.Risk.HighestRiskScore,
+				HighRiskLists:    site.Risk.HighRiskLists,
+				AnonymousLinks:   site.Risk.AnonymousLinks,
+			}
+		}
+		view.Hubs[i] = hubView
+	}
+	for i, site := range data.Skipped {
+		view.Skipped[i] = HubSkippedSiteView(*site)
+	}
+	return view
+}
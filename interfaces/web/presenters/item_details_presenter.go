@@ -0,0 +1,123 @@
+package presenters
+
+import (
+	"fmt"
+	"time"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+// ItemDetails represents the full metadata of an item for the expandable detail row.
+type ItemDetails struct {
+	ItemGUID           string
+	ListItemGUID       string
+	FileFolderUniqueID string
+	ItemID             int64
+	Name               string
+	IsFile             bool
+	IsFolder           bool
+	IsDocumentSet      bool
+	ContentTypeID      string
+	HasUnique          bool
+	Size               string // Empty when unknown or not a file
+	CreatedAt          string // Empty when not collected
+	ModifiedAt         string // Empty when not collected
+	ItemURL            string
+	ListTitle          string
+	ListURL            string
+
+	// Sensitivity label, empty when the item has none
+	LabelName        string
+	LabelID          string
+	LabelOwnerEmail  string
+	LabelSetDate     string
+	LabelMethod      string
+	LabelIRMProtects bool
+
+	Attachments []ItemAttachmentView
+
+	// Fields that could not be collected, so an empty value is unknown rather than absent
+	LabelUnreadable       bool
+	UniqueUnreadable      bool
+	AttachmentsUnreadable bool
+}
+
+// ItemAttachmentView is a file attached to a list item.
+type ItemAttachmentView struct {
+	FileName string
+	URL      string
+}
+
+// ToItemDetails converts item details data to its view model.
+func (p *PermissionPresenter) ToItemDetails(data *application.ItemDetailsData) ItemDetails {
+	item := data.Item
+	details := ItemDetails{
+		ItemGUID:           item.GUID,
+		ListItemGUID:       item.ListItemGUID,
+		FileFolderUniqueID: item.FileFolderUniqueID,
+		ItemID:             int64(item.ID),
+		Name:               item.Name,
+		IsFile:             item.IsFile,
+		IsFolder:           item.IsFolder,
+		IsDocumentSet:      item.IsDocumentSet(),
+		ContentTypeID:      item.ContentTypeID,
+		HasUnique:          item.HasUnique,
+		CreatedAt:          formatDetailTime(item.CreatedAt),
+		ModifiedAt:         formatDetailTime(item.ModifiedAt),
+		ItemURL:            item.URL,
+
+		LabelUnreadable:       !item.IsReadable(sharepoint.ItemFieldSensitivityLabel),
+		UniqueUnreadable:      !item.IsReadable(sharepoint.ItemFieldUniquePermissions),
+		AttachmentsUnreadable: !item.IsReadable(sharepoint.ItemFieldAttachments),
+	}
+	if item.IsFile && item.Size > 0 {
+		details.Size = formatByteSize(item.Size)
+	}
+	if data.List != nil {
+		details.ListTitle = data.List.Title
+		details.ListURL = data.List.URL
+	}
+
+	if label := data.SensitivityLabel; label != nil {
+		details.LabelName = label.DisplayName
+		details.LabelID = label.LabelID
+		details.LabelOwnerEmail = label.OwnerEmail
+		details.LabelSetDate = formatDetailTime(label.SetDate)
+		details.LabelMethod = label.AssignmentMethod
+		details.LabelIRMProtects = label.HasIRMProtection
+	}
+
+	for _, attachment := range data.Attachments {
+		details.Attachments = append(details.Attachments, ItemAttachmentView{FileName: attachment.FileName, URL: attachment.URL})
+	}
+
+	return details
+}
+
+// HasLabel reports whether the item has a sensitivity label.
+func (d ItemDetails) HasLabel() bool {
+	return d.LabelID != "" || d.LabelName != ""
+}
+
+// formatDetailTime formats an optional timestamp for display.
+func formatDetailTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04")
+}
+
+// formatByteSize formats a byte count using binary units.
+func formatByteSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
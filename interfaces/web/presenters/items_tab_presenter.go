@@ -0,0 +1,200 @@
+package presenters
+
+import (
+	"net/url"
+	"strconv"
+
+	"spaudit/application"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+// Items tab permission scopes.
+const (
+	ItemScopeUnique = "unique"
+	ItemScopeAll    = "all"
+)
+
+// ItemsTabPageSize is the number of items shown per items tab page.
+const ItemsTabPageSize = 50
+
+// ItemsTabQuery is the items tab state carried in the URL query string,
+// so a filtered page of the tab can be shared as a link.
+type ItemsTabQuery struct {
+	Scope     string // ItemScopeUnique or ItemScopeAll
+	Kind      string // Empty for all kinds, otherwise a contracts.ItemKind constant
+	Principal int64  // Only items this principal can access, 0 for anyone
+	Page      int    // 1-based
+}
+
+// ParseItemsTabQuery reads the items tab state from query parameters.
+// Unknown or missing values fall back to the defaults (unique items, all kinds, any principal, first page).
+func (p *PermissionPresenter) ParseItemsTabQuery(values url.Values) ItemsTabQuery {
+	query := ItemsTabQuery{Scope: ItemScopeUnique, Page: 1}
+
+	if values.Get("scope") == ItemScopeAll {
+		query.Scope = ItemScopeAll
+	}
+
+	switch kind := values.Get("kind"); kind {
+	case contracts.ItemKindFile, contracts.ItemKindFolder, contracts.ItemKindListItem:
+		query.Kind = kind
+	}
+
+	if principal, err := strconv.ParseInt(values.Get("principal"), 10, 64); err == nil && principal > 0 {
+		query.Principal = principal
+	}
+
+	if page, err := strconv.Atoi(values.Get("page")); err == nil && page > 1 {
+		query.Page = page
+	}
+
+	return query
+}
+
+// Filter converts the query to a repository item filter.
+func (q ItemsTabQuery) Filter() contracts.ItemFilter {
+	return contracts.ItemFilter{
+		UniqueOnly:   q.Scope != ItemScopeAll,
+		Kind:         q.Kind,
+		AccessibleBy: q.Principal,
+	}
+}
+
+// Encode returns the canonical query string, omitting defaults.
+// Returns an empty string when every value is a default.
+func (q ItemsTabQuery) Encode() string {
+	values := url.Values{}
+	if q.Scope == ItemScopeAll {
+		values.Set("scope", ItemScopeAll)
+	}
+	if q.Kind != "" {
+		values.Set("kind", q.Kind)
+	}
+	if q.Principal != 0 {
+		values.Set("principal", strconv.FormatInt(q.Principal, 10))
+	}
+	if q.Page > 1 {
+		values.Set("page", strconv.Itoa(q.Page))
+	}
+	return values.Encode()
+}
+
+// FilterEncode returns the canonical query string without the page, for exports.
+func (q ItemsTabQuery) FilterEncode() string {
+	q.Page = 1
+	return q.Encode()
+}
+
+// WithScope returns the query with a different scope, starting from the first page.
+func (q ItemsTabQuery) WithScope(scope string) ItemsTabQuery {
+	q.Scope = scope
+	q.Page = 1
+	return q
+}
+
+// WithKind returns the query with a different kind filter, starting from the first page.
+func (q ItemsTabQuery) WithKind(kind string) ItemsTabQuery {
+	q.Kind = kind
+	q.Page = 1
+	return q
+}
+
+// WithPrincipal returns the query restricted to items a principal can access, 0 for anyone,
+// starting from the first page.
+func (q ItemsTabQuery) WithPrincipal(principal int64) ItemsTabQuery {
+	q.Principal = principal
+	q.Page = 1
+	return q
+}
+
+// WithPage returns the query for a different page.
+func (q ItemsTabQuery) WithPage(page int) ItemsTabQuery {
+	q.Page = page
+	return q
+}
+
+// ItemsTabPage is the view model for one page of the items tab.
+type ItemsTabPage struct {
+	Items      []ItemSummary
+	Query      ItemsTabQuery
+	TotalCount int64
+	PageCount  int
+	FirstIndex int64 // 1-based position of the first item shown, 0 when empty
+	LastIndex  int64
+	Columns    ColumnLayout
+	Selection  BulkSelection // Rows selected for bulk actions
+
+	// Principals offered by the "accessible by" filter
+	Principals []ItemsTabPrincipalOption
+}
+
+// ItemsTabPrincipalOption is a principal the items tab can be filtered to.
+type ItemsTabPrincipalOption struct {
+	ID       int64
+	Name     string
+	Selected bool
+}
+
+// PrincipalName returns the display name of the principal the page is filtered to, if any.
+func (p ItemsTabPage) PrincipalName() string {
+	for _, option := range p.Principals {
+		if option.Selected {
+			return option.Name
+		}
+	}
+	return ""
+}
+
+// WithPrincipalOptions returns the page offering the principals for the "accessible by" filter,
+// marking the one the query is filtered to.
+func (p ItemsTabPage) WithPrincipalOptions(principals []*sharepoint.Principal) ItemsTabPage {
+	p.Principals = make([]ItemsTabPrincipalOption, 0, len(principals))
+	for _, principal := range principals {
+		p.Principals = append(p.Principals, ItemsTabPrincipalOption{
+			ID:       principal.ID,
+			Name:     principal.GetDisplayName(),
+			Selected: principal.ID == p.Query.Principal,
+		})
+	}
+	return p
+}
+
+// ToItemsTabPage converts a page of list items to the items tab view model.
+// The query page is replaced with the page actually served.
+func (p *PermissionPresenter) ToItemsTabPage(data *application.ListItemsPageData, query ItemsTabQuery) ItemsTabPage {
+	page := ItemsTabPage{
+		Items:   []ItemSummary{},
+		Query:   query,
+		Columns: DefaultColumnLayout(DataTableItems),
+	}
+	if data == nil {
+		return page
+	}
+
+	for _, item := range data.Items {
+		page.Items = append(page.Items, p.MapItemToViewModel(item))
+	}
+
+	page.Query.Page = data.Page
+	page.TotalCount = data.TotalCount
+	if data.PageSize > 0 {
+		page.PageCount = int((data.TotalCount + int64(data.PageSize) - 1) / int64(data.PageSize))
+	}
+	if len(page.Items) > 0 {
+		page.FirstIndex = int64((data.Page-1)*data.PageSize) + 1
+		page.LastIndex = page.FirstIndex + int64(len(page.Items)) - 1
+	}
+
+	return page
+}
+
+// HasPrevious reports whether there is a page before the current one.
+func (p ItemsTabPage) HasPrevious() bool {
+	return p.Query.Page > 1
+}
+
+// HasNext reports whether there is a page after the current one.
+func (p ItemsTabPage) HasNext() bool {
+	return p.Query.Page < p.PageCount
+}
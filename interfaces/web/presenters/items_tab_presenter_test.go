@@ -0,0 +1,66 @@
+package presenters
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/application"
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+func TestPermissionPresenter_ItemsTabQuery_RoundTripsThroughURL(t *testing.T) {
+	presenter := NewPermissionPresenter()
+
+	values, err := url.ParseQuery("scope=all&kind=folder&principal=12&page=3")
+	require.NoError(t, err)
+
+	query := presenter.ParseItemsTabQuery(values)
+	assert.Equal(t, ItemsTabQuery{Scope: ItemScopeAll, Kind: contracts.ItemKindFolder, Principal: 12, Page: 3}, query)
+	assert.Equal(t, contracts.ItemFilter{UniqueOnly: false, Kind: contracts.ItemKindFolder, AccessibleBy: 12}, query.Filter())
+
+	// Encoding and parsing again yields the same state
+	reparsed, err := url.ParseQuery(query.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, query, presenter.ParseItemsTabQuery(reparsed))
+
+	// Changing a filter goes back to the first page
+	assert.Equal(t, 1, query.WithKind(contracts.ItemKindFile).Page)
+	assert.Equal(t, 1, query.WithPrincipal(0).Page)
+	assert.Equal(t, "kind=folder&principal=12&scope=all", query.FilterEncode())
+}
+
+func TestPermissionPresenter_ItemsTabQuery_DefaultsAndInvalidValues(t *testing.T) {
+	presenter := NewPermissionPresenter()
+
+	values, err := url.ParseQuery("scope=bogus&kind=spreadsheet&principal=alice&page=-2")
+	require.NoError(t, err)
+
+	query := presenter.ParseItemsTabQuery(values)
+	assert.Equal(t, ItemsTabQuery{Scope: ItemScopeUnique, Page: 1}, query)
+	assert.True(t, query.Filter().UniqueOnly)
+	assert.Empty(t, query.Encode(), "defaults are omitted from shareable URLs")
+}
+
+func TestPermissionPresenter_ToItemsTabPage_UsesServedPage(t *testing.T) {
+	presenter := NewPermissionPresenter()
+
+	data := &application.ListItemsPageData{
+		Items:      []*sharepoint.Item{{GUID: "a", ID: 101}, {GUID: "b", ID: 102}},
+		Page:       3, // Clamped by the service
+		PageSize:   50,
+		TotalCount: 102,
+	}
+
+	page := presenter.ToItemsTabPage(data, ItemsTabQuery{Scope: ItemScopeAll, Page: 9})
+
+	assert.Equal(t, 3, page.Query.Page)
+	assert.Equal(t, 3, page.PageCount)
+	assert.Equal(t, int64(101), page.FirstIndex)
+	assert.Equal(t, int64(102), page.LastIndex)
+	assert.True(t, page.HasPrevious())
+	assert.False(t, page.HasNext())
+}
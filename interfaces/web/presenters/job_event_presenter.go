@@ -0,0 +1,77 @@
+package presenters
+
+import (
+	"time"
+
+	"spaudit/domain/events"
+)
+
+// JobEventView is a job event replayed from the event log.
+type JobEventView struct {
+	Sequence   int64  `json:"sequence"`
+	Type       string `json:"type"`
+	JobID      string `json:"job_id"`
+	JobType    string `json:"job_type"`
+	JobStatus  string `json:"job_status"`
+	SiteURL    string `json:"site_url,omitempty"`
+	AuditRunID int64  `json:"audit_run_id,omitempty"`
+	Error      string `json:"error,omitempty"`
+	OccurredAt string `json:"occurred_at"`
+}
+
+// JobEventPageView is a chunk of the event log; NextAfter continues the replay.
+type JobEventPageView struct {
+	Events    []JobEventView `json:"events"`
+	NextAfter int64          `json:"next_after"`
+	HasMore   bool           `json:"has_more"`
+}
+
+// FormatJobEvent converts a recorded job event for the API and the event stream.
+func (p *JobPresenter) FormatJobEvent(event *events.RecordedJobEvent) JobEventView {
+	return JobEventView{
+		Sequence:   event.Sequence,
+		Type:       string(event.Type),
+		JobID:      event.JobID,
+		JobType:    string(event.JobType),
+		JobStatus:  string(event.JobStatus),
+		SiteURL:    event.SiteURL,
+		AuditRunID: event.AuditRunID,
+		Error:      event.Error,
+		OccurredAt: event.OccurredAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// FormatJobEventPage converts a chunk of the event log for the API, preserving its order.
+func (p *JobPresenter) FormatJobEventPage(recorded []*events.RecordedJobEvent, nextAfter int64, hasMore bool) JobEventPageView {
+	views := make([]JobEventView, len(recorded))
+	for i, event := range recorded {
+		views[i] = p.FormatJobEvent(event)
+	}
+	return JobEventPageView{Events: views, NextAfter: nextAfter, HasMore: hasMore}
+}
+
+// DeadLetterView is an event a durable handler gave up on.
+type DeadLetterView struct {
+	ID        int64  `json:"id"`
+	Handler   string `json:"handler"`
+	Sequence  int64  `json:"sequence"`
+	Attempts  int    `json:"attempts"`
+	LastError string `json:"last_error"`
+	FailedAt  string `json:"failed_at"`
+}
+
+// ToDeadLetterViews converts dead letters for the API and the dashboard, preserving their order.
+func (p *JobPresenter) ToDeadLetterViews(deadLetters []*events.DeadLetter) []DeadLetterView {
+	views := make([]DeadLetterView, len(deadLetters))
+	for i, deadLetter := range deadLetters {
+		views[i] = DeadLetterView{
+			ID:        deadLetter.ID,
+			Handler:   deadLetter.Handler,
+			Sequence:  deadLetter.Sequence,
+			Attempts:  deadLetter.Attempts,
+			LastError: deadLetter.LastError,
+			FailedAt:  deadLetter.FailedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return views
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"spaudit/domain/jobs"
+	"spaudit/logging"
 )
 
 // Job-related view data structures
@@ -199,7 +200,7 @@ func (p *JobPresenter) FormatJobListHTML(jobs []*jobs.Job, isPartialUpdate bool)
 func (p *JobPresenter) formatJobItemHTML(job *jobs.Job) string {
 	statusClass, statusIcon := p.getJobStatusDisplay(job.Status)
 	jobTypeDisplay := p.getJobTypeDisplay(job.Type)
-	cancelButton := p.getCancelButtonHTML(job)
+	cancelButton := p.getCancelButtonHTML(job) + p.getResumeButtonHTML(job) + p.getLogButtonHTML(job)
 	statusDisplay := p.getJobStatusText(job.Status)
 
 	// Build contextual information and progress details from rich state
@@ -342,6 +343,10 @@ func (p *JobPresenter) getJobTypeDisplay(jobType jobs.JobType) string {
 	switch jobType {
 	case jobs.JobTypeSiteAudit:
 		return "Site Audit"
+	case jobs.JobTypeDatabaseMaintenance:
+		return "Database Maintenance"
+	case jobs.JobTypeTenantAudit:
+		return "Tenant Audit"
 	default:
 		return string(jobType)
 	}
@@ -365,6 +370,36 @@ func (p *JobPresenter) getCancelButtonHTML(job *jobs.Job) string {
 	</div>`, job.ID, job.ID, job.ID)
 }
 
+// getResumeButtonHTML returns HTMX-enabled resume button HTML for site audits that failed or were cancelled.
+func (p *JobPresenter) getResumeButtonHTML(job *jobs.Job) string {
+	if job.Type != jobs.JobTypeSiteAudit || (job.Status != jobs.JobStatusFailed && job.Status != jobs.JobStatusCancelled) {
+		return ""
+	}
+
+	return fmt.Sprintf(`<div class="mt-2">
+		<button class="text-xs px-2 py-1 bg-blue-100 hover:bg-blue-200 text-blue-700 rounded border border-blue-300 transition-colors"
+			hx-post="/jobs/%s/resume"
+			hx-target="#resume-status-%s"
+			hx-swap="innerHTML"
+			hx-on::after-request="if (event.detail.xhr.status === 200) { htmx.trigger('#jobs-list', 'sse:jobs-updated'); }">
+			▶️ Resume
+		</button>
+		<div id="resume-status-%s" class="mt-1"></div>
+	</div>`, job.ID, job.ID, job.ID)
+}
+
+// getLogButtonHTML returns a button opening the job's live log panel.
+func (p *JobPresenter) getLogButtonHTML(job *jobs.Job) string {
+	return fmt.Sprintf(`<div class="mt-2">
+		<button class="text-xs px-2 py-1 bg-slate-100 hover:bg-slate-200 text-slate-700 rounded border border-slate-300 transition-colors"
+			hx-get="/jobs/%s/logs"
+			hx-target="#audit-log-panel"
+			hx-swap="innerHTML">
+			📜 Log
+		</button>
+	</div>`, job.ID)
+}
+
 // wrapWithSSEContainer wraps content with SSE container for HTMX real-time updates.
 func (p *JobPresenter) wrapWithSSEContainer(content string) string {
 	return fmt.Sprintf(`<div id="job-list" 
@@ -385,6 +420,16 @@ func (p *JobPresenter) FormatCancelErrorMessage(err error) string {
 	return fmt.Sprintf(`<div class="text-red-600 text-sm">❌ Failed to cancel job: %s</div>`, err.Error())
 }
 
+// FormatResumeSuccessMessage formats success message for job resumption.
+func (p *JobPresenter) FormatResumeSuccessMessage() string {
+	return `<div class="text-green-600 text-sm">✅ Job resumed from its last completed list</div>`
+}
+
+// FormatResumeErrorMessage formats error message for job resumption.
+func (p *JobPresenter) FormatResumeErrorMessage(err error) string {
+	return fmt.Sprintf(`<div class="text-red-600 text-sm">❌ Failed to resume job: %s</div>`, err.Error())
+}
+
 // FormatJobNotActiveMessage formats message for jobs that can't be cancelled.
 func (p *JobPresenter) FormatJobNotActiveMessage() string {
 	return `<div class="text-orange-600 text-sm">⚠️ Job is no longer active and cannot be cancelled</div>`
@@ -404,3 +449,35 @@ func (p *JobPresenter) FormatAuditQueuedErrorMessage(err error) string {
 func (p *JobPresenter) FormatAuditAlreadyRunningMessage() string {
 	return `<div class="text-orange-600 text-sm">⚠️ An audit is already running or queued for this site. Please wait for it to complete.</div>`
 }
+
+// JobLogLineView is a job log line for the live log panel
+type JobLogLineView struct {
+	Time       string
+	Level      string
+	LevelClass string
+	Component  string
+	Message    string
+	Attrs      string
+}
+
+// FormatJobLogLine converts a job log line for display.
+func (p *JobPresenter) FormatJobLogLine(line logging.LogLine) JobLogLineView {
+	levelClass := "text-slate-500"
+	switch line.Level {
+	case "WARN":
+		levelClass = "text-amber-600"
+	case "ERROR":
+		levelClass = "text-red-600"
+	case "DEBUG":
+		levelClass = "text-slate-400"
+	}
+
+	return JobLogLineView{
+		Time:       line.Time.Format("15:04:05"),
+		Level:      line.Level,
+		LevelClass: levelClass,
+		Component:  line.Component,
+		Message:    line.Message,
+		Attrs:      line.Attrs,
+	}
+}
@@ -0,0 +1,88 @@
+package presenters
+
+import (
+	"strconv"
+
+	"spaudit/domain/sharepoint"
+)
+
+// LinkCreatorsView is who created the active sharing links an audit run found in a site.
+type LinkCreatorsView struct {
+	SiteID       int64             `json:"site_id"`
+	AuditRunID   int64             `json:"audit_run_id"`
+	Links        int64             `json:"links"`
+	ExposedLinks int64             `json:"exposed_links"`
+	Highlighted  int               `json:"highlighted"`
+	Creators     []LinkCreatorView `json:"creators"`
+}
+
+// LinkCreatorView is a principal with the sharing links they created. Highlighted creators exposed
+// the most links to anyone or to external users, for targeted training or policy enforcement.
+type LinkCreatorView struct {
+	PrincipalID    int64  `json:"principal_id"`
+	Title          string `json:"title"`
+	LoginName      string `json:"login_name"`
+	Email          string `json:"email,omitempty"`
+	IsGuest        bool   `json:"is_guest"`
+	Links          int64  `json:"links"`
+	AnonymousLinks int64  `json:"anonymous_links"`
+	ExternalLinks  int64  `json:"external_links"`
+	ExposedLinks   int64  `json:"exposed_links"` // Anonymous or external, each link counted once
+	EditLinks      int64  `json:"edit_links"`
+	Highlighted    bool   `json:"highlighted"`
+}
+
+// ToLinkCreatorsView converts the link creators of an audit run, preserving their order and highlighting
+// the first top creators who exposed any link.
+func (p *ListPresenter) ToLinkCreatorsView(siteID, auditRunID int64, creators []*sharepoint.LinkCreator, top int) LinkCreatorsView {
+	view := LinkCreatorsView{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		Creators:   make([]LinkCreatorView, len(creators)),
+	}
+	for i, creator := range creators {
+		creatorView := LinkCreatorView{
+			PrincipalID:    creator.Principal.ID,
+			Title:          creator.Principal.GetDisplayName(),
+			LoginName:      creator.Principal.LoginName,
+			Email:          creator.Principal.Email,
+			IsGuest:        creator.Principal.IsGuest(),
+			Links:          creator.Links,
+			AnonymousLinks: creator.AnonymousLinks,
+			ExternalLinks:  creator.ExternalLinks,
+			ExposedLinks:   creator.ExposedLinks,
+			EditLinks:      creator.EditLinks,
+			Highlighted:    i < top && creator.ExposedLinks > 0,
+		}
+		if creatorView.Highlighted {
+			view.Highlighted++
+		}
+		view.Links += creator.Links
+		view.ExposedLinks += creator.ExposedLinks
+		view.Creators[i] = creatorView
+	}
+	return view
+}
+
+// LinkCreatorsToCSV lays out the link creators of an audit run one creator per row.
+func (p *ListPresenter) LinkCreatorsToCSV(view LinkCreatorsView) CSVTable {
+	table := CSVTable{
+		Header: []string{"Creator", "Login Name", "Email", "Guest", "Links", "Anonymous Links", "External Links", "Exposed Links", "Edit Links", "Highlighted"},
+		Rows:   make([][]string, len(view.Creators)),
+	}
+	for i, creator := range view.Creators {
+		table.Rows[i] = []string{
+			creator.Title,
+			creator.LoginName,
+			creator.Email,
+			strconv.FormatBool(creator.IsGuest),
+			strconv.FormatInt(creator.Links, 10),
+			strconv.FormatInt(creator.AnonymousLinks, 10),
+			strconv.FormatInt(creator.ExternalLinks, 10),
+			strconv.FormatInt(creator.ExposedLinks, 10),
+			strconv.FormatInt(creator.EditLinks, 10),
+			strconv.FormatBool(creator.Highlighted),
+		}
+	}
+	return table
+}
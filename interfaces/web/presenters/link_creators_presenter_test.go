@@ -0,0 +1,40 @@
+package presenters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/sharepoint"
+)
+
+func TestListPresenter_ToLinkCreatorsView(t *testing.T) {
+	presenter := NewListPresenter()
+	creators := []*sharepoint.LinkCreator{
+		{Principal: &sharepoint.Principal{ID: 10, Title: "Ada", LoginName: "i:0#.f|membership|ada@contoso.com"}, Links: 4, AnonymousLinks: 3, ExposedLinks: 3},
+		{Principal: &sharepoint.Principal{ID: 20, LoginName: "i:0#.f|membership|guest_fabrikam.com#ext#@contoso.onmicrosoft.com"}, Links: 2, ExternalLinks: 1, ExposedLinks: 1, EditLinks: 2},
+		{Principal: &sharepoint.Principal{ID: 11, Title: "Grace"}, Links: 9},
+	}
+
+	view := presenter.ToLinkCreatorsView(1, 7, creators, 2)
+	assert.Equal(t, int64(15), view.Links)
+	assert.Equal(t, int64(4), view.ExposedLinks)
+	assert.Equal(t, 2, view.Highlighted)
+	require.Len(t, view.Creators, 3)
+	assert.True(t, view.Creators[0].Highlighted)
+	assert.False(t, view.Creators[0].IsGuest)
+	assert.True(t, view.Creators[1].Highlighted)
+	assert.True(t, view.Creators[1].IsGuest)
+	assert.Equal(t, creators[1].Principal.LoginName, view.Creators[1].Title, "creators without a title are named by login")
+	assert.False(t, view.Creators[2].Highlighted, "creators exposing no links are never highlighted")
+
+	view = presenter.ToLinkCreatorsView(1, 7, creators, 1)
+	assert.Equal(t, 1, view.Highlighted)
+	assert.False(t, view.Creators[1].Highlighted)
+
+	table := presenter.LinkCreatorsToCSV(view)
+	require.Len(t, table.Rows, 3)
+	assert.Len(t, table.Rows[0], len(table.Header))
+	assert.Equal(t, []string{"Ada", "i:0#.f|membership|ada@contoso.com", "", "false", "4", "3", "0", "3", "0", "true"}, table.Rows[0])
+}
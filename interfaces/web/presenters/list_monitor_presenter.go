@@ -0,0 +1,53 @@
+package presenters
+
+import (
+	"time"
+
+	"spaudit/domain/audit"
+)
+
+// ListMonitorView is a list checked for changes on a short interval and audited again when it changed.
+type ListMonitorView struct {
+	ID              int64  `json:"id"`
+	SiteURL         string `json:"site_url"`
+	ListID          string `json:"list_id"`
+	ListTitle       string `json:"list_title"`
+	ListRootPath    string `json:"list_root_path"`
+	IntervalSeconds int64  `json:"interval_seconds"`
+	CreatedAt       string `json:"created_at"`
+	NextCheckAt     string `json:"next_check_at"`
+	LastCheckedAt   string `json:"last_checked_at,omitempty"`
+	LastAuditJobID  string `json:"last_audit_job_id,omitempty"` // Latest audit of the list
+	LastAuditAt     string `json:"last_audit_at,omitempty"`
+}
+
+// ToListMonitorView converts a list monitor for the API.
+func (p *ListPresenter) ToListMonitorView(monitor *audit.ListMonitor) ListMonitorView {
+	view := ListMonitorView{
+		ID:              monitor.ID,
+		SiteURL:         monitor.SiteURL,
+		ListID:          monitor.ListID,
+		ListTitle:       monitor.ListTitle,
+		ListRootPath:    monitor.ListRootPath,
+		IntervalSeconds: int64(monitor.Interval / time.Second),
+		CreatedAt:       monitor.CreatedAt.UTC().Format(time.RFC3339),
+		NextCheckAt:     monitor.NextCheckAt.UTC().Format(time.RFC3339),
+		LastAuditJobID:  monitor.LastAuditJobID,
+	}
+	if monitor.LastCheckedAt != nil {
+		view.LastCheckedAt = monitor.LastCheckedAt.UTC().Format(time.RFC3339)
+	}
+	if monitor.LastAuditAt != nil {
+		view.LastAuditAt = monitor.LastAuditAt.UTC().Format(time.RFC3339)
+	}
+	return view
+}
+
+// ToListMonitorViews converts list monitors for the API, preserving their order.
+func (p *ListPresenter) ToListMonitorViews(monitors []*audit.ListMonitor) []ListMonitorView {
+	views := make([]ListMonitorView, len(monitors))
+	for i, monitor := range monitors {
+		views[i] = p.ToListMonitorView(monitor)
+	}
+	return views
+}
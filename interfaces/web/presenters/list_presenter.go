@@ -3,10 +3,13 @@ package presenters
 
 import (
 	"fmt"
+	"net/url"
+	"sort"
 	"strings"
 	"time"
 
 	"spaudit/application"
+	"spaudit/domain/audit"
 	"spaudit/domain/sharepoint"
 )
 
@@ -15,6 +18,42 @@ type AuditRunOption struct {
 	ID        int64     `json:"id"`
 	StartedAt time.Time `json:"started_at"`
 	Status    string    `json:"status"`
+	OnHold    bool      `json:"on_hold"`
+	ScopePath string    `json:"scope_path,omitempty"`
+	Label     string    `json:"label,omitempty"`
+}
+
+// AuditRunTimeFormat is the timestamp layout used for audit runs in API responses.
+const AuditRunTimeFormat = "2006-01-02 15:04:05"
+
+// AuditRunView represents an audit run for API responses.
+type AuditRunView struct {
+	ID          int64  `json:"id"`
+	SiteID      int64  `json:"site_id"`
+	JobID       string `json:"job_id"`
+	StartedAt   string `json:"started_at"`
+	CompletedAt string `json:"completed_at,omitempty"`
+	Status      string `json:"status"`
+	Trigger     string `json:"trigger,omitempty"`
+	OnHold      bool   `json:"on_hold"`
+	HeldAt      string `json:"held_at,omitempty"`
+	HoldReason  string `json:"hold_reason,omitempty"`
+	ScopePath   string `json:"scope_path,omitempty"`
+	Label       string `json:"label,omitempty"`
+
+	// Reports generated from the run that have not expired yet
+	Artifacts []RunArtifactView `json:"artifacts,omitempty"`
+
+	// SharePoint API response fields the run did not recognise or did not receive; set on single run responses
+	SchemaDrift []SchemaDriftView `json:"schema_drift,omitempty"`
+}
+
+// SchemaDriftView is a SharePoint API response field an audit run did not recognise, or expected and did not receive.
+type SchemaDriftView struct {
+	Source      string `json:"source"`
+	Field       string `json:"field"`
+	Kind        string `json:"kind"` // unknown | missing
+	Occurrences int64  `json:"occurrences"`
 }
 
 // SiteListsVM is the view model for the site lists page.
@@ -25,9 +64,22 @@ type SiteListsVM struct {
 	ListsWithUnique int
 	TotalItems      int
 	AuditRunID      int64
-	AuditRuns       []AuditRunOption
+	RunContext      RunContext
+
+	// Unique-permission density flagging and table ordering
+	DenseLists             int
+	UniqueDensityThreshold float64
+	SortBy                 string
+	Search                 string
 }
 
+// List table sort keys.
+const (
+	ListSortTitle   = "title"
+	ListSortItems   = "items"
+	ListSortDensity = "density"
+)
+
 // ListPresenter transforms site and list data for templates.
 type ListPresenter struct{}
 
@@ -47,18 +99,21 @@ func (p *ListPresenter) ToSiteListsViewModel(data *application.SiteWithListsData
 			ListsWithUnique: 0,
 			TotalItems:      0,
 			AuditRunID:      0,
-			AuditRuns:       []AuditRunOption{},
+			SortBy:          ListSortTitle,
 		}
 	}
 
 	return &SiteListsVM{
-		Site:            p.toSiteWithMetadata(data),
-		Lists:           p.toListSummaries(data.Lists),
-		TotalLists:      data.TotalLists,
-		ListsWithUnique: data.ListsWithUnique,
-		TotalItems:      int(data.TotalItems),
-		AuditRunID:      data.AuditRunID,
-		AuditRuns:       []AuditRunOption{}, // Will be populated by handler
+		Site:                   p.toSiteWithMetadata(data),
+		Lists:                  p.toListSummaries(data.Lists, data.UniqueDensityThreshold),
+		TotalLists:             data.TotalLists,
+		ListsWithUnique:        data.ListsWithUnique,
+		TotalItems:             int(data.TotalItems),
+		AuditRunID:             data.AuditRunID,
+		RunContext:             RunContext{}, // Will be populated by handler
+		DenseLists:             data.DenseLists,
+		UniqueDensityThreshold: data.UniqueDensityThreshold,
+		SortBy:                 ListSortTitle,
 	}
 }
 
@@ -92,7 +147,7 @@ func (p *ListPresenter) toSiteWithMetadata(data *application.SiteWithListsData)
 }
 
 // toListSummaries converts domain lists to view model summaries.
-func (p *ListPresenter) toListSummaries(domainLists []*sharepoint.List) []ListSummary {
+func (p *ListPresenter) toListSummaries(domainLists []*sharepoint.List, densityThreshold float64) []ListSummary {
 	summaries := make([]ListSummary, len(domainLists))
 
 	for i, list := range domainLists {
@@ -113,7 +168,18 @@ func (p *ListPresenter) toListSummaries(domainLists []*sharepoint.List) []ListSu
 			WebTitle:     "", // TODO: Add WebTitle to sharepoint.List or fetch separately
 			LastModified: p.formatAuditRunID(list.AuditRunID),
 			AuditRunID:   auditRunID,
+
+			UniqueItemCount:         int64(list.UniqueItemCount),
+			UniqueDensity:           list.UniqueDensity,
+			UniqueDensityText:       fmt.Sprintf("%.1f%%", list.UniqueDensity*100),
+			ExceedsDensityThreshold: densityThreshold > 0 && list.ExceedsUniqueDensity(densityThreshold),
+
+			UniqueAssignmentCount:  int64(list.UniqueAssignmentCount),
+			ExternalPrincipalCount: int64(list.ExternalPrincipalCount),
+			SharingLinkCount:       int64(list.SharingLinkCount),
 		}
+		summaries[i] = withSampling(summaries[i], list)
+		summaries[i] = withItemSettings(summaries[i], list)
 	}
 
 	return summaries
@@ -121,7 +187,68 @@ func (p *ListPresenter) toListSummaries(domainLists []*sharepoint.List) []ListSu
 
 // ToListSummaries converts domain lists to view models.
 func (p *ListPresenter) ToListSummaries(domainLists []*sharepoint.List) []ListSummary {
-	return p.toListSummaries(domainLists)
+	return p.toListSummaries(domainLists, sharepoint.DefaultUniqueDensityThreshold)
+}
+
+// ToListSummariesWithThreshold converts domain lists to view models, flagging lists above the density threshold.
+func (p *ListPresenter) ToListSummariesWithThreshold(domainLists []*sharepoint.List, densityThreshold float64) []ListSummary {
+	return p.toListSummaries(domainLists, densityThreshold)
+}
+
+// NormalizeListSort returns a supported sort key, defaulting to title.
+func (p *ListPresenter) NormalizeListSort(sortBy string) string {
+	switch strings.ToLower(strings.TrimSpace(sortBy)) {
+	case ListSortItems:
+		return ListSortItems
+	case ListSortDensity:
+		return ListSortDensity
+	default:
+		return ListSortTitle
+	}
+}
+
+// ListsPageURL builds the shareable lists page URL for a search and sort, omitting defaults.
+func (p *ListPresenter) ListsPageURL(siteID, auditRunID int64, searchQuery, sortBy string) string {
+	values := url.Values{}
+	if search := strings.TrimSpace(searchQuery); search != "" {
+		values.Set("search", search)
+	}
+	if sortBy = p.NormalizeListSort(sortBy); sortBy != ListSortTitle {
+		values.Set("sort", sortBy)
+	}
+
+	pageURL := fmt.Sprintf("/sites/%d/audit-runs/%d/lists", siteID, auditRunID)
+	if encoded := values.Encode(); encoded != "" {
+		pageURL += "?" + encoded
+	}
+	return pageURL
+}
+
+// SortLists orders lists by the given key. Items and density sort descending, title ascending.
+// The input slice is not modified.
+func (p *ListPresenter) SortLists(lists []ListSummary, sortBy string) []ListSummary {
+	sorted := make([]ListSummary, len(lists))
+	copy(sorted, lists)
+
+	switch p.NormalizeListSort(sortBy) {
+	case ListSortItems:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].ItemCount > sorted[j].ItemCount
+		})
+	case ListSortDensity:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			if sorted[i].UniqueDensity != sorted[j].UniqueDensity {
+				return sorted[i].UniqueDensity > sorted[j].UniqueDensity
+			}
+			return sorted[i].UniqueItemCount > sorted[j].UniqueItemCount
+		})
+	default:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return strings.ToLower(sorted[i].Title) < strings.ToLower(sorted[j].Title)
+		})
+	}
+
+	return sorted
 }
 
 // FilterListsForSearch filters lists by search query across title, URL, and web title.
@@ -171,3 +298,46 @@ func (p *ListPresenter) formatAuditRunID(auditRunID *int64) string {
 	}
 	return fmt.Sprintf("Run #%d", *auditRunID)
 }
+
+// ToAuditRunView converts a domain audit run to its API view.
+func (p *ListPresenter) ToAuditRunView(run *audit.AuditRun) AuditRunView {
+	view := AuditRunView{
+		ID:        run.ID,
+		SiteID:    run.SiteID,
+		JobID:     run.JobID,
+		StartedAt: run.StartedAt.Format(AuditRunTimeFormat),
+		Status:    run.GetStatus(),
+		Trigger:   run.Trigger,
+		OnHold:    run.OnHold,
+		ScopePath: run.ScopePath,
+		Label:     run.Label,
+	}
+	if run.CompletedAt != nil {
+		view.CompletedAt = run.CompletedAt.Format(AuditRunTimeFormat)
+	}
+	if run.OnHold {
+		view.HoldReason = run.HoldReason
+		if run.HeldAt != nil {
+			view.HeldAt = run.HeldAt.Format(AuditRunTimeFormat)
+		}
+	}
+	return view
+}
+
+// ToSchemaDriftViews converts recorded schema drift to API views, preserving order.
+func (p *ListPresenter) ToSchemaDriftViews(drift []audit.SchemaDrift) []SchemaDriftView {
+	views := make([]SchemaDriftView, len(drift))
+	for i, d := range drift {
+		views[i] = SchemaDriftView{Source: d.Source, Field: d.Field, Kind: string(d.Kind), Occurrences: d.Occurrences}
+	}
+	return views
+}
+
+// ToAuditRunViews converts domain audit runs to API views, preserving order.
+func (p *ListPresenter) ToAuditRunViews(runs []*audit.AuditRun) []AuditRunView {
+	views := make([]AuditRunView, len(runs))
+	for i, run := range runs {
+		views[i] = p.ToAuditRunView(run)
+	}
+	return views
+}
@@ -3,11 +3,13 @@ package presenters
 import (
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"spaudit/application"
+	"spaudit/domain/audit"
 	"spaudit/domain/sharepoint"
 	"spaudit/test/helpers"
 )
@@ -596,3 +598,241 @@ func TestListPresenter_EdgeCases_MultipleDataSets(t *testing.T) {
 	assert.True(t, result[0].HasUnique)  // Even index (0)
 	assert.True(t, result[14].HasUnique) // Even index (14)
 }
+
+func TestListPresenter_UniqueDensityFlagging(t *testing.T) {
+	presenter := NewListPresenter()
+
+	domainLists := []*sharepoint.List{
+		{ID: "sparse", Title: "Sparse", ItemCount: 100, UniqueItemCount: 5, UniqueDensity: 0.05},
+		{ID: "dense", Title: "Dense", ItemCount: 10, UniqueItemCount: 6, UniqueDensity: 0.6},
+	}
+
+	result := presenter.ToListSummariesWithThreshold(domainLists, 0.25)
+
+	require.Len(t, result, 2)
+	assert.False(t, result[0].ExceedsDensityThreshold)
+	assert.True(t, result[1].ExceedsDensityThreshold)
+	assert.Equal(t, "60.0%", result[1].UniqueDensityText)
+	assert.Equal(t, int64(6), result[1].UniqueItemCount)
+}
+
+func TestListPresenter_AccessCounts(t *testing.T) {
+	presenter := NewListPresenter()
+
+	result := presenter.ToListSummaries([]*sharepoint.List{
+		{ID: "shared", Title: "Shared", ItemCount: 20, UniqueAssignmentCount: 12, ExternalPrincipalCount: 3, SharingLinkCount: 5},
+	})
+
+	require.Len(t, result, 1)
+	assert.Equal(t, int64(12), result[0].UniqueAssignmentCount)
+	assert.Equal(t, int64(3), result[0].ExternalPrincipalCount)
+	assert.Equal(t, int64(5), result[0].SharingLinkCount)
+}
+
+func TestListPresenter_ItemSettings(t *testing.T) {
+	presenter := NewListPresenter()
+
+	result := presenter.ToListSummaries([]*sharepoint.List{
+		{ID: "requests", Title: "Requests", BaseTemplate: 100, ItemSettings: &sharepoint.ListItemSettings{
+			ReadSecurity: sharepoint.ListReadOwnItems, WriteSecurity: sharepoint.ListWriteOwnItems, RatingExperience: sharepoint.ListRatingLikes,
+		}},
+		{ID: "docs", Title: "Documents", BaseTemplate: 101, ItemSettings: &sharepoint.ListItemSettings{
+			ReadSecurity: sharepoint.ListReadAllItems, WriteSecurity: sharepoint.ListWriteAllItems,
+		}},
+		{ID: "legacy", Title: "Legacy", BaseTemplate: 100},
+	})
+
+	require.Len(t, result, 3)
+	assert.Equal(t, "Read own items · Edit own items", result[0].ItemAccessText)
+	assert.Equal(t, []string{"Comments", "Likes"}, result[0].ListFeatures)
+	assert.Empty(t, result[1].ItemAccessText)
+	assert.Empty(t, result[1].ListFeatures, "library comments are not item comments")
+	assert.Empty(t, result[2].ItemAccessText)
+	assert.Empty(t, result[2].ListFeatures)
+}
+
+func TestListPresenter_SampledListsAreLabeled(t *testing.T) {
+	presenter := NewListPresenter()
+
+	result := presenter.ToListSummaries([]*sharepoint.List{
+		{ID: "huge", Title: "Huge", ItemCount: 100000, UniqueItemCount: 25000, UniqueDensity: 0.25, SampledItemCount: 383},
+		{ID: "small", Title: "Small", ItemCount: 10, UniqueItemCount: 2, UniqueDensity: 0.2},
+	})
+
+	require.Len(t, result, 2)
+	assert.True(t, result[0].Sampled)
+	assert.Equal(t, int64(383), result[0].SampledItemCount)
+	assert.Equal(t, "≈25.0% ±4.3%", result[0].UniqueDensityText)
+	assert.Equal(t, "≈25000 unique items (estimated)", result[0].UniqueItemsText())
+	assert.Equal(t, "Estimated from a sample of 383 of 100000 items", result[0].SamplingText)
+
+	assert.False(t, result[1].Sampled)
+	assert.Equal(t, "20.0%", result[1].UniqueDensityText)
+	assert.Equal(t, "2 unique items", result[1].UniqueItemsText())
+
+	table := presenter.ListsToCSV(result)
+	assert.Equal(t, "Sampled Items", table.Header[9])
+	assert.Equal(t, "383", table.Rows[0][9])
+	assert.Empty(t, table.Rows[1][9])
+}
+
+func TestListPresenter_SortLists(t *testing.T) {
+	presenter := NewListPresenter()
+
+	lists := []ListSummary{
+		{ListID: "b", Title: "Bravo", ItemCount: 50, UniqueDensity: 0.1},
+		{ListID: "a", Title: "alpha", ItemCount: 10, UniqueDensity: 0.9},
+		{ListID: "c", Title: "Charlie", ItemCount: 200, UniqueDensity: 0.4},
+	}
+
+	tests := []struct {
+		name     string
+		sortBy   string
+		expected []string
+	}{
+		{"title_default", "", []string{"a", "b", "c"}},
+		{"unknown_key_falls_back_to_title", "bogus", []string{"a", "b", "c"}},
+		{"items_descending", "items", []string{"c", "b", "a"}},
+		{"density_descending", "density", []string{"a", "c", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := presenter.SortLists(lists, tt.sortBy)
+
+			ids := make([]string, len(result))
+			for i, l := range result {
+				ids[i] = l.ListID
+			}
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	// Original slice order is preserved
+	assert.Equal(t, "b", lists[0].ListID)
+}
+
+func TestListPresenter_ListsToCSV_PreservesViewOrder(t *testing.T) {
+	presenter := NewListPresenter()
+
+	lists := presenter.SortLists([]ListSummary{
+		{ListID: "a", Title: "Alpha", ItemCount: 10, UniqueItemCount: 1, UniqueDensity: 0.1},
+		{ListID: "b", Title: "Bravo", ItemCount: 40, UniqueItemCount: 30, UniqueDensity: 0.75, ExceedsDensityThreshold: true},
+	}, ListSortDensity)
+
+	table := presenter.ListsToCSV(lists)
+
+	require.Len(t, table.Rows, 2)
+	assert.Equal(t, len(table.Header), len(table.Rows[0]))
+	assert.Equal(t, "b", table.Rows[0][0])
+	assert.Equal(t, "40", table.Rows[0][4])
+	assert.Equal(t, "0.7500", table.Rows[0][7])
+	assert.Equal(t, "true", table.Rows[0][8])
+	assert.Equal(t, "a", table.Rows[1][0])
+}
+
+func TestListPresenter_ToAuditRunView_Hold(t *testing.T) {
+	presenter := NewListPresenter()
+	heldAt := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+
+	held := presenter.ToAuditRunView(&audit.AuditRun{ID: 3, StartedAt: heldAt, OnHold: true, HeldAt: &heldAt, HoldReason: "Case 4711"})
+	assert.True(t, held.OnHold)
+	assert.Equal(t, "2025-02-03 10:00:00", held.HeldAt)
+	assert.Equal(t, "Case 4711", held.HoldReason)
+
+	// A stale reason is not reported once the hold is released
+	released := presenter.ToAuditRunView(&audit.AuditRun{ID: 3, StartedAt: heldAt, HoldReason: "Case 4711"})
+	assert.False(t, released.OnHold)
+	assert.Empty(t, released.HeldAt)
+	assert.Empty(t, released.HoldReason)
+
+	rc := presenter.ToRunContext(nil, 1, 3, 0, []*audit.AuditRun{
+		{ID: 3, StartedAt: heldAt, OnHold: true},
+		{ID: 2, StartedAt: heldAt},
+	})
+	assert.True(t, rc.OnHold)
+	assert.True(t, rc.Runs[0].OnHold)
+	assert.False(t, rc.Runs[1].OnHold)
+}
+
+func TestListPresenter_FolderScopedRun(t *testing.T) {
+	presenter := NewListPresenter()
+	startedAt := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+	scoped := &audit.AuditRun{ID: 3, StartedAt: startedAt, Trigger: "investigation", ScopePath: "/sites/a/Shared Documents/Payroll"}
+
+	view := presenter.ToAuditRunView(scoped)
+	assert.Equal(t, "/sites/a/Shared Documents/Payroll", view.ScopePath)
+	assert.Equal(t, "investigation", view.Trigger)
+	assert.Empty(t, presenter.ToAuditRunView(&audit.AuditRun{ID: 2, StartedAt: startedAt}).ScopePath)
+
+	rc := presenter.ToRunContext(nil, 1, 3, 0, []*audit.AuditRun{scoped, {ID: 2, StartedAt: startedAt}})
+	assert.Equal(t, "/sites/a/Shared Documents/Payroll", rc.ScopePath)
+	assert.Equal(t, "/sites/a/Shared Documents/Payroll", rc.Runs[0].ScopePath)
+	assert.Empty(t, rc.Runs[1].ScopePath)
+}
+
+func TestListPresenter_ToRunContext_ReferenceRun(t *testing.T) {
+	presenter := NewListPresenter()
+	testData := helpers.NewTestData()
+	runs := []*audit.AuditRun{
+		{ID: 3, StartedAt: *helpers.TestTime(1), CompletedAt: helpers.TestTime(1)},
+		{ID: 2, StartedAt: *helpers.TestTime(2)},
+	}
+
+	pinned := presenter.ToRunContext(testData.SimpleSite(1, "Test Site"), 1, 3, 3, runs)
+	assert.True(t, pinned.IsReferenceRun())
+	assert.Equal(t, int64(3), pinned.ReferenceRunID)
+	assert.Len(t, pinned.Runs, 2)
+
+	other := presenter.ToRunContext(testData.SimpleSite(1, "Test Site"), 1, 2, 3, runs)
+	assert.False(t, other.IsReferenceRun())
+
+	unpinned := presenter.ToRunContext(nil, 1, 3, 0, runs)
+	assert.False(t, unpinned.IsReferenceRun())
+	assert.Equal(t, "Site", unpinned.SiteTitle)
+}
+
+func TestListPresenter_PrintSummaries(t *testing.T) {
+	presenter := NewListPresenter()
+	rc := RunContext{SiteID: 1, AuditRunID: 3}
+	assert.Equal(t, "/sites/1/audit-runs/3/print", rc.PrintURL())
+	assert.Equal(t, "/sites/1/audit-runs/3/lists/list-1/print", rc.WithList(ListSummary{ListID: "list-1"}, "overview").PrintURL())
+
+	generatedAt := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+	vm := presenter.ToSitePrintViewModel(SiteListsVM{
+		Lists:  []ListSummary{{Title: "b", ItemCount: 5}, {Title: "A", ItemCount: 1}, {Title: "c", ItemCount: 9}},
+		SortBy: ListSortItems,
+	}, generatedAt)
+	assert.Equal(t, "Feb 3, 2025 10:00 AM", vm.GeneratedAt)
+	assert.Equal(t, []string{"c", "b", "A"}, []string{vm.Lists[0].Title, vm.Lists[1].Title, vm.Lists[2].Title})
+}
+
+func TestListPresenter_ToReportSharesPageVM(t *testing.T) {
+	presenter := NewListPresenter()
+	rc := RunContext{SiteID: 1, AuditRunID: 3}
+	assert.Equal(t, "/sites/1/audit-runs/3/shares", rc.ShareURL())
+	assert.Equal(t, "/sites/1/audit-runs/3/shares?list=list+1", rc.WithList(ListSummary{ListID: "list 1"}, "overview").ShareURL())
+
+	now := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+	revokedAt := now.Add(-time.Hour)
+	vm := presenter.ToReportSharesPageVM(1, 3, "https://contoso.sharepoint.com/sites/a",
+		[]*sharepoint.List{{ID: "docs", Title: "Documents"}},
+		[]*audit.ReportShare{
+			{ID: 3, Token: "t3", ListID: "docs", CreatedAt: now, ExpiresAt: now.Add(time.Hour)},
+			{ID: 2, Token: "t2", ListID: "gone", CreatedAt: now, ExpiresAt: now.Add(time.Hour), RevokedAt: &revokedAt},
+			{ID: 1, Token: "t1", CreatedAt: now.Add(-48 * time.Hour), ExpiresAt: now.Add(-24 * time.Hour)},
+		}, "docs", 3, now)
+
+	assert.Equal(t, "/sites/1/audit-runs/3/shares", vm.CreateURL)
+	assert.Equal(t, []ReportShareListOption{{ID: "docs", Title: "Documents"}}, vm.Lists)
+	require.Len(t, vm.Shares, 3)
+	assert.Equal(t, ReportShareRow{
+		ID: 3, Report: "List summary: Documents", CreatedAt: "2025-02-03 10:00:00", ExpiresAt: "2025-02-03 11:00:00",
+		Status: "Active", Active: true, ShareURL: "/shared/t3", RevokeURL: "/sites/1/audit-runs/3/shares/3/revoke",
+	}, vm.Shares[0])
+	assert.Equal(t, "List summary: gone", vm.Shares[1].Report)
+	assert.Equal(t, "Revoked", vm.Shares[1].Status)
+	assert.Equal(t, "Site summary", vm.Shares[2].Report)
+	assert.Equal(t, "Expired", vm.Shares[2].Status)
+	assert.False(t, vm.Shares[2].Active)
+}
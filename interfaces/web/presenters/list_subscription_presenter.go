@@ -0,0 +1,52 @@
+package presenters
+
+import (
+	"time"
+
+	"spaudit/domain/audit"
+)
+
+// ListSubscriptionView is a webhook subscription to the changes of a list. Its client state stays
+// private, as it is what proves notifications genuine.
+type ListSubscriptionView struct {
+	ID             string `json:"id"`
+	SiteURL        string `json:"site_url"`
+	ListID         string `json:"list_id"`
+	ListTitle      string `json:"list_title"`
+	ListRootPath   string `json:"list_root_path"`
+	ExpiresAt      string `json:"expires_at"`
+	CreatedAt      string `json:"created_at"`
+	LastNotifiedAt string `json:"last_notified_at,omitempty"`
+	LastAuditJobID string `json:"last_audit_job_id,omitempty"` // Latest micro-audit of the list's changes
+	LastAuditAt    string `json:"last_audit_at,omitempty"`
+}
+
+// ToListSubscriptionView converts a list subscription for the API.
+func (p *ListPresenter) ToListSubscriptionView(subscription *audit.ListSubscription) ListSubscriptionView {
+	view := ListSubscriptionView{
+		ID:             subscription.ID,
+		SiteURL:        subscription.SiteURL,
+		ListID:         subscription.ListID,
+		ListTitle:      subscription.ListTitle,
+		ListRootPath:   subscription.ListRootPath,
+		ExpiresAt:      subscription.ExpiresAt.UTC().Format(time.RFC3339),
+		CreatedAt:      subscription.CreatedAt.UTC().Format(time.RFC3339),
+		LastAuditJobID: subscription.LastAuditJobID,
+	}
+	if subscription.LastNotifiedAt != nil {
+		view.LastNotifiedAt = subscription.LastNotifiedAt.UTC().Format(time.RFC3339)
+	}
+	if subscription.LastAuditAt != nil {
+		view.LastAuditAt = subscription.LastAuditAt.UTC().Format(time.RFC3339)
+	}
+	return view
+}
+
+// ToListSubscriptionViews converts list subscriptions for the API, preserving their order.
+func (p *ListPresenter) ToListSubscriptionViews(subscriptions []*audit.ListSubscription) []ListSubscriptionView {
+	views := make([]ListSubscriptionView, len(subscriptions))
+	for i, subscription := range subscriptions {
+		views[i] = p.ToListSubscriptionView(subscription)
+	}
+	return views
+}
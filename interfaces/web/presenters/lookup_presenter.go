@@ -0,0 +1,67 @@
+package presenters
+
+import (
+	"fmt"
+
+	"spaudit/application"
+)
+
+// ItemLookup is an item resolved from a pasted GUID or URL.
+// Stored results explain access as of the latest audit run holding the item;
+// live results list the item's current role assignments in SharePoint.
+type ItemLookup struct {
+	Query     string `json:"query"`
+	MatchedBy string `json:"matched_by"`
+	Source    string `json:"source"`
+	SiteID    int64  `json:"site_id,omitempty"`
+	SiteURL   string `json:"site_url"`
+
+	// Page showing the list in the audit run the item was found in, stored results only
+	ListPageURL string `json:"list_page_url,omitempty"`
+
+	Stored *ItemAccessExplanation `json:"stored,omitempty"`
+	Live   *LiveItemLookup        `json:"live,omitempty"`
+}
+
+// LiveItemLookup is an item as SharePoint reports it now.
+type LiveItemLookup struct {
+	ListID      string               `json:"list_id"`
+	ListTitle   string               `json:"list_title"`
+	ListURL     string               `json:"list_url"`
+	Item        SnapshotItem         `json:"item"`
+	Assignments []SnapshotAssignment `json:"assignments"`
+}
+
+// ToItemLookup converts an item lookup result to its JSON representation.
+func (p *ListPresenter) ToItemLookup(query string, result *application.ItemLookupResult) *ItemLookup {
+	view := &ItemLookup{
+		Query:     query,
+		MatchedBy: result.Reference.Kind,
+		Source:    result.Source,
+		SiteID:    result.SiteID,
+		SiteURL:   result.SiteURL,
+	}
+
+	if result.Stored != nil {
+		view.Stored = p.ToItemAccessExplanation(result.SiteID, result.Stored)
+		view.ListPageURL = fmt.Sprintf("/sites/%d/audit-runs/%d/lists/%s", result.SiteID, result.Stored.AuditRunID, result.Stored.List.ID)
+	}
+
+	if result.Live != nil {
+		live := &LiveItemLookup{
+			Item:        p.toSnapshotItem(result.Live.Item),
+			Assignments: make([]SnapshotAssignment, 0, len(result.Live.Assignments)),
+		}
+		if result.Live.List != nil {
+			live.ListID = result.Live.List.ID
+			live.ListTitle = result.Live.List.Title
+			live.ListURL = result.Live.List.URL
+		}
+		for _, assignment := range result.Live.Assignments {
+			live.Assignments = append(live.Assignments, p.toSnapshotAssignment(assignment))
+		}
+		view.Live = live
+	}
+
+	return view
+}
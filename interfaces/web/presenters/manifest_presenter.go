@@ -0,0 +1,81 @@
+package presenters
+
+import (
+	"spaudit/domain/audit"
+)
+
+// RunManifestView represents an audit run's tamper-evidence manifest for API responses.
+type RunManifestView struct {
+	AuditRunID  int64               `json:"audit_run_id"`
+	SiteID      int64               `json:"site_id"`
+	Algorithm   string              `json:"algorithm"`
+	ContentHash string              `json:"content_hash"`
+	SealedAt    string              `json:"sealed_at"`
+	Lists       []ManifestEntryView `json:"lists"`
+}
+
+// ManifestEntryView is the sealed content hash of one list's snapshot.
+type ManifestEntryView struct {
+	ListID      string `json:"list_id"`
+	ContentHash string `json:"content_hash"`
+}
+
+// ManifestVerificationView reports whether an audit run's stored data still matches its manifest.
+type ManifestVerificationView struct {
+	AuditRunID   int64                    `json:"audit_run_id"`
+	SiteID       int64                    `json:"site_id"`
+	Verified     bool                     `json:"verified"`
+	Algorithm    string                   `json:"algorithm"`
+	SealedHash   string                   `json:"sealed_hash"`
+	ComputedHash string                   `json:"computed_hash"`
+	SealedAt     string                   `json:"sealed_at"`
+	Lists        []ManifestEntryCheckView `json:"lists"`
+}
+
+// ManifestEntryCheckView is the verification result for one list.
+type ManifestEntryCheckView struct {
+	ListID       string `json:"list_id"`
+	Status       string `json:"status"`
+	ExpectedHash string `json:"expected_hash,omitempty"`
+	ActualHash   string `json:"actual_hash,omitempty"`
+}
+
+// ToRunManifestView converts a run manifest to its API view.
+// Lists is sized to the manifest's entries, so a run sealed without lists reports [].
+func (p *ListPresenter) ToRunManifestView(manifest *audit.RunManifest) RunManifestView {
+	view := RunManifestView{
+		AuditRunID:  manifest.AuditRunID,
+		SiteID:      manifest.SiteID,
+		Algorithm:   manifest.Algorithm,
+		ContentHash: manifest.ContentHash,
+		SealedAt:    manifest.CreatedAt.UTC().Format(AuditRunTimeFormat),
+		Lists:       make([]ManifestEntryView, len(manifest.Entries)),
+	}
+	for i, entry := range manifest.Entries {
+		view.Lists[i] = ManifestEntryView{ListID: entry.ListID, ContentHash: entry.ContentHash}
+	}
+	return view
+}
+
+// ToManifestVerificationView converts a manifest verification to its API view.
+func (p *ListPresenter) ToManifestVerificationView(verification *audit.ManifestVerification) ManifestVerificationView {
+	view := ManifestVerificationView{
+		AuditRunID:   verification.Manifest.AuditRunID,
+		SiteID:       verification.Manifest.SiteID,
+		Verified:     verification.Verified,
+		Algorithm:    verification.Manifest.Algorithm,
+		SealedHash:   verification.Manifest.ContentHash,
+		ComputedHash: verification.ContentHash,
+		SealedAt:     verification.Manifest.CreatedAt.UTC().Format(AuditRunTimeFormat),
+		Lists:        make([]ManifestEntryCheckView, len(verification.Entries)),
+	}
+	for i, check := range verification.Entries {
+		view.Lists[i] = ManifestEntryCheckView{
+			ListID:       check.ListID,
+			Status:       check.Status,
+			ExpectedHash: check.ExpectedHash,
+			ActualHash:   check.ActualHash,
+		}
+	}
+	return view
+}
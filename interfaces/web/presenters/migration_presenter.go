@@ -0,0 +1,138 @@
+package presenters
+
+import (
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+// MigrationAssessmentView compares a run of a migration's source site with a run of its target site.
+type MigrationAssessmentView struct {
+	Source             MigrationSideView         `json:"source"`
+	Target             MigrationSideView         `json:"target"`
+	HasGaps            bool                      `json:"has_gaps"`
+	Summary            MigrationSummaryView      `json:"summary"`
+	Objects            []MigrationObjectGapView  `json:"objects"`
+	MissingPermissions []MigrationPermissionView `json:"missing_permissions"`
+	ExtraPermissions   []MigrationPermissionView `json:"extra_permissions"`
+	LinkGaps           []MigrationLinkGapView    `json:"link_gaps"`
+}
+
+// MigrationSideView is the site and resolved audit run on one side of a migration assessment.
+type MigrationSideView struct {
+	SiteID     int64  `json:"site_id"`
+	SiteURL    string `json:"site_url"`
+	AuditRunID int64  `json:"audit_run_id"`
+}
+
+// MigrationSummaryView counts what carried over and what did not.
+type MigrationSummaryView struct {
+	MatchedPermissions int `json:"matched_permissions"`
+	MissingPermissions int `json:"missing_permissions"`
+	ExtraPermissions   int `json:"extra_permissions"`
+	MissingObjects     int `json:"missing_objects"` // Source objects with no explicit permissions on the target
+	MatchedLinks       int `json:"matched_links"`
+	MissingLinks       int `json:"missing_links"`
+	ExtraLinks         int `json:"extra_links"`
+	Unmapped           int `json:"unmapped"`
+}
+
+// MigrationObjectGapView is a source object whose explicit permissions did not carry over unchanged.
+type MigrationObjectGapView struct {
+	ObjectType string `json:"object_type"`
+	ObjectName string `json:"object_name"`
+	SourceURL  string `json:"source_url"`
+	TargetURL  string `json:"target_url"`
+	Status     string `json:"status"`
+	Missing    int    `json:"missing"`
+	Extra      int    `json:"extra"`
+}
+
+// MigrationPermissionView is an explicit permission present on only one side of a migration.
+type MigrationPermissionView struct {
+	ObjectType string            `json:"object_type"`
+	ObjectName string            `json:"object_name"`
+	SourceURL  string            `json:"source_url,omitempty"`
+	TargetURL  string            `json:"target_url"`
+	Principal  SnapshotPrincipal `json:"principal"`
+	Identity   string            `json:"identity"`
+	Role       string            `json:"role"`
+}
+
+// MigrationLinkGapView is a kind of sharing link whose count differs between an item and its migrated copy.
+type MigrationLinkGapView struct {
+	ItemName    string `json:"item_name"`
+	SourceURL   string `json:"source_url,omitempty"`
+	TargetURL   string `json:"target_url"`
+	LinkKind    int    `json:"link_kind"`
+	LinkType    string `json:"link_type"`
+	Scope       string `json:"scope"`
+	SourceLinks int    `json:"source_links"`
+	TargetLinks int    `json:"target_links"`
+}
+
+// ToMigrationAssessmentView converts a migration assessment to its API view.
+func (p *ListPresenter) ToMigrationAssessmentView(data *application.MigrationAssessmentData) MigrationAssessmentView {
+	view := MigrationAssessmentView{
+		Source:             MigrationSideView(data.Source),
+		Target:             MigrationSideView(data.Target),
+		HasGaps:            data.HasGaps(),
+		Objects:            make([]MigrationObjectGapView, len(data.Objects)),
+		MissingPermissions: p.toMigrationPermissionViews(data.MissingPermissions),
+		ExtraPermissions:   p.toMigrationPermissionViews(data.ExtraPermissions),
+		LinkGaps:           make([]MigrationLinkGapView, len(data.LinkGaps)),
+		Summary: MigrationSummaryView{
+			MatchedPermissions: data.MatchedPermissions,
+			MissingPermissions: len(data.MissingPermissions),
+			ExtraPermissions:   len(data.ExtraPermissions),
+			MatchedLinks:       data.MatchedLinks,
+			Unmapped:           data.Unmapped,
+		},
+	}
+
+	for i, object := range data.Objects {
+		view.Objects[i] = MigrationObjectGapView{
+			ObjectType: object.ObjectType,
+			ObjectName: object.ObjectName,
+			SourceURL:  object.SourceURL,
+			TargetURL:  object.TargetURL,
+			Status:     object.Status,
+			Missing:    object.Missing,
+			Extra:      object.Extra,
+		}
+		if object.Status == application.MigrationObjectMissing {
+			view.Summary.MissingObjects++
+		}
+	}
+	for i, gap := range data.LinkGaps {
+		view.LinkGaps[i] = MigrationLinkGapView{
+			ItemName:    gap.ItemName,
+			SourceURL:   gap.SourceURL,
+			TargetURL:   gap.TargetURL,
+			LinkKind:    gap.LinkKind,
+			LinkType:    sharepoint.LinkKindName(gap.LinkKind),
+			Scope:       sharepoint.ScopeName(gap.Scope),
+			SourceLinks: gap.SourceLinks,
+			TargetLinks: gap.TargetLinks,
+		}
+		view.Summary.MissingLinks += max(0, gap.SourceLinks-gap.TargetLinks)
+		view.Summary.ExtraLinks += max(0, gap.TargetLinks-gap.SourceLinks)
+	}
+	return view
+}
+
+// toMigrationPermissionViews converts missing or extra migration permissions to their API views.
+func (p *ListPresenter) toMigrationPermissionViews(permissions []*application.MigrationPermission) []MigrationPermissionView {
+	views := make([]MigrationPermissionView, len(permissions))
+	for i, permission := range permissions {
+		views[i] = MigrationPermissionView{
+			ObjectType: permission.ObjectType,
+			ObjectName: permission.ObjectName,
+			SourceURL:  permission.SourceURL,
+			TargetURL:  permission.TargetURL,
+			Principal:  p.toSnapshotPrincipal(permission.Principal),
+			Identity:   permission.Identity,
+			Role:       permission.Role,
+		}
+	}
+	return views
+}
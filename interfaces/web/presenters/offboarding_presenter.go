@@ -0,0 +1,133 @@
+package presenters
+
+import (
+	"strconv"
+	"time"
+
+	"spaudit/domain/audit"
+)
+
+// OffboardingCheckView is a check of what a leaver still has across every audited site.
+type OffboardingCheckView struct {
+	ID           int64  `json:"id"`
+	JobID        string `json:"job_id"`
+	Identity     string `json:"identity"`
+	Remediate    bool   `json:"remediate"`
+	StartedAt    string `json:"started_at"`
+	CompletedAt  string `json:"completed_at,omitempty"` // Empty while the check runs
+	Access       int    `json:"access"`
+	LinksCreated int    `json:"links_created"`
+	ItemsOwned   int    `json:"items_owned"`
+	Remediations int    `json:"remediations"`
+	Error        string `json:"error,omitempty"`
+}
+
+// OffboardingCheckDetailView is an offboarding check with the remediation actions it queued.
+type OffboardingCheckDetailView struct {
+	OffboardingCheckView
+	RemediationActions []OffboardingRemediationView `json:"remediation_actions"`
+}
+
+// OffboardingRemediationView is an action queued to take away something a leaver still has.
+type OffboardingRemediationView struct {
+	ID          int64  `json:"id"`
+	SiteID      int64  `json:"site_id"`
+	SiteURL     string `json:"site_url"`
+	AuditRunID  int64  `json:"audit_run_id"`
+	Action      string `json:"action"`
+	PrincipalID int64  `json:"principal_id"`
+	ObjectType  string `json:"object_type,omitempty"`
+	ObjectKey   string `json:"object_key,omitempty"`
+	ObjectURL   string `json:"object_url,omitempty"`
+	GroupID     int64  `json:"group_id,omitempty"`
+	LinkID      string `json:"link_id,omitempty"`
+	Detail      string `json:"detail,omitempty"`
+	Status      string `json:"status"`
+	QueuedAt    string `json:"queued_at"`
+	UpdatedAt   string `json:"updated_at,omitempty"`
+}
+
+// ToOffboardingCheckView converts an offboarding check for the API.
+func (p *ListPresenter) ToOffboardingCheckView(check *audit.OffboardingCheck) OffboardingCheckView {
+	view := OffboardingCheckView{
+		ID:           check.ID,
+		JobID:        check.JobID,
+		Identity:     check.Identity,
+		Remediate:    check.Remediate,
+		StartedAt:    check.StartedAt.UTC().Format(time.RFC3339),
+		Access:       check.Access,
+		LinksCreated: check.LinksCreated,
+		ItemsOwned:   check.ItemsOwned,
+		Remediations: check.Remediations,
+		Error:        check.Error,
+	}
+	if check.CompletedAt != nil {
+		view.CompletedAt = check.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	return view
+}
+
+// ToOffboardingCheckViews converts offboarding checks for the API, preserving their order.
+func (p *ListPresenter) ToOffboardingCheckViews(checks []*audit.OffboardingCheck) []OffboardingCheckView {
+	views := make([]OffboardingCheckView, len(checks))
+	for i, check := range checks {
+		views[i] = p.ToOffboardingCheckView(check)
+	}
+	return views
+}
+
+// ToOffboardingCheckDetailView converts an offboarding check and its remediation actions for the API.
+func (p *ListPresenter) ToOffboardingCheckDetailView(check *audit.OffboardingCheck, remediations []*audit.OffboardingRemediation) OffboardingCheckDetailView {
+	view := OffboardingCheckDetailView{
+		OffboardingCheckView: p.ToOffboardingCheckView(check),
+		RemediationActions:   make([]OffboardingRemediationView, len(remediations)),
+	}
+	for i, remediation := range remediations {
+		view.RemediationActions[i] = OffboardingRemediationView{
+			ID:          remediation.ID,
+			SiteID:      remediation.SiteID,
+			SiteURL:     remediation.SiteURL,
+			AuditRunID:  remediation.AuditRunID,
+			Action:      remediation.Action,
+			PrincipalID: remediation.PrincipalID,
+			ObjectType:  remediation.ObjectType,
+			ObjectKey:   remediation.ObjectKey,
+			ObjectURL:   remediation.ObjectURL,
+			GroupID:     remediation.GroupID,
+			LinkID:      remediation.LinkID,
+			Detail:      remediation.Detail,
+			Status:      remediation.Status,
+			QueuedAt:    remediation.QueuedAt.UTC().Format(time.RFC3339),
+		}
+		if remediation.UpdatedAt != nil {
+			view.RemediationActions[i].UpdatedAt = remediation.UpdatedAt.UTC().Format(time.RFC3339)
+		}
+	}
+	return view
+}
+
+// OffboardingReportToCSV lays out an offboarding report one finding per row, for the leaver's manager
+// or records. Sites that could not be read are listed after the findings.
+func (p *ListPresenter) OffboardingReportToCSV(report *audit.OffboardingReport) CSVTable {
+	table := CSVTable{
+		Header: []string{"Finding", "Site", "Audit Run", "Object Type", "Object URL", "Object Name", "Role", "Through", "Via"},
+		Rows:   make([][]string, 0, len(report.Findings)+len(report.Skipped)),
+	}
+	for _, finding := range report.Findings {
+		table.Rows = append(table.Rows, []string{
+			finding.Kind,
+			finding.SiteURL,
+			strconv.FormatInt(finding.AuditRunID, 10),
+			finding.ObjectType,
+			finding.ObjectURL,
+			finding.ObjectName,
+			finding.Role,
+			finding.Through,
+			finding.Via,
+		})
+	}
+	for _, site := range report.Skipped {
+		table.Rows = append(table.Rows, []string{"skipped", site.SiteURL, "", "", "", "", "", "", site.Reason})
+	}
+	return table
+}
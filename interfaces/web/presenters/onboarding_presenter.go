@@ -0,0 +1,61 @@
+package presenters
+
+import "spaudit/domain/audit"
+
+// Onboarding wizard steps, in order.
+const (
+	OnboardingStepCredentials = 1
+	OnboardingStepSite        = 2
+	OnboardingStepPreset      = 3
+	OnboardingStepStarted     = 4
+)
+
+// OnboardingVM is the view model of one step of the first-run setup wizard.
+type OnboardingVM struct {
+	Step int
+
+	// Credentials check
+	CredentialsOK    bool
+	CredentialsError string
+
+	// Site and preset chosen so far, carried between steps
+	SiteURL string
+	Label   string
+	Preset  string
+	Presets []AuditPresetView
+
+	// Set once the first audit is queued
+	JobID string
+
+	Error string // Why the step's form was rejected
+}
+
+// AuditPresetView is a named set of audit options offered by the setup wizard.
+type AuditPresetView struct {
+	Name        string
+	Title       string
+	Description string
+}
+
+// ToOnboardingVM builds the view model of a setup wizard step; the preset defaults to standard.
+func (p *SitePresenter) ToOnboardingVM(step int, siteURL, label, preset string) OnboardingVM {
+	if preset == "" {
+		preset = audit.PresetStandard
+	}
+	presets := audit.AuditPresets()
+	views := make([]AuditPresetView, len(presets))
+	for i, preset := range presets {
+		views[i] = AuditPresetView{Name: preset.Name, Title: preset.Title, Description: preset.Description}
+	}
+	return OnboardingVM{Step: step, SiteURL: siteURL, Label: label, Preset: preset, Presets: views}
+}
+
+// PresetTitle returns the title of the chosen preset.
+func (vm OnboardingVM) PresetTitle() string {
+	for _, preset := range vm.Presets {
+		if preset.Name == vm.Preset {
+			return preset.Title
+		}
+	}
+	return vm.Preset
+}
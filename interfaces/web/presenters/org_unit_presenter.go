@@ -0,0 +1,219 @@
+package presenters
+
+import (
+	"strconv"
+	"time"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+)
+
+// unassignedOrgUnit names the sites no mapping assigned in exports.
+const unassignedOrgUnit = "Unassigned"
+
+// OrgUnitMappingView assigns the sites matching a site URL, hub or run label to an organizational unit.
+type OrgUnitMappingView struct {
+	ID         int64  `json:"id"`
+	MatchType  string `json:"match_type"`
+	MatchValue string `json:"match_value"`
+	OrgUnit    string `json:"org_unit"`
+	UpdatedAt  string `json:"updated_at"`
+}
+
+// OrgUnitScorecardsView is a scorecard for every organizational unit.
+type OrgUnitScorecardsView struct {
+	Scorecards   []OrgUnitScorecardView   `json:"scorecards"`
+	SitesScanned int                      `json:"sites_scanned"`
+	StaleDays    int                      `json:"stale_days"`
+	GeneratedAt  string                   `json:"generated_at"`
+	Skipped      []OrgUnitSkippedSiteView `json:"skipped"`
+}
+
+// OrgUnitScorecardView is the aggregate risk, external access and audit freshness of one unit's sites.
+type OrgUnitScorecardView struct {
+	OrgUnit         string `json:"org_unit,omitempty"` // Empty for the sites no mapping assigned
+	RiskLevel       string `json:"risk_level"`
+	SiteCount       int    `json:"site_count"`
+	HighRiskSites   int    `json:"high_risk_sites"`
+	MediumRiskSites int    `json:"medium_risk_sites"`
+	LowRiskSites    int    `json:"low_risk_sites"`
+
+	ListsAnalyzed          int `json:"lists_analyzed"`
+	HighRiskLists          int `json:"high_risk_lists"`
+	SharingLinks           int `json:"sharing_links"`
+	AnonymousLinks         int `json:"anonymous_links"`
+	OrganizationLinks      int `json:"organization_links"`
+	FullControlAssignments int `json:"full_control_assignments"`
+
+	GuestUsers              int `json:"guest_users"`
+	SitesWithExternalAccess int `json:"sites_with_external_access"`
+
+	FreshSites        int    `json:"fresh_sites"`
+	StaleSites        int    `json:"stale_sites"`
+	NeverAuditedSites int    `json:"never_audited_sites"`
+	OldestAudit       string `json:"oldest_audit,omitempty"`
+	LatestAudit       string `json:"latest_audit,omitempty"`
+
+	RiskiestSiteID int64             `json:"riskiest_site_id,omitempty"`
+	Sites          []OrgUnitSiteView `json:"sites"`
+}
+
+// OrgUnitSiteView is one site of a unit and what its latest audit run found.
+type OrgUnitSiteView struct {
+	SiteID            int64   `json:"site_id"`
+	SiteURL           string  `json:"site_url"`
+	Title             string  `json:"title"`
+	Source            string  `json:"source,omitempty"` // site, hub, label or hub_title
+	AuditRunID        int64   `json:"audit_run_id,omitempty"`
+	AuditedAt         string  `json:"audited_at,omitempty"`
+	Stale             bool    `json:"stale"`
+	NeverAudited      bool    `json:"never_audited"`
+	HubTitle          string  `json:"hub_title,omitempty"`
+	RunLabel          string  `json:"run_label,omitempty"`
+	RiskLevel         string  `json:"risk_level,omitempty"`
+	HighestRiskScore  float64 `json:"highest_risk_score"`
+	AnonymousLinks    int     `json:"anonymous_links"`
+	GuestUsers        int     `json:"guest_users"`
+	HasExternalAccess bool    `json:"has_external_access"`
+}
+
+// OrgUnitSkippedSiteView is an audited site left out of the scorecards.
+type OrgUnitSkippedSiteView struct {
+	SiteID  int64  `json:"site_id"`
+	SiteURL string `json:"site_url"`
+	Reason  string `json:"reason"`
+}
+
+// ToOrgUnitMappingViews converts organizational unit mappings for the API, preserving their order.
+func (p *ListPresenter) ToOrgUnitMappingViews(mappings []*audit.OrgUnitMapping) []OrgUnitMappingView {
+	views := make([]OrgUnitMappingView, len(mappings))
+	for i, mapping := range mappings {
+		views[i] = OrgUnitMappingView{
+			ID:         mapping.ID,
+			MatchType:  mapping.MatchType,
+			MatchValue: mapping.MatchValue,
+			OrgUnit:    mapping.OrgUnit,
+			UpdatedAt:  mapping.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return views
+}
+
+// ToOrgUnitScorecardsView converts organizational unit scorecards to their API view.
+func (p *ListPresenter) ToOrgUnitScorecardsView(data *application.OrgUnitScorecardData) OrgUnitScorecardsView {
+	view := OrgUnitScorecardsView{
+		Scorecards:   make([]OrgUnitScorecardView, len(data.Scorecards)),
+		SitesScanned: data.SitesScanned,
+		StaleDays:    int(data.StaleAfter / (24 * time.Hour)),
+		GeneratedAt:  data.GeneratedAt.UTC().Format(time.RFC3339),
+		Skipped:      make([]OrgUnitSkippedSiteView, len(data.Skipped)),
+	}
+	for i, scorecard := range data.Scorecards {
+		scorecardView := OrgUnitScorecardView{
+			OrgUnit:                 scorecard.OrgUnit,
+			RiskLevel:               scorecard.RiskLevel,
+			SiteCount:               len(scorecard.Sites),
+			HighRiskSites:           scorecard.HighRiskSites,
+			MediumRiskSites:         scorecard.MediumRiskSites,
+			LowRiskSites:            scorecard.LowRiskSites,
+			ListsAnalyzed:           scorecard.ListsAnalyzed,
+			HighRiskLists:           scorecard.HighRiskLists,
+			SharingLinks:            scorecard.SharingLinks,
+			AnonymousLinks:          scorecard.AnonymousLinks,
+			OrganizationLinks:       scorecard.OrganizationLinks,
+			FullControlAssignments:  scorecard.FullControlAssignments,
+			GuestUsers:              scorecard.GuestUsers,
+			SitesWithExternalAccess: scorecard.SitesWithExternalAccess,
+			FreshSites:              scorecard.FreshSites,
+			StaleSites:              scorecard.StaleSites,
+			NeverAuditedSites:       scorecard.NeverAuditedSites,
+			OldestAudit:             formatOptionalRFC3339(scorecard.OldestAudit),
+			LatestAudit:             formatOptionalRFC3339(scorecard.LatestAudit),
+			Sites:                   make([]OrgUnitSiteView, len(scorecard.Sites)),
+		}
+		if scorecard.RiskiestSite != nil {
+			scorecardView.RiskiestSiteID = scorecard.RiskiestSite.Site.ID
+		}
+		for j, site := range scorecard.Sites {
+			siteView := OrgUnitSiteView{
+				SiteID:            site.Site.ID,
+				SiteURL:           site.Site.URL,
+				Title:             site.Site.Title,
+				Source:            site.Source,
+				AuditRunID:        site.AuditRunID,
+				AuditedAt:         formatOptionalRFC3339(site.AuditedAt),
+				Stale:             site.Stale,
+				NeverAudited:      site.NeverAudited,
+				HubTitle:          site.HubTitle,
+				RunLabel:          site.RunLabel,
+				GuestUsers:        site.GuestUsers,
+				HasExternalAccess: site.HasExternalAccess(),
+			}
+			if site.Risk != nil {
+				siteView.RiskLevel = site.Risk.RiskLevel
+				siteView.HighestRiskScore = site.Risk.HighestRiskScore
+				siteView.AnonymousLinks = site.Risk.AnonymousLinks
+			}
+			scorecardView.Sites[j] = siteView
+		}
+		view.Scorecards[i] = scorecardView
+	}
+	for i, site := range data.Skipped {
+		view.Skipped[i] = OrgUnitSkippedSiteView(*site)
+	}
+	return view
+}
+
+// OrgUnitScorecardsToCSV lays out organizational unit scorecards one unit per row, for leadership reports.
+func (p *ListPresenter) OrgUnitScorecardsToCSV(data *application.OrgUnitScorecardData) CSVTable {
+	table := CSVTable{
+		Header: []string{
+			"Org Unit", "Risk Level", "Sites", "High Risk Sites", "Medium Risk Sites", "Low Risk Sites",
+			"Lists Analyzed", "High Risk Lists", "Sharing Links", "Anonymous Links", "Organization Links",
+			"Full Control Assignments", "Guest Users", "Sites With External Access",
+			"Fresh Sites", "Stale Sites", "Never Audited Sites", "Oldest Audit", "Latest Audit", "Riskiest Site",
+		},
+		Rows: make([][]string, 0, len(data.Scorecards)),
+	}
+	for _, scorecard := range data.Scorecards {
+		orgUnit := scorecard.OrgUnit
+		if orgUnit == "" {
+			orgUnit = unassignedOrgUnit
+		}
+		riskiestSite := ""
+		if scorecard.RiskiestSite != nil {
+			riskiestSite = scorecard.RiskiestSite.Site.URL
+		}
+		table.Rows = append(table.Rows, []string{
+			orgUnit,
+			scorecard.RiskLevel,
+			strconv.Itoa(len(scorecard.Sites)),
+			strconv.Itoa(scorecard.HighRiskSites),
+			strconv.Itoa(scorecard.MediumRiskSites),
+			strconv.Itoa(scorecard.LowRiskSites),
+			strconv.Itoa(scorecard.ListsAnalyzed),
+			strconv.Itoa(scorecard.HighRiskLists),
+			strconv.Itoa(scorecard.SharingLinks),
+			strconv.Itoa(scorecard.AnonymousLinks),
+			strconv.Itoa(scorecard.OrganizationLinks),
+			strconv.Itoa(scorecard.FullControlAssignments),
+			strconv.Itoa(scorecard.GuestUsers),
+			strconv.Itoa(scorecard.SitesWithExternalAccess),
+			strconv.Itoa(scorecard.FreshSites),
+			strconv.Itoa(scorecard.StaleSites),
+			strconv.Itoa(scorecard.NeverAuditedSites),
+			formatOptionalRFC3339(scorecard.OldestAudit),
+			formatOptionalRFC3339(scorecard.LatestAudit),
+			riskiestSite,
+		})
+	}
+	return table
+}
+
+// formatOptionalRFC3339 formats a time in UTC, empty for nil
+func formatOptionalRFC3339(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,134 @@
+package presenters
+
+import (
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+// Page library kinds
+const (
+	PageLibrarySitePages  = "site_pages"
+	PageLibrarySiteAssets = "site_assets"
+)
+
+// PageLibraryExposureView is the exposure of an audit run's Site Pages and Site Assets libraries.
+type PageLibraryExposureView struct {
+	SiteID     int64                    `json:"site_id"`
+	AuditRunID int64                    `json:"audit_run_id"`
+	Exposed    bool                     `json:"exposed"`
+	Libraries  []PageLibraryView        `json:"libraries"`
+	Findings   []PageLibraryFindingView `json:"findings"`
+}
+
+// PageLibraryView is one page library with its anyone links and external contributors.
+type PageLibraryView struct {
+	ListID               string                       `json:"list_id"`
+	ListTitle            string                       `json:"list_title"`
+	ListURL              string                       `json:"list_url"`
+	Kind                 string                       `json:"kind"` // site_pages or site_assets
+	AnonymousLinks       []PageLibraryLinkView        `json:"anonymous_links"`
+	ExternalContributors []PageLibraryContributorView `json:"external_contributors"`
+}
+
+// PageLibraryLinkView is an anyone link on a page library or one of its items.
+type PageLibraryLinkView struct {
+	LinkID     string `json:"link_id"`
+	ItemGUID   string `json:"item_guid"`
+	ItemName   string `json:"item_name,omitempty"`
+	ItemURL    string `json:"item_url,omitempty"`
+	LinkType   string `json:"link_type"`
+	IsEditLink bool   `json:"is_edit_link"`
+}
+
+// PageLibraryContributorView is a guest who can edit a page library or one of its items.
+type PageLibraryContributorView struct {
+	Principal  string `json:"principal"`
+	LoginName  string `json:"login_name"`
+	ObjectType string `json:"object_type"`
+	ObjectKey  string `json:"object_key"`
+	ObjectName string `json:"object_name,omitempty"`
+	Role       string `json:"role"`
+	GrantType  string `json:"grant_type"`
+	GrantedVia string `json:"granted_via,omitempty"`
+}
+
+// PageLibraryFindingView is one kind of exposure found in a page library.
+type PageLibraryFindingView struct {
+	Kind        string `json:"kind"`
+	Severity    string `json:"severity"`
+	ListID      string `json:"list_id"`
+	ListTitle   string `json:"list_title"`
+	ObjectCount int    `json:"object_count"`
+}
+
+// ToPageLibraryExposureView converts an audit run's page library exposure for the API and the printable summary.
+func (p *ListPresenter) ToPageLibraryExposureView(siteID, auditRunID int64, data *application.PageLibraryExposureData) PageLibraryExposureView {
+	findings := data.Findings()
+	view := PageLibraryExposureView{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		Exposed:    len(findings) > 0,
+		Libraries:  make([]PageLibraryView, len(data.Libraries)),
+		Findings:   make([]PageLibraryFindingView, len(findings)),
+	}
+
+	for i, library := range data.Libraries {
+		libraryView := PageLibraryView{
+			ListID:               library.List.ID,
+			ListTitle:            library.List.Title,
+			ListURL:              library.List.URL,
+			Kind:                 PageLibrarySiteAssets,
+			AnonymousLinks:       make([]PageLibraryLinkView, len(library.AnonymousLinks)),
+			ExternalContributors: make([]PageLibraryContributorView, len(library.ExternalContributors)),
+		}
+		if library.List.IsSitePages() {
+			libraryView.Kind = PageLibrarySitePages
+		}
+		for j, anyone := range library.AnonymousLinks {
+			libraryView.AnonymousLinks[j] = PageLibraryLinkView{
+				LinkID:     anyone.Link.ID,
+				ItemGUID:   anyone.Link.ItemGUID,
+				ItemName:   anyone.ItemName,
+				ItemURL:    anyone.ItemURL,
+				LinkType:   sharepoint.LinkKindName(anyone.Link.LinkKind),
+				IsEditLink: anyone.Link.IsEditLink,
+			}
+		}
+		for j, contributor := range library.ExternalContributors {
+			libraryView.ExternalContributors[j] = PageLibraryContributorView{
+				Principal:  contributor.Principal.GetDisplayName(),
+				LoginName:  contributor.Principal.LoginName,
+				ObjectType: contributor.ObjectType,
+				ObjectKey:  contributor.ObjectKey,
+				ObjectName: contributor.ObjectName,
+				Role:       contributor.Role,
+				GrantType:  contributor.GrantType,
+				GrantedVia: contributor.GrantedVia,
+			}
+		}
+		view.Libraries[i] = libraryView
+	}
+
+	for i, finding := range findings {
+		view.Findings[i] = PageLibraryFindingView{
+			Kind:        finding.Kind,
+			Severity:    string(finding.Severity),
+			ListID:      finding.ListID,
+			ListTitle:   finding.ListTitle,
+			ObjectCount: finding.ObjectCount,
+		}
+	}
+	return view
+}
+
+// PageLibraryFindingLabel names a page library finding kind for display.
+func PageLibraryFindingLabel(kind string) string {
+	switch kind {
+	case application.PageLibraryAnonymousAccess:
+		return "Anyone links"
+	case application.PageLibraryExternalContributor:
+		return "External contributors"
+	default:
+		return kind
+	}
+}
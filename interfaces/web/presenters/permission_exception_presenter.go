@@ -0,0 +1,102 @@
+package presenters
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"spaudit/domain/audit"
+)
+
+// PermissionExceptionView accepts a sharing link, assignment or item with unique permissions of a list.
+type PermissionExceptionView struct {
+	ID              int64  `json:"id"`
+	SiteID          int64  `json:"site_id"`
+	ListID          string `json:"list_id"`
+	Kind            string `json:"kind"`
+	ObjectKey       string `json:"object_key"`
+	PrincipalID     int64  `json:"principal_id,omitempty"`
+	RoleDefID       int64  `json:"role_def_id,omitempty"`
+	Justification   string `json:"justification"`
+	Owner           string `json:"owner,omitempty"`
+	ExpiresAt       string `json:"expires_at"`
+	CreatedAt       string `json:"created_at"`
+	Active          bool   `json:"active"`
+	OwnerNotifiedAt string `json:"owner_notified_at,omitempty"`
+}
+
+// ExceptionReviewView is an exception in the review queue.
+type ExceptionReviewView struct {
+	PermissionExceptionView
+	SiteURL     string `json:"site_url"`
+	DaysLeft    int    `json:"days_left"`    // Negative once expired
+	RenewsUntil string `json:"renews_until"` // When the exception would expire if renewed now
+}
+
+// Subject describes what the exception accepts.
+func (v ExceptionReviewView) Subject() string {
+	switch v.Kind {
+	case audit.ExceptionSharingLink:
+		return "Sharing link " + v.ObjectKey
+	case audit.ExceptionUniqueItem:
+		return "Unique permissions of item " + v.ObjectKey
+	default:
+		return fmt.Sprintf("Role %d of principal %d on %s", v.RoleDefID, v.PrincipalID, v.ObjectKey)
+	}
+}
+
+// ExpiryLabel describes how soon the exception expires.
+func (v ExceptionReviewView) ExpiryLabel() string {
+	switch {
+	case !v.Active:
+		return "Expired"
+	case v.DaysLeft == 0:
+		return "Expires today"
+	case v.DaysLeft == 1:
+		return "1 day left"
+	default:
+		return fmt.Sprintf("%d days left", v.DaysLeft)
+	}
+}
+
+// ToPermissionExceptionViews converts permission exceptions for the API, preserving their order and
+// marking those active at now.
+func (p *ListPresenter) ToPermissionExceptionViews(exceptions []*audit.PermissionException, now time.Time) []PermissionExceptionView {
+	views := make([]PermissionExceptionView, len(exceptions))
+	for i, exception := range exceptions {
+		views[i] = PermissionExceptionView{
+			ID:            exception.ID,
+			SiteID:        exception.SiteID,
+			ListID:        exception.ListID,
+			Kind:          exception.Kind,
+			ObjectKey:     exception.ObjectKey,
+			PrincipalID:   exception.PrincipalID,
+			RoleDefID:     exception.RoleDefID,
+			Justification: exception.Justification,
+			Owner:         exception.Owner,
+			ExpiresAt:     exception.ExpiresAt.UTC().Format(time.RFC3339),
+			CreatedAt:     exception.CreatedAt.UTC().Format(time.RFC3339),
+			Active:        exception.Active(now),
+		}
+		if exception.ReviewNotifiedAt != nil {
+			views[i].OwnerNotifiedAt = exception.ReviewNotifiedAt.UTC().Format(time.RFC3339)
+		}
+	}
+	return views
+}
+
+// ToExceptionReviewViews converts the review queue for the API and the review page, preserving its
+// order. Days left are counted in whole days from now, rounded down.
+func (p *ListPresenter) ToExceptionReviewViews(reviews []*audit.ExceptionReview, now time.Time) []ExceptionReviewView {
+	views := make([]ExceptionReviewView, len(reviews))
+	for i, review := range reviews {
+		exception := review.Exception
+		views[i] = ExceptionReviewView{
+			PermissionExceptionView: p.ToPermissionExceptionViews([]*audit.PermissionException{exception}, now)[0],
+			SiteURL:                 review.SiteURL,
+			DaysLeft:                int(math.Floor(exception.ExpiresAt.Sub(now).Hours() / 24)),
+			RenewsUntil:             exception.RenewedExpiry(now).UTC().Format(time.RFC3339),
+		}
+	}
+	return views
+}
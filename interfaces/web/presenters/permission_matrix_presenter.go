@@ -0,0 +1,135 @@
+package presenters
+
+import (
+	"strings"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+// Permission matrix layouts, naming what the columns of the matrix are.
+const (
+	// PermissionMatrixByPrincipal has a column per principal, each cell the roles it holds on the object.
+	PermissionMatrixByPrincipal = "principal"
+	// PermissionMatrixByRole has a column per role, each cell the principals holding it on the object.
+	PermissionMatrixByRole = "role"
+)
+
+// PermissionMatrixObjectColumns is the number of columns describing each object, before the matrix itself.
+const PermissionMatrixObjectColumns = 3
+
+// RoleTier ranks a permission level by what it lets a principal do, to highlight matrix cells.
+type RoleTier int
+
+// Role tiers, from custom levels that are not highlighted to Full Control.
+const (
+	RoleTierOther       RoleTier = iota // Custom levels
+	RoleTierRead                        // Read and View Only
+	RoleTierEdit                        // Contribute, Edit and Design
+	RoleTierFullControl                 // Full Control
+)
+
+// ParsePermissionMatrixLayout returns the permission matrix layout with the given name, a column per
+// principal when empty.
+func ParsePermissionMatrixLayout(name string) (string, bool) {
+	switch name {
+	case "", PermissionMatrixByPrincipal:
+		return PermissionMatrixByPrincipal, true
+	case PermissionMatrixByRole:
+		return PermissionMatrixByRole, true
+	}
+	return "", false
+}
+
+// PermissionRoleTier returns the tier of a canonical role name. Custom levels are RoleTierOther.
+func PermissionRoleTier(role string) RoleTier {
+	switch role {
+	case sharepoint.RoleNameFullControl:
+		return RoleTierFullControl
+	case sharepoint.RoleNameContribute, sharepoint.RoleNameEdit, sharepoint.RoleNameDesign:
+		return RoleTierEdit
+	case sharepoint.RoleNameRead, sharepoint.RoleNameViewOnly:
+		return RoleTierRead
+	}
+	return RoleTierOther
+}
+
+// PermissionMatrixToCSV lays out a permission matrix with a row per object, preserving their order, and a
+// column per principal or per role. Multiple roles or principals in a cell are separated by semicolons.
+func (p *PermissionPresenter) PermissionMatrixToCSV(data *application.PermissionMatrixData, layout string) CSVTable {
+	table := CSVTable{
+		Header: []string{"Type", "Object", "URL"},
+		Rows:   make([][]string, 0, len(data.Objects)),
+	}
+
+	if layout == PermissionMatrixByRole {
+		table.Header = append(table.Header, data.Roles...)
+		titles := matrixPrincipalTitles(data.Principals)
+		for _, object := range data.Objects {
+			holders := make(map[string][]string, len(data.Roles))
+			for _, principal := range data.Principals {
+				for _, role := range object.Grants[principal.ID] {
+					holders[role] = append(holders[role], titles[principal.ID])
+				}
+			}
+			row := matrixObjectCells(object)
+			for _, role := range data.Roles {
+				row = append(row, strings.Join(holders[role], "; "))
+			}
+			table.Rows = append(table.Rows, row)
+		}
+		return table
+	}
+
+	titles := matrixPrincipalTitles(data.Principals)
+	for _, principal := range data.Principals {
+		table.Header = append(table.Header, titles[principal.ID])
+	}
+	for _, object := range data.Objects {
+		row := matrixObjectCells(object)
+		for _, principal := range data.Principals {
+			row = append(row, strings.Join(object.Grants[principal.ID], "; "))
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+// matrixObjectCells returns the cells describing an object at the start of its matrix row.
+func matrixObjectCells(object *application.PermissionMatrixObject) []string {
+	title := object.Title
+	if title == "" {
+		title = object.Key
+	}
+	var kind string
+	switch object.Type {
+	case sharepoint.ObjectTypeWeb:
+		kind = "Web"
+	case sharepoint.ObjectTypeList:
+		kind = "List"
+	default:
+		kind = "Item"
+	}
+	return []string{kind, title, object.URL}
+}
+
+// matrixPrincipalTitles names each principal by title, or login name when it has none. Principals
+// sharing a title are told apart by their login name.
+func matrixPrincipalTitles(principals []*sharepoint.Principal) map[int64]string {
+	counts := make(map[string]int, len(principals))
+	for _, principal := range principals {
+		counts[principal.Title]++
+	}
+	titles := make(map[int64]string, len(principals))
+	for _, principal := range principals {
+		switch {
+		case principal.Title == "":
+			titles[principal.ID] = principal.LoginName
+		case counts[principal.Title] > 1 && principal.LoginName != "":
+			titles[principal.ID] = principal.Title + " (" + principal.LoginName + ")"
+		default:
+			titles[principal.ID] = principal.Title
+		}
+	}
+	return titles
+}
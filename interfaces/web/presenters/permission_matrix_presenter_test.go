@@ -0,0 +1,59 @@
+package presenters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+func TestPermissionPresenter_PermissionMatrixToCSV(t *testing.T) {
+	presenter := NewPermissionPresenter()
+	data := &application.PermissionMatrixData{
+		Objects: []*application.PermissionMatrixObject{
+			{Type: sharepoint.ObjectTypeList, Key: "docs", Title: "Documents", URL: "https://contoso.sharepoint.com/sites/a/docs",
+				Grants: map[int64][]string{3: {"Full Control"}, 5: {"Read"}}},
+			{Type: sharepoint.ObjectTypeItem, Key: "guid-1", URL: "https://contoso.sharepoint.com/sites/a/docs/a.docx",
+				Grants: map[int64][]string{3: {"Full Control"}, 10: {"Edit", "Read"}, 11: {"Read"}}},
+		},
+		Principals: []*sharepoint.Principal{
+			{ID: 10, Title: "Ada", LoginName: "i:0#.f|membership|ada@contoso.com"},
+			{ID: 11, Title: "Ada", LoginName: "i:0#.f|membership|ada@fabrikam.com"},
+			{ID: 3, Title: "Owners"},
+			{ID: 5, LoginName: "c:0-.f|rolemanager|spo-grid-all-users"},
+		},
+		Roles: []string{"Full Control", "Edit", "Read"},
+	}
+
+	table := presenter.PermissionMatrixToCSV(data, PermissionMatrixByPrincipal)
+	assert.Equal(t, []string{"Type", "Object", "URL",
+		"Ada (i:0#.f|membership|ada@contoso.com)", "Ada (i:0#.f|membership|ada@fabrikam.com)", "Owners", "c:0-.f|rolemanager|spo-grid-all-users"}, table.Header)
+	assert.Equal(t, [][]string{
+		{"List", "Documents", "https://contoso.sharepoint.com/sites/a/docs", "", "", "Full Control", "Read"},
+		{"Item", "guid-1", "https://contoso.sharepoint.com/sites/a/docs/a.docx", "Edit; Read", "Read", "Full Control", ""},
+	}, table.Rows)
+
+	table = presenter.PermissionMatrixToCSV(data, PermissionMatrixByRole)
+	assert.Equal(t, []string{"Type", "Object", "URL", "Full Control", "Edit", "Read"}, table.Header)
+	assert.Equal(t, []string{"Full Control", "Edit", "Read"}, table.Header[PermissionMatrixObjectColumns:])
+	assert.Equal(t, []string{"Item", "guid-1", "https://contoso.sharepoint.com/sites/a/docs/a.docx",
+		"Owners", "Ada (i:0#.f|membership|ada@contoso.com)", "Ada (i:0#.f|membership|ada@contoso.com); Ada (i:0#.f|membership|ada@fabrikam.com)"}, table.Rows[1])
+}
+
+func TestParsePermissionMatrixLayout(t *testing.T) {
+	layout, ok := ParsePermissionMatrixLayout("")
+	assert.True(t, ok)
+	assert.Equal(t, PermissionMatrixByPrincipal, layout)
+	layout, ok = ParsePermissionMatrixLayout("role")
+	assert.True(t, ok)
+	assert.Equal(t, PermissionMatrixByRole, layout)
+	_, ok = ParsePermissionMatrixLayout("group")
+	assert.False(t, ok)
+
+	assert.Equal(t, RoleTierFullControl, PermissionRoleTier("Full Control"))
+	assert.Equal(t, RoleTierEdit, PermissionRoleTier("Design"))
+	assert.Equal(t, RoleTierRead, PermissionRoleTier("View Only"))
+	assert.Equal(t, RoleTierOther, PermissionRoleTier("Approve"))
+}
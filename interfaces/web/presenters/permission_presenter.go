@@ -2,6 +2,7 @@ package presenters
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 
 	"spaudit/application"
@@ -27,6 +28,7 @@ type SiteWithMetadata struct {
 	ListsWithUnique int
 	LastAuditDate   string // Formatted relative date
 	DaysAgo         int
+	RunAlias        string // Run the site's pages open: "latest", or "latest@<label>" when sites are filtered by label
 }
 
 // ListSummary represents list data for table display.
@@ -42,19 +44,86 @@ type ListSummary struct {
 	WebTitle     string
 	LastModified string
 	AuditRunID   int64
+
+	UniqueItemCount         int64
+	UniqueDensity           float64
+	UniqueDensityText       string
+	ExceedsDensityThreshold bool
+
+	// Access counts materialized by the audit, shown as badges for triage
+	UniqueAssignmentCount  int64
+	ExternalPrincipalCount int64
+	SharingLinkCount       int64
+
+	// Set when only a sample of the list's items was deep-scanned; unique counts are then estimates
+	Sampled          bool
+	SampledItemCount int64
+	SamplingText     string
+
+	// Item permission defaults and Microsoft Lists features, empty when the run did not capture them
+	ItemAccessText string   // Restrictions on what users can read or edit, e.g. "Read own items"
+	ListFeatures   []string // Features that show who interacted with items, e.g. "Comments"
+}
+
+// withItemSettings describes the list's item permission defaults and the features readers can see.
+func withItemSettings(summary ListSummary, list *sharepoint.List) ListSummary {
+	settings := list.ItemSettings
+	if settings == nil {
+		return summary
+	}
+	var restrictions []string
+	if settings.ReadsOwnItemsOnly() {
+		restrictions = append(restrictions, "Read own items")
+	}
+	if settings.EditsOwnItemsOnly() {
+		restrictions = append(restrictions, "Edit own items")
+	} else if settings.ReadOnly() {
+		restrictions = append(restrictions, "No item edits")
+	}
+	summary.ItemAccessText = strings.Join(restrictions, " · ")
+	// Libraries keep comments in the files themselves; item comments are a list feature
+	if settings.CommentsVisible() && !list.IsDocumentLibrary() {
+		summary.ListFeatures = append(summary.ListFeatures, "Comments")
+	}
+	if settings.HasRatings() {
+		summary.ListFeatures = append(summary.ListFeatures, settings.RatingExperience)
+	}
+	return summary
+}
+
+// withSampling labels the summary of a sampled list, whose unique counts are estimates.
+func withSampling(summary ListSummary, list *sharepoint.List) ListSummary {
+	if !list.IsSampled() {
+		return summary
+	}
+	summary.Sampled = true
+	summary.SampledItemCount = int64(list.SampledItemCount)
+	summary.UniqueDensityText = fmt.Sprintf("≈%.1f%% ±%.1f%%", list.UniqueDensity*100, list.UniqueDensityMargin()*100)
+	summary.SamplingText = fmt.Sprintf("Estimated from a sample of %d of %d items", list.SampledItemCount, list.ItemCount)
+	return summary
+}
+
+// UniqueItemsText describes the list's unique item count, marked as an estimate for sampled lists.
+func (l ListSummary) UniqueItemsText() string {
+	if l.Sampled {
+		return fmt.Sprintf("≈%d unique items (estimated)", l.UniqueItemCount)
+	}
+	return fmt.Sprintf("%d unique items", l.UniqueItemCount)
 }
 
 // ItemSummary represents item data for permission analysis.
 type ItemSummary struct {
-	SiteID    int64
-	ItemGUID  string
-	ListID    string
-	ItemID    int64
-	URL       string
-	IsFile    bool
-	IsFolder  bool
-	HasUnique bool
-	Name      string
+	SiteID     int64
+	ItemGUID   string
+	ListID     string
+	ItemID     int64
+	URL        string
+	IsFile     bool
+	IsFolder   bool
+	HasUnique  bool
+	Name       string
+	Size       string // Formatted file size, empty for folders and list items
+	ModifiedAt string
 }
 
 // Assignment represents a permission assignment.
@@ -62,7 +131,9 @@ type Assignment struct {
 	PrincipalTitle string
 	LoginName      string
 	PrincipalType  int32
+	PrincipalKind  sharepoint.PrincipalKind
 	RoleName       string
+	LimitedAccess  bool // Navigation-only level SharePoint grants automatically
 	Inherited      bool
 }
 
@@ -83,13 +154,35 @@ type ExpandableAssignment struct {
 	HasRootCauses bool          // Whether any root causes were found
 	// Unique identifier for HTMX interactions
 	UniqueID string
+	// Link behind a sharing link group principal, nil for other principals
+	SharingLink *UnwrappedSharingLink
+	// Members of a SharePoint group principal, nil for other principals
+	SiteGroup *ExpandedSiteGroup
 }
 
-type AssignmentCollection struct {
-	Assignments      []Assignment
-	HasLimitedAccess bool
-	HasSharingLinks  bool
-	HasSiteGroups    bool
+// UnwrappedSharingLink is the sharing link a "SharingLinks.*" group assignment stands for.
+type UnwrappedSharingLink struct {
+	LinkID       string
+	LinkKindName string
+	IsEditLink   bool
+	ItemName     string
+	IsFile       bool
+	IsFolder     bool
+	Members      []SharingLinkMember
+}
+
+// ExpandedSiteGroup is who a SharePoint group assignment grants its role to.
+type ExpandedSiteGroup struct {
+	GroupID int64
+	Members []SharingLinkMember
+}
+
+// MembersLabel returns the member count for the group's expand toggle.
+func (g *ExpandedSiteGroup) MembersLabel() string {
+	if len(g.Members) == 1 {
+		return "1 member"
+	}
+	return fmt.Sprintf("%d members", len(g.Members))
 }
 
 type ExpandableAssignmentCollection struct {
@@ -97,6 +190,20 @@ type ExpandableAssignmentCollection struct {
 	HasLimitedAccess bool
 	HasSharingLinks  bool
 	HasSiteGroups    bool
+	ListID           string       // List the assignments belong to, when built for a list
+	InheritsFromWeb  bool         // The object has no unique permissions, so its assignments are the web's
+	Columns          ColumnLayout // Columns the assignments table renders
+	TotalCount       int          // Assignments of the object, including chunks not loaded yet
+	NextURL          string       // Loads the next chunk of rows; empty once every assignment is shown
+}
+
+// WebAssignments represents a web with its expandable role assignments.
+type WebAssignments struct {
+	WebID       string
+	Title       string
+	URL         string
+	HasUnique   bool
+	Assignments ExpandableAssignmentCollection
 }
 
 type SharingLink struct {
@@ -124,6 +231,9 @@ type SharingLink struct {
 	CreatedByLogin     string
 	ModifiedByTitle    string
 	ModifiedByLogin    string
+	HasAnalytics       bool  // View counts were collected for the item
+	ViewCount          int64 // All-time views and downloads of the item
+	ViewerCount        int64 // Distinct users who viewed or downloaded the item
 }
 
 type SharingLinkMember struct {
@@ -133,7 +243,22 @@ type SharingLinkMember struct {
 	LoginName     string
 	Email         string
 	PrincipalType int64
+	PrincipalKind sharepoint.PrincipalKind
 	IsGroup       bool
+	Added         bool // Joined the link since the previous audit run
+}
+
+// SharingLinkMemberChanges represents sharing link members with changes since the previous audit run.
+type SharingLinkMemberChanges struct {
+	Members            []SharingLinkMember
+	Removed            []SharingLinkMember
+	AddedCount         int
+	PreviousAuditRunID int64 // 0 when no earlier audit run captured the link
+}
+
+// HasPreviousRun reports whether an earlier audit run captured the link.
+func (c SharingLinkMemberChanges) HasPreviousRun() bool {
+	return c.PreviousAuditRunID != 0
 }
 
 type ResolvedAssignment struct {
@@ -177,11 +302,18 @@ type ListAnalytics struct {
 	RiskFromAssignments    float64 // Points from permission assignments
 	RiskFromSharingLinks   float64 // Points from sharing links
 	RiskFromElevatedAccess float64 // Points from Full Control/Contribute
+	RiskLowReduced         bool    // Factors were halved for a list granting only limited or read access
+	RiskRaisedForDensity   bool    // Level raised to Medium by the unique density
+	UniqueDensityThreshold float64
+	ExceptedObjects        int // Accepted exceptions left out of the figures
 
 	// Principal breakdown
-	UserCount        int
-	GroupCount       int
-	SharingLinkUsers int
+	UserCount             int
+	GroupCount            int
+	SharingLinkUsers      int
+	EffectiveUsers        int // Distinct users reached once SharePoint and resolved Entra groups are expanded
+	ExpandedEntraGroups   int
+	UnexpandedEntraGroups int // Entra groups whose users are not in EffectiveUsers
 
 	// Sharing link type breakdown
 	FlexibleLinksCount    int
@@ -210,39 +342,13 @@ func NewPermissionPresenter() *PermissionPresenter {
 
 // Collection constructor methods with business logic
 
-func (p *PermissionPresenter) NewAssignmentCollection(assignments []Assignment) AssignmentCollection {
-	hasLimitedAccess := false
-	hasSharingLinks := false
-	hasSiteGroups := false
-
-	for _, assignment := range assignments {
-		if assignment.RoleName == "Limited Access" || assignment.RoleName == "Web-Only Limited Access" {
-			hasLimitedAccess = true
-		}
-		if len(assignment.LoginName) > 12 && assignment.LoginName[:12] == "SharingLinks" {
-			hasSharingLinks = true
-		}
-		// Detect site groups: groups with common site group names that have direct (non-inherited) permissions
-		if !assignment.Inherited && (containsSiteGroupPattern(assignment.PrincipalTitle) || containsSiteGroupPattern(assignment.LoginName)) {
-			hasSiteGroups = true
-		}
-	}
-
-	return AssignmentCollection{
-		Assignments:      assignments,
-		HasLimitedAccess: hasLimitedAccess,
-		HasSharingLinks:  hasSharingLinks,
-		HasSiteGroups:    hasSiteGroups,
-	}
-}
-
 func (p *PermissionPresenter) NewExpandableAssignmentCollection(assignments []ExpandableAssignment) ExpandableAssignmentCollection {
 	hasLimitedAccess := false
 	hasSharingLinks := false
 	hasSiteGroups := false
 
 	for _, assignment := range assignments {
-		if assignment.RoleName == "Limited Access" || assignment.RoleName == "Web-Only Limited Access" {
+		if assignment.LimitedAccess {
 			hasLimitedAccess = true
 		}
 		if len(assignment.LoginName) > 12 && assignment.LoginName[:12] == "SharingLinks" {
@@ -259,6 +365,7 @@ func (p *PermissionPresenter) NewExpandableAssignmentCollection(assignments []Ex
 		HasLimitedAccess: hasLimitedAccess,
 		HasSharingLinks:  hasSharingLinks,
 		HasSiteGroups:    hasSiteGroups,
+		Columns:          DefaultColumnLayout(DataTableAssignments),
 	}
 }
 
@@ -296,6 +403,13 @@ func (p *PermissionPresenter) ToListAnalyticsViewModel(data *application.Permiss
 		RiskFromAssignments:    data.RiskFromAssignments,
 		RiskFromSharingLinks:   data.RiskFromSharingLinks,
 		RiskFromElevatedAccess: data.RiskFromElevatedAccess,
+		RiskLowReduced:         data.RiskLowReduced,
+		RiskRaisedForDensity:   data.RiskRaisedForDensity,
+		UniqueDensityThreshold: data.UniqueDensityThreshold,
+		ExceptedObjects:        data.ExceptedObjects,
+		EffectiveUsers:         data.EffectiveUsers,
+		ExpandedEntraGroups:    data.ExpandedEntraGroups,
+		UnexpandedEntraGroups:  data.UnexpandedEntraGroups,
 	}
 }
 
@@ -307,7 +421,7 @@ func (p *PermissionPresenter) MapListToViewModel(list *sharepoint.List) ListSumm
 		auditRunID = *list.AuditRunID
 	}
 	
-	return ListSummary{
+	return withSampling(ListSummary{
 		SiteID:     list.SiteID,
 		ListID:     list.ID,
 		WebID:      list.WebID,
@@ -317,21 +431,26 @@ func (p *PermissionPresenter) MapListToViewModel(list *sharepoint.List) ListSumm
 		HasUnique:  list.HasUnique,
 		WebTitle:   list.Title,
 		AuditRunID: auditRunID,
-	}
+	}, list)
 }
 
 func (p *PermissionPresenter) MapItemToViewModel(item *sharepoint.Item) ItemSummary {
-	return ItemSummary{
-		SiteID:    item.SiteID,
-		ItemGUID:  item.GUID,
-		ListID:    item.ListID,
-		ItemID:    int64(item.ID),
-		URL:       item.URL,
-		IsFile:    item.IsFile,
-		IsFolder:  item.IsFolder,
-		HasUnique: item.HasUnique,
-		Name:      item.Name,
+	summary := ItemSummary{
+		SiteID:     item.SiteID,
+		ItemGUID:   item.GUID,
+		ListID:     item.ListID,
+		ItemID:     int64(item.ID),
+		URL:        item.URL,
+		IsFile:     item.IsFile,
+		IsFolder:   item.IsFolder,
+		HasUnique:  item.HasUnique,
+		Name:       item.Name,
+		ModifiedAt: formatDetailTime(item.ModifiedAt),
 	}
+	if item.IsFile && item.Size > 0 {
+		summary.Size = formatByteSize(item.Size)
+	}
+	return summary
 }
 
 func (p *PermissionPresenter) MapAssignmentToViewModel(assignment *sharepoint.Assignment) Assignment {
@@ -339,7 +458,9 @@ func (p *PermissionPresenter) MapAssignmentToViewModel(assignment *sharepoint.As
 		PrincipalTitle: assignment.Principal.GetDisplayName(),
 		LoginName:      assignment.Principal.LoginName,
 		PrincipalType:  int32(assignment.Principal.PrincipalType),
+		PrincipalKind:  assignment.Principal.Kind(),
 		RoleName:       assignment.RoleDefinition.Name,
+		LimitedAccess:  assignment.RoleDefinition.IsLimitedAccess(),
 		Inherited:      assignment.IsInherited(),
 	}
 }
@@ -362,6 +483,29 @@ func (p *PermissionPresenter) MapResolvedAssignmentToViewModel(resolved *sharepo
 	}
 }
 
+// ToSharingLinkMemberChanges converts sharing link members and their changes to view model.
+func (p *PermissionPresenter) ToSharingLinkMemberChanges(data *application.SharingLinkMemberChangesData) SharingLinkMemberChanges {
+	added := make(map[int64]bool, len(data.Added))
+	for _, principal := range data.Added {
+		added[principal.ID] = true
+	}
+
+	changes := SharingLinkMemberChanges{
+		Members:            make([]SharingLinkMember, len(data.Members)),
+		Removed:            make([]SharingLinkMember, len(data.Removed)),
+		AddedCount:         len(data.Added),
+		PreviousAuditRunID: data.PreviousAuditRunID,
+	}
+	for i, principal := range data.Members {
+		changes.Members[i] = p.MapPrincipalToSharingLinkMemberViewModel(principal)
+		changes.Members[i].Added = added[principal.ID]
+	}
+	for i, principal := range data.Removed {
+		changes.Removed[i] = p.MapPrincipalToSharingLinkMemberViewModel(principal)
+	}
+	return changes
+}
+
 func (p *PermissionPresenter) MapPrincipalToSharingLinkMemberViewModel(principal *sharepoint.Principal) SharingLinkMember {
 	return SharingLinkMember{
 		SiteID:        principal.SiteID,
@@ -370,6 +514,7 @@ func (p *PermissionPresenter) MapPrincipalToSharingLinkMemberViewModel(principal
 		LoginName:     principal.LoginName,
 		Email:         principal.Email,
 		PrincipalType: principal.PrincipalType,
+		PrincipalKind: principal.Kind(),
 		IsGroup:       principal.IsGroup() || principal.IsSharePointGroup(),
 	}
 }
@@ -388,6 +533,10 @@ func (p *PermissionPresenter) ToAssignmentToggleHTML(resolved *sharepoint.Resolv
 				var icon, title, detail string
 
 				switch rootCause.Type {
+				case "DIRECT_ASSIGNMENT":
+					icon = `<span class="text-indigo-600">🎯</span>`
+					title = "Direct Assignment"
+					detail = rootCause.Detail
 				case "SHARING_LINK":
 					icon = `<span class="text-amber-600">🔗</span>`
 					title = "Sharing Link Permission"
@@ -446,8 +595,49 @@ func (p *PermissionPresenter) ToAssignmentToggleHTML(resolved *sharepoint.Resolv
 	}
 }
 
-// ToExpandableAssignmentCollection converts resolved assignments to expandable assignment collection.
+// ToExpandableAssignmentCollection converts resolved list assignments to expandable assignment collection.
 func (p *PermissionPresenter) ToExpandableAssignmentCollection(resolvedAssignments []*sharepoint.ResolvedAssignment, listID string) ExpandableAssignmentCollection {
+	collection := p.ToExpandableObjectAssignmentCollection(resolvedAssignments, sharepoint.ObjectTypeList, listID)
+	collection.ListID = listID
+	return collection
+}
+
+// ToExpandableObjectAssignmentCollection converts resolved assignments on a web, list or item to expandable assignment collection.
+// Unique IDs have the form assignment-{objectType}-{objectKey}-{index} so toggles can reload the assignment.
+func (p *PermissionPresenter) ToExpandableObjectAssignmentCollection(resolvedAssignments []*sharepoint.ResolvedAssignment, objectType, objectKey string) ExpandableAssignmentCollection {
+	collection := p.NewExpandableAssignmentCollection(p.toExpandableAssignments(resolvedAssignments, objectType, objectKey, 0))
+	collection.TotalCount = len(resolvedAssignments)
+	return collection
+}
+
+// AssignmentsChunkSize is the number of assignment rows the list assignments tab renders at a time.
+const AssignmentsChunkSize = 100
+
+// ToAssignmentsChunkCollection converts one chunk of a list's assignments to an expandable collection.
+// Unique IDs keep each assignment's position among all of the list's, and on the first chunk the help
+// cards cover every assignment, not just the chunk's.
+func (p *PermissionPresenter) ToAssignmentsChunkCollection(page *application.AssignmentsPage, siteID, auditRunID int64, listID string) ExpandableAssignmentCollection {
+	collection := p.NewExpandableAssignmentCollection(p.toExpandableAssignments(page.Assignments, sharepoint.ObjectTypeList, listID, page.Offset))
+	collection.ListID = listID
+	if page.All != nil {
+		all := make([]ExpandableAssignment, len(page.All))
+		for i, assignment := range page.All {
+			all[i] = ExpandableAssignment{Assignment: p.MapAssignmentToViewModel(assignment)}
+		}
+		summary := p.NewExpandableAssignmentCollection(all)
+		collection.HasLimitedAccess = summary.HasLimitedAccess
+		collection.HasSharingLinks = summary.HasSharingLinks
+		collection.HasSiteGroups = summary.HasSiteGroups
+		collection.TotalCount = len(page.All)
+	}
+	if page.NextCursor != "" {
+		collection.NextURL = fmt.Sprintf("/sites/%d/audit-runs/%d/tabs/%s/assignments/rows?cursor=%s", siteID, auditRunID, listID, url.QueryEscape(page.NextCursor))
+	}
+	return collection
+}
+
+// toExpandableAssignments converts resolved assignments of an object starting at offset to view models.
+func (p *PermissionPresenter) toExpandableAssignments(resolvedAssignments []*sharepoint.ResolvedAssignment, objectType, objectKey string, offset int) []ExpandableAssignment {
 	vm := make([]ExpandableAssignment, len(resolvedAssignments))
 	for i, resolved := range resolvedAssignments {
 		baseAssignment := p.MapAssignmentToViewModel(resolved.Assignment)
@@ -456,11 +646,58 @@ func (p *PermissionPresenter) ToExpandableAssignmentCollection(resolvedAssignmen
 			Assignment:    baseAssignment,
 			RootCauses:    resolvedVM.RootCauses,
 			HasRootCauses: len(resolvedVM.RootCauses) > 0,
-			UniqueID:      fmt.Sprintf("assignment-%s-%d", listID, i),
+			UniqueID:      fmt.Sprintf("assignment-%s-%s-%d", objectType, objectKey, offset+i),
+		}
+		if resolved.SharingLink != nil {
+			vm[i].SharingLink = p.toUnwrappedSharingLink(resolved.SharingLink)
+		}
+		if principal := resolved.Assignment.Principal; principal != nil && principal.IsSharePointGroup() && !principal.IsSharingLinkPrincipal() {
+			vm[i].SiteGroup = p.toExpandedSiteGroup(principal.ID, resolved.GroupMembers)
 		}
 	}
+	return vm
+}
 
-	return p.NewExpandableAssignmentCollection(vm)
+// toUnwrappedSharingLink converts the link behind a sharing link group assignment to view model.
+func (p *PermissionPresenter) toUnwrappedSharingLink(linkData *sharepoint.SharingLinkWithItemData) *UnwrappedSharingLink {
+	link := linkData.SharingLink
+	unwrapped := &UnwrappedSharingLink{
+		LinkID:       link.ID,
+		LinkKindName: link.GetLinkKindName(),
+		IsEditLink:   link.IsEditLink,
+		ItemName:     linkData.ItemName,
+		IsFile:       linkData.ItemIsFile,
+		IsFolder:     linkData.ItemIsFolder,
+		Members:      make([]SharingLinkMember, len(link.Members)),
+	}
+	for i, member := range link.Members {
+		unwrapped.Members[i] = p.MapPrincipalToSharingLinkMemberViewModel(member)
+	}
+	return unwrapped
+}
+
+// toExpandedSiteGroup converts the members of a SharePoint group assignment to view model.
+func (p *PermissionPresenter) toExpandedSiteGroup(groupID int64, members []*sharepoint.Principal) *ExpandedSiteGroup {
+	group := &ExpandedSiteGroup{GroupID: groupID, Members: make([]SharingLinkMember, len(members))}
+	for i, member := range members {
+		group.Members[i] = p.MapPrincipalToSharingLinkMemberViewModel(member)
+	}
+	return group
+}
+
+// ToWebAssignmentsViewModels converts webs and their resolved assignments to view models.
+func (p *PermissionPresenter) ToWebAssignmentsViewModels(data []*application.WebAssignmentsData) []WebAssignments {
+	vms := make([]WebAssignments, len(data))
+	for i, webData := range data {
+		vms[i] = WebAssignments{
+			WebID:       webData.Web.ID,
+			Title:       webData.Web.Title,
+			URL:         webData.Web.URL,
+			HasUnique:   webData.Web.HasUnique,
+			Assignments: p.ToExpandableObjectAssignmentCollection(webData.Assignments, sharepoint.ObjectTypeWeb, webData.Web.ID),
+		}
+	}
+	return vms
 }
 
 // MapSharingLinkWithItemDataToViewModel converts domain model to view model for UI display.
@@ -479,7 +716,7 @@ func (p *PermissionPresenter) MapSharingLinkWithItemDataToViewModel(linkData *sh
 		createdByTitle = link.CreatedBy.Title
 	}
 
-	return SharingLink{
+	vm := SharingLink{
 		SiteID:             link.SiteID,
 		LinkID:             link.ID,
 		ItemGUID:           link.ItemGUID,
@@ -498,6 +735,12 @@ func (p *PermissionPresenter) MapSharingLinkWithItemDataToViewModel(linkData *sh
 		CreatedByTitle:     createdByTitle,
 		ActualMembersCount: int64(link.TotalMembersCount),
 	}
+	if linkData.ItemAnalytics != nil {
+		vm.HasAnalytics = true
+		vm.ViewCount = linkData.ItemAnalytics.ViewCount
+		vm.ViewerCount = linkData.ItemAnalytics.ViewerCount
+	}
+	return vm
 }
 
 // containsSiteGroupPattern checks if a principal name indicates a SharePoint site group
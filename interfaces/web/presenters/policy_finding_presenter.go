@@ -0,0 +1,103 @@
+package presenters
+
+import (
+	"time"
+
+	"spaudit/domain/audit"
+)
+
+// AccessPolicyView is a policy audit runs are checked against, with the conditions it sets.
+type AccessPolicyView struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+	Target      string `json:"target"`
+	Remediation string `json:"remediation"`
+	Audience    string `json:"audience,omitempty"`
+	Edit        bool   `json:"edit,omitempty"`
+	Principal   string `json:"principal,omitempty"`
+	Role        string `json:"role,omitempty"`
+	ObjectType  string `json:"object_type,omitempty"`
+	Label       string `json:"label,omitempty"`
+}
+
+// PolicyFindingView is a sharing link or grant violating an access policy.
+type PolicyFindingView struct {
+	ID          int64  `json:"id"`
+	SiteID      int64  `json:"site_id"`
+	SiteURL     string `json:"site_url,omitempty"`
+	AuditRunID  int64  `json:"audit_run_id"`
+	Policy      string `json:"policy"`
+	Severity    string `json:"severity"`
+	ObjectType  string `json:"object_type"`
+	ObjectKey   string `json:"object_key"`
+	ObjectURL   string `json:"object_url"`
+	ObjectName  string `json:"object_name"`
+	Subject     string `json:"subject"`
+	Detail      string `json:"detail"`
+	Remediation string `json:"remediation"`
+}
+
+// PolicyEvaluationView is an audit run's check against the access policies with its findings.
+type PolicyEvaluationView struct {
+	SiteID      int64               `json:"site_id"`
+	AuditRunID  int64               `json:"audit_run_id"`
+	EvaluatedAt string              `json:"evaluated_at"`
+	Policies    int                 `json:"policies"`
+	Findings    []PolicyFindingView `json:"findings"`
+}
+
+// ToAccessPolicyViews converts access policies for the API, preserving their order.
+func (p *ListPresenter) ToAccessPolicyViews(policies []audit.AccessPolicy) []AccessPolicyView {
+	views := make([]AccessPolicyView, len(policies))
+	for i, policy := range policies {
+		views[i] = AccessPolicyView{
+			Name:        policy.Name,
+			Description: policy.Description,
+			Severity:    string(policy.Severity),
+			Target:      policy.Target,
+			Remediation: policy.Remediation,
+			Audience:    policy.Audience,
+			Edit:        policy.Edit,
+			Principal:   policy.Principal,
+			Role:        policy.Role,
+			ObjectType:  policy.ObjectType,
+			Label:       policy.Label,
+		}
+	}
+	return views
+}
+
+// ToPolicyFindingViews converts policy findings for the API, preserving their order.
+func (p *ListPresenter) ToPolicyFindingViews(findings []*audit.PolicyFinding) []PolicyFindingView {
+	views := make([]PolicyFindingView, len(findings))
+	for i, finding := range findings {
+		views[i] = PolicyFindingView{
+			ID:          finding.ID,
+			SiteID:      finding.SiteID,
+			SiteURL:     finding.SiteURL,
+			AuditRunID:  finding.AuditRunID,
+			Policy:      finding.Policy,
+			Severity:    string(finding.Severity),
+			ObjectType:  finding.ObjectType,
+			ObjectKey:   finding.ObjectKey,
+			ObjectURL:   finding.ObjectURL,
+			ObjectName:  finding.ObjectName,
+			Subject:     finding.Subject,
+			Detail:      finding.Detail,
+			Remediation: finding.Remediation,
+		}
+	}
+	return views
+}
+
+// ToPolicyEvaluationView converts an audit run's policy evaluation for the API.
+func (p *ListPresenter) ToPolicyEvaluationView(evaluation *audit.PolicyEvaluation) PolicyEvaluationView {
+	return PolicyEvaluationView{
+		SiteID:      evaluation.SiteID,
+		AuditRunID:  evaluation.AuditRunID,
+		EvaluatedAt: evaluation.EvaluatedAt.UTC().Format(time.RFC3339),
+		Policies:    evaluation.Policies,
+		Findings:    p.ToPolicyFindingViews(evaluation.Findings),
+	}
+}
@@ -0,0 +1,83 @@
+package presenters
+
+import (
+	"sort"
+	"time"
+
+	"spaudit/application"
+)
+
+// PolicyPackView is a policy pack and how it differs from the global defaults.
+type PolicyPackView struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Severities  map[string]string `json:"severities"` // Categories the pack rates differently, by category
+	Muted       []string          `json:"muted"`      // Categories raising no alerts, sorted
+}
+
+// SitePolicyView is the policy pack a site is checked with, and the severity of every finding
+// category under it.
+type SitePolicyView struct {
+	SiteID       int64                    `json:"site_id"`
+	AuditRunID   int64                    `json:"audit_run_id,omitempty"`
+	Template     string                   `json:"template"`
+	DetectedPack string                   `json:"detected_pack"`
+	Pack         string                   `json:"pack"`
+	Overridden   bool                     `json:"overridden"`
+	OverriddenAt string                   `json:"overridden_at,omitempty"`
+	Categories   []SitePolicyCategoryView `json:"categories"`
+}
+
+// SitePolicyCategoryView is the severity of one finding category under a site's policy pack, and
+// whether the pack mutes its alerts.
+type SitePolicyCategoryView struct {
+	FindingSeverityView
+	Muted bool `json:"muted"`
+}
+
+// ToPolicyPackViews converts policy packs to their API view.
+func (p *ListPresenter) ToPolicyPackViews(packs []*application.PolicyPack) []PolicyPackView {
+	views := make([]PolicyPackView, len(packs))
+	for i, pack := range packs {
+		views[i] = PolicyPackView{
+			Name:        pack.Name,
+			Description: pack.Description,
+			Severities:  make(map[string]string, len(pack.Severities)),
+			Muted:       []string{},
+		}
+		for category, severity := range pack.Severities {
+			views[i].Severities[category] = string(severity)
+		}
+		for category, muted := range pack.Muted {
+			if muted {
+				views[i].Muted = append(views[i].Muted, category)
+			}
+		}
+		sort.Strings(views[i].Muted)
+	}
+	return views
+}
+
+// ToSitePolicyView converts a site's policy to its API view.
+func (p *ListPresenter) ToSitePolicyView(policy *application.SitePolicy) SitePolicyView {
+	view := SitePolicyView{
+		SiteID:       policy.SiteID,
+		AuditRunID:   policy.AuditRunID,
+		Template:     policy.Template,
+		DetectedPack: policy.Detected.Name,
+		Pack:         policy.Pack.Name,
+		Overridden:   policy.Override != nil,
+	}
+	if policy.Override != nil {
+		view.OverriddenAt = policy.Override.SetAt.UTC().Format(time.RFC3339)
+	}
+	severities := p.ToFindingSeveritiesView(policy.Severities.Mapping())
+	view.Categories = make([]SitePolicyCategoryView, len(severities.Categories))
+	for i, category := range severities.Categories {
+		view.Categories[i] = SitePolicyCategoryView{
+			FindingSeverityView: category,
+			Muted:               !policy.Severities.Raises(category.Category),
+		}
+	}
+	return view
+}
@@ -0,0 +1,46 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+)
+
+func TestListPresenter_ToSitePolicyView(t *testing.T) {
+	presenter := NewListPresenter()
+	team, _ := application.GetPolicyPack("team")
+	channel, _ := application.GetPolicyPack("channel")
+	setAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	view := presenter.ToSitePolicyView(&application.SitePolicy{
+		SiteID:     1,
+		AuditRunID: 2,
+		Template:   "STS#3",
+		Detected:   team,
+		Pack:       channel,
+		Override:   &audit.SitePolicyPack{SiteID: 1, Pack: "channel", SetAt: setAt},
+		Severities: (*application.FindingSeverities)(nil).ForPack(channel),
+	})
+	assert.Equal(t, "team", view.DetectedPack)
+	assert.Equal(t, "channel", view.Pack)
+	assert.True(t, view.Overridden)
+	assert.Equal(t, "2025-03-01T12:00:00Z", view.OverriddenAt)
+	for _, category := range view.Categories {
+		assert.Equal(t, category.Category == application.RiskyDefaultMembersCanShare || category.Category == application.FolderLinkMemberAdded, category.Muted, category.Category)
+		if category.Category == application.DriftPermissionsChanged {
+			assert.Equal(t, "low", category.Severity)
+			assert.False(t, category.Custom)
+		}
+	}
+
+	packs := presenter.ToPolicyPackViews(application.PolicyPacks())
+	require.Len(t, packs, 3)
+	assert.Empty(t, packs[0].Muted)
+	assert.Equal(t, []string{"folder_inheritance_broken", "list_added", "members_can_share"}, packs[1].Muted)
+	assert.Equal(t, "info", packs[1].Severities[application.DriftListAdded])
+}
@@ -0,0 +1,74 @@
+package presenters
+
+import "spaudit/application"
+
+// PrincipalAccessReportView is everything one principal can access across the latest runs of every audited site.
+type PrincipalAccessReportView struct {
+	Identity     string                           `json:"identity"`
+	Sites        []PrincipalSiteAccessView        `json:"sites"`
+	GrantCount   int                              `json:"grant_count"`
+	SitesScanned int                              `json:"sites_scanned"`
+	Skipped      []PrincipalAccessSkippedSiteView `json:"skipped"`
+}
+
+// PrincipalSiteAccessView is what a principal can access on one site.
+type PrincipalSiteAccessView struct {
+	SiteID     int64                      `json:"site_id"`
+	SiteURL    string                     `json:"site_url"`
+	AuditRunID int64                      `json:"audit_run_id"`
+	Accounts   []SnapshotPrincipal        `json:"accounts"`
+	Groups     []string                   `json:"groups"`
+	Grants     []PrincipalAccessGrantView `json:"grants"`
+}
+
+// PrincipalAccessGrantView is one role or sharing link a principal holds on a site.
+type PrincipalAccessGrantView struct {
+	ObjectType  string `json:"object_type"`
+	ObjectKey   string `json:"object_key"`
+	ObjectURL   string `json:"object_url"`
+	ObjectName  string `json:"object_name"`
+	PrincipalID int64  `json:"principal_id"`
+	Role        string `json:"role"`
+	Through     string `json:"through"`
+	Via         string `json:"via,omitempty"`
+}
+
+// PrincipalAccessSkippedSiteView is a site left out of a principal's access.
+type PrincipalAccessSkippedSiteView struct {
+	SiteID  int64  `json:"site_id"`
+	SiteURL string `json:"site_url"`
+	Reason  string `json:"reason"`
+}
+
+// ToPrincipalAccessReportView converts a principal's access across sites to its API view.
+func (p *ListPresenter) ToPrincipalAccessReportView(data *application.PrincipalAccessData) PrincipalAccessReportView {
+	view := PrincipalAccessReportView{
+		Identity:     data.Identity,
+		Sites:        make([]PrincipalSiteAccessView, len(data.Sites)),
+		GrantCount:   data.GrantCount,
+		SitesScanned: data.SitesScanned,
+		Skipped:      make([]PrincipalAccessSkippedSiteView, len(data.Skipped)),
+	}
+	for i, site := range data.Sites {
+		accounts := make([]SnapshotPrincipal, len(site.Accounts))
+		for j, account := range site.Accounts {
+			accounts[j] = p.toSnapshotPrincipal(account)
+		}
+		grants := make([]PrincipalAccessGrantView, len(site.Grants))
+		for j, grant := range site.Grants {
+			grants[j] = PrincipalAccessGrantView(*grant)
+		}
+		view.Sites[i] = PrincipalSiteAccessView{
+			SiteID:     site.SiteID,
+			SiteURL:    site.SiteURL,
+			AuditRunID: site.AuditRunID,
+			Accounts:   accounts,
+			Groups:     site.Groups,
+			Grants:     grants,
+		}
+	}
+	for i, site := range data.Skipped {
+		view.Skipped[i] = PrincipalAccessSkippedSiteView(*site)
+	}
+	return view
+}
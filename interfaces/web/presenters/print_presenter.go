@@ -0,0 +1,43 @@
+package presenters
+
+import "time"
+
+// SitePrintVM is the printable summary of a site's lists in one audit run.
+type SitePrintVM struct {
+	SiteListsVM
+	GeneratedAt   string
+	SharedNotice  string                   // Set when viewed through a read-only report share, which has no way back into spaudit
+	PageLibraries *PageLibraryExposureView // Nil when the page libraries could not be analyzed
+}
+
+// ListPrintVM is the printable summary of who can access one list in one audit run.
+type ListPrintVM struct {
+	RunContext   RunContext
+	List         ListSummary
+	Assignments  []ExpandableAssignment
+	SharingLinks []SharingLink
+	GeneratedAt  string
+	SharedNotice string // Set when viewed through a read-only report share, which has no way back into spaudit
+}
+
+// ToSitePrintViewModel prepares the site's lists page for printing, with every list in the chosen sort order.
+func (p *ListPresenter) ToSitePrintViewModel(vm SiteListsVM, generatedAt time.Time) SitePrintVM {
+	vm.Lists = p.SortLists(vm.Lists, vm.SortBy)
+	return SitePrintVM{SiteListsVM: vm, GeneratedAt: formatPrintTime(generatedAt)}
+}
+
+// ToListPrintViewModel builds the printable summary of a list from its assignments and sharing links.
+func (p *PermissionPresenter) ToListPrintViewModel(rc RunContext, list ListSummary, assignments ExpandableAssignmentCollection, links []SharingLink, generatedAt time.Time) ListPrintVM {
+	return ListPrintVM{
+		RunContext:   rc,
+		List:         list,
+		Assignments:  assignments.Assignments,
+		SharingLinks: links,
+		GeneratedAt:  formatPrintTime(generatedAt),
+	}
+}
+
+// formatPrintTime formats the time a printable summary was generated.
+func formatPrintTime(t time.Time) string {
+	return t.Format("Jan 2, 2006 3:04 PM")
+}
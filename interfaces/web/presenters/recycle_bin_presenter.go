@@ -0,0 +1,87 @@
+package presenters
+
+import (
+	"time"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+// RecycleBinRemnantsView is what an audit run's recycle bin scan found.
+type RecycleBinRemnantsView struct {
+	SiteID       int64                   `json:"site_id"`
+	AuditRunID   int64                   `json:"audit_run_id"`
+	Scanned      bool                    `json:"scanned"`
+	ScannedAt    *time.Time              `json:"scanned_at,omitempty"`
+	DeletedItems int                     `json:"deleted_items"`
+	Remnants     []RecycleBinRemnantView `json:"remnants"`
+}
+
+// RecycleBinRemnantView is a deleted item that still had active sharing links.
+type RecycleBinRemnantView struct {
+	RecycleBinID   string                `json:"recycle_bin_id"`
+	ItemName       string                `json:"item_name"`
+	ItemURL        string                `json:"item_url"`
+	ItemType       string                `json:"item_type"` // file, folder or item
+	Stage          int                   `json:"stage"`     // 1 first-stage, 2 second-stage recycle bin
+	DeletedAt      time.Time             `json:"deleted_at"`
+	DeletedBy      string                `json:"deleted_by,omitempty"`
+	DeletedByEmail string                `json:"deleted_by_email,omitempty"`
+	ListID         string                `json:"list_id"`
+	ItemGUID       string                `json:"item_guid"`
+	LastAuditRunID int64                 `json:"last_audit_run_id"`
+	Severity       string                `json:"severity"`
+	HasAnyoneLink  bool                  `json:"has_anyone_link"`
+	Links          []DeletedItemLinkView `json:"links"`
+}
+
+// DeletedItemLinkView is a sharing link that was active on a deleted item.
+type DeletedItemLinkView struct {
+	LinkID       string     `json:"link_id"`
+	LinkType     string     `json:"link_type"`
+	IsEditLink   bool       `json:"is_edit_link"`
+	MembersCount int64      `json:"members_count"`
+	Expiration   *time.Time `json:"expiration,omitempty"`
+}
+
+// ToRecycleBinRemnantsView converts an audit run's recycle bin remnants for the API.
+func (p *ListPresenter) ToRecycleBinRemnantsView(siteID, auditRunID int64, data *application.RecycleBinRemnantsData) RecycleBinRemnantsView {
+	view := RecycleBinRemnantsView{
+		SiteID:       siteID,
+		AuditRunID:   auditRunID,
+		Scanned:      data.ScannedAt != nil,
+		ScannedAt:    data.ScannedAt,
+		DeletedItems: data.DeletedItems,
+		Remnants:     make([]RecycleBinRemnantView, len(data.Remnants)),
+	}
+
+	for i, remnant := range data.Remnants {
+		remnantView := RecycleBinRemnantView{
+			RecycleBinID:   remnant.Item.ID,
+			ItemName:       remnant.ItemName,
+			ItemURL:        remnant.Item.URL,
+			ItemType:       remnant.Item.ItemTypeName(),
+			Stage:          remnant.Item.ItemState,
+			DeletedAt:      remnant.Item.DeletedAt,
+			DeletedBy:      remnant.Item.DeletedByName,
+			DeletedByEmail: remnant.Item.DeletedByEmail,
+			ListID:         remnant.ListID,
+			ItemGUID:       remnant.ItemGUID,
+			LastAuditRunID: remnant.AuditRunID,
+			Severity:       string(remnant.Severity),
+			HasAnyoneLink:  remnant.HasAnyoneLink(),
+			Links:          make([]DeletedItemLinkView, len(remnant.Links)),
+		}
+		for j, link := range remnant.Links {
+			remnantView.Links[j] = DeletedItemLinkView{
+				LinkID:       link.LinkID,
+				LinkType:     sharepoint.LinkKindName(link.LinkKind),
+				IsEditLink:   link.IsEditLink,
+				MembersCount: link.MembersCount,
+				Expiration:   link.Expiration,
+			}
+		}
+		view.Remnants[i] = remnantView
+	}
+	return view
+}
@@ -0,0 +1,114 @@
+package presenters
+
+import (
+	"fmt"
+	"time"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+// ReportShareTTLs are the lifetimes offered for a read-only report share, as form values.
+var ReportShareTTLs = []ReportShareTTLOption{
+	{Value: "24h", Label: "1 day"},
+	{Value: "168h", Label: "7 days"},
+	{Value: "720h", Label: "30 days"},
+	{Value: "2160h", Label: "90 days"},
+}
+
+// ReportShareTTLOption is one lifetime offered for a report share.
+type ReportShareTTLOption struct {
+	Value string // A Go duration
+	Label string
+}
+
+// ReportSharesPageVM lists the read-only shares of an audit run with a form creating another.
+type ReportSharesPageVM struct {
+	SiteID         int64
+	SiteURL        string
+	AuditRunID     int64
+	RunURL         string
+	CreateURL      string
+	Lists          []ReportShareListOption
+	SelectedListID string // The list whose summary the form offers; empty for the site summary
+	TTLs           []ReportShareTTLOption
+	Shares         []ReportShareRow
+	CreatedID      int64 // The share just created, whose link is highlighted
+}
+
+// ReportShareListOption is a list whose summary can be shared.
+type ReportShareListOption struct {
+	ID    string
+	Title string
+}
+
+// ReportShareRow is one read-only share of an audit run.
+type ReportShareRow struct {
+	ID        int64
+	Report    string
+	CreatedAt string
+	ExpiresAt string
+	Status    string // Active, Expired or Revoked
+	Active    bool
+	ShareURL  string
+	RevokeURL string
+}
+
+// ReportSharePath returns the path of a read-only report share link.
+func ReportSharePath(token string) string {
+	return "/shared/" + token
+}
+
+// ReportShareNotice describes the share a printable summary is viewed through.
+func ReportShareNotice(share *audit.ReportShare) string {
+	return fmt.Sprintf("Read-only shared view · link expires %s UTC", share.ExpiresAt.UTC().Format(AuditRunTimeFormat))
+}
+
+// ToReportSharesPageVM builds the read-only shares page of an audit run.
+func (p *ListPresenter) ToReportSharesPageVM(siteID, auditRunID int64, siteURL string, lists []*sharepoint.List, shares []*audit.ReportShare, selectedListID string, createdID int64, now time.Time) ReportSharesPageVM {
+	base := fmt.Sprintf("/sites/%d/audit-runs/%d/shares", siteID, auditRunID)
+	vm := ReportSharesPageVM{
+		SiteID:         siteID,
+		SiteURL:        siteURL,
+		AuditRunID:     auditRunID,
+		RunURL:         fmt.Sprintf("/sites/%d/audit-runs/%d/lists", siteID, auditRunID),
+		CreateURL:      base,
+		Lists:          make([]ReportShareListOption, len(lists)),
+		SelectedListID: selectedListID,
+		TTLs:           ReportShareTTLs,
+		Shares:         make([]ReportShareRow, len(shares)),
+		CreatedID:      createdID,
+	}
+	titles := make(map[string]string, len(lists))
+	for i, list := range lists {
+		vm.Lists[i] = ReportShareListOption{ID: list.ID, Title: list.Title}
+		titles[list.ID] = list.Title
+	}
+	for i, share := range shares {
+		row := ReportShareRow{
+			ID:        share.ID,
+			Report:    "Site summary",
+			CreatedAt: share.CreatedAt.UTC().Format(AuditRunTimeFormat),
+			ExpiresAt: share.ExpiresAt.UTC().Format(AuditRunTimeFormat),
+			Active:    share.IsActive(now),
+			ShareURL:  ReportSharePath(share.Token),
+			RevokeURL: fmt.Sprintf("%s/%d/revoke", base, share.ID),
+		}
+		if share.ListID != "" {
+			row.Report = "List summary: " + share.ListID
+			if title, ok := titles[share.ListID]; ok {
+				row.Report = "List summary: " + title
+			}
+		}
+		switch {
+		case share.IsRevoked():
+			row.Status = "Revoked"
+		case !row.Active:
+			row.Status = "Expired"
+		default:
+			row.Status = "Active"
+		}
+		vm.Shares[i] = row
+	}
+	return vm
+}
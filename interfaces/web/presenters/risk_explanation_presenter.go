@@ -0,0 +1,111 @@
+package presenters
+
+import (
+	"fmt"
+	"net/url"
+
+	"spaudit/domain/sharepoint"
+)
+
+// RiskFactor is one factor of a list's risk score with the figures its points were derived from.
+type RiskFactor struct {
+	Name      string
+	Points    float64
+	MaxPoints float64
+	Rule      string // How the points are derived
+	Basis     string // The list's figures the points were derived from
+	LinkLabel string
+	LinkURL   string // The tab showing the objects driving the factor; empty when none do
+}
+
+// PointsText formats the factor's points against its cap.
+func (f RiskFactor) PointsText() string {
+	return fmt.Sprintf("%.1f / %.0f pts", f.Points, f.MaxPoints)
+}
+
+// PercentOfMax returns the factor's points as a share of its cap, 0-100.
+func (f RiskFactor) PercentOfMax() float64 {
+	if f.MaxPoints == 0 {
+		return 0
+	}
+	return f.Points / f.MaxPoints * 100
+}
+
+// RiskFactors explains each factor of the list's risk score, linking to the tab listing the
+// items, assignments or sharing links behind it.
+func (a ListAnalytics) RiskFactors() []RiskFactor {
+	elevated := a.FullControlCount + a.ContributeCount
+	counted := a.TotalAssignments - a.LimitedAccessCount
+	uniqueShare := 0.0
+	if a.TotalItems > 0 {
+		uniqueShare = float64(a.ItemsWithUnique) / float64(a.TotalItems) * 100
+	}
+
+	factors := []RiskFactor{
+		{
+			Name:      "Items with unique permissions",
+			Points:    a.RiskFromUniqueItems,
+			MaxPoints: sharepoint.MaxRiskFromUniqueItems,
+			Rule:      fmt.Sprintf("Up to %.0f points in proportion to the share of items with unique permissions", sharepoint.MaxRiskFromUniqueItems),
+			Basis:     fmt.Sprintf("%d of %d items (%.1f%%)", a.ItemsWithUnique, a.TotalItems, uniqueShare),
+		},
+		{
+			Name:      "Permission assignments",
+			Points:    a.RiskFromAssignments,
+			MaxPoints: sharepoint.MaxRiskFromAssignments,
+			Rule:      fmt.Sprintf("Up to %.0f points, growing logarithmically with the assignments other than Limited Access (10 give about 8 points, 100 about 16)", sharepoint.MaxRiskFromAssignments),
+			Basis:     fmt.Sprintf("%d of %d assignments, excluding %d Limited Access", max(counted, 0), a.TotalAssignments, a.LimitedAccessCount),
+		},
+		{
+			Name:      "Sharing links",
+			Points:    a.RiskFromSharingLinks,
+			MaxPoints: sharepoint.MaxRiskFromSharingLinks,
+			Rule:      fmt.Sprintf("1.5 points per sharing link, up to %.0f", sharepoint.MaxRiskFromSharingLinks),
+			Basis:     fmt.Sprintf("%d sharing links", a.SharingLinkCount),
+		},
+		{
+			Name:      "Elevated access",
+			Points:    a.RiskFromElevatedAccess,
+			MaxPoints: sharepoint.MaxRiskFromElevatedAccess,
+			Rule:      fmt.Sprintf("1.5 points per Full Control or Contribute assignment, up to %.0f", sharepoint.MaxRiskFromElevatedAccess),
+			Basis:     fmt.Sprintf("%d Full Control and %d Contribute assignments", a.FullControlCount, a.ContributeCount),
+		},
+	}
+
+	if a.ItemsWithUnique > 0 {
+		factors[0].LinkLabel, factors[0].LinkURL = "View items with unique permissions", a.tabURL(ListTabItems)
+	}
+	if counted > 0 {
+		factors[1].LinkLabel, factors[1].LinkURL = "View assignments", a.tabURL(ListTabAssignments)
+	}
+	if a.SharingLinkCount > 0 {
+		factors[2].LinkLabel, factors[2].LinkURL = "View sharing links", a.tabURL(ListTabLinks)
+	}
+	if elevated > 0 {
+		factors[3].LinkLabel, factors[3].LinkURL = "View Full Control and Contribute assignments", a.tabURL(ListTabAssignments)
+	}
+	return factors
+}
+
+// RiskNotes explains adjustments made to the sum of the factors and caveats on the figures.
+func (a ListAnalytics) RiskNotes() []string {
+	var notes []string
+	if a.RiskLowReduced {
+		notes = append(notes, fmt.Sprintf("Every factor was halved and the score capped at %.0f points, because the list has no items with unique permissions, sharing links or elevated access.", sharepoint.LowRiskScoreCap))
+	}
+	if a.RiskRaisedForDensity {
+		notes = append(notes, fmt.Sprintf("The level was raised from Low to Medium, because more than %.0f%% of the items have unique permissions.", a.UniqueDensityThreshold*100))
+	}
+	if a.ExceptedObjects > 0 {
+		notes = append(notes, fmt.Sprintf("%d accepted exceptions were left out of the figures and the score until they expire.", a.ExceptedObjects))
+	}
+	if a.List.Sampled {
+		notes = append(notes, "Item figures are estimated from a sample of the list's items.")
+	}
+	return notes
+}
+
+// tabURL returns a tab of the list the analytics describe.
+func (a ListAnalytics) tabURL(tab string) string {
+	return fmt.Sprintf("/sites/%d/audit-runs/%d/tabs/%s/%s", a.List.SiteID, a.List.AuditRunID, url.PathEscape(a.List.ListID), tab)
+}
@@ -0,0 +1,36 @@
+package presenters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAnalytics_RiskFactors(t *testing.T) {
+	analytics := ListAnalytics{
+		List:                   ListSummary{SiteID: 1, AuditRunID: 3, ListID: "docs"},
+		TotalAssignments:       5,
+		LimitedAccessCount:     5,
+		SharingLinkCount:       2,
+		TotalItems:             10,
+		ItemsWithUnique:        4,
+		RiskFromUniqueItems:    20,
+		RiskFromSharingLinks:   3,
+		RiskRaisedForDensity:   true,
+		UniqueDensityThreshold: 0.2,
+	}
+
+	factors := analytics.RiskFactors()
+	require.Len(t, factors, 4)
+	assert.Equal(t, "20.0 / 50 pts", factors[0].PointsText())
+	assert.Equal(t, 40.0, factors[0].PercentOfMax())
+	assert.Equal(t, "4 of 10 items (40.0%)", factors[0].Basis)
+	assert.Equal(t, "/sites/1/audit-runs/3/tabs/docs/items", factors[0].LinkURL)
+	assert.Equal(t, "0 of 5 assignments, excluding 5 Limited Access", factors[1].Basis)
+	assert.Empty(t, factors[1].LinkURL, "Limited Access alone drives nothing")
+	assert.Equal(t, "/sites/1/audit-runs/3/tabs/docs/links", factors[2].LinkURL)
+	assert.Empty(t, factors[3].LinkURL)
+
+	assert.Equal(t, []string{"The level was raised from Low to Medium, because more than 20% of the items have unique permissions."}, analytics.RiskNotes())
+}
@@ -0,0 +1,99 @@
+package presenters
+
+import (
+	"time"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+// RiskyDefaultsView is the risky defaults an audit run's site relies on.
+type RiskyDefaultsView struct {
+	SiteID             int64                   `json:"site_id"`
+	AuditRunID         int64                   `json:"audit_run_id"`
+	GovernanceCaptured bool                    `json:"governance_captured"`
+	DefaultSharingLink *DefaultSharingLinkView `json:"default_sharing_link,omitempty"`
+	Findings           []RiskyDefaultView      `json:"findings"`
+}
+
+// DefaultSharingLinkView is the link the share dialog offers unless the user picks another.
+type DefaultSharingLinkView struct {
+	LinkKind   int    `json:"link_kind"`
+	LinkType   string `json:"link_type"`
+	Scope      string `json:"scope"`
+	GrantsEdit bool   `json:"grants_edit"`
+}
+
+// RiskyDefaultView is one risky default with the sharing links created under it.
+type RiskyDefaultView struct {
+	Kind        string                 `json:"kind"`
+	Severity    string                 `json:"severity"`
+	WebID       string                 `json:"web_id,omitempty"`
+	WebURL      string                 `json:"web_url,omitempty"`
+	ObjectCount int                    `json:"object_count"`
+	Objects     []RiskyDefaultLinkView `json:"objects"` // Capped, object_count has the full count
+}
+
+// RiskyDefaultLinkView is an active sharing link relying on a risky default.
+type RiskyDefaultLinkView struct {
+	LinkID       string  `json:"link_id"`
+	ItemGUID     string  `json:"item_guid"`
+	ItemName     string  `json:"item_name"`
+	ItemURL      string  `json:"item_url,omitempty"`
+	LinkKind     int     `json:"link_kind"`
+	LinkType     string  `json:"link_type"`
+	Scope        string  `json:"scope"`
+	IsDefault    bool    `json:"is_default"`
+	IsEditLink   bool    `json:"is_edit_link"`
+	MembersCount int64   `json:"members_count"`
+	Expiration   *string `json:"expiration,omitempty"`
+}
+
+// ToRiskyDefaultsView converts an audit run's risky defaults for the API.
+func (p *ListPresenter) ToRiskyDefaultsView(siteID, auditRunID int64, data *application.RiskyDefaultsData) RiskyDefaultsView {
+	view := RiskyDefaultsView{
+		SiteID:             siteID,
+		AuditRunID:         auditRunID,
+		GovernanceCaptured: data.GovernanceCaptured,
+		Findings:           make([]RiskyDefaultView, len(data.Findings)),
+	}
+	if defaultLink := data.DefaultSharingLink; defaultLink != nil {
+		view.DefaultSharingLink = &DefaultSharingLinkView{
+			LinkKind:   defaultLink.LinkKind,
+			LinkType:   sharepoint.LinkKindName(defaultLink.LinkKind),
+			Scope:      sharepoint.ScopeName(defaultLink.Scope),
+			GrantsEdit: defaultLink.GrantsEdit(),
+		}
+	}
+
+	for i, finding := range data.Findings {
+		findingView := RiskyDefaultView{
+			Kind:        finding.Kind,
+			Severity:    string(finding.Severity),
+			WebID:       finding.WebID,
+			WebURL:      finding.WebURL,
+			ObjectCount: finding.ObjectCount,
+			Objects:     make([]RiskyDefaultLinkView, len(finding.Objects)),
+		}
+		for j, link := range finding.Objects {
+			findingView.Objects[j] = RiskyDefaultLinkView{
+				LinkID:       link.LinkID,
+				ItemGUID:     link.ItemGUID,
+				ItemName:     link.ItemName,
+				ItemURL:      link.ItemURL,
+				LinkKind:     link.LinkKind,
+				LinkType:     sharepoint.LinkKindName(link.LinkKind),
+				Scope:        sharepoint.ScopeName(link.Scope),
+				IsDefault:    link.IsDefault,
+				IsEditLink:   link.IsEditLink,
+				MembersCount: link.MembersCount,
+			}
+			if link.Expiration != nil {
+				expiration := link.Expiration.UTC().Format(time.RFC3339)
+				findingView.Objects[j].Expiration = &expiration
+			}
+		}
+		view.Findings[i] = findingView
+	}
+	return view
+}
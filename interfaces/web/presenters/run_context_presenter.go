@@ -0,0 +1,193 @@
+package presenters
+
+import (
+	"fmt"
+	"net/url"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+// List tab keys, as used in tab URLs.
+const (
+	ListTabOverview    = "overview"
+	ListTabAssignments = "assignments"
+	ListTabItems       = "items"
+	ListTabLinks       = "links"
+	ListTabSimulate    = "simulate"
+)
+
+// RunContext is the view model for the breadcrumb and run banner on run-scoped pages.
+type RunContext struct {
+	SiteID     int64
+	SiteTitle  string
+	SiteURL    string
+	AuditRunID int64
+	RunStatus  string // Empty when the run is not among the recent runs
+	RunTime    string // Completion time for completed runs, otherwise start time
+	OnHold     bool   // Run is on legal hold
+	ScopePath  string // Folder a targeted run was limited to, empty for a full site run
+	Label      string // Environment or source the run was tagged with, empty when unlabeled
+
+	// ReferenceRunID is the site's pinned reference run, 0 when none is pinned
+	ReferenceRunID int64
+
+	// BaselineRunID is the site's approved baseline run, 0 when none is approved
+	BaselineRunID int64
+
+	// Set on list pages only
+	ListID    string
+	ListTitle string
+	Tab       string
+
+	// Recent runs offered by the run switcher
+	Runs []AuditRunOption
+
+	// Unexpired reports generated from the current run
+	Artifacts []RunArtifactView
+
+	// SchemaDrift summarizes the API response fields the run did not recognise or did not receive,
+	// empty when responses matched the expected schema
+	SchemaDrift string
+}
+
+// ToRunContext builds the run context for a site-level page.
+// A nil site or empty runs slice still yields a usable banner with fallback labels.
+func (p *ListPresenter) ToRunContext(site *sharepoint.Site, siteID, auditRunID, referenceRunID int64, runs []*audit.AuditRun) RunContext {
+	rc := RunContext{
+		SiteID:         siteID,
+		SiteTitle:      "Site",
+		AuditRunID:     auditRunID,
+		ReferenceRunID: referenceRunID,
+		Runs:           p.ToAuditRunOptions(runs),
+	}
+	if site != nil && site.Title != "" {
+		rc.SiteTitle = site.Title
+	}
+	if site != nil {
+		rc.SiteURL = site.URL
+	}
+
+	for _, run := range runs {
+		if run.ID != auditRunID {
+			continue
+		}
+		rc.RunStatus = run.GetStatus()
+		rc.OnHold = run.OnHold
+		rc.ScopePath = run.ScopePath
+		rc.Label = run.Label
+		if run.CompletedAt != nil {
+			rc.RunTime = "Completed " + run.CompletedAt.Format("Jan 2, 2006 3:04 PM")
+		} else {
+			rc.RunTime = "Started " + run.StartedAt.Format("Jan 2, 2006 3:04 PM")
+		}
+		break
+	}
+
+	return rc
+}
+
+// IsReferenceRun reports whether the current run is the site's pinned reference run.
+func (rc RunContext) IsReferenceRun() bool {
+	return rc.ReferenceRunID != 0 && rc.ReferenceRunID == rc.AuditRunID
+}
+
+// IsBaselineRun reports whether the current run is the site's approved baseline run.
+func (rc RunContext) IsBaselineRun() bool {
+	return rc.BaselineRunID != 0 && rc.BaselineRunID == rc.AuditRunID
+}
+
+// CanCheckDrift reports whether the current run can be compared with an approved baseline other than itself.
+func (rc RunContext) CanCheckDrift() bool {
+	return rc.BaselineRunID != 0 && rc.BaselineRunID != rc.AuditRunID
+}
+
+// CanApproveBaseline reports whether the current run can be approved as the site's baseline,
+// which needs a completed run that is not already the baseline.
+func (rc RunContext) CanApproveBaseline() bool {
+	return rc.RunStatus == "completed" && !rc.IsBaselineRun()
+}
+
+// CanExportChanges reports whether the list's changes since the reference run can be exported,
+// which needs a list page and a pinned reference run other than the current one.
+func (rc RunContext) CanExportChanges() bool {
+	return rc.ListID != "" && rc.ReferenceRunID != 0 && rc.ReferenceRunID != rc.AuditRunID
+}
+
+// ChangesExportURL returns the redline export of the list's changes since the reference run.
+func (rc RunContext) ChangesExportURL(format string) string {
+	return fmt.Sprintf("/sites/%d/audit-runs/%d/lists/%s/changes/export?base=%d&format=%s",
+		rc.SiteID, rc.AuditRunID, url.PathEscape(rc.ListID), rc.ReferenceRunID, format)
+}
+
+// PrintURL returns the printable summary of the list, or of the site's lists outside list pages.
+func (rc RunContext) PrintURL() string {
+	if rc.ListID != "" {
+		return fmt.Sprintf("/sites/%d/audit-runs/%d/lists/%s/print", rc.SiteID, rc.AuditRunID, url.PathEscape(rc.ListID))
+	}
+	return fmt.Sprintf("/sites/%d/audit-runs/%d/print", rc.SiteID, rc.AuditRunID)
+}
+
+// AccessReviewURL returns the access review export of the site's run in the given format.
+func (rc RunContext) AccessReviewURL(format string) string {
+	return fmt.Sprintf("/sites/%d/audit-runs/%d/access-review/export?format=%s", rc.SiteID, rc.AuditRunID, format)
+}
+
+// AttestationsURL returns the site owner attestations of the run.
+func (rc RunContext) AttestationsURL() string {
+	return fmt.Sprintf("/sites/%d/audit-runs/%d/attestations", rc.SiteID, rc.AuditRunID)
+}
+
+// AnonymousLinksURL returns the anonymous sharing links of the run across all of the site's lists.
+func (rc RunContext) AnonymousLinksURL() string {
+	return fmt.Sprintf("/sites/%d/audit-runs/%d/anonymous-links", rc.SiteID, rc.AuditRunID)
+}
+
+// WithList returns the run context for a tab of a list page.
+func (rc RunContext) WithList(list ListSummary, tab string) RunContext {
+	rc.ListID = list.ListID
+	rc.ListTitle = list.Title
+	rc.Tab = tab
+	return rc
+}
+
+// ToAuditRunOptions converts audit runs to selector options, preserving order.
+func (p *ListPresenter) ToAuditRunOptions(runs []*audit.AuditRun) []AuditRunOption {
+	options := make([]AuditRunOption, len(runs))
+	for i, run := range runs {
+		options[i] = AuditRunOption{
+			ID:        run.ID,
+			StartedAt: run.StartedAt,
+			Status:    run.GetStatus(),
+			OnHold:    run.OnHold,
+			ScopePath: run.ScopePath,
+			Label:     run.Label,
+		}
+	}
+	return options
+}
+
+// ListTabLabel returns the display label for a list tab key.
+func ListTabLabel(tab string) string {
+	switch tab {
+	case ListTabAssignments:
+		return "List Permissions"
+	case ListTabItems:
+		return "Items"
+	case ListTabLinks:
+		return "Sharing Links"
+	case ListTabSimulate:
+		return "What-if"
+	default:
+		return "Overview"
+	}
+}
+
+// ShareURL returns the read-only shares of the run, offering the printable summary shown by PrintURL.
+func (rc RunContext) ShareURL() string {
+	shares := fmt.Sprintf("/sites/%d/audit-runs/%d/shares", rc.SiteID, rc.AuditRunID)
+	if rc.ListID != "" {
+		return shares + "?list=" + url.QueryEscape(rc.ListID)
+	}
+	return shares
+}
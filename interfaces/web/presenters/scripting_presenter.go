@@ -0,0 +1,138 @@
+package presenters
+
+import (
+	"math"
+	"time"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/domain/jobs"
+	"spaudit/domain/sharepoint"
+)
+
+// Scripting view data structures
+//
+// These views are flat (no nested objects or arrays) and always carry every
+// property, so PowerShell's Invoke-RestMethod yields objects that pipe straight
+// into Format-Table, Where-Object and Export-Csv.
+
+// AuditRunResultView is the outcome of a trigger-and-wait audit request
+type AuditRunResultView struct {
+	SiteURL           string  `json:"site_url"`
+	SiteID            int64   `json:"site_id"`
+	JobID             string  `json:"job_id"`
+	Status            string  `json:"status"`
+	Completed         bool    `json:"completed"`
+	TimedOut          bool    `json:"timed_out"`
+	AuditRunID        int64   `json:"audit_run_id"`
+	StartedAt         string  `json:"started_at"`
+	CompletedAt       string  `json:"completed_at"`
+	DurationSeconds   float64 `json:"duration_seconds"`
+	ListsProcessed    int     `json:"lists_processed"`
+	ItemsProcessed    int     `json:"items_processed"`
+	SharingLinksFound int     `json:"sharing_links_found"`
+	ErrorsEncountered int     `json:"errors_encountered"`
+	Error             string  `json:"error"`
+}
+
+// SiteRiskSummaryView is the permission risk summary of a site's latest audit run
+type SiteRiskSummaryView struct {
+	SiteID                 int64   `json:"site_id"`
+	SiteURL                string  `json:"site_url"`
+	SiteTitle              string  `json:"site_title"`
+	AuditRunID             int64   `json:"audit_run_id"`
+	AuditStatus            string  `json:"audit_status"`
+	AuditStartedAt         string  `json:"audit_started_at"`
+	AuditCompletedAt       string  `json:"audit_completed_at"`
+	RiskLevel              string  `json:"risk_level"`
+	HighestRiskScore       float64 `json:"highest_risk_score"`
+	RiskiestListID         string  `json:"riskiest_list_id"`
+	RiskiestListTitle      string  `json:"riskiest_list_title"`
+	RiskiestListURL        string  `json:"riskiest_list_url"`
+	ListsAnalyzed          int     `json:"lists_analyzed"`
+	HighRiskLists          int     `json:"high_risk_lists"`
+	MediumRiskLists        int     `json:"medium_risk_lists"`
+	LowRiskLists           int     `json:"low_risk_lists"`
+	DenseLists             int     `json:"dense_lists"`
+	TotalItems             int64   `json:"total_items"`
+	ItemsWithUnique        int64   `json:"items_with_unique"`
+	UniqueItemPercent      float64 `json:"unique_item_percent"`
+	SharingLinks           int     `json:"sharing_links"`
+	AnonymousLinks         int     `json:"anonymous_links"`
+	OrganizationLinks      int     `json:"organization_links"`
+	FullControlAssignments int     `json:"full_control_assignments"`
+}
+
+// ScriptingPresenter formats flat views for admin scripting endpoints.
+type ScriptingPresenter struct{}
+
+// NewScriptingPresenter creates a new scripting presenter.
+func NewScriptingPresenter() *ScriptingPresenter {
+	return &ScriptingPresenter{}
+}
+
+// ToAuditRunResultView formats a job snapshot taken when waiting ended.
+// auditRun is nil until the job has created its audit run; timedOut reports
+// that the wait ended before the job finished.
+func (p *ScriptingPresenter) ToAuditRunResultView(job *jobs.Job, auditRun *audit.AuditRun, timedOut bool) *AuditRunResultView {
+	stats := job.State.Stats
+	view := &AuditRunResultView{
+		SiteURL:           job.GetSiteURL(),
+		JobID:             job.ID,
+		Status:            string(job.Status),
+		Completed:         job.IsComplete(),
+		TimedOut:          timedOut,
+		StartedAt:         job.StartedAt.UTC().Format(time.RFC3339),
+		DurationSeconds:   math.Max(0, math.Round(job.Duration().Seconds())), // completed_at is stored to the second
+		ListsProcessed:    stats.ListsProcessed,
+		ItemsProcessed:    stats.ItemsProcessed,
+		SharingLinksFound: stats.SharingLinksFound,
+		ErrorsEncountered: stats.ErrorsEncountered,
+		Error:             job.Error,
+	}
+	if job.CompletedAt != nil {
+		view.CompletedAt = job.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	if auditRun != nil {
+		view.AuditRunID = auditRun.ID
+		view.SiteID = auditRun.SiteID
+	}
+	return view
+}
+
+// ToSiteRiskSummaryView formats a site risk summary for the given audit run.
+func (p *ScriptingPresenter) ToSiteRiskSummaryView(site *sharepoint.Site, auditRun *audit.AuditRun, summary *application.SiteRiskSummaryData) *SiteRiskSummaryView {
+	view := &SiteRiskSummaryView{
+		SiteID:                 site.ID,
+		SiteURL:                site.URL,
+		SiteTitle:              site.Title,
+		AuditRunID:             auditRun.ID,
+		AuditStatus:            auditRun.GetStatus(),
+		AuditStartedAt:         auditRun.StartedAt.UTC().Format(time.RFC3339),
+		RiskLevel:              summary.RiskLevel,
+		HighestRiskScore:       math.Round(summary.HighestRiskScore*10) / 10,
+		ListsAnalyzed:          summary.ListsAnalyzed,
+		HighRiskLists:          summary.HighRiskLists,
+		MediumRiskLists:        summary.MediumRiskLists,
+		LowRiskLists:           summary.LowRiskLists,
+		DenseLists:             summary.DenseLists,
+		TotalItems:             summary.TotalItems,
+		ItemsWithUnique:        summary.ItemsWithUnique,
+		SharingLinks:           summary.SharingLinks,
+		AnonymousLinks:         summary.AnonymousLinks,
+		OrganizationLinks:      summary.OrganizationLinks,
+		FullControlAssignments: summary.FullControlAssignments,
+	}
+	if auditRun.CompletedAt != nil {
+		view.AuditCompletedAt = auditRun.CompletedAt.UTC().Format(time.RFC3339)
+	}
+	if summary.RiskiestList != nil {
+		view.RiskiestListID = summary.RiskiestList.ID
+		view.RiskiestListTitle = summary.RiskiestList.Title
+		view.RiskiestListURL = summary.RiskiestList.URL
+	}
+	if summary.TotalItems > 0 {
+		view.UniqueItemPercent = math.Round(float64(summary.ItemsWithUnique)/float64(summary.TotalItems)*1000) / 10
+	}
+	return view
+}
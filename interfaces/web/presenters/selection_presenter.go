@@ -0,0 +1,112 @@
+package presenters
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ParseSelectableTable returns the data table with the given name if its rows can be selected.
+func ParseSelectableTable(name string) (DataTable, bool) {
+	switch table := DataTable(name); table {
+	case DataTableItems, DataTableLinks:
+		return table, true
+	}
+	return "", false
+}
+
+// BulkSelection is the rows selected in a data table, kept for the browser session across pages
+// and filters, with the bulk actions offered for them.
+type BulkSelection struct {
+	Table     DataTable
+	UpdateURL string   // Adds or removes rows
+	ExportURL string   // Downloads the selected rows as CSV
+	ReturnTo  string   // The table view, reloaded after changes to several rows
+	PageKeys  []string // Keys of the rows shown
+	Count     int
+
+	selected map[string]bool
+}
+
+// ToBulkSelection builds the selection of a list's table from the session's selected keys.
+func (p *PermissionPresenter) ToBulkSelection(table DataTable, siteID, auditRunID int64, listID string, selected, pageKeys []string, returnTo string) BulkSelection {
+	base := fmt.Sprintf("/sites/%d/audit-runs/%d/lists/%s/selection/%s", siteID, auditRunID, listID, table)
+	selection := BulkSelection{
+		Table:     table,
+		UpdateURL: base,
+		ExportURL: base + "/export",
+		ReturnTo:  returnTo,
+		PageKeys:  pageKeys,
+		Count:     len(selected),
+		selected:  make(map[string]bool, len(selected)),
+	}
+	for _, key := range selected {
+		selection.selected[key] = true
+	}
+	return selection
+}
+
+// IsSelected returns true if the row is selected.
+func (s BulkSelection) IsSelected(key string) bool {
+	return s.selected[key]
+}
+
+// PageSelected returns true if every row shown is selected.
+func (s BulkSelection) PageSelected() bool {
+	for _, key := range s.PageKeys {
+		if !s.selected[key] {
+			return false
+		}
+	}
+	return len(s.PageKeys) > 0
+}
+
+// Colspan returns the colspan of a full-width row in a table with a selection column.
+func (s BulkSelection) Colspan(columns ColumnLayout) string {
+	return strconv.Itoa(len(columns.Columns) + 1)
+}
+
+// BarID returns the element ID of the table's bulk action bar.
+func (s BulkSelection) BarID() string {
+	return "bulk-bar-" + string(s.Table)
+}
+
+// RowVals returns the hx-vals of a row checkbox. The view is passed along so the returned
+// action bar can reload it.
+func (s BulkSelection) RowVals(key string) string {
+	return selectionVals(map[string]any{"keys": []string{key}, "view": s.ReturnTo})
+}
+
+// PageVals returns the hx-vals of the checkbox selecting every row shown.
+func (s BulkSelection) PageVals() string {
+	return selectionVals(map[string]any{"keys": s.PageKeys, "return_to": s.ReturnTo})
+}
+
+// ClearVals returns the hx-vals of the button clearing the selection.
+func (s BulkSelection) ClearVals() string {
+	return selectionVals(map[string]any{"clear": "true", "return_to": s.ReturnTo})
+}
+
+// selectionVals encodes form values for hx-vals.
+func selectionVals(values map[string]any) string {
+	encoded, _ := json.Marshal(values)
+	return string(encoded)
+}
+
+// ItemKeys returns the selection keys of the items shown.
+func (page ItemsTabPage) ItemKeys() []string {
+	keys := make([]string, len(page.Items))
+	for i, item := range page.Items {
+		keys[i] = item.ItemGUID
+	}
+	return keys
+}
+
+// SharingLinkKeys returns the selection keys of sharing links.
+func SharingLinkKeys(links []SharingLink) []string {
+	keys := make([]string, len(links))
+	for i, link := range links {
+		keys[i] = link.LinkID
+	}
+	return keys
+}
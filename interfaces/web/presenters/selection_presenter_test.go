@@ -0,0 +1,44 @@
+package presenters
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSelectableTable(t *testing.T) {
+	for _, name := range []string{"items", "links"} {
+		table, ok := ParseSelectableTable(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, DataTable(name), table)
+	}
+	_, ok := ParseSelectableTable("assignments")
+	assert.False(t, ok)
+}
+
+func TestPermissionPresenter_ToBulkSelection(t *testing.T) {
+	presenter := NewPermissionPresenter()
+	returnTo := "/sites/1/audit-runs/7/tabs/abc/items?page=2"
+
+	selection := presenter.ToBulkSelection(DataTableItems, 1, 7, "abc", []string{"g1", "g3"}, []string{"g1", "g2"}, returnTo)
+
+	assert.Equal(t, "/sites/1/audit-runs/7/lists/abc/selection/items", selection.UpdateURL)
+	assert.Equal(t, "/sites/1/audit-runs/7/lists/abc/selection/items/export", selection.ExportURL)
+	assert.Equal(t, "bulk-bar-items", selection.BarID())
+	assert.Equal(t, 2, selection.Count)
+	assert.True(t, selection.IsSelected("g3"))
+	assert.False(t, selection.IsSelected("g2"))
+	assert.False(t, selection.PageSelected())
+	assert.Equal(t, "4", selection.Colspan(DefaultColumnLayout(DataTableItems))) // Three default columns and the checkbox
+
+	assert.JSONEq(t, `{"keys":["g2"],"view":"/sites/1/audit-runs/7/tabs/abc/items?page=2"}`, selection.RowVals("g2"))
+	assert.JSONEq(t, `{"keys":["g1","g2"],"return_to":"/sites/1/audit-runs/7/tabs/abc/items?page=2"}`, selection.PageVals())
+	assert.JSONEq(t, `{"clear":"true","return_to":"/sites/1/audit-runs/7/tabs/abc/items?page=2"}`, selection.ClearVals())
+
+	selection = presenter.ToBulkSelection(DataTableItems, 1, 7, "abc", []string{"g1", "g2"}, []string{"g1", "g2"}, returnTo)
+	assert.True(t, selection.PageSelected())
+
+	// An empty page is never fully selected
+	selection = presenter.ToBulkSelection(DataTableItems, 1, 7, "abc", []string{"g1"}, nil, returnTo)
+	assert.False(t, selection.PageSelected())
+}
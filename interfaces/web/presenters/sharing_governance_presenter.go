@@ -0,0 +1,34 @@
+package presenters
+
+import "spaudit/application"
+
+// SharingGovernanceView is a site's sharing policy as captured by an audit run.
+type SharingGovernanceView struct {
+	SiteID             int64                         `json:"site_id"`
+	AuditRunID         int64                         `json:"audit_run_id"`
+	PreviousAuditRunID int64                         `json:"previous_audit_run_id,omitempty"`
+	Settings           map[string]string             `json:"settings"`
+	Changes            []SharingGovernanceChangeView `json:"changes"` // Since the previous audit run
+}
+
+// SharingGovernanceChangeView is one sharing policy setting that changed between audit runs.
+type SharingGovernanceChangeView struct {
+	Setting string `json:"setting"`
+	Before  string `json:"before"`
+	After   string `json:"after"`
+}
+
+// ToSharingGovernanceView converts a run's sharing policy and its changes for the API.
+func (p *ListPresenter) ToSharingGovernanceView(data *application.SharingGovernanceData) SharingGovernanceView {
+	view := SharingGovernanceView{
+		SiteID:             data.Governance.SiteID,
+		AuditRunID:         data.Governance.AuditRunID,
+		PreviousAuditRunID: data.PreviousAuditRunID,
+		Settings:           application.SharingGovernanceSettings(data.Governance),
+		Changes:            make([]SharingGovernanceChangeView, len(data.Changes)),
+	}
+	for i, change := range data.Changes {
+		view.Changes[i] = SharingGovernanceChangeView{Setting: change.Setting, Before: change.Before, After: change.After}
+	}
+	return view
+}
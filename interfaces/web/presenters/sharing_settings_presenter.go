@@ -0,0 +1,140 @@
+package presenters
+
+import (
+	"strconv"
+	"time"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+// SharingSettingsView is the default sharing behavior of the webs and lists an audit run found in a site.
+type SharingSettingsView struct {
+	SiteID      int64                     `json:"site_id"`
+	AuditRunID  int64                     `json:"audit_run_id"`
+	Sensitive   bool                      `json:"sensitive"`
+	Sensitivity *SiteSensitivityView      `json:"sensitivity,omitempty"`
+	Flagged     int                       `json:"flagged"`
+	Webs        []WebSharingSettingsView  `json:"webs"`
+	Lists       []ListSharingSettingsView `json:"lists"`
+}
+
+// SiteSensitivityView is the tag marking a site as holding sensitive content.
+type SiteSensitivityView struct {
+	SiteID   int64  `json:"site_id"`
+	Reason   string `json:"reason"`
+	TaggedAt string `json:"tagged_at"`
+}
+
+// AccessRequestsView is where a web sends access requests. Enabled is false when users without access
+// cannot ask for it.
+type AccessRequestsView struct {
+	Enabled  bool   `json:"enabled"`
+	Email    string `json:"email,omitempty"`
+	ToOwners bool   `json:"to_owners"`
+}
+
+// WebSharingSettingsView is whether a web's members can share and where its access requests go. Either is
+// omitted when the run did not capture it.
+type WebSharingSettingsView struct {
+	WebID           string              `json:"web_id"`
+	Title           string              `json:"title"`
+	URL             string              `json:"url"`
+	MembersCanShare *bool               `json:"members_can_share,omitempty"`
+	AccessRequests  *AccessRequestsView `json:"access_requests,omitempty"`
+}
+
+// ListSharingSettingsView is the default sharing behavior a list inherits from its web. Flagged lists let
+// members share on a site tagged sensitive.
+type ListSharingSettingsView struct {
+	ListID          string              `json:"list_id"`
+	Title           string              `json:"title"`
+	URL             string              `json:"url"`
+	WebID           string              `json:"web_id"`
+	WebURL          string              `json:"web_url,omitempty"`
+	MembersCanShare *bool               `json:"members_can_share,omitempty"`
+	AccessRequests  *AccessRequestsView `json:"access_requests,omitempty"`
+	Flagged         bool                `json:"flagged"`
+}
+
+// ToSiteSensitivityView converts a site's sensitivity tag, nil for an untagged site.
+func (p *ListPresenter) ToSiteSensitivityView(tag *audit.SiteSensitivity) *SiteSensitivityView {
+	if tag == nil {
+		return nil
+	}
+	return &SiteSensitivityView{SiteID: tag.SiteID, Reason: tag.Reason, TaggedAt: tag.TaggedAt.UTC().Format(time.RFC3339)}
+}
+
+// ToSharingSettingsView converts the sharing settings of an audit run, preserving their order.
+func (p *ListPresenter) ToSharingSettingsView(siteID, auditRunID int64, data *application.SharingSettingsData) SharingSettingsView {
+	view := SharingSettingsView{
+		SiteID:      siteID,
+		AuditRunID:  auditRunID,
+		Sensitive:   data.Sensitivity != nil,
+		Sensitivity: p.ToSiteSensitivityView(data.Sensitivity),
+		Flagged:     data.Flagged,
+		Webs:        make([]WebSharingSettingsView, len(data.Webs)),
+		Lists:       make([]ListSharingSettingsView, len(data.Lists)),
+	}
+	for i, web := range data.Webs {
+		view.Webs[i] = WebSharingSettingsView{
+			WebID:           web.Web.ID,
+			Title:           web.Web.Title,
+			URL:             web.Web.URL,
+			MembersCanShare: web.MembersCanShare,
+			AccessRequests:  toAccessRequestsView(web.AccessRequests),
+		}
+	}
+	for i, list := range data.Lists {
+		view.Lists[i] = ListSharingSettingsView{
+			ListID:          list.List.ID,
+			Title:           list.List.Title,
+			URL:             list.List.URL,
+			WebID:           list.List.WebID,
+			WebURL:          list.WebURL,
+			MembersCanShare: list.MembersCanShare,
+			AccessRequests:  toAccessRequestsView(list.AccessRequests),
+			Flagged:         list.Flagged,
+		}
+	}
+	return view
+}
+
+func toAccessRequestsView(requests *sharepoint.WebAccessRequests) *AccessRequestsView {
+	if requests == nil {
+		return nil
+	}
+	return &AccessRequestsView{Enabled: requests.Enabled(), Email: requests.Email, ToOwners: requests.ToOwners}
+}
+
+// SharingSettingsToCSV lays out the sharing settings of an audit run one web or list per row, webs first.
+// Settings the run did not capture are left blank.
+func (p *ListPresenter) SharingSettingsToCSV(view SharingSettingsView) CSVTable {
+	table := CSVTable{
+		Header: []string{"Type", "ID", "Title", "URL", "Web URL", "Members Can Share", "Access Requests", "Access Request Email", "Requests To Owners", "Flagged"},
+		Rows:   make([][]string, 0, len(view.Webs)+len(view.Lists)),
+	}
+	for _, web := range view.Webs {
+		table.Rows = append(table.Rows, append([]string{"web", web.WebID, web.Title, web.URL, web.URL},
+			sharingSettingsCells(web.MembersCanShare, web.AccessRequests, false)...))
+	}
+	for _, list := range view.Lists {
+		table.Rows = append(table.Rows, append([]string{"list", list.ListID, list.Title, list.URL, list.WebURL},
+			sharingSettingsCells(list.MembersCanShare, list.AccessRequests, list.Flagged)...))
+	}
+	return table
+}
+
+func sharingSettingsCells(membersCanShare *bool, requests *AccessRequestsView, flagged bool) []string {
+	cells := []string{"", "", "", "", strconv.FormatBool(flagged)}
+	if membersCanShare != nil {
+		cells[0] = strconv.FormatBool(*membersCanShare)
+	}
+	if requests != nil {
+		cells[1] = strconv.FormatBool(requests.Enabled)
+		cells[2] = requests.Email
+		cells[3] = strconv.FormatBool(requests.ToOwners)
+	}
+	return cells
+}
@@ -0,0 +1,51 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+)
+
+func TestListPresenter_ToSharingSettingsView(t *testing.T) {
+	presenter := NewListPresenter()
+	canShare := true
+	web := &sharepoint.Web{ID: "root", Title: "A", URL: "https://contoso.sharepoint.com/sites/a", MembersCanShare: &canShare,
+		AccessRequests: &sharepoint.WebAccessRequests{Email: "owners@contoso.com"}}
+	lists := []*sharepoint.List{
+		{ID: "docs", WebID: "root", Title: "Documents", URL: "/sites/a/Shared Documents"},
+		{ID: "orphan", WebID: "gone", Title: "Archive", URL: "/sites/a/Archive"},
+	}
+	tag := &audit.SiteSensitivity{SiteID: 1, Reason: "Board papers", TaggedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)}
+
+	view := presenter.ToSharingSettingsView(1, 7, application.ReportSharingSettings([]*sharepoint.Web{web}, lists, tag))
+	assert.True(t, view.Sensitive)
+	require.NotNil(t, view.Sensitivity)
+	assert.Equal(t, "2025-03-01T12:00:00Z", view.Sensitivity.TaggedAt)
+	assert.Equal(t, 1, view.Flagged)
+	require.Len(t, view.Webs, 1)
+	assert.Equal(t, &AccessRequestsView{Enabled: true, Email: "owners@contoso.com"}, view.Webs[0].AccessRequests)
+	require.Len(t, view.Lists, 2)
+	assert.Nil(t, view.Lists[0].AccessRequests, "lists of webs not captured have no settings")
+	assert.True(t, view.Lists[1].Flagged)
+
+	table := presenter.SharingSettingsToCSV(view)
+	require.Len(t, table.Rows, 3)
+	for _, row := range table.Rows {
+		assert.Len(t, row, len(table.Header))
+	}
+	assert.Equal(t, []string{"web", "root", "A", web.URL, web.URL, "true", "true", "owners@contoso.com", "false", "false"}, table.Rows[0])
+	assert.Equal(t, []string{"list", "orphan", "Archive", "/sites/a/Archive", "", "", "", "", "", "false"}, table.Rows[1])
+	assert.Equal(t, "true", table.Rows[2][9])
+
+	view = presenter.ToSharingSettingsView(1, 7, application.ReportSharingSettings(nil, nil, nil))
+	assert.False(t, view.Sensitive)
+	assert.Nil(t, view.Sensitivity)
+	assert.NotNil(t, view.Webs)
+	assert.NotNil(t, view.Lists)
+}
@@ -0,0 +1,176 @@
+package presenters
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+// PermissionSimulationView previews how proposed changes would affect a list's access and risk.
+type PermissionSimulationView struct {
+	SiteID         int64                  `json:"site_id"`
+	AuditRunID     int64                  `json:"audit_run_id"`
+	ListID         string                 `json:"list_id"`
+	ListTitle      string                 `json:"list_title"`
+	Proposed       []ProposedChangeView   `json:"proposed"`
+	Before         SimulationRiskView     `json:"before"`
+	After          SimulationRiskView     `json:"after"`
+	RiskScoreDelta float64                `json:"risk_score_delta"` // Negative when the changes lower the risk
+	Removed        []PermissionChangeView `json:"removed"`
+	AccessLost     []SnapshotPrincipal    `json:"access_lost"`    // No access left on the list or its items
+	AccessReduced  []SnapshotPrincipal    `json:"access_reduced"` // Keep some access but lose at least one grant
+}
+
+// ProposedChangeView is one remediation step of a simulation, as submitted.
+type ProposedChangeView struct {
+	Action      string `json:"action"`
+	PrincipalID int64  `json:"principal_id,omitempty"`
+	ItemGUID    string `json:"item_guid,omitempty"`
+	LinkID      string `json:"link_id,omitempty"`
+}
+
+// SimulationRiskView is a list's risk assessment and the counts behind it, before or after the changes.
+type SimulationRiskView struct {
+	RiskScore          float64 `json:"risk_score"`
+	RiskLevel          string  `json:"risk_level"`
+	ListAssignments    int     `json:"list_assignments"`
+	FullControl        int     `json:"full_control"`
+	Contribute         int     `json:"contribute"`
+	SharingLinks       int     `json:"sharing_links"`
+	SharingLinkMembers int     `json:"sharing_link_members"`
+	AnonymousLinks     int     `json:"anonymous_links"`
+}
+
+// SimulationForm offers the list assignments and sharing links a simulation can remove.
+type SimulationForm struct {
+	SiteID     int64
+	AuditRunID int64
+	ListID     string
+	Principals []SimulationPrincipalOption
+	Links      []SimulationLinkOption
+}
+
+// SimulationPrincipalOption is a principal assigned on the list, with every role it holds there.
+type SimulationPrincipalOption struct {
+	ID    int64
+	Title string
+	Kind  sharepoint.PrincipalKind
+	Roles string
+}
+
+// SimulationLinkOption is a sharing link on the list's items.
+type SimulationLinkOption struct {
+	ID       string
+	Kind     string
+	ItemName string
+	Members  int
+	Active   bool
+}
+
+// ToPermissionSimulationView converts a simulation result to its view.
+// Each change and principal section is sized to its data, so a simulation changing nothing reports [] for them.
+func (p *ListPresenter) ToPermissionSimulationView(siteID int64, data *application.PermissionSimulationData) PermissionSimulationView {
+	view := PermissionSimulationView{
+		SiteID:        siteID,
+		AuditRunID:    data.AuditRunID,
+		ListID:        data.List.ID,
+		ListTitle:     data.List.Title,
+		Proposed:      make([]ProposedChangeView, len(data.Proposed)),
+		Before:        toSimulationRiskView(data.Before),
+		After:         toSimulationRiskView(data.After),
+		Removed:       make([]PermissionChangeView, len(data.Changes)),
+		AccessLost:    make([]SnapshotPrincipal, len(data.AccessLost)),
+		AccessReduced: make([]SnapshotPrincipal, len(data.AccessReduced)),
+	}
+	view.RiskScoreDelta = math.Round((view.After.RiskScore-view.Before.RiskScore)*10) / 10
+
+	for i, change := range data.Proposed {
+		view.Proposed[i] = ProposedChangeView{
+			Action:      change.Action,
+			PrincipalID: change.PrincipalID,
+			ItemGUID:    change.ItemGUID,
+			LinkID:      change.LinkID,
+		}
+	}
+	for i, change := range data.Changes {
+		view.Removed[i] = toPermissionChangeView(change)
+	}
+	for i, principal := range data.AccessLost {
+		view.AccessLost[i] = p.toSnapshotPrincipal(principal)
+	}
+	for i, principal := range data.AccessReduced {
+		view.AccessReduced[i] = p.toSnapshotPrincipal(principal)
+	}
+	return view
+}
+
+// toSimulationRiskView converts permission analysis to the risk view of a simulation.
+func toSimulationRiskView(data *application.PermissionAnalysisData) SimulationRiskView {
+	return SimulationRiskView{
+		RiskScore:          math.Round(data.PermissionRiskScore*10) / 10,
+		RiskLevel:          data.PermissionRiskLevel,
+		ListAssignments:    data.TotalAssignments,
+		FullControl:        data.FullControlCount,
+		Contribute:         data.ContributeCount,
+		SharingLinks:       data.SharingLinkCount,
+		SharingLinkMembers: data.SharingLinkUsers,
+		AnonymousLinks:     data.AnonymousViewCount + data.AnonymousEditCount,
+	}
+}
+
+// ToSimulationForm lists the list assignments, one option per principal, and sharing links of a snapshot
+// as the changes the what-if tab offers.
+func (p *ListPresenter) ToSimulationForm(siteID int64, snapshot *application.ListSnapshotData) SimulationForm {
+	form := SimulationForm{SiteID: siteID, AuditRunID: snapshot.AuditRunID, ListID: snapshot.List.ID}
+
+	options := make(map[int64]int)
+	var roles [][]string
+	for _, resolved := range snapshot.Assignments {
+		assignment := resolved.Assignment
+		if assignment.Principal == nil {
+			continue
+		}
+		index, exists := options[assignment.Principal.ID]
+		if !exists {
+			index = len(form.Principals)
+			options[assignment.Principal.ID] = index
+			form.Principals = append(form.Principals, SimulationPrincipalOption{
+				ID:    assignment.Principal.ID,
+				Title: assignment.Principal.GetDisplayName(),
+				Kind:  assignment.Principal.Kind(),
+			})
+			roles = append(roles, nil)
+		}
+		if assignment.RoleDefinition != nil {
+			roles[index] = append(roles[index], assignment.RoleDefinition.Name)
+		}
+	}
+	for i := range form.Principals {
+		form.Principals[i].Roles = strings.Join(roles[i], ", ")
+	}
+	sort.SliceStable(form.Principals, func(i, j int) bool {
+		return strings.ToLower(form.Principals[i].Title) < strings.ToLower(form.Principals[j].Title)
+	})
+
+	itemNames := make(map[string]string, len(snapshot.Items))
+	for _, item := range snapshot.Items {
+		itemNames[item.GUID] = item.Name
+	}
+	for _, link := range snapshot.SharingLinks {
+		itemName := itemNames[link.ItemGUID]
+		if itemName == "" {
+			itemName = itemNames[link.FileFolderUniqueID]
+		}
+		form.Links = append(form.Links, SimulationLinkOption{
+			ID:       link.ID,
+			Kind:     link.GetLinkKindName(),
+			ItemName: itemName,
+			Members:  len(link.Members),
+			Active:   link.IsActive,
+		})
+	}
+	return form
+}
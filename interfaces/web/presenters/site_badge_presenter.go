@@ -0,0 +1,91 @@
+package presenters
+
+import (
+	"fmt"
+	"html"
+	"time"
+
+	"spaudit/domain/audit"
+)
+
+// SiteBadgeView is a site's current risk, for portals embedding its badge.
+type SiteBadgeView struct {
+	SiteID      int64   `json:"site_id"`
+	SiteURL     string  `json:"site_url"`
+	Title       string  `json:"title"`
+	RiskLevel   string  `json:"risk_level,omitempty"` // Omitted for a site never audited
+	RiskScore   float64 `json:"risk_score"`
+	AuditRunID  int64   `json:"audit_run_id,omitempty"`
+	LastAuditAt string  `json:"last_audit_at,omitempty"`
+}
+
+// SiteBadgeLinkView is the signed URLs of a site's badge, as JSON and as an SVG image.
+type SiteBadgeLinkView struct {
+	SiteID    int64  `json:"site_id"`
+	Signature string `json:"signature"`
+	JSONURL   string `json:"json_url"`
+	SVGURL    string `json:"svg_url"`
+}
+
+// ToSiteBadgeView converts a site badge for the API.
+func (p *SitePresenter) ToSiteBadgeView(badge *audit.SiteBadge) SiteBadgeView {
+	view := SiteBadgeView{
+		SiteID:     badge.SiteID,
+		SiteURL:    badge.SiteURL,
+		Title:      badge.Title,
+		RiskLevel:  badge.RiskLevel,
+		RiskScore:  badge.RiskScore,
+		AuditRunID: badge.AuditRunID,
+	}
+	if badge.LastAuditAt != nil {
+		view.LastAuditAt = badge.LastAuditAt.UTC().Format(time.RFC3339)
+	}
+	return view
+}
+
+// ToSiteBadgeLinkView returns the signed badge URLs of a site, relative to the app's root.
+func (p *SitePresenter) ToSiteBadgeLinkView(siteID int64, signature string) SiteBadgeLinkView {
+	url := fmt.Sprintf("/api/sites/%d/badge?sig=%s", siteID, signature)
+	return SiteBadgeLinkView{
+		SiteID:    siteID,
+		Signature: signature,
+		JSONURL:   url,
+		SVGURL:    url + "&format=svg",
+	}
+}
+
+// RenderSiteBadgeSVG draws a site badge as a flat two-part SVG image: "SP risk" and the risk level
+// with the last audit date, colored by level.
+func (p *SitePresenter) RenderSiteBadgeSVG(badge *audit.SiteBadge) []byte {
+	value, color := "not audited", "#9f9f9f"
+	if badge.IsAudited() {
+		value = fmt.Sprintf("%s · %s", badge.RiskLevel, badge.LastAuditAt.Format("2006-01-02"))
+		switch badge.RiskLevel {
+		case "High":
+			color = "#e05d44"
+		case "Medium":
+			color = "#dfb317"
+		default:
+			color = "#4c1"
+		}
+	}
+
+	const label = "SP risk"
+	labelWidth := badgeTextWidth(label)
+	valueWidth := badgeTextWidth(value)
+	width := labelWidth + valueWidth
+	return []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+
+		`<title>%s: %s</title>`+
+		`<rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/>`+
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`+
+		`<text x="%d" y="14">%s</text><text x="%d" y="14">%s</text></g></svg>`,
+		width, label, html.EscapeString(value),
+		html.EscapeString(badge.Title), html.EscapeString(value),
+		width, labelWidth, valueWidth, color,
+		labelWidth/2, label, labelWidth+valueWidth/2, html.EscapeString(value)))
+}
+
+// badgeTextWidth estimates the width of badge text in pixels, with padding on both sides.
+func badgeTextWidth(text string) int {
+	return len([]rune(text))*7 + 10
+}
@@ -0,0 +1,23 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"spaudit/domain/audit"
+)
+
+func TestSitePresenter_RenderSiteBadgeSVG(t *testing.T) {
+	presenter := NewSitePresenter()
+	auditedAt := time.Date(2026, 5, 31, 10, 0, 0, 0, time.UTC)
+
+	svg := string(presenter.RenderSiteBadgeSVG(&audit.SiteBadge{SiteID: 1, Title: "R&D <Team>", AuditRunID: 3, RiskLevel: "High", LastAuditAt: &auditedAt}))
+	assert.Contains(t, svg, "High · 2026-05-31")
+	assert.Contains(t, svg, "#e05d44")
+	assert.Contains(t, svg, "R&amp;D &lt;Team&gt;", "titles are escaped")
+
+	svg = string(presenter.RenderSiteBadgeSVG(&audit.SiteBadge{SiteID: 4, Title: "New"}))
+	assert.Contains(t, svg, "not audited")
+}
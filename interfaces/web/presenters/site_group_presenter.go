@@ -0,0 +1,25 @@
+package presenters
+
+import "spaudit/application"
+
+// SiteGroupMembersView is a SharePoint group captured by an audit run, with its members.
+type SiteGroupMembersView struct {
+	SiteID     int64               `json:"site_id"`
+	AuditRunID int64               `json:"audit_run_id"`
+	Group      SnapshotPrincipal   `json:"group"`
+	Members    []SnapshotPrincipal `json:"members"`
+}
+
+// ToSiteGroupMembersView converts a SharePoint group and its members for the API.
+func (p *ListPresenter) ToSiteGroupMembersView(siteID, auditRunID int64, data *application.SiteGroupMembersData) SiteGroupMembersView {
+	view := SiteGroupMembersView{
+		SiteID:     siteID,
+		AuditRunID: auditRunID,
+		Group:      p.toSnapshotPrincipal(data.Group),
+		Members:    make([]SnapshotPrincipal, len(data.Members)),
+	}
+	for i, member := range data.Members {
+		view.Members[i] = p.toSnapshotPrincipal(member)
+	}
+	return view
+}
@@ -1,6 +1,10 @@
 package presenters
 
 import (
+	"net/url"
+	"time"
+
+	"spaudit/domain/audit"
 	"spaudit/domain/contracts"
 )
 
@@ -10,6 +14,26 @@ import (
 type SiteSelectionVM struct {
 	Sites         []SiteWithMetadata
 	HasActiveJobs bool
+	Storage       *StorageUsageView // Nil when storage monitoring is unavailable
+	Label         string            // Run label the sites are filtered by, empty for every run
+}
+
+// SitesPath returns a sites table endpoint that keeps the label filter, e.g. "/sites/search?label=prod".
+func (vm SiteSelectionVM) SitesPath(path string) string {
+	if vm.Label == "" {
+		return path
+	}
+	return path + "?label=" + url.QueryEscape(vm.Label)
+}
+
+// SiteView represents a site with latest-run metadata for API responses
+type SiteView struct {
+	ID              int64      `json:"id"`
+	URL             string     `json:"url"`
+	Title           string     `json:"title"`
+	TotalLists      int        `json:"total_lists"`
+	ListsWithUnique int        `json:"lists_with_unique"`
+	LastAuditAt     *time.Time `json:"last_audit_at,omitempty"`
 }
 
 // SitePresenter transforms site service data into UI-ready view models.
@@ -21,19 +45,26 @@ func NewSitePresenter() *SitePresenter {
 }
 
 // ToSiteSelectionViewModel converts service data to site selection view model.
-func (p *SitePresenter) ToSiteSelectionViewModel(sitesData []*contracts.SiteWithMetadata, hasActiveJobs bool) *SiteSelectionVM {
+// A label filters the sites to those audited with runs tagged with it, and links them to their latest such run.
+func (p *SitePresenter) ToSiteSelectionViewModel(sitesData []*contracts.SiteWithMetadata, hasActiveJobs bool, label string) *SiteSelectionVM {
 	return &SiteSelectionVM{
-		Sites:         p.ToSitesWithMetadata(sitesData),
+		Sites:         p.ToSitesWithMetadata(sitesData, label),
 		HasActiveJobs: hasActiveJobs,
+		Label:         label,
 	}
 }
 
-// ToSitesWithMetadata converts service data to view model collection.
-func (p *SitePresenter) ToSitesWithMetadata(sitesData []*contracts.SiteWithMetadata) []SiteWithMetadata {
+// ToSitesWithMetadata converts service data to view model collection, linked to the latest run with label if set.
+func (p *SitePresenter) ToSitesWithMetadata(sitesData []*contracts.SiteWithMetadata, label string) []SiteWithMetadata {
 	viewModels := make([]SiteWithMetadata, len(sitesData))
 
+	runAlias := audit.RunAliasLatest
+	if label != "" {
+		runAlias = audit.LabeledLatestRun(label)
+	}
 	for i, siteData := range sitesData {
 		viewModels[i] = p.toSiteWithMetadata(siteData)
+		viewModels[i].RunAlias = runAlias
 	}
 
 	return viewModels
@@ -58,3 +89,22 @@ func (p *SitePresenter) toSiteWithMetadata(siteData *contracts.SiteWithMetadata)
 		DaysAgo:         siteData.LastAuditDaysAgo,
 	}
 }
+
+// ToSiteViews converts service data to API site views.
+func (p *SitePresenter) ToSiteViews(sitesData []*contracts.SiteWithMetadata) []SiteView {
+	views := make([]SiteView, 0, len(sitesData))
+	for _, siteData := range sitesData {
+		if siteData == nil || siteData.Site == nil {
+			continue
+		}
+		views = append(views, SiteView{
+			ID:              siteData.Site.ID,
+			URL:             siteData.Site.URL,
+			Title:           siteData.Site.Title,
+			TotalLists:      siteData.TotalLists,
+			ListsWithUnique: siteData.ListsWithUnique,
+			LastAuditAt:     siteData.LastAuditDate,
+		})
+	}
+	return views
+}
@@ -0,0 +1,332 @@
+package presenters
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"spaudit/application"
+	"spaudit/domain/sharepoint"
+)
+
+// ListSnapshotSchemaVersion is bumped whenever the snapshot JSON shape changes.
+const ListSnapshotSchemaVersion = 1
+
+// Snapshot JSON structures. Field names are stable so snapshots can be used as golden files.
+
+// ListSnapshot is the canonical JSON representation of a list within an audit run.
+type ListSnapshot struct {
+	SchemaVersion     int                        `json:"schema_version"`
+	SiteID            int64                      `json:"site_id"`
+	AuditRunID        int64                      `json:"audit_run_id"`
+	List              SnapshotList               `json:"list"`
+	Assignments       []SnapshotAssignment       `json:"assignments"`
+	Items             []SnapshotItem             `json:"items"`
+	SharingLinks      []SnapshotSharingLink      `json:"sharing_links"`
+	SensitivityLabels []SnapshotSensitivityLabel `json:"sensitivity_labels"`
+}
+
+// SnapshotList contains list metadata.
+type SnapshotList struct {
+	ID              string  `json:"id"`
+	WebID           string  `json:"web_id"`
+	Title           string  `json:"title"`
+	URL             string  `json:"url"`
+	BaseTemplate    int     `json:"base_template"`
+	ItemCount       int     `json:"item_count"`
+	HasUnique       bool    `json:"has_unique"`
+	UniqueItemCount int     `json:"unique_item_count"`
+	UniqueDensity   float64 `json:"unique_density"`
+
+	Sampling     *SnapshotSampling         `json:"sampling,omitempty"`
+	ItemSettings *SnapshotListItemSettings `json:"item_settings,omitempty"`
+}
+
+// SnapshotListItemSettings are the list's item permission defaults and Microsoft Lists features,
+// omitted for runs that did not capture them.
+type SnapshotListItemSettings struct {
+	ReadSecurity     int    `json:"read_security"`
+	WriteSecurity    int    `json:"write_security"`
+	CommentsDisabled bool   `json:"comments_disabled"`
+	RatingExperience string `json:"rating_experience"`
+}
+
+// SnapshotSampling describes a list whose items were sampled rather than all scanned.
+// The list's unique item count and density are then estimates.
+type SnapshotSampling struct {
+	SampledItemCount int     `json:"sampled_item_count"`
+	Estimated        bool    `json:"estimated"`
+	MarginOfError    float64 `json:"margin_of_error"`
+}
+
+// SnapshotPrincipal identifies a user or group.
+type SnapshotPrincipal struct {
+	ID        int64  `json:"id"`
+	Type      int64  `json:"type"`
+	Kind      string `json:"kind"`
+	Title     string `json:"title"`
+	LoginName string `json:"login_name"`
+	Email     string `json:"email,omitempty"`
+}
+
+// SnapshotRootCause explains why an assignment exists.
+type SnapshotRootCause struct {
+	Type         string `json:"type"`
+	Detail       string `json:"detail"`
+	SourceObject string `json:"source_object,omitempty"`
+	SourceRole   string `json:"source_role,omitempty"`
+}
+
+// SnapshotAssignment is a role assignment with its principal.
+type SnapshotAssignment struct {
+	Principal  SnapshotPrincipal   `json:"principal"`
+	RoleDefID  int64               `json:"role_def_id"`
+	RoleName   string              `json:"role_name"`
+	Inherited  bool                `json:"inherited"`
+	RootCauses []SnapshotRootCause `json:"root_causes,omitempty"`
+}
+
+// SnapshotItem is a list item with its assignments when it has unique permissions.
+type SnapshotItem struct {
+	ID           int                  `json:"id"`
+	GUID         string               `json:"guid"`
+	ListItemGUID string               `json:"list_item_guid"`
+	Name         string               `json:"name"`
+	URL          string               `json:"url"`
+	IsFile       bool                 `json:"is_file"`
+	IsFolder     bool                 `json:"is_folder"`
+	HasUnique    bool                 `json:"has_unique"`
+	Unreadable   []string             `json:"unreadable_fields,omitempty"` // Fields that could not be collected
+	Assignments  []SnapshotAssignment `json:"assignments,omitempty"`
+}
+
+// SnapshotSharingLink is a sharing link with its members.
+type SnapshotSharingLink struct {
+	ID                 string              `json:"id"`
+	ItemGUID           string              `json:"item_guid"`
+	FileFolderUniqueID string              `json:"file_folder_unique_id"`
+	URL                string              `json:"url"`
+	LinkKind           int                 `json:"link_kind"`
+	Scope              int                 `json:"scope"`
+	IsActive           bool                `json:"is_active"`
+	IsDefault          bool                `json:"is_default"`
+	IsEditLink         bool                `json:"is_edit_link"`
+	IsReviewLink       bool                `json:"is_review_link"`
+	CreatedAt          *time.Time          `json:"created_at,omitempty"`
+	CreatedBy          *SnapshotPrincipal  `json:"created_by,omitempty"`
+	TotalMembersCount  int                 `json:"total_members_count"`
+	Members            []SnapshotPrincipal `json:"members"`
+}
+
+// SnapshotSensitivityLabel is a sensitivity label applied to an item.
+// Discovery timestamps are omitted so snapshots of equivalent runs compare equal.
+type SnapshotSensitivityLabel struct {
+	ItemGUID         string     `json:"item_guid"`
+	LabelID          string     `json:"label_id"`
+	DisplayName      string     `json:"display_name"`
+	OwnerEmail       string     `json:"owner_email,omitempty"`
+	SetDate          *time.Time `json:"set_date,omitempty"`
+	AssignmentMethod string     `json:"assignment_method,omitempty"`
+	HasIRMProtection bool       `json:"has_irm_protection"`
+	ContentBits      int        `json:"content_bits"`
+	LabelFlags       int        `json:"label_flags"`
+	PromotionVersion int        `json:"promotion_version"`
+	LabelHash        string     `json:"label_hash,omitempty"`
+}
+
+// EncodeListSnapshot encodes a list snapshot exactly as the snapshot API returns it:
+// indented JSON with a trailing newline. Run manifests hash these bytes.
+func (p *ListPresenter) EncodeListSnapshot(siteID int64, data *application.ListSnapshotData) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(p.ToListSnapshot(siteID, data)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ToListSnapshot converts snapshot service data to its canonical JSON representation.
+// Every section starts empty, so even a nil snapshot keeps the schema's arrays as [] for diffing tools.
+func (p *ListPresenter) ToListSnapshot(siteID int64, data *application.ListSnapshotData) *ListSnapshot {
+	snapshot := &ListSnapshot{
+		SchemaVersion:     ListSnapshotSchemaVersion,
+		SiteID:            siteID,
+		Assignments:       []SnapshotAssignment{},
+		Items:             []SnapshotItem{},
+		SharingLinks:      []SnapshotSharingLink{},
+		SensitivityLabels: []SnapshotSensitivityLabel{},
+	}
+	if data == nil {
+		return snapshot
+	}
+
+	snapshot.AuditRunID = data.AuditRunID
+	if data.List != nil {
+		snapshot.List = SnapshotList{
+			ID:              data.List.ID,
+			WebID:           data.List.WebID,
+			Title:           data.List.Title,
+			URL:             data.List.URL,
+			BaseTemplate:    data.List.BaseTemplate,
+			ItemCount:       data.List.ItemCount,
+			HasUnique:       data.List.HasUnique,
+			UniqueItemCount: data.List.UniqueItemCount,
+			UniqueDensity:   data.List.UniqueDensity,
+		}
+		if data.List.IsSampled() {
+			snapshot.List.Sampling = &SnapshotSampling{
+				SampledItemCount: data.List.SampledItemCount,
+				Estimated:        true,
+				MarginOfError:    data.List.UniqueDensityMargin(),
+			}
+		}
+		if settings := data.List.ItemSettings; settings != nil {
+			snapshot.List.ItemSettings = &SnapshotListItemSettings{
+				ReadSecurity:     settings.ReadSecurity,
+				WriteSecurity:    settings.WriteSecurity,
+				CommentsDisabled: settings.CommentsDisabled,
+				RatingExperience: settings.RatingExperience,
+			}
+		}
+	}
+
+	for _, resolved := range data.Assignments {
+		if resolved == nil || resolved.Assignment == nil {
+			continue
+		}
+		assignment := p.toSnapshotAssignment(resolved.Assignment)
+		for _, cause := range resolved.RootCauses {
+			assignment.RootCauses = append(assignment.RootCauses, SnapshotRootCause{
+				Type:         cause.Type,
+				Detail:       cause.Detail,
+				SourceObject: cause.SourceObject,
+				SourceRole:   cause.SourceRole,
+			})
+		}
+		snapshot.Assignments = append(snapshot.Assignments, assignment)
+	}
+
+	for _, item := range data.Items {
+		snapshotItem := p.toSnapshotItem(item)
+		for _, assignment := range data.ItemAssignments[item.GUID] {
+			snapshotItem.Assignments = append(snapshotItem.Assignments, p.toSnapshotAssignment(assignment))
+		}
+		snapshot.Items = append(snapshot.Items, snapshotItem)
+	}
+
+	for _, link := range data.SharingLinks {
+		snapshot.SharingLinks = append(snapshot.SharingLinks, p.toSnapshotSharingLink(link))
+	}
+
+	for _, label := range data.SensitivityLabels {
+		snapshot.SensitivityLabels = append(snapshot.SensitivityLabels, SnapshotSensitivityLabel{
+			ItemGUID:         label.ItemGUID,
+			LabelID:          label.LabelID,
+			DisplayName:      label.DisplayName,
+			OwnerEmail:       label.OwnerEmail,
+			SetDate:          label.SetDate,
+			AssignmentMethod: label.AssignmentMethod,
+			HasIRMProtection: label.HasIRMProtection,
+			ContentBits:      label.ContentBits,
+			LabelFlags:       label.LabelFlags,
+			PromotionVersion: label.PromotionVersion,
+			LabelHash:        label.LabelHash,
+		})
+	}
+
+	return snapshot
+}
+
+// ItemsPageView is one page of list items for API responses.
+type ItemsPageView struct {
+	Page       int            `json:"page"`
+	PageSize   int            `json:"page_size"`
+	TotalCount int64          `json:"total_count"`
+	Items      []SnapshotItem `json:"items"`
+}
+
+// ToItemsPageView converts a page of list items to its API view.
+// Items carry no assignments; fetch a snapshot for per-item permissions.
+func (p *ListPresenter) ToItemsPageView(data *application.ListItemsPageData) *ItemsPageView {
+	view := &ItemsPageView{
+		Page:       data.Page,
+		PageSize:   data.PageSize,
+		TotalCount: data.TotalCount,
+		Items:      make([]SnapshotItem, 0, len(data.Items)),
+	}
+	for _, item := range data.Items {
+		view.Items = append(view.Items, p.toSnapshotItem(item))
+	}
+	return view
+}
+
+// toSnapshotItem converts a domain item to its snapshot form without assignments.
+func (p *ListPresenter) toSnapshotItem(item *sharepoint.Item) SnapshotItem {
+	return SnapshotItem{
+		ID:           item.ID,
+		GUID:         item.GUID,
+		ListItemGUID: item.ListItemGUID,
+		Name:         item.Name,
+		URL:          item.URL,
+		IsFile:       item.IsFile,
+		IsFolder:     item.IsFolder,
+		HasUnique:    item.HasUnique,
+		Unreadable:   item.UnreadableFields,
+	}
+}
+
+// toSnapshotSharingLink converts a domain sharing link and its members to snapshot form.
+func (p *ListPresenter) toSnapshotSharingLink(link *sharepoint.SharingLink) SnapshotSharingLink {
+	snapshotLink := SnapshotSharingLink{
+		ID:                 link.ID,
+		ItemGUID:           link.ItemGUID,
+		FileFolderUniqueID: link.FileFolderUniqueID,
+		URL:                link.URL,
+		LinkKind:           link.LinkKind,
+		Scope:              link.Scope,
+		IsActive:           link.IsActive,
+		IsDefault:          link.IsDefault,
+		IsEditLink:         link.IsEditLink,
+		IsReviewLink:       link.IsReviewLink,
+		CreatedAt:          link.CreatedAt,
+		TotalMembersCount:  link.TotalMembersCount,
+		Members:            []SnapshotPrincipal{},
+	}
+	if link.CreatedBy != nil {
+		createdBy := p.toSnapshotPrincipal(link.CreatedBy)
+		snapshotLink.CreatedBy = &createdBy
+	}
+	for _, member := range link.Members {
+		snapshotLink.Members = append(snapshotLink.Members, p.toSnapshotPrincipal(member))
+	}
+	return snapshotLink
+}
+
+// toSnapshotAssignment converts a domain assignment to its snapshot form.
+func (p *ListPresenter) toSnapshotAssignment(assignment *sharepoint.Assignment) SnapshotAssignment {
+	var result SnapshotAssignment
+	if assignment.Principal != nil {
+		result.Principal = p.toSnapshotPrincipal(assignment.Principal)
+	}
+	if assignment.RoleAssignment != nil {
+		result.RoleDefID = assignment.RoleAssignment.RoleDefID
+		result.Inherited = assignment.RoleAssignment.Inherited
+	}
+	if assignment.RoleDefinition != nil {
+		result.RoleName = assignment.RoleDefinition.Name
+	}
+	return result
+}
+
+// toSnapshotPrincipal converts a domain principal to its snapshot form.
+func (p *ListPresenter) toSnapshotPrincipal(principal *sharepoint.Principal) SnapshotPrincipal {
+	return SnapshotPrincipal{
+		ID:        principal.ID,
+		Type:      principal.PrincipalType,
+		Kind:      string(principal.Kind()),
+		Title:     principal.Title,
+		LoginName: principal.LoginName,
+		Email:     principal.Email,
+	}
+}
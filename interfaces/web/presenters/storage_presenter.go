@@ -0,0 +1,131 @@
+package presenters
+
+import (
+	"spaudit/application"
+	"spaudit/domain/audit"
+)
+
+// StorageUsageView is the database's disk usage for /health and the dashboard storage panel.
+type StorageUsageView struct {
+	DatabaseBytes  int64              `json:"database_bytes"`
+	WALBytes       int64              `json:"wal_bytes"`
+	FreeBytes      int64              `json:"free_bytes"`
+	TotalBytes     int64              `json:"total_bytes"`
+	SoftLimitBytes int64              `json:"soft_limit_bytes"`
+	OverLimit      bool               `json:"over_limit"`
+	AuditsPaused   bool               `json:"audits_paused"`
+	RecentRuns     []RunRowCountsView `json:"recent_runs"`
+
+	LastMaintenance *MaintenanceRunView `json:"last_maintenance,omitempty"`
+
+	// Human readable sizes for the dashboard
+	TotalSize     string `json:"-"`
+	DatabaseSize  string `json:"-"`
+	WALSize       string `json:"-"`
+	FreeSize      string `json:"-"`
+	SoftLimitSize string `json:"-"` // Empty when no limit is configured
+	UsedPercent   int    `json:"-"` // Of the soft limit, capped at 100
+}
+
+// RunRowCountsView is how many rows an audit run stored in its largest tables.
+type RunRowCountsView struct {
+	AuditRunID      int64  `json:"audit_run_id"`
+	SiteID          int64  `json:"site_id"`
+	SiteURL         string `json:"site_url"`
+	Items           int64  `json:"items"`
+	RoleAssignments int64  `json:"role_assignments"`
+	SharingLinks    int64  `json:"sharing_links"`
+	Principals      int64  `json:"principals"`
+	TotalRows       int64  `json:"total_rows"`
+}
+
+// ToStorageUsageView converts the database's disk usage to its view.
+func (p *SitePresenter) ToStorageUsageView(usage *application.StorageUsage) *StorageUsageView {
+	total := usage.Disk.TotalBytes()
+	view := &StorageUsageView{
+		DatabaseBytes:  usage.Disk.DatabaseBytes,
+		WALBytes:       usage.Disk.WALBytes,
+		FreeBytes:      usage.Disk.FreeBytes,
+		TotalBytes:     total,
+		SoftLimitBytes: usage.Quota.SoftLimitBytes,
+		OverLimit:      usage.OverLimit,
+		AuditsPaused:   usage.Quota.BlocksAudits(total),
+		RecentRuns:     make([]RunRowCountsView, len(usage.Runs)),
+		TotalSize:      formatByteSize(total),
+		DatabaseSize:   formatByteSize(usage.Disk.DatabaseBytes),
+		WALSize:        formatByteSize(usage.Disk.WALBytes),
+		FreeSize:       formatByteSize(usage.Disk.FreeBytes),
+	}
+	if limit := usage.Quota.SoftLimitBytes; limit > 0 {
+		view.SoftLimitSize = formatByteSize(limit)
+		view.UsedPercent = int(min(total*100/limit, 100))
+	}
+	if usage.LastMaintenance != nil {
+		view.LastMaintenance = p.ToMaintenanceRunView(usage.LastMaintenance)
+	}
+	for i, run := range usage.Runs {
+		view.RecentRuns[i] = RunRowCountsView{
+			AuditRunID:      run.AuditRunID,
+			SiteID:          run.SiteID,
+			SiteURL:         run.SiteURL,
+			Items:           run.Items,
+			RoleAssignments: run.RoleAssignments,
+			SharingLinks:    run.SharingLinks,
+			Principals:      run.Principals,
+			TotalRows:       run.Total(),
+		}
+	}
+	return view
+}
+
+// MaintenanceRunView is one database maintenance run for API responses and the dashboard.
+type MaintenanceRunView struct {
+	ID              int64    `json:"id"`
+	JobID           string   `json:"job_id"`
+	Trigger         string   `json:"trigger"`
+	StartedAt       string   `json:"started_at"`
+	CompletedAt     string   `json:"completed_at,omitempty"` // Empty while running
+	BytesBefore     int64    `json:"bytes_before"`
+	BytesAfter      int64    `json:"bytes_after"`
+	ReclaimedBytes  int64    `json:"reclaimed_bytes"`
+	Reclaimed       string   `json:"reclaimed"` // Human readable size
+	Converted       bool     `json:"converted"`
+	IntegrityErrors []string `json:"integrity_errors"`
+	Error           string   `json:"error,omitempty"`
+	Healthy         bool     `json:"healthy"`
+}
+
+// ToMaintenanceRunView converts a database maintenance run to its view.
+func (p *SitePresenter) ToMaintenanceRunView(run *audit.MaintenanceRun) *MaintenanceRunView {
+	view := &MaintenanceRunView{
+		ID:              run.ID,
+		JobID:           run.JobID,
+		Trigger:         run.Trigger,
+		StartedAt:       run.StartedAt.UTC().Format(AuditRunTimeFormat),
+		BytesBefore:     run.BytesBefore,
+		BytesAfter:      run.BytesAfter,
+		ReclaimedBytes:  run.ReclaimedBytes(),
+		Reclaimed:       formatByteSize(run.ReclaimedBytes()),
+		Converted:       run.Converted,
+		IntegrityErrors: run.IntegrityErrors,
+		Error:           run.Error,
+		Healthy:         run.Healthy(),
+	}
+	if run.CompletedAt != nil {
+		view.CompletedAt = run.CompletedAt.UTC().Format(AuditRunTimeFormat)
+	}
+	if view.IntegrityErrors == nil {
+		view.IntegrityErrors = []string{}
+	}
+	return view
+}
+
+// ToMaintenanceRunViews converts maintenance runs to views, preserving order.
+// The result is never nil so an empty list serializes as [] rather than null.
+func (p *SitePresenter) ToMaintenanceRunViews(runs []*audit.MaintenanceRun) []*MaintenanceRunView {
+	views := make([]*MaintenanceRunView, len(runs))
+	for i, run := range runs {
+		views[i] = p.ToMaintenanceRunView(run)
+	}
+	return views
+}
@@ -2,9 +2,11 @@ package presenters
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
+	"spaudit/domain/audit"
 	"spaudit/domain/jobs"
 	"spaudit/interfaces/web/templates/components/ui"
 )
@@ -33,12 +35,16 @@ func (p *ToastPresenter) FormatToastNotification(message, toastType string) (str
 	return buf.String(), nil
 }
 
-// FormatRichJobToastNotification creates a rich toast notification from job data.
-func (p *ToastPresenter) FormatRichJobToastNotification(job *jobs.Job) (string, error) {
+// FormatRichJobToastNotification creates a rich toast notification from job data,
+// offering the reports attached to the job's audit run for download.
+func (p *ToastPresenter) FormatRichJobToastNotification(job *jobs.Job, artifacts []*audit.RunArtifact) (string, error) {
 	ctx := context.Background()
 
 	// Create rich view model from job data
 	toastView := p.createToastViewFromJob(job)
+	for _, artifact := range artifacts {
+		toastView.Attachments = append(toastView.Attachments, toToastAttachmentView(artifact))
+	}
 
 	// Use rich toast template
 	component := ui.RichToastNotification(toastView)
@@ -52,6 +58,35 @@ func (p *ToastPresenter) FormatRichJobToastNotification(job *jobs.Job) (string,
 	return buf.String(), nil
 }
 
+// FormatArtifactToastNotification creates a toast offering a report just attached to an audit run for download.
+func (p *ToastPresenter) FormatArtifactToastNotification(artifact *audit.RunArtifact) (string, error) {
+	ctx := context.Background()
+
+	toastView := ui.ToastNotificationView{
+		Title:       "Report Ready",
+		Message:     fmt.Sprintf("Attached to audit run #%d until %s", artifact.AuditRunID, artifact.ExpiresAt.UTC().Format(AuditRunTimeFormat)),
+		Type:        "info",
+		Attachments: []ui.ToastAttachmentView{toToastAttachmentView(artifact)},
+		Timestamp:   time.Now(),
+	}
+
+	var buf strings.Builder
+	if err := ui.RichToastNotification(toastView).Render(ctx, &buf); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// toToastAttachmentView converts a run artifact to a toast download link.
+func toToastAttachmentView(artifact *audit.RunArtifact) ui.ToastAttachmentView {
+	return ui.ToastAttachmentView{
+		Filename: artifact.Filename,
+		Size:     formatByteSize(artifact.SizeBytes),
+		URL:      RunArtifactDownloadURL(artifact),
+	}
+}
+
 // createToastViewFromJob transforms job domain data into toast view model.
 func (p *ToastPresenter) createToastViewFromJob(job *jobs.Job) ui.ToastNotificationView {
 	// Extract stats from job state
@@ -0,0 +1,67 @@
+package presenters
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"spaudit/domain/events"
+)
+
+// WebhookDeliveryView is a notification posted, or to be posted, to an outbound webhook endpoint.
+type WebhookDeliveryView struct {
+	ID             int64           `json:"id"`
+	Endpoint       string          `json:"endpoint"` // Scheme and host only; paths of chat webhooks embed their secret
+	EventType      string          `json:"event_type"`
+	EventKey       string          `json:"event_key"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus int             `json:"response_status,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	CreatedAt      string          `json:"created_at"`
+	NextAttemptAt  string          `json:"next_attempt_at,omitempty"`
+	DeliveredAt    string          `json:"delivered_at,omitempty"`
+	Payload        json.RawMessage `json:"payload"`
+}
+
+// ToWebhookDeliveryView converts a webhook delivery for the API.
+func (p *ListPresenter) ToWebhookDeliveryView(delivery *events.WebhookDelivery) WebhookDeliveryView {
+	view := WebhookDeliveryView{
+		ID:             delivery.ID,
+		Endpoint:       webhookEndpointOrigin(delivery.EndpointURL),
+		EventType:      string(delivery.EventType),
+		EventKey:       delivery.EventKey,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		LastError:      delivery.LastError,
+		CreatedAt:      delivery.CreatedAt.UTC().Format(time.RFC3339),
+		Payload:        json.RawMessage(delivery.Payload),
+	}
+	if delivery.NextAttemptAt != nil {
+		view.NextAttemptAt = delivery.NextAttemptAt.UTC().Format(time.RFC3339)
+	}
+	if delivery.DeliveredAt != nil {
+		view.DeliveredAt = delivery.DeliveredAt.UTC().Format(time.RFC3339)
+	}
+	return view
+}
+
+// ToWebhookDeliveryViews converts webhook deliveries for the API, preserving their order.
+func (p *ListPresenter) ToWebhookDeliveryViews(deliveries []*events.WebhookDelivery) []WebhookDeliveryView {
+	views := make([]WebhookDeliveryView, len(deliveries))
+	for i, delivery := range deliveries {
+		views[i] = p.ToWebhookDeliveryView(delivery)
+	}
+	return views
+}
+
+// webhookEndpointOrigin returns the scheme and host of an endpoint URL, dropping the path and query
+// that may carry a token.
+func webhookEndpointOrigin(endpoint string) string {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" {
+		return ""
+	}
+	return parsed.Scheme + "://" + parsed.Host
+}
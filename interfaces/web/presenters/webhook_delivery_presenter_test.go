@@ -0,0 +1,35 @@
+package presenters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"spaudit/domain/events"
+)
+
+func TestListPresenter_ToWebhookDeliveryView(t *testing.T) {
+	presenter := NewListPresenter()
+	createdAt := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	nextAttemptAt := createdAt.Add(time.Minute)
+
+	view := presenter.ToWebhookDeliveryView(&events.WebhookDelivery{
+		ID:             3,
+		EndpointURL:    "https://hooks.slack.com/services/T000/B000/secret-token?x=1",
+		EventType:      events.WebhookJobFailed,
+		EventKey:       "job_event:9",
+		Payload:        []byte(`{"type":"job_failed"}`),
+		Status:         events.WebhookDeliveryPending,
+		Attempts:       1,
+		ResponseStatus: 503,
+		LastError:      "endpoint answered 503 Service Unavailable",
+		CreatedAt:      createdAt,
+		NextAttemptAt:  &nextAttemptAt,
+	})
+	assert.Equal(t, "https://hooks.slack.com", view.Endpoint, "endpoint paths may carry a token")
+	assert.Equal(t, "job_failed", view.EventType)
+	assert.Equal(t, "2025-03-01T12:01:00Z", view.NextAttemptAt)
+	assert.Empty(t, view.DeliveredAt)
+	assert.JSONEq(t, `{"type":"job_failed"}`, string(view.Payload))
+}
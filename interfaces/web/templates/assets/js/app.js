@@ -26,4 +26,20 @@ document.addEventListener('DOMContentLoaded', function() {
             }
         }
     });
-});
\ No newline at end of file
+});
+
+// Export buttons carry the current filter/sort inputs into the CSV export URL
+document.addEventListener('click', function(e) {
+    const link = e.target.closest('a[data-export-include]');
+    if (!link || !link.dataset.exportInclude) {
+        return;
+    }
+
+    const url = new URL(link.getAttribute('href'), window.location.origin);
+    document.querySelectorAll(link.dataset.exportInclude).forEach(function(input) {
+        if (input.name) {
+            url.searchParams.set(input.name, input.value);
+        }
+    });
+    link.href = url.pathname + url.search;
+});
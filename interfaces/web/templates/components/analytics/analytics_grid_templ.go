@@ -71,6 +71,12 @@ func AnalyticsGrid(analytics presenters.ListAnalytics) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
+			if analytics.List.Sampled {
+				templ_7745c5c3_Err = MetricItem("Sampled Items", fmt.Sprintf("%d", analytics.List.SampledItemCount), "Item figures cover the sample only", "warning").Render(ctx, templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
 			templ_7745c5c3_Err = PermissionScopeMetric(analytics.List.HasUnique).Render(ctx, templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
@@ -44,7 +44,7 @@ func Layout(title string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</title><script src=\"https://cdn.tailwindcss.com\"></script><script src=\"https://unpkg.com/htmx.org@2.0.6\" crossorigin=\"anonymous\"></script><script src=\"https://unpkg.com/htmx-ext-sse@2.2.2/sse.js\" crossorigin=\"anonymous\"></script><link rel=\"stylesheet\" href=\"/assets/css/components.css\"><script src=\"/assets/js/app.js\"></script></head><body class=\"min-h-screen bg-slate-50 text-slate-900\" hx-boost=\"true\" hx-ext=\"sse\" sse-connect=\"/events\"><header class=\"border-b bg-white shadow-sm\"><div class=\"max-w-7xl mx-auto px-4 py-4 flex items-center justify-between\"><div class=\"flex items-center gap-3\"><div class=\"h-10 w-10 rounded-xl bg-gradient-to-br from-blue-500 to-blue-600 grid place-items-center text-white font-bold text-lg shadow-sm\">SP</div><div><h1 class=\"text-lg font-semibold text-slate-900\">SharePoint Audit</h1><p class=\"text-xs text-slate-500\">Permissions & Sharing Link Analysis Tool</p></div></div><nav class=\"flex items-center gap-4\"><a href=\"/\" class=\"text-sm text-slate-600 hover:text-slate-900 px-3 py-2 rounded-lg hover:bg-slate-50 transition-colors\">Dashboard</a></nav></div></header><main class=\"max-w-7xl mx-auto p-4\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "</title><script src=\"https://cdn.tailwindcss.com\"></script><script src=\"https://unpkg.com/htmx.org@2.0.6\" crossorigin=\"anonymous\"></script><script src=\"https://unpkg.com/htmx-ext-sse@2.2.2/sse.js\" crossorigin=\"anonymous\"></script><link rel=\"stylesheet\" href=\"/assets/css/components.css\"><link rel=\"stylesheet\" href=\"/assets/css/print.css\" media=\"print\"><script src=\"/assets/js/app.js\"></script></head><body class=\"min-h-screen bg-slate-50 text-slate-900\" hx-boost=\"true\" hx-ext=\"sse\" sse-connect=\"/events\"><header class=\"border-b bg-white shadow-sm\"><div class=\"max-w-7xl mx-auto px-4 py-4 flex items-center justify-between\"><div class=\"flex items-center gap-3\"><div class=\"h-10 w-10 rounded-xl bg-gradient-to-br from-blue-500 to-blue-600 grid place-items-center text-white font-bold text-lg shadow-sm\">SP</div><div><h1 class=\"text-lg font-semibold text-slate-900\">SharePoint Audit</h1><p class=\"text-xs text-slate-500\">Permissions & Sharing Link Analysis Tool</p></div></div><nav class=\"flex items-center gap-4\"><a href=\"/\" class=\"text-sm text-slate-600 hover:text-slate-900 px-3 py-2 rounded-lg hover:bg-slate-50 transition-colors\">Dashboard</a> <a href=\"/lookup\" class=\"text-sm text-slate-600 hover:text-slate-900 px-3 py-2 rounded-lg hover:bg-slate-50 transition-colors\">Item Lookup</a> <a href=\"/exceptions/review\" class=\"text-sm text-slate-600 hover:text-slate-900 px-3 py-2 rounded-lg hover:bg-slate-50 transition-colors\">Exception Review</a></nav></div></header><main class=\"max-w-7xl mx-auto p-4\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -72,4 +72,56 @@ func Layout(title string) templ.Component {
 	})
 }
 
+// PrintLayout is the static page of a printable summary: no scripts, navigation or live updates,
+// so it prints and saves as PDF the same way it looks on screen.
+func PrintLayout(title string) templ.Component {
+	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
+		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
+		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
+			return templ_7745c5c3_CtxErr
+		}
+		templ_7745c5c3_Buffer, templ_7745c5c3_IsBuffer := templruntime.GetBuffer(templ_7745c5c3_W)
+		if !templ_7745c5c3_IsBuffer {
+			defer func() {
+				templ_7745c5c3_BufErr := templruntime.ReleaseBuffer(templ_7745c5c3_Buffer)
+				if templ_7745c5c3_Err == nil {
+					templ_7745c5c3_Err = templ_7745c5c3_BufErr
+				}
+			}()
+		}
+		ctx = templ.InitializeContext(ctx)
+		templ_7745c5c3_Var3 := templ.GetChildren(ctx)
+		if templ_7745c5c3_Var3 == nil {
+			templ_7745c5c3_Var3 = templ.NopComponent
+		}
+		ctx = templ.ClearChildren(ctx)
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "<!doctype html><html lang=\"en\"><head><meta charset=\"utf-8\"><meta name=\"viewport\" content=\"width=device-width, initial-scale=1\"><title>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		var templ_7745c5c3_Var4 string
+		templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(title)
+		if templ_7745c5c3_Err != nil {
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `interfaces/web/templates/components/core/layout.templ`, Line: 55, Col: 20}
+		}
+		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "</title><link rel=\"stylesheet\" href=\"/assets/css/print.css\"></head><body class=\"print-report\">")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templ_7745c5c3_Var3.Render(ctx, templ_7745c5c3_Buffer)
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 7, "</body></html>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		return nil
+	})
+}
+
 var _ = templruntime.GeneratedTemplate
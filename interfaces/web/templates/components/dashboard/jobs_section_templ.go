@@ -50,7 +50,7 @@ func BackgroundJobsSection(vm presenters.SiteSelectionVM) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "\"><div class=\"bg-white border rounded-xl shadow-sm\"><div class=\"px-6 py-4 border-b\"><h2 class=\"font-semibold text-lg text-slate-900\">Background Jobs</h2><p class=\"text-sm text-slate-500\">Track the progress of your audit jobs</p></div><div id=\"jobs-list\" hx-get=\"/jobs\" hx-trigger=\"load, sse:jobs-updated\" hx-swap=\"innerHTML\" class=\"divide-y divide-slate-200\"><!-- Jobs will be loaded here via HTMX with real-time SSE updates --></div></div></div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "\"><div class=\"bg-white border rounded-xl shadow-sm\"><div class=\"px-6 py-4 border-b\"><h2 class=\"font-semibold text-lg text-slate-900\">Background Jobs</h2><p class=\"text-sm text-slate-500\">Track the progress of your audit jobs</p></div><div id=\"jobs-list\" hx-get=\"/jobs\" hx-trigger=\"load, sse:jobs-updated\" hx-swap=\"innerHTML\" class=\"divide-y divide-slate-200\"><!-- Jobs will be loaded here via HTMX with real-time SSE updates --></div></div><!-- A job's live log panel is loaded here from its Log button --><div id=\"audit-log-panel\"></div></div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
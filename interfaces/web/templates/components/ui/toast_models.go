@@ -12,6 +12,9 @@ type ToastNotificationView struct {
 	SiteURL   string
 	Stats     *ToastStatsView
 	Timestamp time.Time
+
+	// Reports attached to the notification, offered for download
+	Attachments []ToastAttachmentView
 }
 
 // ToastStatsView represents job statistics for the toast.
@@ -22,3 +25,10 @@ type ToastStatsView struct {
 	SharingLinks     int
 	ErrorsCount      int
 }
+
+// ToastAttachmentView represents a downloadable report in a toast.
+type ToastAttachmentView struct {
+	Filename string
+	Size     string
+	URL      string
+}
@@ -97,7 +97,7 @@ func ToastNotification(message, toastType string) templ.Component {
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</span> <button onclick=\"this.parentElement.parentElement.style.animation = 'fadeOut 0.3s ease-in'; setTimeout(() => this.parentElement.parentElement.remove(), 300);\" class=\"ml-4 text-white hover:text-gray-200 focus:outline-none\"><svg class=\"w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M4.293 4.293a1 1 0 011.414 0L10 8.586l4.293-4.293a1 1 0 111.414 1.414L11.414 10l4.293 4.293a1 1 0 01-1.414 1.414L10 11.414l-4.293 4.293a1 1 0 01-1.414-1.414L8.586 10 4.293 5.707a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></button></div></div><script>\r\n\t\t// Auto-remove toast after 5 seconds\r\n\t\tsetTimeout(function() {\r\n\t\t\tvar toasts = document.querySelectorAll('.toast');\r\n\t\t\tvar lastToast = toasts[toasts.length - 1];\r\n\t\t\tif (lastToast) {\r\n\t\t\t\tlastToast.style.animation = 'fadeOut 0.3s ease-in';\r\n\t\t\t\tsetTimeout(() => lastToast.remove(), 300);\r\n\t\t\t}\r\n\t\t}, 5000);\r\n\t</script><style>\r\n\t\t@keyframes slideIn {\r\n\t\t\tfrom { opacity: 0; transform: translateX(100%); }\r\n\t\t\tto { opacity: 1; transform: translateX(0); }\r\n\t\t}\r\n\t\t@keyframes fadeOut {\r\n\t\t\tfrom { opacity: 1; transform: translateX(0); }\r\n\t\t\tto { opacity: 0; transform: translateX(100%); }\r\n\t\t}\r\n\t</style>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "</span> <button onclick=\"this.parentElement.parentElement.style.animation = 'fadeOut 0.3s ease-in'; setTimeout(() => this.parentElement.parentElement.remove(), 300);\" class=\"ml-4 text-white hover:text-gray-200 focus:outline-none\"><svg class=\"w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M4.293 4.293a1 1 0 011.414 0L10 8.586l4.293-4.293a1 1 0 111.414 1.414L11.414 10l4.293 4.293a1 1 0 01-1.414 1.414L10 11.414l-4.293 4.293a1 1 0 01-1.414-1.414L8.586 10 4.293 5.707a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></button></div></div><script>\n\t\t// Auto-remove toast after 5 seconds\n\t\tsetTimeout(function() {\n\t\t\tvar toasts = document.querySelectorAll('.toast');\n\t\t\tvar lastToast = toasts[toasts.length - 1];\n\t\t\tif (lastToast) {\n\t\t\t\tlastToast.style.animation = 'fadeOut 0.3s ease-in';\n\t\t\t\tsetTimeout(() => lastToast.remove(), 300);\n\t\t\t}\n\t\t}, 5000);\n\t</script><style>\n\t\t@keyframes slideIn {\n\t\t\tfrom { opacity: 0; transform: translateX(100%); }\n\t\t\tto { opacity: 1; transform: translateX(0); }\n\t\t}\n\t\t@keyframes fadeOut {\n\t\t\tfrom { opacity: 1; transform: translateX(0); }\n\t\t\tto { opacity: 0; transform: translateX(100%); }\n\t\t}\n\t</style>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -353,7 +353,79 @@ func RichToastNotification(toast ToastNotificationView) templ.Component {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, "</div><script>\r\n\t\t// Auto-remove toast after 8 seconds (longer for rich toast)\r\n\t\tsetTimeout(function() {\r\n\t\t\tvar toasts = document.querySelectorAll('.toast');\r\n\t\t\tvar lastToast = toasts[toasts.length - 1];\r\n\t\t\tif (lastToast) {\r\n\t\t\t\tlastToast.style.animation = 'slideOutUp 0.3s ease-in';\r\n\t\t\t\tsetTimeout(() => lastToast.remove(), 300);\r\n\t\t\t}\r\n\t\t}, 8000);\r\n\t</script><style>\r\n\t\t@keyframes slideInUp {\r\n\t\t\tfrom { opacity: 0; transform: translateY(100%) translateX(0); }\r\n\t\t\tto { opacity: 1; transform: translateY(0) translateX(0); }\r\n\t\t}\r\n\t\t@keyframes slideOutUp {\r\n\t\t\tfrom { opacity: 1; transform: translateY(0) translateX(0); }\r\n\t\t\tto { opacity: 0; transform: translateY(-100%) translateX(0); }\r\n\t\t}\r\n\t</style>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, "<!-- Attached reports if available -->")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if len(toast.Attachments) > 0 {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, "<div class=\"border-t pt-2 mt-2 space-y-1 text-xs\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			for _, attachment := range toast.Attachments {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 32, "<div class=\"flex items-center justify-between gap-2\"><a href=\"")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var19 templ.SafeURL
+				templ_7745c5c3_Var19, templ_7745c5c3_Err = templ.JoinURLErrs(templ.SafeURL(attachment.URL))
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `interfaces/web/templates/components/ui/toast.templ`, Line: 133, Col: 45}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var19))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, "\" class=\"truncate text-blue-600 hover:text-blue-700 font-medium hover:underline\" title=\"")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var20 string
+				templ_7745c5c3_Var20, templ_7745c5c3_Err = templ.JoinStringErrs(attachment.Filename)
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `interfaces/web/templates/components/ui/toast.templ`, Line: 133, Col: 154}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var20))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 34, "\">📎 ")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var21 string
+				templ_7745c5c3_Var21, templ_7745c5c3_Err = templ.JoinStringErrs(attachment.Filename)
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `interfaces/web/templates/components/ui/toast.templ`, Line: 133, Col: 183}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var21))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 35, "</a> <span class=\"text-slate-500 flex-shrink-0\">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var22 string
+				templ_7745c5c3_Var22, templ_7745c5c3_Err = templ.JoinStringErrs(attachment.Size)
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `interfaces/web/templates/components/ui/toast.templ`, Line: 134, Col: 66}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var22))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 36, "</span></div>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 37, "</div>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 38, "</div><script>\n\t\t// Auto-remove toast after 8 seconds (longer for rich toast)\n\t\tsetTimeout(function() {\n\t\t\tvar toasts = document.querySelectorAll('.toast');\n\t\t\tvar lastToast = toasts[toasts.length - 1];\n\t\t\tif (lastToast) {\n\t\t\t\tlastToast.style.animation = 'slideOutUp 0.3s ease-in';\n\t\t\t\tsetTimeout(() => lastToast.remove(), 300);\n\t\t\t}\n\t\t}, 8000);\n\t</script><style>\n\t\t@keyframes slideInUp {\n\t\t\tfrom { opacity: 0; transform: translateY(100%) translateX(0); }\n\t\t\tto { opacity: 1; transform: translateY(0) translateX(0); }\n\t\t}\n\t\t@keyframes slideOutUp {\n\t\t\tfrom { opacity: 1; transform: translateY(0) translateX(0); }\n\t\t\tto { opacity: 0; transform: translateY(-100%) translateX(0); }\n\t\t}\n\t</style>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
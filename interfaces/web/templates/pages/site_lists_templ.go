@@ -10,7 +10,6 @@ import templruntime "github.com/a-h/templ/runtime"
 
 import (
 	"spaudit/interfaces/web/presenters"
-	"spaudit/interfaces/web/templates/components"
 	"spaudit/interfaces/web/templates/components/core"
 	"spaudit/interfaces/web/templates/components/site"
 )
@@ -48,7 +47,7 @@ func SiteListsPage(vm presenters.SiteListsVM) templ.Component {
 				}()
 			}
 			ctx = templ.InitializeContext(ctx)
-			templ_7745c5c3_Err = site.SiteBreadcrumbs(vm.Site).Render(ctx, templ_7745c5c3_Buffer)
+			templ_7745c5c3_Err = site.RunContextBanner(vm.RunContext).Render(ctx, templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -64,17 +63,15 @@ func SiteListsPage(vm presenters.SiteListsVM) templ.Component {
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			if len(vm.AuditRuns) > 0 {
-				templ_7745c5c3_Err = components.AuditRunSelector(vm.Site.SiteID, vm.AuditRunID, vm.AuditRuns).Render(ctx, templ_7745c5c3_Buffer)
-				if templ_7745c5c3_Err != nil {
-					return templ_7745c5c3_Err
-				}
+			templ_7745c5c3_Err = site.SiteStatsGrid(vm).Render(ctx, templ_7745c5c3_Buffer)
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
 			}
 			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, " ")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = site.SiteStatsGrid(vm).Render(ctx, templ_7745c5c3_Buffer)
+			templ_7745c5c3_Err = site.WebPermissionsPanel(vm).Render(ctx, templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
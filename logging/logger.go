@@ -14,14 +14,18 @@ type Config struct {
 	Level  string `env:"LOG_LEVEL" default:"info"`
 	Format string `env:"LOG_FORMAT" default:"json"`
 	Output string `env:"LOG_OUTPUT" default:"stdout"`
+
+	// TailLines is how many recent job log lines are kept for the UI's live log panel
+	TailLines int `env:"LOG_TAIL_LINES" default:"1000"`
 }
 
 // DefaultConfig returns the default logging configuration
 func DefaultConfig() *Config {
 	return &Config{
-		Level:  "info",
-		Format: "json",
-		Output: "stdout",
+		Level:     "info",
+		Format:    "json",
+		Output:    "stdout",
+		TailLines: 1000,
 	}
 }
 
@@ -94,6 +98,13 @@ func (l *Logger) WithComponent(component string) *Logger {
 	}
 }
 
+// WithJob scopes the logger to a background job; a LogTail keeps the records of job-scoped loggers
+func (l *Logger) WithJob(jobID string) *Logger {
+	return &Logger{
+		Logger: l.Logger.With(JobIDKey, jobID),
+	}
+}
+
 // WithContext adds request context to logger (if available)
 func (l *Logger) WithContext(ctx context.Context) *Logger {
 	// Extract common context values if available
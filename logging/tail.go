@@ -0,0 +1,197 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+)
+
+// JobIDKey is the attribute WithJob tags job-scoped log records with
+const JobIDKey = "job_id"
+
+// tailSubscriberBuffer is how many lines a slow subscriber may fall behind before lines are dropped for it
+const tailSubscriberBuffer = 256
+
+// LogLine is a job-scoped log record kept by a LogTail
+type LogLine struct {
+	Time      time.Time
+	Level     string
+	JobID     string
+	Component string
+	Message   string
+	Attrs     string // Remaining attributes as key=value pairs
+}
+
+// LogTail keeps the most recent job-scoped log lines in memory and fans new ones out to subscribers,
+// so operators can watch an audit without access to the server's log output.
+// Only records from loggers created with WithJob are kept.
+type LogTail struct {
+	mu          sync.Mutex
+	lines       []LogLine
+	next        int
+	full        bool
+	subscribers map[chan LogLine]string
+}
+
+// NewLogTail creates a log tail keeping up to capacity lines across all jobs
+func NewLogTail(capacity int) *LogTail {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LogTail{
+		lines:       make([]LogLine, capacity),
+		subscribers: make(map[chan LogLine]string),
+	}
+}
+
+// Attach returns a logger writing to the same output as logger that also feeds the tail
+func (t *LogTail) Attach(logger *Logger) *Logger {
+	return &Logger{
+		Logger: slog.New(&tailHandler{next: logger.Handler(), tail: t}),
+	}
+}
+
+// Recent returns the kept lines for a job, oldest first. An empty jobID returns lines of all jobs.
+func (t *LogTail) Recent(jobID string) []LogLine {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.recentLocked(jobID)
+}
+
+func (t *LogTail) recentLocked(jobID string) []LogLine {
+	ordered := t.lines[:t.next]
+	if t.full {
+		ordered = append(append([]LogLine{}, t.lines[t.next:]...), t.lines[:t.next]...)
+	}
+
+	recent := make([]LogLine, 0, len(ordered))
+	for _, line := range ordered {
+		if jobID == "" || line.JobID == jobID {
+			recent = append(recent, line)
+		}
+	}
+	return recent
+}
+
+// Subscribe returns the kept lines for a job, oldest first, and a channel receiving the job's new lines.
+// An empty jobID follows all jobs. Lines are dropped rather than block logging when the subscriber falls behind.
+// The returned function unsubscribes and closes the channel.
+func (t *LogTail) Subscribe(jobID string) ([]LogLine, <-chan LogLine, func()) {
+	ch := make(chan LogLine, tailSubscriberBuffer)
+
+	t.mu.Lock()
+	recent := t.recentLocked(jobID)
+	t.subscribers[ch] = jobID
+	t.mu.Unlock()
+
+	var once sync.Once
+	return recent, ch, func() {
+		once.Do(func() {
+			t.mu.Lock()
+			delete(t.subscribers, ch)
+			t.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// add keeps a line and sends it to matching subscribers
+func (t *LogTail) add(line LogLine) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.lines[t.next] = line
+	t.next = (t.next + 1) % len(t.lines)
+	if t.next == 0 {
+		t.full = true
+	}
+
+	for ch, jobID := range t.subscribers {
+		if jobID != "" && jobID != line.JobID {
+			continue
+		}
+		select {
+		case ch <- line:
+		default:
+		}
+	}
+}
+
+// tailHandler passes records to the wrapped handler and copies job-scoped ones into the tail
+type tailHandler struct {
+	next      slog.Handler
+	tail      *LogTail
+	jobID     string
+	component string
+	attrs     []string
+}
+
+func (h *tailHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *tailHandler) Handle(ctx context.Context, record slog.Record) error {
+	err := h.next.Handle(ctx, record)
+	if h.jobID == "" {
+		return err
+	}
+
+	attrs := append([]string{}, h.attrs...)
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, formatTailAttr(attr))
+		return true
+	})
+
+	h.tail.add(LogLine{
+		Time:      record.Time,
+		Level:     record.Level.String(),
+		JobID:     h.jobID,
+		Component: h.component,
+		Message:   record.Message,
+		Attrs:     strings.Join(attrs, " "),
+	})
+	return err
+}
+
+func (h *tailHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	derived := &tailHandler{
+		next:      h.next.WithAttrs(attrs),
+		tail:      h.tail,
+		jobID:     h.jobID,
+		component: h.component,
+		attrs:     append([]string{}, h.attrs...),
+	}
+	for _, attr := range attrs {
+		switch attr.Key {
+		case JobIDKey:
+			derived.jobID = attr.Value.String()
+		case "component":
+			derived.component = attr.Value.String()
+		default:
+			derived.attrs = append(derived.attrs, formatTailAttr(attr))
+		}
+	}
+	return derived
+}
+
+func (h *tailHandler) WithGroup(name string) slog.Handler {
+	return &tailHandler{
+		next:      h.next.WithGroup(name),
+		tail:      h.tail,
+		jobID:     h.jobID,
+		component: h.component,
+		attrs:     h.attrs,
+	}
+}
+
+// formatTailAttr formats an attribute as key=value, quoting values with spaces
+func formatTailAttr(attr slog.Attr) string {
+	value := attr.Value.Resolve().String()
+	if strings.ContainsAny(value, " \t\n\"") {
+		value = fmt.Sprintf("%q", value)
+	}
+	return attr.Key + "=" + value
+}
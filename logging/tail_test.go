@@ -0,0 +1,90 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTailTestLogger(tail *LogTail) (*Logger, *bytes.Buffer) {
+	var out bytes.Buffer
+	base := &Logger{Logger: slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: slog.LevelDebug}))}
+	return tail.Attach(base), &out
+}
+
+func TestLogTail_KeepsOnlyJobScopedLines(t *testing.T) {
+	tail := NewLogTail(10)
+	logger, out := newTailTestLogger(tail)
+
+	logger.Info("Server started")
+	logger.Info("Broadcast", JobIDKey, "job-1")
+	logger.WithJob("job-1").WithComponent("audit_workflow").Info("Collecting lists", "site_url", "https://x", "lists", 3)
+
+	lines := tail.Recent("")
+	require.Len(t, lines, 1)
+	assert.Equal(t, "job-1", lines[0].JobID)
+	assert.Equal(t, "INFO", lines[0].Level)
+	assert.Equal(t, "audit_workflow", lines[0].Component)
+	assert.Equal(t, "Collecting lists", lines[0].Message)
+	assert.Equal(t, "site_url=https://x lists=3", lines[0].Attrs)
+
+	assert.Contains(t, out.String(), "Server started", "every line still reaches the wrapped handler")
+	assert.Contains(t, out.String(), "Collecting lists")
+}
+
+func TestLogTail_RecentFiltersByJobAndDropsOldest(t *testing.T) {
+	tail := NewLogTail(3)
+	logger, _ := newTailTestLogger(tail)
+	job1, job2 := logger.WithJob("job-1"), logger.WithJob("job-2")
+
+	job1.Info("one")
+	job2.Info("two")
+	job1.Info("three")
+	job1.Info("four")
+
+	var messages []string
+	for _, line := range tail.Recent("") {
+		messages = append(messages, line.Message)
+	}
+	assert.Equal(t, []string{"two", "three", "four"}, messages)
+
+	messages = nil
+	for _, line := range tail.Recent("job-1") {
+		messages = append(messages, line.Message)
+	}
+	assert.Equal(t, []string{"three", "four"}, messages)
+}
+
+func TestLogTail_Subscribe(t *testing.T) {
+	tail := NewLogTail(10)
+	logger, _ := newTailTestLogger(tail)
+	job1 := logger.WithJob("job-1")
+
+	job1.Info("before")
+	recent, lines, unsubscribe := tail.Subscribe("job-1")
+	require.Len(t, recent, 1)
+	assert.Equal(t, "before", recent[0].Message)
+
+	logger.WithJob("job-2").Info("other job")
+	job1.Warn("after")
+
+	select {
+	case line := <-lines:
+		assert.Equal(t, "after", line.Message)
+		assert.Equal(t, "WARN", line.Level)
+	case <-time.After(time.Second):
+		t.Fatal("subscriber did not receive the new line")
+	}
+
+	unsubscribe()
+	unsubscribe()
+	_, open := <-lines
+	assert.False(t, open, "unsubscribing closes the channel")
+
+	job1.Info("after unsubscribe")
+	assert.Len(t, tail.Recent("job-1"), 3)
+}
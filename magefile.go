@@ -6,6 +6,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -97,6 +98,7 @@ func Deps() error {
 		{"go", "install", "github.com/golangci/golangci-lint/cmd/golangci-lint@latest"},
 		{"go", "install", "github.com/go-delve/delve/cmd/dlv@latest"},
 		{"go", "install", "golang.org/x/vuln/cmd/govulncheck@latest"},
+		{"go", "install", "golang.org/x/perf/cmd/benchstat@latest"},
 	}
 	for _, c := range cmds {
 		if err := sh(c[0], c[1:]...); err != nil {
@@ -182,6 +184,37 @@ func Cover() error {
 	return sh("go", "tool", "cover", "-html=coverage.out", "-o", "coverage.html")
 }
 
+// Bench: run the benchmarks over the synthetic dataset (test/dataset) and write them to bench.txt.
+// Set BENCH_COUNT for more samples (default 5), and BENCH_BASELINE to an earlier bench.txt to
+// compare with benchstat, e.g. the output of 'mage bench' on the main branch.
+func Bench() error {
+	count := os.Getenv("BENCH_COUNT")
+	if count == "" {
+		count = "5"
+	}
+
+	f, err := os.Create("bench.txt")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	cmd := exec.Command("go", "test", "-run", "^$", "-bench", ".", "-benchmem", "-count", count, "./...")
+	cmd.Stdout, cmd.Stderr = io.MultiWriter(os.Stdout, f), os.Stderr
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	baseline := os.Getenv("BENCH_BASELINE")
+	if baseline == "" {
+		return nil
+	}
+	if !which("benchstat") {
+		return fmt.Errorf("benchstat not found; run 'mage deps'")
+	}
+	return sh("benchstat", baseline, "bench.txt")
+}
+
 // Lint: vet + staticcheck + golangci-lint
 func Lint() error {
 	for _, b := range []string{"staticcheck", "golangci-lint"} {
@@ -0,0 +1,406 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// auditRunTimeLayout is the layout of audit run timestamps, which the server reports in UTC.
+const auditRunTimeLayout = "2006-01-02 15:04:05"
+
+// RunID identifies an audit run: a numeric ID from Run, or one of the aliases.
+type RunID string
+
+// Audit run aliases resolved by the server.
+const (
+	LatestRun    RunID = "latest"    // Latest run according to the server's LATEST_RUN_POLICY
+	ReferenceRun RunID = "reference" // The site's pinned reference run, or latest when none is pinned
+	BaselineRun  RunID = "baseline"  // The site's approved baseline run
+)
+
+// Run returns the RunID of a numeric audit run ID.
+func Run(id int64) RunID {
+	return RunID(strconv.FormatInt(id, 10))
+}
+
+// LatestLabeledRun returns the RunID of the latest run tagged with label, e.g. "latest@prod-tenant".
+func LatestLabeledRun(label string) RunID {
+	return LatestRun + "@" + RunID(label)
+}
+
+// Site is an audited site with metadata from its latest audit run.
+type Site struct {
+	ID              int64      `json:"id"`
+	URL             string     `json:"url"`
+	Title           string     `json:"title"`
+	TotalLists      int        `json:"total_lists"`
+	ListsWithUnique int        `json:"lists_with_unique"`
+	LastAuditAt     *time.Time `json:"last_audit_at,omitempty"`
+}
+
+// AuditRun is one audit of a site.
+type AuditRun struct {
+	ID          int64
+	SiteID      int64
+	JobID       string
+	StartedAt   time.Time
+	CompletedAt *time.Time // nil while the run is in progress
+	Status      string     // "running" or "completed"
+	Trigger     string
+	OnHold      bool       // Exempt from retention pruning and archival
+	HeldAt      *time.Time // nil when not on hold
+	HoldReason  string
+	ScopePath   string // Folder a targeted audit was limited to, empty for a full site audit
+	Label       string // Environment or source the run was tagged with, empty when unlabeled
+}
+
+// auditRunResponse is the wire form of AuditRun.
+type auditRunResponse struct {
+	ID          int64  `json:"id"`
+	SiteID      int64  `json:"site_id"`
+	JobID       string `json:"job_id"`
+	StartedAt   string `json:"started_at"`
+	CompletedAt string `json:"completed_at"`
+	Status      string `json:"status"`
+	Trigger     string `json:"trigger"`
+	OnHold      bool   `json:"on_hold"`
+	HeldAt      string `json:"held_at"`
+	HoldReason  string `json:"hold_reason"`
+	ScopePath   string `json:"scope_path"`
+	Label       string `json:"label"`
+}
+
+func (r auditRunResponse) toAuditRun() (AuditRun, error) {
+	run := AuditRun{
+		ID:         r.ID,
+		SiteID:     r.SiteID,
+		JobID:      r.JobID,
+		Status:     r.Status,
+		Trigger:    r.Trigger,
+		OnHold:     r.OnHold,
+		HoldReason: r.HoldReason,
+		ScopePath:  r.ScopePath,
+		Label:      r.Label,
+	}
+
+	startedAt, err := time.ParseInLocation(auditRunTimeLayout, r.StartedAt, time.UTC)
+	if err != nil {
+		return AuditRun{}, fmt.Errorf("audit run %d: invalid started_at: %w", r.ID, err)
+	}
+	run.StartedAt = startedAt
+
+	if r.CompletedAt != "" {
+		completedAt, err := time.ParseInLocation(auditRunTimeLayout, r.CompletedAt, time.UTC)
+		if err != nil {
+			return AuditRun{}, fmt.Errorf("audit run %d: invalid completed_at: %w", r.ID, err)
+		}
+		run.CompletedAt = &completedAt
+	}
+
+	if r.HeldAt != "" {
+		heldAt, err := time.ParseInLocation(auditRunTimeLayout, r.HeldAt, time.UTC)
+		if err != nil {
+			return AuditRun{}, fmt.Errorf("audit run %d: invalid held_at: %w", r.ID, err)
+		}
+		run.HeldAt = &heldAt
+	}
+
+	return run, nil
+}
+
+// Item is a file, folder or list item captured by an audit run.
+type Item struct {
+	ID           int    `json:"id"`
+	GUID         string `json:"guid"`
+	ListItemGUID string `json:"list_item_guid"`
+	Name         string `json:"name"`
+	URL          string `json:"url"`
+	IsFile       bool   `json:"is_file"`
+	IsFolder     bool   `json:"is_folder"`
+	HasUnique    bool   `json:"has_unique"`
+}
+
+// ItemKind restricts StreamItems to one kind of item.
+type ItemKind string
+
+// Item kinds accepted by StreamItems.
+const (
+	ItemKindAll      ItemKind = ""
+	ItemKindFile     ItemKind = "file"
+	ItemKindFolder   ItemKind = "folder"
+	ItemKindListItem ItemKind = "item"
+)
+
+// ItemsOptions filters and sizes the pages fetched by StreamItems.
+// The zero value streams every item in pages of the server's default size.
+type ItemsOptions struct {
+	UniqueOnly bool     // Only items with unique permissions
+	Kind       ItemKind // ItemKindAll for every kind
+	PageSize   int      // Items per request; 0 for the server default (100), at most 500
+}
+
+// itemsPage is the wire form of one page of items.
+type itemsPage struct {
+	Page       int    `json:"page"`
+	PageSize   int    `json:"page_size"`
+	TotalCount int64  `json:"total_count"`
+	Items      []Item `json:"items"`
+}
+
+// AuditOptions overrides the server's default audit parameters.
+// Nil fields and zero numbers keep the defaults.
+type AuditOptions struct {
+	ScanIndividualItems  *bool
+	SkipHidden           *bool
+	IncludeSharing       *bool
+	CollectAnalytics     *bool
+	SampleLargeLists     *bool // Deep-scan a statistical sample of items in lists over SampleThreshold
+	SampleThreshold      int
+	ScanRecycleBin       *bool  // Record the recycle bin to find deleted items that were still shared
+	AuditGraphGrants     *bool  // Read the apps Microsoft Graph grants access to the site
+	ExpandEntraGroups    *bool  // Resolve the users Entra groups holding roles on the site reach
+	FolderPath           string // Audit only this folder and the items below it, as a URL or server-relative path
+	Label                string // Tag the run with an environment or source, e.g. "prod-tenant"
+	BatchSize            int
+	MaxRequestsPerSecond int    // Pace the tenant's requests, shared by every audit of the tenant
+	CollectorBackend     string // "rest", or "graph" to also read sharing through Microsoft Graph
+	Timeout              time.Duration
+}
+
+// queueAuditRequest is the wire form of a TriggerAudit request.
+type queueAuditRequest struct {
+	SiteURL              string `json:"site_url"`
+	ScanIndividualItems  *bool  `json:"scan_individual_items,omitempty"`
+	SkipHidden           *bool  `json:"skip_hidden,omitempty"`
+	IncludeSharing       *bool  `json:"include_sharing,omitempty"`
+	CollectAnalytics     *bool  `json:"collect_analytics,omitempty"`
+	SampleLargeLists     *bool  `json:"sample_large_lists,omitempty"`
+	SampleThreshold      int    `json:"sample_threshold,omitempty"`
+	ScanRecycleBin       *bool  `json:"scan_recycle_bin,omitempty"`
+	AuditGraphGrants     *bool  `json:"audit_graph_grants,omitempty"`
+	ExpandEntraGroups    *bool  `json:"expand_entra_groups,omitempty"`
+	FolderPath           string `json:"folder_path,omitempty"`
+	Label                string `json:"label,omitempty"`
+	BatchSize            int    `json:"batch_size,omitempty"`
+	MaxRequestsPerSecond int    `json:"max_requests_per_second,omitempty"`
+	CollectorBackend     string `json:"collector_backend,omitempty"`
+	Timeout              int    `json:"timeout,omitempty"`
+}
+
+// QueuedAudit acknowledges an audit accepted by TriggerAudit.
+type QueuedAudit struct {
+	RequestID string    `json:"request_id"`
+	JobID     string    `json:"job_id"`
+	SiteURL   string    `json:"site_url"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// queueTenantAuditRequest is the wire form of a TriggerTenantAudit request.
+type queueTenantAuditRequest struct {
+	TenantURL string `json:"tenant_url"`
+	queueAuditRequest
+}
+
+// QueuedTenantAudit acknowledges a tenant audit accepted by TriggerTenantAudit.
+type QueuedTenantAudit struct {
+	JobID    string `json:"job_id"`
+	AdminURL string `json:"admin_url"` // The tenant's admin center, which lists its sites
+	Status   string `json:"status"`
+}
+
+// ListSites returns every audited site.
+func (c *Client) ListSites(ctx context.Context) ([]Site, error) {
+	return c.ListLabeledSites(ctx, "")
+}
+
+// ListLabeledSites returns the sites audited with runs tagged with label, with metadata from their
+// latest such run. An empty label returns every site.
+func (c *Client) ListLabeledSites(ctx context.Context, label string) ([]Site, error) {
+	var sites []Site
+	if err := c.get(ctx, "/api/sites", labelQuery(label), &sites); err != nil {
+		return nil, err
+	}
+	return sites, nil
+}
+
+// ListRuns returns the site's most recent audit runs, newest first.
+func (c *Client) ListRuns(ctx context.Context, siteID int64) ([]AuditRun, error) {
+	return c.ListLabeledRuns(ctx, siteID, "")
+}
+
+// ListLabeledRuns returns the site's most recent audit runs tagged with label, newest first.
+// An empty label returns every run.
+func (c *Client) ListLabeledRuns(ctx context.Context, siteID int64, label string) ([]AuditRun, error) {
+	var responses []auditRunResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/sites/%d/audit-runs", siteID), labelQuery(label), &responses); err != nil {
+		return nil, err
+	}
+
+	runs := make([]AuditRun, len(responses))
+	for i, response := range responses {
+		run, err := response.toAuditRun()
+		if err != nil {
+			return nil, err
+		}
+		runs[i] = run
+	}
+	return runs, nil
+}
+
+// GetRun returns one audit run of a site. Aliases resolve to the concrete run.
+func (c *Client) GetRun(ctx context.Context, siteID int64, run RunID) (*AuditRun, error) {
+	var response auditRunResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/sites/%d/audit-runs/%s", siteID, url.PathEscape(string(run))), nil, &response); err != nil {
+		return nil, err
+	}
+
+	auditRun, err := response.toAuditRun()
+	if err != nil {
+		return nil, err
+	}
+	return &auditRun, nil
+}
+
+// HoldRun places an audit run on legal hold, exempting it from retention pruning
+// and archival. reason is recorded with the hold and may be empty.
+func (c *Client) HoldRun(ctx context.Context, siteID int64, run RunID, reason string) (*AuditRun, error) {
+	return c.setRunHold(ctx, http.MethodPut, siteID, run, holdRunRequest{Reason: reason})
+}
+
+// ReleaseRunHold takes an audit run off legal hold.
+func (c *Client) ReleaseRunHold(ctx context.Context, siteID int64, run RunID) (*AuditRun, error) {
+	return c.setRunHold(ctx, http.MethodDelete, siteID, run, nil)
+}
+
+// holdRunRequest is the wire form of a HoldRun request.
+type holdRunRequest struct {
+	Reason string `json:"reason,omitempty"`
+}
+
+// setRunHold issues a hold request and decodes the updated run.
+func (c *Client) setRunHold(ctx context.Context, method string, siteID int64, run RunID, body interface{}) (*AuditRun, error) {
+	var response auditRunResponse
+	path := fmt.Sprintf("/api/sites/%d/audit-runs/%s/hold", siteID, url.PathEscape(string(run)))
+	if err := c.do(ctx, method, path, nil, body, &response); err != nil {
+		return nil, err
+	}
+
+	auditRun, err := response.toAuditRun()
+	if err != nil {
+		return nil, err
+	}
+	return &auditRun, nil
+}
+
+// StreamItems pages through a list's items within an audit run, calling fn for
+// each item in order. Streaming stops at the first error from fn or the API.
+// Pass LatestRun, ReferenceRun or BaselineRun to follow the site's current run;
+// the alias is resolved once so every page comes from the same run.
+func (c *Client) StreamItems(ctx context.Context, siteID int64, run RunID, listID string, opts *ItemsOptions, fn func(Item) error) error {
+	if opts == nil {
+		opts = &ItemsOptions{}
+	}
+
+	if run == LatestRun || run == ReferenceRun || run == BaselineRun {
+		resolved, err := c.GetRun(ctx, siteID, run)
+		if err != nil {
+			return err
+		}
+		run = Run(resolved.ID)
+	}
+
+	path := fmt.Sprintf("/api/sites/%d/audit-runs/%s/lists/%s/items", siteID, url.PathEscape(string(run)), url.PathEscape(listID))
+	query := url.Values{}
+	if opts.UniqueOnly {
+		query.Set("scope", "unique")
+	} else {
+		query.Set("scope", "all")
+	}
+	if opts.Kind != ItemKindAll {
+		query.Set("kind", string(opts.Kind))
+	}
+	if opts.PageSize > 0 {
+		query.Set("page_size", strconv.Itoa(opts.PageSize))
+	}
+
+	for page := 1; ; page++ {
+		query.Set("page", strconv.Itoa(page))
+
+		var response itemsPage
+		if err := c.get(ctx, path, query, &response); err != nil {
+			return err
+		}
+
+		// The server clamps pages past the end to the last page, so stop on the reported position
+		if response.Page != page {
+			return nil
+		}
+		for _, item := range response.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if len(response.Items) == 0 || int64(page)*int64(response.PageSize) >= response.TotalCount {
+			return nil
+		}
+	}
+}
+
+// TriggerAudit queues an audit of the site at siteURL. opts may be nil.
+// Use IsConflict to detect a site that already has an audit running or queued.
+func (c *Client) TriggerAudit(ctx context.Context, siteURL string, opts *AuditOptions) (*QueuedAudit, error) {
+	var queued QueuedAudit
+	if err := c.do(ctx, http.MethodPost, "/api/audits", nil, newQueueAuditRequest(siteURL, opts), &queued); err != nil {
+		return nil, err
+	}
+	return &queued, nil
+}
+
+// TriggerTenantAudit queues an audit of every site collection in the tenant tenantURL belongs to,
+// each with opts, which may be nil and cannot set FolderPath. Follow the returned job for the site
+// audits it queues. Use IsConflict to detect a tenant audit already running or queued.
+func (c *Client) TriggerTenantAudit(ctx context.Context, tenantURL string, opts *AuditOptions) (*QueuedTenantAudit, error) {
+	request := queueTenantAuditRequest{TenantURL: tenantURL, queueAuditRequest: newQueueAuditRequest("", opts)}
+
+	var queued QueuedTenantAudit
+	if err := c.do(ctx, http.MethodPost, "/api/tenant-audits", nil, request, &queued); err != nil {
+		return nil, err
+	}
+	return &queued, nil
+}
+
+// newQueueAuditRequest builds the wire form of an audit request. opts may be nil.
+func newQueueAuditRequest(siteURL string, opts *AuditOptions) queueAuditRequest {
+	request := queueAuditRequest{SiteURL: siteURL}
+	if opts != nil {
+		request.ScanIndividualItems = opts.ScanIndividualItems
+		request.SkipHidden = opts.SkipHidden
+		request.IncludeSharing = opts.IncludeSharing
+		request.CollectAnalytics = opts.CollectAnalytics
+		request.SampleLargeLists = opts.SampleLargeLists
+		request.SampleThreshold = opts.SampleThreshold
+		request.ScanRecycleBin = opts.ScanRecycleBin
+		request.AuditGraphGrants = opts.AuditGraphGrants
+		request.ExpandEntraGroups = opts.ExpandEntraGroups
+		request.FolderPath = opts.FolderPath
+		request.Label = opts.Label
+		request.BatchSize = opts.BatchSize
+		request.MaxRequestsPerSecond = opts.MaxRequestsPerSecond
+		request.CollectorBackend = opts.CollectorBackend
+		request.Timeout = int(opts.Timeout / time.Second)
+	}
+	return request
+}
+
+// labelQuery returns the query filtering by a run label, nil for no filter.
+func labelQuery(label string) url.Values {
+	if label == "" {
+		return nil
+	}
+	return url.Values{"label": {label}}
+}
@@ -0,0 +1,195 @@
+// Package client is a Go client for the SharePoint audit server's JSON API.
+//
+// It wraps the endpoints described at /api/openapi.json with typed methods so
+// other services can list sites, inspect audit runs, page through list items
+// and queue audits without hand-writing HTTP calls.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultTimeout bounds each request made with the default HTTP client.
+const defaultTimeout = 30 * time.Second
+
+// problemContentType is the media type of API error responses.
+const problemContentType = "application/problem+json"
+
+// Error codes returned by the API in Error.Code.
+const (
+	CodeInvalidParameter    = "invalid_parameter"
+	CodeNotFound            = "not_found"
+	CodeAuditRunUnavailable = "audit_run_unavailable"
+	CodeAuditConflict       = "audit_conflict"
+	CodeManifestConflict    = "manifest_conflict"
+	CodeInternal            = "internal_error"
+)
+
+// Client calls the audit server's JSON API. It is safe for concurrent use.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	userAgent  string
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sets the HTTP client used for requests, e.g. to add
+// authentication transports or change timeouts.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// New creates a client for the server at baseURL, e.g. "http://localhost:8080".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme and host are required", baseURL)
+	}
+
+	c := &Client{
+		baseURL:    parsed,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		userAgent:  "spaudit-client",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// Error is an API error response (RFC 7807 problem details).
+type Error struct {
+	StatusCode    int    `json:"status"`
+	Type          string `json:"type"`
+	Title         string `json:"title"`
+	Detail        string `json:"detail"`
+	Instance      string `json:"instance"`
+	Code          string `json:"code"`
+	CorrelationID string `json:"correlation_id"`
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("spaudit: %d %s", e.StatusCode, e.Title)
+	if e.Code != "" {
+		msg += " (" + e.Code + ")"
+	}
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.CorrelationID != "" {
+		msg += " [request " + e.CorrelationID + "]"
+	}
+	return msg
+}
+
+// IsNotFound reports whether err is an API error for a missing site, audit run or list.
+func IsNotFound(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsConflict reports whether err means an audit is already running or queued for the site.
+func IsConflict(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.Code == CodeAuditConflict
+}
+
+// get issues a GET request and decodes the JSON response into out.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out interface{}) error {
+	return c.do(ctx, http.MethodGet, path, query, nil, out)
+}
+
+// do issues a request with an optional JSON body and decodes the JSON response into out.
+// path must already be escaped.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	endpoint, err := url.Parse(c.baseURL.String() + path)
+	if err != nil {
+		return fmt.Errorf("invalid request path %q: %w", path, err)
+	}
+	endpoint.RawQuery = query.Encode()
+
+	var reqBody io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reqBody = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), reqBody)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return decodeError(resp)
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%s %s: failed to decode response: %w", method, path, err)
+	}
+	return nil
+}
+
+// decodeError converts a non-2xx response into an *Error, falling back to the
+// status line when the body is not a problem document.
+func decodeError(resp *http.Response) error {
+	apiErr := &Error{}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), problemContentType) {
+		_ = json.Unmarshal(body, apiErr)
+	} else {
+		apiErr.Detail = strings.TrimSpace(string(body))
+	}
+
+	apiErr.StatusCode = resp.StatusCode
+	if apiErr.Title == "" {
+		apiErr.Title = http.StatusText(resp.StatusCode)
+	}
+	if apiErr.CorrelationID == "" {
+		apiErr.CorrelationID = resp.Header.Get("X-Request-ID")
+	}
+	return apiErr
+}
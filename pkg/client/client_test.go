@@ -0,0 +1,304 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/interfaces/web/handlers"
+	"spaudit/interfaces/web/presenters"
+)
+
+// newTestClient serves mux and returns a client for it.
+// Responses are built from the server's presenter views so field renames break these tests.
+func newTestClient(t *testing.T, mux *http.ServeMux) *Client {
+	t.Helper()
+	server := httptest.NewServer(handlers.CorrelationID(mux))
+	t.Cleanup(server.Close)
+
+	c, err := New(server.URL + "/")
+	require.NoError(t, err)
+	return c
+}
+
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, v interface{}) {
+	t.Helper()
+	require.NoError(t, handlers.WriteJSON(w, status, v))
+}
+
+func TestNew_RejectsRelativeURL(t *testing.T) {
+	_, err := New("localhost:8080")
+	assert.Error(t, err)
+}
+
+func TestListSites(t *testing.T) {
+	lastAudit := time.Date(2025, 1, 31, 9, 15, 0, 0, time.UTC)
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/sites", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, []presenters.SiteView{
+			{ID: 1, URL: "https://contoso.sharepoint.com/sites/a", Title: "A", TotalLists: 4, ListsWithUnique: 1, LastAuditAt: &lastAudit},
+			{ID: 2, URL: "https://contoso.sharepoint.com/sites/b", Title: "B"},
+		})
+	})
+
+	sites, err := newTestClient(t, mux).ListSites(context.Background())
+	require.NoError(t, err)
+	require.Len(t, sites, 2)
+	assert.Equal(t, "A", sites[0].Title)
+	assert.Equal(t, 4, sites[0].TotalLists)
+	require.NotNil(t, sites[0].LastAuditAt)
+	assert.True(t, lastAudit.Equal(*sites[0].LastAuditAt))
+	assert.Nil(t, sites[1].LastAuditAt)
+}
+
+func TestGetRun(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/sites/{siteID}/audit-runs/{auditRunID}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "7", r.PathValue("siteID"))
+		assert.Equal(t, "latest", r.PathValue("auditRunID"))
+		writeJSON(t, w, http.StatusOK, presenters.AuditRunView{
+			ID:          42,
+			SiteID:      7,
+			JobID:       "job-1",
+			StartedAt:   "2025-01-31 09:15:00",
+			CompletedAt: "2025-01-31 09:20:30",
+			Status:      "completed",
+		})
+	})
+
+	run, err := newTestClient(t, mux).GetRun(context.Background(), 7, LatestRun)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), run.ID)
+	assert.Equal(t, time.Date(2025, 1, 31, 9, 15, 0, 0, time.UTC), run.StartedAt)
+	require.NotNil(t, run.CompletedAt)
+	assert.Equal(t, 5*time.Minute+30*time.Second, run.CompletedAt.Sub(run.StartedAt))
+}
+
+func TestListLabeledRuns(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/sites/{siteID}/audit-runs", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "prod-tenant", r.URL.Query().Get("label"))
+		writeJSON(t, w, http.StatusOK, []presenters.AuditRunView{
+			{ID: 42, SiteID: 7, JobID: "job-1", StartedAt: "2025-01-31 09:15:00", Status: "running", Label: "prod-tenant"},
+		})
+	})
+	mux.HandleFunc("GET /api/sites/{siteID}/audit-runs/{auditRunID}", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "latest@prod-tenant", r.PathValue("auditRunID"))
+		writeJSON(t, w, http.StatusOK, presenters.AuditRunView{ID: 42, SiteID: 7, JobID: "job-1", StartedAt: "2025-01-31 09:15:00", Status: "running", Label: "prod-tenant"})
+	})
+	c := newTestClient(t, mux)
+
+	runs, err := c.ListLabeledRuns(context.Background(), 7, "prod-tenant")
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	assert.Equal(t, "prod-tenant", runs[0].Label)
+
+	run, err := c.GetRun(context.Background(), 7, LatestLabeledRun("prod-tenant"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), run.ID)
+}
+
+func TestHoldRun(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /api/sites/{siteID}/audit-runs/{auditRunID}/hold", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "reference", r.PathValue("auditRunID"))
+		var req handlers.HoldAuditRunRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "Case 4711", req.Reason)
+		writeJSON(t, w, http.StatusOK, presenters.AuditRunView{
+			ID:         42,
+			SiteID:     7,
+			StartedAt:  "2025-01-31 09:15:00",
+			Status:     "completed",
+			OnHold:     true,
+			HeldAt:     "2025-02-03 10:00:00",
+			HoldReason: "Case 4711",
+		})
+	})
+	mux.HandleFunc("DELETE /api/sites/{siteID}/audit-runs/{auditRunID}/hold", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "42", r.PathValue("auditRunID"))
+		writeJSON(t, w, http.StatusOK, presenters.AuditRunView{ID: 42, SiteID: 7, StartedAt: "2025-01-31 09:15:00", Status: "completed"})
+	})
+	c := newTestClient(t, mux)
+
+	held, err := c.HoldRun(context.Background(), 7, ReferenceRun, "Case 4711")
+	require.NoError(t, err)
+	assert.True(t, held.OnHold)
+	assert.Equal(t, "Case 4711", held.HoldReason)
+	require.NotNil(t, held.HeldAt)
+	assert.Equal(t, time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC), *held.HeldAt)
+
+	released, err := c.ReleaseRunHold(context.Background(), 7, Run(42))
+	require.NoError(t, err)
+	assert.False(t, released.OnHold)
+	assert.Nil(t, released.HeldAt)
+}
+
+func TestGetRun_NotFoundProblem(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/sites/{siteID}/audit-runs/{auditRunID}", func(w http.ResponseWriter, r *http.Request) {
+		handlers.WriteProblem(w, r, http.StatusNotFound, handlers.ErrCodeAuditRunUnavailable, "audit run 99 not found")
+	})
+
+	_, err := newTestClient(t, mux).GetRun(context.Background(), 1, Run(99))
+	require.Error(t, err)
+	assert.True(t, IsNotFound(err))
+
+	var apiErr *Error
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, CodeAuditRunUnavailable, apiErr.Code)
+	assert.Equal(t, "audit run 99 not found", apiErr.Detail)
+	assert.NotEmpty(t, apiErr.CorrelationID)
+}
+
+func TestStreamItems_PagesThroughAllItems(t *testing.T) {
+	const total, pageSize = 5, 2
+	var requestedPages []string
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/sites/{siteID}/audit-runs/{auditRunID}", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, presenters.AuditRunView{ID: 3, SiteID: 1, StartedAt: "2025-01-31 09:15:00", Status: "completed"})
+	})
+	mux.HandleFunc("GET /api/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/items", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "3", r.PathValue("auditRunID"), "alias must be resolved once before paging")
+		assert.Equal(t, "all", r.URL.Query().Get("scope"))
+		assert.Equal(t, "file", r.URL.Query().Get("kind"))
+		assert.Equal(t, strconv.Itoa(pageSize), r.URL.Query().Get("page_size"))
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		requestedPages = append(requestedPages, r.URL.Query().Get("page"))
+
+		view := presenters.ItemsPageView{Page: page, PageSize: pageSize, TotalCount: total, Items: []presenters.SnapshotItem{}}
+		for id := (page-1)*pageSize + 1; id <= page*pageSize && id <= total; id++ {
+			view.Items = append(view.Items, presenters.SnapshotItem{ID: id, GUID: "item-" + strconv.Itoa(id), IsFile: true})
+		}
+		writeJSON(t, w, http.StatusOK, view)
+	})
+
+	var ids []int
+	err := newTestClient(t, mux).StreamItems(context.Background(), 1, LatestRun, "list-1",
+		&ItemsOptions{Kind: ItemKindFile, PageSize: pageSize},
+		func(item Item) error {
+			ids = append(ids, item.ID)
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, ids)
+	assert.Equal(t, []string{"1", "2", "3"}, requestedPages)
+}
+
+func TestStreamItems_StopsOnCallbackError(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/items", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, presenters.ItemsPageView{
+			Page: 1, PageSize: 2, TotalCount: 10,
+			Items: []presenters.SnapshotItem{{ID: 1}, {ID: 2}},
+		})
+	})
+
+	stop := errors.New("stop")
+	calls := 0
+	err := newTestClient(t, mux).StreamItems(context.Background(), 1, Run(3), "list-1", nil, func(Item) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestStreamItems_EmptyList(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/sites/{siteID}/audit-runs/{auditRunID}/lists/{listID}/items", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusOK, presenters.ItemsPageView{Page: 1, PageSize: 100, Items: []presenters.SnapshotItem{}})
+	})
+
+	err := newTestClient(t, mux).StreamItems(context.Background(), 1, Run(3), "list-1", nil, func(Item) error {
+		t.Fatal("no items expected")
+		return nil
+	})
+	assert.NoError(t, err)
+}
+
+func TestTriggerAudit(t *testing.T) {
+	created := time.Date(2025, 2, 1, 8, 0, 0, 0, time.UTC)
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/audits", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var body handlers.QueueAuditRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "https://contoso.sharepoint.com/sites/a", body.SiteURL)
+		require.NotNil(t, body.ScanIndividualItems)
+		assert.False(t, *body.ScanIndividualItems)
+		assert.Nil(t, body.SkipHidden, "unset options must be omitted so server defaults apply")
+		assert.Equal(t, 600, body.Timeout)
+
+		writeJSON(t, w, http.StatusAccepted, presenters.AuditQueuedView{
+			RequestID: "job-9", JobID: "job-9", SiteURL: body.SiteURL, Status: "queued", CreatedAt: created,
+		})
+	})
+
+	scanItems := false
+	queued, err := newTestClient(t, mux).TriggerAudit(context.Background(), "https://contoso.sharepoint.com/sites/a",
+		&AuditOptions{ScanIndividualItems: &scanItems, Timeout: 10 * time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, "job-9", queued.JobID)
+	assert.Equal(t, "queued", queued.Status)
+	assert.True(t, created.Equal(queued.CreatedAt))
+}
+
+func TestTriggerTenantAudit(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/tenant-audits", func(w http.ResponseWriter, r *http.Request) {
+		var body handlers.QueueTenantAuditRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "https://contoso.sharepoint.com", body.TenantURL)
+		assert.Equal(t, "prod-tenant", body.Label, "audit options are sent alongside the tenant URL")
+		assert.Nil(t, body.ScanIndividualItems)
+
+		writeJSON(t, w, http.StatusAccepted, handlers.TenantAuditQueued{
+			JobID: "tenant_audit-1", AdminURL: "https://contoso-admin.sharepoint.com", Status: "queued",
+		})
+	})
+
+	queued, err := newTestClient(t, mux).TriggerTenantAudit(context.Background(), "https://contoso.sharepoint.com", &AuditOptions{Label: "prod-tenant"})
+	require.NoError(t, err)
+	assert.Equal(t, "tenant_audit-1", queued.JobID)
+	assert.Equal(t, "https://contoso-admin.sharepoint.com", queued.AdminURL)
+}
+
+func TestTriggerAudit_Conflict(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/audits", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(handlers.CorrelationIDHeader, "req-7")
+		handlers.WriteProblem(w, r, http.StatusConflict, handlers.ErrCodeAuditConflict, "audit already running or queued for site: x")
+	})
+
+	_, err := newTestClient(t, mux).TriggerAudit(context.Background(), "x", nil)
+	require.Error(t, err)
+	assert.True(t, IsConflict(err))
+	assert.False(t, IsNotFound(err))
+	assert.Contains(t, err.Error(), "audit_conflict")
+}
+
+func TestError_NonProblemResponse(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/sites", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusBadGateway)
+	})
+
+	_, err := newTestClient(t, mux).ListSites(context.Background())
+	var apiErr *Error
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	assert.Equal(t, "Bad Gateway", apiErr.Title)
+	assert.Equal(t, "upstream unavailable", apiErr.Detail)
+}
@@ -0,0 +1,63 @@
+package client_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"spaudit/pkg/client"
+)
+
+// Lists audited sites, then walks the items with unique permissions in one
+// list of a site's latest audit run.
+func Example() {
+	ctx := context.Background()
+
+	c, err := client.New("http://localhost:8080")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	sites, err := c.ListSites(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, site := range sites {
+		fmt.Printf("%d %s (%d lists)\n", site.ID, site.URL, site.TotalLists)
+	}
+
+	run, err := c.GetRun(ctx, sites[0].ID, client.LatestRun)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("latest run %d started %s\n", run.ID, run.StartedAt.Format("2006-01-02 15:04"))
+
+	err = c.StreamItems(ctx, sites[0].ID, client.Run(run.ID), "list-guid", &client.ItemsOptions{UniqueOnly: true}, func(item client.Item) error {
+		fmt.Println(item.URL)
+		return nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Queues an audit, treating an audit that is already running as success.
+func ExampleClient_TriggerAudit() {
+	c, err := client.New("http://localhost:8080")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	scanItems := false
+	queued, err := c.TriggerAudit(context.Background(), "https://contoso.sharepoint.com/sites/finance", &client.AuditOptions{
+		ScanIndividualItems: &scanItems,
+	})
+	switch {
+	case client.IsConflict(err):
+		fmt.Println("audit already in progress")
+	case err != nil:
+		log.Fatal(err)
+	default:
+		fmt.Println("queued job", queued.JobID)
+	}
+}
@@ -0,0 +1,116 @@
+// Package compress compresses blobs kept in storage, such as run artifacts and raw payload samples.
+// Blobs are stored with their encoding, so data written before compression was enabled, or with it
+// disabled, still reads back unchanged.
+package compress
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Encoding identifies how a stored blob is compressed.
+type Encoding string
+
+const (
+	None Encoding = ""     // Stored as is
+	Zstd Encoding = "zstd" // Zstandard
+)
+
+// maxDecodedWindow bounds the memory a single decompression may use, so a corrupt or hostile blob
+// cannot claim an unbounded window.
+const maxDecodedWindow = 64 << 20
+
+// ParseEncoding parses a configured encoding: "zstd", or "none" or empty for no compression.
+func ParseEncoding(value string) (Encoding, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "none":
+		return None, nil
+	case string(Zstd):
+		return Zstd, nil
+	}
+	return None, fmt.Errorf("unknown compression %q, expected zstd or none", value)
+}
+
+// Extension returns the file name suffix for files written with the encoding, e.g. ".zst".
+func (e Encoding) Extension() string {
+	if e == Zstd {
+		return ".zst"
+	}
+	return ""
+}
+
+// NewWriter returns a writer compressing into w. Close flushes the compressed stream but leaves w open.
+func NewWriter(w io.Writer, encoding Encoding) (io.WriteCloser, error) {
+	switch encoding {
+	case None:
+		return nopWriteCloser{w}, nil
+	case Zstd:
+		return zstd.NewWriter(w)
+	}
+	return nil, fmt.Errorf("unknown compression %q", encoding)
+}
+
+// NewReader returns a reader decompressing r. Close releases the decoder but leaves r open.
+func NewReader(r io.Reader, encoding Encoding) (io.ReadCloser, error) {
+	switch encoding {
+	case None:
+		return io.NopCloser(r), nil
+	case Zstd:
+		decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderMaxWindow(maxDecodedWindow))
+		if err != nil {
+			return nil, err
+		}
+		return decoder.IOReadCloser(), nil
+	}
+	return nil, fmt.Errorf("unknown compression %q", encoding)
+}
+
+// Encode compresses data with the encoding. Data compression would not shrink, such as XLSX files
+// that are already ZIP archives, is returned as is with None, so the returned encoding is the one to store.
+func Encode(data []byte, encoding Encoding) ([]byte, Encoding, error) {
+	switch encoding {
+	case None:
+		return data, None, nil
+	case Zstd:
+		compressed := encoder().EncodeAll(data, make([]byte, 0, len(data)/2))
+		if len(compressed) >= len(data) {
+			return data, None, nil
+		}
+		return compressed, Zstd, nil
+	}
+	return nil, None, fmt.Errorf("unknown compression %q", encoding)
+}
+
+// Decode decompresses data stored with the encoding.
+func Decode(data []byte, encoding Encoding) ([]byte, error) {
+	if encoding == None {
+		return data, nil
+	}
+	reader, err := NewReader(bytes.NewReader(data), encoding)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// encoder returns the shared encoder for in-memory compression, which is safe for concurrent EncodeAll calls.
+var encoder = sync.OnceValue(func() *zstd.Encoder {
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		panic(fmt.Sprintf("create zstd encoder: %v", err))
+	}
+	return enc
+})
+
+// nopWriteCloser passes writes through and does nothing on Close.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
@@ -0,0 +1,73 @@
+package compress
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	data := []byte(strings.Repeat(`{"Id":7,"Title":"Quarterly report.docx"},`, 200))
+
+	compressed, encoding, err := Encode(data, Zstd)
+	require.NoError(t, err)
+	assert.Equal(t, Zstd, encoding)
+	assert.Less(t, len(compressed), len(data))
+
+	decoded, err := Decode(compressed, encoding)
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
+
+func TestEncode_KeepsIncompressibleData(t *testing.T) {
+	data := []byte("ab")
+
+	stored, encoding, err := Encode(data, Zstd)
+	require.NoError(t, err)
+	assert.Equal(t, None, encoding, "compressing two bytes only adds a frame header")
+	assert.Equal(t, data, stored)
+
+	decoded, err := Decode(stored, encoding)
+	require.NoError(t, err)
+	assert.Equal(t, data, decoded)
+}
+
+func TestWriterReader_Stream(t *testing.T) {
+	data := strings.Repeat("user@contoso.com;", 10000)
+
+	var buf bytes.Buffer
+	writer, err := NewWriter(&buf, Zstd)
+	require.NoError(t, err)
+	_, err = io.Copy(writer, strings.NewReader(data))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+	assert.Less(t, buf.Len(), len(data))
+
+	reader, err := NewReader(&buf, Zstd)
+	require.NoError(t, err)
+	defer reader.Close()
+	decoded, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, data, string(decoded))
+}
+
+func TestDecode_RejectsCorruptData(t *testing.T) {
+	_, err := Decode([]byte("not zstd"), Zstd)
+	assert.Error(t, err)
+}
+
+func TestParseEncoding(t *testing.T) {
+	for value, want := range map[string]Encoding{"": None, "none": None, "zstd": Zstd, " ZSTD ": Zstd} {
+		got, err := ParseEncoding(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, want, got, value)
+	}
+	_, err := ParseEncoding("gzip")
+	assert.Error(t, err)
+	assert.Equal(t, ".zst", Zstd.Extension())
+	assert.Equal(t, "", None.Extension())
+}
@@ -0,0 +1,261 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/events"
+	"spaudit/logging"
+)
+
+// durableDispatcherPoll is how often handlers check the event log for events they were not woken for
+const durableDispatcherPoll = 30 * time.Second
+
+// durableDispatcherBatch is the number of events read from the event log at a time
+const durableDispatcherBatch = 100
+
+// DurableHandler handles a recorded job event. Returning an error has the event delivered again, so
+// handlers must tolerate seeing an event more than once.
+type DurableHandler func(ctx context.Context, event *events.RecordedJobEvent) error
+
+// durableSubscription is a durable handler and the signal waking it for new events
+type durableSubscription struct {
+	name    string
+	handler DurableHandler
+	wake    chan struct{}
+}
+
+// DurableDispatcher delivers job events from the event log to durable handlers at least once. Each
+// handler's offset in the log is stored after it finishes with an event, so events recorded but not
+// handled before a crash are delivered on restart. Failed events are retried with backoff, then moved
+// to the dead-letter list and skipped.
+type DurableDispatcher struct {
+	mu     sync.RWMutex
+	logger *logging.Logger
+
+	eventLog      contracts.JobEventLog
+	store         contracts.JobEventDeliveryStore
+	policy        events.RetryPolicy
+	subscriptions []*durableSubscription
+}
+
+// NewDurableDispatcher creates a dispatcher reading the event log and storing offsets and dead letters
+func NewDurableDispatcher(eventLog contracts.JobEventLog, store contracts.JobEventDeliveryStore, policy events.RetryPolicy) *DurableDispatcher {
+	if policy.MaxAttempts < 1 {
+		policy.MaxAttempts = 1
+	}
+	return &DurableDispatcher{
+		logger:   logging.Default().WithComponent("durable_dispatcher"),
+		eventLog: eventLog,
+		store:    store,
+		policy:   policy,
+	}
+}
+
+// Subscribe registers a durable handler under the name its offset is stored by. Subscribe before Run;
+// a handler subscribed for the first time starts with the events recorded after Run starts.
+func (d *DurableDispatcher) Subscribe(name string, handler DurableHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.subscriptions = append(d.subscriptions, &durableSubscription{
+		name:    name,
+		handler: handler,
+		wake:    make(chan struct{}, 1),
+	})
+}
+
+// Notify wakes the handlers for a newly recorded event. Register it with JobEventBus.OnEventRecorded.
+func (d *DurableDispatcher) Notify(_ *events.RecordedJobEvent) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, subscription := range d.subscriptions {
+		select {
+		case subscription.wake <- struct{}{}:
+		default:
+			// Already woken; the handler reads every pending event when it runs
+		}
+	}
+}
+
+// Run delivers events to the subscribed handlers until ctx is cancelled, first catching up on the
+// events each handler missed while the app was stopped.
+func (d *DurableDispatcher) Run(ctx context.Context) {
+	d.mu.RLock()
+	subscriptions := make([]*durableSubscription, len(d.subscriptions))
+	copy(subscriptions, d.subscriptions)
+	d.mu.RUnlock()
+
+	latest, err := d.eventLog.LatestJobEventSequence(ctx)
+	if err != nil {
+		d.logger.Error("Failed to start durable event handlers", "error", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, subscription := range subscriptions {
+		if err := d.store.InitHandlerOffset(ctx, subscription.name, latest); err != nil {
+			d.logger.Error("Failed to start durable event handler", "handler", subscription.name, "error", err)
+			continue
+		}
+		wg.Add(1)
+		go func(s *durableSubscription) {
+			defer wg.Done()
+			d.runSubscription(ctx, s)
+		}(subscription)
+	}
+	wg.Wait()
+}
+
+// runSubscription delivers pending events to a handler whenever it is woken or polls
+func (d *DurableDispatcher) runSubscription(ctx context.Context, subscription *durableSubscription) {
+	ticker := time.NewTicker(durableDispatcherPoll)
+	defer ticker.Stop()
+
+	for {
+		if err := d.deliverPending(ctx, subscription); err != nil && ctx.Err() == nil {
+			d.logger.Error("Failed to deliver job events", "handler", subscription.name, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-subscription.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverPending delivers the events after a handler's offset in sequence order, storing the offset
+// after each one
+func (d *DurableDispatcher) deliverPending(ctx context.Context, subscription *durableSubscription) error {
+	offset, err := d.store.GetHandlerOffset(ctx, subscription.name)
+	if err != nil {
+		return err
+	}
+
+	for {
+		pending, err := d.eventLog.ListJobEvents(ctx, events.JobEventFilter{AfterSequence: offset, Limit: durableDispatcherBatch})
+		if err != nil {
+			return err
+		}
+		for _, event := range pending {
+			if err := d.deliver(ctx, subscription, event); err != nil {
+				return err
+			}
+			if err := d.store.SetHandlerOffset(ctx, subscription.name, event.Sequence); err != nil {
+				return err
+			}
+			offset = event.Sequence
+		}
+		if len(pending) < durableDispatcherBatch {
+			return nil
+		}
+	}
+}
+
+// deliver calls a handler with an event, retrying with backoff, and moves an event it still fails on
+// to the dead-letter list. An error means the event was neither handled nor dead-lettered and is
+// delivered again later.
+func (d *DurableDispatcher) deliver(ctx context.Context, subscription *durableSubscription, event *events.RecordedJobEvent) error {
+	var handlerErr error
+	attempt := 1
+	for ; ; attempt++ {
+		if handlerErr = d.call(ctx, subscription, event); handlerErr == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if attempt >= d.policy.MaxAttempts {
+			break
+		}
+
+		backoff := d.policy.Backoff(attempt)
+		d.logger.Warn("Durable event handler failed, retrying",
+			"handler", subscription.name,
+			"sequence", event.Sequence,
+			"attempt", attempt,
+			"retry_in", backoff,
+			"error", handlerErr)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+
+	deadLetter := &events.DeadLetter{
+		Handler:   subscription.name,
+		Sequence:  event.Sequence,
+		Attempts:  attempt,
+		LastError: handlerErr.Error(),
+		FailedAt:  time.Now().UTC(),
+	}
+	if err := d.store.AddDeadLetter(ctx, deadLetter); err != nil {
+		return err
+	}
+	d.logger.Error("Durable event handler gave up, event moved to dead-letter list",
+		"handler", subscription.name,
+		"sequence", event.Sequence,
+		"job_id", event.JobID,
+		"attempts", attempt,
+		"error", handlerErr)
+	return nil
+}
+
+// call runs a handler, turning a panic into an error
+func (d *DurableDispatcher) call(ctx context.Context, subscription *durableSubscription, event *events.RecordedJobEvent) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return subscription.handler(ctx, event)
+}
+
+// Redeliver delivers a dead letter's event to its handler once more, removing the dead letter when
+// the handler succeeds and recording the failed attempt otherwise
+func (d *DurableDispatcher) Redeliver(ctx context.Context, deadLetter *events.DeadLetter) error {
+	subscription := d.subscription(deadLetter.Handler)
+	if subscription == nil {
+		return fmt.Errorf("%w: %s", events.ErrUnknownHandler, deadLetter.Handler)
+	}
+	event, err := d.eventLog.GetJobEvent(ctx, deadLetter.Sequence)
+	if err != nil {
+		return err
+	}
+
+	if handlerErr := d.call(ctx, subscription, event); handlerErr != nil {
+		failed := &events.DeadLetter{
+			Handler:   deadLetter.Handler,
+			Sequence:  deadLetter.Sequence,
+			Attempts:  1,
+			LastError: handlerErr.Error(),
+			FailedAt:  time.Now().UTC(),
+		}
+		if err := d.store.AddDeadLetter(ctx, failed); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: %s on event %d: %v", events.ErrHandlerFailed, deadLetter.Handler, deadLetter.Sequence, handlerErr)
+	}
+
+	if _, err := d.store.DeleteDeadLetter(ctx, deadLetter.ID); err != nil {
+		return err
+	}
+	d.logger.Info("Dead letter redelivered", "handler", deadLetter.Handler, "sequence", deadLetter.Sequence)
+	return nil
+}
+
+// subscription returns the handler subscribed under a name, nil when there is none
+func (d *DurableDispatcher) subscription(name string) *durableSubscription {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	for _, subscription := range d.subscriptions {
+		if subscription.name == name {
+			return subscription
+		}
+	}
+	return nil
+}
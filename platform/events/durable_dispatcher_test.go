@@ -0,0 +1,194 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spaudit/domain/events"
+)
+
+// memoryDeliveryStore keeps handler offsets and dead letters in memory.
+type memoryDeliveryStore struct {
+	mu          sync.Mutex
+	offsets     map[string]int64
+	deadLetters []*events.DeadLetter
+}
+
+func newMemoryDeliveryStore() *memoryDeliveryStore {
+	return &memoryDeliveryStore{offsets: map[string]int64{}}
+}
+
+func (s *memoryDeliveryStore) InitHandlerOffset(_ context.Context, handler string, sequence int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.offsets[handler]; !ok {
+		s.offsets[handler] = sequence
+	}
+	return nil
+}
+
+func (s *memoryDeliveryStore) GetHandlerOffset(_ context.Context, handler string) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.offsets[handler], nil
+}
+
+func (s *memoryDeliveryStore) SetHandlerOffset(_ context.Context, handler string, sequence int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.offsets[handler] = sequence
+	return nil
+}
+
+func (s *memoryDeliveryStore) AddDeadLetter(_ context.Context, deadLetter *events.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, existing := range s.deadLetters {
+		if existing.Handler == deadLetter.Handler && existing.Sequence == deadLetter.Sequence {
+			existing.Attempts += deadLetter.Attempts
+			existing.LastError = deadLetter.LastError
+			deadLetter.ID = existing.ID
+			return nil
+		}
+	}
+	deadLetter.ID = int64(len(s.deadLetters) + 1)
+	s.deadLetters = append(s.deadLetters, deadLetter)
+	return nil
+}
+
+func (s *memoryDeliveryStore) GetDeadLetter(_ context.Context, id int64) (*events.DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, deadLetter := range s.deadLetters {
+		if deadLetter.ID == id {
+			return deadLetter, nil
+		}
+	}
+	return nil, errors.New("dead letter not found")
+}
+
+func (s *memoryDeliveryStore) ListDeadLetters(_ context.Context, _ int) ([]*events.DeadLetter, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*events.DeadLetter(nil), s.deadLetters...), nil
+}
+
+func (s *memoryDeliveryStore) DeleteDeadLetter(_ context.Context, id int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, deadLetter := range s.deadLetters {
+		if deadLetter.ID == id {
+			s.deadLetters = append(s.deadLetters[:i], s.deadLetters[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *memoryDeliveryStore) offset(handler string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.offsets[handler]
+}
+
+func (s *memoryDeliveryStore) started(handler string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, ok := s.offsets[handler]
+	return ok
+}
+
+func appendTestEvents(t *testing.T, eventLog *memoryJobEventLog, count int) {
+	t.Helper()
+	for i := 0; i < count; i++ {
+		require.NoError(t, eventLog.AppendJobEvent(context.Background(), &events.RecordedJobEvent{Type: events.JobEventCompleted, JobID: "job"}))
+	}
+}
+
+func TestDurableDispatcher_ResumesAfterStoredOffset(t *testing.T) {
+	eventLog := &memoryJobEventLog{}
+	appendTestEvents(t, eventLog, 3)
+	store := newMemoryDeliveryStore()
+	// The handler finished with event 1 before the app stopped
+	store.offsets["webhook"] = 1
+
+	dispatcher := NewDurableDispatcher(eventLog, store, events.RetryPolicy{MaxAttempts: 1})
+	var mu sync.Mutex
+	var delivered []int64
+	dispatcher.Subscribe("webhook", func(_ context.Context, event *events.RecordedJobEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		delivered = append(delivered, event.Sequence)
+		return nil
+	})
+	// A handler subscribed for the first time skips the events recorded before it
+	dispatcher.Subscribe("new", func(_ context.Context, event *events.RecordedJobEvent) error {
+		t.Errorf("new handler got event %d recorded before it was subscribed", event.Sequence)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dispatcher.Run(ctx)
+
+	require.Eventually(t, func() bool { return store.offset("webhook") == 3 }, time.Second, 5*time.Millisecond)
+	mu.Lock()
+	assert.Equal(t, []int64{2, 3}, delivered)
+	mu.Unlock()
+	assert.Equal(t, int64(3), store.offset("new"))
+}
+
+func TestDurableDispatcher_DeadLettersAfterRetries(t *testing.T) {
+	eventLog := &memoryJobEventLog{}
+	store := newMemoryDeliveryStore()
+	dispatcher := NewDurableDispatcher(eventLog, store, events.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+
+	var mu sync.Mutex
+	failing := true
+	dispatcher.Subscribe("webhook", func(_ context.Context, _ *events.RecordedJobEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if failing {
+			return errors.New("endpoint unreachable")
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dispatcher.Run(ctx)
+
+	// Record the event once the handler has started, as it is published while the app runs
+	require.Eventually(t, func() bool { return store.started("webhook") }, time.Second, 5*time.Millisecond)
+	appendTestEvents(t, eventLog, 1)
+	dispatcher.Notify(eventLog.events[0])
+
+	require.Eventually(t, func() bool { return store.offset("webhook") == 1 }, time.Second, 5*time.Millisecond)
+	deadLetters, err := store.ListDeadLetters(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, deadLetters, 1)
+	assert.Equal(t, 3, deadLetters[0].Attempts)
+	assert.Equal(t, "endpoint unreachable", deadLetters[0].LastError)
+
+	// Retrying while the handler still fails keeps the dead letter
+	err = dispatcher.Redeliver(ctx, deadLetters[0])
+	assert.ErrorIs(t, err, events.ErrHandlerFailed)
+	assert.Equal(t, 4, deadLetters[0].Attempts)
+
+	mu.Lock()
+	failing = false
+	mu.Unlock()
+	require.NoError(t, dispatcher.Redeliver(ctx, deadLetters[0]))
+	remaining, err := store.ListDeadLetters(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+
+	err = dispatcher.Redeliver(ctx, &events.DeadLetter{Handler: "removed", Sequence: 1})
+	assert.ErrorIs(t, err, events.ErrUnknownHandler)
+}
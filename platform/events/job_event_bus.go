@@ -1,9 +1,13 @@
 package events
 
 import (
+	"context"
 	"sync"
+	"time"
 
+	"spaudit/domain/contracts"
 	"spaudit/domain/events"
+	"spaudit/domain/jobs"
 	"spaudit/logging"
 )
 
@@ -17,6 +21,10 @@ type JobEventBus struct {
 	jobFailedHandlers          []func(events.JobFailedEvent)
 	jobCancelledHandlers       []func(events.JobCancelledEvent)
 	siteAuditCompletedHandlers []func(events.SiteAuditCompletedEvent)
+	eventRecordedHandlers      []func(*events.RecordedJobEvent)
+
+	// Event log every published event is appended to, if set
+	eventLog contracts.JobEventLog
 }
 
 // NewJobEventBus creates a new typed job event bus
@@ -27,9 +35,18 @@ func NewJobEventBus() *JobEventBus {
 		jobFailedHandlers:          make([]func(events.JobFailedEvent), 0),
 		jobCancelledHandlers:       make([]func(events.JobCancelledEvent), 0),
 		siteAuditCompletedHandlers: make([]func(events.SiteAuditCompletedEvent), 0),
+		eventRecordedHandlers:      make([]func(*events.RecordedJobEvent), 0),
 	}
 }
 
+// SetEventLog sets the event log published events are appended to, numbering them so they can be
+// replayed. Without one events are only dispatched to handlers.
+func (bus *JobEventBus) SetEventLog(eventLog contracts.JobEventLog) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	bus.eventLog = eventLog
+}
+
 // Subscribe methods for each event type
 
 func (bus *JobEventBus) OnJobCompleted(handler func(events.JobCompletedEvent)) {
@@ -56,9 +73,58 @@ func (bus *JobEventBus) OnSiteAuditCompleted(handler func(events.SiteAuditComple
 	bus.siteAuditCompletedHandlers = append(bus.siteAuditCompletedHandlers, handler)
 }
 
+// OnEventRecorded subscribes to every published event once it is appended to the event log.
+// Handlers are only called when an event log is set.
+func (bus *JobEventBus) OnEventRecorded(handler func(*events.RecordedJobEvent)) {
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+	bus.eventRecordedHandlers = append(bus.eventRecordedHandlers, handler)
+}
+
+// record appends an event to the event log before it is dispatched, so the sequence number is
+// assigned in publish order. Failing to record an event is logged and does not stop its dispatch.
+func (bus *JobEventBus) record(eventType events.JobEventType, job *jobs.Job, siteURL, errorMessage string, occurredAt time.Time) {
+	bus.mu.RLock()
+	eventLog := bus.eventLog
+	handlers := make([]func(*events.RecordedJobEvent), len(bus.eventRecordedHandlers))
+	copy(handlers, bus.eventRecordedHandlers)
+	bus.mu.RUnlock()
+
+	if eventLog == nil {
+		return
+	}
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	recorded := events.NewRecordedJobEvent(eventType, job, siteURL, errorMessage, occurredAt)
+	if err := eventLog.AppendJobEvent(context.Background(), recorded); err != nil {
+		bus.logger.Error("Failed to record job event",
+			"event_type", eventType,
+			"job_id", recorded.JobID,
+			"error", err)
+		return
+	}
+
+	for _, handler := range handlers {
+		go func(h func(*events.RecordedJobEvent)) {
+			defer func() {
+				if r := recover(); r != nil {
+					bus.logger.Error("Event handler panicked in EventRecorded",
+						"sequence", recorded.Sequence,
+						"job_id", recorded.JobID,
+						"panic", r)
+				}
+			}()
+			h(recorded)
+		}(handler)
+	}
+}
+
 // Publish methods for each event type
 
 func (bus *JobEventBus) PublishJobCompleted(event events.JobCompletedEvent) {
+	bus.record(events.JobEventCompleted, event.Job, "", "", event.Timestamp)
+
 	bus.mu.RLock()
 	handlers := make([]func(events.JobCompletedEvent), len(bus.jobCompletedHandlers))
 	copy(handlers, bus.jobCompletedHandlers)
@@ -80,6 +146,8 @@ func (bus *JobEventBus) PublishJobCompleted(event events.JobCompletedEvent) {
 }
 
 func (bus *JobEventBus) PublishJobFailed(event events.JobFailedEvent) {
+	bus.record(events.JobEventFailed, event.Job, "", event.Error, event.Timestamp)
+
 	bus.mu.RLock()
 	handlers := make([]func(events.JobFailedEvent), len(bus.jobFailedHandlers))
 	copy(handlers, bus.jobFailedHandlers)
@@ -101,6 +169,8 @@ func (bus *JobEventBus) PublishJobFailed(event events.JobFailedEvent) {
 }
 
 func (bus *JobEventBus) PublishJobCancelled(event events.JobCancelledEvent) {
+	bus.record(events.JobEventCancelled, event.Job, "", "", event.Timestamp)
+
 	bus.mu.RLock()
 	handlers := make([]func(events.JobCancelledEvent), len(bus.jobCancelledHandlers))
 	copy(handlers, bus.jobCancelledHandlers)
@@ -121,6 +191,8 @@ func (bus *JobEventBus) PublishJobCancelled(event events.JobCancelledEvent) {
 }
 
 func (bus *JobEventBus) PublishSiteAuditCompleted(event events.SiteAuditCompletedEvent) {
+	bus.record(events.JobEventSiteAuditCompleted, event.Job, event.SiteURL, "", event.Timestamp)
+
 	bus.mu.RLock()
 	handlers := make([]func(events.SiteAuditCompletedEvent), len(bus.siteAuditCompletedHandlers))
 	copy(handlers, bus.siteAuditCompletedHandlers)
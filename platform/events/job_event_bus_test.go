@@ -1,6 +1,8 @@
 package events
 
 import (
+	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -343,3 +345,77 @@ func TestJobEventBus_EventIsolation_HandlersNotCrossCalled(t *testing.T) {
 		// Expected - other handlers were not called
 	}
 }
+
+// memoryJobEventLog numbers events in memory.
+type memoryJobEventLog struct {
+	mu     sync.Mutex
+	events []*events.RecordedJobEvent
+}
+
+func (l *memoryJobEventLog) AppendJobEvent(_ context.Context, event *events.RecordedJobEvent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	event.Sequence = int64(len(l.events) + 1)
+	l.events = append(l.events, event)
+	return nil
+}
+
+func (l *memoryJobEventLog) ListJobEvents(_ context.Context, filter events.JobEventFilter) ([]*events.RecordedJobEvent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var matching []*events.RecordedJobEvent
+	for _, event := range l.events {
+		if event.Sequence > filter.AfterSequence && (filter.Limit == 0 || len(matching) < filter.Limit) {
+			matching = append(matching, event)
+		}
+	}
+	return matching, nil
+}
+
+func (l *memoryJobEventLog) GetJobEvent(_ context.Context, sequence int64) (*events.RecordedJobEvent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if sequence < 1 || int(sequence) > len(l.events) {
+		return nil, fmt.Errorf("job event %d not found", sequence)
+	}
+	return l.events[sequence-1], nil
+}
+
+func (l *memoryJobEventLog) LatestJobEventSequence(_ context.Context) (int64, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int64(len(l.events)), nil
+}
+
+func TestJobEventBus_RecordsEventsInPublishOrder(t *testing.T) {
+	eventBus := NewJobEventBus()
+	eventLog := &memoryJobEventLog{}
+	eventBus.SetEventLog(eventLog)
+
+	recorded := make(chan *events.RecordedJobEvent, 2)
+	eventBus.OnEventRecorded(func(event *events.RecordedJobEvent) {
+		recorded <- event
+	})
+
+	job := createTestJob("test-job-log", jobs.JobStatusFailed)
+	eventBus.PublishJobCompleted(events.JobCompletedEvent{Job: job, Timestamp: time.Now()})
+	eventBus.PublishJobFailed(events.JobFailedEvent{Job: job, Error: "throttled", Timestamp: time.Now()})
+
+	// Events are appended before the publisher returns
+	require.Len(t, eventLog.events, 2)
+	assert.Equal(t, events.JobEventCompleted, eventLog.events[0].Type)
+	assert.Equal(t, int64(2), eventLog.events[1].Sequence)
+	assert.Equal(t, "throttled", eventLog.events[1].Error)
+	assert.Equal(t, "https://test.sharepoint.com", eventLog.events[1].SiteURL)
+
+	sequences := map[int64]bool{}
+	for i := 0; i < 2; i++ {
+		select {
+		case event := <-recorded:
+			sequences[event.Sequence] = true
+		case <-time.After(100 * time.Millisecond):
+			t.Fatal("Recorded handler was not called within timeout")
+		}
+	}
+	assert.Equal(t, map[int64]bool{1: true, 2: true}, sequences)
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"context"
+	"errors"
+
+	"spaudit/application"
+	"spaudit/domain/events"
+	"spaudit/logging"
+)
+
+// manifestHandlerName is the name the manifest handler's offset and dead letters are stored under
+const manifestHandlerName = "run_manifest"
+
+// RunSealer defines the interface for sealing completed audit runs
+type RunSealer interface {
+	SealCompletedAuditRun(ctx context.Context, auditRunID int64) error
+}
+
+// ManifestEventHandlers seals each audit run with a tamper-evidence manifest once its audit completes
+type ManifestEventHandlers struct {
+	sealer RunSealer
+	logger *logging.Logger
+}
+
+// NewManifestEventHandlers creates event handlers for run manifests
+func NewManifestEventHandlers(sealer RunSealer) *ManifestEventHandlers {
+	return &ManifestEventHandlers{
+		sealer: sealer,
+		logger: logging.Default().WithComponent("manifest_events"),
+	}
+}
+
+// RegisterHandlers registers the manifest event handlers as durable handlers, so a run whose audit
+// completed just before a crash is still sealed
+func (h *ManifestEventHandlers) RegisterHandlers(dispatcher *DurableDispatcher) {
+	dispatcher.Subscribe(manifestHandlerName, h.handleSiteAuditCompleted)
+}
+
+func (h *ManifestEventHandlers) handleSiteAuditCompleted(ctx context.Context, event *events.RecordedJobEvent) error {
+	if event.Type != events.JobEventSiteAuditCompleted || event.AuditRunID == 0 {
+		return nil
+	}
+
+	// A redelivered event finds the run already sealed
+	err := h.sealer.SealCompletedAuditRun(ctx, event.AuditRunID)
+	if errors.Is(err, application.ErrRunAlreadySealed) {
+		h.logger.Debug("Audit run already sealed", "audit_run_id", event.AuditRunID, "job_id", event.JobID)
+		return nil
+	}
+	return err
+}
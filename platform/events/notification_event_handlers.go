@@ -1,6 +1,9 @@
 package events
 
 import (
+	"context"
+
+	"spaudit/domain/audit"
 	"spaudit/domain/events"
 	"spaudit/domain/jobs"
 	"spaudit/logging"
@@ -20,10 +23,47 @@ type SiteService interface {
 	// Add methods here if needed for site-specific event handling
 }
 
+// PostAuditReporter generates the reports configured for an audit run once it completes
+type PostAuditReporter interface {
+	GenerateRunReports(ctx context.Context, auditRunID int64) ([]*audit.RunArtifact, error)
+}
+
+// FindingAlerter raises alerts for the new findings of an audit run once it completes
+type FindingAlerter interface {
+	RaiseRunAlerts(ctx context.Context, auditRunID int64) ([]*audit.FindingAlert, error)
+}
+
+// DriftChecker compares a completed scheduled audit run with the run its schedule compares with and
+// raises a drift alert when the drift exceeds the schedule's thresholds
+type DriftChecker interface {
+	CheckRunDrift(ctx context.Context, auditRunID int64) (*audit.AuditScheduleCheck, error)
+}
+
+// PolicyEvaluator checks an audit run against the access policies once it completes and records what violates them
+type PolicyEvaluator interface {
+	EvaluateRun(ctx context.Context, auditRunID int64) (*audit.PolicyEvaluation, error)
+}
+
+// runNotificationHandlerPrefix prefixes the name each run notification channel's offset and dead
+// letters are stored under
+const runNotificationHandlerPrefix = "run_notifications_"
+
+// RunNotifier announces a summary of a site audit's run on a notification channel, e.g. email or
+// chat, once its job completes or fails
+type RunNotifier interface {
+	Channels() []string
+	NotifyJobEvent(ctx context.Context, channel string, event *events.RecordedJobEvent) error
+}
+
 // NotificationEventHandlers handles job events and converts them to appropriate notifications
 type NotificationEventHandlers struct {
 	sseBroadcaster SSEBroadcaster
 	siteService    SiteService
+	reporter       PostAuditReporter
+	alerter        FindingAlerter
+	driftChecker   DriftChecker
+	evaluator      PolicyEvaluator
+	runNotifier    RunNotifier
 	logger         *logging.Logger
 }
 
@@ -36,6 +76,36 @@ func NewNotificationEventHandlers(sseBroadcaster SSEBroadcaster, siteService Sit
 	}
 }
 
+// SetPostAuditReporter sets the reporter run before a job completion is announced,
+// so the reports are attached to the audit run by the time users are notified
+func (h *NotificationEventHandlers) SetPostAuditReporter(reporter PostAuditReporter) {
+	h.reporter = reporter
+}
+
+// SetFindingAlerter sets the alerter run after a job completion is announced, so finding alerts
+// follow the completion toast
+func (h *NotificationEventHandlers) SetFindingAlerter(alerter FindingAlerter) {
+	h.alerter = alerter
+}
+
+// SetDriftChecker sets the drift checker run after the finding alerter, so scheduled runs are
+// compared with their base run once they complete
+func (h *NotificationEventHandlers) SetDriftChecker(checker DriftChecker) {
+	h.driftChecker = checker
+}
+
+// SetPolicyEvaluator sets the policy evaluator run before a job completion is announced, so the
+// run's policy findings are recorded by the time users are notified
+func (h *NotificationEventHandlers) SetPolicyEvaluator(evaluator PolicyEvaluator) {
+	h.evaluator = evaluator
+}
+
+// SetRunNotifier sets the notifier announcing finished site audits on the notification channels
+// once RegisterDurableHandlers registers them
+func (h *NotificationEventHandlers) SetRunNotifier(notifier RunNotifier) {
+	h.runNotifier = notifier
+}
+
 // RegisterHandlers registers all notification event handlers with the event bus
 func (h *NotificationEventHandlers) RegisterHandlers(eventBus *JobEventBus) {
 	// Register handlers for each event type
@@ -45,6 +115,20 @@ func (h *NotificationEventHandlers) RegisterHandlers(eventBus *JobEventBus) {
 	eventBus.OnSiteAuditCompleted(h.handleSiteAuditCompleted)
 }
 
+// RegisterDurableHandlers registers a durable handler for each run notification channel, so a site
+// audit that finished just before a crash is still announced. Each channel keeps its own offset, so a
+// failing channel is retried without announcing the run again on the others.
+func (h *NotificationEventHandlers) RegisterDurableHandlers(dispatcher *DurableDispatcher) {
+	if h.runNotifier == nil {
+		return
+	}
+	for _, channel := range h.runNotifier.Channels() {
+		dispatcher.Subscribe(runNotificationHandlerPrefix+channel, func(ctx context.Context, event *events.RecordedJobEvent) error {
+			return h.runNotifier.NotifyJobEvent(ctx, channel, event)
+		})
+	}
+}
+
 // Event handler implementations
 
 func (h *NotificationEventHandlers) handleJobCompleted(event events.JobCompletedEvent) {
@@ -54,9 +138,41 @@ func (h *NotificationEventHandlers) handleJobCompleted(event events.JobCompleted
 	}
 	h.logger.Info("Handling job completed event", "job_id", jobID)
 
+	// Generate the configured reports first so the toast can offer them
+	if h.reporter != nil && event.Job != nil && event.Job.HasAuditRun() {
+		auditRunID := event.Job.GetAuditRunID()
+		if _, err := h.reporter.GenerateRunReports(context.Background(), auditRunID); err != nil {
+			h.logger.Error("Failed to generate post-audit reports", "audit_run_id", auditRunID, "job_id", jobID, "error", err)
+		}
+	}
+
+	// Check the run against the access policies; folder-scoped runs are skipped by the evaluator
+	if h.evaluator != nil && event.Job != nil && event.Job.HasAuditRun() {
+		auditRunID := event.Job.GetAuditRunID()
+		if _, err := h.evaluator.EvaluateRun(context.Background(), auditRunID); err != nil {
+			h.logger.Error("Failed to check audit run against access policies", "audit_run_id", auditRunID, "job_id", jobID, "error", err)
+		}
+	}
+
 	// Send rich toast notification for job completion
 	h.sseBroadcaster.BroadcastRichJobToast(event.Job)
 
+	// Alert on the run's new findings; repeats and quiet hours are handled by the alerter
+	if h.alerter != nil && event.Job != nil && event.Job.HasAuditRun() {
+		auditRunID := event.Job.GetAuditRunID()
+		if _, err := h.alerter.RaiseRunAlerts(context.Background(), auditRunID); err != nil {
+			h.logger.Error("Failed to raise finding alerts", "audit_run_id", auditRunID, "job_id", jobID, "error", err)
+		}
+	}
+
+	// Check scheduled runs for drift; runs no schedule queued are skipped by the checker
+	if h.driftChecker != nil && event.Job != nil && event.Job.HasAuditRun() {
+		auditRunID := event.Job.GetAuditRunID()
+		if _, err := h.driftChecker.CheckRunDrift(context.Background(), auditRunID); err != nil {
+			h.logger.Error("Failed to check scheduled run drift", "audit_run_id", auditRunID, "job_id", jobID, "error", err)
+		}
+	}
+
 	// Update job list for all connected clients
 	h.sseBroadcaster.BroadcastJobListUpdate()
 }
@@ -1,12 +1,17 @@
 package events
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"spaudit/domain/audit"
 	"spaudit/domain/events"
 	"spaudit/domain/jobs"
 )
@@ -79,6 +84,122 @@ func TestNotificationEventHandlers_HandleJobCompleted_Success(t *testing.T) {
 	mockSSE.AssertCalled(t, "BroadcastJobListUpdate")
 }
 
+// recordingReporter records the audit runs it generated reports for
+type recordingReporter struct {
+	auditRunIDs []int64
+	onGenerate  func()
+}
+
+func (r *recordingReporter) GenerateRunReports(ctx context.Context, auditRunID int64) ([]*audit.RunArtifact, error) {
+	r.auditRunIDs = append(r.auditRunIDs, auditRunID)
+	if r.onGenerate != nil {
+		r.onGenerate()
+	}
+	return nil, nil
+}
+
+func TestNotificationEventHandlers_HandleJobCompleted_GeneratesReportsBeforeToast(t *testing.T) {
+	mockSSE := &MockSSEBroadcaster{}
+	handlers := NewNotificationEventHandlers(mockSSE, &MockSiteService{})
+
+	// The toast must not have been broadcast when the reports are generated
+	reporter := &recordingReporter{onGenerate: func() {
+		mockSSE.AssertNotCalled(t, "BroadcastRichJobToast", mock.Anything)
+	}}
+	handlers.SetPostAuditReporter(reporter)
+
+	job := createTestJobForHandlers("completed-job-2", jobs.JobStatusCompleted)
+	job.SetAuditRunID(42)
+	mockSSE.On("BroadcastRichJobToast", job).Return()
+	mockSSE.On("BroadcastJobListUpdate").Return()
+
+	handlers.handleJobCompleted(events.JobCompletedEvent{Job: job, Timestamp: time.Now()})
+
+	assert.Equal(t, []int64{42}, reporter.auditRunIDs)
+	mockSSE.AssertExpectations(t)
+
+	// Jobs without an audit run have nothing to report on
+	other := createTestJobForHandlers("completed-job-3", jobs.JobStatusCompleted)
+	mockSSE.On("BroadcastRichJobToast", other).Return()
+	handlers.handleJobCompleted(events.JobCompletedEvent{Job: other, Timestamp: time.Now()})
+	assert.Equal(t, []int64{42}, reporter.auditRunIDs)
+}
+
+// recordingAlerter records the audit runs it raised finding alerts for
+type recordingAlerter struct {
+	auditRunIDs []int64
+	onRaise     func()
+}
+
+func (a *recordingAlerter) RaiseRunAlerts(ctx context.Context, auditRunID int64) ([]*audit.FindingAlert, error) {
+	a.auditRunIDs = append(a.auditRunIDs, auditRunID)
+	if a.onRaise != nil {
+		a.onRaise()
+	}
+	return nil, nil
+}
+
+func TestNotificationEventHandlers_HandleJobCompleted_RaisesFindingAlertsAfterToast(t *testing.T) {
+	mockSSE := &MockSSEBroadcaster{}
+	handlers := NewNotificationEventHandlers(mockSSE, &MockSiteService{})
+
+	job := createTestJobForHandlers("completed-job-4", jobs.JobStatusCompleted)
+	job.SetAuditRunID(42)
+	alerter := &recordingAlerter{onRaise: func() {
+		mockSSE.AssertCalled(t, "BroadcastRichJobToast", job)
+	}}
+	handlers.SetFindingAlerter(alerter)
+	mockSSE.On("BroadcastRichJobToast", job).Return()
+	mockSSE.On("BroadcastJobListUpdate").Return()
+
+	handlers.handleJobCompleted(events.JobCompletedEvent{Job: job, Timestamp: time.Now()})
+	assert.Equal(t, []int64{42}, alerter.auditRunIDs)
+
+	// Jobs without an audit run have no findings
+	other := createTestJobForHandlers("completed-job-5", jobs.JobStatusCompleted)
+	mockSSE.On("BroadcastRichJobToast", other).Return()
+	handlers.handleJobCompleted(events.JobCompletedEvent{Job: other, Timestamp: time.Now()})
+	assert.Equal(t, []int64{42}, alerter.auditRunIDs)
+}
+
+// recordingEvaluator records the audit runs it checked against the access policies
+type recordingEvaluator struct {
+	auditRunIDs []int64
+	onEvaluate  func()
+}
+
+func (e *recordingEvaluator) EvaluateRun(ctx context.Context, auditRunID int64) (*audit.PolicyEvaluation, error) {
+	e.auditRunIDs = append(e.auditRunIDs, auditRunID)
+	if e.onEvaluate != nil {
+		e.onEvaluate()
+	}
+	return nil, nil
+}
+
+func TestNotificationEventHandlers_HandleJobCompleted_EvaluatesPoliciesBeforeToast(t *testing.T) {
+	mockSSE := &MockSSEBroadcaster{}
+	handlers := NewNotificationEventHandlers(mockSSE, &MockSiteService{})
+
+	evaluator := &recordingEvaluator{onEvaluate: func() {
+		mockSSE.AssertNotCalled(t, "BroadcastRichJobToast", mock.Anything)
+	}}
+	handlers.SetPolicyEvaluator(evaluator)
+
+	job := createTestJobForHandlers("completed-job-6", jobs.JobStatusCompleted)
+	job.SetAuditRunID(42)
+	mockSSE.On("BroadcastRichJobToast", job).Return()
+	mockSSE.On("BroadcastJobListUpdate").Return()
+
+	handlers.handleJobCompleted(events.JobCompletedEvent{Job: job, Timestamp: time.Now()})
+	assert.Equal(t, []int64{42}, evaluator.auditRunIDs)
+
+	// Jobs without an audit run have nothing to check
+	other := createTestJobForHandlers("completed-job-7", jobs.JobStatusCompleted)
+	mockSSE.On("BroadcastRichJobToast", other).Return()
+	handlers.handleJobCompleted(events.JobCompletedEvent{Job: other, Timestamp: time.Now()})
+	assert.Equal(t, []int64{42}, evaluator.auditRunIDs)
+}
+
 func TestNotificationEventHandlers_HandleJobFailed_Success(t *testing.T) {
 	// Arrange
 	mockSSE := &MockSSEBroadcaster{}
@@ -105,6 +226,55 @@ func TestNotificationEventHandlers_HandleJobFailed_Success(t *testing.T) {
 	mockSSE.AssertCalled(t, "BroadcastJobListUpdate")
 }
 
+// flakyRunNotifier counts the announcements made on each channel, failing the first on failChannel.
+type flakyRunNotifier struct {
+	mu          sync.Mutex
+	failChannel string
+	calls       map[string]int
+}
+
+func (n *flakyRunNotifier) Channels() []string {
+	return []string{"email", "chat"}
+}
+
+func (n *flakyRunNotifier) NotifyJobEvent(_ context.Context, channel string, _ *events.RecordedJobEvent) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.calls[channel]++
+	if channel == n.failChannel && n.calls[channel] == 1 {
+		return errors.New("smtp unavailable")
+	}
+	return nil
+}
+
+func TestNotificationEventHandlers_RetriesOnlyTheFailingRunNotificationChannel(t *testing.T) {
+	eventLog := &memoryJobEventLog{}
+	store := newMemoryDeliveryStore()
+	dispatcher := NewDurableDispatcher(eventLog, store, events.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond})
+	notifier := &flakyRunNotifier{failChannel: "email", calls: map[string]int{}}
+	handlers := NewNotificationEventHandlers(&MockSSEBroadcaster{}, &MockSiteService{})
+	handlers.SetRunNotifier(notifier)
+	handlers.RegisterDurableHandlers(dispatcher)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dispatcher.Run(ctx)
+
+	require.Eventually(t, func() bool {
+		return store.started("run_notifications_email") && store.started("run_notifications_chat")
+	}, time.Second, 5*time.Millisecond)
+	appendTestEvents(t, eventLog, 1)
+	dispatcher.Notify(eventLog.events[0])
+
+	require.Eventually(t, func() bool {
+		return store.offset("run_notifications_email") == 1 && store.offset("run_notifications_chat") == 1
+	}, time.Second, 5*time.Millisecond)
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+	assert.Equal(t, 2, notifier.calls["email"])
+	assert.Equal(t, 1, notifier.calls["chat"], "the chat card is not posted again when email is retried")
+}
+
 func TestNotificationEventHandlers_HandleJobCancelled_Success(t *testing.T) {
 	// Arrange
 	mockSSE := &MockSSEBroadcaster{}
@@ -0,0 +1,31 @@
+package events
+
+import (
+	"context"
+
+	"spaudit/domain/events"
+)
+
+// webhookHandlerName is the name the webhook handler's offset and dead letters are stored under
+const webhookHandlerName = "webhook_notifications"
+
+// WebhookNotifier queues job events to be posted to the outbound webhook endpoints
+type WebhookNotifier interface {
+	EnqueueJobEvent(ctx context.Context, event *events.RecordedJobEvent) error
+}
+
+// WebhookEventHandlers posts job completions and failures to the outbound webhook endpoints
+type WebhookEventHandlers struct {
+	notifier WebhookNotifier
+}
+
+// NewWebhookEventHandlers creates event handlers for outbound webhooks
+func NewWebhookEventHandlers(notifier WebhookNotifier) *WebhookEventHandlers {
+	return &WebhookEventHandlers{notifier: notifier}
+}
+
+// RegisterHandlers registers the webhook event handlers as durable handlers, so a job that finished
+// just before a crash is still announced. The notifier queues each event once, however often it is delivered.
+func (h *WebhookEventHandlers) RegisterHandlers(dispatcher *DurableDispatcher) {
+	dispatcher.Subscribe(webhookHandlerName, h.notifier.EnqueueJobEvent)
+}
@@ -0,0 +1,52 @@
+package executors
+
+import (
+	"context"
+	"encoding/json"
+
+	"spaudit/application"
+	"spaudit/domain/jobs"
+	"spaudit/logging"
+)
+
+// DatabaseMaintenanceExecutor handles database maintenance job execution
+type DatabaseMaintenanceExecutor struct {
+	maintenanceService *application.DatabaseMaintenanceService
+	logger             *logging.Logger
+}
+
+// NewDatabaseMaintenanceExecutor creates a new database maintenance executor
+func NewDatabaseMaintenanceExecutor(maintenanceService *application.DatabaseMaintenanceService) *DatabaseMaintenanceExecutor {
+	return &DatabaseMaintenanceExecutor{
+		maintenanceService: maintenanceService,
+		logger:             logging.Default().WithComponent("database_maintenance_executor"),
+	}
+}
+
+// Execute implements the JobExecutor interface for database maintenance jobs
+func (e *DatabaseMaintenanceExecutor) Execute(ctx context.Context, job *jobs.Job, progressCallback application.ProgressCallback) error {
+	e.logger.Info("Starting database maintenance", "jobID", job.ID)
+
+	run, err := e.maintenanceService.RunMaintenance(ctx, job.ID, progressCallback)
+	if run != nil {
+		// Store the outcome in the job, failed runs included
+		resultJSON, marshalErr := json.Marshal(map[string]interface{}{
+			"maintenanceRunID": run.ID,
+			"trigger":          run.Trigger,
+			"reclaimedBytes":   run.ReclaimedBytes(),
+			"converted":        run.Converted,
+			"integrityErrors":  len(run.IntegrityErrors),
+		})
+		if marshalErr != nil {
+			e.logger.Warn("Failed to store maintenance result in job", "job_id", job.ID, "error", marshalErr)
+		} else {
+			job.Result = string(resultJSON)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info("Database maintenance completed", "jobID", job.ID)
+	return nil
+}
@@ -0,0 +1,53 @@
+package executors
+
+import (
+	"context"
+	"encoding/json"
+
+	"spaudit/application"
+	"spaudit/domain/jobs"
+	"spaudit/logging"
+)
+
+// OffboardingCheckExecutor handles offboarding check job execution
+type OffboardingCheckExecutor struct {
+	offboardingService *application.OffboardingService
+	logger             *logging.Logger
+}
+
+// NewOffboardingCheckExecutor creates a new offboarding check executor
+func NewOffboardingCheckExecutor(offboardingService *application.OffboardingService) *OffboardingCheckExecutor {
+	return &OffboardingCheckExecutor{
+		offboardingService: offboardingService,
+		logger:             logging.Default().WithComponent("offboarding_check_executor"),
+	}
+}
+
+// Execute implements the JobExecutor interface for offboarding check jobs
+func (e *OffboardingCheckExecutor) Execute(ctx context.Context, job *jobs.Job, progressCallback application.ProgressCallback) error {
+	e.logger.Info("Starting offboarding check", "jobID", job.ID)
+
+	check, err := e.offboardingService.RunCheck(ctx, job.ID, progressCallback)
+	if check != nil {
+		// Store the outcome in the job, failed checks included
+		resultJSON, marshalErr := json.Marshal(map[string]interface{}{
+			"offboardingCheckID": check.ID,
+			"identity":           check.Identity,
+			"access":             check.Access,
+			"linksCreated":       check.LinksCreated,
+			"itemsOwned":         check.ItemsOwned,
+			"remediations":       check.Remediations,
+		})
+		if marshalErr != nil {
+			e.logger.Warn("Failed to store offboarding check result in job", "job_id", job.ID, "error", marshalErr)
+		} else {
+			job.Result = string(resultJSON)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info("Offboarding check completed", "jobID", job.ID)
+	return nil
+}
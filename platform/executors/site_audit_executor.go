@@ -40,7 +40,7 @@ func (e *SiteAuditExecutor) Execute(ctx context.Context, job *jobs.Job, progress
 	}
 
 	// Create audit workflow using factory with parameters and audit run ID
-	workflow, err := e.workflowFactory.CreateAuditWorkflow(siteURL, auditRunID, parameters)
+	workflow, err := e.workflowFactory.CreateAuditWorkflow(siteURL, auditRunID, parameters, logging.Default().WithJob(job.ID))
 	if err != nil {
 		return err
 	}
@@ -68,6 +68,14 @@ func (e *SiteAuditExecutor) Execute(ctx context.Context, job *jobs.Job, progress
 	return nil
 }
 
+// Resume implements the ResumableJobExecutor interface for site audit jobs. The job keeps its audit
+// run, whose list checkpoints let the collector skip the lists already collected and continue the one
+// it was collecting. Everything after the lists, such as the sharing audit, runs again.
+func (e *SiteAuditExecutor) Resume(ctx context.Context, job *jobs.Job, progressCallback application.ProgressCallback) error {
+	e.logger.Info("Resuming site audit execution", "jobID", job.ID, "auditRunID", job.GetAuditRunID())
+	return e.Execute(ctx, job, progressCallback)
+}
+
 // ProgressAdapter adapts the workflow progress reporting to the job system's progress callback
 type ProgressAdapter struct {
 	progressCallback application.ProgressCallback
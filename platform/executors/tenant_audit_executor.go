@@ -0,0 +1,46 @@
+package executors
+
+import (
+	"context"
+	"encoding/json"
+
+	"spaudit/application"
+	"spaudit/domain/jobs"
+	"spaudit/logging"
+)
+
+// TenantAuditExecutor handles tenant audit job execution
+type TenantAuditExecutor struct {
+	tenantAuditService *application.TenantAuditService
+	logger             *logging.Logger
+}
+
+// NewTenantAuditExecutor creates a new tenant audit executor
+func NewTenantAuditExecutor(tenantAuditService *application.TenantAuditService) *TenantAuditExecutor {
+	return &TenantAuditExecutor{
+		tenantAuditService: tenantAuditService,
+		logger:             logging.Default().WithComponent("tenant_audit_executor"),
+	}
+}
+
+// Execute implements the JobExecutor interface for tenant audit jobs
+func (e *TenantAuditExecutor) Execute(ctx context.Context, job *jobs.Job, progressCallback application.ProgressCallback) error {
+	e.logger.Info("Starting tenant audit", "jobID", job.ID, "adminURL", job.GetSiteURL())
+
+	result, err := e.tenantAuditService.RunTenantAudit(ctx, job, progressCallback)
+	if result != nil {
+		// Store the site audits in the job, of failed and cancelled runs too
+		resultJSON, marshalErr := json.Marshal(result)
+		if marshalErr != nil {
+			e.logger.Warn("Failed to store tenant audit result in job", "job_id", job.ID, "error", marshalErr)
+		} else {
+			job.Result = string(resultJSON)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	e.logger.Info("Tenant audit completed", "jobID", job.ID, "sites", len(result.Sites))
+	return nil
+}
@@ -7,8 +7,10 @@ import (
 	"spaudit/application"
 	"spaudit/database"
 	"spaudit/domain/audit"
+	"spaudit/domain/contracts"
 	"spaudit/domain/jobs"
 	"spaudit/domain/sharepoint"
+	"spaudit/infrastructure/graphclient"
 	"spaudit/infrastructure/repositories"
 	"spaudit/infrastructure/spclient"
 	"spaudit/logging"
@@ -20,8 +22,10 @@ import (
 
 // AuditWorkflowFactory creates fully configured audit workflows
 type AuditWorkflowFactory struct {
-	db     *database.Database
-	logger *logging.Logger
+	db           *database.Database
+	sampleLimits audit.PayloadSampleLimits
+	sampleStore  contracts.PayloadSampleStore
+	logger       *logging.Logger
 }
 
 // NewAuditWorkflowFactory creates a new audit workflow factory
@@ -32,8 +36,14 @@ func NewAuditWorkflowFactory(db *database.Database) *AuditWorkflowFactory {
 	}
 }
 
+// SetPayloadSampling makes created workflows keep sanitized raw API payloads within the limits in the store.
+func (f *AuditWorkflowFactory) SetPayloadSampling(limits audit.PayloadSampleLimits, store contracts.PayloadSampleStore) {
+	f.sampleLimits = limits
+	f.sampleStore = store
+}
+
 // CreateAuditWorkflow creates a fully configured audit workflow for the specified site and audit run
-func (f *AuditWorkflowFactory) CreateAuditWorkflow(siteURL string, auditRunID int64, parameters *audit.AuditParameters) (application.AuditWorkflow, error) {
+func (f *AuditWorkflowFactory) CreateAuditWorkflow(siteURL string, auditRunID int64, parameters *audit.AuditParameters, jobLogger *logging.Logger) (application.AuditWorkflow, error) {
 	f.logger.Info("Creating audit workflow", "siteURL", siteURL)
 
 	// Use default parameters if none provided
@@ -42,7 +52,7 @@ func (f *AuditWorkflowFactory) CreateAuditWorkflow(siteURL string, auditRunID in
 	}
 
 	// Create SharePoint client for this specific site
-	spClient, err := f.createSharePointClient(siteURL, parameters)
+	spClient, err := newSharePointClient(siteURL, parameters, f.sampleLimits, jobLogger)
 	if err != nil {
 		return nil, fmt.Errorf("create SharePoint client: %w", err)
 	}
@@ -82,15 +92,22 @@ func (f *AuditWorkflowFactory) CreateAuditWorkflow(siteURL string, auditRunID in
 		itemRepo,
 		spClient,
 		f.db,
+		jobLogger,
 	)
+	if f.sampleStore != nil && f.sampleLimits.Enabled {
+		auditWorkflow.SetPayloadSampleStore(f.sampleStore)
+	}
 	f.logger.Info("Audit workflow created successfully")
 
 	return &WorkflowAdapter{workflow: auditWorkflow}, nil
 }
 
-// createSharePointClient creates a properly configured SharePoint client for the specific site
-func (f *AuditWorkflowFactory) createSharePointClient(siteURL string, parameters *audit.AuditParameters) (spclient.SharePointClient, error) {
-	f.logger.Info("Setting up SharePoint authentication", "siteURL", siteURL)
+// newSharePointClient authenticates with the environment's SharePoint credentials and creates a client for the site.
+// The client samples raw payloads within sampleLimits, reads through the parameters' collector backend and logs through logger.
+// Entra group expansion reads the directory with the environment's Microsoft Graph credentials, when they are set.
+func newSharePointClient(siteURL string, parameters *audit.AuditParameters, sampleLimits audit.PayloadSampleLimits, logger *logging.Logger) (spclient.SharePointClient, error) {
+	authLogger := logger.WithComponent("sharepoint_auth")
+	authLogger.Info("Setting up SharePoint authentication", "siteURL", siteURL)
 
 	// Setup SharePoint authentication
 	cfg, err := spauth.FromEnv()
@@ -106,9 +123,18 @@ func (f *AuditWorkflowFactory) createSharePointClient(siteURL string, parameters
 
 	// Create SharePoint client adapter with parameters
 	sp := api.NewSP(client)
-	spClient := spclient.NewSharePointClient(sp, client, parameters)
+	spClient := spclient.NewSharePointClientWithPayloadSampling(sp, client, parameters, sampleLimits, logger)
+	if parameters.ExpandEntraGroups {
+		directory := spauth.DirectoryFromEnv(cfg)
+		spClient.SetDirectoryCredentials(directory.TenantID, directory.ClientID, directory.CertPath, directory.CertPassword)
+	}
+	if parameters.UsesGraphCollector() {
+		if spClient, err = graphclient.NewClient(spClient, siteURL, logger); err != nil {
+			return nil, fmt.Errorf("graph client error: %w", err)
+		}
+	}
 
-	f.logger.Info("SharePoint client created successfully", "siteURL", siteURL)
+	authLogger.Info("SharePoint client created successfully", "siteURL", siteURL, "collector_backend", audit.NormalizeCollectorBackend(parameters.CollectorBackend))
 	return spClient, nil
 }
 
@@ -0,0 +1,96 @@
+package factories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/infrastructure/spclient"
+	"spaudit/logging"
+)
+
+// LiveListWebhookClient manages list webhook subscriptions and reads list change logs in SharePoint with
+// the environment's credentials
+type LiveListWebhookClient struct {
+	logger *logging.Logger
+}
+
+// NewLiveListWebhookClient creates a new live list webhook client
+func NewLiveListWebhookClient() *LiveListWebhookClient {
+	return &LiveListWebhookClient{
+		logger: logging.Default().WithComponent("list_webhook_client"),
+	}
+}
+
+// GetList returns the list of the site with the ID
+func (c *LiveListWebhookClient) GetList(ctx context.Context, siteURL, listID string) (*sharepoint.List, error) {
+	client, err := c.client(siteURL)
+	if err != nil {
+		return nil, err
+	}
+	return client.GetListByID(ctx, listID)
+}
+
+// Subscribe subscribes notificationURL to the list's changes until expiration
+func (c *LiveListWebhookClient) Subscribe(ctx context.Context, siteURL, listID, notificationURL, clientState string, expiration time.Time) (string, time.Time, error) {
+	c.logger.Info("Subscribing to list changes", "site_url", siteURL, "list_id", listID, "notification_url", notificationURL)
+	client, err := c.client(siteURL)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return client.AddListSubscription(ctx, listID, notificationURL, clientState, expiration)
+}
+
+// Renew moves the expiration of a subscription to the list's changes
+func (c *LiveListWebhookClient) Renew(ctx context.Context, siteURL, listID, subscriptionID string, expiration time.Time) (time.Time, error) {
+	client, err := c.client(siteURL)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return client.RenewListSubscription(ctx, listID, subscriptionID, expiration)
+}
+
+// Unsubscribe ends a subscription to the list's changes
+func (c *LiveListWebhookClient) Unsubscribe(ctx context.Context, siteURL, listID, subscriptionID string) error {
+	client, err := c.client(siteURL)
+	if err != nil {
+		return err
+	}
+	return client.DeleteListSubscription(ctx, listID, subscriptionID)
+}
+
+// GetChangeToken returns the list's current position in its change log
+func (c *LiveListWebhookClient) GetChangeToken(ctx context.Context, siteURL, listID string) (string, error) {
+	client, err := c.client(siteURL)
+	if err != nil {
+		return "", err
+	}
+	return client.GetListChangeToken(ctx, listID)
+}
+
+// GetChangedItems returns the items changed in the list since changeToken, and the token to read on from
+func (c *LiveListWebhookClient) GetChangedItems(ctx context.Context, siteURL, listID, changeToken string) ([]*sharepoint.ChangedItem, string, error) {
+	client, err := c.client(siteURL)
+	if err != nil {
+		return nil, "", err
+	}
+	return client.GetChangedListItems(ctx, listID, changeToken)
+}
+
+// client creates a SharePoint client for the site
+func (c *LiveListWebhookClient) client(siteURL string) (spclient.SharePointClient, error) {
+	client, err := newSharePointClient(siteURL, audit.DefaultParameters(), audit.PayloadSampleLimits{}, logging.Default())
+	if err != nil {
+		return nil, fmt.Errorf("create SharePoint client: %w", err)
+	}
+	return client, nil
+}
+
+// Ensure LiveListWebhookClient implements the application interfaces
+var (
+	_ application.ListWebhookClient = (*LiveListWebhookClient)(nil)
+	_ application.ListChangeReader  = (*LiveListWebhookClient)(nil)
+)
@@ -0,0 +1,113 @@
+package factories
+
+import (
+	"context"
+	"fmt"
+
+	"spaudit/application"
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/infrastructure/spclient"
+	"spaudit/logging"
+)
+
+// LiveItemResolver looks items up in SharePoint with the environment's credentials
+type LiveItemResolver struct {
+	logger *logging.Logger
+}
+
+// NewLiveItemResolver creates a new live item resolver
+func NewLiveItemResolver() *LiveItemResolver {
+	return &LiveItemResolver{
+		logger: logging.Default().WithComponent("live_item_resolver"),
+	}
+}
+
+// ResolveItem looks the referenced item up in the site, with its list and effective role assignments
+func (r *LiveItemResolver) ResolveItem(ctx context.Context, siteURL string, ref sharepoint.ItemReference) (*application.LiveItemData, error) {
+	r.logger.Info("Looking up item in SharePoint", "site_url", siteURL, "reference_kind", ref.Kind)
+
+	client, err := newSharePointClient(siteURL, audit.DefaultParameters(), audit.PayloadSampleLimits{}, logging.Default())
+	if err != nil {
+		return nil, fmt.Errorf("create SharePoint client: %w", err)
+	}
+
+	var item *sharepoint.Item
+	switch ref.Kind {
+	case sharepoint.ItemReferenceGUID:
+		// A GUID may be a file's or a folder's UniqueId
+		item, err = client.ResolveFileByGUID(ctx, ref.GUID)
+		if err != nil {
+			item, err = client.ResolveFolderByGUID(ctx, ref.GUID)
+		}
+	case sharepoint.ItemReferencePath:
+		item, err = client.ResolveItemByPath(ctx, ref.Path)
+	default:
+		return nil, fmt.Errorf("%w: %s references cannot be looked up in SharePoint", application.ErrItemNotFound, ref.Kind)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolve item: %w", err)
+	}
+
+	list, err := client.GetListByID(ctx, item.ListID)
+	if err != nil {
+		return nil, err
+	}
+	list.SiteID = item.SiteID
+
+	target := spclient.PermissionTarget{ObjectType: sharepoint.ObjectTypeItem, ObjectID: item.ListID, ListItemID: item.ID}
+	item.HasUnique, err = client.CheckUniquePermissions(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("check item permission inheritance: %w", err)
+	}
+
+	// An item that inherits reports the assignments of the object it inherits from
+	roleAssignments, principals, err := client.GetObjectRoleAssignments(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("get item role assignments: %w", err)
+	}
+	roleDefinitions, err := client.GetSiteRoleDefinitions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get role definitions: %w", err)
+	}
+
+	return &application.LiveItemData{
+		Item:        item,
+		List:        list,
+		Assignments: buildLiveAssignments(roleAssignments, principals, roleDefinitions, !item.HasUnique),
+	}, nil
+}
+
+// buildLiveAssignments joins role assignments with their principals and role definitions
+func buildLiveAssignments(roleAssignments []*sharepoint.RoleAssignment, principals []*sharepoint.Principal, roleDefinitions []*sharepoint.RoleDefinition, inherited bool) []*sharepoint.Assignment {
+	principalsByID := make(map[int64]*sharepoint.Principal, len(principals))
+	for _, principal := range principals {
+		principalsByID[principal.ID] = principal
+	}
+	roleDefinitionsByID := make(map[int64]*sharepoint.RoleDefinition, len(roleDefinitions))
+	for _, roleDefinition := range roleDefinitions {
+		roleDefinitionsByID[roleDefinition.ID] = roleDefinition
+	}
+
+	assignments := make([]*sharepoint.Assignment, 0, len(roleAssignments))
+	for _, roleAssignment := range roleAssignments {
+		roleAssignment.Inherited = inherited
+		principal := principalsByID[roleAssignment.PrincipalID]
+		if principal == nil {
+			principal = &sharepoint.Principal{ID: roleAssignment.PrincipalID}
+		}
+		roleDefinition := roleDefinitionsByID[roleAssignment.RoleDefID]
+		if roleDefinition == nil {
+			roleDefinition = &sharepoint.RoleDefinition{ID: roleAssignment.RoleDefID, Name: fmt.Sprintf("Role %d", roleAssignment.RoleDefID)}
+		}
+		assignments = append(assignments, &sharepoint.Assignment{
+			RoleAssignment: roleAssignment,
+			Principal:      principal,
+			RoleDefinition: roleDefinition,
+		})
+	}
+	return assignments
+}
+
+// Ensure LiveItemResolver implements the application interface
+var _ application.LiveItemResolver = (*LiveItemResolver)(nil)
@@ -0,0 +1,33 @@
+package factories
+
+import (
+	"context"
+	"fmt"
+
+	"spaudit/domain/audit"
+	"spaudit/domain/sharepoint"
+	"spaudit/logging"
+)
+
+// LiveTenantSiteDiscoverer lists a tenant's site collections in its admin center with the environment's credentials
+type LiveTenantSiteDiscoverer struct {
+	logger *logging.Logger
+}
+
+// NewLiveTenantSiteDiscoverer creates a new live tenant site discoverer
+func NewLiveTenantSiteDiscoverer() *LiveTenantSiteDiscoverer {
+	return &LiveTenantSiteDiscoverer{
+		logger: logging.Default().WithComponent("tenant_site_discoverer"),
+	}
+}
+
+// DiscoverSites lists the site collections of the tenant whose admin center is at adminURL
+func (d *LiveTenantSiteDiscoverer) DiscoverSites(ctx context.Context, adminURL string) ([]*sharepoint.TenantSite, error) {
+	d.logger.Info("Listing tenant site collections", "admin_url", adminURL)
+
+	client, err := newSharePointClient(adminURL, audit.DefaultParameters(), audit.PayloadSampleLimits{}, logging.Default())
+	if err != nil {
+		return nil, fmt.Errorf("create SharePoint client: %w", err)
+	}
+	return client.GetTenantSites(ctx)
+}
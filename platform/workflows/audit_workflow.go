@@ -58,8 +58,10 @@ type AuditWorkflow struct {
 	// Infrastructure dependencies
 	spClient         spclient.SharePointClient
 	db               *database.Database
+	jobLogger        *logging.Logger // Collectors created during the run log through it
 	logger           *logging.Logger
 	progressReporter audit.ProgressReporter
+	sampleStore      contracts.PayloadSampleStore // Keeps the client's raw payload samples, if set
 }
 
 // NewAuditWorkflow creates a new audit workflow.
@@ -69,9 +71,10 @@ func NewAuditWorkflow(
 	itemRepo contracts.ItemRepository,
 	spClient spclient.SharePointClient,
 	db *database.Database,
+	jobLogger *logging.Logger,
 ) *AuditWorkflow {
 	// Create existing audit services for data collection
-	sharingDataCollector := spauditor.NewSharingDataCollector(spClient, auditRepo)
+	sharingDataCollector := spauditor.NewSharingDataCollector(spClient, auditRepo, jobLogger)
 
 	return &AuditWorkflow{
 		contentService:       sharepoint.NewContentService(),
@@ -83,7 +86,8 @@ func NewAuditWorkflow(
 		itemRepo:             itemRepo,
 		spClient:             spClient,
 		db:                   db,
-		logger:               logging.Default().WithComponent("audit_workflow"),
+		jobLogger:            jobLogger,
+		logger:               jobLogger.WithComponent("audit_workflow"),
 	}
 }
 
@@ -92,6 +96,11 @@ func (w *AuditWorkflow) SetProgressReporter(reporter audit.ProgressReporter) {
 	w.progressReporter = reporter
 }
 
+// SetPayloadSampleStore sets the store the SharePoint client's sampled raw payloads are saved to when the run ends
+func (w *AuditWorkflow) SetPayloadSampleStore(store contracts.PayloadSampleStore) {
+	w.sampleStore = store
+}
+
 // ExecuteSiteAudit executes a complete site audit using domain services.
 func (w *AuditWorkflow) ExecuteSiteAudit(ctx context.Context, job *jobs.Job, siteURL string) (*AuditWorkflowResult, error) {
 	// Get audit run ID from job
@@ -99,6 +108,7 @@ func (w *AuditWorkflow) ExecuteSiteAudit(ctx context.Context, job *jobs.Job, sit
 	if auditRunID == 0 {
 		return nil, fmt.Errorf("job must have an associated audit run")
 	}
+	defer w.savePayloadSamples(ctx, auditRunID)
 	startTime := time.Now()
 	w.logger.Audit("Starting platform audit workflow for site", siteURL)
 
@@ -122,6 +132,11 @@ func (w *AuditWorkflow) ExecuteSiteAudit(ctx context.Context, job *jobs.Job, sit
 	}
 	result.SiteID = siteID
 
+	// Persist per-list unique-permission density now that item collection is complete
+	if err := w.auditRepo.UpdateListUniqueDensity(ctx); err != nil {
+		w.logger.Warn("Failed to compute list unique-permission density", "error", err)
+	}
+
 	// Phase 2: Content Collection and Analysis
 	w.reportProgress(audit.StandardStages.ListDiscovery, "Analyzing content structure", 30)
 	if err := w.analyzeContent(ctx, siteID, result); err != nil {
@@ -134,6 +149,11 @@ func (w *AuditWorkflow) ExecuteSiteAudit(ctx context.Context, job *jobs.Job, sit
 		return nil, fmt.Errorf("sharing analysis: %w", err)
 	}
 
+	// Persist per-list access counts now that sharing links are collected
+	if err := w.auditRepo.UpdateListAccessCounts(ctx); err != nil {
+		w.logger.Warn("Failed to compute list access counts", "error", err)
+	}
+
 	// Phase 4: Permission Analysis
 	w.reportProgress(audit.StandardStages.Permissions, "Analyzing permission risks", 70)
 	if err := w.analyzePermissions(ctx, siteID, result); err != nil {
@@ -286,7 +306,7 @@ func (w *AuditWorkflow) performFullSiteAudit(ctx context.Context, auditRunID int
 	}
 
 	// Create the proven data collector with progress reporting
-	dataCollector := spauditor.NewSharePointDataCollectorWithProgress(parameters, w.spClient, w.auditRepo, w.db, w.progressReporter)
+	dataCollector := spauditor.NewSharePointDataCollectorWithProgress(parameters, w.spClient, w.auditRepo, w.db, w.progressReporter, w.jobLogger)
 
 	// Run the full site data collection
 	if err := dataCollector.CollectSiteData(ctx, auditRunID, siteURL); err != nil {
@@ -306,6 +326,24 @@ func (w *AuditWorkflow) performFullSiteAudit(ctx context.Context, auditRunID int
 	return site.ID, nil
 }
 
+// savePayloadSamples saves the raw payloads the SharePoint client sampled during the run, including
+// runs that failed, since those are the ones parsing issues show up in
+func (w *AuditWorkflow) savePayloadSamples(ctx context.Context, auditRunID int64) {
+	if w.sampleStore == nil {
+		return
+	}
+	samples := w.spClient.PayloadSamples()
+	if len(samples) == 0 {
+		return
+	}
+
+	if err := w.sampleStore.SavePayloadSamples(context.WithoutCancel(ctx), auditRunID, samples); err != nil {
+		w.logger.Warn("Failed to save payload samples", "audit_run_id", auditRunID, "samples", len(samples), "error", err.Error())
+		return
+	}
+	w.logger.Info("Saved payload samples", "audit_run_id", auditRunID, "samples", len(samples))
+}
+
 // reportProgress reports workflow progress if a reporter is configured
 func (w *AuditWorkflow) reportProgress(stage, description string, percentage int) {
 	if w.progressReporter != nil {
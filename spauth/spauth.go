@@ -32,6 +32,46 @@ func FromEnv() (Config, error) {
 	return cfg, nil
 }
 
+// DirectoryFromEnv returns the credentials Entra group expansion reads group membership from Microsoft
+// Graph with. GRAPH_TENANT_ID, GRAPH_CLIENT_ID and GRAPH_CERT_PATH name an app registration holding
+// GroupMember.Read.All, each one unset falling back to the SharePoint credential's; GRAPH_CERT_PASSWORD
+// goes with GRAPH_CERT_PATH.
+func DirectoryFromEnv(sharePoint Config) Config {
+	cfg := sharePoint
+	cfg.TenantID = getEnvOr("GRAPH_TENANT_ID", sharePoint.TenantID)
+	cfg.ClientID = getEnvOr("GRAPH_CLIENT_ID", sharePoint.ClientID)
+	if certPath := os.Getenv("GRAPH_CERT_PATH"); certPath != "" {
+		cfg.CertPath = certPath
+		cfg.CertPassword = os.Getenv("GRAPH_CERT_PASSWORD")
+	}
+	return cfg
+}
+
+// getEnvOr returns the environment variable, or fallback when it is unset or empty
+func getEnvOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// CheckCredentials checks the environment holds SharePoint credentials and their certificate can be read.
+// It does not sign in.
+func CheckCredentials() error {
+	cfg, err := FromEnv()
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(cfg.CertPath)
+	if err != nil {
+		return fmt.Errorf("certificate SP_CERT_PATH=%s cannot be read: %w", cfg.CertPath, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("certificate SP_CERT_PATH=%s is a directory", cfg.CertPath)
+	}
+	return nil
+}
+
 func NewClient(cfg Config) (*gosip.SPClient, error) {
 	ac := &azurecert.AuthCnfg{
 		SiteURL:  cfg.SiteURL,
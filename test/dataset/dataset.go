@@ -0,0 +1,244 @@
+// Package dataset generates reproducible synthetic SharePoint sites for benchmarks. The same Spec
+// always produces the same site, so a benchmark result measures the code, not the data.
+package dataset
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+
+	"spaudit/domain/contracts"
+	"spaudit/domain/sharepoint"
+)
+
+// Built-in SharePoint role definition IDs.
+const (
+	RoleLimitedAccess int64 = 1073741825
+	RoleRead          int64 = 1073741826
+	RoleFullControl   int64 = 1073741829
+	RoleEdit          int64 = 1073741830
+)
+
+// Spec sizes a synthetic site: Lists lists of ItemsPerList items, where each item with unique
+// permissions carries AssignmentsPerItem user assignments.
+type Spec struct {
+	SiteID             int64
+	SiteURL            string
+	Lists              int
+	ItemsPerList       int
+	AssignmentsPerItem int
+	Users              int     // Distinct users assignments are drawn from, at least AssignmentsPerItem
+	UniqueRatio        float64 // Share of items with unique permissions, 0 to 1
+	Seed               uint64
+}
+
+// Small is a site for quick benchmark runs: 5 lists × 200 items × 4 assignments.
+var Small = Spec{SiteID: 1, SiteURL: "https://contoso.sharepoint.com/sites/bench", Lists: 5, ItemsPerList: 200, AssignmentsPerItem: 4, Users: 50, UniqueRatio: 0.25, Seed: 1}
+
+// Dataset is a generated site. Every object carries Spec.SiteID.
+type Dataset struct {
+	Spec            Spec
+	Site            *sharepoint.Site
+	Web             *sharepoint.Web
+	Lists           []*sharepoint.List
+	Items           []*sharepoint.Item
+	Principals      []*sharepoint.Principal // Owners, Members and Visitors groups, then the users
+	RoleDefinitions []*sharepoint.RoleDefinition
+	Assignments     []*sharepoint.RoleAssignment // The groups on the web and every list, users on unique items
+}
+
+// Generate builds the site described by spec.
+func Generate(spec Spec) *Dataset {
+	spec.Users = max(spec.Users, spec.AssignmentsPerItem)
+	rng := rand.New(rand.NewPCG(spec.Seed, spec.Seed))
+
+	d := &Dataset{
+		Spec: spec,
+		Site: &sharepoint.Site{ID: spec.SiteID, URL: spec.SiteURL, Title: "Benchmark"},
+		Web:  &sharepoint.Web{SiteID: spec.SiteID, ID: guid(rng), URL: spec.SiteURL, Title: "Benchmark"},
+		RoleDefinitions: []*sharepoint.RoleDefinition{
+			{SiteID: spec.SiteID, ID: RoleLimitedAccess, Name: "Limited Access"},
+			{SiteID: spec.SiteID, ID: RoleRead, Name: "Read"},
+			{SiteID: spec.SiteID, ID: RoleFullControl, Name: "Full Control"},
+			{SiteID: spec.SiteID, ID: RoleEdit, Name: "Edit"},
+		},
+	}
+
+	groups := []struct {
+		title string
+		role  int64
+	}{{"Owners", RoleFullControl}, {"Members", RoleEdit}, {"Visitors", RoleRead}}
+	for i, group := range groups {
+		d.Principals = append(d.Principals, &sharepoint.Principal{
+			SiteID:        spec.SiteID,
+			ID:            int64(i + 3),
+			PrincipalType: sharepoint.PrincipalTypeSharePointGroup,
+			Title:         "Benchmark " + group.title,
+			LoginName:     "Benchmark " + group.title,
+		})
+	}
+	groupAssignments := func(objectType, objectKey string) {
+		for i, group := range groups {
+			d.Assignments = append(d.Assignments, &sharepoint.RoleAssignment{
+				SiteID: spec.SiteID, ObjectType: objectType, ObjectKey: objectKey, PrincipalID: int64(i + 3), RoleDefID: group.role,
+			})
+		}
+	}
+	groupAssignments(sharepoint.ObjectTypeWeb, d.Web.ID)
+
+	const firstUserID = 100
+	for u := 0; u < spec.Users; u++ {
+		d.Principals = append(d.Principals, &sharepoint.Principal{
+			SiteID:        spec.SiteID,
+			ID:            int64(firstUserID + u),
+			PrincipalType: sharepoint.PrincipalTypeUser,
+			Title:         fmt.Sprintf("User %d", u),
+			LoginName:     fmt.Sprintf("i:0#.f|membership|user%d@contoso.com", u),
+			Email:         fmt.Sprintf("user%d@contoso.com", u),
+		})
+	}
+	userRoles := []int64{RoleRead, RoleEdit, RoleFullControl}
+
+	for l := 0; l < spec.Lists; l++ {
+		list := &sharepoint.List{
+			SiteID:       spec.SiteID,
+			ID:           guid(rng),
+			WebID:        d.Web.ID,
+			Title:        fmt.Sprintf("Library %d", l),
+			URL:          fmt.Sprintf("%s/Library%d", spec.SiteURL, l),
+			BaseTemplate: 101,
+			ItemCount:    spec.ItemsPerList,
+		}
+		d.Lists = append(d.Lists, list)
+		groupAssignments(sharepoint.ObjectTypeList, list.ID)
+
+		for i := 1; i <= spec.ItemsPerList; i++ {
+			name := fmt.Sprintf("Document %d.docx", i)
+			item := &sharepoint.Item{
+				SiteID:        spec.SiteID,
+				GUID:          guid(rng),
+				ListItemGUID:  guid(rng),
+				ListID:        list.ID,
+				ID:            i,
+				URL:           fmt.Sprintf("%s/%s", list.URL, name),
+				Name:          name,
+				IsFile:        true,
+				HasUnique:     rng.Float64() < spec.UniqueRatio,
+				Size:          rng.Int64N(10 << 20),
+				ContentTypeID: "0x0101",
+			}
+			item.FileFolderUniqueID = item.GUID // Files share their UniqueId with sharing links
+			d.Items = append(d.Items, item)
+			if !item.HasUnique {
+				continue
+			}
+			list.UniqueItemCount++
+
+			// Consecutive users from a random start keep an item's principals distinct
+			start := rng.IntN(spec.Users)
+			for a := 0; a < spec.AssignmentsPerItem; a++ {
+				d.Assignments = append(d.Assignments, &sharepoint.RoleAssignment{
+					SiteID:      spec.SiteID,
+					ObjectType:  sharepoint.ObjectTypeItem,
+					ObjectKey:   item.GUID,
+					PrincipalID: int64(firstUserID + (start+a)%spec.Users),
+					RoleDefID:   userRoles[rng.IntN(len(userRoles))],
+				})
+			}
+		}
+	}
+	return d
+}
+
+// Save writes the generated site into an audit run. The site and the audit run must already exist.
+func (d *Dataset) Save(ctx context.Context, repo contracts.AuditRepository, auditRunID int64) error {
+	if err := repo.SaveWeb(ctx, auditRunID, d.Web); err != nil {
+		return fmt.Errorf("save web: %w", err)
+	}
+	if err := repo.SaveRoleDefinitions(ctx, auditRunID, d.Spec.SiteID, d.RoleDefinitions); err != nil {
+		return fmt.Errorf("save role definitions: %w", err)
+	}
+	for _, principal := range d.Principals {
+		if err := repo.SavePrincipal(ctx, auditRunID, principal); err != nil {
+			return fmt.Errorf("save principal %d: %w", principal.ID, err)
+		}
+	}
+	for _, list := range d.Lists {
+		if err := repo.SaveList(ctx, auditRunID, list); err != nil {
+			return fmt.Errorf("save list %s: %w", list.ID, err)
+		}
+	}
+	for _, item := range d.Items {
+		if err := repo.SaveItem(ctx, auditRunID, item); err != nil {
+			return fmt.Errorf("save item %s: %w", item.GUID, err)
+		}
+	}
+	if err := repo.SaveRoleAssignments(ctx, auditRunID, d.Spec.SiteID, d.Assignments); err != nil {
+		return fmt.Errorf("save role assignments: %w", err)
+	}
+	if err := repo.UpdateListUniqueDensity(ctx, auditRunID, d.Spec.SiteID); err != nil {
+		return err
+	}
+	return repo.UpdateListAccessCounts(ctx, auditRunID, d.Spec.SiteID)
+}
+
+// RoleAssignmentPayload renders an object's assignments as the JSON SharePoint returns for
+// RoleAssignments expanded with Member and RoleDefinitionBindings.
+func (d *Dataset) RoleAssignmentPayload(objectKey string) ([]byte, error) {
+	type member struct {
+		Id            int64
+		Title         string
+		LoginName     string
+		PrincipalType int64
+		Email         string
+	}
+	type binding struct {
+		Id          int64
+		Name        string
+		Description string
+	}
+	type assignment struct {
+		Member                 member
+		RoleDefinitionBindings []binding
+	}
+
+	principals := make(map[int64]*sharepoint.Principal, len(d.Principals))
+	for _, p := range d.Principals {
+		principals[p.ID] = p
+	}
+	roles := make(map[int64]*sharepoint.RoleDefinition, len(d.RoleDefinitions))
+	for _, rd := range d.RoleDefinitions {
+		roles[rd.ID] = rd
+	}
+
+	var payload struct{ RoleAssignments []assignment }
+	for _, ra := range d.Assignments {
+		if ra.ObjectKey != objectKey {
+			continue
+		}
+		p, rd := principals[ra.PrincipalID], roles[ra.RoleDefID]
+		payload.RoleAssignments = append(payload.RoleAssignments, assignment{
+			Member:                 member{Id: p.ID, Title: p.Title, LoginName: p.LoginName, PrincipalType: p.PrincipalType, Email: p.Email},
+			RoleDefinitionBindings: []binding{{Id: rd.ID, Name: rd.Name, Description: rd.Description}},
+		})
+	}
+	return json.Marshal(payload)
+}
+
+// UniqueItems returns the items with unique permissions, in generation order.
+func (d *Dataset) UniqueItems() []*sharepoint.Item {
+	var unique []*sharepoint.Item
+	for _, item := range d.Items {
+		if item.HasUnique {
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
+
+// guid draws a GUID-shaped identifier from rng.
+func guid(rng *rand.Rand) string {
+	return fmt.Sprintf("%08x-%04x-%04x-%04x-%012x",
+		rng.Uint32(), rng.Uint32()&0xffff, rng.Uint32()&0xffff, rng.Uint32()&0xffff, rng.Uint64()&0xffffffffffff)
+}
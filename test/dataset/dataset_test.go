@@ -0,0 +1,39 @@
+package dataset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_IsReproducible(t *testing.T) {
+	first, second := Generate(Small), Generate(Small)
+	require.Len(t, first.Items, Small.Lists*Small.ItemsPerList)
+	assert.Equal(t, first.Items, second.Items)
+	assert.Equal(t, first.Assignments, second.Assignments)
+
+	reseeded := Small
+	reseeded.Seed++
+	assert.NotEqual(t, first.Items[0].GUID, Generate(reseeded).Items[0].GUID)
+}
+
+func TestGenerate_AssignsUniqueItems(t *testing.T) {
+	d := Generate(Small)
+	unique := d.UniqueItems()
+	require.NotEmpty(t, unique)
+
+	// The three groups on the web and each list, then the users on every unique item
+	assert.Len(t, d.Assignments, 3*(1+Small.Lists)+len(unique)*Small.AssignmentsPerItem)
+
+	seen := make(map[[2]int64]bool)
+	for _, ra := range d.Assignments {
+		if ra.ObjectKey != unique[0].GUID {
+			continue
+		}
+		key := [2]int64{ra.PrincipalID, ra.RoleDefID}
+		assert.False(t, seen[key], "assignment repeated on one item")
+		seen[key] = true
+	}
+	assert.Len(t, seen, Small.AssignmentsPerItem)
+}
@@ -139,6 +139,11 @@ func (m *MockJobRepository) CancelJob(ctx context.Context, jobID string) error {
 	return args.Error(0)
 }
 
+func (m *MockJobRepository) ReopenJob(ctx context.Context, jobID string) error {
+	args := m.Called(ctx, jobID)
+	return args.Error(0)
+}
+
 func (m *MockJobRepository) GetJob(ctx context.Context, jobID string) (*jobs.Job, error) {
 	args := m.Called(ctx, jobID)
 	return args.Get(0).(*jobs.Job), args.Error(1)
@@ -185,11 +190,27 @@ func (m *MockAssignmentRepository) GetResolvedAssignmentsForObject(ctx context.C
 	return args.Get(0).([]*sharepoint.ResolvedAssignment), args.Error(1)
 }
 
+func (m *MockAssignmentRepository) GetResolvedAssignmentsPageForObject(ctx context.Context, siteID int64, objectType, objectKey string, offset, limit int) ([]*sharepoint.ResolvedAssignment, error) {
+	args := m.Called(ctx, siteID, objectType, objectKey, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.ResolvedAssignment), args.Error(1)
+}
+
 // MockItemRepository implements ItemRepository for testing
 type MockItemRepository struct {
 	mock.Mock
 }
 
+func (m *MockItemRepository) GetByGUID(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.Item, error) {
+	args := m.Called(ctx, siteID, itemGUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharepoint.Item), args.Error(1)
+}
+
 func (m *MockItemRepository) GetItemsForList(ctx context.Context, siteID int64, listID string, offset, limit int64) ([]*sharepoint.Item, error) {
 	args := m.Called(ctx, siteID, listID, offset, limit)
 	if args.Get(0) == nil {
@@ -206,6 +227,19 @@ func (m *MockItemRepository) GetItemsWithUniqueForList(ctx context.Context, site
 	return args.Get(0).([]*sharepoint.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) GetFilteredItemsForList(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter, offset, limit int64) ([]*sharepoint.Item, error) {
+	args := m.Called(ctx, siteID, listID, filter, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) CountFilteredItemsForList(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter) (int64, error) {
+	args := m.Called(ctx, siteID, listID, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // MockSharingRepository implements SharingRepository for testing
 type MockSharingRepository struct {
 	mock.Mock
@@ -235,6 +269,22 @@ func (m *MockSharingRepository) GetSharingLinkMembers(ctx context.Context, siteI
 	return args.Get(0).([]*sharepoint.Principal), args.Error(1)
 }
 
+func (m *MockSharingRepository) GetItemSensitivityLabel(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.ItemSensitivityLabel, error) {
+	args := m.Called(ctx, siteID, itemGUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharepoint.ItemSensitivityLabel), args.Error(1)
+}
+
+func (m *MockSharingRepository) GetSensitivityLabelsForList(ctx context.Context, siteID int64, listID string) ([]*sharepoint.ItemSensitivityLabel, error) {
+	args := m.Called(ctx, siteID, listID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.ItemSensitivityLabel), args.Error(1)
+}
+
 // MockAuditService implements AuditService for testing
 type MockAuditService struct {
 	mock.Mock
@@ -280,6 +330,69 @@ func (m *MockAuditService) CancelAudit(siteURL string) error {
 	return args.Error(0)
 }
 
+func (m *MockAuditService) GetAuditRunsForSite(ctx context.Context, siteID int64, label string, limit int) ([]*audit.AuditRun, error) {
+	args := m.Called(ctx, siteID, label, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*audit.AuditRun), args.Error(1)
+}
+
+func (m *MockAuditService) GetAuditRun(ctx context.Context, siteID, auditRunID int64) (*audit.AuditRun, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.AuditRun), args.Error(1)
+}
+
+func (m *MockAuditService) GetAuditRunSchemaDrift(ctx context.Context, siteID, auditRunID int64) ([]audit.SchemaDrift, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]audit.SchemaDrift), args.Error(1)
+}
+
+func (m *MockAuditService) GetAuditRunForJob(ctx context.Context, jobID string) (*audit.AuditRun, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.AuditRun), args.Error(1)
+}
+
+func (m *MockAuditService) PinAuditRun(ctx context.Context, siteID, auditRunID int64) error {
+	args := m.Called(ctx, siteID, auditRunID)
+	return args.Error(0)
+}
+
+func (m *MockAuditService) UnpinAuditRun(ctx context.Context, siteID int64) error {
+	args := m.Called(ctx, siteID)
+	return args.Error(0)
+}
+
+func (m *MockAuditService) GetPinnedAuditRunID(ctx context.Context, siteID int64) (int64, error) {
+	args := m.Called(ctx, siteID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockAuditService) HoldAuditRun(ctx context.Context, siteID, auditRunID int64, reason string) (*audit.AuditRun, error) {
+	args := m.Called(ctx, siteID, auditRunID, reason)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.AuditRun), args.Error(1)
+}
+
+func (m *MockAuditService) ReleaseAuditRunHold(ctx context.Context, siteID, auditRunID int64) (*audit.AuditRun, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*audit.AuditRun), args.Error(1)
+}
+
 // MockJobServiceForApplication implements JobService interface for application layer testing
 type MockJobServiceForApplication struct {
 	mock.Mock
@@ -309,6 +422,19 @@ func (m *MockJobServiceForApplication) CancelJob(jobID string) (*jobs.Job, error
 	return args.Get(0).(*jobs.Job), args.Error(1)
 }
 
+func (m *MockJobServiceForApplication) ResumeJob(jobID string) (*jobs.Job, error) {
+	args := m.Called(jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*jobs.Job), args.Error(1)
+}
+
+func (m *MockJobServiceForApplication) FailInterruptedJobs() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockJobServiceForApplication) ListAllJobs() []*jobs.Job {
 	args := m.Called()
 	if args.Get(0) == nil {
@@ -374,6 +500,22 @@ func (m *MockSiteContentAggregateRepository) GetAllSitesWithMetadata(ctx context
 	return args.Get(0).([]*contracts.SiteWithMetadata), args.Error(1)
 }
 
+func (m *MockSiteContentAggregateRepository) GetAllSitesWithLatestRunMetadata(ctx context.Context, preferCompleted bool, label string) ([]*contracts.SiteWithMetadata, error) {
+	args := m.Called(ctx, preferCompleted, label)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*contracts.SiteWithMetadata), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetSiteByURL(ctx context.Context, siteURL string) (*sharepoint.Site, error) {
+	args := m.Called(ctx, siteURL)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharepoint.Site), args.Error(1)
+}
+
 func (m *MockSiteContentAggregateRepository) SearchSites(ctx context.Context, searchQuery string) ([]*contracts.SiteWithMetadata, error) {
 	args := m.Called(ctx, searchQuery)
 	if args.Get(0) == nil {
@@ -382,6 +524,14 @@ func (m *MockSiteContentAggregateRepository) SearchSites(ctx context.Context, se
 	return args.Get(0).([]*contracts.SiteWithMetadata), args.Error(1)
 }
 
+func (m *MockSiteContentAggregateRepository) GetWebsForSite(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.Web, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.Web), args.Error(1)
+}
+
 func (m *MockSiteContentAggregateRepository) GetListByID(ctx context.Context, siteID int64, listID string) (*sharepoint.List, error) {
 	args := m.Called(ctx, siteID, listID)
 	if args.Get(0) == nil {
@@ -406,6 +556,22 @@ func (m *MockSiteContentAggregateRepository) GetListAssignmentsWithRootCause(ctx
 	return args.Get(0).([]*sharepoint.ResolvedAssignment), args.Error(1)
 }
 
+func (m *MockSiteContentAggregateRepository) GetResolvedAssignmentsForObject(ctx context.Context, siteID int64, auditRunID int64, objectType, objectKey string) ([]*sharepoint.ResolvedAssignment, error) {
+	args := m.Called(ctx, siteID, auditRunID, objectType, objectKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.ResolvedAssignment), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetResolvedAssignmentsPageForObject(ctx context.Context, siteID int64, auditRunID int64, objectType, objectKey string, offset, limit int) ([]*sharepoint.ResolvedAssignment, error) {
+	args := m.Called(ctx, siteID, auditRunID, objectType, objectKey, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.ResolvedAssignment), args.Error(1)
+}
+
 func (m *MockSiteContentAggregateRepository) GetAssignmentsForObject(ctx context.Context, siteID int64, auditRunID int64, objectType, objectKey string) ([]*sharepoint.Assignment, error) {
 	args := m.Called(ctx, siteID, auditRunID, objectType, objectKey)
 	if args.Get(0) == nil {
@@ -414,6 +580,62 @@ func (m *MockSiteContentAggregateRepository) GetAssignmentsForObject(ctx context
 	return args.Get(0).([]*sharepoint.Assignment), args.Error(1)
 }
 
+func (m *MockSiteContentAggregateRepository) GetListChanges(ctx context.Context, siteID int64, listID string, baseAuditRunID, auditRunID int64, maxRows int) (*contracts.ListChanges, error) {
+	args := m.Called(ctx, siteID, listID, baseAuditRunID, auditRunID, maxRows)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contracts.ListChanges), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetRunChanges(ctx context.Context, siteID int64, baseAuditRunID, auditRunID int64, maxRows int) (*contracts.RunChanges, error) {
+	args := m.Called(ctx, siteID, baseAuditRunID, auditRunID, maxRows)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contracts.RunChanges), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetRunFacts(ctx context.Context, siteID int64, auditRunID int64, maxRows int) (*contracts.RunFacts, error) {
+	args := m.Called(ctx, siteID, auditRunID, maxRows)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contracts.RunFacts), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetSharingLinkFacts(ctx context.Context, siteID int64, auditRunID int64, maxRows int) ([]*contracts.SharingLinkFact, error) {
+	args := m.Called(ctx, siteID, auditRunID, maxRows)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*contracts.SharingLinkFact), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetSharingLinkFactsCreatedBy(ctx context.Context, siteID int64, auditRunID int64, principalID int64) ([]*contracts.SharingLinkFact, error) {
+	args := m.Called(ctx, siteID, auditRunID, principalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*contracts.SharingLinkFact), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetItemsOwnedBy(ctx context.Context, siteID int64, auditRunID int64, email string) ([]*contracts.OwnedItem, error) {
+	args := m.Called(ctx, siteID, auditRunID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*contracts.OwnedItem), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetItemLabels(ctx context.Context, siteID int64, auditRunID int64) (map[string]string, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
 func (m *MockSiteContentAggregateRepository) GetListItems(ctx context.Context, siteID int64, listID string, offset, limit int) ([]*sharepoint.Item, error) {
 	args := m.Called(ctx, siteID, listID, offset, limit)
 	if args.Get(0) == nil {
@@ -422,6 +644,43 @@ func (m *MockSiteContentAggregateRepository) GetListItems(ctx context.Context, s
 	return args.Get(0).([]*sharepoint.Item), args.Error(1)
 }
 
+func (m *MockSiteContentAggregateRepository) GetAllListItems(ctx context.Context, siteID int64, listID string, offset, limit int) ([]*sharepoint.Item, error) {
+	args := m.Called(ctx, siteID, listID, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.Item), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetFilteredListItems(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter, offset, limit int) ([]*sharepoint.Item, error) {
+	args := m.Called(ctx, siteID, listID, filter, offset, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.Item), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) CountFilteredListItems(ctx context.Context, siteID int64, listID string, filter contracts.ItemFilter) (int64, error) {
+	args := m.Called(ctx, siteID, listID, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetItemByGUID(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.Item, error) {
+	args := m.Called(ctx, siteID, itemGUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharepoint.Item), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetItemAttachments(ctx context.Context, siteID int64, auditRunID int64, itemGUID string) ([]*sharepoint.ItemAttachment, error) {
+	args := m.Called(ctx, siteID, auditRunID, itemGUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.ItemAttachment), args.Error(1)
+}
+
 func (m *MockSiteContentAggregateRepository) GetListSharingLinks(ctx context.Context, siteID int64, listID string) ([]*sharepoint.SharingLink, error) {
 	args := m.Called(ctx, siteID, listID)
 	if args.Get(0) == nil {
@@ -446,6 +705,184 @@ func (m *MockSiteContentAggregateRepository) GetSharingLinkMembers(ctx context.C
 	return args.Get(0).([]*sharepoint.Principal), args.Error(1)
 }
 
+func (m *MockSiteContentAggregateRepository) GetPrincipalsForAuditRun(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.Principal, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.Principal), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetGroupIDsForMember(ctx context.Context, siteID int64, auditRunID int64, principalID int64) ([]int64, error) {
+	args := m.Called(ctx, siteID, auditRunID, principalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetEntraGroupIDsForUser(ctx context.Context, siteID int64, auditRunID int64, identity string) ([]int64, error) {
+	args := m.Called(ctx, siteID, auditRunID, identity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetSharingLinkIDsForMember(ctx context.Context, siteID int64, auditRunID int64, principalID int64) ([]string, error) {
+	args := m.Called(ctx, siteID, auditRunID, principalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetGroupMembersForAuditRun(ctx context.Context, siteID int64, auditRunID int64, groupID int64) ([]*sharepoint.Principal, error) {
+	args := m.Called(ctx, siteID, auditRunID, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.Principal), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetPreviousSharingLinkAuditRun(ctx context.Context, siteID int64, auditRunID int64, linkID string) (int64, error) {
+	args := m.Called(ctx, siteID, auditRunID, linkID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetSharingLinkMembersForAuditRun(ctx context.Context, siteID int64, auditRunID int64, linkID string) ([]*sharepoint.Principal, error) {
+	args := m.Called(ctx, siteID, auditRunID, linkID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.Principal), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetSharingLinkForAuditRun(ctx context.Context, siteID int64, auditRunID int64, linkID string) (*sharepoint.SharingLinkWithItemData, error) {
+	args := m.Called(ctx, siteID, auditRunID, linkID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharepoint.SharingLinkWithItemData), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetAnonymousSharingLinksForAuditRun(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.AnonymousSharingLink, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.AnonymousSharingLink), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetLinkCreatorsForAuditRun(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.LinkCreator, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.LinkCreator), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetSharingGovernanceForAuditRun(ctx context.Context, siteID int64, auditRunID int64) (*sharepoint.SharingGovernance, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharepoint.SharingGovernance), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetPreviousSharingGovernanceAuditRun(ctx context.Context, siteID int64, auditRunID int64) (int64, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetListSensitivityLabels(ctx context.Context, siteID int64, listID string) ([]*sharepoint.ItemSensitivityLabel, error) {
+	args := m.Called(ctx, siteID, listID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.ItemSensitivityLabel), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetItemSensitivityLabel(ctx context.Context, siteID int64, itemGUID string) (*sharepoint.ItemSensitivityLabel, error) {
+	args := m.Called(ctx, siteID, itemGUID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharepoint.ItemSensitivityLabel), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetRecycleBinScan(ctx context.Context, siteID int64, auditRunID int64) (*time.Time, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetRecycleBinItems(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.RecycleBinItem, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.RecycleBinItem), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetDeletedItemLinks(ctx context.Context, siteID int64, auditRunID int64) ([]*contracts.DeletedItemLink, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*contracts.DeletedItemLink), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetAppInventory(ctx context.Context, siteID int64, auditRunID int64) (*time.Time, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetInstalledApps(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.InstalledApp, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.InstalledApp), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetAppPermissionFacts(ctx context.Context, siteID int64, auditRunID int64) ([]*contracts.PermissionFact, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*contracts.PermissionFact), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetGraphGrantScan(ctx context.Context, siteID int64, auditRunID int64) (*time.Time, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetGraphAppGrants(ctx context.Context, siteID int64, auditRunID int64) ([]*sharepoint.GraphAppGrant, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.GraphAppGrant), args.Error(1)
+}
+
+func (m *MockSiteContentAggregateRepository) GetHubAssociation(ctx context.Context, siteID int64, auditRunID int64) (*sharepoint.HubAssociation, error) {
+	args := m.Called(ctx, siteID, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*sharepoint.HubAssociation), args.Error(1)
+}
+
 func (m *MockSiteContentAggregateRepository) GetLastAuditDate(ctx context.Context, siteID int64) (*time.Time, error) {
 	args := m.Called(ctx, siteID)
 	if args.Get(0) == nil {
@@ -454,6 +891,27 @@ func (m *MockSiteContentAggregateRepository) GetLastAuditDate(ctx context.Contex
 	return args.Get(0).(*time.Time), args.Error(1)
 }
 
+// MockPermissionAggregateRepository implements PermissionAggregateRepository for testing
+type MockPermissionAggregateRepository struct {
+	mock.Mock
+}
+
+func (m *MockPermissionAggregateRepository) GetPermissionAnalysisComponents(ctx context.Context, siteID int64, auditRunID int64, list *sharepoint.List) (*contracts.PermissionAnalysisComponents, error) {
+	args := m.Called(ctx, siteID, auditRunID, list)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contracts.PermissionAnalysisComponents), args.Error(1)
+}
+
+func (m *MockPermissionAggregateRepository) GetExceptedPermissionComponents(ctx context.Context, siteID int64, auditRunID int64, list *sharepoint.List, exceptions *audit.PermissionExceptionSet) (*contracts.PermissionAnalysisComponents, error) {
+	args := m.Called(ctx, siteID, auditRunID, list, exceptions)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contracts.PermissionAnalysisComponents), args.Error(1)
+}
+
 // MockAuditRepository implements AuditRepository for testing
 type MockAuditRepository struct {
 	mock.Mock
@@ -482,6 +940,21 @@ func (m *MockAuditRepository) SaveList(ctx context.Context, auditRunID int64, li
 	return args.Error(0)
 }
 
+func (m *MockAuditRepository) UpdateListUniqueDensity(ctx context.Context, auditRunID, siteID int64) error {
+	args := m.Called(ctx, auditRunID, siteID)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) UpdateListAccessCounts(ctx context.Context, auditRunID, siteID int64) error {
+	args := m.Called(ctx, auditRunID, siteID)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) UpdateListSampling(ctx context.Context, auditRunID, siteID int64, listID string, sampledItemCount int) error {
+	args := m.Called(ctx, auditRunID, siteID, listID, sampledItemCount)
+	return args.Error(0)
+}
+
 func (m *MockAuditRepository) SaveItem(ctx context.Context, auditRunID int64, item *sharepoint.Item) error {
 	args := m.Called(ctx, auditRunID, item)
 	return args.Error(0)
@@ -502,8 +975,26 @@ func (m *MockAuditRepository) SaveRoleAssignments(ctx context.Context, auditRunI
 	return args.Error(0)
 }
 
-func (m *MockAuditRepository) ClearRoleAssignments(ctx context.Context, siteID int64, objectType, objectKey string) error {
-	args := m.Called(ctx, siteID, objectType, objectKey)
+func (m *MockAuditRepository) SaveGroupMembers(ctx context.Context, auditRunID int64, siteID int64, groupID int64, members []*sharepoint.Principal) error {
+	args := m.Called(ctx, auditRunID, siteID, groupID, members)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) GetSecurityGroupPrincipals(ctx context.Context, auditRunID int64, siteID int64) ([]*sharepoint.Principal, error) {
+	args := m.Called(ctx, auditRunID, siteID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*sharepoint.Principal), args.Error(1)
+}
+
+func (m *MockAuditRepository) SaveEntraGroupMembers(ctx context.Context, auditRunID int64, siteID int64, principalID int64, group sharepoint.EntraGroupRef, resolvedAt time.Time, members []*sharepoint.EntraGroupMember) error {
+	args := m.Called(ctx, auditRunID, siteID, principalID, group, resolvedAt, members)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) ReplaceRoleAssignments(ctx context.Context, auditRunID int64, siteID int64, objectType, objectKey string, assignments []*sharepoint.RoleAssignment) error {
+	args := m.Called(ctx, auditRunID, siteID, objectType, objectKey, assignments)
 	return args.Error(0)
 }
 
@@ -582,6 +1073,49 @@ func (m *MockAuditRepository) SaveSensitivityLabel(ctx context.Context, auditRun
 	return args.Error(0)
 }
 
+func (m *MockAuditRepository) SaveItemAnalytics(ctx context.Context, analytics *sharepoint.ItemAnalytics) error {
+	args := m.Called(ctx, analytics)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) SaveRecycleBinScan(ctx context.Context, auditRunID, siteID int64, scannedAt time.Time, items []*sharepoint.RecycleBinItem) error {
+	args := m.Called(ctx, auditRunID, siteID, scannedAt, items)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) SaveAppInventory(ctx context.Context, auditRunID, siteID int64, collectedAt time.Time, apps []*sharepoint.InstalledApp) error {
+	args := m.Called(ctx, auditRunID, siteID, collectedAt, apps)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) SaveGraphGrantScan(ctx context.Context, auditRunID, siteID int64, scannedAt time.Time, grants []*sharepoint.GraphAppGrant) error {
+	args := m.Called(ctx, auditRunID, siteID, scannedAt, grants)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) SaveHubAssociation(ctx context.Context, auditRunID, siteID int64, collectedAt time.Time, hub *sharepoint.HubAssociation) error {
+	args := m.Called(ctx, auditRunID, siteID, collectedAt, hub)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) SaveSchemaDrift(ctx context.Context, auditRunID int64, drift []audit.SchemaDrift) error {
+	args := m.Called(ctx, auditRunID, drift)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) SaveListCheckpoint(ctx context.Context, auditRunID int64, checkpoint *audit.ListCheckpoint) error {
+	args := m.Called(ctx, auditRunID, checkpoint)
+	return args.Error(0)
+}
+
+func (m *MockAuditRepository) GetListCheckpoints(ctx context.Context, auditRunID int64) ([]*audit.ListCheckpoint, error) {
+	args := m.Called(ctx, auditRunID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*audit.ListCheckpoint), args.Error(1)
+}
+
 // Audit-aware query operations
 func (m *MockAuditRepository) GetSitesByAuditRun(ctx context.Context, auditRunID int64) ([]*sharepoint.Site, error) {
 	args := m.Called(ctx, auditRunID)
@@ -591,24 +1125,24 @@ func (m *MockAuditRepository) GetSitesByAuditRun(ctx context.Context, auditRunID
 	return args.Get(0).([]*sharepoint.Site), args.Error(1)
 }
 
-func (m *MockAuditRepository) GetWebsByAuditRun(ctx context.Context, auditRunID int64) ([]*sharepoint.Web, error) {
-	args := m.Called(ctx, auditRunID)
+func (m *MockAuditRepository) GetWebsByAuditRun(ctx context.Context, auditRunID int64, offset, limit int64) ([]*sharepoint.Web, error) {
+	args := m.Called(ctx, auditRunID, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*sharepoint.Web), args.Error(1)
 }
 
-func (m *MockAuditRepository) GetListsByAuditRun(ctx context.Context, auditRunID int64) ([]*sharepoint.List, error) {
-	args := m.Called(ctx, auditRunID)
+func (m *MockAuditRepository) GetListsByAuditRun(ctx context.Context, auditRunID int64, offset, limit int64) ([]*sharepoint.List, error) {
+	args := m.Called(ctx, auditRunID, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]*sharepoint.List), args.Error(1)
 }
 
-func (m *MockAuditRepository) GetItemsByAuditRun(ctx context.Context, auditRunID int64) ([]*sharepoint.Item, error) {
-	args := m.Called(ctx, auditRunID)
+func (m *MockAuditRepository) GetItemsByAuditRun(ctx context.Context, auditRunID int64, offset, limit int64) ([]*sharepoint.Item, error) {
+	args := m.Called(ctx, auditRunID, offset, limit)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}